@@ -0,0 +1,180 @@
+package adapters
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/net/http2"
+	"google.golang.org/protobuf/encoding/protowire"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AuthenticatorSidecarMethod 는 사이드카가 제공해야 하는 gRPC 메소드이다.
+//
+//	service Authenticator {
+//	  rpc Authenticate(AuthenticateRequest) returns (AuthenticateResponse);
+//	}
+//	message AuthenticateRequest {
+//	  string authenticator = 1;
+//	  map<string, string> credentials = 2;
+//	  string client_ip = 3;
+//	}
+//	message AuthenticateResponse {
+//	  bool authenticated = 1;
+//	  string external_id = 2;
+//	  string name = 3;
+//	  string email = 4;
+//	  string reason = 5;
+//	  repeated string groups = 6;
+//	}
+const AuthenticatorSidecarMethod = "/betteradmin.auth.v1.Authenticator/Authenticate"
+
+// AuthenticatorSidecar 는 외부 gRPC 라이브러리 없이 HTTP/2(h2c) 로 사이드카의 Authenticate 를 호출하는 최소한의 gRPC 클라이언트이다.
+// 단항(unary) 호출만 지원하며 메시지를 압축하지 않는다.
+type AuthenticatorSidecar struct {
+	Address string
+	Timeout time.Duration
+}
+
+func (a AuthenticatorSidecar) Authenticate(ctx context.Context, request dtos.CustomAuthRequest) (dtos.CustomAuthIdentity, error) {
+	message := encodeAuthenticateRequest(request)
+	frame := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	frame = append(frame, message...)
+
+	transport := &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, address string, _ *tls.Config) (net.Conn, error) {
+			return net.DialTimeout(network, address, a.Timeout)
+		},
+	}
+	defer transport.CloseIdleConnections()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+a.Address+AuthenticatorSidecarMethod, bytes.NewReader(frame))
+	if err != nil {
+		return dtos.CustomAuthIdentity{}, pkgerrors.Wrap(err, "authenticator sidecar request error")
+	}
+	httpRequest.Header.Set("Content-Type", "application/grpc+proto")
+	httpRequest.Header.Set("Te", "trailers")
+	httpRequest.Header.Set("Grpc-Timeout", fmt.Sprintf("%dm", a.Timeout.Milliseconds()))
+
+	client := http.Client{Transport: transport, Timeout: a.Timeout}
+	response, err := client.Do(httpRequest)
+	if err != nil {
+		return dtos.CustomAuthIdentity{}, pkgerrors.Wrap(err, "authenticator sidecar request error")
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return dtos.CustomAuthIdentity{}, pkgerrors.Wrap(err, "authenticator sidecar response error")
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return dtos.CustomAuthIdentity{}, pkgerrors.Errorf("authenticator sidecar response status %d", response.StatusCode)
+	}
+
+	// 응답 메시지가 없는 오류(Trailers-Only)는 grpc-status 를 헤더로 보낸다.
+	status := response.Trailer.Get("Grpc-Status")
+	grpcMessage := response.Trailer.Get("Grpc-Message")
+	if len(status) == 0 {
+		status = response.Header.Get("Grpc-Status")
+		grpcMessage = response.Header.Get("Grpc-Message")
+	}
+	if status != "0" {
+		grpcMessage, _ = url.PathUnescape(grpcMessage)
+		return dtos.CustomAuthIdentity{}, pkgerrors.Errorf("authenticator sidecar grpc status %s: %s", status, grpcMessage)
+	}
+
+	if len(body) < 5 || body[0] != 0 || int(binary.BigEndian.Uint32(body[1:5])) != len(body)-5 {
+		return dtos.CustomAuthIdentity{}, pkgerrors.New("authenticator sidecar invalid response message")
+	}
+
+	return decodeAuthenticateResponse(body[5:])
+}
+
+func encodeAuthenticateRequest(request dtos.CustomAuthRequest) []byte {
+	var message []byte
+	message = protowire.AppendTag(message, 1, protowire.BytesType)
+	message = protowire.AppendString(message, request.Authenticator)
+
+	// map 필드는 key(1), value(2) 를 가진 메시지의 반복이다.
+	for key, value := range request.Credentials {
+		var entry []byte
+		entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+		entry = protowire.AppendString(entry, key)
+		entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+		entry = protowire.AppendString(entry, value)
+
+		message = protowire.AppendTag(message, 2, protowire.BytesType)
+		message = protowire.AppendBytes(message, entry)
+	}
+
+	if len(request.ClientIp) > 0 {
+		message = protowire.AppendTag(message, 3, protowire.BytesType)
+		message = protowire.AppendString(message, request.ClientIp)
+	}
+
+	return message
+}
+
+func decodeAuthenticateResponse(message []byte) (dtos.CustomAuthIdentity, error) {
+	var authenticated bool
+	var identity dtos.CustomAuthIdentity
+	var reason string
+
+	for len(message) > 0 {
+		number, wireType, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return dtos.CustomAuthIdentity{}, pkgerrors.Wrap(protowire.ParseError(n), "authenticator sidecar response decode error")
+		}
+		message = message[n:]
+
+		switch {
+		case number == 1 && wireType == protowire.VarintType:
+			var value uint64
+			value, n = protowire.ConsumeVarint(message)
+			authenticated = value != 0
+		case number >= 2 && number <= 6 && wireType == protowire.BytesType:
+			var value string
+			value, n = protowire.ConsumeString(message)
+			switch number {
+			case 2:
+				identity.ExternalId = value
+			case 3:
+				identity.Name = value
+			case 4:
+				identity.Email = value
+			case 5:
+				reason = value
+			case 6:
+				identity.Groups = append(identity.Groups, value)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(number, wireType, message)
+		}
+
+		if n < 0 {
+			return dtos.CustomAuthIdentity{}, pkgerrors.Wrap(protowire.ParseError(n), "authenticator sidecar response decode error")
+		}
+		message = message[n:]
+	}
+
+	if !authenticated {
+		helpers.LoggingHelper().Debugf(constants.LoggingModuleAuth, "authenticator sidecar rejected. reason=%s", reason)
+		return dtos.CustomAuthIdentity{}, errors.ErrAuthentication
+	}
+
+	return identity, nil
+}
@@ -2,24 +2,41 @@ package adapters
 
 import (
 	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
 	"fmt"
-	"github.com/bettercode-oss/rest"
 	"github.com/go-ldap/ldap/v3"
 	pkgerrors "github.com/pkg/errors"
+	"net"
+	"time"
 )
 
 type DoorayAdapter struct {
 }
 
-func (DoorayAdapter) Authenticate(doorayDomain, token, signId, password string) (dtos.DoorayMember, error) {
-	ldapConn, err := ldap.DialURL(config.Config.Dooray.LdapDialUrl)
+// Authenticate 는 LDAP 으로 인증하고 두레이 멤버 정보를 가져온다. ctx 에 제한 시간이 있으면 LDAP 요청에도 적용한다.
+func (DoorayAdapter) Authenticate(ctx context.Context, doorayDomain, token, signId, password string) (dtos.DoorayMember, error) {
+	if helpers.ContextHelper().HasChaosFault(ctx, constants.ChaosFaultIdp) {
+		return dtos.DoorayMember{}, pkgerrors.Wrap(errors.ErrChaosInjected, "ldap conn error")
+	}
+
+	dialer := &net.Dialer{Timeout: ldap.DefaultTimeout}
+	if deadline, ok := ctx.Deadline(); ok {
+		dialer.Deadline = deadline
+	}
+
+	ldapConn, err := ldap.DialURL(config.Config.Dooray.LdapDialUrl, ldap.DialWithDialer(dialer))
 	if err != nil {
 		return dtos.DoorayMember{}, pkgerrors.Wrap(err, "ldap conn error")
 	}
 
 	defer ldapConn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		ldapConn.SetTimeout(time.Until(deadline))
+	}
 
 	if err := ldapConn.Bind(fmt.Sprint(fmt.Sprintf("%s\\", doorayDomain), signId), password); err != nil {
 		if ldap.IsErrorWithCode(err, ldap.ErrorNetwork) || err.Error() == "ldap: connection timed out" {
@@ -31,12 +48,10 @@ func (DoorayAdapter) Authenticate(doorayDomain, token, signId, password string)
 
 	result := map[string]interface{}{}
 
-	client := rest.Client{}
-	err = client.
-		Request().
-		SetHeader("Authorization", fmt.Sprintf("dooray-api %s", token)).
-		SetResult(&result).
-		Get(fmt.Sprintf("https://api.dooray.com/common/v1/members?userCode=%s", signId))
+	err = HttpClientAdapter().Client(constants.HttpClientDooray).GetJson(ctx,
+		fmt.Sprintf("https://api.dooray.com/common/v1/members?userCode=%s", signId),
+		map[string]string{"Authorization": fmt.Sprintf("dooray-api %s", token)},
+		&result)
 
 	if err != nil {
 		return dtos.DoorayMember{}, pkgerrors.Wrap(err, "find dooray member error")
@@ -50,11 +65,14 @@ func (DoorayAdapter) Authenticate(doorayDomain, token, signId, password string)
 		//// 그리고 해당 이미지를 DB Blob 로 저장 한다.
 
 		user := result["result"].([]interface{})[0].(map[string]interface{})
+		// 부서는 조직 설정에 따라 응답에 없을 수 있다.
+		department, _ := user["department"].(string)
 		return dtos.DoorayMember{
 			Id:                   user["id"].(string),
 			UserCode:             signId,
 			Name:                 user["name"].(string),
 			ExternalEmailAddress: user["externalEmailAddress"].(string),
+			Department:           department,
 		}, nil
 	}
 
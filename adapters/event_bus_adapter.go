@@ -0,0 +1,53 @@
+package adapters
+
+import (
+	"better-admin-backend-service/dtos"
+	log "github.com/sirupsen/logrus"
+	"sync"
+)
+
+var (
+	eventBusAdapterOnce     sync.Once
+	eventBusAdapterInstance *eventBusAdapter
+)
+
+// EventHandler 는 이벤트 버스의 이벤트를 받는다. Publish 한 곳에서 바로 호출되므로 오래 걸리는 처리는 큐에 넣고 따로 처리한다.
+type EventHandler func(event dtos.Event)
+
+// EventBusAdapter 는 서비스 안에서 발생한 인증, 감사 이벤트를 구독자에게 전달한다.
+func EventBusAdapter() *eventBusAdapter {
+	eventBusAdapterOnce.Do(func() {
+		eventBusAdapterInstance = &eventBusAdapter{}
+	})
+
+	return eventBusAdapterInstance
+}
+
+type eventBusAdapter struct {
+	mutex    sync.RWMutex
+	handlers []EventHandler
+}
+
+func (e *eventBusAdapter) Subscribe(handler EventHandler) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.handlers = append(e.handlers, handler)
+}
+
+// Publish 는 구독자에게 이벤트를 전달한다. 구독자의 오류가 이벤트를 발생시킨 요청에 영향을 주지 않도록 panic 은 로그만 남긴다.
+func (e *eventBusAdapter) Publish(event dtos.Event) {
+	e.mutex.RLock()
+	handlers := e.handlers
+	e.mutex.RUnlock()
+
+	for _, handler := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Errorf("event handler panic. eventId=%s, %v", event.Id, r)
+				}
+			}()
+			handler(event)
+		}()
+	}
+}
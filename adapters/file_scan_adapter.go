@@ -0,0 +1,200 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// clamd INSTREAM 으로 한 번에 보내는 크기
+const clamAvChunkSize = 64 * 1024
+
+var (
+	fileScanAdapterOnce     sync.Once
+	fileScanAdapterInstance *fileScanAdapter
+)
+
+// FileScanner 는 업로드 파일의 바이러스를 검사한다. 설정(FileScan.Scanner)에 없는 검사기는 구현하여 SetScanner 로 등록한다.
+type FileScanner interface {
+	Scan(name string, content []byte) (dtos.FileScanResult, error)
+}
+
+func FileScanAdapter() *fileScanAdapter {
+	fileScanAdapterOnce.Do(func() {
+		fileScanAdapterInstance = &fileScanAdapter{}
+	})
+
+	return fileScanAdapterInstance
+}
+
+type fileScanAdapter struct {
+	mutex   sync.RWMutex
+	scanner FileScanner
+}
+
+// SetScanner 는 검사기를 교체한다. nil 이면 설정(FileScan.Scanner)의 검사기를 사용한다.
+func (f *fileScanAdapter) SetScanner(scanner FileScanner) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.scanner = scanner
+}
+
+// IsEnabled 는 파일을 검사할 검사기가 있는지 확인한다.
+func (f *fileScanAdapter) IsEnabled() bool {
+	return f.getScanner() != nil
+}
+
+// Scan 은 검사기로 파일을 검사한다. 검사기가 없으면 검사하지 않고 통과시킨다.
+func (f *fileScanAdapter) Scan(name string, content []byte) (dtos.FileScanResult, error) {
+	scanner := f.getScanner()
+	if scanner == nil {
+		return dtos.FileScanResult{}, nil
+	}
+
+	return scanner.Scan(name, content)
+}
+
+func (f *fileScanAdapter) getScanner() FileScanner {
+	f.mutex.RLock()
+	scanner := f.scanner
+	f.mutex.RUnlock()
+
+	if scanner != nil {
+		return scanner
+	}
+
+	scanConfig := config.Config.FileScan
+	timeout := time.Duration(scanConfig.TimeoutSeconds) * time.Second
+	switch scanConfig.Scanner {
+	case constants.FileScannerClamAv:
+		return ClamAvFileScanner{Address: scanConfig.ClamAvAddress, Timeout: timeout}
+	case constants.FileScannerHttp:
+		return HttpFileScanner{Url: scanConfig.HttpUrl, ApiKey: scanConfig.HttpApiKey, Timeout: timeout}
+	}
+
+	return nil
+}
+
+// ClamAvFileScanner 는 clamd 에 TCP 로 연결하여 INSTREAM 명령으로 검사한다.
+type ClamAvFileScanner struct {
+	Address string
+	Timeout time.Duration
+}
+
+func (c ClamAvFileScanner) Scan(name string, content []byte) (dtos.FileScanResult, error) {
+	conn, err := net.DialTimeout("tcp", c.Address, c.Timeout)
+	if err != nil {
+		return dtos.FileScanResult{}, pkgerrors.Wrap(err, "clamav connect error")
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(c.Timeout))
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return dtos.FileScanResult{}, pkgerrors.Wrap(err, "clamav write error")
+	}
+
+	for offset := 0; offset < len(content); offset += clamAvChunkSize {
+		end := offset + clamAvChunkSize
+		if end > len(content) {
+			end = len(content)
+		}
+
+		if err := c.writeChunk(conn, content[offset:end]); err != nil {
+			return dtos.FileScanResult{}, err
+		}
+	}
+
+	// 길이가 0 인 chunk 로 스트림의 끝을 알린다.
+	if err := c.writeChunk(conn, nil); err != nil {
+		return dtos.FileScanResult{}, err
+	}
+
+	reply, err := io.ReadAll(conn)
+	if err != nil {
+		return dtos.FileScanResult{}, pkgerrors.Wrap(err, "clamav read error")
+	}
+
+	return parseClamAvReply(string(reply))
+}
+
+func (ClamAvFileScanner) writeChunk(conn net.Conn, chunk []byte) error {
+	size := make([]byte, 4)
+	binary.BigEndian.PutUint32(size, uint32(len(chunk)))
+
+	if _, err := conn.Write(append(size, chunk...)); err != nil {
+		return pkgerrors.Wrap(err, "clamav write error")
+	}
+
+	return nil
+}
+
+// parseClamAvReply 는 "stream: OK" 또는 "stream: <signature> FOUND" 응답을 해석한다.
+func parseClamAvReply(reply string) (dtos.FileScanResult, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	reply = strings.TrimPrefix(reply, "stream:")
+	reply = strings.TrimSpace(reply)
+
+	if reply == "OK" {
+		return dtos.FileScanResult{}, nil
+	}
+
+	if strings.HasSuffix(reply, " FOUND") {
+		return dtos.FileScanResult{Infected: true, Signature: strings.TrimSuffix(reply, " FOUND")}, nil
+	}
+
+	return dtos.FileScanResult{}, pkgerrors.Errorf("clamav scan error. %s", reply)
+}
+
+// HttpFileScanner 는 외부 검사 API 에 파일 내용을 POST 하여 검사한다.
+type HttpFileScanner struct {
+	Url     string
+	ApiKey  string
+	Timeout time.Duration
+}
+
+func (h HttpFileScanner) Scan(name string, content []byte) (dtos.FileScanResult, error) {
+	ctx, cancel := newTimeoutContext(context.Background(), h.Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Url, bytes.NewReader(content))
+	if err != nil {
+		return dtos.FileScanResult{}, pkgerrors.Wrap(err, "file scan request error")
+	}
+	request.Header.Set("Content-Type", "application/octet-stream")
+	request.Header.Set("X-File-Name", url.QueryEscape(name))
+	if len(h.ApiKey) > 0 {
+		request.Header.Set("X-Api-Key", h.ApiKey)
+	}
+
+	response, err := HttpClientAdapter().Client(constants.HttpClientFileScan).Do(request)
+	if err != nil {
+		return dtos.FileScanResult{}, pkgerrors.Wrap(err, "file scan request error")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return dtos.FileScanResult{}, pkgerrors.Errorf("file scan response status %d", response.StatusCode)
+	}
+
+	var result struct {
+		Infected  bool   `json:"infected"`
+		Signature string `json:"signature"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return dtos.FileScanResult{}, pkgerrors.Wrap(err, "file scan response error")
+	}
+
+	return dtos.FileScanResult{Infected: result.Infected, Signature: result.Signature}, nil
+}
@@ -0,0 +1,336 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	FileStorageBackendLocal = "local"
+	FileStorageBackendS3    = "s3"
+	FileStorageBackendGcs   = "gcs"
+)
+
+var (
+	fileStorageAdapterOnce     sync.Once
+	fileStorageAdapterInstance *fileStorageAdapter
+
+	ErrFileNotFound = pkgerrors.New("file not found in storage")
+)
+
+// FileStorage 는 파일 내용을 저장하는 저장소이다. 파일 정보(metadata)는 DB 에 저장하고 내용만 저장소에 둔다.
+type FileStorage interface {
+	Put(key string, content []byte, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+func FileStorageAdapter() *fileStorageAdapter {
+	fileStorageAdapterOnce.Do(func() {
+		fileStorageAdapterInstance = &fileStorageAdapter{}
+	})
+
+	return fileStorageAdapterInstance
+}
+
+type fileStorageAdapter struct {
+	mutex   sync.RWMutex
+	storage FileStorage
+}
+
+// SetStorage 는 저장소를 교체한다. nil 이면 설정(FileStorage.Backend)의 저장소를 사용한다.
+func (f *fileStorageAdapter) SetStorage(storage FileStorage) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.storage = storage
+}
+
+// GetBackend 는 파일 정보에 기록할 저장소 종류이다.
+func (f *fileStorageAdapter) GetBackend() string {
+	if len(config.Config.FileStorage.Backend) == 0 {
+		return FileStorageBackendLocal
+	}
+	return config.Config.FileStorage.Backend
+}
+
+func (f *fileStorageAdapter) Put(key string, content []byte, contentType string) error {
+	storage, err := f.getStorage()
+	if err != nil {
+		return err
+	}
+	return storage.Put(key, content, contentType)
+}
+
+func (f *fileStorageAdapter) Get(key string) (io.ReadCloser, error) {
+	storage, err := f.getStorage()
+	if err != nil {
+		return nil, err
+	}
+	return storage.Get(key)
+}
+
+func (f *fileStorageAdapter) Delete(key string) error {
+	storage, err := f.getStorage()
+	if err != nil {
+		return err
+	}
+	return storage.Delete(key)
+}
+
+func (f *fileStorageAdapter) getStorage() (FileStorage, error) {
+	f.mutex.RLock()
+	storage := f.storage
+	f.mutex.RUnlock()
+
+	if storage != nil {
+		return storage, nil
+	}
+
+	storageConfig := config.Config.FileStorage
+	switch f.GetBackend() {
+	case FileStorageBackendLocal:
+		return LocalFileStorage{Directory: storageConfig.LocalDirectory}, nil
+	case FileStorageBackendS3:
+		endpoint := storageConfig.S3.Endpoint
+		if len(endpoint) == 0 {
+			endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", storageConfig.S3.Region)
+		}
+		return S3FileStorage{
+			Endpoint:        endpoint,
+			Region:          storageConfig.S3.Region,
+			Bucket:          storageConfig.S3.Bucket,
+			AccessKeyId:     storageConfig.S3.AccessKeyId,
+			SecretAccessKey: storageConfig.S3.SecretAccessKey,
+		}, nil
+	case FileStorageBackendGcs:
+		return S3FileStorage{
+			Endpoint:        "https://storage.googleapis.com",
+			Region:          "auto",
+			Bucket:          storageConfig.Gcs.Bucket,
+			AccessKeyId:     storageConfig.Gcs.AccessKeyId,
+			SecretAccessKey: storageConfig.Gcs.SecretAccessKey,
+		}, nil
+	}
+
+	return nil, pkgerrors.Errorf("not supported file storage backend %q", storageConfig.Backend)
+}
+
+// LocalFileStorage 는 파일을 디스크의 Directory 아래에 저장한다.
+type LocalFileStorage struct {
+	Directory string
+}
+
+func (l LocalFileStorage) Put(key string, content []byte, contentType string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return pkgerrors.Wrap(err, "file storage write error")
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return pkgerrors.Wrap(err, "file storage write error")
+	}
+
+	return nil
+}
+
+func (l LocalFileStorage) Get(key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrFileNotFound
+		}
+		return nil, pkgerrors.Wrap(err, "file storage read error")
+	}
+
+	return file, nil
+}
+
+func (l LocalFileStorage) Delete(key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return pkgerrors.Wrap(err, "file storage delete error")
+	}
+
+	return nil
+}
+
+// path 는 key 가 Directory 밖을 가리키지 않는지 확인한다.
+func (l LocalFileStorage) path(key string) (string, error) {
+	cleanKey := filepath.Clean("/" + key)
+	if cleanKey == "/" {
+		return "", pkgerrors.Errorf("invalid file storage key %q", key)
+	}
+
+	return filepath.Join(l.Directory, cleanKey), nil
+}
+
+// S3FileStorage 는 S3 호환 API(AWS Signature Version 4, path-style)로 파일을 저장한다.
+type S3FileStorage struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyId     string
+	SecretAccessKey string
+}
+
+func (s S3FileStorage) Put(key string, content []byte, contentType string) error {
+	response, err := s.do(http.MethodPut, key, content, map[string]string{"Content-Type": contentType})
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return s.checkResponse(response)
+}
+
+func (s S3FileStorage) Get(key string) (io.ReadCloser, error) {
+	response, err := s.do(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, ErrFileNotFound
+	}
+
+	if err := s.checkResponse(response); err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (s S3FileStorage) Delete(key string) error {
+	response, err := s.do(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	return s.checkResponse(response)
+}
+
+func (S3FileStorage) checkResponse(response *http.Response) error {
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+	return pkgerrors.Errorf("file storage response status %d. %s", response.StatusCode, string(body))
+}
+
+func (s S3FileStorage) do(method, key string, content []byte, headers map[string]string) (*http.Response, error) {
+	endpoint, err := url.Parse(s.Endpoint)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "invalid file storage endpoint")
+	}
+
+	objectUrl := *endpoint
+	objectUrl.Path = "/" + s.Bucket + "/" + strings.TrimPrefix(key, "/")
+
+	request, err := http.NewRequest(method, objectUrl.String(), bytes.NewReader(content))
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "file storage request error")
+	}
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	s.sign(request, content, time.Now().UTC())
+
+	response, err := HttpClientAdapter().Client(constants.HttpClientFileStorage).Do(request)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "file storage request error")
+	}
+
+	return response, nil
+}
+
+// sign 은 AWS Signature Version 4 로 요청에 서명한다.
+func (s S3FileStorage) sign(request *http.Request, content []byte, now time.Time) {
+	payloadHash := sha256Hex(content)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	request.Header.Set("Host", request.URL.Host)
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headerNames := make([]string, 0)
+	for name := range request.Header {
+		headerNames = append(headerNames, strings.ToLower(name))
+	}
+	sort.Strings(headerNames)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(request.Header.Get(name)) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		request.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	signingKey := hmacSha256([]byte("AWS4"+s.SecretAccessKey), date)
+	signingKey = hmacSha256(signingKey, s.Region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKeyId, scope, signedHeaders, signature))
+}
+
+func sha256Hex(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,255 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	geoIpAdapterOnce     sync.Once
+	geoIpAdapterInstance *geoIpAdapter
+)
+
+// GeoIpResolver 는 IP 의 위치 정보를 찾는다. MaxMind 등 다른 데이터베이스를 사용하려면 구현하여 SetResolver 로 교체한다.
+type GeoIpResolver interface {
+	Resolve(ip net.IP) (dtos.GeoLocation, bool)
+}
+
+func GeoIpAdapter() *geoIpAdapter {
+	geoIpAdapterOnce.Do(func() {
+		geoIpAdapterInstance = &geoIpAdapter{}
+	})
+
+	return geoIpAdapterInstance
+}
+
+type geoIpAdapter struct {
+	mutex    sync.RWMutex
+	resolver GeoIpResolver
+	loaded   bool
+}
+
+func (g *geoIpAdapter) SetResolver(resolver GeoIpResolver) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.resolver = resolver
+	g.loaded = resolver != nil
+}
+
+// Lookup 은 IP 의 위치 정보를 찾는다. 데이터베이스가 없거나 사설 IP 등 찾을 수 없으면 빈 값을 반환한다.
+func (g *geoIpAdapter) Lookup(ipAddress string) dtos.GeoLocation {
+	ip := net.ParseIP(ipAddress)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() {
+		return dtos.GeoLocation{}
+	}
+
+	resolver := g.getResolver()
+	if resolver == nil {
+		return dtos.GeoLocation{}
+	}
+
+	location, _ := resolver.Resolve(ip)
+	return location
+}
+
+func (g *geoIpAdapter) getResolver() GeoIpResolver {
+	g.mutex.RLock()
+	resolver, loaded := g.resolver, g.loaded
+	g.mutex.RUnlock()
+
+	if loaded {
+		return resolver
+	}
+
+	// 처음 조회할 때 설정된 데이터베이스 파일을 읽는다. 파일이 없으면 다시 읽지 않는다.
+	if err := g.ReloadDatabase(); err != nil {
+		log.Errorf("geo ip database load error. %v", err)
+	}
+
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+	return g.resolver
+}
+
+// ReloadDatabase 는 설정(GeoIp.DatabasePath)의 데이터베이스 파일을 다시 읽는다.
+func (g *geoIpAdapter) ReloadDatabase() error {
+	path := config.Config.GeoIp.DatabasePath
+
+	var resolver GeoIpResolver
+	var loadErr error
+	if len(path) > 0 {
+		database, err := LoadCsvGeoIpDatabase(path)
+		if err != nil {
+			loadErr = err
+		} else {
+			resolver = database
+		}
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if loadErr == nil || g.resolver == nil {
+		g.resolver = resolver
+	}
+	g.loaded = true
+
+	return loadErr
+}
+
+// UpdateDatabase 는 설정(GeoIp.DatabaseUrl)에서 데이터베이스를 내려받아 파일을 교체하고 다시 읽는다.
+// 내려받은 파일을 읽을 수 없으면 기존 파일을 유지한다.
+func (g *geoIpAdapter) UpdateDatabase() error {
+	geoIpConfig := config.Config.GeoIp
+	if len(geoIpConfig.DatabaseUrl) == 0 || len(geoIpConfig.DatabasePath) == 0 {
+		return nil
+	}
+
+	request, err := http.NewRequest(http.MethodGet, geoIpConfig.DatabaseUrl, nil)
+	if err != nil {
+		return pkgerrors.Wrap(err, "geo ip database download error")
+	}
+
+	response, err := HttpClientAdapter().Client(constants.HttpClientGeoIp).Do(request)
+	if err != nil {
+		return pkgerrors.Wrap(err, "geo ip database download error")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return pkgerrors.Errorf("geo ip database download status %d", response.StatusCode)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return pkgerrors.Wrap(err, "geo ip database download error")
+	}
+
+	if _, err := parseCsvGeoIpDatabase(bytes.NewReader(content)); err != nil {
+		return err
+	}
+
+	temporaryPath := filepath.Join(filepath.Dir(geoIpConfig.DatabasePath), fmt.Sprintf(".%s.download", filepath.Base(geoIpConfig.DatabasePath)))
+	if err := os.WriteFile(temporaryPath, content, 0644); err != nil {
+		return pkgerrors.Wrap(err, "geo ip database write error")
+	}
+
+	if err := os.Rename(temporaryPath, geoIpConfig.DatabasePath); err != nil {
+		return pkgerrors.Wrap(err, "geo ip database write error")
+	}
+
+	return g.ReloadDatabase()
+}
+
+type geoIpRange struct {
+	start    net.IP
+	end      net.IP
+	location dtos.GeoLocation
+}
+
+// CsvGeoIpDatabase 는 "network,country,city,asn,asnOrganization" 형식(network 는 CIDR)의 CSV 데이터베이스이다.
+// 네트워크 범위는 서로 겹치지 않아야 한다.
+type CsvGeoIpDatabase struct {
+	ranges []geoIpRange
+}
+
+func LoadCsvGeoIpDatabase(path string) (*CsvGeoIpDatabase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "geo ip database open error")
+	}
+	defer file.Close()
+
+	return parseCsvGeoIpDatabase(file)
+}
+
+func parseCsvGeoIpDatabase(reader io.Reader) (*CsvGeoIpDatabase, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.Comment = '#'
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "geo ip database parse error")
+	}
+
+	database := &CsvGeoIpDatabase{}
+	for i, record := range records {
+		if len(record) == 0 || record[0] == "network" {
+			continue
+		}
+
+		_, network, err := net.ParseCIDR(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, pkgerrors.Errorf("geo ip database parse error. line=%d, %v", i+1, err)
+		}
+
+		location := dtos.GeoLocation{}
+		if len(record) > 1 {
+			location.Country = strings.TrimSpace(record[1])
+		}
+		if len(record) > 2 {
+			location.City = strings.TrimSpace(record[2])
+		}
+		if len(record) > 3 && len(strings.TrimSpace(record[3])) > 0 {
+			asn, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(record[3]), "AS"), 10, 32)
+			if err != nil {
+				return nil, pkgerrors.Errorf("geo ip database parse error. line=%d, invalid asn %q", i+1, record[3])
+			}
+			location.Asn = uint(asn)
+		}
+		if len(record) > 4 {
+			location.AsnOrganization = strings.TrimSpace(record[4])
+		}
+
+		start := network.IP.To16()
+		end := make(net.IP, len(start))
+		mask := net.IP(network.Mask)
+		if len(mask) == net.IPv4len {
+			mask = append(net.IP{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, mask...)
+		}
+		for b := range start {
+			end[b] = start[b] | ^mask[b]
+		}
+
+		database.ranges = append(database.ranges, geoIpRange{start: start, end: end, location: location})
+	}
+
+	sort.Slice(database.ranges, func(i, j int) bool {
+		return bytes.Compare(database.ranges[i].start, database.ranges[j].start) < 0
+	})
+
+	return database, nil
+}
+
+func (d *CsvGeoIpDatabase) Resolve(ip net.IP) (dtos.GeoLocation, bool) {
+	target := ip.To16()
+	if target == nil {
+		return dtos.GeoLocation{}, false
+	}
+
+	// 시작 IP 가 target 보다 큰 첫 번째 범위의 바로 앞 범위를 확인한다.
+	index := sort.Search(len(d.ranges), func(i int) bool {
+		return bytes.Compare(d.ranges[i].start, target) > 0
+	}) - 1
+
+	if index >= 0 && bytes.Compare(target, d.ranges[index].end) <= 0 {
+		return d.ranges[index].location, true
+	}
+
+	return dtos.GeoLocation{}, false
+}
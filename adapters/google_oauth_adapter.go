@@ -2,11 +2,13 @@ package adapters
 
 import (
 	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
+	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/bettercode-oss/rest"
 	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
 	"net/url"
@@ -17,27 +19,103 @@ import (
 type GoogleOAuthAdapter struct {
 }
 
-func (adapter GoogleOAuthAdapter) Authenticate(code string, setting dtos.GoogleWorkspaceLoginSetting) (dtos.GoogleMember, error) {
-	accessToken, err := adapter.getAccessToken(code, setting)
+// Authenticate 는 인가 코드로 구글 사용자 정보를 가져온다. 디렉터리 접근을 사용하면 디렉터리 API 로 조직 단위와 전화번호를,
+// 그룹을 동기화하면 속한 그룹도 가져온다.
+func (adapter GoogleOAuthAdapter) Authenticate(ctx context.Context, code string, setting dtos.GoogleWorkspaceLoginSetting, directoryAccess dtos.GoogleWorkspaceDirectoryAccess) (dtos.GoogleMember, error) {
+	accessToken, err := adapter.getAccessToken(ctx, code, setting)
 	if err != nil {
 		return dtos.GoogleMember{}, err
 	}
 
-	client := rest.Client{}
 	googleMember := dtos.GoogleMember{}
-	err = client.
-		Request().
-		SetResult(&googleMember).
-		Get(fmt.Sprintf("%v?access_token=%v", config.Config.GoogleOAuth.AuthUri, accessToken))
+	err = HttpClientAdapter().Client(constants.HttpClientGoogle).
+		GetJson(ctx, fmt.Sprintf("%v?access_token=%v", config.Config.GoogleOAuth.AuthUri, accessToken), nil, &googleMember)
 
 	if err != nil {
 		return googleMember, errors.Wrap(err, "google authenticate error")
 	}
 
+	if directoryAccess.IsUsable() {
+		// 디렉터리 정보는 추가 정보이므로 가져오지 못해도 로그인은 계속한다.
+		if err := adapter.importDirectoryProfile(ctx, accessToken, &googleMember); err != nil {
+			log.Warnf("google directory profile import error: %v", err)
+		}
+	}
+
+	if directoryAccess.IsGroupSyncUsable() {
+		// 그룹을 가져오지 못하면 Groups 가 nil 이므로 역할을 동기화하지 않는다.
+		if err := adapter.importGroups(ctx, accessToken, &googleMember); err != nil {
+			log.Warnf("google directory groups import error: %v", err)
+		}
+	}
+
 	return googleMember, nil
 }
 
-func (GoogleOAuthAdapter) getAccessToken(code string, setting dtos.GoogleWorkspaceLoginSetting) (string, error) {
+func (GoogleOAuthAdapter) importDirectoryProfile(ctx context.Context, accessToken string, googleMember *dtos.GoogleMember) error {
+	directoryUser := struct {
+		OrgUnitPath string `json:"orgUnitPath"`
+		Phones      []struct {
+			Value   string `json:"value"`
+			Primary bool   `json:"primary"`
+		} `json:"phones"`
+	}{}
+
+	err := HttpClientAdapter().Client(constants.HttpClientGoogle).GetJson(ctx,
+		fmt.Sprintf("%v/users/%v?projection=basic&viewType=admin_view", config.Config.GoogleOAuth.DirectoryUri, url.PathEscape(googleMember.Id)),
+		map[string]string{"Authorization": fmt.Sprintf("Bearer %v", accessToken)},
+		&directoryUser)
+
+	if err != nil {
+		return errors.Wrap(err, "google directory error")
+	}
+
+	googleMember.OrgUnitPath = directoryUser.OrgUnitPath
+	for _, phone := range directoryUser.Phones {
+		if len(googleMember.Phone) == 0 || phone.Primary {
+			googleMember.Phone = phone.Value
+		}
+	}
+
+	return nil
+}
+
+// importGroups 는 구글 사용자가 속한 그룹의 메일 주소를 모든 페이지에서 가져온다.
+func (GoogleOAuthAdapter) importGroups(ctx context.Context, accessToken string, googleMember *dtos.GoogleMember) error {
+	groups := make([]string, 0)
+	pageToken := ""
+	for {
+		response := struct {
+			Groups []struct {
+				Email string `json:"email"`
+			} `json:"groups"`
+			NextPageToken string `json:"nextPageToken"`
+		}{}
+
+		err := HttpClientAdapter().Client(constants.HttpClientGoogle).GetJson(ctx,
+			fmt.Sprintf("%v/groups?userKey=%v&maxResults=200&pageToken=%v", config.Config.GoogleOAuth.DirectoryUri, url.QueryEscape(googleMember.Id), url.QueryEscape(pageToken)),
+			map[string]string{"Authorization": fmt.Sprintf("Bearer %v", accessToken)},
+			&response)
+
+		if err != nil {
+			return errors.Wrap(err, "google directory groups error")
+		}
+
+		for _, group := range response.Groups {
+			groups = append(groups, group.Email)
+		}
+
+		if len(response.NextPageToken) == 0 {
+			break
+		}
+		pageToken = response.NextPageToken
+	}
+
+	googleMember.Groups = groups
+	return nil
+}
+
+func (GoogleOAuthAdapter) getAccessToken(ctx context.Context, code string, setting dtos.GoogleWorkspaceLoginSetting) (string, error) {
 	data := url.Values{}
 	data.Set("code", code)
 	data.Set("client_id", setting.ClientId)
@@ -45,15 +123,14 @@ func (GoogleOAuthAdapter) getAccessToken(code string, setting dtos.GoogleWorkspa
 	data.Set("redirect_uri", setting.RedirectUri)
 	data.Set("grant_type", "authorization_code")
 
-	client := &http.Client{}
-	r, err := http.NewRequest("POST", config.Config.GoogleOAuth.TokenUri, strings.NewReader(data.Encode())) // URL-encoded payload
+	r, err := http.NewRequestWithContext(ctx, "POST", config.Config.GoogleOAuth.TokenUri, strings.NewReader(data.Encode())) // URL-encoded payload
 	if err != nil {
 		return "", errors.Wrap(err, "google oauth error")
 	}
 	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	r.Header.Add("Content-Length", strconv.Itoa(len(data.Encode())))
 
-	res, err := client.Do(r)
+	res, err := HttpClientAdapter().Client(constants.HttpClientGoogle).Do(r)
 	if err != nil {
 		return "", errors.Wrap(err, "google oauth error")
 	}
@@ -0,0 +1,371 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 재시도 예산은 요청마다 RetryBudgetPercent/100 만큼 쌓이고 재시도마다 1 씩 줄어든다. 최대 retryBudgetCapacity 까지 쌓인다.
+const retryBudgetCapacity = 10
+
+var (
+	httpClientAdapterOnce     sync.Once
+	httpClientAdapterInstance *httpClientAdapter
+)
+
+// HttpClientAdapter 는 외부 연동(두레이, 구글, 웹훅 등)이 함께 사용하는 HTTP Client 를 연동 이름별로 만든다.
+func HttpClientAdapter() *httpClientAdapter {
+	httpClientAdapterOnce.Do(func() {
+		httpClientAdapterInstance = &httpClientAdapter{clients: map[string]*InstrumentedHttpClient{}}
+	})
+
+	return httpClientAdapterInstance
+}
+
+type httpClientAdapter struct {
+	mutex   sync.Mutex
+	clients map[string]*InstrumentedHttpClient
+}
+
+// Client 는 연동 이름의 정책(config.HttpClient)으로 만든 Client 를 반환한다. 처음 사용할 때 만든다.
+func (h *httpClientAdapter) Client(name string) *InstrumentedHttpClient {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if client, ok := h.clients[name]; ok {
+		return client
+	}
+
+	client := newInstrumentedHttpClient(name, getHttpClientPolicy(name))
+	h.clients[name] = client
+	return client
+}
+
+func (h *httpClientAdapter) GetMetrics() []dtos.HttpClientMetric {
+	h.mutex.Lock()
+	clients := make([]*InstrumentedHttpClient, 0, len(h.clients))
+	for _, client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mutex.Unlock()
+
+	metrics := make([]dtos.HttpClientMetric, 0, len(clients))
+	for _, client := range clients {
+		metrics = append(metrics, client.getMetric())
+	}
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
+
+func getHttpClientPolicy(name string) config.HttpClientPolicy {
+	policy, ok := config.Config.HttpClient.Integrations[name]
+	if !ok {
+		return config.Config.HttpClient.Default
+	}
+
+	if policy.TimeoutSeconds == 0 {
+		policy.TimeoutSeconds = config.Config.HttpClient.Default.TimeoutSeconds
+	}
+
+	return policy
+}
+
+// InstrumentedHttpClient 는 정책에 따라 제한 시간, 재시도, 회로 차단을 적용하고 호출 현황을 기록한다.
+type InstrumentedHttpClient struct {
+	name     string
+	policy   config.HttpClientPolicy
+	client   *http.Client
+	mutex    sync.Mutex
+	breakers map[string]*circuitBreaker
+	budget   float64
+	requests int64
+	retries  int64
+	failures int64
+	rejected int64
+	attempts int64
+	latency  time.Duration
+}
+
+func newInstrumentedHttpClient(name string, policy config.HttpClientPolicy) *InstrumentedHttpClient {
+	return &InstrumentedHttpClient{
+		name:     name,
+		policy:   policy,
+		client:   &http.Client{Timeout: time.Duration(policy.TimeoutSeconds) * time.Second},
+		breakers: map[string]*circuitBreaker{},
+		budget:   retryBudgetCapacity,
+	}
+}
+
+// Do 는 요청을 보낸다. 회로가 열려 있으면 보내지 않고 errors.ErrCircuitOpen 을 반환한다.
+// 5xx 응답도 error 없이 반환하므로 상태 코드는 호출하는 쪽에서 확인한다.
+func (c *InstrumentedHttpClient) Do(request *http.Request) (*http.Response, error) {
+	if c.isIdp() && helpers.ContextHelper().HasChaosFault(request.Context(), constants.ChaosFaultIdp) {
+		return nil, pkgerrors.Wrapf(errors.ErrChaosInjected, "%s(%s)", c.name, request.URL.Host)
+	}
+
+	breaker := c.getCircuitBreaker(request.URL.Host)
+	if !breaker.allow(time.Now()) {
+		c.count(&c.rejected)
+		return nil, pkgerrors.Wrapf(errors.ErrCircuitOpen, "%s(%s)", c.name, request.URL.Host)
+	}
+
+	c.count(&c.requests)
+	c.depositRetryBudget()
+
+	for attempt := 0; ; attempt++ {
+		startedAt := time.Now()
+		response, err := c.client.Do(request)
+		c.recordLatency(time.Since(startedAt))
+
+		if err == nil && response.StatusCode < http.StatusInternalServerError && response.StatusCode != http.StatusTooManyRequests {
+			breaker.success()
+			return response, nil
+		}
+
+		if attempt >= c.policy.MaxRetries || !isRetryableRequest(request) || request.Context().Err() != nil || !c.withdrawRetryBudget() {
+			if err != nil || response.StatusCode >= http.StatusInternalServerError {
+				breaker.failure(time.Now(), c.policy)
+				c.count(&c.failures)
+			} else {
+				breaker.success()
+			}
+			return response, err
+		}
+
+		if response != nil {
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+
+		if request.GetBody != nil {
+			body, err := request.GetBody()
+			if err != nil {
+				breaker.release()
+				return nil, pkgerrors.Wrap(err, "http request body error")
+			}
+			request.Body = body
+		}
+
+		c.count(&c.retries)
+		select {
+		case <-time.After(time.Duration(c.policy.RetryDelayMilliseconds) * time.Millisecond << attempt):
+		case <-request.Context().Done():
+			breaker.release()
+			return nil, request.Context().Err()
+		}
+	}
+}
+
+// GetJson 은 GET 요청을 보내 2xx 응답 본문을 result 로 읽는다. ctx 가 취소되면 요청을 중단한다.
+func (c *InstrumentedHttpClient) GetJson(ctx context.Context, url string, headers map[string]string, result interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	response, err := c.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return pkgerrors.Errorf("http response status %d: %s", response.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// newTimeoutContext 는 timeout 이 있으면 parent 에 제한 시간을 더한 context 를 만든다.
+func newTimeoutContext(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+
+	return context.WithCancel(parent)
+}
+
+// isRetryableRequest 는 멱등 요청이고 본문을 다시 읽을 수 있는지 확인한다.
+func isRetryableRequest(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+	}
+
+	return false
+}
+
+// isIdp 는 로그인에 사용하는 외부 IdP(두레이, 구글) 연동인지 여부이다. 장애 주입(idp)에 사용한다.
+func (c *InstrumentedHttpClient) isIdp() bool {
+	return c.name == constants.HttpClientDooray || c.name == constants.HttpClientGoogle
+}
+
+func (c *InstrumentedHttpClient) getCircuitBreaker(host string) *circuitBreaker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	breaker, ok := c.breakers[host]
+	if !ok {
+		breaker = &circuitBreaker{state: constants.CircuitBreakerStateClosed}
+		c.breakers[host] = breaker
+	}
+
+	return breaker
+}
+
+func (c *InstrumentedHttpClient) count(counter *int64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	*counter++
+}
+
+func (c *InstrumentedHttpClient) recordLatency(latency time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.attempts++
+	c.latency += latency
+}
+
+func (c *InstrumentedHttpClient) depositRetryBudget() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.budget += float64(c.policy.RetryBudgetPercent) / 100
+	if c.budget > retryBudgetCapacity {
+		c.budget = retryBudgetCapacity
+	}
+}
+
+func (c *InstrumentedHttpClient) withdrawRetryBudget() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.budget < 1 {
+		return false
+	}
+
+	c.budget--
+	return true
+}
+
+func (c *InstrumentedHttpClient) getMetric() dtos.HttpClientMetric {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	metric := dtos.HttpClientMetric{
+		Name:            c.name,
+		Requests:        c.requests,
+		Retries:         c.retries,
+		Failures:        c.failures,
+		Rejected:        c.rejected,
+		CircuitBreakers: []dtos.CircuitBreakerStatus{},
+	}
+	if c.attempts > 0 {
+		metric.AverageLatencyMilliseconds = (c.latency / time.Duration(c.attempts)).Milliseconds()
+	}
+
+	for host, breaker := range c.breakers {
+		metric.CircuitBreakers = append(metric.CircuitBreakers, breaker.getStatus(host))
+	}
+	sort.Slice(metric.CircuitBreakers, func(i, j int) bool { return metric.CircuitBreakers[i].Host < metric.CircuitBreakers[j].Host })
+
+	return metric
+}
+
+// circuitBreaker 는 호스트별 회로 상태이다. 열린 시간이 지나면 반열림(half-open) 상태로 요청 하나만 보내 보고 성공하면 닫는다.
+type circuitBreaker struct {
+	mutex               sync.Mutex
+	state               string
+	consecutiveFailures int
+	openUntil           time.Time
+	probing             bool
+}
+
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case constants.CircuitBreakerStateOpen:
+		if now.Before(b.openUntil) {
+			return false
+		}
+		b.state = constants.CircuitBreakerStateHalfOpen
+		b.probing = true
+		return true
+	case constants.CircuitBreakerStateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	}
+
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.state = constants.CircuitBreakerStateClosed
+	b.consecutiveFailures = 0
+	b.probing = false
+}
+
+// release 는 결과를 알 수 없이 끝난(취소된) 요청의 시험 요청 표시를 지운다.
+func (b *circuitBreaker) release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+}
+
+func (b *circuitBreaker) failure(now time.Time, policy config.HttpClientPolicy) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.consecutiveFailures++
+	b.probing = false
+	if policy.FailureThreshold > 0 &&
+		(b.state == constants.CircuitBreakerStateHalfOpen || b.consecutiveFailures >= policy.FailureThreshold) {
+		b.state = constants.CircuitBreakerStateOpen
+		b.openUntil = now.Add(time.Duration(policy.OpenSeconds) * time.Second)
+	}
+}
+
+func (b *circuitBreaker) getStatus(host string) dtos.CircuitBreakerStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	status := dtos.CircuitBreakerStatus{
+		Host:                host,
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+	}
+	if b.state == constants.CircuitBreakerStateOpen {
+		openUntil := b.openUntil
+		status.OpenUntil = &openUntil
+	}
+
+	return status
+}
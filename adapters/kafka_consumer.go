@@ -0,0 +1,268 @@
+package adapters
+
+import (
+	pkgerrors "github.com/pkg/errors"
+	"time"
+)
+
+// Fetch v4 는 Kafka 0.11 이후 브로커가 RecordBatch v2 로 응답한다.
+const (
+	kafkaApiKeyFetch             = 1
+	kafkaApiKeyListOffsets       = 2
+	kafkaFetchVersion            = 4
+	kafkaListOffsetsVersion      = 1
+	kafkaLatestOffset            = -1
+	kafkaEarliestOffset          = -2
+	kafkaErrorOffsetOutOfRange   = 1
+	kafkaFetchMaxBytes           = 4 * 1024 * 1024
+	kafkaRecordBatchHeaderLength = 61
+)
+
+// KafkaConsumer 는 외부 라이브러리 없이 Metadata, ListOffsets, Fetch 요청만 구현한 최소한의 Kafka 컨슈머이다.
+// 컨슈머 그룹과 offset 커밋은 구현하지 않으므로 처리한 offset 은 Subscription.LoadOffset 으로 직접 관리한다.
+// 인스턴스마다 모든 파티션을 읽으며 압축한 메시지와 트랜잭션(read committed)은 지원하지 않는다.
+type KafkaConsumer struct {
+	Brokers  []string
+	ClientId string
+	Tls      bool
+	Timeout  time.Duration
+	// MaxWait 은 새 메시지가 없을 때 브로커가 Fetch 응답을 기다리는 시간이다.
+	MaxWait time.Duration
+}
+
+func (k KafkaConsumer) Subscribe(subscription Subscription, stopped <-chan struct{}) error {
+	// 연결과 메타데이터 조회는 KafkaProducer 와 같다.
+	client := &KafkaProducer{Brokers: k.Brokers, ClientId: k.ClientId, Tls: k.Tls, Timeout: k.Timeout}
+	metadata, err := client.fetchMetadata(subscription.Topic)
+	if err != nil {
+		return err
+	}
+
+	offsets := map[int32]int64{}
+	for _, partition := range metadata.partitions {
+		offset, ok, err := subscription.LoadOffset(subscription.Topic, partition.id)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			if offset, err = k.listOffset(client, metadata, subscription.Topic, partition, kafkaLatestOffset); err != nil {
+				return err
+			}
+		}
+		offsets[partition.id] = offset
+	}
+
+	for {
+		select {
+		case <-stopped:
+			return nil
+		default:
+		}
+
+		for _, partition := range metadata.partitions {
+			messages, errorCode, err := k.fetch(client, metadata, subscription.Topic, partition, offsets[partition.id])
+			if err != nil {
+				return err
+			}
+
+			// 보관 기간이 지나 지워진 offset 이면 남아 있는 가장 오래된 메시지부터 읽는다.
+			if errorCode == kafkaErrorOffsetOutOfRange {
+				if offsets[partition.id], err = k.listOffset(client, metadata, subscription.Topic, partition, kafkaEarliestOffset); err != nil {
+					return err
+				}
+				continue
+			}
+			if errorCode != 0 {
+				return pkgerrors.Errorf("kafka fetch error. topic=%s, partition=%d, errorCode=%d", subscription.Topic, partition.id, errorCode)
+			}
+
+			for _, message := range messages {
+				// RecordBatch 는 요청한 offset 보다 앞의 메시지를 포함할 수 있다.
+				if message.Offset < offsets[partition.id] {
+					continue
+				}
+
+				if err := subscription.Handle(message); err != nil {
+					return err
+				}
+				offsets[partition.id] = message.Offset + 1
+			}
+		}
+	}
+}
+
+func (k KafkaConsumer) listOffset(client *KafkaProducer, metadata kafkaTopicMetadata, topic string, partition kafkaPartition, timestamp int64) (int64, error) {
+	address, ok := metadata.brokers[partition.leader]
+	if !ok {
+		return 0, pkgerrors.Errorf("kafka leader broker %d of %s is not available", partition.leader, topic)
+	}
+
+	request := kafkaEncoder{}
+	request.putInt32(-1) // replica id
+	request.putInt32(1)
+	request.putString(topic)
+	request.putInt32(1)
+	request.putInt32(partition.id)
+	request.putInt64(timestamp)
+
+	response, err := client.request(address, kafkaApiKeyListOffsets, kafkaListOffsetsVersion, request.Bytes())
+	if err != nil {
+		return 0, err
+	}
+
+	decoder := kafkaDecoder{data: response}
+	topicCount := decoder.int32()
+	for i := int32(0); i < topicCount && decoder.err == nil; i++ {
+		decoder.string() // topic
+		partitionCount := decoder.int32()
+		for j := int32(0); j < partitionCount && decoder.err == nil; j++ {
+			decoder.int32() // partition
+			errorCode := decoder.int16()
+			decoder.int64() // timestamp
+			offset := decoder.int64()
+			if decoder.err == nil {
+				if errorCode != 0 {
+					return 0, pkgerrors.Errorf("kafka list offsets error. topic=%s, partition=%d, errorCode=%d", topic, partition.id, errorCode)
+				}
+				return offset, nil
+			}
+		}
+	}
+
+	if decoder.err != nil {
+		return 0, decoder.err
+	}
+	return 0, pkgerrors.Errorf("kafka list offsets response has no partition %d of %s", partition.id, topic)
+}
+
+// fetch 는 파티션의 offset 부터 메시지를 가져온다. 파티션 오류는 errorCode 로 돌려준다.
+func (k KafkaConsumer) fetch(client *KafkaProducer, metadata kafkaTopicMetadata, topic string, partition kafkaPartition, offset int64) ([]BrokerMessage, int16, error) {
+	address, ok := metadata.brokers[partition.leader]
+	if !ok {
+		return nil, 0, pkgerrors.Errorf("kafka leader broker %d of %s is not available", partition.leader, topic)
+	}
+
+	request := kafkaEncoder{}
+	request.putInt32(-1) // replica id
+	request.putInt32(int32(k.getMaxWait() / time.Millisecond))
+	request.putInt32(1) // min bytes
+	request.putInt32(kafkaFetchMaxBytes)
+	request.putInt8(0) // read uncommitted
+	request.putInt32(1)
+	request.putString(topic)
+	request.putInt32(1)
+	request.putInt32(partition.id)
+	request.putInt64(offset)
+	request.putInt32(kafkaFetchMaxBytes)
+
+	response, err := client.request(address, kafkaApiKeyFetch, kafkaFetchVersion, request.Bytes())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	decoder := kafkaDecoder{data: response}
+	decoder.int32() // throttle time
+	topicCount := decoder.int32()
+	for i := int32(0); i < topicCount && decoder.err == nil; i++ {
+		decoder.string() // topic
+		partitionCount := decoder.int32()
+		for j := int32(0); j < partitionCount && decoder.err == nil; j++ {
+			partitionId := decoder.int32()
+			errorCode := decoder.int16()
+			decoder.int64() // high watermark
+			decoder.int64() // last stable offset
+			if abortedCount := decoder.int32(); abortedCount > 0 {
+				decoder.next(int(abortedCount) * 16)
+			}
+			records := decoder.bytes()
+			if decoder.err != nil {
+				break
+			}
+			if errorCode != 0 {
+				return nil, errorCode, nil
+			}
+
+			messages, err := decodeKafkaRecordBatches(topic, partitionId, records)
+			return messages, 0, err
+		}
+	}
+
+	return nil, 0, decoder.err
+}
+
+// decodeKafkaRecordBatches 는 압축하지 않은 RecordBatch(magic 2)를 읽는다. 응답의 마지막 RecordBatch 는 잘려 있을 수 있으므로 무시한다.
+func decodeKafkaRecordBatches(topic string, partition int32, records []byte) ([]BrokerMessage, error) {
+	messages := make([]BrokerMessage, 0)
+	decoder := kafkaDecoder{data: records}
+
+	for len(records)-decoder.offset >= 12 {
+		baseOffset := decoder.int64()
+		batchLength := decoder.int32()
+		if batchLength < kafkaRecordBatchHeaderLength-12 || int(batchLength) > len(records)-decoder.offset {
+			break
+		}
+
+		batch := kafkaDecoder{data: decoder.next(int(batchLength))}
+		batch.int32() // partition leader epoch
+		magic := batch.int8()
+		batch.int32() // crc
+		attributes := batch.int16()
+		batch.int32() // last offset delta
+		batch.int64() // first timestamp
+		batch.int64() // max timestamp
+		batch.int64() // producer id
+		batch.int16() // producer epoch
+		batch.int32() // base sequence
+		recordCount := batch.int32()
+
+		if magic != 2 {
+			return nil, pkgerrors.Errorf("kafka unsupported record batch version %d", magic)
+		}
+		if attributes&0x07 != 0 {
+			return nil, pkgerrors.Errorf("kafka compressed record batch is not supported. topic=%s, partition=%d", topic, partition)
+		}
+		// 트랜잭션의 커밋, 중단을 나타내는 control batch 는 메시지가 아니다.
+		if attributes&0x20 != 0 {
+			continue
+		}
+
+		for i := int32(0); i < recordCount && batch.err == nil; i++ {
+			batch.varint() // record length
+			batch.int8()   // attributes
+			batch.varint() // timestamp delta
+			offsetDelta := batch.varint()
+			message := BrokerMessage{
+				Topic:     topic,
+				Key:       string(batch.varintBytes()),
+				Value:     batch.varintBytes(),
+				Headers:   map[string]string{},
+				Partition: partition,
+				Offset:    baseOffset + offsetDelta,
+			}
+
+			headerCount := batch.varint()
+			for j := int64(0); j < headerCount && batch.err == nil; j++ {
+				key := string(batch.varintBytes())
+				message.Headers[key] = string(batch.varintBytes())
+			}
+
+			if batch.err == nil {
+				messages = append(messages, message)
+			}
+		}
+
+		if batch.err != nil {
+			return nil, batch.err
+		}
+	}
+
+	return messages, decoder.err
+}
+
+func (k KafkaConsumer) getMaxWait() time.Duration {
+	if k.MaxWait <= 0 {
+		return 500 * time.Millisecond
+	}
+	return k.MaxWait
+}
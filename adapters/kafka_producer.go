@@ -0,0 +1,446 @@
+package adapters
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	pkgerrors "github.com/pkg/errors"
+	"hash/crc32"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Kafka 프로토콜의 API 키와 버전. Produce v3(RecordBatch v2)는 Kafka 0.11 이후 모든 브로커가 지원한다.
+const (
+	kafkaApiKeyProduce     = 0
+	kafkaApiKeyMetadata    = 3
+	kafkaProduceVersion    = 3
+	kafkaMetadataVersion   = 1
+	kafkaMaxResponseLength = 64 * 1024 * 1024
+)
+
+var kafkaCrc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// KafkaMessage 는 Kafka 토픽에 보낼 메시지이다. 같은 Key 의 메시지는 같은 파티션으로 보낸다.
+type KafkaMessage struct {
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// KafkaProducer 는 외부 라이브러리 없이 Metadata, Produce 요청만 구현한 최소한의 Kafka 프로듀서이다.
+// 압축과 SASL 인증은 지원하지 않으며 acks=all 로 보낸다.
+type KafkaProducer struct {
+	Brokers  []string
+	ClientId string
+	Tls      bool
+	Timeout  time.Duration
+
+	correlationId int32
+	nextPartition uint32
+}
+
+// Produce 는 메시지를 파티션 리더 브로커에 보내고 모든 파티션이 저장할 때까지 기다린다.
+func (k *KafkaProducer) Produce(topic string, messages []KafkaMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	metadata, err := k.fetchMetadata(topic)
+	if err != nil {
+		return err
+	}
+
+	// 리더 브로커 -> 파티션 -> 메시지
+	batches := map[int32]map[int32][]KafkaMessage{}
+	for _, message := range messages {
+		partition := metadata.partitions[k.choosePartition(message.Key, len(metadata.partitions))]
+		if batches[partition.leader] == nil {
+			batches[partition.leader] = map[int32][]KafkaMessage{}
+		}
+		batches[partition.leader][partition.id] = append(batches[partition.leader][partition.id], message)
+	}
+
+	for leader, partitionMessages := range batches {
+		address, ok := metadata.brokers[leader]
+		if !ok {
+			return pkgerrors.Errorf("kafka leader broker %d of %s is not available", leader, topic)
+		}
+
+		if err := k.produceToBroker(address, topic, partitionMessages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k *KafkaProducer) choosePartition(key []byte, partitionCount int) int {
+	if len(key) == 0 {
+		return int(atomic.AddUint32(&k.nextPartition, 1) % uint32(partitionCount))
+	}
+	return int(crc32.ChecksumIEEE(key) % uint32(partitionCount))
+}
+
+type kafkaPartition struct {
+	id     int32
+	leader int32
+}
+
+type kafkaTopicMetadata struct {
+	brokers    map[int32]string
+	partitions []kafkaPartition
+}
+
+// fetchMetadata 는 Brokers 중 응답하는 첫 브로커에서 토픽의 파티션과 리더를 찾는다.
+func (k *KafkaProducer) fetchMetadata(topic string) (kafkaTopicMetadata, error) {
+	request := kafkaEncoder{}
+	request.putInt32(1)
+	request.putString(topic)
+
+	var lastErr error = pkgerrors.New("kafka brokers are not configured")
+	for _, broker := range k.Brokers {
+		response, err := k.request(broker, kafkaApiKeyMetadata, kafkaMetadataVersion, request.Bytes())
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		return parseKafkaMetadataResponse(response, topic)
+	}
+
+	return kafkaTopicMetadata{}, lastErr
+}
+
+func parseKafkaMetadataResponse(response []byte, topic string) (kafkaTopicMetadata, error) {
+	decoder := kafkaDecoder{data: response}
+	metadata := kafkaTopicMetadata{brokers: map[int32]string{}}
+
+	brokerCount := decoder.int32()
+	for i := int32(0); i < brokerCount && decoder.err == nil; i++ {
+		nodeId := decoder.int32()
+		host := decoder.string()
+		port := decoder.int32()
+		decoder.string() // rack
+		metadata.brokers[nodeId] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	decoder.int32() // controller id
+
+	topicCount := decoder.int32()
+	for i := int32(0); i < topicCount && decoder.err == nil; i++ {
+		errorCode := decoder.int16()
+		name := decoder.string()
+		decoder.int8() // is internal
+
+		partitions := make([]kafkaPartition, 0)
+		partitionCount := decoder.int32()
+		for j := int32(0); j < partitionCount && decoder.err == nil; j++ {
+			decoder.int16() // partition error code
+			partition := kafkaPartition{id: decoder.int32(), leader: decoder.int32()}
+			decoder.int32Array() // replicas
+			decoder.int32Array() // isr
+			partitions = append(partitions, partition)
+		}
+
+		if name != topic {
+			continue
+		}
+		if errorCode != 0 {
+			return kafkaTopicMetadata{}, pkgerrors.Errorf("kafka metadata error. topic=%s, errorCode=%d", topic, errorCode)
+		}
+		metadata.partitions = partitions
+	}
+
+	if decoder.err != nil {
+		return kafkaTopicMetadata{}, decoder.err
+	}
+	if len(metadata.partitions) == 0 {
+		return kafkaTopicMetadata{}, pkgerrors.Errorf("kafka topic %s has no partitions", topic)
+	}
+
+	return metadata, nil
+}
+
+func (k *KafkaProducer) produceToBroker(address string, topic string, partitionMessages map[int32][]KafkaMessage) error {
+	request := kafkaEncoder{}
+	request.putInt16(-1) // transactional id (null)
+	request.putInt16(-1) // acks=all
+	request.putInt32(int32(k.getTimeout() / time.Millisecond))
+	request.putInt32(1)
+	request.putString(topic)
+	request.putInt32(int32(len(partitionMessages)))
+	for partition, messages := range partitionMessages {
+		request.putInt32(partition)
+		request.putBytes(encodeKafkaRecordBatch(messages, time.Now()))
+	}
+
+	response, err := k.request(address, kafkaApiKeyProduce, kafkaProduceVersion, request.Bytes())
+	if err != nil {
+		return err
+	}
+
+	decoder := kafkaDecoder{data: response}
+	topicCount := decoder.int32()
+	for i := int32(0); i < topicCount && decoder.err == nil; i++ {
+		decoder.string() // topic
+		partitionCount := decoder.int32()
+		for j := int32(0); j < partitionCount && decoder.err == nil; j++ {
+			partition := decoder.int32()
+			errorCode := decoder.int16()
+			decoder.int64() // base offset
+			decoder.int64() // log append time
+			if decoder.err == nil && errorCode != 0 {
+				return pkgerrors.Errorf("kafka produce error. topic=%s, partition=%d, errorCode=%d", topic, partition, errorCode)
+			}
+		}
+	}
+
+	return decoder.err
+}
+
+// encodeKafkaRecordBatch 는 압축하지 않은 RecordBatch(magic 2)를 만든다.
+func encodeKafkaRecordBatch(messages []KafkaMessage, now time.Time) []byte {
+	timestamp := now.UnixNano() / int64(time.Millisecond)
+
+	records := kafkaEncoder{}
+	for i, message := range messages {
+		record := kafkaEncoder{}
+		record.putInt8(0)          // attributes
+		record.putVarint(0)        // timestamp delta
+		record.putVarint(int64(i)) // offset delta
+		record.putVarintBytes(message.Key)
+		record.putVarintBytes(message.Value)
+		record.putVarint(int64(len(message.Headers)))
+		for key, value := range message.Headers {
+			record.putVarintBytes([]byte(key))
+			record.putVarintBytes([]byte(value))
+		}
+
+		records.putVarint(int64(record.Len()))
+		records.Write(record.Bytes())
+	}
+
+	// attributes 부터 마지막 record 까지가 CRC 대상이다.
+	body := kafkaEncoder{}
+	body.putInt16(0) // attributes
+	body.putInt32(int32(len(messages) - 1))
+	body.putInt64(timestamp)
+	body.putInt64(timestamp)
+	body.putInt64(-1) // producer id
+	body.putInt16(-1) // producer epoch
+	body.putInt32(-1) // base sequence
+	body.putInt32(int32(len(messages)))
+	body.Write(records.Bytes())
+
+	batch := kafkaEncoder{}
+	batch.putInt64(0) // base offset
+	batch.putInt32(int32(4 + 1 + 4 + body.Len()))
+	batch.putInt32(-1) // partition leader epoch
+	batch.putInt8(2)   // magic
+	batch.putInt32(int32(crc32.Checksum(body.Bytes(), kafkaCrc32cTable)))
+	batch.Write(body.Bytes())
+
+	return batch.Bytes()
+}
+
+// request 는 요청 하나를 보내고 응답에서 correlation id 를 뺀 나머지를 돌려준다.
+func (k *KafkaProducer) request(address string, apiKey, apiVersion int16, body []byte) ([]byte, error) {
+	conn, err := k.dial(address)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "kafka connect error")
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(k.getTimeout()))
+
+	correlationId := atomic.AddInt32(&k.correlationId, 1)
+
+	message := kafkaEncoder{}
+	message.putInt16(apiKey)
+	message.putInt16(apiVersion)
+	message.putInt32(correlationId)
+	message.putString(k.ClientId)
+	message.Write(body)
+
+	frame := kafkaEncoder{}
+	frame.putInt32(int32(message.Len()))
+	frame.Write(message.Bytes())
+	if _, err := conn.Write(frame.Bytes()); err != nil {
+		return nil, pkgerrors.Wrap(err, "kafka write error")
+	}
+
+	size := make([]byte, 4)
+	if _, err := io.ReadFull(conn, size); err != nil {
+		return nil, pkgerrors.Wrap(err, "kafka read error")
+	}
+	length := binary.BigEndian.Uint32(size)
+	if length < 4 || length > kafkaMaxResponseLength {
+		return nil, pkgerrors.Errorf("kafka invalid response length %d", length)
+	}
+
+	response := make([]byte, length)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return nil, pkgerrors.Wrap(err, "kafka read error")
+	}
+
+	if int32(binary.BigEndian.Uint32(response)) != correlationId {
+		return nil, pkgerrors.New("kafka correlation id mismatch")
+	}
+
+	return response[4:], nil
+}
+
+func (k *KafkaProducer) dial(address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: k.getTimeout()}
+	if k.Tls {
+		return tls.DialWithDialer(dialer, "tcp", address, &tls.Config{})
+	}
+	return dialer.Dial("tcp", address)
+}
+
+func (k *KafkaProducer) getTimeout() time.Duration {
+	if k.Timeout <= 0 {
+		return 10 * time.Second
+	}
+	return k.Timeout
+}
+
+type kafkaEncoder struct {
+	bytes.Buffer
+}
+
+func (e *kafkaEncoder) putInt8(v int8) {
+	e.WriteByte(byte(v))
+}
+
+func (e *kafkaEncoder) putInt16(v int16) {
+	binary.Write(e, binary.BigEndian, v)
+}
+
+func (e *kafkaEncoder) putInt32(v int32) {
+	binary.Write(e, binary.BigEndian, v)
+}
+
+func (e *kafkaEncoder) putInt64(v int64) {
+	binary.Write(e, binary.BigEndian, v)
+}
+
+func (e *kafkaEncoder) putString(v string) {
+	e.putInt16(int16(len(v)))
+	e.WriteString(v)
+}
+
+func (e *kafkaEncoder) putBytes(v []byte) {
+	e.putInt32(int32(len(v)))
+	e.Write(v)
+}
+
+// putVarint 는 zigzag varint 로 쓴다. binary.PutVarint 가 Kafka 와 같은 zigzag 인코딩을 사용한다.
+func (e *kafkaEncoder) putVarint(v int64) {
+	buffer := make([]byte, binary.MaxVarintLen64)
+	e.Write(buffer[:binary.PutVarint(buffer, v)])
+}
+
+// putVarintBytes 는 길이를 varint 로 쓴다. nil 은 길이 -1 이다.
+func (e *kafkaEncoder) putVarintBytes(v []byte) {
+	if v == nil {
+		e.putVarint(-1)
+		return
+	}
+	e.putVarint(int64(len(v)))
+	e.Write(v)
+}
+
+// kafkaDecoder 는 응답을 읽다가 처음 발생한 오류를 err 에 남기고 이후에는 0 값을 돌려준다.
+type kafkaDecoder struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (d *kafkaDecoder) next(size int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if size < 0 || d.offset+size > len(d.data) {
+		d.err = pkgerrors.New("kafka response is too short")
+		return nil
+	}
+	value := d.data[d.offset : d.offset+size]
+	d.offset += size
+	return value
+}
+
+func (d *kafkaDecoder) int8() int8 {
+	if value := d.next(1); value != nil {
+		return int8(value[0])
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int16() int16 {
+	if value := d.next(2); value != nil {
+		return int16(binary.BigEndian.Uint16(value))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int32() int32 {
+	if value := d.next(4); value != nil {
+		return int32(binary.BigEndian.Uint32(value))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int64() int64 {
+	if value := d.next(8); value != nil {
+		return int64(binary.BigEndian.Uint64(value))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) string() string {
+	length := d.int16()
+	if length < 0 {
+		return ""
+	}
+	return string(d.next(int(length)))
+}
+
+func (d *kafkaDecoder) int32Array() {
+	if count := d.int32(); count > 0 {
+		d.next(int(count) * 4)
+	}
+}
+
+func (d *kafkaDecoder) bytes() []byte {
+	length := d.int32()
+	if length < 0 {
+		return nil
+	}
+	return d.next(int(length))
+}
+
+func (d *kafkaDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	value, size := binary.Varint(d.data[d.offset:])
+	if size <= 0 {
+		d.err = pkgerrors.New("kafka invalid varint")
+		return 0
+	}
+	d.offset += size
+	return value
+}
+
+// varintBytes 는 길이가 varint 인 값을 읽는다. 길이 -1 은 nil 이다.
+func (d *kafkaDecoder) varintBytes() []byte {
+	length := d.varint()
+	if length < 0 {
+		return nil
+	}
+	return d.next(int(length))
+}
@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/security"
+	pkgerrors "github.com/pkg/errors"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	licenseAdapterOnce     sync.Once
+	licenseAdapterInstance *licenseAdapter
+)
+
+// LicenseAdapter 는 설정(License.FilePath)의 라이선스 파일을 읽고 바이너리에 넣은 공개 키(security.LicensePublicKey)로 서명을 확인한다.
+func LicenseAdapter() *licenseAdapter {
+	licenseAdapterOnce.Do(func() {
+		licenseAdapterInstance = &licenseAdapter{}
+	})
+
+	return licenseAdapterInstance
+}
+
+type licenseAdapter struct {
+	mutex       sync.Mutex
+	license     *security.License
+	licensePath string
+	publicKey   string
+	modifiedAt  time.Time
+}
+
+// GetLicense 는 라이선스를 처음 사용할 때 읽는다. 경로, 공개 키나 파일이 바뀌면(라이선스 갱신) 다시 읽는다.
+// 라이선스 파일을 설정하지 않았으면 nil 이다.
+func (l *licenseAdapter) GetLicense() (*security.License, error) {
+	path, publicKey := config.Config.License.FilePath, security.LicensePublicKey
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "license file open error")
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.license != nil && l.licensePath == path && l.publicKey == publicKey && l.modifiedAt.Equal(info.ModTime()) {
+		return l.license, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "license file open error")
+	}
+
+	license, err := security.ParseLicense(data, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	l.license, l.licensePath, l.publicKey, l.modifiedAt = &license, path, publicKey, info.ModTime()
+	return &license, nil
+}
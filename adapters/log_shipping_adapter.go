@@ -0,0 +1,446 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"encoding/json"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	logShippingAdapterOnce     sync.Once
+	logShippingAdapterInstance *logShippingAdapter
+)
+
+// LogSink 는 이벤트를 SIEM 등 외부 시스템으로 보낸다. 설정(LogShipping)에 없는 싱크는 구현하여 SetSinks 로 등록한다.
+type LogSink interface {
+	Name() string
+	Ship(events []dtos.Event) error
+}
+
+// LogShippingAdapter 는 이벤트 버스의 이벤트를 싱크별 버퍼에 쌓고 별도 goroutine 에서 묶어서 보낸다.
+func LogShippingAdapter() *logShippingAdapter {
+	logShippingAdapterOnce.Do(func() {
+		logShippingAdapterInstance = &logShippingAdapter{}
+		EventBusAdapter().Subscribe(logShippingAdapterInstance.Ship)
+	})
+
+	return logShippingAdapterInstance
+}
+
+type logShippingAdapter struct {
+	mutex     sync.RWMutex
+	sinks     []LogSink
+	shippers  []*logShipper
+	stopped   chan struct{}
+	waitGroup sync.WaitGroup
+}
+
+// SetSinks 는 싱크를 교체한다. nil 이면 설정(LogShipping)의 싱크를 사용한다. 다음 Start 부터 적용된다.
+func (l *logShippingAdapter) SetSinks(sinks []LogSink) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.sinks = sinks
+}
+
+// Start 는 싱크마다 전송 goroutine 을 시작한다. 이미 시작했으면 멈춘 뒤 다시 시작한다.
+func (l *logShippingAdapter) Start() {
+	l.Stop()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	sinks := l.sinks
+	if sinks == nil {
+		sinks = getConfiguredLogSinks()
+	}
+
+	shippingConfig := config.Config.LogShipping
+	l.shippers = make([]*logShipper, 0)
+	l.stopped = make(chan struct{})
+	for _, sink := range sinks {
+		shipper := newLogShipper(sink, shippingConfig.BufferSize, shippingConfig.BatchSize,
+			time.Duration(shippingConfig.FlushIntervalSeconds)*time.Second, shippingConfig.MaxRetries)
+		l.shippers = append(l.shippers, shipper)
+
+		l.waitGroup.Add(1)
+		go shipper.run(l.stopped, &l.waitGroup)
+	}
+}
+
+// Stop 은 버퍼에 남은 이벤트를 한 번씩 보내고 전송 goroutine 을 멈춘다.
+func (l *logShippingAdapter) Stop() {
+	l.mutex.Lock()
+	stopped := l.stopped
+	l.stopped = nil
+	l.mutex.Unlock()
+
+	if stopped == nil {
+		return
+	}
+
+	close(stopped)
+	l.waitGroup.Wait()
+}
+
+// Ship 은 이벤트를 싱크별 버퍼에 넣는다. 요청 처리가 느려지지 않도록 버퍼가 가득 찬 싱크에는 이벤트를 버린다.
+func (l *logShippingAdapter) Ship(event dtos.Event) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if l.stopped == nil {
+		return
+	}
+
+	for _, shipper := range l.shippers {
+		shipper.enqueue(event)
+	}
+}
+
+func (l *logShippingAdapter) GetStatus() dtos.LogShippingStatus {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	status := dtos.LogShippingStatus{Sinks: make([]dtos.LogSinkStatus, 0)}
+	for _, shipper := range l.shippers {
+		status.Sinks = append(status.Sinks, shipper.getStatus())
+	}
+
+	return status
+}
+
+func getConfiguredLogSinks() []LogSink {
+	shippingConfig := config.Config.LogShipping
+	sinks := make([]LogSink, 0)
+
+	if len(shippingConfig.Syslog.Address) > 0 {
+		sinks = append(sinks, SyslogLogSink{
+			Network: shippingConfig.Syslog.Network,
+			Address: shippingConfig.Syslog.Address,
+			AppName: shippingConfig.Syslog.AppName,
+		})
+	}
+
+	if len(shippingConfig.Kafka.Brokers) > 0 {
+		sinks = append(sinks, KafkaLogSink{
+			Producer: &KafkaProducer{Brokers: shippingConfig.Kafka.Brokers, ClientId: "better-admin", Tls: shippingConfig.Kafka.Tls},
+			Topic:    shippingConfig.Kafka.Topic,
+		})
+	}
+
+	if len(shippingConfig.WebHook.Url) > 0 {
+		sinks = append(sinks, WebHookLogSink{
+			Url:           shippingConfig.WebHook.Url,
+			Authorization: shippingConfig.WebHook.Authorization,
+		})
+	}
+
+	return sinks
+}
+
+type logShipper struct {
+	sink          LogSink
+	queue         chan dtos.Event
+	batchSize     int
+	flushInterval time.Duration
+	maxRetries    int
+
+	mutex  sync.Mutex
+	status dtos.LogSinkStatus
+}
+
+func newLogShipper(sink LogSink, bufferSize, batchSize int, flushInterval time.Duration, maxRetries int) *logShipper {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+
+	return &logShipper{
+		sink:          sink,
+		queue:         make(chan dtos.Event, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		maxRetries:    maxRetries,
+		status:        dtos.LogSinkStatus{Name: sink.Name(), Capacity: bufferSize},
+	}
+}
+
+func (s *logShipper) enqueue(event dtos.Event) {
+	select {
+	case s.queue <- event:
+	default:
+		s.mutex.Lock()
+		s.status.Dropped++
+		s.mutex.Unlock()
+	}
+}
+
+// run 은 BatchSize 만큼 모이거나 FlushInterval 이 지나면 보낸다. 보내는 동안(재시도 포함)은 버퍼에 쌓이기만 한다.
+func (s *logShipper) run(stopped <-chan struct{}, waitGroup *sync.WaitGroup) {
+	defer waitGroup.Done()
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]dtos.Event, 0, s.batchSize)
+	for {
+		select {
+		case event := <-s.queue:
+			batch = append(batch, event)
+			if len(batch) >= s.batchSize {
+				s.ship(batch, stopped)
+				batch = make([]dtos.Event, 0, s.batchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				s.ship(batch, stopped)
+				batch = make([]dtos.Event, 0, s.batchSize)
+			}
+		case <-stopped:
+			for {
+				select {
+				case event := <-s.queue:
+					batch = append(batch, event)
+					if len(batch) >= s.batchSize {
+						s.ship(batch, stopped)
+						batch = make([]dtos.Event, 0, s.batchSize)
+					}
+				default:
+					if len(batch) > 0 {
+						s.ship(batch, stopped)
+					}
+					return
+				}
+			}
+		}
+	}
+}
+
+// ship 은 실패하면 1초부터 두 배씩 기다리며 다시 보낸다. 멈추는 중에는 기다리지 않고 실패로 센다.
+func (s *logShipper) ship(events []dtos.Event, stopped <-chan struct{}) {
+	backoff := time.Second
+	for attempt := 0; ; attempt++ {
+		err := s.sink.Ship(events)
+
+		s.mutex.Lock()
+		if err == nil {
+			now := time.Now()
+			s.status.Shipped += int64(len(events))
+			s.status.LastShippedAt = &now
+			s.mutex.Unlock()
+			return
+		}
+		s.status.LastError = err.Error()
+		if attempt >= s.maxRetries {
+			s.status.Failed += int64(len(events))
+			s.mutex.Unlock()
+			log.Errorf("log shipping error. sink=%s, events=%d, %v", s.sink.Name(), len(events), err)
+			return
+		}
+		s.status.Retries++
+		s.mutex.Unlock()
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-stopped:
+			s.mutex.Lock()
+			s.status.Failed += int64(len(events))
+			s.mutex.Unlock()
+			log.Errorf("log shipping error. sink=%s, events=%d, %v", s.sink.Name(), len(events), err)
+			return
+		}
+	}
+}
+
+func (s *logShipper) getStatus() dtos.LogSinkStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	status := s.status
+	status.Queued = len(s.queue)
+	return status
+}
+
+// SyslogLogSink 는 이벤트를 CEF(ArcSight Common Event Format) 로 만들어 syslog(RFC 5424) 로 보낸다.
+// tcp 는 메시지를 줄바꿈으로 구분한다.
+type SyslogLogSink struct {
+	Network string
+	Address string
+	AppName string
+}
+
+func (SyslogLogSink) Name() string {
+	return constants.LogSinkSyslog
+}
+
+func (s SyslogLogSink) Ship(events []dtos.Event) error {
+	conn, err := net.DialTimeout(s.Network, s.Address, 10*time.Second)
+	if err != nil {
+		return pkgerrors.Wrap(err, "syslog connect error")
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	hostname, _ := os.Hostname()
+	for _, event := range events {
+		message := FormatSyslogMessage(event, hostname, s.AppName)
+		if s.Network != "udp" {
+			message += "\n"
+		}
+
+		if _, err := conn.Write([]byte(message)); err != nil {
+			return pkgerrors.Wrap(err, "syslog write error")
+		}
+	}
+
+	return nil
+}
+
+// FormatSyslogMessage 는 RFC 5424 형식에 CEF 메시지를 담는다. 로그인 실패는 warning, 그 외는 info 수준이다.
+func FormatSyslogMessage(event dtos.Event, hostname, appName string) string {
+	syslogSeverity, cefSeverity := 6, 3
+	if !event.Succeeded {
+		syslogSeverity, cefSeverity = 4, 5
+	}
+
+	if len(hostname) == 0 {
+		hostname = "-"
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s - - - %s",
+		constants.SyslogFacilityAuthPriv*8+syslogSeverity,
+		event.OccurredAt.Format("2006-01-02T15:04:05.000Z07:00"),
+		hostname, appName, formatCef(event, cefSeverity))
+}
+
+func formatCef(event dtos.Event, severity int) string {
+	name := event.Action
+	if event.Type == constants.EventTypeLogin {
+		name = "login success"
+		if !event.Succeeded {
+			name = "login failure"
+		}
+	}
+
+	outcome := "success"
+	if !event.Succeeded {
+		outcome = "failure"
+	}
+
+	// 값이 없는 확장 필드는 이름표(csNLabel)도 쓰지 않는다.
+	extensions := [][3]string{
+		{"externalId", "", event.Id},
+		{"rt", "", fmt.Sprint(event.OccurredAt.UnixNano() / int64(time.Millisecond))},
+		{"cat", "", event.Type},
+		{"act", "", event.Action},
+		{"outcome", "", outcome},
+		{"reason", "", event.Reason},
+		{"cs1", "actorType", event.ActorType},
+		{"suid", "", formatCefId(event.ActorId)},
+		{"cs2", "targetType", event.TargetType},
+		{"cs3", "targetId", formatCefId(event.TargetId)},
+		{"src", "", event.IpAddress},
+		{"cs4", "country", event.Country},
+		{"msg", "", event.Detail},
+	}
+
+	values := make([]string, 0)
+	for _, extension := range extensions {
+		if len(extension[2]) == 0 {
+			continue
+		}
+		if len(extension[1]) > 0 {
+			values = append(values, extension[0]+"Label="+extension[1])
+		}
+		values = append(values, extension[0]+"="+escapeCefExtension(extension[2]))
+	}
+
+	return fmt.Sprintf("CEF:0|Bettercode|better-ADMIN|1.0|%s|%s|%d|%s",
+		escapeCefHeader(event.Type+":"+event.Action), escapeCefHeader(name), severity, strings.Join(values, " "))
+}
+
+func formatCefId(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprint(id)
+}
+
+func escapeCefHeader(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(value)
+}
+
+func escapeCefExtension(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
+
+// KafkaLogSink 는 이벤트를 JSON 으로 Topic 에 보낸다. 메시지 키는 이벤트 Id 이다.
+type KafkaLogSink struct {
+	Producer *KafkaProducer
+	Topic    string
+}
+
+func (KafkaLogSink) Name() string {
+	return constants.LogSinkKafka
+}
+
+func (k KafkaLogSink) Ship(events []dtos.Event) error {
+	messages := make([]KafkaMessage, 0)
+	for _, event := range events {
+		value, err := json.Marshal(event)
+		if err != nil {
+			return pkgerrors.Wrap(err, "event encode error")
+		}
+
+		messages = append(messages, KafkaMessage{
+			Key:     []byte(event.Id),
+			Value:   value,
+			Headers: map[string]string{"eventType": event.Type},
+		})
+	}
+
+	return k.Producer.Produce(k.Topic, messages)
+}
+
+// WebHookLogSink 는 이벤트 배열을 JSON 으로 Url 에 POST 한다.
+type WebHookLogSink struct {
+	Url           string
+	Authorization string
+}
+
+func (WebHookLogSink) Name() string {
+	return constants.LogSinkWebHook
+}
+
+func (w WebHookLogSink) Ship(events []dtos.Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return pkgerrors.Wrap(err, "event encode error")
+	}
+
+	headers := map[string]string{}
+	if len(w.Authorization) > 0 {
+		headers["Authorization"] = w.Authorization
+	}
+
+	return OutgoingWebHookAdapter().Send(dtos.OutgoingWebHookRequest{
+		Url:         w.Url,
+		ContentType: "application/json",
+		Headers:     headers,
+		Body:        body,
+	})
+}
@@ -0,0 +1,146 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+var (
+	mailAdapterOnce     sync.Once
+	mailAdapterInstance *mailAdapter
+)
+
+// MailSender 는 실제 메일 전송을 담당한다. 테스트 등에서 교체할 수 있다.
+type MailSender interface {
+	Send(message dtos.MailMessage) error
+}
+
+func MailAdapter() *mailAdapter {
+	mailAdapterOnce.Do(func() {
+		mailAdapterInstance = &mailAdapter{}
+	})
+
+	return mailAdapterInstance
+}
+
+type mailAdapter struct {
+	mutex  sync.RWMutex
+	sender MailSender
+}
+
+func (m *mailAdapter) SetSender(sender MailSender) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sender = sender
+}
+
+func (m *mailAdapter) Send(message dtos.MailMessage) error {
+	m.mutex.RLock()
+	sender := m.sender
+	m.mutex.RUnlock()
+
+	if sender != nil {
+		return sender.Send(message)
+	}
+
+	return smtpMailSender{}.Send(message)
+}
+
+// smtpMailSender 는 설정(Mail.SmtpHost)이 없으면 메일을 보내지 않고 로그만 남긴다.
+type smtpMailSender struct {
+}
+
+func (smtpMailSender) Send(message dtos.MailMessage) error {
+	mailConfig := config.Config.Mail
+	if len(mailConfig.SmtpHost) == 0 {
+		log.Infof("mail is not configured. to=%v, subject=%v", message.To, message.Subject)
+		return nil
+	}
+
+	var auth smtp.Auth
+	if len(mailConfig.Username) > 0 {
+		auth = smtp.PlainAuth("", mailConfig.Username, mailConfig.Password, mailConfig.SmtpHost)
+	}
+
+	headers := []string{
+		fmt.Sprintf("From: %s", mailConfig.From),
+		fmt.Sprintf("To: %s", strings.Join(message.To, ",")),
+		fmt.Sprintf("Subject: %s", mime.BEncoding.Encode("UTF-8", message.Subject)),
+		"MIME-Version: 1.0",
+	}
+
+	body, err := encodeMailBody(headers, message)
+	if err != nil {
+		return err
+	}
+
+	if err := smtp.SendMail(fmt.Sprintf("%s:%d", mailConfig.SmtpHost, mailConfig.SmtpPort), auth, mailConfig.From, message.To, body); err != nil {
+		return pkgerrors.Wrap(err, "send mail error")
+	}
+
+	return nil
+}
+
+// encodeMailBody 는 첨부 파일이 있으면 multipart/mixed 로, 없으면 일반 텍스트로 메일 본문을 만든다.
+func encodeMailBody(headers []string, message dtos.MailMessage) ([]byte, error) {
+	if len(message.Attachments) == 0 {
+		return []byte(strings.Join(append(headers,
+			"Content-Type: text/plain; charset=\"UTF-8\"",
+			"",
+			message.Body,
+		), "\r\n")), nil
+	}
+
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+
+	buffer.WriteString(strings.Join(append(headers,
+		fmt.Sprintf("Content-Type: multipart/mixed; boundary=%q", writer.Boundary()),
+		"",
+		"",
+	), "\r\n"))
+
+	textPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/plain; charset=\"UTF-8\""},
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "mail body encode error")
+	}
+	textPart.Write([]byte(message.Body))
+
+	for _, attachment := range message.Attachments {
+		attachmentPart, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {attachment.ContentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})},
+		})
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "mail attachment encode error")
+		}
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		// RFC 2045 에 따라 76자 마다 줄을 바꾼다.
+		for len(encoded) > 76 {
+			attachmentPart.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		attachmentPart.Write([]byte(encoded))
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, pkgerrors.Wrap(err, "mail body encode error")
+	}
+
+	return buffer.Bytes(), nil
+}
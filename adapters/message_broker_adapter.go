@@ -0,0 +1,179 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	pkgerrors "github.com/pkg/errors"
+	"sync"
+)
+
+var (
+	messageBrokerAdapterOnce     sync.Once
+	messageBrokerAdapterInstance *messageBrokerAdapter
+)
+
+// BrokerMessage 는 메시지 브로커로 발행하거나 받은 메시지이다. NATS 는 Topic 을 subject 로 사용하고 Key 는 무시한다.
+type BrokerMessage struct {
+	Topic   string
+	Key     string
+	Value   []byte
+	Headers map[string]string
+	// 받은 메시지의 Kafka 파티션과 offset 이다. offset 을 관리하지 않는 브로커(NATS)는 Offset 이 -1 이다.
+	Partition int32
+	Offset    int64
+}
+
+// MessagePublisher 는 메시지를 브로커로 발행한다. 설정(MessageBroker.Broker)에 없는 브로커는 구현하여 SetPublisher 로 등록한다.
+type MessagePublisher interface {
+	Publish(messages []BrokerMessage) error
+}
+
+// MessageSubscriber 는 stopped 가 닫힐 때까지 토픽의 메시지를 받아 하나씩 Handle 에 넘긴다. 연결이 끊기면 에러를 반환한다.
+// 설정(MessageBroker.Broker)에 없는 브로커는 구현하여 SetSubscriber 로 등록한다.
+type MessageSubscriber interface {
+	Subscribe(subscription Subscription, stopped <-chan struct{}) error
+}
+
+type Subscription struct {
+	Topic string
+	// Group 은 NATS 의 큐 그룹이다. 같은 그룹의 인스턴스 중 하나만 메시지를 받는다.
+	Group string
+	// LoadOffset 은 파티션에서 마지막으로 처리한 메시지의 다음 offset 을 찾는다. 없으면 최신 메시지부터 받는다.
+	LoadOffset func(topic string, partition int32) (int64, bool, error)
+	// Handle 이 에러를 반환하면 Kafka 는 그 메시지부터 다시 받는다.
+	Handle func(message BrokerMessage) error
+}
+
+func MessageBrokerAdapter() *messageBrokerAdapter {
+	messageBrokerAdapterOnce.Do(func() {
+		messageBrokerAdapterInstance = &messageBrokerAdapter{}
+	})
+
+	return messageBrokerAdapterInstance
+}
+
+type messageBrokerAdapter struct {
+	mutex      sync.RWMutex
+	publisher  MessagePublisher
+	subscriber MessageSubscriber
+}
+
+// SetPublisher 는 발행자를 교체한다. nil 이면 설정(MessageBroker.Broker)의 발행자를 사용한다.
+func (m *messageBrokerAdapter) SetPublisher(publisher MessagePublisher) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.publisher = publisher
+}
+
+// SetSubscriber 는 구독자를 교체한다. nil 이면 설정(MessageBroker.Broker)의 구독자를 사용한다.
+func (m *messageBrokerAdapter) SetSubscriber(subscriber MessageSubscriber) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.subscriber = subscriber
+}
+
+// IsEnabled 는 도메인 이벤트를 발행할 브로커가 있는지 확인한다.
+func (m *messageBrokerAdapter) IsEnabled() bool {
+	return m.getPublisher() != nil
+}
+
+// Publish 는 메시지를 순서대로 발행한다. 브로커가 없으면 아무것도 하지 않는다.
+func (m *messageBrokerAdapter) Publish(messages []BrokerMessage) error {
+	publisher := m.getPublisher()
+	if publisher == nil || len(messages) == 0 {
+		return nil
+	}
+
+	return publisher.Publish(messages)
+}
+
+// Subscribe 는 구독자로 토픽을 구독한다. 브로커가 없으면 에러를 반환한다.
+func (m *messageBrokerAdapter) Subscribe(subscription Subscription, stopped <-chan struct{}) error {
+	subscriber := m.getSubscriber()
+	if subscriber == nil {
+		return pkgerrors.New("message broker is not configured")
+	}
+
+	return subscriber.Subscribe(subscription, stopped)
+}
+
+func (m *messageBrokerAdapter) getPublisher() MessagePublisher {
+	m.mutex.RLock()
+	publisher := m.publisher
+	m.mutex.RUnlock()
+
+	if publisher != nil {
+		return publisher
+	}
+
+	brokerConfig := config.Config.MessageBroker
+	switch brokerConfig.Broker {
+	case constants.MessageBrokerKafka:
+		return KafkaMessagePublisher{
+			Producer: &KafkaProducer{Brokers: brokerConfig.Kafka.Brokers, ClientId: constants.DomainEventSource, Tls: brokerConfig.Kafka.Tls},
+		}
+	case constants.MessageBrokerNats:
+		return newConfiguredNatsClient()
+	}
+
+	return nil
+}
+
+func (m *messageBrokerAdapter) getSubscriber() MessageSubscriber {
+	m.mutex.RLock()
+	subscriber := m.subscriber
+	m.mutex.RUnlock()
+
+	if subscriber != nil {
+		return subscriber
+	}
+
+	brokerConfig := config.Config.MessageBroker
+	switch brokerConfig.Broker {
+	case constants.MessageBrokerKafka:
+		return KafkaConsumer{Brokers: brokerConfig.Kafka.Brokers, ClientId: constants.DomainEventSource, Tls: brokerConfig.Kafka.Tls}
+	case constants.MessageBrokerNats:
+		return newConfiguredNatsClient()
+	}
+
+	return nil
+}
+
+func newConfiguredNatsClient() NatsClient {
+	natsConfig := config.Config.MessageBroker.Nats
+	return NatsClient{
+		Url:      natsConfig.Url,
+		Token:    natsConfig.Token,
+		Username: natsConfig.Username,
+		Password: natsConfig.Password,
+	}
+}
+
+// KafkaMessagePublisher 는 토픽별로 메시지를 묶어 Kafka 로 보낸다.
+type KafkaMessagePublisher struct {
+	Producer *KafkaProducer
+}
+
+func (k KafkaMessagePublisher) Publish(messages []BrokerMessage) error {
+	topics := make([]string, 0)
+	topicMessages := map[string][]KafkaMessage{}
+	for _, message := range messages {
+		if _, ok := topicMessages[message.Topic]; !ok {
+			topics = append(topics, message.Topic)
+		}
+
+		kafkaMessage := KafkaMessage{Value: message.Value, Headers: message.Headers}
+		if len(message.Key) > 0 {
+			kafkaMessage.Key = []byte(message.Key)
+		}
+		topicMessages[message.Topic] = append(topicMessages[message.Topic], kafkaMessage)
+	}
+
+	for _, topic := range topics {
+		if err := k.Producer.Produce(topic, topicMessages[topic]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
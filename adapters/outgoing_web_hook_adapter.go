@@ -0,0 +1,81 @@
+package adapters
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"bytes"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"sync"
+)
+
+var (
+	outgoingWebHookAdapterOnce     sync.Once
+	outgoingWebHookAdapterInstance *outgoingWebHookAdapter
+)
+
+// OutgoingWebHookSender 는 외부 URL 로 웹훅 요청을 전송한다. 테스트 등에서 교체할 수 있다.
+type OutgoingWebHookSender interface {
+	Send(request dtos.OutgoingWebHookRequest) error
+}
+
+func OutgoingWebHookAdapter() *outgoingWebHookAdapter {
+	outgoingWebHookAdapterOnce.Do(func() {
+		outgoingWebHookAdapterInstance = &outgoingWebHookAdapter{}
+	})
+
+	return outgoingWebHookAdapterInstance
+}
+
+type outgoingWebHookAdapter struct {
+	mutex  sync.RWMutex
+	sender OutgoingWebHookSender
+}
+
+func (o *outgoingWebHookAdapter) SetSender(sender OutgoingWebHookSender) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.sender = sender
+}
+
+func (o *outgoingWebHookAdapter) Send(request dtos.OutgoingWebHookRequest) error {
+	helpers.LoggingHelper().Debugf(constants.LoggingModuleWebHooks, "send web hook. url=%s, contentType=%s, bytes=%d", request.Url, request.ContentType, len(request.Body))
+
+	o.mutex.RLock()
+	sender := o.sender
+	o.mutex.RUnlock()
+
+	if sender != nil {
+		return sender.Send(request)
+	}
+
+	return httpWebHookSender{}.Send(request)
+}
+
+type httpWebHookSender struct {
+}
+
+func (httpWebHookSender) Send(request dtos.OutgoingWebHookRequest) error {
+	httpRequest, err := http.NewRequest(http.MethodPost, request.Url, bytes.NewReader(request.Body))
+	if err != nil {
+		return pkgerrors.Wrap(err, "web hook request error")
+	}
+
+	httpRequest.Header.Set("Content-Type", request.ContentType)
+	for key, value := range request.Headers {
+		httpRequest.Header.Set(key, value)
+	}
+
+	response, err := HttpClientAdapter().Client(constants.HttpClientWebHook).Do(httpRequest)
+	if err != nil {
+		return pkgerrors.Wrap(err, "web hook send error")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return pkgerrors.Errorf("web hook response status %d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/security"
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// range API 에 보내는 SHA-1 앞부분의 길이
+const passwordHashPrefixLength = 5
+
+var (
+	passwordBreachAdapterOnce     sync.Once
+	passwordBreachAdapterInstance *passwordBreachAdapter
+)
+
+// PasswordBreachAdapter 는 비밀번호가 유출된 비밀번호 목록에 있는지 확인한다.
+func PasswordBreachAdapter() *passwordBreachAdapter {
+	passwordBreachAdapterOnce.Do(func() {
+		passwordBreachAdapterInstance = &passwordBreachAdapter{}
+	})
+
+	return passwordBreachAdapterInstance
+}
+
+type passwordBreachAdapter struct {
+	mutex           sync.Mutex
+	bloomFilter     *security.PasswordBloomFilter
+	bloomFilterPath string
+}
+
+// Check 는 비밀번호가 유출된 횟수를 반환한다. offline 은 횟수를 알 수 없어 유출되었을 수 있으면 1 을 반환한다.
+func (p *passwordBreachAdapter) Check(ctx context.Context, mode, password string) (int, error) {
+	switch mode {
+	case constants.PasswordBreachCheckModeOnline:
+		return p.checkRange(ctx, password)
+	case constants.PasswordBreachCheckModeOffline:
+		bloomFilter, err := p.getBloomFilter()
+		if err != nil {
+			return 0, err
+		}
+
+		if bloomFilter.MayContain(password) {
+			return 1, nil
+		}
+		return 0, nil
+	}
+
+	return 0, nil
+}
+
+// checkRange 는 k-익명성 range API 로 확인한다. SHA-1 의 앞 5자리만 보내고 받은 목록에서 나머지를 찾는다.
+func (p *passwordBreachAdapter) checkRange(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:passwordHashPrefixLength], hash[passwordHashPrefixLength:]
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, config.Config.PasswordBreach.RangeApiUrl+prefix, nil)
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "password breach range request error")
+	}
+	// 응답 크기로 앞부분을 추측할 수 없도록 가짜 항목(횟수 0)을 섞어 달라고 요청한다.
+	request.Header.Set("Add-Padding", "true")
+	request.Header.Set("User-Agent", "better-admin-backend-service")
+
+	response, err := HttpClientAdapter().Client(constants.HttpClientPasswordBreach).Do(request)
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "password breach range request error")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, pkgerrors.Errorf("password breach range response status %d", response.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		hashSuffix, count, found := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !found || !strings.EqualFold(hashSuffix, suffix) {
+			continue
+		}
+
+		return strconv.Atoi(count)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return 0, pkgerrors.Wrap(err, "password breach range response read error")
+	}
+
+	return 0, nil
+}
+
+// getBloomFilter 는 설정(PasswordBreach.BloomFilterPath)의 bloom filter 를 처음 사용할 때 읽는다. 경로가 바뀌면 다시 읽는다.
+func (p *passwordBreachAdapter) getBloomFilter() (*security.PasswordBloomFilter, error) {
+	path := config.Config.PasswordBreach.BloomFilterPath
+	if len(path) == 0 {
+		return nil, pkgerrors.New("password bloom filter path is not configured")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.bloomFilter != nil && p.bloomFilterPath == path {
+		return p.bloomFilter, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "password bloom filter open error")
+	}
+	defer file.Close()
+
+	bloomFilter, err := security.ReadPasswordBloomFilter(bufio.NewReader(file))
+	if err != nil {
+		return nil, err
+	}
+
+	p.bloomFilter, p.bloomFilterPath = bloomFilter, path
+	return bloomFilter, nil
+}
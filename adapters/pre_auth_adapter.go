@@ -0,0 +1,121 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"bytes"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	preAuthAdapterOnce     sync.Once
+	preAuthAdapterInstance *preAuthAdapter
+)
+
+// PreAuthChecker 는 로그인 직전에 외부 시스템(예. 인사 정보, 협력사 명단)에 멤버의 로그인 허용 여부를 묻는다.
+// 설정(PreAuthHook.Url)의 웹훅 대신 사용할 구현은 SetChecker 로 등록한다.
+type PreAuthChecker interface {
+	Check(ctx context.Context, request dtos.PreAuthRequest) (dtos.PreAuthResult, error)
+}
+
+func PreAuthAdapter() *preAuthAdapter {
+	preAuthAdapterOnce.Do(func() {
+		preAuthAdapterInstance = &preAuthAdapter{}
+	})
+
+	return preAuthAdapterInstance
+}
+
+type preAuthAdapter struct {
+	mutex   sync.RWMutex
+	checker PreAuthChecker
+}
+
+// SetChecker 는 확인자를 교체한다. nil 이면 설정(PreAuthHook.Url)의 웹훅을 사용한다.
+func (p *preAuthAdapter) SetChecker(checker PreAuthChecker) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.checker = checker
+}
+
+// IsEnabled 는 로그인 허용 여부를 물을 확인자가 있는지 확인한다.
+func (p *preAuthAdapter) IsEnabled() bool {
+	return p.getChecker() != nil
+}
+
+// Check 는 확인자에게 로그인 허용 여부를 묻는다. 확인자가 없으면 허용한다.
+func (p *preAuthAdapter) Check(ctx context.Context, request dtos.PreAuthRequest) (dtos.PreAuthResult, error) {
+	checker := p.getChecker()
+	if checker == nil {
+		return dtos.PreAuthResult{Allow: true}, nil
+	}
+
+	return checker.Check(ctx, request)
+}
+
+func (p *preAuthAdapter) getChecker() PreAuthChecker {
+	p.mutex.RLock()
+	checker := p.checker
+	p.mutex.RUnlock()
+
+	if checker != nil {
+		return checker
+	}
+
+	hookConfig := config.Config.PreAuthHook
+	if len(hookConfig.Url) == 0 {
+		return nil
+	}
+
+	return HttpPreAuthChecker{Url: hookConfig.Url, ApiKey: hookConfig.ApiKey, Timeout: time.Duration(hookConfig.TimeoutSeconds) * time.Second}
+}
+
+// HttpPreAuthChecker 는 웹훅 URL 에 멤버 정보를 JSON 으로 POST 하고 {"allow": bool, "reason": string, "claims": object} 응답을 받는다.
+type HttpPreAuthChecker struct {
+	Url     string
+	ApiKey  string
+	Timeout time.Duration
+}
+
+func (h HttpPreAuthChecker) Check(ctx context.Context, preAuthRequest dtos.PreAuthRequest) (dtos.PreAuthResult, error) {
+	body, err := json.Marshal(preAuthRequest)
+	if err != nil {
+		return dtos.PreAuthResult{}, pkgerrors.Wrap(err, "pre auth request error")
+	}
+
+	// 검사기마다 설정한 제한 시간을 요청에 적용한다.
+	ctx, cancel := newTimeoutContext(ctx, h.Timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, h.Url, bytes.NewReader(body))
+	if err != nil {
+		return dtos.PreAuthResult{}, pkgerrors.Wrap(err, "pre auth request error")
+	}
+	request.Header.Set("Content-Type", "application/json")
+	if len(h.ApiKey) > 0 {
+		request.Header.Set("X-Api-Key", h.ApiKey)
+	}
+
+	response, err := HttpClientAdapter().Client(constants.HttpClientPreAuth).Do(request)
+	if err != nil {
+		return dtos.PreAuthResult{}, pkgerrors.Wrap(err, "pre auth request error")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return dtos.PreAuthResult{}, pkgerrors.Errorf("pre auth response status %d", response.StatusCode)
+	}
+
+	var result dtos.PreAuthResult
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return dtos.PreAuthResult{}, pkgerrors.Wrap(err, "pre auth response error")
+	}
+
+	return result, nil
+}
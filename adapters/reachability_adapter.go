@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"github.com/pkg/errors"
+	"net"
+	"net/url"
+	"time"
+)
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+	"ldap":  "389",
+	"ldaps": "636",
+}
+
+type ReachabilityAdapter struct {
+}
+
+// Check 는 주소(rawUrl)의 host 에 TCP 로 연결할 수 있는지 확인한다.
+func (ReachabilityAdapter) Check(rawUrl string, timeout time.Duration) error {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || len(parsedUrl.Hostname()) == 0 {
+		return errors.Errorf("invalid url %q", rawUrl)
+	}
+
+	port := parsedUrl.Port()
+	if len(port) == 0 {
+		port = defaultPorts[parsedUrl.Scheme]
+	}
+	if len(port) == 0 {
+		return errors.Errorf("unknown port of url %q", rawUrl)
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(parsedUrl.Hostname(), port), timeout)
+	if err != nil {
+		return errors.Wrapf(err, "can't connect to %v", parsedUrl.Host)
+	}
+
+	return conn.Close()
+}
@@ -0,0 +1,271 @@
+package adapters
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	pkgerrors "github.com/pkg/errors"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	redisMaxIdleConnections = 8
+	redisMaxBulkLength      = 64 * 1024 * 1024
+)
+
+// RedisError 는 Redis 서버가 -ERR 로 거절한 응답이다. 연결은 계속 사용할 수 있다.
+type RedisError string
+
+func (e RedisError) Error() string {
+	return "redis error: " + string(e)
+}
+
+// RedisClient 는 외부 라이브러리 없이 RESP2 프로토콜의 명령 실행과 SUBSCRIBE 만 구현한 최소한의 클라이언트이다.
+// 명령을 실행한 연결은 다시 사용하도록 최대 redisMaxIdleConnections 개까지 남겨 둔다.
+type RedisClient struct {
+	// Url 은 redis://[:password@]host:port/db 또는 TLS 를 사용하는 rediss://host:port/db 이다.
+	Url      string
+	Password string
+	Timeout  time.Duration
+
+	mutex sync.Mutex
+	idle  []*redisConnection
+}
+
+type redisConnection struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewRedisClient(url, password string, timeout time.Duration) *RedisClient {
+	return &RedisClient{Url: url, Password: password, Timeout: timeout}
+}
+
+// Do 는 명령을 실행하고 응답을 반환한다. 응답은 string, int64, []byte, []interface{} 또는 nil 이다.
+func (r *RedisClient) Do(args ...string) (interface{}, error) {
+	connection, err := r.getConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	connection.conn.SetDeadline(time.Now().Add(r.getTimeout()))
+	reply, err := connection.execute(args...)
+	if err != nil {
+		if _, ok := err.(RedisError); !ok {
+			connection.conn.Close()
+			return nil, err
+		}
+	}
+
+	r.putConnection(connection)
+	return reply, err
+}
+
+// Subscribe 는 stopped 가 닫힐 때까지 channel 을 구독한다. 연결이 끊기는 동안 발행된 메시지는 받지 못한다.
+func (r *RedisClient) Subscribe(channel string, stopped <-chan struct{}, handle func(message []byte)) error {
+	connection, err := r.connect()
+	if err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-stopped:
+		case <-done:
+		}
+		connection.conn.Close()
+	}()
+
+	connection.conn.SetDeadline(time.Now().Add(r.getTimeout()))
+	if _, err := connection.execute("SUBSCRIBE", channel); err != nil {
+		return err
+	}
+
+	// 구독한 뒤에는 메시지가 올 때까지 기다리므로 읽기 제한 시간을 두지 않는다.
+	connection.conn.SetDeadline(time.Time{})
+	for {
+		reply, err := readRedisReply(connection.reader)
+		if err != nil {
+			select {
+			case <-stopped:
+				return nil
+			default:
+				return err
+			}
+		}
+
+		// 메시지는 ["message", channel, payload] 이다.
+		values, ok := reply.([]interface{})
+		if !ok || len(values) != 3 {
+			continue
+		}
+		if kind, _ := values[0].([]byte); string(kind) != "message" {
+			continue
+		}
+		if payload, ok := values[2].([]byte); ok {
+			handle(payload)
+		}
+	}
+}
+
+func (r *RedisClient) getConnection() (*redisConnection, error) {
+	r.mutex.Lock()
+	if count := len(r.idle); count > 0 {
+		connection := r.idle[count-1]
+		r.idle = r.idle[:count-1]
+		r.mutex.Unlock()
+		return connection, nil
+	}
+	r.mutex.Unlock()
+
+	return r.connect()
+}
+
+func (r *RedisClient) putConnection(connection *redisConnection) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.idle) >= redisMaxIdleConnections {
+		connection.conn.Close()
+		return
+	}
+	r.idle = append(r.idle, connection)
+}
+
+// connect 는 연결하고 AUTH, SELECT 를 실행한다.
+func (r *RedisClient) connect() (*redisConnection, error) {
+	serverUrl, err := url.Parse(r.Url)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "redis url error")
+	}
+
+	address := serverUrl.Host
+	if len(serverUrl.Port()) == 0 {
+		address = net.JoinHostPort(serverUrl.Hostname(), "6379")
+	}
+
+	conn, err := net.DialTimeout("tcp", address, r.getTimeout())
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "redis connect error")
+	}
+	if serverUrl.Scheme == "rediss" {
+		conn = tls.Client(conn, &tls.Config{ServerName: serverUrl.Hostname()})
+	}
+
+	connection := &redisConnection{conn: conn, reader: bufio.NewReaderSize(conn, 4096)}
+	conn.SetDeadline(time.Now().Add(r.getTimeout()))
+
+	password := r.Password
+	if serverUrl.User != nil {
+		if urlPassword, ok := serverUrl.User.Password(); ok {
+			password = urlPassword
+		}
+	}
+	if len(password) > 0 {
+		if _, err := connection.execute("AUTH", password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if database := strings.Trim(serverUrl.Path, "/"); len(database) > 0 && database != "0" {
+		if _, err := connection.execute("SELECT", database); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return connection, nil
+}
+
+func (r *RedisClient) getTimeout() time.Duration {
+	if r.Timeout <= 0 {
+		return 3 * time.Second
+	}
+	return r.Timeout
+}
+
+// execute 는 명령을 *<인자 수> 다음에 $<길이> 로 시작하는 인자를 쓰고 응답을 읽는다.
+func (c *redisConnection) execute(args ...string) (interface{}, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		buffer.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n")
+		buffer.WriteString(arg)
+		buffer.WriteString("\r\n")
+	}
+
+	if _, err := c.conn.Write(buffer.Bytes()); err != nil {
+		return nil, pkgerrors.Wrap(err, "redis write error")
+	}
+
+	return readRedisReply(c.reader)
+}
+
+func readRedisReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "redis read error")
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if len(line) == 0 {
+		return nil, pkgerrors.New("redis invalid reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, RedisError(line[1:])
+	case ':':
+		value, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, pkgerrors.Errorf("redis invalid integer: %s", line)
+		}
+		return value, nil
+	case '$':
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length > redisMaxBulkLength {
+			return nil, pkgerrors.Errorf("redis invalid bulk length: %s", line)
+		}
+		if length < 0 {
+			return nil, nil
+		}
+
+		content := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, pkgerrors.Wrap(err, "redis read error")
+		}
+		return content[:length], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, pkgerrors.Errorf("redis invalid array length: %s", line)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+
+		values := make([]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			value, err := readRedisReply(reader)
+			if err != nil {
+				if _, ok := err.(RedisError); !ok {
+					return nil, err
+				}
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	default:
+		return nil, pkgerrors.Errorf("redis invalid reply: %s", line)
+	}
+}
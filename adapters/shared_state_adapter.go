@@ -0,0 +1,299 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	sharedStateAdapterOnce     sync.Once
+	sharedStateAdapterInstance *sharedStateAdapter
+)
+
+// SharedStore 는 여러 인스턴스(replica)가 함께 사용하는 상태(캐시, 잠금, 인스턴스 사이의 알림) 저장소이다.
+type SharedStore interface {
+	// Get 은 key 의 값을 조회한다. 없거나 만료되었으면 false 이다.
+	Get(key string) (string, bool, error)
+	// Set 은 key 에 값을 저장한다. ttl 이 0 이면 만료하지 않는다.
+	Set(key, value string, ttl time.Duration) error
+	Delete(key string) error
+	// AcquireLock 은 key 가 잠겨 있지 않을 때만 owner 로 ttl 동안 잠근다.
+	AcquireLock(key, owner string, ttl time.Duration) (bool, error)
+	// RenewLock 은 owner 가 잠근 key 의 만료 시간을 ttl 뒤로 늘린다. 만료되었거나 다른 owner 가 잠갔으면 false 이다.
+	RenewLock(key, owner string, ttl time.Duration) (bool, error)
+	// ReleaseLock 은 owner 가 잠근 key 를 푼다. 다른 owner 의 잠금은 풀지 않는다.
+	ReleaseLock(key, owner string) error
+	Publish(channel string, message []byte) error
+	// Subscribe 는 stopped 가 닫힐 때까지 channel 의 메시지를 받는다.
+	Subscribe(channel string, stopped <-chan struct{}, handle func(message []byte)) error
+}
+
+// SharedStateAdapter 는 설정(SharedState.Backend)의 공유 상태 저장소를 제공한다.
+// memory 는 인스턴스 하나에서만 공유되므로 여러 인스턴스로 실행할 때는 redis 를 사용한다.
+func SharedStateAdapter() *sharedStateAdapter {
+	sharedStateAdapterOnce.Do(func() {
+		sharedStateAdapterInstance = &sharedStateAdapter{memory: NewMemorySharedStore()}
+	})
+
+	return sharedStateAdapterInstance
+}
+
+type sharedStateAdapter struct {
+	mutex  sync.RWMutex
+	store  SharedStore
+	memory SharedStore
+	redis  *redisSharedStore
+}
+
+// SetStore 는 저장소를 교체한다. nil 이면 설정(SharedState.Backend)의 저장소를 사용한다.
+func (s *sharedStateAdapter) SetStore(store SharedStore) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.store = store
+}
+
+// IsShared 는 저장소가 여러 인스턴스에서 공유되는지 확인한다.
+func (s *sharedStateAdapter) IsShared() bool {
+	_, ok := s.Store().(*memorySharedStore)
+	return !ok
+}
+
+func (s *sharedStateAdapter) Store() SharedStore {
+	s.mutex.RLock()
+	store := s.store
+	s.mutex.RUnlock()
+
+	if store != nil {
+		return store
+	}
+
+	stateConfig := config.Config.SharedState
+	if stateConfig.Backend != constants.SharedStateBackendRedis {
+		return s.memory
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.redis == nil || s.redis.client.Url != stateConfig.RedisUrl {
+		s.redis = &redisSharedStore{
+			client:    NewRedisClient(stateConfig.RedisUrl, stateConfig.RedisPassword, time.Duration(stateConfig.TimeoutSeconds)*time.Second),
+			keyPrefix: stateConfig.KeyPrefix,
+		}
+	}
+	return s.redis
+}
+
+// redisSharedStore 는 모든 key 와 channel 앞에 keyPrefix 를 붙여 다른 서비스와 같은 Redis 를 사용할 수 있게 한다.
+type redisSharedStore struct {
+	client    *RedisClient
+	keyPrefix string
+}
+
+// 잠금을 확인하고 바꾸는 사이에 다른 owner 가 잠글 수 있으므로 스크립트로 한 번에 실행한다.
+const (
+	redisRenewLockScript   = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("pexpire", KEYS[1], ARGV[2]) else return 0 end`
+	redisReleaseLockScript = `if redis.call("get", KEYS[1]) == ARGV[1] then return redis.call("del", KEYS[1]) else return 0 end`
+)
+
+func (r *redisSharedStore) Get(key string) (string, bool, error) {
+	reply, err := r.client.Do("GET", r.keyPrefix+key)
+	if err != nil {
+		return "", false, err
+	}
+
+	value, ok := reply.([]byte)
+	return string(value), ok, nil
+}
+
+func (r *redisSharedStore) Set(key, value string, ttl time.Duration) error {
+	args := []string{"SET", r.keyPrefix + key, value}
+	if ttl > 0 {
+		args = append(args, "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	}
+
+	_, err := r.client.Do(args...)
+	return err
+}
+
+func (r *redisSharedStore) Delete(key string) error {
+	_, err := r.client.Do("DEL", r.keyPrefix+key)
+	return err
+}
+
+func (r *redisSharedStore) AcquireLock(key, owner string, ttl time.Duration) (bool, error) {
+	reply, err := r.client.Do("SET", r.keyPrefix+key, owner, "NX", "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+
+	return reply == "OK", nil
+}
+
+func (r *redisSharedStore) RenewLock(key, owner string, ttl time.Duration) (bool, error) {
+	reply, err := r.client.Do("EVAL", redisRenewLockScript, "1", r.keyPrefix+key, owner, strconv.FormatInt(ttl.Milliseconds(), 10))
+	if err != nil {
+		return false, err
+	}
+
+	return reply == int64(1), nil
+}
+
+func (r *redisSharedStore) ReleaseLock(key, owner string) error {
+	_, err := r.client.Do("EVAL", redisReleaseLockScript, "1", r.keyPrefix+key, owner)
+	return err
+}
+
+func (r *redisSharedStore) Publish(channel string, message []byte) error {
+	_, err := r.client.Do("PUBLISH", r.keyPrefix+channel, string(message))
+	return err
+}
+
+func (r *redisSharedStore) Subscribe(channel string, stopped <-chan struct{}, handle func(message []byte)) error {
+	return r.client.Subscribe(r.keyPrefix+channel, stopped, handle)
+}
+
+// memorySharedStore 는 인스턴스 하나의 메모리에 저장한다. 인스턴스를 하나만 실행하거나 테스트할 때 사용한다.
+type memorySharedStore struct {
+	mutex       sync.Mutex
+	values      map[string]memorySharedValue
+	subscribers map[string]map[int]func(message []byte)
+	nextId      int
+	sweptAt     time.Time
+}
+
+// 한 번도 다시 조회하지 않은 만료된 값(예. 응답 캐시)도 지우도록 저장할 때 이 주기마다 만료된 값을 모두 지운다.
+const memorySharedStoreSweepInterval = time.Minute
+
+type memorySharedValue struct {
+	value     string
+	expiresAt time.Time
+}
+
+func NewMemorySharedStore() *memorySharedStore {
+	return &memorySharedStore{
+		values:      map[string]memorySharedValue{},
+		subscribers: map[string]map[int]func(message []byte){},
+	}
+}
+
+func (m *memorySharedStore) Get(key string) (string, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	value, ok := m.get(key)
+	return value.value, ok, nil
+}
+
+func (m *memorySharedStore) Set(key, value string, ttl time.Duration) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.set(key, value, ttl)
+	return nil
+}
+
+func (m *memorySharedStore) Delete(key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.values, key)
+	return nil
+}
+
+func (m *memorySharedStore) AcquireLock(key, owner string, ttl time.Duration) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.get(key); ok {
+		return false, nil
+	}
+	m.set(key, owner, ttl)
+	return true, nil
+}
+
+func (m *memorySharedStore) RenewLock(key, owner string, ttl time.Duration) (bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if value, ok := m.get(key); !ok || value.value != owner {
+		return false, nil
+	}
+	m.set(key, owner, ttl)
+	return true, nil
+}
+
+func (m *memorySharedStore) ReleaseLock(key, owner string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if value, ok := m.get(key); ok && value.value == owner {
+		delete(m.values, key)
+	}
+	return nil
+}
+
+// Publish 는 구독자를 바로 호출한다.
+func (m *memorySharedStore) Publish(channel string, message []byte) error {
+	m.mutex.Lock()
+	handlers := make([]func(message []byte), 0, len(m.subscribers[channel]))
+	for _, handler := range m.subscribers[channel] {
+		handlers = append(handlers, handler)
+	}
+	m.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(message)
+	}
+	return nil
+}
+
+func (m *memorySharedStore) Subscribe(channel string, stopped <-chan struct{}, handle func(message []byte)) error {
+	m.mutex.Lock()
+	m.nextId++
+	id := m.nextId
+	if m.subscribers[channel] == nil {
+		m.subscribers[channel] = map[int]func(message []byte){}
+	}
+	m.subscribers[channel][id] = handle
+	m.mutex.Unlock()
+
+	<-stopped
+
+	m.mutex.Lock()
+	delete(m.subscribers[channel], id)
+	m.mutex.Unlock()
+	return nil
+}
+
+func (m *memorySharedStore) get(key string) (memorySharedValue, bool) {
+	value, ok := m.values[key]
+	if !ok {
+		return memorySharedValue{}, false
+	}
+
+	if !value.expiresAt.IsZero() && !time.Now().Before(value.expiresAt) {
+		delete(m.values, key)
+		return memorySharedValue{}, false
+	}
+	return value, true
+}
+
+func (m *memorySharedStore) set(key, value string, ttl time.Duration) {
+	if now := time.Now(); now.Sub(m.sweptAt) >= memorySharedStoreSweepInterval {
+		m.sweptAt = now
+		for expiredKey, expiredValue := range m.values {
+			if !expiredValue.expiresAt.IsZero() && !now.Before(expiredValue.expiresAt) {
+				delete(m.values, expiredKey)
+			}
+		}
+	}
+
+	entry := memorySharedValue{value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	m.values[key] = entry
+}
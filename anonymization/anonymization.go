@@ -0,0 +1,258 @@
+package anonymization
+
+import (
+	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"sort"
+)
+
+// 한 번에 읽고 바꾸는 행의 수
+const batchSize = 500
+
+// CreateSnapshot 은 실행 중인 SQLite DB 의 사본(output)을 만들고 사본의 개인 정보를 익명화한다.
+// MySQL 은 백업을 staging DB 에 복원한 뒤 Anonymize 로 직접 익명화한다.
+func CreateSnapshot(gormDB *gorm.DB, output string) ([]dtos.AnonymizedTable, error) {
+	if db.GetDriver() != db.DriverSqlite {
+		return nil, pkgerrors.Errorf("anonymized snapshot requires sqlite, current database is %s", db.GetDriver())
+	}
+
+	if _, err := os.Stat(output); err == nil {
+		return nil, pkgerrors.Errorf("snapshot %s already exists", output)
+	}
+
+	if err := gormDB.Exec("VACUUM INTO ?", output).Error; err != nil {
+		return nil, pkgerrors.Wrap(err, "sqlite snapshot error")
+	}
+
+	tables, err := anonymizeSnapshot(output)
+	if err != nil {
+		// 익명화하지 못한 사본은 남기지 않는다.
+		os.Remove(output)
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+func anonymizeSnapshot(path string) ([]dtos.AnonymizedTable, error) {
+	snapshotDB, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "sqlite snapshot error")
+	}
+	sqlDB, err := snapshotDB.DB()
+	if err != nil {
+		return nil, err
+	}
+	defer sqlDB.Close()
+
+	return Anonymize(snapshotDB)
+}
+
+// Anonymize 는 설정(Anonymization.Rules)의 테이블별 컬럼 값을 익명화한다. 비어 있거나 NULL 인 값은 그대로 둔다.
+// 규칙의 테이블이나 컬럼이 없으면 개인 정보가 남지 않도록 아무것도 바꾸지 않고 실패한다.
+func Anonymize(gormDB *gorm.DB) ([]dtos.AnonymizedTable, error) {
+	rules := config.Config.Anonymization.Rules
+
+	tableNames := make([]string, 0, len(rules))
+	for table := range rules {
+		tableNames = append(tableNames, table)
+	}
+	sort.Strings(tableNames)
+
+	tables := make([]dtos.AnonymizedTable, 0, len(tableNames))
+	for _, tableName := range tableNames {
+		columns := make([]string, 0, len(rules[tableName]))
+		for column, method := range rules[tableName] {
+			if !isSupportedMethod(method) {
+				return nil, pkgerrors.Errorf("anonymization method %q of %s.%s is not supported", method, tableName, column)
+			}
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		if err := checkColumns(gormDB, tableName, columns); err != nil {
+			return nil, err
+		}
+		tables = append(tables, dtos.AnonymizedTable{Table: tableName, Columns: columns})
+	}
+
+	secret, err := secret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = gormDB.Transaction(func(tx *gorm.DB) error {
+		for i := range tables {
+			rows, err := anonymizeTable(tx, secret, tables[i].Table, tables[i].Columns, rules[tables[i].Table])
+			if err != nil {
+				return err
+			}
+			tables[i].Rows = rows
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tables, nil
+}
+
+// CheckLegalHolds 는 법적 보존 중인 멤버가 있으면 실패한다. 보존 중인 멤버의 데이터는 DB 를 직접 익명화하여 바꾸지 않는다.
+// 사본을 익명화하는 CreateSnapshot 은 원본의 데이터를 바꾸지 않으므로 확인하지 않는다.
+func CheckLegalHolds(gormDB *gorm.DB) error {
+	if !gormDB.Migrator().HasTable("legal_holds") {
+		return nil
+	}
+
+	var memberIds []uint
+	if err := gormDB.Table("legal_holds").Where("member_id > 0 AND deleted_at IS NULL").
+		Distinct("member_id").Order("member_id").Pluck("member_id", &memberIds).Error; err != nil {
+		return pkgerrors.Wrap(err, "legal hold read error")
+	}
+
+	if len(memberIds) > 0 {
+		return pkgerrors.Errorf("members %v are on legal hold, release the holds before anonymization", memberIds)
+	}
+
+	return nil
+}
+
+// Value 는 method 로 익명화한 값이다. 같은 값은 항상 같은 가명이 된다.
+func Value(secret []byte, method string, value string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(value))
+	hash := mac.Sum(nil)
+
+	switch method {
+	case constants.AnonymizationMethodPseudonym:
+		return "anon-" + hex.EncodeToString(hash[:5])
+	case constants.AnonymizationMethodEmail:
+		return "anon-" + hex.EncodeToString(hash[:5]) + "@example.com"
+	case constants.AnonymizationMethodPhone:
+		number := binary.BigEndian.Uint32(hash[:4]) % 100000000
+		return fmt.Sprintf("010-%04d-%04d", number/10000, number%10000)
+	case constants.AnonymizationMethodIpAddress:
+		return fmt.Sprintf("10.%d.%d.%d", hash[0], hash[1], hash[2])
+	}
+
+	return ""
+}
+
+func anonymizeTable(tx *gorm.DB, secret []byte, table string, columns []string, methods map[string]string) (int64, error) {
+	var anonymized int64
+	var lastId uint64
+	for {
+		rows, err := tx.Table(table).Select(append([]string{"id"}, columns...)).
+			Where("id > ?", lastId).Order("id").Limit(batchSize).Rows()
+		if err != nil {
+			return 0, pkgerrors.Wrapf(err, "%s read error", table)
+		}
+
+		updates := make(map[uint64]map[string]interface{})
+		ids := make([]uint64, 0, batchSize)
+		for rows.Next() {
+			var id uint64
+			values := make([]sql.NullString, len(columns))
+			dest := []interface{}{&id}
+			for i := range values {
+				dest = append(dest, &values[i])
+			}
+			if err := rows.Scan(dest...); err != nil {
+				rows.Close()
+				return 0, pkgerrors.Wrapf(err, "%s read error", table)
+			}
+
+			ids = append(ids, id)
+			update := map[string]interface{}{}
+			for i, column := range columns {
+				if values[i].Valid && len(values[i].String) > 0 {
+					update[column] = Value(secret, methods[column], values[i].String)
+				}
+			}
+			if len(update) > 0 {
+				updates[id] = update
+			}
+		}
+		if err := rows.Err(); err != nil {
+			rows.Close()
+			return 0, pkgerrors.Wrapf(err, "%s read error", table)
+		}
+		rows.Close()
+
+		for _, id := range ids {
+			update, ok := updates[id]
+			if !ok {
+				continue
+			}
+			if err := tx.Table(table).Where("id = ?", id).UpdateColumns(update).Error; err != nil {
+				return 0, pkgerrors.Wrapf(err, "%s update error", table)
+			}
+			anonymized++
+		}
+
+		if len(ids) < batchSize {
+			return anonymized, nil
+		}
+		lastId = ids[len(ids)-1]
+	}
+}
+
+// checkColumns 는 테이블과 컬럼이 있는지 행을 읽지 않는 조회로 확인한다.
+func checkColumns(gormDB *gorm.DB, table string, columns []string) error {
+	if !gormDB.Migrator().HasTable(table) {
+		return pkgerrors.Errorf("anonymization table %s does not exist", table)
+	}
+
+	for _, column := range append([]string{"id"}, columns...) {
+		rows, err := gormDB.Table(table).Select(column).Limit(0).Rows()
+		if err != nil {
+			return pkgerrors.Errorf("anonymization column %s.%s does not exist", table, column)
+		}
+		rows.Close()
+	}
+
+	return nil
+}
+
+func isSupportedMethod(method string) bool {
+	switch method {
+	case constants.AnonymizationMethodPseudonym, constants.AnonymizationMethodEmail, constants.AnonymizationMethodPhone,
+		constants.AnonymizationMethodIpAddress, constants.AnonymizationMethodClear:
+		return true
+	}
+
+	return false
+}
+
+// secret 은 SecretProvider 에서 조회한 익명화 Secret 이다. 비어 있으면 JWT Secret 을 사용한다.
+func secret() ([]byte, error) {
+	anonymizationSecret, err := config.GetSecret(config.SecretAnonymization)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "get anonymization secret error")
+	}
+
+	if len(anonymizationSecret) > 0 {
+		return []byte(anonymizationSecret), nil
+	}
+
+	jwtSecret, err := config.GetJwtSecret()
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "get jwt secret error")
+	}
+
+	return []byte(jwtSecret), nil
+}
@@ -0,0 +1,141 @@
+package anonymization
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setUpAnonymizationTest(t *testing.T) *gorm.DB {
+	anonymizationConfig := config.Config.Anonymization
+	t.Cleanup(func() { config.Config.Anonymization = anonymizationConfig })
+	config.Config.Anonymization.Secret = "anonymization-test"
+	config.Config.Anonymization.Rules = map[string]map[string]string{
+		"members":        {"sign_id": "pseudonym", "email": "email", "phone": "phone", "password": "clear"},
+		"login_attempts": {"ip_address": "ip-address", "sign_id": "pseudonym"},
+	}
+
+	gormDB, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "source.db")), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, gormDB.Exec("CREATE TABLE members (id INTEGER PRIMARY KEY, sign_id TEXT, email TEXT, phone TEXT, password TEXT, status TEXT)").Error)
+	assert.NoError(t, gormDB.Exec("CREATE TABLE login_attempts (id INTEGER PRIMARY KEY, sign_id TEXT, ip_address TEXT)").Error)
+	assert.NoError(t, gormDB.Exec(`INSERT INTO members(sign_id, email, phone, password, status) VALUES
+		('ymyoo', 'ymyoo@example.org', '010-1234-5678', '$2a$10$hash', 'approved'),
+		('dooray', NULL, '', '$2a$10$hash', 'approved')`).Error)
+	assert.NoError(t, gormDB.Exec(`INSERT INTO login_attempts(sign_id, ip_address) VALUES ('ymyoo', '203.0.113.7'), ('ymyoo', '203.0.113.7')`).Error)
+
+	return gormDB
+}
+
+func TestAnonymize(t *testing.T) {
+	// given
+	gormDB := setUpAnonymizationTest(t)
+
+	// when
+	tables, err := Anonymize(gormDB)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+	assert.Equal(t, "login_attempts", tables[0].Table)
+	assert.Equal(t, int64(2), tables[0].Rows)
+	assert.Equal(t, []string{"email", "password", "phone", "sign_id"}, tables[1].Columns)
+
+	type member struct {
+		SignId   string
+		Email    *string
+		Phone    string
+		Password string
+		Status   string
+	}
+	var members []member
+	gormDB.Raw("SELECT sign_id, email, phone, password, status FROM members ORDER BY id").Scan(&members)
+	secret := []byte("anonymization-test")
+	assert.Equal(t, Value(secret, constants.AnonymizationMethodPseudonym, "ymyoo"), members[0].SignId)
+	assert.Regexp(t, `^anon-[0-9a-f]{10}@example\.com$`, *members[0].Email)
+	assert.Regexp(t, `^010-\d{4}-\d{4}$`, members[0].Phone)
+	assert.Empty(t, members[0].Password)
+	assert.Equal(t, "approved", members[0].Status)
+	// 비어 있거나 NULL 인 값은 그대로 둔다.
+	assert.Nil(t, members[1].Email)
+	assert.Empty(t, members[1].Phone)
+
+	// 같은 값은 다른 테이블에서도 같은 가명이다.
+	var attempts []struct {
+		SignId    string
+		IpAddress string
+	}
+	gormDB.Raw("SELECT sign_id, ip_address FROM login_attempts ORDER BY id").Scan(&attempts)
+	assert.Equal(t, members[0].SignId, attempts[0].SignId)
+	assert.Equal(t, attempts[0].IpAddress, attempts[1].IpAddress)
+	assert.Regexp(t, `^10\.\d+\.\d+\.\d+$`, attempts[0].IpAddress)
+}
+
+func TestAnonymize_없는_컬럼(t *testing.T) {
+	// given
+	gormDB := setUpAnonymizationTest(t)
+	config.Config.Anonymization.Rules["members"]["address"] = "clear"
+
+	// when
+	_, err := Anonymize(gormDB)
+
+	// then
+	assert.ErrorContains(t, err, "members.address does not exist")
+	var signId string
+	gormDB.Raw("SELECT sign_id FROM members WHERE id = 1").Scan(&signId)
+	assert.Equal(t, "ymyoo", signId)
+
+	config.Config.Anonymization.Rules["members"] = map[string]string{"sign_id": "shuffle"}
+	_, err = Anonymize(gormDB)
+	assert.ErrorContains(t, err, `"shuffle"`)
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	// given
+	gormDB := setUpAnonymizationTest(t)
+	output := filepath.Join(t.TempDir(), "staging.db")
+
+	// when
+	tables, err := CreateSnapshot(gormDB, output)
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, tables, 2)
+
+	snapshotDB, err := gorm.Open(sqlite.Open(output), &gorm.Config{})
+	assert.NoError(t, err)
+	var signId string
+	snapshotDB.Raw("SELECT sign_id FROM members WHERE id = 1").Scan(&signId)
+	assert.Equal(t, Value([]byte("anonymization-test"), constants.AnonymizationMethodPseudonym, "ymyoo"), signId)
+
+	// 원본 DB 는 바꾸지 않는다.
+	gormDB.Raw("SELECT sign_id FROM members WHERE id = 1").Scan(&signId)
+	assert.Equal(t, "ymyoo", signId)
+
+	// 이미 있는 파일은 덮어쓰지 않는다.
+	_, err = CreateSnapshot(gormDB, output)
+	assert.ErrorContains(t, err, "already exists")
+	_, err = os.Stat(output)
+	assert.NoError(t, err)
+}
+
+func TestCheckLegalHolds(t *testing.T) {
+	// given
+	gormDB := setUpAnonymizationTest(t)
+	assert.NoError(t, CheckLegalHolds(gormDB))
+	assert.NoError(t, gormDB.Exec("CREATE TABLE legal_holds (id INTEGER PRIMARY KEY, member_id INTEGER, deleted_at DATETIME)").Error)
+	assert.NoError(t, gormDB.Exec("INSERT INTO legal_holds(member_id, deleted_at) VALUES (0, NULL), (2, CURRENT_TIMESTAMP)").Error)
+	assert.NoError(t, CheckLegalHolds(gormDB))
+
+	// when
+	assert.NoError(t, gormDB.Exec("INSERT INTO legal_holds(member_id, deleted_at) VALUES (1, NULL)").Error)
+	err := CheckLegalHolds(gormDB)
+
+	// then
+	assert.ErrorContains(t, err, "members [1] are on legal hold")
+}
@@ -3,13 +3,23 @@ package app
 import (
 	"better-admin-backend-service/app/db"
 	"better-admin-backend-service/app/routes"
+	"better-admin-backend-service/errors"
 	"better-admin-backend-service/http/ws"
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout 은 종료 신호를 받은 뒤 처리 중인 요청을 기다리는 최대 시간이다.
+const shutdownTimeout = 30 * time.Second
+
 type App struct {
 	gormDB            *gorm.DB
 	webSocketUpgrader websocket.Upgrader
@@ -36,28 +46,79 @@ func (a *App) SetUp() error {
 	}
 	a.gormDB = db
 
+	if err := a.registerChaosCallbacks(); err != nil {
+		return err
+	}
+
 	if err := a.migrateDatabase(); err != nil {
 		return err
 	}
 
+	if err := a.loadSecuritySettings(); err != nil {
+		return err
+	}
+	a.registerClusterHandlers()
+
+	if err := a.setUpTrustedProxies(); err != nil {
+		return err
+	}
+
+	if err := a.runSelfCheck(); err != nil {
+		return err
+	}
+
 	a.gin.GET("/ws/:id", ws.WebSocketHandler(a.webSocketUpgrader))
 
 	a.addGinMiddlewares()
 
-	a.router.MapRoutes(a.gin.Group("/api"))
+	routerGroup := a.gin.Group("/api")
+	a.router.MapRoutes(routerGroup)
+	if err := checkRouteClassification(routerGroup.BasePath()); err != nil {
+		return err
+	}
+	a.setUpFrontend()
 	return nil
 }
 
 func (a *App) Run() error {
-	a.SetUp()
+	if err := a.SetUp(); err != nil {
+		return err
+	}
 	sqlDB, err := a.gormDB.DB()
 	if err != nil {
 		return err
 	}
 	defer sqlDB.Close()
 
-	a.gin.Run(":2016")
-	return nil
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	stopWorkers := startWorkers(a.gormDB)
+
+	server := &http.Server{Addr: ":2016", Handler: a.gin}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		stopWorkers()
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	// 종료 신호를 받으면 처리 중인 요청을 마친 뒤 워커를 멈춘다.
+	log.Info(">>> Shutdown server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	err = server.Shutdown(shutdownCtx)
+	stopWorkers()
+	return err
 }
 
 func (a App) GetGin() *gin.Engine {
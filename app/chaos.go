@@ -0,0 +1,35 @@
+package app
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"gorm.io/gorm"
+)
+
+// registerChaosCallbacks 는 장애 주입(middlewares.Chaos) 규칙에 database 가 있는 요청의 DB 조회, 변경을 실패시킨다.
+func (a *App) registerChaosCallbacks() error {
+	callback := a.gormDB.Callback()
+	registers := []func(name string, fn func(*gorm.DB)) error{
+		callback.Create().Before("gorm:create").Register,
+		callback.Query().Before("gorm:query").Register,
+		callback.Update().Before("gorm:update").Register,
+		callback.Delete().Before("gorm:delete").Register,
+		callback.Row().Before("gorm:row").Register,
+		callback.Raw().Before("gorm:raw").Register,
+	}
+
+	for _, register := range registers {
+		if err := register("chaos:database", injectDatabaseFault); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func injectDatabaseFault(db *gorm.DB) {
+	if db.Statement.Context != nil && helpers.ContextHelper().HasChaosFault(db.Statement.Context, constants.ChaosFaultDatabase) {
+		db.AddError(errors.ErrChaosInjected)
+	}
+}
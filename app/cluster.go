@@ -0,0 +1,24 @@
+package app
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/cluster"
+	"better-admin-backend-service/constants"
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+)
+
+// registerClusterHandlers 는 다른 인스턴스가 알린 변경을 이 인스턴스의 메모리 상태(보안 설정, 웹 소켓 연결)에 반영한다.
+func (a *App) registerClusterHandlers() {
+	cluster.Handle(constants.ClusterTopicSecuritySettings, func(json.RawMessage) {
+		if err := a.loadSecuritySettings(); err != nil {
+			log.Error("security settings reload error: ", err)
+		}
+	})
+
+	cluster.Handle(constants.ClusterTopicWebSocketBroadcast, func(payload json.RawMessage) {
+		if err := adapters.WebSocketAdapter().BroadcastMessage(payload); err != nil {
+			log.Warn("web socket broadcast error: ", err)
+		}
+	})
+}
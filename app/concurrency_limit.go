@@ -0,0 +1,16 @@
+package app
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	siteRepository "better-admin-backend-service/site/repository"
+	"context"
+)
+
+// getConcurrencyLimitRouteGroups 는 GORMDb 보다 먼저 실행되는 ConcurrencyLimit 을 위해 요청의 트랜잭션이 아닌 앱의 DB 로 설정을 조회한다.
+func (a *App) getConcurrencyLimitRouteGroups() ([]dtos.ConcurrencyLimitRouteGroup, error) {
+	ctx := helpers.ContextHelper().SetDB(context.Background(), a.gormDB)
+	siteService := services.NewSiteService(&siteRepository.SiteSettingRepository{}, &siteRepository.SiteSettingVersionRepository{})
+	return services.NewConcurrencyLimitService(siteService).GetRouteGroups(ctx)
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"better-admin-backend-service/config"
 	"fmt"
 	"github.com/pkg/errors"
 	"gorm.io/driver/mysql"
@@ -8,7 +9,11 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/plugin/dbresolver"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,6 +29,48 @@ const (
 	EnvReplicaDbPassword = "REPLICA_DB_PASSWORD"
 )
 
+const (
+	DriverMysql  = "mysql"
+	DriverSqlite = "sqlite"
+)
+
+// GetDriver 는 DB 종류이다. 환경 변수(DB_DRIVER)가 있으면 설정(Database.Driver)보다 우선한다.
+func GetDriver() string {
+	driver := os.Getenv(EnvDbDriver)
+	if len(driver) == 0 {
+		driver = config.Config.Database.Driver
+	}
+
+	if len(driver) == 0 {
+		return DriverSqlite
+	}
+	return strings.ToLower(driver)
+}
+
+// SqlitePath 는 SQLite DB 파일 경로이다.
+func SqlitePath() string {
+	if len(config.Config.Database.SqlitePath) == 0 {
+		return "account.db"
+	}
+
+	return config.Config.Database.SqlitePath
+}
+
+// sqliteDsn 은 SQLite 연결 문자열이다.
+// 쓰기 트랜잭션이 읽기 잠금을 쓰기 잠금으로 바꿀 때는 busy timeout 동안 기다리지 않고 바로 실패(SQLITE_BUSY)하므로 트랜잭션을 시작할 때 쓰기 잠금을 잡는다(_txlock=immediate).
+func sqliteDsn(path string) string {
+	databaseConfig := config.Config.Database
+
+	values := url.Values{}
+	values.Set("_busy_timeout", strconv.Itoa(databaseConfig.SqliteBusyTimeoutMilliseconds))
+	values.Set("_txlock", "immediate")
+	if len(databaseConfig.SqliteJournalMode) > 0 {
+		values.Set("_journal_mode", strings.ToUpper(databaseConfig.SqliteJournalMode))
+	}
+
+	return fmt.Sprintf("file:%s?%s", path, values.Encode())
+}
+
 type DatabaseConnector interface {
 	Connect() (*gorm.DB, error)
 }
@@ -34,9 +81,8 @@ type ProductionDbConnector struct {
 func (ProductionDbConnector) Connect() (*gorm.DB, error) {
 	var dialector gorm.Dialector
 
-	driver := os.Getenv(EnvDbDriver)
-
-	if driver == "mysql" {
+	switch GetDriver() {
+	case DriverMysql:
 		if len(os.Getenv(EnvDbHost)) > 0 &&
 			len(os.Getenv(EnvDbName)) > 0 &&
 			len(os.Getenv(EnvDbUser)) > 0 &&
@@ -50,13 +96,21 @@ func (ProductionDbConnector) Connect() (*gorm.DB, error) {
 		} else {
 			return nil, errors.New(fmt.Sprintf("%s, %s, %s  and %s environment variable are required.", EnvDbHost, EnvDbName, EnvDbUser, EnvDbPassword))
 		}
-	} else {
+	case DriverSqlite:
 		// 기본적으로 DB는 sqlite
-		dialector = sqlite.Open("account.db")
+		path := SqlitePath()
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, errors.Wrap(err, "Database Connection Error")
+		}
+		dialector = sqlite.Open(sqliteDsn(path))
+	default:
+		return nil, errors.Errorf("%s database driver is not supported. use %s or %s", GetDriver(), DriverMysql, DriverSqlite)
 	}
 
+	databaseConfig := config.Config.Database
 	db, err := gorm.Open(dialector, &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:      logger.Default.LogMode(logger.Info),
+		PrepareStmt: databaseConfig.PrepareStmt,
 	})
 
 	if err != nil {
@@ -73,13 +127,20 @@ func (ProductionDbConnector) Connect() (*gorm.DB, error) {
 				os.Getenv(EnvReplicaDbPassword),
 				os.Getenv(EnvReplicaDbHost),
 				os.Getenv(EnvReplicaDbName)))},
-		}).SetConnMaxIdleTime(10).SetConnMaxLifetime(10 * time.Minute).SetMaxIdleConns(5).SetMaxOpenConns(10))
+		}).SetConnMaxIdleTime(time.Duration(databaseConfig.ConnMaxIdleTimeMinutes) * time.Minute).
+			SetConnMaxLifetime(time.Duration(databaseConfig.ConnMaxLifetimeMinutes) * time.Minute).
+			SetMaxIdleConns(databaseConfig.MaxIdleConns).
+			SetMaxOpenConns(databaseConfig.MaxOpenConns))
 	}
 
 	sqlDB, err := db.DB()
-	sqlDB.SetMaxOpenConns(10)
-	sqlDB.SetMaxIdleConns(5)
-	sqlDB.SetConnMaxLifetime(10 * time.Minute)
+	if err != nil {
+		return nil, errors.Wrap(err, "Database Connection Error")
+	}
+	sqlDB.SetMaxOpenConns(databaseConfig.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(databaseConfig.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(databaseConfig.ConnMaxLifetimeMinutes) * time.Minute)
+	sqlDB.SetConnMaxIdleTime(time.Duration(databaseConfig.ConnMaxIdleTimeMinutes) * time.Minute)
 
 	return db, nil
 }
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"better-admin-backend-service/config"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetDriver(t *testing.T) {
+	databaseConfig := config.Config.Database
+	defer func() { config.Config.Database = databaseConfig }()
+
+	// 설정
+	t.Setenv(EnvDbDriver, "")
+	config.Config.Database.Driver = "MySQL"
+	assert.Equal(t, DriverMysql, GetDriver())
+
+	config.Config.Database.Driver = ""
+	assert.Equal(t, DriverSqlite, GetDriver())
+
+	// 환경 변수가 설정보다 우선한다.
+	t.Setenv(EnvDbDriver, DriverSqlite)
+	config.Config.Database.Driver = DriverMysql
+	assert.Equal(t, DriverSqlite, GetDriver())
+}
+
+func TestProductionDbConnector_sqlite(t *testing.T) {
+	databaseConfig := config.Config.Database
+	defer func() { config.Config.Database = databaseConfig }()
+	t.Setenv(EnvDbDriver, DriverSqlite)
+
+	// given
+	config.Config.Database.SqlitePath = filepath.Join(t.TempDir(), "data", "account.db")
+	config.Config.Database.SqliteJournalMode = "wal"
+	config.Config.Database.SqliteBusyTimeoutMilliseconds = 3000
+
+	// when
+	gormDB, err := ProductionDbConnector{}.Connect()
+
+	// then
+	assert.NoError(t, err)
+	sqlDB, _ := gormDB.DB()
+	defer sqlDB.Close()
+
+	var journalMode string
+	gormDB.Raw("PRAGMA journal_mode").Scan(&journalMode)
+	assert.Equal(t, "wal", journalMode)
+
+	var busyTimeout int
+	gormDB.Raw("PRAGMA busy_timeout").Scan(&busyTimeout)
+	assert.Equal(t, 3000, busyTimeout)
+
+	assert.FileExists(t, config.Config.Database.SqlitePath)
+}
+
+func TestProductionDbConnector_지원하지_않는_DB(t *testing.T) {
+	// given
+	t.Setenv(EnvDbDriver, "postgres")
+
+	// when
+	_, err := ProductionDbConnector{}.Connect()
+
+	// then
+	assert.EqualError(t, err, "postgres database driver is not supported. use mysql or sqlite")
+}
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"database/sql"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+var (
+	poolMonitorOnce     sync.Once
+	poolMonitorInstance *poolMonitor
+)
+
+// PoolMonitor 는 DB 커넥션 풀 사용 현황을 주기(config.Database.MonitorIntervalSeconds)마다 확인하고 연결 대기가 기준을 넘으면 경고한다.
+func PoolMonitor() *poolMonitor {
+	poolMonitorOnce.Do(func() {
+		poolMonitorInstance = &poolMonitor{}
+	})
+
+	return poolMonitorInstance
+}
+
+type poolMonitor struct {
+	mutex     sync.Mutex
+	stopped   chan struct{}
+	waitGroup sync.WaitGroup
+	last      sql.DBStats
+	lastCheck *dtos.DatabasePoolCheck
+}
+
+func (m *poolMonitor) Start(gormDB *gorm.DB) {
+	interval := time.Duration(config.Config.Database.MonitorIntervalSeconds) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		log.Warn("database pool monitor error: ", err)
+		return
+	}
+
+	m.mutex.Lock()
+	if m.stopped != nil {
+		m.mutex.Unlock()
+		return
+	}
+	stopped := make(chan struct{})
+	m.stopped = stopped
+	m.last = sqlDB.Stats()
+	m.mutex.Unlock()
+
+	m.waitGroup.Add(1)
+	go func() {
+		defer m.waitGroup.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				m.check(sqlDB.Stats(), time.Now())
+			case <-stopped:
+				return
+			}
+		}
+	}()
+}
+
+func (m *poolMonitor) Stop() {
+	m.mutex.Lock()
+	stopped := m.stopped
+	m.stopped = nil
+	m.mutex.Unlock()
+
+	if stopped == nil {
+		return
+	}
+	close(stopped)
+	m.waitGroup.Wait()
+}
+
+// check 는 지난 확인 이후의 연결 대기를 기준과 비교한다.
+// 기준을 넘는 상태가 이어지면 1, 2, 4, 8... 번째 확인에서만 경고하여 로그가 넘치지 않게 하고, 회복하면 한 번 알린다.
+func (m *poolMonitor) check(stats sql.DBStats, now time.Time) dtos.DatabasePoolCheck {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	check := dtos.DatabasePoolCheck{CheckedAt: now, WaitCount: stats.WaitCount - m.last.WaitCount}
+	if check.WaitCount > 0 {
+		check.AverageWaitDurationMilliseconds = ((stats.WaitDuration - m.last.WaitDuration) / time.Duration(check.WaitCount)).Milliseconds()
+	}
+	m.last = stats
+
+	databaseConfig := config.Config.Database
+	check.Warning = check.WaitCount > 0 &&
+		(check.WaitCount >= int64(databaseConfig.WaitWarningCount) || check.AverageWaitDurationMilliseconds >= int64(databaseConfig.WaitWarningMilliseconds))
+
+	consecutiveWarnings := 0
+	if m.lastCheck != nil {
+		consecutiveWarnings = m.lastCheck.ConsecutiveWarnings
+	}
+
+	if check.Warning {
+		check.ConsecutiveWarnings = consecutiveWarnings + 1
+		if check.ConsecutiveWarnings&(check.ConsecutiveWarnings-1) == 0 {
+			log.Warnf("database pool wait: %d waits, average %dms (in use %d/%d, %d consecutive checks). consider increasing Database.MaxOpenConns",
+				check.WaitCount, check.AverageWaitDurationMilliseconds, stats.InUse, stats.MaxOpenConnections, check.ConsecutiveWarnings)
+		}
+	} else if consecutiveWarnings > 0 {
+		log.Infof("database pool wait recovered after %d consecutive checks", consecutiveWarnings)
+	}
+
+	m.lastCheck = &check
+	return check
+}
+
+// GetMetric 은 현재 풀 사용 현황과 마지막 확인 결과를 조회한다.
+func (m *poolMonitor) GetMetric(sqlDB *sql.DB) dtos.DatabasePoolMetric {
+	stats := sqlDB.Stats()
+
+	metric := dtos.DatabasePoolMetric{
+		MaxOpenConnections:       stats.MaxOpenConnections,
+		OpenConnections:          stats.OpenConnections,
+		InUse:                    stats.InUse,
+		Idle:                     stats.Idle,
+		WaitCount:                stats.WaitCount,
+		WaitDurationMilliseconds: stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:            stats.MaxIdleClosed,
+		MaxIdleTimeClosed:        stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:        stats.MaxLifetimeClosed,
+		PrepareStmt:              config.Config.Database.PrepareStmt,
+	}
+	if stats.MaxOpenConnections > 0 {
+		metric.UtilizationPercent = stats.InUse * 100 / stats.MaxOpenConnections
+	}
+
+	m.mutex.Lock()
+	if m.lastCheck != nil {
+		lastCheck := *m.lastCheck
+		metric.LastCheck = &lastCheck
+	}
+	m.mutex.Unlock()
+
+	return metric
+}
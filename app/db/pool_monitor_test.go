@@ -0,0 +1,43 @@
+package db
+
+import (
+	"better-admin-backend-service/config"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPoolMonitor_check(t *testing.T) {
+	databaseConfig := config.Config.Database
+	defer func() { config.Config.Database = databaseConfig }()
+	config.Config.Database.WaitWarningCount = 10
+	config.Config.Database.WaitWarningMilliseconds = 100
+
+	// given
+	monitor := &poolMonitor{}
+	now := time.Now()
+
+	// when
+	few := monitor.check(sql.DBStats{WaitCount: 2, WaitDuration: 20 * time.Millisecond}, now)
+	slow := monitor.check(sql.DBStats{WaitCount: 4, WaitDuration: 420 * time.Millisecond}, now)
+	many := monitor.check(sql.DBStats{WaitCount: 24, WaitDuration: 440 * time.Millisecond}, now)
+	recovered := monitor.check(sql.DBStats{WaitCount: 24, WaitDuration: 440 * time.Millisecond}, now)
+
+	// then
+	assert.False(t, few.Warning)
+	assert.Equal(t, int64(10), few.AverageWaitDurationMilliseconds)
+
+	assert.True(t, slow.Warning)
+	assert.Equal(t, int64(2), slow.WaitCount)
+	assert.Equal(t, int64(200), slow.AverageWaitDurationMilliseconds)
+	assert.Equal(t, 1, slow.ConsecutiveWarnings)
+
+	assert.True(t, many.Warning)
+	assert.Equal(t, int64(20), many.WaitCount)
+	assert.Equal(t, 2, many.ConsecutiveWarnings)
+
+	assert.False(t, recovered.Warning)
+	assert.Equal(t, int64(0), recovered.WaitCount)
+	assert.Equal(t, 0, recovered.ConsecutiveWarnings)
+}
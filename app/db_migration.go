@@ -1,22 +1,86 @@
 package app
 
 import (
+	approvalDomain "better-admin-backend-service/approval/domain"
+	auditDomain "better-admin-backend-service/audit/domain"
+	breakGlassDomain "better-admin-backend-service/breakglass/domain"
+	changeRequestDomain "better-admin-backend-service/changerequest/domain"
+	clusterDomain "better-admin-backend-service/cluster/domain"
+	commandDomain "better-admin-backend-service/command/domain"
 	"better-admin-backend-service/constants"
+	dataMigrationDomain "better-admin-backend-service/datamigration/domain"
+	eventDomain "better-admin-backend-service/event/domain"
+	exportDomain "better-admin-backend-service/export/domain"
+	fileDomain "better-admin-backend-service/file/domain"
+	i18nDomain "better-admin-backend-service/i18n/domain"
 	memberDomain "better-admin-backend-service/member/domain"
+	messageTemplateDomain "better-admin-backend-service/messagetemplate/domain"
+	noteDomain "better-admin-backend-service/note/domain"
+	oauthDomain "better-admin-backend-service/oauth/domain"
 	organizationDomain "better-admin-backend-service/organization/domain"
+	pluginSettingDomain "better-admin-backend-service/pluginsetting/domain"
 	rbacDomain "better-admin-backend-service/rbac/domain"
+	reportDomain "better-admin-backend-service/report/domain"
+	retentionDomain "better-admin-backend-service/retention/domain"
+	securityEventDomain "better-admin-backend-service/securityevent/domain"
+	segmentDomain "better-admin-backend-service/segment/domain"
+	serviceAccountDomain "better-admin-backend-service/serviceaccount/domain"
+	sessionDomain "better-admin-backend-service/session/domain"
 	siteDomain "better-admin-backend-service/site/domain"
+	statisticsDomain "better-admin-backend-service/statistics/domain"
+	tableViewDomain "better-admin-backend-service/tableview/domain"
+	tokenDomain "better-admin-backend-service/token/domain"
 	webhookDomain "better-admin-backend-service/webhook/domain"
 	log "github.com/sirupsen/logrus"
 	"time"
 )
 
+// migrationEntities 는 테이블을 생성할 엔티티 목록이다. 시작 점검(self check)에서 테이블이 있는지 확인할 때도 사용한다.
+var migrationEntities = []interface{}{
+	&memberDomain.MemberEntity{}, &siteDomain.SettingEntity{}, &rbacDomain.PermissionEntity{},
+	&rbacDomain.RoleEntity{}, &organizationDomain.OrganizationEntity{},
+	&webhookDomain.WebHookEntity{}, &webhookDomain.WebHookMessageEntity{},
+	&sessionDomain.MemberSessionEntity{}, &auditDomain.AuditLogEntity{}, &auditDomain.ActivityFeedEntity{},
+	&serviceAccountDomain.ServiceAccountEntity{}, &serviceAccountDomain.TokenExchangePolicyEntity{},
+	&oauthDomain.OAuthClientEntity{}, &oauthDomain.MemberConsentEntity{}, &oauthDomain.OAuthAuthorizationCodeEntity{},
+	&oauthDomain.OAuthDeviceCodeEntity{}, &tokenDomain.RevokedTokenEntity{},
+	&memberDomain.SignIdChangeEntity{}, &memberDomain.MemberIdentityEntity{},
+	&memberDomain.MemberDeprovisioningEntity{},
+	&approvalDomain.ApprovalRequestEntity{}, &approvalDomain.ApprovalDecisionEntity{},
+	&approvalDomain.ApprovalDelegationEntity{},
+	&memberDomain.MemberTagEntity{}, &segmentDomain.SegmentEntity{},
+	&memberDomain.MemberPreferenceEntity{}, &memberDomain.MemberAssignmentRuleEntity{},
+	&reportDomain.ReportEntity{}, &reportDomain.ReportRunEntity{},
+	&statisticsDomain.LoginAttemptEntity{}, &statisticsDomain.UsageStatisticEntity{},
+	&fileDomain.FileEntity{}, &fileDomain.AttachmentEntity{},
+	&eventDomain.DomainEventEntity{},
+	&eventDomain.EventStoreEntity{},
+	&commandDomain.InboundCommandEntity{}, &commandDomain.ConsumerOffsetEntity{},
+	&breakGlassDomain.BreakGlassAccountEntity{}, &breakGlassDomain.BreakGlassUsageEntity{},
+	&rbacDomain.RoleMemberBulkJobEntity{},
+	&rbacDomain.GroupRoleMappingEntity{}, &rbacDomain.GroupRoleGrantEntity{},
+	&rbacDomain.RoleElevationEntity{},
+	&rbacDomain.MemberRoleAssignmentEntity{}, &rbacDomain.RolePermissionAssignmentEntity{},
+	&siteDomain.SettingVersionEntity{},
+	&pluginSettingDomain.PluginSettingEntity{},
+	&dataMigrationDomain.DataMigrationEntity{},
+	&clusterDomain.ClusterLockEntity{},
+	&changeRequestDomain.ChangeRequestEntity{},
+	&noteDomain.NoteEntity{},
+	&securityEventDomain.SecurityEventEntity{},
+	&sessionDomain.LoginDeviceEntity{}, &sessionDomain.LoginNotificationEntity{},
+	&memberDomain.MemberFieldChangeEntity{},
+	&exportDomain.ExportJobEntity{},
+	&retentionDomain.LegalHoldEntity{}, &retentionDomain.RetentionRunEntity{},
+	&tableViewDomain.TableViewEntity{},
+	&i18nDomain.LocaleBundleEntity{}, &i18nDomain.LocaleBundleVersionEntity{},
+	&messageTemplateDomain.MessageTemplateEntity{},
+}
+
 func (a *App) migrateDatabase() error {
 	log.Info(">>> Database Migrate")
 	// 테이블 생성
-	if err := a.gormDB.AutoMigrate(&memberDomain.MemberEntity{}, &siteDomain.SettingEntity{}, &rbacDomain.PermissionEntity{},
-		&rbacDomain.RoleEntity{}, &organizationDomain.OrganizationEntity{},
-		&webhookDomain.WebHookEntity{}, &webhookDomain.WebHookMessageEntity{}); err != nil {
+	if err := a.gormDB.AutoMigrate(migrationEntities...); err != nil {
 		return err
 	}
 
@@ -94,5 +158,25 @@ func (a *App) migrateDatabase() error {
 		}
 	}
 
-	return nil
+	return a.backfillAccessHistory()
+}
+
+// backfillAccessHistory 는 유효 기간이 기록되지 않은 현재 할당(기능을 추가하기 전의 할당 등)을 지금부터 유효한 할당으로 기록한다.
+func (a *App) backfillAccessHistory() error {
+	now := time.Now()
+	if err := a.gormDB.Exec("INSERT INTO member_role_assignments(member_id, role_id, effective_from, assigned_by, revoked_by) "+
+		"SELECT mr.member_entity_id, mr.role_entity_id, ?, 0, 0 FROM member_roles mr "+
+		"INNER JOIN members m ON m.id = mr.member_entity_id AND m.deleted_at IS NULL "+
+		"INNER JOIN roles r ON r.id = mr.role_entity_id AND r.deleted_at IS NULL "+
+		"WHERE NOT EXISTS (SELECT 1 FROM member_role_assignments a "+
+		"WHERE a.member_id = mr.member_entity_id AND a.role_id = mr.role_entity_id AND a.effective_to IS NULL)", now).Error; err != nil {
+		return err
+	}
+
+	return a.gormDB.Exec("INSERT INTO role_permission_assignments(role_id, permission_id, effective_from, assigned_by, revoked_by) "+
+		"SELECT rp.role_entity_id, rp.permission_entity_id, ?, 0, 0 FROM role_permissions rp "+
+		"INNER JOIN roles r ON r.id = rp.role_entity_id AND r.deleted_at IS NULL "+
+		"INNER JOIN permissions p ON p.id = rp.permission_entity_id AND p.deleted_at IS NULL "+
+		"WHERE NOT EXISTS (SELECT 1 FROM role_permission_assignments a "+
+		"WHERE a.role_id = rp.role_entity_id AND a.permission_id = rp.permission_entity_id AND a.effective_to IS NULL)", now).Error
 }
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/frontend"
+	log "github.com/sirupsen/logrus"
+	"io/fs"
+)
+
+const (
+	frontendSourceEmbed     = "embed"
+	frontendSourceDirectory = "directory"
+)
+
+// setUpFrontend 는 설정에 따라 API 와 웹소켓이 아닌 경로에 프론트엔드를 응답한다. 작은 배포 환경에서 하나의 컨테이너로 실행할 때 사용한다.
+func (a *App) setUpFrontend() {
+	frontendConfig := config.Config.Frontend
+
+	var files fs.FS
+	switch frontendConfig.Source {
+	case frontendSourceEmbed:
+		files = frontend.EmbeddedFileSystem()
+	case frontendSourceDirectory:
+		files = frontend.DirectoryFileSystem(frontendConfig.Directory)
+	default:
+		return
+	}
+
+	if !frontend.HasIndex(files) {
+		log.Warnf("frontend(%s) index.html is not found", frontendConfig.Source)
+		return
+	}
+
+	log.Infof(">>> Serve Frontend(%s)", frontendConfig.Source)
+	a.gin.NoRoute(frontend.Handler(files, []string{"/api/", "/ws/"}, frontendConfig.AssetPrefixes, frontendConfig.AssetMaxAgeSeconds))
+}
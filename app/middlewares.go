@@ -6,9 +6,19 @@ import (
 )
 
 func (a *App) addGinMiddlewares() {
+	a.gin.Use(middlewares.AuthorizationProbe(a.gin))
 	a.gin.Use(cors.New(a.newCorsConfig()))
+	a.gin.Use(middlewares.ErrorCode())
 	a.gin.Use(middlewares.ErrorHandler)
+	a.gin.Use(middlewares.RequestSizeLimit())
+	a.gin.Use(middlewares.RequestTimeout())
+	a.gin.Use(middlewares.ClientIp())
+	a.gin.Use(middlewares.ClientFingerprint())
 	a.gin.Use(middlewares.JwtToken())
+	a.gin.Use(middlewares.ScopedToken())
+	a.gin.Use(middlewares.StepUpToken())
+	// 대기하는 요청이 DB 트랜잭션을 잡지 않도록 GORMDb 보다 먼저 등록한다.
+	a.gin.Use(middlewares.ConcurrencyLimit(a.getConcurrencyLimitRouteGroups))
 	a.gin.Use(middlewares.GORMDb(a.gormDB))
 }
 
@@ -18,7 +28,9 @@ func (a *App) newCorsConfig() cors.Config {
 	corsConfig.AllowOriginFunc = func(origin string) bool {
 		return true
 	}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", middlewares.ApiKeyHeader, middlewares.DeviceIdHeader,
+		middlewares.FrontendVersionHeader, middlewares.StepUpTokenHeader}
+	corsConfig.ExposeHeaders = []string{middlewares.ErrorCodeHeader, middlewares.WarningCodeHeader}
 
 	return corsConfig
 }
@@ -0,0 +1,252 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 라우트의 인증을 정하는 middleware 의 handler 이름. 컴파일러가 붙이는 closure 이름(.func1, .1)은 빌드마다 다를 수 있어 앞부분만 비교한다.
+var authorizationHandlerNames = []string{"/app/middlewares.PermissionChecker.", "/app/middlewares.Public."}
+
+type authorizationProbeKey struct{}
+
+// authorizationProbe 는 권한 매트릭스를 만들 때 라우트마다 보내는 내부 요청의 결과이다.
+// Context 값으로만 전달하므로 외부 요청으로 흉내낼 수 없다.
+type authorizationProbe struct {
+	fullPath    string
+	guarded     bool
+	checked     bool
+	public      bool
+	permissions []string
+}
+
+var (
+	probeEngine *gin.Engine
+
+	// 라우트는 시작한 뒤 바뀌지 않으므로 FindAuthorizationRule 은 처음 만든 권한 매트릭스를 사용한다.
+	authorizationRulesMutex sync.Mutex
+	authorizationRules      = map[string][]dtos.AuthorizationRule{}
+)
+
+// AuthorizationProbe 는 권한 매트릭스(AuthorizationMatrix)를 만드는 내부 요청을 라우트의 PermissionChecker(혹은 Public) 까지만 실행한다.
+// 둘 다 없는 라우트는 handler 가 실행되지 않도록 바로 멈추므로 가장 먼저 등록해야 한다.
+func AuthorizationProbe(engine *gin.Engine) gin.HandlerFunc {
+	probeEngine = engine
+
+	return func(c *gin.Context) {
+		probe := getAuthorizationProbe(c)
+		if probe == nil {
+			c.Next()
+			return
+		}
+
+		probe.fullPath = c.FullPath()
+		for _, handlerName := range c.HandlerNames() {
+			for _, authorizationHandlerName := range authorizationHandlerNames {
+				if strings.Contains(handlerName, authorizationHandlerName) {
+					probe.guarded = true
+					c.Next()
+					return
+				}
+			}
+		}
+		c.Abort()
+	}
+}
+
+// AuthorizationMatrix 는 basePath 아래에 등록된 라우트마다 PermissionChecker 에 지정된 권한을 확인한다.
+func AuthorizationMatrix(basePath string) []dtos.AuthorizationRule {
+	rules := make([]dtos.AuthorizationRule, 0)
+	if probeEngine == nil {
+		return rules
+	}
+
+	for _, route := range probeEngine.Routes() {
+		if !strings.HasPrefix(route.Path, basePath) {
+			continue
+		}
+
+		probe := &authorizationProbe{}
+		request := httptest.NewRequest(route.Method, probePath(route.Path), nil)
+		request = request.WithContext(context.WithValue(context.Background(), authorizationProbeKey{}, probe))
+		probeEngine.ServeHTTP(httptest.NewRecorder(), request)
+
+		rule := dtos.AuthorizationRule{
+			Method:         route.Method,
+			Path:           route.Path,
+			Authentication: constants.AuthorizationUnclassified,
+			Permissions:    make([]string, 0),
+		}
+		switch {
+		case probe.fullPath != route.Path || (probe.guarded && !probe.checked):
+			// 다른 라우트로 연결되었거나 PermissionChecker 전에 멈춘 경우
+			rule.Authentication = constants.AuthorizationUnknown
+		case probe.public:
+			rule.Authentication = constants.AuthorizationNone
+		case probe.checked && len(probe.permissions) == 1 && probe.permissions[0] == "*":
+			rule.Authentication = constants.AuthorizationAuthenticated
+		case probe.checked:
+			rule.Authentication = constants.AuthorizationPermission
+			rule.Permissions = probe.permissions
+		}
+		rules = append(rules, rule)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].Path != rules[j].Path {
+			return rules[i].Path < rules[j].Path
+		}
+		return rules[i].Method < rules[j].Method
+	})
+
+	return rules
+}
+
+// DescribeRoutes 는 basePath 아래에 등록된 라우트마다 handler 와 인증/권한을 반환한다.
+func DescribeRoutes(basePath string) []dtos.RouteDescription {
+	handlers := make(map[string]string)
+	if probeEngine != nil {
+		for _, route := range probeEngine.Routes() {
+			handlers[route.Method+" "+route.Path] = route.Handler
+		}
+	}
+
+	rules := AuthorizationMatrix(basePath)
+	routes := make([]dtos.RouteDescription, 0, len(rules))
+	for _, rule := range rules {
+		routes = append(routes, dtos.RouteDescription{
+			Method:         rule.Method,
+			Path:           rule.Path,
+			Handler:        handlers[rule.Method+" "+rule.Path],
+			Public:         rule.Authentication == constants.AuthorizationNone,
+			Authentication: rule.Authentication,
+			Permissions:    rule.Permissions,
+		})
+	}
+
+	return routes
+}
+
+// FindAuthorizationRule 은 basePath 아래에서 method, path(예. DELETE /api/members/3)를 처리하는 라우트의 인증/권한을 찾는다.
+// gin 과 같이 경로 파라미터(:id, *path)보다 고정된 경로가 많이 일치하는 라우트를 먼저 찾는다.
+func FindAuthorizationRule(basePath string, method string, path string) (dtos.AuthorizationRule, bool) {
+	authorizationRulesMutex.Lock()
+	rules, ok := authorizationRules[basePath]
+	if !ok {
+		rules = AuthorizationMatrix(basePath)
+		authorizationRules[basePath] = rules
+	}
+	authorizationRulesMutex.Unlock()
+
+	var found dtos.AuthorizationRule
+	foundScore := -1
+	for _, rule := range rules {
+		if rule.Method != method {
+			continue
+		}
+		if score, ok := matchRoutePath(rule.Path, path); ok && score > foundScore {
+			found, foundScore = rule, score
+		}
+	}
+
+	return found, foundScore >= 0
+}
+
+// IsAuthorized 는 permissions 로 rule 의 API 를 호출할 수 있는지 확인한다.
+func IsAuthorized(rule dtos.AuthorizationRule, permissions []string) bool {
+	switch rule.Authentication {
+	case constants.AuthorizationNone, constants.AuthorizationAuthenticated, constants.AuthorizationUnclassified:
+		return true
+	case constants.AuthorizationPermission:
+		for _, allowPermission := range rule.Permissions {
+			for _, permission := range permissions {
+				if permission == allowPermission {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// matchRoutePath 는 path 가 라우트 경로와 일치하면 일치한 고정 경로의 수를 반환한다.
+func matchRoutePath(routePath string, path string) (int, bool) {
+	routeSegments := strings.Split(strings.Trim(routePath, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	score := 0
+	for i, routeSegment := range routeSegments {
+		if strings.HasPrefix(routeSegment, "*") {
+			return score, true
+		}
+		if i >= len(segments) {
+			return 0, false
+		}
+		if strings.HasPrefix(routeSegment, ":") {
+			if len(segments[i]) == 0 {
+				return 0, false
+			}
+			continue
+		}
+		if routeSegment != segments[i] {
+			return 0, false
+		}
+		score++
+	}
+
+	return score, len(routeSegments) == len(segments)
+}
+
+func getAuthorizationProbe(c *gin.Context) *authorizationProbe {
+	probe, ok := c.Request.Context().Value(authorizationProbeKey{}).(*authorizationProbe)
+	if !ok {
+		return nil
+	}
+
+	return probe
+}
+
+// recordAuthorizationProbe 는 내부 요청이면 라우트에 지정된 권한을 기록하고 요청을 멈춘다.
+func recordAuthorizationProbe(c *gin.Context, allowPermissions []string) bool {
+	probe := getAuthorizationProbe(c)
+	if probe == nil {
+		return false
+	}
+
+	probe.checked = true
+	probe.permissions = allowPermissions
+	c.Abort()
+	return true
+}
+
+// recordPublicProbe 는 내부 요청이면 로그인 없이 호출할 수 있는 라우트로 기록하고 요청을 멈춘다.
+func recordPublicProbe(c *gin.Context) bool {
+	probe := getAuthorizationProbe(c)
+	if probe == nil {
+		return false
+	}
+
+	probe.checked = true
+	probe.public = true
+	c.Abort()
+	return true
+}
+
+// probePath 는 경로 파라미터(:id, *path)를 값으로 바꾼다. 숫자 파라미터를 받는 라우트가 많으므로 0 을 사용한다.
+func probePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "0"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
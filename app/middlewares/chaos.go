@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"github.com/gin-gonic/gin"
+	"strings"
+	"time"
+)
+
+// Chaos 는 장애 주입 규칙(PUT /system/chaos)에 일치하는 요청을 늦게 처리하거나 오류로 응답하고, DB 와 외부 IdP 호출을 실패시킨다.
+// 프론트엔드의 재시도와 알림을 시험할 때 사용하며 Chaos.Enabled 가 아니면 아무것도 하지 않는다.
+// 규칙을 끌 수 있도록 skipPaths 로 시작하는 경로(예. /system/chaos)에는 주입하지 않는다. DB 에 주입하려면 GORMDb 다음에 등록해야 한다.
+func Chaos(skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.Config.Chaos.Enabled || getAuthorizationProbe(c) != nil {
+			c.Next()
+			return
+		}
+
+		for _, skipPath := range skipPaths {
+			if strings.HasPrefix(c.Request.URL.Path, skipPath) {
+				c.Next()
+				return
+			}
+		}
+
+		rule, ok := helpers.ChaosHelper().Match(c.Request.Method, c.Request.URL.Path)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if rule.LatencyMs > 0 {
+			select {
+			case <-time.After(time.Duration(rule.LatencyMs) * time.Millisecond):
+			case <-c.Request.Context().Done():
+			}
+		}
+
+		if rule.ErrorStatus > 0 {
+			c.Header(ErrorCodeHeader, errors.ErrChaosInjected.Code)
+			c.JSON(rule.ErrorStatus, dtos.ErrorMessage{Code: errors.ErrChaosInjected.Code, Message: errors.ErrChaosInjected.Error()})
+			c.Abort()
+			return
+		}
+
+		if len(rule.Faults) > 0 {
+			ctx := helpers.ContextHelper().SetChaosFaults(c.Request.Context(), rule.Faults)
+			// 요청의 DB 가 장애를 확인할 수 있도록 새 Context 를 사용하게 한다. 트랜잭션은 그대로 사용한다.
+			ctx = helpers.ContextHelper().SetDB(ctx, helpers.ContextHelper().GetDB(ctx).WithContext(ctx))
+			c.Request = c.Request.WithContext(ctx)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/helpers"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceIdHeader 는 앱이 설치마다 만들어 보내는 기기 Id 헤더이다. 없으면 User-Agent 만으로 지문을 만든다.
+const DeviceIdHeader = "X-Device-Id"
+
+// ClientFingerprint 는 User-Agent 와 기기 Id 의 해시를 클라이언트 지문으로 Context 에 설정한다. 세션과 Refresh 토큰을 클라이언트에 묶는 데 사용한다.
+func ClientFingerprint() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hash := sha256.Sum256([]byte(c.Request.UserAgent() + "\n" + c.GetHeader(DeviceIdHeader)))
+		c.Request = c.Request.WithContext(helpers.ContextHelper().SetClientFingerprint(c.Request.Context(), hex.EncodeToString(hash[:])))
+		c.Next()
+	}
+}
@@ -0,0 +1,15 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// ClientIp 는 요청한 클라이언트의 IP 를 Context 에 설정한다. 로그인 기록, 감사 로그의 위치 정보에 사용한다.
+// 신뢰하는 프록시(TrustedProxy.Cidrs)를 거친 요청이면 X-Forwarded-For 등의 헤더에서 찾은 실제 클라이언트 IP 이다.
+func ClientIp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(helpers.ContextHelper().SetClientIp(c.Request.Context(), c.ClientIP()))
+		c.Next()
+	}
+}
@@ -0,0 +1,187 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/dtos"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultConcurrencyLimitQueueTimeout = 10 * time.Second
+	defaultConcurrencyLimitRetryAfter   = 1
+)
+
+var concurrencyLimiters = struct {
+	mutex  sync.Mutex
+	groups map[string]*routeGroupLimiter
+}{groups: map[string]*routeGroupLimiter{}}
+
+// routeGroupLimiter 는 경로 그룹 하나의 동시 처리 슬롯이다. 설정이 바뀌면 새 슬롯을 만들고 현황(counters)은 이어서 센다.
+type routeGroupLimiter struct {
+	group    dtos.ConcurrencyLimitRouteGroup
+	slots    chan struct{}
+	counters *concurrencyLimitCounters
+}
+
+type concurrencyLimitCounters struct {
+	inFlight      int64
+	queued        int64
+	admitted      int64
+	rejected      int64
+	timedOut      int64
+	queueWaits    int64
+	queueWaitTime int64
+}
+
+// ConcurrencyLimit 은 경로 그룹(PUT /site/settings/concurrency-limit)별로 동시에 처리하는 요청 수를 제한하여
+// 비용이 큰 요청(예. 내보내기, 리포트)이 로그인 같은 다른 요청을 처리하지 못하게 막지 않도록 한다.
+// 기다리는 동안 DB 트랜잭션을 잡지 않도록 GORMDb 보다 먼저 등록하므로 getRouteGroups 는 요청의 DB 를 사용하지 않아야 한다.
+func ConcurrencyLimit(getRouteGroups func() ([]dtos.ConcurrencyLimitRouteGroup, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if getAuthorizationProbe(c) != nil {
+			c.Next()
+			return
+		}
+
+		routeGroups, err := getRouteGroups()
+		if err != nil {
+			// 설정을 읽지 못해도 요청은 처리한다.
+			log.Warnf("concurrency limit setting error: %v", err)
+			c.Next()
+			return
+		}
+
+		group, ok := matchRouteGroup(routeGroups, c.Request.URL.Path)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		limiter := getRouteGroupLimiter(group)
+		if !limiter.acquire(c) {
+			retryAfter := group.RetryAfterSeconds
+			if retryAfter <= 0 {
+				retryAfter = defaultConcurrencyLimitRetryAfter
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, dtos.ErrorMessage{Message: "too many concurrent requests. retry later"})
+			c.Abort()
+			return
+		}
+		defer limiter.release()
+
+		c.Next()
+	}
+}
+
+// GetConcurrencyLimitMetrics 는 경로 그룹별 동시 처리 현황을 이름 순으로 반환한다.
+func GetConcurrencyLimitMetrics() []dtos.ConcurrencyLimitMetric {
+	concurrencyLimiters.mutex.Lock()
+	defer concurrencyLimiters.mutex.Unlock()
+
+	metrics := make([]dtos.ConcurrencyLimitMetric, 0, len(concurrencyLimiters.groups))
+	for _, limiter := range concurrencyLimiters.groups {
+		counters := limiter.counters
+		metric := dtos.ConcurrencyLimitMetric{
+			Name:          limiter.group.Name,
+			MaxConcurrent: limiter.group.MaxConcurrent,
+			MaxQueue:      limiter.group.MaxQueue,
+			InFlight:      atomic.LoadInt64(&counters.inFlight),
+			Queued:        atomic.LoadInt64(&counters.queued),
+			Admitted:      atomic.LoadInt64(&counters.admitted),
+			Rejected:      atomic.LoadInt64(&counters.rejected),
+			TimedOut:      atomic.LoadInt64(&counters.timedOut),
+		}
+		if queueWaits := atomic.LoadInt64(&counters.queueWaits); queueWaits > 0 {
+			metric.AverageQueueWaitMilliseconds = time.Duration(atomic.LoadInt64(&counters.queueWaitTime) / queueWaits).Milliseconds()
+		}
+		metrics = append(metrics, metric)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
+
+func matchRouteGroup(routeGroups []dtos.ConcurrencyLimitRouteGroup, path string) (dtos.ConcurrencyLimitRouteGroup, bool) {
+	for _, group := range routeGroups {
+		for _, pathPrefix := range group.PathPrefixes {
+			if strings.HasPrefix(path, pathPrefix) {
+				return group, true
+			}
+		}
+	}
+
+	return dtos.ConcurrencyLimitRouteGroup{}, false
+}
+
+func getRouteGroupLimiter(group dtos.ConcurrencyLimitRouteGroup) *routeGroupLimiter {
+	concurrencyLimiters.mutex.Lock()
+	defer concurrencyLimiters.mutex.Unlock()
+
+	limiter, ok := concurrencyLimiters.groups[group.Name]
+	if ok && reflect.DeepEqual(limiter.group, group) {
+		return limiter
+	}
+
+	counters := &concurrencyLimitCounters{}
+	if ok {
+		counters = limiter.counters
+	}
+	limiter = &routeGroupLimiter{group: group, slots: make(chan struct{}, group.MaxConcurrent), counters: counters}
+	concurrencyLimiters.groups[group.Name] = limiter
+	return limiter
+}
+
+// acquire 는 슬롯이 없으면 대기열에서 기다린다. 대기열이 찼거나, 기다리는 시간이 지났거나, 요청이 취소되면 false 이다.
+func (l *routeGroupLimiter) acquire(c *gin.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+		atomic.AddInt64(&l.counters.admitted, 1)
+		atomic.AddInt64(&l.counters.inFlight, 1)
+		return true
+	default:
+	}
+
+	if atomic.AddInt64(&l.counters.queued, 1) > int64(l.group.MaxQueue) {
+		atomic.AddInt64(&l.counters.queued, -1)
+		atomic.AddInt64(&l.counters.rejected, 1)
+		return false
+	}
+	defer atomic.AddInt64(&l.counters.queued, -1)
+
+	timeout := time.Duration(l.group.QueueTimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultConcurrencyLimitQueueTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	startedAt := time.Now()
+	select {
+	case l.slots <- struct{}{}:
+		atomic.AddInt64(&l.counters.queueWaits, 1)
+		atomic.AddInt64(&l.counters.queueWaitTime, int64(time.Since(startedAt)))
+		atomic.AddInt64(&l.counters.admitted, 1)
+		atomic.AddInt64(&l.counters.inFlight, 1)
+		return true
+	case <-timer.C:
+		atomic.AddInt64(&l.counters.timedOut, 1)
+		return false
+	case <-c.Request.Context().Done():
+		atomic.AddInt64(&l.counters.timedOut, 1)
+		return false
+	}
+}
+
+func (l *routeGroupLimiter) release() {
+	atomic.AddInt64(&l.counters.inFlight, -1)
+	<-l.slots
+}
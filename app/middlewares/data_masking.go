@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/helpers"
+	"context"
+	"github.com/gin-gonic/gin"
+)
+
+// DataMasking 은 요청한 사용자에게 적용할 마스킹 정책을 Context 에 설정한다. 응답(helpers.ResponseHelper)에서 정책을 적용한다.
+// 정책 조회에 DB 가 필요하고 사용자 권한을 확인해야 하므로 ApiKey 다음에 등록해야 한다.
+func DataMasking(getPolicies func(ctx context.Context) (map[string]string, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policies, err := getPolicies(c.Request.Context())
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(helpers.ContextHelper().SetDataMaskingPolicies(c.Request.Context(), policies))
+		c.Next()
+	}
+}
@@ -13,6 +13,9 @@ func ErrorHandler(c *gin.Context) {
 		for _, err := range c.Errors {
 			log.Errorf("%+v", err.Err)
 		}
-		c.Status(http.StatusInternalServerError)
+		// 제한 시간 초과(504)는 그대로 응답한다.
+		if c.Writer.Status() != http.StatusGatewayTimeout {
+			c.Status(http.StatusInternalServerError)
+		}
 	}
 }
@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ErrorCodeHeader 는 오류 응답의 오류 코드(GET /api/system/error-codes)이다.
+const ErrorCodeHeader = "X-Error-Code"
+
+// WarningCodeHeader 는 처리는 했지만 확인이 필요한 응답의 경고 코드(예. BREACHED_PASSWORD)이다.
+const WarningCodeHeader = "X-Warning-Code"
+
+// statusErrorCodes 는 핸들러가 오류 코드를 정하지 않은 오류 응답의 기본 코드이다.
+var statusErrorCodes = map[int]*errors.CodedError{
+	http.StatusBadRequest:            errors.ErrBadRequest,
+	http.StatusUnauthorized:          errors.ErrUnauthorized,
+	http.StatusForbidden:             errors.ErrForbidden,
+	http.StatusNotFound:              errors.ErrNotFound,
+	http.StatusNotAcceptable:         errors.ErrNotAcceptable,
+	http.StatusConflict:              errors.ErrConflict,
+	http.StatusRequestEntityTooLarge: errors.ErrRequestTooLarge,
+	http.StatusUpgradeRequired:       errors.ErrUpgradeRequired,
+	http.StatusTooManyRequests:       errors.ErrTooManyRequests,
+	http.StatusServiceUnavailable:    errors.ErrServiceUnavailable,
+	http.StatusGatewayTimeout:        errors.ErrGatewayTimeout,
+	http.StatusInsufficientStorage:   errors.ErrInsufficientQuota,
+}
+
+// ErrorCode 는 모든 오류 응답(4xx, 5xx)에 오류 코드 헤더를 설정한다.
+// 핸들러가 헤더를 설정하지 않았으면 Context 에 추가된 오류(c.Error)의 코드를, 그것도 없으면 상태 코드의 기본 코드를 사용한다.
+func ErrorCode() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &errorCodeWriter{ResponseWriter: c.Writer, ctx: c}
+		c.Next()
+	}
+}
+
+type errorCodeWriter struct {
+	gin.ResponseWriter
+	ctx *gin.Context
+}
+
+func (w *errorCodeWriter) WriteHeader(statusCode int) {
+	if statusCode >= http.StatusBadRequest && len(w.Header().Get(ErrorCodeHeader)) == 0 {
+		w.Header().Set(ErrorCodeHeader, getErrorCode(w.ctx, statusCode))
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func getErrorCode(c *gin.Context, statusCode int) string {
+	if last := c.Errors.Last(); last != nil {
+		if code := errors.Code(last.Err); len(code) > 0 {
+			return code
+		}
+	}
+
+	if codedError, ok := statusErrorCodes[statusCode]; ok {
+		return codedError.Code
+	}
+
+	if statusCode >= http.StatusInternalServerError {
+		return errors.ErrInternal.Code
+	}
+
+	return errors.ErrBadRequest.Code
+}
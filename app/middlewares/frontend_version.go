@@ -0,0 +1,91 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// FrontendVersionHeader 는 프론트엔드가 요청마다 보내는 자신의 버전(예. 1.4.2)이다.
+const FrontendVersionHeader = "X-Frontend-Version"
+
+// FrontendVersion 은 프론트엔드 버전이 Compatibility.MinFrontendVersion 보다 낮으면 426 으로 거절하여
+// 새 백엔드에 이전 프론트엔드를 사용할 때 알 수 없는 오류 대신 새로고침을 안내하게 한다.
+// 헤더가 없는 요청(예. 서비스 계정, 스크립트)과 skipPaths 로 시작하는 경로(예. 버전 조회)는 확인하지 않는다.
+func FrontendVersion(skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		minVersion := config.Config.Compatibility.MinFrontendVersion
+		frontendVersion := c.GetHeader(FrontendVersionHeader)
+		if getAuthorizationProbe(c) != nil || len(minVersion) == 0 || len(frontendVersion) == 0 {
+			c.Next()
+			return
+		}
+
+		for _, skipPath := range skipPaths {
+			if strings.HasPrefix(c.Request.URL.Path, skipPath) {
+				c.Next()
+				return
+			}
+		}
+
+		if compareVersions(frontendVersion, minVersion) >= 0 {
+			c.Next()
+			return
+		}
+
+		c.JSON(http.StatusUpgradeRequired, dtos.UpgradeRequired{
+			Code:               errors.ErrUpgradeRequired.Code,
+			Message:            fmt.Sprintf("frontend version %s is no longer supported. upgrade to %s or later", frontendVersion, minVersion),
+			FrontendVersion:    frontendVersion,
+			MinFrontendVersion: minVersion,
+			BackendVersion:     config.Version,
+		})
+		c.Abort()
+	}
+}
+
+// compareVersions 는 점으로 구분한 버전(v 접두사, -beta 같은 접미사는 무시)을 비교한다. 숫자가 아닌 버전은 가장 낮은 버전으로 본다.
+func compareVersions(version, other string) int {
+	versionParts, otherParts := parseVersion(version), parseVersion(other)
+	for i := 0; i < len(versionParts) || i < len(otherParts); i++ {
+		var versionPart, otherPart int
+		if i < len(versionParts) {
+			versionPart = versionParts[i]
+		}
+		if i < len(otherParts) {
+			otherPart = otherParts[i]
+		}
+
+		if versionPart != otherPart {
+			if versionPart < otherPart {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func parseVersion(version string) []int {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if index := strings.IndexAny(version, "-+"); index >= 0 {
+		version = version[:index]
+	}
+
+	parts := make([]int, 0)
+	for _, part := range strings.Split(version, ".") {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return []int{-1}
+		}
+		parts = append(parts, number)
+	}
+
+	return parts
+}
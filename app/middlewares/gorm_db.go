@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"better-admin-backend-service/helpers"
+	"database/sql"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
@@ -12,9 +13,16 @@ func GORMDb(db *gorm.DB) gin.HandlerFunc {
 		req := c.Request
 		ctx := req.Context()
 
-		switch req.Method {
+		method := req.Method
+		// 권한 매트릭스의 내부 요청은 handler 를 실행하지 않으므로 트랜잭션을 시작하지 않는다.
+		if getAuthorizationProbe(c) != nil {
+			method = "GET"
+		}
+
+		switch method {
 		case "POST", "PUT", "DELETE", "PATCH":
-			tx := db.Begin()
+			// 요청 Context 로 실행하여 제한 시간이 지나거나 요청이 취소되면 조회를 중단한다.
+			tx := db.WithContext(ctx).Begin()
 			defer func() {
 				if r := recover(); r != nil {
 					tx.Rollback()
@@ -27,12 +35,15 @@ func GORMDb(db *gorm.DB) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
-			c.Request = c.Request.WithContext(helpers.ContextHelper().SetDB(ctx, tx))
+			txCtx := helpers.ContextHelper().SetAfterCommit(helpers.ContextHelper().SetDB(ctx, tx))
+			c.Request = c.Request.WithContext(txCtx)
 
 			c.Next()
 
-			if len(c.Errors) > 0 {
-				if err := tx.Rollback().Error; err != nil {
+			// 제한 시간이 지난 요청은 핸들러가 끝까지 실행되지 않았을 수 있으므로 반영하지 않는다.
+			if len(c.Errors) > 0 || ctx.Err() != nil {
+				// Context 가 취소되면 database/sql 이 이미 롤백하여 ErrTxDone 을 반환한다.
+				if err := tx.Rollback().Error; err != nil && err != sql.ErrTxDone {
 					c.Error(errors.Wrap(err, "database rollback error"))
 				}
 				c.Abort()
@@ -44,8 +55,9 @@ func GORMDb(db *gorm.DB) gin.HandlerFunc {
 				c.Abort()
 				return
 			}
+			helpers.ContextHelper().RunAfterCommit(txCtx)
 		default:
-			c.Request = c.Request.WithContext(helpers.ContextHelper().SetDB(ctx, db))
+			c.Request = c.Request.WithContext(helpers.ContextHelper().SetDB(ctx, db.WithContext(ctx)))
 			c.Next()
 		}
 	}
@@ -1,9 +1,12 @@
 package middlewares
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/security"
+	"context"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 	"net/http"
@@ -18,23 +21,15 @@ func JwtToken() gin.HandlerFunc {
 	jwtAuthentication = security.JwtAuthentication{}
 
 	return func(c *gin.Context) {
-		accessToken := c.Request.Header.Get("Authorization")
+		accessToken := getAccessToken(c)
 		if len(accessToken) == 0 {
 			c.Next()
 			return
 		}
 
-		index := strings.Index(accessToken, "Bearer")
-		if index < 0 {
-			index = strings.Index(accessToken, "Bearer")
-		}
-		if index >= 0 {
-			accessToken = accessToken[index+len("Bearer"):]
-			accessToken = strings.Trim(accessToken, " ")
-		}
-
 		userClaim, err := jwtAuthentication.ConvertTokenUserClaim(accessToken)
 		if err != nil {
+			helpers.LoggingHelper().Debugf(constants.LoggingModuleAuth, "invalid access token. uri=%s, error=%v", c.Request.RequestURI, err)
 			c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
 			c.Abort()
 			return
@@ -45,6 +40,89 @@ func JwtToken() gin.HandlerFunc {
 	}
 }
 
+func getAccessToken(c *gin.Context) string {
+	accessToken := c.Request.Header.Get("Authorization")
+	// Basic 인증은 OAuth2 토큰 엔드포인트의 Client 인증에 사용하므로 JWT 로 검증하지 않는다.
+	if len(accessToken) == 0 || strings.HasPrefix(accessToken, "Basic ") {
+		return ""
+	}
+
+	index := strings.Index(accessToken, "Bearer")
+	if index >= 0 {
+		accessToken = accessToken[index+len("Bearer"):]
+		accessToken = strings.Trim(accessToken, " ")
+	}
+
+	return accessToken
+}
+
+// RevokedToken 은 폐기(RFC 7009)한 Access 토큰으로 요청하지 못하게 한다.
+// 폐기 기록 조회에 DB 가 필요하므로 GORMDb 다음에 등록해야 한다.
+func RevokedToken(isRevoked func(ctx context.Context, token string) (bool, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		accessToken := getAccessToken(c)
+		if len(accessToken) == 0 {
+			c.Next()
+			return
+		}
+
+		revoked, err := isRevoked(c.Request.Context(), accessToken)
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+
+		if revoked {
+			c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: security.TokenRevoked.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RevokedSession 은 종료된 세션(로그아웃, 세션 수 제한, 로그인 신고 등)에서 발급한 Access 토큰으로 요청하지 못하게 한다.
+// 세션 조회에 DB 가 필요하므로 GORMDb 다음에 등록해야 한다. 세션이 없는 토큰(서비스 계정 등)은 확인하지 않는다.
+func RevokedSession(isRevoked func(ctx context.Context, sessionId string) (bool, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaim, err := helpers.ContextHelper().GetUserClaim(c.Request.Context())
+		if err != nil || len(userClaim.SessionId) == 0 {
+			c.Next()
+			return
+		}
+
+		revoked, err := isRevoked(c.Request.Context(), userClaim.SessionId)
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+
+		if revoked {
+			c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.ErrSessionRevoked.Code, Message: errors.ErrSessionRevoked.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Public 은 로그인하지 않아도 호출할 수 있는 라우트임을 명시한다.
+// 모든 라우트는 PermissionChecker 와 Public 중 하나가 있어야 하며, 없으면 시작할 때 실패한다(GET /system/routes).
+func Public() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if recordPublicProbe(c) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// PermissionChecker 는 로그인하지 않았으면 401(UNAUTHORIZED), 허용한 권한이 없으면 403(FORBIDDEN)으로 응답한다.
 func PermissionChecker(allowPermissions []string) gin.HandlerFunc {
 	allowPermissionMap := make(map[string]bool)
 	for _, permission := range allowPermissions {
@@ -52,10 +130,14 @@ func PermissionChecker(allowPermissions []string) gin.HandlerFunc {
 	}
 
 	return func(ctx *gin.Context) {
+		if recordAuthorizationProbe(ctx, allowPermissions) {
+			return
+		}
+
 		userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
 		if err != nil {
 			log.Warnf("No valid credentials: %s", ctx.Request.RequestURI)
-			ctx.JSON(http.StatusUnauthorized, "Please provide valid credentials")
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.ErrUnauthorized.Code, Message: "Please provide valid credentials"})
 			ctx.Abort()
 			return
 		}
@@ -70,9 +152,55 @@ func PermissionChecker(allowPermissions []string) gin.HandlerFunc {
 				}
 			}
 			log.Warnf("Can't access this API: %s", ctx.Request.RequestURI)
-			ctx.JSON(http.StatusForbidden, "Can't access this API")
+			ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.ErrForbidden.Code, Message: "Can't access this API"})
 			ctx.Abort()
 			return
 		}
 	}
 }
+
+// ScopedToken 은 Authorization 헤더 대신 쿼리 파라미터(token)로 전달된 스코프 토큰을 검증한다.
+// 스코프 토큰은 발급 시 지정한 경로와 HTTP Method 에 대해서만 유효하다.
+func ScopedToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopedToken := c.Query(security.ScopedTokenQueryKey)
+		if len(scopedToken) == 0 || len(c.Request.Header.Get("Authorization")) > 0 {
+			c.Next()
+			return
+		}
+
+		userClaim, err := security.JwtAuthentication{}.ConvertScopedTokenUserClaim(scopedToken, c.Request.URL.Path, c.Request.Method)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(helpers.ContextHelper().SetUserClaim(c.Request.Context(), userClaim))
+		c.Next()
+	}
+}
+
+const ApiKeyHeader = "X-Api-Key"
+
+// ApiKey 는 X-Api-Key 헤더로 전달된 서비스 계정의 API Key 를 검증한다.
+// API Key 조회에 DB 가 필요하므로 GORMDb 다음에 등록해야 한다.
+func ApiKey(authenticate func(ctx context.Context, apiKey string) (*security.UserClaim, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.Request.Header.Get(ApiKeyHeader)
+		if len(apiKey) == 0 || len(c.Request.Header.Get("Authorization")) > 0 {
+			c.Next()
+			return
+		}
+
+		userClaim, err := authenticate(c.Request.Context(), apiKey)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(helpers.ContextHelper().SetUserClaim(c.Request.Context(), userClaim))
+		c.Next()
+	}
+}
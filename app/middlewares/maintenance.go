@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Maintenance 는 점검 모드이면 요청을 503 으로 거절한다. 점검 우회 권한이 있는 멤버와 skipPaths 로 시작하는 경로(예. 로그인)는 허용한다.
+// 점검 설정 조회에 DB 가 필요하고 멤버 권한을 확인해야 하므로 ApiKey 다음에 등록해야 한다.
+func Maintenance(getStatus func(ctx context.Context) (dtos.MaintenanceStatus, error), skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if getAuthorizationProbe(c) != nil {
+			c.Next()
+			return
+		}
+
+		for _, skipPath := range skipPaths {
+			if strings.HasPrefix(c.Request.URL.Path, skipPath) {
+				c.Next()
+				return
+			}
+		}
+
+		status, err := getStatus(c.Request.Context())
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+
+		if !status.Active || hasPermission(c.Request.Context(), constants.PermissionBypassMaintenance) {
+			c.Next()
+			return
+		}
+
+		if status.RetryAfterSeconds > 0 {
+			c.Header("Retry-After", strconv.Itoa(status.RetryAfterSeconds))
+		}
+		c.JSON(http.StatusServiceUnavailable, dtos.ErrorMessage{Message: status.Message})
+		c.Abort()
+	}
+}
+
+func hasPermission(ctx context.Context, permission string) bool {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return false
+	}
+
+	for _, userPermission := range userClaim.Permissions {
+		if userPermission == permission {
+			return true
+		}
+	}
+
+	return false
+}
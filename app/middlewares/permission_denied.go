@@ -0,0 +1,18 @@
+package middlewares
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// PermissionDenied 는 권한이 없어 403 으로 응답한 요청을 record 로 기록한다. 권한 매트릭스의 내부 요청은 기록하지 않는다.
+func PermissionDenied(record func(ctx context.Context, method, path string)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.Writer.Status() == http.StatusForbidden && getAuthorizationProbe(c) == nil {
+			record(c.Request.Context(), c.Request.Method, c.Request.URL.Path)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// 기록이 이보다 많아지면 지난 구간의 기록을 지운다.
+const maxRateLimitWindows = 10000
+
+var rateLimitWindows = struct {
+	mutex   sync.Mutex
+	windows map[string]*rateLimitWindow
+}{windows: map[string]*rateLimitWindow{}}
+
+type rateLimitWindow struct {
+	startedAt time.Time
+	count     int
+}
+
+// RateLimit 은 클라이언트 IP 마다 window 동안 getLimit() 번까지만 요청을 처리하고, 넘으면 429 로 거절한다.
+// 인증하지 않는 공개 API(예. GET /status)에 등록한다. 인스턴스마다 따로 센다. getLimit() 이 0 이하이면 제한하지 않는다.
+func RateLimit(name string, window time.Duration, getLimit func() int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := getLimit()
+		if getAuthorizationProbe(c) != nil || limit <= 0 {
+			c.Next()
+			return
+		}
+
+		key := name + ":" + helpers.ContextHelper().GetClientIp(c.Request.Context())
+		if retryAfter, allowed := allowRequest(key, limit, window, time.Now()); !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter/time.Second)+1))
+			c.JSON(http.StatusTooManyRequests, dtos.ErrorMessage{Message: "too many requests. retry later"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// allowRequest 는 key 의 현재 구간에 요청을 더한다. 허용하지 않으면 다음 구간까지 남은 시간을 반환한다.
+func allowRequest(key string, limit int, window time.Duration, now time.Time) (time.Duration, bool) {
+	rateLimitWindows.mutex.Lock()
+	defer rateLimitWindows.mutex.Unlock()
+
+	current, exists := rateLimitWindows.windows[key]
+	if !exists || !now.Before(current.startedAt.Add(window)) {
+		if len(rateLimitWindows.windows) >= maxRateLimitWindows {
+			deleteExpiredRateLimitWindows(window, now)
+		}
+		current = &rateLimitWindow{startedAt: now}
+		rateLimitWindows.windows[key] = current
+	}
+
+	if current.count >= limit {
+		return current.startedAt.Add(window).Sub(now), false
+	}
+
+	current.count++
+	return 0, true
+}
+
+func deleteExpiredRateLimitWindows(window time.Duration, now time.Time) {
+	for key, expired := range rateLimitWindows.windows {
+		if !now.Before(expired.startedAt.Add(window)) {
+			delete(rateLimitWindows.windows, key)
+		}
+	}
+}
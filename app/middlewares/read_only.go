@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+// ReadOnly 는 읽기 전용 모드가 적용되는 사용자의 변경 요청(POST, PUT, PATCH, DELETE)을 403(READ_ONLY)으로 거절하고 record 로 기록한다.
+// skipPaths 로 시작하는 경로(예. 로그인)는 허용하고, settingPath(읽기 전용 설정)는 전체 읽기 전용 모드를 끌 수 있도록 역할로 적용되지 않은 사용자에게 허용한다.
+// 사용자의 역할을 확인해야 하므로 ApiKey 다음에, 권한 거부로 다시 기록하지 않도록 PermissionDenied 이전에 등록해야 한다.
+func ReadOnly(getStatus func(ctx context.Context) (dtos.ReadOnlyStatus, error), record func(ctx context.Context, method, path string),
+	settingPath string, skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !isMutatingMethod(c.Request.Method) || getAuthorizationProbe(c) != nil {
+			c.Next()
+			return
+		}
+
+		for _, skipPath := range skipPaths {
+			if strings.HasPrefix(c.Request.URL.Path, skipPath) {
+				c.Next()
+				return
+			}
+		}
+
+		status, err := getStatus(c.Request.Context())
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+
+		if !status.Active || (!status.ByRole && c.Request.URL.Path == settingPath) {
+			c.Next()
+			return
+		}
+
+		record(c.Request.Context(), c.Request.Method, c.Request.URL.Path)
+		c.Header(ErrorCodeHeader, errors.ErrReadOnly.Code)
+		c.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.ErrReadOnly.Code, Message: status.Message})
+		c.Abort()
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
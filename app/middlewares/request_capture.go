@@ -0,0 +1,99 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"bytes"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// 기록하는 요청, 응답 본문의 최대 크기
+const maxCapturedBodyBytes = 16 * 1024
+
+// 값을 기록하지 않는 인증 관련 헤더
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	ApiKeyHeader:    true,
+}
+
+type capturingResponseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w capturingResponseWriter) Write(b []byte) (int, error) {
+	if remaining := maxCapturedBodyBytes - w.body.Len(); remaining > 0 {
+		if len(b) > remaining {
+			w.body.Write(b[:remaining])
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// RequestCapture 는 문제 확인을 위해 지정된 멤버의 요청과 응답 상세 내용을 기록한다(PUT /system/logging).
+// 멤버를 확인해야 하므로 ApiKey 다음에 등록해야 한다.
+func RequestCapture() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userClaim, err := helpers.ContextHelper().GetUserClaim(c.Request.Context())
+		if err != nil || !helpers.LoggingHelper().ShouldCaptureRequest(userClaim.Id) {
+			c.Next()
+			return
+		}
+
+		var requestBody []byte
+		if c.Request.Body != nil {
+			requestBody, _ = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(requestBody))
+		}
+		if len(requestBody) > maxCapturedBodyBytes {
+			requestBody = requestBody[:maxCapturedBodyBytes]
+		}
+
+		writer := capturingResponseWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+		startedAt := time.Now()
+
+		c.Next()
+
+		helpers.LoggingHelper().AddRequestCapture(dtos.RequestCapture{
+			MemberId:     userClaim.Id,
+			Method:       c.Request.Method,
+			Path:         c.Request.URL.Path,
+			Query:        captureQuery(c.Request.URL.Query()),
+			Headers:      captureHeaders(c.Request.Header),
+			RequestBody:  string(requestBody),
+			Status:       c.Writer.Status(),
+			ResponseBody: writer.body.String(),
+			ClientIp:     c.ClientIP(),
+			LatencyMs:    time.Since(startedAt).Milliseconds(),
+			CapturedAt:   startedAt,
+		})
+	}
+}
+
+func captureQuery(query url.Values) string {
+	if len(query.Get(security.ScopedTokenQueryKey)) > 0 {
+		query.Set(security.ScopedTokenQueryKey, "[REDACTED]")
+	}
+	return query.Encode()
+}
+
+func captureHeaders(header http.Header) map[string]string {
+	headers := map[string]string{}
+	for key := range header {
+		if redactedHeaders[key] {
+			headers[key] = "[REDACTED]"
+			continue
+		}
+		headers[key] = header.Get(key)
+	}
+	return headers
+}
@@ -0,0 +1,72 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// RequestSizeLimit 는 요청 본문이 라우터 그룹별 최대 크기(config.RequestSize)를 넘으면 읽기 전에 413(REQUEST_TOO_LARGE)으로 응답한다.
+// Content-Length 가 없는(chunked) 요청은 최대 크기까지만 읽어 확인하고 읽은 내용으로 본문을 바꾼다.
+func RequestSizeLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := getRequestSizeLimit(c.Request.URL.Path)
+		if limit <= 0 || c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			abortRequestTooLarge(c, limit)
+			return
+		}
+
+		if c.Request.ContentLength < 0 {
+			body, err := io.ReadAll(io.LimitReader(c.Request.Body, limit+1))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+				c.Abort()
+				return
+			}
+			if int64(len(body)) > limit {
+				abortRequestTooLarge(c, limit)
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		c.Next()
+	}
+}
+
+func abortRequestTooLarge(c *gin.Context, limit int64) {
+	// 본문을 다 읽지 않고 응답하므로 연결을 다시 사용하지 않는다.
+	c.Header("Connection", "close")
+	c.JSON(http.StatusRequestEntityTooLarge, dtos.ErrorMessage{
+		Code:    errors.ErrRequestTooLarge.Code,
+		Message: fmt.Sprintf("%v: limit %d bytes", errors.ErrRequestTooLarge, limit),
+	})
+	c.Abort()
+}
+
+func getRequestSizeLimit(path string) int64 {
+	sizeConfig := config.Config.RequestSize
+
+	limit, matched := sizeConfig.DefaultBytes, ""
+	for routeGroup, routeGroupBytes := range sizeConfig.RouteGroups {
+		if len(routeGroup) <= len(matched) {
+			continue
+		}
+		if path == routeGroup || strings.HasPrefix(path, strings.TrimSuffix(routeGroup, "/")+"/") {
+			limit, matched = routeGroupBytes, routeGroup
+		}
+	}
+
+	return limit
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/config"
+	"context"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestTimeout 은 요청 Context 에 라우터 그룹별 제한 시간(config.RequestTimeout)을 설정한다.
+// DB 조회와 외부 연동 호출은 요청 Context 를 사용하므로 제한 시간이 지나면 취소되고, 응답하지 못한 요청은 504 로 응답한다.
+// 핸들러를 별도 고루틴으로 실행하지 않으므로 핸들러가 취소된 호출에서 돌아온 뒤에 응답한다.
+func RequestTimeout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		timeout := getRequestTimeout(c.Request.URL.Path)
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		if ctx.Err() == context.DeadlineExceeded && !c.Writer.Written() {
+			c.Status(http.StatusGatewayTimeout)
+		}
+	}
+}
+
+func getRequestTimeout(path string) time.Duration {
+	timeoutConfig := config.Config.RequestTimeout
+
+	seconds, matched := timeoutConfig.DefaultSeconds, ""
+	for routeGroup, routeGroupSeconds := range timeoutConfig.RouteGroups {
+		if len(routeGroup) <= len(matched) {
+			continue
+		}
+		if path == routeGroup || strings.HasPrefix(path, strings.TrimSuffix(routeGroup, "/")+"/") {
+			seconds, matched = routeGroupSeconds, routeGroup
+		}
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/helpers"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResponseCacheHeader 는 저장한 응답을 보냈는지(HIT) 핸들러가 응답했는지(MISS) 알려주는 헤더이다.
+const ResponseCacheHeader = "X-Cache"
+
+const (
+	responseCacheKeyPrefix = "response-cache:"
+	// 이보다 큰 응답은 저장하지 않는다.
+	maxCachedResponseBytes = 256 * 1024
+)
+
+type cachedResponse struct {
+	ContentType string `json:"contentType"`
+	Body        []byte `json:"body"`
+}
+
+type cachingResponseWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+	ctx  *gin.Context
+	ttl  time.Duration
+}
+
+// WriteHeader 는 오류 응답을 CDN 이나 브라우저가 저장하지 않도록 성공한 응답에만 Cache-Control 을 설정한다.
+func (w cachingResponseWriter) WriteHeader(statusCode int) {
+	if statusCode == http.StatusOK {
+		setCacheControl(w.ctx, w.ttl)
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w cachingResponseWriter) Write(b []byte) (int, error) {
+	if w.body.Len() <= maxCachedResponseBytes {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// ResponseCache 는 GET 응답(200)을 ttl 동안 공유 상태 저장소(SharedState.Backend)에 저장하고 같은 요청에는 저장한 응답을 보낸다.
+// 권한에 따라 응답(예. 권한 태그, 마스킹)이 달라지므로 경로, 쿼리와 요청한 사용자의 권한으로 구분하여 저장한다. 사용자마다 다른 응답에는 사용하지 않는다.
+// 응답을 바꾸는 경로에는 PurgeResponseCache(name) 을 등록한다.
+func ResponseCache(name string, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if getAuthorizationProbe(c) != nil || c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		store := adapters.SharedStateAdapter().Store()
+		generation, _, err := store.Get(responseCacheGenerationKey(name))
+		if err != nil {
+			// 저장소에 연결할 수 없어도 요청은 처리한다.
+			log.Warnf("response cache(%s) error: %v", name, err)
+			c.Next()
+			return
+		}
+		key := responseCacheKey(c, name, generation)
+
+		c.Header("Vary", "Authorization")
+		if content, ok, _ := store.Get(key); ok {
+			var response cachedResponse
+			if err := json.Unmarshal([]byte(content), &response); err == nil {
+				c.Header(ResponseCacheHeader, "HIT")
+				setCacheControl(c, ttl)
+				c.Data(http.StatusOK, response.ContentType, response.Body)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Header(ResponseCacheHeader, "MISS")
+		writer := cachingResponseWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}, ctx: c, ttl: ttl}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if c.Writer.Status() != http.StatusOK || len(c.Errors) > 0 || writer.body.Len() > maxCachedResponseBytes {
+			return
+		}
+
+		content, err := json.Marshal(cachedResponse{ContentType: c.Writer.Header().Get("Content-Type"), Body: writer.body.Bytes()})
+		if err != nil {
+			return
+		}
+		if err := store.Set(key, string(content), ttl); err != nil {
+			log.Warnf("response cache(%s) save error: %v", name, err)
+		}
+	}
+}
+
+// PurgeResponseCache 는 요청이 성공하여 커밋된 뒤 names 의 저장한 응답을 모든 인스턴스에서 지운다.
+// 저장한 응답을 하나씩 지우지 않고 세대(generation)를 바꾸므로 이전 응답은 더 이상 사용되지 않고 ttl 이 지나면 사라진다.
+func PurgeResponseCache(names ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if getAuthorizationProbe(c) != nil || c.Writer.Status() >= http.StatusBadRequest || len(c.Errors) > 0 {
+			return
+		}
+
+		helpers.ContextHelper().AfterCommit(c.Request.Context(), func() {
+			for _, name := range names {
+				PurgeResponseCacheByName(name)
+			}
+		})
+	}
+}
+
+// PurgeResponseCacheByName 은 name 의 저장한 응답을 바로 지운다.
+func PurgeResponseCacheByName(name string) {
+	generation := strconv.FormatInt(time.Now().UnixNano(), 36)
+	if err := adapters.SharedStateAdapter().Store().Set(responseCacheGenerationKey(name), generation, 0); err != nil {
+		log.Errorf("response cache(%s) purge error: %v", name, err)
+	}
+}
+
+func responseCacheGenerationKey(name string) string {
+	return responseCacheKeyPrefix + name + ":generation"
+}
+
+// responseCacheKey 는 경로, 쿼리, 권한의 해시로 구분한다. 로그인하지 않은 요청은 권한이 없는 것으로 구분한다.
+func responseCacheKey(c *gin.Context, name string, generation string) string {
+	var permissions []string
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(c.Request.Context()); err == nil {
+		permissions = append(permissions, userClaim.Permissions...)
+		sort.Strings(permissions)
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s?%s|%s", c.Request.URL.Path, c.Request.URL.Query().Encode(), strings.Join(permissions, ","))))
+	return responseCacheKeyPrefix + name + ":" + generation + ":" + hex.EncodeToString(hash[:])
+}
+
+// setCacheControl 은 로그인하지 않은 요청은 CDN 도, 로그인한 요청은 브라우저만 저장할 수 있게 한다.
+func setCacheControl(c *gin.Context, ttl time.Duration) {
+	visibility := "public"
+	if _, err := helpers.ContextHelper().GetUserClaim(c.Request.Context()); err == nil {
+		visibility = "private"
+	}
+
+	c.Header("Cache-Control", fmt.Sprintf("%s, max-age=%d", visibility, int(ttl/time.Second)))
+}
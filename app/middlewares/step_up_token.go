@@ -0,0 +1,17 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// StepUpTokenHeader 는 비밀번호를 다시 확인하고 받은 단계 인증 토큰을 보내는 헤더이다.
+const StepUpTokenHeader = "X-Step-Up-Token"
+
+// StepUpToken 은 단계 인증 토큰 헤더를 Context 에 설정한다. 헤더가 없어도 빈 문자열로 설정하여 HTTP 요청임을 구분한다.
+func StepUpToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(helpers.ContextHelper().SetStepUpToken(c.Request.Context(), c.GetHeader(StepUpTokenHeader)))
+		c.Next()
+	}
+}
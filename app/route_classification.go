@@ -0,0 +1,23 @@
+package app
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	pkgerrors "github.com/pkg/errors"
+	"strings"
+)
+
+// checkRouteClassification 은 PermissionChecker 와 Public 이 모두 없어 의도하지 않게 공개된 API 가 있으면 시작하지 않는다.
+func checkRouteClassification(basePath string) error {
+	var unclassified []string
+	for _, rule := range middlewares.AuthorizationMatrix(basePath) {
+		if rule.Authentication == constants.AuthorizationUnclassified || rule.Authentication == constants.AuthorizationUnknown {
+			unclassified = append(unclassified, rule.Method+" "+rule.Path)
+		}
+	}
+
+	if len(unclassified) > 0 {
+		return pkgerrors.Errorf("routes must have middlewares.PermissionChecker or middlewares.Public: %s", strings.Join(unclassified, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCheckRouteClassification(t *testing.T) {
+	// given
+	engine := gin.New()
+	engine.Use(middlewares.AuthorizationProbe(engine))
+	handler := func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	}
+
+	routerGroup := engine.Group("/api")
+	routerGroup.POST("/auth", middlewares.Public(), handler)
+	routerGroup.GET("/members/:id", middlewares.PermissionChecker([]string{"MANAGE_MEMBERS"}), handler)
+
+	// when
+	err := checkRouteClassification(routerGroup.BasePath())
+
+	// then
+	assert.NoError(t, err)
+
+	// PermissionChecker 와 Public 이 모두 없는 라우트가 있으면 실패한다.
+	routerGroup.GET("/reports", handler)
+	err = checkRouteClassification(routerGroup.BasePath())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "GET /api/reports")
+	assert.NotContains(t, err.Error(), "/api/auth")
+}
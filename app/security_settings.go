@@ -0,0 +1,15 @@
+package app
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	siteRepository "better-admin-backend-service/site/repository"
+	"context"
+	log "github.com/sirupsen/logrus"
+)
+
+func (a *App) loadSecuritySettings() error {
+	log.Info(">>> Load Security Settings")
+	ctx := helpers.ContextHelper().SetDB(context.Background(), a.gormDB)
+	return services.NewSiteService(&siteRepository.SiteSettingRepository{}, &siteRepository.SiteSettingVersionRepository{}).LoadSecuritySettings(ctx)
+}
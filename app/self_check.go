@@ -0,0 +1,284 @@
+package app
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/selfcheck"
+	"better-admin-backend-service/services"
+	siteRepository "better-admin-backend-service/site/repository"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"math"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 설정 파일에 들어 있는 기본 JWT Secret
+const defaultJwtSecret = "betterAdminSecret"
+
+// runSelfCheck 는 시작 점검을 실행한다. error 수준의 점검이 실패하면 시작하지 않고, warning 수준은 로그만 남긴다.
+func (a *App) runSelfCheck() error {
+	log.Info(">>> Self Check")
+	registerSelfChecks()
+
+	report := selfcheck.Run(helpers.ContextHelper().SetDB(context.Background(), a.gormDB))
+
+	failures := make([]string, 0)
+	for _, result := range report.Results {
+		if result.Passed {
+			continue
+		}
+
+		if result.Severity == constants.SelfCheckSeverityError {
+			log.Errorf("self check(%s) failed: %s", result.Name, result.Message)
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Message))
+		} else {
+			log.Warnf("self check(%s) warning: %s", result.Name, result.Message)
+		}
+	}
+
+	if !report.Healthy {
+		return pkgerrors.Errorf("self check failed. %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+func registerSelfChecks() {
+	selfcheck.Register(selfcheck.Check{Name: "database-connection", Severity: constants.SelfCheckSeverityError, Run: checkDatabaseConnection})
+	selfcheck.Register(selfcheck.Check{Name: "database-migration", Severity: constants.SelfCheckSeverityError, Run: checkDatabaseMigration})
+	selfcheck.Register(selfcheck.Check{Name: "sqlite-journal-mode", Severity: constants.SelfCheckSeverityWarning, Run: checkSqliteJournalMode})
+	selfcheck.Register(selfcheck.Check{Name: "shared-state", Severity: constants.SelfCheckSeverityError, Run: checkSharedState})
+	selfcheck.Register(selfcheck.Check{Name: "jwt-secret", Severity: constants.SelfCheckSeverityError, Run: checkJwtSecret})
+	selfcheck.Register(selfcheck.Check{Name: "jwt-secret-strength", Severity: constants.SelfCheckSeverityWarning, Run: checkJwtSecretStrength})
+	selfcheck.Register(selfcheck.Check{Name: "smtp", Severity: constants.SelfCheckSeverityWarning, Run: checkSmtp})
+	selfcheck.Register(selfcheck.Check{Name: "oauth", Severity: constants.SelfCheckSeverityWarning, Run: checkOAuth})
+	selfcheck.Register(selfcheck.Check{Name: "cookie-security", Severity: constants.SelfCheckSeverityWarning, Run: checkCookieSecurity})
+	selfcheck.Register(selfcheck.Check{Name: "clock-skew", Severity: constants.SelfCheckSeverityWarning, Run: checkClockSkew})
+}
+
+func checkDatabaseConnection(ctx context.Context) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	sqlDB, err := db.DB()
+	if err != nil {
+		// 트랜잭션 안에서 점검(예. 변경 요청을 적용한 뒤)하면 연결 풀을 얻을 수 없으므로 쿼리로 확인한다.
+		return db.Exec("SELECT 1").Error
+	}
+
+	return sqlDB.PingContext(ctx)
+}
+
+func checkDatabaseMigration(ctx context.Context) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	missingTables := make([]string, 0)
+	for _, entity := range migrationEntities {
+		if !db.Migrator().HasTable(entity) {
+			missingTables = append(missingTables, fmt.Sprintf("%T", entity))
+		}
+	}
+
+	if len(missingTables) > 0 {
+		return pkgerrors.Errorf("tables are not migrated. %s", strings.Join(missingTables, ", "))
+	}
+
+	return nil
+}
+
+// checkSqliteJournalMode 는 SQLite DB 파일이 설정한 journal mode 로 열렸는지 확인한다. 네트워크 파일 시스템 등은 WAL 을 지원하지 않아 다른 모드로 열린다.
+func checkSqliteJournalMode(ctx context.Context) error {
+	journalMode := config.Config.Database.SqliteJournalMode
+	if db.GetDriver() != db.DriverSqlite || len(journalMode) == 0 {
+		return nil
+	}
+
+	var current string
+	if err := helpers.ContextHelper().GetDB(ctx).Raw("PRAGMA journal_mode").Scan(&current).Error; err != nil {
+		return err
+	}
+
+	// 메모리 DB 는 journal mode 를 바꿀 수 없다.
+	if current == "memory" || strings.EqualFold(current, journalMode) {
+		return nil
+	}
+
+	return pkgerrors.Errorf("sqlite journal mode is %s, not %s. concurrent requests may fail with database is locked", current, journalMode)
+}
+
+// checkSharedState 는 공유 상태 저장소(SharedState.Backend)에 연결할 수 있는지 확인한다. 연결할 수 없으면 백그라운드 작업을 실행하지 못한다.
+func checkSharedState(ctx context.Context) error {
+	switch config.Config.SharedState.Backend {
+	case constants.SharedStateBackendMemory:
+		return nil
+	case constants.SharedStateBackendRedis:
+		_, _, err := adapters.SharedStateAdapter().Store().Get("self-check")
+		return err
+	default:
+		return pkgerrors.Errorf("%s shared state backend is not supported", config.Config.SharedState.Backend)
+	}
+}
+
+func checkJwtSecret(ctx context.Context) error {
+	secret, err := config.GetJwtSecret()
+	if err != nil {
+		return err
+	}
+
+	if len(secret) == 0 {
+		return pkgerrors.New("jwt secret is empty")
+	}
+
+	return nil
+}
+
+func checkJwtSecretStrength(ctx context.Context) error {
+	secret, err := config.GetJwtSecret()
+	if err != nil {
+		return err
+	}
+
+	if secret == defaultJwtSecret {
+		return pkgerrors.Errorf("default jwt secret is used. set %s environment variable or rotate the secret", config.EnvJwtSecret)
+	}
+
+	if len(secret) < config.Config.SelfCheck.MinJwtSecretLength {
+		return pkgerrors.Errorf("jwt secret is shorter than %d characters", config.Config.SelfCheck.MinJwtSecretLength)
+	}
+
+	return nil
+}
+
+func checkSmtp(ctx context.Context) error {
+	mailConfig := config.Config.Mail
+	if len(mailConfig.SmtpHost) == 0 {
+		// 메일을 사용하지 않는다.
+		return nil
+	}
+
+	if mailConfig.SmtpPort <= 0 || mailConfig.SmtpPort > math.MaxUint16 {
+		return pkgerrors.Errorf("invalid smtp port %d", mailConfig.SmtpPort)
+	}
+
+	if _, err := mail.ParseAddress(mailConfig.From); err != nil {
+		return pkgerrors.Errorf("invalid mail from address %q", mailConfig.From)
+	}
+
+	if len(mailConfig.Username) > 0 && len(mailConfig.Password) == 0 {
+		return pkgerrors.New("smtp password is empty")
+	}
+
+	return nil
+}
+
+func checkOAuth(ctx context.Context) error {
+	googleOAuth := config.Config.GoogleOAuth
+	for _, uri := range []string{googleOAuth.OAuthUri, googleOAuth.AuthUri, googleOAuth.TokenUri} {
+		if !isAbsoluteUrl(uri) {
+			return pkgerrors.Errorf("invalid google oauth uri %q", uri)
+		}
+	}
+
+	siteService := services.NewSiteService(&siteRepository.SiteSettingRepository{}, &siteRepository.SiteSettingVersionRepository{})
+
+	googleWorkspaceLoginSetting, err := siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		var setting dtos.GoogleWorkspaceLoginSetting
+		if err := mapstructure.Decode(googleWorkspaceLoginSetting, &setting); err != nil {
+			return err
+		}
+
+		if setting.Used != nil && *setting.Used {
+			if len(setting.ClientId) == 0 || len(setting.ClientSecret) == 0 {
+				return pkgerrors.New("google workspace login is used without client credentials")
+			}
+			if !isAbsoluteUrl(setting.RedirectUri) {
+				return pkgerrors.Errorf("invalid google workspace redirect uri %q", setting.RedirectUri)
+			}
+		}
+	}
+
+	doorayLoginSetting, err := siteService.GetSettingWithKey(ctx, constants.SettingKeyDoorayLogin)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+	if err == nil {
+		var setting dtos.DoorayLoginSetting
+		if err := mapstructure.Decode(doorayLoginSetting, &setting); err != nil {
+			return err
+		}
+
+		if setting.Used != nil && *setting.Used {
+			if len(setting.AuthorizationToken) == 0 {
+				return pkgerrors.New("dooray login is used without authorization token")
+			}
+			if !isAbsoluteUrl(config.Config.Dooray.LdapDialUrl) {
+				return pkgerrors.Errorf("invalid dooray ldap dial url %q", config.Config.Dooray.LdapDialUrl)
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkCookieSecurity(ctx context.Context) error {
+	if config.Config.RefreshTokenCookie.Secure {
+		return nil
+	}
+
+	// 외부에 노출되는 주소가 HTTPS 이면 쿠키도 HTTPS 로만 전송해야 한다.
+	for _, publicUrl := range []string{config.Config.SignIdChange.ConfirmUrl, config.Config.SignIdChange.CancelUrl} {
+		if strings.HasPrefix(publicUrl, "https://") {
+			return pkgerrors.New("service is served over https but refresh token cookie is not secure. set RefreshTokenCookie.Secure")
+		}
+	}
+
+	return pkgerrors.New("refresh token cookie is not secure. set RefreshTokenCookie.Secure when serving over TLS")
+}
+
+func checkClockSkew(ctx context.Context) error {
+	timeServerUrl := config.Config.SelfCheck.TimeServerUrl
+	if len(timeServerUrl) == 0 {
+		return nil
+	}
+
+	client := http.Client{Timeout: 5 * time.Second}
+	requestedAt := time.Now()
+	response, err := client.Head(timeServerUrl)
+	if err != nil {
+		return pkgerrors.Wrap(err, "time server request error")
+	}
+	defer response.Body.Close()
+
+	serverTime, err := http.ParseTime(response.Header.Get("Date"))
+	if err != nil {
+		return pkgerrors.Wrap(err, "time server date header error")
+	}
+
+	// Date 헤더는 초 단위이므로 요청 중간 시각과 비교한다.
+	localTime := requestedAt.Add(time.Since(requestedAt) / 2)
+	skew := localTime.Sub(serverTime)
+	if math.Abs(skew.Seconds()) > float64(config.Config.SelfCheck.MaxClockSkewSeconds) {
+		return pkgerrors.Errorf("clock skew is %v", skew.Round(time.Second))
+	}
+
+	return nil
+}
+
+func isAbsoluteUrl(rawUrl string) bool {
+	parsedUrl, err := url.Parse(rawUrl)
+	return err == nil && len(parsedUrl.Scheme) > 0 && len(parsedUrl.Host) > 0
+}
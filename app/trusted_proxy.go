@@ -0,0 +1,18 @@
+package app
+
+import (
+	"better-admin-backend-service/config"
+	log "github.com/sirupsen/logrus"
+)
+
+// setUpTrustedProxies 는 설정된 프록시(로드 밸런서)에서 온 요청만 X-Forwarded-For, X-Real-IP 헤더로 클라이언트 IP 를 찾도록 한다.
+func (a *App) setUpTrustedProxies() error {
+	trustedProxy := config.Config.TrustedProxy
+	log.Infof(">>> Set Up Trusted Proxies %v", trustedProxy.Cidrs)
+
+	if len(trustedProxy.Headers) > 0 {
+		a.gin.RemoteIPHeaders = trustedProxy.Headers
+	}
+
+	return a.gin.SetTrustedProxies(trustedProxy.Cidrs)
+}
@@ -0,0 +1,70 @@
+package app
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/backup"
+	"better-admin-backend-service/cluster"
+	"better-admin-backend-service/consumer"
+	"better-admin-backend-service/datamigration"
+	"better-admin-backend-service/scheduler"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"sync"
+)
+
+// Worker 는 서버와 함께 실행하는 백그라운드 작업이다. 서버를 시작하기 전에 등록한 순서대로 Start 하고 서버가 멈추면 반대 순서로 Stop 한다.
+type Worker struct {
+	Name  string
+	Start func(db *gorm.DB)
+	Stop  func()
+}
+
+var (
+	workerMutex sync.Mutex
+	workers     = []Worker{
+		{Name: "cluster", Start: func(*gorm.DB) { cluster.Start() }, Stop: cluster.Stop},
+		{Name: "data-migration", Start: datamigration.Start, Stop: datamigration.Stop},
+		{Name: "scheduler", Start: scheduler.Start, Stop: scheduler.Stop},
+		{Name: "consumer", Start: consumer.Start, Stop: consumer.Stop},
+		{
+			Name:  "log-shipping",
+			Start: func(*gorm.DB) { adapters.LogShippingAdapter().Start() },
+			Stop:  func() { adapters.LogShippingAdapter().Stop() },
+		},
+		{Name: "db-pool-monitor", Start: db.PoolMonitor().Start, Stop: db.PoolMonitor().Stop},
+		{Name: "database-backup", Start: backup.Start, Stop: backup.Stop},
+	}
+)
+
+// RegisterWorker 는 백그라운드 작업을 등록한다. 같은 이름의 작업이 있으면 교체한다.
+func RegisterWorker(worker Worker) {
+	workerMutex.Lock()
+	defer workerMutex.Unlock()
+
+	for i := range workers {
+		if workers[i].Name == worker.Name {
+			workers[i] = worker
+			return
+		}
+	}
+	workers = append(workers, worker)
+}
+
+// startWorkers 는 등록한 작업을 시작하고 반대 순서로 멈추는 함수를 반환한다.
+func startWorkers(db *gorm.DB) func() {
+	workerMutex.Lock()
+	started := append([]Worker{}, workers...)
+	workerMutex.Unlock()
+
+	for _, worker := range started {
+		log.Infof(">>> Start worker %s", worker.Name)
+		worker.Start(db)
+	}
+
+	return func() {
+		for i := len(started) - 1; i >= 0; i-- {
+			started[i].Stop()
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package application
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
+	"context"
+	"time"
+)
+
+// AuthUseCase 는 로그인, 로그인 유지(Refresh 토큰), 로그아웃 유스케이스이다.
+// 쿠키나 상태 코드는 다루지 않으므로 REST 외의 전송 계층(gRPC, CLI 등)도 같은 유스케이스를 사용한다.
+type AuthUseCase struct {
+	authService *services.AuthService
+}
+
+func NewAuthUseCase(authService *services.AuthService) *AuthUseCase {
+	return &AuthUseCase{
+		authService: authService,
+	}
+}
+
+// SignInOutput 은 로그인 결과이다. 전송 계층은 RefreshToken 을 RefreshTokenExpires 까지 보관하도록 전달한다.
+type SignInOutput struct {
+	AccessToken         string
+	RefreshToken        string
+	RefreshTokenExpires time.Time
+}
+
+// CustomSignInInput 은 등록된 외부 인증(Authenticator)의 이름과 인증 정보이다.
+type CustomSignInInput struct {
+	AuthenticatorName string
+	Credentials       map[string]string
+}
+
+// GoogleWorkspaceCallbackInput 은 구글 로그인 화면에서 돌아온 요청의 state 와 인가 코드(code)이다.
+type GoogleWorkspaceCallbackInput struct {
+	State string
+	Code  string
+}
+
+// GoogleWorkspaceCallbackOutput 은 구글 로그인 결과와 돌아갈 주소(Redirect)이다.
+// 계정 연결로 시작한 구글 로그인이면 로그인하지 않고(SignIn 이 비어 있음) IdentityLinked 가 true 이다.
+type GoogleWorkspaceCallbackOutput struct {
+	Redirect       string
+	IdentityLinked bool
+	SignIn         SignInOutput
+}
+
+// RefreshTokenStatus 는 사용할 수 있는 Refresh 토큰의 만료 시간이다.
+type RefreshTokenStatus struct {
+	Expires time.Time
+}
+
+func (u AuthUseCase) SignInWithPassword(ctx context.Context, input dtos.MemberSignIn) (SignInOutput, error) {
+	jwtToken, err := u.authService.AuthWithSignIdPassword(ctx, input)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return SignInOutput{}, newFailure(constants.FailureKindInvalidCredential, errors.ErrMemberNotFound)
+		}
+
+		if errors.Is(err, errors.ErrUnApproved) {
+			return SignInOutput{}, newFailure(constants.FailureKindUnapproved, err)
+		}
+
+		return SignInOutput{}, convertSignInError(err)
+	}
+
+	return newSignInOutput(jwtToken), nil
+}
+
+func (u AuthUseCase) SignInWithDooray(ctx context.Context, input dtos.MemberSignIn) (SignInOutput, error) {
+	jwtToken, err := u.authService.AuthWithDoorayIdAndPassword(ctx, input)
+	if err != nil {
+		return SignInOutput{}, convertSignInError(err)
+	}
+
+	return newSignInOutput(jwtToken), nil
+}
+
+func (u AuthUseCase) SignInWithCustomAuthenticator(ctx context.Context, input CustomSignInInput) (SignInOutput, error) {
+	jwtToken, err := u.authService.AuthWithCustomAuthenticator(ctx, input.AuthenticatorName, input.Credentials)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return SignInOutput{}, newFailureWithReason(constants.FailureKindNotFound, "authenticator not found", err)
+		}
+
+		return SignInOutput{}, convertSignInError(err)
+	}
+
+	return newSignInOutput(jwtToken), nil
+}
+
+func (u AuthUseCase) SignInWithBreakGlass(ctx context.Context, input dtos.BreakGlassSignIn) (SignInOutput, error) {
+	jwtToken, err := u.authService.AuthWithBreakGlassAccount(ctx, input)
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			return SignInOutput{}, newFailure(constants.FailureKindInvalidCredential, err)
+		}
+
+		return SignInOutput{}, err
+	}
+
+	return newSignInOutput(jwtToken), nil
+}
+
+// StartGoogleWorkspaceAuth 는 로그인 후 돌아갈 주소(redirect)를 담은 state 와 함께 보낼 구글 로그인 화면의 주소를 만든다.
+func (u AuthUseCase) StartGoogleWorkspaceAuth(ctx context.Context, redirect string) (string, error) {
+	oauthUri, err := u.authService.StartGoogleWorkspaceAuth(ctx, redirect)
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidTarget) {
+			return "", newFailureWithReason(constants.FailureKindInvalidRequest, "redirect is not allowed", err)
+		}
+
+		if errors.Is(err, errors.ErrNotFound) {
+			return "", newFailure(constants.FailureKindNotFound, nil)
+		}
+
+		return "", err
+	}
+
+	return oauthUri, nil
+}
+
+// CompleteGoogleWorkspaceAuth 는 구글 로그인 화면에서 돌아온 요청으로 로그인하거나, 계정 연결로 시작했으면 계정을 연결한다.
+// state 를 확인할 수 없으면 돌아갈 주소를 믿을 수 없으므로 Redirect 없이 FailureKindInvalidRequest 이다.
+// 그 밖의 오류는 Redirect 와 함께 반환하므로 전송 계층은 돌아갈 주소로 오류를 알린다.
+func (u AuthUseCase) CompleteGoogleWorkspaceAuth(ctx context.Context, input GoogleWorkspaceCallbackInput) (GoogleWorkspaceCallbackOutput, error) {
+	if memberId, redirect, err := u.authService.VerifyGoogleWorkspaceLinkState(input.State); err == nil {
+		if _, err := u.authService.LinkGoogleWorkspaceIdentity(ctx, memberId, input.Code); err != nil {
+			return GoogleWorkspaceCallbackOutput{Redirect: redirect}, err
+		}
+
+		return GoogleWorkspaceCallbackOutput{Redirect: redirect, IdentityLinked: true}, nil
+	}
+
+	redirect, err := u.authService.VerifyGoogleWorkspaceState(input.State)
+	if err != nil {
+		return GoogleWorkspaceCallbackOutput{}, newFailureWithReason(constants.FailureKindInvalidRequest, security.InvalidOAuthState.Error(), err)
+	}
+
+	jwtToken, err := u.authService.AuthWithGoogleWorkspaceAccount(ctx, input.Code)
+	if err != nil {
+		return GoogleWorkspaceCallbackOutput{Redirect: redirect}, convertSignInError(err)
+	}
+
+	return GoogleWorkspaceCallbackOutput{Redirect: redirect, SignIn: newSignInOutput(jwtToken)}, nil
+}
+
+// StepUp 은 비밀번호를 다시 확인하고 단계 인증 토큰을 발급한다.
+// 비밀번호가 틀려도 Access 토큰은 유효하므로 FailureKindUnauthorized 가 아닌 FailureKindInvalidCredential 이다.
+func (u AuthUseCase) StepUp(ctx context.Context, input dtos.StepUpRequest) (dtos.StepUpToken, error) {
+	stepUpToken, err := u.authService.StepUp(ctx, input)
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) || errors.Is(err, errors.ErrNotFound) {
+			return dtos.StepUpToken{}, newFailureWithReason(constants.FailureKindInvalidCredential, errors.ErrAuthentication.Error(), errors.ErrAuthentication)
+		}
+
+		return dtos.StepUpToken{}, err
+	}
+
+	return stepUpToken, nil
+}
+
+// CheckRefreshToken 은 로그인을 유지할 수 있는 Refresh 토큰인지 확인한다.
+func (AuthUseCase) CheckRefreshToken(refreshToken string) (RefreshTokenStatus, error) {
+	if len(refreshToken) == 0 {
+		return RefreshTokenStatus{}, newFailure(constants.FailureKindInvalidRefreshToken, nil)
+	}
+
+	jwtAuthentication := security.JwtAuthentication{}
+	if err := jwtAuthentication.ValidateToken(refreshToken); err != nil {
+		return RefreshTokenStatus{}, newFailure(constants.FailureKindInvalidRefreshToken, err)
+	}
+
+	expires, err := jwtAuthentication.GetTokenExpires(refreshToken)
+	if err != nil {
+		return RefreshTokenStatus{}, err
+	}
+
+	return RefreshTokenStatus{Expires: expires}, nil
+}
+
+// RefreshAccessToken 은 Refresh 토큰으로 Access 토큰을 발급한다.
+func (u AuthUseCase) RefreshAccessToken(ctx context.Context, refreshToken string) (string, error) {
+	accessToken, err := u.authService.RefreshAccessToken(ctx, refreshToken)
+	if err != nil {
+		if errors.Is(err, errors.ErrSessionRevoked) || errors.Is(err, errors.ErrClientMismatched) {
+			return "", newFailureWithReason(constants.FailureKindUnauthorized, err.Error(), err)
+		}
+
+		return "", err
+	}
+
+	return accessToken, nil
+}
+
+func (u AuthUseCase) Logout(ctx context.Context, refreshToken string) error {
+	return u.authService.Logout(ctx, refreshToken)
+}
+
+// convertSignInError 는 로그인 방법에 상관없이 같은 의미인 오류를 Failure 로 바꾼다.
+func convertSignInError(err error) error {
+	if errors.Is(err, errors.ErrAuthentication) {
+		return newFailure(constants.FailureKindInvalidCredential, err)
+	}
+
+	if errors.Is(err, errors.ErrUnApproved) {
+		return newFailure(constants.FailureKindUnapproved, err)
+	}
+
+	if errors.Is(err, errors.ErrSessionLimitExceeded) {
+		return newFailure(constants.FailureKindSessionLimitExceeded, err)
+	}
+
+	// 비밀번호를 다시 설정해야 하는 멤버는 비밀번호가 맞아도 로그인할 수 없으므로 인증 실패와 같이 응답하고 코드로 구분한다.
+	if errors.Is(err, errors.ErrPasswordResetNeeded) {
+		return newFailure(constants.FailureKindInvalidCredential, err)
+	}
+
+	if e, ok := err.(*errors.ErrPreAuthDenied); ok {
+		return newFailureWithReason(constants.FailureKindDenied, e.Reason, err)
+	}
+
+	// 멤버가 속한 조직에서 허용하지 않는 로그인 방법이다.
+	if errors.Is(err, errors.ErrLoginMethodNotAllowed) {
+		return newFailureWithReason(constants.FailureKindDenied, err.Error(), err)
+	}
+
+	// SSO 로 처음 로그인한 멤버를 만들 때 라이선스의 최대 멤버 수를 넘었거나 라이선스가 유효하지 않다.
+	switch err.(type) {
+	case *errors.ErrLicenseQuotaExceeded, *errors.ErrInvalidLicense:
+		return newFailureWithReason(constants.FailureKindDenied, err.Error(), err)
+	}
+
+	return err
+}
+
+func newSignInOutput(jwtToken security.JwtToken) SignInOutput {
+	return SignInOutput{
+		AccessToken:         jwtToken.AccessToken,
+		RefreshToken:        jwtToken.RefreshToken,
+		RefreshTokenExpires: jwtToken.RefreshTokenExpires,
+	}
+}
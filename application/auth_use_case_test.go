@@ -0,0 +1,36 @@
+package application
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConvertSignInError(t *testing.T) {
+	// given
+	unknownErr := pkgerrors.New("db error")
+
+	// when
+	authentication := convertSignInError(errors.ErrAuthentication)
+	sessionLimit := convertSignInError(errors.ErrSessionLimitExceeded)
+	preAuthDenied := convertSignInError(&errors.ErrPreAuthDenied{Reason: "퇴사자입니다"})
+	unknown := convertSignInError(unknownErr)
+
+	// then
+	assert.Equal(t, constants.FailureKindInvalidCredential, authentication.(*Failure).Kind)
+	assert.ErrorIs(t, authentication, errors.ErrAuthentication)
+	assert.Equal(t, constants.FailureKindSessionLimitExceeded, sessionLimit.(*Failure).Kind)
+	assert.Equal(t, constants.FailureKindDenied, preAuthDenied.(*Failure).Kind)
+	assert.Equal(t, "퇴사자입니다", preAuthDenied.(*Failure).Reason)
+	assert.Equal(t, unknownErr, unknown)
+}
+
+func TestAuthUseCase_CheckRefreshToken_빈_토큰(t *testing.T) {
+	// when
+	_, err := AuthUseCase{}.CheckRefreshToken("")
+
+	// then
+	assert.Equal(t, constants.FailureKindInvalidRefreshToken, err.(*Failure).Kind)
+}
@@ -0,0 +1,35 @@
+package application
+
+import "fmt"
+
+// Failure 는 유스케이스가 실패한 이유이다. 전송 계층(REST, gRPC, CLI 등)은 Kind 로 응답 형식(상태 코드 등)을 정한다.
+// Reason 이 있으면 사용자에게 보여줄 수 있는 이유이다.
+type Failure struct {
+	Kind   string
+	Reason string
+	Err    error
+}
+
+func (f *Failure) Error() string {
+	if len(f.Reason) > 0 {
+		return fmt.Sprintf("%s: %s", f.Kind, f.Reason)
+	}
+
+	if f.Err != nil {
+		return fmt.Sprintf("%s: %v", f.Kind, f.Err)
+	}
+
+	return f.Kind
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+func newFailure(kind string, err error) *Failure {
+	return &Failure{Kind: kind, Err: err}
+}
+
+func newFailureWithReason(kind string, reason string, err error) *Failure {
+	return &Failure{Kind: kind, Reason: reason, Err: err}
+}
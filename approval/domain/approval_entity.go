@@ -0,0 +1,300 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ApprovalRequestEntity 는 승인 단계를 순서대로 거치는 승인 요청이다.
+// 요청 시점의 승인 단계 정의를 Steps 에 보관하므로 설정이 바뀌어도 진행 중인 요청에는 영향이 없다.
+type ApprovalRequestEntity struct {
+	gorm.Model
+	Subject       string `gorm:"type:varchar(50);not null;index"`
+	TargetId      uint   `gorm:"index"`
+	Payload       string `gorm:"type:text"`
+	Status        string `gorm:"type:varchar(20);not null"`
+	Steps         string `gorm:"type:text"`
+	CurrentStep   int
+	StepStartedAt time.Time
+	RemindedAt    *time.Time
+	EscalatedAt   *time.Time
+	// ConsumedAt 은 승인 후 요청자가 직접 실행하는 대상(예. API Key 발급)을 실행한 시간이다.
+	ConsumedAt  *time.Time
+	RequestedBy uint
+	// RequestedByServiceAccount 는 서비스 계정이 요청했는지이다. RequestedBy 가 같아도 멤버와 서비스 계정은 다른 요청자이다.
+	RequestedByServiceAccount bool                     `gorm:"not null;default:false"`
+	Decisions                 []ApprovalDecisionEntity `gorm:"foreignKey:ApprovalRequestId"`
+}
+
+func (ApprovalRequestEntity) TableName() string {
+	return "approval_requests"
+}
+
+type ApprovalDecisionEntity struct {
+	gorm.Model
+	ApprovalRequestId uint `gorm:"not null;index"`
+	Step              int  `gorm:"not null"`
+	ApproverId        uint `gorm:"not null"`
+	OnBehalfOf        uint
+	Decision          string `gorm:"type:varchar(20);not null"`
+	Comment           string `gorm:"type:varchar(1000)"`
+}
+
+func (ApprovalDecisionEntity) TableName() string {
+	return "approval_decisions"
+}
+
+// principalId 는 위임 받아 처리한 경우 위임한 멤버를 반환한다.
+func (d ApprovalDecisionEntity) principalId() uint {
+	if d.OnBehalfOf != 0 {
+		return d.OnBehalfOf
+	}
+
+	return d.ApproverId
+}
+
+// Approver 는 승인/반려하는 멤버이다.
+// 위임 받아 대신 처리하는 경우 OnBehalfOf 는 위임한 멤버, RoleNames 는 위임한 멤버의 역할이다.
+type Approver struct {
+	Id         uint
+	RoleNames  []string
+	OnBehalfOf uint
+}
+
+func (a Approver) principalId() uint {
+	if a.OnBehalfOf != 0 {
+		return a.OnBehalfOf
+	}
+
+	return a.Id
+}
+
+// IsRequestedBy 는 userClaim 이 요청했는지 확인한다.
+func (a ApprovalRequestEntity) IsRequestedBy(userClaim security.UserClaim) bool {
+	return a.RequestedBy == userClaim.Id && a.RequestedByServiceAccount == userClaim.IsServiceAccount()
+}
+
+func (a ApprovalRequestEntity) GetSteps() []dtos.ApprovalStep {
+	var steps []dtos.ApprovalStep
+	if err := json.Unmarshal([]byte(a.Steps), &steps); err != nil {
+		return []dtos.ApprovalStep{}
+	}
+
+	return steps
+}
+
+func (a ApprovalRequestEntity) GetCurrentStep() (dtos.ApprovalStep, bool) {
+	steps := a.GetSteps()
+	if a.CurrentStep >= len(steps) {
+		return dtos.ApprovalStep{}, false
+	}
+
+	return steps[a.CurrentStep], true
+}
+
+func (a ApprovalRequestEntity) IsPending() bool {
+	return a.Status == constants.ApprovalStatusPending
+}
+
+// GetApproverRoleNames 는 현재 단계를 승인할 수 있는 역할 이름을 반환한다.
+// 기한이 지나 상위 승인자에게 넘어간 경우 상위 승인 역할도 포함된다.
+func (a ApprovalRequestEntity) GetApproverRoleNames() []string {
+	step, ok := a.GetCurrentStep()
+	if !ok {
+		return []string{}
+	}
+
+	roleNames := []string{step.ApproverRoleName}
+	if a.EscalatedAt != nil && len(step.EscalationRoleName) > 0 {
+		roleNames = append(roleNames, step.EscalationRoleName)
+	}
+
+	return roleNames
+}
+
+func (a ApprovalRequestEntity) CanDecide(roleNames []string) bool {
+	if !a.IsPending() {
+		return false
+	}
+
+	for _, approverRoleName := range a.GetApproverRoleNames() {
+		for _, roleName := range roleNames {
+			if approverRoleName == roleName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Approve 는 현재 단계에 승인을 기록하고, 필요한 승인 수를 채우면 다음 단계로 넘어간다.
+// 마지막 단계까지 승인되면 completed 가 true 이다.
+func (a *ApprovalRequestEntity) Approve(approver Approver, comment string) (completed bool, err error) {
+	if err := a.checkDecidable(approver); err != nil {
+		return false, err
+	}
+
+	// 멤버 중요 필드 변경과 임시 권한 상승은 요청한 멤버가 아닌 다른 멤버의 승인이 있어야 반영된다.
+	if (a.Subject == constants.ApprovalSubjectMemberFieldChange || a.Subject == constants.ApprovalSubjectRoleElevation) &&
+		!a.RequestedByServiceAccount && approver.principalId() == a.RequestedBy {
+		return false, errors.ErrSelfReview
+	}
+
+	a.addDecision(approver, constants.ApprovalDecisionApproved, comment)
+
+	step, _ := a.GetCurrentStep()
+	if a.countCurrentStepApprovals() < step.GetRequiredApprovals() {
+		return false, nil
+	}
+
+	a.CurrentStep++
+	a.StepStartedAt = time.Now()
+	a.RemindedAt = nil
+	a.EscalatedAt = nil
+
+	if a.CurrentStep >= len(a.GetSteps()) {
+		a.Status = constants.ApprovalStatusApproved
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (a *ApprovalRequestEntity) Reject(approver Approver, comment string) error {
+	if err := a.checkDecidable(approver); err != nil {
+		return err
+	}
+
+	a.addDecision(approver, constants.ApprovalDecisionRejected, comment)
+	a.Status = constants.ApprovalStatusRejected
+	return nil
+}
+
+// Expire 는 기한까지 처리되지 않은 요청을 만료한다. 만료된 요청은 더 이상 승인/반려할 수 없다.
+func (a *ApprovalRequestEntity) Expire() error {
+	if !a.IsPending() {
+		return errors.ErrNonChangeable
+	}
+
+	a.Status = constants.ApprovalStatusExpired
+	return nil
+}
+
+func (a ApprovalRequestEntity) NeedsReminder(now time.Time) bool {
+	step, ok := a.GetCurrentStep()
+	if !ok || !a.IsPending() || step.ReminderHours == 0 || a.RemindedAt != nil {
+		return false
+	}
+
+	return a.StepStartedAt.Add(time.Duration(step.ReminderHours) * time.Hour).Before(now)
+}
+
+func (a ApprovalRequestEntity) NeedsEscalation(now time.Time) bool {
+	step, ok := a.GetCurrentStep()
+	if !ok || !a.IsPending() || step.EscalationHours == 0 || len(step.EscalationRoleName) == 0 || a.EscalatedAt != nil {
+		return false
+	}
+
+	return a.StepStartedAt.Add(time.Duration(step.EscalationHours) * time.Hour).Before(now)
+}
+
+func (a *ApprovalRequestEntity) MarkReminded() {
+	now := time.Now()
+	a.RemindedAt = &now
+}
+
+func (a *ApprovalRequestEntity) Escalate() {
+	now := time.Now()
+	a.EscalatedAt = &now
+}
+
+func (a *ApprovalRequestEntity) Consume() {
+	now := time.Now()
+	a.ConsumedAt = &now
+}
+
+func (a ApprovalRequestEntity) DecodePayload(payload interface{}) error {
+	if err := json.Unmarshal([]byte(a.Payload), payload); err != nil {
+		return pkgerrors.Wrap(err, "approval payload decode error")
+	}
+
+	return nil
+}
+
+func (a ApprovalRequestEntity) checkDecidable(approver Approver) error {
+	if !a.IsPending() {
+		return errors.ErrNonChangeable
+	}
+
+	if !a.CanDecide(approver.RoleNames) {
+		return errors.ErrForbidden
+	}
+
+	// 같은 단계에서 한 사람이(위임 받은 멤버가 대신 처리한 경우 포함) 두 번 승인할 수 없다.
+	for _, decision := range a.Decisions {
+		if decision.Step == a.CurrentStep && decision.principalId() == approver.principalId() {
+			return errors.ErrDuplicated
+		}
+	}
+
+	return nil
+}
+
+func (a *ApprovalRequestEntity) addDecision(approver Approver, decision string, comment string) {
+	a.Decisions = append(a.Decisions, ApprovalDecisionEntity{
+		Step:       a.CurrentStep,
+		ApproverId: approver.Id,
+		OnBehalfOf: approver.OnBehalfOf,
+		Decision:   decision,
+		Comment:    comment,
+	})
+}
+
+func (a ApprovalRequestEntity) countCurrentStepApprovals() int {
+	count := 0
+	for _, decision := range a.Decisions {
+		if decision.Step == a.CurrentStep && decision.Decision == constants.ApprovalDecisionApproved {
+			count++
+		}
+	}
+
+	return count
+}
+
+func NewApprovalRequestEntity(ctx context.Context, workflow dtos.ApprovalWorkflow, targetId uint, payload interface{}) (ApprovalRequestEntity, error) {
+	steps, err := json.Marshal(workflow.Steps)
+	if err != nil {
+		return ApprovalRequestEntity{}, pkgerrors.Wrap(err, "approval steps encode error")
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return ApprovalRequestEntity{}, pkgerrors.Wrap(err, "approval payload encode error")
+	}
+
+	entity := ApprovalRequestEntity{
+		Subject:       workflow.Subject,
+		TargetId:      targetId,
+		Payload:       string(payloadBytes),
+		Status:        constants.ApprovalStatusPending,
+		Steps:         string(steps),
+		StepStartedAt: time.Now(),
+	}
+
+	// 회원 가입처럼 로그인 하지 않은 요청도 있다.
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(ctx); err == nil {
+		entity.RequestedBy = userClaim.Id
+		entity.RequestedByServiceAccount = userClaim.IsServiceAccount()
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ApprovalDelegationEntity 는 부재 기간(StartsAt ~ EndsAt) 동안 승인 권한을 다른 멤버에게 위임한 것이다.
+type ApprovalDelegationEntity struct {
+	gorm.Model
+	DelegatorId uint      `gorm:"not null;index"`
+	DelegateId  uint      `gorm:"not null;index"`
+	StartsAt    time.Time `gorm:"not null"`
+	EndsAt      time.Time `gorm:"not null"`
+	Reason      string    `gorm:"type:varchar(200)"`
+}
+
+func (ApprovalDelegationEntity) TableName() string {
+	return "approval_delegations"
+}
+
+func (d ApprovalDelegationEntity) IsActive(now time.Time) bool {
+	return !now.Before(d.StartsAt) && now.Before(d.EndsAt)
+}
+
+func (d *ApprovalDelegationEntity) Update(ctx context.Context, information dtos.ApprovalDelegationInformation) error {
+	if err := d.checkOwner(ctx); err != nil {
+		return err
+	}
+
+	if err := validateDelegation(d.DelegatorId, information); err != nil {
+		return err
+	}
+
+	d.DelegateId = information.DelegateId
+	d.StartsAt = information.StartsAt
+	d.EndsAt = information.EndsAt
+	d.Reason = information.Reason
+	return nil
+}
+
+// checkOwner 는 위임한 멤버만 위임을 변경할 수 있게 한다.
+func (d ApprovalDelegationEntity) checkOwner(ctx context.Context) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userClaim.Id != d.DelegatorId {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
+
+func (d ApprovalDelegationEntity) CheckDeletable(ctx context.Context) error {
+	return d.checkOwner(ctx)
+}
+
+func validateDelegation(delegatorId uint, information dtos.ApprovalDelegationInformation) error {
+	if !information.StartsAt.Before(information.EndsAt) {
+		return errors.ErrInvalidPeriod
+	}
+
+	if information.DelegateId == delegatorId {
+		return errors.ErrNonChangeable
+	}
+
+	return nil
+}
+
+func NewApprovalDelegationEntity(ctx context.Context, information dtos.ApprovalDelegationInformation) (ApprovalDelegationEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return ApprovalDelegationEntity{}, err
+	}
+
+	if err := validateDelegation(userClaim.Id, information); err != nil {
+		return ApprovalDelegationEntity{}, err
+	}
+
+	return ApprovalDelegationEntity{
+		DelegatorId: userClaim.Id,
+		DelegateId:  information.DelegateId,
+		StartsAt:    information.StartsAt,
+		EndsAt:      information.EndsAt,
+		Reason:      information.Reason,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"better-admin-backend-service/approval/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ApprovalRequestRepository struct {
+}
+
+func (ApprovalRequestRepository) Create(ctx context.Context, entity *domain.ApprovalRequestEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ApprovalRequestRepository) Save(ctx context.Context, entity *domain.ApprovalRequestEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ApprovalRequestRepository) FindById(ctx context.Context, id uint) (domain.ApprovalRequestEntity, error) {
+	var entity domain.ApprovalRequestEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Preload("Decisions").First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (ApprovalRequestRepository) FindAllPending(ctx context.Context) ([]domain.ApprovalRequestEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ApprovalRequestEntity, 0)
+	if err := db.Where("status = ?", constants.ApprovalStatusPending).
+		Preload("Decisions").
+		Order("created_at asc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (ApprovalRequestRepository) FindBySubjectAndTargetId(ctx context.Context, subject string, targetId uint, status string) ([]domain.ApprovalRequestEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ApprovalRequestEntity, 0)
+	if err := db.Where("subject = ? AND target_id = ? AND status = ?", subject, targetId, status).
+		Preload("Decisions").
+		Order("created_at asc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindByTargetId 는 대상(TargetId)의 승인 요청을 상태와 관계없이 요청한 순서로 조회한다.
+func (ApprovalRequestRepository) FindByTargetId(ctx context.Context, subjects []string, targetId uint) ([]domain.ApprovalRequestEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ApprovalRequestEntity, 0)
+	if err := db.Where("subject IN ? AND target_id = ?", subjects, targetId).
+		Preload("Decisions").
+		Order("created_at asc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
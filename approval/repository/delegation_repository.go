@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"better-admin-backend-service/approval/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ApprovalDelegationRepository struct {
+}
+
+func (ApprovalDelegationRepository) Create(ctx context.Context, entity *domain.ApprovalDelegationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ApprovalDelegationRepository) Save(ctx context.Context, entity *domain.ApprovalDelegationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ApprovalDelegationRepository) Delete(ctx context.Context, entity domain.ApprovalDelegationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ApprovalDelegationRepository) FindById(ctx context.Context, id uint) (domain.ApprovalDelegationEntity, error) {
+	var entity domain.ApprovalDelegationEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (ApprovalDelegationRepository) FindByDelegatorId(ctx context.Context, delegatorId uint) ([]domain.ApprovalDelegationEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ApprovalDelegationEntity, 0)
+	if err := db.Where(&domain.ApprovalDelegationEntity{DelegatorId: delegatorId}).
+		Order("starts_at desc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindActiveByDelegateId 는 지금 위임 받아 대신 승인할 수 있는 위임을 조회한다.
+func (ApprovalDelegationRepository) FindActiveByDelegateId(ctx context.Context, delegateId uint, now time.Time) ([]domain.ApprovalDelegationEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ApprovalDelegationEntity, 0)
+	if err := db.Where("delegate_id = ? AND starts_at <= ? AND ends_at > ?", delegateId, now, now).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindActiveByDelegatorId 는 지금 부재 중인 멤버의 위임을 조회한다.
+func (ApprovalDelegationRepository) FindActiveByDelegatorId(ctx context.Context, delegatorId uint, now time.Time) ([]domain.ApprovalDelegationEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ApprovalDelegationEntity, 0)
+	if err := db.Where("delegator_id = ? AND starts_at <= ? AND ends_at > ?", delegatorId, now, now).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
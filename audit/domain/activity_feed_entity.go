@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"gorm.io/gorm"
+	"time"
+)
+
+// activitySummaries 는 활동 피드에 보여줄 동작 설명이다. 없는 동작은 동작 이름을 그대로 보여준다.
+var activitySummaries = map[string]string{
+	constants.ActivityActionLogin:                     "로그인했습니다.",
+	constants.AuditActionSessionRevoked:               "세션을 강제로 종료했습니다.",
+	constants.AuditActionForceLogout:                  "모든 사용자를 로그아웃 시켰습니다.",
+	constants.AuditActionRotateSecret:                 "JWT Secret 을 교체했습니다.",
+	constants.AuditActionServiceAccountCreated:        "서비스 계정을 만들었습니다.",
+	constants.AuditActionServiceAccountDeleted:        "서비스 계정을 삭제했습니다.",
+	constants.AuditActionServiceAccountRoleAssigned:   "서비스 계정에 역할을 할당했습니다.",
+	constants.AuditActionApiKeyIssued:                 "API Key 를 발급했습니다.",
+	constants.AuditActionClientSecretIssued:           "Client Secret 을 발급했습니다.",
+	constants.AuditActionCredentialsRevoked:           "API Key 와 Client Secret 을 폐기했습니다.",
+	constants.AuditActionConsentGranted:               "OAuth Client 에 동의했습니다.",
+	constants.AuditActionConsentRevoked:               "OAuth Client 동의를 철회했습니다.",
+	constants.AuditActionSignIdChangeRequested:        "아이디 변경을 요청했습니다.",
+	constants.AuditActionSignIdChangeConfirmed:        "아이디를 변경했습니다.",
+	constants.AuditActionSignIdChangeCanceled:         "아이디 변경을 취소했습니다.",
+	constants.AuditActionLoginReported:                "본인이 하지 않은 로그인을 신고했습니다.",
+	constants.AuditActionPasswordReset:                "비밀번호를 다시 설정했습니다.",
+	constants.AuditActionApprovalRequested:            "승인을 요청했습니다.",
+	constants.AuditActionApprovalStepApproved:         "승인 단계를 승인했습니다.",
+	constants.AuditActionApprovalApproved:             "승인 요청이 최종 승인되었습니다.",
+	constants.AuditActionApprovalRejected:             "승인 요청을 반려했습니다.",
+	constants.AuditActionApprovalEscalated:            "승인 요청이 상위 승인자에게 이관되었습니다.",
+	constants.AuditActionApprovalDelegated:            "승인 권한을 위임했습니다.",
+	constants.AuditActionApprovalDelegationDeleted:    "승인 권한 위임을 취소했습니다.",
+	constants.AuditActionSignUpEscalated:              "가입 신청이 상위 승인자에게 이관되었습니다.",
+	constants.AuditActionSignUpExpired:                "가입 신청이 기한이 지나 거절되었습니다.",
+	constants.AuditActionMembersBulkApproved:          "가입 신청을 일괄 승인했습니다.",
+	constants.AuditActionMemberAutoApproved:           "가입 신청이 메일 도메인 규칙으로 자동 승인되었습니다.",
+	constants.AuditActionNoteCreated:                  "메모를 남겼습니다.",
+	constants.AuditActionNoteUpdated:                  "메모를 수정했습니다.",
+	constants.AuditActionNoteDeleted:                  "메모를 삭제했습니다.",
+	constants.AuditActionAttachmentAdded:              "첨부 파일을 추가했습니다.",
+	constants.AuditActionAttachmentDownloaded:         "첨부 파일을 내려받았습니다.",
+	constants.AuditActionAttachmentExpired:            "보관 기간이 지난 첨부 파일을 삭제했습니다.",
+	constants.AuditActionMemberDataExported:           "멤버 데이터를 내보냈습니다.",
+	constants.AuditActionMemberDeprovisioned:          "멤버를 해지하고 소유한 리소스를 후임자에게 넘겼습니다.",
+	constants.AuditActionResourceOwnershipTransferred: "리소스의 소유자를 바꿨습니다.",
+}
+
+// ActivityFeedEntity 는 감사 로그와 로그인 기록을 활동 피드로 조회하기 위한 비정규화된 Projection 이다.
+// 기록 시점에 설명(Summary)을 만들어 두므로 조회할 때 다른 테이블을 조인하지 않는다.
+type ActivityFeedEntity struct {
+	gorm.Model
+	EventType  string `gorm:"type:varchar(20);not null;index"`
+	Action     string `gorm:"type:varchar(50);not null"`
+	ActorType  string `gorm:"type:varchar(20);not null;index:idx_activity_feed_actor"`
+	ActorId    uint   `gorm:"index:idx_activity_feed_actor"`
+	EntityType string `gorm:"type:varchar(50);index:idx_activity_feed_entity"`
+	EntityId   uint   `gorm:"index:idx_activity_feed_entity"`
+	Summary    string `gorm:"type:varchar(200)"`
+	Detail     string `gorm:"type:text"`
+	AuditLogId uint
+	OccurredAt time.Time `gorm:"not null;index"`
+}
+
+func (ActivityFeedEntity) TableName() string {
+	return "activity_feeds"
+}
+
+func NewActivityFeedEntityFromAuditLog(auditLog AuditLogEntity) ActivityFeedEntity {
+	eventType := constants.ActivityEventTypeAudit
+	if auditLog.TargetType == constants.AuditTargetTypeApproval || auditLog.TargetType == constants.AuditTargetTypeApprovalDelegation {
+		eventType = constants.ActivityEventTypeApproval
+	}
+	switch auditLog.Action {
+	case constants.AuditActionNoteCreated, constants.AuditActionNoteUpdated, constants.AuditActionNoteDeleted:
+		eventType = constants.ActivityEventTypeNote
+	}
+
+	return ActivityFeedEntity{
+		EventType:  eventType,
+		Action:     auditLog.Action,
+		ActorType:  auditLog.ActorType,
+		ActorId:    auditLog.ActorId,
+		EntityType: auditLog.TargetType,
+		EntityId:   auditLog.TargetId,
+		Summary:    summarizeActivity(auditLog.Action),
+		Detail:     auditLog.Detail,
+		AuditLogId: auditLog.ID,
+		OccurredAt: auditLog.CreatedAt,
+	}
+}
+
+func NewLoginActivityFeedEntity(memberId uint, sessionId uint) ActivityFeedEntity {
+	return ActivityFeedEntity{
+		EventType:  constants.ActivityEventTypeLogin,
+		Action:     constants.ActivityActionLogin,
+		ActorType:  constants.AuditActorTypeMember,
+		ActorId:    memberId,
+		EntityType: constants.AuditTargetTypeSession,
+		EntityId:   sessionId,
+		Summary:    summarizeActivity(constants.ActivityActionLogin),
+		OccurredAt: time.Now(),
+	}
+}
+
+func summarizeActivity(action string) string {
+	if summary, ok := activitySummaries[action]; ok {
+		return summary
+	}
+
+	return action
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+type AuditLogEntity struct {
+	gorm.Model
+	ActorType  string `gorm:"type:varchar(20);not null"`
+	ActorId    uint
+	Action     string `gorm:"type:varchar(50);not null"`
+	TargetType string `gorm:"type:varchar(50)"`
+	TargetId   uint
+	Detail     string `gorm:"type:text"`
+	// 요청한 클라이언트의 IP 와 GeoIP 로 찾은 위치
+	IpAddress       string `gorm:"type:varchar(45)"`
+	Country         string `gorm:"type:varchar(2)"`
+	City            string `gorm:"type:varchar(100)"`
+	Asn             uint
+	AsnOrganization string `gorm:"type:varchar(200)"`
+	// ElevationId 는 임시 권한 상승 중인 멤버가 남긴 감사 로그의 상승 ID 이다.
+	ElevationId uint `gorm:"index"`
+}
+
+func (AuditLogEntity) TableName() string {
+	return "audit_logs"
+}
+
+func NewAuditLogEntity(ctx context.Context, action, targetType string, targetId uint, detail string) AuditLogEntity {
+	entity := AuditLogEntity{
+		ActorType:  constants.AuditActorTypeSystem,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Detail:     detail,
+	}
+
+	// 로그인 한 사용자의 요청이 아닌 경우(예. 로그인 처리 중) 시스템이 수행한 것으로 기록한다.
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err == nil {
+		entity.ActorType = constants.AuditActorTypeMember
+		if userClaim.IsServiceAccount() {
+			entity.ActorType = constants.AuditActorTypeServiceAccount
+		}
+		entity.ActorId = userClaim.Id
+	}
+
+	entity.IpAddress = helpers.ContextHelper().GetClientIp(ctx)
+	location := adapters.GeoIpAdapter().Lookup(entity.IpAddress)
+	entity.Country = location.Country
+	entity.City = location.City
+	entity.Asn = location.Asn
+	entity.AsnOrganization = location.AsnOrganization
+
+	return entity
+}
+
+// ToEvent 는 이벤트 버스로 보낼 감사 이벤트를 만든다.
+func (a AuditLogEntity) ToEvent() dtos.Event {
+	return dtos.Event{
+		Id:          fmt.Sprintf("%s-%d", constants.EventTypeAudit, a.ID),
+		Type:        constants.EventTypeAudit,
+		Action:      a.Action,
+		OccurredAt:  a.CreatedAt,
+		ActorType:   a.ActorType,
+		ActorId:     a.ActorId,
+		TargetType:  a.TargetType,
+		TargetId:    a.TargetId,
+		Succeeded:   true,
+		Detail:      a.Detail,
+		IpAddress:   a.IpAddress,
+		Country:     a.Country,
+		City:        a.City,
+		ElevationId: a.ElevationId,
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ActivityFeedRepository struct {
+}
+
+func (ActivityFeedRepository) Create(ctx context.Context, entity *domain.ActivityFeedEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindByMemberId 는 멤버가 한 활동과 멤버를 대상으로 한 활동을 조회한다.
+func (r ActivityFeedRepository) FindByMemberId(ctx context.Context, memberId uint, eventTypes []string, pageable dtos.Pageable) ([]domain.ActivityFeedEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ActivityFeedEntity{}).
+		Where("(actor_type = ? AND actor_id = ?) OR (entity_type = ? AND entity_id = ?)",
+			constants.AuditActorTypeMember, memberId, constants.AuditTargetTypeMember, memberId)
+
+	return r.find(db, eventTypes, pageable)
+}
+
+func (r ActivityFeedRepository) FindByEntity(ctx context.Context, entityType string, entityId uint, eventTypes []string, pageable dtos.Pageable) ([]domain.ActivityFeedEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ActivityFeedEntity{}).
+		Where("entity_type = ? AND entity_id = ?", entityType, entityId)
+
+	return r.find(db, eventTypes, pageable)
+}
+
+func (ActivityFeedRepository) find(db *gorm.DB, eventTypes []string, pageable dtos.Pageable) ([]domain.ActivityFeedEntity, int64, error) {
+	if len(eventTypes) > 0 {
+		db = db.Where("event_type IN ?", eventTypes)
+	}
+
+	var entities = make([]domain.ActivityFeedEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("occurred_at desc, id desc").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
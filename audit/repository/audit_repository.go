@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type AuditLogRepository struct {
+}
+
+func (AuditLogRepository) Create(ctx context.Context, entity *domain.AuditLogEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindByElevationId 는 임시 권한 상승 중에 기록한 감사 로그를 기록한 순으로 조회한다.
+func (AuditLogRepository) FindByElevationId(ctx context.Context, elevationId uint) ([]domain.AuditLogEntity, error) {
+	var entities = make([]domain.AuditLogEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).Where("elevation_id = ?", elevationId).
+		Order("id").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
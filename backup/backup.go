@@ -0,0 +1,295 @@
+package backup
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/cluster"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 백업 목록은 DB 를 복원해도 바뀌지 않도록 DB 가 아닌 파일 저장소에 저장한다.
+const manifestName = "manifest.json"
+
+// 서버를 자주 다시 시작해도 백업하도록 주기마다 마지막 백업 시각을 확인한다.
+const checkInterval = 10 * time.Minute
+
+// 백업하는 동안 연장하는 잠금의 유지 시간
+const lockTtl = time.Minute
+
+var (
+	mutex     sync.Mutex
+	stopped   chan struct{}
+	stopMutex sync.Mutex
+	waitGroup sync.WaitGroup
+)
+
+// Start 는 설정(Backup.Enabled)이면 마지막 주기 백업 후 IntervalHours 가 지났을 때 백업한다.
+func Start(gormDB *gorm.DB) {
+	if !config.Config.Backup.Enabled {
+		return
+	}
+
+	stopMutex.Lock()
+	defer stopMutex.Unlock()
+
+	if stopped != nil {
+		return
+	}
+	stopped = make(chan struct{})
+
+	waitGroup.Add(1)
+	go func(stopped chan struct{}) {
+		defer waitGroup.Done()
+
+		ticker := time.NewTicker(checkInterval)
+		defer ticker.Stop()
+		for {
+			// 여러 인스턴스가 같은 주기에 백업하지 않도록 잠근 인스턴스만 확인한다.
+			if _, err := cluster.RunExclusive(constants.ClusterLockDatabaseBackup, lockTtl, func() { backupIfDue(gormDB, time.Now()) }); err != nil {
+				log.Error("backup lock error: ", err)
+			}
+
+			select {
+			case <-ticker.C:
+			case <-stopped:
+				return
+			}
+		}
+	}(stopped)
+}
+
+// Stop 은 진행 중인 백업을 마칠 때까지 기다린다.
+func Stop() {
+	stopMutex.Lock()
+	if stopped == nil {
+		stopMutex.Unlock()
+		return
+	}
+	close(stopped)
+	stopped = nil
+	stopMutex.Unlock()
+
+	waitGroup.Wait()
+}
+
+func backupIfDue(gormDB *gorm.DB, now time.Time) {
+	backups, err := GetBackups()
+	if err != nil {
+		log.Error("backup manifest error: ", err)
+		return
+	}
+
+	interval := time.Duration(config.Config.Backup.IntervalHours) * time.Hour
+	for _, backup := range backups {
+		if backup.Reason == constants.BackupReasonScheduled && now.Sub(backup.CreatedAt) < interval {
+			return
+		}
+	}
+
+	backup, err := CreateBackup(gormDB, constants.BackupReasonScheduled)
+	if err != nil {
+		log.Errorf("backup error: %+v", err)
+		return
+	}
+	log.Infof(">>> Database backup %v (%d bytes)", backup.Key, backup.SizeBytes)
+}
+
+// CreateBackup 은 DB 를 백업하여 파일 저장소에 저장하고 최근 Retain 개를 넘는 오래된 백업을 지운다.
+func CreateBackup(gormDB *gorm.DB, reason string) (dtos.Backup, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	dumper := newDumper()
+	directory, err := os.MkdirTemp("", "better-admin-backup")
+	if err != nil {
+		return dtos.Backup{}, pkgerrors.Wrap(err, "backup temp directory error")
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "dump"+dumper.Extension())
+	if err := dumper.Dump(gormDB, path); err != nil {
+		return dtos.Backup{}, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return dtos.Backup{}, pkgerrors.Wrap(err, "backup read error")
+	}
+
+	now := time.Now().UTC()
+	backup := dtos.Backup{
+		Key:       fmt.Sprintf("%s%s-%s%s", config.Config.Backup.KeyPrefix, now.Format("20060102T150405.000Z"), reason, dumper.Extension()),
+		Driver:    db.GetDriver(),
+		Reason:    reason,
+		SizeBytes: int64(len(content)),
+		Checksum:  checksum(content),
+		CreatedAt: now,
+	}
+
+	if err := adapters.FileStorageAdapter().Put(backup.Key, content, "application/octet-stream"); err != nil {
+		return dtos.Backup{}, err
+	}
+
+	backups, err := loadManifest()
+	if err != nil {
+		return dtos.Backup{}, err
+	}
+
+	if err := saveManifest(rotate(append(backups, backup))); err != nil {
+		return dtos.Backup{}, err
+	}
+
+	return backup, nil
+}
+
+// GetBackups 는 백업 목록을 최근 순서로 조회한다.
+func GetBackups() ([]dtos.Backup, error) {
+	backups, err := loadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+	return backups, nil
+}
+
+// Restore 는 서버를 멈춘 상태에서 백업으로 DB 를 복원한다.
+// 백업의 checksum 과 DB 종류를 확인하고, 복원하기 전에 현재 DB 를 pre-restore 로 백업한 뒤 gormDB 연결을 닫고 복원한다.
+func Restore(gormDB *gorm.DB, key string) error {
+	backups, err := GetBackups()
+	if err != nil {
+		return err
+	}
+
+	var target *dtos.Backup
+	for i := range backups {
+		if backups[i].Key == key {
+			target = &backups[i]
+		}
+	}
+	if target == nil {
+		return pkgerrors.Wrapf(errors.ErrNotFound, "backup %s", key)
+	}
+
+	dumper := newDumper()
+	if target.Driver != db.GetDriver() {
+		return pkgerrors.Errorf("backup %s is %s backup, current database is %s", key, target.Driver, db.GetDriver())
+	}
+
+	content, err := readFile(key)
+	if err != nil {
+		return err
+	}
+	if checksum(content) != target.Checksum {
+		return pkgerrors.Errorf("backup %s checksum mismatch", key)
+	}
+
+	preRestore, err := CreateBackup(gormDB, constants.BackupReasonPreRestore)
+	if err != nil {
+		return pkgerrors.Wrap(err, "pre-restore backup error")
+	}
+	log.Infof(">>> Pre-restore backup %v", preRestore.Key)
+
+	directory, err := os.MkdirTemp("", "better-admin-restore")
+	if err != nil {
+		return pkgerrors.Wrap(err, "restore temp directory error")
+	}
+	defer os.RemoveAll(directory)
+
+	path := filepath.Join(directory, "restore"+dumper.Extension())
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return pkgerrors.Wrap(err, "restore write error")
+	}
+
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		return err
+	}
+	if err := sqlDB.Close(); err != nil {
+		return pkgerrors.Wrap(err, "database close error")
+	}
+
+	return dumper.Restore(path)
+}
+
+// rotate 는 최근 Retain 개를 남기고 나머지를 저장소에서 지운다. 지우지 못한 백업은 다음에 다시 지우도록 목록에 남긴다.
+func rotate(backups []dtos.Backup) []dtos.Backup {
+	sort.Slice(backups, func(i, j int) bool { return backups[i].CreatedAt.After(backups[j].CreatedAt) })
+
+	retain := config.Config.Backup.Retain
+	if retain <= 0 || len(backups) <= retain {
+		return backups
+	}
+
+	kept := backups[:retain]
+	for _, backup := range backups[retain:] {
+		if err := adapters.FileStorageAdapter().Delete(backup.Key); err != nil {
+			log.Warnf("backup %v delete error: %v", backup.Key, err)
+			kept = append(kept, backup)
+		}
+	}
+
+	return kept
+}
+
+func loadManifest() ([]dtos.Backup, error) {
+	content, err := readFile(config.Config.Backup.KeyPrefix + manifestName)
+	if err != nil {
+		if pkgerrors.Is(err, adapters.ErrFileNotFound) {
+			return []dtos.Backup{}, nil
+		}
+		return nil, err
+	}
+
+	var backups []dtos.Backup
+	if err := json.Unmarshal(content, &backups); err != nil {
+		return nil, pkgerrors.Wrap(err, "backup manifest error")
+	}
+
+	return backups, nil
+}
+
+func saveManifest(backups []dtos.Backup) error {
+	content, err := json.Marshal(backups)
+	if err != nil {
+		return pkgerrors.Wrap(err, "backup manifest error")
+	}
+
+	return adapters.FileStorageAdapter().Put(config.Config.Backup.KeyPrefix+manifestName, content, "application/json")
+}
+
+func readFile(key string) ([]byte, error) {
+	reader, err := adapters.FileStorageAdapter().Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "backup read error")
+	}
+
+	return content, nil
+}
+
+func checksum(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
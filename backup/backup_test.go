@@ -0,0 +1,103 @@
+package backup
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setUpBackupTest(t *testing.T) (*gorm.DB, string) {
+	backupConfig := config.Config.Backup
+	t.Cleanup(func() { config.Config.Backup = backupConfig })
+	config.Config.Backup.KeyPrefix = "backups/"
+	config.Config.Backup.Retain = 2
+	config.Config.Backup.IntervalHours = 24
+
+	directory := t.TempDir()
+	adapters.FileStorageAdapter().SetStorage(adapters.LocalFileStorage{Directory: directory})
+	t.Cleanup(func() { adapters.FileStorageAdapter().SetStorage(nil) })
+
+	gormDB, err := gorm.Open(sqlite.Open("file:backup_test?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, gormDB.Exec("CREATE TABLE IF NOT EXISTS notes (id INTEGER PRIMARY KEY, body TEXT)").Error)
+	assert.NoError(t, gormDB.Exec("INSERT INTO notes(body) VALUES ('백업')").Error)
+
+	return gormDB, directory
+}
+
+func TestCreateBackup_보관_개수(t *testing.T) {
+	// given
+	gormDB, directory := setUpBackupTest(t)
+
+	// when
+	var keys []string
+	for i := 0; i < 3; i++ {
+		backup, err := CreateBackup(gormDB, constants.BackupReasonScheduled)
+		assert.NoError(t, err)
+		keys = append(keys, backup.Key)
+	}
+	backups, err := GetBackups()
+
+	// then
+	assert.NoError(t, err)
+	assert.Len(t, backups, 2)
+	assert.Equal(t, keys[2], backups[0].Key)
+	assert.Equal(t, keys[1], backups[1].Key)
+	assert.Equal(t, "sqlite", backups[0].Driver)
+	assert.Greater(t, backups[0].SizeBytes, int64(0))
+
+	_, err = os.Stat(filepath.Join(directory, keys[0]))
+	assert.True(t, os.IsNotExist(err))
+
+	// 백업한 파일은 SQLite DB 이다.
+	backupDB, err := gorm.Open(sqlite.Open(filepath.Join(directory, keys[2])), &gorm.Config{})
+	assert.NoError(t, err)
+	var body string
+	backupDB.Raw("SELECT body FROM notes LIMIT 1").Scan(&body)
+	assert.Equal(t, "백업", body)
+}
+
+func TestBackupIfDue(t *testing.T) {
+	// given
+	gormDB, _ := setUpBackupTest(t)
+	backupIfDue(gormDB, time.Now())
+
+	// when
+	backupIfDue(gormDB, time.Now().Add(time.Hour))
+	notDue, _ := GetBackups()
+	backupIfDue(gormDB, time.Now().Add(25*time.Hour))
+	due, _ := GetBackups()
+
+	// then
+	assert.Len(t, notDue, 1)
+	assert.Len(t, due, 2)
+}
+
+func TestRestore_확인_실패(t *testing.T) {
+	// given
+	gormDB, directory := setUpBackupTest(t)
+	backup, err := CreateBackup(gormDB, constants.BackupReasonScheduled)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, backup.Key), []byte("corrupted"), 0644))
+
+	// when
+	notFoundErr := Restore(gormDB, "backups/unknown.db")
+	checksumErr := Restore(gormDB, backup.Key)
+
+	// then
+	assert.ErrorIs(t, notFoundErr, errors.ErrNotFound)
+	assert.ErrorContains(t, checksumErr, "checksum mismatch")
+
+	// 복원하지 않았으므로 DB 연결과 백업 목록은 그대로이다.
+	assert.NoError(t, gormDB.Exec("SELECT 1").Error)
+	backups, _ := GetBackups()
+	assert.Len(t, backups, 1)
+}
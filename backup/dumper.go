@@ -0,0 +1,136 @@
+package backup
+
+import (
+	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/config"
+	"bytes"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+)
+
+// Dumper 는 DB 종류별 백업(dump) 파일을 만들고 그 파일로 복원한다.
+type Dumper interface {
+	Extension() string
+	Dump(gormDB *gorm.DB, path string) error
+	// Restore 는 DB 연결을 모두 닫은 뒤에 실행한다.
+	Restore(path string) error
+}
+
+func newDumper() Dumper {
+	if db.GetDriver() == db.DriverMysql {
+		return mysqlDumper{}
+	}
+
+	return sqliteDumper{file: db.SqlitePath()}
+}
+
+// sqliteDumper 는 VACUUM INTO 로 실행 중에도 일관된 DB 파일 사본을 만들고, 복원할 때는 DB 파일을 교체한다.
+type sqliteDumper struct {
+	file string
+}
+
+func (sqliteDumper) Extension() string {
+	return ".db"
+}
+
+func (sqliteDumper) Dump(gormDB *gorm.DB, path string) error {
+	if err := gormDB.Exec("VACUUM INTO ?", path).Error; err != nil {
+		return pkgerrors.Wrap(err, "sqlite backup error")
+	}
+
+	return nil
+}
+
+func (s sqliteDumper) Restore(path string) error {
+	restoring := s.file + ".restoring"
+	if err := copyFile(path, restoring); err != nil {
+		return err
+	}
+
+	// 이전 DB 의 WAL 파일이 남아 있으면 복원한 DB 에 적용되므로 지운다.
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(s.file + suffix); err != nil && !os.IsNotExist(err) {
+			return pkgerrors.Wrap(err, "sqlite restore error")
+		}
+	}
+
+	if err := os.Rename(restoring, s.file); err != nil {
+		return pkgerrors.Wrap(err, "sqlite restore error")
+	}
+
+	return nil
+}
+
+// mysqlDumper 는 mysqldump 로 백업하고 mysql 로 복원한다. 비밀번호는 명령 인자에 보이지 않도록 환경 변수(MYSQL_PWD)로 전달한다.
+type mysqlDumper struct {
+}
+
+func (mysqlDumper) Extension() string {
+	return ".sql"
+}
+
+func (m mysqlDumper) Dump(gormDB *gorm.DB, path string) error {
+	args := append(m.connectionArgs(), "--single-transaction", "--routines", "--triggers", "--result-file="+path, os.Getenv(db.EnvDbName))
+	return m.run(config.Config.Backup.MysqlDumpCommand, args, nil)
+}
+
+func (m mysqlDumper) Restore(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return pkgerrors.Wrap(err, "mysql restore error")
+	}
+	defer file.Close()
+
+	return m.run(config.Config.Backup.MysqlCommand, append(m.connectionArgs(), os.Getenv(db.EnvDbName)), file)
+}
+
+func (mysqlDumper) connectionArgs() []string {
+	host, port, err := net.SplitHostPort(os.Getenv(db.EnvDbHost))
+	if err != nil {
+		host, port = os.Getenv(db.EnvDbHost), "3306"
+	}
+
+	return []string{"--host=" + host, "--port=" + port, "--user=" + os.Getenv(db.EnvDbUser)}
+}
+
+func (mysqlDumper) run(command string, args []string, stdin io.Reader) error {
+	cmd := exec.Command(command, args...)
+	cmd.Env = append(os.Environ(), "MYSQL_PWD="+os.Getenv(db.EnvDbPassword))
+	cmd.Stdin = stdin
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return pkgerrors.Wrapf(err, "%s error: %s", command, stderr.String())
+	}
+
+	return nil
+}
+
+func copyFile(source, target string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return pkgerrors.Wrap(err, "copy file error")
+	}
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return pkgerrors.Wrap(err, "copy file error")
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return pkgerrors.Wrap(err, "copy file error")
+	}
+
+	if err := out.Close(); err != nil {
+		return pkgerrors.Wrap(err, "copy file error")
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// BreakGlassAccountEntity 는 IdP(SSO) 장애 시 멤버 대신 로그인할 비상 접근 계정이다.
+// 멤버의 로그인 정보와 따로 보관하며 로그인하면 연결된 멤버의 역할과 권한을 사용한다.
+type BreakGlassAccountEntity struct {
+	gorm.Model
+	SignId      string `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Password    string `gorm:"type:varchar(255);not null"`
+	MemberId    uint   `gorm:"not null"`
+	Description string `gorm:"type:varchar(1000)"`
+	LastUsedAt  *time.Time
+	CreatedBy   uint
+}
+
+func (BreakGlassAccountEntity) TableName() string {
+	return "break_glass_accounts"
+}
+
+func (b BreakGlassAccountEntity) ValidatePassword(password string) error {
+	if matched, err := security.VerifyPassword(b.Password, password); err != nil || !matched {
+		return errors.ErrAuthentication
+	}
+
+	return nil
+}
+
+func (b *BreakGlassAccountEntity) MarkUsed() {
+	now := time.Now()
+	b.LastUsedAt = &now
+}
+
+func NewBreakGlassAccountEntity(ctx context.Context, creation dtos.BreakGlassAccountCreation) (BreakGlassAccountEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return BreakGlassAccountEntity{}, err
+	}
+
+	hash, err := security.HashPassword(creation.Password)
+	if err != nil {
+		return BreakGlassAccountEntity{}, pkgerrors.Wrap(err, "hash break glass password error")
+	}
+
+	return BreakGlassAccountEntity{
+		SignId:      creation.SignId,
+		Password:    hash,
+		MemberId:    creation.MemberId,
+		Description: creation.Description,
+		CreatedBy:   userClaim.Id,
+	}, nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// BreakGlassUsageEntity 는 비상 접근 계정을 사용한 기록이다. 로그인할 때 입력한 사유와 세션 만료 시각을 남긴다.
+type BreakGlassUsageEntity struct {
+	gorm.Model
+	AccountId uint   `gorm:"not null;index"`
+	SignId    string `gorm:"type:varchar(50);not null"`
+	MemberId  uint   `gorm:"not null"`
+	Reason    string `gorm:"type:varchar(1000);not null"`
+	ClientIp  string `gorm:"type:varchar(50)"`
+	SessionId uint
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+func (BreakGlassUsageEntity) TableName() string {
+	return "break_glass_usages"
+}
+
+func NewBreakGlassUsageEntity(account BreakGlassAccountEntity, reason string, clientIp string, sessionId uint, expiresAt time.Time) BreakGlassUsageEntity {
+	return BreakGlassUsageEntity{
+		AccountId: account.ID,
+		SignId:    account.SignId,
+		MemberId:  account.MemberId,
+		Reason:    reason,
+		ClientIp:  clientIp,
+		SessionId: sessionId,
+		ExpiresAt: expiresAt,
+	}
+}
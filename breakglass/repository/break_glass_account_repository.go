@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"better-admin-backend-service/breakglass/domain"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type BreakGlassAccountRepository struct {
+}
+
+func (BreakGlassAccountRepository) Create(ctx context.Context, entity *domain.BreakGlassAccountEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (BreakGlassAccountRepository) Save(ctx context.Context, entity *domain.BreakGlassAccountEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (BreakGlassAccountRepository) FindAll(ctx context.Context, pageable dtos.Pageable) ([]domain.BreakGlassAccountEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.BreakGlassAccountEntity{})
+
+	var entities = make([]domain.BreakGlassAccountEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (BreakGlassAccountRepository) FindById(ctx context.Context, id uint) (domain.BreakGlassAccountEntity, error) {
+	var entity domain.BreakGlassAccountEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (BreakGlassAccountRepository) FindBySignId(ctx context.Context, signId string) (domain.BreakGlassAccountEntity, error) {
+	var entity domain.BreakGlassAccountEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.BreakGlassAccountEntity{SignId: signId}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// Delete 는 계정을 완전히 삭제한다. 같은 아이디로 다시 만들 수 있도록 soft delete 하지 않는다.
+func (BreakGlassAccountRepository) Delete(ctx context.Context, entity domain.BreakGlassAccountEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Unscoped().Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"better-admin-backend-service/breakglass/domain"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type BreakGlassUsageRepository struct {
+}
+
+func (BreakGlassUsageRepository) Create(ctx context.Context, entity *domain.BreakGlassUsageEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (BreakGlassUsageRepository) FindAll(ctx context.Context, pageable dtos.Pageable) ([]domain.BreakGlassUsageEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.BreakGlassUsageEntity{})
+
+	var entities = make([]domain.BreakGlassUsageEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Order("id DESC").Scopes(helpers.GormHelper().Pageable(pageable)).
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
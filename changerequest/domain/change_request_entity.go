@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ChangeRequestEntity 는 설정/역할 변경 요청이다. 작성(draft) → 검토(in-review) → 승인(approved) → 적용(applied) 순서로 진행한다.
+// 적용한 뒤 점검(selfcheck)에 실패하면 모든 변경을 되돌리고 rolled-back 이 된다.
+type ChangeRequestEntity struct {
+	gorm.Model
+	Title         string `gorm:"type:varchar(200);not null"`
+	Description   string `gorm:"type:varchar(2000)"`
+	Changes       string `gorm:"type:text;not null"`
+	Status        string `gorm:"type:varchar(20);not null;index"`
+	ScheduledAt   *time.Time
+	CreatedBy     uint
+	ReviewedBy    uint
+	ReviewedAt    *time.Time
+	ReviewComment string `gorm:"type:varchar(1000)"`
+	AppliedBy     uint
+	AppliedAt     *time.Time
+	FailureReason string `gorm:"type:varchar(2000)"`
+	HealthCheck   string `gorm:"type:text"`
+}
+
+func (ChangeRequestEntity) TableName() string {
+	return "change_requests"
+}
+
+func (c ChangeRequestEntity) GetChanges() []dtos.ChangeRequestItem {
+	var changes []dtos.ChangeRequestItem
+	if err := json.Unmarshal([]byte(c.Changes), &changes); err != nil {
+		return []dtos.ChangeRequestItem{}
+	}
+
+	return changes
+}
+
+func (c ChangeRequestEntity) GetHealthCheck() *dtos.SelfCheckReport {
+	if len(c.HealthCheck) == 0 {
+		return nil
+	}
+
+	var report dtos.SelfCheckReport
+	if err := json.Unmarshal([]byte(c.HealthCheck), &report); err != nil {
+		return nil
+	}
+
+	return &report
+}
+
+// Update 는 작성 중인 요청만 바꿀 수 있다.
+func (c *ChangeRequestEntity) Update(information dtos.ChangeRequestInformation) error {
+	if c.Status != constants.ChangeRequestStatusDraft {
+		return errors.ErrNonChangeable
+	}
+
+	changes, err := json.Marshal(information.Changes)
+	if err != nil {
+		return pkgerrors.Wrap(err, "change request changes encode error")
+	}
+
+	c.Title = information.Title
+	c.Description = information.Description
+	c.Changes = string(changes)
+	c.ScheduledAt = information.ScheduledAt
+	return nil
+}
+
+func (c *ChangeRequestEntity) Submit(ctx context.Context) error {
+	if err := c.checkCreator(ctx); err != nil {
+		return err
+	}
+
+	if c.Status != constants.ChangeRequestStatusDraft {
+		return errors.ErrNonChangeable
+	}
+
+	c.Status = constants.ChangeRequestStatusInReview
+	return nil
+}
+
+// Cancel 은 적용하기 전의 요청을 작성한 관리자가 취소한다.
+func (c *ChangeRequestEntity) Cancel(ctx context.Context) error {
+	if err := c.checkCreator(ctx); err != nil {
+		return err
+	}
+
+	switch c.Status {
+	case constants.ChangeRequestStatusDraft, constants.ChangeRequestStatusInReview, constants.ChangeRequestStatusApproved:
+		c.Status = constants.ChangeRequestStatusCancelled
+		return nil
+	default:
+		return errors.ErrNonChangeable
+	}
+}
+
+// Review 는 검토 중인 요청을 승인하거나 반려한다. 작성한 관리자는 검토할 수 없다.
+func (c *ChangeRequestEntity) Review(ctx context.Context, approved bool, comment string) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userClaim.Id == c.CreatedBy {
+		return errors.ErrSelfReview
+	}
+
+	if c.Status != constants.ChangeRequestStatusInReview {
+		return errors.ErrNonChangeable
+	}
+
+	now := time.Now()
+	c.Status = constants.ChangeRequestStatusRejected
+	if approved {
+		c.Status = constants.ChangeRequestStatusApproved
+	}
+	c.ReviewedBy = userClaim.Id
+	c.ReviewedAt = &now
+	c.ReviewComment = comment
+	return nil
+}
+
+// CanApply 는 승인된 요청이고 예약한 시간이 지났는지 확인한다.
+func (c ChangeRequestEntity) CanApply(now time.Time) bool {
+	return c.Status == constants.ChangeRequestStatusApproved && (c.ScheduledAt == nil || !c.ScheduledAt.After(now))
+}
+
+// Applied 는 적용 결과를 기록한다. failureReason 이 있으면 변경을 되돌린 것이다.
+func (c *ChangeRequestEntity) Applied(appliedBy uint, healthCheck *dtos.SelfCheckReport, failureReason string) error {
+	now := time.Now()
+	c.Status = constants.ChangeRequestStatusApplied
+	if len(failureReason) > 0 {
+		c.Status = constants.ChangeRequestStatusRolledBack
+	}
+	c.AppliedBy = appliedBy
+	c.AppliedAt = &now
+	c.FailureReason = failureReason
+
+	c.HealthCheck = ""
+	if healthCheck != nil {
+		report, err := json.Marshal(healthCheck)
+		if err != nil {
+			return pkgerrors.Wrap(err, "change request health check encode error")
+		}
+		c.HealthCheck = string(report)
+	}
+
+	return nil
+}
+
+func (c ChangeRequestEntity) checkCreator(ctx context.Context) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userClaim.Id != c.CreatedBy {
+		return errors.ErrForbidden
+	}
+
+	return nil
+}
+
+func NewChangeRequestEntity(ctx context.Context, information dtos.ChangeRequestInformation) (ChangeRequestEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return ChangeRequestEntity{}, err
+	}
+
+	entity := ChangeRequestEntity{Status: constants.ChangeRequestStatusDraft, CreatedBy: userClaim.Id}
+	if err := entity.Update(information); err != nil {
+		return ChangeRequestEntity{}, err
+	}
+
+	return entity, nil
+}
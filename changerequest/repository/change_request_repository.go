@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"better-admin-backend-service/changerequest/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ChangeRequestRepository struct {
+}
+
+func (ChangeRequestRepository) Create(ctx context.Context, entity *domain.ChangeRequestEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ChangeRequestRepository) Save(ctx context.Context, entity *domain.ChangeRequestEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ChangeRequestRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.ChangeRequestEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ChangeRequestEntity{})
+	if status, ok := filters["status"]; ok {
+		db = db.Where("status = ?", status)
+	}
+
+	var entities = make([]domain.ChangeRequestEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id desc").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (ChangeRequestRepository) FindById(ctx context.Context, id uint) (domain.ChangeRequestEntity, error) {
+	var entity domain.ChangeRequestEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindScheduled 는 승인되었고 예약한 적용 시간이 지난 요청을 조회한다.
+func (ChangeRequestRepository) FindScheduled(ctx context.Context, now time.Time) ([]domain.ChangeRequestEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ChangeRequestEntity, 0)
+	if err := db.Where("status = ? AND scheduled_at IS NOT NULL AND scheduled_at <= ?", constants.ChangeRequestStatusApproved, now).
+		Order("scheduled_at").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
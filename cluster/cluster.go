@@ -0,0 +1,150 @@
+package cluster
+
+import (
+	"better-admin-backend-service/adapters"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"sync"
+	"time"
+)
+
+// 인스턴스 사이의 알림을 주고받는 채널
+const notificationChannel = "cluster-notifications"
+
+// 구독이 끊긴 뒤 다시 구독할 때까지 기다리는 시간
+const resubscribeDelay = 5 * time.Second
+
+// Handler 는 다른 인스턴스가 Notify 한 알림을 받는다.
+type Handler func(payload json.RawMessage)
+
+type notification struct {
+	InstanceId string          `json:"instanceId"`
+	Topic      string          `json:"topic"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+}
+
+var (
+	instanceIdOnce sync.Once
+	instanceId     string
+
+	mutex     sync.Mutex
+	handlers  = map[string]Handler{}
+	stopped   chan struct{}
+	waitGroup sync.WaitGroup
+)
+
+// InstanceId 는 이 서버 인스턴스를 구분하는 ID(호스트 이름-임의 값)이다. 잠금의 owner 로 사용한다.
+func InstanceId() string {
+	instanceIdOnce.Do(func() {
+		hostname, err := os.Hostname()
+		if err != nil {
+			hostname = "unknown"
+		}
+
+		random := make([]byte, 4)
+		rand.Read(random)
+		instanceId = hostname + "-" + hex.EncodeToString(random)
+	})
+
+	return instanceId
+}
+
+// Handle 은 topic 의 알림을 받을 함수를 등록한다. 같은 topic 의 함수가 있으면 교체한다.
+func Handle(topic string, handler Handler) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	handlers[topic] = handler
+}
+
+// Notify 는 다른 인스턴스에 topic 을 알린다(예. 메모리에 올린 설정을 DB 에서 다시 읽게 한다).
+// 알린 인스턴스의 Handler 는 호출하지 않으므로 알린 인스턴스에는 호출한 곳에서 직접 반영한다.
+func Notify(topic string, payload interface{}) error {
+	message := notification{InstanceId: InstanceId(), Topic: topic}
+	if payload != nil {
+		content, err := json.Marshal(payload)
+		if err != nil {
+			return pkgerrors.Wrap(err, "cluster notification error")
+		}
+		message.Payload = content
+	}
+
+	content, err := json.Marshal(message)
+	if err != nil {
+		return pkgerrors.Wrap(err, "cluster notification error")
+	}
+
+	return adapters.SharedStateAdapter().Store().Publish(notificationChannel, content)
+}
+
+// Start 는 다른 인스턴스의 알림을 구독한다. 구독이 끊기면 resubscribeDelay 뒤에 다시 구독한다.
+func Start() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if stopped != nil {
+		return
+	}
+	stopped = make(chan struct{})
+
+	waitGroup.Add(1)
+	go func(stopped chan struct{}) {
+		defer waitGroup.Done()
+
+		for {
+			if err := adapters.SharedStateAdapter().Store().Subscribe(notificationChannel, stopped, dispatch); err != nil {
+				log.Warnf("cluster notification subscribe error: %v", err)
+			}
+
+			select {
+			case <-stopped:
+				return
+			case <-time.After(resubscribeDelay):
+			}
+		}
+	}(stopped)
+}
+
+func Stop() {
+	mutex.Lock()
+	if stopped == nil {
+		mutex.Unlock()
+		return
+	}
+	close(stopped)
+	stopped = nil
+	mutex.Unlock()
+
+	waitGroup.Wait()
+}
+
+func dispatch(content []byte) {
+	var message notification
+	if err := json.Unmarshal(content, &message); err != nil {
+		log.Warnf("cluster notification error: %v", err)
+		return
+	}
+
+	if message.InstanceId == InstanceId() {
+		return
+	}
+
+	mutex.Lock()
+	handler := handlers[message.Topic]
+	mutex.Unlock()
+
+	if handler == nil {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("cluster notification(%s) handler panic: %v", message.Topic, r)
+		}
+	}()
+	handler(message.Payload)
+}
@@ -0,0 +1,90 @@
+package cluster
+
+import (
+	"better-admin-backend-service/adapters"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunExclusive(t *testing.T) {
+	adapters.SharedStateAdapter().SetStore(adapters.NewMemorySharedStore())
+	defer adapters.SharedStateAdapter().SetStore(nil)
+
+	// given
+	var concurrent bool
+	var concurrentErr error
+
+	// when
+	executed, err := RunExclusive("test-job", time.Minute, func() {
+		// 실행하는 동안 다른 인스턴스(혹은 같은 인스턴스)는 실행하지 않는다.
+		concurrent, concurrentErr = RunExclusive("test-job", time.Minute, func() {})
+	})
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, executed)
+	assert.NoError(t, concurrentErr)
+	assert.False(t, concurrent)
+
+	// 실행을 마치면 잠금을 푼다.
+	executed, _ = RunExclusive("test-job", time.Minute, func() {})
+	assert.True(t, executed)
+}
+
+func TestLeadership_장애_조치(t *testing.T) {
+	adapters.SharedStateAdapter().SetStore(adapters.NewMemorySharedStore())
+	defer adapters.SharedStateAdapter().SetStore(nil)
+
+	// given
+	first := &Leadership{name: "scheduler", ttl: 300 * time.Millisecond, owner: "instance-1", locker: SharedStateLocker()}
+	second := &Leadership{name: "scheduler", ttl: 300 * time.Millisecond, owner: "instance-2", locker: SharedStateLocker()}
+
+	// when
+	first.Start()
+	assert.Eventually(t, first.IsLeader, time.Second, 10*time.Millisecond)
+	second.Start()
+	defer second.Stop()
+
+	// then
+	time.Sleep(200 * time.Millisecond)
+	assert.False(t, second.IsLeader())
+	leader, ok, _ := second.Leader()
+	assert.True(t, ok)
+	assert.Equal(t, "instance-1", leader)
+
+	// 리더가 멈추면 다른 인스턴스가 리더가 된다.
+	first.Stop()
+	assert.False(t, first.IsLeader())
+	assert.Eventually(t, second.IsLeader, time.Second, 10*time.Millisecond)
+}
+
+func TestNotify_다른_인스턴스에만_알림(t *testing.T) {
+	store := adapters.NewMemorySharedStore()
+	adapters.SharedStateAdapter().SetStore(store)
+	defer adapters.SharedStateAdapter().SetStore(nil)
+
+	// given
+	var received int32
+	var payload json.RawMessage
+	Handle("test-topic", func(p json.RawMessage) {
+		payload = p
+		atomic.AddInt32(&received, 1)
+	})
+	Start()
+	defer Stop()
+
+	// when
+	assert.Eventually(t, func() bool {
+		// 알린 인스턴스는 받지 않는다.
+		Notify("test-topic", map[string]int{"epoch": 1})
+		content, _ := json.Marshal(notification{InstanceId: "other-instance", Topic: "test-topic", Payload: json.RawMessage(`{"epoch":2}`)})
+		store.Publish(notificationChannel, content)
+		return atomic.LoadInt32(&received) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// then
+	assert.JSONEq(t, `{"epoch":2}`, string(payload))
+}
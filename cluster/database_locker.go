@@ -0,0 +1,67 @@
+package cluster
+
+import (
+	"better-admin-backend-service/cluster/domain"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// DatabaseLocker 는 Redis 없이 여러 인스턴스를 실행할 때 DB 테이블(cluster_locks)로 잠근다.
+// 만료 시각은 각 인스턴스의 시각으로 비교하므로 인스턴스 사이의 시간 차이가 ttl 보다 작아야 한다.
+type DatabaseLocker struct {
+	db *gorm.DB
+}
+
+func NewDatabaseLocker(db *gorm.DB) DatabaseLocker {
+	return DatabaseLocker{db: db}
+}
+
+// AcquireLock 은 만료된 잠금을 지우고 잠금을 추가한다. 다른 인스턴스가 먼저 추가했으면 추가하지 않는다.
+func (d DatabaseLocker) AcquireLock(key, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	if err := d.db.Where("name = ? AND expires_at < ?", key, now).Delete(&domain.ClusterLockEntity{}).Error; err != nil {
+		return false, pkgerrors.Wrap(err, "cluster lock error")
+	}
+
+	result := d.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&domain.ClusterLockEntity{Name: key, Owner: owner, ExpiresAt: now.Add(ttl)})
+	if result.Error != nil {
+		return false, pkgerrors.Wrap(result.Error, "cluster lock error")
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (d DatabaseLocker) RenewLock(key, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	result := d.db.Model(&domain.ClusterLockEntity{}).
+		Where("name = ? AND owner = ? AND expires_at >= ?", key, owner, now).
+		Update("expires_at", now.Add(ttl))
+	if result.Error != nil {
+		return false, pkgerrors.Wrap(result.Error, "cluster lock error")
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (d DatabaseLocker) ReleaseLock(key, owner string) error {
+	if err := d.db.Where("name = ? AND owner = ?", key, owner).Delete(&domain.ClusterLockEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "cluster lock error")
+	}
+
+	return nil
+}
+
+func (d DatabaseLocker) Get(key string) (string, bool, error) {
+	var entities []domain.ClusterLockEntity
+	if err := d.db.Where("name = ? AND expires_at >= ?", key, time.Now().UTC()).Limit(1).Find(&entities).Error; err != nil {
+		return "", false, pkgerrors.Wrap(err, "cluster lock error")
+	}
+
+	if len(entities) == 0 {
+		return "", false, nil
+	}
+	return entities[0].Owner, true, nil
+}
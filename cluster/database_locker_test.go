@@ -0,0 +1,51 @@
+package cluster
+
+import (
+	"better-admin-backend-service/cluster/domain"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestDatabaseLocker(t *testing.T) {
+	gormDB, err := gorm.Open(sqlite.Open("file:database_locker_test?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, gormDB.AutoMigrate(&domain.ClusterLockEntity{}))
+	locker := NewDatabaseLocker(gormDB)
+
+	// given
+	acquired, err := locker.AcquireLock("leader:scheduler", "instance-1", 100*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+
+	// when
+	// 다른 인스턴스는 잠그거나 연장하지 못한다.
+	acquired, _ = locker.AcquireLock("leader:scheduler", "instance-2", time.Minute)
+	renewed, _ := locker.RenewLock("leader:scheduler", "instance-2", time.Minute)
+
+	// then
+	assert.False(t, acquired)
+	assert.False(t, renewed)
+	owner, ok, _ := locker.Get("leader:scheduler")
+	assert.True(t, ok)
+	assert.Equal(t, "instance-1", owner)
+
+	// 만료되면 다른 인스턴스가 잠그고, 이전 owner 는 연장하지 못한다.
+	time.Sleep(150 * time.Millisecond)
+	_, ok, _ = locker.Get("leader:scheduler")
+	assert.False(t, ok)
+	acquired, _ = locker.AcquireLock("leader:scheduler", "instance-2", time.Minute)
+	assert.True(t, acquired)
+	renewed, _ = locker.RenewLock("leader:scheduler", "instance-1", time.Minute)
+	assert.False(t, renewed)
+
+	// 다른 owner 의 잠금은 풀지 않는다.
+	assert.NoError(t, locker.ReleaseLock("leader:scheduler", "instance-1"))
+	owner, _, _ = locker.Get("leader:scheduler")
+	assert.Equal(t, "instance-2", owner)
+	assert.NoError(t, locker.ReleaseLock("leader:scheduler", "instance-2"))
+	_, ok, _ = locker.Get("leader:scheduler")
+	assert.False(t, ok)
+}
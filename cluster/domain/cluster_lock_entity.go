@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// ClusterLockEntity 는 DB 로 관리하는 잠금(리더 선출, 작업 실행)이다. ExpiresAt 이 지난 잠금은 다른 인스턴스가 가져갈 수 있다.
+type ClusterLockEntity struct {
+	Name      string    `gorm:"type:varchar(150);primaryKey"`
+	Owner     string    `gorm:"type:varchar(100);not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (ClusterLockEntity) TableName() string {
+	return "cluster_locks"
+}
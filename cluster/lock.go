@@ -0,0 +1,219 @@
+package cluster
+
+import (
+	"better-admin-backend-service/adapters"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+const (
+	lockKeyPrefix   = "lock:"
+	leaderKeyPrefix = "leader:"
+)
+
+// Locker 는 여러 인스턴스가 함께 사용하는 잠금 저장소이다. 공유 상태 저장소(adapters.SharedStore)와 DatabaseLocker 가 구현한다.
+type Locker interface {
+	AcquireLock(key, owner string, ttl time.Duration) (bool, error)
+	RenewLock(key, owner string, ttl time.Duration) (bool, error)
+	ReleaseLock(key, owner string) error
+	// Get 은 잠금의 owner 를 조회한다. 잠겨 있지 않으면 false 이다.
+	Get(key string) (string, bool, error)
+}
+
+// sharedStateLocker 는 호출할 때의 공유 상태 저장소(SharedState.Backend)를 사용한다.
+type sharedStateLocker struct {
+}
+
+func (sharedStateLocker) AcquireLock(key, owner string, ttl time.Duration) (bool, error) {
+	return adapters.SharedStateAdapter().Store().AcquireLock(key, owner, ttl)
+}
+
+func (sharedStateLocker) RenewLock(key, owner string, ttl time.Duration) (bool, error) {
+	return adapters.SharedStateAdapter().Store().RenewLock(key, owner, ttl)
+}
+
+func (sharedStateLocker) ReleaseLock(key, owner string) error {
+	return adapters.SharedStateAdapter().Store().ReleaseLock(key, owner)
+}
+
+func (sharedStateLocker) Get(key string) (string, bool, error) {
+	return adapters.SharedStateAdapter().Store().Get(key)
+}
+
+// SharedStateLocker 는 공유 상태 저장소의 잠금이다.
+func SharedStateLocker() Locker {
+	return sharedStateLocker{}
+}
+
+// RunExclusive 는 여러 인스턴스 중 name 을 잠근 인스턴스에서만 run 을 실행한다. 다른 인스턴스가 실행 중이면 실행하지 않고 false 를 반환한다.
+// 실행하는 동안 ttl 의 1/3 마다 잠금을 연장하므로 실행하던 인스턴스가 멈추면 ttl 이 지난 뒤 다른 인스턴스가 잠글 수 있다.
+func RunExclusive(name string, ttl time.Duration, run func()) (bool, error) {
+	return RunExclusiveWithLocker(SharedStateLocker(), name, ttl, run)
+}
+
+// RunExclusiveWithLocker 는 locker 로 잠그고 RunExclusive 와 같이 실행한다.
+func RunExclusiveWithLocker(locker Locker, name string, ttl time.Duration, run func()) (bool, error) {
+	key := lockKeyPrefix + name
+	owner := InstanceId()
+
+	acquired, err := locker.AcquireLock(key, owner, ttl)
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if renewed, err := locker.RenewLock(key, owner, ttl); err != nil || !renewed {
+					log.Warnf("lock(%s) renew failed. another instance may run it. %v", name, err)
+				}
+			}
+		}
+	}()
+
+	defer func() {
+		close(done)
+		if err := locker.ReleaseLock(key, owner); err != nil {
+			log.Warnf("lock(%s) release error: %v", name, err)
+		}
+	}()
+
+	run()
+	return true, nil
+}
+
+// Leadership 은 여러 인스턴스 중 name 의 리더 하나를 선출한다.
+// 리더는 ttl 의 1/3 마다 리더 잠금을 연장하고, 리더가 멈추면 ttl 이 지난 뒤 다른 인스턴스가 리더가 된다.
+type Leadership struct {
+	name   string
+	ttl    time.Duration
+	owner  string
+	locker Locker
+
+	mutex     sync.Mutex
+	leader    bool
+	stopped   chan struct{}
+	waitGroup sync.WaitGroup
+}
+
+// NewLeadership 은 locker 로 리더 잠금을 관리한다. locker 가 nil 이면 공유 상태 저장소를 사용한다.
+func NewLeadership(name string, ttl time.Duration, locker Locker) *Leadership {
+	if locker == nil {
+		locker = SharedStateLocker()
+	}
+
+	return &Leadership{name: name, ttl: ttl, owner: InstanceId(), locker: locker}
+}
+
+// Start 는 리더 선출에 참여한다.
+func (l *Leadership) Start() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.stopped != nil {
+		return
+	}
+	stopped := make(chan struct{})
+	l.stopped = stopped
+
+	l.waitGroup.Add(1)
+	go func() {
+		defer l.waitGroup.Done()
+
+		ticker := time.NewTicker(l.ttl / 3)
+		defer ticker.Stop()
+		for {
+			l.campaign()
+
+			select {
+			case <-ticker.C:
+			case <-stopped:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 은 리더 선출에서 빠진다. 리더이면 다른 인스턴스가 바로 리더가 되도록 잠금을 푼다.
+func (l *Leadership) Stop() {
+	l.mutex.Lock()
+	stopped := l.stopped
+	l.stopped = nil
+	l.mutex.Unlock()
+
+	if stopped == nil {
+		return
+	}
+	close(stopped)
+	l.waitGroup.Wait()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.leader {
+		l.leader = false
+		if err := l.locker.ReleaseLock(leaderKeyPrefix+l.name, l.owner); err != nil {
+			log.Warnf("leadership(%s) release error: %v", l.name, err)
+		}
+	}
+}
+
+// IsLeader 는 이 인스턴스가 리더인지 확인한다.
+func (l *Leadership) IsLeader() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.leader
+}
+
+// Leader 는 현재 리더 인스턴스의 ID 를 조회한다. 리더가 없으면 false 이다.
+func (l *Leadership) Leader() (string, bool, error) {
+	return Leader(l.locker, l.name)
+}
+
+// Leader 는 locker 로 선출한 name 의 리더 인스턴스 ID 를 조회한다. 리더 선출에 참여하지 않는 인스턴스에서도 조회할 수 있다.
+func Leader(locker Locker, name string) (string, bool, error) {
+	return locker.Get(leaderKeyPrefix + name)
+}
+
+// LockOwner 는 RunExclusive 로 name 을 실행 중인 인스턴스 ID 를 조회한다. 실행 중이 아니면 false 이다.
+func LockOwner(locker Locker, name string) (string, bool, error) {
+	return locker.Get(lockKeyPrefix + name)
+}
+
+// campaign 은 리더이면 잠금을 연장하고, 아니면 잠근다. 저장소에 연결할 수 없으면 두 인스턴스가 리더가 되지 않도록 리더에서 물러난다.
+func (l *Leadership) campaign() {
+	key := leaderKeyPrefix + l.name
+
+	l.mutex.Lock()
+	wasLeader := l.leader
+	l.mutex.Unlock()
+
+	var leader bool
+	var err error
+	if wasLeader {
+		leader, err = l.locker.RenewLock(key, l.owner, l.ttl)
+	} else {
+		leader, err = l.locker.AcquireLock(key, l.owner, l.ttl)
+	}
+	if err != nil {
+		log.Warnf("leadership(%s) error: %v", l.name, err)
+		leader = false
+	}
+
+	l.mutex.Lock()
+	l.leader = leader
+	l.mutex.Unlock()
+
+	if leader && !wasLeader {
+		log.Infof(">>> %s became leader of %s", l.owner, l.name)
+	} else if !leader && wasLeader {
+		log.Warnf(">>> %s lost leadership of %s", l.owner, l.name)
+	}
+}
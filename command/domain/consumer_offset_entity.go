@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+)
+
+// ConsumerOffsetEntity 는 Kafka 토픽의 파티션마다 다음에 읽을 offset 이다.
+// 컨슈머 그룹의 offset 커밋을 사용하지 않으므로 명령 처리와 같은 트랜잭션에서 저장한다.
+type ConsumerOffsetEntity struct {
+	gorm.Model
+	Topic       string `gorm:"type:varchar(255);not null;uniqueIndex:idx_consumer_offset_topic_partition"`
+	PartitionId int32  `gorm:"not null;uniqueIndex:idx_consumer_offset_topic_partition"`
+	NextOffset  int64  `gorm:"not null"`
+}
+
+func (ConsumerOffsetEntity) TableName() string {
+	return "message_consumer_offsets"
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"gorm.io/gorm"
+)
+
+// InboundCommandEntity 는 메시지 브로커로 받은 명령의 처리 결과이다. CommandId 로 같은 명령을 두 번 처리하지 않는다.
+type InboundCommandEntity struct {
+	gorm.Model
+	CommandId string `gorm:"type:varchar(100);uniqueIndex;not null"`
+	Type      string `gorm:"type:varchar(100);not null;index"`
+	Principal string `gorm:"type:varchar(64)"`
+	Topic     string `gorm:"type:varchar(255)"`
+	Status    string `gorm:"type:varchar(20);not null;index"`
+	Attempts  int    `gorm:"not null"`
+	Error     string `gorm:"type:varchar(1000)"`
+	// Payload 는 받은 dtos.InboundCommand 의 JSON 이다.
+	Payload string `gorm:"type:text"`
+}
+
+func (InboundCommandEntity) TableName() string {
+	return "inbound_commands"
+}
+
+func NewInboundCommandEntity(message adapters.BrokerMessage, command dtos.InboundCommand) InboundCommandEntity {
+	return InboundCommandEntity{
+		CommandId: command.Id,
+		Type:      command.Type,
+		Principal: command.Principal,
+		Topic:     message.Topic,
+		Payload:   string(message.Value),
+	}
+}
+
+func (e *InboundCommandEntity) MarkProcessed(attempts int) {
+	e.Status = constants.InboundCommandStatusProcessed
+	e.Attempts = attempts
+	e.Error = ""
+}
+
+// MarkRejected 는 실행하지 않고 거부한 명령(알 수 없는 명령, 권한 없음)을 기록한다.
+func (e *InboundCommandEntity) MarkRejected(reason string) {
+	e.Status = constants.InboundCommandStatusRejected
+	e.setError(reason)
+}
+
+// MarkDeadLettered 는 attempts 번 실행하여 모두 실패한 명령을 기록한다.
+func (e *InboundCommandEntity) MarkDeadLettered(attempts int, err error) {
+	e.Status = constants.InboundCommandStatusDeadLettered
+	e.Attempts = attempts
+	e.setError(err.Error())
+}
+
+func (e *InboundCommandEntity) setError(message string) {
+	e.Error = message
+	if len(e.Error) > 1000 {
+		e.Error = e.Error[:1000]
+	}
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"better-admin-backend-service/command/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ConsumerOffsetRepository struct {
+}
+
+func (ConsumerOffsetRepository) FindByTopicAndPartition(ctx context.Context, topic string, partition int32) (domain.ConsumerOffsetEntity, error) {
+	var entity domain.ConsumerOffsetEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("topic = ? AND partition_id = ?", topic, partition).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// Save 는 파티션의 다음 offset 을 저장한다. 없으면 새로 만든다.
+func (ConsumerOffsetRepository) Save(ctx context.Context, topic string, partition int32, nextOffset int64) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	entity := domain.ConsumerOffsetEntity{Topic: topic, PartitionId: partition, NextOffset: nextOffset}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "topic"}, {Name: "partition_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"next_offset", "updated_at"}),
+	}).Create(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"better-admin-backend-service/command/domain"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type InboundCommandRepository struct {
+}
+
+func (InboundCommandRepository) Create(ctx context.Context, entity *domain.InboundCommandEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (InboundCommandRepository) FindByCommandId(ctx context.Context, commandId string) (domain.InboundCommandEntity, error) {
+	var entity domain.InboundCommandEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("command_id = ?", commandId).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (InboundCommandRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.InboundCommandEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.InboundCommandEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "status" {
+				db.Where("status = ?", value)
+			}
+
+			if key == "type" {
+				db.Where("type = ?", value)
+			}
+		}
+	}
+
+	var entities = make([]domain.InboundCommandEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
@@ -0,0 +1,8 @@
+package config
+
+// 빌드 정보는 빌드할 때 -ldflags "-X better-admin-backend-service/config.Version=1.2.0" 처럼 설정한다.(Dockerfile 의 VERSION, GIT_SHA, BUILD_TIME)
+var (
+	Version   = "dev"
+	GitCommit string
+	BuildTime string
+)
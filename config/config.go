@@ -2,13 +2,26 @@ package config
 
 import (
 	"github.com/jinzhu/configor"
-	"os"
 )
 
 const (
+	// EnvJwtSecret 은 EnvironmentSecretProvider 가 JWT Secret(SecretJwt)을 읽는 환경 변수이다.
 	EnvJwtSecret = "JWT_SECRET"
 )
 
+// HttpClientPolicy 는 외부 연동 HTTP 호출의 제한 시간, 재시도와 회로 차단(circuit breaker) 정책이다.
+type HttpClientPolicy struct {
+	TimeoutSeconds int `default:"10"`
+	// 멱등(GET, HEAD, OPTIONS, PUT, DELETE) 요청이 연결 오류, 429, 5xx 로 실패하면 MaxRetries 번까지 RetryDelayMilliseconds 부터 두 배씩 기다렸다가 다시 보낸다.
+	MaxRetries             int `default:"2"`
+	RetryDelayMilliseconds int `default:"200"`
+	// 재시도는 요청 수의 RetryBudgetPercent(%) 만큼만 허용하여 장애 중에 재시도로 부하가 커지지 않게 한다.
+	RetryBudgetPercent int `default:"20"`
+	// 호스트별로 연결 오류나 5xx 가 FailureThreshold 번 연속되면 OpenSeconds 동안 요청을 보내지 않는다. 0 이면 차단하지 않는다.
+	FailureThreshold int `default:"5"`
+	OpenSeconds      int `default:"30"`
+}
+
 var Config = struct {
 	JwtSecret string
 	Dooray    struct {
@@ -18,6 +31,390 @@ var Config = struct {
 		OAuthUri string
 		AuthUri  string
 		TokenUri string
+		// DirectoryUri 는 구글 워크스페이스 디렉터리(Admin SDK) API 주소이다.
+		DirectoryUri string
+	}
+	JwtClaims struct {
+		// Issuer 가 있으면 발급하는 토큰의 iss 로 기록하고 iss 가 다른 토큰은 거부한다.
+		Issuer string
+		// Audience 가 있으면 발급하는 토큰의 aud 로 기록한다. AcceptedAudiences 는 Audience 외에 받아들일 aud 이다.
+		// 둘 중 하나라도 있으면 aud 가 없거나 받아들일 aud 가 아닌 토큰은 거부한다.
+		Audience          string
+		AcceptedAudiences []string
+	}
+	// OAuthState 는 외부 로그인을 시작할 때 발급하는 상태(state) 토큰의 수명과 로그인 후 돌아갈 수 있는 주소의 origin(예. https://admin.example.com)이다.
+	// 상대 경로(/ 로 시작)는 항상 허용한다.
+	OAuthState struct {
+		LifetimeSeconds        int `default:"600"`
+		AllowedRedirectOrigins []string
+	}
+	ScopedToken struct {
+		LifetimeSeconds    int `default:"300"`
+		MaxLifetimeSeconds int `default:"3600"`
+	}
+	// StepUp 은 비밀번호를 다시 확인하고 발급하는 단계 인증 토큰의 수명이다.
+	StepUp struct {
+		LifetimeSeconds int `default:"300"`
+	}
+	ClientCredentials struct {
+		DefaultLifetimeSeconds int `default:"3600"`
+		MaxLifetimeSeconds     int `default:"86400"`
+	}
+	// TokenExchange 는 토큰 교환(RFC 8693)으로 발급하는 위임 토큰의 수명이다. 정책에 수명이 없으면 DefaultLifetimeSeconds 를 사용한다.
+	TokenExchange struct {
+		DefaultLifetimeSeconds int `default:"300"`
+		MaxLifetimeSeconds     int `default:"3600"`
+	}
+	// AuthorizationCode 는 OAuth Client 에 발급하는 인가 코드와 코드로 교환한 멤버 Access 토큰의 수명이다.
+	AuthorizationCode struct {
+		LifetimeSeconds            int `default:"60"`
+		AccessTokenLifetimeSeconds int `default:"900"`
+	}
+	// DeviceAuthorization 은 CLI 도구가 사용하는 기기 인가(RFC 8628)의 코드 수명, 확인 간격과 멤버가 승인할 관리 화면 주소이다.
+	DeviceAuthorization struct {
+		LifetimeSeconds int `default:"600"`
+		IntervalSeconds int `default:"5"`
+		VerificationUri string
+	}
+	Mail struct {
+		SmtpHost string
+		SmtpPort int `default:"25"`
+		Username string
+		Password string
+		From     string
+	}
+	SignIdChange struct {
+		TokenLifetimeMinutes int `default:"1440"`
+		// ConfirmUrl, CancelUrl 의 %s 에 토큰이 들어간다.
+		ConfirmUrl string
+		CancelUrl  string
+	}
+	// LoginNotification 은 새 기기나 위치에서 로그인했을 때 보내는 알림의 "본인이 아닙니다" 링크와 비밀번호 재설정 링크이다.
+	// ReportUrl, PasswordResetUrl 의 %s 에 토큰이 들어간다.
+	LoginNotification struct {
+		TokenLifetimeMinutes int `default:"1440"`
+		ReportUrl            string
+		PasswordResetUrl     string
+	}
+	// MemberFieldChange 는 멤버 중요 필드(아이디, 메일, 최고 관리자의 역할) 변경이 승인을 기다리는 시간이다. 지나면 만료된다.
+	MemberFieldChange struct {
+		ExpiryHours int `default:"72"`
+	}
+	// RoleElevation 은 임시 권한 상승으로 요청할 수 있는 최대 시간이다.
+	RoleElevation struct {
+		MaxHours int `default:"8"`
+	}
+	// CustomAuthenticators 는 Authenticator gRPC 서비스를 제공하는 사이드카로 인증할 Authenticator 이다.
+	// SidecarAddress 는 TLS 를 사용하지 않는(h2c) host:port 이다.
+	CustomAuthenticators []struct {
+		Name           string
+		SidecarAddress string
+		TimeoutSeconds int
+	}
+	BreakGlass struct {
+		// SessionLifetimeMinutes 가 지나면 비상 접근 계정의 세션은 만료되어 Refresh 토큰으로 연장할 수 없다.
+		SessionLifetimeMinutes int `default:"60"`
+		// NotifyRoleName 역할의 멤버에게 비상 접근 계정 사용을 메일로 알린다.
+		NotifyRoleName string
+	}
+	PreAuthHook struct {
+		// Url 이 있으면 로그인할 때마다 멤버 정보를 POST 하여 로그인 허용 여부와 토큰에 추가할 클레임을 받는다.
+		Url            string
+		ApiKey         string
+		TimeoutSeconds int `default:"5"`
+		// FailureAction 은 웹훅이 응답하지 않거나 오류를 응답했을 때의 동작으로 deny(로그인 거부) 또는 allow(로그인 허용)이다.
+		FailureAction string `default:"deny"`
+	}
+	JwtClaimEnrichment struct {
+		Enrichers    []string
+		MemberFields []string
+	}
+	GeoIp struct {
+		// DatabasePath 는 오프라인 GeoIP 데이터베이스(CSV) 파일 경로이다. 비어 있으면 위치 정보를 기록하지 않는다.
+		DatabasePath string
+		// DatabaseUrl 이 있으면 UpdateIntervalHours 마다 내려받아 DatabasePath 를 교체한다.
+		DatabaseUrl         string
+		UpdateIntervalHours int `default:"24"`
+	}
+	RefreshTokenCookie struct {
+		// Secure 이면 HTTPS 로만 Refresh 토큰 쿠키를 전송한다. TLS(혹은 TLS 를 처리하는 프록시) 뒤에서 실행할 때 설정한다.
+		Secure bool
+	}
+	HttpClient struct {
+		// Default 는 Integrations 에 없는 외부 연동(dooray, google, webhook, pre-auth, file-scan, file-storage, geo-ip)의 정책이다.
+		Default HttpClientPolicy
+		// Integrations 의 정책은 설정한 값을 그대로 사용한다(TimeoutSeconds 만 0 이면 Default 를 사용).
+		Integrations map[string]HttpClientPolicy
+	}
+	Database struct {
+		// Driver 는 sqlite 또는 mysql 이다. 환경 변수(DB_DRIVER)가 있으면 환경 변수를 사용한다.
+		Driver string `default:"sqlite"`
+		// SQLite DB 파일 경로이다. 여러 연결이 같은 파일을 사용하므로 WAL 모드로 읽기와 쓰기를 동시에 하고,
+		// 다른 연결이 잠근 동안 SqliteBusyTimeoutMilliseconds 까지 기다린다.
+		SqlitePath                    string `default:"account.db"`
+		SqliteJournalMode             string `default:"WAL"`
+		SqliteBusyTimeoutMilliseconds int    `default:"5000"`
+		// 커넥션 풀 설정이다. 동시에 관리하는 사용자가 많으면 MaxOpenConns 를 늘린다(DB 의 최대 연결 수를 넘지 않게 한다).
+		MaxOpenConns           int `default:"25"`
+		MaxIdleConns           int `default:"10"`
+		ConnMaxLifetimeMinutes int `default:"10"`
+		ConnMaxIdleTimeMinutes int `default:"5"`
+		// PrepareStmt 이면 실행한 SQL 의 Prepared Statement 를 연결별로 캐시하여 다시 사용한다.
+		PrepareStmt bool
+		// MonitorIntervalSeconds 마다 풀 사용 현황을 확인하여 그 사이 연결을 기다린 횟수가 WaitWarningCount 이상이거나
+		// 평균 대기 시간이 WaitWarningMilliseconds 이상이면 경고를 기록한다. 0 이면 확인하지 않는다.
+		MonitorIntervalSeconds  int `default:"60"`
+		WaitWarningCount        int `default:"50"`
+		WaitWarningMilliseconds int `default:"100"`
+	}
+	// Diagnostics 는 /api/system/pprof 프로파일링 API 설정이다. PprofDisabled 이면 404 로 응답하고,
+	// CPU 프로파일과 trace 는 요청 제한 시간(RequestTimeout)보다 짧게 MaxProfileSeconds 까지만 수집한다.
+	Diagnostics struct {
+		PprofDisabled     bool
+		MaxProfileSeconds int `default:"10"`
+	}
+	RequestTimeout struct {
+		// DefaultSeconds 는 RouteGroups 에 없는 API 요청의 제한 시간이다. 0 이면 제한하지 않는다.
+		DefaultSeconds int `default:"30"`
+		// RouteGroups 는 라우터 그룹 경로(예. /api/files)별 제한 시간이며 가장 길게 일치하는 경로를 사용한다.
+		RouteGroups map[string]int
+	}
+	// RequestSize 는 요청 본문의 최대 크기(byte)이다. RouteGroups 는 라우터 그룹 경로별 크기이며 가장 길게 일치하는 경로를 사용한다.
+	// 0 이면 제한하지 않는다.
+	RequestSize struct {
+		DefaultBytes int64 `default:"1048576"`
+		RouteGroups  map[string]int64
+	}
+	CookieEncryption struct {
+		// Keys 의 첫 번째 키로 쿠키 값을 암호화하고 나머지(이전) 키는 복호화에만 사용한다. 키를 교체할 때 새 키를 앞에 추가한다.
+		// 비어 있으면 JwtSecret 에서 키를 만든다.
+		Keys []string
+	}
+	SiteSettingVersion struct {
+		// SigningKey 로 설정 스냅샷에 서명한다. 비어 있으면 JwtSecret 을 사용하므로 Secret 을 교체하면 이전 스냅샷으로 되돌릴 수 없다.
+		SigningKey string
+		// MaxVersions 개의 스냅샷만 남긴다. 0 이면 지우지 않는다.
+		MaxVersions int `default:"100"`
+		// 설정을 적용하기 전에 인증 서버(LDAP, OAuth)에 연결할 수 있는지 확인할 때의 제한 시간이다.
+		ValidationTimeoutSeconds int `default:"5"`
+	}
+	SelfCheck struct {
+		MinJwtSecretLength  int `default:"32"`
+		MaxClockSkewSeconds int `default:"30"`
+		// TimeServerUrl 이 있으면 응답의 Date 헤더와 서버 시각을 비교한다.
+		TimeServerUrl string
+	}
+	Status struct {
+		// GET /status 는 인증하지 않으므로 클라이언트 IP 마다 1분에 RateLimitPerMinute 번까지만 응답하고, 응답은 CacheSeconds 동안 저장한다.
+		RateLimitPerMinute int `default:"60"`
+		CacheSeconds       int `default:"15"`
+	}
+	Compatibility struct {
+		// MinFrontendVersion 보다 낮은 X-Frontend-Version 헤더로 요청하면 426(UPGRADE_REQUIRED)으로 거절한다. 비어 있으면 확인하지 않는다.
+		MinFrontendVersion string
+	}
+	PasswordHashing struct {
+		// Algorithm(bcrypt, scrypt, argon2id)과 비용으로 비밀번호를 저장한다. 로그인할 때 다른 알고리즘이나 비용으로 저장된 비밀번호는 다시 저장한다.
+		Algorithm  string `default:"bcrypt"`
+		BcryptCost int    `default:"10"`
+		// Scrypt.N 은 2 의 거듭제곱이어야 한다.
+		Scrypt struct {
+			N int `default:"32768"`
+			R int `default:"8"`
+			P int `default:"1"`
+		}
+		Argon2id struct {
+			MemoryKiB   int `default:"65536"`
+			Iterations  int `default:"3"`
+			Parallelism int `default:"2"`
+		}
+	}
+	PasswordBreach struct {
+		// RangeApiUrl 은 k-익명성 range API(Have I Been Pwned) 주소이다. 비밀번호 SHA-1 의 앞 5자리만 붙여 요청한다.
+		RangeApiUrl string `default:"https://api.pwnedpasswords.com/range/"`
+		// BloomFilterPath 는 offline 검사에서 사용하는 bloom filter 파일 경로이다. build-password-bloom-filter 로 만든다.
+		BloomFilterPath string
+	}
+	Chaos struct {
+		// Enabled 이면 /system/chaos 로 장애(지연, 오류, DB/IdP 실패)를 주입할 수 있다. 운영 환경에서는 켜지 않는다.
+		Enabled bool
+	}
+	FileStorage struct {
+		// Backend 는 local, s3, gcs 중 하나이다. gcs 는 HMAC 키로 S3 호환(XML) API 를 사용한다.
+		Backend        string `default:"local"`
+		LocalDirectory string `default:"files"`
+		MaxSizeBytes   int64  `default:"10485760"`
+		S3             struct {
+			// Endpoint 가 비어 있으면 AWS S3 의 리전 엔드포인트를 사용한다. MinIO 등 S3 호환 저장소는 Endpoint 를 지정한다.
+			Endpoint        string
+			Region          string
+			Bucket          string
+			AccessKeyId     string
+			SecretAccessKey string
+		}
+		Gcs struct {
+			Bucket          string
+			AccessKeyId     string
+			SecretAccessKey string
+		}
+	}
+	Backup struct {
+		// Enabled 이면 IntervalHours 마다 DB 를 백업하여 파일 저장소(FileStorage)의 KeyPrefix 아래에 저장하고 최근 Retain 개만 남긴다.
+		Enabled       bool
+		IntervalHours int    `default:"24"`
+		Retain        int    `default:"7"`
+		KeyPrefix     string `default:"backups/"`
+		// MySQL 은 mysqldump 로 백업하고 mysql 로 복원한다.
+		MysqlDumpCommand string `default:"mysqldump"`
+		MysqlCommand     string `default:"mysql"`
+	}
+	Attachment struct {
+		// 첨부 파일은 첨부한 날부터 대상별 보관 기간(일)이 지나면 삭제한다. 0 이면 삭제하지 않는다.
+		ApprovalRetentionDays int `default:"365"`
+		NoteRetentionDays     int `default:"730"`
+		MaxFilesPerEntity     int `default:"10"`
+	}
+	// Export 는 내보내기 작업 결과를 보관하는 시간이다. 지나면 결과 파일을 지우고 작업은 expired 가 된다.
+	Export struct {
+		RetentionHours int `default:"24"`
+	}
+	// Retention 은 데이터 종류별 기본 보관 기간(일)이다. 0 이면 지우지 않으며 사이트 설정(retention)으로 종류별로 바꿀 수 있다.
+	// IntervalHours 마다 보관 기간이 지난 데이터를 지우고 실행 보고서를 남긴다.
+	Retention struct {
+		AccessLogDays     int `default:"180"`
+		AuditLogDays      int `default:"730"`
+		NotificationDays  int `default:"90"`
+		ExportDays        int `default:"7"`
+		SecurityEventDays int `default:"365"`
+		IntervalHours     int `default:"24"`
+	}
+	// Anonymization 은 staging 용 익명화 스냅샷의 규칙이다. Rules 는 테이블별 컬럼과 익명화 방법(pseudonym, email, phone, ip-address, clear)이다.
+	// 같은 값은 Secret 으로 항상 같은 가명으로 바꾸므로 테이블 사이의 관계가 유지된다. Secret 이 비어 있으면 JwtSecret 을 사용한다.
+	Anonymization struct {
+		Secret string
+		Rules  map[string]map[string]string
+	}
+	Deprovisioning struct {
+		// 멤버를 해지(deprovisioning)하면 WebHookUrls 에 완료 보고서를 JSON 으로 POST 하여 연동 시스템이 계정을 정리하게 한다.
+		// Authorization 이 있으면 Authorization 헤더로 보낸다.
+		WebHookUrls   []string
+		Authorization string
+	}
+	FileScan struct {
+		// Scanner 는 clamav(clamd INSTREAM) 또는 http(외부 검사 API) 이다. 비어 있으면 검사하지 않는다.
+		Scanner       string
+		ClamAvAddress string `default:"localhost:3310"`
+		// HttpUrl 에 파일 내용을 POST 하면 {"infected": bool, "signature": string} 을 응답해야 한다.
+		HttpUrl        string
+		HttpApiKey     string
+		TimeoutSeconds int `default:"30"`
+		// NotifyRoleName 역할의 멤버에게 격리된 파일을 메일로 알린다.
+		NotifyRoleName string
+	}
+	Thumbnail struct {
+		// Sizes 는 만들 수 있는 썸네일 크기(가로x세로)이다. 목록에 없는 크기는 요청할 수 없다.
+		Sizes         []string
+		MaxAgeSeconds int `default:"86400"`
+	}
+	Frontend struct {
+		// Source 가 embed 이면 실행 파일에 포함된 프론트엔드를, directory 이면 Directory 의 프론트엔드를 응답한다. 비어 있으면 응답하지 않는다.
+		Source    string
+		Directory string
+		// AssetPrefixes 로 시작하는 파일(파일명에 해시가 포함된 빌드 결과)은 AssetMaxAgeSeconds 동안 캐시한다.
+		AssetPrefixes      []string
+		AssetMaxAgeSeconds int `default:"31536000"`
+	}
+	TrustedProxy struct {
+		// Cidrs 에서 온 요청만 Headers 로 클라이언트 IP 를 찾는다. 비어 있으면 어떤 프록시도 신뢰하지 않는다.
+		Cidrs []string
+		// Headers 는 클라이언트 IP 를 찾을 헤더 순서이다. 비어 있으면 X-Forwarded-For, X-Real-IP 순서로 찾는다.
+		Headers []string
+	}
+	LogShipping struct {
+		// 인증, 감사 이벤트를 주소가 설정된 싱크(Syslog, Kafka, WebHook)로 보낸다. 싱크마다 BufferSize 만큼 버퍼에 쌓아 BatchSize 씩 보낸다.
+		BufferSize           int `default:"10000"`
+		BatchSize            int `default:"100"`
+		FlushIntervalSeconds int `default:"5"`
+		// 보내지 못하면 MaxRetries 번까지 1초부터 두 배씩 기다렸다가 다시 보낸다.
+		MaxRetries int `default:"3"`
+		Syslog     struct {
+			// Address 에 CEF 형식의 syslog(RFC 5424) 메시지를 보낸다. Network 는 udp 또는 tcp 이다.
+			Network string `default:"udp"`
+			Address string
+			AppName string `default:"better-admin"`
+		}
+		Kafka struct {
+			Brokers []string
+			Topic   string `default:"better-admin.security-events"`
+			Tls     bool
+		}
+		WebHook struct {
+			// Url 에 이벤트 배열을 JSON 으로 POST 한다. Authorization 이 있으면 Authorization 헤더로 보낸다.
+			Url           string
+			Authorization string
+		}
+	}
+	// EventStore 가 Enabled 이면 멤버의 변경(member.* 도메인 이벤트)을 추가만 하는 이벤트 저장소에 기록하여 과거 시점의 상태를 조회할 수 있다.
+	EventStore struct {
+		Enabled bool
+	}
+	MessageBroker struct {
+		// 도메인 이벤트(멤버, 역할 변경)를 아웃박스(domain_events)에 기록했다가 Broker(kafka, nats)로 발행한다. 비어 있으면 기록하지 않는다.
+		Broker string
+		// TopicStrategy 가 type 이면 TopicPrefix + 이벤트 타입을 토픽으로, single 이면 Topic 하나에 eventType 헤더를 붙여 보낸다.
+		TopicStrategy          string `default:"type"`
+		TopicPrefix            string `default:"better-admin."`
+		Topic                  string `default:"better-admin.events"`
+		PublishIntervalSeconds int    `default:"5"`
+		BatchSize              int    `default:"100"`
+		// 발행에 실패하면 RetryBaseSeconds 부터 두 배씩(최대 1시간) 기다렸다가 MaxAttempts 번까지 다시 발행한다.
+		MaxAttempts      int `default:"10"`
+		RetryBaseSeconds int `default:"5"`
+		// 발행한 이벤트는 RetentionDays 가 지나면 아웃박스에서 지운다.
+		RetentionDays int `default:"7"`
+		Kafka         struct {
+			Brokers []string
+			Tls     bool
+		}
+		Nats struct {
+			// Url 은 nats://host:port 또는 tls://host:port 이다. Token 이나 Username/Password 로 인증한다.
+			Url      string `default:"nats://localhost:4222"`
+			Token    string
+			Username string
+			Password string
+		}
+		// Commands 는 다른 서비스가 보낸 명령(멤버 승인 등)을 받는 토픽이다. Topic 이 비어 있으면 구독하지 않는다.
+		Commands struct {
+			Topic string
+			// Group 은 NATS 큐 그룹이다. Kafka 는 컨슈머 그룹 없이 처리한 offset 을 DB 에 저장한다.
+			Group string `default:"better-admin"`
+			// 처리할 수 없거나 MaxAttempts 번 실패한 명령은 DeadLetterTopic 으로 보낸다.
+			DeadLetterTopic string `default:"better-admin.commands.dlq"`
+			MaxAttempts     int    `default:"3"`
+		}
+	}
+	SharedState struct {
+		// Backend 가 redis 이면 잠금, 인스턴스 사이의 알림을 Redis 로 공유한다. 여러 인스턴스(replica)로 실행할 때 사용한다.
+		// memory 이면 인스턴스 하나의 메모리에 저장한다.
+		Backend string `default:"memory"`
+		// RedisUrl 은 redis://[:password@]host:port/db 또는 rediss://host:port/db 이다.
+		RedisUrl       string `default:"redis://localhost:6379/0"`
+		RedisPassword  string
+		KeyPrefix      string `default:"better-admin:"`
+		TimeoutSeconds int    `default:"3"`
+	}
+	Scheduler struct {
+		// 여러 인스턴스 중 리더 인스턴스만 주기 작업을 실행한다. LeaderElection 이 shared-state 이면 공유 상태 저장소(SharedState.Backend)로,
+		// database 이면 DB 테이블(cluster_locks)로 리더를 선출한다. 리더가 멈추면 LeaderTtlSeconds 가 지난 뒤 다른 인스턴스가 리더가 된다.
+		LeaderElection   string `default:"shared-state"`
+		LeaderTtlSeconds int    `default:"30"`
+	}
+	License struct {
+		// FilePath 는 서명한 라이선스 파일이다. 비어 있으면 멤버, 역할, API Key 수를 제한하지 않는다.
+		FilePath string
+		// 사용량이 최대 수의 WarningPercent % 에 이르면 NotifyRoleName 역할의 멤버에게 메일로 알린다.
+		WarningPercent int `default:"90"`
+		NotifyRoleName string
 	}
 }{}
 
@@ -27,9 +424,5 @@ func InitConfig(file string) error {
 		return err
 	}
 
-	if len(os.Getenv(EnvJwtSecret)) > 0 {
-		Config.JwtSecret = os.Getenv(EnvJwtSecret)
-	}
-
 	return nil
 }
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// Secret 의 이름이다. SecretProvider 는 이 이름으로 값을 조회한다.
+const (
+	SecretJwt                          = "jwt-secret"
+	SecretCookieEncryptionKeys         = "cookie-encryption-keys"
+	SecretSiteSettingVersionSigningKey = "site-setting-version-signing-key"
+	SecretAnonymization                = "anonymization-secret"
+)
+
+// SecretProvider 는 이름(Secret*)으로 Secret 을 조회한다. 값이 없으면 빈 문자열이다.
+// 배포 환경에 따라 비밀 저장소(예. Vault, KMS)를 사용하는 구현을 RegisterSecretProvider 로 등록한다.
+// 여러 값(CookieEncryption.Keys)은 쉼표로 구분한다.
+type SecretProvider interface {
+	GetSecret(name string) (string, error)
+}
+
+// EnvironmentSecretProvider 는 이름을 대문자와 밑줄로 바꾼 환경 변수(예. jwt-secret 은 JWT_SECRET)를 읽고, 없으면 설정 파일의 값을 사용한다.
+type EnvironmentSecretProvider struct {
+}
+
+func (EnvironmentSecretProvider) GetSecret(name string) (string, error) {
+	if value := os.Getenv(secretEnvironmentName(name)); len(value) > 0 {
+		return value, nil
+	}
+
+	switch name {
+	case SecretJwt:
+		return Config.JwtSecret, nil
+	case SecretCookieEncryptionKeys:
+		return strings.Join(Config.CookieEncryption.Keys, ","), nil
+	case SecretSiteSettingVersionSigningKey:
+		return Config.SiteSettingVersion.SigningKey, nil
+	case SecretAnonymization:
+		return Config.Anonymization.Secret, nil
+	}
+
+	return "", nil
+}
+
+func secretEnvironmentName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+var (
+	secretProviderMutex sync.RWMutex
+	secretProvider      SecretProvider = EnvironmentSecretProvider{}
+	rotatedJwtSecret    string
+)
+
+// RegisterSecretProvider 는 Secret 을 조회할 구현을 바꾼다. nil 이면 EnvironmentSecretProvider 를 사용한다.
+func RegisterSecretProvider(provider SecretProvider) {
+	secretProviderMutex.Lock()
+	defer secretProviderMutex.Unlock()
+
+	if provider == nil {
+		provider = EnvironmentSecretProvider{}
+	}
+	secretProvider = provider
+}
+
+func GetSecret(name string) (string, error) {
+	secretProviderMutex.RLock()
+	defer secretProviderMutex.RUnlock()
+
+	return secretProvider.GetSecret(name)
+}
+
+// GetJwtSecret 은 JWT 서명 Secret 이다. 교체한 Secret(SetRotatedJwtSecret)이 있으면 SecretProvider 보다 우선한다.
+func GetJwtSecret() (string, error) {
+	secretProviderMutex.RLock()
+	secret := rotatedJwtSecret
+	secretProviderMutex.RUnlock()
+
+	if len(secret) > 0 {
+		return secret, nil
+	}
+
+	return GetSecret(SecretJwt)
+}
+
+// SetRotatedJwtSecret 은 교체하여 DB 에 저장한 JWT Secret 을 사용한다. 빈 문자열이면 다시 SecretProvider 의 값을 사용한다.
+func SetRotatedJwtSecret(secret string) {
+	secretProviderMutex.Lock()
+	defer secretProviderMutex.Unlock()
+
+	rotatedJwtSecret = secret
+}
@@ -6,28 +6,633 @@ const (
 	PreDefineTypeName  = "사전정의"
 	UserDefineTypeKey  = "user-define"
 	UserDefineTypeName = "사용자정의"
+	CatalogTypeKey     = "catalog"
+	CatalogTypeName    = "서비스등록"
 
 	// Permissaion
-	PermissionManageAccessControl  = "MANAGE_ACCESS_CONTROL"
-	PermissionManageMembers        = "MANAGE_MEMBERS"
-	PermissionManageOrganization   = "MANAGE_ORGANIZATION"
-	PermissionManageSystemSettings = "MANAGE_SYSTEM_SETTINGS"
-	PermissionNoteWebHooks         = "NOTE_WEB_HOOKS"
-	PermissionViewMonitoring       = "VIEW_MONITORING"
+	PermissionManageAccessControl       = "MANAGE_ACCESS_CONTROL"
+	PermissionManageMembers             = "MANAGE_MEMBERS"
+	PermissionManageOrganization        = "MANAGE_ORGANIZATION"
+	PermissionManageSystemSettings      = "MANAGE_SYSTEM_SETTINGS"
+	PermissionNoteWebHooks              = "NOTE_WEB_HOOKS"
+	PermissionViewMonitoring            = "VIEW_MONITORING"
+	PermissionBypassMaintenance         = "BYPASS_MAINTENANCE"
+	PermissionViewMemberPersonalInfo    = "VIEW_MEMBER_PERSONAL_INFO"
+	PermissionUnmask                    = "UNMASK"
+	PermissionRegisterPermissionCatalog = "REGISTER_PERMISSION_CATALOG"
+	PermissionManageOwnResources        = "MANAGE_OWN_RESOURCES"
+	PermissionSystemDiagnostics         = "SYSTEM_DIAGNOSTICS"
 
 	// Member
-	TypeMemberSite       = "site"
-	TypeMemberSiteName   = "사이트"
-	TypeMemberDooray     = "dooray"
-	TypeMemberDoorayName = "두레이"
-	TypeMemberGoogle     = "google"
-	TypeMemberGoogleName = "구글"
-	StatusMemberApplied  = "applied"
-	StatusMemberApproved = "approved"
+	TypeMemberSite            = "site"
+	TypeMemberSiteName        = "사이트"
+	TypeMemberDooray          = "dooray"
+	TypeMemberDoorayName      = "두레이"
+	TypeMemberGoogle          = "google"
+	TypeMemberGoogleName      = "구글"
+	TypeMemberCustom          = "custom"
+	TypeMemberCustomName      = "외부 인증"
+	TypeMemberBreakGlass      = "break-glass"
+	StatusMemberApplied       = "applied"
+	StatusMemberApproved      = "approved"
+	StatusMemberDeprovisioned = "deprovisioned"
+
+	// Sign Id Change
+	SignIdChangeStatusPending   = "pending"
+	SignIdChangeStatusConfirmed = "confirmed"
+	SignIdChangeStatusCanceled  = "canceled"
+	SignIdChangeStatusExpired   = "expired"
+
+	// Member Field Change
+	MemberFieldSignId               = "sign-id"
+	MemberFieldEmail                = "email"
+	MemberFieldRoles                = "roles"
+	MemberFieldChangeStatusPending  = "pending"
+	MemberFieldChangeStatusApplied  = "applied"
+	MemberFieldChangeStatusRejected = "rejected"
+	MemberFieldChangeStatusExpired  = "expired"
+
+	// Role Elevation
+	RoleElevationStatusPending  = "pending"
+	RoleElevationStatusActive   = "active"
+	RoleElevationStatusRejected = "rejected"
+	RoleElevationStatusExpired  = "expired"
+	RoleElevationStatusRevoked  = "revoked"
+	// 최고 관리자 역할. 이 역할이 있거나 받게 되는 멤버의 역할 변경은 중요 필드 변경으로 승인을 받는다.
+	RoleNameSuperAdmin = "SYSTEM MANAGER"
+
+	// Logging
+	LoggingModuleAuth     = "auth"
+	LoggingModuleDb       = "db"
+	LoggingModuleWebHooks = "webhooks"
+
+	// Password Hashing
+	PasswordHashAlgorithmBcrypt   = "bcrypt"
+	PasswordHashAlgorithmScrypt   = "scrypt"
+	PasswordHashAlgorithmArgon2id = "argon2id"
+	PasswordHashAlgorithmUnknown  = "unknown"
+
+	// Password Breach Check (유출된 비밀번호 확인)
+	PasswordBreachCheckModeOff      = "off"
+	PasswordBreachCheckModeOnline   = "online"
+	PasswordBreachCheckModeOffline  = "offline"
+	PasswordBreachCheckActionWarn   = "warn"
+	PasswordBreachCheckActionReject = "reject"
+
+	// Security Event (보안 이벤트)
+	SecurityEventTypeLoginFailed      = "login-failed"
+	SecurityEventTypeLockout          = "lockout"
+	SecurityEventTypeImpersonation    = "impersonation"
+	SecurityEventTypePermissionDenied = "permission-denied"
+	SecurityEventTypeAnomaly          = "anomaly"
+	SecurityEventTypeBreakGlassUsed   = "break-glass-used"
+	SecurityEventTypeReadOnlyWrite    = "read-only-write"
+	SecurityEventTypeSuperAdminRole   = "super-admin-changed"
+	SecurityEventTypeSuperAdminBlock  = "super-admin-blocked"
+	SecurityEventSeverityLow          = "low"
+	SecurityEventSeverityMedium       = "medium"
+	SecurityEventSeverityHigh         = "high"
+	SecurityEventSeverityCritical     = "critical"
+	SecurityEventStatusOpen           = "open"
+	SecurityEventStatusAcknowledged   = "acknowledged"
+
+	// Chaos (장애 주입)
+	ChaosFaultDatabase = "database"
+	ChaosFaultIdp      = "idp"
+
+	// Self Check
+	SelfCheckSeverityError   = "error"
+	SelfCheckSeverityWarning = "warning"
 
 	// Settings
 	SettingKeyDoorayLogin          = "dooray-login"
 	SettingKeyGoogleWorkspaceLogin = "google-workspace-login"
 	SettingKeyMemberAccessLog      = "member-access-log"
 	SettingKeyAppVersion           = "app-version"
+	SettingKeySessionLimit         = "session-limit"
+	SettingKeyTokenEpoch           = "token-epoch"
+	SettingKeyJwtSecret            = "jwt-secret"
+	SettingKeyApprovalWorkflow     = "approval-workflow"
+	SettingKeyPendingSignUp        = "pending-signup"
+	SettingKeyMaintenance          = "maintenance"
+	SettingKeyRefreshTokenBinding  = "refresh-token-binding"
+	SettingKeyDataMasking          = "data-masking"
+	SettingKeyLogin                = "login"
+	SettingKeyMemberApproval       = "member-approval"
+	SettingKeyAutoApproval         = "auto-approval"
+	SettingKeyGoogleWorkspace      = "google-workspace"
+	SettingKeyConcurrencyLimit     = "concurrency-limit"
+	SettingKeyAnnouncementBanners  = "announcement-banners"
+	SettingKeyPasswordBreachCheck  = "password-breach-check"
+	SettingKeySecurityEventRule    = "security-event-rules"
+	SettingKeyLoginNotification    = "login-notification"
+	SettingKeyReadOnly             = "read-only"
+	SettingKeySuperAdminProtection = "super-admin-protection"
+	SettingKeyFileQuota            = "file-quota"
+	SettingKeyRetention            = "retention"
+	SettingKeyPasswordPolicy       = "password-policy"
+	SettingKeySessionLifetime      = "session-lifetime"
+
+	// Announcement Banner
+	AnnouncementBannerLevelInfo     = "info"
+	AnnouncementBannerLevelWarning  = "warning"
+	AnnouncementBannerLevelCritical = "critical"
+
+	// Service Status
+	ServiceStatusOperational = "operational"
+	ServiceStatusDegraded    = "degraded"
+	ServiceStatusMaintenance = "maintenance"
+
+	// Google Workspace
+	GoogleOAuthScopeDirectoryUserReadonly  = "https://www.googleapis.com/auth/admin.directory.user.readonly"
+	GoogleOAuthScopeDirectoryGroupReadonly = "https://www.googleapis.com/auth/admin.directory.group.readonly"
+
+	// Plugin Setting
+	PluginSettingMaxValueBytes = 64 * 1024
+	PluginSettingActionChanged = "plugin-setting-changed"
+	PluginSettingActionDeleted = "plugin-setting-deleted"
+
+	// Member Preference
+	PreferenceMaxValueBytes         = 16 * 1024
+	PreferenceMaxNamespaces         = 30
+	PreferenceNamespaceTheme        = "theme"
+	PreferenceNamespaceNotification = "notification"
+	PreferenceNamespaceTableColumns = "table-columns"
+
+	// Table View
+	TableViewTableMembers   = "members"
+	TableViewTableAuditLogs = "audit-logs"
+	TableViewTableRoles     = "roles"
+	TableViewMaxPerTable    = 50
+
+	// I18n
+	LocaleBundleMaxMessages    = 5000
+	LocaleBundleMaxKeyLength   = 200
+	LocaleBundleMaxValueLength = 5000
+	LocaleBundleMaxVersions    = 100
+
+	// Message Template
+	MessageTemplateApprovalRequested       = "approval-requested"
+	MessageTemplateSignIdChangeConfirm     = "sign-id-change-confirm"
+	MessageTemplateSignIdChangeRequested   = "sign-id-change-requested"
+	MessageTemplateSignIdChanged           = "sign-id-changed"
+	MessageTemplateLoginNewDevice          = "login-new-device"
+	MessageTemplatePasswordReset           = "password-reset"
+	MessageTemplateSessionClientMismatched = "session-client-mismatched"
+	MessageTemplateBreakGlassUsed          = "break-glass-used"
+	MessageTemplateSignUpReminder          = "sign-up-reminder"
+	MessageTemplateSignUpEscalated         = "sign-up-escalated"
+	MessageTemplateSignUpExpired           = "sign-up-expired"
+	MessageTemplateNoteMentioned           = "note-mentioned"
+	MessageTemplateReportDelivered         = "report-delivered"
+	MessageTemplateFileQuarantined         = "file-quarantined"
+	MessageTemplateSecurityEvent           = "security-event"
+	MessageTemplateLicenseQuotaWarning     = "license-quota-warning"
+	MessageTemplateMaxSubjectLength        = 200
+	MessageTemplateMaxBodyLength           = 10000
+
+	// Session
+	SessionLimitExceedActionBlock        = "block"
+	SessionLimitExceedActionRevokeOldest = "revoke-oldest"
+	SessionRevokedReasonLogout           = "logout"
+	SessionRevokedReasonLimitExceeded    = "limit-exceeded"
+	SessionRevokedReasonSignIdChanged    = "sign-id-changed"
+	SessionRevokedReasonDeprovisioned    = "deprovisioned"
+	SessionRevokedReasonLoginReported    = "login-reported"
+	RefreshTokenBindingActionWarn        = "warn"
+	RefreshTokenBindingActionEnforce     = "enforce"
+	LoginNotificationChannelMail         = "mail"
+	LoginNotificationChannelInApp        = "in-app"
+
+	// Organization Setting (조직별 설정의 값을 정한 단계)
+	OrganizationSettingLevelOrganization = "organization"
+	OrganizationSettingLevelGlobal       = "global"
+	PasswordPolicyMaxMinLength           = 128
+	SessionLifetimeMaxMinutes            = 60 * 24 * 7
+
+	// License Resource (라이선스로 수를 제한하는 대상)
+	LicenseResourceMembers = "members"
+	LicenseResourceRoles   = "roles"
+	LicenseResourceApiKeys = "api-keys"
+
+	// Login Method
+	LoginMethodPassword        = "password"
+	LoginMethodDooray          = "dooray"
+	LoginMethodGoogleWorkspace = "google-workspace"
+	LoginMethodSaml            = "saml"
+	LoginMethodLdap            = "ldap"
+	LoginMethodPasskey         = "passkey"
+
+	// Use Case Failure (전송 계층과 상관없는 유스케이스 실패 종류)
+	FailureKindInvalidCredential    = "invalid-credential"
+	FailureKindUnapproved           = "unapproved"
+	FailureKindSessionLimitExceeded = "session-limit-exceeded"
+	FailureKindDenied               = "denied"
+	FailureKindNotFound             = "not-found"
+	FailureKindUnauthorized         = "unauthorized"
+	FailureKindInvalidRefreshToken  = "invalid-refresh-token"
+	FailureKindInvalidRequest       = "invalid-request"
+
+	// Data Masking
+	DataMaskingMethodName  = "name"
+	DataMaskingMethodEmail = "email"
+	DataMaskingMethodPhone = "phone"
+	DataMaskingMethodFull  = "full"
+
+	// Approval
+	ApprovalSubjectMemberSignUp   = "member-signup"
+	ApprovalSubjectRoleGrant      = "role-grant"
+	ApprovalSubjectApiKeyCreation = "api-key-creation"
+	ApprovalStatusPending         = "pending"
+	ApprovalStatusApproved        = "approved"
+	ApprovalStatusRejected        = "rejected"
+	ApprovalStatusExpired         = "expired"
+	ApprovalDecisionApproved      = "approved"
+	ApprovalDecisionRejected      = "rejected"
+	// 멤버 중요 필드 변경은 요청한 관리자가 아닌 다른 관리자가 승인해야 한다.
+	ApprovalSubjectMemberFieldChange = "member-field-change"
+	// 임시 권한 상승은 승인 절차가 정의되어 있어야 요청할 수 있고, 요청한 멤버가 아닌 다른 멤버가 승인해야 한다.
+	ApprovalSubjectRoleElevation = "role-elevation"
+
+	// OAuth
+	OAuthGrantTypeClientCredentials = "client_credentials"
+	OAuthGrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+	OAuthGrantTypeAuthorizationCode = "authorization_code"
+	OAuthGrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+	OAuthTokenTypeBearer            = "Bearer"
+	OAuthTokenTypeAccessToken       = "urn:ietf:params:oauth:token-type:access_token"
+	OAuthErrorInvalidRequest        = "invalid_request"
+	OAuthErrorInvalidClient         = "invalid_client"
+	OAuthErrorInvalidGrant          = "invalid_grant"
+	OAuthErrorInvalidScope          = "invalid_scope"
+	OAuthErrorInvalidTarget         = "invalid_target"
+	OAuthErrorUnsupportedGrantType  = "unsupported_grant_type"
+	OAuthErrorConsentRequired       = "consent_required"
+	OAuthErrorUnauthorizedClient    = "unauthorized_client"
+	OAuthErrorAuthorizationPending  = "authorization_pending"
+	OAuthErrorSlowDown              = "slow_down"
+	OAuthErrorAccessDenied          = "access_denied"
+	OAuthErrorExpiredToken          = "expired_token"
+	OAuthCodeChallengeMethodS256    = "S256"
+	OAuthCodeChallengeMethodPlain   = "plain"
+	OAuthPkcePolicyS256             = "s256"
+	OAuthPkcePolicyRequired         = "required"
+	OAuthPkcePolicyOptional         = "optional"
+	OAuthClaimKeyClientId           = "client_id"
+	OAuthClaimKeyScope              = "scope"
+	OAuthDeviceCodeStatusPending    = "pending"
+	OAuthDeviceCodeStatusApproved   = "approved"
+	OAuthDeviceCodeStatusDenied     = "denied"
+
+	// JWT Claim Enricher
+	ClaimEnricherOrganizationPath = "organization-path"
+	ClaimEnricherMemberFields     = "member-fields"
+
+	// Audit
+	AuditActorTypeMember                    = "member"
+	AuditActorTypeSystem                    = "system"
+	AuditActorTypeServiceAccount            = "service-account"
+	AuditTargetTypeSession                  = "session"
+	AuditActionSessionRevoked               = "session-force-revoked"
+	AuditActionSessionClientMismatched      = "session-client-mismatched"
+	AuditTargetTypeSystem                   = "system"
+	AuditActionForceLogout                  = "force-logout"
+	AuditActionLoggingChanged               = "logging-changed"
+	AuditActionChaosChanged                 = "chaos-changed"
+	AuditActionRotateSecret                 = "jwt-secret-rotated"
+	AuditTargetTypeServiceAccount           = "service-account"
+	AuditActionServiceAccountCreated        = "service-account-created"
+	AuditActionServiceAccountDeleted        = "service-account-deleted"
+	AuditActionServiceAccountRoleAssigned   = "service-account-role-assigned"
+	AuditActionClientSecretIssued           = "client-secret-issued"
+	AuditActionTokenExchangePolicyChanged   = "token-exchange-policy-changed"
+	AuditActionTokenExchanged               = "token-exchanged"
+	AuditActionTokenRevoked                 = "token-revoked"
+	AuditTargetTypeOAuthClient              = "oauth-client"
+	AuditActionConsentGranted               = "consent-granted"
+	AuditActionConsentRevoked               = "consent-revoked"
+	AuditTargetTypeMember                   = "member"
+	AuditActionSignIdChangeRequested        = "sign-id-change-requested"
+	AuditActionSignIdChangeConfirmed        = "sign-id-changed"
+	AuditActionSignIdChangeCanceled         = "sign-id-change-canceled"
+	AuditActionMemberIdentityLinked         = "member-identity-linked"
+	AuditActionMemberIdentityUnlinked       = "member-identity-unlinked"
+	AuditActionMemberGroupRolesSynced       = "member-group-roles-synced"
+	AuditActionMemberFieldChangeRequested   = "member-field-change-requested"
+	AuditActionMemberFieldChangeApplied     = "member-field-change-applied"
+	AuditActionMemberFieldChangeRejected    = "member-field-change-rejected"
+	AuditActionMemberFieldChangeExpired     = "member-field-change-expired"
+	AuditActionLoginReported                = "login-reported"
+	AuditActionPasswordReset                = "password-reset"
+	AuditTargetTypeApproval                 = "approval"
+	AuditActionApprovalRequested            = "approval-requested"
+	AuditActionApprovalStepApproved         = "approval-step-approved"
+	AuditActionApprovalApproved             = "approval-approved"
+	AuditActionApprovalRejected             = "approval-rejected"
+	AuditActionApprovalEscalated            = "approval-escalated"
+	AuditActionApprovalExpired              = "approval-expired"
+	AuditTargetTypeApprovalDelegation       = "approval-delegation"
+	AuditActionApprovalDelegated            = "approval-delegated"
+	AuditActionApprovalDelegationDeleted    = "approval-delegation-deleted"
+	AuditActionSignUpEscalated              = "sign-up-escalated"
+	AuditActionSignUpExpired                = "sign-up-expired"
+	AuditActionApiKeyIssued                 = "api-key-issued"
+	AuditActionCredentialsRevoked           = "credentials-revoked"
+	AuditTargetTypeFile                     = "file"
+	AuditActionFileQuarantined              = "file-quarantined"
+	AuditActionDiagnosticsDumpCaptured      = "diagnostics-dump-captured"
+	AuditTargetTypeBreakGlassAccount        = "break-glass-account"
+	AuditActionBreakGlassAccountCreated     = "break-glass-account-created"
+	AuditActionBreakGlassAccountDeleted     = "break-glass-account-deleted"
+	AuditActionBreakGlassAccountUsed        = "break-glass-account-used"
+	AuditTargetTypeRole                     = "role"
+	AuditActionRoleMembersAssigned          = "role-members-assigned"
+	AuditActionRoleMembersRemoved           = "role-members-removed"
+	AuditActionRoleMembersMerged            = "role-members-merged"
+	AuditTargetTypePermissionCatalog        = "permission-catalog"
+	AuditActionPermissionCatalogChanged     = "permission-catalog-changed"
+	AuditActionMembersBulkApproved          = "members-bulk-approved"
+	AuditActionMemberAutoApproved           = "member-auto-approved"
+	AuditTargetTypeChangeRequest            = "change-request"
+	AuditActionChangeRequestApproved        = "change-request-approved"
+	AuditActionChangeRequestRejected        = "change-request-rejected"
+	AuditActionChangeRequestApplied         = "change-request-applied"
+	AuditActionChangeRequestRolledBack      = "change-request-rolled-back"
+	AuditActionNoteCreated                  = "note-created"
+	AuditActionNoteUpdated                  = "note-updated"
+	AuditActionNoteDeleted                  = "note-deleted"
+	AuditTargetTypeNote                     = "note"
+	AuditActionAttachmentAdded              = "attachment-added"
+	AuditActionAttachmentDownloaded         = "attachment-downloaded"
+	AuditActionAttachmentExpired            = "attachment-expired"
+	AuditActionMemberDataExported           = "member-data-exported"
+	AuditActionMemberDeprovisioned          = "member-deprovisioned"
+	AuditActionResourceOwnershipTransferred = "resource-ownership-transferred"
+	AuditTargetTypeSecurityEvent            = "security-event"
+	AuditActionSecurityEventAcknowledged    = "security-event-acknowledged"
+	AuditTargetTypeLegalHold                = "legal-hold"
+	AuditActionLegalHoldCreated             = "legal-hold-created"
+	AuditActionLegalHoldReleased            = "legal-hold-released"
+	AuditTargetTypeRoleElevation            = "role-elevation"
+	AuditActionRoleElevationRequested       = "role-elevation-requested"
+	AuditActionRoleElevationActivated       = "role-elevation-activated"
+	AuditActionRoleElevationRejected        = "role-elevation-rejected"
+	AuditActionRoleElevationExpired         = "role-elevation-expired"
+	AuditActionRoleElevationRevoked         = "role-elevation-revoked"
+	AuditTargetTypeLocaleBundle             = "locale-bundle"
+	AuditActionLocaleBundleChanged          = "locale-bundle-changed"
+	AuditActionLocaleBundleImported         = "locale-bundle-imported"
+	AuditActionLocaleBundleRolledBack       = "locale-bundle-rolled-back"
+	AuditTargetTypeMessageTemplate          = "message-template"
+	AuditActionMessageTemplateChanged       = "message-template-changed"
+	AuditActionMessageTemplateReset         = "message-template-reset"
+	AuditTargetTypeOrganization             = "organization"
+	AuditActionOrganizationSettingChanged   = "organization-setting-changed"
+
+	// Role Member Bulk
+	RoleMemberBulkActionAssign           = "assign"
+	RoleMemberBulkActionRemove           = "remove"
+	RoleMemberBulkJobStatusPending       = "pending"
+	RoleMemberBulkJobStatusSucceeded     = "succeeded"
+	RoleMemberBulkJobStatusFailed        = "failed"
+	RoleMemberBulkFailureNotFound        = "not-found"
+	RoleMemberBulkFailureAlreadyAssigned = "already-assigned"
+	RoleMemberBulkFailureNotAssigned     = "not-assigned"
+	// DB 쿼리의 IN 조건이 너무 길어지지 않도록 멤버를 나누어 처리한다.
+	RoleMemberBulkChunkSize = 500
+
+	// Retention
+	RetentionCategoryAccessLogs     = "access-logs"
+	RetentionCategoryAuditLogs      = "audit-logs"
+	RetentionCategoryNotifications  = "notifications"
+	RetentionCategoryExports        = "exports"
+	RetentionCategorySecurityEvents = "security-events"
+	RetentionRunTriggerScheduled    = "scheduled"
+	RetentionRunTriggerManual       = "manual"
+	RetentionRunStatusSucceeded     = "succeeded"
+	RetentionRunStatusFailed        = "failed"
+
+	// Export Job
+	ExportTypeMemberData     = "member-data"
+	ExportTypeReport         = "report"
+	ExportJobStatusPending   = "pending"
+	ExportJobStatusRunning   = "running"
+	ExportJobStatusSucceeded = "succeeded"
+	ExportJobStatusFailed    = "failed"
+	ExportJobStatusExpired   = "expired"
+
+	// Member Bulk Approval
+	MemberBulkApprovalApproved                = "approved"
+	MemberBulkApprovalFailed                  = "failed"
+	MemberBulkApprovalFailureNotFound         = "not-found"
+	MemberBulkApprovalFailureAlreadyApproved  = "already-approved"
+	MemberBulkApprovalFailureApprovalProgress = "approval-in-progress"
+
+	// Activity Feed
+	ActivityEventTypeAudit    = "audit"
+	ActivityEventTypeLogin    = "login"
+	ActivityEventTypeApproval = "approval"
+	ActivityEventTypeNote     = "note"
+	ActivityActionLogin       = "login"
+
+	// Report
+	ReportEntityMembers           = "members"
+	ReportEntityAuditLogs         = "audit-logs"
+	ReportEntityActivityFeeds     = "activity-feeds"
+	ReportEntityLegalHolds        = "legal-holds"
+	ReportFormatCsv               = "csv"
+	ReportFormatXlsx              = "xlsx"
+	ReportDeliveryChannelEmail    = "email"
+	ReportDeliveryChannelWebHook  = "webhook"
+	ReportRunTriggerManual        = "manual"
+	ReportRunTriggerScheduled     = "scheduled"
+	ReportRunStatusSucceeded      = "succeeded"
+	ReportRunStatusFailed         = "failed"
+	ReportDeliveryStatusNone      = "none"
+	ReportDeliveryStatusDelivered = "delivered"
+	ReportDeliveryStatusFailed    = "failed"
+	ReportMaxRows                 = 100000
+
+	// Change Request
+	ChangeRequestStatusDraft      = "draft"
+	ChangeRequestStatusInReview   = "in-review"
+	ChangeRequestStatusApproved   = "approved"
+	ChangeRequestStatusRejected   = "rejected"
+	ChangeRequestStatusCancelled  = "cancelled"
+	ChangeRequestStatusApplied    = "applied"
+	ChangeRequestStatusRolledBack = "rolled-back"
+	ChangeRequestItemTypeSetting  = "setting"
+	ChangeRequestItemTypeRole     = "role"
+
+	// Note
+	NoteMaxMentions             = 20
+	NotificationTypeNoteMention = "note-mention"
+
+	// Member Deprovisioning
+	DeprovisioningStepDeactivate            = "deactivate"
+	DeprovisioningStepRevokeSessions        = "revoke-sessions"
+	DeprovisioningStepRevokeApiKeys         = "revoke-api-keys"
+	DeprovisioningStepRemoveRoles           = "remove-roles"
+	DeprovisioningStepTransferResources     = "transfer-resources"
+	DeprovisioningStepNotifyIntegrations    = "notify-integrations"
+	DeprovisioningStepStatusDone            = "done"
+	DeprovisioningStepStatusSkipped         = "skipped"
+	DeprovisioningStepStatusFailed          = "failed"
+	DeprovisioningStatusCompleted           = "completed"
+	DeprovisioningStatusCompletedWithErrors = "completed-with-errors"
+
+	// Resource Ownership
+	OwnedResourceTypeWebHook        = "web-hook"
+	OwnedResourceTypeReport         = "report"
+	OwnedResourceTypeServiceAccount = "service-account"
+	OwnedResourceTypeSegment        = "segment"
+
+	// Usage Statistics
+	UsageStatisticPeriodDaily           = "daily"
+	UsageStatisticPeriodWeekly          = "weekly"
+	UsageStatisticMetricLogins          = "logins"
+	UsageStatisticMetricActiveMembers   = "active-members"
+	UsageStatisticMetricLoginFailures   = "login-failures"
+	UsageStatisticBreakdownOrganization = "organization"
+	UsageStatisticMaxBackfillDays       = 90
+	LoginFailureReasonUnknownMember     = "unknown-member"
+	LoginFailureReasonInvalidCredential = "invalid-credential"
+	LoginFailureReasonUnApproved        = "unapproved"
+	LoginFailureReasonSessionLimit      = "session-limit-exceeded"
+	LoginFailureReasonInvalidAccount    = "invalid-account"
+	LoginFailureReasonPreAuthDenied     = "pre-auth-denied"
+	LoginFailureReasonPasswordReset     = "password-reset-required"
+	LoginFailureReasonError             = "error"
+
+	// File
+	FilePurposeAvatar   = "avatar"
+	FilePurposeBranding = "branding"
+	FilePurposeImport   = "import"
+	FilePurposeReport   = "report"
+	// FilePurposeAttachment 는 결재 요청, 메모의 첨부 파일로, 첨부한 대상의 API 로만 내려받을 수 있다.
+	FilePurposeAttachment = "attachment"
+	// FilePurposeDiagnostics 는 런타임 진단 덤프(goroutine, heap)로, SYSTEM_DIAGNOSTICS 권한이 있어야 내려받을 수 있다.
+	FilePurposeDiagnostics = "diagnostics"
+	// FilePurposeExport 는 내보내기 작업의 결과로, 요청한 멤버만 내려받을 수 있다.
+	FilePurposeExport = "export"
+	FileScannerClamAv = "clamav"
+	FileScannerHttp   = "http"
+
+	// Diagnostics
+	DiagnosticsDumpGoroutine = "goroutine"
+	DiagnosticsDumpHeap      = "heap"
+
+	// Pre Auth Hook
+	PreAuthFailureActionAllow = "allow"
+	PreAuthFailureActionDeny  = "deny"
+	// 검사기가 없으면 not-scanned, 바이러스가 있으면 격리(infected)하여 내려받을 수 없다.
+	FileScanStatusNotScanned = "not-scanned"
+	FileScanStatusClean      = "clean"
+	FileScanStatusInfected   = "infected"
+
+	// Organization Chart
+	OrganizationChartFormatJson = "json"
+	OrganizationChartFormatCsv  = "csv"
+	OrganizationChartFormatDot  = "dot"
+
+	// Event
+	EventTypeAudit         = "audit"
+	EventTypeLogin         = "login"
+	EventTypePluginSetting = "plugin-setting"
+	LogSinkSyslog          = "syslog"
+	LogSinkKafka           = "kafka"
+	LogSinkWebHook         = "webhook"
+	SyslogFacilityAuthPriv = 10
+
+	// HTTP Client (외부 연동별 정책과 현황의 이름)
+	HttpClientDooray            = "dooray"
+	HttpClientGoogle            = "google"
+	HttpClientWebHook           = "webhook"
+	HttpClientPreAuth           = "pre-auth"
+	HttpClientFileScan          = "file-scan"
+	HttpClientFileStorage       = "file-storage"
+	HttpClientGeoIp             = "geo-ip"
+	HttpClientPasswordBreach    = "password-breach"
+	CircuitBreakerStateClosed   = "closed"
+	CircuitBreakerStateOpen     = "open"
+	CircuitBreakerStateHalfOpen = "half-open"
+
+	// Domain Event
+	DomainEventSource                = "better-admin"
+	DomainEventSchemaVersion         = 1
+	DomainEventStatusPending         = "pending"
+	DomainEventStatusPublished       = "published"
+	DomainEventStatusFailed          = "failed"
+	DomainEventAggregateMember       = "member"
+	DomainEventAggregateRole         = "role"
+	DomainEventMemberCreated         = "member.created"
+	DomainEventMemberSignedUp        = "member.signed-up"
+	DomainEventMemberApproved        = "member.approved"
+	DomainEventMemberRejected        = "member.rejected"
+	DomainEventMemberRolesAssigned   = "member.roles-assigned"
+	DomainEventMemberSignIdChanged   = "member.sign-id-changed"
+	DomainEventMemberDeprovisioned   = "member.deprovisioned"
+	DomainEventRoleCreated           = "role.created"
+	DomainEventRoleUpdated           = "role.updated"
+	DomainEventRoleDeleted           = "role.deleted"
+	MessageBrokerKafka               = "kafka"
+	MessageBrokerNats                = "nats"
+	MessageBrokerTopicStrategyType   = "type"
+	MessageBrokerTopicStrategySingle = "single"
+
+	// Inbound Command
+	CommandMemberApprove             = "member.approve"
+	CommandMemberReject              = "member.reject"
+	CommandMemberGrantRoles          = "member.grant-roles"
+	InboundCommandStatusProcessed    = "processed"
+	InboundCommandStatusRejected     = "rejected"
+	InboundCommandStatusDeadLettered = "dead-lettered"
+	DeadLetterReasonInvalidCommand   = "invalid-command"
+	DeadLetterReasonUnknownCommand   = "unknown-command"
+	DeadLetterReasonUnknownPrincipal = "unknown-principal"
+	DeadLetterReasonPermissionDenied = "permission-denied"
+	DeadLetterReasonMaxAttempts      = "max-attempts-exceeded"
+
+	// Anonymization
+	AnonymizationMethodPseudonym = "pseudonym"
+	AnonymizationMethodEmail     = "email"
+	AnonymizationMethodPhone     = "phone"
+	AnonymizationMethodIpAddress = "ip-address"
+	AnonymizationMethodClear     = "clear"
+
+	// Backup
+	BackupReasonScheduled  = "scheduled"
+	BackupReasonPreRestore = "pre-restore"
+
+	// Data Migration
+	DataMigrationStatusPending   = "pending"
+	DataMigrationStatusRunning   = "running"
+	DataMigrationStatusCompleted = "completed"
+	DataMigrationStatusFailed    = "failed"
+	DataMigrationDefaultBatch    = 500
+
+	// Shared State
+	SharedStateBackendMemory       = "memory"
+	SharedStateBackendRedis        = "redis"
+	ClusterTopicSecuritySettings   = "security-settings"
+	ClusterTopicWebSocketBroadcast = "web-socket-broadcast"
+	ClusterLockDataMigration       = "data-migration"
+	ClusterLockDatabaseBackup      = "database-backup"
+	ClusterLeaderScheduler         = "scheduler"
+	LeaderElectionSharedState      = "shared-state"
+	LeaderElectionDatabase         = "database"
+
+	// Response Cache
+	ResponseCacheLoginPage         = "login-page"
+	ResponseCacheMaintenanceStatus = "maintenance-status"
+	ResponseCacheAppVersion        = "app-version"
+	ResponseCacheUsageStatistics   = "usage-statistics"
+	ResponseCacheServiceStatus     = "service-status"
+
+	// Authorization Matrix
+	AuthorizationNone          = "none"
+	AuthorizationAuthenticated = "authenticated"
+	AuthorizationPermission    = "permission"
+	AuthorizationUnknown       = "unknown"
+	AuthorizationUnclassified  = "unclassified"
 )
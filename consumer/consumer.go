@@ -0,0 +1,122 @@
+package consumer
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+// 구독이 끊긴 뒤 다시 구독할 때까지 기다리는 시간
+const resubscribeDelay = 5 * time.Second
+
+// Consumer 는 메시지 브로커의 토픽을 구독한다. 메시지를 처리할 때마다 DB 트랜잭션이 Context 에 설정된다.
+type Consumer struct {
+	Name  string
+	Topic string
+	Group string
+	// Handle 이 에러를 반환하면 트랜잭션을 롤백하고 다시 구독한다.
+	Handle     func(ctx context.Context, message adapters.BrokerMessage) error
+	LoadOffset func(ctx context.Context, topic string, partition int32) (int64, bool, error)
+}
+
+var (
+	mutex     sync.Mutex
+	consumers []Consumer
+	stopped   chan struct{}
+)
+
+// Register 는 컨슈머를 등록한다. 같은 이름의 컨슈머가 있으면 교체한다.
+func Register(consumer Consumer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for i := range consumers {
+		if consumers[i].Name == consumer.Name {
+			consumers[i] = consumer
+			return
+		}
+	}
+	consumers = append(consumers, consumer)
+}
+
+func Start(db *gorm.DB) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if stopped != nil {
+		return
+	}
+	stopped = make(chan struct{})
+
+	for _, consumer := range consumers {
+		go subscribe(db, consumer, stopped)
+	}
+}
+
+func Stop() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if stopped == nil {
+		return
+	}
+	close(stopped)
+	stopped = nil
+}
+
+func subscribe(db *gorm.DB, consumer Consumer, stopped chan struct{}) {
+	subscription := adapters.Subscription{
+		Topic: consumer.Topic,
+		Group: consumer.Group,
+		LoadOffset: func(topic string, partition int32) (int64, bool, error) {
+			return consumer.LoadOffset(helpers.ContextHelper().SetDB(context.Background(), db), topic, partition)
+		},
+		Handle: func(message adapters.BrokerMessage) error {
+			return HandleMessage(db, consumer, message)
+		},
+	}
+
+	for {
+		err := adapters.MessageBrokerAdapter().Subscribe(subscription, stopped)
+
+		select {
+		case <-stopped:
+			return
+		default:
+		}
+
+		log.Errorf("consumer(%v) subscribe error: %v", consumer.Name, err)
+
+		select {
+		case <-stopped:
+			return
+		case <-time.After(resubscribeDelay):
+		}
+	}
+}
+
+// HandleMessage 는 메시지 하나를 트랜잭션 안에서 처리한다. 커밋한 뒤 AfterCommit 으로 등록한 함수를 실행한다.
+func HandleMessage(db *gorm.DB, consumer Consumer, message adapters.BrokerMessage) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("consumer(%v) panic: %v", consumer.Name, r)
+			err = pkgerrors.Errorf("consumer(%v) panic: %v", consumer.Name, r)
+		}
+	}()
+
+	ctx := helpers.ContextHelper().SetAfterCommit(context.Background())
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return consumer.Handle(helpers.ContextHelper().SetDB(ctx, tx), message)
+	})
+	if err != nil {
+		return err
+	}
+
+	helpers.ContextHelper().RunAfterCommit(ctx)
+	return nil
+}
@@ -0,0 +1,251 @@
+package datamigration
+
+import (
+	"better-admin-backend-service/cluster"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/datamigration/domain"
+	"better-admin-backend-service/datamigration/repository"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+// Job 은 스키마 변경(AutoMigrate) 뒤에 한 번만 실행하는 데이터 이전(backfill) 작업이다. 등록한 순서대로 실행한다.
+// 묶음(batch)마다 트랜잭션에서 RunBatch 와 진행 위치(Checkpoint)를 함께 저장하므로 서버가 멈추거나 실패하면 다음에 시작할 때 마지막 진행 위치부터 이어서 실행한다.
+type Job struct {
+	Name        string
+	Description string
+	// BatchSize 가 0 이면 constants.DataMigrationDefaultBatch 건씩 처리한다.
+	BatchSize int
+	// Count 는 처리할 전체 건수를 센다(진행률). nil 이면 전체 건수를 알 수 없다.
+	Count func(ctx context.Context) (int64, error)
+	// RunBatch 는 checkpoint(처음에는 빈 문자열) 다음부터 batchSize 건까지 처리한다. 처리할 것이 남지 않았으면 Done 을 반환한다.
+	RunBatch func(ctx context.Context, checkpoint string, batchSize int) (Batch, error)
+}
+
+// Batch 는 한 묶음을 처리한 결과이다. Checkpoint 는 다음 묶음을 시작할 위치(예. 마지막으로 처리한 ID)이다.
+type Batch struct {
+	Checkpoint string
+	Processed  int
+	Done       bool
+}
+
+// 실행하는 동안 연장하는 잠금의 유지 시간
+const lockTtl = time.Minute
+
+var (
+	mutex     sync.Mutex
+	jobs      []Job
+	stopped   chan struct{}
+	waitGroup sync.WaitGroup
+)
+
+// Register 는 작업을 등록한다. 같은 이름의 작업이 있으면 교체한다.
+func Register(job Job) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for i := range jobs {
+		if jobs[i].Name == job.Name {
+			jobs[i] = job
+			return
+		}
+	}
+	jobs = append(jobs, job)
+}
+
+// Start 는 완료하지 않은 작업을 백그라운드에서 차례로 실행한다.
+func Start(db *gorm.DB) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if stopped != nil {
+		return
+	}
+	stopped = make(chan struct{})
+
+	waitGroup.Add(1)
+	go func(stopped chan struct{}) {
+		defer waitGroup.Done()
+
+		// 여러 인스턴스를 함께 시작하면 한 인스턴스만 실행한다.
+		executed, err := cluster.RunExclusive(constants.ClusterLockDataMigration, lockTtl, func() { RunPending(db, stopped) })
+		if err != nil {
+			log.Error("data migration lock error: ", err)
+		} else if !executed {
+			log.Info(">>> Data migration is running on another instance")
+		}
+	}(stopped)
+}
+
+// Stop 은 실행 중인 묶음을 마칠 때까지 기다린다. 남은 묶음은 다음에 시작할 때 이어서 실행한다.
+func Stop() {
+	mutex.Lock()
+	if stopped == nil {
+		mutex.Unlock()
+		return
+	}
+	close(stopped)
+	stopped = nil
+	mutex.Unlock()
+
+	waitGroup.Wait()
+}
+
+// RunPending 은 완료하지 않은 작업을 등록한 순서대로 실행한다.
+// 작업이 실패하면 뒤의 작업이 앞의 작업 결과에 의존할 수 있으므로 실행하지 않고, 다음에 시작할 때 실패한 작업부터 다시 실행한다.
+func RunPending(db *gorm.DB, stopped <-chan struct{}) {
+	mutex.Lock()
+	registered := append([]Job{}, jobs...)
+	mutex.Unlock()
+
+	for _, job := range registered {
+		completed, err := runJob(db, job, stopped)
+		if err != nil {
+			log.Errorf("data migration(%v) error: %+v", job.Name, err)
+			return
+		}
+		if !completed {
+			return
+		}
+	}
+}
+
+// runJob 은 작업을 완료하거나 멈출 때까지 묶음씩 실행한다.
+func runJob(db *gorm.DB, job Job, stopped <-chan struct{}) (bool, error) {
+	ctx := helpers.ContextHelper().SetDB(context.Background(), db)
+
+	entity, err := repository.DataMigrationRepository{}.FindByName(ctx, job.Name)
+	if err != nil {
+		if !errors.Is(err, errors.ErrNotFound) {
+			return false, err
+		}
+		entity = domain.NewDataMigrationEntity(job.Name)
+	}
+
+	if entity.IsCompleted() {
+		return true, nil
+	}
+
+	if !entity.IsStarted() {
+		var total int64
+		if job.Count != nil {
+			if total, err = job.Count(ctx); err != nil {
+				return false, pkgerrors.Wrap(err, "count error")
+			}
+		}
+		entity.Start(total)
+	}
+	entity.Resume()
+	if err := (repository.DataMigrationRepository{}).Save(ctx, &entity); err != nil {
+		return false, err
+	}
+	log.Infof(">>> Data migration %v (%d/%d)", job.Name, entity.Processed, entity.Total)
+
+	batchSize := job.BatchSize
+	if batchSize <= 0 {
+		batchSize = constants.DataMigrationDefaultBatch
+	}
+
+	for {
+		select {
+		case <-stopped:
+			return false, nil
+		default:
+		}
+
+		next := entity
+		err := db.Transaction(func(tx *gorm.DB) error {
+			txCtx := helpers.ContextHelper().SetDB(context.Background(), tx)
+
+			batch, err := runBatch(txCtx, job, entity.Checkpoint, batchSize)
+			if err != nil {
+				return err
+			}
+
+			next.Advance(batch.Checkpoint, batch.Processed)
+			if batch.Done {
+				next.Complete()
+			}
+			return repository.DataMigrationRepository{}.Save(txCtx, &next)
+		})
+
+		if err != nil {
+			entity.Fail(err)
+			if saveErr := (repository.DataMigrationRepository{}).Save(ctx, &entity); saveErr != nil {
+				log.Errorf("data migration(%v) save error: %v", job.Name, saveErr)
+			}
+			return false, err
+		}
+
+		entity = next
+		if entity.IsCompleted() {
+			log.Infof(">>> Data migration %v completed (%d)", job.Name, entity.Processed)
+			return true, nil
+		}
+	}
+}
+
+func runBatch(ctx context.Context, job Job, checkpoint string, batchSize int) (batch Batch, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = pkgerrors.Errorf("panic: %v", r)
+		}
+	}()
+
+	return job.RunBatch(ctx, checkpoint, batchSize)
+}
+
+// GetStatuses 는 등록한 작업의 진행 상태를 등록한 순서대로 조회한다.
+func GetStatuses(ctx context.Context) ([]dtos.DataMigrationStatus, error) {
+	entities, err := repository.DataMigrationRepository{}.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entitiesByName := map[string]domain.DataMigrationEntity{}
+	for _, entity := range entities {
+		entitiesByName[entity.Name] = entity
+	}
+
+	mutex.Lock()
+	registered := append([]Job{}, jobs...)
+	mutex.Unlock()
+
+	statuses := make([]dtos.DataMigrationStatus, 0, len(registered))
+	for _, job := range registered {
+		entity, ok := entitiesByName[job.Name]
+		if !ok {
+			entity = domain.NewDataMigrationEntity(job.Name)
+		}
+
+		status := dtos.DataMigrationStatus{
+			Name:        job.Name,
+			Description: job.Description,
+			Status:      entity.Status,
+			Processed:   entity.Processed,
+			Total:       entity.Total,
+			Checkpoint:  entity.Checkpoint,
+			LastError:   entity.LastError,
+			StartedAt:   entity.StartedAt,
+			CompletedAt: entity.CompletedAt,
+		}
+		if entity.IsCompleted() {
+			status.ProgressPercent = 100
+		} else if entity.Total > 0 {
+			status.ProgressPercent = int(entity.Processed * 100 / entity.Total)
+			if status.ProgressPercent > 99 {
+				status.ProgressPercent = 99
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
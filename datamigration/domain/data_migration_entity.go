@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"gorm.io/gorm"
+	"time"
+)
+
+// DataMigrationEntity 는 데이터 이전(backfill) 작업의 진행 상태이다. 묶음을 처리할 때마다 같은 트랜잭션에서 Checkpoint 를 저장한다.
+type DataMigrationEntity struct {
+	gorm.Model
+	Name       string `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Status     string `gorm:"type:varchar(20);not null"`
+	Checkpoint string `gorm:"type:varchar(255)"`
+	Processed  int64
+	// Total 은 시작할 때 센 전체 건수이다. 0 이면 알 수 없다.
+	Total       int64
+	LastError   string `gorm:"type:varchar(1000)"`
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+func (DataMigrationEntity) TableName() string {
+	return "data_migrations"
+}
+
+func NewDataMigrationEntity(name string) DataMigrationEntity {
+	return DataMigrationEntity{Name: name, Status: constants.DataMigrationStatusPending}
+}
+
+func (d DataMigrationEntity) IsStarted() bool {
+	return d.StartedAt != nil
+}
+
+func (d DataMigrationEntity) IsCompleted() bool {
+	return d.Status == constants.DataMigrationStatusCompleted
+}
+
+func (d *DataMigrationEntity) Start(total int64) {
+	now := time.Now()
+	d.Total = total
+	d.StartedAt = &now
+}
+
+// Resume 은 처음 시작하거나, 중단(서버 종료) 혹은 실패한 작업을 마지막 Checkpoint 부터 다시 실행한다.
+func (d *DataMigrationEntity) Resume() {
+	d.Status = constants.DataMigrationStatusRunning
+	d.LastError = ""
+}
+
+func (d *DataMigrationEntity) Advance(checkpoint string, processed int) {
+	d.Checkpoint = checkpoint
+	d.Processed += int64(processed)
+}
+
+func (d *DataMigrationEntity) Complete() {
+	now := time.Now()
+	d.Status = constants.DataMigrationStatusCompleted
+	d.CompletedAt = &now
+}
+
+func (d *DataMigrationEntity) Fail(err error) {
+	message := []rune(err.Error())
+	if len(message) > 1000 {
+		message = message[:1000]
+	}
+
+	d.Status = constants.DataMigrationStatusFailed
+	d.LastError = string(message)
+}
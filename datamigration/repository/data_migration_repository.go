@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"better-admin-backend-service/datamigration/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type DataMigrationRepository struct {
+}
+
+func (DataMigrationRepository) FindByName(ctx context.Context, name string) (domain.DataMigrationEntity, error) {
+	var entity domain.DataMigrationEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("name = ?", name).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (DataMigrationRepository) FindAll(ctx context.Context) ([]domain.DataMigrationEntity, error) {
+	var entities []domain.DataMigrationEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Find(&entities).Error; err != nil {
+		return nil, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (DataMigrationRepository) Save(ctx context.Context, entity *domain.DataMigrationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -50,3 +50,129 @@ type RoleDetails struct {
 	CreatedAt          time.Time           `json:"createdAt"`
 	AllowedPermissions []AllowedPermission `json:"permissions"`
 }
+
+// RoleMemberBulkRequest 는 역할에 여러 멤버를 한 번에 할당하거나 제거하는 요청이다. 멤버 ID 목록(MemberIds)이나 세그먼트(SegmentId) 중 하나로 대상을 지정한다.
+// Async 이면 작업을 등록만 하고 스케줄러에서 처리한다.
+type RoleMemberBulkRequest struct {
+	Action    string `json:"action" binding:"required,oneof=assign remove"`
+	MemberIds []uint `json:"memberIds"`
+	SegmentId uint   `json:"segmentId"`
+	Async     bool   `json:"async"`
+}
+
+type RoleMemberBulkResult struct {
+	Action    string                  `json:"action"`
+	Requested int                     `json:"requested"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    []RoleMemberBulkFailure `json:"failed"`
+}
+
+type RoleMemberBulkFailure struct {
+	MemberId uint   `json:"memberId"`
+	Reason   string `json:"reason"`
+}
+
+type RoleMemberBulkJobInformation struct {
+	Id           uint                  `json:"id"`
+	RoleId       uint                  `json:"roleId"`
+	Action       string                `json:"action"`
+	Status       string                `json:"status"`
+	Result       *RoleMemberBulkResult `json:"result,omitempty"`
+	ErrorMessage string                `json:"errorMessage,omitempty"`
+	RequestedBy  uint                  `json:"requestedBy"`
+	CreatedAt    time.Time             `json:"createdAt"`
+	FinishedAt   *time.Time            `json:"finishedAt"`
+}
+
+// RoleComparison 은 두 역할(A, B)의 권한과 멤버를 비교한 결과이다. 중복된 역할을 정리할 때 사용한다.
+type RoleComparison struct {
+	RoleA             RoleComparisonRole  `json:"roleA"`
+	RoleB             RoleComparisonRole  `json:"roleB"`
+	OnlyInA           []AllowedPermission `json:"onlyInA"`
+	OnlyInB           []AllowedPermission `json:"onlyInB"`
+	SharedPermissions []AllowedPermission `json:"sharedPermissions"`
+	Members           RoleMemberOverlap   `json:"members"`
+}
+
+type RoleComparisonRole struct {
+	Id          uint   `json:"id"`
+	Name        string `json:"name"`
+	MemberCount int    `json:"memberCount"`
+}
+
+// RoleMemberOverlap 은 역할이 직접 할당된 멤버 ID 이다.(조직을 통해 할당된 역할은 포함하지 않는다.)
+type RoleMemberOverlap struct {
+	OnlyInA []uint `json:"onlyInA"`
+	OnlyInB []uint `json:"onlyInB"`
+	Shared  []uint `json:"shared"`
+}
+
+type RoleMerge struct {
+	TargetRoleId uint `json:"targetRoleId" binding:"required"`
+}
+
+type RoleMergeResult struct {
+	SourceRoleId    uint `json:"sourceRoleId"`
+	TargetRoleId    uint `json:"targetRoleId"`
+	Moved           int  `json:"moved"`
+	AlreadyAssigned int  `json:"alreadyAssigned"`
+}
+
+// AccessHistoryRole 은 특정 시각에 유효했던 역할 할당이다. EffectiveTo 가 없으면 지금도 할당되어 있다.
+type AccessHistoryRole struct {
+	Id            uint       `json:"id"`
+	Name          string     `json:"name"`
+	EffectiveFrom time.Time  `json:"effectiveFrom"`
+	EffectiveTo   *time.Time `json:"effectiveTo"`
+}
+
+// MemberAccessHistory 는 At 시각에 멤버에게 직접 할당되어 있던 역할과 그 역할로 가진 권한이다.(조직을 통해 할당된 역할은 포함하지 않는다.)
+type MemberAccessHistory struct {
+	MemberId    uint                `json:"memberId"`
+	At          time.Time           `json:"at"`
+	Roles       []AccessHistoryRole `json:"roles"`
+	Permissions []string            `json:"permissions"`
+}
+
+// PermissionAccessHistory 는 At 시각에 권한을 가지고 있던 멤버이다.
+type PermissionAccessHistory struct {
+	Permission string                   `json:"permission"`
+	At         time.Time                `json:"at"`
+	Holders    []PermissionAccessHolder `json:"holders"`
+}
+
+type PermissionAccessHolder struct {
+	MemberId uint                `json:"memberId"`
+	SignId   string              `json:"signId"`
+	Name     string              `json:"name"`
+	Roles    []AccessHistoryRole `json:"roles"`
+}
+
+// PermissionSimulationRequest 는 역할(RoleId)에서 권한(RemovePermissions)을 제거하면 어떻게 되는지 미리 확인하는 요청이다.
+type PermissionSimulationRequest struct {
+	RoleId            uint     `json:"roleId" binding:"required"`
+	RemovePermissions []string `json:"removePermissions" binding:"required,min=1,dive,required"`
+}
+
+// PermissionSimulationResult 는 권한을 제거하면 접근을 잃는 멤버와 API 이다. 역할을 바꾸지는 않는다.
+// MemberCount 는 역할이 직접 또는 조직을 통해 할당된 멤버 수이고, 다른 역할로 같은 권한을 가진 멤버는 AffectedMembers 에 포함하지 않는다.
+type PermissionSimulationResult struct {
+	RoleId            uint                         `json:"roleId"`
+	RoleName          string                       `json:"roleName"`
+	RemovePermissions []string                     `json:"removePermissions"`
+	MemberCount       int                          `json:"memberCount"`
+	AffectedMembers   []PermissionSimulationMember `json:"affectedMembers"`
+	LostEndpoints     []AuthorizationRule          `json:"lostEndpoints"`
+}
+
+// PermissionSimulationMember 는 멤버가 잃는 권한과 호출할 수 없게 되는 API, 관리할 수 없게 되는 소유 리소스이다.
+type PermissionSimulationMember struct {
+	MemberId          uint                `json:"memberId"`
+	SignId            string              `json:"signId"`
+	Name              string              `json:"name"`
+	LostPermissions   []string            `json:"lostPermissions"`
+	LostEndpoints     []AuthorizationRule `json:"lostEndpoints"`
+	LostResources     []OwnedResource     `json:"lostResources"`
+	PermissionsBefore []string            `json:"-"`
+	PermissionsAfter  []string            `json:"-"`
+}
@@ -0,0 +1,16 @@
+package dtos
+
+import "time"
+
+type ActivityFeedItem struct {
+	Id         uint      `json:"id"`
+	EventType  string    `json:"eventType"`
+	Action     string    `json:"action"`
+	ActorType  string    `json:"actorType"`
+	ActorId    uint      `json:"actorId"`
+	EntityType string    `json:"entityType"`
+	EntityId   uint      `json:"entityId"`
+	Summary    string    `json:"summary"`
+	Detail     string    `json:"detail"`
+	OccurredAt time.Time `json:"occurredAt"`
+}
@@ -0,0 +1,8 @@
+package dtos
+
+// AnonymizedTable 은 익명화한 테이블의 컬럼과 값을 바꾼 행의 수이다.
+type AnonymizedTable struct {
+	Table   string   `json:"table"`
+	Columns []string `json:"columns"`
+	Rows    int64    `json:"rows"`
+}
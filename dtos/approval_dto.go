@@ -0,0 +1,79 @@
+package dtos
+
+import "time"
+
+// ApprovalWorkflowSetting 은 승인 대상(Subject)별 승인 단계를 정의한다.
+// 정의되지 않은 대상은 기존과 같이 승인 절차 없이 처리된다.
+type ApprovalWorkflowSetting struct {
+	Workflows []ApprovalWorkflow `json:"workflows" binding:"dive"`
+}
+
+func (s ApprovalWorkflowSetting) FindWorkflow(subject string) (ApprovalWorkflow, bool) {
+	for _, workflow := range s.Workflows {
+		if workflow.Subject == subject {
+			return workflow, true
+		}
+	}
+
+	return ApprovalWorkflow{}, false
+}
+
+type ApprovalWorkflow struct {
+	Subject string         `json:"subject" binding:"required,oneof=member-signup role-grant api-key-creation member-field-change role-elevation"`
+	Steps   []ApprovalStep `json:"steps" binding:"required,min=1,dive"`
+}
+
+type ApprovalStep struct {
+	Name             string `json:"name" binding:"required"`
+	ApproverRoleName string `json:"approverRoleName" binding:"required"`
+	// RequiredApprovals 는 다음 단계로 넘어가기 위해 필요한 승인 수이다.(0 이면 1)
+	RequiredApprovals int `json:"requiredApprovals" binding:"min=0"`
+	// ReminderHours 가 지나도록 처리되지 않으면 승인자에게 다시 알린다.
+	ReminderHours int `json:"reminderHours" binding:"min=0"`
+	// EscalationHours 가 지나도록 처리되지 않으면 EscalationRoleName 역할도 승인할 수 있다.
+	EscalationHours    int    `json:"escalationHours" binding:"min=0"`
+	EscalationRoleName string `json:"escalationRoleName"`
+}
+
+func (s ApprovalStep) GetRequiredApprovals() int {
+	if s.RequiredApprovals < 1 {
+		return 1
+	}
+
+	return s.RequiredApprovals
+}
+
+type ApprovalRequestInformation struct {
+	Id          uint               `json:"id"`
+	Subject     string             `json:"subject"`
+	TargetId    uint               `json:"targetId"`
+	Status      string             `json:"status"`
+	CurrentStep int                `json:"currentStep"`
+	Steps       []ApprovalStep     `json:"steps"`
+	RequestedBy uint               `json:"requestedBy"`
+	Escalated   bool               `json:"escalated"`
+	Decisions   []ApprovalDecision `json:"decisions"`
+	CreatedAt   time.Time          `json:"createdAt"`
+}
+
+type ApprovalDecision struct {
+	Step       int  `json:"step"`
+	ApproverId uint `json:"approverId"`
+	// OnBehalfOf 는 위임 받아 대신 승인한 경우 위임한 멤버이다.
+	OnBehalfOf uint      `json:"onBehalfOf,omitempty"`
+	Decision   string    `json:"decision"`
+	Comment    string    `json:"comment"`
+	DecidedAt  time.Time `json:"decidedAt"`
+}
+
+type ApprovalComment struct {
+	Comment string `json:"comment"`
+}
+
+type ApprovalDelegationInformation struct {
+	Id         uint      `json:"id"`
+	DelegateId uint      `json:"delegateId" binding:"required"`
+	StartsAt   time.Time `json:"startsAt" binding:"required"`
+	EndsAt     time.Time `json:"endsAt" binding:"required"`
+	Reason     string    `json:"reason" binding:"max=200"`
+}
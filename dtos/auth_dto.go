@@ -1,5 +1,7 @@
 package dtos
 
+import "time"
+
 type MemberSignIn struct {
 	Id       string `json:"id" binding:"required"`
 	Password string `json:"password" binding:"required"`
@@ -10,6 +12,7 @@ type DoorayMember struct {
 	UserCode             string `json:"userCode"`
 	Name                 string `json:"name"`
 	ExternalEmailAddress string `json:"externalEmailAddress"`
+	Department           string `json:"department"`
 }
 
 type GoogleMember struct {
@@ -18,4 +21,140 @@ type GoogleMember struct {
 	Name    string `json:"name"`
 	Picture string `json:"picture"`
 	Hd      string `json:"hd"`
+	// OrgUnitPath, Phone 은 디렉터리 API 로 가져온 조직 단위 경로(예. /Engineering/Backend)와 전화번호이다.
+	OrgUnitPath string `json:"orgUnitPath"`
+	Phone       string `json:"phone"`
+	// Groups 는 디렉터리 API 로 가져온 그룹 메일 주소이다. 가져오지 않았거나 가져오지 못했으면 nil 이다.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// CustomAuthIdentity 는 Authenticator 가 인증한 외부 사용자이다. ExternalId 는 Authenticator 안에서 고유해야 한다.
+type CustomAuthIdentity struct {
+	ExternalId string
+	Name       string
+	Email      string
+	// Groups 는 외부 사용자가 속한 그룹(예. SAML/LDAP 그룹)이다.
+	Groups []string
+}
+
+// CustomAuthRequest 는 사이드카 Authenticator 에 보내는 인증 요청이다.
+type CustomAuthRequest struct {
+	Authenticator string
+	Credentials   map[string]string
+	ClientIp      string
+}
+
+// PreAuthRequest 는 로그인 직전에 외부 시스템에 보내는 멤버 정보이다.
+type PreAuthRequest struct {
+	MemberId uint     `json:"memberId"`
+	Type     string   `json:"type"`
+	SignId   string   `json:"signId,omitempty"`
+	DoorayId string   `json:"doorayId,omitempty"`
+	GoogleId string   `json:"googleId,omitempty"`
+	Name     string   `json:"name"`
+	Email    string   `json:"email,omitempty"`
+	Roles    []string `json:"roles"`
+	ClientIp string   `json:"clientIp,omitempty"`
+}
+
+// PreAuthResult 는 외부 시스템의 로그인 허용 여부이다. Claims 는 Access/Refresh 토큰에 추가할 클레임이다.
+type PreAuthResult struct {
+	Allow  bool                   `json:"allow"`
+	Reason string                 `json:"reason"`
+	Claims map[string]interface{} `json:"claims"`
+}
+
+type ScopedTokenRequest struct {
+	Resource  string `json:"resource" binding:"required,startswith=/"`
+	Action    string `json:"action" binding:"required,oneof=GET HEAD"`
+	ExpiresIn int    `json:"expiresIn" binding:"min=0"`
+}
+
+type ScopedToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// StepUpRequest 는 단계 인증을 위해 로그인한 멤버의 비밀번호를 다시 확인하는 요청이다.
+type StepUpRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+type StepUpToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ClientCredentialsTokenRequest 는 RFC 6749 4.4(4.1.3) 의 Access Token 요청이다.
+// Client 인증 정보는 HTTP Basic 인증 헤더로도 전달할 수 있다.
+type ClientCredentialsTokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required"`
+	ClientId     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+	Scope        string `form:"scope"`
+	// 토큰 교환(RFC 8693 2.1) 그랜트에서 교환할 멤버의 Access 토큰과 새 토큰을 사용할 서비스(audience)이다.
+	SubjectToken       string `form:"subject_token"`
+	SubjectTokenType   string `form:"subject_token_type"`
+	RequestedTokenType string `form:"requested_token_type"`
+	Audience           string `form:"audience"`
+	// 인가 코드(RFC 6749 4.1.3) 그랜트에서 교환할 코드와 PKCE(RFC 7636 4.5) 검증 값이다.
+	Code         string `form:"code"`
+	RedirectUri  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	// 기기 인가(RFC 8628 3.4) 그랜트에서 확인할 기기 코드이다.
+	DeviceCode string `form:"device_code"`
+}
+
+// DeviceAuthorizationRequest 는 기기 인가 요청(RFC 8628 3.1)이다.
+type DeviceAuthorizationRequest struct {
+	ClientId string `form:"client_id" binding:"required"`
+	Scope    string `form:"scope"`
+}
+
+// DeviceAuthorization 은 기기 인가 응답(RFC 8628 3.2)이다.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// OAuthTokenRequest 는 토큰 조회(RFC 7662 2.1)와 폐기(RFC 7009 2.1) 요청이다.
+// Client 인증 정보는 HTTP Basic 인증 헤더로도 전달할 수 있다.
+type OAuthTokenRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientId      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// TokenIntrospection 은 토큰 조회 응답(RFC 7662 2.2)이다. 사용할 수 없는 토큰은 active 만 false 로 응답한다.
+type TokenIntrospection struct {
+	Active        bool     `json:"active"`
+	Scope         string   `json:"scope,omitempty"`
+	ClientId      string   `json:"client_id,omitempty"`
+	TokenType     string   `json:"token_type,omitempty"`
+	Exp           int64    `json:"exp,omitempty"`
+	Sub           string   `json:"sub,omitempty"`
+	Aud           []string `json:"aud,omitempty"`
+	Iss           string   `json:"iss,omitempty"`
+	PrincipalType string   `json:"principal_type,omitempty"`
+	Roles         []string `json:"roles,omitempty"`
+	Permissions   []string `json:"permissions,omitempty"`
+}
+
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	// IssuedTokenType 은 토큰 교환 응답(RFC 8693 2.2.1)에만 포함된다.
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
 }
@@ -0,0 +1,38 @@
+package dtos
+
+// AuthorizationRule 은 API 하나에 필요한 인증/권한이다. Permissions 중 하나만 있으면 호출할 수 있다.
+type AuthorizationRule struct {
+	Method         string   `json:"method"`
+	Path           string   `json:"path"`
+	Authentication string   `json:"authentication"`
+	Permissions    []string `json:"permissions"`
+	Allowed        bool     `json:"allowed"`
+}
+
+// PermissionCheckRequest 는 화면에서 버튼마다 확인할 권한을 한 번에 확인하는 요청이다.
+type PermissionCheckRequest struct {
+	Checks []PermissionCheck `json:"checks" binding:"required,min=1,max=200,dive"`
+}
+
+// PermissionCheck 의 Permission 은 토큰에 있어야 하는 권한, Resource 는 호출할 API(예. DELETE /api/members/3)이다.
+// 둘 다 지정하면 둘 다 만족해야 허용한다.
+type PermissionCheck struct {
+	Permission string `json:"permission" binding:"required_without=Resource"`
+	Resource   string `json:"resource" binding:"required_without=Permission"`
+}
+
+type PermissionCheckResult struct {
+	Permission string `json:"permission,omitempty"`
+	Resource   string `json:"resource,omitempty"`
+	Allowed    bool   `json:"allowed"`
+}
+
+// RouteDescription 은 등록된 API 와 API 에 필요한 인증/권한이다. Public 은 로그인 없이 호출할 수 있다고 명시(middlewares.Public)한 API 이다.
+type RouteDescription struct {
+	Method         string   `json:"method"`
+	Path           string   `json:"path"`
+	Handler        string   `json:"handler"`
+	Public         bool     `json:"public"`
+	Authentication string   `json:"authentication"`
+	Permissions    []string `json:"permissions"`
+}
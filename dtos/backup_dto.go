@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+// Backup 은 파일 저장소에 저장한 DB 백업이다. Reason 은 scheduled(주기 백업) 또는 pre-restore(복원 직전 백업)이다.
+type Backup struct {
+	Key       string    `json:"key"`
+	Driver    string    `json:"driver"`
+	Reason    string    `json:"reason"`
+	SizeBytes int64     `json:"sizeBytes"`
+	Checksum  string    `json:"checksum"`
+	CreatedAt time.Time `json:"createdAt"`
+}
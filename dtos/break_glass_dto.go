@@ -0,0 +1,37 @@
+package dtos
+
+import "time"
+
+type BreakGlassAccountCreation struct {
+	SignId      string `json:"signId" binding:"required,max=50"`
+	Password    string `json:"password" binding:"required,min=12"`
+	MemberId    uint   `json:"memberId" binding:"required"`
+	Description string `json:"description" binding:"max=1000"`
+}
+
+type BreakGlassAccountInformation struct {
+	Id          uint       `json:"id"`
+	SignId      string     `json:"signId"`
+	MemberId    uint       `json:"memberId"`
+	Description string     `json:"description"`
+	LastUsedAt  *time.Time `json:"lastUsedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// BreakGlassSignIn 은 비상 접근 계정의 로그인 요청이다. 사용 사유는 반드시 입력해야 한다.
+type BreakGlassSignIn struct {
+	Id       string `json:"id" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Reason   string `json:"reason" binding:"required,min=10,max=1000"`
+}
+
+type BreakGlassUsageInformation struct {
+	Id        uint      `json:"id"`
+	AccountId uint      `json:"accountId"`
+	SignId    string    `json:"signId"`
+	MemberId  uint      `json:"memberId"`
+	Reason    string    `json:"reason"`
+	ClientIp  string    `json:"clientIp"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
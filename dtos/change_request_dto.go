@@ -0,0 +1,53 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ChangeRequestInformation 은 다른 관리자의 검토를 거쳐 한 번에 적용하는 설정/역할 변경 요청이다.
+type ChangeRequestInformation struct {
+	Id          uint                `json:"id"`
+	Title       string              `json:"title" binding:"required,max=200"`
+	Description string              `json:"description" binding:"max=2000"`
+	Changes     []ChangeRequestItem `json:"changes" binding:"required,min=1,max=20,dive"`
+	// ScheduledAt 은 승인된 요청을 자동으로 적용할 시간(예. 점검 시간)이다. 비어 있으면 승인 후 직접 적용한다.
+	ScheduledAt   *time.Time       `json:"scheduledAt"`
+	Status        string           `json:"status"`
+	CreatedBy     uint             `json:"createdBy"`
+	ReviewedBy    uint             `json:"reviewedBy"`
+	ReviewedAt    *time.Time       `json:"reviewedAt"`
+	ReviewComment string           `json:"reviewComment"`
+	AppliedBy     uint             `json:"appliedBy"`
+	AppliedAt     *time.Time       `json:"appliedAt"`
+	FailureReason string           `json:"failureReason,omitempty"`
+	HealthCheck   *SelfCheckReport `json:"healthCheck,omitempty"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+}
+
+// ChangeRequestItem 은 변경 항목 하나이다.
+// Type 이 setting 이면 Key 의 사이트 설정을 Value 로 바꾸고, role 이면 RoleId 의 역할을 Value(RoleInformation)로 바꾼다.
+type ChangeRequestItem struct {
+	Type   string          `json:"type" binding:"required,oneof=setting role"`
+	Key    string          `json:"key"`
+	RoleId uint            `json:"roleId"`
+	Value  json.RawMessage `json:"value" binding:"required"`
+}
+
+type ChangeRequestReview struct {
+	Comment string `json:"comment" binding:"max=1000"`
+}
+
+// ChangeRequestItemDiff 는 변경 항목의 현재 값과 바꿀 값이다. 현재 값이 없으면 Current 는 null 이다.
+type ChangeRequestItemDiff struct {
+	Type     string      `json:"type"`
+	Key      string      `json:"key,omitempty"`
+	RoleId   uint        `json:"roleId,omitempty"`
+	Current  interface{} `json:"current"`
+	Proposed interface{} `json:"proposed"`
+	// ChangedFields 는 값이 바뀌는 필드 이름이다.
+	ChangedFields      []string `json:"changedFields"`
+	AddedPermissions   []string `json:"addedPermissions,omitempty"`
+	RemovedPermissions []string `json:"removedPermissions,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+// ChaosSetting 은 장애 주입 규칙이다. DurationMinutes 가 지나면 모든 규칙이 자동으로 꺼진다. 규칙이 비어 있으면 바로 끈다.
+type ChaosSetting struct {
+	Rules           []ChaosRule `json:"rules" binding:"max=20,dive"`
+	DurationMinutes int         `json:"durationMinutes" binding:"min=0,max=240"`
+}
+
+// ChaosRule 은 PathPrefix 로 시작하는 요청(Methods 가 비어 있으면 모든 메서드) 중 Percent 만큼에 장애를 주입한다.
+// LatencyMs 만큼 늦게 처리하고, Faults 의 DB(database) 나 외부 IdP(idp) 호출을 실패시키며, ErrorStatus 가 있으면 handler 를 실행하지 않고 오류로 응답한다.
+type ChaosRule struct {
+	PathPrefix  string   `json:"pathPrefix" binding:"required,startswith=/api/"`
+	Methods     []string `json:"methods" binding:"dive,oneof=GET POST PUT PATCH DELETE"`
+	Percent     int      `json:"percent" binding:"required,min=1,max=100"`
+	LatencyMs   int      `json:"latencyMs" binding:"min=0,max=30000"`
+	ErrorStatus int      `json:"errorStatus" binding:"omitempty,oneof=429 500 502 503 504"`
+	Faults      []string `json:"faults" binding:"dive,oneof=database idp"`
+}
+
+type ChaosStatus struct {
+	Enabled bool        `json:"enabled"`
+	Rules   []ChaosRule `json:"rules"`
+	Until   *time.Time  `json:"until,omitempty"`
+	// Injected 는 규칙을 켠 뒤 장애를 주입한 요청 수이다.
+	Injected int64 `json:"injected"`
+}
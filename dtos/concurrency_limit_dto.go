@@ -0,0 +1,15 @@
+package dtos
+
+// ConcurrencyLimitMetric 은 경로 그룹별 동시 처리 현황이다.
+// Rejected 는 대기열이 차서, TimedOut 은 기다리는 시간이 지나서 429 로 거절한 요청 수이다.
+type ConcurrencyLimitMetric struct {
+	Name                         string `json:"name"`
+	MaxConcurrent                int    `json:"maxConcurrent"`
+	MaxQueue                     int    `json:"maxQueue"`
+	InFlight                     int64  `json:"inFlight"`
+	Queued                       int64  `json:"queued"`
+	Admitted                     int64  `json:"admitted"`
+	Rejected                     int64  `json:"rejected"`
+	TimedOut                     int64  `json:"timedOut"`
+	AverageQueueWaitMilliseconds int64  `json:"averageQueueWaitMilliseconds"`
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+// DataMigrationStatus 는 등록한 데이터 이전 작업의 진행 상태이다. 전체 건수를 알 수 없으면 ProgressPercent 는 완료했을 때만 100 이다.
+type DataMigrationStatus struct {
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	Status          string     `json:"status"`
+	Processed       int64      `json:"processed"`
+	Total           int64      `json:"total"`
+	ProgressPercent int        `json:"progressPercent"`
+	Checkpoint      string     `json:"checkpoint"`
+	LastError       string     `json:"lastError,omitempty"`
+	StartedAt       *time.Time `json:"startedAt,omitempty"`
+	CompletedAt     *time.Time `json:"completedAt,omitempty"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+// DatabasePoolMetric 은 DB 커넥션 풀 사용 현황이다. WaitCount, WaitDurationMilliseconds 는 서버를 시작한 뒤 연결을 기다린 횟수와 시간의 합이다.
+type DatabasePoolMetric struct {
+	MaxOpenConnections       int                `json:"maxOpenConnections"`
+	OpenConnections          int                `json:"openConnections"`
+	InUse                    int                `json:"inUse"`
+	Idle                     int                `json:"idle"`
+	UtilizationPercent       int                `json:"utilizationPercent"`
+	WaitCount                int64              `json:"waitCount"`
+	WaitDurationMilliseconds int64              `json:"waitDurationMilliseconds"`
+	MaxIdleClosed            int64              `json:"maxIdleClosed"`
+	MaxIdleTimeClosed        int64              `json:"maxIdleTimeClosed"`
+	MaxLifetimeClosed        int64              `json:"maxLifetimeClosed"`
+	PrepareStmt              bool               `json:"prepareStmt"`
+	LastCheck                *DatabasePoolCheck `json:"lastCheck,omitempty"`
+}
+
+// DatabasePoolCheck 는 마지막 확인 주기 동안의 대기 현황이다. Warning 이면 대기가 기준(config.Database)을 넘었다.
+type DatabasePoolCheck struct {
+	CheckedAt                       time.Time `json:"checkedAt"`
+	WaitCount                       int64     `json:"waitCount"`
+	AverageWaitDurationMilliseconds int64     `json:"averageWaitDurationMilliseconds"`
+	Warning                         bool      `json:"warning"`
+	ConsecutiveWarnings             int       `json:"consecutiveWarnings"`
+}
@@ -0,0 +1,42 @@
+package dtos
+
+import "time"
+
+// RuntimeMetric 은 서버 프로세스의 런타임 현황이다.
+type RuntimeMetric struct {
+	GoVersion  string             `json:"goVersion"`
+	NumCPU     int                `json:"numCpu"`
+	GOMAXPROCS int                `json:"gomaxprocs"`
+	Goroutines int                `json:"goroutines"`
+	Heap       HeapMetric         `json:"heap"`
+	GC         GCMetric           `json:"gc"`
+	Database   DatabasePoolMetric `json:"database"`
+}
+
+// HeapMetric 은 heap 사용 현황이다. TotalAllocBytes, Mallocs, Frees 는 서버를 시작한 뒤의 합이다.
+type HeapMetric struct {
+	AllocBytes      uint64 `json:"allocBytes"`
+	SysBytes        uint64 `json:"sysBytes"`
+	InuseBytes      uint64 `json:"inuseBytes"`
+	IdleBytes       uint64 `json:"idleBytes"`
+	ReleasedBytes   uint64 `json:"releasedBytes"`
+	Objects         uint64 `json:"objects"`
+	TotalAllocBytes uint64 `json:"totalAllocBytes"`
+	Mallocs         uint64 `json:"mallocs"`
+	Frees           uint64 `json:"frees"`
+}
+
+// GCMetric 은 GC 현황이다. RecentPauseMicroseconds 는 최근 GC 의 멈춘 시간을 최근 순서로 최대 10 개까지 담는다.
+type GCMetric struct {
+	NumGC                   uint32     `json:"numGc"`
+	LastGCAt                *time.Time `json:"lastGcAt"`
+	NextGCBytes             uint64     `json:"nextGcBytes"`
+	PauseTotalMilliseconds  float64    `json:"pauseTotalMilliseconds"`
+	RecentPauseMicroseconds []uint64   `json:"recentPauseMicroseconds"`
+	CPUFraction             float64    `json:"cpuFraction"`
+}
+
+// DiagnosticsDumpRequest 는 goroutine 이나 heap 덤프를 파일 저장소에 저장하는 요청이다.
+type DiagnosticsDumpRequest struct {
+	Type string `json:"type" binding:"required,oneof=goroutine heap"`
+}
@@ -0,0 +1,77 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DomainEventEnvelope 는 메시지 브로커로 발행하는 도메인 이벤트의 JSON 이다.
+// data 의 구조가 호환되지 않게 바뀌면 schemaVersion 을 올린다. 같은 이벤트가 두 번 발행될 수 있으므로 소비자는 id 로 중복을 거른다.
+type DomainEventEnvelope struct {
+	Id            string           `json:"id"`
+	Type          string           `json:"type"`
+	SchemaVersion int              `json:"schemaVersion"`
+	Source        string           `json:"source"`
+	OccurredAt    time.Time        `json:"occurredAt"`
+	Actor         DomainEventActor `json:"actor"`
+	Data          interface{}      `json:"data"`
+}
+
+type DomainEventActor struct {
+	Type string `json:"type"`
+	Id   uint   `json:"id,omitempty"`
+}
+
+// MemberEventData 는 member.* 이벤트의 data 이다.
+type MemberEventData struct {
+	MemberId uint     `json:"memberId"`
+	Type     string   `json:"type"`
+	SignId   string   `json:"signId,omitempty"`
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Roles    []string `json:"roles"`
+}
+
+// RoleEventData 는 role.* 이벤트의 data 이다.
+type RoleEventData struct {
+	RoleId      uint     `json:"roleId"`
+	Type        string   `json:"type"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// DomainEventInformation 은 아웃박스에 기록한 도메인 이벤트와 발행 상태이다. Status 는 pending, published, failed 중 하나이다.
+type DomainEventInformation struct {
+	Id            uint            `json:"id"`
+	EventId       string          `json:"eventId"`
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schemaVersion"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateId   uint            `json:"aggregateId"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	PublishedAt   *time.Time      `json:"publishedAt"`
+	LastError     string          `json:"lastError,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+// MemberHistoryEvent 는 이벤트 저장소에 기록한 멤버의 변경 이벤트이다. Data 는 변경 후 멤버의 상태이다.
+type MemberHistoryEvent struct {
+	Sequence   uint            `json:"sequence"`
+	Type       string          `json:"type"`
+	ActorId    uint            `json:"actorId"`
+	OccurredAt time.Time       `json:"occurredAt"`
+	Data       MemberEventData `json:"data"`
+}
+
+// MemberHistoryState 는 이벤트를 순서대로 적용하여 만든 특정 시각(AsOf)의 멤버 상태이다.
+// Version 은 마지막으로 적용한 이벤트의 Sequence 이며 Deleted 는 거절(member.rejected)되어 삭제된 상태를 뜻한다.
+type MemberHistoryState struct {
+	MemberEventData
+	AsOf    time.Time `json:"asOf"`
+	Version uint      `json:"version"`
+	Deleted bool      `json:"deleted"`
+}
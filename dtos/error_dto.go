@@ -1,5 +1,12 @@
 package dtos
 
 type ErrorMessage struct {
+	// Code 는 오류 코드(GET /api/system/error-codes)이다.
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+type ErrorCode struct {
+	Code    string `json:"code"`
 	Message string `json:"message"`
 }
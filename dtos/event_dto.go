@@ -0,0 +1,40 @@
+package dtos
+
+import "time"
+
+// Event 는 이벤트 버스로 전달하는 인증(login), 감사(audit) 이벤트이다. Id 는 같은 이벤트를 다시 보냈을 때 중복을 거르는 데 사용한다.
+type Event struct {
+	Id         string    `json:"id"`
+	Type       string    `json:"type"`
+	Action     string    `json:"action"`
+	OccurredAt time.Time `json:"occurredAt"`
+	ActorType  string    `json:"actorType,omitempty"`
+	ActorId    uint      `json:"actorId,omitempty"`
+	TargetType string    `json:"targetType,omitempty"`
+	TargetId   uint      `json:"targetId,omitempty"`
+	Succeeded  bool      `json:"succeeded"`
+	Reason     string    `json:"reason,omitempty"`
+	Detail     string    `json:"detail,omitempty"`
+	IpAddress  string    `json:"ipAddress,omitempty"`
+	Country    string    `json:"country,omitempty"`
+	City       string    `json:"city,omitempty"`
+	// ElevationId 는 임시 권한 상승 중에 한 변경이다.
+	ElevationId uint `json:"elevationId,omitempty"`
+}
+
+type LogShippingStatus struct {
+	Sinks []LogSinkStatus `json:"sinks"`
+}
+
+// LogSinkStatus 는 싱크별 전송 현황이다. 버퍼가 가득 차면 새 이벤트는 버리고 Dropped 로 센다.
+type LogSinkStatus struct {
+	Name          string     `json:"name"`
+	Queued        int        `json:"queued"`
+	Capacity      int        `json:"capacity"`
+	Shipped       int64      `json:"shipped"`
+	Failed        int64      `json:"failed"`
+	Dropped       int64      `json:"dropped"`
+	Retries       int64      `json:"retries"`
+	LastError     string     `json:"lastError,omitempty"`
+	LastShippedAt *time.Time `json:"lastShippedAt,omitempty"`
+}
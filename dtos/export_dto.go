@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+// ExportJobRequest 는 내보내기 작업 요청이다. member-data 는 멤버 Id, report 는 리포트 Id 를 TargetId 로 지정한다.
+type ExportJobRequest struct {
+	Type     string `json:"type" binding:"required,oneof=member-data report"`
+	TargetId uint   `json:"targetId" binding:"required"`
+}
+
+// ExportJobInformation 은 내보내기 작업의 진행 상태이다. 완료하면 DownloadUrl 로 ExpiresAt 전까지 결과를 내려받을 수 있다.
+type ExportJobInformation struct {
+	Id           uint       `json:"id"`
+	Type         string     `json:"type"`
+	TargetId     uint       `json:"targetId"`
+	Status       string     `json:"status"`
+	Progress     int        `json:"progress"`
+	ErrorMessage string     `json:"errorMessage,omitempty"`
+	FileId       uint       `json:"fileId,omitempty"`
+	FileName     string     `json:"fileName,omitempty"`
+	Size         int64      `json:"size,omitempty"`
+	DownloadUrl  string     `json:"downloadUrl,omitempty"`
+	RequestedBy  uint       `json:"requestedBy"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	StartedAt    *time.Time `json:"startedAt"`
+	FinishedAt   *time.Time `json:"finishedAt"`
+	ExpiresAt    *time.Time `json:"expiresAt"`
+}
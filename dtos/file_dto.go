@@ -0,0 +1,57 @@
+package dtos
+
+import "time"
+
+type FileInformation struct {
+	Id          uint   `json:"id"`
+	Purpose     string `json:"purpose"`
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	// ScanStatus 는 not-scanned, clean, infected 중 하나이다.
+	ScanStatus    string     `json:"scanStatus"`
+	ScanSignature string     `json:"scanSignature,omitempty"`
+	ScannedAt     *time.Time `json:"scannedAt"`
+	CreatedBy     uint       `json:"createdBy"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// FileStorageUsage 는 멤버가 올린 파일의 크기 합과 저장 한도이다. QuotaBytes 가 0 이면 제한하지 않는다.
+type FileStorageUsage struct {
+	UsedBytes  int64 `json:"usedBytes"`
+	QuotaBytes int64 `json:"quotaBytes"`
+}
+
+// FileScanResult 는 업로드 파일의 바이러스 검사 결과이다.
+type FileScanResult struct {
+	Infected  bool
+	Signature string
+}
+
+type FileDownloadUrlRequest struct {
+	// ExpiresIn 이 0 이면 설정(ScopedToken.LifetimeSeconds)을 따른다.
+	ExpiresIn int `json:"expiresIn" binding:"min=0"`
+}
+
+type FileDownloadUrl struct {
+	Url       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// AttachmentInformation 은 결재 요청, 메모 등에 첨부한 파일이다. 내용은 첨부한 대상의 첨부 파일 API 로 내려받는다.
+type AttachmentInformation struct {
+	Id          uint       `json:"id"`
+	FileId      uint       `json:"fileId"`
+	Name        string     `json:"name"`
+	ContentType string     `json:"contentType"`
+	Size        int64      `json:"size"`
+	CreatedBy   uint       `json:"createdBy"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
+
+// AttachmentRequest 는 업로드(POST /files, purpose: attachment)한 파일을 첨부하는 요청이다.
+type AttachmentRequest struct {
+	FileIds []uint `json:"fileIds" binding:"required,min=1,max=10"`
+}
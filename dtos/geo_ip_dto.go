@@ -0,0 +1,9 @@
+package dtos
+
+// GeoLocation 은 IP 로 찾은 위치 정보이다. 찾지 못한 값은 비어 있다.
+type GeoLocation struct {
+	Country         string `json:"country"`
+	City            string `json:"city"`
+	Asn             uint   `json:"asn"`
+	AsnOrganization string `json:"asnOrganization"`
+}
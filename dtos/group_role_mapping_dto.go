@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+// GroupRoleMappingInformation 은 SSO 그룹에 속한 멤버에게 역할을 할당하는 매핑이다.
+// Provider 는 구글 워크스페이스(google) 또는 커스텀 Authenticator(custom:이름)이다.
+type GroupRoleMappingInformation struct {
+	Id            uint      `json:"id"`
+	Provider      string    `json:"provider" binding:"required,max=100"`
+	GroupName     string    `json:"groupName" binding:"required,max=255"`
+	RoleId        uint      `json:"roleId" binding:"required"`
+	Authoritative bool      `json:"authoritative"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// GroupRoleSyncResult 는 로그인할 때 그룹-역할 매핑으로 추가하고 회수한 역할이다.
+type GroupRoleSyncResult struct {
+	AddedRoleIds   []uint `json:"addedRoleIds"`
+	RemovedRoleIds []uint `json:"removedRoleIds"`
+}
@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+// HttpClientMetric 은 외부 연동별 HTTP 호출 현황이다. Requests 는 재시도를 제외한 요청 수이고 Rejected 는 회로가 열려 보내지 않은 요청 수이다.
+type HttpClientMetric struct {
+	Name                       string                 `json:"name"`
+	Requests                   int64                  `json:"requests"`
+	Retries                    int64                  `json:"retries"`
+	Failures                   int64                  `json:"failures"`
+	Rejected                   int64                  `json:"rejected"`
+	AverageLatencyMilliseconds int64                  `json:"averageLatencyMilliseconds"`
+	CircuitBreakers            []CircuitBreakerStatus `json:"circuitBreakers"`
+}
+
+type CircuitBreakerStatus struct {
+	Host                string     `json:"host"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	OpenUntil           *time.Time `json:"openUntil,omitempty"`
+}
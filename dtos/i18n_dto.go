@@ -0,0 +1,35 @@
+package dtos
+
+import "time"
+
+// LocaleMessageChanges 는 바꿀 문구(키별 값)이다. 값이 null 인 키는 지워서 프론트엔드의 내장 문구를 사용한다.
+type LocaleMessageChanges map[string]*string
+
+// LocaleBundle 은 로케일의 전체 문구이다. 내보내기(export)한 파일을 그대로 가져올(import) 수 있다.
+type LocaleBundle struct {
+	Locale   string            `json:"locale"`
+	Version  uint              `json:"version"`
+	Messages map[string]string `json:"messages" binding:"required"`
+}
+
+type LocaleBundleSummary struct {
+	Locale       string    `json:"locale"`
+	Version      uint      `json:"version"`
+	MessageCount int       `json:"messageCount"`
+	UpdatedBy    uint      `json:"updatedBy"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+type LocaleBundleVersion struct {
+	Locale         string    `json:"locale"`
+	Version        uint      `json:"version"`
+	MessageCount   int       `json:"messageCount"`
+	RolledBackFrom uint      `json:"rolledBackFrom,omitempty"`
+	CreatedBy      uint      `json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type LocaleBundleVersionDetails struct {
+	LocaleBundleVersion
+	Messages map[string]string `json:"messages"`
+}
@@ -0,0 +1,42 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// InboundCommand 는 다른 서비스가 메시지 브로커로 보낸 명령의 JSON 이다.
+// 같은 명령이 두 번 도착할 수 있으므로 보내는 쪽은 명령마다 고유한 id 를 정하고, 같은 id 는 한 번만 처리한다.
+type InboundCommand struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	// Principal 은 명령을 보낸 서비스 계정의 Client Id 이다. 서비스 계정의 역할로 명령 권한을 확인한다.
+	Principal string          `json:"principal"`
+	IssuedAt  time.Time       `json:"issuedAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// MemberCommandData 는 member.approve, member.reject 명령의 data 이다.
+type MemberCommandData struct {
+	MemberId uint `json:"memberId"`
+}
+
+// MemberGrantRolesCommandData 는 member.grant-roles 명령의 data 이다. 멤버가 가진 역할에 RoleIds 를 더한다.
+type MemberGrantRolesCommandData struct {
+	MemberId uint   `json:"memberId"`
+	RoleIds  []uint `json:"roleIds"`
+}
+
+// InboundCommandInformation 은 받은 명령의 처리 결과이다. Status 는 processed, rejected, dead-lettered 중 하나이다.
+type InboundCommandInformation struct {
+	Id        uint            `json:"id"`
+	CommandId string          `json:"commandId"`
+	Type      string          `json:"type"`
+	Principal string          `json:"principal"`
+	Topic     string          `json:"topic"`
+	Status    string          `json:"status"`
+	Attempts  int             `json:"attempts"`
+	Error     string          `json:"error,omitempty"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
@@ -0,0 +1,28 @@
+package dtos
+
+import "time"
+
+// JobSchedule 은 주기 작업의 리더 인스턴스와 작업별 실행 현황이다. 리더 인스턴스만 작업을 실행한다.
+type JobSchedule struct {
+	InstanceId     string         `json:"instanceId"`
+	LeaderElection string         `json:"leaderElection"`
+	Leader         string         `json:"leader"`
+	IsLeader       bool           `json:"isLeader"`
+	Jobs           []ScheduledJob `json:"jobs"`
+}
+
+// ScheduledJob 은 주기 작업이다. RunningOn 은 지금 실행 중인 인스턴스이다.
+type ScheduledJob struct {
+	Name            string           `json:"name"`
+	IntervalSeconds int64            `json:"intervalSeconds"`
+	RunningOn       string           `json:"runningOn,omitempty"`
+	LastRun         *ScheduledJobRun `json:"lastRun,omitempty"`
+}
+
+// ScheduledJobRun 은 마지막 실행 결과이다. Error 가 비어 있으면 성공했다.
+type ScheduledJobRun struct {
+	InstanceId           string    `json:"instanceId"`
+	StartedAt            time.Time `json:"startedAt"`
+	DurationMilliseconds int64     `json:"durationMilliseconds"`
+	Error                string    `json:"error,omitempty"`
+}
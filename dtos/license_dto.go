@@ -0,0 +1,24 @@
+package dtos
+
+import "time"
+
+// LicenseStatus 는 라이선스와 사용량이다(GET /api/system/license). 라이선스 파일을 설정하지 않았으면(Configured false) 수를 제한하지 않는다.
+// 라이선스를 읽을 수 없거나 만료되었으면 Valid 가 false 이고 Error 에 이유가 있다.
+type LicenseStatus struct {
+	Configured bool           `json:"configured"`
+	Valid      bool           `json:"valid"`
+	Error      string         `json:"error,omitempty"`
+	Licensee   string         `json:"licensee,omitempty"`
+	IssuedAt   *time.Time     `json:"issuedAt,omitempty"`
+	ExpiresAt  *time.Time     `json:"expiresAt,omitempty"`
+	Quotas     []LicenseQuota `json:"quotas"`
+}
+
+// LicenseQuota 는 대상(constants.LicenseResource*)의 사용량과 최대 수이다. Limit 이 0 이면 제한하지 않는다.
+// Warning 은 사용량이 알림 기준(License.WarningPercent)에 이르렀는지이다.
+type LicenseQuota struct {
+	Resource string `json:"resource"`
+	Usage    int64  `json:"usage"`
+	Limit    int64  `json:"limit"`
+	Warning  bool   `json:"warning"`
+}
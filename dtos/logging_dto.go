@@ -0,0 +1,42 @@
+package dtos
+
+import "time"
+
+// LoggingSetting 은 실행 중에 변경할 로그 설정이다. 값이 없는 항목은 바꾸지 않는다.
+// DebugModules 는 DebugMinutes 동안 디버그 로그를 남기며 DebugMinutes 가 0 이면 디버그 로그를 끈다.
+type LoggingSetting struct {
+	Level          string                 `json:"level" binding:"omitempty,oneof=panic fatal error warn info debug trace"`
+	DebugModules   []string               `json:"debugModules" binding:"dive,oneof=auth db webhooks"`
+	DebugMinutes   int                    `json:"debugMinutes" binding:"min=0,max=1440"`
+	RequestCapture *RequestCaptureSetting `json:"requestCapture"`
+}
+
+type RequestCaptureSetting struct {
+	MemberId uint `json:"memberId" binding:"required"`
+	Requests int  `json:"requests" binding:"required,min=1,max=100"`
+}
+
+type LoggingStatus struct {
+	Level          string                 `json:"level"`
+	DebugModules   []LoggingDebugModule   `json:"debugModules"`
+	RequestCapture *RequestCaptureSetting `json:"requestCapture,omitempty"`
+}
+
+type LoggingDebugModule struct {
+	Module string    `json:"module"`
+	Until  time.Time `json:"until"`
+}
+
+type RequestCapture struct {
+	MemberId     uint              `json:"memberId"`
+	Method       string            `json:"method"`
+	Path         string            `json:"path"`
+	Query        string            `json:"query,omitempty"`
+	Headers      map[string]string `json:"headers"`
+	RequestBody  string            `json:"requestBody,omitempty"`
+	Status       int               `json:"status"`
+	ResponseBody string            `json:"responseBody,omitempty"`
+	ClientIp     string            `json:"clientIp"`
+	LatencyMs    int64             `json:"latencyMs"`
+	CapturedAt   time.Time         `json:"capturedAt"`
+}
@@ -0,0 +1,24 @@
+package dtos
+
+import "time"
+
+// LoginNotificationInformation 은 새 기기나 위치에서 로그인했다는 알림(앱 내 알림)이다.
+type LoginNotificationInformation struct {
+	Id         uint       `json:"id"`
+	IpAddress  string     `json:"ipAddress"`
+	Country    string     `json:"country"`
+	City       string     `json:"city"`
+	LoggedInAt time.Time  `json:"loggedInAt"`
+	ReportedAt *time.Time `json:"reportedAt,omitempty"`
+}
+
+// LoginNotificationToken 은 알림 메일의 "본인이 아닙니다" 링크로 받은 토큰이다.
+type LoginNotificationToken struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PasswordReset 은 본인이 아닌 로그인을 신고한 뒤 메일로 받은 토큰으로 비밀번호를 다시 설정하는 요청이다.
+type PasswordReset struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
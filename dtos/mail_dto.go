@@ -0,0 +1,14 @@
+package dtos
+
+type MailMessage struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []MailAttachment
+}
+
+type MailAttachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
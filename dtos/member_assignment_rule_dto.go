@@ -0,0 +1,56 @@
+package dtos
+
+import "time"
+
+// MemberAssignmentRuleInformation 은 SSO 로 처음 로그인한 멤버에게 역할과 조직을 자동으로 할당하는 규칙이다.
+// Priority 가 작은 규칙부터 평가하여 처음 맞는 규칙 하나만 적용한다.
+type MemberAssignmentRuleInformation struct {
+	Id              uint                      `json:"id"`
+	Name            string                    `json:"name" binding:"required,max=100"`
+	Priority        int                       `json:"priority"`
+	Condition       MemberAssignmentCondition `json:"condition"`
+	RoleIds         []uint                    `json:"roleIds"`
+	OrganizationIds []uint                    `json:"organizationIds"`
+	CreatedAt       time.Time                 `json:"createdAt"`
+	UpdatedAt       time.Time                 `json:"updatedAt"`
+}
+
+// MemberAssignmentCondition 은 값이 있는 조건을 모두 만족해야 맞는다. 한 조건 안의 값은 하나만 맞으면 된다.
+type MemberAssignmentCondition struct {
+	MemberTypes  []string `json:"memberTypes,omitempty"`
+	EmailDomains []string `json:"emailDomains,omitempty"`
+	// 구글 워크스페이스 조직 단위 경로. 하위 조직 단위도 맞는 것으로 본다.
+	GoogleOrgUnits    []string `json:"googleOrgUnits,omitempty"`
+	DoorayDepartments []string `json:"doorayDepartments,omitempty"`
+}
+
+// MemberSignUpAttributes 는 할당 규칙을 평가할 멤버의 가입 정보이다.
+type MemberSignUpAttributes struct {
+	Type             string `json:"type"`
+	Email            string `json:"email"`
+	GoogleOrgUnit    string `json:"googleOrgUnit"`
+	DoorayDepartment string `json:"doorayDepartment"`
+}
+
+// MemberAssignmentDryRun 은 규칙을 기존 멤버에게 적용하면 어떻게 되는지 미리 확인하는 요청이다.
+// MemberIds 가 없으면 SSO 로 가입한 모든 멤버를, Rules 가 없으면 저장된 규칙을 사용한다.
+type MemberAssignmentDryRun struct {
+	MemberIds []uint                            `json:"memberIds"`
+	Rules     []MemberAssignmentRuleInformation `json:"rules"`
+}
+
+type MemberAssignmentDryRunResult struct {
+	Evaluated int                            `json:"evaluated"`
+	Matched   int                            `json:"matched"`
+	Results   []MemberAssignmentDryRunMember `json:"results"`
+}
+
+type MemberAssignmentDryRunMember struct {
+	MemberId        uint                   `json:"memberId"`
+	Name            string                 `json:"name"`
+	Attributes      MemberSignUpAttributes `json:"attributes"`
+	RuleId          uint                   `json:"ruleId"`
+	RuleName        string                 `json:"ruleName"`
+	RoleIds         []uint                 `json:"roleIds"`
+	OrganizationIds []uint                 `json:"organizationIds"`
+}
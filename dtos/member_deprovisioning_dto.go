@@ -0,0 +1,32 @@
+package dtos
+
+import (
+	"time"
+)
+
+// MemberDeprovisioningRequest 는 멤버 해지 요청이다. 멤버가 소유한 리소스(웹훅, 리포트, 서비스 계정, 세그먼트)는 후임자(SuccessorId)에게 넘긴다.
+type MemberDeprovisioningRequest struct {
+	SuccessorId uint   `json:"successorId" binding:"required"`
+	Reason      string `json:"reason" binding:"max=1000"`
+}
+
+// MemberDeprovisioningReport 는 해지 완료 보고서이다. Steps 는 실행한 순서의 체크리스트이며 연동 시스템에도 같은 내용을 보낸다.
+type MemberDeprovisioningReport struct {
+	Id                   uint                 `json:"id"`
+	MemberId             uint                 `json:"memberId"`
+	SuccessorId          uint                 `json:"successorId"`
+	Reason               string               `json:"reason"`
+	Status               string               `json:"status"`
+	Steps                []DeprovisioningStep `json:"steps"`
+	TransferredResources []OwnedResource      `json:"transferredResources"`
+	RequestedBy          uint                 `json:"requestedBy"`
+	CreatedAt            time.Time            `json:"createdAt"`
+	CompletedAt          *time.Time           `json:"completedAt"`
+}
+
+// DeprovisioningStep 은 체크리스트 항목의 결과이다. Status 는 done, skipped, failed 이다.
+type DeprovisioningStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
@@ -1,20 +1,26 @@
 package dtos
 
 import (
+	"strings"
 	"time"
 )
 
+// MemberInformation 의 메일, 전화번호, 마지막 접속 시간은 권한(permission 태그)이 있는 사용자에게만 응답하고
+// mask 태그 필드는 마스킹 정책에 따라 가린다.(helpers.ResponseHelper)
 type MemberInformation struct {
 	Id                  uint                 `json:"id"`
 	SignId              string               `json:"signId"`
 	Type                string               `json:"type"`
 	TypeName            string               `json:"typeName"`
 	CandidateId         string               `json:"candidateId"`
-	Name                string               `json:"name"`
+	Name                string               `json:"name" mask:"name"`
 	MemberRoles         []MemberRole         `json:"roles"`
 	MemberOrganizations []MemberOrganization `json:"organizations"`
+	Tags                []string             `json:"tags,omitempty"`
 	CreatedAt           time.Time            `json:"createdAt"`
-	LastAccessAt        *time.Time           `json:"lastAccessAt"`
+	Email               string               `json:"email,omitempty" permission:"VIEW_MEMBER_PERSONAL_INFO" mask:"email"`
+	Phone               string               `json:"phone,omitempty" permission:"VIEW_MEMBER_PERSONAL_INFO" mask:"phone"`
+	LastAccessAt        *time.Time           `json:"lastAccessAt,omitempty" permission:"VIEW_MEMBER_PERSONAL_INFO,VIEW_MONITORING"`
 }
 
 type MemberRole struct {
@@ -33,6 +39,10 @@ type MemberOrganizationRole struct {
 	Name string `json:"name"`
 }
 
+type MemberTags struct {
+	Tags []string `json:"tags" binding:"dive,max=50"`
+}
+
 type MemberAssignRole struct {
 	RoleIds []uint `json:"roleIds" binding:"required"`
 }
@@ -56,4 +66,142 @@ type MemberSignUp struct {
 	SignId   string `json:"signId" binding:"required"`
 	Name     string `json:"name" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	Phone    string `json:"phone" binding:"max=20"`
+}
+
+type SignIdChangeRequest struct {
+	NewSignId string `json:"newSignId" binding:"required,email,max=50"`
+	Password  string `json:"password" binding:"required"`
+}
+
+type SignIdChangeToken struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// MemberFieldChangeRequest 는 관리자가 요청하는 멤버의 아이디, 메일 변경이다. 최고 관리자의 역할 변경은 역할 할당으로 요청한다.
+type MemberFieldChangeRequest struct {
+	Field string `json:"field" binding:"required,oneof=sign-id email"`
+	Value string `json:"value" binding:"required,max=50"`
+}
+
+type MemberFieldChangeInformation struct {
+	Id          uint       `json:"id"`
+	MemberId    uint       `json:"memberId"`
+	Field       string     `json:"field"`
+	OldValue    string     `json:"oldValue"`
+	NewValue    string     `json:"newValue"`
+	Status      string     `json:"status"`
+	RequestedBy uint       `json:"requestedBy"`
+	ExpiresAt   time.Time  `json:"expiresAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// MemberFieldChangeDiff 는 승인 전에 확인하는 변경 전후 값이다. 역할 변경은 추가/제거되는 역할 이름도 포함한다.
+type MemberFieldChangeDiff struct {
+	Id           uint      `json:"id"`
+	MemberId     uint      `json:"memberId"`
+	Field        string    `json:"field"`
+	OldValue     string    `json:"oldValue"`
+	NewValue     string    `json:"newValue"`
+	AddedRoles   []string  `json:"addedRoles,omitempty"`
+	RemovedRoles []string  `json:"removedRoles,omitempty"`
+	Status       string    `json:"status"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+// MemberApprovalSetting 은 가입 신청을 일괄 승인할 때 함께 할당하는 기본 역할이다.
+type MemberApprovalSetting struct {
+	DefaultRoleIds []uint `json:"defaultRoleIds"`
+}
+
+// MemberBulkApproval 은 가입 신청을 일괄 승인하는 요청이다. 멤버 ID 목록(MemberIds)이나 조건(Filter) 중 하나로 대상을 지정한다.
+type MemberBulkApproval struct {
+	MemberIds []uint                    `json:"memberIds"`
+	Filter    *MemberBulkApprovalFilter `json:"filter"`
+}
+
+// MemberBulkApprovalFilter 는 승인 대기(applied) 멤버 중 일괄 승인할 멤버의 조건이다.
+type MemberBulkApprovalFilter struct {
+	Name  string   `json:"name"`
+	Types []string `json:"types"`
+	Tags  []string `json:"tags"`
+}
+
+type MemberBulkApprovalResult struct {
+	Requested    int                        `json:"requested"`
+	Succeeded    int                        `json:"succeeded"`
+	DefaultRoles []string                   `json:"defaultRoles"`
+	Results      []MemberBulkApprovalMember `json:"results"`
+}
+
+// MemberBulkApprovalMember 는 멤버마다의 결과이다. Status 는 approved 또는 failed 이며 failed 이면 Reason 이 있다.
+type MemberBulkApprovalMember struct {
+	MemberId uint   `json:"memberId"`
+	Status   string `json:"status"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// AutoApprovalSetting 은 메일 도메인으로 가입 신청을 자동 승인하는 규칙이다. 규칙은 순서대로 평가하여 처음 맞는 규칙을 사용한다.
+// Enabled 이면 규칙에 맞지 않는 SSO 멤버도 바로 가입시키지 않고 승인 대기(applied)로 둔다.
+type AutoApprovalSetting struct {
+	Enabled bool               `json:"enabled"`
+	Rules   []AutoApprovalRule `json:"rules" binding:"max=100,dive"`
+}
+
+// AutoApprovalRule 은 자동 승인할 메일 도메인(예. bettercode.kr)과 승인할 때 할당하는 기본 역할이다.
+type AutoApprovalRule struct {
+	Domain         string `json:"domain" binding:"required,max=100"`
+	DefaultRoleIds []uint `json:"defaultRoleIds"`
+}
+
+// FindRule 은 메일 주소의 도메인에 맞는 처음 규칙을 찾는다. 도메인은 대소문자를 구분하지 않는다.
+func (s AutoApprovalSetting) FindRule(email string) (AutoApprovalRule, bool) {
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return AutoApprovalRule{}, false
+	}
+
+	domain := email[at+1:]
+	for _, rule := range s.Rules {
+		if strings.EqualFold(strings.TrimPrefix(rule.Domain, "@"), domain) {
+			return rule, true
+		}
+	}
+
+	return AutoApprovalRule{}, false
+}
+
+// AutoApprovalDryRun 은 규칙을 승인 대기 멤버에게 적용하면 어떻게 되는지 미리 확인하는 요청이다. Rules 가 없으면 저장된 규칙을 사용한다.
+type AutoApprovalDryRun struct {
+	Rules []AutoApprovalRule `json:"rules" binding:"max=100,dive"`
+}
+
+type AutoApprovalDryRunResult struct {
+	Evaluated int                        `json:"evaluated"`
+	Matched   int                        `json:"matched"`
+	Results   []AutoApprovalDryRunMember `json:"results"`
+}
+
+type AutoApprovalDryRunMember struct {
+	MemberId       uint   `json:"memberId"`
+	Name           string `json:"name"`
+	Email          string `json:"email"`
+	Domain         string `json:"domain"`
+	DefaultRoleIds []uint `json:"defaultRoleIds"`
+}
+
+// PasswordHashReport 는 비밀번호로 로그인하는 멤버의 비밀번호 해시 현황이다.
+// LegacyCount 는 설정(Algorithm)과 다른 알고리즘이나 비용으로 저장되어 다음 로그인 때 다시 저장될 멤버 수이다.
+type PasswordHashReport struct {
+	Algorithm   string                       `json:"algorithm"`
+	TotalCount  int                          `json:"totalCount"`
+	LegacyCount int                          `json:"legacyCount"`
+	Algorithms  []PasswordHashAlgorithmCount `json:"algorithms"`
+}
+
+type PasswordHashAlgorithmCount struct {
+	Algorithm   string `json:"algorithm"`
+	Count       int    `json:"count"`
+	LegacyCount int    `json:"legacyCount"`
 }
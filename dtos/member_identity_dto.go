@@ -0,0 +1,19 @@
+package dtos
+
+import "time"
+
+// MemberIdentityInformation 은 멤버가 로그인할 수 있는 계정이다. Primary 는 가입한 인증 방식의 계정이다.
+type MemberIdentityInformation struct {
+	Id         uint       `json:"id,omitempty"`
+	Provider   string     `json:"provider"`
+	ExternalId string     `json:"externalId"`
+	Email      string     `json:"email,omitempty"`
+	Primary    bool       `json:"primary"`
+	LinkedAt   *time.Time `json:"linkedAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// GoogleIdentityLinkStart 는 구글 계정 연결을 시작할 구글 로그인 화면 주소이다.
+type GoogleIdentityLinkStart struct {
+	Url string `json:"url"`
+}
@@ -0,0 +1,41 @@
+package dtos
+
+import "time"
+
+// MessageTemplateVariable 은 템플릿에서 사용할 수 있는 변수({{.name}})와 미리보기에 사용하는 예시 값이다.
+type MessageTemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Sample      string `json:"sample"`
+}
+
+// MessageTemplate 은 메일(알림) 템플릿이다. Customized 가 아니면 Subject, Body 는 내장 템플릿이다.
+type MessageTemplate struct {
+	Name           string                    `json:"name"`
+	Description    string                    `json:"description"`
+	Variables      []MessageTemplateVariable `json:"variables"`
+	Subject        string                    `json:"subject"`
+	Body           string                    `json:"body"`
+	DefaultSubject string                    `json:"defaultSubject"`
+	DefaultBody    string                    `json:"defaultBody"`
+	Customized     bool                      `json:"customized"`
+	UpdatedBy      uint                      `json:"updatedBy,omitempty"`
+	UpdatedAt      *time.Time                `json:"updatedAt,omitempty"`
+}
+
+type MessageTemplateInformation struct {
+	Subject string `json:"subject" binding:"required"`
+	Body    string `json:"body" binding:"required"`
+}
+
+// MessageTemplatePreviewRequest 는 미리보기할 템플릿이다. Subject, Body 가 비어 있으면 현재 템플릿을, Data 에 없는 변수는 예시 값을 사용한다.
+type MessageTemplatePreviewRequest struct {
+	Subject string            `json:"subject"`
+	Body    string            `json:"body"`
+	Data    map[string]string `json:"data"`
+}
+
+type MessageTemplatePreview struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
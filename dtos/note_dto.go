@@ -0,0 +1,24 @@
+package dtos
+
+import "time"
+
+// NoteInformation 은 대상에 남긴 관리자 메모이다. 최상위 메모는 Replies 에 답글을 포함한다.
+type NoteInformation struct {
+	Id         uint   `json:"id"`
+	EntityType string `json:"entityType"`
+	EntityId   uint   `json:"entityId"`
+	// ParentId 는 답글을 달 최상위 메모이다. 0 이면 최상위 메모이다.
+	ParentId uint   `json:"parentId"`
+	Content  string `json:"content" binding:"required,max=5000"`
+	// Mentions 는 내용에서 언급(@signId)한 멤버 Id 이다.
+	Mentions []uint `json:"mentions"`
+	// AttachmentFileIds 는 작성/수정할 때 새로 첨부할 파일(POST /files, purpose: attachment)이다.
+	AttachmentFileIds []uint                  `json:"attachmentFileIds,omitempty" binding:"max=10"`
+	Attachments       []AttachmentInformation `json:"attachments"`
+	CreatedBy         uint                    `json:"createdBy"`
+	UpdatedBy         uint                    `json:"updatedBy"`
+	EditedAt          *time.Time              `json:"editedAt"`
+	CreatedAt         time.Time               `json:"createdAt"`
+	UpdatedAt         time.Time               `json:"updatedAt"`
+	Replies           []NoteInformation       `json:"replies,omitempty"`
+}
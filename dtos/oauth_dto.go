@@ -0,0 +1,71 @@
+package dtos
+
+import "time"
+
+type OAuthClientInformation struct {
+	Id           uint     `json:"id"`
+	ClientId     string   `json:"clientId"`
+	Name         string   `json:"name" binding:"required"`
+	Description  string   `json:"description"`
+	RedirectUris []string `json:"redirectUris"`
+	Scopes       []string `json:"scopes"`
+	Trusted      bool     `json:"trusted"`
+	// PkcePolicy 는 인가 요청에 PKCE 를 요구하는 정책(s256, required, optional)이다. 비어 있으면 s256 이다.
+	PkcePolicy string    `json:"pkcePolicy" binding:"omitempty,oneof=s256 required optional"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type OAuthClientSummary struct {
+	ClientId    string `json:"clientId"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// OAuthConsent 는 동의 화면을 그리기 위한 정보이다.
+type OAuthConsent struct {
+	Client          OAuthClientSummary `json:"client"`
+	RequestedScopes []string           `json:"requestedScopes"`
+	GrantedScopes   []string           `json:"grantedScopes"`
+	ConsentRequired bool               `json:"consentRequired"`
+}
+
+type MemberConsentGrant struct {
+	ClientId string   `json:"clientId" binding:"required"`
+	Scopes   []string `json:"scopes" binding:"required,min=1"`
+}
+
+type MemberConsent struct {
+	Client    OAuthClientSummary `json:"client"`
+	Scopes    []string           `json:"scopes"`
+	GrantedAt time.Time          `json:"grantedAt"`
+}
+
+// OAuthAuthorizationRequest 는 로그인한 멤버가 Client 에 인가 코드를 발급하는 요청(RFC 6749 4.1.1, RFC 7636 4.3)이다.
+type OAuthAuthorizationRequest struct {
+	ClientId            string `json:"clientId" binding:"required"`
+	RedirectUri         string `json:"redirectUri" binding:"required"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+}
+
+// OAuthAuthorizationCode 는 발급한 인가 코드이다. RedirectUri 는 code 와 state 를 붙인 돌아갈 주소이다.
+type OAuthAuthorizationCode struct {
+	Code        string `json:"code"`
+	State       string `json:"state,omitempty"`
+	RedirectUri string `json:"redirectUri"`
+	ExpiresIn   int    `json:"expiresIn"`
+}
+
+// OAuthDeviceAuthorizationInformation 은 관리 화면의 기기 승인 화면에 보여줄 정보이다.
+type OAuthDeviceAuthorizationInformation struct {
+	Client    OAuthClientSummary `json:"client"`
+	UserCode  string             `json:"userCode"`
+	Scopes    []string           `json:"scopes"`
+	ExpiresAt time.Time          `json:"expiresAt"`
+}
+
+type OAuthDeviceApproval struct {
+	Approved bool `json:"approved"`
+}
@@ -24,17 +24,32 @@ type OrganizationRole struct {
 
 type OrganizationMember struct {
 	Id   uint   `json:"id"`
-	Name string `json:"name"`
+	Name string `json:"name" mask:"name"`
 }
 
 type OrganizationAssignMember struct {
 	MemberIds []uint `json:"memberIds" binding:"required"`
 }
 
+type OrganizationAssignLeader struct {
+	MemberIds []uint `json:"memberIds" binding:"required"`
+}
+
 type OrganizationDetails struct {
 	Id        uint                 `json:"id"`
 	Name      string               `json:"name"`
 	CreatedAt time.Time            `json:"createdAt"`
 	Roles     []OrganizationRole   `json:"roles,omitempty"`
 	Members   []OrganizationMember `json:"members,omitempty"`
+	Leaders   []OrganizationMember `json:"leaders,omitempty"`
+}
+
+// OrganizationChartNode 는 조직도를 그리기 위한 조직이다. TotalMemberCount 는 하위 조직을 포함한 멤버 수(중복 제외)이다.
+type OrganizationChartNode struct {
+	Id               uint                    `json:"id"`
+	Name             string                  `json:"name"`
+	MemberCount      int                     `json:"memberCount"`
+	TotalMemberCount int                     `json:"totalMemberCount"`
+	Leaders          []OrganizationMember    `json:"leaders"`
+	Children         []OrganizationChartNode `json:"children"`
 }
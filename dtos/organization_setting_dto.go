@@ -0,0 +1,53 @@
+package dtos
+
+// PasswordPolicySetting 은 비밀번호 정책이다. MinLength 가 0 이면 길이를 제한하지 않고, Require* 는 반드시 포함할 문자 종류이다.
+type PasswordPolicySetting struct {
+	MinLength        int  `json:"minLength" binding:"min=0,max=128"`
+	RequireUppercase bool `json:"requireUppercase"`
+	RequireLowercase bool `json:"requireLowercase"`
+	RequireDigit     bool `json:"requireDigit"`
+	RequireSymbol    bool `json:"requireSymbol"`
+}
+
+// SessionLifetimeSetting 은 로그인한 세션(Refresh 토큰)을 사용할 수 있는 시간(분)이다.
+type SessionLifetimeSetting struct {
+	LifetimeMinutes int `json:"lifetimeMinutes" binding:"required,min=1,max=10080"`
+}
+
+// OrganizationSettingOverrides 는 조직에서 바꾼 사이트 설정이다. nil 이면 상위 조직의 설정을, 상위 조직도 바꾸지 않았으면 사이트 설정을 사용한다.
+// AuthMethods 는 허용하는 로그인 방법(constants.LoginMethod*)이다.
+type OrganizationSettingOverrides struct {
+	PasswordPolicy  *PasswordPolicySetting  `json:"passwordPolicy"`
+	SessionLifetime *SessionLifetimeSetting `json:"sessionLifetime"`
+	AuthMethods     []string                `json:"authMethods"`
+}
+
+// OrganizationSettingSource 는 설정 값을 정한 단계(organization, global)와 값을 정한 조직이다.
+type OrganizationSettingSource struct {
+	Level            string `json:"level"`
+	OrganizationId   uint   `json:"organizationId,omitempty"`
+	OrganizationName string `json:"organizationName,omitempty"`
+}
+
+type EffectivePasswordPolicySetting struct {
+	Value  PasswordPolicySetting     `json:"value"`
+	Source OrganizationSettingSource `json:"source"`
+}
+
+type EffectiveSessionLifetimeSetting struct {
+	Value  SessionLifetimeSetting    `json:"value"`
+	Source OrganizationSettingSource `json:"source"`
+}
+
+type EffectiveAuthMethodsSetting struct {
+	Value  []string                  `json:"value"`
+	Source OrganizationSettingSource `json:"source"`
+}
+
+// OrganizationEffectiveSettings 는 조직 -> 상위 조직 -> 사이트 설정 순서로 찾은, 조직에 적용되는 설정이다.
+type OrganizationEffectiveSettings struct {
+	OrganizationId  uint                            `json:"organizationId"`
+	PasswordPolicy  EffectivePasswordPolicySetting  `json:"passwordPolicy"`
+	SessionLifetime EffectiveSessionLifetimeSetting `json:"sessionLifetime"`
+	AuthMethods     EffectiveAuthMethodsSetting     `json:"authMethods"`
+}
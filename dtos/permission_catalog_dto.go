@@ -0,0 +1,36 @@
+package dtos
+
+// PermissionCatalogRegistration 은 서비스가 네임스페이스에 등록하는 권한 전체 목록이다. 목록에 없는 기존 권한은 제거된다.
+type PermissionCatalogRegistration struct {
+	Permissions []PermissionCatalogItem `json:"permissions" binding:"required,dive"`
+}
+
+// PermissionCatalogItem 의 Name 은 네임스페이스를 제외한 이름이다. 등록된 권한 이름은 "네임스페이스:이름" 이 된다.
+type PermissionCatalogItem struct {
+	Name        string `json:"name" binding:"required,max=49"`
+	Description string `json:"description" binding:"max=1000"`
+	Group       string `json:"group" binding:"max=100"`
+}
+
+type PermissionCatalogRegistrationResult struct {
+	Namespace string `json:"namespace"`
+	Created   int    `json:"created"`
+	Updated   int    `json:"updated"`
+	Removed   int    `json:"removed"`
+}
+
+type PermissionCatalogNamespace struct {
+	Namespace string                   `json:"namespace"`
+	Groups    []PermissionCatalogGroup `json:"groups"`
+}
+
+type PermissionCatalogGroup struct {
+	Name        string                        `json:"name"`
+	Permissions []PermissionCatalogPermission `json:"permissions"`
+}
+
+type PermissionCatalogPermission struct {
+	Id          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
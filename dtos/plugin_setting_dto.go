@@ -0,0 +1,15 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// PluginSetting 은 Namespace 의 설정이다. 저장한 적이 없으면 Value 는 null 이다.
+type PluginSetting struct {
+	Namespace string          `json:"namespace"`
+	Value     json.RawMessage `json:"value"`
+	Writable  bool            `json:"writable"`
+	UpdatedBy uint            `json:"updatedBy,omitempty"`
+	UpdatedAt time.Time       `json:"updatedAt,omitempty"`
+}
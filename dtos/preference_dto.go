@@ -0,0 +1,28 @@
+package dtos
+
+import "encoding/json"
+
+// MemberPreferences 는 Namespace 별 설정 값이다. 값이 null 인 Namespace 는 삭제한다.
+type MemberPreferences map[string]json.RawMessage
+
+type ThemePreference struct {
+	Mode         string `json:"mode" binding:"omitempty,oneof=light dark system"`
+	PrimaryColor string `json:"primaryColor" binding:"omitempty,max=20"`
+	Locale       string `json:"locale" binding:"omitempty,max=10"`
+}
+
+type NotificationPreference struct {
+	// OptOuts 는 수신하지 않을 알림 종류이다.
+	OptOuts []string `json:"optOuts" binding:"dive,max=50"`
+}
+
+// TableColumnPreference 는 화면(테이블) 별 컬럼 배치이다.
+type TableColumnPreference struct {
+	Tables map[string]TableColumnLayout `json:"tables" binding:"dive,keys,max=100,endkeys"`
+}
+
+type TableColumnLayout struct {
+	Columns  []string       `json:"columns" binding:"dive,max=50"`
+	Widths   map[string]int `json:"widths"`
+	PageSize int            `json:"pageSize" binding:"min=0,max=1000"`
+}
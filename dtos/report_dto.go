@@ -0,0 +1,59 @@
+package dtos
+
+import "time"
+
+type ReportInformation struct {
+	Id          uint             `json:"id"`
+	Name        string           `json:"name" binding:"required,max=100"`
+	Description string           `json:"description" binding:"max=1000"`
+	Definition  ReportDefinition `json:"definition"`
+	Format      string           `json:"format" binding:"required,oneof=csv xlsx"`
+	// Schedule 은 "분 시 일 월 요일" 형식의 cron 표현식이다. 비어 있으면 요청할 때만 실행한다.
+	Schedule  string         `json:"schedule"`
+	Delivery  ReportDelivery `json:"delivery"`
+	NextRunAt *time.Time     `json:"nextRunAt"`
+	OwnerId   uint           `json:"ownerId"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// ReportDefinition 은 리포트로 조회할 대상과 조건, 컬럼, 집계 방법이다.
+type ReportDefinition struct {
+	Entity      string             `json:"entity" binding:"required"`
+	Columns     []string           `json:"columns"`
+	Filters     []ReportFilter     `json:"filters" binding:"dive"`
+	Aggregation *ReportAggregation `json:"aggregation,omitempty"`
+}
+
+type ReportFilter struct {
+	Column   string `json:"column" binding:"required"`
+	Operator string `json:"operator" binding:"required,oneof=eq ne contains gte lte"`
+	Value    string `json:"value"`
+}
+
+// ReportAggregation 은 GroupBy 컬럼별로 Function(count, sum, avg, min, max)을 계산한다. count 외에는 Column 이 필요하다.
+type ReportAggregation struct {
+	GroupBy  []string `json:"groupBy"`
+	Function string   `json:"function" binding:"required,oneof=count sum avg min max"`
+	Column   string   `json:"column"`
+}
+
+type ReportDelivery struct {
+	Channel    string   `json:"channel" binding:"omitempty,oneof=email webhook"`
+	Recipients []string `json:"recipients" binding:"omitempty,dive,email"`
+	WebHookUrl string   `json:"webHookUrl" binding:"omitempty,url"`
+}
+
+type ReportRunInformation struct {
+	Id             uint       `json:"id"`
+	ReportId       uint       `json:"reportId"`
+	Trigger        string     `json:"trigger"`
+	Status         string     `json:"status"`
+	DeliveryStatus string     `json:"deliveryStatus"`
+	FileName       string     `json:"fileName"`
+	RowCount       int        `json:"rowCount"`
+	ErrorMessage   string     `json:"errorMessage"`
+	RequestedBy    uint       `json:"requestedBy"`
+	StartedAt      time.Time  `json:"startedAt"`
+	FinishedAt     *time.Time `json:"finishedAt"`
+}
@@ -0,0 +1,27 @@
+package dtos
+
+// OwnedResource 는 멤버가 소유한 리소스이다. Type 은 web-hook, report, service-account, segment 이다.
+type OwnedResource struct {
+	Type string `json:"type"`
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// ResourceOwnershipTransfer 는 멤버(FromOwnerId)가 소유한 리소스를 모두 다른 멤버(ToOwnerId)에게 넘기는 요청이다.
+// ResourceTypes 가 비어 있으면 모든 유형을 넘긴다.
+type ResourceOwnershipTransfer struct {
+	FromOwnerId   uint     `json:"fromOwnerId" binding:"required"`
+	ToOwnerId     uint     `json:"toOwnerId" binding:"required"`
+	ResourceTypes []string `json:"resourceTypes" binding:"max=10"`
+}
+
+// ResourceOwnershipResourcesTransfer 는 지정한 리소스들을 ToOwnerId 에게 넘기는 요청이다.
+type ResourceOwnershipResourcesTransfer struct {
+	ToOwnerId uint                `json:"toOwnerId" binding:"required"`
+	Resources []ResourceReference `json:"resources" binding:"required,min=1,max=100,dive"`
+}
+
+type ResourceReference struct {
+	Type string `json:"type" binding:"required"`
+	Id   uint   `json:"id" binding:"required"`
+}
@@ -0,0 +1,57 @@
+package dtos
+
+import "time"
+
+// RetentionSetting 은 데이터 종류별 보관 기간(일)을 기본 설정(config.Retention) 대신 사용할 값이다. 0 이면 지우지 않는다.
+type RetentionSetting struct {
+	Days map[string]int `json:"days"`
+}
+
+// RetentionPolicy 는 데이터 종류의 기본 보관 기간과 사이트 설정을 반영한 보관 기간이다.
+type RetentionPolicy struct {
+	Category    string `json:"category"`
+	DefaultDays int    `json:"defaultDays"`
+	Days        int    `json:"days"`
+	Overridden  bool   `json:"overridden"`
+}
+
+// LegalHoldRequest 는 법적 보존 요청이다. 멤버(MemberId)나 기간(HeldFrom~HeldTo) 중 하나 이상을 지정한다.
+// OwnerId 는 보존을 책임지는 멤버로 지정하지 않으면 요청한 멤버이다.
+type LegalHoldRequest struct {
+	MemberId uint       `json:"memberId"`
+	OwnerId  uint       `json:"ownerId"`
+	HeldFrom *time.Time `json:"heldFrom"`
+	HeldTo   *time.Time `json:"heldTo"`
+	Reason   string     `json:"reason" binding:"required,max=1000"`
+}
+
+type LegalHoldInformation struct {
+	Id        uint       `json:"id"`
+	MemberId  uint       `json:"memberId,omitempty"`
+	HeldFrom  *time.Time `json:"heldFrom"`
+	HeldTo    *time.Time `json:"heldTo"`
+	Reason    string     `json:"reason"`
+	OwnerId   uint       `json:"ownerId"`
+	CreatedBy uint       `json:"createdBy"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// RetentionResult 는 보관 기간 적용 실행에서 데이터 종류별로 지운 수와 법적 보존으로 남긴 수이다.
+type RetentionResult struct {
+	Category     string     `json:"category"`
+	Days         int        `json:"days"`
+	Cutoff       *time.Time `json:"cutoff,omitempty"`
+	DeletedCount int64      `json:"deletedCount"`
+	HeldCount    int64      `json:"heldCount"`
+	ErrorMessage string     `json:"errorMessage,omitempty"`
+}
+
+type RetentionRunInformation struct {
+	Id          uint              `json:"id"`
+	TriggerType string            `json:"triggerType"`
+	Status      string            `json:"status"`
+	Results     []RetentionResult `json:"results"`
+	RequestedBy uint              `json:"requestedBy,omitempty"`
+	StartedAt   time.Time         `json:"startedAt"`
+	FinishedAt  *time.Time        `json:"finishedAt"`
+}
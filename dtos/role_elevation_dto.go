@@ -0,0 +1,25 @@
+package dtos
+
+import "time"
+
+// RoleElevationRequest 는 멤버가 승인을 받아 Hours 시간 동안만 역할(RoleId)을 받으려는 요청이다.
+type RoleElevationRequest struct {
+	RoleId        uint   `json:"roleId" binding:"required"`
+	Hours         int    `json:"hours" binding:"required,min=1"`
+	Justification string `json:"justification" binding:"required,max=1000"`
+}
+
+type RoleElevationInformation struct {
+	Id            uint       `json:"id"`
+	MemberId      uint       `json:"memberId"`
+	RoleId        uint       `json:"roleId"`
+	RoleName      string     `json:"roleName"`
+	Hours         int        `json:"hours"`
+	Justification string     `json:"justification"`
+	Status        string     `json:"status"`
+	ActivatedAt   *time.Time `json:"activatedAt,omitempty"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+	EndedAt       *time.Time `json:"endedAt,omitempty"`
+	EndedBy       uint       `json:"endedBy,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
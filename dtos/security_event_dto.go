@@ -0,0 +1,48 @@
+package dtos
+
+import "time"
+
+type SecurityEventInformation struct {
+	Id              uint       `json:"id"`
+	Type            string     `json:"type"`
+	Severity        string     `json:"severity"`
+	Status          string     `json:"status"`
+	MemberId        uint       `json:"memberId"`
+	IpAddress       string     `json:"ipAddress"`
+	Country         string     `json:"country"`
+	Detail          string     `json:"detail"`
+	OccurredAt      time.Time  `json:"occurredAt"`
+	AcknowledgedBy  uint       `json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt  *time.Time `json:"acknowledgedAt,omitempty"`
+	AcknowledgeNote string     `json:"acknowledgeNote,omitempty"`
+}
+
+type SecurityEventAcknowledgement struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+// SecurityEventSummary 는 확인하지 않은(open) 보안 이벤트의 심각도별, 유형별 수이다.
+type SecurityEventSummary struct {
+	OpenCount  int64                `json:"openCount"`
+	Severities []SecurityEventCount `json:"severities"`
+	Types      []SecurityEventCount `json:"types"`
+}
+
+type SecurityEventCount struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// SecurityEventRuleSetting 은 보안 이벤트를 알림으로 보내는 규칙이다. 이벤트가 맞는 규칙마다 알린다.
+type SecurityEventRuleSetting struct {
+	Rules []SecurityEventRule `json:"rules" binding:"max=20,dive"`
+}
+
+// SecurityEventRule 은 EventTypes(비어 있으면 모든 유형) 중 MinSeverity 이상인 이벤트를 WebHookUrls 로 보내고 NotifyRoleName 역할의 멤버에게 메일로 알린다.
+type SecurityEventRule struct {
+	Name           string   `json:"name" binding:"required,max=50"`
+	EventTypes     []string `json:"eventTypes" binding:"dive,oneof=login-failed lockout impersonation permission-denied anomaly break-glass-used read-only-write super-admin-changed super-admin-blocked"`
+	MinSeverity    string   `json:"minSeverity" binding:"required,oneof=low medium high critical"`
+	WebHookUrls    []string `json:"webHookUrls" binding:"max=5,dive,url"`
+	NotifyRoleName string   `json:"notifyRoleName" binding:"max=50"`
+}
@@ -0,0 +1,49 @@
+package dtos
+
+import "time"
+
+type SegmentInformation struct {
+	Id          uint          `json:"id"`
+	Name        string        `json:"name" binding:"required,max=100"`
+	Description string        `json:"description" binding:"max=1000"`
+	Filter      SegmentFilter `json:"filter"`
+	OwnerId     uint          `json:"ownerId"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// SegmentFilter 는 멤버 목록 조회(GET /members)와 같은 검색 조건이다.
+type SegmentFilter struct {
+	Status  string   `json:"status,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Types   []string `json:"types,omitempty"`
+	RoleIds []string `json:"roleIds,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// ToMemberFilters 는 멤버 목록 조회 조건으로 변환한다.
+func (f SegmentFilter) ToMemberFilters() map[string]interface{} {
+	filters := map[string]interface{}{}
+
+	if len(f.Status) > 0 {
+		filters["status"] = f.Status
+	}
+
+	if len(f.Name) > 0 {
+		filters["name"] = f.Name
+	}
+
+	if len(f.Types) > 0 {
+		filters["types"] = f.Types
+	}
+
+	if len(f.RoleIds) > 0 {
+		filters["roleIds"] = f.RoleIds
+	}
+
+	if len(f.Tags) > 0 {
+		filters["tags"] = f.Tags
+	}
+
+	return filters
+}
@@ -0,0 +1,17 @@
+package dtos
+
+import "time"
+
+// SelfCheckReport 는 설정 점검 결과이다. Severity 가 error 인 항목이 하나라도 실패하면 Healthy 는 false 이다.
+type SelfCheckReport struct {
+	Healthy   bool              `json:"healthy"`
+	CheckedAt time.Time         `json:"checkedAt"`
+	Results   []SelfCheckResult `json:"results"`
+}
+
+type SelfCheckResult struct {
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	Passed   bool   `json:"passed"`
+	Message  string `json:"message,omitempty"`
+}
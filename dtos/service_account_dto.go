@@ -0,0 +1,47 @@
+package dtos
+
+import "time"
+
+type ServiceAccountInformation struct {
+	Id          uint   `json:"id"`
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// TokenLifetimeSeconds 가 0 이면 설정(ClientCredentials.DefaultLifetimeSeconds)을 따른다.
+	TokenLifetimeSeconds int                  `json:"tokenLifetimeSeconds" binding:"min=0"`
+	ClientId             string               `json:"clientId"`
+	Roles                []ServiceAccountRole `json:"roles"`
+	ApiKeyPrefix         string               `json:"apiKeyPrefix"`
+	ApiKeyIssuedAt       *time.Time           `json:"apiKeyIssuedAt"`
+	OwnerId              uint                 `json:"ownerId"`
+	CreatedAt            time.Time            `json:"createdAt"`
+}
+
+type ServiceAccountRole struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type ServiceAccountAssignRole struct {
+	RoleIds []uint `json:"roleIds" binding:"required"`
+}
+
+type ServiceAccountApiKey struct {
+	ApiKey string `json:"apiKey"`
+}
+
+// TokenExchangePolicy 는 서비스 계정이 토큰 교환으로 멤버 대신 호출할 수 있는 서비스(audience)와 넘겨줄 수 있는 권한이다.
+type TokenExchangePolicy struct {
+	Audience string   `json:"audience" binding:"required"`
+	Scopes   []string `json:"scopes"`
+	// LifetimeSeconds 가 0 이면 설정(TokenExchange.DefaultLifetimeSeconds)을 따른다.
+	LifetimeSeconds int `json:"lifetimeSeconds" binding:"min=0"`
+}
+
+type ServiceAccountTokenExchangePolicies struct {
+	Policies []TokenExchangePolicy `json:"policies" binding:"dive"`
+}
+
+type ServiceAccountClientSecret struct {
+	ClientId     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
@@ -3,6 +3,8 @@ package dtos
 import (
 	"better-admin-backend-service/config"
 	"fmt"
+	"strings"
+	"time"
 )
 
 type DoorayLoginSetting struct {
@@ -17,17 +19,87 @@ type SiteSettingsSummary struct {
 	GoogleWorkspaceOAuthUri  string `json:"googleWorkspaceOAuthUri"`
 }
 
+// GoogleWorkspaceLoginSetting 의 Domain 은 허용 도메인 설정(/site/settings/google-workspace)이 없을 때만 사용한다.
 type GoogleWorkspaceLoginSetting struct {
 	Used         *bool  `json:"used" binding:"required"`
-	Domain       string `json:"domain" binding:"required_if=Used true"`
+	Domain       string `json:"domain"`
 	ClientId     string `json:"clientId" binding:"required_if=Used true"`
 	ClientSecret string `json:"clientSecret" binding:"required_if=Used true"`
 	RedirectUri  string `json:"redirectUri" binding:"required_if=Used true"`
 }
 
-func (g GoogleWorkspaceLoginSetting) GetOAuthUri() string {
-	return fmt.Sprintf("%v?client_id=%v&redirect_uri=%v&response_type=code&scope=https://www.googleapis.com/auth/userinfo.profile https://www.googleapis.com/auth/userinfo.email&approval_prompt=force&access_type=offline",
-		config.Config.GoogleOAuth.OAuthUri, g.ClientId, g.RedirectUri)
+// GetOAuthUri 는 구글 로그인 화면 주소이다. additionalScopes 는 기본 프로필, 메일 범위에 더해 요청할 범위이다.
+func (g GoogleWorkspaceLoginSetting) GetOAuthUri(additionalScopes ...string) string {
+	scopes := append([]string{"https://www.googleapis.com/auth/userinfo.profile", "https://www.googleapis.com/auth/userinfo.email"}, additionalScopes...)
+	return fmt.Sprintf("%v?client_id=%v&redirect_uri=%v&response_type=code&scope=%v&approval_prompt=force&access_type=offline",
+		config.Config.GoogleOAuth.OAuthUri, g.ClientId, g.RedirectUri, strings.Join(scopes, " "))
+}
+
+// GoogleWorkspaceSetting 은 구글 워크스페이스 로그인을 허용할 도메인과 디렉터리 프로필 가져오기 설정이다.
+type GoogleWorkspaceSetting struct {
+	Domains []GoogleWorkspaceDomain `json:"domains" binding:"required,min=1,dive"`
+	// VerifyHostedDomain 이면 구글이 알려준 워크스페이스 도메인(hd)이 허용된 도메인인 계정만 허용한다.
+	// 아니면 hd 가 없는 계정(개인 구글 계정 등)도 메일 도메인이 허용된 도메인이면 허용한다.
+	VerifyHostedDomain *bool                          `json:"verifyHostedDomain" binding:"required"`
+	DirectoryAccess    GoogleWorkspaceDirectoryAccess `json:"directoryAccess"`
+}
+
+type GoogleWorkspaceDomain struct {
+	Domain string `json:"domain" binding:"required,max=100"`
+	// DefaultRoleIds 는 이 도메인 계정으로 처음 로그인한 멤버에게 할당할 역할이다.
+	DefaultRoleIds []uint `json:"defaultRoleIds"`
+}
+
+// GoogleWorkspaceDirectoryAccess 는 디렉터리 API 로 조직 단위, 전화번호 등 프로필을 더 가져오는 설정이다.
+// 워크스페이스 관리자가 앱에 디렉터리 읽기 범위를 승인(AdminConsented)해야 사용할 수 있다.
+// GroupSync 이면 그룹 읽기 범위도 요청하여 로그인할 때 그룹-역할 매핑으로 역할을 동기화한다.
+type GoogleWorkspaceDirectoryAccess struct {
+	Enabled        bool `json:"enabled"`
+	AdminConsented bool `json:"adminConsented"`
+	GroupSync      bool `json:"groupSync,omitempty"`
+}
+
+func (d GoogleWorkspaceDirectoryAccess) IsUsable() bool {
+	return d.Enabled && d.AdminConsented
+}
+
+func (d GoogleWorkspaceDirectoryAccess) IsGroupSyncUsable() bool {
+	return d.IsUsable() && d.GroupSync
+}
+
+func (s GoogleWorkspaceSetting) GetDomainNames() []string {
+	names := make([]string, 0, len(s.Domains))
+	for _, domain := range s.Domains {
+		names = append(names, domain.Domain)
+	}
+
+	return names
+}
+
+// FindDomain 은 구글 계정이 속한 허용 도메인을 찾는다. 워크스페이스 도메인(hd)을 먼저 확인한다.
+func (s GoogleWorkspaceSetting) FindDomain(googleMember GoogleMember) (GoogleWorkspaceDomain, bool) {
+	for _, domain := range s.Domains {
+		if len(googleMember.Hd) > 0 && strings.EqualFold(domain.Domain, googleMember.Hd) {
+			return domain, true
+		}
+	}
+
+	if s.VerifyHostedDomain == nil || *s.VerifyHostedDomain {
+		return GoogleWorkspaceDomain{}, false
+	}
+
+	at := strings.LastIndex(googleMember.Email, "@")
+	if at < 0 {
+		return GoogleWorkspaceDomain{}, false
+	}
+
+	for _, domain := range s.Domains {
+		if strings.EqualFold(domain.Domain, googleMember.Email[at+1:]) {
+			return domain, true
+		}
+	}
+
+	return GoogleWorkspaceDomain{}, false
 }
 
 type AppVersionSetting struct {
@@ -43,3 +115,234 @@ func NewAppVersionSetting() AppVersionSetting {
 		Version: 1,
 	}
 }
+
+type SessionLimitSetting struct {
+	MaxSessions   int                        `json:"maxSessions" binding:"min=0"`
+	ExceedAction  string                     `json:"exceedAction" binding:"required,oneof=block revoke-oldest"`
+	RoleOverrides []SessionLimitRoleOverride `json:"roleOverrides" binding:"dive"`
+}
+
+// RefreshTokenBindingSetting 은 세션을 시작한 클라이언트와 지문이 다른 클라이언트가 Refresh 토큰을 사용했을 때의 동작이다.
+// warn 은 알림만 보내고 Access 토큰을 발급하며, enforce 는 발급을 거부한다.
+type RefreshTokenBindingSetting struct {
+	Action string `json:"action" binding:"required,oneof=warn enforce"`
+}
+
+// LoginNotificationSetting 은 멤버가 처음 보는 기기나 위치(국가)에서 로그인했을 때 알릴지와 알릴 방법(mail, in-app)이다.
+type LoginNotificationSetting struct {
+	Enabled  bool     `json:"enabled"`
+	Channels []string `json:"channels" binding:"dive,oneof=mail in-app"`
+}
+
+func (s LoginNotificationSetting) HasChannel(channel string) bool {
+	for _, settingChannel := range s.Channels {
+		if settingChannel == channel {
+			return true
+		}
+	}
+
+	return false
+}
+
+type SessionLimitRoleOverride struct {
+	RoleName    string `json:"roleName" binding:"required"`
+	MaxSessions int    `json:"maxSessions" binding:"min=0"`
+}
+
+// GetMaxSessions 는 멤버에게 할당된 역할을 기준으로 허용되는 최대 세션 수를 반환한다.
+// 역할별 설정이 여러 개 해당되는 경우 가장 큰 값을 사용하며, 0 은 제한 없음을 의미한다.
+func (s SessionLimitSetting) GetMaxSessions(roleNames []string) int {
+	maxSessions := -1
+	for _, override := range s.RoleOverrides {
+		for _, roleName := range roleNames {
+			if override.RoleName != roleName {
+				continue
+			}
+
+			if override.MaxSessions == 0 {
+				return 0
+			}
+
+			if override.MaxSessions > maxSessions {
+				maxSessions = override.MaxSessions
+			}
+		}
+	}
+
+	if maxSessions < 0 {
+		return s.MaxSessions
+	}
+
+	return maxSessions
+}
+
+type TokenEpochSetting struct {
+	Epoch uint `json:"epoch"`
+}
+
+type JwtSecretSetting struct {
+	Secret string `json:"secret"`
+}
+
+// PendingSignUpSetting 은 승인되지 않은 가입 신청을 처리하는 기한(일)이다. 기한이 0 이면 해당 처리를 하지 않는다.
+type PendingSignUpSetting struct {
+	ApproverRoleName   string `json:"approverRoleName"`
+	ReminderDays       int    `json:"reminderDays" binding:"min=0"`
+	EscalationDays     int    `json:"escalationDays" binding:"min=0"`
+	EscalationRoleName string `json:"escalationRoleName"`
+	ExpiryDays         int    `json:"expiryDays" binding:"min=0"`
+}
+
+// DataMaskingSetting 은 UNMASK 권한이 없는 사용자에게 응답, 내보내기, 리포트의 값을 가려서 보여줄 필드와 방법이다.
+type DataMaskingSetting struct {
+	Policies []DataMaskingPolicy `json:"policies" binding:"dive"`
+}
+
+// DataMaskingPolicy 의 Field 는 DTO 의 mask 태그, 리포트의 컬럼 이름이다.
+// Method 는 phone(010-****-1234), email(ab***@example.com), name(홍*동), full(****) 중 하나이다.
+type DataMaskingPolicy struct {
+	Field  string `json:"field" binding:"required,oneof=name email phone"`
+	Method string `json:"method" binding:"required,oneof=phone email name full"`
+}
+
+// ConcurrencyLimitSetting 은 비용이 큰 경로(예. 내보내기, 가져오기, 리포트)가 동시에 처리하는 요청 수 제한이다.
+type ConcurrencyLimitSetting struct {
+	RouteGroups []ConcurrencyLimitRouteGroup `json:"routeGroups" binding:"dive"`
+}
+
+// ConcurrencyLimitRouteGroup 은 PathPrefixes(예. /api/reports) 로 시작하는 요청을 MaxConcurrent 개까지 동시에 처리한다.
+// 나머지는 MaxQueue 개까지 QueueTimeoutSeconds(0 이면 10초) 동안 기다리고, 대기열이 차거나 시간이 지나면 429 로 거절한다.
+type ConcurrencyLimitRouteGroup struct {
+	Name                string   `json:"name" binding:"required,max=50"`
+	PathPrefixes        []string `json:"pathPrefixes" binding:"required,min=1,dive,startswith=/"`
+	MaxConcurrent       int      `json:"maxConcurrent" binding:"required,min=1"`
+	MaxQueue            int      `json:"maxQueue" binding:"min=0"`
+	QueueTimeoutSeconds int      `json:"queueTimeoutSeconds" binding:"min=0"`
+	RetryAfterSeconds   int      `json:"retryAfterSeconds" binding:"min=0"`
+}
+
+// MaintenanceSetting 은 점검 모드 설정이다. Enabled 이거나 점검 일정(Windows) 중이면 점검 모드이다.
+type MaintenanceSetting struct {
+	Enabled           bool                `json:"enabled"`
+	Message           string              `json:"message"`
+	RetryAfterSeconds int                 `json:"retryAfterSeconds" binding:"min=0"`
+	Windows           []MaintenanceWindow `json:"windows" binding:"dive"`
+}
+
+// MaintenanceWindow 는 점검 일정이다. 시각은 RFC3339 형식이다.
+type MaintenanceWindow struct {
+	StartAt string `json:"startAt" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	EndAt   string `json:"endAt" binding:"required,datetime=2006-01-02T15:04:05Z07:00"`
+	Message string `json:"message"`
+}
+
+type MaintenanceStatus struct {
+	Active            bool                `json:"active"`
+	Message           string              `json:"message,omitempty"`
+	RetryAfterSeconds int                 `json:"retryAfterSeconds,omitempty"`
+	UpcomingWindows   []MaintenanceWindow `json:"upcomingWindows"`
+}
+
+// ReadOnlySetting 은 변경 요청(POST, PUT, PATCH, DELETE)을 막는 읽기 전용 모드이다.
+// Enabled 는 모든 사용자(예. 장애 대응 중 변경 동결)에, RoleNames 는 해당 역할의 사용자(예. 감사인)에 적용한다.
+type ReadOnlySetting struct {
+	Enabled   bool     `json:"enabled"`
+	RoleNames []string `json:"roleNames" binding:"dive,max=50"`
+	Message   string   `json:"message" binding:"max=200"`
+}
+
+// ReadOnlyStatus 는 요청한 사용자에게 적용되는 읽기 전용 모드이다. ByRole 은 역할로 적용되었는지 여부이다.
+type ReadOnlyStatus struct {
+	Active  bool
+	ByRole  bool
+	Message string
+}
+
+// LoginSetting 은 로그인 화면에 보여줄 로그인 방법이다. 화면에는 Methods 의 순서대로 보여준다.
+// DefaultRedirect 는 로그인 후 이동할 화면의 경로(예. /members)이다.
+type LoginSetting struct {
+	Methods         []LoginMethodSetting `json:"methods" binding:"required,dive"`
+	DefaultRedirect string               `json:"defaultRedirect" binding:"max=500"`
+}
+
+// LoginMethodSetting 의 Authenticator 는 saml, ldap, passkey 를 처리하는 외부 인증(RegisterAuthenticator)의 이름이다.
+type LoginMethodSetting struct {
+	Type          string `json:"type" binding:"required,oneof=password dooray google-workspace saml ldap passkey"`
+	Enabled       *bool  `json:"enabled" binding:"required"`
+	Label         string `json:"label" binding:"max=50"`
+	Authenticator string `json:"authenticator,omitempty" binding:"max=100"`
+}
+
+// LoginPage 는 로그인 화면에서 사용할 수 있는 로그인 방법이다. Endpoint 는 로그인을 요청할 API 이다.
+type LoginPage struct {
+	Methods         []LoginPageMethod `json:"methods"`
+	DefaultRedirect string            `json:"defaultRedirect,omitempty"`
+}
+
+type LoginPageMethod struct {
+	Type     string `json:"type"`
+	Label    string `json:"label"`
+	Endpoint string `json:"endpoint"`
+	OAuthUri string `json:"oauthUri,omitempty"`
+}
+
+// SiteSettingVersion 은 사이트 설정 스냅샷이다. ChangedKey 가 없으면 RolledBackFrom 버전으로 되돌린 스냅샷이다.
+type SiteSettingVersion struct {
+	Version        uint      `json:"version"`
+	ChangedKey     string    `json:"changedKey,omitempty"`
+	RolledBackFrom uint      `json:"rolledBackFrom,omitempty"`
+	CreatedBy      uint      `json:"createdBy"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type SiteSettingVersionDetails struct {
+	SiteSettingVersion
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// SettingValidation 은 설정을 적용하기 전에 확인한 결과이다. 하나라도 실패하면 Valid 는 false 이다.
+type SettingValidation struct {
+	Valid   bool                      `json:"valid"`
+	Results []SettingValidationResult `json:"results"`
+}
+
+// AddResult 는 확인 결과를 추가한다. err 가 있으면 실패로 기록한다.
+func (v *SettingValidation) AddResult(name string, err error) {
+	result := SettingValidationResult{Name: name, Passed: err == nil}
+	if err != nil {
+		v.Valid = false
+		result.Message = err.Error()
+	}
+	v.Results = append(v.Results, result)
+}
+
+type SettingValidationResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+// PasswordBreachCheckSetting 은 비밀번호를 정할 때 유출된 비밀번호인지 확인하는 설정이다.
+// online 은 range API 에 SHA-1 의 앞 5자리만 보내고, offline 은 bloom filter 파일로 확인한다.
+// reject 는 유출된 비밀번호를 거절하고, warn 은 저장하고 경고 코드만 응답한다.
+type PasswordBreachCheckSetting struct {
+	Mode   string `json:"mode" binding:"required,oneof=off online offline"`
+	Action string `json:"action" binding:"required,oneof=warn reject"`
+}
+
+// PasswordBreachCheckResult 는 유출 확인 결과이다. offline 은 횟수를 알 수 없어 Count 가 0 이다.
+type PasswordBreachCheckResult struct {
+	Breached bool `json:"breached"`
+	Count    int  `json:"count"`
+}
+
+// SuperAdminProtectionSetting 은 최고 관리자 역할(SYSTEM MANAGER)을 가진 승인된 멤버가 최소 몇 명 있어야 하는지이다.
+type SuperAdminProtectionSetting struct {
+	MinimumCount int `json:"minimumCount" binding:"min=1"`
+}
+
+// FileQuotaSetting 은 파일 저장 한도(byte)이다. MemberQuotaBytes 는 멤버가 올린 파일의 합, TotalQuotaBytes 는 사이트 전체 파일의 합이며 0 이면 제한하지 않는다.
+type FileQuotaSetting struct {
+	MemberQuotaBytes int64 `json:"memberQuotaBytes" binding:"min=0"`
+	TotalQuotaBytes  int64 `json:"totalQuotaBytes" binding:"min=0"`
+}
@@ -0,0 +1,33 @@
+package dtos
+
+import "time"
+
+// UsageStatisticsQuery 는 사용 통계 조회 조건이다. 날짜는 yyyy-MM-dd 형식이며 주간 통계는 월요일 날짜로 조회한다.
+type UsageStatisticsQuery struct {
+	Period         string `form:"period" binding:"omitempty,oneof=daily weekly"`
+	From           string `form:"from" binding:"required,datetime=2006-01-02"`
+	To             string `form:"to" binding:"required,datetime=2006-01-02"`
+	OrganizationId uint   `form:"organizationId"`
+	Breakdown      string `form:"breakdown" binding:"omitempty,oneof=organization"`
+}
+
+type UsageStatistic struct {
+	Date           string `json:"date"`
+	OrganizationId uint   `json:"organizationId"`
+	Dimension      string `json:"dimension,omitempty"`
+	Value          int64  `json:"value"`
+}
+
+type AccessLog struct {
+	Id              uint      `json:"id"`
+	Method          string    `json:"method"`
+	MemberId        uint      `json:"memberId"`
+	Succeeded       bool      `json:"succeeded"`
+	FailureReason   string    `json:"failureReason,omitempty"`
+	IpAddress       string    `json:"ipAddress"`
+	Country         string    `json:"country"`
+	City            string    `json:"city"`
+	Asn             uint      `json:"asn"`
+	AsnOrganization string    `json:"asnOrganization"`
+	AttemptedAt     time.Time `json:"attemptedAt"`
+}
@@ -0,0 +1,47 @@
+package dtos
+
+import "time"
+
+// ServiceStatus 는 로그인하지 않은 프론트엔드와 모니터링이 보는 서비스 상태이다. 점검 항목의 상세 결과는 포함하지 않는다.
+type ServiceStatus struct {
+	Status      string               `json:"status"`
+	Build       BuildInfo            `json:"build"`
+	Banners     []AnnouncementBanner `json:"banners"`
+	Maintenance MaintenanceStatus    `json:"maintenance"`
+	CheckedAt   time.Time            `json:"checkedAt"`
+}
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit,omitempty"`
+	BuildTime string `json:"buildTime,omitempty"`
+}
+
+// VersionInfo 는 GET /system/version 의 응답이다.
+type VersionInfo struct {
+	BuildInfo
+	MinFrontendVersion string `json:"minFrontendVersion,omitempty"`
+}
+
+// UpgradeRequired 는 프론트엔드 버전이 MinFrontendVersion 보다 낮을 때의 응답(426)이다.
+type UpgradeRequired struct {
+	Code               string `json:"code"`
+	Message            string `json:"message"`
+	FrontendVersion    string `json:"frontendVersion"`
+	MinFrontendVersion string `json:"minFrontendVersion"`
+	BackendVersion     string `json:"backendVersion"`
+}
+
+type AnnouncementBannerSetting struct {
+	Banners []AnnouncementBanner `json:"banners" binding:"max=20,dive"`
+}
+
+// AnnouncementBanner 는 화면 상단에 보여줄 공지이다. StartAt, EndAt(RFC3339)이 비어 있으면 기간 제한 없이 보여준다.
+type AnnouncementBanner struct {
+	Id      string `json:"id" binding:"required,max=50"`
+	Message string `json:"message" binding:"required,max=500"`
+	Level   string `json:"level" binding:"required,oneof=info warning critical"`
+	Link    string `json:"link,omitempty" binding:"omitempty,url"`
+	StartAt string `json:"startAt,omitempty" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	EndAt   string `json:"endAt,omitempty" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
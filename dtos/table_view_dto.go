@@ -0,0 +1,26 @@
+package dtos
+
+import "time"
+
+// TableViewInformation 은 목록 화면(Table)의 이름 붙인 보기 설정(컬럼, 검색 조건, 정렬, 페이지 크기)이다.
+// RoleId 가 있으면 역할의 멤버에게 공유하고, 없으면 만든 멤버만 사용한다. Table 과 RoleId 는 만든 뒤 바꿀 수 없다.
+type TableViewInformation struct {
+	Id    uint   `json:"id"`
+	Table string `json:"table" binding:"required,oneof=members audit-logs roles"`
+	Name  string `json:"name" binding:"required,max=100"`
+	// Columns 는 화면에 보여줄 컬럼을 순서대로 나열한 것이다.
+	Columns []string `json:"columns" binding:"max=100,dive,required,max=50"`
+	// Filters 는 목록 조회 API 의 query 파라미터와 값이다.(예. status=approved)
+	Filters   map[string]string `json:"filters" binding:"max=30,dive,keys,required,max=50,endkeys,max=500"`
+	Sort      TableViewSort     `json:"sort"`
+	PageSize  int               `json:"pageSize" binding:"min=0,max=1000"`
+	RoleId    uint              `json:"roleId"`
+	MemberId  uint              `json:"memberId"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+type TableViewSort struct {
+	Field string `json:"field" binding:"max=50"`
+	Order string `json:"order" binding:"omitempty,oneof=asc desc"`
+}
@@ -10,12 +10,14 @@ type WebHookInformation struct {
 	Id          uint   `json:"id"`
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	OwnerId     uint   `json:"ownerId"`
 }
 
 type WebHookDetails struct {
 	Id              uint            `json:"id"`
 	Name            string          `json:"name"`
 	Description     string          `json:"description"`
+	OwnerId         uint            `json:"ownerId"`
 	WebHookCallSpec WebHookCallSpec `json:"webHookCallSpec"`
 }
 
@@ -43,3 +45,11 @@ type WebHookMessage struct {
 	Title string `json:"title"`
 	Text  string `json:"text" binding:"required"`
 }
+
+// OutgoingWebHookRequest 는 외부 시스템으로 보내는 웹훅 요청이다.
+type OutgoingWebHookRequest struct {
+	Url         string
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+}
@@ -0,0 +1,155 @@
+package errors
+
+import (
+	stderrors "errors"
+	"sort"
+	"sync"
+)
+
+// 필드가 있는 오류(구조체)의 코드이다.
+const (
+	codeInvalidGoogleWorkspaceAccount = "INVALID_GOOGLE_WORKSPACE_ACCOUNT"
+	codePreAuthDenied                 = "PRE_AUTH_DENIED"
+	codeInvalidPreference             = "INVALID_PREFERENCE"
+	codeInvalidReport                 = "INVALID_REPORT"
+	codeInvalidFile                   = "INVALID_FILE"
+	codeInvalidOrganization           = "INVALID_ORGANIZATION"
+	codeInvalidPermissionCatalog      = "INVALID_PERMISSION_CATALOG"
+	codeInvalidLoginSetting           = "INVALID_LOGIN_SETTING"
+	codeInvalidPluginSetting          = "INVALID_PLUGIN_SETTING"
+	codeInvalidMemberAssignmentRule   = "INVALID_MEMBER_ASSIGNMENT_RULE"
+	codeInvalidGoogleWorkspaceSetting = "INVALID_GOOGLE_WORKSPACE_SETTING"
+	codeInvalidAuthorizationRequest   = "INVALID_AUTHORIZATION_REQUEST"
+	codeInvalidChangeRequest          = "INVALID_CHANGE_REQUEST"
+	codeInvalidNote                   = "INVALID_NOTE"
+	codeInvalidAutoApprovalRule       = "INVALID_AUTO_APPROVAL_RULE"
+	codeInvalidDeprovisioning         = "INVALID_DEPROVISIONING"
+	codeInvalidOwnershipTransfer      = "INVALID_OWNERSHIP_TRANSFER"
+	codeInvalidMemberFieldChange      = "INVALID_MEMBER_FIELD_CHANGE"
+	codeInvalidRetention              = "INVALID_RETENTION"
+	codeInvalidGroupRoleMapping       = "INVALID_GROUP_ROLE_MAPPING"
+	codeInvalidRoleElevation          = "INVALID_ROLE_ELEVATION"
+	codeInvalidPermissionSimulation   = "INVALID_PERMISSION_SIMULATION"
+	codeInvalidTableView              = "INVALID_TABLE_VIEW"
+	codeInvalidLocaleBundle           = "INVALID_LOCALE_BUNDLE"
+	codeInvalidMessageTemplate        = "INVALID_MESSAGE_TEMPLATE"
+	codeInvalidOrganizationSetting    = "INVALID_ORGANIZATION_SETTING"
+	codePasswordPolicyViolated        = "PASSWORD_POLICY_VIOLATED"
+	codeInvalidLicense                = "INVALID_LICENSE"
+	codeLicenseQuotaExceeded          = "LICENSE_QUOTA_EXCEEDED"
+)
+
+// CodedError 는 기계가 읽을 수 있는 고정 코드(Code)가 있는 오류이다. 프론트엔드가 코드로 오류를 구분하므로 한 번 정한 코드는 바꾸지 않는다.
+// 감싼 오류와 하위 오류(예. ErrMemberNotFound)도 같은 오류로 비교되도록 errors.Is(err, ErrNotFound) 처럼 비교한다.
+type CodedError struct {
+	Code    string
+	Message string
+	parent  *CodedError
+}
+
+func (e *CodedError) Error() string { return e.Message }
+
+func (e *CodedError) ErrorCode() string { return e.Code }
+
+// Is 는 상위 오류(예. ErrMemberNotFound 의 ErrNotFound)와 같은 오류로 비교되게 한다.
+func (e *CodedError) Is(target error) bool {
+	for parent := e.parent; parent != nil; parent = parent.parent {
+		if parent == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Is 는 err(감싼 오류, 하위 오류 포함)가 target 과 같은 오류인지 비교한다(표준 errors.Is).
+func Is(err, target error) bool {
+	return stderrors.Is(err, target)
+}
+
+// ErrorCodeInfo 는 오류 코드 목록(GET /api/system/error-codes)의 항목이다.
+type ErrorCodeInfo struct {
+	Code    string
+	Message string
+}
+
+var (
+	errorCodeMutex sync.Mutex
+	errorCodes     = map[string]ErrorCodeInfo{
+		codeInvalidGoogleWorkspaceAccount: {codeInvalidGoogleWorkspaceAccount, "not allowed google workspace domain"},
+		codePreAuthDenied:                 {codePreAuthDenied, "pre auth denied"},
+		codeInvalidPreference:             {codeInvalidPreference, "invalid preference"},
+		codeInvalidReport:                 {codeInvalidReport, "invalid report"},
+		codeInvalidFile:                   {codeInvalidFile, "invalid file"},
+		codeInvalidOrganization:           {codeInvalidOrganization, "invalid organization"},
+		codeInvalidPermissionCatalog:      {codeInvalidPermissionCatalog, "invalid permission catalog"},
+		codeInvalidLoginSetting:           {codeInvalidLoginSetting, "invalid login setting"},
+		codeInvalidPluginSetting:          {codeInvalidPluginSetting, "invalid plugin setting"},
+		codeInvalidMemberAssignmentRule:   {codeInvalidMemberAssignmentRule, "invalid member assignment rule"},
+		codeInvalidGoogleWorkspaceSetting: {codeInvalidGoogleWorkspaceSetting, "invalid google workspace setting"},
+		codeInvalidAuthorizationRequest:   {codeInvalidAuthorizationRequest, "invalid authorization request"},
+		codeInvalidChangeRequest:          {codeInvalidChangeRequest, "invalid change request"},
+		codeInvalidNote:                   {codeInvalidNote, "invalid note"},
+		codeInvalidAutoApprovalRule:       {codeInvalidAutoApprovalRule, "invalid auto approval rule"},
+		codeInvalidDeprovisioning:         {codeInvalidDeprovisioning, "invalid deprovisioning"},
+		codeInvalidOwnershipTransfer:      {codeInvalidOwnershipTransfer, "invalid ownership transfer"},
+		codeInvalidMemberFieldChange:      {codeInvalidMemberFieldChange, "invalid member field change"},
+		codeInvalidRetention:              {codeInvalidRetention, "invalid retention"},
+		codeInvalidGroupRoleMapping:       {codeInvalidGroupRoleMapping, "invalid group role mapping"},
+		codeInvalidRoleElevation:          {codeInvalidRoleElevation, "invalid role elevation"},
+		codeInvalidPermissionSimulation:   {codeInvalidPermissionSimulation, "invalid permission simulation"},
+		codeInvalidTableView:              {codeInvalidTableView, "invalid table view"},
+		codeInvalidLocaleBundle:           {codeInvalidLocaleBundle, "invalid locale bundle"},
+		codeInvalidMessageTemplate:        {codeInvalidMessageTemplate, "invalid message template"},
+		codeInvalidOrganizationSetting:    {codeInvalidOrganizationSetting, "invalid organization setting"},
+		codePasswordPolicyViolated:        {codePasswordPolicyViolated, "password policy violated"},
+		codeInvalidLicense:                {codeInvalidLicense, "invalid license"},
+		codeLicenseQuotaExceeded:          {codeLicenseQuotaExceeded, "license quota exceeded"},
+	}
+)
+
+func newCodedError(code string, message string) *CodedError {
+	return newCodedErrorOf(nil, code, message)
+}
+
+func newCodedErrorOf(parent *CodedError, code string, message string) *CodedError {
+	errorCodeMutex.Lock()
+	defer errorCodeMutex.Unlock()
+
+	errorCodes[code] = ErrorCodeInfo{Code: code, Message: message}
+	return &CodedError{Code: code, Message: message, parent: parent}
+}
+
+// Code 는 오류(감싼 오류 포함)의 코드를 찾는다. 코드가 있는 오류가 아니면 빈 문자열이다.
+func Code(err error) string {
+	for err != nil {
+		if coded, ok := err.(interface{ ErrorCode() string }); ok {
+			return coded.ErrorCode()
+		}
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		case interface{ Cause() error }:
+			err = e.Cause()
+		default:
+			return ""
+		}
+	}
+
+	return ""
+}
+
+// GetErrorCodes 는 모든 오류 코드를 코드 순으로 반환한다.
+func GetErrorCodes() []ErrorCodeInfo {
+	errorCodeMutex.Lock()
+	defer errorCodeMutex.Unlock()
+
+	infos := make([]ErrorCodeInfo, 0, len(errorCodes))
+	for _, info := range errorCodes {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Code < infos[j].Code })
+
+	return infos
+}
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	stderrors "errors"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, "NOT_FOUND", Code(ErrNotFound))
+	assert.Equal(t, "MEMBER_NOT_FOUND", Code(pkgerrors.Wrap(ErrMemberNotFound, "member 1")))
+	assert.Equal(t, codePreAuthDenied, Code(&ErrPreAuthDenied{Reason: "퇴사자"}))
+	assert.Equal(t, "", Code(stderrors.New("unknown")))
+}
+
+func TestCodedError_Is(t *testing.T) {
+	assert.True(t, stderrors.Is(ErrMemberNotFound, ErrNotFound))
+	assert.True(t, stderrors.Is(pkgerrors.WithStack(ErrMemberNotFound), ErrNotFound))
+	assert.False(t, stderrors.Is(ErrNotFound, ErrMemberNotFound))
+	assert.False(t, stderrors.Is(ErrMemberNotFound, ErrAuthentication))
+}
+
+func TestGetErrorCodes(t *testing.T) {
+	errorCodes := GetErrorCodes()
+
+	codes := map[string]bool{}
+	for i, info := range errorCodes {
+		if i > 0 {
+			assert.True(t, errorCodes[i-1].Code < info.Code)
+		}
+		codes[info.Code] = true
+	}
+	assert.True(t, codes["MEMBER_NOT_FOUND"])
+	assert.True(t, codes[codePreAuthDenied])
+}
@@ -1,19 +1,296 @@
 package errors
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
+	"strings"
+)
 
 var (
-	ErrNotFound                  = errors.New("not found")
-	ErrAuthentication            = errors.New("error authentication")
-	ErrDuplicated                = errors.New("duplicated")
-	ErrNonChangeable             = errors.New("non changeable")
-	ErrAlreadyApproved           = errors.New("already approved")
-	ErrUnApproved                = errors.New("unapproved")
-	ErrNotSupportedAccessLogType = errors.New("not supported access log type")
+	ErrNotFound                  = newCodedError("NOT_FOUND", "not found")
+	ErrAuthentication            = newCodedError("AUTH_FAILED", "error authentication")
+	ErrDuplicated                = newCodedError("DUPLICATED", "duplicated")
+	ErrNonChangeable             = newCodedError("NON_CHANGEABLE", "non changeable")
+	ErrAlreadyApproved           = newCodedError("ALREADY_APPROVED", "already approved")
+	ErrUnApproved                = newCodedError("MEMBER_UNAPPROVED", "unapproved")
+	ErrNotSupportedAccessLogType = newCodedError("NOT_SUPPORTED_ACCESS_LOG_TYPE", "not supported access log type")
+	ErrSessionLimitExceeded      = newCodedError("SESSION_LIMIT_EXCEEDED", "session limit exceeded")
+	ErrSessionRevoked            = newCodedError("SESSION_REVOKED", "session revoked")
+	ErrClientMismatched          = newCodedError("CLIENT_MISMATCHED", "client mismatched")
+	ErrInvalidScope              = newCodedError("INVALID_SCOPE", "invalid scope")
+	ErrInvalidTarget             = newCodedError("INVALID_TARGET", "invalid target")
+	ErrInvalidSubjectToken       = newCodedError("INVALID_SUBJECT_TOKEN", "invalid subject token")
+	ErrExpired                   = newCodedError("EXPIRED", "expired")
+	ErrForbidden                 = newCodedError("FORBIDDEN", "forbidden")
+	ErrApprovalInProgress        = newCodedError("APPROVAL_IN_PROGRESS", "approval in progress")
+	ErrInvalidPeriod             = newCodedError("INVALID_PERIOD", "invalid period")
+	ErrQuarantined               = newCodedError("QUARANTINED", "quarantined")
+	ErrApprovalRequired          = newCodedError("APPROVAL_REQUIRED", "approval required")
+	ErrInvalidSignature          = newCodedError("INVALID_SIGNATURE", "invalid signature")
+	ErrInvalidGrant              = newCodedError("INVALID_GRANT", "invalid grant")
+	ErrConsentRequired           = newCodedError("CONSENT_REQUIRED", "consent required")
+	ErrAuthorizationPending      = newCodedError("AUTHORIZATION_PENDING", "authorization pending")
+	ErrSlowDown                  = newCodedError("SLOW_DOWN", "slow down")
+	ErrAccessDenied              = newCodedError("ACCESS_DENIED", "access denied")
+	ErrCircuitOpen               = newCodedError("CIRCUIT_OPEN", "circuit open")
+	// ErrMemberNotFound 는 ErrNotFound 와 같은 오류로 비교(errors.Is)되며 멤버를 찾지 못한 경우를 구분하는 코드만 다르다.
+	ErrMemberNotFound = newCodedErrorOf(ErrNotFound, "MEMBER_NOT_FOUND", "member not found")
+	// ErrSelfReview 는 ErrForbidden 과 같은 오류로 비교되며 변경 요청을 작성한 관리자가 직접 승인/반려하려는 경우이다.
+	ErrSelfReview = newCodedErrorOf(ErrForbidden, "SELF_REVIEW", "self review is not allowed")
+
+	// 응답할 오류를 알 수 없을 때 HTTP 상태 코드로 정하는 기본 코드이다.
+	ErrBadRequest          = newCodedError("BAD_REQUEST", "bad request")
+	ErrUnauthorized        = newCodedError("UNAUTHORIZED", "unauthorized")
+	ErrNotAcceptable       = newCodedError("NOT_ACCEPTABLE", "not acceptable")
+	ErrConflict            = newCodedError("CONFLICT", "conflict")
+	ErrRequestTooLarge     = newCodedError("REQUEST_TOO_LARGE", "request body too large")
+	ErrTooManyRequests     = newCodedError("TOO_MANY_REQUESTS", "too many requests")
+	ErrInternal            = newCodedError("INTERNAL_ERROR", "internal error")
+	ErrServiceUnavailable  = newCodedError("SERVICE_UNAVAILABLE", "service unavailable")
+	ErrGatewayTimeout      = newCodedError("GATEWAY_TIMEOUT", "request timeout")
+	ErrUpgradeRequired     = newCodedError("UPGRADE_REQUIRED", "frontend upgrade required")
+	ErrChaosDisabled       = newCodedError("CHAOS_DISABLED", "fault injection is disabled")
+	ErrChaosInjected       = newCodedError("CHAOS_INJECTED", "injected fault")
+	ErrBreachedPassword    = newCodedError("BREACHED_PASSWORD", "password found in data breach")
+	ErrAlreadyAcknowledged = newCodedError("ALREADY_ACKNOWLEDGED", "already acknowledged")
+	ErrPasswordResetNeeded = newCodedError("PASSWORD_RESET_REQUIRED", "password reset required")
+	ErrReadOnly            = newCodedError("READ_ONLY", "read only")
+	ErrStepUpRequired      = newCodedError("STEP_UP_REQUIRED", "step-up authentication required")
+	ErrSuperAdminMinimum   = newCodedError("SUPER_ADMIN_MINIMUM", "super admin count below minimum")
+	ErrInsufficientQuota   = newCodedError("INSUFFICIENT_QUOTA", "insufficient storage quota")
+	ErrLegalHold           = newCodedError("LEGAL_HOLD", "member is on legal hold")
+
+	// 멤버가 속한 조직에서 허용하지 않는 로그인 방법이다.
+	ErrLoginMethodNotAllowed = newCodedError("LOGIN_METHOD_NOT_ALLOWED", "login method not allowed")
 )
 
+// ErrInvalidGoogleWorkspaceAccount 는 허용된 도메인(Domains)의 계정이 아닌 경우이다.
 type ErrInvalidGoogleWorkspaceAccount struct {
-	Domain string
+	Domains []string
+}
+
+func (e *ErrInvalidGoogleWorkspaceAccount) Error() string { return strings.Join(e.Domains, ", ") }
+func (e *ErrInvalidGoogleWorkspaceAccount) ErrorCode() string {
+	return codeInvalidGoogleWorkspaceAccount
+}
+
+// ErrPreAuthDenied 는 외부 시스템(PreAuthHook)이 로그인을 거부한 경우이다.
+type ErrPreAuthDenied struct {
+	Reason string
+}
+
+func (e *ErrPreAuthDenied) Error() string     { return "pre auth denied: " + e.Reason }
+func (e *ErrPreAuthDenied) ErrorCode() string { return codePreAuthDenied }
+
+type ErrInvalidPreference struct {
+	Namespace string
+	Reason    string
+}
+
+func (e *ErrInvalidPreference) Error() string     { return e.Namespace + ": " + e.Reason }
+func (e *ErrInvalidPreference) ErrorCode() string { return codeInvalidPreference }
+
+type ErrInvalidReport struct {
+	Reason string
+}
+
+func (e *ErrInvalidReport) Error() string     { return e.Reason }
+func (e *ErrInvalidReport) ErrorCode() string { return codeInvalidReport }
+
+type ErrInvalidFile struct {
+	Reason string
+}
+
+func (e *ErrInvalidFile) Error() string     { return e.Reason }
+func (e *ErrInvalidFile) ErrorCode() string { return codeInvalidFile }
+
+type ErrInvalidOrganization struct {
+	Reason string
+}
+
+func (e *ErrInvalidOrganization) Error() string     { return e.Reason }
+func (e *ErrInvalidOrganization) ErrorCode() string { return codeInvalidOrganization }
+
+type ErrInvalidPermissionCatalog struct {
+	Reason string
+}
+
+func (e *ErrInvalidPermissionCatalog) Error() string     { return e.Reason }
+func (e *ErrInvalidPermissionCatalog) ErrorCode() string { return codeInvalidPermissionCatalog }
+
+type ErrInvalidLoginSetting struct {
+	Reason string
+}
+
+func (e *ErrInvalidLoginSetting) Error() string     { return e.Reason }
+func (e *ErrInvalidLoginSetting) ErrorCode() string { return codeInvalidLoginSetting }
+
+type ErrInvalidPluginSetting struct {
+	Namespace string
+	Reason    string
+}
+
+func (e *ErrInvalidPluginSetting) Error() string     { return e.Namespace + ": " + e.Reason }
+func (e *ErrInvalidPluginSetting) ErrorCode() string { return codeInvalidPluginSetting }
+
+type ErrInvalidMemberAssignmentRule struct {
+	Reason string
+}
+
+func (e *ErrInvalidMemberAssignmentRule) Error() string     { return e.Reason }
+func (e *ErrInvalidMemberAssignmentRule) ErrorCode() string { return codeInvalidMemberAssignmentRule }
+
+type ErrInvalidGoogleWorkspaceSetting struct {
+	Reason string
+}
+
+func (e *ErrInvalidGoogleWorkspaceSetting) Error() string { return e.Reason }
+func (e *ErrInvalidGoogleWorkspaceSetting) ErrorCode() string {
+	return codeInvalidGoogleWorkspaceSetting
+}
+
+// ErrInvalidAuthorizationRequest 는 인가 요청의 redirect_uri 나 PKCE(code_challenge) 가 Client 설정에 맞지 않는 경우이다.
+type ErrInvalidAuthorizationRequest struct {
+	Reason string
+}
+
+func (e *ErrInvalidAuthorizationRequest) Error() string     { return e.Reason }
+func (e *ErrInvalidAuthorizationRequest) ErrorCode() string { return codeInvalidAuthorizationRequest }
+
+// ErrInvalidChangeRequest 는 변경 요청의 변경 항목(설정 key, 역할, 값)이 올바르지 않은 경우이다.
+type ErrInvalidChangeRequest struct {
+	Reason string
+}
+
+func (e *ErrInvalidChangeRequest) Error() string     { return e.Reason }
+func (e *ErrInvalidChangeRequest) ErrorCode() string { return codeInvalidChangeRequest }
+
+// ErrInvalidNote 는 답글을 달 메모가 없거나 언급한 멤버가 너무 많은 경우이다.
+type ErrInvalidNote struct {
+	Reason string
+}
+
+func (e *ErrInvalidNote) Error() string     { return e.Reason }
+func (e *ErrInvalidNote) ErrorCode() string { return codeInvalidNote }
+
+// ErrInvalidAutoApprovalRule 은 자동 승인 규칙의 도메인이 올바르지 않거나 중복되었거나 기본 역할이 없는 경우이다.
+type ErrInvalidAutoApprovalRule struct {
+	Reason string
+}
+
+func (e *ErrInvalidAutoApprovalRule) Error() string     { return e.Reason }
+func (e *ErrInvalidAutoApprovalRule) ErrorCode() string { return codeInvalidAutoApprovalRule }
+
+// ErrInvalidDeprovisioning 은 이미 해지한 멤버이거나 후임자가 승인된 다른 멤버가 아닌 경우이다.
+type ErrInvalidDeprovisioning struct {
+	Reason string
+}
+
+func (e *ErrInvalidDeprovisioning) Error() string     { return e.Reason }
+func (e *ErrInvalidDeprovisioning) ErrorCode() string { return codeInvalidDeprovisioning }
+
+// ErrInvalidOwnershipTransfer 는 새 소유자가 승인된 멤버가 아니거나 지원하지 않는 리소스 유형인 경우이다.
+type ErrInvalidOwnershipTransfer struct {
+	Reason string
+}
+
+func (e *ErrInvalidOwnershipTransfer) Error() string     { return e.Reason }
+func (e *ErrInvalidOwnershipTransfer) ErrorCode() string { return codeInvalidOwnershipTransfer }
+
+// ErrInvalidMemberFieldChange 는 변경할 값이 올바르지 않거나 지금 값과 같은 경우, 변경할 수 없는 멤버 유형인 경우이다.
+type ErrInvalidMemberFieldChange struct {
+	Reason string
+}
+
+func (e *ErrInvalidMemberFieldChange) Error() string     { return e.Reason }
+func (e *ErrInvalidMemberFieldChange) ErrorCode() string { return codeInvalidMemberFieldChange }
+
+// ErrInvalidRetention 은 보관 기간을 정하지 않은 데이터 종류이거나 법적 보존(legal hold)의 대상과 기간이 올바르지 않은 경우이다.
+type ErrInvalidRetention struct {
+	Reason string
+}
+
+func (e *ErrInvalidRetention) Error() string     { return e.Reason }
+func (e *ErrInvalidRetention) ErrorCode() string { return codeInvalidRetention }
+
+// ErrInvalidGroupRoleMapping 은 그룹-역할 매핑의 인증 방식이나 역할이 올바르지 않은 경우이다.
+type ErrInvalidGroupRoleMapping struct {
+	Reason string
+}
+
+func (e *ErrInvalidGroupRoleMapping) Error() string     { return e.Reason }
+func (e *ErrInvalidGroupRoleMapping) ErrorCode() string { return codeInvalidGroupRoleMapping }
+
+// ErrInvalidRoleElevation 은 임시 권한 상승을 요청하거나 승인할 수 없는 경우(역할, 시간, 승인 절차)이다.
+type ErrInvalidRoleElevation struct {
+	Reason string
+}
+
+func (e *ErrInvalidRoleElevation) Error() string     { return e.Reason }
+func (e *ErrInvalidRoleElevation) ErrorCode() string { return codeInvalidRoleElevation }
+
+// ErrInvalidPermissionSimulation 은 역할에 없는 권한을 제거하는 시뮬레이션이다.
+type ErrInvalidPermissionSimulation struct {
+	Reason string
 }
 
-func (e *ErrInvalidGoogleWorkspaceAccount) Error() string { return e.Domain }
+func (e *ErrInvalidPermissionSimulation) Error() string     { return e.Reason }
+func (e *ErrInvalidPermissionSimulation) ErrorCode() string { return codeInvalidPermissionSimulation }
+
+// ErrInvalidTableView 는 저장할 수 없는 목록 보기 설정(공유할 역할, 개수)이다.
+type ErrInvalidTableView struct {
+	Reason string
+}
+
+func (e *ErrInvalidTableView) Error() string     { return e.Reason }
+func (e *ErrInvalidTableView) ErrorCode() string { return codeInvalidTableView }
+
+// ErrInvalidLocaleBundle 은 저장할 수 없는 로케일 번들(로케일 형식, 문구 수, 키와 값의 길이)이다.
+type ErrInvalidLocaleBundle struct {
+	Reason string
+}
+
+func (e *ErrInvalidLocaleBundle) Error() string     { return e.Reason }
+func (e *ErrInvalidLocaleBundle) ErrorCode() string { return codeInvalidLocaleBundle }
+
+// ErrInvalidMessageTemplate 은 렌더링할 수 없는 메일 템플릿(문법 오류, 지원하지 않는 문법, 정의되지 않은 변수)이다.
+type ErrInvalidMessageTemplate struct {
+	Reason string
+}
+
+func (e *ErrInvalidMessageTemplate) Error() string     { return e.Reason }
+func (e *ErrInvalidMessageTemplate) ErrorCode() string { return codeInvalidMessageTemplate }
+
+// ErrInvalidOrganizationSetting 은 저장할 수 없는 조직별 설정(비밀번호 정책, 세션 유지 시간, 로그인 방법)이다.
+type ErrInvalidOrganizationSetting struct {
+	Reason string
+}
+
+func (e *ErrInvalidOrganizationSetting) Error() string     { return e.Reason }
+func (e *ErrInvalidOrganizationSetting) ErrorCode() string { return codeInvalidOrganizationSetting }
+
+// ErrPasswordPolicyViolated 는 비밀번호 정책(길이, 문자 종류)에 맞지 않는 비밀번호이다.
+type ErrPasswordPolicyViolated struct {
+	Reason string
+}
+
+func (e *ErrPasswordPolicyViolated) Error() string     { return e.Reason }
+func (e *ErrPasswordPolicyViolated) ErrorCode() string { return codePasswordPolicyViolated }
+
+// ErrInvalidLicense 는 읽을 수 없거나(서명 오류) 만료된 라이선스이다. 라이선스를 갱신할 때까지 멤버, 역할, API Key 를 만들 수 없다.
+type ErrInvalidLicense struct {
+	Reason string
+}
+
+func (e *ErrInvalidLicense) Error() string     { return e.Reason }
+func (e *ErrInvalidLicense) ErrorCode() string { return codeInvalidLicense }
+
+// ErrLicenseQuotaExceeded 는 라이선스의 최대 수(Limit)를 넘어 Resource(constants.LicenseResource*)를 만들 수 없다.
+type ErrLicenseQuotaExceeded struct {
+	Resource string
+	Limit    int64
+}
+
+func (e *ErrLicenseQuotaExceeded) Error() string {
+	return fmt.Sprintf("license quota exceeded: %s (limit %d)", e.Resource, e.Limit)
+}
+func (e *ErrLicenseQuotaExceeded) ErrorCode() string { return codeLicenseQuotaExceeded }
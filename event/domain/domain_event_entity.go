@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 발행을 다시 시도할 때 기다리는 최대 시간
+const maxDomainEventRetryDelay = time.Hour
+
+// DomainEventEntity 는 트랜잭션 아웃박스이다. 도메인 변경과 같은 트랜잭션에서 기록하고, 커밋된 뒤 스케줄러가 메시지 브로커로 발행한다.
+type DomainEventEntity struct {
+	gorm.Model
+	EventId       string `gorm:"type:varchar(32);uniqueIndex;not null"`
+	Type          string `gorm:"type:varchar(100);not null"`
+	SchemaVersion int
+	AggregateType string `gorm:"type:varchar(50);not null"`
+	AggregateId   uint
+	// Payload 는 발행할 dtos.DomainEventEnvelope 의 JSON 이다.
+	Payload       string    `gorm:"type:text"`
+	Status        string    `gorm:"type:varchar(20);not null;index"`
+	Attempts      int       `gorm:"not null"`
+	NextAttemptAt time.Time `gorm:"index"`
+	PublishedAt   *time.Time
+	LastError     string `gorm:"type:varchar(1000)"`
+}
+
+func (DomainEventEntity) TableName() string {
+	return "domain_events"
+}
+
+func NewDomainEventEntity(ctx context.Context, eventType, aggregateType string, aggregateId uint, data interface{}) (DomainEventEntity, error) {
+	eventId, err := newDomainEventId()
+	if err != nil {
+		return DomainEventEntity{}, err
+	}
+
+	now := time.Now()
+	envelope := dtos.DomainEventEnvelope{
+		Id:            eventId,
+		Type:          eventType,
+		SchemaVersion: constants.DomainEventSchemaVersion,
+		Source:        constants.DomainEventSource,
+		OccurredAt:    now,
+		Actor:         dtos.DomainEventActor{Type: constants.AuditActorTypeSystem},
+		Data:          data,
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err == nil {
+		envelope.Actor.Type = constants.AuditActorTypeMember
+		if userClaim.IsServiceAccount() {
+			envelope.Actor.Type = constants.AuditActorTypeServiceAccount
+		}
+		envelope.Actor.Id = userClaim.Id
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return DomainEventEntity{}, pkgerrors.Wrap(err, "domain event encode error")
+	}
+
+	return DomainEventEntity{
+		EventId:       eventId,
+		Type:          eventType,
+		SchemaVersion: constants.DomainEventSchemaVersion,
+		AggregateType: aggregateType,
+		AggregateId:   aggregateId,
+		Payload:       string(payload),
+		Status:        constants.DomainEventStatusPending,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// ToBrokerMessage 는 설정한 토픽 전략에 맞춰 발행할 메시지를 만든다.
+// 같은 대상(aggregate)의 이벤트는 같은 키로 보내 Kafka 의 한 파티션 안에서 순서가 유지되도록 한다.
+func (e DomainEventEntity) ToBrokerMessage(topicStrategy, topicPrefix, topic string) adapters.BrokerMessage {
+	if topicStrategy != constants.MessageBrokerTopicStrategySingle {
+		topic = topicPrefix + e.Type
+	}
+
+	return adapters.BrokerMessage{
+		Topic: topic,
+		Key:   fmt.Sprintf("%s:%d", e.AggregateType, e.AggregateId),
+		Value: []byte(e.Payload),
+		Headers: map[string]string{
+			"eventId":       e.EventId,
+			"eventType":     e.Type,
+			"schemaVersion": fmt.Sprint(e.SchemaVersion),
+			"contentType":   "application/json",
+		},
+	}
+}
+
+func (e *DomainEventEntity) MarkPublished(now time.Time) {
+	e.Status = constants.DomainEventStatusPublished
+	e.PublishedAt = &now
+	e.LastError = ""
+}
+
+// MarkPublishFailed 는 발행 실패를 기록한다. maxAttempts 번 실패하면 더 이상 발행하지 않고 failed 로 둔다.
+func (e *DomainEventEntity) MarkPublishFailed(err error, now time.Time, maxAttempts int, retryBase time.Duration) {
+	e.Attempts++
+	e.LastError = err.Error()
+	if len(e.LastError) > 1000 {
+		e.LastError = e.LastError[:1000]
+	}
+
+	if e.Attempts >= maxAttempts {
+		e.Status = constants.DomainEventStatusFailed
+		return
+	}
+
+	delay := retryBase
+	for i := 1; i < e.Attempts && delay < maxDomainEventRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDomainEventRetryDelay {
+		delay = maxDomainEventRetryDelay
+	}
+	e.NextAttemptAt = now.Add(delay)
+}
+
+// Retry 는 발행에 실패(failed)한 이벤트를 처음부터 다시 발행하도록 한다.
+func (e *DomainEventEntity) Retry(now time.Time) error {
+	if e.Status != constants.DomainEventStatusFailed {
+		return errors.ErrNonChangeable
+	}
+
+	e.Status = constants.DomainEventStatusPending
+	e.Attempts = 0
+	e.NextAttemptAt = now
+	return nil
+}
+
+func newDomainEventId() (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", pkgerrors.Wrap(err, "generate random error")
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
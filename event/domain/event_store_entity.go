@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"time"
+)
+
+// EventStoreEntity 는 Aggregate 의 변경 이벤트이다. 추가만 하며 바꾸거나 지우지 않는다.
+// Sequence 는 Aggregate 별 순서이며 Data 는 이벤트가 발생한 뒤 Aggregate 의 상태(JSON)이다.
+type EventStoreEntity struct {
+	ID            uint      `gorm:"primarykey"`
+	AggregateType string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_event_store_sequence"`
+	AggregateId   uint      `gorm:"not null;uniqueIndex:idx_event_store_sequence"`
+	Sequence      uint      `gorm:"not null;uniqueIndex:idx_event_store_sequence"`
+	Type          string    `gorm:"type:varchar(100);not null"`
+	Data          string    `gorm:"type:text;not null"`
+	ActorId       uint      `gorm:"not null"`
+	OccurredAt    time.Time `gorm:"not null;index"`
+}
+
+func (EventStoreEntity) TableName() string {
+	return "event_store"
+}
+
+func NewEventStoreEntity(ctx context.Context, eventType, aggregateType string, aggregateId uint, data interface{}) (EventStoreEntity, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return EventStoreEntity{}, pkgerrors.Wrap(err, "event encode error")
+	}
+
+	entity := EventStoreEntity{
+		AggregateType: aggregateType,
+		AggregateId:   aggregateId,
+		Type:          eventType,
+		Data:          string(b),
+		OccurredAt:    time.Now(),
+	}
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(ctx); err == nil {
+		entity.ActorId = userClaim.Id
+	}
+
+	return entity, nil
+}
+
+func (e EventStoreEntity) ToMemberHistoryEvent() (dtos.MemberHistoryEvent, error) {
+	var data dtos.MemberEventData
+	if err := json.Unmarshal([]byte(e.Data), &data); err != nil {
+		return dtos.MemberHistoryEvent{}, pkgerrors.Wrap(err, "event decode error")
+	}
+
+	return dtos.MemberHistoryEvent{
+		Sequence:   e.Sequence,
+		Type:       e.Type,
+		ActorId:    e.ActorId,
+		OccurredAt: e.OccurredAt,
+		Data:       data,
+	}, nil
+}
+
+// ProjectMemberState 는 멤버의 이벤트를 순서대로 적용하여 asOf 시각의 상태를 만든다. 적용할 이벤트가 없으면 false 를 반환한다.
+func ProjectMemberState(events []dtos.MemberHistoryEvent, asOf time.Time) (dtos.MemberHistoryState, bool) {
+	state := dtos.MemberHistoryState{AsOf: asOf}
+	applied := false
+	for _, event := range events {
+		if event.OccurredAt.After(asOf) {
+			break
+		}
+
+		state.MemberEventData = event.Data
+		state.Version = event.Sequence
+		state.Deleted = event.Type == constants.DomainEventMemberRejected
+		applied = true
+	}
+
+	if state.Roles == nil {
+		state.Roles = make([]string, 0)
+	}
+
+	return state, applied
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/event/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type DomainEventRepository struct {
+}
+
+func (DomainEventRepository) Create(ctx context.Context, entity *domain.DomainEventEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindPublishable 은 발행할 차례가 된 이벤트를 기록한 순서대로 찾는다.
+func (DomainEventRepository) FindPublishable(ctx context.Context, now time.Time, limit int) ([]domain.DomainEventEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.DomainEventEntity, 0)
+	if err := db.Where("status = ? AND next_attempt_at <= ?", constants.DomainEventStatusPending, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (DomainEventRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.DomainEventEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.DomainEventEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "status" {
+				db.Where("status = ?", value)
+			}
+
+			if key == "type" {
+				db.Where("type = ?", value)
+			}
+		}
+	}
+
+	var entities = make([]domain.DomainEventEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (DomainEventRepository) FindById(ctx context.Context, id uint) (domain.DomainEventEntity, error) {
+	var entity domain.DomainEventEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (DomainEventRepository) Save(ctx context.Context, entity *domain.DomainEventEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// DeletePublishedBefore 는 before 이전에 발행한 이벤트를 지운다.
+func (DomainEventRepository) DeletePublishedBefore(ctx context.Context, before time.Time) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Unscoped().Where("status = ? AND published_at < ?", constants.DomainEventStatusPublished, before).
+		Delete(&domain.DomainEventEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
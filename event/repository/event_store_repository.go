@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"better-admin-backend-service/event/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"time"
+)
+
+type EventStoreRepository struct {
+}
+
+// Append 는 Aggregate 의 다음 순서(Sequence)로 이벤트를 추가한다. 동시에 추가하면 유니크 인덱스로 하나만 성공한다.
+func (EventStoreRepository) Append(ctx context.Context, entity *domain.EventStoreEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var sequence uint
+	if err := db.Model(&domain.EventStoreEntity{}).
+		Where("aggregate_type = ? AND aggregate_id = ?", entity.AggregateType, entity.AggregateId).
+		Select("COALESCE(MAX(sequence), 0)").Scan(&sequence).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	entity.Sequence = sequence + 1
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindByAggregate 는 Aggregate 의 이벤트를 순서대로 찾는다. until 이 있으면 그 시각까지 발생한 이벤트만 찾는다.
+func (EventStoreRepository) FindByAggregate(ctx context.Context, aggregateType string, aggregateId uint, until *time.Time) ([]domain.EventStoreEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx).
+		Where("aggregate_type = ? AND aggregate_id = ?", aggregateType, aggregateId)
+	if until != nil {
+		db = db.Where("occurred_at <= ?", *until)
+	}
+
+	var entities = make([]domain.EventStoreEntity, 0)
+	if err := db.Order("sequence ASC").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
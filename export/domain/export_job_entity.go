@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 단계별 진행률. 결과를 만드는 동안에는 세부 진행률을 알 수 없으므로 단계가 끝날 때 기록한다.
+const (
+	ExportProgressStarted   = 10
+	ExportProgressGenerated = 80
+	ExportProgressCompleted = 100
+)
+
+// ExportJobEntity 는 비동기로 처리하는 내보내기 작업이다. 결과는 파일 저장소에 FileId 로 저장하고 ExpiresAt 이 지나면 지운다.
+type ExportJobEntity struct {
+	gorm.Model
+	Type     string `gorm:"type:varchar(20);not null"`
+	TargetId uint   `gorm:"not null"`
+	Status   string `gorm:"type:varchar(20);not null;index"`
+	Progress int
+	// 요청한 멤버의 권한으로 처리하도록 요청할 때의 권한을 기록한다(예. 마스킹 해제).
+	Permissions  string `gorm:"type:text"`
+	ErrorMessage string `gorm:"type:varchar(1000)"`
+	FileId       uint
+	RequestedBy  uint `gorm:"not null;index"`
+	StartedAt    *time.Time
+	FinishedAt   *time.Time
+	ExpiresAt    *time.Time `gorm:"index"`
+}
+
+func (ExportJobEntity) TableName() string {
+	return "export_jobs"
+}
+
+func NewExportJobEntity(ctx context.Context, request dtos.ExportJobRequest) (ExportJobEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return ExportJobEntity{}, err
+	}
+
+	permissions, err := json.Marshal(userClaim.Permissions)
+	if err != nil {
+		return ExportJobEntity{}, err
+	}
+
+	return ExportJobEntity{
+		Type:        request.Type,
+		TargetId:    request.TargetId,
+		Status:      constants.ExportJobStatusPending,
+		Permissions: string(permissions),
+		RequestedBy: userClaim.Id,
+	}, nil
+}
+
+// GetUserClaim 은 작업을 처리할 때 사용할 요청한 멤버의 인증 정보이다.
+func (e ExportJobEntity) GetUserClaim() *security.UserClaim {
+	var permissions []string
+	if err := json.Unmarshal([]byte(e.Permissions), &permissions); err != nil {
+		permissions = []string{}
+	}
+
+	return &security.UserClaim{Id: e.RequestedBy, Permissions: permissions}
+}
+
+func (e *ExportJobEntity) Start() {
+	now := time.Now()
+	e.Status = constants.ExportJobStatusRunning
+	e.Progress = ExportProgressStarted
+	e.StartedAt = &now
+}
+
+func (e *ExportJobEntity) Succeed(fileId uint, retention time.Duration) {
+	now := time.Now()
+	expiresAt := now.Add(retention)
+	e.Status = constants.ExportJobStatusSucceeded
+	e.Progress = ExportProgressCompleted
+	e.FileId = fileId
+	e.FinishedAt = &now
+	e.ExpiresAt = &expiresAt
+}
+
+func (e *ExportJobEntity) Fail(err error) {
+	message := []rune(err.Error())
+	if len(message) > 1000 {
+		message = message[:1000]
+	}
+
+	now := time.Now()
+	e.Status = constants.ExportJobStatusFailed
+	e.ErrorMessage = string(message)
+	e.FinishedAt = &now
+}
+
+func (e *ExportJobEntity) Expire() {
+	e.Status = constants.ExportJobStatusExpired
+	e.FileId = 0
+}
+
+// IsDownloadable 은 결과 파일을 내려받을 수 있는지이다. 보관 시간이 지났으면 정리 작업 전이라도 내려받을 수 없다.
+func (e ExportJobEntity) IsDownloadable(now time.Time) bool {
+	return e.Status == constants.ExportJobStatusSucceeded && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+func (e ExportJobEntity) ToInformation() dtos.ExportJobInformation {
+	return dtos.ExportJobInformation{
+		Id:           e.ID,
+		Type:         e.Type,
+		TargetId:     e.TargetId,
+		Status:       e.Status,
+		Progress:     e.Progress,
+		ErrorMessage: e.ErrorMessage,
+		FileId:       e.FileId,
+		RequestedBy:  e.RequestedBy,
+		CreatedAt:    e.CreatedAt,
+		StartedAt:    e.StartedAt,
+		FinishedAt:   e.FinishedAt,
+		ExpiresAt:    e.ExpiresAt,
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/export/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ExportJobRepository struct {
+}
+
+func (ExportJobRepository) Create(ctx context.Context, entity *domain.ExportJobEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ExportJobRepository) Save(ctx context.Context, entity *domain.ExportJobEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindByIdAndRequestedBy 는 멤버(requestedBy)가 요청한 작업만 찾는다.
+func (ExportJobRepository) FindByIdAndRequestedBy(ctx context.Context, id uint, requestedBy uint) (domain.ExportJobEntity, error) {
+	var entity domain.ExportJobEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.ExportJobEntity{RequestedBy: requestedBy}).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (ExportJobRepository) FindByRequestedBy(ctx context.Context, requestedBy uint, pageable dtos.Pageable) ([]domain.ExportJobEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ExportJobEntity{}).Where("requested_by = ?", requestedBy)
+
+	var entities = make([]domain.ExportJobEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (ExportJobRepository) FindByStatus(ctx context.Context, status string) ([]domain.ExportJobEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ExportJobEntity, 0)
+	if err := db.Where(&domain.ExportJobEntity{Status: status}).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindExpired 는 보관 시간(now)이 지난 완료된 작업을 찾는다.
+func (ExportJobRepository) FindExpired(ctx context.Context, now time.Time) ([]domain.ExportJobEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ExportJobEntity, 0)
+	if err := db.Where("status = ? AND expires_at <= ?", constants.ExportJobStatusSucceeded, now).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
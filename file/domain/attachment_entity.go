@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"gorm.io/gorm"
+	"time"
+)
+
+// AttachmentEntity 는 결재 요청, 메모 등 대상(EntityType, EntityId)에 첨부한 파일이다.
+// 파일 하나는 한 대상에만 첨부할 수 있고, 첨부한 파일은 교체할 수 없으므로 파일 정보(이름, 형식, 크기)를 함께 보관한다.
+type AttachmentEntity struct {
+	gorm.Model
+	EntityType  string `gorm:"type:varchar(50);not null;index:idx_attachment_entity"`
+	EntityId    uint   `gorm:"not null;index:idx_attachment_entity"`
+	FileId      uint   `gorm:"not null;uniqueIndex"`
+	Name        string `gorm:"type:varchar(255);not null"`
+	ContentType string `gorm:"type:varchar(100);not null"`
+	Size        int64
+	CreatedBy   uint
+	// ExpiresAt 이 지나면 보관 기간이 끝난 것으로 파일과 함께 삭제한다. 비어 있으면 삭제하지 않는다.
+	ExpiresAt *time.Time `gorm:"index"`
+}
+
+func (AttachmentEntity) TableName() string {
+	return "attachments"
+}
+
+// NewAttachmentEntity 는 첨부한 날부터 retentionDays 일 동안 보관하는 첨부 파일을 만든다. retentionDays 가 0 이면 계속 보관한다.
+func NewAttachmentEntity(entityType string, entityId uint, file FileEntity, createdBy uint, retentionDays int, now time.Time) AttachmentEntity {
+	entity := AttachmentEntity{
+		EntityType:  entityType,
+		EntityId:    entityId,
+		FileId:      file.ID,
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		CreatedBy:   createdBy,
+	}
+
+	if retentionDays > 0 {
+		expiresAt := now.AddDate(0, 0, retentionDays)
+		entity.ExpiresAt = &expiresAt
+	}
+
+	return entity
+}
+
+func ToAttachmentInformations(attachments []AttachmentEntity) []dtos.AttachmentInformation {
+	informations := make([]dtos.AttachmentInformation, 0, len(attachments))
+	for _, attachment := range attachments {
+		informations = append(informations, dtos.AttachmentInformation{
+			Id:          attachment.ID,
+			FileId:      attachment.FileId,
+			Name:        attachment.Name,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			CreatedBy:   attachment.CreatedBy,
+			CreatedAt:   attachment.CreatedAt,
+			ExpiresAt:   attachment.ExpiresAt,
+		})
+	}
+
+	return informations
+}
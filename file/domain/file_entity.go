@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const quarantineStorageKeyPrefix = "quarantine/"
+
+const (
+	contentTypeCsv  = "text/csv"
+	contentTypeJson = "application/json"
+	contentTypeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	contentTypePdf  = "application/pdf"
+)
+
+// 용도별로 업로드할 수 있는 파일 형식
+var allowedContentTypes = map[string][]string{
+	constants.FilePurposeAvatar:   {"image/png", "image/jpeg", "image/gif", "image/webp"},
+	constants.FilePurposeBranding: {"image/png", "image/jpeg", "image/gif", "image/webp", "image/x-icon"},
+	constants.FilePurposeImport:   {contentTypeCsv, contentTypeJson, contentTypeXlsx},
+	constants.FilePurposeReport:   {contentTypeCsv, contentTypeXlsx, contentTypePdf},
+	// 신분 확인, 위임장 등 결재 요청과 메모에 첨부하는 문서
+	constants.FilePurposeAttachment: {contentTypePdf, "image/png", "image/jpeg", "image/gif", "image/webp"},
+	// goroutine 덤프(text)와 heap 프로파일(gzip 으로 압축한 pprof)
+	constants.FilePurposeDiagnostics: {"text/plain", "application/x-gzip"},
+	// 내보내기 결과(멤버 데이터 zip, 리포트)
+	constants.FilePurposeExport: {"application/zip", contentTypeCsv, contentTypeXlsx},
+}
+
+// 내용으로 형식을 구분할 수 없는 파일(text, zip)은 확장자로 형식을 정한다.
+var extensionContentTypes = map[string]string{
+	".csv":  contentTypeCsv,
+	".json": contentTypeJson,
+	".xlsx": contentTypeXlsx,
+}
+
+// FileEntity 는 업로드한 파일의 정보이다. 파일 내용은 StorageBackend 저장소의 StorageKey 에 있다.
+type FileEntity struct {
+	gorm.Model
+	Purpose        string `gorm:"type:varchar(20);not null;index"`
+	Name           string `gorm:"type:varchar(255);not null"`
+	ContentType    string `gorm:"type:varchar(100);not null"`
+	Size           int64
+	Checksum       string `gorm:"type:varchar(64)"`
+	StorageBackend string `gorm:"type:varchar(20);not null"`
+	StorageKey     string `gorm:"type:varchar(200);not null;uniqueIndex"`
+	// 바이러스 검사 결과. 바이러스가 있는 파일은 격리 영역(quarantine/)에 저장하고 내려받을 수 없다.
+	ScanStatus    string `gorm:"type:varchar(20);index"`
+	ScanSignature string `gorm:"type:varchar(200)"`
+	ScannedAt     *time.Time
+	CreatedBy     uint
+	UpdatedBy     uint
+	// 서비스 계정의 Id 는 멤버의 Id 와 겹칠 수 있으므로 서비스 계정이 올린 파일인지 기록한다.
+	CreatedByServiceAccount bool `gorm:"not null;default:false"`
+}
+
+func (FileEntity) TableName() string {
+	return "files"
+}
+
+func (f FileEntity) IsAttachment() bool {
+	return f.Purpose == constants.FilePurposeAttachment
+}
+
+func (f FileEntity) IsDiagnostics() bool {
+	return f.Purpose == constants.FilePurposeDiagnostics
+}
+
+func (f FileEntity) IsQuarantined() bool {
+	return f.ScanStatus == constants.FileScanStatusInfected
+}
+
+// ApplyScanResult 는 바이러스 검사 결과를 기록한다. 바이러스가 있으면 격리 영역에 저장하도록 저장소 키를 바꾼다.
+func (f *FileEntity) ApplyScanResult(scanned bool, result dtos.FileScanResult) {
+	if !scanned {
+		f.ScanStatus = constants.FileScanStatusNotScanned
+		return
+	}
+
+	now := time.Now()
+	f.ScannedAt = &now
+	f.ScanStatus = constants.FileScanStatusClean
+	if result.Infected {
+		f.ScanStatus = constants.FileScanStatusInfected
+		f.ScanSignature = result.Signature
+		f.StorageKey = quarantineStorageKeyPrefix + f.StorageKey
+	}
+}
+
+// UploaderMemberId 는 파일을 올린 멤버이다. 서비스 계정이 올린 파일이면 0 이다.
+func (f FileEntity) UploaderMemberId() uint {
+	if f.CreatedByServiceAccount {
+		return 0
+	}
+
+	return f.CreatedBy
+}
+
+// IsUploadedBy 는 userClaim 이 올린 파일인지 확인한다. Id 가 같아도 멤버와 서비스 계정은 다른 사용자이다.
+func (f FileEntity) IsUploadedBy(userClaim security.UserClaim) bool {
+	return f.CreatedBy == userClaim.Id && f.CreatedByServiceAccount == userClaim.IsServiceAccount()
+}
+
+// IsModifiable 은 업로드한 사용자이거나 시스템 설정 권한이 있으면 교체하거나 삭제할 수 있다.
+func (f FileEntity) IsModifiable(ctx context.Context) (bool, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if f.IsUploadedBy(*userClaim) {
+		return true, nil
+	}
+
+	for _, permission := range userClaim.Permissions {
+		if permission == constants.PermissionManageSystemSettings {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// IsAccessible 은 진단 덤프이면 SYSTEM_DIAGNOSTICS 권한이 있어야 업로드하거나 내려받을 수 있다. 메모리 내용이 들어 있기 때문이다.
+// 내보내기 결과는 요청한 멤버만 내려받을 수 있다. 서비스 계정은 내보내기를 요청할 수 없으므로 내려받을 수도 없다.
+func (f FileEntity) IsAccessible(ctx context.Context) (bool, error) {
+	if !f.IsDiagnostics() && f.Purpose != constants.FilePurposeExport {
+		return true, nil
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if f.Purpose == constants.FilePurposeExport {
+		return !userClaim.IsServiceAccount() && f.IsUploadedBy(*userClaim), nil
+	}
+
+	for _, permission := range userClaim.Permissions {
+		if permission == constants.PermissionSystemDiagnostics {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Replace 는 같은 용도의 다른 내용으로 파일을 교체한다. 저장소 키도 새로 만든다.
+func (f *FileEntity) Replace(ctx context.Context, name string, content []byte) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	contentType, err := validateContent(f.Purpose, name, content)
+	if err != nil {
+		return err
+	}
+
+	storageKey, err := newStorageKey(f.Purpose)
+	if err != nil {
+		return err
+	}
+
+	f.Name = filepath.Base(name)
+	f.ContentType = contentType
+	f.Size = int64(len(content))
+	f.Checksum = checksum(content)
+	f.StorageKey = storageKey
+	f.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+// NewFileEntity 는 파일의 형식을 확인하고 저장소에 저장할 키를 만든다.
+func NewFileEntity(ctx context.Context, purpose string, name string, content []byte, storageBackend string) (FileEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return FileEntity{}, err
+	}
+
+	contentType, err := validateContent(purpose, name, content)
+	if err != nil {
+		return FileEntity{}, err
+	}
+
+	storageKey, err := newStorageKey(purpose)
+	if err != nil {
+		return FileEntity{}, err
+	}
+
+	return FileEntity{
+		Purpose:        purpose,
+		Name:           filepath.Base(name),
+		ContentType:    contentType,
+		Size:           int64(len(content)),
+		Checksum:       checksum(content),
+		StorageBackend: storageBackend,
+		StorageKey:     storageKey,
+		CreatedBy:      userClaim.Id,
+		UpdatedBy:      userClaim.Id,
+
+		CreatedByServiceAccount: userClaim.IsServiceAccount(),
+	}, nil
+}
+
+// validateContent 는 용도에 허용된 형식인지 확인하고 파일 형식을 반환한다.
+func validateContent(purpose string, name string, content []byte) (string, error) {
+	allowed, ok := allowedContentTypes[purpose]
+	if !ok {
+		return "", &errors.ErrInvalidFile{Reason: fmt.Sprintf("unsupported purpose: %v", purpose)}
+	}
+
+	if len(content) == 0 {
+		return "", &errors.ErrInvalidFile{Reason: "file is empty"}
+	}
+
+	contentType := DetectContentType(name, content)
+	if !containsString(allowed, contentType) {
+		return "", &errors.ErrInvalidFile{Reason: fmt.Sprintf("%v is not allowed for %v", contentType, purpose)}
+	}
+
+	return contentType, nil
+}
+
+// DetectContentType 은 파일 내용으로 형식을 찾는다. 클라이언트가 보낸 Content-Type 은 신뢰하지 않는다.
+func DetectContentType(name string, content []byte) string {
+	contentType := http.DetectContentType(content)
+	if index := strings.Index(contentType, ";"); index > 0 {
+		contentType = contentType[:index]
+	}
+
+	if contentType == "text/plain" || contentType == "application/zip" || contentType == "application/octet-stream" {
+		if extensionContentType, ok := extensionContentTypes[strings.ToLower(filepath.Ext(name))]; ok {
+			return extensionContentType
+		}
+	}
+
+	return contentType
+}
+
+func newStorageKey(purpose string) (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", pkgerrors.Wrap(err, "generate random error")
+	}
+
+	return purpose + "/" + hex.EncodeToString(randomBytes), nil
+}
+
+func checksum(content []byte) string {
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+// 썸네일을 만들 원본 이미지의 최대 픽셀 수. 작은 파일로 큰 이미지를 만들어 메모리를 소진시키는 것을 막는다.
+const maxThumbnailSourcePixels = 40 * 1000 * 1000
+
+// 썸네일을 만들 수 있는 파일의 용도와 형식
+var (
+	thumbnailPurposes     = []string{constants.FilePurposeAvatar, constants.FilePurposeBranding}
+	thumbnailContentTypes = []string{"image/png", "image/jpeg", "image/gif"}
+)
+
+// Thumbnail 은 이미지 파일을 줄인 결과이다.
+type Thumbnail struct {
+	ContentType string
+	Content     []byte
+}
+
+func (f FileEntity) IsThumbnailSupported() bool {
+	return containsString(thumbnailPurposes, f.Purpose) && containsString(thumbnailContentTypes, f.ContentType)
+}
+
+// ThumbnailStorageKey 는 썸네일을 캐시할 저장소 키이다. 원본 파일을 교체하면 원본의 키가 바뀌므로 이전 썸네일은 사용하지 않는다.
+func (f FileEntity) ThumbnailStorageKey(width, height int) string {
+	return fmt.Sprintf("thumbnails/%s/%dx%d", f.StorageKey, width, height)
+}
+
+// NewThumbnail 은 원본 이미지를 width x height 를 채우도록 줄인 뒤 가운데를 잘라낸다.
+// JPEG 은 JPEG 으로, 그 외 이미지는 PNG 로 만든다.
+func NewThumbnail(file FileEntity, content []byte, width, height int) (Thumbnail, error) {
+	if !file.IsThumbnailSupported() {
+		return Thumbnail{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("thumbnail is not supported for %v(%v)", file.Purpose, file.ContentType)}
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return Thumbnail{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("invalid image: %v", err)}
+	}
+	if imageConfig.Width*imageConfig.Height > maxThumbnailSourcePixels {
+		return Thumbnail{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("image is too large: %dx%d", imageConfig.Width, imageConfig.Height)}
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return Thumbnail{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("invalid image: %v", err)}
+	}
+
+	resized := resizeToFill(source, width, height)
+
+	var buffer bytes.Buffer
+	if file.ContentType == "image/jpeg" {
+		if err := jpeg.Encode(&buffer, resized, &jpeg.Options{Quality: 85}); err != nil {
+			return Thumbnail{}, err
+		}
+		return Thumbnail{ContentType: "image/jpeg", Content: buffer.Bytes()}, nil
+	}
+
+	if err := png.Encode(&buffer, resized); err != nil {
+		return Thumbnail{}, err
+	}
+	return Thumbnail{ContentType: "image/png", Content: buffer.Bytes()}, nil
+}
+
+// resizeToFill 은 원본에서 대상 비율에 맞는 가운데 영역을 골라 대상 픽셀마다 해당하는 원본 픽셀의 평균 색을 사용한다.
+func resizeToFill(source image.Image, width, height int) image.Image {
+	bounds := source.Bounds()
+	sourceWidth, sourceHeight := bounds.Dx(), bounds.Dy()
+
+	cropWidth, cropHeight := sourceWidth, sourceHeight
+	if sourceWidth*height > sourceHeight*width {
+		cropWidth = maxInt(sourceHeight*width/height, 1)
+	} else {
+		cropHeight = maxInt(sourceWidth*height/width, 1)
+	}
+	cropX := bounds.Min.X + (sourceWidth-cropWidth)/2
+	cropY := bounds.Min.Y + (sourceHeight-cropHeight)/2
+
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, source, bounds.Min, draw.Src)
+
+	target := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := cropY + y*cropHeight/height
+		y1 := maxInt(cropY+(y+1)*cropHeight/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := cropX + x*cropWidth/width
+			x1 := maxInt(cropX+(x+1)*cropWidth/width, x0+1)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pixel := rgba.RGBAAt(sx, sy)
+					r += uint64(pixel.R)
+					g += uint64(pixel.G)
+					b += uint64(pixel.B)
+					a += uint64(pixel.A)
+					count++
+				}
+			}
+
+			target.SetRGBA(x, y, color.RGBA{R: uint8(r / count), G: uint8(g / count), B: uint8(b / count), A: uint8(a / count)})
+		}
+	}
+
+	return target
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/file/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AttachmentRepository struct {
+}
+
+func (AttachmentRepository) Create(ctx context.Context, entity *domain.AttachmentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (AttachmentRepository) FindById(ctx context.Context, id uint) (domain.AttachmentEntity, error) {
+	var entity domain.AttachmentEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (AttachmentRepository) ExistsByFileId(ctx context.Context, fileId uint) (bool, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var count int64
+	if err := db.Model(&domain.AttachmentEntity{}).Where("file_id = ?", fileId).Count(&count).Error; err != nil {
+		return false, pkgerrors.Wrap(err, "db error")
+	}
+
+	return count > 0, nil
+}
+
+// FindByEntityIds 는 대상들의 첨부 파일을 첨부한 순서로 조회한다.
+func (AttachmentRepository) FindByEntityIds(ctx context.Context, entityType string, entityIds []uint) ([]domain.AttachmentEntity, error) {
+	var entities = make([]domain.AttachmentEntity, 0)
+	if len(entityIds) == 0 {
+		return entities, nil
+	}
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Where("entity_type = ? AND entity_id IN ?", entityType, entityIds).
+		Order("id").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindExpired 는 보관 기간이 지난 첨부 파일을 조회한다.
+func (AttachmentRepository) FindExpired(ctx context.Context, now time.Time) ([]domain.AttachmentEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.AttachmentEntity, 0)
+	if err := db.Where("expires_at IS NOT NULL AND expires_at <= ?", now).
+		Order("id").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (AttachmentRepository) Delete(ctx context.Context, entity domain.AttachmentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
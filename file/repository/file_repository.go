@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/file/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type FileRepository struct {
+}
+
+func (FileRepository) Create(ctx context.Context, entity *domain.FileEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (FileRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.FileEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.FileEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "purpose" {
+				db.Where("purpose = ?", value)
+			}
+
+			if key == "scanStatus" {
+				db.Where("scan_status = ?", value)
+			}
+
+			if key == "createdBy" {
+				db.Where("created_by = ?", value)
+			}
+		}
+	}
+
+	var entities = make([]domain.FileEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+// SumSize 는 멤버(createdBy)가 올린 파일의 크기 합이다. 서비스 계정이 올린 파일은 포함하지 않는다. createdBy 가 0 이면 모든 파일의 크기 합이다.
+func (FileRepository) SumSize(ctx context.Context, createdBy uint) (int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.FileEntity{})
+
+	if createdBy > 0 {
+		db.Where("created_by = ? AND created_by_service_account = ?", createdBy, false)
+	}
+
+	var size int64
+	if err := db.Select("COALESCE(SUM(size), 0)").Scan(&size).Error; err != nil {
+		return 0, pkgerrors.Wrap(err, "db error")
+	}
+
+	return size, nil
+}
+
+func (FileRepository) FindById(ctx context.Context, id uint) (domain.FileEntity, error) {
+	var entity domain.FileEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (FileRepository) Save(ctx context.Context, entity *domain.FileEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (FileRepository) Delete(ctx context.Context, entity domain.FileEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
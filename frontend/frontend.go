@@ -0,0 +1,86 @@
+package frontend
+
+import (
+	"embed"
+	"github.com/gin-gonic/gin"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const indexFileName = "index.html"
+
+// embeddedFiles 는 빌드할 때 frontend/dist 에 복사한 better-ADMIN 프론트엔드 빌드 결과이다.
+//
+//go:embed all:dist
+var embeddedFiles embed.FS
+
+// EmbeddedFileSystem 은 실행 파일에 포함된 프론트엔드 파일이다.
+func EmbeddedFileSystem() fs.FS {
+	files, _ := fs.Sub(embeddedFiles, "dist")
+	return files
+}
+
+// DirectoryFileSystem 은 디스크의 프론트엔드 빌드 디렉터리이다.
+func DirectoryFileSystem(directory string) fs.FS {
+	return os.DirFS(directory)
+}
+
+// HasIndex 는 프론트엔드 빌드 결과(index.html)가 있는지 확인한다.
+func HasIndex(files fs.FS) bool {
+	stat, err := fs.Stat(files, indexFileName)
+	return err == nil && !stat.IsDir()
+}
+
+// Handler 는 프론트엔드 파일을 응답한다. 파일이 없는 경로는 SPA 라우팅을 위해 index.html 로 응답하고,
+// apiPrefixes 로 시작하는 경로는 404 로 응답한다.
+// assetPrefixes 로 시작하는 파일은 파일명에 해시가 포함되므로 assetMaxAgeSeconds 동안 캐시하고, index.html 은 캐시하지 않는다.
+func Handler(files fs.FS, apiPrefixes []string, assetPrefixes []string, assetMaxAgeSeconds int) gin.HandlerFunc {
+	fileServer := http.FileServer(http.FS(files))
+
+	return func(c *gin.Context) {
+		requestPath := c.Request.URL.Path
+		for _, apiPrefix := range apiPrefixes {
+			if strings.HasPrefix(requestPath, apiPrefix) {
+				c.String(http.StatusNotFound, "404 page not found")
+				return
+			}
+		}
+
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.String(http.StatusNotFound, "404 page not found")
+			return
+		}
+
+		filePath := strings.TrimPrefix(path.Clean(requestPath), "/")
+		if stat, err := fs.Stat(files, filePath); err != nil || stat.IsDir() || filePath == indexFileName {
+			serveIndex(c, files)
+			return
+		}
+
+		cacheControl := "no-cache"
+		for _, assetPrefix := range assetPrefixes {
+			if strings.HasPrefix(filePath, assetPrefix) {
+				cacheControl = "public, max-age=" + strconv.Itoa(assetMaxAgeSeconds) + ", immutable"
+				break
+			}
+		}
+		c.Header("Cache-Control", cacheControl)
+
+		fileServer.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+func serveIndex(c *gin.Context, files fs.FS) {
+	content, err := fs.ReadFile(files, indexFileName)
+	if err != nil {
+		c.String(http.StatusNotFound, "404 page not found")
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, "text/html; charset=utf-8", content)
+}
@@ -0,0 +1,82 @@
+package frontend
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func newTestFrontendApp() *gin.Engine {
+	files := fstest.MapFS{
+		"index.html":         {Data: []byte("<html>better-admin</html>")},
+		"assets/app.1a2b.js": {Data: []byte("console.log('app')")},
+		"favicon.ico":        {Data: []byte("icon")},
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/api/members", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	engine.NoRoute(Handler(files, []string{"/api/", "/ws/"}, []string{"assets/"}, 3600))
+	return engine
+}
+
+func serveTestFrontend(engine *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_정적_파일(t *testing.T) {
+	engine := newTestFrontendApp()
+
+	// when
+	rec := serveTestFrontend(engine, http.MethodGet, "/assets/app.1a2b.js")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "console.log('app')", rec.Body.String())
+	assert.Equal(t, "public, max-age=3600, immutable", rec.Header().Get("Cache-Control"))
+
+	rec = serveTestFrontend(engine, http.MethodGet, "/favicon.ico")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+}
+
+func TestHandler_SPA_경로는_index_응답(t *testing.T) {
+	engine := newTestFrontendApp()
+
+	for _, path := range []string{"/", "/index.html", "/members/1", "/assets/"} {
+		// when
+		rec := serveTestFrontend(engine, http.MethodGet, path)
+
+		// then
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Equal(t, "<html>better-admin</html>", rec.Body.String(), path)
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"), path)
+	}
+}
+
+func TestHandler_API_경로는_404(t *testing.T) {
+	engine := newTestFrontendApp()
+
+	// when
+	rec := serveTestFrontend(engine, http.MethodGet, "/api/unknown")
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, http.StatusOK, serveTestFrontend(engine, http.MethodGet, "/api/members").Code)
+	assert.Equal(t, http.StatusNotFound, serveTestFrontend(engine, http.MethodPost, "/members").Code)
+}
+
+func TestHasIndex(t *testing.T) {
+	assert.True(t, HasIndex(fstest.MapFS{"index.html": {Data: []byte("<html></html>")}}))
+	assert.False(t, HasIndex(fstest.MapFS{"app.js": {Data: []byte("")}}))
+	// 빌드 결과를 복사하지 않은 경우 포함된 파일에 index.html 이 없다.
+	assert.False(t, HasIndex(EmbeddedFileSystem()))
+}
@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"better-admin-backend-service/dtos"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	chaosHelperOnce     sync.Once
+	chaosHelperInstance *chaosHelper
+)
+
+func ChaosHelper() *chaosHelper {
+	chaosHelperOnce.Do(func() {
+		chaosHelperInstance = &chaosHelper{}
+	})
+
+	return chaosHelperInstance
+}
+
+// chaosHelper 는 실행 중인 인스턴스의 장애 주입 규칙이다. 재시작하면 초기화된다.
+type chaosHelper struct {
+	mutex    sync.RWMutex
+	rules    []dtos.ChaosRule
+	until    time.Time
+	injected int64
+}
+
+// SetRules 는 until 까지 규칙을 적용한다. 규칙이 비어 있으면 장애 주입을 끈다.
+func (h *chaosHelper) SetRules(rules []dtos.ChaosRule, until time.Time) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.rules = rules
+	h.until = until
+	h.injected = 0
+}
+
+// Match 는 요청에 주입할 규칙을 찾는다. 처음 일치하는 규칙의 Percent 만큼만 주입한다.
+func (h *chaosHelper) Match(method, path string) (dtos.ChaosRule, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if len(h.rules) == 0 || !time.Now().Before(h.until) {
+		return dtos.ChaosRule{}, false
+	}
+
+	for _, rule := range h.rules {
+		if !strings.HasPrefix(path, rule.PathPrefix) || !matchChaosMethod(rule.Methods, method) {
+			continue
+		}
+
+		if rand.Intn(100) >= rule.Percent {
+			return dtos.ChaosRule{}, false
+		}
+
+		h.injected++
+		return rule, true
+	}
+
+	return dtos.ChaosRule{}, false
+}
+
+func (h *chaosHelper) GetStatus(enabled bool) dtos.ChaosStatus {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	status := dtos.ChaosStatus{
+		Enabled:  enabled,
+		Rules:    make([]dtos.ChaosRule, 0),
+		Injected: h.injected,
+	}
+
+	if len(h.rules) > 0 && time.Now().Before(h.until) {
+		until := h.until
+		status.Rules = append(status.Rules, h.rules...)
+		status.Until = &until
+	}
+
+	return status
+}
+
+func matchChaosMethod(methods []string, method string) bool {
+	if len(methods) == 0 {
+		return true
+	}
+
+	for _, ruleMethod := range methods {
+		if ruleMethod == method {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/security"
 	"context"
 	"github.com/pkg/errors"
@@ -10,6 +11,12 @@ import (
 
 const ContextDBKey = "DB"
 const ContextUserClaimKey = "userClaim"
+const ContextClientIpKey = "clientIp"
+const ContextClientFingerprintKey = "clientFingerprint"
+const ContextDataMaskingPoliciesKey = "dataMaskingPolicies"
+const ContextAfterCommitKey = "afterCommit"
+const ContextChaosFaultsKey = "chaosFaults"
+const ContextStepUpTokenKey = "stepUpToken"
 
 var (
 	contextHelperOnce     sync.Once
@@ -33,6 +40,9 @@ func (contextHelper) GetDB(ctx context.Context) *gorm.DB {
 		panic("DB is not exist")
 	}
 	if db, ok := v.(*gorm.DB); ok {
+		if LoggingHelper().IsDebugEnabled(constants.LoggingModuleDb) {
+			return db.Debug()
+		}
 		return db
 	}
 	panic("DB is not exist")
@@ -56,3 +66,109 @@ func (contextHelper) GetUserClaim(ctx context.Context) (*security.UserClaim, err
 	}
 	return nil, errors.New("UserClaim is not exist")
 }
+
+func (contextHelper) SetClientIp(ctx context.Context, clientIp string) context.Context {
+	return context.WithValue(ctx, ContextClientIpKey, clientIp)
+}
+
+// GetClientIp 는 요청한 클라이언트의 IP 를 반환한다. HTTP 요청이 아닌 경우(예. 스케줄러) 빈 문자열이다.
+func (contextHelper) GetClientIp(ctx context.Context) string {
+	if clientIp, ok := ctx.Value(ContextClientIpKey).(string); ok {
+		return clientIp
+	}
+
+	return ""
+}
+
+func (contextHelper) SetClientFingerprint(ctx context.Context, clientFingerprint string) context.Context {
+	return context.WithValue(ctx, ContextClientFingerprintKey, clientFingerprint)
+}
+
+// GetClientFingerprint 는 요청한 클라이언트의 지문(User-Agent, 기기 Id 의 해시)을 반환한다. HTTP 요청이 아닌 경우 빈 문자열이다.
+func (contextHelper) GetClientFingerprint(ctx context.Context) string {
+	if clientFingerprint, ok := ctx.Value(ContextClientFingerprintKey).(string); ok {
+		return clientFingerprint
+	}
+
+	return ""
+}
+
+func (contextHelper) SetStepUpToken(ctx context.Context, stepUpToken string) context.Context {
+	return context.WithValue(ctx, ContextStepUpTokenKey, stepUpToken)
+}
+
+// GetStepUpToken 은 요청에 포함된 단계 인증 토큰을 반환한다. HTTP 요청이 아닌 경우(예. 예약 작업) ok 는 false 이다.
+func (contextHelper) GetStepUpToken(ctx context.Context) (string, bool) {
+	stepUpToken, ok := ctx.Value(ContextStepUpTokenKey).(string)
+	return stepUpToken, ok
+}
+
+// SetDataMaskingPolicies 는 요청한 사용자에게 적용할 마스킹 정책(필드 이름별 마스킹 방법)을 설정한다.
+func (contextHelper) SetDataMaskingPolicies(ctx context.Context, policies map[string]string) context.Context {
+	return context.WithValue(ctx, ContextDataMaskingPoliciesKey, policies)
+}
+
+// GetDataMaskingPolicies 는 요청한 사용자에게 적용할 마스킹 정책을 반환한다. 없으면 nil 이다.
+func (contextHelper) GetDataMaskingPolicies(ctx context.Context) map[string]string {
+	if policies, ok := ctx.Value(ContextDataMaskingPoliciesKey).(map[string]string); ok {
+		return policies
+	}
+
+	return nil
+}
+
+type afterCommitFuncs struct {
+	mutex sync.Mutex
+	funcs []func()
+}
+
+// SetAfterCommit 은 트랜잭션이 커밋된 뒤 실행할 함수(AfterCommit)를 모을 수 있게 한다.
+func (contextHelper) SetAfterCommit(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ContextAfterCommitKey, &afterCommitFuncs{})
+}
+
+// AfterCommit 은 요청의 트랜잭션이 커밋된 뒤 fn 을 실행한다. 트랜잭션이 없으면 바로 실행한다.
+func (contextHelper) AfterCommit(ctx context.Context, fn func()) {
+	afterCommit, ok := ctx.Value(ContextAfterCommitKey).(*afterCommitFuncs)
+	if !ok {
+		fn()
+		return
+	}
+
+	afterCommit.mutex.Lock()
+	defer afterCommit.mutex.Unlock()
+	afterCommit.funcs = append(afterCommit.funcs, fn)
+}
+
+// RunAfterCommit 은 커밋한 뒤 모아 둔 함수를 실행한다. 롤백한 경우에는 호출하지 않는다.
+func (contextHelper) RunAfterCommit(ctx context.Context) {
+	afterCommit, ok := ctx.Value(ContextAfterCommitKey).(*afterCommitFuncs)
+	if !ok {
+		return
+	}
+
+	afterCommit.mutex.Lock()
+	funcs := afterCommit.funcs
+	afterCommit.funcs = nil
+	afterCommit.mutex.Unlock()
+
+	for _, fn := range funcs {
+		fn()
+	}
+}
+
+// SetChaosFaults 는 요청에 주입할 장애(constants.ChaosFault*)를 설정한다. DB 와 외부 IdP 호출에서 확인한다.
+func (contextHelper) SetChaosFaults(ctx context.Context, faults []string) context.Context {
+	return context.WithValue(ctx, ContextChaosFaultsKey, faults)
+}
+
+func (contextHelper) HasChaosFault(ctx context.Context, fault string) bool {
+	faults, _ := ctx.Value(ContextChaosFaultsKey).([]string)
+	for _, chaosFault := range faults {
+		if chaosFault == fault {
+			return true
+		}
+	}
+
+	return false
+}
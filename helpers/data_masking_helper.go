@@ -0,0 +1,125 @@
+package helpers
+
+import (
+	"better-admin-backend-service/constants"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// MaskTag 는 마스킹 정책(DataMaskingPolicy.Field)을 적용할 응답 필드를 지정하는 태그이다. string 필드에만 적용한다.
+//
+//	Phone string `json:"phone,omitempty" mask:"phone"`
+const MaskTag = "mask"
+
+const maskedValue = "****"
+
+var (
+	dataMaskingHelperOnce     sync.Once
+	dataMaskingHelperInstance *dataMaskingHelper
+)
+
+func DataMaskingHelper() *dataMaskingHelper {
+	dataMaskingHelperOnce.Do(func() {
+		dataMaskingHelperInstance = &dataMaskingHelper{}
+	})
+
+	return dataMaskingHelperInstance
+}
+
+type dataMaskingHelper struct {
+}
+
+// Mask 는 값을 마스킹 방법(method)으로 가린다. 빈 값은 그대로 둔다.
+func (d dataMaskingHelper) Mask(method string, value string) string {
+	if len(value) == 0 {
+		return value
+	}
+
+	switch method {
+	case constants.DataMaskingMethodPhone:
+		return d.maskPhone(value)
+	case constants.DataMaskingMethodEmail:
+		return d.maskEmail(value)
+	case constants.DataMaskingMethodName:
+		return d.maskName(value)
+	}
+
+	return maskedValue
+}
+
+// MaskRows 는 컬럼 이름(headers)에 정책이 있는 값을 가린다. policies 는 필드 이름별 마스킹 방법이다.
+func (d dataMaskingHelper) MaskRows(headers []string, rows [][]string, policies map[string]string) {
+	for i, header := range headers {
+		method, ok := policies[header]
+		if !ok {
+			continue
+		}
+
+		for _, row := range rows {
+			if i < len(row) {
+				row[i] = d.Mask(method, row[i])
+			}
+		}
+	}
+}
+
+// maskPhone 은 앞 3자리와 뒤 4자리 숫자만 남긴다. 구분자(-, 공백 등)는 그대로 둔다. 예) 010-1234-5678 → 010-****-5678
+func (dataMaskingHelper) maskPhone(value string) string {
+	digitCount := 0
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			digitCount++
+		}
+	}
+
+	var builder strings.Builder
+	digitIndex := 0
+	for _, r := range value {
+		if !unicode.IsDigit(r) {
+			builder.WriteRune(r)
+			continue
+		}
+
+		if (digitIndex < 3 && digitCount > 7) || digitIndex >= digitCount-4 {
+			builder.WriteRune(r)
+		} else {
+			builder.WriteRune('*')
+		}
+		digitIndex++
+	}
+
+	return builder.String()
+}
+
+// maskEmail 은 아이디의 앞 2글자와 도메인만 남긴다. 예) ymyoo@bettercode.kr → ym***@bettercode.kr
+func (dataMaskingHelper) maskEmail(value string) string {
+	at := strings.LastIndex(value, "@")
+	if at < 0 {
+		return maskedValue
+	}
+
+	local := []rune(value[:at])
+	visible := 2
+	if len(local) <= visible {
+		visible = 1
+	}
+	if len(local) < visible {
+		visible = len(local)
+	}
+
+	return string(local[:visible]) + "***" + value[at:]
+}
+
+// maskName 은 첫 글자와 마지막 글자만 남긴다. 두 글자 이름은 마지막 글자를 가린다. 예) 유영모 → 유*모
+func (dataMaskingHelper) maskName(value string) string {
+	runes := []rune(value)
+	switch len(runes) {
+	case 1:
+		return "*"
+	case 2:
+		return string(runes[0]) + "*"
+	}
+
+	return string(runes[0]) + strings.Repeat("*", len(runes)-2) + string(runes[len(runes)-1])
+}
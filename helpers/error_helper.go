@@ -1,7 +1,9 @@
 package helpers
 
 import (
+	"context"
 	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
 	"net/http"
 	"sync"
 )
@@ -22,7 +24,12 @@ func ErrorHelper() *errorHelper {
 type errorHelper struct {
 }
 
+// InternalServerError 는 오류를 기록하고 500 으로 응답한다. 요청 제한 시간이 지나 실패한 경우는 504 로 응답한다.
 func (errorHelper) InternalServerError(ctx *gin.Context, err error) {
 	ctx.Error(err)
+	if errors.Is(err, context.DeadlineExceeded) {
+		ctx.Status(http.StatusGatewayTimeout)
+		return
+	}
 	ctx.Status(http.StatusInternalServerError)
 }
@@ -30,3 +30,10 @@ func (gormHelper) Pageable(pageable dtos.Pageable) func(db *gorm.DB) *gorm.DB {
 		return db
 	}
 }
+
+// OwnedBy 는 멤버가 소유한 리소스만 조회한다. 소유자(owner_id)를 기록하기 전에 만든 리소스는 만든 멤버(created_by)가 소유자이다.
+func (gormHelper) OwnedBy(memberId uint) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(owner_id = ? OR (COALESCE(owner_id, 0) = 0 AND created_by = ?))", memberId, memberId)
+	}
+}
@@ -0,0 +1,245 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	jsonSchemaHelperOnce     sync.Once
+	jsonSchemaHelperInstance *jsonSchemaHelper
+)
+
+func JsonSchemaHelper() *jsonSchemaHelper {
+	jsonSchemaHelperOnce.Do(func() {
+		jsonSchemaHelperInstance = &jsonSchemaHelper{}
+	})
+
+	return jsonSchemaHelperInstance
+}
+
+type jsonSchemaHelper struct {
+}
+
+// JsonSchema 는 JSON Schema 중 type, properties, required, additionalProperties(boolean), items, enum,
+// minimum, maximum, minLength, maxLength, minItems, maxItems, pattern 만 지원한다. 그 외의 키워드는 무시한다.
+type JsonSchema struct {
+	Type                 interface{}            `json:"type"`
+	Properties           map[string]*JsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *JsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+	Pattern              string                 `json:"pattern"`
+	pattern              *regexp.Regexp
+}
+
+// Compile 은 스키마를 읽고 pattern 을 미리 컴파일한다.
+func (j jsonSchemaHelper) Compile(schema string) (*JsonSchema, error) {
+	var compiled JsonSchema
+	if err := json.Unmarshal([]byte(schema), &compiled); err != nil {
+		return nil, fmt.Errorf("invalid json schema: %w", err)
+	}
+
+	if err := compiled.compile(); err != nil {
+		return nil, err
+	}
+
+	return &compiled, nil
+}
+
+// Validate 는 값(value)이 스키마에 맞는지 확인한다. 맞지 않으면 위치($.a.b)와 이유를 반환한다.
+func (j jsonSchemaHelper) Validate(schema *JsonSchema, value json.RawMessage) error {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return fmt.Errorf("invalid json")
+	}
+
+	return schema.validate("$", decoded)
+}
+
+func (s *JsonSchema) compile() error {
+	if len(s.Pattern) > 0 {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q", s.Pattern)
+		}
+		s.pattern = pattern
+	}
+
+	for _, property := range s.Properties {
+		if err := property.compile(); err != nil {
+			return err
+		}
+	}
+
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+
+	return nil
+}
+
+func (s *JsonSchema) validate(path string, value interface{}) error {
+	if err := s.validateType(path, value); err != nil {
+		return err
+	}
+
+	if len(s.Enum) > 0 && !s.inEnum(value) {
+		return fmt.Errorf("%s: must be one of enum", path)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return s.validateObject(path, v)
+	case []interface{}:
+		return s.validateArray(path, v)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.MinLength != nil && length < *s.MinLength {
+			return fmt.Errorf("%s: length must be at least %d", path, *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			return fmt.Errorf("%s: length must be at most %d", path, *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			return fmt.Errorf("%s: must match pattern %q", path, s.Pattern)
+		}
+	case json.Number:
+		number, _ := v.Float64()
+		if s.Minimum != nil && number < *s.Minimum {
+			return fmt.Errorf("%s: must be at least %v", path, *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			return fmt.Errorf("%s: must be at most %v", path, *s.Maximum)
+		}
+	}
+
+	return nil
+}
+
+func (s *JsonSchema) validateObject(path string, value map[string]interface{}) error {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			return fmt.Errorf("%s.%s: is required", path, name)
+		}
+	}
+
+	// 오류 위치가 항상 같도록 이름 순서로 확인한다.
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				return fmt.Errorf("%s.%s: is not allowed", path, name)
+			}
+			continue
+		}
+
+		if err := property.validate(path+"."+name, value[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *JsonSchema) validateArray(path string, value []interface{}) error {
+	if s.MinItems != nil && len(value) < *s.MinItems {
+		return fmt.Errorf("%s: items must be at least %d", path, *s.MinItems)
+	}
+	if s.MaxItems != nil && len(value) > *s.MaxItems {
+		return fmt.Errorf("%s: items must be at most %d", path, *s.MaxItems)
+	}
+
+	if s.Items == nil {
+		return nil
+	}
+
+	for i, item := range value {
+		if err := s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *JsonSchema) validateType(path string, value interface{}) error {
+	var types []string
+	switch t := s.Type.(type) {
+	case nil:
+		return nil
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if name, ok := item.(string); ok {
+				types = append(types, name)
+			}
+		}
+	}
+
+	for _, name := range types {
+		if jsonTypeMatches(name, value) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s: must be %v", path, s.Type)
+}
+
+func (s *JsonSchema) inEnum(value interface{}) bool {
+	encoded, _ := json.Marshal(value)
+	for _, item := range s.Enum {
+		encodedItem, _ := json.Marshal(item)
+		if bytes.Equal(encoded, encodedItem) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func jsonTypeMatches(name string, value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return name == "null"
+	case bool:
+		return name == "boolean"
+	case string:
+		return name == "string"
+	case map[string]interface{}:
+		return name == "object"
+	case []interface{}:
+		return name == "array"
+	case json.Number:
+		if name == "number" {
+			return true
+		}
+		number, err := v.Float64()
+		return name == "integer" && err == nil && number == math.Trunc(number)
+	}
+
+	return false
+}
@@ -0,0 +1,147 @@
+package helpers
+
+import (
+	"better-admin-backend-service/dtos"
+	log "github.com/sirupsen/logrus"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 보관하는 요청 상세 기록의 최대 개수
+const maxRequestCaptures = 100
+
+var (
+	loggingHelperOnce     sync.Once
+	loggingHelperInstance *loggingHelper
+)
+
+func LoggingHelper() *loggingHelper {
+	loggingHelperOnce.Do(func() {
+		loggingHelperInstance = &loggingHelper{
+			debugModules: map[string]time.Time{},
+		}
+	})
+
+	return loggingHelperInstance
+}
+
+// loggingHelper 는 실행 중에 로그 레벨, 모듈별 디버그 로그, 특정 멤버의 요청 상세 기록을 제어한다.
+type loggingHelper struct {
+	mutex           sync.RWMutex
+	debugModules    map[string]time.Time
+	captureMemberId uint
+	captureRequests int
+	captures        []dtos.RequestCapture
+}
+
+func (l *loggingHelper) SetLevel(level string) error {
+	parsedLevel, err := log.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	log.SetLevel(parsedLevel)
+	return nil
+}
+
+// EnableDebug 는 until 까지 모듈의 디버그 로그를 남긴다. 시간이 지나면 자동으로 꺼진다.
+func (l *loggingHelper) EnableDebug(module string, until time.Time) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.debugModules[module] = until
+}
+
+func (l *loggingHelper) DisableDebug(module string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.debugModules, module)
+}
+
+func (l *loggingHelper) IsDebugEnabled(module string) bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	until, ok := l.debugModules[module]
+	return ok && time.Now().Before(until)
+}
+
+// Debugf 는 모듈의 디버그 로그가 켜져 있으면 로그 레벨과 관계없이 로그를 남긴다.
+func (l *loggingHelper) Debugf(module string, format string, args ...interface{}) {
+	if !l.IsDebugEnabled(module) {
+		return
+	}
+
+	log.WithField("module", module).Infof(format, args...)
+}
+
+// StartRequestCapture 는 멤버의 다음 요청 requests 개의 상세 내용을 기록한다.
+func (l *loggingHelper) StartRequestCapture(memberId uint, requests int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.captureMemberId = memberId
+	l.captureRequests = requests
+}
+
+func (l *loggingHelper) StopRequestCapture() {
+	l.StartRequestCapture(0, 0)
+}
+
+// ShouldCaptureRequest 는 멤버의 요청을 기록해야 하는지 확인하고 남은 기록 횟수를 줄인다.
+func (l *loggingHelper) ShouldCaptureRequest(memberId uint) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.captureRequests <= 0 || l.captureMemberId != memberId {
+		return false
+	}
+
+	l.captureRequests--
+	return true
+}
+
+func (l *loggingHelper) AddRequestCapture(capture dtos.RequestCapture) {
+	log.WithField("capture", capture).Info("request captured")
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.captures = append(l.captures, capture)
+	if len(l.captures) > maxRequestCaptures {
+		l.captures = l.captures[len(l.captures)-maxRequestCaptures:]
+	}
+}
+
+func (l *loggingHelper) GetRequestCaptures() []dtos.RequestCapture {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	captures := make([]dtos.RequestCapture, len(l.captures))
+	copy(captures, l.captures)
+	return captures
+}
+
+func (l *loggingHelper) GetStatus() dtos.LoggingStatus {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	status := dtos.LoggingStatus{
+		Level:        log.GetLevel().String(),
+		DebugModules: make([]dtos.LoggingDebugModule, 0),
+	}
+
+	now := time.Now()
+	for module, until := range l.debugModules {
+		if now.Before(until) {
+			status.DebugModules = append(status.DebugModules, dtos.LoggingDebugModule{Module: module, Until: until})
+		}
+	}
+	sort.Slice(status.DebugModules, func(i, j int) bool {
+		return status.DebugModules[i].Module < status.DebugModules[j].Module
+	})
+
+	if l.captureRequests > 0 {
+		status.RequestCapture = &dtos.RequestCaptureSetting{MemberId: l.captureMemberId, Requests: l.captureRequests}
+	}
+
+	return status
+}
@@ -0,0 +1,181 @@
+package helpers
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// PermissionTag 는 응답 필드를 볼 수 있는 권한을 지정하는 태그이다. 여러 권한은 쉼표로 구분하며 그 중 하나만 있으면 볼 수 있다.
+// 권한이 없으면 필드를 zero value 로 바꾸므로 응답에서 제외하려면 json 태그에 omitempty 를 함께 지정한다.
+//
+//	Email string `json:"email,omitempty" permission:"VIEW_MEMBER_PERSONAL_INFO"`
+const PermissionTag = "permission"
+
+var (
+	responseHelperOnce     sync.Once
+	responseHelperInstance *responseHelper
+)
+
+func ResponseHelper() *responseHelper {
+	responseHelperOnce.Do(func() {
+		responseHelperInstance = &responseHelper{}
+	})
+
+	return responseHelperInstance
+}
+
+type responseHelper struct {
+	// 타입(reflect.Type)마다 permission, mask 태그가 있는 필드를 포함하는지 기록한다.
+	filteredTypes sync.Map
+}
+
+// JSON 은 요청한 사용자에게 권한이 없는 필드를 제외하고 마스킹 정책을 적용하여 응답한다.
+func (r *responseHelper) JSON(ctx *gin.Context, code int, obj interface{}) {
+	ctx.JSON(code, r.Serialize(ctx.Request.Context(), obj))
+}
+
+// Serialize 는 요청한 사용자의 권한과 마스킹 정책(ContextHelper().GetDataMaskingPolicies)을 적용한 복사본을 반환한다.
+// JSON 이 아닌 형식으로 내보낼 때(예. CSV)도 이 결과를 사용한다.
+func (r *responseHelper) Serialize(ctx context.Context, obj interface{}) interface{} {
+	permissions := make([]string, 0)
+	if userClaim, err := ContextHelper().GetUserClaim(ctx); err == nil {
+		permissions = userClaim.Permissions
+	}
+
+	return r.FilterFields(obj, permissions, ContextHelper().GetDataMaskingPolicies(ctx))
+}
+
+// FilterFields 는 권한(permissions)으로 볼 수 없는 필드를 zero value 로 바꾸고, 정책(policies)이 있는 mask 태그 필드를 가린 복사본을 반환한다.
+// 원본은 바꾸지 않는다.
+func (r *responseHelper) FilterFields(obj interface{}, permissions []string, policies map[string]string) interface{} {
+	if obj == nil {
+		return nil
+	}
+
+	return r.filterValue(reflect.ValueOf(obj), permissions, policies).Interface()
+}
+
+func (r *responseHelper) filterValue(value reflect.Value, permissions []string, policies map[string]string) reflect.Value {
+	if !r.isFilteredType(value.Type()) {
+		return value
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		filtered := reflect.New(value.Type().Elem())
+		filtered.Elem().Set(r.filterValue(value.Elem(), permissions, policies))
+		return filtered
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		filtered := reflect.New(value.Type()).Elem()
+		filtered.Set(r.filterValue(value.Elem(), permissions, policies))
+		return filtered
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		filtered := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			filtered.Index(i).Set(r.filterValue(value.Index(i), permissions, policies))
+		}
+		return filtered
+	case reflect.Array:
+		filtered := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			filtered.Index(i).Set(r.filterValue(value.Index(i), permissions, policies))
+		}
+		return filtered
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		filtered := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			filtered.SetMapIndex(iterator.Key(), r.filterValue(iterator.Value(), permissions, policies))
+		}
+		return filtered
+	case reflect.Struct:
+		filtered := reflect.New(value.Type()).Elem()
+		filtered.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if len(field.PkgPath) > 0 {
+				continue
+			}
+
+			if requiredPermissions, ok := field.Tag.Lookup(PermissionTag); ok && !hasAnyPermission(permissions, requiredPermissions) {
+				filtered.Field(i).Set(reflect.Zero(field.Type))
+				continue
+			}
+
+			if method, ok := policies[field.Tag.Get(MaskTag)]; ok && field.Type.Kind() == reflect.String {
+				filtered.Field(i).SetString(DataMaskingHelper().Mask(method, value.Field(i).String()))
+				continue
+			}
+			filtered.Field(i).Set(r.filterValue(value.Field(i), permissions, policies))
+		}
+		return filtered
+	}
+
+	return value
+}
+
+// isFilteredType 은 타입 안에 permission, mask 태그가 있는 필드가 있을 수 있는지 확인한다. interface 는 실제 값을 알 수 없으므로 항상 확인한다.
+func (r *responseHelper) isFilteredType(t reflect.Type) bool {
+	if filtered, ok := r.filteredTypes.Load(t); ok {
+		return filtered.(bool)
+	}
+
+	filtered := r.checkFilteredType(t, map[reflect.Type]bool{})
+	r.filteredTypes.Store(t, filtered)
+	return filtered
+}
+
+func (r *responseHelper) checkFilteredType(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	// 자기 자신을 참조하는 타입은 다른 필드에서 판단한다.
+	if visiting[t] {
+		return false
+	}
+	visiting[t] = true
+
+	filtered := false
+	switch t.Kind() {
+	case reflect.Interface:
+		filtered = true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		filtered = r.checkFilteredType(t.Elem(), visiting)
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && !filtered; i++ {
+			field := t.Field(i)
+			if len(field.PkgPath) > 0 {
+				continue
+			}
+			_, permissionTagged := field.Tag.Lookup(PermissionTag)
+			_, maskTagged := field.Tag.Lookup(MaskTag)
+			filtered = permissionTagged || maskTagged || r.checkFilteredType(field.Type, visiting)
+		}
+	}
+
+	return filtered
+}
+
+func hasAnyPermission(permissions []string, requiredPermissions string) bool {
+	for _, requiredPermission := range strings.Split(requiredPermissions, ",") {
+		for _, permission := range permissions {
+			if permission == strings.TrimSpace(requiredPermission) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
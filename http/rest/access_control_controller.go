@@ -6,23 +6,34 @@ import (
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/rbac/domain"
 	"better-admin-backend-service/services"
 	etag "github.com/bettercode-oss/gin-middleware-etag"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type AccessControlController struct {
 	routerGroup                   *gin.RouterGroup
 	roleBasedAccessControlService *services.RoleBasedAccessControlService
+	roleMemberBulkService         *services.RoleMemberBulkService
+	accessHistoryService          *services.AccessHistoryService
+	permissionSimulationService   *services.PermissionSimulationService
 }
 
 func NewAccessControlController(rg *gin.RouterGroup,
-	roleBasedAccessControlService *services.RoleBasedAccessControlService) *AccessControlController {
+	roleBasedAccessControlService *services.RoleBasedAccessControlService,
+	roleMemberBulkService *services.RoleMemberBulkService,
+	accessHistoryService *services.AccessHistoryService,
+	permissionSimulationService *services.PermissionSimulationService) *AccessControlController {
 	return &AccessControlController{
 		routerGroup:                   rg,
 		roleBasedAccessControlService: roleBasedAccessControlService,
+		roleMemberBulkService:         roleMemberBulkService,
+		accessHistoryService:          accessHistoryService,
+		permissionSimulationService:   permissionSimulationService,
 	}
 }
 
@@ -46,6 +57,11 @@ func (c AccessControlController) MapRoutes() {
 	route.GET("/roles", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
 		etag.HttpEtagCache(0),
 		c.getRoles)
+	route.GET("/roles/compare", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		etag.HttpEtagCache(0),
+		c.compareRoles)
+	route.POST("/roles/simulate", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.simulateRemovePermissions)
 	route.GET("/roles/:roleId", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
 		etag.HttpEtagCache(0),
 		c.getRole)
@@ -53,6 +69,14 @@ func (c AccessControlController) MapRoutes() {
 		c.updateRole)
 	route.DELETE("/roles/:roleId", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
 		c.deleteRole)
+	route.POST("/roles/:roleId/members/bulk", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.bulkRoleMembers)
+	route.GET("/roles/:roleId/members/bulk/:jobId", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.getRoleMemberBulkJob)
+	route.POST("/roles/:roleId/merge", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.mergeRole)
+	route.GET("/access-history", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.getAccessHistory)
 }
 
 func (c AccessControlController) createPermission(ctx *gin.Context) {
@@ -65,7 +89,7 @@ func (c AccessControlController) createPermission(ctx *gin.Context) {
 
 	err := c.roleBasedAccessControlService.CreatePermission(ctx.Request.Context(), permission)
 	if err != nil {
-		if err == errors.ErrDuplicated {
+		if errors.Is(err, errors.ErrDuplicated) {
 			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
 			return
 		}
@@ -119,7 +143,7 @@ func (c AccessControlController) getPermission(ctx *gin.Context) {
 
 	permissionEntity, err := c.roleBasedAccessControlService.GetPermission(ctx.Request.Context(), uint(permissionId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -154,12 +178,12 @@ func (c AccessControlController) updatePermission(ctx *gin.Context) {
 
 	err = c.roleBasedAccessControlService.UpdatePermission(ctx.Request.Context(), uint(permissionId), permission)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
 
-		if err == errors.ErrNonChangeable || err == errors.ErrDuplicated {
+		if errors.Is(err, errors.ErrNonChangeable) || errors.Is(err, errors.ErrDuplicated) {
 			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
 			return
 		}
@@ -179,11 +203,11 @@ func (c AccessControlController) deletePermission(ctx *gin.Context) {
 
 	err = c.roleBasedAccessControlService.DeletePermission(ctx.Request.Context(), uint(permissionId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
-		if err == errors.ErrNonChangeable {
+		if errors.Is(err, errors.ErrNonChangeable) {
 			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
 			return
 		}
@@ -204,6 +228,10 @@ func (c AccessControlController) createRole(ctx *gin.Context) {
 
 	err := c.roleBasedAccessControlService.CreateRole(ctx.Request.Context(), role)
 	if err != nil {
+		if respondLicenseError(ctx, err) {
+			return
+		}
+
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
@@ -262,7 +290,7 @@ func (c AccessControlController) getRole(ctx *gin.Context) {
 
 	roleEntity, err := c.roleBasedAccessControlService.GetRole(ctx.Request.Context(), uint(roleId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -307,11 +335,11 @@ func (c AccessControlController) updateRole(ctx *gin.Context) {
 
 	err = c.roleBasedAccessControlService.UpdateRole(ctx.Request.Context(), uint(roleId), role)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
-		if err == errors.ErrNonChangeable {
+		if errors.Is(err, errors.ErrNonChangeable) {
 			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
 			return
 		}
@@ -331,11 +359,11 @@ func (c AccessControlController) deleteRole(ctx *gin.Context) {
 
 	err = c.roleBasedAccessControlService.DeleteRole(ctx.Request.Context(), uint(roleId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
-		if err == errors.ErrNonChangeable {
+		if errors.Is(err, errors.ErrNonChangeable) {
 			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
 			return
 		}
@@ -345,3 +373,248 @@ func (c AccessControlController) deleteRole(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func (c AccessControlController) bulkRoleMembers(ctx *gin.Context) {
+	roleId, err := strconv.ParseInt(ctx.Param("roleId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var request dtos.RoleMemberBulkRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if (len(request.MemberIds) == 0) == (request.SegmentId == 0) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: "either memberIds or segmentId is required"})
+		return
+	}
+
+	if request.Async {
+		job, err := c.roleMemberBulkService.Enqueue(ctx.Request.Context(), uint(roleId), request)
+		if err != nil {
+			c.handleRoleMemberBulkError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusAccepted, c.toRoleMemberBulkJobInformation(job))
+		return
+	}
+
+	result, err := c.roleMemberBulkService.Apply(ctx.Request.Context(), uint(roleId), request)
+	if err != nil {
+		c.handleRoleMemberBulkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c AccessControlController) getRoleMemberBulkJob(ctx *gin.Context) {
+	roleId, err := strconv.ParseInt(ctx.Param("roleId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jobId, err := strconv.ParseInt(ctx.Param("jobId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	job, err := c.roleMemberBulkService.GetJob(ctx.Request.Context(), uint(roleId), uint(jobId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toRoleMemberBulkJobInformation(job))
+}
+
+func (c AccessControlController) compareRoles(ctx *gin.Context) {
+	roleIdA, err := strconv.ParseInt(ctx.Query("a"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	roleIdB, err := strconv.ParseInt(ctx.Query("b"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	comparison, err := c.roleMemberBulkService.CompareRoles(ctx.Request.Context(), uint(roleIdA), uint(roleIdB))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, comparison)
+}
+
+// simulateRemovePermissions 는 역할에서 권한을 제거하면 접근을 잃는 멤버와 API, 소유 리소스를 반환한다. 역할은 바꾸지 않는다.
+func (c AccessControlController) simulateRemovePermissions(ctx *gin.Context) {
+	var request dtos.PermissionSimulationRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.permissionSimulationService.SimulateRemovePermissions(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if e, ok := err.(*errors.ErrInvalidPermissionSimulation); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	rules := middlewares.AuthorizationMatrix(c.routerGroup.BasePath())
+	lostEndpoints := map[string]bool{}
+	for i, member := range result.AffectedMembers {
+		for _, rule := range rules {
+			if !middlewares.IsAuthorized(rule, member.PermissionsBefore) || middlewares.IsAuthorized(rule, member.PermissionsAfter) {
+				continue
+			}
+
+			result.AffectedMembers[i].LostEndpoints = append(result.AffectedMembers[i].LostEndpoints, rule)
+			lostEndpoints[rule.Method+" "+rule.Path] = true
+		}
+	}
+
+	for _, rule := range rules {
+		if lostEndpoints[rule.Method+" "+rule.Path] {
+			result.LostEndpoints = append(result.LostEndpoints, rule)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c AccessControlController) mergeRole(ctx *gin.Context) {
+	roleId, err := strconv.ParseInt(ctx.Param("roleId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var merge dtos.RoleMerge
+	if err := ctx.BindJSON(&merge); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.roleMemberBulkService.MergeRole(ctx.Request.Context(), uint(roleId), merge)
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidTarget) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		c.handleRoleMemberBulkError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (AccessControlController) handleRoleMemberBulkError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrApprovalRequired) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+		return
+	}
+
+	if handleSuperAdminProtectionError(ctx, err) {
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+func (AccessControlController) toRoleMemberBulkJobInformation(job domain.RoleMemberBulkJobEntity) dtos.RoleMemberBulkJobInformation {
+	return dtos.RoleMemberBulkJobInformation{
+		Id:           job.ID,
+		RoleId:       job.RoleId,
+		Action:       job.Action,
+		Status:       job.Status,
+		Result:       job.GetResult(),
+		ErrorMessage: job.ErrorMessage,
+		RequestedBy:  job.RequestedBy,
+		CreatedAt:    job.CreatedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+}
+
+// getAccessHistory 는 at(RFC3339) 시각에 멤버(memberId)가 가진 권한 또는 권한(permission)을 가진 멤버를 반환한다. at 이 없으면 현재 시각이다.
+func (c AccessControlController) getAccessHistory(ctx *gin.Context) {
+	at := time.Now()
+	if len(ctx.Query("at")) > 0 {
+		parsed, err := time.Parse(time.RFC3339, ctx.Query("at"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		at = parsed
+	}
+
+	if len(ctx.Query("memberId")) > 0 {
+		memberId, err := strconv.ParseInt(ctx.Query("memberId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		history, err := c.accessHistoryService.GetMemberAccessAt(ctx.Request.Context(), uint(memberId), at)
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				ctx.Status(http.StatusNotFound)
+				return
+			}
+
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, history)
+		return
+	}
+
+	if len(ctx.Query("permission")) > 0 {
+		history, err := c.accessHistoryService.GetPermissionHoldersAt(ctx.Request.Context(), ctx.Query("permission"), at)
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, history)
+		return
+	}
+
+	ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: "memberId or permission is required"})
+}
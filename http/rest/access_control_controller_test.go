@@ -1,12 +1,19 @@
 package rest
 
 import (
+	"better-admin-backend-service/app/middlewares"
+	auditDomain "better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/testdata/testdb"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -1229,3 +1236,563 @@ func TestAccessControlController_deleteRole_사전정의_유형(t *testing.T) {
 	json.Unmarshal(rec.Body.Bytes(), &actual)
 	assert.Equal(t, "non changeable", actual.(map[string]interface{})["message"])
 }
+
+func postRoleMembersBulk(roleId uint, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/access-control/roles/%v/members/bulk", roleId), strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(1))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAccessControlController_bulkRoleMembers_할당(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := postRoleMembersBulk(2, `{"action": "assign", "memberIds": [1, 2, 3, 99, 3]}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "assign", actual["action"])
+	assert.Equal(t, float64(4), actual["requested"])
+	assert.Equal(t, float64(2), actual["succeeded"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"memberId": float64(99), "reason": "not-found"},
+		map[string]interface{}{"memberId": float64(2), "reason": "already-assigned"},
+	}, actual["failed"])
+
+	var memberIds []uint
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 2).Order("member_entity_id").Pluck("member_entity_id", &memberIds)
+	assert.Equal(t, []uint{1, 2, 3}, memberIds)
+
+	var roleCount int64
+	gormDB.Table("member_roles").Where("member_entity_id = ?", 1).Count(&roleCount)
+	assert.Equal(t, int64(2), roleCount)
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).
+		Where("action = ? AND target_type = ? AND target_id = ?", "role-members-assigned", "role", 2).
+		Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+}
+
+func TestAccessControlController_bulkRoleMembers_제거(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := postRoleMembersBulk(1, `{"action": "remove", "memberIds": [2, 3]}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["succeeded"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"memberId": float64(3), "reason": "not-assigned"},
+	}, actual["failed"])
+
+	var memberIds []uint
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 1).Pluck("member_entity_id", &memberIds)
+	assert.Equal(t, []uint{1}, memberIds)
+
+	var roleIds []uint
+	gormDB.Table("member_roles").Where("member_entity_id = ?", 2).Pluck("role_entity_id", &roleIds)
+	assert.Equal(t, []uint{2}, roleIds)
+}
+
+func TestAccessControlController_bulkRoleMembers_비동기(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := postRoleMembersBulk(3, `{"action": "assign", "memberIds": [3, 4], "async": true}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var job map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &job)
+	assert.Equal(t, "pending", job["status"])
+
+	var roleCount int64
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 3).Count(&roleCount)
+	assert.Equal(t, int64(0), roleCount)
+
+	// when
+	err := NewContainer().RoleMemberBulkService.ProcessPendingJobs(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/access-control/roles/3/members/bulk/%v", job["id"]), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "succeeded", actual["status"])
+	assert.NotNil(t, actual["finishedAt"])
+	assert.Equal(t, float64(2), actual["result"].(map[string]interface{})["succeeded"])
+
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 3).Count(&roleCount)
+	assert.Equal(t, int64(2), roleCount)
+
+	var auditLog auditDomain.AuditLogEntity
+	gormDB.Where("action = ?", "role-members-assigned").First(&auditLog)
+	assert.Equal(t, uint(1), auditLog.ActorId)
+}
+
+func TestAccessControlController_bulkRoleMembers_승인_절차가_있는_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpRoleGrantApprovalWorkflow(t)
+
+	// when
+	rec := postRoleMembersBulk(2, `{"action": "assign", "memberIds": [1]}`)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var roleCount int64
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 2).Count(&roleCount)
+	assert.Equal(t, int64(1), roleCount)
+}
+
+func TestAccessControlController_bulkRoleMembers_대상이_없는_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := postRoleMembersBulk(2, `{"action": "assign"}`)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAccessControlController_compareRoles(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/access-control/roles/compare?a=1&b=2", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "name": "SYSTEM MANAGER", "memberCount": float64(2)}, actual["roleA"])
+	assert.Equal(t, map[string]interface{}{"id": float64(2), "name": "MEMBER MANAGER", "memberCount": float64(1)}, actual["roleB"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": float64(1), "name": "MANAGE_SYSTEM_SETTINGS"}}, actual["onlyInA"])
+	assert.Equal(t, []interface{}{}, actual["onlyInB"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": float64(2), "name": "MANAGE_MEMBERS"}}, actual["sharedPermissions"])
+	assert.Equal(t, map[string]interface{}{
+		"onlyInA": []interface{}{float64(1)},
+		"onlyInB": []interface{}{},
+		"shared":  []interface{}{float64(2)},
+	}, actual["members"])
+}
+
+func TestAccessControlController_compareRoles_역할이_없는_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/access-control/roles/compare?a=1&b=100", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAccessControlController_mergeRole(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Exec("INSERT INTO member_roles (member_entity_id, role_entity_id) VALUES (3, 2)")
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/access-control/roles/2/merge", strings.NewReader(`{"targetRoleId": 1}`))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(1))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["moved"])
+	assert.Equal(t, float64(1), actual["alreadyAssigned"])
+
+	var sourceCount int64
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 2).Count(&sourceCount)
+	assert.Equal(t, int64(0), sourceCount)
+
+	var memberIds []uint
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 1).Order("member_entity_id").Pluck("member_entity_id", &memberIds)
+	assert.Equal(t, []uint{1, 2, 3}, memberIds)
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).
+		Where("action = ? AND target_type = ? AND target_id = ?", "role-members-merged", "role", 2).
+		Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+}
+
+func TestAccessControlController_mergeRole_최고_관리자_최소_인원(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/access-control/roles/1/merge", strings.NewReader(`{"targetRoleId": 2}`))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(1))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "SUPER_ADMIN_MINIMUM", actual["code"])
+
+	var memberIds []uint
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 1).Order("member_entity_id").Pluck("member_entity_id", &memberIds)
+	assert.Equal(t, []uint{1, 2}, memberIds)
+}
+
+func TestAccessControlController_mergeRole_같은_역할(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/access-control/roles/1/merge", strings.NewReader(`{"targetRoleId": 1}`))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAccessControlController_getAccessHistory_멤버(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	at := time.Now().AddDate(0, 0, -25).Format(time.RFC3339)
+	req := httptest.NewRequest(http.MethodGet, "/api/access-control/access-history?memberId=3&at="+url.QueryEscape(at), nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.MemberAccessHistory
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, uint(3), actual.MemberId)
+	assert.Equal(t, 1, len(actual.Roles))
+	assert.Equal(t, "MEMBER MANAGER", actual.Roles[0].Name)
+	assert.NotNil(t, actual.Roles[0].EffectiveTo)
+	// ACCESS_STOCK 은 40일 전에 역할에서 제외되었다.
+	assert.Equal(t, []string{"MANAGE_MEMBERS"}, actual.Permissions)
+}
+
+func TestAccessControlController_getAccessHistory_권한을_가진_멤버(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+
+	getHolders := func(at time.Time) []uint {
+		req := httptest.NewRequest(http.MethodGet, "/api/access-control/access-history?permission=MANAGE_MEMBERS&at="+url.QueryEscape(at.Format(time.RFC3339Nano)), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual dtos.PermissionAccessHistory
+		json.Unmarshal(rec.Body.Bytes(), &actual)
+		memberIds := make([]uint, 0)
+		for _, holder := range actual.Holders {
+			memberIds = append(memberIds, holder.MemberId)
+		}
+		return memberIds
+	}
+
+	// given
+	// 멤버 관리 권한이 없는 역할로 바꾼다.
+	beforeRevoke := time.Now()
+	req := httptest.NewRequest(http.MethodPut, "/api/members/1/assign-roles", strings.NewReader(`{"roleIds": [3]}`))
+	memberToken, _ := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberToken))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(1))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	past := getHolders(time.Now().AddDate(0, 0, -25))
+	before := getHolders(beforeRevoke)
+	now := getHolders(time.Now())
+
+	// then
+	assert.Equal(t, []uint{1, 2, 3}, past)
+	assert.Equal(t, []uint{1, 2}, before)
+	assert.Equal(t, []uint{2}, now)
+}
+
+func TestAccessControlController_getAccessHistory_조건이_없는_경우(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/access-control/access-history", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAccessControlController_simulateRemovePermissions(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// SYSTEM MANAGER 역할은 멤버 1, 2 에게 직접, 멤버 3 에게 조직(4)을 통해 할당되어 있다. 멤버 1, 2 는 MEMBER MANAGER 역할로도 MANAGE_MEMBERS 권한을 가진다.
+	requestBody := `{"roleId": 1, "removePermissions": ["MANAGE_MEMBERS"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/access-control/roles/simulate", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.PermissionSimulationResult
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, uint(1), actual.RoleId)
+	assert.Equal(t, "SYSTEM MANAGER", actual.RoleName)
+	assert.Equal(t, 3, actual.MemberCount)
+	assert.Equal(t, 1, len(actual.AffectedMembers))
+	assert.Equal(t, uint(3), actual.AffectedMembers[0].MemberId)
+	assert.Equal(t, []string{"MANAGE_MEMBERS"}, actual.AffectedMembers[0].LostPermissions)
+	assert.Contains(t, actual.AffectedMembers[0].LostEndpoints, dtos.AuthorizationRule{
+		Method:         http.MethodGet,
+		Path:           "/api/members",
+		Authentication: constants.AuthorizationPermission,
+		Permissions:    []string{"MANAGE_MEMBERS"},
+	})
+	assert.Equal(t, actual.AffectedMembers[0].LostEndpoints, actual.LostEndpoints)
+	for _, rule := range actual.LostEndpoints {
+		assert.Contains(t, rule.Permissions, "MANAGE_MEMBERS")
+		assert.NotContains(t, rule.Permissions, "MANAGE_SYSTEM_SETTINGS")
+	}
+
+	// 역할은 바뀌지 않는다.
+	roleEntity, err := NewContainer().RbacService.GetRole(helpers.ContextHelper().SetDB(context.Background(), gormDB), 1)
+	assert.NoError(t, err)
+	assert.True(t, roleEntity.HasPermission("MANAGE_MEMBERS"))
+}
+
+func TestAccessControlController_simulateRemovePermissions_역할에_없는_권한(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{"roleId": 2, "removePermissions": ["MANAGE_SYSTEM_SETTINGS"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/access-control/roles/simulate", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "INVALID_PERMISSION_SIMULATION", actual.Code)
+}
+
+func TestAccessControlController_simulateRemovePermissions_역할이_없는_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{"roleId": 100, "removePermissions": ["MANAGE_MEMBERS"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/access-control/roles/simulate", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ACCESS_CONTROL",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,97 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type AccessLogController struct {
+	routerGroup            *gin.RouterGroup
+	usageStatisticsService *services.UsageStatisticsService
+}
+
+func NewAccessLogController(
+	routerGroup *gin.RouterGroup,
+	usageStatisticsService *services.UsageStatisticsService) *AccessLogController {
+
+	return &AccessLogController{
+		routerGroup:            routerGroup,
+		usageStatisticsService: usageStatisticsService,
+	}
+}
+
+func (c AccessLogController) MapRoutes() {
+	route := c.routerGroup.Group("/access-logs")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionViewMonitoring}),
+		etag.HttpEtagCache(0),
+		c.getAccessLogs)
+}
+
+func (c AccessLogController) getAccessLogs(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	filters := map[string]interface{}{}
+
+	if len(ctx.Query("memberId")) > 0 {
+		memberId, err := strconv.ParseUint(ctx.Query("memberId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters["memberId"] = uint(memberId)
+	}
+
+	if len(ctx.Query("succeeded")) > 0 {
+		succeeded, err := strconv.ParseBool(ctx.Query("succeeded"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters["succeeded"] = succeeded
+	}
+
+	if len(ctx.Query("countries")) > 0 {
+		filters["countries"] = strings.Split(ctx.Query("countries"), ",")
+	}
+
+	if len(ctx.Query("ipAddress")) > 0 {
+		filters["ipAddress"] = ctx.Query("ipAddress")
+	}
+
+	entities, totalCount, err := c.usageStatisticsService.GetAccessLogs(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var accessLogs = make([]dtos.AccessLog, 0)
+	for _, entity := range entities {
+		accessLogs = append(accessLogs, dtos.AccessLog{
+			Id:              entity.ID,
+			Method:          entity.Method,
+			MemberId:        entity.MemberId,
+			Succeeded:       entity.Succeeded,
+			FailureReason:   entity.FailureReason,
+			IpAddress:       entity.IpAddress,
+			Country:         entity.Country,
+			City:            entity.City,
+			Asn:             entity.Asn,
+			AsnOrganization: entity.AsnOrganization,
+			AttemptedAt:     entity.AttemptedAt,
+		})
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     accessLogs,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
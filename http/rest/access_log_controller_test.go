@@ -0,0 +1,176 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	auditDomain "better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	statisticsDomain "better-admin-backend-service/statistics/domain"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setUpTestGeoIpDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	os.WriteFile(path, []byte("network,country,city,asn,asnOrganization\n"+
+		"203.0.113.0/24,KR,Seoul,AS64500,Example Telecom\n"+
+		"198.51.100.0/24,US,New York,64501,Example Hosting\n"+
+		"2001:db8::/32,JP,Tokyo,64502,Example IPv6\n"), 0644)
+
+	databasePath := config.Config.GeoIp.DatabasePath
+	config.Config.GeoIp.DatabasePath = path
+	adapters.GeoIpAdapter().SetResolver(nil)
+
+	t.Cleanup(func() {
+		config.Config.GeoIp.DatabasePath = databasePath
+		adapters.GeoIpAdapter().SetResolver(nil)
+	})
+}
+
+func loginFrom(remoteAddr string, requestBody string) {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+}
+
+func TestAuthController_로그인_시도_위치_기록(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestGeoIpDatabase(t)
+
+	// when
+	loginFrom("203.0.113.10:1234", `{"id": "siteadm", "password": "123456"}`)
+	loginFrom("[2001:db8::1]:1234", `{"id": "siteadm", "password": "wrong"}`)
+	loginFrom("10.0.0.1:1234", `{"id": "siteadm", "password": "123456"}`)
+
+	// then
+	var attempts []statisticsDomain.LoginAttemptEntity
+	gormDB.Order("id").Find(&attempts)
+	assert.Equal(t, 3, len(attempts))
+	assert.Equal(t, "203.0.113.10", attempts[0].IpAddress)
+	assert.Equal(t, "KR", attempts[0].Country)
+	assert.Equal(t, "Seoul", attempts[0].City)
+	assert.Equal(t, uint(64500), attempts[0].Asn)
+	assert.Equal(t, "Example Telecom", attempts[0].AsnOrganization)
+	assert.Equal(t, "2001:db8::1", attempts[1].IpAddress)
+	assert.Equal(t, "JP", attempts[1].Country)
+	// 사설 IP 는 위치를 찾지 않는다.
+	assert.Equal(t, "10.0.0.1", attempts[2].IpAddress)
+	assert.Equal(t, "", attempts[2].Country)
+}
+
+func TestAuditLog_위치_기록(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestGeoIpDatabase(t)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/service-accounts", strings.NewReader(`{"name": "모니터링 수집기"}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "198.51.100.7:1234"
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var auditLog auditDomain.AuditLogEntity
+	gormDB.Where("action = ?", constants.AuditActionServiceAccountCreated).Order("id DESC").First(&auditLog)
+	assert.Equal(t, "198.51.100.7", auditLog.IpAddress)
+	assert.Equal(t, "US", auditLog.Country)
+	assert.Equal(t, "New York", auditLog.City)
+	assert.Equal(t, uint(64501), auditLog.Asn)
+}
+
+func TestAccessLogController_접근_기록_조회(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestGeoIpDatabase(t)
+
+	// given
+	loginFrom("203.0.113.10:1234", `{"id": "siteadm", "password": "123456"}`)
+	loginFrom("198.51.100.7:1234", `{"id": "siteadm", "password": "wrong"}`)
+	loginFrom("198.51.100.8:1234", `{"id": "nobody", "password": "123456"}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/access-logs?countries=US&memberId=1", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalCount"])
+	accessLog := actual["result"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "198.51.100.7", accessLog["ipAddress"])
+	assert.Equal(t, "US", accessLog["country"])
+	assert.Equal(t, false, accessLog["succeeded"])
+	assert.Equal(t, constants.LoginFailureReasonInvalidCredential, accessLog["failureReason"])
+	assert.Equal(t, float64(64501), accessLog["asn"])
+}
+
+func TestAccessLogController_접근_기록_조회_권한_없음(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/access-logs", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestAuthController_신뢰하는_프록시의_클라이언트_IP(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	ginApp.SetTrustedProxies([]string{"192.0.2.0/24"})
+	defer ginApp.SetTrustedProxies(config.Config.TrustedProxy.Cidrs)
+
+	// given
+	for _, remoteAddr := range []string{"192.0.2.1:1234", "198.51.100.1:1234"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(`{"id": "siteadm", "password": "123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", "203.0.113.10, 192.0.2.7")
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+	}
+
+	// then
+	var attempts []statisticsDomain.LoginAttemptEntity
+	gormDB.Order("id").Find(&attempts)
+	assert.Equal(t, 2, len(attempts))
+	assert.Equal(t, "203.0.113.10", attempts[0].IpAddress)
+	// 신뢰하지 않는 프록시가 보낸 헤더는 무시한다.
+	assert.Equal(t, "198.51.100.1", attempts[1].IpAddress)
+}
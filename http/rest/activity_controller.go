@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// activityEntityRoutes 는 활동 피드를 조회할 수 있는 대상(경로, 감사 대상 유형, 권한)이다.
+var activityEntityRoutes = []struct {
+	path       string
+	entityType string
+	permission string
+}{
+	{"/service-accounts", constants.AuditTargetTypeServiceAccount, constants.PermissionManageSystemSettings},
+	{"/oauth-clients", constants.AuditTargetTypeOAuthClient, constants.PermissionManageSystemSettings},
+	{"/approvals", constants.AuditTargetTypeApproval, constants.PermissionManageSystemSettings},
+}
+
+type ActivityController struct {
+	routerGroup  *gin.RouterGroup
+	auditService *services.AuditService
+}
+
+func NewActivityController(
+	routerGroup *gin.RouterGroup,
+	auditService *services.AuditService) *ActivityController {
+
+	return &ActivityController{
+		routerGroup:  routerGroup,
+		auditService: auditService,
+	}
+}
+
+func (c ActivityController) MapRoutes() {
+	c.routerGroup.GET("/members/:id/activity", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		etag.HttpEtagCache(0),
+		c.getMemberActivities)
+
+	for _, route := range activityEntityRoutes {
+		c.routerGroup.GET(route.path+"/:id/activity", middlewares.PermissionChecker([]string{route.permission}),
+			etag.HttpEtagCache(0),
+			c.getEntityActivities(route.entityType))
+	}
+}
+
+func (c ActivityController) getMemberActivities(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entities, totalCount, err := c.auditService.GetMemberActivities(ctx.Request.Context(), uint(memberId),
+		c.getEventTypes(ctx), dtos.NewPageableFromRequest(ctx))
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toPageResult(entities, totalCount))
+}
+
+func (c ActivityController) getEntityActivities(entityType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		entityId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		entities, totalCount, err := c.auditService.GetEntityActivities(ctx.Request.Context(), entityType, uint(entityId),
+			c.getEventTypes(ctx), dtos.NewPageableFromRequest(ctx))
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, c.toPageResult(entities, totalCount))
+	}
+}
+
+// getEventTypes 는 eventTypes 쿼리(쉼표 구분, 예. login,approval)로 활동 유형을 거른다.
+func (ActivityController) getEventTypes(ctx *gin.Context) []string {
+	if len(ctx.Query("eventTypes")) == 0 {
+		return nil
+	}
+
+	return strings.Split(ctx.Query("eventTypes"), ",")
+}
+
+func (ActivityController) toPageResult(entities []domain.ActivityFeedEntity, totalCount int64) dtos.PageResult {
+	var activities = make([]dtos.ActivityFeedItem, 0)
+	for _, entity := range entities {
+		activities = append(activities, dtos.ActivityFeedItem{
+			Id:         entity.ID,
+			EventType:  entity.EventType,
+			Action:     entity.Action,
+			ActorType:  entity.ActorType,
+			ActorId:    entity.ActorId,
+			EntityType: entity.EntityType,
+			EntityId:   entity.EntityId,
+			Summary:    entity.Summary,
+			Detail:     entity.Detail,
+			OccurredAt: entity.OccurredAt,
+		})
+	}
+
+	return dtos.PageResult{
+		Result:     activities,
+		TotalCount: totalCount,
+	}
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getTestActivities(t *testing.T, url string, permission string) map[string]interface{} {
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{permission},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual
+}
+
+func TestActivityController_getMemberActivities(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{"id": "siteadm", "password": "123456"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// when
+	actual := getTestActivities(t, "/api/members/1/activity?page=1&pageSize=10", constants.PermissionManageMembers)
+
+	// then
+	assert.Equal(t, float64(3), actual["totalCount"])
+	activities := actual["result"].([]interface{})
+	// 최근 활동이 먼저 조회된다.
+	latest := activities[0].(map[string]interface{})
+	assert.Equal(t, constants.ActivityEventTypeLogin, latest["eventType"])
+	assert.Equal(t, "로그인했습니다.", latest["summary"])
+}
+
+func TestActivityController_getMemberActivities_유형_필터(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	actual := getTestActivities(t, "/api/members/1/activity?eventTypes=audit,approval", constants.PermissionManageMembers)
+
+	// then
+	assert.Equal(t, float64(1), actual["totalCount"])
+	activity := actual["result"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, constants.AuditActionServiceAccountCreated, activity["action"])
+}
+
+func TestActivityController_getEntityActivities(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/service-accounts/1/api-key", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// when
+	actual := getTestActivities(t, "/api/service-accounts/1/activity", constants.PermissionManageSystemSettings)
+
+	// then
+	assert.Equal(t, float64(2), actual["totalCount"])
+	activity := actual["result"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, constants.AuditActionApiKeyIssued, activity["action"])
+	assert.Equal(t, "API Key 를 발급했습니다.", activity["summary"])
+}
+
+func TestActivityController_getEntityActivities_권한_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/service-accounts/1/activity", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,274 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/approval/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	fileDomain "better-admin-backend-service/file/domain"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type ApprovalController struct {
+	routerGroup     *gin.RouterGroup
+	approvalService *services.ApprovalService
+	fileService     *services.FileService
+}
+
+func NewApprovalController(
+	routerGroup *gin.RouterGroup,
+	approvalService *services.ApprovalService,
+	fileService *services.FileService) *ApprovalController {
+
+	return &ApprovalController{
+		routerGroup:     routerGroup,
+		approvalService: approvalService,
+		fileService:     fileService,
+	}
+}
+
+func (c ApprovalController) MapRoutes() {
+	route := c.routerGroup.Group("/approvals")
+	route.GET("", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getDecidableApprovals)
+	route.GET("/:id", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getApproval)
+	route.PUT("/:id/approved", middlewares.PermissionChecker([]string{"*"}),
+		c.approve)
+	route.PUT("/:id/rejected", middlewares.PermissionChecker([]string{"*"}),
+		c.reject)
+	route.GET("/:id/attachments", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getAttachments)
+	route.POST("/:id/attachments", middlewares.PermissionChecker([]string{"*"}),
+		c.addAttachments)
+	route.GET("/:id/attachments/:attachmentId/content", middlewares.PermissionChecker([]string{"*"}),
+		c.getAttachmentContent)
+}
+
+// getDecidableApprovals 는 로그인한 멤버가 승인할 수 있는 승인 요청 목록을 반환한다.
+func (c ApprovalController) getDecidableApprovals(ctx *gin.Context) {
+	entities, err := c.approvalService.GetDecidableRequests(ctx.Request.Context())
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	var approvals = make([]dtos.ApprovalRequestInformation, 0)
+	for _, entity := range entities {
+		approvals = append(approvals, c.toApprovalRequestInformation(entity))
+	}
+
+	ctx.JSON(http.StatusOK, approvals)
+}
+
+func (c ApprovalController) getApproval(ctx *gin.Context) {
+	approvalId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.approvalService.GetApprovalRequest(ctx.Request.Context(), uint(approvalId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toApprovalRequestInformation(entity))
+}
+
+func (c ApprovalController) approve(ctx *gin.Context) {
+	approvalId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var comment dtos.ApprovalComment
+	if err := ctx.BindJSON(&comment); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.approvalService.ApproveRequest(ctx.Request.Context(), uint(approvalId), comment.Comment); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c ApprovalController) reject(ctx *gin.Context) {
+	approvalId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var comment dtos.ApprovalComment
+	if err := ctx.BindJSON(&comment); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.approvalService.RejectRequest(ctx.Request.Context(), uint(approvalId), comment.Comment); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getAttachments 는 요청자 또는 현재 단계의 승인자만 조회할 수 있다.
+func (c ApprovalController) getAttachments(ctx *gin.Context) {
+	approvalId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := c.approvalService.GetApprovalRequest(ctx.Request.Context(), uint(approvalId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	attachments, err := c.fileService.GetAttachments(ctx.Request.Context(), constants.AuditTargetTypeApproval, []uint{uint(approvalId)})
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, fileDomain.ToAttachmentInformations(attachments))
+}
+
+// addAttachments 는 신분 확인, 위임장 등 업로드한 파일(purpose: attachment)을 승인 요청에 첨부한다.
+func (c ApprovalController) addAttachments(ctx *gin.Context) {
+	approvalId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var request dtos.AttachmentRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := c.approvalService.GetApprovalRequest(ctx.Request.Context(), uint(approvalId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	attachments, err := c.fileService.AttachFiles(ctx.Request.Context(), constants.AuditTargetTypeApproval, uint(approvalId), request.FileIds)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, fileDomain.ToAttachmentInformations(attachments))
+}
+
+func (c ApprovalController) getAttachmentContent(ctx *gin.Context) {
+	approvalId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachmentId, err := strconv.ParseInt(ctx.Param("attachmentId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := c.approvalService.GetApprovalRequest(ctx.Request.Context(), uint(approvalId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	attachment, content, err := c.fileService.OpenAttachmentContent(ctx.Request.Context(), constants.AuditTargetTypeApproval, uint(approvalId), uint(attachmentId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	writeAttachmentContent(ctx, attachment, content)
+}
+
+func (c ApprovalController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	// 직접 승인/반려(ErrSelfReview)도 ErrForbidden 이며 코드로 구분한다.
+	if errors.Is(err, errors.ErrForbidden) || errors.Is(err, errors.ErrQuarantined) {
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	// 서비스 계정은 승인자가 아니다.
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidFile); ok {
+		ctx.JSON(http.StatusBadRequest, e.Error())
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidRoleElevation); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrNonChangeable) || errors.Is(err, errors.ErrDuplicated) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if handleSuperAdminProtectionError(ctx, err) {
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+func (ApprovalController) toApprovalRequestInformation(entity domain.ApprovalRequestEntity) dtos.ApprovalRequestInformation {
+	decisions := make([]dtos.ApprovalDecision, 0)
+	for _, decision := range entity.Decisions {
+		decisions = append(decisions, dtos.ApprovalDecision{
+			Step:       decision.Step,
+			ApproverId: decision.ApproverId,
+			OnBehalfOf: decision.OnBehalfOf,
+			Decision:   decision.Decision,
+			Comment:    decision.Comment,
+			DecidedAt:  decision.CreatedAt,
+		})
+	}
+
+	return dtos.ApprovalRequestInformation{
+		Id:          entity.ID,
+		Subject:     entity.Subject,
+		TargetId:    entity.TargetId,
+		Status:      entity.Status,
+		CurrentStep: entity.CurrentStep,
+		Steps:       entity.GetSteps(),
+		RequestedBy: entity.RequestedBy,
+		Escalated:   entity.EscalatedAt != nil,
+		Decisions:   decisions,
+		CreatedAt:   entity.CreatedAt,
+	}
+}
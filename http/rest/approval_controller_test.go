@@ -0,0 +1,317 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setUpRoleGrantApprovalWorkflow(t *testing.T) {
+	requestBody := `{
+		"workflows": [{
+			"subject": "role-grant",
+			"steps": [
+				{"name": "멤버 관리자 승인", "approverRoleName": "MEMBER MANAGER", "requiredApprovals": 1},
+				{"name": "시스템 관리자 승인", "approverRoleName": "SYSTEM MANAGER", "requiredApprovals": 1}
+			]
+		}]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/approval-workflow", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func decideApproval(id uint, decision string, claim map[string]interface{}) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/approvals/%v/%v", id, decision), strings.NewReader(`{"comment": "확인"}`))
+	token, _ := generateTestJWT(claim, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestApprovalController_역할_할당_다단계_승인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+	setUpRoleGrantApprovalWorkflow(t)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/members/4/assign-roles", strings.NewReader(`{"roleIds": [3]}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'role-grant' AND target_id = 4").Scan(&approvalId)
+	assert.NotZero(t, approvalId)
+
+	// 승인 전에는 역할이 할당되지 않는다.
+	var roleCount int64
+	gormDB.Raw("SELECT count(*) FROM member_roles WHERE member_entity_id = 4").Scan(&roleCount)
+	assert.Equal(t, int64(0), roleCount)
+
+	// 첫 단계 승인자가 아니면 승인할 수 없다.
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          3,
+		"Roles":       []string{"테스트 관리자"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	gormDB.Raw("SELECT count(*) FROM member_roles WHERE member_entity_id = 4").Scan(&roleCount)
+	assert.Equal(t, int64(0), roleCount)
+
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          1,
+		"Roles":       []string{"SYSTEM MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var status string
+	gormDB.Raw("SELECT status FROM approval_requests WHERE id = ?", approvalId).Scan(&status)
+	assert.Equal(t, constants.ApprovalStatusApproved, status)
+
+	var roleId uint
+	gormDB.Raw("SELECT role_entity_id FROM member_roles WHERE member_entity_id = 4").Scan(&roleId)
+	assert.Equal(t, uint(3), roleId)
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = ?", constants.AuditActionApprovalApproved, approvalId).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestApprovalController_반려(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := decideApproval(1, "rejected", map[string]interface{}{
+		"Id":          3,
+		"Roles":       []string{"테스트 관리자"},
+		"Permissions": []string{},
+	})
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var status string
+	gormDB.Raw("SELECT status FROM approval_requests WHERE id = 1").Scan(&status)
+	assert.Equal(t, constants.ApprovalStatusRejected, status)
+
+	var roleCount int64
+	gormDB.Raw("SELECT count(*) FROM member_roles WHERE member_entity_id = 3").Scan(&roleCount)
+	assert.Equal(t, int64(0), roleCount)
+
+	// 이미 처리된 요청은 다시 처리할 수 없다.
+	rec = decideApproval(1, "approved", map[string]interface{}{
+		"Id":          3,
+		"Roles":       []string{"테스트 관리자"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestApprovalController_승인_대기_목록(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/approvals", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          3,
+		"Roles":       []string{"테스트 관리자"},
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual []map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, "role-grant", actual[0]["subject"])
+
+	// 승인 역할이 없으면 목록에 포함되지 않는다.
+	req = httptest.NewRequest(http.MethodGet, "/api/approvals", nil)
+	token, _ = generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 0, len(actual))
+}
+
+func TestApprovalService_기한이_지난_승인_요청_이관(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+
+	// when
+	err := NewContainer().ApprovalService.ProcessOverdueApprovals(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.Nil(t, err)
+
+	var escalated int64
+	gormDB.Raw("SELECT count(*) FROM approval_requests WHERE id = 1 AND escalated_at IS NOT NULL AND reminded_at IS NOT NULL").Scan(&escalated)
+	assert.Equal(t, int64(1), escalated)
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ?", constants.AuditActionApprovalEscalated).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+
+	// 이관되면 상위 승인 역할도 승인할 수 있다.
+	rec := decideApproval(1, "approved", map[string]interface{}{
+		"Id":          1,
+		"Roles":       []string{"SYSTEM MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var roleId uint
+	gormDB.Raw("SELECT role_entity_id FROM member_roles WHERE member_entity_id = 3").Scan(&roleId)
+	assert.Equal(t, uint(3), roleId)
+}
+
+func requestTestApprovalAttachment(method, url string, body string, claim map[string]interface{}) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	token, _ := generateTestJWT(claim, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func uploadTestAttachment(t *testing.T, memberId int, fileName string, content []byte) uint {
+	rec := uploadTestFile(memberId, []string{}, constants.FilePurposeAttachment, fileName, content)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var file dtos.FileInformation
+	json.Unmarshal(rec.Body.Bytes(), &file)
+	return file.Id
+}
+
+func TestApprovalController_첨부_파일(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+	requester := map[string]interface{}{"Id": 2, "Permissions": []string{}}
+	approver := map[string]interface{}{"Id": 3, "Roles": []string{"테스트 관리자"}, "Permissions": []string{}}
+	other := map[string]interface{}{"Id": 4, "Permissions": []string{}}
+
+	// given
+	pdfContent := []byte("%PDF-1.4\n신분증 사본")
+	fileId := uploadTestAttachment(t, 2, "id-card.pdf", pdfContent)
+
+	// 다른 멤버가 업로드한 파일은 첨부할 수 없다.
+	rec := requestTestApprovalAttachment(http.MethodPost, "/api/approvals/1/attachments", fmt.Sprintf(`{"fileIds": [%v]}`, fileId), approver)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// when
+	rec = requestTestApprovalAttachment(http.MethodPost, "/api/approvals/1/attachments", fmt.Sprintf(`{"fileIds": [%v]}`, fileId), requester)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var attachments []dtos.AttachmentInformation
+	json.Unmarshal(rec.Body.Bytes(), &attachments)
+	assert.Equal(t, 1, len(attachments))
+	assert.Equal(t, "id-card.pdf", attachments[0].Name)
+	assert.NotNil(t, attachments[0].ExpiresAt)
+
+	// 요청자나 승인자가 아니면 조회할 수 없다.
+	rec = requestTestApprovalAttachment(http.MethodGet, "/api/approvals/1/attachments", "", other)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = requestTestApprovalAttachment(http.MethodGet, fmt.Sprintf("/api/approvals/1/attachments/%v/content", attachments[0].Id), "", other)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = requestTestApprovalAttachment(http.MethodGet, fmt.Sprintf("/api/approvals/1/attachments/%v/content", attachments[0].Id), "", approver)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, pdfContent, rec.Body.Bytes())
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment")
+
+	// 첨부 파일은 파일 API 로 내려받거나 삭제할 수 없다.
+	rec = requestTestApprovalAttachment(http.MethodGet, fmt.Sprintf("/api/files/%v/content", fileId), "", requester)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = requestTestApprovalAttachment(http.MethodDelete, fmt.Sprintf("/api/files/%v", fileId), "", requester)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_type = 'approval' AND target_id = 1 AND actor_id = 3",
+		constants.AuditActionAttachmentDownloaded).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestApprovalController_첨부_파일_보관_기간(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+	requester := map[string]interface{}{"Id": 2, "Permissions": []string{}}
+
+	fileId := uploadTestAttachment(t, 2, "form.pdf", []byte("%PDF-1.4\n위임장"))
+	rec := requestTestApprovalAttachment(http.MethodPost, "/api/approvals/1/attachments", fmt.Sprintf(`{"fileIds": [%v]}`, fileId), requester)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	gormDB.Exec("UPDATE attachments SET expires_at = ? WHERE file_id = ?", time.Now().Add(-time.Minute), fileId)
+
+	// when
+	err := NewContainer().FileService.DeleteExpiredAttachments(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.NoError(t, err)
+	var attachmentCount, fileCount int64
+	gormDB.Raw("SELECT count(*) FROM attachments WHERE deleted_at IS NULL").Scan(&attachmentCount)
+	gormDB.Raw("SELECT count(*) FROM files WHERE id = ? AND deleted_at IS NULL", fileId).Scan(&fileCount)
+	assert.Equal(t, int64(0), attachmentCount)
+	assert.Equal(t, int64(0), fileCount)
+}
@@ -0,0 +1,137 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/approval/domain"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type ApprovalDelegationController struct {
+	routerGroup       *gin.RouterGroup
+	delegationService *services.ApprovalDelegationService
+}
+
+func NewApprovalDelegationController(
+	routerGroup *gin.RouterGroup,
+	delegationService *services.ApprovalDelegationService) *ApprovalDelegationController {
+
+	return &ApprovalDelegationController{
+		routerGroup:       routerGroup,
+		delegationService: delegationService,
+	}
+}
+
+func (c ApprovalDelegationController) MapRoutes() {
+	route := c.routerGroup.Group("/members/me/approval-delegations")
+	route.GET("", middlewares.PermissionChecker([]string{"*"}),
+		c.getMyDelegations)
+	route.POST("", middlewares.PermissionChecker([]string{"*"}),
+		c.createDelegation)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{"*"}),
+		c.updateDelegation)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{"*"}),
+		c.deleteDelegation)
+}
+
+func (c ApprovalDelegationController) getMyDelegations(ctx *gin.Context) {
+	entities, err := c.delegationService.GetMyDelegations(ctx.Request.Context())
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	var delegations = make([]dtos.ApprovalDelegationInformation, 0)
+	for _, entity := range entities {
+		delegations = append(delegations, c.toApprovalDelegationInformation(entity))
+	}
+
+	ctx.JSON(http.StatusOK, delegations)
+}
+
+func (c ApprovalDelegationController) createDelegation(ctx *gin.Context) {
+	var information dtos.ApprovalDelegationInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.delegationService.CreateDelegation(ctx.Request.Context(), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.toApprovalDelegationInformation(entity))
+}
+
+func (c ApprovalDelegationController) updateDelegation(ctx *gin.Context) {
+	delegationId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.ApprovalDelegationInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.delegationService.UpdateDelegation(ctx.Request.Context(), uint(delegationId), information); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c ApprovalDelegationController) deleteDelegation(ctx *gin.Context) {
+	delegationId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.delegationService.DeleteDelegation(ctx.Request.Context(), uint(delegationId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (ApprovalDelegationController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrInvalidPeriod) || errors.Is(err, errors.ErrNonChangeable) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// 서비스 계정은 위임하거나 위임 받을 수 없다.
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+func (ApprovalDelegationController) toApprovalDelegationInformation(entity domain.ApprovalDelegationEntity) dtos.ApprovalDelegationInformation {
+	return dtos.ApprovalDelegationInformation{
+		Id:         entity.ID,
+		DelegateId: entity.DelegateId,
+		StartsAt:   entity.StartsAt,
+		EndsAt:     entity.EndsAt,
+		Reason:     entity.Reason,
+	}
+}
@@ -0,0 +1,197 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTestApprovalDelegation(t *testing.T, delegatorId uint, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/members/me/approval-delegations", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          delegatorId,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	assert.Nil(t, err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestApprovalDelegationController_위임_받은_멤버가_대신_승인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+	setUpRoleGrantApprovalWorkflow(t)
+
+	// given
+	requestBody := fmt.Sprintf(`{
+		"delegateId": 3,
+		"startsAt": "%s",
+		"endsAt": "%s",
+		"reason": "휴가"
+	}`, time.Now().Add(-time.Hour).Format(time.RFC3339), time.Now().Add(24*time.Hour).Format(time.RFC3339))
+	rec := createTestApprovalDelegation(t, 2, requestBody)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/4/assign-roles", strings.NewReader(`{"roleIds": [3]}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'role-grant' AND target_id = 4").Scan(&approvalId)
+
+	// 위임 받은 멤버의 승인 대기 목록에 포함된다.
+	req = httptest.NewRequest(http.MethodGet, "/api/approvals", nil)
+	token, _ = generateTestJWT(map[string]interface{}{
+		"Id":          3,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	var approvals []map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &approvals)
+	assert.Equal(t, 1, len(approvals))
+
+	// when
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          3,
+		"Permissions": []string{},
+	})
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var onBehalfOf uint
+	gormDB.Raw("SELECT on_behalf_of FROM approval_decisions WHERE approval_request_id = ? AND approver_id = 3", approvalId).Scan(&onBehalfOf)
+	assert.Equal(t, uint(2), onBehalfOf)
+
+	var detail string
+	gormDB.Raw("SELECT detail FROM audit_logs WHERE action = ? AND actor_id = 3", constants.AuditActionApprovalStepApproved).Scan(&detail)
+	assert.Contains(t, detail, "onBehalfOf=2")
+}
+
+func TestApprovalDelegationController_기간이_지난_위임(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// 멤버 1 의 위임은 이미 끝났으므로 멤버 3 이 대신 승인할 수 없다.
+	rec := decideApproval(1, "approved", map[string]interface{}{
+		"Id":          3,
+		"Roles":       []string{},
+		"Permissions": []string{},
+	})
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestApprovalDelegationController_위임_목록과_삭제(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/members/me/approval-delegations", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var delegations []map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &delegations)
+	assert.Equal(t, 1, len(delegations))
+	assert.Equal(t, float64(3), delegations[0]["delegateId"])
+
+	// 다른 멤버의 위임은 삭제할 수 없다.
+	req = httptest.NewRequest(http.MethodDelete, "/api/members/me/approval-delegations/1", nil)
+	otherToken, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", otherToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/api/members/me/approval-delegations/1", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestApprovalDelegationController_Bad_Request_잘못된_기간(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := fmt.Sprintf(`{
+		"delegateId": 3,
+		"startsAt": "%s",
+		"endsAt": "%s"
+	}`, time.Now().Format(time.RFC3339), time.Now().Add(-time.Hour).Format(time.RFC3339))
+
+	// when
+	rec := createTestApprovalDelegation(t, 2, requestBody)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestApprovalDelegationController_서비스_계정은_위임할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	// 서비스 계정의 Id(1)는 위임한 멤버(1)의 Id 와 같다.
+	rec := requestAsServiceAccount(http.MethodGet, "/api/members/me/approval-delegations", "", 1, []string{})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = requestAsServiceAccount(http.MethodPost, "/api/members/me/approval-delegations", fmt.Sprintf(`{
+		"delegateId": 3,
+		"startsAt": "%s",
+		"endsAt": "%s"
+	}`, time.Now().Format(time.RFC3339), time.Now().Add(24*time.Hour).Format(time.RFC3339)), 1, []string{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = requestAsServiceAccount(http.MethodDelete, "/api/members/me/approval-delegations/1", "", 1, []string{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var delegationCount int64
+	gormDB.Raw("SELECT COUNT(*) FROM approval_delegations").Scan(&delegationCount)
+	assert.Equal(t, int64(1), delegationCount)
+
+	// 서비스 계정은 승인자가 아니다.
+	rec = requestAsServiceAccount(http.MethodGet, "/api/approvals", "", 1, []string{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
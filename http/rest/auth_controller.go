@@ -1,95 +1,127 @@
 package rest
 
 import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/application"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/security"
 	"better-admin-backend-service/services"
-	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	pkgerrors "github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	"net/http"
+	"strings"
 	"time"
 )
 
 type AuthController struct {
-	routerGroup   *gin.RouterGroup
-	authService   *services.AuthService
-	memberService *services.MemberService
+	routerGroup               *gin.RouterGroup
+	authUseCase               *application.AuthUseCase
+	tokenService              *services.TokenService
+	oauthAuthorizationService *services.OAuthAuthorizationService
 }
 
 func NewAuthController(
 	routerGroup *gin.RouterGroup,
-	authService *services.AuthService,
-	memberService *services.MemberService) *AuthController {
+	authUseCase *application.AuthUseCase,
+	tokenService *services.TokenService,
+	oauthAuthorizationService *services.OAuthAuthorizationService) *AuthController {
 
 	return &AuthController{
-		routerGroup:   routerGroup,
-		authService:   authService,
-		memberService: memberService,
+		routerGroup:               routerGroup,
+		authUseCase:               authUseCase,
+		tokenService:              tokenService,
+		oauthAuthorizationService: oauthAuthorizationService,
 	}
 }
 
 func (c AuthController) MapRoutes() {
 	route := c.routerGroup.Group("/auth")
 
-	route.POST("", c.authWithSignIdPassword)
-	route.POST("/dooray", c.authWithDoorayIdPassword)
-	route.GET("/google-workspace", c.authWithGoogleWorkspaceAccount)
-	route.GET("/check", c.checkAuth)
-	route.POST("/logout", c.logout)
-	route.POST("/token/refresh", c.refreshAccessToken)
+	route.POST("", middlewares.Public(), c.authWithSignIdPassword)
+	route.POST("/dooray", middlewares.Public(), c.authWithDoorayIdPassword)
+	route.GET("/google-workspace", middlewares.Public(), c.authWithGoogleWorkspaceAccount)
+	route.GET("/google-workspace/start", middlewares.Public(), c.startGoogleWorkspaceAuth)
+	route.POST("/custom/:name", middlewares.Public(), c.authWithCustomAuthenticator)
+	route.POST("/break-glass", middlewares.Public(), c.authWithBreakGlassAccount)
+	route.GET("/check", middlewares.Public(), c.checkAuth)
+	route.POST("/logout", middlewares.Public(), c.logout)
+	route.POST("/token/refresh", middlewares.Public(), c.refreshAccessToken)
+	route.POST("/token", middlewares.Public(), c.issueToken)
+	route.POST("/token/introspect", middlewares.Public(), c.introspectToken)
+	route.POST("/token/revoke", middlewares.Public(), c.revokeToken)
+	route.POST("/device/code", middlewares.Public(), c.startDeviceAuthorization)
+	route.POST("/scoped-tokens", middlewares.PermissionChecker([]string{"*"}),
+		c.issueScopedToken)
+	route.POST("/step-up", middlewares.PermissionChecker([]string{"*"}),
+		c.stepUp)
+	route.POST("/permissions/check", middlewares.PermissionChecker([]string{"*"}),
+		c.checkPermissions)
 }
 
-func (c AuthController) authWithSignIdPassword(ctx *gin.Context) {
-	var memberSignIn dtos.MemberSignIn
-
-	if err := ctx.BindJSON(&memberSignIn); err != nil {
+// checkPermissions 는 화면에 보여줄 버튼을 정하기 위해 요청한 사용자의 권한과 API 호출 가능 여부를 한 번에 확인한다.
+func (c AuthController) checkPermissions(ctx *gin.Context) {
+	var request dtos.PermissionCheckRequest
+	if err := ctx.BindJSON(&request); err != nil {
 		ctx.JSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	jwtToken, err := c.authService.AuthWithSignIdPassword(ctx.Request.Context(), memberSignIn)
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
 	if err != nil {
-		if err == errors.ErrNotFound || err == errors.ErrAuthentication {
-			ctx.JSON(http.StatusBadRequest, err.Error())
-			return
-		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
 
-		if err == errors.ErrUnApproved {
-			ctx.JSON(http.StatusNotAcceptable, err.Error())
-			return
+	results := make([]dtos.PermissionCheckResult, 0, len(request.Checks))
+	for _, check := range request.Checks {
+		allowed := true
+		if len(check.Permission) > 0 {
+			allowed = hasClaimPermission(userClaim, check.Permission)
+		}
+		if allowed && len(check.Resource) > 0 {
+			// API 는 "METHOD 경로" 형식이고, 없는 API 는 허용하지 않는다.
+			method, path, ok := strings.Cut(strings.TrimSpace(check.Resource), " ")
+			rule, found := middlewares.FindAuthorizationRule(c.routerGroup.BasePath(), strings.ToUpper(method), strings.TrimSpace(path))
+			allowed = ok && found && middlewares.IsAuthorized(rule, userClaim.Permissions)
 		}
 
-		helpers.ErrorHelper().InternalServerError(ctx, err)
-		return
+		results = append(results, dtos.PermissionCheckResult{Permission: check.Permission, Resource: check.Resource, Allowed: allowed})
 	}
 
-	refreshToken, err := ctx.Request.Cookie("refreshToken")
-	if err != nil || len(refreshToken.Value) == 0 {
-		cookie := new(http.Cookie)
-		cookie.Name = "refreshToken"
-		cookie.Value = jwtToken.RefreshToken
-		cookie.HttpOnly = true
-		cookie.Path = "/"
-		cookie.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+	ctx.JSON(http.StatusOK, results)
+}
 
-		http.SetCookie(ctx.Writer, cookie)
-	} else {
-		refreshToken.Value = jwtToken.RefreshToken
-		refreshToken.HttpOnly = true
-		refreshToken.Path = "/"
-		refreshToken.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+func hasClaimPermission(userClaim *security.UserClaim, permission string) bool {
+	for _, claimPermission := range userClaim.Permissions {
+		if claimPermission == permission {
+			return true
+		}
+	}
 
-		http.SetCookie(ctx.Writer, refreshToken)
+	return false
+}
+
+func (c AuthController) authWithSignIdPassword(ctx *gin.Context) {
+	var memberSignIn dtos.MemberSignIn
+
+	if err := ctx.BindJSON(&memberSignIn); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
 	}
 
-	result := map[string]string{}
-	result["accessToken"] = jwtToken.AccessToken
+	output, err := c.authUseCase.SignInWithPassword(ctx.Request.Context(), memberSignIn)
+	if err != nil {
+		respondFailure(ctx, err)
+		return
+	}
 
-	ctx.JSON(http.StatusOK, result)
+	c.respondSignIn(ctx, output)
 }
 
 func (c AuthController) authWithDoorayIdPassword(ctx *gin.Context) {
@@ -100,103 +132,180 @@ func (c AuthController) authWithDoorayIdPassword(ctx *gin.Context) {
 		return
 	}
 
-	jwtToken, err := c.authService.AuthWithDoorayIdAndPassword(ctx.Request.Context(), memberSignIn)
+	output, err := c.authUseCase.SignInWithDooray(ctx.Request.Context(), memberSignIn)
 	if err != nil {
-		if err == errors.ErrAuthentication {
-			ctx.JSON(http.StatusBadRequest, err.Error())
-			return
-		}
+		respondFailure(ctx, err)
+		return
+	}
 
-		helpers.ErrorHelper().InternalServerError(ctx, err)
+	c.respondSignIn(ctx, output)
+}
+
+func (c AuthController) authWithCustomAuthenticator(ctx *gin.Context) {
+	var credentials map[string]string
+
+	if err := ctx.BindJSON(&credentials); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
 		return
 	}
 
-	refreshToken, err := ctx.Request.Cookie("refreshToken")
-	if err != nil || len(refreshToken.Value) == 0 {
-		cookie := new(http.Cookie)
-		cookie.Name = "refreshToken"
-		cookie.Value = jwtToken.RefreshToken
-		cookie.HttpOnly = true
-		cookie.Path = "/"
-		cookie.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+	output, err := c.authUseCase.SignInWithCustomAuthenticator(ctx.Request.Context(), application.CustomSignInInput{
+		AuthenticatorName: ctx.Param("name"),
+		Credentials:       credentials,
+	})
+	if err != nil {
+		respondFailure(ctx, err)
+		return
+	}
 
-		http.SetCookie(ctx.Writer, cookie)
-	} else {
-		refreshToken.Value = jwtToken.RefreshToken
-		refreshToken.HttpOnly = true
-		refreshToken.Path = "/"
-		refreshToken.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+	c.respondSignIn(ctx, output)
+}
 
-		http.SetCookie(ctx.Writer, refreshToken)
+func (c AuthController) authWithBreakGlassAccount(ctx *gin.Context) {
+	var signIn dtos.BreakGlassSignIn
+
+	if err := ctx.BindJSON(&signIn); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	output, err := c.authUseCase.SignInWithBreakGlass(ctx.Request.Context(), signIn)
+	if err != nil {
+		respondFailure(ctx, err)
+		return
+	}
+
+	c.respondSignIn(ctx, output)
+}
+
+// respondSignIn 은 Refresh 토큰을 쿠키에 저장하고 Access 토큰을 응답한다.
+func (AuthController) respondSignIn(ctx *gin.Context, output application.SignInOutput) {
+	if err := setRefreshTokenCookie(ctx, security.JwtToken{RefreshToken: output.RefreshToken, RefreshTokenExpires: output.RefreshTokenExpires}); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
 	}
 
 	result := map[string]string{}
-	result["accessToken"] = jwtToken.AccessToken
+	result["accessToken"] = output.AccessToken
 
 	ctx.JSON(http.StatusOK, result)
 }
 
-func (c AuthController) authWithGoogleWorkspaceAccount(ctx *gin.Context) {
-	code := ctx.Query("code")
-	redirect := ctx.Query("state")
+// startGoogleWorkspaceAuth 는 로그인 후 돌아갈 주소(redirect)를 담은 state 와 함께 구글 로그인 화면으로 보낸다.
+func (c AuthController) startGoogleWorkspaceAuth(ctx *gin.Context) {
+	oauthUri, err := c.authUseCase.StartGoogleWorkspaceAuth(ctx.Request.Context(), ctx.DefaultQuery("redirect", "/"))
+	if err != nil {
+		respondFailure(ctx, err)
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, oauthUri)
+}
 
-	jwtToken, err := c.authService.AuthWithGoogleWorkspaceAccount(ctx.Request.Context(), code)
+func (c AuthController) authWithGoogleWorkspaceAccount(ctx *gin.Context) {
+	output, err := c.authUseCase.CompleteGoogleWorkspaceAuth(ctx.Request.Context(), application.GoogleWorkspaceCallbackInput{
+		State: ctx.Query("state"),
+		Code:  ctx.Query("code"),
+	})
 	if err != nil {
-		if e, ok := err.(*errors.ErrInvalidGoogleWorkspaceAccount); ok {
-			ctx.Redirect(http.StatusFound, redirect+fmt.Sprintf("&error=%v 로 끝나는 메일 주소만 사용 가능 합니다", e.Domain))
+		if len(output.Redirect) == 0 {
+			respondFailure(ctx, err)
 			return
 		}
 
-		ctx.Redirect(http.StatusFound, redirect+"&error=server-internal-error")
+		ctx.Redirect(http.StatusFound, c.appendRedirectQuery(output.Redirect, "error="+c.googleWorkspaceAuthError(err)))
 		return
 	}
 
-	refreshToken, err := ctx.Request.Cookie("refreshToken")
-	if err != nil || len(refreshToken.Value) == 0 {
-		cookie := new(http.Cookie)
-		cookie.Name = "refreshToken"
-		cookie.Value = jwtToken.RefreshToken
-		cookie.HttpOnly = true
-		cookie.Path = "/"
-		cookie.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+	if output.IdentityLinked {
+		ctx.Redirect(http.StatusFound, c.appendRedirectQuery(output.Redirect, "identityLinked="+constants.LoginMethodGoogleWorkspace))
+		return
+	}
 
-		http.SetCookie(ctx.Writer, cookie)
-	} else {
-		refreshToken.Value = jwtToken.RefreshToken
-		refreshToken.HttpOnly = true
-		refreshToken.Path = "/"
-		refreshToken.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+	if err := setRefreshTokenCookie(ctx, security.JwtToken{RefreshToken: output.SignIn.RefreshToken, RefreshTokenExpires: output.SignIn.RefreshTokenExpires}); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
 
-		http.SetCookie(ctx.Writer, refreshToken)
+	ctx.Redirect(http.StatusFound, c.appendRedirectQuery(output.Redirect, "accessToken="+output.SignIn.AccessToken))
+}
+
+// googleWorkspaceAuthError 는 구글 로그인, 계정 연결에 실패한 이유를 돌아갈 주소의 error 쿼리로 알린다.
+func (AuthController) googleWorkspaceAuthError(err error) string {
+	var invalidAccount *errors.ErrInvalidGoogleWorkspaceAccount
+	if pkgerrors.As(err, &invalidAccount) {
+		return fmt.Sprintf("%v 로 끝나는 메일 주소만 사용 가능 합니다", invalidAccount.Error())
+	}
+
+	var preAuthDenied *errors.ErrPreAuthDenied
+	var licenseQuotaExceeded *errors.ErrLicenseQuotaExceeded
+	var invalidLicense *errors.ErrInvalidLicense
+	switch {
+	case errors.Is(err, errors.ErrUnApproved):
+		return "unapproved"
+	case errors.Is(err, errors.ErrSessionLimitExceeded):
+		return "session-limit-exceeded"
+	case pkgerrors.As(err, &preAuthDenied):
+		return "pre-auth-denied"
+	case errors.Is(err, errors.ErrLoginMethodNotAllowed):
+		return "login-method-not-allowed"
+	case pkgerrors.As(err, &licenseQuotaExceeded), pkgerrors.As(err, &invalidLicense):
+		return "license-quota-exceeded"
+	case errors.Is(err, errors.ErrDuplicated):
+		return "identity-duplicated"
 	}
 
-	ctx.Redirect(http.StatusFound, redirect+"&accessToken="+jwtToken.AccessToken)
+	return "server-internal-error"
 }
 
-func (AuthController) checkAuth(ctx *gin.Context) {
-	refreshToken, err := ctx.Request.Cookie("refreshToken")
-	if err != nil || len(refreshToken.Value) == 0 {
+func (AuthController) appendRedirectQuery(redirect string, query string) string {
+	if strings.Contains(redirect, "?") {
+		return redirect + "&" + query
+	}
+
+	return redirect + "?" + query
+}
+
+func (c AuthController) checkAuth(ctx *gin.Context) {
+	refreshToken, rotated, err := getRefreshTokenCookie(ctx)
+	if err != nil {
 		ctx.JSON(http.StatusNotAcceptable, nil)
 		return
 	}
 
-	jwtAuthentication := security.JwtAuthentication{}
-	if err := jwtAuthentication.ValidateToken(refreshToken.Value); err != nil {
+	status, err := c.authUseCase.CheckRefreshToken(refreshToken)
+	if err != nil {
 		log.Error(err)
-		ctx.JSON(http.StatusNotAcceptable, nil)
+		respondFailure(ctx, err)
 		return
 	}
 
+	// 이전 키로 암호화한 쿠키는 현재 키로 다시 암호화하여 내려준다.
+	if rotated {
+		if err := setRefreshTokenCookie(ctx, security.JwtToken{RefreshToken: refreshToken, RefreshTokenExpires: status.Expires}); err != nil {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+	}
+
 	ctx.Status(http.StatusNoContent)
 }
 
-func (AuthController) logout(ctx *gin.Context) {
+func (c AuthController) logout(ctx *gin.Context) {
 	cookie, err := ctx.Request.Cookie("refreshToken")
 	if err != nil {
 		ctx.JSON(http.StatusOK, nil)
 		return
 	}
 
+	// 복호화할 수 없는 쿠키는 세션을 찾을 수 없으므로 쿠키만 지운다.
+	if refreshToken, _, err := (security.CookieCodec{}).Decode(cookie.Name, cookie.Value); err == nil {
+		if err := c.authUseCase.Logout(ctx.Request.Context(), refreshToken); err != nil {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+	}
+
 	cookie.Value = ""
 	cookie.HttpOnly = true
 	cookie.Path = "/"
@@ -208,43 +317,327 @@ func (AuthController) logout(ctx *gin.Context) {
 }
 
 func (c AuthController) refreshAccessToken(ctx *gin.Context) {
-	cookie, err := ctx.Request.Cookie("refreshToken")
+	refreshToken, _, err := getRefreshTokenCookie(ctx)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, nil)
 		return
 	}
 
-	refreshToken := cookie.Value
-	jwtAuthentication := security.JwtAuthentication{}
-	accessToken, err := jwtAuthentication.RefreshAccessToken(refreshToken)
-
+	accessToken, err := c.authUseCase.RefreshAccessToken(ctx.Request.Context(), refreshToken)
 	if err != nil {
-		helpers.ErrorHelper().InternalServerError(ctx, err)
+		respondFailure(ctx, err)
 		return
 	}
 
-	err = c.logMemberAccessAtByToken(ctx.Request.Context(), refreshToken)
+	result := map[string]string{}
+	result["accessToken"] = accessToken
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c AuthController) issueScopedToken(ctx *gin.Context) {
+	var request dtos.ScopedTokenRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	scopedToken, err := c.tokenService.IssueScopedToken(ctx.Request.Context(), request)
 	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
 
-	result := map[string]string{}
-	result["accessToken"] = accessToken
-	ctx.JSON(http.StatusOK, result)
+	ctx.JSON(http.StatusCreated, scopedToken)
 }
 
-func (c AuthController) logMemberAccessAtByToken(ctx context.Context, token string) error {
-	jwtAuthentication := security.JwtAuthentication{}
-	userClaim, err := jwtAuthentication.ConvertTokenUserClaim(token)
+// stepUp 은 비밀번호를 다시 확인하고 단계 인증 토큰을 발급한다. 토큰은 X-Step-Up-Token 헤더로 보낸다.
+func (c AuthController) stepUp(ctx *gin.Context) {
+	var request dtos.StepUpRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stepUpToken, err := c.authUseCase.StepUp(ctx.Request.Context(), request)
 	if err != nil {
-		return err
+		respondFailure(ctx, err)
+		return
 	}
 
-	err = c.memberService.UpdateMemberLastAccessAt(ctx, userClaim.Id)
+	ctx.JSON(http.StatusCreated, stepUpToken)
+}
+
+// setRefreshTokenCookie 는 Refresh 토큰을 암호화하여 쿠키에 저장한다.
+func setRefreshTokenCookie(ctx *gin.Context, jwtToken security.JwtToken) error {
+	value, err := security.CookieCodec{}.Encode("refreshToken", jwtToken.RefreshToken)
 	if err != nil {
 		return err
 	}
 
+	refreshToken, err := ctx.Request.Cookie("refreshToken")
+	if err != nil || len(refreshToken.Value) == 0 {
+		cookie := new(http.Cookie)
+		cookie.Name = "refreshToken"
+		cookie.Value = value
+		cookie.HttpOnly = true
+		cookie.Secure = config.Config.RefreshTokenCookie.Secure
+		cookie.Path = "/"
+		cookie.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+
+		http.SetCookie(ctx.Writer, cookie)
+	} else {
+		refreshToken.Value = value
+		refreshToken.HttpOnly = true
+		refreshToken.Secure = config.Config.RefreshTokenCookie.Secure
+		refreshToken.Path = "/"
+		refreshToken.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+
+		http.SetCookie(ctx.Writer, refreshToken)
+	}
+
 	return nil
 }
+
+// getRefreshTokenCookie 는 쿠키에서 Refresh 토큰을 복호화한다. rotated 이면 이전 키로 암호화한 쿠키이다.
+func getRefreshTokenCookie(ctx *gin.Context) (string, bool, error) {
+	cookie, err := ctx.Request.Cookie("refreshToken")
+	if err != nil {
+		return "", false, err
+	}
+
+	return security.CookieCodec{}.Decode(cookie.Name, cookie.Value)
+}
+
+// issueToken 은 OAuth2 토큰 엔드포인트로 client_credentials, authorization_code, 기기 인가(RFC 8628)와 토큰 교환(RFC 8693) 그랜트를 지원한다.
+// 응답 형식은 RFC 6749 5.1, 5.2 를 따른다.
+func (c AuthController) issueToken(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+
+	var request dtos.ClientCredentialsTokenRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: err.Error()})
+		return
+	}
+
+	if request.GrantType != constants.OAuthGrantTypeClientCredentials && request.GrantType != constants.OAuthGrantTypeTokenExchange &&
+		request.GrantType != constants.OAuthGrantTypeAuthorizationCode && request.GrantType != constants.OAuthGrantTypeDeviceCode {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorUnsupportedGrantType})
+		return
+	}
+
+	if clientId, clientSecret, ok := ctx.Request.BasicAuth(); ok {
+		request.ClientId = clientId
+		request.ClientSecret = clientSecret
+	}
+
+	// 인가 코드 그랜트는 Secret 이 없는 공개 Client(예. SPA) 가 사용하므로 PKCE 로 코드를 요청한 Client 인지 확인한다.
+	if request.GrantType == constants.OAuthGrantTypeAuthorizationCode {
+		c.exchangeAuthorizationCode(ctx, request)
+		return
+	}
+
+	// 기기 인가 그랜트도 공개 Client(예. CLI 도구) 가 사용하며 멤버가 승인할 때까지 같은 기기 코드로 다시 요청한다.
+	if request.GrantType == constants.OAuthGrantTypeDeviceCode {
+		c.exchangeDeviceCode(ctx, request)
+		return
+	}
+
+	if len(request.ClientId) == 0 || len(request.ClientSecret) == 0 {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: "client_id and client_secret are required"})
+		return
+	}
+
+	var token dtos.OAuthToken
+	var err error
+	if request.GrantType == constants.OAuthGrantTypeTokenExchange {
+		if len(request.SubjectToken) == 0 || len(request.SubjectTokenType) == 0 || len(request.Audience) == 0 {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: "subject_token, subject_token_type and audience are required"})
+			return
+		}
+
+		token, err = c.tokenService.IssueExchangedToken(ctx.Request.Context(), request)
+	} else {
+		token, err = c.tokenService.IssueClientCredentialsToken(ctx.Request.Context(), request.ClientId, request.ClientSecret, request.Scope)
+	}
+
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: constants.OAuthErrorInvalidClient})
+			return
+		}
+
+		if errors.Is(err, errors.ErrInvalidScope) {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidScope})
+			return
+		}
+
+		if errors.Is(err, errors.ErrInvalidTarget) {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidTarget})
+			return
+		}
+
+		if errors.Is(err, errors.ErrInvalidSubjectToken) {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: err.Error()})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, token)
+}
+
+func (c AuthController) exchangeAuthorizationCode(ctx *gin.Context, request dtos.ClientCredentialsTokenRequest) {
+	if len(request.ClientId) == 0 || len(request.Code) == 0 || len(request.RedirectUri) == 0 {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: "client_id, code and redirect_uri are required"})
+		return
+	}
+
+	token, err := c.oauthAuthorizationService.ExchangeAuthorizationCode(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: constants.OAuthErrorInvalidClient})
+			return
+		}
+
+		if errors.Is(err, errors.ErrInvalidGrant) {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidGrant})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, token)
+}
+
+// introspectToken 은 토큰 조회 엔드포인트(RFC 7662)로 서비스 계정의 Client 인증이 필요하다.
+func (c AuthController) introspectToken(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+
+	request, ok := c.bindOAuthTokenRequest(ctx)
+	if !ok {
+		return
+	}
+
+	introspection, err := c.tokenService.IntrospectToken(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: constants.OAuthErrorInvalidClient})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, introspection)
+}
+
+// revokeToken 은 토큰 폐기 엔드포인트(RFC 7009)이다. 이미 사용할 수 없는 토큰도 200 으로 응답한다.
+func (c AuthController) revokeToken(ctx *gin.Context) {
+	request, ok := c.bindOAuthTokenRequest(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.tokenService.RevokeToken(ctx.Request.Context(), request); err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: constants.OAuthErrorInvalidClient})
+			return
+		}
+
+		if errors.Is(err, errors.ErrForbidden) {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorUnauthorizedClient})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (AuthController) bindOAuthTokenRequest(ctx *gin.Context) (dtos.OAuthTokenRequest, bool) {
+	var request dtos.OAuthTokenRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: err.Error()})
+		return request, false
+	}
+
+	if clientId, clientSecret, ok := ctx.Request.BasicAuth(); ok {
+		request.ClientId = clientId
+		request.ClientSecret = clientSecret
+	}
+
+	if len(request.ClientId) == 0 || len(request.ClientSecret) == 0 {
+		ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: constants.OAuthErrorInvalidClient})
+		return request, false
+	}
+
+	return request, true
+}
+
+// startDeviceAuthorization 은 기기 인가 엔드포인트(RFC 8628 3.1)이다. 멤버는 응답의 verification_uri 에서 user_code 를 입력하여 승인한다.
+func (c AuthController) startDeviceAuthorization(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+
+	var request dtos.DeviceAuthorizationRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: err.Error()})
+		return
+	}
+
+	deviceAuthorization, err := c.oauthAuthorizationService.StartDeviceAuthorization(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: constants.OAuthErrorInvalidClient})
+			return
+		}
+
+		if errors.Is(err, errors.ErrInvalidScope) {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidScope})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, deviceAuthorization)
+}
+
+func (c AuthController) exchangeDeviceCode(ctx *gin.Context, request dtos.ClientCredentialsTokenRequest) {
+	if len(request.ClientId) == 0 || len(request.DeviceCode) == 0 {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: "client_id and device_code are required"})
+		return
+	}
+
+	token, err := c.oauthAuthorizationService.ExchangeDeviceCode(ctx.Request.Context(), request)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, token)
+	case errors.Is(err, errors.ErrAuthorizationPending):
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorAuthorizationPending})
+	case errors.Is(err, errors.ErrSlowDown):
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorSlowDown})
+	case errors.Is(err, errors.ErrAccessDenied):
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorAccessDenied})
+	case errors.Is(err, errors.ErrExpired):
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorExpiredToken})
+	case errors.Is(err, errors.ErrInvalidGrant):
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidGrant})
+	case errors.Is(err, errors.ErrAuthentication):
+		ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: constants.OAuthErrorInvalidClient})
+	default:
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+	}
+}
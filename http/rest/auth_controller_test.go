@@ -1,15 +1,34 @@
 package rest
 
 import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/middlewares"
+	auditDomain "better-admin-backend-service/audit/domain"
 	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
 	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
+	sessionDomain "better-admin-backend-service/session/domain"
+	siteDomain "better-admin-backend-service/site/domain"
 	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"github.com/golang-jwt/jwt"
+	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/encoding/protowire"
+	"io"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -49,7 +68,8 @@ func Test_authWithSignIdPassword(t *testing.T) {
 	assert.True(t, strings.Contains(headerSetCookie, expires))
 	assert.True(t, strings.Contains(headerSetCookie, "HttpOnly"))
 
-	refreshToken := headerSetCookie[strings.Index(headerSetCookie, "refreshToken=")+len("refreshToken=") : strings.Index(headerSetCookie, ";")]
+	refreshToken, _, err := security.CookieCodec{}.Decode("refreshToken", headerSetCookie[strings.Index(headerSetCookie, "refreshToken=")+len("refreshToken="):strings.Index(headerSetCookie, ";")])
+	assert.Nil(t, err)
 	tokenUserClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(refreshToken)
 	assert.Equal(t, uint(1), tokenUserClaim.Id)
 }
@@ -92,6 +112,7 @@ func Test_authWithSignIdPassword_계정이_유효하지_않는_경우(t *testing
 	// then
 	fmt.Println(rec.Body.String())
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "MEMBER_NOT_FOUND", rec.Header().Get(middlewares.ErrorCodeHeader))
 }
 
 func Test_authWithSignIdPassword_비밀번호가_유효하지_않은_경우(t *testing.T) {
@@ -114,6 +135,7 @@ func Test_authWithSignIdPassword_비밀번호가_유효하지_않은_경우(t *t
 	// then
 	fmt.Println(rec.Body.String())
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "AUTH_FAILED", rec.Header().Get(middlewares.ErrorCodeHeader))
 }
 
 func Test_authWithSignIdPassword_미_승인_사용자(t *testing.T) {
@@ -136,6 +158,19 @@ func Test_authWithSignIdPassword_미_승인_사용자(t *testing.T) {
 	// then
 	fmt.Println(rec.Body.String())
 	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	assert.Equal(t, "MEMBER_UNAPPROVED", rec.Header().Get(middlewares.ErrorCodeHeader))
+}
+
+// startTestGoogleWorkspaceAuth 는 구글 로그인을 시작하여 콜백에 전달할 state 를 받는다.
+func startTestGoogleWorkspaceAuth(t *testing.T, redirect string) string {
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace/start?redirect="+url.QueryEscape(redirect), nil)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusFound, rec.Code)
+
+	location, err := url.Parse(rec.Header().Get("Location"))
+	assert.Nil(t, err)
+	return location.Query().Get("state")
 }
 
 func Test_authWithGoogleWorkspaceAccount(t *testing.T) {
@@ -178,13 +213,14 @@ func Test_authWithGoogleWorkspaceAccount(t *testing.T) {
 	defer server.Close()
 	serverPort := server.Listener.Addr().(*net.TCPAddr).Port
 
-	url := fmt.Sprintf("http://localhost:%v", serverPort)
-	config.Config.GoogleOAuth.AuthUri = url
-	config.Config.GoogleOAuth.TokenUri = url
+	serverUrl := fmt.Sprintf("http://localhost:%v", serverPort)
+	config.Config.GoogleOAuth.AuthUri = serverUrl
+	config.Config.GoogleOAuth.TokenUri = serverUrl
 
 	// given
 	code := "test-google-code"
-	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/google-workspace?code=%v", code), nil)
+	state := startTestGoogleWorkspaceAuth(t, "/login?from=google")
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/google-workspace?code=%v&state=%v", code, url.QueryEscape(state)), nil)
 	rec := httptest.NewRecorder()
 
 	// when
@@ -193,7 +229,7 @@ func Test_authWithGoogleWorkspaceAccount(t *testing.T) {
 	// then
 	fmt.Println(rec.Body.String())
 	assert.Equal(t, http.StatusFound, rec.Code)
-	assert.True(t, strings.Contains(rec.Header().Get("Location"), "accessToken="))
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Location"), "/login?from=google&accessToken="))
 
 	// assert Cookie value
 	headerSetCookie := rec.Header().Get("Set-Cookie")
@@ -205,7 +241,8 @@ func Test_authWithGoogleWorkspaceAccount(t *testing.T) {
 	assert.True(t, strings.Contains(headerSetCookie, expires))
 	assert.True(t, strings.Contains(headerSetCookie, "HttpOnly"))
 
-	refreshToken := headerSetCookie[strings.Index(headerSetCookie, "refreshToken=")+len("refreshToken=") : strings.Index(headerSetCookie, ";")]
+	refreshToken, _, err := security.CookieCodec{}.Decode("refreshToken", headerSetCookie[strings.Index(headerSetCookie, "refreshToken=")+len("refreshToken="):strings.Index(headerSetCookie, ";")])
+	assert.Nil(t, err)
 	tokenUserClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(refreshToken)
 	assert.Equal(t, uint(5), tokenUserClaim.Id)
 }
@@ -254,7 +291,8 @@ func Test_authWithGoogleWorkspaceAccount_구글_워크스페이스_멤버가_아
 
 	// given
 	code := "test-google-code"
-	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/google-workspace?code=%v", code), nil)
+	state := startTestGoogleWorkspaceAuth(t, "/login?from=google")
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/google-workspace?code=%v&state=%v", code, url.QueryEscape(state)), nil)
 	rec := httptest.NewRecorder()
 
 	// when
@@ -271,6 +309,143 @@ func Test_authWithGoogleWorkspaceAccount_구글_워크스페이스_멤버가_아
 		"error=bettercode.kr %eb%a1%9c %eb%81%9d%eb%82%98%eb%8a%94 %eb%a9%94%ec%9d%bc %ec%a3%bc%ec%86%8c%eb%a7%8c %ec%82%ac%ec%9a%a9 %ea%b0%80%eb%8a%a5 %ed%95%a9%eb%8b%88%eb%8b%a4"))
 }
 
+func Test_authWithGoogleWorkspaceAccount_허용된_도메인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// setUp WebServer Fixture
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"access_token": "test-token", "token_type": "Bearer"}`))
+			return
+		}
+
+		// 디렉터리 API
+		if r.URL.Path == "/users/987654" {
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"orgUnitPath": "/Engineering/Backend", "phones": [{"value": "010-0000-0000"}, {"value": "010-1234-5678", "primary": true}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"id": "987654", "email": "kim@example.com", "name": "김신입", "hd": "example.com"}`))
+	}))
+	defer server.Close()
+	serverPort := server.Listener.Addr().(*net.TCPAddr).Port
+
+	googleWorkspaceServerUrl := fmt.Sprintf("http://localhost:%v", serverPort)
+	config.Config.GoogleOAuth.AuthUri = googleWorkspaceServerUrl
+	config.Config.GoogleOAuth.TokenUri = googleWorkspaceServerUrl
+	defer func(directoryUri string) { config.Config.GoogleOAuth.DirectoryUri = directoryUri }(config.Config.GoogleOAuth.DirectoryUri)
+	config.Config.GoogleOAuth.DirectoryUri = googleWorkspaceServerUrl
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/google-workspace", strings.NewReader(`{
+		"domains": [{"domain": "bettercode.kr"}, {"domain": "example.com", "defaultRoleIds": [2]}],
+		"verifyHostedDomain": true,
+		"directoryAccess": {"enabled": true, "adminConsented": true}
+	}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"MANAGE_SYSTEM_SETTINGS"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// given
+	state := startTestGoogleWorkspaceAuth(t, "/login")
+	req = httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace?code=test-google-code&state="+url.QueryEscape(state), nil)
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println("Location", rec.Header().Get("Location"))
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.True(t, strings.Contains(rec.Header().Get("Location"), "accessToken="))
+
+	// 처음 로그인하면 도메인의 기본 역할을 할당하고 디렉터리 프로필을 가져온다.
+	var member memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where("google_id = ?", "987654").First(&member)
+	assert.Equal(t, []uint{2}, member.GetRoleIds())
+	assert.Equal(t, "/Engineering/Backend", member.GoogleOrgUnit)
+	assert.Equal(t, "010-1234-5678", member.Phone)
+}
+
+func Test_startGoogleWorkspaceAuth(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer func(origins []string) { config.Config.OAuthState.AllowedRedirectOrigins = origins }(config.Config.OAuthState.AllowedRedirectOrigins)
+	config.Config.OAuthState.AllowedRedirectOrigins = []string{"https://admin.bettercode.kr/"}
+
+	testCases := map[string]int{
+		"/members":                              http.StatusFound,
+		"https://admin.bettercode.kr/login?a=1": http.StatusFound,
+		"https://evil.example.com/login":        http.StatusBadRequest,
+		"//evil.example.com/login":              http.StatusBadRequest,
+		"https://admin.bettercode.kr@evil.com/": http.StatusBadRequest,
+		"javascript:alert(1)":                   http.StatusBadRequest,
+	}
+
+	for redirect, expectedCode := range testCases {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace/start?redirect="+url.QueryEscape(redirect), nil)
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, expectedCode, rec.Code, redirect)
+		if expectedCode == http.StatusFound {
+			location := rec.Header().Get("Location")
+			assert.True(t, strings.Contains(location, "client_id=test-client-id"), redirect)
+			assert.True(t, strings.Contains(location, "&state="), redirect)
+		}
+	}
+}
+
+func Test_authWithGoogleWorkspaceAccount_잘못된_state(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// 만료된 state, 다른 용도의 토큰, 허용 목록에서 빠진 주소의 state 는 받지 않는다.
+	expiredState, _ := security.JwtAuthentication{}.GenerateOAuthStateToken(constants.LoginMethodGoogleWorkspace, "/login", -time.Minute)
+	accessToken, _ := generateTestJWT(map[string]interface{}{"Id": 1}, time.Minute*15)
+	defer func(origins []string) { config.Config.OAuthState.AllowedRedirectOrigins = origins }(config.Config.OAuthState.AllowedRedirectOrigins)
+	config.Config.OAuthState.AllowedRedirectOrigins = []string{"https://old-admin.bettercode.kr"}
+	removedOriginState := startTestGoogleWorkspaceAuth(t, "https://old-admin.bettercode.kr/login")
+	config.Config.OAuthState.AllowedRedirectOrigins = []string{}
+
+	testCases := map[string]string{
+		"state 없음":      "",
+		"변조된 주소":        "https://evil.example.com/login",
+		"만료된 state":     expiredState,
+		"액세스 토큰":        accessToken,
+		"허용 목록에서 빠진 주소": removedOriginState,
+	}
+
+	for name, state := range testCases {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace?code=test-google-code&state="+url.QueryEscape(state), nil)
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+		assert.Empty(t, rec.Header().Get("Location"), name)
+	}
+
+	// state 토큰은 액세스 토큰으로 사용할 수 없다.
+	state, _ := security.JwtAuthentication{}.GenerateOAuthStateToken(constants.LoginMethodGoogleWorkspace, "/login", time.Minute)
+	assert.NotNil(t, security.JwtAuthentication{}.ValidateToken(state))
+}
+
 func Test_checkAuth(t *testing.T) {
 	// given
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/check", nil)
@@ -287,7 +462,7 @@ func Test_checkAuth(t *testing.T) {
 
 	cookie := new(http.Cookie)
 	cookie.Name = "refreshToken"
-	cookie.Value = token
+	cookie.Value, _ = security.CookieCodec{}.Encode("refreshToken", token)
 	cookie.HttpOnly = true
 	cookie.Path = "/"
 	req.AddCookie(cookie)
@@ -302,6 +477,59 @@ func Test_checkAuth(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 }
 
+func Test_checkAuth_이전_키로_암호화한_쿠키(t *testing.T) {
+	keys := config.Config.CookieEncryption.Keys
+	defer func() { config.Config.CookieEncryption.Keys = keys }()
+
+	// given
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Roles":       []string{},
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	config.Config.CookieEncryption.Keys = []string{"previous-cookie-key"}
+	previousValue, _ := security.CookieCodec{}.Encode("refreshToken", token)
+	config.Config.CookieEncryption.Keys = []string{"current-cookie-key", "previous-cookie-key"}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/check", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: previousValue, HttpOnly: true, Path: "/"})
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	headerSetCookie := rec.Header().Get("Set-Cookie")
+	assert.True(t, strings.HasPrefix(headerSetCookie, "refreshToken="))
+
+	config.Config.CookieEncryption.Keys = []string{"current-cookie-key"}
+	refreshToken, rotated, err := security.CookieCodec{}.Decode("refreshToken", headerSetCookie[len("refreshToken="):strings.Index(headerSetCookie, ";")])
+	assert.Nil(t, err)
+	assert.False(t, rotated)
+	assert.Equal(t, token, refreshToken)
+}
+
+func Test_checkAuth_암호화하지_않은_쿠키(t *testing.T) {
+	// given
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Roles":       []string{},
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/check", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: token, HttpOnly: true, Path: "/"})
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+}
+
 func Test_checkAuth_토큰_없음(t *testing.T) {
 	// given
 	req := httptest.NewRequest(http.MethodGet, "/api/auth/check", nil)
@@ -331,7 +559,7 @@ func Test_checkAuth_토큰_Expired(t *testing.T) {
 
 	cookie := new(http.Cookie)
 	cookie.Name = "refreshToken"
-	cookie.Value = token
+	cookie.Value, _ = security.CookieCodec{}.Encode("refreshToken", token)
 	cookie.HttpOnly = true
 	cookie.Path = "/"
 	req.AddCookie(cookie)
@@ -363,7 +591,7 @@ func Test_logout(t *testing.T) {
 
 	cookie := new(http.Cookie)
 	cookie.Name = "refreshToken"
-	cookie.Value = token
+	cookie.Value, _ = security.CookieCodec{}.Encode("refreshToken", token)
 	cookie.HttpOnly = true
 	cookie.Path = "/"
 	req.AddCookie(cookie)
@@ -420,7 +648,7 @@ func Test_refreshAccessToken(t *testing.T) {
 
 	cookie := new(http.Cookie)
 	cookie.Name = "refreshToken"
-	cookie.Value = token
+	cookie.Value, _ = security.CookieCodec{}.Encode("refreshToken", token)
 	cookie.HttpOnly = true
 	cookie.Path = "/"
 	req.AddCookie(cookie)
@@ -451,3 +679,1046 @@ func Test_refreshAccessToken_토큰이_없는_경우(t *testing.T) {
 	fmt.Println(rec.Body.String())
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func Test_authWithSignIdPassword_동시_세션_제한_초과_로그인_차단(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Create(&siteDomain.SettingEntity{
+		Key: "session-limit",
+		ValueObject: dtos.SessionLimitSetting{
+			MaxSessions:  1,
+			ExceedAction: "block",
+		},
+	})
+
+	// given
+	requestBody := `{
+		"id": "siteadm",
+		"password": "123456"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func Test_authWithSignIdPassword_동시_세션_제한_초과_오래된_세션_만료(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Create(&siteDomain.SettingEntity{
+		Key: "session-limit",
+		ValueObject: dtos.SessionLimitSetting{
+			MaxSessions:  1,
+			ExceedAction: "revoke-oldest",
+		},
+	})
+
+	// given
+	requestBody := `{
+		"id": "siteadm",
+		"password": "123456"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var oldestSession sessionDomain.MemberSessionEntity
+	gormDB.First(&oldestSession, 1)
+	assert.NotNil(t, oldestSession.RevokedAt)
+	assert.Equal(t, "limit-exceeded", oldestSession.RevokedReason)
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).
+		Where("action = ? AND target_type = ? AND target_id = ?", "session-force-revoked", "session", 1).
+		Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+
+	// 만료된 세션에서 발급한 Access 토큰은 사용할 수 없고, 새로운 세션의 Access 토큰은 사용할 수 있다.
+	oldestToken, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+		"SessionId":   oldestSession.SessionKey,
+	}, time.Minute*15)
+	assert.Equal(t, http.StatusUnauthorized, requestWithAccessToken(http.MethodGet, "/api/members/me/preferences", oldestToken).Code)
+	assert.Equal(t, http.StatusOK, requestWithAccessToken(http.MethodGet, "/api/members/me/preferences", accessTokenOf(rec)).Code)
+}
+
+func Test_refreshAccessToken_만료된_세션인_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          3,
+		"Roles":       []string{},
+		"Permissions": []string{},
+		"SessionId":   "test-session-key-2",
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+
+	cookie := new(http.Cookie)
+	cookie.Name = "refreshToken"
+	cookie.Value, _ = security.CookieCodec{}.Encode("refreshToken", token)
+	cookie.HttpOnly = true
+	cookie.Path = "/"
+	req.AddCookie(cookie)
+
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_authWithSignIdPassword_조직_경로_클레임_추가(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	config.Config.JwtClaimEnrichment.Enrichers = []string{"organization-path"}
+	defer func() {
+		config.Config.JwtClaimEnrichment.Enrichers = nil
+	}()
+
+	// given
+	requestBody := `{
+		"id": "ymyoo",
+		"password": "123456"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	tokenUserClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	assert.Equal(t, []interface{}{"베터코드 연구소/부서B/부서C"}, tokenUserClaim.Extra["organizations"])
+}
+
+func Test_issueScopedToken(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"resource": "/api/site/settings/dooray-login",
+		"action": "GET"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/scoped-tokens", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	scopedToken := actual["token"].(string)
+	assert.NotEmpty(t, actual["expiresAt"])
+
+	// 발급 받은 리소스는 Authorization 헤더 없이 접근할 수 있다.
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/dooray-login?token="+scopedToken, nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 다른 리소스에는 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/google-workspace-login?token="+scopedToken, nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 일반 Access 토큰으로 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/dooray-login", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", scopedToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_issueScopedToken_세션이_종료된_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/scoped-tokens", strings.NewReader(`{
+		"resource": "/api/site/settings/dooray-login",
+		"action": "GET"
+	}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"MANAGE_SYSTEM_SETTINGS"},
+		"SessionId":   "test-session-key-1",
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	scopedToken := actual["token"].(string)
+
+	// when
+	gormDB.Exec("UPDATE member_sessions SET revoked_at = datetime('now'), revoked_reason = 'logout' WHERE session_key = ?", "test-session-key-1")
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/dooray-login?token="+scopedToken, nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// 발급을 요청한 세션이 종료되면 스코프 토큰도 사용할 수 없다.
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SESSION_REVOKED")
+}
+
+func Test_issueScopedToken_서비스_계정은_발급할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	// 스코프 토큰에는 멤버 Id 만 기록되므로 서비스 계정(1)의 토큰은 멤버(1)의 토큰이 된다.
+	rec := requestAsServiceAccount(http.MethodPost, "/api/auth/scoped-tokens", `{
+		"resource": "/api/site/settings/dooray-login",
+		"action": "GET"
+	}`, 1, []string{"MANAGE_SYSTEM_SETTINGS"})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_issueScopedToken_Bad_Request_지원하지_않는_동작(t *testing.T) {
+	// given
+	requestBody := `{
+		"resource": "/api/site/settings/dooray-login",
+		"action": "DELETE"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/scoped-tokens", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Roles":       []string{},
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_issueToken_client_credentials(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", "MANAGE_SYSTEM_SETTINGS")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.SetBasicAuth("test-client", "test-secret")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var actual dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "Bearer", actual.TokenType)
+	assert.Equal(t, 600, actual.ExpiresIn)
+	assert.Equal(t, "MANAGE_SYSTEM_SETTINGS", actual.Scope)
+
+	userClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(actual.AccessToken)
+	assert.NoError(t, err)
+	assert.True(t, userClaim.IsServiceAccount())
+	assert.Equal(t, []string{"MANAGE_SYSTEM_SETTINGS"}, userClaim.Permissions)
+
+	// 발급받은 토큰으로 API 를 호출할 수 있다.
+	req = httptest.NewRequest(http.MethodGet, "/api/service-accounts", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual.AccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_issueToken_client_credentials_잘못된_Secret(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", "test-client")
+	form.Set("client_secret", "wrong-secret")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_client")
+}
+
+func Test_issueToken_client_credentials_허용되지_않은_scope(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", "test-client")
+	form.Set("client_secret", "test-secret")
+	form.Set("scope", "MANAGE_SYSTEM_SETTINGS VIEW_MONITORING")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_scope")
+}
+
+func Test_issueToken_지원하지_않는_grant_type(t *testing.T) {
+	// given
+	form := url.Values{}
+	form.Set("grant_type", "password")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "unsupported_grant_type")
+}
+
+func exchangeTestToken(t *testing.T, subjectToken, audience, scope string) *httptest.ResponseRecorder {
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:token-exchange")
+	form.Set("subject_token", subjectToken)
+	form.Set("subject_token_type", "urn:ietf:params:oauth:token-type:access_token")
+	form.Set("audience", audience)
+	if len(scope) > 0 {
+		form.Set("scope", scope)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.SetBasicAuth("test-client", "test-secret")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_issueToken_token_exchange(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusNoContent, setTestTokenExchangePolicies(t, 1, `{
+		"policies": [{"audience": "billing-service", "scopes": ["MANAGE_MEMBERS", "MANAGE_SYSTEM_SETTINGS"], "lifetimeSeconds": 120}]
+	}`).Code)
+	subjectToken, _ := generateTestJWT(map[string]interface{}{
+		"id":          2,
+		"roles":       []string{"MEMBER MANAGER"},
+		"permissions": []string{"MANAGE_MEMBERS", "VIEW_MONITORING"},
+	}, time.Minute*15)
+
+	// when
+	rec := exchangeTestToken(t, subjectToken, "billing-service", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "urn:ietf:params:oauth:token-type:access_token", actual.IssuedTokenType)
+	assert.Equal(t, "Bearer", actual.TokenType)
+	assert.Equal(t, 120, actual.ExpiresIn)
+	// 정책이 허용하는 권한 중 멤버가 가진 권한만 넘겨준다.
+	assert.Equal(t, "MANAGE_MEMBERS", actual.Scope)
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(actual.AccessToken, claims, func(token *jwt.Token) (interface{}, error) { return []byte(config.Config.JwtSecret), nil })
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2), claims["id"])
+	assert.Equal(t, "billing-service", claims["aud"])
+	assert.Equal(t, map[string]interface{}{"sub": "test-client"}, claims["act"])
+	assert.Equal(t, []interface{}{"MANAGE_MEMBERS"}, claims["permissions"])
+
+	// 교환한 토큰은 이 서비스의 API 에 사용할 수 없다.
+	req := httptest.NewRequest(http.MethodGet, "/api/members", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual.AccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var audit auditDomain.AuditLogEntity
+	gormDB.Where("action = ?", "token-exchanged").First(&audit)
+	assert.Equal(t, "service-account", audit.ActorType)
+	assert.Equal(t, uint(1), audit.ActorId)
+	assert.Equal(t, uint(2), audit.TargetId)
+	assert.Equal(t, "audience=billing-service, scope=MANAGE_MEMBERS", audit.Detail)
+}
+
+func Test_issueToken_token_exchange_허용되지_않은_요청(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusNoContent, setTestTokenExchangePolicies(t, 1, `{
+		"policies": [{"audience": "billing-service", "scopes": ["MANAGE_MEMBERS", "MANAGE_SYSTEM_SETTINGS"]}]
+	}`).Code)
+	subjectToken, _ := generateTestJWT(map[string]interface{}{
+		"id":          2,
+		"permissions": []string{"MANAGE_MEMBERS"},
+	}, time.Minute*15)
+	serviceAccountToken, _ := generateTestJWT(map[string]interface{}{
+		"id":            1,
+		"permissions":   []string{"MANAGE_MEMBERS"},
+		"principalType": "service-account",
+	}, time.Minute*15)
+
+	// when
+	unknownAudience := exchangeTestToken(t, subjectToken, "payment-service", "")
+	notGrantedScope := exchangeTestToken(t, subjectToken, "billing-service", "MANAGE_SYSTEM_SETTINGS")
+	serviceAccountSubject := exchangeTestToken(t, serviceAccountToken, "billing-service", "")
+	invalidSubject := exchangeTestToken(t, "invalid", "billing-service", "")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, unknownAudience.Code)
+	assert.Contains(t, unknownAudience.Body.String(), "invalid_target")
+	assert.Equal(t, http.StatusBadRequest, notGrantedScope.Code)
+	assert.Contains(t, notGrantedScope.Body.String(), "invalid_scope")
+	assert.Equal(t, http.StatusBadRequest, serviceAccountSubject.Code)
+	assert.Contains(t, serviceAccountSubject.Body.String(), "invalid subject token")
+	assert.Equal(t, http.StatusBadRequest, invalidSubject.Code)
+	assert.Contains(t, invalidSubject.Body.String(), "invalid_request")
+}
+
+func signInTestClient(t *testing.T, userAgent string, deviceId string) string {
+	requestBody := `{
+		"id": "siteadm",
+		"password": "123456"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Device-Id", deviceId)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	headerSetCookie := rec.Header().Get("Set-Cookie")
+	return headerSetCookie[strings.Index(headerSetCookie, "refreshToken=")+len("refreshToken=") : strings.Index(headerSetCookie, ";")]
+}
+
+func refreshTestClientAccessToken(refreshToken string, userAgent string, deviceId string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Device-Id", deviceId)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: refreshToken, HttpOnly: true, Path: "/"})
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func Test_refreshAccessToken_다른_클라이언트_경고(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	refreshToken := signInTestClient(t, "test-browser/1.0", "device-1")
+
+	// when
+	sameClientRec := refreshTestClientAccessToken(refreshToken, "test-browser/1.0", "device-1")
+	otherClientRec := refreshTestClientAccessToken(refreshToken, "test-browser/1.0", "device-2")
+
+	// then
+	assert.Equal(t, http.StatusOK, sameClientRec.Code)
+	fmt.Println(otherClientRec.Body.String())
+	assert.Equal(t, http.StatusOK, otherClientRec.Code)
+
+	var session sessionDomain.MemberSessionEntity
+	gormDB.Where("member_id = ?", 1).Last(&session)
+	assert.Len(t, session.ClientFingerprint, 64)
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).
+		Where("action = ? AND target_type = ? AND target_id = ?", "session-client-mismatched", "session", session.ID).
+		Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+}
+
+func Test_refreshAccessToken_다른_클라이언트_거부(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Create(&siteDomain.SettingEntity{
+		Key:         "refresh-token-binding",
+		ValueObject: dtos.RefreshTokenBindingSetting{Action: "enforce"},
+	})
+
+	// given
+	refreshToken := signInTestClient(t, "test-browser/1.0", "")
+
+	// when
+	rec := refreshTestClientAccessToken(refreshToken, "other-browser/2.0", "")
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var audit auditDomain.AuditLogEntity
+	gormDB.Where("action = ?", "session-client-mismatched").First(&audit)
+	assert.Contains(t, audit.Detail, "action=enforce")
+}
+
+type fakePreAuthChecker struct {
+	result   dtos.PreAuthResult
+	err      error
+	requests []dtos.PreAuthRequest
+}
+
+func (f *fakePreAuthChecker) Check(ctx context.Context, request dtos.PreAuthRequest) (dtos.PreAuthResult, error) {
+	f.requests = append(f.requests, request)
+	return f.result, f.err
+}
+
+func Test_authWithSignIdPassword_사전_인증_허용(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	checker := &fakePreAuthChecker{result: dtos.PreAuthResult{Allow: true, Claims: map[string]interface{}{"employeeNo": "E-001", "roles": "ignored"}}}
+	adapters.PreAuthAdapter().SetChecker(checker)
+	defer adapters.PreAuthAdapter().SetChecker(nil)
+
+	// given
+	requestBody := `{
+		"id": "siteadm",
+		"password": "123456"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, checker.requests, 1)
+	assert.Equal(t, uint(1), checker.requests[0].MemberId)
+	assert.Equal(t, "siteadm", checker.requests[0].SignId)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	tokenUserClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, "E-001", tokenUserClaim.Extra["employeeNo"])
+	assert.NotContains(t, tokenUserClaim.Extra, "roles")
+}
+
+func Test_authWithSignIdPassword_사전_인증_거부(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	adapters.PreAuthAdapter().SetChecker(&fakePreAuthChecker{result: dtos.PreAuthResult{Allow: false, Reason: "퇴사자"}})
+	defer adapters.PreAuthAdapter().SetChecker(nil)
+
+	// given
+	requestBody := `{
+		"id": "siteadm",
+		"password": "123456"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.JSONEq(t, `{"code":"PRE_AUTH_DENIED","message":"퇴사자"}`, rec.Body.String())
+	assert.Equal(t, "PRE_AUTH_DENIED", rec.Header().Get(middlewares.ErrorCodeHeader))
+
+	var sessionCount int64
+	gormDB.Model(&sessionDomain.MemberSessionEntity{}).Where("member_id = ?", 1).Count(&sessionCount)
+	assert.Equal(t, int64(1), sessionCount)
+}
+
+func Test_authWithSignIdPassword_사전_인증_실패시_동작(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	adapters.PreAuthAdapter().SetChecker(&fakePreAuthChecker{err: pkgerrors.New("timeout")})
+	defer adapters.PreAuthAdapter().SetChecker(nil)
+	defer func(failureAction string) { config.Config.PreAuthHook.FailureAction = failureAction }(config.Config.PreAuthHook.FailureAction)
+
+	requestBody := `{
+		"id": "siteadm",
+		"password": "123456"
+	}`
+
+	for failureAction, expectedCode := range map[string]int{"deny": http.StatusForbidden, "allow": http.StatusOK} {
+		// given
+		config.Config.PreAuthHook.FailureAction = failureAction
+		req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		fmt.Println(rec.Body.String())
+		assert.Equal(t, expectedCode, rec.Code, failureAction)
+	}
+}
+
+type fakeAuthenticator struct {
+}
+
+func (fakeAuthenticator) Authenticate(ctx context.Context, credentials map[string]string) (dtos.CustomAuthIdentity, error) {
+	if credentials["username"] != "kim" || credentials["otp"] != "123456" {
+		return dtos.CustomAuthIdentity{}, errors.ErrAuthentication
+	}
+
+	return dtos.CustomAuthIdentity{ExternalId: "emp-1", Name: "김신입", Email: "kim@bettercode.kr"}, nil
+}
+
+func Test_authWithCustomAuthenticator(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-sso", fakeAuthenticator{})
+
+	for i := 0; i < 2; i++ {
+		// given
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/custom/test-sso", strings.NewReader(`{"username": "kim", "otp": "123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		fmt.Println(rec.Body.String())
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	var members []memberDomain.MemberEntity
+	gormDB.Where("authenticator_name = ? AND external_id = ?", "test-sso", "emp-1").Find(&members)
+	assert.Len(t, members, 1)
+	assert.Equal(t, "custom", members[0].Type)
+	assert.Equal(t, "approved", members[0].Status)
+	assert.Equal(t, "kim@bettercode.kr", members[0].GetEmail())
+}
+
+func Test_authWithCustomAuthenticator_할당_규칙(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-sso", fakeAuthenticator{})
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/custom/test-sso", strings.NewReader(`{"username": "kim", "otp": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 메일 도메인이 맞는 규칙의 역할과 조직이 할당된다.
+	var member memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where("authenticator_name = ? AND external_id = ?", "test-sso", "emp-1").First(&member)
+	assert.Equal(t, []uint{2}, member.GetRoleIds())
+
+	var organizationCount int64
+	gormDB.Raw("SELECT count(*) FROM organization_members WHERE organization_entity_id = 1 AND member_entity_id = ?", member.ID).Scan(&organizationCount)
+	assert.Equal(t, int64(1), organizationCount)
+}
+
+func Test_authWithCustomAuthenticator_인증_실패(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-sso", fakeAuthenticator{})
+
+	testCases := map[string]int{
+		"/api/auth/custom/test-sso":      http.StatusBadRequest,
+		"/api/auth/custom/not-supported": http.StatusNotFound,
+	}
+
+	for path, expectedCode := range testCases {
+		// given
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"username": "kim", "otp": "000000"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		fmt.Println(rec.Body.String())
+		assert.Equal(t, expectedCode, rec.Code, path)
+	}
+}
+
+func Test_authWithCustomAuthenticator_사이드카(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	var requestMessage []byte
+	sidecar := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/betteradmin.auth.v1.Authenticator/Authenticate" || len(body) < 5 {
+			w.Header().Set("Grpc-Status", "12")
+			return
+		}
+		requestMessage = body[5:]
+
+		var message []byte
+		message = protowire.AppendTag(message, 1, protowire.VarintType)
+		message = protowire.AppendVarint(message, 1)
+		message = protowire.AppendTag(message, 2, protowire.BytesType)
+		message = protowire.AppendString(message, "emp-2")
+		message = protowire.AppendTag(message, 3, protowire.BytesType)
+		message = protowire.AppendString(message, "이사이드")
+
+		frame := make([]byte, 5)
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.Write(append(frame, message...))
+		w.Header().Set("Grpc-Status", "0")
+	}), &http2.Server{}))
+	defer sidecar.Close()
+
+	customAuthenticators := config.Config.CustomAuthenticators
+	defer func() { config.Config.CustomAuthenticators = customAuthenticators }()
+	config.Config.CustomAuthenticators = nil
+	json.Unmarshal([]byte(fmt.Sprintf(`[{"Name": "test-sidecar", "SidecarAddress": "%s", "TimeoutSeconds": 5}]`,
+		strings.TrimPrefix(sidecar.URL, "http://"))), &config.Config.CustomAuthenticators)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/custom/test-sidecar", strings.NewReader(`{"ticket": "abc"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, string(requestMessage), "test-sidecar")
+	assert.Contains(t, string(requestMessage), "ticket")
+	assert.Contains(t, string(requestMessage), "abc")
+
+	var member memberDomain.MemberEntity
+	gormDB.Where("authenticator_name = ? AND external_id = ?", "test-sidecar", "emp-2").First(&member)
+	assert.Equal(t, "이사이드", member.Name)
+}
+
+func requestTestTokenEndpoint(path, token string, basicAuth bool) *httptest.ResponseRecorder {
+	form := url.Values{}
+	form.Set("token", token)
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	if basicAuth {
+		req.SetBasicAuth("test-client", "test-secret")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func Test_introspectToken(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusNoContent, setTestTokenExchangePolicies(t, 1, `{
+		"policies": [{"audience": "billing-service", "scopes": ["MANAGE_MEMBERS"]}]
+	}`).Code)
+	memberToken, _ := generateTestJWT(map[string]interface{}{
+		"id":          2,
+		"roles":       []string{"MEMBER MANAGER"},
+		"permissions": []string{"MANAGE_MEMBERS"},
+		"sessionId":   "test-session-key-1",
+	}, time.Minute*15)
+	var exchangedToken dtos.OAuthToken
+	json.Unmarshal(exchangeTestToken(t, memberToken, "billing-service", "").Body.Bytes(), &exchangedToken)
+
+	// when
+	rec := requestTestTokenEndpoint("/api/auth/token/introspect", memberToken, true)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	var actual dtos.TokenIntrospection
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.True(t, actual.Active)
+	assert.Equal(t, "2", actual.Sub)
+	assert.Equal(t, "MANAGE_MEMBERS", actual.Scope)
+	assert.Equal(t, []string{"MEMBER MANAGER"}, actual.Roles)
+	assert.Equal(t, "Bearer", actual.TokenType)
+	assert.True(t, actual.Exp > time.Now().Unix())
+
+	// 다른 서비스에 교환해 준 토큰도 조회할 수 있으며 aud 는 호출한 서비스가 확인한다.
+	rec = requestTestTokenEndpoint("/api/auth/token/introspect", exchangedToken.AccessToken, true)
+	actual = dtos.TokenIntrospection{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.True(t, actual.Active)
+	assert.Equal(t, []string{"billing-service"}, actual.Aud)
+	assert.Equal(t, "test-client", actual.ClientId)
+}
+
+func Test_introspectToken_사용할_수_없는_토큰(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	expiredToken, _ := generateTestJWT(map[string]interface{}{"id": 1}, -time.Minute)
+	revokedSessionToken, _ := generateTestJWT(map[string]interface{}{"id": 3, "sessionId": "test-session-key-2"}, time.Minute*15)
+	scopedToken, _, _ := security.JwtAuthentication{}.GenerateScopedToken(security.ScopedTokenClaim{
+		Id: 1, Resource: "/api/files/1", Action: http.MethodGet,
+	}, time.Minute)
+	testCases := map[string]string{
+		"위조된 토큰":     "invalid-token",
+		"만료된 토큰":     expiredToken,
+		"세션이 종료된 토큰": revokedSessionToken,
+		"스코프 토큰":     scopedToken,
+	}
+
+	for name, token := range testCases {
+		// when
+		rec := requestTestTokenEndpoint("/api/auth/token/introspect", token, true)
+
+		// then
+		assert.Equal(t, http.StatusOK, rec.Code, name)
+		assert.JSONEq(t, `{"active": false}`, rec.Body.String(), name)
+	}
+
+	// Client 인증 없이는 조회할 수 없다.
+	rec := requestTestTokenEndpoint("/api/auth/token/introspect", expiredToken, false)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_client")
+}
+
+func Test_revokeToken(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.SetBasicAuth("test-client", "test-secret")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	var issued dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &issued)
+
+	// when
+	rec = requestTestTokenEndpoint("/api/auth/token/revoke", issued.AccessToken, true)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = requestTestTokenEndpoint("/api/auth/token/introspect", issued.AccessToken, true)
+	assert.JSONEq(t, `{"active": false}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/service-accounts", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", issued.AccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "token revoked")
+
+	var audit auditDomain.AuditLogEntity
+	gormDB.Where("action = ?", "token-revoked").First(&audit)
+	assert.Equal(t, "service-account", audit.ActorType)
+	assert.Equal(t, "service-account", audit.TargetType)
+
+	// 이미 폐기했거나 사용할 수 없는 토큰도 성공으로 응답한다.
+	assert.Equal(t, http.StatusOK, requestTestTokenEndpoint("/api/auth/token/revoke", issued.AccessToken, true).Code)
+	assert.Equal(t, http.StatusOK, requestTestTokenEndpoint("/api/auth/token/revoke", "invalid-token", true).Code)
+}
+
+func Test_revokeToken_다른_Client_에_발급한_토큰(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	memberToken, _ := generateTestJWT(map[string]interface{}{"id": 1, "permissions": []string{"MANAGE_MEMBERS"}}, time.Minute*15)
+	otherServiceAccountToken, _ := generateTestJWT(map[string]interface{}{"id": 2, "principalType": "service-account"}, time.Minute*15)
+
+	for _, token := range []string{memberToken, otherServiceAccountToken} {
+		// when
+		rec := requestTestTokenEndpoint("/api/auth/token/revoke", token, true)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "unauthorized_client")
+	}
+
+	// 폐기하지 않았으므로 계속 사용할 수 있다.
+	rec := requestTestTokenEndpoint("/api/auth/token/introspect", memberToken, true)
+	assert.Contains(t, rec.Body.String(), `"active":true`)
+}
+
+func TestAuthController_checkPermissions(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{"checks": [
+		{"permission": "MANAGE_MEMBERS"},
+		{"permission": "MANAGE_SYSTEM_SETTINGS"},
+		{"resource": "GET /api/members/3"},
+		{"resource": "GET /api/members/my"},
+		{"resource": "get /api/site/maintenance"},
+		{"permission": "MANAGE_SYSTEM_SETTINGS", "resource": "GET /api/members/3"},
+		{"resource": "GET /api/unknown"}
+	]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/permissions/check", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var results []dtos.PermissionCheckResult
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &results))
+
+	allowed := make([]bool, 0, len(results))
+	for _, result := range results {
+		allowed = append(allowed, result.Allowed)
+	}
+	assert.Equal(t, []bool{true, false, true, true, true, false, false}, allowed)
+	assert.Equal(t, "GET /api/members/3", results[2].Resource)
+}
+
+func TestAuthController_checkPermissions_고정된_경로_우선(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// GET /api/members/:id 는 MANAGE_MEMBERS 권한이 필요하지만 GET /api/members/my 는 로그인만 하면 된다.
+	requestBody := `{"checks": [{"resource": "GET /api/members/my"}, {"resource": "GET /api/members/3"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/permissions/check", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[{"resource": "GET /api/members/my", "allowed": true}, {"resource": "GET /api/members/3", "allowed": false}]`, rec.Body.String())
+}
+
+func TestAuthController_checkPermissions_권한과_API_가_없는_확인(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/permissions/check", strings.NewReader(`{"checks": [{"permission": ""}]}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id": 2,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
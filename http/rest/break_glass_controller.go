@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/breakglass/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type BreakGlassController struct {
+	routerGroup           *gin.RouterGroup
+	breakGlassService     *services.BreakGlassService
+	passwordBreachService *services.PasswordBreachService
+}
+
+func NewBreakGlassController(
+	routerGroup *gin.RouterGroup,
+	breakGlassService *services.BreakGlassService,
+	passwordBreachService *services.PasswordBreachService) *BreakGlassController {
+
+	return &BreakGlassController{
+		routerGroup:           routerGroup,
+		breakGlassService:     breakGlassService,
+		passwordBreachService: passwordBreachService,
+	}
+}
+
+func (c BreakGlassController) MapRoutes() {
+	route := c.routerGroup.Group("/break-glass-accounts")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.createAccount)
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getAccounts)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.deleteAccount)
+	route.GET("/usages", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getUsages)
+}
+
+func (c BreakGlassController) createAccount(ctx *gin.Context) {
+	var creation dtos.BreakGlassAccountCreation
+	if err := ctx.BindJSON(&creation); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkBreachedPassword(ctx, c.passwordBreachService, creation.Password) {
+		return
+	}
+
+	err := c.breakGlassService.CreateAccount(ctx.Request.Context(), creation)
+	if err != nil {
+		if errors.Is(err, errors.ErrDuplicated) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (c BreakGlassController) getAccounts(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.breakGlassService.GetAccounts(ctx.Request.Context(), pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var accounts = make([]dtos.BreakGlassAccountInformation, 0)
+	for _, entity := range entities {
+		accounts = append(accounts, c.toAccountInformation(entity))
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     accounts,
+		TotalCount: totalCount,
+	})
+}
+
+func (c BreakGlassController) deleteAccount(ctx *gin.Context) {
+	accountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.breakGlassService.DeleteAccount(ctx.Request.Context(), uint(accountId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c BreakGlassController) getUsages(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.breakGlassService.GetUsages(ctx.Request.Context(), pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var usages = make([]dtos.BreakGlassUsageInformation, 0)
+	for _, entity := range entities {
+		usages = append(usages, dtos.BreakGlassUsageInformation{
+			Id:        entity.ID,
+			AccountId: entity.AccountId,
+			SignId:    entity.SignId,
+			MemberId:  entity.MemberId,
+			Reason:    entity.Reason,
+			ClientIp:  entity.ClientIp,
+			ExpiresAt: entity.ExpiresAt,
+			CreatedAt: entity.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     usages,
+		TotalCount: totalCount,
+	})
+}
+
+func (BreakGlassController) toAccountInformation(entity domain.BreakGlassAccountEntity) dtos.BreakGlassAccountInformation {
+	return dtos.BreakGlassAccountInformation{
+		Id:          entity.ID,
+		SignId:      entity.SignId,
+		MemberId:    entity.MemberId,
+		Description: entity.Description,
+		LastUsedAt:  entity.LastUsedAt,
+		CreatedAt:   entity.CreatedAt,
+	}
+}
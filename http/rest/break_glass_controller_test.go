@@ -0,0 +1,225 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	auditDomain "better-admin-backend-service/audit/domain"
+	breakGlassDomain "better-admin-backend-service/breakglass/domain"
+	"better-admin-backend-service/config"
+	sessionDomain "better-admin-backend-service/session/domain"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTestBreakGlassAccount(t *testing.T) {
+	requestBody := `{
+		"signId": "emergency",
+		"password": "emergency-1234",
+		"memberId": 1,
+		"description": "SSO 장애 대비"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/break-glass-accounts", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestBreakGlassController_createAccount(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	createTestBreakGlassAccount(t)
+
+	// then
+	req := httptest.NewRequest(http.MethodGet, "/api/break-glass-accounts", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalCount"])
+	account := actual["result"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "emergency", account["signId"])
+	assert.Nil(t, account["lastUsedAt"])
+	assert.NotContains(t, account, "password")
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).Where("action = ?", "break-glass-account-created").Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+}
+
+func TestBreakGlassController_createAccount_중복(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	createTestBreakGlassAccount(t)
+
+	// given
+	requestBody := `{
+		"signId": "emergency",
+		"password": "emergency-5678",
+		"memberId": 3
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/break-glass-accounts", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAuthController_authWithBreakGlassAccount(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	createTestBreakGlassAccount(t)
+	gormDB.Exec("UPDATE members SET google_mail = ? WHERE id = ?", "security@bettercode.kr", 2)
+
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+
+	breakGlassConfig := config.Config.BreakGlass
+	config.Config.BreakGlass.NotifyRoleName = "SYSTEM MANAGER"
+	defer func() { config.Config.BreakGlass = breakGlassConfig }()
+
+	// given
+	requestBody := `{
+		"id": "emergency",
+		"password": "emergency-1234",
+		"reason": "SSO(두레이) 장애로 긴급 점검"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/break-glass", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["accessToken"])
+
+	var session sessionDomain.MemberSessionEntity
+	gormDB.Where("member_id = ?", 1).Last(&session)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), session.ExpiresAt, time.Minute)
+
+	var usage breakGlassDomain.BreakGlassUsageEntity
+	gormDB.Last(&usage)
+	assert.Equal(t, "emergency", usage.SignId)
+	assert.Equal(t, uint(1), usage.MemberId)
+	assert.Equal(t, session.ID, usage.SessionId)
+	assert.Equal(t, "SSO(두레이) 장애로 긴급 점검", usage.Reason)
+
+	var account breakGlassDomain.BreakGlassAccountEntity
+	gormDB.Where("sign_id = ?", "emergency").First(&account)
+	assert.NotNil(t, account.LastUsedAt)
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).
+		Where("action = ? AND target_type = ? AND target_id = ?", "break-glass-account-used", "break-glass-account", account.ID).
+		Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+
+	assert.Len(t, mailSender.messages, 1)
+	assert.Equal(t, []string{"security@bettercode.kr"}, mailSender.messages[0].To)
+	assert.Contains(t, mailSender.messages[0].Body, "SSO(두레이) 장애로 긴급 점검")
+}
+
+func TestAuthController_authWithBreakGlassAccount_사유_없음(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	createTestBreakGlassAccount(t)
+
+	// given
+	requestBody := `{
+		"id": "emergency",
+		"password": "emergency-1234"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/break-glass", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var usageCount int64
+	gormDB.Model(&breakGlassDomain.BreakGlassUsageEntity{}).Count(&usageCount)
+	assert.Equal(t, int64(0), usageCount)
+}
+
+func TestAuthController_authWithBreakGlassAccount_비밀번호_오류(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	createTestBreakGlassAccount(t)
+
+	// given
+	requestBody := `{
+		"id": "emergency",
+		"password": "wrong-password",
+		"reason": "SSO(두레이) 장애로 긴급 점검"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/break-glass", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,278 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/changerequest/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+type ChangeRequestController struct {
+	routerGroup          *gin.RouterGroup
+	changeRequestService *services.ChangeRequestService
+}
+
+func NewChangeRequestController(
+	routerGroup *gin.RouterGroup,
+	changeRequestService *services.ChangeRequestService) *ChangeRequestController {
+
+	return &ChangeRequestController{
+		routerGroup:          routerGroup,
+		changeRequestService: changeRequestService,
+	}
+}
+
+func (c ChangeRequestController) MapRoutes() {
+	route := c.routerGroup.Group("/change-requests")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.createChangeRequest)
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getChangeRequests)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getChangeRequest)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.updateChangeRequest)
+	route.GET("/:id/diff", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getChangeRequestDiff)
+	route.POST("/:id/submit", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.submitChangeRequest)
+	route.POST("/:id/cancel", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.cancelChangeRequest)
+	route.POST("/:id/approve", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.approveChangeRequest)
+	route.POST("/:id/reject", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.rejectChangeRequest)
+	route.POST("/:id/apply", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		middlewares.PurgeResponseCache(constants.ResponseCacheLoginPage, constants.ResponseCacheMaintenanceStatus),
+		c.applyChangeRequest)
+}
+
+func (c ChangeRequestController) createChangeRequest(ctx *gin.Context) {
+	var information dtos.ChangeRequestInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.changeRequestService.CreateChangeRequest(ctx.Request.Context(), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.toChangeRequestInformation(entity))
+}
+
+func (c ChangeRequestController) getChangeRequests(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	filters := map[string]interface{}{}
+
+	if len(ctx.Query("status")) > 0 {
+		filters["status"] = ctx.Query("status")
+	}
+
+	entities, totalCount, err := c.changeRequestService.GetChangeRequests(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var changeRequests = make([]dtos.ChangeRequestInformation, 0)
+	for _, entity := range entities {
+		changeRequests = append(changeRequests, c.toChangeRequestInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     changeRequests,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (c ChangeRequestController) getChangeRequest(ctx *gin.Context) {
+	changeRequestId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.changeRequestService.GetChangeRequest(ctx.Request.Context(), uint(changeRequestId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toChangeRequestInformation(entity))
+}
+
+func (c ChangeRequestController) updateChangeRequest(ctx *gin.Context) {
+	changeRequestId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.ChangeRequestInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.changeRequestService.UpdateChangeRequest(ctx.Request.Context(), uint(changeRequestId), information); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getChangeRequestDiff 는 검토할 수 있도록 항목마다 현재 값과 바꿀 값을 보여준다.
+func (c ChangeRequestController) getChangeRequestDiff(ctx *gin.Context) {
+	changeRequestId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	diffs, err := c.changeRequestService.GetChangeRequestDiff(ctx.Request.Context(), uint(changeRequestId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, diffs)
+}
+
+func (c ChangeRequestController) submitChangeRequest(ctx *gin.Context) {
+	changeRequestId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.changeRequestService.SubmitChangeRequest(ctx.Request.Context(), uint(changeRequestId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c ChangeRequestController) cancelChangeRequest(ctx *gin.Context) {
+	changeRequestId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.changeRequestService.CancelChangeRequest(ctx.Request.Context(), uint(changeRequestId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c ChangeRequestController) approveChangeRequest(ctx *gin.Context) {
+	c.reviewChangeRequest(ctx, true)
+}
+
+func (c ChangeRequestController) rejectChangeRequest(ctx *gin.Context) {
+	c.reviewChangeRequest(ctx, false)
+}
+
+func (c ChangeRequestController) reviewChangeRequest(ctx *gin.Context, approved bool) {
+	changeRequestId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var review dtos.ChangeRequestReview
+	if err := ctx.BindJSON(&review); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.changeRequestService.ReviewChangeRequest(ctx.Request.Context(), uint(changeRequestId), approved, review.Comment); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// applyChangeRequest 는 승인된 요청을 적용한다. 점검에 실패하여 되돌린 경우에도 200 이며 응답의 status 가 rolled-back 이다.
+func (c ChangeRequestController) applyChangeRequest(ctx *gin.Context) {
+	changeRequestId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.changeRequestService.ApplyChangeRequest(ctx.Request.Context(), uint(changeRequestId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toChangeRequestInformation(entity))
+}
+
+func (ChangeRequestController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	// 직접 승인/반려(ErrSelfReview)도 ErrForbidden 이며 코드로 구분한다.
+	if errors.Is(err, errors.ErrForbidden) {
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrNonChangeable) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var invalidChangeRequest *errors.ErrInvalidChangeRequest
+	if pkgerrors.As(err, &invalidChangeRequest) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+func (ChangeRequestController) toChangeRequestInformation(entity domain.ChangeRequestEntity) dtos.ChangeRequestInformation {
+	return dtos.ChangeRequestInformation{
+		Id:            entity.ID,
+		Title:         entity.Title,
+		Description:   entity.Description,
+		Changes:       entity.GetChanges(),
+		ScheduledAt:   entity.ScheduledAt,
+		Status:        entity.Status,
+		CreatedBy:     entity.CreatedBy,
+		ReviewedBy:    entity.ReviewedBy,
+		ReviewedAt:    entity.ReviewedAt,
+		ReviewComment: entity.ReviewComment,
+		AppliedBy:     entity.AppliedBy,
+		AppliedAt:     entity.AppliedAt,
+		FailureReason: entity.FailureReason,
+		HealthCheck:   entity.GetHealthCheck(),
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+	}
+}
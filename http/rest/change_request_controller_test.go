@@ -0,0 +1,203 @@
+package rest
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestTestChangeRequest(method, url string, body io.Reader, memberId uint) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, body)
+	req.Header.Set("Content-Type", "application/json")
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": []string{constants.PermissionManageSystemSettings, constants.PermissionManageAccessControl},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+// createApprovedTestChangeRequest 는 멤버 1 이 작성하고 멤버 2 가 승인한 변경 요청을 만든다.
+func createApprovedTestChangeRequest(t *testing.T, requestBody string) dtos.ChangeRequestInformation {
+	rec := requestTestChangeRequest(http.MethodPost, "/api/change-requests", strings.NewReader(requestBody), 1)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var changeRequest dtos.ChangeRequestInformation
+	json.Unmarshal(rec.Body.Bytes(), &changeRequest)
+	assert.Equal(t, constants.ChangeRequestStatusDraft, changeRequest.Status)
+
+	rec = requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/submit", changeRequest.Id), nil, 1)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/approve", changeRequest.Id), strings.NewReader(`{"comment": "확인"}`), 2)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	return changeRequest
+}
+
+func getTestChangeRequest(t *testing.T, changeRequestId uint) dtos.ChangeRequestInformation {
+	rec := requestTestChangeRequest(http.MethodGet, fmt.Sprintf("/api/change-requests/%v", changeRequestId), nil, 1)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var changeRequest dtos.ChangeRequestInformation
+	json.Unmarshal(rec.Body.Bytes(), &changeRequest)
+	return changeRequest
+}
+
+func getMaintenanceSettingForTest(t *testing.T) dtos.MaintenanceSetting {
+	rec := requestSiteSetting(http.MethodGet, "/api/site/settings/maintenance", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var setting dtos.MaintenanceSetting
+	json.Unmarshal(rec.Body.Bytes(), &setting)
+	return setting
+}
+
+func TestChangeRequestController_검토_후_적용(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"title": "점검 안내 문구와 테스트 관리자 권한 변경",
+		"changes": [
+			{"type": "setting", "key": "maintenance", "value": {"enabled": false, "message": "정기 점검"}},
+			{"type": "role", "roleId": 3, "value": {"name": "테스트 관리자", "allowedPermissionIds": [3]}}
+		]
+	}`
+	rec := requestTestChangeRequest(http.MethodPost, "/api/change-requests", strings.NewReader(requestBody), 1)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var changeRequest dtos.ChangeRequestInformation
+	json.Unmarshal(rec.Body.Bytes(), &changeRequest)
+
+	rec = requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/submit", changeRequest.Id), nil, 1)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 작성한 관리자는 승인할 수 없다.
+	rec = requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/approve", changeRequest.Id), strings.NewReader(`{}`), 1)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SELF_REVIEW")
+
+	rec = requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/approve", changeRequest.Id), strings.NewReader(`{"comment": "확인"}`), 2)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	rec = requestTestChangeRequest(http.MethodGet, fmt.Sprintf("/api/change-requests/%v/diff", changeRequest.Id), nil, 2)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var diffs []dtos.ChangeRequestItemDiff
+	json.Unmarshal(rec.Body.Bytes(), &diffs)
+	assert.Equal(t, 2, len(diffs))
+	assert.Nil(t, diffs[0].Current)
+	assert.Equal(t, []string{"enabled", "message", "retryAfterSeconds"}, diffs[0].ChangedFields)
+	assert.Equal(t, []string{"allowedPermissionIds"}, diffs[1].ChangedFields)
+	assert.Equal(t, []string{"ACCESS_STOCK"}, diffs[1].AddedPermissions)
+	assert.Equal(t, []string{"MANAGE_SYSTEM_SETTINGS"}, diffs[1].RemovedPermissions)
+
+	// when
+	rec = requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/apply", changeRequest.Id), nil, 2)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &changeRequest)
+	assert.Equal(t, constants.ChangeRequestStatusApplied, changeRequest.Status)
+	assert.Equal(t, uint(2), changeRequest.ReviewedBy)
+	assert.Equal(t, uint(2), changeRequest.AppliedBy)
+	assert.True(t, changeRequest.HealthCheck.Healthy)
+
+	setting := getMaintenanceSettingForTest(t)
+	assert.Equal(t, "정기 점검", setting.Message)
+
+	role, err := NewContainer().RbacService.GetRole(helpers.ContextHelper().SetDB(context.Background(), gormDB), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{3}, role.GetPermissionIds())
+
+	// 적용한 요청은 다시 적용할 수 없다.
+	rec = requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/apply", changeRequest.Id), nil, 2)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestChangeRequestController_점검_실패시_되돌림(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	scheduledAt := time.Now().Add(-time.Minute).Format(time.RFC3339)
+	changeRequest := createApprovedTestChangeRequest(t, `{
+		"title": "점검 안내 문구 변경",
+		"scheduledAt": "`+scheduledAt+`",
+		"changes": [{"type": "setting", "key": "maintenance", "value": {"enabled": false, "message": "정기 점검"}}]
+	}`)
+
+	// 적용한 뒤 점검(jwt-secret)에 실패하게 한다.
+	jwtSecret := config.Config.JwtSecret
+	config.Config.JwtSecret = ""
+
+	// when
+	err := NewContainer().ChangeRequestService.ProcessScheduledChangeRequests(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+	config.Config.JwtSecret = jwtSecret
+
+	// then
+	assert.NoError(t, err)
+
+	actual := getTestChangeRequest(t, changeRequest.Id)
+	assert.Equal(t, constants.ChangeRequestStatusRolledBack, actual.Status)
+	assert.Equal(t, uint(2), actual.AppliedBy)
+	assert.Equal(t, "health check failed after apply", actual.FailureReason)
+	assert.False(t, actual.HealthCheck.Healthy)
+
+	setting := getMaintenanceSettingForTest(t)
+	assert.Equal(t, "", setting.Message)
+}
+
+func TestChangeRequestController_예약_시간_전_적용(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	changeRequest := createApprovedTestChangeRequest(t, `{
+		"title": "세션 제한",
+		"scheduledAt": "`+scheduledAt+`",
+		"changes": [{"type": "setting", "key": "session-limit", "value": {"maxSessions": 2, "exceedAction": "block"}}]
+	}`)
+
+	// when
+	rec := requestTestChangeRequest(http.MethodPost, fmt.Sprintf("/api/change-requests/%v/apply", changeRequest.Id), nil, 2)
+	err := NewContainer().ChangeRequestService.ProcessScheduledChangeRequests(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ChangeRequestStatusApproved, getTestChangeRequest(t, changeRequest.Id).Status)
+}
+
+func TestChangeRequestController_createChangeRequest_지원하지_않는_설정(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestTestChangeRequest(http.MethodPost, "/api/change-requests", strings.NewReader(`{
+		"title": "JWT Secret 변경",
+		"changes": [{"type": "setting", "key": "jwt-secret", "value": {"secret": "x"}}]
+	}`), 1)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_CHANGE_REQUEST")
+}
@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type ConsentController struct {
+	routerGroup    *gin.RouterGroup
+	consentService *services.ConsentService
+}
+
+func NewConsentController(
+	routerGroup *gin.RouterGroup,
+	consentService *services.ConsentService) *ConsentController {
+
+	return &ConsentController{
+		routerGroup:    routerGroup,
+		consentService: consentService,
+	}
+}
+
+func (c ConsentController) MapRoutes() {
+	route := c.routerGroup.Group("/members/me/consents")
+	route.GET("", middlewares.PermissionChecker([]string{"*"}),
+		c.getMyConsents)
+	route.POST("", middlewares.PermissionChecker([]string{"*"}),
+		c.grantConsent)
+	route.GET("/:clientId", middlewares.PermissionChecker([]string{"*"}),
+		c.getConsent)
+	route.DELETE("/:clientId", middlewares.PermissionChecker([]string{"*"}),
+		c.revokeConsent)
+}
+
+func (c ConsentController) getMyConsents(ctx *gin.Context) {
+	entities, err := c.consentService.GetMyConsents(ctx.Request.Context())
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	var consents = make([]dtos.MemberConsent, 0)
+	for _, entity := range entities {
+		consents = append(consents, dtos.MemberConsent{
+			Client: dtos.OAuthClientSummary{
+				ClientId:    entity.OAuthClient.ClientId,
+				Name:        entity.OAuthClient.Name,
+				Description: entity.OAuthClient.Description,
+			},
+			Scopes:    entity.GetScopes(),
+			GrantedAt: entity.UpdatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, consents)
+}
+
+// getConsent 는 동의 화면에 필요한 정보를 반환한다. scope 는 공백으로 구분하여 전달한다.
+func (c ConsentController) getConsent(ctx *gin.Context) {
+	consent, err := c.consentService.GetConsent(ctx.Request.Context(), ctx.Param("clientId"), strings.Fields(ctx.Query("scope")))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, errors.ErrInvalidScope) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, consent)
+}
+
+func (c ConsentController) grantConsent(ctx *gin.Context) {
+	var grant dtos.MemberConsentGrant
+	if err := ctx.BindJSON(&grant); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.consentService.GrantConsent(ctx.Request.Context(), grant)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) || errors.Is(err, errors.ErrInvalidScope) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (c ConsentController) revokeConsent(ctx *gin.Context) {
+	err := c.consentService.RevokeConsent(ctx.Request.Context(), ctx.Param("clientId"))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (ConsentController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,190 @@
+package rest
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsentController_getConsent_추가_동의가_필요한_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/members/me/consents/report-app?scope=profile+organizations", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.OAuthConsent
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "리포트 앱", actual.Client.Name)
+	assert.Equal(t, []string{"profile"}, actual.GrantedScopes)
+	assert.True(t, actual.ConsentRequired)
+}
+
+func TestConsentController_getConsent_자사_Client_인_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/members/me/consents/intranet?scope=profile", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.OAuthConsent
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.False(t, actual.ConsentRequired)
+}
+
+func TestConsentController_getConsent_등록되지_않은_scope(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/members/me/consents/intranet?scope=organizations", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestConsentController_grantConsent(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"clientId": "report-app",
+		"scopes": ["organizations"]
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/me/consents", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// 기존에 동의한 scope 에 더해진다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/me/consents", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []dtos.MemberConsent
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, []string{"profile", "organizations"}, actual[0].Scopes)
+}
+
+func TestConsentController_revokeConsent(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodDelete, "/api/members/me/consents/report-app", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/me/consents", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, "[]", rec.Body.String())
+}
+
+func TestConsentController_서비스_계정은_사용할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	// 서비스 계정의 Id(1)는 동의한 멤버(1)의 Id 와 같다.
+	getRec := requestAsServiceAccount(http.MethodGet, "/api/members/me/consents", "", 1, []string{})
+	grantRec := requestAsServiceAccount(http.MethodPost, "/api/members/me/consents", `{"clientId": "report-app", "scopes": ["organizations"]}`, 1, []string{})
+	revokeRec := requestAsServiceAccount(http.MethodDelete, "/api/members/me/consents/report-app", "", 1, []string{})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, getRec.Code)
+	assert.NotContains(t, getRec.Body.String(), "report-app")
+	assert.Equal(t, http.StatusUnauthorized, grantRec.Code)
+	assert.Equal(t, http.StatusUnauthorized, revokeRec.Code)
+
+	var scopes string
+	gormDB.Raw("SELECT scopes FROM member_consents WHERE member_id = 1 AND oauth_client_id = 1").Scan(&scopes)
+	assert.Equal(t, "profile", scopes)
+}
@@ -0,0 +1,222 @@
+package rest
+
+import (
+	"better-admin-backend-service/application"
+	approvalRepository "better-admin-backend-service/approval/repository"
+	auditRepository "better-admin-backend-service/audit/repository"
+	breakGlassRepository "better-admin-backend-service/breakglass/repository"
+	changeRequestRepository "better-admin-backend-service/changerequest/repository"
+	commandRepository "better-admin-backend-service/command/repository"
+	"better-admin-backend-service/constants"
+	eventRepository "better-admin-backend-service/event/repository"
+	exportRepository "better-admin-backend-service/export/repository"
+	fileRepository "better-admin-backend-service/file/repository"
+	i18nRepository "better-admin-backend-service/i18n/repository"
+	memberRepository "better-admin-backend-service/member/repository"
+	messageTemplateRepository "better-admin-backend-service/messagetemplate/repository"
+	noteRepository "better-admin-backend-service/note/repository"
+	oauthRepository "better-admin-backend-service/oauth/repository"
+	organizationRepository "better-admin-backend-service/organization/repository"
+	pluginSettingRepository "better-admin-backend-service/pluginsetting/repository"
+	rbacRepository "better-admin-backend-service/rbac/repository"
+	reportRepository "better-admin-backend-service/report/repository"
+	retentionRepository "better-admin-backend-service/retention/repository"
+	securityEventRepository "better-admin-backend-service/securityevent/repository"
+	segmentRepository "better-admin-backend-service/segment/repository"
+	serviceAccountRepository "better-admin-backend-service/serviceaccount/repository"
+	"better-admin-backend-service/services"
+	sessionRepository "better-admin-backend-service/session/repository"
+	siteRepository "better-admin-backend-service/site/repository"
+	statisticsRepository "better-admin-backend-service/statistics/repository"
+	tableViewRepository "better-admin-backend-service/tableview/repository"
+	tokenRepository "better-admin-backend-service/token/repository"
+	webHookRepository "better-admin-backend-service/webhook/repository"
+	"context"
+)
+
+// Container 는 라우터가 사용하는 서비스를 한 번만 만들어 보관한다. 모듈(RegisterModule)은 Container 의 서비스로 컨트롤러를 만든다.
+type Container struct {
+	DomainEventService          *services.DomainEventService
+	AccessHistoryService        *services.AccessHistoryService
+	RbacService                 *services.RoleBasedAccessControlService
+	MemberService               *services.MemberService
+	OrganizationService         *services.OrganizationService
+	SiteService                 *services.SiteService
+	WebHookService              *services.WebHookService
+	AuditService                *services.AuditService
+	SessionService              *services.SessionService
+	UsageStatisticsService      *services.UsageStatisticsService
+	BreakGlassService           *services.BreakGlassService
+	MemberAssignmentRuleService *services.MemberAssignmentRuleService
+	GoogleWorkspaceService      *services.GoogleWorkspaceService
+	AuthService                 *services.AuthService
+	AuthUseCase                 *application.AuthUseCase
+	SystemService               *services.SystemService
+	ServiceAccountService       *services.ServiceAccountService
+	TokenService                *services.TokenService
+	OauthClientService          *services.OAuthClientService
+	SignIdChangeService         *services.SignIdChangeService
+	ConsentService              *services.ConsentService
+	OauthAuthorizationService   *services.OAuthAuthorizationService
+	ApprovalDelegationService   *services.ApprovalDelegationService
+	ApprovalService             *services.ApprovalService
+	SegmentService              *services.SegmentService
+	PermissionCatalogService    *services.PermissionCatalogService
+	RoleMemberBulkService       *services.RoleMemberBulkService
+	PreferenceService           *services.PreferenceService
+	PendingSignUpService        *services.PendingSignUpService
+	MaintenanceService          *services.MaintenanceService
+	ServiceStatusService        *services.ServiceStatusService
+	PasswordBreachService       *services.PasswordBreachService
+	SecurityEventService        *services.SecurityEventService
+	LoginNotificationService    *services.LoginNotificationService
+	ReadOnlyService             *services.ReadOnlyService
+	DataMaskingService          *services.DataMaskingService
+	ConcurrencyLimitService     *services.ConcurrencyLimitService
+	LoginSettingService         *services.LoginSettingService
+	MemberApprovalService       *services.MemberApprovalService
+	PluginSettingService        *services.PluginSettingService
+	FileService                 *services.FileService
+	ReportService               *services.ReportService
+	InboundCommandService       *services.InboundCommandService
+	ChangeRequestService        *services.ChangeRequestService
+	NoteService                 *services.NoteService
+	MemberDataExportService     *services.MemberDataExportService
+	MemberDeprovisioningService *services.MemberDeprovisioningService
+	ResourceOwnershipService    *services.ResourceOwnershipService
+	PermissionSimulationService *services.PermissionSimulationService
+	MemberFieldChangeService    *services.MemberFieldChangeService
+	SuperAdminProtectionService *services.SuperAdminProtectionService
+	DiagnosticsService          *services.DiagnosticsService
+	ExportJobService            *services.ExportJobService
+	RetentionService            *services.RetentionService
+	MemberIdentityService       *services.MemberIdentityService
+	GroupRoleMappingService     *services.GroupRoleMappingService
+	RoleElevationService        *services.RoleElevationService
+	TableViewService            *services.TableViewService
+	I18nService                 *services.I18nService
+	MessageTemplateService      *services.MessageTemplateService
+	OrganizationSettingService  *services.OrganizationSettingService
+	LicenseService              *services.LicenseService
+}
+
+// NewContainer 는 서비스를 의존하는 순서대로 만든다.
+func NewContainer() *Container {
+	c := &Container{}
+	c.DomainEventService = services.NewDomainEventService(&eventRepository.DomainEventRepository{}, &eventRepository.EventStoreRepository{})
+	c.AccessHistoryService = services.NewAccessHistoryService(&rbacRepository.AccessHistoryRepository{}, &memberRepository.MemberRepository{})
+	c.RbacService = services.NewRoleBasedAccessControlService(&rbacRepository.PermissionRepository{}, &rbacRepository.RoleRepository{}, c.DomainEventService, c.AccessHistoryService)
+	c.MemberService = services.NewMemberService(c.RbacService, &memberRepository.MemberRepository{}, c.DomainEventService, c.AccessHistoryService)
+	c.OrganizationService = services.NewOrganizationService(c.RbacService, &organizationRepository.OrganizationRepository{}, c.MemberService)
+	c.SiteService = services.NewSiteService(&siteRepository.SiteSettingRepository{}, &siteRepository.SiteSettingVersionRepository{})
+	c.WebHookService = services.NewWebHookService(&webHookRepository.WebHookRepository{})
+	c.AuditService = services.NewAuditService(&auditRepository.AuditLogRepository{}, &auditRepository.ActivityFeedRepository{})
+	c.MessageTemplateService = services.NewMessageTemplateService(&messageTemplateRepository.MessageTemplateRepository{}, c.AuditService)
+	c.SecurityEventService = services.NewSecurityEventService(&securityEventRepository.SecurityEventRepository{}, c.SiteService, c.MemberService, c.AuditService,
+		c.MessageTemplateService)
+	c.SuperAdminProtectionService = services.NewSuperAdminProtectionService(c.SiteService, c.MemberService, c.SecurityEventService)
+	c.MemberService.RegisterSuperAdminGuard(c.SuperAdminProtectionService)
+	c.SessionService = services.NewSessionService(&sessionRepository.MemberSessionRepository{}, c.SiteService, c.AuditService)
+	c.LoginNotificationService = services.NewLoginNotificationService(&sessionRepository.LoginDeviceRepository{}, &sessionRepository.LoginNotificationRepository{},
+		c.SiteService, c.MemberService, c.SessionService, c.AuditService, c.SecurityEventService, c.MessageTemplateService)
+	c.UsageStatisticsService = services.NewUsageStatisticsService(c.OrganizationService, &statisticsRepository.LoginAttemptRepository{}, &statisticsRepository.UsageStatisticRepository{})
+	c.BreakGlassService = services.NewBreakGlassService(c.MemberService, &breakGlassRepository.BreakGlassAccountRepository{},
+		&breakGlassRepository.BreakGlassUsageRepository{}, c.AuditService, c.SecurityEventService, c.MessageTemplateService)
+	c.ApprovalDelegationService = services.NewApprovalDelegationService(c.MemberService, &approvalRepository.ApprovalDelegationRepository{}, c.AuditService)
+	c.ApprovalService = services.NewApprovalService(c.SiteService, c.MemberService, c.ApprovalDelegationService, &approvalRepository.ApprovalRequestRepository{}, c.AuditService,
+		c.MessageTemplateService)
+	c.ApprovalService.RegisterHandler(constants.ApprovalSubjectMemberSignUp, services.NewMemberSignUpApprovalHandler(c.MemberService))
+	c.ApprovalService.RegisterHandler(constants.ApprovalSubjectRoleGrant, services.NewRoleGrantApprovalHandler(c.MemberService))
+	c.MemberFieldChangeService = services.NewMemberFieldChangeService(c.MemberService, c.RbacService, c.ApprovalService,
+		&memberRepository.MemberFieldChangeRepository{}, c.AuditService)
+	c.ApprovalService.RegisterHandler(constants.ApprovalSubjectMemberFieldChange, services.NewMemberFieldChangeApprovalHandler(c.MemberFieldChangeService))
+	c.RoleElevationService = services.NewRoleElevationService(c.MemberService, c.RbacService, c.ApprovalService,
+		&rbacRepository.RoleElevationRepository{}, c.AuditService)
+	c.ApprovalService.RegisterHandler(constants.ApprovalSubjectRoleElevation, services.NewRoleElevationApprovalHandler(c.RoleElevationService))
+	c.AuditService.RegisterElevationFinder(c.RoleElevationService)
+	c.MemberApprovalService = services.NewMemberApprovalService(c.SiteService, c.RbacService, c.MemberService, c.ApprovalService, c.AuditService)
+	c.MemberAssignmentRuleService = services.NewMemberAssignmentRuleService(&memberRepository.MemberAssignmentRuleRepository{}, c.RbacService,
+		c.OrganizationService, c.MemberService, c.MemberApprovalService)
+	c.GoogleWorkspaceService = services.NewGoogleWorkspaceService(c.SiteService, c.RbacService)
+	c.MemberIdentityService = services.NewMemberIdentityService(c.MemberService, &memberRepository.MemberIdentityRepository{}, c.AuditService)
+	c.GroupRoleMappingService = services.NewGroupRoleMappingService(&rbacRepository.GroupRoleMappingRepository{}, c.RbacService, c.MemberService, c.AuditService)
+	c.LoginSettingService = services.NewLoginSettingService(c.SiteService, c.GoogleWorkspaceService)
+	c.OrganizationSettingService = services.NewOrganizationSettingService(c.SiteService, c.OrganizationService, c.LoginSettingService, c.AuditService)
+	c.MemberService.RegisterPasswordPolicyGuard(c.OrganizationSettingService)
+	c.AuthService = services.NewAuthService(c.MemberService, c.OrganizationService, c.SiteService, c.SessionService, c.UsageStatisticsService, c.AuditService,
+		c.BreakGlassService, c.MemberAssignmentRuleService, c.GoogleWorkspaceService, c.SecurityEventService, c.LoginNotificationService,
+		c.MemberIdentityService, c.GroupRoleMappingService, c.MessageTemplateService, c.OrganizationSettingService)
+	c.AuthUseCase = application.NewAuthUseCase(c.AuthService)
+	c.SystemService = services.NewSystemService(c.SiteService, c.WebHookService, c.AuditService)
+	c.ServiceAccountService = services.NewServiceAccountService(c.RbacService, &serviceAccountRepository.ServiceAccountRepository{},
+		&serviceAccountRepository.TokenExchangePolicyRepository{}, c.AuditService)
+	c.LicenseService = services.NewLicenseService(c.MemberService, c.RbacService, c.ServiceAccountService, c.MessageTemplateService)
+	c.MemberService.RegisterQuotaGuard(c.LicenseService)
+	c.RbacService.RegisterQuotaGuard(c.LicenseService)
+	c.ServiceAccountService.RegisterQuotaGuard(c.LicenseService)
+	c.TokenService = services.NewTokenService(c.ServiceAccountService, c.SessionService, c.AuditService, &tokenRepository.RevokedTokenRepository{})
+	c.OauthClientService = services.NewOAuthClientService(&oauthRepository.OAuthClientRepository{})
+	c.SignIdChangeService = services.NewSignIdChangeService(c.MemberService, &memberRepository.SignIdChangeRepository{}, c.SessionService, c.AuditService,
+		c.MessageTemplateService)
+	c.ConsentService = services.NewConsentService(c.OauthClientService, &oauthRepository.MemberConsentRepository{}, c.AuditService)
+	c.OauthAuthorizationService = services.NewOAuthAuthorizationService(c.OauthClientService, c.ConsentService, c.MemberService, c.OrganizationService,
+		&oauthRepository.OAuthAuthorizationCodeRepository{}, &oauthRepository.OAuthDeviceCodeRepository{})
+
+	c.SegmentService = services.NewSegmentService(c.MemberService, &segmentRepository.SegmentRepository{})
+	c.PermissionCatalogService = services.NewPermissionCatalogService(&rbacRepository.PermissionRepository{}, c.AuditService)
+	c.RoleMemberBulkService = services.NewRoleMemberBulkService(c.RbacService, c.MemberService, c.SegmentService, c.ApprovalService, c.AuditService,
+		&rbacRepository.RoleMemberBulkJobRepository{})
+	c.PreferenceService = services.NewPreferenceService(&memberRepository.MemberPreferenceRepository{})
+	c.PendingSignUpService = services.NewPendingSignUpService(c.SiteService, c.MemberService, &memberRepository.MemberRepository{}, c.AuditService,
+		c.MessageTemplateService)
+	c.MaintenanceService = services.NewMaintenanceService(c.SiteService)
+	c.ServiceStatusService = services.NewServiceStatusService(c.SiteService, c.MaintenanceService)
+	c.PasswordBreachService = services.NewPasswordBreachService(c.SiteService)
+	c.ReadOnlyService = services.NewReadOnlyService(c.SiteService)
+	c.DataMaskingService = services.NewDataMaskingService(c.SiteService)
+	c.ConcurrencyLimitService = services.NewConcurrencyLimitService(c.SiteService)
+	c.PluginSettingService = services.NewPluginSettingService(&pluginSettingRepository.PluginSettingRepository{})
+	c.FileService = services.NewFileService(&fileRepository.FileRepository{}, &fileRepository.AttachmentRepository{}, c.SiteService, c.MemberService, c.AuditService,
+		c.MessageTemplateService)
+	c.ReportService = services.NewReportService(&reportRepository.ReportRepository{}, &reportRepository.ReportRunRepository{}, &reportRepository.ReportDataRepository{},
+		c.DataMaskingService, c.MessageTemplateService)
+	c.ResourceOwnershipService = services.NewResourceOwnershipService(c.MemberService, c.AuditService)
+	c.ResourceOwnershipService.RegisterResourceType(constants.OwnedResourceTypeWebHook, constants.PermissionManageSystemSettings, c.WebHookService)
+	c.ResourceOwnershipService.RegisterResourceType(constants.OwnedResourceTypeReport, constants.PermissionManageSystemSettings, c.ReportService)
+	c.ResourceOwnershipService.RegisterResourceType(constants.OwnedResourceTypeServiceAccount, constants.PermissionManageSystemSettings, c.ServiceAccountService)
+	c.ResourceOwnershipService.RegisterResourceType(constants.OwnedResourceTypeSegment, constants.PermissionManageMembers, c.SegmentService)
+	c.PermissionSimulationService = services.NewPermissionSimulationService(c.RbacService, c.MemberService, c.OrganizationService, c.ResourceOwnershipService)
+	c.MemberDeprovisioningService = services.NewMemberDeprovisioningService(c.MemberService, c.SessionService, c.ConsentService, c.ServiceAccountService,
+		c.ResourceOwnershipService, &memberRepository.MemberDeprovisioningRepository{}, c.AuditService)
+	c.ChangeRequestService = services.NewChangeRequestService(&changeRequestRepository.ChangeRequestRepository{}, c.SiteService, c.RbacService,
+		c.MaintenanceService, c.DataMaskingService, c.ConcurrencyLimitService, c.LoginSettingService, c.AuditService)
+	c.InboundCommandService = services.NewInboundCommandService(c.ServiceAccountService, &commandRepository.InboundCommandRepository{}, &commandRepository.ConsumerOffsetRepository{})
+	c.InboundCommandService.RegisterHandler(constants.CommandMemberApprove, constants.PermissionManageMembers, services.NewMemberApproveCommandHandler(c.MemberService))
+	c.InboundCommandService.RegisterHandler(constants.CommandMemberReject, constants.PermissionManageMembers, services.NewMemberRejectCommandHandler(c.MemberService))
+	c.InboundCommandService.RegisterHandler(constants.CommandMemberGrantRoles, constants.PermissionManageMembers, services.NewMemberGrantRolesCommandHandler(c.MemberService))
+	c.TableViewService = services.NewTableViewService(&tableViewRepository.TableViewRepository{}, c.MemberService, c.OrganizationService, c.RbacService)
+	c.I18nService = services.NewI18nService(&i18nRepository.LocaleBundleRepository{}, c.AuditService)
+	c.NoteService = services.NewNoteService(&noteRepository.NoteRepository{}, c.MemberService, c.PreferenceService, c.FileService, c.AuditService,
+		c.MessageTemplateService)
+	c.NoteService.RegisterEntityType(constants.AuditTargetTypeMember, func(ctx context.Context, id uint) error {
+		_, err := c.MemberService.GetMember(ctx, id)
+		return err
+	})
+	c.NoteService.RegisterEntityType(constants.AuditTargetTypeApproval, func(ctx context.Context, id uint) error {
+		_, err := c.ApprovalService.GetApprovalRequest(ctx, id)
+		return err
+	})
+	c.NoteService.RegisterEntityType(constants.AuditTargetTypeChangeRequest, func(ctx context.Context, id uint) error {
+		_, err := c.ChangeRequestService.GetChangeRequest(ctx, id)
+		return err
+	})
+	c.MemberDataExportService = services.NewMemberDataExportService(c.MemberService, c.NoteService, c.ApprovalService, c.FileService, c.AuditService)
+	c.DiagnosticsService = services.NewDiagnosticsService(c.FileService, c.AuditService)
+	c.ExportJobService = services.NewExportJobService(&exportRepository.ExportJobRepository{}, c.FileService, c.MemberService,
+		c.MemberDataExportService, c.ReportService)
+	c.RetentionService = services.NewRetentionService(c.SiteService, c.MemberService, c.AuditService, &retentionRepository.LegalHoldRepository{},
+		&retentionRepository.RetentionRunRepository{}, &retentionRepository.RetentionDataRepository{})
+	c.MemberService.RegisterLegalHoldGuard(c.RetentionService)
+
+	return c
+}
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"strings"
+)
+
+type DiagnosticsController struct {
+	routerGroup        *gin.RouterGroup
+	diagnosticsService *services.DiagnosticsService
+}
+
+func NewDiagnosticsController(
+	routerGroup *gin.RouterGroup,
+	diagnosticsService *services.DiagnosticsService) *DiagnosticsController {
+
+	return &DiagnosticsController{
+		routerGroup:        routerGroup,
+		diagnosticsService: diagnosticsService,
+	}
+}
+
+func (c DiagnosticsController) MapRoutes() {
+	route := c.routerGroup.Group("/system")
+	route.GET("/runtime",
+		middlewares.PermissionChecker([]string{constants.PermissionSystemDiagnostics}),
+		c.getRuntimeMetric)
+	route.POST("/runtime/dumps",
+		middlewares.PermissionChecker([]string{constants.PermissionSystemDiagnostics}),
+		c.captureDump)
+	route.GET("/pprof/*name",
+		middlewares.PermissionChecker([]string{constants.PermissionSystemDiagnostics}),
+		c.servePprof)
+}
+
+func (c DiagnosticsController) getRuntimeMetric(ctx *gin.Context) {
+	metric, err := c.diagnosticsService.GetRuntimeMetric(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, metric)
+}
+
+// captureDump 는 goroutine 이나 heap 덤프를 파일 저장소에 저장하고 저장한 파일 정보를 응답한다.
+func (c DiagnosticsController) captureDump(ctx *gin.Context) {
+	var request dtos.DiagnosticsDumpRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.diagnosticsService.CaptureDump(ctx.Request.Context(), request)
+	if err != nil {
+		FileController{}.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, FileController{}.toFileInformation(entity))
+}
+
+// servePprof 는 net/http/pprof 의 handler 로 응답한다. /pprof/ 는 프로파일 목록이고 /pprof/{heap, goroutine, profile, trace 등} 은 각 프로파일이다.
+// CPU 프로파일과 trace 는 요청 제한 시간에 걸리지 않도록 seconds 를 Diagnostics.MaxProfileSeconds 까지로 줄인다.
+func (c DiagnosticsController) servePprof(ctx *gin.Context) {
+	if config.Config.Diagnostics.PprofDisabled {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	name := strings.TrimPrefix(ctx.Param("name"), "/")
+	switch name {
+	case "":
+		pprof.Index(ctx.Writer, ctx.Request)
+	case "cmdline":
+		pprof.Cmdline(ctx.Writer, ctx.Request)
+	case "symbol":
+		pprof.Symbol(ctx.Writer, ctx.Request)
+	case "profile":
+		c.limitProfileSeconds(ctx, 30)
+		pprof.Profile(ctx.Writer, ctx.Request)
+	case "trace":
+		c.limitProfileSeconds(ctx, 1)
+		pprof.Trace(ctx.Writer, ctx.Request)
+	default:
+		pprof.Handler(name).ServeHTTP(ctx.Writer, ctx.Request)
+	}
+}
+
+// limitProfileSeconds 는 요청한 수집 시간(seconds, 없으면 pprof 의 기본값 defaultSeconds)을 최대 수집 시간으로 제한한다.
+func (DiagnosticsController) limitProfileSeconds(ctx *gin.Context, defaultSeconds int) {
+	seconds, err := strconv.Atoi(ctx.Query("seconds"))
+	if err != nil || seconds <= 0 {
+		seconds = defaultSeconds
+	}
+
+	maxSeconds := config.Config.Diagnostics.MaxProfileSeconds
+	if maxSeconds <= 0 || seconds <= maxSeconds {
+		return
+	}
+
+	query := ctx.Request.URL.Query()
+	query.Set("seconds", strconv.Itoa(maxSeconds))
+	ctx.Request.URL.RawQuery = query.Encode()
+}
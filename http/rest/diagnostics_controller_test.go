@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestDiagnostics(method, url, requestBody string, permissions []string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if len(requestBody) > 0 {
+		req = httptest.NewRequest(method, url, strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, url, nil)
+	}
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDiagnosticsController_런타임_현황(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestDiagnostics(http.MethodGet, "/api/system/runtime", "", []string{constants.PermissionSystemDiagnostics})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var metric dtos.RuntimeMetric
+	json.Unmarshal(rec.Body.Bytes(), &metric)
+	assert.True(t, metric.Goroutines > 0)
+	assert.True(t, metric.Heap.AllocBytes > 0)
+	assert.NotEmpty(t, metric.GoVersion)
+	assert.True(t, metric.Database.OpenConnections > 0)
+
+	// 시스템 설정 권한으로는 조회할 수 없다.
+	rec = requestDiagnostics(http.MethodGet, "/api/system/runtime", "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestDiagnosticsController_pprof(t *testing.T) {
+	// when
+	rec := requestDiagnostics(http.MethodGet, "/api/system/pprof/goroutine?debug=1", "", []string{constants.PermissionSystemDiagnostics})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "goroutine profile:")
+
+	rec = requestDiagnostics(http.MethodGet, "/api/system/pprof/", "", []string{constants.PermissionSystemDiagnostics})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "heap")
+
+	rec = requestDiagnostics(http.MethodGet, "/api/system/pprof/heap", "", []string{constants.PermissionViewMonitoring})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestDiagnosticsController_덤프_저장(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// when
+	rec := requestDiagnostics(http.MethodPost, "/api/system/runtime/dumps", `{"type": "goroutine"}`, []string{constants.PermissionSystemDiagnostics})
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var goroutineDump dtos.FileInformation
+	json.Unmarshal(rec.Body.Bytes(), &goroutineDump)
+	assert.Equal(t, constants.FilePurposeDiagnostics, goroutineDump.Purpose)
+	assert.Equal(t, "text/plain", goroutineDump.ContentType)
+	assert.True(t, strings.HasPrefix(goroutineDump.Name, "goroutine-"))
+
+	rec = requestDiagnostics(http.MethodPost, "/api/system/runtime/dumps", `{"type": "heap"}`, []string{constants.PermissionSystemDiagnostics})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var heapDump dtos.FileInformation
+	json.Unmarshal(rec.Body.Bytes(), &heapDump)
+	assert.Equal(t, "application/x-gzip", heapDump.ContentType)
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ?", constants.AuditActionDiagnosticsDumpCaptured).Scan(&auditCount)
+	assert.Equal(t, int64(2), auditCount)
+
+	rec = requestDiagnostics(http.MethodPost, "/api/system/runtime/dumps", `{"type": "cpu"}`, []string{constants.PermissionSystemDiagnostics})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 진단 덤프는 SYSTEM_DIAGNOSTICS 권한이 있어야 내려받을 수 있다.
+	url := fmt.Sprintf("/api/files/%v/content", goroutineDump.Id)
+	rec = requestDiagnostics(http.MethodGet, url, "", []string{constants.PermissionSystemDiagnostics})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "goroutine")
+
+	rec = requestDiagnostics(http.MethodGet, url, "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 파일 업로드 API 로 진단 파일을 만들 수도 없다.
+	rec = uploadTestFile(2, []string{}, constants.FilePurposeDiagnostics, "goroutine.txt", []byte("goroutine 1 [running]:"))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
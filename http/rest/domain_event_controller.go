@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/event/domain"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"encoding/json"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type DomainEventController struct {
+	routerGroup        *gin.RouterGroup
+	domainEventService *services.DomainEventService
+}
+
+func NewDomainEventController(routerGroup *gin.RouterGroup, domainEventService *services.DomainEventService) *DomainEventController {
+	return &DomainEventController{
+		routerGroup:        routerGroup,
+		domainEventService: domainEventService,
+	}
+}
+
+func (c DomainEventController) MapRoutes() {
+	route := c.routerGroup.Group("/domain-events")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getDomainEvents)
+	route.POST("/:id/retry", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.retryDomainEvent)
+}
+
+func (c DomainEventController) getDomainEvents(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	filters := map[string]interface{}{}
+
+	if len(ctx.Query("status")) > 0 {
+		filters["status"] = ctx.Query("status")
+	}
+
+	if len(ctx.Query("type")) > 0 {
+		filters["type"] = ctx.Query("type")
+	}
+
+	entities, totalCount, err := c.domainEventService.GetDomainEvents(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var domainEvents = make([]dtos.DomainEventInformation, 0)
+	for _, entity := range entities {
+		domainEvents = append(domainEvents, c.toDomainEventInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     domainEvents,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (c DomainEventController) retryDomainEvent(ctx *gin.Context) {
+	domainEventId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.domainEventService.RetryDomainEvent(ctx.Request.Context(), uint(domainEventId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, errors.ErrNonChangeable) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toDomainEventInformation(entity))
+}
+
+func (DomainEventController) toDomainEventInformation(entity domain.DomainEventEntity) dtos.DomainEventInformation {
+	return dtos.DomainEventInformation{
+		Id:            entity.ID,
+		EventId:       entity.EventId,
+		Type:          entity.Type,
+		SchemaVersion: entity.SchemaVersion,
+		AggregateType: entity.AggregateType,
+		AggregateId:   entity.AggregateId,
+		Status:        entity.Status,
+		Attempts:      entity.Attempts,
+		NextAttemptAt: entity.NextAttemptAt,
+		PublishedAt:   entity.PublishedAt,
+		LastError:     entity.LastError,
+		Payload:       json.RawMessage(entity.Payload),
+		CreatedAt:     entity.CreatedAt,
+	}
+}
@@ -0,0 +1,367 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	eventRepository "better-admin-backend-service/event/repository"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"better-admin-backend-service/testdata/testdb"
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeMessagePublisher struct {
+	mutex    sync.Mutex
+	messages []adapters.BrokerMessage
+	failures int
+}
+
+func (f *fakeMessagePublisher) Publish(messages []adapters.BrokerMessage) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return fmt.Errorf("fake broker error")
+	}
+	f.messages = append(f.messages, messages...)
+	return nil
+}
+
+func publishTestDomainEvents(t *testing.T) {
+	service := services.NewDomainEventService(&eventRepository.DomainEventRepository{}, &eventRepository.EventStoreRepository{})
+	err := service.PublishPendingEvents(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+	assert.NoError(t, err)
+}
+
+func approveTestMember(t *testing.T, memberId uint) {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/members/%d/approved", memberId), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestDomainEvent_멤버_승인_이벤트_발행(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	publisher := &fakeMessagePublisher{}
+	adapters.MessageBrokerAdapter().SetPublisher(publisher)
+	defer adapters.MessageBrokerAdapter().SetPublisher(nil)
+	approveTestMember(t, 4)
+
+	var status string
+	gormDB.Raw("SELECT status FROM domain_events WHERE type = ? AND aggregate_id = 4", constants.DomainEventMemberApproved).Scan(&status)
+	assert.Equal(t, constants.DomainEventStatusPending, status)
+
+	// when
+	publishTestDomainEvents(t)
+
+	// then
+	assert.Equal(t, 1, len(publisher.messages))
+	message := publisher.messages[0]
+	assert.Equal(t, "better-admin.member.approved", message.Topic)
+	assert.Equal(t, "member:4", message.Key)
+	assert.Equal(t, constants.DomainEventMemberApproved, message.Headers["eventType"])
+	assert.Equal(t, "1", message.Headers["schemaVersion"])
+
+	var envelope map[string]interface{}
+	json.Unmarshal(message.Value, &envelope)
+	assert.Equal(t, message.Headers["eventId"], envelope["id"])
+	assert.Equal(t, float64(constants.DomainEventSchemaVersion), envelope["schemaVersion"])
+	assert.Equal(t, constants.DomainEventSource, envelope["source"])
+	assert.Equal(t, map[string]interface{}{"type": constants.AuditActorTypeMember, "id": float64(1)}, envelope["actor"])
+	data := envelope["data"].(map[string]interface{})
+	assert.Equal(t, float64(4), data["memberId"])
+	assert.Equal(t, constants.StatusMemberApproved, data["status"])
+	assert.Nil(t, data["password"])
+
+	gormDB.Raw("SELECT status FROM domain_events WHERE type = ? AND aggregate_id = 4", constants.DomainEventMemberApproved).Scan(&status)
+	assert.Equal(t, constants.DomainEventStatusPublished, status)
+}
+
+func TestDomainEvent_브로커가_없으면_기록하지_않음(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	approveTestMember(t, 4)
+
+	// then
+	var count int64
+	gormDB.Raw("SELECT count(*) FROM domain_events").Scan(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestDomainEvent_발행_실패하면_다시_발행(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	maxAttempts := config.Config.MessageBroker.MaxAttempts
+	config.Config.MessageBroker.MaxAttempts = 2
+	defer func() { config.Config.MessageBroker.MaxAttempts = maxAttempts }()
+
+	publisher := &fakeMessagePublisher{failures: 2}
+	adapters.MessageBrokerAdapter().SetPublisher(publisher)
+	defer adapters.MessageBrokerAdapter().SetPublisher(nil)
+	approveTestMember(t, 4)
+
+	// when
+	publishTestDomainEvents(t)
+
+	// then
+	var event struct {
+		Id            uint
+		Status        string
+		Attempts      int
+		NextAttemptAt time.Time
+		LastError     string
+	}
+	gormDB.Raw("SELECT id, status, attempts, next_attempt_at, last_error FROM domain_events").Scan(&event)
+	assert.Equal(t, constants.DomainEventStatusPending, event.Status)
+	assert.Equal(t, 1, event.Attempts)
+	assert.Equal(t, "fake broker error", event.LastError)
+	assert.True(t, event.NextAttemptAt.After(time.Now()))
+
+	// 기다리는 중에는 발행하지 않는다.
+	publishTestDomainEvents(t)
+	gormDB.Raw("SELECT id, status, attempts, next_attempt_at, last_error FROM domain_events").Scan(&event)
+	assert.Equal(t, 1, event.Attempts)
+
+	// MaxAttempts 번 실패하면 failed 로 둔다.
+	gormDB.Exec("UPDATE domain_events SET next_attempt_at = ?", time.Now().Add(-time.Second))
+	publishTestDomainEvents(t)
+	gormDB.Raw("SELECT id, status, attempts, next_attempt_at, last_error FROM domain_events").Scan(&event)
+	assert.Equal(t, constants.DomainEventStatusFailed, event.Status)
+	assert.Equal(t, 2, event.Attempts)
+	assert.Equal(t, 0, len(publisher.messages))
+}
+
+func TestDomainEventController_실패한_이벤트_조회_및_재발행(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	maxAttempts := config.Config.MessageBroker.MaxAttempts
+	config.Config.MessageBroker.MaxAttempts = 1
+	defer func() { config.Config.MessageBroker.MaxAttempts = maxAttempts }()
+
+	publisher := &fakeMessagePublisher{failures: 1}
+	adapters.MessageBrokerAdapter().SetPublisher(publisher)
+	defer adapters.MessageBrokerAdapter().SetPublisher(nil)
+	approveTestMember(t, 4)
+	publishTestDomainEvents(t)
+
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/domain-events?status=failed", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var pageResult struct {
+		Result     []dtos.DomainEventInformation `json:"result"`
+		TotalCount int64                         `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &pageResult)
+	assert.Equal(t, int64(1), pageResult.TotalCount)
+	failed := pageResult.Result[0]
+	assert.Equal(t, constants.DomainEventMemberApproved, failed.Type)
+	assert.Equal(t, "fake broker error", failed.LastError)
+	assert.Contains(t, string(failed.Payload), `"memberId":4`)
+
+	// when
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/domain-events/%d/retry", failed.Id), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	publishTestDomainEvents(t)
+	assert.Equal(t, 1, len(publisher.messages))
+	assert.Equal(t, failed.EventId, publisher.messages[0].Headers["eventId"])
+
+	// 발행한 이벤트는 다시 발행할 수 없다.
+	req = httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/domain-events/%d/retry", failed.Id), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDomainEventController_권한_확인(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/domain-events", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"TC"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestDomainEvent_하나의_토픽으로_발행(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	topicStrategy := config.Config.MessageBroker.TopicStrategy
+	config.Config.MessageBroker.TopicStrategy = constants.MessageBrokerTopicStrategySingle
+	defer func() { config.Config.MessageBroker.TopicStrategy = topicStrategy }()
+
+	publisher := &fakeMessagePublisher{}
+	adapters.MessageBrokerAdapter().SetPublisher(publisher)
+	defer adapters.MessageBrokerAdapter().SetPublisher(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/access-control/roles/3", strings.NewReader(`{
+		"name": "프로덕트 오너",
+		"description": "프로덕트",
+		"allowedPermissionIds": [1, 2]
+	}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageAccessControl},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	publishTestDomainEvents(t)
+
+	// then
+	assert.Equal(t, 1, len(publisher.messages))
+	assert.Equal(t, config.Config.MessageBroker.Topic, publisher.messages[0].Topic)
+	assert.Equal(t, constants.DomainEventRoleUpdated, publisher.messages[0].Headers["eventType"])
+	assert.Equal(t, "role:3", publisher.messages[0].Key)
+
+	var envelope struct {
+		Data dtos.RoleEventData `json:"data"`
+	}
+	json.Unmarshal(publisher.messages[0].Value, &envelope)
+	assert.Equal(t, "프로덕트 오너", envelope.Data.Name)
+	assert.Equal(t, 2, len(envelope.Data.Permissions))
+}
+
+// startFakeNatsServer 는 INFO, CONNECT, HPUB, PING 만 처리하는 NATS 서버이다. 받은 HPUB 의 헤더와 본문을 published 로 보낸다.
+func startFakeNatsServer(t *testing.T, rejectSubject string, published chan string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				conn.Write([]byte(`INFO {"server_id":"fake","headers":true,"max_payload":1048576}` + "\r\n"))
+
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					if len(fields) == 0 {
+						continue
+					}
+
+					switch fields[0] {
+					case "CONNECT":
+						var options map[string]interface{}
+						json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "CONNECT ")), &options)
+						if options["auth_token"] != "secret" {
+							conn.Write([]byte("-ERR 'Authorization Violation'\r\n"))
+							return
+						}
+					case "HPUB":
+						totalLength, _ := strconv.Atoi(fields[3])
+						content := make([]byte, totalLength+2)
+						io.ReadFull(reader, content)
+						if fields[1] == rejectSubject {
+							conn.Write([]byte("-ERR 'Permissions Violation for Publish to " + rejectSubject + "'\r\n"))
+							continue
+						}
+						published <- fields[1] + "\n" + string(content[:totalLength])
+					case "PING":
+						conn.Write([]byte("PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return listener
+}
+
+func TestNatsClient_Publish(t *testing.T) {
+	// given
+	published := make(chan string, 10)
+	listener := startFakeNatsServer(t, "", published)
+	defer listener.Close()
+
+	publisher := adapters.NatsClient{Url: "nats://" + listener.Addr().String(), Token: "secret", Timeout: 5 * time.Second}
+
+	// when
+	err := publisher.Publish([]adapters.BrokerMessage{{
+		Topic:   "better-admin.member.approved",
+		Key:     "member:4",
+		Value:   []byte(`{"id":"abc"}`),
+		Headers: map[string]string{"eventId": "abc", "eventType": "member.approved"},
+	}})
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "better-admin.member.approved\nNATS/1.0\r\neventId: abc\r\neventType: member.approved\r\n\r\n{\"id\":\"abc\"}", <-published)
+}
+
+func TestNatsClient_Publish_서버가_거절(t *testing.T) {
+	// given
+	published := make(chan string, 10)
+	listener := startFakeNatsServer(t, "denied", published)
+	defer listener.Close()
+
+	// when
+	authErr := adapters.NatsClient{Url: "nats://" + listener.Addr().String(), Timeout: 5 * time.Second}.
+		Publish([]adapters.BrokerMessage{{Topic: "allowed", Value: []byte("{}")}})
+	publishErr := adapters.NatsClient{Url: "nats://secret@" + listener.Addr().String(), Timeout: 5 * time.Second}.
+		Publish([]adapters.BrokerMessage{{Topic: "denied", Value: []byte("{}")}})
+
+	// then
+	assert.EqualError(t, authErr, "nats error: 'Authorization Violation'")
+	assert.EqualError(t, publishErr, "nats error: 'Permissions Violation for Publish to denied'")
+	assert.Equal(t, 0, len(published))
+}
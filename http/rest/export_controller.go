@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/export/domain"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type ExportController struct {
+	routerGroup      *gin.RouterGroup
+	exportJobService *services.ExportJobService
+	tokenService     *services.TokenService
+}
+
+func NewExportController(
+	routerGroup *gin.RouterGroup,
+	exportJobService *services.ExportJobService,
+	tokenService *services.TokenService) *ExportController {
+
+	return &ExportController{
+		routerGroup:      routerGroup,
+		exportJobService: exportJobService,
+		tokenService:     tokenService,
+	}
+}
+
+func (c ExportController) MapRoutes() {
+	route := c.routerGroup.Group("/exports")
+	route.POST("", middlewares.PermissionChecker([]string{"*"}),
+		c.createExportJob)
+	route.GET("", middlewares.PermissionChecker([]string{"*"}),
+		c.getExportJobs)
+	route.GET("/:id", middlewares.PermissionChecker([]string{"*"}),
+		c.getExportJob)
+	route.GET("/:id/download", middlewares.PermissionChecker([]string{"*"}),
+		c.downloadExportFile)
+}
+
+// createExportJob 은 내보내기 작업을 등록한다. 진행 상태는 GET /exports/:id 로 확인한다.
+func (c ExportController) createExportJob(ctx *gin.Context) {
+	var request dtos.ExportJobRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.exportJobService.CreateExportJob(ctx.Request.Context(), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+func (c ExportController) getExportJobs(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	entities, totalCount, err := c.exportJobService.GetExportJobs(ctx.Request.Context(), pageable)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	jobs := make([]dtos.ExportJobInformation, 0)
+	for _, entity := range entities {
+		jobs = append(jobs, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     jobs,
+		TotalCount: totalCount,
+	})
+}
+
+// getExportJob 은 작업의 진행 상태를 조회한다. 완료한 작업은 보관 시간까지 사용할 수 있는 다운로드 URL 을 함께 응답한다.
+func (c ExportController) getExportJob(ctx *gin.Context) {
+	jobId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.exportJobService.GetExportJob(ctx.Request.Context(), uint(jobId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	information, err := c.toExportJobInformation(ctx, entity)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, information)
+}
+
+// downloadExportFile 은 결과 파일을 내려받는다. Range 요청을 지원하므로 중단된 다운로드를 이어서 받을 수 있다.
+func (c ExportController) downloadExportFile(ctx *gin.Context) {
+	jobId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, content, err := c.exportJobService.OpenExportFile(ctx.Request.Context(), uint(jobId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	readSeeker, ok := content.(io.ReadSeeker)
+	if !ok {
+		buffer, err := io.ReadAll(content)
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+		readSeeker = bytes.NewReader(buffer)
+	}
+
+	// If-Range 로 이어 받는 내용이 같은 파일인지 확인할 수 있도록 체크섬을 ETag 로 응답한다.
+	ctx.Header("Content-Type", entity.ContentType)
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": entity.Name}))
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("ETag", strconv.Quote(entity.Checksum))
+	http.ServeContent(ctx.Writer, ctx.Request, entity.Name, entity.CreatedAt, readSeeker)
+}
+
+func (c ExportController) toExportJobInformation(ctx *gin.Context, entity domain.ExportJobEntity) (dtos.ExportJobInformation, error) {
+	information := entity.ToInformation()
+	if !entity.IsDownloadable(time.Now()) {
+		return information, nil
+	}
+
+	file, err := c.exportJobService.GetExportFile(ctx.Request.Context(), entity)
+	if err != nil {
+		return dtos.ExportJobInformation{}, err
+	}
+	information.FileName = file.Name
+	information.Size = file.Size
+
+	resource := fmt.Sprintf("%s/exports/%d/download", c.routerGroup.BasePath(), entity.ID)
+	scopedToken, err := c.tokenService.IssueScopedToken(ctx.Request.Context(), dtos.ScopedTokenRequest{
+		Resource:  resource,
+		Action:    http.MethodGet,
+		ExpiresIn: int(time.Until(*entity.ExpiresAt).Seconds()),
+	})
+	if err != nil {
+		return dtos.ExportJobInformation{}, err
+	}
+	information.DownloadUrl = fmt.Sprintf("%s?%s=%s", resource, security.ScopedTokenQueryKey, scopedToken.Token)
+
+	return information, nil
+}
+
+func (ExportController) handleError(ctx *gin.Context, err error) {
+	FileController{}.handleError(ctx, err)
+}
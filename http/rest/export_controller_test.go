@@ -0,0 +1,169 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestTestExport(method, url, requestBody string, memberId int, permissions []string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if len(requestBody) > 0 {
+		req = httptest.NewRequest(method, url, strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, url, nil)
+	}
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func processTestExportJobs(t *testing.T) {
+	err := NewContainer().ExportJobService.ProcessPendingJobs(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+	assert.NoError(t, err)
+}
+
+func TestExportController_내보내기_작업(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+	permissions := []string{constants.PermissionManageMembers}
+
+	// given
+	rec := requestTestExport(http.MethodPost, "/api/exports", `{"type": "member-data", "targetId": 3}`, 1, permissions)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var created dtos.ExportJobInformation
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	assert.Equal(t, constants.ExportJobStatusPending, created.Status)
+	assert.Equal(t, 0, created.Progress)
+
+	// when
+	processTestExportJobs(t)
+
+	// then
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/exports/%d", created.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var job dtos.ExportJobInformation
+	json.Unmarshal(rec.Body.Bytes(), &job)
+	assert.Equal(t, constants.ExportJobStatusSucceeded, job.Status)
+	assert.Equal(t, 100, job.Progress)
+	assert.True(t, strings.HasSuffix(job.FileName, ".zip"))
+	assert.True(t, job.Size > 0)
+	assert.NotNil(t, job.ExpiresAt)
+	assert.True(t, strings.HasPrefix(job.DownloadUrl, fmt.Sprintf("/api/exports/%d/download?", created.Id)))
+
+	// Authorization 헤더 없이 다운로드 URL 로 내려받는다.
+	req := httptest.NewRequest(http.MethodGet, job.DownloadUrl, nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "bytes", rec.Header().Get("Accept-Ranges"))
+	assert.Equal(t, job.Size, int64(rec.Body.Len()))
+	content := rec.Body.Bytes()
+
+	// 중단된 다운로드는 Range 요청으로 이어서 받는다.
+	req = httptest.NewRequest(http.MethodGet, job.DownloadUrl, nil)
+	req.Header.Set("Range", "bytes=10-")
+	req.Header.Set("If-Range", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusPartialContent, rec.Code)
+	assert.Equal(t, fmt.Sprintf("bytes 10-%d/%d", job.Size-1, job.Size), rec.Header().Get("Content-Range"))
+	assert.Equal(t, content[10:], rec.Body.Bytes())
+
+	// 다른 멤버의 작업은 조회하거나 내려받을 수 없다.
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/exports/%d", created.Id), "", 2, permissions)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/exports/%d/download", created.Id), "", 2, permissions)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 결과 파일은 요청한 멤버만 볼 수 있다.
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/files/%d/content", job.FileId), "", 2, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 서비스 계정의 Id(1)가 요청한 멤버(1)의 Id 와 같아도 작업을 조회하거나 결과를 내려받을 수 없다.
+	rec = requestAsServiceAccount(http.MethodGet, "/api/exports", "", 1, permissions)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = requestAsServiceAccount(http.MethodGet, fmt.Sprintf("/api/exports/%d/download", created.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = requestAsServiceAccount(http.MethodGet, fmt.Sprintf("/api/files/%d/content", job.FileId), "", 1, permissions)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = requestAsServiceAccount(http.MethodPost, "/api/exports", `{"type": "member-data", "targetId": 3}`, 1, permissions)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestExportController_내보내기_작업_권한과_대상(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// 멤버 관리 권한이 없으면 멤버 데이터를 내보낼 수 없다.
+	rec := requestTestExport(http.MethodPost, "/api/exports", `{"type": "member-data", "targetId": 3}`, 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 없는 대상은 작업을 등록하지 않는다.
+	rec = requestTestExport(http.MethodPost, "/api/exports", `{"type": "report", "targetId": 999}`, 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = requestTestExport(http.MethodPost, "/api/exports", `{"type": "audit", "targetId": 1}`, 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var jobCount int64
+	gormDB.Raw("SELECT count(*) FROM export_jobs").Scan(&jobCount)
+	assert.Equal(t, int64(0), jobCount)
+}
+
+func TestExportController_보관_시간이_지난_결과(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+	permissions := []string{constants.PermissionManageMembers}
+
+	// given
+	rec := requestTestExport(http.MethodPost, "/api/exports", `{"type": "member-data", "targetId": 3}`, 1, permissions)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var created dtos.ExportJobInformation
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	processTestExportJobs(t)
+
+	var fileId uint
+	gormDB.Raw("SELECT file_id FROM export_jobs WHERE id = ?", created.Id).Scan(&fileId)
+	gormDB.Exec("UPDATE export_jobs SET expires_at = ? WHERE id = ?", time.Now().Add(-time.Minute), created.Id)
+
+	// 정리 작업 전이라도 내려받을 수 없다.
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/exports/%d/download", created.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// when
+	err := NewContainer().ExportJobService.DeleteExpiredExports(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.NoError(t, err)
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/exports/%d", created.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var job dtos.ExportJobInformation
+	json.Unmarshal(rec.Body.Bytes(), &job)
+	assert.Equal(t, constants.ExportJobStatusExpired, job.Status)
+	assert.Empty(t, job.DownloadUrl)
+
+	var fileCount int64
+	gormDB.Raw("SELECT count(*) FROM files WHERE id = ? AND deleted_at IS NULL", fileId).Scan(&fileCount)
+	assert.Equal(t, int64(0), fileCount)
+}
@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/application"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// failureStatusCodes 는 유스케이스 실패 종류의 HTTP 상태 코드이다.
+var failureStatusCodes = map[string]int{
+	constants.FailureKindInvalidCredential:    http.StatusBadRequest,
+	constants.FailureKindUnapproved:           http.StatusNotAcceptable,
+	constants.FailureKindSessionLimitExceeded: http.StatusConflict,
+	constants.FailureKindDenied:               http.StatusForbidden,
+	constants.FailureKindNotFound:             http.StatusNotFound,
+	constants.FailureKindUnauthorized:         http.StatusUnauthorized,
+	constants.FailureKindInvalidRefreshToken:  http.StatusNotAcceptable,
+	constants.FailureKindInvalidRequest:       http.StatusBadRequest,
+}
+
+// respondFailure 는 유스케이스 오류를 오류 코드와 함께 응답한다. Failure 가 아니면 500 으로 응답한다.
+func respondFailure(ctx *gin.Context, err error) {
+	failure, ok := err.(*application.Failure)
+	if !ok {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	statusCode, ok := failureStatusCodes[failure.Kind]
+	if !ok {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	code := errors.Code(failure)
+	if len(code) > 0 {
+		ctx.Header(middlewares.ErrorCodeHeader, code)
+	}
+
+	if len(failure.Reason) > 0 {
+		ctx.JSON(statusCode, dtos.ErrorMessage{Code: code, Message: failure.Reason})
+		return
+	}
+
+	if failure.Err != nil {
+		ctx.JSON(statusCode, failure.Err.Error())
+		return
+	}
+
+	ctx.JSON(statusCode, nil)
+}
@@ -0,0 +1,410 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/file/domain"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
+	"fmt"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type FileController struct {
+	routerGroup  *gin.RouterGroup
+	fileService  *services.FileService
+	tokenService *services.TokenService
+}
+
+func NewFileController(
+	routerGroup *gin.RouterGroup,
+	fileService *services.FileService,
+	tokenService *services.TokenService) *FileController {
+
+	return &FileController{
+		routerGroup:  routerGroup,
+		fileService:  fileService,
+		tokenService: tokenService,
+	}
+}
+
+func (c FileController) MapRoutes() {
+	route := c.routerGroup.Group("/files")
+	route.POST("", middlewares.PermissionChecker([]string{"*"}),
+		c.uploadFile)
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getFiles)
+	route.GET("/usage", middlewares.PermissionChecker([]string{"*"}),
+		c.getFileStorageUsage)
+	route.GET("/:id", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getFile)
+	route.GET("/:id/content", middlewares.PermissionChecker([]string{"*"}),
+		c.getFileContent)
+	route.PUT("/:id/content", middlewares.PermissionChecker([]string{"*"}),
+		c.replaceFileContent)
+	route.GET("/:id/thumbnail", middlewares.PermissionChecker([]string{"*"}),
+		c.getThumbnail)
+	route.POST("/:id/download-url", middlewares.PermissionChecker([]string{"*"}),
+		c.issueDownloadUrl)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{"*"}),
+		c.deleteFile)
+
+	siteRoute := c.routerGroup.Group("/site")
+	siteRoute.GET("/settings/file-quota",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getFileQuotaSetting)
+	siteRoute.PUT("/settings/file-quota",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setFileQuotaSetting)
+}
+
+func (c FileController) uploadFile(ctx *gin.Context) {
+	name, content, err := c.readFormFile(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.fileService.UploadFile(ctx.Request.Context(), ctx.PostForm("purpose"), name, content)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.toFileInformation(entity))
+}
+
+func (c FileController) getFiles(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	filters := map[string]interface{}{}
+
+	if len(ctx.Query("purpose")) > 0 {
+		filters["purpose"] = ctx.Query("purpose")
+	}
+
+	if len(ctx.Query("scanStatus")) > 0 {
+		filters["scanStatus"] = ctx.Query("scanStatus")
+	}
+
+	if len(ctx.Query("createdBy")) > 0 {
+		createdBy, err := strconv.ParseUint(ctx.Query("createdBy"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters["createdBy"] = uint(createdBy)
+	}
+
+	entities, totalCount, err := c.fileService.GetFiles(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var files = make([]dtos.FileInformation, 0)
+	for _, entity := range entities {
+		files = append(files, c.toFileInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     files,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+// getFileStorageUsage 는 요청한 멤버가 올린 파일의 크기 합과 저장 한도를 조회한다.
+func (c FileController) getFileStorageUsage(ctx *gin.Context) {
+	usage, err := c.fileService.GetFileStorageUsage(ctx.Request.Context())
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, usage)
+}
+
+func (c FileController) getFileQuotaSetting(ctx *gin.Context) {
+	setting, err := c.fileService.GetFileQuotaSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c FileController) setFileQuotaSetting(ctx *gin.Context) {
+	var setting dtos.FileQuotaSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.fileService.SetFileQuotaSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c FileController) getFile(ctx *gin.Context) {
+	fileId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.fileService.GetFile(ctx.Request.Context(), uint(fileId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toFileInformation(entity))
+}
+
+func (c FileController) getFileContent(ctx *gin.Context) {
+	fileId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, content, err := c.fileService.OpenFileContent(ctx.Request.Context(), uint(fileId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+	defer content.Close()
+
+	// 이미지는 화면에 바로 표시하고, 그 외 파일은 내려받는다.
+	disposition := "attachment"
+	if strings.HasPrefix(entity.ContentType, "image/") {
+		disposition = "inline"
+	}
+
+	ctx.DataFromReader(http.StatusOK, entity.Size, entity.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": entity.Name}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (c FileController) replaceFileContent(ctx *gin.Context) {
+	fileId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	name, content, err := c.readFormFile(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.fileService.ReplaceFile(ctx.Request.Context(), uint(fileId), name, content)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toFileInformation(entity))
+}
+
+// getThumbnail 은 설정(Thumbnail.Sizes)에 있는 크기의 썸네일만 응답한다.
+// ETag 에 원본의 checksum 이 들어가므로 원본을 교체하면 브라우저 캐시도 사용하지 않는다.
+func (c FileController) getThumbnail(ctx *gin.Context) {
+	fileId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	width, err := strconv.Atoi(ctx.Query("w"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	height, err := strconv.Atoi(ctx.Query("h"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, thumbnail, err := c.fileService.GetThumbnail(ctx.Request.Context(), uint(fileId), width, height)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	thumbnailEtag := fmt.Sprintf(`"%s-%dx%d"`, entity.Checksum, width, height)
+	ctx.Header("ETag", thumbnailEtag)
+	ctx.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", config.Config.Thumbnail.MaxAgeSeconds))
+	if ctx.GetHeader("If-None-Match") == thumbnailEtag {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.Data(http.StatusOK, thumbnail.ContentType, thumbnail.Content)
+}
+
+// issueDownloadUrl 은 Authorization 헤더 없이 파일 내용을 받을 수 있는 URL 을 발급한다.
+// URL 의 토큰은 해당 파일 내용의 GET 요청에만 사용할 수 있는 스코프 토큰이다.
+func (c FileController) issueDownloadUrl(ctx *gin.Context) {
+	fileId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var request dtos.FileDownloadUrlRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.BindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	entity, err := c.fileService.GetFile(ctx.Request.Context(), uint(fileId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	if entity.IsQuarantined() {
+		c.handleError(ctx, errors.ErrQuarantined)
+		return
+	}
+
+	// 첨부 파일은 첨부한 대상의 API 로만 내려받을 수 있다.
+	if entity.IsAttachment() {
+		c.handleError(ctx, errors.ErrForbidden)
+		return
+	}
+
+	resource := fmt.Sprintf("%s/files/%d/content", c.routerGroup.BasePath(), entity.ID)
+	scopedToken, err := c.tokenService.IssueScopedToken(ctx.Request.Context(), dtos.ScopedTokenRequest{
+		Resource:  resource,
+		Action:    http.MethodGet,
+		ExpiresIn: request.ExpiresIn,
+	})
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.FileDownloadUrl{
+		Url:       fmt.Sprintf("%s?%s=%s", resource, security.ScopedTokenQueryKey, scopedToken.Token),
+		ExpiresAt: scopedToken.ExpiresAt,
+	})
+}
+
+func (c FileController) deleteFile(ctx *gin.Context) {
+	fileId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.fileService.DeleteFile(ctx.Request.Context(), uint(fileId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// readFormFile 은 multipart 의 file 을 읽는다. 크기 제한을 넘는지만 알면 되므로 제한보다 1 byte 더 읽는다.
+func (FileController) readFormFile(ctx *gin.Context) (string, []byte, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return "", nil, err
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, config.Config.FileStorage.MaxSizeBytes+1))
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fileHeader.Filename, content, nil
+}
+
+func (FileController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	// 격리된 파일은 업로드한 멤버와 관리자도 내려받을 수 없다.
+	if errors.Is(err, errors.ErrForbidden) || errors.Is(err, errors.ErrQuarantined) {
+		ctx.JSON(http.StatusForbidden, err.Error())
+		return
+	}
+
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrInsufficientQuota) {
+		ctx.JSON(http.StatusInsufficientStorage, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidFile); ok {
+		ctx.JSON(http.StatusBadRequest, e.Error())
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+func (FileController) toFileInformation(entity domain.FileEntity) dtos.FileInformation {
+	return dtos.FileInformation{
+		Id:            entity.ID,
+		Purpose:       entity.Purpose,
+		Name:          entity.Name,
+		ContentType:   entity.ContentType,
+		Size:          entity.Size,
+		Checksum:      entity.Checksum,
+		ScanStatus:    entity.ScanStatus,
+		ScanSignature: entity.ScanSignature,
+		ScannedAt:     entity.ScannedAt,
+		CreatedBy:     entity.CreatedBy,
+		CreatedAt:     entity.CreatedAt,
+	}
+}
+
+// writeAttachmentContent 는 첨부 파일을 내려받도록 응답한다. 이미지도 화면에 바로 표시하지 않는다.
+func writeAttachmentContent(ctx *gin.Context, attachment domain.AttachmentEntity, content io.Reader) {
+	ctx.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "no-store",
+	})
+}
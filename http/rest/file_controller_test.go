@@ -0,0 +1,585 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	fileDomain "better-admin-backend-service/file/domain"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/testdata/testdb"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testPngContent = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+type testFileScanner struct {
+	signature string
+}
+
+func (s testFileScanner) Scan(name string, content []byte) (dtos.FileScanResult, error) {
+	if bytes.Contains(content, []byte("EICAR")) {
+		return dtos.FileScanResult{Infected: true, Signature: s.signature}, nil
+	}
+	return dtos.FileScanResult{}, nil
+}
+
+func setUpTestFileStorage(t *testing.T) string {
+	directory := t.TempDir()
+	adapters.FileStorageAdapter().SetStorage(adapters.LocalFileStorage{Directory: directory})
+	t.Cleanup(func() {
+		adapters.FileStorageAdapter().SetStorage(nil)
+	})
+
+	return directory
+}
+
+func uploadTestFile(memberId int, permissions []string, purpose string, fileName string, content []byte) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("purpose", purpose)
+	part, _ := writer.CreateFormFile("file", fileName)
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/files", body)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func uploadTestFileAsServiceAccount(serviceAccountId int, purpose string, fileName string, content []byte) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("purpose", purpose)
+	part, _ := writer.CreateFormFile("file", fileName)
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/files", body)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":            serviceAccountId,
+		"Permissions":   []string{},
+		"PrincipalType": security.PrincipalTypeServiceAccount,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestFileController_파일_업로드(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	directory := setUpTestFileStorage(t)
+
+	// when
+	rec := uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "profile.png", actual["name"])
+	assert.Equal(t, "image/png", actual["contentType"])
+	assert.Equal(t, float64(len(testPngContent)), actual["size"])
+	assert.Equal(t, float64(2), actual["createdBy"])
+	assert.Equal(t, constants.FileScanStatusNotScanned, actual["scanStatus"])
+
+	var entity fileDomain.FileEntity
+	gormDB.First(&entity, actual["id"])
+	assert.Equal(t, adapters.FileStorageBackendLocal, entity.StorageBackend)
+	stored, err := os.ReadFile(filepath.Join(directory, entity.StorageKey))
+	assert.Nil(t, err)
+	assert.Equal(t, testPngContent, stored)
+}
+
+func TestFileController_파일_업로드_확장자로_형식_구분(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// when
+	rec := uploadTestFile(1, []string{}, constants.FilePurposeImport, "members.csv", []byte("name,email\n유영모,yumi@example.com\n"))
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "text/csv", actual["contentType"])
+}
+
+func TestFileController_파일_업로드_허용하지_않는_형식(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// when
+	// 확장자가 이미지여도 내용으로 형식을 확인한다.
+	rec := uploadTestFile(1, []string{}, constants.FilePurposeAvatar, "profile.png", []byte("<html><script>alert(1)</script></html>"))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "text/html is not allowed for avatar")
+	var count int64
+	gormDB.Model(&fileDomain.FileEntity{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestFileController_파일_업로드_크기_제한(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+	maxSizeBytes := config.Config.FileStorage.MaxSizeBytes
+	config.Config.FileStorage.MaxSizeBytes = 10
+	defer func() { config.Config.FileStorage.MaxSizeBytes = maxSizeBytes }()
+
+	// when
+	rec := uploadTestFile(1, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "file is larger than 10 bytes")
+}
+
+func TestFileController_파일_업로드_바이러스_격리(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	directory := setUpTestFileStorage(t)
+	adapters.FileScanAdapter().SetScanner(testFileScanner{signature: "Eicar-Test-Signature"})
+	defer adapters.FileScanAdapter().SetScanner(nil)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+	notifyRoleName := config.Config.FileScan.NotifyRoleName
+	config.Config.FileScan.NotifyRoleName = "SYSTEM MANAGER"
+	defer func() { config.Config.FileScan.NotifyRoleName = notifyRoleName }()
+	gormDB.Exec("UPDATE members SET google_mail = ? WHERE id = 1", "security@example.com")
+
+	// when
+	rec := uploadTestFile(3, []string{}, constants.FilePurposeImport, "members.csv", []byte("name\nEICAR\n"))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Eicar-Test-Signature")
+
+	// 바이러스가 있는 파일은 격리 영역에 보관한다.
+	var entity fileDomain.FileEntity
+	gormDB.First(&entity)
+	assert.Equal(t, constants.FileScanStatusInfected, entity.ScanStatus)
+	assert.Equal(t, "Eicar-Test-Signature", entity.ScanSignature)
+	assert.NotNil(t, entity.ScannedAt)
+	assert.Equal(t, "quarantine/import/", entity.StorageKey[:len("quarantine/import/")])
+	_, err := os.Stat(filepath.Join(directory, entity.StorageKey))
+	assert.Nil(t, err)
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = ?", constants.AuditActionFileQuarantined, entity.ID).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+
+	assert.Equal(t, 1, len(mailSender.messages))
+	assert.Equal(t, []string{"security@example.com"}, mailSender.messages[0].To)
+	assert.Contains(t, mailSender.messages[0].Body, "Eicar-Test-Signature")
+
+	// 격리된 파일은 내려받을 수 없다.
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/files/%v/content", entity.ID), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+// startTestClamAv 는 clamd 의 INSTREAM 명령을 흉내내는 서버를 실행한다. 내용에 EICAR 가 있으면 바이러스로 응답한다.
+func startTestClamAv(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				command := make([]byte, len("zINSTREAM\x00"))
+				io.ReadFull(conn, command)
+
+				var content []byte
+				for {
+					size := make([]byte, 4)
+					if _, err := io.ReadFull(conn, size); err != nil {
+						return
+					}
+					chunk := make([]byte, binary.BigEndian.Uint32(size))
+					if len(chunk) == 0 {
+						break
+					}
+					io.ReadFull(conn, chunk)
+					content = append(content, chunk...)
+				}
+
+				if bytes.Contains(content, []byte("EICAR")) {
+					conn.Write([]byte("stream: Win.Test.EICAR_HDB-1 FOUND\x00"))
+					return
+				}
+				conn.Write([]byte("stream: OK\x00"))
+			}(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestFileController_ClamAV_바이러스_검사(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+	scanConfig := config.Config.FileScan
+	config.Config.FileScan.Scanner = constants.FileScannerClamAv
+	config.Config.FileScan.ClamAvAddress = startTestClamAv(t)
+	defer func() { config.Config.FileScan = scanConfig }()
+
+	// when
+	clean := uploadTestFile(1, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	infected := uploadTestFile(1, []string{}, constants.FilePurposeImport, "members.csv", []byte("name\nEICAR\n"))
+
+	// then
+	assert.Equal(t, http.StatusCreated, clean.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(clean.Body.Bytes(), &actual)
+	assert.Equal(t, constants.FileScanStatusClean, actual["scanStatus"])
+	assert.NotNil(t, actual["scannedAt"])
+
+	assert.Equal(t, http.StatusBadRequest, infected.Code)
+	assert.Contains(t, infected.Body.String(), "Win.Test.EICAR_HDB-1")
+}
+
+func TestFileController_파일_목록_검사_상태(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+	uploadTestFile(1, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	adapters.FileScanAdapter().SetScanner(testFileScanner{signature: "Eicar-Test-Signature"})
+	defer adapters.FileScanAdapter().SetScanner(nil)
+	uploadTestFile(1, []string{}, constants.FilePurposeImport, "members.csv", []byte("name\nEICAR\n"))
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/files?scanStatus=infected", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalCount"])
+	file := actual["result"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "members.csv", file["name"])
+	assert.Equal(t, "Eicar-Test-Signature", file["scanSignature"])
+}
+
+func TestFileController_서명된_URL로_파일_내려받기(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// given
+	rec := uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	var uploaded map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &uploaded)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/files/%v/download-url", uploaded["id"]), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var downloadUrl map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &downloadUrl)
+
+	// when
+	req = httptest.NewRequest(http.MethodGet, downloadUrl["url"].(string), nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `inline; filename=profile.png`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, testPngContent, rec.Body.Bytes())
+
+	// 다른 파일에는 사용할 수 없다.
+	parsedUrl, _ := url.Parse(downloadUrl["url"].(string))
+	req = httptest.NewRequest(http.MethodGet, "/api/files/999/content?"+parsedUrl.RawQuery, nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 스코프 토큰은 멤버로 인증하므로 서비스 계정은 서명된 URL 을 만들 수 없다.
+	rec = requestAsServiceAccount(http.MethodPost, fmt.Sprintf("/api/files/%v/download-url", uploaded["id"]), "", 2,
+		[]string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestFileController_파일_삭제(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	directory := setUpTestFileStorage(t)
+
+	// given
+	rec := uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	var uploaded map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &uploaded)
+	var entity fileDomain.FileEntity
+	gormDB.First(&entity, uploaded["id"])
+
+	deleteFile := func(memberId int, permissions []string) int {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/files/%v", uploaded["id"]), nil)
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          memberId,
+			"Permissions": permissions,
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// when
+	// 업로드한 멤버나 시스템 설정 권한이 있어야 삭제할 수 있다.
+	assert.Equal(t, http.StatusForbidden, deleteFile(3, []string{constants.PermissionManageMembers}))
+	// 서비스 계정의 Id(2)가 업로드한 멤버(2)의 Id 와 같아도 업로드한 사용자가 아니다.
+	assert.Equal(t, http.StatusForbidden, requestAsServiceAccount(http.MethodDelete, fmt.Sprintf("/api/files/%v", uploaded["id"]), "", 2, []string{}).Code)
+	assert.Equal(t, http.StatusUnauthorized, requestAsServiceAccount(http.MethodGet, "/api/files/usage", "", 2, []string{}).Code)
+	assert.Equal(t, http.StatusNoContent, deleteFile(1, []string{constants.PermissionManageSystemSettings}))
+
+	// then
+	var count int64
+	gormDB.Model(&fileDomain.FileEntity{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	_, err := os.Stat(filepath.Join(directory, entity.StorageKey))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func newTestPngContent(width, height int, fill color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: fill}, image.Point{}, draw.Src)
+
+	var buffer bytes.Buffer
+	png.Encode(&buffer, img)
+	return buffer.Bytes()
+}
+
+func getTestThumbnail(fileId interface{}, query string, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/files/%v/thumbnail?%s", fileId, query), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	if len(ifNoneMatch) > 0 {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestFileController_썸네일(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	directory := setUpTestFileStorage(t)
+
+	// given
+	rec := uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", newTestPngContent(100, 50, color.RGBA{R: 255, A: 255}))
+	var uploaded map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &uploaded)
+
+	// when
+	rec = getTestThumbnail(uploaded["id"], "w=64&h=64", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "private, max-age=86400", rec.Header().Get("Cache-Control"))
+	thumbnail, err := png.Decode(bytes.NewReader(rec.Body.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, 64, thumbnail.Bounds().Dx())
+	assert.Equal(t, 64, thumbnail.Bounds().Dy())
+	r, g, b, _ := thumbnail.At(32, 32).RGBA()
+	assert.Equal(t, []uint32{0xffff, 0, 0}, []uint32{r, g, b})
+
+	// 만든 썸네일은 저장소에 캐시한다.
+	var entity fileDomain.FileEntity
+	gormDB.First(&entity, uploaded["id"])
+	cached, err := os.ReadFile(filepath.Join(directory, entity.ThumbnailStorageKey(64, 64)))
+	assert.Nil(t, err)
+	assert.Equal(t, rec.Body.Bytes(), cached)
+
+	etag := rec.Header().Get("ETag")
+	rec = getTestThumbnail(uploaded["id"], "w=64&h=64", etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func TestFileController_썸네일_허용하지_않는_크기(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// given
+	rec := uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", newTestPngContent(10, 10, color.White))
+	var uploaded map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &uploaded)
+
+	// when
+	rec = getTestThumbnail(uploaded["id"], "w=1000&h=1000", "")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "thumbnail size 1000x1000 is not allowed")
+}
+
+func TestFileController_썸네일_이미지가_아닌_파일(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// given
+	rec := uploadTestFile(1, []string{}, constants.FilePurposeImport, "members.csv", []byte("name\n유영모\n"))
+	var uploaded map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &uploaded)
+
+	// when
+	rec = getTestThumbnail(uploaded["id"], "w=64&h=64", "")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "thumbnail is not supported for import(text/csv)")
+}
+
+func TestFileController_원본_교체시_썸네일_갱신(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	directory := setUpTestFileStorage(t)
+
+	// given
+	rec := uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", newTestPngContent(20, 20, color.RGBA{R: 255, A: 255}))
+	var uploaded map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &uploaded)
+	var previous fileDomain.FileEntity
+	gormDB.First(&previous, uploaded["id"])
+	previousEtag := getTestThumbnail(uploaded["id"], "w=32&h=32", "").Header().Get("ETag")
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "new-profile.png")
+	part.Write(newTestPngContent(20, 20, color.RGBA{B: 255, A: 255}))
+	writer.Close()
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/files/%v/content", uploaded["id"]), body)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var replaced map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &replaced)
+	assert.Equal(t, uploaded["id"], replaced["id"])
+	assert.Equal(t, "new-profile.png", replaced["name"])
+
+	// 이전 원본과 썸네일은 지운다.
+	_, err := os.Stat(filepath.Join(directory, previous.StorageKey))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(directory, previous.ThumbnailStorageKey(32, 32)))
+	assert.True(t, os.IsNotExist(err))
+
+	rec = getTestThumbnail(uploaded["id"], "w=32&h=32", previousEtag)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	thumbnail, _ := png.Decode(bytes.NewReader(rec.Body.Bytes()))
+	r, g, b, _ := thumbnail.At(16, 16).RGBA()
+	assert.Equal(t, []uint32{0, 0, 0xffff}, []uint32{r, g, b})
+}
+
+func TestFileController_파일_업로드_저장_한도(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/file-quota",
+		strings.NewReader(fmt.Sprintf(`{"memberQuotaBytes": %d, "totalQuotaBytes": %d}`, len(testPngContent)+10, len(testPngContent)*3)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// when
+	rec = uploadTestFile(2, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+
+	// then
+	assert.Equal(t, http.StatusInsufficientStorage, rec.Code)
+	assert.Equal(t, "INSUFFICIENT_QUOTA", rec.Header().Get(middlewares.ErrorCodeHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/files/usage", nil)
+	token, _ = generateTestJWT(map[string]interface{}{"Id": 2, "Permissions": []string{}}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{"usedBytes": %d, "quotaBytes": %d}`, len(testPngContent), len(testPngContent)+10), rec.Body.String())
+
+	// 사이트 전체 저장 한도도 확인한다. 서비스 계정이 올린 파일은 Id 가 같은 멤버(2)의 저장 한도에는 포함하지 않는다.
+	rec = uploadTestFile(3, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	rec = uploadTestFileAsServiceAccount(2, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	rec = uploadTestFile(1, []string{}, constants.FilePurposeAvatar, "profile.png", testPngContent)
+	assert.Equal(t, http.StatusInsufficientStorage, rec.Code)
+}
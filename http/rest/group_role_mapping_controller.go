@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type GroupRoleMappingController struct {
+	routerGroup             *gin.RouterGroup
+	groupRoleMappingService *services.GroupRoleMappingService
+}
+
+func NewGroupRoleMappingController(
+	routerGroup *gin.RouterGroup,
+	groupRoleMappingService *services.GroupRoleMappingService) *GroupRoleMappingController {
+
+	return &GroupRoleMappingController{
+		routerGroup:             routerGroup,
+		groupRoleMappingService: groupRoleMappingService,
+	}
+}
+
+func (c GroupRoleMappingController) MapRoutes() {
+	route := c.routerGroup.Group("/group-role-mappings")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.createMapping)
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		etag.HttpEtagCache(0),
+		c.getMappings)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		etag.HttpEtagCache(0),
+		c.getMapping)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.updateMapping)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.deleteMapping)
+}
+
+func (c GroupRoleMappingController) createMapping(ctx *gin.Context) {
+	var information dtos.GroupRoleMappingInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.groupRoleMappingService.CreateMapping(ctx.Request.Context(), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+// getMappings 는 provider 로 인증 방식의 매핑만 조회한다.
+func (c GroupRoleMappingController) getMappings(ctx *gin.Context) {
+	entities, err := c.groupRoleMappingService.GetMappings(ctx.Request.Context(), ctx.Query("provider"))
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var mappings = make([]dtos.GroupRoleMappingInformation, 0)
+	for _, entity := range entities {
+		mappings = append(mappings, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, mappings)
+}
+
+func (c GroupRoleMappingController) getMapping(ctx *gin.Context) {
+	mappingId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.groupRoleMappingService.GetMapping(ctx.Request.Context(), uint(mappingId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c GroupRoleMappingController) updateMapping(ctx *gin.Context) {
+	mappingId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.GroupRoleMappingInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.groupRoleMappingService.UpdateMapping(ctx.Request.Context(), uint(mappingId), information); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c GroupRoleMappingController) deleteMapping(ctx *gin.Context) {
+	mappingId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.groupRoleMappingService.DeleteMapping(ctx.Request.Context(), uint(mappingId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (GroupRoleMappingController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrDuplicated) {
+		ctx.JSON(http.StatusConflict, dtos.ErrorMessage{Code: errors.Code(err), Message: "group is already mapped to the role"})
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidGroupRoleMapping); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,242 @@
+package rest
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/services"
+	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// fakeGroupAuthenticator 는 인증 정보의 groups(쉼표로 구분)를 외부 사용자의 그룹으로 알려준다.
+type fakeGroupAuthenticator struct {
+}
+
+func (fakeGroupAuthenticator) Authenticate(ctx context.Context, credentials map[string]string) (dtos.CustomAuthIdentity, error) {
+	if credentials["username"] != "lee" {
+		return dtos.CustomAuthIdentity{}, errors.ErrAuthentication
+	}
+
+	identity := dtos.CustomAuthIdentity{ExternalId: "grp-1", Name: "이그룹", Email: "lee@example.com"}
+	if len(credentials["groups"]) > 0 {
+		identity.Groups = strings.Split(credentials["groups"], ",")
+	}
+
+	return identity, nil
+}
+
+func createTestGroupRoleMapping(t *testing.T, requestBody string) {
+	rec := requestTestExport(http.MethodPost, "/api/group-role-mappings", requestBody, 1, []string{constants.PermissionManageAccessControl})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func loginTestGroupAuthenticator(t *testing.T, groups string) memberDomain.MemberEntity {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/custom/test-groups",
+		strings.NewReader(fmt.Sprintf(`{"username": "lee", "groups": %q}`, groups)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var member memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where("authenticator_name = ? AND external_id = ?", "test-groups", "grp-1").First(&member)
+	return member
+}
+
+func TestGroupRoleMappingController_로그인할_때_역할_동기화(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-groups", fakeGroupAuthenticator{})
+
+	createTestGroupRoleMapping(t, `{"provider": "custom:test-groups", "groupName": "admins", "roleId": 3}`)
+	createTestGroupRoleMapping(t, `{"provider": "custom:test-groups", "groupName": "managers", "roleId": 2, "authoritative": true}`)
+
+	// 그룹 이름은 대소문자를 구분하지 않는다.
+	member := loginTestGroupAuthenticator(t, "Admins,developers")
+	assert.Equal(t, []uint{3}, member.GetRoleIds())
+
+	// 직접 할당한 역할
+	gormDB.Exec("INSERT INTO member_roles(member_entity_id, role_entity_id) VALUES (?, 2)", member.ID)
+
+	// when
+	member = loginTestGroupAuthenticator(t, "")
+
+	// then
+	// 매핑으로 할당한 역할과 권한 매핑(authoritative)의 역할은 회수한다.
+	assert.Empty(t, member.GetRoleIds())
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = ?", constants.AuditActionMemberGroupRolesSynced, member.ID).Scan(&auditCount)
+	assert.Equal(t, int64(2), auditCount)
+
+	// 권한 매핑이 아니면 직접 할당한 역할은 그룹에서 빠져도 회수하지 않는다.
+	gormDB.Exec("INSERT INTO member_roles(member_entity_id, role_entity_id) VALUES (?, 3)", member.ID)
+	member = loginTestGroupAuthenticator(t, "admins,managers")
+	assert.ElementsMatch(t, []uint{2, 3}, member.GetRoleIds())
+
+	member = loginTestGroupAuthenticator(t, "")
+	assert.Equal(t, []uint{3}, member.GetRoleIds())
+}
+
+func TestGroupRoleMappingController_같은_역할의_여러_매핑(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-groups", fakeGroupAuthenticator{})
+
+	createTestGroupRoleMapping(t, `{"provider": "custom:test-groups", "groupName": "admins", "roleId": 3}`)
+	createTestGroupRoleMapping(t, `{"provider": "custom:test-groups", "groupName": "operators", "roleId": 3}`)
+
+	member := loginTestGroupAuthenticator(t, "admins,operators")
+	assert.Equal(t, []uint{3}, member.GetRoleIds())
+
+	// 그룹 하나에서만 빠지면 다른 그룹의 매핑이 같은 역할을 할당하므로 회수하지 않는다.
+	member = loginTestGroupAuthenticator(t, "operators")
+	assert.Equal(t, []uint{3}, member.GetRoleIds())
+
+	member = loginTestGroupAuthenticator(t, "")
+	assert.Empty(t, member.GetRoleIds())
+}
+
+func TestGroupRoleMappingController_매핑_관리(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-groups", fakeGroupAuthenticator{})
+	permissions := []string{constants.PermissionManageAccessControl}
+
+	// given
+	rec := requestTestExport(http.MethodPost, "/api/group-role-mappings",
+		`{"provider": "google", "groupName": "eng@example.com", "roleId": 3}`, 1, permissions)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var mapping dtos.GroupRoleMappingInformation
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &mapping))
+
+	// when
+	rec = requestTestExport(http.MethodPut, fmt.Sprintf("/api/group-role-mappings/%v", mapping.Id),
+		`{"provider": "google", "groupName": "eng@example.com", "roleId": 3, "authoritative": true}`, 1, permissions)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = requestTestExport(http.MethodGet, "/api/group-role-mappings?provider=google", "", 1, permissions)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var mappings []dtos.GroupRoleMappingInformation
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &mappings))
+	assert.Len(t, mappings, 1)
+	assert.True(t, mappings[0].Authoritative)
+
+	// 순서대로 요청한다.
+	testCases := []struct {
+		requestBody  string
+		expectedCode int
+	}{
+		{`{"provider": "google", "groupName": "eng@example.com", "roleId": 3}`, http.StatusConflict},
+		{`{"provider": "saml", "groupName": "eng", "roleId": 3}`, http.StatusBadRequest},
+		{`{"provider": "custom:not-supported", "groupName": "eng", "roleId": 3}`, http.StatusBadRequest},
+		{`{"provider": "custom:test-groups", "groupName": "admins", "roleId": 1}`, http.StatusBadRequest},
+		{`{"provider": "custom:test-groups", "groupName": "admins", "roleId": 999}`, http.StatusBadRequest},
+		{`{"provider": "custom:test-groups", "groupName": "", "roleId": 3}`, http.StatusBadRequest},
+		{`{"provider": "custom:test-groups", "groupName": "managers", "roleId": 2}`, http.StatusCreated},
+		{`{"provider": "custom:test-groups", "groupName": "managers", "roleId": 3}`, http.StatusCreated},
+		{`{"provider": "custom:test-groups", "groupName": "Managers ", "roleId": 3}`, http.StatusConflict},
+	}
+	for _, testCase := range testCases {
+		rec = requestTestExport(http.MethodPost, "/api/group-role-mappings", testCase.requestBody, 1, permissions)
+		assert.Equal(t, testCase.expectedCode, rec.Code, testCase.requestBody)
+	}
+
+	rec = requestTestExport(http.MethodDelete, fmt.Sprintf("/api/group-role-mappings/%v", mapping.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/group-role-mappings/%v", mapping.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = requestTestExport(http.MethodGet, "/api/group-role-mappings", "", 3, []string{})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func Test_authWithGoogleWorkspaceAccount_그룹_역할_동기화(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// setUp WebServer Fixture
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.Write([]byte(`{"access_token": "test-token", "token_type": "Bearer"}`))
+			return
+		}
+
+		// 디렉터리 API
+		if r.URL.Path == "/users/987654" {
+			w.Write([]byte(`{"orgUnitPath": "/Engineering"}`))
+			return
+		}
+
+		if r.URL.Path == "/groups" {
+			if r.URL.Query().Get("userKey") != "987654" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if r.URL.Query().Get("pageToken") == "" {
+				w.Write([]byte(`{"groups": [{"email": "all@example.com"}], "nextPageToken": "next"}`))
+				return
+			}
+			w.Write([]byte(`{"groups": [{"email": "eng@example.com"}]}`))
+			return
+		}
+
+		w.Write([]byte(`{"id": "987654", "email": "kim@example.com", "name": "김신입", "hd": "example.com"}`))
+	}))
+	defer server.Close()
+	serverPort := server.Listener.Addr().(*net.TCPAddr).Port
+
+	googleWorkspaceServerUrl := fmt.Sprintf("http://localhost:%v", serverPort)
+	config.Config.GoogleOAuth.AuthUri = googleWorkspaceServerUrl
+	config.Config.GoogleOAuth.TokenUri = googleWorkspaceServerUrl
+	defer func(directoryUri string) { config.Config.GoogleOAuth.DirectoryUri = directoryUri }(config.Config.GoogleOAuth.DirectoryUri)
+	config.Config.GoogleOAuth.DirectoryUri = googleWorkspaceServerUrl
+
+	rec := requestTestExport(http.MethodPut, "/api/site/settings/google-workspace", `{
+		"domains": [{"domain": "example.com", "defaultRoleIds": [2]}],
+		"verifyHostedDomain": true,
+		"directoryAccess": {"enabled": true, "adminConsented": true, "groupSync": true}
+	}`, 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	createTestGroupRoleMapping(t, `{"provider": "google", "groupName": "eng@example.com", "roleId": 3}`)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace/start?redirect=/login", nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Contains(t, rec.Header().Get("Location"), constants.GoogleOAuthScopeDirectoryGroupReadonly)
+
+	state := startTestGoogleWorkspaceAuth(t, "/login")
+	req = httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace?code=test-google-code&state="+url.QueryEscape(state), nil)
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Contains(t, rec.Header().Get("Location"), "accessToken=")
+
+	// 처음 로그인해도 도메인 기본 역할과 함께 그룹 매핑의 역할을 할당한다.
+	var member memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where("google_id = ?", "987654").First(&member)
+	assert.ElementsMatch(t, []uint{2, 3}, member.GetRoleIds())
+
+	var grantCount int64
+	gormDB.Raw("SELECT count(*) FROM group_role_grants WHERE member_id = ? AND role_id = 3", member.ID).Scan(&grantCount)
+	assert.Equal(t, int64(1), grantCount)
+}
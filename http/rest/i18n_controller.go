@@ -0,0 +1,215 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/i18n/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+type I18nController struct {
+	routerGroup *gin.RouterGroup
+	i18nService *services.I18nService
+}
+
+func NewI18nController(
+	routerGroup *gin.RouterGroup,
+	i18nService *services.I18nService) *I18nController {
+
+	return &I18nController{
+		routerGroup: routerGroup,
+		i18nService: i18nService,
+	}
+}
+
+func (c I18nController) MapRoutes() {
+	route := c.routerGroup.Group("/i18n")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getLocaleBundles)
+	// 프론트엔드는 로그인 전에도 문구를 불러온다.
+	route.GET("/:locale", middlewares.Public(),
+		etag.HttpEtagCache(0),
+		c.getMessages)
+	route.PUT("/:locale/messages", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.updateMessages)
+	route.GET("/:locale/export", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.exportBundle)
+	route.POST("/:locale/import", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.importBundle)
+	route.GET("/:locale/versions", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getVersions)
+	route.GET("/:locale/versions/:version", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getVersion)
+	route.POST("/:locale/versions/:version/rollback", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.rollbackBundle)
+}
+
+func (c I18nController) getLocaleBundles(ctx *gin.Context) {
+	entities, err := c.i18nService.GetLocaleBundles(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	summaries := make([]dtos.LocaleBundleSummary, 0)
+	for _, entity := range entities {
+		summaries = append(summaries, dtos.LocaleBundleSummary{
+			Locale:       entity.Locale,
+			Version:      entity.Version,
+			MessageCount: len(entity.GetMessages()),
+			UpdatedBy:    entity.UpdatedBy,
+			UpdatedAt:    entity.UpdatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, summaries)
+}
+
+// getMessages 는 로케일에서 바꾼 문구(키별 값)이다. 바꾼 문구가 없으면 404 이며 프론트엔드는 내장 문구를 사용한다.
+func (c I18nController) getMessages(ctx *gin.Context) {
+	entity, err := c.i18nService.GetLocaleBundle(ctx.Request.Context(), ctx.Param("locale"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.GetMessages())
+}
+
+func (c I18nController) updateMessages(ctx *gin.Context) {
+	var changes dtos.LocaleMessageChanges
+	if err := ctx.BindJSON(&changes); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.i18nService.UpdateMessages(ctx.Request.Context(), ctx.Param("locale"), changes)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toLocaleBundle(entity))
+}
+
+func (c I18nController) exportBundle(ctx *gin.Context) {
+	entity, err := c.i18nService.GetLocaleBundle(ctx.Request.Context(), ctx.Param("locale"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": entity.Locale + ".json"}))
+	ctx.JSON(http.StatusOK, c.toLocaleBundle(entity))
+}
+
+// importBundle 은 내보낸 번들을 가져온다. replace=true 이면 번들에 없는 문구를 지운다.
+func (c I18nController) importBundle(ctx *gin.Context) {
+	var bundle dtos.LocaleBundle
+	if err := ctx.BindJSON(&bundle); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.i18nService.ImportBundle(ctx.Request.Context(), ctx.Param("locale"), bundle, ctx.Query("replace") == "true")
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toLocaleBundle(entity))
+}
+
+func (c I18nController) getVersions(ctx *gin.Context) {
+	entities, totalCount, err := c.i18nService.GetVersions(ctx.Request.Context(), ctx.Param("locale"), dtos.NewPageableFromRequest(ctx))
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	versions := make([]dtos.LocaleBundleVersion, 0)
+	for _, entity := range entities {
+		versions = append(versions, c.toLocaleBundleVersion(entity))
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     versions,
+		TotalCount: totalCount,
+	})
+}
+
+func (c I18nController) getVersion(ctx *gin.Context) {
+	version, err := strconv.ParseUint(ctx.Param("version"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.i18nService.GetVersion(ctx.Request.Context(), ctx.Param("locale"), uint(version))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.LocaleBundleVersionDetails{
+		LocaleBundleVersion: c.toLocaleBundleVersion(entity),
+		Messages:            entity.GetMessages(),
+	})
+}
+
+func (c I18nController) rollbackBundle(ctx *gin.Context) {
+	version, err := strconv.ParseUint(ctx.Param("version"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.i18nService.RollbackBundle(ctx.Request.Context(), ctx.Param("locale"), uint(version))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toLocaleBundle(entity))
+}
+
+func (I18nController) toLocaleBundle(entity domain.LocaleBundleEntity) dtos.LocaleBundle {
+	return dtos.LocaleBundle{
+		Locale:   entity.Locale,
+		Version:  entity.Version,
+		Messages: entity.GetMessages(),
+	}
+}
+
+func (I18nController) toLocaleBundleVersion(entity domain.LocaleBundleVersionEntity) dtos.LocaleBundleVersion {
+	return dtos.LocaleBundleVersion{
+		Locale:         entity.Locale,
+		Version:        entity.Version,
+		MessageCount:   len(entity.GetMessages()),
+		RolledBackFrom: entity.RolledBackFrom,
+		CreatedBy:      entity.CreatedBy,
+		CreatedAt:      entity.CreatedAt,
+	}
+}
+
+func (I18nController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidLocaleBundle); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
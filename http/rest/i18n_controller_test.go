@@ -0,0 +1,218 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestI18nAdmin(method, url string, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func getLocaleMessages(t *testing.T, locale string) map[string]string {
+	req := httptest.NewRequest(http.MethodGet, "/api/i18n/"+locale, nil)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var messages map[string]string
+	json.Unmarshal(rec.Body.Bytes(), &messages)
+	return messages
+}
+
+func TestI18nController_문구_변경(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/i18n/ko", nil)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// when
+	rec = requestI18nAdmin(http.MethodPut, "/api/i18n/ko/messages", `{"login.title": "관리자 로그인", "login.submit": "로그인"}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var bundle dtos.LocaleBundle
+	json.Unmarshal(rec.Body.Bytes(), &bundle)
+	assert.Equal(t, uint(1), bundle.Version)
+	assert.Equal(t, map[string]string{"login.title": "관리자 로그인", "login.submit": "로그인"}, getLocaleMessages(t, "ko"))
+
+	// null 인 키는 지운다.
+	rec = requestI18nAdmin(http.MethodPut, "/api/i18n/ko/messages", `{"login.submit": null, "menu.members": "멤버"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &bundle)
+	assert.Equal(t, uint(2), bundle.Version)
+	assert.Equal(t, map[string]string{"login.title": "관리자 로그인", "menu.members": "멤버"}, getLocaleMessages(t, "ko"))
+
+	// 바뀐 문구가 없으면 버전을 남기지 않는다.
+	rec = requestI18nAdmin(http.MethodPut, "/api/i18n/ko/messages", `{"menu.members": "멤버"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &bundle)
+	assert.Equal(t, uint(2), bundle.Version)
+
+	rec = requestI18nAdmin(http.MethodGet, "/api/i18n", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var summaries []dtos.LocaleBundleSummary
+	json.Unmarshal(rec.Body.Bytes(), &summaries)
+	assert.Equal(t, 1, len(summaries))
+	assert.Equal(t, "ko", summaries[0].Locale)
+	assert.Equal(t, 2, summaries[0].MessageCount)
+}
+
+func TestI18nController_ETag(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestI18nAdmin(http.MethodPut, "/api/i18n/en-US/messages", `{"login.title": "Sign in"}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/i18n/en-US", nil)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	// when
+	req = httptest.NewRequest(http.MethodGet, "/api/i18n/en-US", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	// 문구를 바꾸면 ETag 도 바뀐다.
+	requestI18nAdmin(http.MethodPut, "/api/i18n/en-US/messages", `{"login.title": "Log in"}`)
+	req = httptest.NewRequest(http.MethodGet, "/api/i18n/en-US", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestI18nController_내보내기와_가져오기(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestI18nAdmin(http.MethodPut, "/api/i18n/ja/messages", `{"login.title": "ログイン", "menu.members": "メンバー"}`)
+
+	rec := requestI18nAdmin(http.MethodGet, "/api/i18n/ja/export", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "ja.json")
+	exported := rec.Body.String()
+
+	// when
+	// 다른 로케일로 만든 번들은 가져올 수 없다.
+	rec = requestI18nAdmin(http.MethodPost, "/api/i18n/ko/import", exported)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 덮어쓰기
+	rec = requestI18nAdmin(http.MethodPost, "/api/i18n/ja/import", `{"messages": {"login.title": "サインイン"}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]string{"login.title": "サインイン", "menu.members": "メンバー"}, getLocaleMessages(t, "ja"))
+
+	// 바꾸기
+	rec = requestI18nAdmin(http.MethodPost, "/api/i18n/ja/import?replace=true", `{"locale": "ja", "messages": {"menu.roles": "ロール"}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]string{"menu.roles": "ロール"}, getLocaleMessages(t, "ja"))
+
+	// 내보낸 번들을 다시 가져온다.
+	rec = requestI18nAdmin(http.MethodPost, "/api/i18n/ja/import?replace=true", exported)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// then
+	var bundle dtos.LocaleBundle
+	json.Unmarshal(rec.Body.Bytes(), &bundle)
+	assert.Equal(t, uint(4), bundle.Version)
+	assert.Equal(t, map[string]string{"login.title": "ログイン", "menu.members": "メンバー"}, bundle.Messages)
+}
+
+func TestI18nController_버전과_되돌리기(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestI18nAdmin(http.MethodPut, "/api/i18n/ko/messages", `{"login.title": "로그인"}`)
+	requestI18nAdmin(http.MethodPut, "/api/i18n/ko/messages", `{"login.title": "관리자 로그인"}`)
+
+	// when
+	rec := requestI18nAdmin(http.MethodPost, "/api/i18n/ko/versions/1/rollback", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, map[string]string{"login.title": "로그인"}, getLocaleMessages(t, "ko"))
+
+	rec = requestI18nAdmin(http.MethodGet, "/api/i18n/ko/versions", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var versions struct {
+		Result     []dtos.LocaleBundleVersion `json:"result"`
+		TotalCount int64                      `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &versions)
+	assert.Equal(t, int64(3), versions.TotalCount)
+	assert.Equal(t, uint(3), versions.Result[0].Version)
+	assert.Equal(t, uint(1), versions.Result[0].RolledBackFrom)
+
+	rec = requestI18nAdmin(http.MethodGet, "/api/i18n/ko/versions/2", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var details dtos.LocaleBundleVersionDetails
+	json.Unmarshal(rec.Body.Bytes(), &details)
+	assert.Equal(t, map[string]string{"login.title": "관리자 로그인"}, details.Messages)
+
+	rec = requestI18nAdmin(http.MethodPost, "/api/i18n/ko/versions/10/rollback", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestI18nController_잘못된_요청(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// 로케일 형식
+	rec := requestI18nAdmin(http.MethodPut, "/api/i18n/KOREAN/messages", `{"login.title": "로그인"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "INVALID_LOCALE_BUNDLE", actual.Code)
+
+	// 문구 길이
+	rec = requestI18nAdmin(http.MethodPut, "/api/i18n/ko/messages", fmt.Sprintf(`{"login.title": "%s"}`, strings.Repeat("가", constants.LocaleBundleMaxValueLength+1)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 권한
+	req := httptest.NewRequest(http.MethodPut, "/api/i18n/ko/messages", strings.NewReader(`{"login.title": "로그인"}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          3,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,79 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/command/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"encoding/json"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type InboundCommandController struct {
+	routerGroup           *gin.RouterGroup
+	inboundCommandService *services.InboundCommandService
+}
+
+func NewInboundCommandController(routerGroup *gin.RouterGroup, inboundCommandService *services.InboundCommandService) *InboundCommandController {
+	return &InboundCommandController{
+		routerGroup:           routerGroup,
+		inboundCommandService: inboundCommandService,
+	}
+}
+
+func (c InboundCommandController) MapRoutes() {
+	route := c.routerGroup.Group("/inbound-commands")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getInboundCommands)
+}
+
+func (c InboundCommandController) getInboundCommands(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	filters := map[string]interface{}{}
+
+	if len(ctx.Query("status")) > 0 {
+		filters["status"] = ctx.Query("status")
+	}
+
+	if len(ctx.Query("type")) > 0 {
+		filters["type"] = ctx.Query("type")
+	}
+
+	entities, totalCount, err := c.inboundCommandService.GetInboundCommands(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var inboundCommands = make([]dtos.InboundCommandInformation, 0)
+	for _, entity := range entities {
+		inboundCommands = append(inboundCommands, c.toInboundCommandInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     inboundCommands,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (InboundCommandController) toInboundCommandInformation(entity domain.InboundCommandEntity) dtos.InboundCommandInformation {
+	return dtos.InboundCommandInformation{
+		Id:        entity.ID,
+		CommandId: entity.CommandId,
+		Type:      entity.Type,
+		Principal: entity.Principal,
+		Topic:     entity.Topic,
+		Status:    entity.Status,
+		Attempts:  entity.Attempts,
+		Error:     entity.Error,
+		Payload:   json.RawMessage(entity.Payload),
+		CreatedAt: entity.CreatedAt,
+	}
+}
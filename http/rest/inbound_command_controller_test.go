@@ -0,0 +1,483 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/consumer"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"better-admin-backend-service/testdata/testdb"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func handleTestCommand(t *testing.T, service *services.InboundCommandService, offset int64, command string) {
+	err := consumer.HandleMessage(gormDB, consumer.Consumer{Name: "test", Handle: service.HandleMessage}, adapters.BrokerMessage{
+		Topic:   "better-admin.commands",
+		Value:   []byte(command),
+		Headers: map[string]string{"traceId": "trace-1"},
+		Offset:  offset,
+	})
+	assert.NoError(t, err)
+}
+
+// newTestInboundCommandService 는 Container 의 서비스에서 member.reject 에 서비스 계정 역할에 없는 권한(MANAGE_ACCESS_CONTROL)을 요구하여
+// 권한이 없는 명령을 만든다.
+func newTestInboundCommandService() *services.InboundCommandService {
+	container := NewContainer()
+	container.InboundCommandService.RegisterHandler(constants.CommandMemberReject, constants.PermissionManageAccessControl,
+		services.NewMemberRejectCommandHandler(container.MemberService))
+	return container.InboundCommandService
+}
+
+func TestInboundCommand_멤버_승인_명령_처리(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	service := newTestInboundCommandService()
+	command := `{"id": "cmd-1", "type": "member.approve", "principal": "test-client", "data": {"memberId": 4}}`
+
+	// when
+	handleTestCommand(t, service, 10, command)
+	// 같은 명령을 다시 받으면 실행하지 않고 offset 만 저장한다.
+	handleTestCommand(t, service, 11, command)
+
+	// then
+	var memberStatus string
+	gormDB.Raw("SELECT status FROM members WHERE id = 4").Scan(&memberStatus)
+	assert.Equal(t, constants.StatusMemberApproved, memberStatus)
+
+	var commands []struct {
+		Status   string
+		Attempts int
+	}
+	gormDB.Raw("SELECT status, attempts FROM inbound_commands WHERE command_id = 'cmd-1'").Scan(&commands)
+	assert.Equal(t, 1, len(commands))
+	assert.Equal(t, constants.InboundCommandStatusProcessed, commands[0].Status)
+	assert.Equal(t, 1, commands[0].Attempts)
+
+	var nextOffset int64
+	gormDB.Raw("SELECT next_offset FROM message_consumer_offsets WHERE topic = 'better-admin.commands' AND partition_id = 0").Scan(&nextOffset)
+	assert.Equal(t, int64(12), nextOffset)
+
+	offset, ok, err := service.LoadOffset(helpers.ContextHelper().SetDB(context.Background(), gormDB), "better-admin.commands", 0)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), offset)
+}
+
+func TestInboundCommand_역할_추가_명령_처리(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	service := newTestInboundCommandService()
+
+	// when
+	handleTestCommand(t, service, -1, `{"id": "cmd-2", "type": "member.grant-roles", "principal": "test-client", "data": {"memberId": 2, "roleIds": [2, 3]}}`)
+
+	// then
+	var roleIds []uint
+	gormDB.Raw("SELECT role_entity_id FROM member_roles WHERE member_entity_id = 2 ORDER BY role_entity_id").Scan(&roleIds)
+	assert.Equal(t, []uint{1, 2, 3}, roleIds)
+
+	var offsetCount int64
+	gormDB.Raw("SELECT count(*) FROM message_consumer_offsets").Scan(&offsetCount)
+	assert.Equal(t, int64(0), offsetCount)
+}
+
+func TestInboundCommand_처리할_수_없는_명령은_데드_레터로_보냄(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	publisher := &fakeMessagePublisher{}
+	adapters.MessageBrokerAdapter().SetPublisher(publisher)
+	defer adapters.MessageBrokerAdapter().SetPublisher(nil)
+	service := newTestInboundCommandService()
+
+	// when
+	handleTestCommand(t, service, 1, `not json`)
+	handleTestCommand(t, service, 2, `{"id": "cmd-unknown", "type": "member.delete", "principal": "test-client", "data": {}}`)
+	handleTestCommand(t, service, 3, `{"id": "cmd-principal", "type": "member.approve", "principal": "unknown-client", "data": {"memberId": 4}}`)
+	// 서비스 계정 역할에 MANAGE_ACCESS_CONTROL 권한이 없다.
+	handleTestCommand(t, service, 4, `{"id": "cmd-denied", "type": "member.reject", "principal": "test-client", "data": {"memberId": 4}}`)
+
+	// then
+	var memberStatus string
+	gormDB.Raw("SELECT status FROM members WHERE id = 4").Scan(&memberStatus)
+	assert.Equal(t, constants.StatusMemberApplied, memberStatus)
+
+	assert.Equal(t, 4, len(publisher.messages))
+	reasons := make([]string, 0)
+	for _, message := range publisher.messages {
+		assert.Equal(t, config.Config.MessageBroker.Commands.DeadLetterTopic, message.Topic)
+		assert.Equal(t, "trace-1", message.Headers["traceId"])
+		assert.Equal(t, "better-admin.commands", message.Headers["dlqSourceTopic"])
+		reasons = append(reasons, message.Headers["dlqReason"])
+	}
+	assert.Equal(t, []string{constants.DeadLetterReasonInvalidCommand, constants.DeadLetterReasonUnknownCommand,
+		constants.DeadLetterReasonUnknownPrincipal, constants.DeadLetterReasonPermissionDenied}, reasons)
+	assert.Equal(t, []byte(`not json`), publisher.messages[0].Value)
+
+	var statuses []string
+	gormDB.Raw("SELECT status FROM inbound_commands ORDER BY id").Scan(&statuses)
+	assert.Equal(t, []string{constants.InboundCommandStatusRejected, constants.InboundCommandStatusRejected, constants.InboundCommandStatusRejected}, statuses)
+
+	var nextOffset int64
+	gormDB.Raw("SELECT next_offset FROM message_consumer_offsets").Scan(&nextOffset)
+	assert.Equal(t, int64(5), nextOffset)
+}
+
+func TestInboundCommand_실패하면_다시_실행하고_데드_레터로_보냄(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	publisher := &fakeMessagePublisher{}
+	adapters.MessageBrokerAdapter().SetPublisher(publisher)
+	defer adapters.MessageBrokerAdapter().SetPublisher(nil)
+	service := newTestInboundCommandService()
+
+	// when
+	// 승인된 멤버(1)는 다시 승인할 수 없다.
+	handleTestCommand(t, service, 7, `{"id": "cmd-3", "type": "member.approve", "principal": "test-client", "data": {"memberId": 1}}`)
+
+	// then
+	var command struct {
+		Status   string
+		Attempts int
+		Error    string
+	}
+	gormDB.Raw("SELECT status, attempts, error FROM inbound_commands WHERE command_id = 'cmd-3'").Scan(&command)
+	assert.Equal(t, constants.InboundCommandStatusDeadLettered, command.Status)
+	assert.Equal(t, config.Config.MessageBroker.Commands.MaxAttempts, command.Attempts)
+	assert.NotEmpty(t, command.Error)
+
+	assert.Equal(t, 1, len(publisher.messages))
+	assert.Equal(t, constants.DeadLetterReasonMaxAttempts, publisher.messages[0].Headers["dlqReason"])
+	assert.Equal(t, command.Error, publisher.messages[0].Headers["dlqError"])
+
+	// 실패한 시도는 도메인 이벤트를 남기지 않는다.
+	var eventCount int64
+	gormDB.Raw("SELECT count(*) FROM domain_events").Scan(&eventCount)
+	assert.Equal(t, int64(0), eventCount)
+}
+
+func TestInboundCommand_서비스_계정으로_도메인_이벤트_기록(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	publisher := &fakeMessagePublisher{}
+	adapters.MessageBrokerAdapter().SetPublisher(publisher)
+	defer adapters.MessageBrokerAdapter().SetPublisher(nil)
+	service := newTestInboundCommandService()
+
+	// when
+	handleTestCommand(t, service, 1, `{"id": "cmd-4", "type": "member.approve", "principal": "test-client", "data": {"memberId": 4}}`)
+
+	// then
+	var payload string
+	gormDB.Raw("SELECT payload FROM domain_events WHERE type = ?", constants.DomainEventMemberApproved).Scan(&payload)
+	var envelope dtos.DomainEventEnvelope
+	json.Unmarshal([]byte(payload), &envelope)
+	assert.Equal(t, dtos.DomainEventActor{Type: constants.AuditActorTypeServiceAccount, Id: 1}, envelope.Actor)
+}
+
+func TestInboundCommandController_명령_조회(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	service := newTestInboundCommandService()
+	handleTestCommand(t, service, 1, `{"id": "cmd-5", "type": "member.approve", "principal": "test-client", "data": {"memberId": 4}}`)
+	handleTestCommand(t, service, 2, `{"id": "cmd-6", "type": "member.reject", "principal": "test-client", "data": {"memberId": 4}}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/inbound-commands?status=rejected", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var pageResult struct {
+		Result     []dtos.InboundCommandInformation `json:"result"`
+		TotalCount int64                            `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &pageResult)
+	assert.Equal(t, int64(1), pageResult.TotalCount)
+	assert.Equal(t, "cmd-6", pageResult.Result[0].CommandId)
+	assert.Equal(t, constants.DeadLetterReasonPermissionDenied, pageResult.Result[0].Error)
+	assert.Equal(t, "test-client", pageResult.Result[0].Principal)
+	assert.Contains(t, string(pageResult.Result[0].Payload), `"memberId":4`)
+}
+
+func TestInboundCommandController_권한_확인(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/inbound-commands", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+// startFakeNatsSubscribeServer 는 SUB 를 받으면 구독한 subject 로 message 를 보내는 NATS 서버이다. 받은 SUB 줄은 subscribed 로 보낸다.
+func startFakeNatsSubscribeServer(t *testing.T, headers, payload string, subscribed chan string) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				conn.Write([]byte(`INFO {"server_id":"fake","headers":true,"max_payload":1048576}` + "\r\n"))
+
+				reader := bufio.NewReader(conn)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					fields := strings.Fields(line)
+					if len(fields) == 0 {
+						continue
+					}
+
+					switch fields[0] {
+					case "SUB":
+						subscribed <- strings.TrimSpace(line)
+						conn.Write([]byte("PING\r\n"))
+						fmt.Fprintf(conn, "HMSG %s %s %d %d\r\n%s%s\r\n", fields[1], fields[len(fields)-1],
+							len(headers), len(headers)+len(payload), headers, payload)
+					case "PING":
+						conn.Write([]byte("PONG\r\n"))
+					}
+				}
+			}(conn)
+		}
+	}()
+
+	return listener
+}
+
+func TestNatsClient_Subscribe(t *testing.T) {
+	// given
+	subscribed := make(chan string, 1)
+	listener := startFakeNatsSubscribeServer(t, "NATS/1.0\r\ntraceId: trace-1\r\n\r\n", `{"id":"cmd-1"}`, subscribed)
+	defer listener.Close()
+
+	client := adapters.NatsClient{Url: "nats://" + listener.Addr().String(), Timeout: 5 * time.Second}
+	stopped := make(chan struct{})
+	received := make([]adapters.BrokerMessage, 0)
+
+	// when
+	err := client.Subscribe(adapters.Subscription{
+		Topic: "better-admin.commands",
+		Group: "better-admin",
+		Handle: func(message adapters.BrokerMessage) error {
+			received = append(received, message)
+			close(stopped)
+			return nil
+		},
+	}, stopped)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, "SUB better-admin.commands better-admin 1", <-subscribed)
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, "better-admin.commands", received[0].Topic)
+	assert.Equal(t, `{"id":"cmd-1"}`, string(received[0].Value))
+	assert.Equal(t, map[string]string{"traceId": "trace-1"}, received[0].Headers)
+	assert.Equal(t, int64(-1), received[0].Offset)
+}
+
+// encodeTestKafkaRecordBatch 는 baseOffset 부터 values 를 담은 압축하지 않은 RecordBatch(magic 2)를 만든다.
+func encodeTestKafkaRecordBatch(baseOffset int64, values ...string) []byte {
+	putVarint := func(buffer *bytes.Buffer, value int64) {
+		varint := make([]byte, binary.MaxVarintLen64)
+		buffer.Write(varint[:binary.PutVarint(varint, value)])
+	}
+
+	records := &bytes.Buffer{}
+	for i, value := range values {
+		record := &bytes.Buffer{}
+		record.WriteByte(0)  // attributes
+		putVarint(record, 0) // timestamp delta
+		putVarint(record, int64(i))
+		putVarint(record, -1) // key
+		putVarint(record, int64(len(value)))
+		record.WriteString(value)
+		putVarint(record, 1)
+		putVarint(record, int64(len("traceId")))
+		record.WriteString("traceId")
+		putVarint(record, int64(len("trace-1")))
+		record.WriteString("trace-1")
+
+		putVarint(records, int64(record.Len()))
+		records.Write(record.Bytes())
+	}
+
+	batch := &bytes.Buffer{}
+	binary.Write(batch, binary.BigEndian, int32(0)) // partition leader epoch
+	batch.WriteByte(2)                              // magic
+	binary.Write(batch, binary.BigEndian, int32(0)) // crc
+	binary.Write(batch, binary.BigEndian, int16(0)) // attributes
+	binary.Write(batch, binary.BigEndian, int32(len(values)-1))
+	binary.Write(batch, binary.BigEndian, []int64{0, 0, -1})
+	binary.Write(batch, binary.BigEndian, int16(-1))
+	binary.Write(batch, binary.BigEndian, []int32{-1, int32(len(values))})
+	batch.Write(records.Bytes())
+
+	result := &bytes.Buffer{}
+	binary.Write(result, binary.BigEndian, baseOffset)
+	binary.Write(result, binary.BigEndian, int32(batch.Len()))
+	result.Write(batch.Bytes())
+	return result.Bytes()
+}
+
+// startFakeKafkaFetchBroker 는 Metadata(파티션 0 의 리더가 자신)와 Fetch 요청에 응답한다.
+// fetchOffset 의 Fetch 요청에는 records 를 보내고 그 밖의 offset 은 빈 응답을 보낸다. 받은 Fetch offset 은 fetched 로 보낸다.
+func startFakeKafkaFetchBroker(t *testing.T, topic string, fetchOffset int64, records []byte, fetched chan int64) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	host, portValue, _ := net.SplitHostPort(listener.Addr().String())
+	var port int32
+	fmt.Sscan(portValue, &port)
+
+	writeString := func(buffer *bytes.Buffer, value string) {
+		binary.Write(buffer, binary.BigEndian, int16(len(value)))
+		buffer.WriteString(value)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				for {
+					var size int32
+					if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+						return
+					}
+					request := make([]byte, size)
+					if _, err := io.ReadFull(conn, request); err != nil {
+						return
+					}
+					apiKey := binary.BigEndian.Uint16(request[0:2])
+					correlationId := binary.BigEndian.Uint32(request[4:8])
+
+					response := &bytes.Buffer{}
+					binary.Write(response, binary.BigEndian, correlationId)
+					if apiKey == 3 {
+						binary.Write(response, binary.BigEndian, int32(1))
+						binary.Write(response, binary.BigEndian, int32(0))
+						writeString(response, host)
+						binary.Write(response, binary.BigEndian, port)
+						binary.Write(response, binary.BigEndian, int16(-1))
+						binary.Write(response, binary.BigEndian, int32(0))
+						binary.Write(response, binary.BigEndian, int32(1))
+						binary.Write(response, binary.BigEndian, int16(0))
+						writeString(response, topic)
+						response.WriteByte(0)
+						binary.Write(response, binary.BigEndian, []int32{1, 0, 0, 0})
+						binary.Write(response, binary.BigEndian, int16(0))
+						binary.Write(response, binary.BigEndian, []int32{0, 0, 1, 0, 1, 0})
+					} else {
+						// 요청 헤더(api key, version, correlation id, client id) 다음의 offset 을 읽는다.
+						clientIdLength := int(binary.BigEndian.Uint16(request[8:10]))
+						body := request[10+clientIdLength:]
+						offset := int64(binary.BigEndian.Uint64(body[len(body)-12 : len(body)-4]))
+						fetched <- offset
+
+						partitionRecords := records
+						if offset != fetchOffset {
+							partitionRecords = nil
+							time.Sleep(10 * time.Millisecond)
+						}
+
+						binary.Write(response, binary.BigEndian, []int32{0, 1})
+						writeString(response, topic)
+						binary.Write(response, binary.BigEndian, []int32{1, 0})
+						binary.Write(response, binary.BigEndian, int16(0))
+						binary.Write(response, binary.BigEndian, []int64{fetchOffset + 2, fetchOffset + 2})
+						binary.Write(response, binary.BigEndian, int32(-1))
+						binary.Write(response, binary.BigEndian, int32(len(partitionRecords)))
+						response.Write(partitionRecords)
+					}
+
+					binary.Write(conn, binary.BigEndian, int32(response.Len()))
+					conn.Write(response.Bytes())
+				}
+			}(conn)
+		}
+	}()
+
+	return listener
+}
+
+func TestKafkaConsumer_Subscribe(t *testing.T) {
+	// given
+	// offset 5 부터 읽으면 브로커는 offset 4 부터 들어 있는 RecordBatch 를 보낸다.
+	fetched := make(chan int64, 100)
+	listener := startFakeKafkaFetchBroker(t, "better-admin.commands", 5, encodeTestKafkaRecordBatch(4, "skipped", `{"id":"cmd-1"}`), fetched)
+	defer listener.Close()
+
+	kafkaConsumer := adapters.KafkaConsumer{Brokers: []string{listener.Addr().String()}, ClientId: "test", MaxWait: 10 * time.Millisecond}
+	stopped := make(chan struct{})
+	received := make([]adapters.BrokerMessage, 0)
+
+	// when
+	err := kafkaConsumer.Subscribe(adapters.Subscription{
+		Topic: "better-admin.commands",
+		LoadOffset: func(topic string, partition int32) (int64, bool, error) {
+			return 5, true, nil
+		},
+		Handle: func(message adapters.BrokerMessage) error {
+			received = append(received, message)
+			close(stopped)
+			return nil
+		},
+	}, stopped)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), <-fetched)
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, `{"id":"cmd-1"}`, string(received[0].Value))
+	assert.Equal(t, int64(5), received[0].Offset)
+	assert.Equal(t, int32(0), received[0].Partition)
+	assert.Equal(t, map[string]string{"traceId": "trace-1"}, received[0].Headers)
+}
@@ -2,6 +2,7 @@ package rest
 
 import (
 	"better-admin-backend-service/config"
+	"better-admin-backend-service/security"
 	"better-admin-backend-service/testdata/testserver"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
@@ -20,6 +21,7 @@ func init() {
 		panic(err)
 	}
 
+	RegisterModule(testModule)
 	testAppServer := testserver.NewTestAppServer(Router{})
 	gormDB = testAppServer.GetDB()
 	ginApp = testAppServer.GetGin()
@@ -32,5 +34,12 @@ func generateTestJWT(claim map[string]interface{}, duration time.Duration) (stri
 	}
 
 	token["exp"] = time.Now().Add(duration).Unix()
+	token["epoch"] = security.GetTokenEpoch()
 	return jwt.NewWithClaims(jwt.SigningMethodHS256, token).SignedString([]byte(config.Config.JwtSecret))
 }
+
+// generateTestStepUpToken 은 최고 관리자 역할을 바꾸는 요청에 보낼 단계 인증 토큰을 만든다.
+func generateTestStepUpToken(memberId uint) string {
+	token, _, _ := security.JwtAuthentication{}.GenerateStepUpToken(memberId, time.Minute*5)
+	return token
+}
@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type LicenseController struct {
+	routerGroup    *gin.RouterGroup
+	licenseService *services.LicenseService
+}
+
+func NewLicenseController(
+	routerGroup *gin.RouterGroup,
+	licenseService *services.LicenseService) *LicenseController {
+
+	return &LicenseController{
+		routerGroup:    routerGroup,
+		licenseService: licenseService,
+	}
+}
+
+func (c LicenseController) MapRoutes() {
+	route := c.routerGroup.Group("/system")
+	route.GET("/license",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getLicenseStatus)
+}
+
+func (c LicenseController) getLicenseStatus(ctx *gin.Context) {
+	status, err := c.licenseService.GetLicenseStatus(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+// respondLicenseError 는 라이선스 때문에 만들 수 없는 오류(ErrLicenseQuotaExceeded, ErrInvalidLicense)이면 403 으로 응답하고 true 를 반환한다.
+func respondLicenseError(ctx *gin.Context, err error) bool {
+	switch e := err.(type) {
+	case *errors.ErrLicenseQuotaExceeded:
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return true
+	case *errors.ErrInvalidLicense:
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,193 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/testdata/testdb"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setUpTestLicense 는 license 를 서명한 라이선스 파일을 만들어 설정하고, 서명한 키를 공급자의 공개 키로 바꾼다. 되돌리는 함수를 반환한다.
+func setUpTestLicense(t *testing.T, license security.License) func() {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+
+	data, err := security.SignLicense(license, privateKey)
+	assert.Nil(t, err)
+
+	path := filepath.Join(t.TempDir(), "license.json")
+	assert.Nil(t, os.WriteFile(path, data, 0600))
+
+	original, originalPublicKey := config.Config.License, security.LicensePublicKey
+	config.Config.License.FilePath = path
+	security.LicensePublicKey = base64.StdEncoding.EncodeToString(publicKey)
+	return func() { config.Config.License, security.LicensePublicKey = original, originalPublicKey }
+}
+
+func signUpLicensedMember(signId string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/members", strings.NewReader(fmt.Sprintf(`{
+		"signId": "%s",
+		"name": "라이선스",
+		"password": "password1234"
+	}`, signId)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func getTestLicenseStatus() (int, dtos.LicenseStatus) {
+	req := httptest.NewRequest(http.MethodGet, "/api/system/license", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	var status dtos.LicenseStatus
+	json.Unmarshal(rec.Body.Bytes(), &status)
+	return rec.Code, status
+}
+
+func TestLicenseController_최대_멤버_수(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+	defer setUpTestLicense(t, security.License{Licensee: "베터코드", ExpiresAt: time.Now().AddDate(1, 0, 0), MaxMembers: 6})()
+	config.Config.License.WarningPercent = 80
+	config.Config.License.NotifyRoleName = "SYSTEM MANAGER"
+	gormDB.Exec("UPDATE members SET google_mail = ? WHERE id = 1", "license@example.com")
+
+	// when
+	// 멤버(4명)가 기준(6명의 80%, 5명)과 최대 수에 이를 때 알린다.
+	assert.Equal(t, http.StatusCreated, signUpLicensedMember("license-1").Code)
+	assert.Equal(t, http.StatusCreated, signUpLicensedMember("license-2").Code)
+	rec := signUpLicensedMember("license-3")
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "LICENSE_QUOTA_EXCEEDED", errorMessage.Code)
+	assert.Equal(t, "license quota exceeded: members (limit 6)", errorMessage.Message)
+
+	assert.Equal(t, 2, len(mailSender.messages))
+	assert.Equal(t, []string{"license@example.com"}, mailSender.messages[0].To)
+	assert.Equal(t, "[Better Admin] 라이선스 사용량 members 5/6", mailSender.messages[0].Subject)
+	assert.Equal(t, "[Better Admin] 라이선스 사용량 members 6/6", mailSender.messages[1].Subject)
+
+	code, status := getTestLicenseStatus()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, status.Configured)
+	assert.Equal(t, true, status.Valid)
+	assert.Equal(t, "베터코드", status.Licensee)
+	assert.NotNil(t, status.ExpiresAt)
+	assert.Equal(t, []dtos.LicenseQuota{
+		{Resource: constants.LicenseResourceMembers, Usage: 6, Limit: 6, Warning: true},
+		{Resource: constants.LicenseResourceRoles, Usage: 3, Limit: 0, Warning: false},
+		{Resource: constants.LicenseResourceApiKeys, Usage: 1, Limit: 0, Warning: false},
+	}, status.Quotas)
+
+	// 해지한 멤버는 세지 않는다.
+	gormDB.Exec("UPDATE members SET status = ? WHERE sign_id = ?", constants.StatusMemberDeprovisioned, "license-1")
+	assert.Equal(t, http.StatusCreated, signUpLicensedMember("license-3").Code)
+}
+
+func TestLicenseController_최대_역할과_API_Key_수(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer setUpTestLicense(t, security.License{Licensee: "베터코드", MaxRoles: 3, MaxApiKeys: 1})()
+
+	// when
+	rec := requestOrganizationSetting(http.MethodPost, "/api/access-control/roles", `{
+		"name": "라이선스 관리자",
+		"allowedPermissionIds": [1]
+	}`, []string{constants.PermissionManageAccessControl})
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "LICENSE_QUOTA_EXCEEDED", errorMessage.Code)
+
+	// 발급한 API Key 를 다시 발급하면 API Key 수가 늘지 않는다.
+	rec = requestOrganizationSetting(http.MethodPost, "/api/service-accounts/1/api-key", "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requestOrganizationSetting(http.MethodPost, "/api/service-accounts", `{"name": "라이선스"}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var serviceAccountId uint
+	gormDB.Raw("SELECT id FROM service_accounts WHERE name = ?", "라이선스").Scan(&serviceAccountId)
+	rec = requestOrganizationSetting(http.MethodPost, fmt.Sprintf("/api/service-accounts/%d/api-key", serviceAccountId), "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "LICENSE_QUOTA_EXCEEDED", errorMessage.Code)
+	assert.Equal(t, "license quota exceeded: api-keys (limit 1)", errorMessage.Message)
+}
+
+func TestLicenseController_유효하지_않은_라이선스(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// 라이선스 파일이 없으면 제한하지 않는다.
+	code, status := getTestLicenseStatus()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, false, status.Configured)
+	assert.Equal(t, true, status.Valid)
+	assert.Equal(t, int64(4), status.Quotas[0].Usage)
+	assert.Equal(t, int64(0), status.Quotas[0].Limit)
+
+	// 공급자가 아닌 다른 키로 서명한 라이선스(라이선스를 받는 쪽에서 직접 서명)는 사용할 수 없다.
+	reset := setUpTestLicense(t, security.License{Licensee: "베터코드", MaxMembers: 100})
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	security.LicensePublicKey = base64.StdEncoding.EncodeToString(publicKey)
+
+	code, status = getTestLicenseStatus()
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, status.Configured)
+	assert.Equal(t, false, status.Valid)
+	assert.Equal(t, "invalid license signature", status.Error)
+	assert.Equal(t, int64(0), status.Quotas[0].Limit)
+
+	rec := signUpLicensedMember("license-1")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "INVALID_LICENSE", errorMessage.Code)
+	reset()
+
+	// 만료된 라이선스도 사용할 수 없다.
+	defer setUpTestLicense(t, security.License{Licensee: "베터코드", ExpiresAt: time.Now().Add(-time.Hour), MaxMembers: 100})()
+	code, status = getTestLicenseStatus()
+	assert.Equal(t, false, status.Valid)
+	assert.Equal(t, "베터코드", status.Licensee)
+	assert.True(t, strings.HasPrefix(status.Error, "license expired at "))
+
+	rec = signUpLicensedMember("license-1")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "INVALID_LICENSE", errorMessage.Code)
+
+	rec = requestOrganizationSetting(http.MethodGet, "/api/system/license", "", []string{constants.PermissionManageMembers})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
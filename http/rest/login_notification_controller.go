@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type LoginNotificationController struct {
+	routerGroup              *gin.RouterGroup
+	loginNotificationService *services.LoginNotificationService
+	passwordBreachService    *services.PasswordBreachService
+}
+
+func NewLoginNotificationController(
+	routerGroup *gin.RouterGroup,
+	loginNotificationService *services.LoginNotificationService,
+	passwordBreachService *services.PasswordBreachService) *LoginNotificationController {
+
+	return &LoginNotificationController{
+		routerGroup:              routerGroup,
+		loginNotificationService: loginNotificationService,
+		passwordBreachService:    passwordBreachService,
+	}
+}
+
+func (c LoginNotificationController) MapRoutes() {
+	route := c.routerGroup.Group("/members")
+	route.GET("/me/login-notifications", middlewares.PermissionChecker([]string{"*"}),
+		c.getMyLoginNotifications)
+	route.PUT("/me/login-notifications/:id/reported", middlewares.PermissionChecker([]string{"*"}),
+		c.reportMyLogin)
+	// 신고와 비밀번호 재설정은 메일로 받은 토큰으로 인증한다.
+	route.POST("/login-notifications/report", middlewares.Public(), c.reportLogin)
+	route.POST("/password-reset", middlewares.Public(), c.resetPassword)
+
+	settingRoute := c.routerGroup.Group("/site")
+	settingRoute.GET("/settings/login-notification",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getLoginNotificationSetting)
+	settingRoute.PUT("/settings/login-notification",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setLoginNotificationSetting)
+}
+
+func (c LoginNotificationController) getMyLoginNotifications(ctx *gin.Context) {
+	entities, err := c.loginNotificationService.GetMyLoginNotifications(ctx.Request.Context())
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	var loginNotifications = make([]dtos.LoginNotificationInformation, 0)
+	for _, entity := range entities {
+		loginNotifications = append(loginNotifications, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, loginNotifications)
+}
+
+func (c LoginNotificationController) reportMyLogin(ctx *gin.Context) {
+	loginNotificationId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.loginNotificationService.ReportMyLogin(ctx.Request.Context(), uint(loginNotificationId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c LoginNotificationController) reportLogin(ctx *gin.Context) {
+	var token dtos.LoginNotificationToken
+	if err := ctx.BindJSON(&token); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.loginNotificationService.ReportLogin(ctx.Request.Context(), token.Token); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c LoginNotificationController) resetPassword(ctx *gin.Context) {
+	var passwordReset dtos.PasswordReset
+	if err := ctx.BindJSON(&passwordReset); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !checkBreachedPassword(ctx, c.passwordBreachService, passwordReset.Password) {
+		return
+	}
+
+	if err := c.loginNotificationService.ResetPassword(ctx.Request.Context(), passwordReset); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c LoginNotificationController) getLoginNotificationSetting(ctx *gin.Context) {
+	setting, err := c.loginNotificationService.GetLoginNotificationSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c LoginNotificationController) setLoginNotificationSetting(ctx *gin.Context) {
+	var setting dtos.LoginNotificationSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.loginNotificationService.SetLoginNotificationSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (LoginNotificationController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrExpired) || errors.Is(err, errors.ErrNonChangeable) {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if e, ok := err.(*errors.ErrPasswordPolicyViolated); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,176 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func loginWithUserAgent(signId, password, userAgent string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth",
+		strings.NewReader(fmt.Sprintf(`{"id": "%s", "password": "%s"}`, signId, password)))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func accessTokenOf(rec *httptest.ResponseRecorder) string {
+	var response map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	accessToken, _ := response["accessToken"].(string)
+	return accessToken
+}
+
+func requestWithAccessToken(method, url, accessToken string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLoginNotificationController_본인이_아닌_로그인_신고(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Exec("UPDATE members SET sign_id = 'siteadm@bettercode.kr' WHERE id = 1")
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/site/settings/login-notification",
+		strings.NewReader(`{"enabled": true, "channels": ["mail"]}`), 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 알림을 켠 뒤 처음 로그인한 기기는 기록만 하고, 같은 기기로 다시 로그인해도 알리지 않는다.
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("siteadm@bettercode.kr", "123456", "browser-a").Code)
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("siteadm@bettercode.kr", "123456", "browser-a").Code)
+	assert.Equal(t, 0, len(mailSender.messages))
+
+	// when
+	rec = loginWithUserAgent("siteadm@bettercode.kr", "123456", "browser-b")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	accessToken := accessTokenOf(rec)
+	assert.Equal(t, http.StatusOK, requestWithAccessToken(http.MethodGet, "/api/members/me/preferences", accessToken).Code)
+
+	// then
+	assert.Equal(t, 1, len(mailSender.messages))
+	assert.Equal(t, []string{"siteadm@bettercode.kr"}, mailSender.messages[0].To)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/login-notifications/report",
+		strings.NewReader(fmt.Sprintf(`{"token": "%s"}`, extractMailToken(mailSender.messages[0]))))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 신고하면 모든 세션을 종료하고 비밀번호를 다시 설정할 때까지 비밀번호로 로그인할 수 없다.
+	var activeSessionCount int64
+	gormDB.Raw("SELECT count(*) FROM member_sessions WHERE member_id = 1 AND revoked_at IS NULL").Scan(&activeSessionCount)
+	assert.Equal(t, int64(0), activeSessionCount)
+
+	// 종료된 세션에서 발급한 Access 토큰은 만료 전이라도 사용할 수 없다.
+	rec = requestWithAccessToken(http.MethodGet, "/api/members/me/preferences", accessToken)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SESSION_REVOKED")
+
+	var anomalyCount int64
+	gormDB.Raw("SELECT count(*) FROM security_events WHERE type = 'anomaly' AND member_id = 1").Scan(&anomalyCount)
+	assert.Equal(t, int64(1), anomalyCount)
+
+	rec = loginWithUserAgent("siteadm@bettercode.kr", "123456", "browser-a")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "PASSWORD_RESET_REQUIRED", rec.Header().Get(middlewares.ErrorCodeHeader))
+
+	assert.Equal(t, 2, len(mailSender.messages))
+	req = httptest.NewRequest(http.MethodPost, "/api/members/password-reset",
+		strings.NewReader(fmt.Sprintf(`{"token": "%s", "password": "new-password-1234"}`, extractMailToken(mailSender.messages[1]))))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	assert.Equal(t, http.StatusBadRequest, loginWithUserAgent("siteadm@bettercode.kr", "123456", "browser-a").Code)
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("siteadm@bettercode.kr", "new-password-1234", "browser-a").Code)
+
+	// 재설정 토큰은 한 번만 사용할 수 있다.
+	req = httptest.NewRequest(http.MethodPost, "/api/members/password-reset",
+		strings.NewReader(fmt.Sprintf(`{"token": "%s", "password": "other-password-1234"}`, extractMailToken(mailSender.messages[1]))))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestLoginNotificationController_앱_내_알림에서_신고(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/site/settings/login-notification",
+		strings.NewReader(`{"enabled": true, "channels": ["in-app"]}`), 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// given
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("ymyoo", "123456", "browser-a").Code)
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("ymyoo", "123456", "browser-b").Code)
+
+	// when
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/members/me/login-notifications", nil, 3, []string{})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var loginNotificationId uint
+	gormDB.Raw("SELECT id FROM login_notifications WHERE member_id = 3").Scan(&loginNotificationId)
+	assert.Contains(t, rec.Body.String(), fmt.Sprintf(`"id":%d`, loginNotificationId))
+
+	// 다른 멤버의 알림은 신고할 수 없다.
+	rec = requestTestResourceOwnership(http.MethodPut, fmt.Sprintf("/api/members/me/login-notifications/%d/reported", loginNotificationId), nil, 1, []string{})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = requestTestResourceOwnership(http.MethodPut, fmt.Sprintf("/api/members/me/login-notifications/%d/reported", loginNotificationId), nil, 3, []string{})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestTestResourceOwnership(http.MethodPut, fmt.Sprintf("/api/members/me/login-notifications/%d/reported", loginNotificationId), nil, 3, []string{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var passwordResetRequired bool
+	gormDB.Raw("SELECT password_reset_required FROM members WHERE id = 3").Scan(&passwordResetRequired)
+	assert.True(t, passwordResetRequired)
+}
+
+func TestLoginNotificationController_서비스_계정은_신고할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/site/settings/login-notification",
+		strings.NewReader(`{"enabled": true, "channels": ["in-app"]}`), 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// given
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("ymyoo", "123456", "browser-a").Code)
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("ymyoo", "123456", "browser-b").Code)
+	var loginNotificationId uint
+	gormDB.Raw("SELECT id FROM login_notifications WHERE member_id = 3").Scan(&loginNotificationId)
+
+	// when
+	// 서비스 계정의 Id(3)가 알림을 받은 멤버(3)의 Id 와 같아도 멤버의 알림을 보거나 신고할 수 없다.
+	getRec := requestAsServiceAccount(http.MethodGet, "/api/members/me/login-notifications", "", 3, []string{})
+	reportRec := requestAsServiceAccount(http.MethodPut, fmt.Sprintf("/api/members/me/login-notifications/%d/reported", loginNotificationId), "", 3, []string{})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, getRec.Code)
+	assert.Equal(t, http.StatusUnauthorized, reportRec.Code)
+
+	var passwordResetRequired bool
+	gormDB.Raw("SELECT password_reset_required FROM members WHERE id = 3").Scan(&passwordResetRequired)
+	assert.False(t, passwordResetRequired)
+}
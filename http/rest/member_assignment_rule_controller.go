@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type MemberAssignmentRuleController struct {
+	routerGroup                 *gin.RouterGroup
+	memberAssignmentRuleService *services.MemberAssignmentRuleService
+}
+
+func NewMemberAssignmentRuleController(
+	routerGroup *gin.RouterGroup,
+	memberAssignmentRuleService *services.MemberAssignmentRuleService) *MemberAssignmentRuleController {
+
+	return &MemberAssignmentRuleController{
+		routerGroup:                 routerGroup,
+		memberAssignmentRuleService: memberAssignmentRuleService,
+	}
+}
+
+func (c MemberAssignmentRuleController) MapRoutes() {
+	route := c.routerGroup.Group("/member-assignment-rules")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.createRule)
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		etag.HttpEtagCache(0),
+		c.getRules)
+	route.POST("/dry-run", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.dryRun)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		etag.HttpEtagCache(0),
+		c.getRule)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.updateRule)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.deleteRule)
+}
+
+func (c MemberAssignmentRuleController) createRule(ctx *gin.Context) {
+	var information dtos.MemberAssignmentRuleInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.memberAssignmentRuleService.CreateRule(ctx.Request.Context(), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.toRuleInformation(entity))
+}
+
+func (c MemberAssignmentRuleController) getRules(ctx *gin.Context) {
+	entities, err := c.memberAssignmentRuleService.GetRules(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var rules = make([]dtos.MemberAssignmentRuleInformation, 0)
+	for _, entity := range entities {
+		rules = append(rules, c.toRuleInformation(entity))
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+func (c MemberAssignmentRuleController) getRule(ctx *gin.Context) {
+	ruleId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.memberAssignmentRuleService.GetRule(ctx.Request.Context(), uint(ruleId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toRuleInformation(entity))
+}
+
+func (c MemberAssignmentRuleController) updateRule(ctx *gin.Context) {
+	ruleId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.MemberAssignmentRuleInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.memberAssignmentRuleService.UpdateRule(ctx.Request.Context(), uint(ruleId), information); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberAssignmentRuleController) deleteRule(ctx *gin.Context) {
+	ruleId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.memberAssignmentRuleService.DeleteRule(ctx.Request.Context(), uint(ruleId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// dryRun 은 규칙을 기존 멤버에게 적용한 결과를 미리 보여준다. 역할과 조직은 할당하지 않는다.
+func (c MemberAssignmentRuleController) dryRun(ctx *gin.Context) {
+	var dryRun dtos.MemberAssignmentDryRun
+	if err := ctx.BindJSON(&dryRun); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberAssignmentRuleService.DryRun(ctx.Request.Context(), dryRun)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (MemberAssignmentRuleController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if invalidRule, ok := err.(*errors.ErrInvalidMemberAssignmentRule); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: invalidRule.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+func (MemberAssignmentRuleController) toRuleInformation(entity domain.MemberAssignmentRuleEntity) dtos.MemberAssignmentRuleInformation {
+	return dtos.MemberAssignmentRuleInformation{
+		Id:              entity.ID,
+		Name:            entity.Name,
+		Priority:        entity.Priority,
+		Condition:       entity.GetCondition(),
+		RoleIds:         entity.GetRoleIds(),
+		OrganizationIds: entity.GetOrganizationIds(),
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
+	}
+}
@@ -0,0 +1,157 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemberAssignmentRuleController_createRule(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/member-assignment-rules", strings.NewReader(`{
+		"name": "백엔드 조직 단위", "priority": 5,
+		"condition": {"googleOrgUnits": ["/Engineering/Backend"]},
+		"roleIds": [2], "organizationIds": [4]
+	}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// 우선순위 순으로 조회된다.
+	req = httptest.NewRequest(http.MethodGet, "/api/member-assignment-rules", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var rules []dtos.MemberAssignmentRuleInformation
+	json.Unmarshal(rec.Body.Bytes(), &rules)
+	assert.Len(t, rules, 3)
+	assert.Equal(t, "백엔드 조직 단위", rules[0].Name)
+	assert.Equal(t, []string{"/Engineering/Backend"}, rules[0].Condition.GoogleOrgUnits)
+	assert.Equal(t, []uint{2}, rules[0].RoleIds)
+	assert.Equal(t, []uint{4}, rules[0].OrganizationIds)
+	assert.Equal(t, "개발팀 기본 역할", rules[1].Name)
+}
+
+func TestMemberAssignmentRuleController_createRule_잘못된_규칙(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	testCases := map[string]string{
+		"조건이 없는 경우":    `{"name": "전체", "condition": {}, "roleIds": [2]}`,
+		"할당 대상이 없는 경우": `{"name": "메일", "condition": {"emailDomains": ["bettercode.kr"]}}`,
+		"없는 역할":        `{"name": "메일", "condition": {"emailDomains": ["bettercode.kr"]}, "roleIds": [999]}`,
+		"없는 조직":        `{"name": "메일", "condition": {"emailDomains": ["bettercode.kr"]}, "organizationIds": [999]}`,
+	}
+
+	for name, body := range testCases {
+		// given
+		req := httptest.NewRequest(http.MethodPost, "/api/member-assignment-rules", strings.NewReader(body))
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          1,
+			"Permissions": []string{constants.PermissionManageMembers},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		fmt.Println(rec.Body.String())
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+}
+
+func TestMemberAssignmentRuleController_dryRun(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/member-assignment-rules/dry-run", strings.NewReader(`{}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.MemberAssignmentDryRunResult
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, actual.Evaluated)
+	assert.Equal(t, 1, actual.Matched)
+	assert.Equal(t, uint(2), actual.Results[0].MemberId)
+	assert.Equal(t, uint(1), actual.Results[0].RuleId)
+	assert.Equal(t, "개발팀", actual.Results[0].Attributes.DoorayDepartment)
+	assert.Equal(t, []uint{2}, actual.Results[0].RoleIds)
+	assert.Equal(t, []uint{3}, actual.Results[0].OrganizationIds)
+
+	// 미리 보기만 하므로 조직에 추가되지 않는다.
+	var organizationCount int64
+	gormDB.Raw("SELECT count(*) FROM organization_members WHERE organization_entity_id = 3 AND member_entity_id = 2").Scan(&organizationCount)
+	assert.Equal(t, int64(0), organizationCount)
+}
+
+func TestMemberAssignmentRuleController_dryRun_저장하지_않은_규칙(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/member-assignment-rules/dry-run", strings.NewReader(`{
+		"memberIds": [1, 2, 3],
+		"rules": [
+			{"name": "개발팀", "priority": 2, "condition": {"doorayDepartments": ["개발팀"]}, "roleIds": [1]},
+			{"name": "두레이", "priority": 1, "condition": {"memberTypes": ["dooray"]}, "organizationIds": [1]}
+		]
+	}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.MemberAssignmentDryRunResult
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 3, actual.Evaluated)
+	assert.Equal(t, 1, actual.Matched)
+	// 우선순위가 높은 규칙 하나만 적용된다.
+	assert.Equal(t, uint(2), actual.Results[0].MemberId)
+	assert.Equal(t, "두레이", actual.Results[0].RuleName)
+	assert.Equal(t, []uint{1}, actual.Results[0].OrganizationIds)
+}
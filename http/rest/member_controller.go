@@ -12,32 +12,48 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type MemberController struct {
-	routerGroup         *gin.RouterGroup
-	rbacService         *services.RoleBasedAccessControlService
-	memberService       *services.MemberService
-	organizationService *services.OrganizationService
+	routerGroup              *gin.RouterGroup
+	rbacService              *services.RoleBasedAccessControlService
+	memberService            *services.MemberService
+	organizationService      *services.OrganizationService
+	approvalService          *services.ApprovalService
+	domainEventService       *services.DomainEventService
+	memberApprovalService    *services.MemberApprovalService
+	passwordBreachService    *services.PasswordBreachService
+	memberFieldChangeService *services.MemberFieldChangeService
 }
 
 func NewMemberController(routerGroup *gin.RouterGroup,
 	rbacService *services.RoleBasedAccessControlService,
 	memberService *services.MemberService,
-	organizationService *services.OrganizationService) *MemberController {
+	organizationService *services.OrganizationService,
+	approvalService *services.ApprovalService,
+	domainEventService *services.DomainEventService,
+	memberApprovalService *services.MemberApprovalService,
+	passwordBreachService *services.PasswordBreachService,
+	memberFieldChangeService *services.MemberFieldChangeService) *MemberController {
 
 	return &MemberController{
-		routerGroup:         routerGroup,
-		rbacService:         rbacService,
-		memberService:       memberService,
-		organizationService: organizationService,
+		routerGroup:              routerGroup,
+		rbacService:              rbacService,
+		memberService:            memberService,
+		organizationService:      organizationService,
+		approvalService:          approvalService,
+		domainEventService:       domainEventService,
+		memberApprovalService:    memberApprovalService,
+		passwordBreachService:    passwordBreachService,
+		memberFieldChangeService: memberFieldChangeService,
 	}
 }
 
 func (c MemberController) MapRoutes() {
 	route := c.routerGroup.Group("/members")
 
-	route.POST("", c.signUpMember)
+	route.POST("", middlewares.Public(), c.signUpMember)
 	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
 		etag.HttpEtagCache(0),
 		c.getMembers)
@@ -52,9 +68,22 @@ func (c MemberController) MapRoutes() {
 		c.approveMember)
 	route.PUT("/:id/rejected", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
 		c.rejectMember)
+	route.POST("/approve/bulk", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.approveMembers)
 	route.GET("/search-filters", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
 		etag.HttpEtagCache(0),
 		c.getSearchFilters)
+	route.GET("/tags", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		etag.HttpEtagCache(0),
+		c.getTags)
+	route.PUT("/:id/tags", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.setTags)
+	route.GET("/password-hashes", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getPasswordHashReport)
+	route.GET("/:id/history", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.getMemberHistory)
+	route.GET("/:id/history/state", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.getMemberHistoryState)
 }
 
 func (c MemberController) signUpMember(ctx *gin.Context) {
@@ -64,12 +93,43 @@ func (c MemberController) signUpMember(ctx *gin.Context) {
 		return
 	}
 
-	err := c.memberService.SignUpMember(ctx.Request.Context(), memberSignUp)
+	if !checkBreachedPassword(ctx, c.passwordBreachService, memberSignUp.Password) {
+		return
+	}
+
+	member, err := c.memberService.SignUpMember(ctx.Request.Context(), memberSignUp)
 	if err != nil {
-		if err == errors.ErrDuplicated {
+		if errors.Is(err, errors.ErrDuplicated) {
 			ctx.JSON(http.StatusBadRequest, err.Error())
 			return
 		}
+
+		if e, ok := err.(*errors.ErrPasswordPolicyViolated); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+			return
+		}
+
+		if respondLicenseError(ctx, err) {
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	// 메일 도메인 규칙에 맞으면 바로 승인하고, 아니면 승인 대기(수동 승인)로 둔다.
+	autoApproved, err := c.memberApprovalService.AutoApproveSignUp(ctx.Request.Context(), member)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if autoApproved {
+		ctx.Status(http.StatusCreated)
+		return
+	}
+
+	// 가입 승인 절차가 설정되어 있으면 승인 요청을 만든다. 승인 결과는 승인 절차가 끝날 때 반영된다.
+	if _, err := c.approvalService.RequireApproval(ctx.Request.Context(), constants.ApprovalSubjectMemberSignUp, member.ID, nil); err != nil {
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
@@ -84,9 +144,15 @@ func (c MemberController) getCurrentMember(ctx *gin.Context) {
 		return
 	}
 
+	// 서비스 계정은 멤버가 아니므로 멤버 정보가 없다.
+	if userClaim.IsServiceAccount() {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
 	memberEntity, err := c.memberService.GetMemberById(ctx.Request.Context(), userClaim.Id)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -133,6 +199,10 @@ func (c MemberController) getMembers(ctx *gin.Context) {
 		filters["roleIds"] = strings.Split(ctx.Query("roleIds"), ",")
 	}
 
+	if len(ctx.Query("tags")) > 0 {
+		filters["tags"] = strings.Split(ctx.Query("tags"), ",")
+	}
+
 	memberEntities, totalCount, err := c.memberService.GetMembers(ctx.Request.Context(), filters, pageable)
 	if err != nil {
 		helpers.ErrorHelper().InternalServerError(ctx, err)
@@ -169,7 +239,10 @@ func (c MemberController) getMembers(ctx *gin.Context) {
 			TypeName:     entity.GetTypeName(),
 			Name:         entity.Name,
 			MemberRoles:  roles,
+			Tags:         entity.GetTagNames(),
 			CreatedAt:    entity.CreatedAt,
+			Email:        entity.GetEmail(),
+			Phone:        entity.Phone,
 			LastAccessAt: entity.LastAccessAt,
 		}
 
@@ -202,7 +275,7 @@ func (c MemberController) getMembers(ctx *gin.Context) {
 		TotalCount: totalCount,
 	}
 
-	ctx.JSON(http.StatusOK, pageResult)
+	helpers.ResponseHelper().JSON(ctx, http.StatusOK, pageResult)
 }
 
 func (c MemberController) getMember(ctx *gin.Context) {
@@ -214,7 +287,7 @@ func (c MemberController) getMember(ctx *gin.Context) {
 
 	memberEntity, err := c.memberService.GetMember(ctx.Request.Context(), uint(memberId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.JSON(http.StatusNotFound, err)
 			return
 		}
@@ -230,14 +303,18 @@ func (c MemberController) getMember(ctx *gin.Context) {
 		})
 	}
 	memberInformation := dtos.MemberInformation{
-		Id:          memberEntity.ID,
-		Type:        memberEntity.Type,
-		TypeName:    memberEntity.GetTypeName(),
-		Name:        memberEntity.Name,
-		MemberRoles: roles,
+		Id:           memberEntity.ID,
+		Type:         memberEntity.Type,
+		TypeName:     memberEntity.GetTypeName(),
+		Name:         memberEntity.Name,
+		MemberRoles:  roles,
+		Tags:         memberEntity.GetTagNames(),
+		Email:        memberEntity.GetEmail(),
+		Phone:        memberEntity.Phone,
+		LastAccessAt: memberEntity.LastAccessAt,
 	}
 
-	ctx.JSON(http.StatusOK, memberInformation)
+	helpers.ResponseHelper().JSON(ctx, http.StatusOK, memberInformation)
 }
 
 func (c MemberController) assignRole(ctx *gin.Context) {
@@ -253,12 +330,46 @@ func (c MemberController) assignRole(ctx *gin.Context) {
 		return
 	}
 
+	// 최고 관리자 역할이 관련된 변경은 역할 할당 승인 대신 중요 필드 변경 승인을 받는다.
+	critical, err := c.memberFieldChangeService.IsCriticalRoleChange(ctx.Request.Context(), uint(memberId), assignRole)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if critical {
+		c.requestRoleChange(ctx, uint(memberId), assignRole)
+		return
+	}
+
+	approved, err := c.approvalService.RequireApproval(ctx.Request.Context(), constants.ApprovalSubjectRoleGrant, uint(memberId), assignRole)
+	if err != nil {
+		if errors.Is(err, errors.ErrApprovalInProgress) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if !approved {
+		ctx.Status(http.StatusAccepted)
+		return
+	}
+
 	err = c.memberService.AssignRole(ctx.Request.Context(), uint(memberId), assignRole)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
+		if handleSuperAdminProtectionError(ctx, err) {
+			return
+		}
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
@@ -266,6 +377,29 @@ func (c MemberController) assignRole(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// requestRoleChange 는 승인을 기다리면 202 와 변경 내용을, 바로 반영되면 204 를 응답한다.
+func (c MemberController) requestRoleChange(ctx *gin.Context, memberId uint, assignRole dtos.MemberAssignRole) {
+	entity, err := c.memberFieldChangeService.RequestRoleChange(ctx.Request.Context(), memberId, assignRole)
+	if err != nil {
+		if errors.Is(err, errors.ErrApprovalInProgress) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		if handleSuperAdminProtectionError(ctx, err) {
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if entity.IsPending() {
+		ctx.JSON(http.StatusAccepted, entity.ToInformation())
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func (c MemberController) approveMember(ctx *gin.Context) {
 	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
 	if err != nil {
@@ -273,13 +407,17 @@ func (c MemberController) approveMember(ctx *gin.Context) {
 		return
 	}
 
+	if c.rejectIfSignUpApprovalInProgress(ctx, uint(memberId)) {
+		return
+	}
+
 	err = c.memberService.ApproveMember(ctx.Request.Context(), uint(memberId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
-		if err == errors.ErrAlreadyApproved {
+		if errors.Is(err, errors.ErrAlreadyApproved) {
 			ctx.JSON(http.StatusBadRequest, err.Error())
 			return
 		}
@@ -290,6 +428,31 @@ func (c MemberController) approveMember(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+func (c MemberController) approveMembers(ctx *gin.Context) {
+	var request dtos.MemberBulkApproval
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if (len(request.MemberIds) == 0) == (request.Filter == nil) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: "either memberIds or filter is required"})
+		return
+	}
+
+	result, err := c.memberApprovalService.ApproveMembers(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, errors.ErrApprovalRequired) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
 func (c MemberController) getSearchFilters(ctx *gin.Context) {
 	filters := make([]dtos.SearchFilter, 0)
 
@@ -308,6 +471,10 @@ func (c MemberController) getSearchFilters(ctx *gin.Context) {
 				Text:  constants.TypeMemberGoogleName,
 				Value: constants.TypeMemberGoogle,
 			},
+			{
+				Text:  constants.TypeMemberCustomName,
+				Value: constants.TypeMemberCustom,
+			},
 		},
 	}
 	filters = append(filters, memberTypeSearchFilter)
@@ -341,9 +508,82 @@ func (c MemberController) rejectMember(ctx *gin.Context) {
 		return
 	}
 
+	if c.rejectIfSignUpApprovalInProgress(ctx, uint(memberId)) {
+		return
+	}
+
 	err = c.memberService.RejectMember(ctx.Request.Context(), uint(memberId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, errors.ErrLegalHold) {
+			ctx.JSON(http.StatusConflict, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
+		if handleSuperAdminProtectionError(ctx, err) {
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// rejectIfSignUpApprovalInProgress 는 가입 승인 절차가 진행 중인 멤버를 직접 승인/거절하지 못하게 한다.
+func (c MemberController) rejectIfSignUpApprovalInProgress(ctx *gin.Context, memberId uint) bool {
+	pending, err := c.approvalService.HasPendingRequest(ctx.Request.Context(), constants.ApprovalSubjectMemberSignUp, memberId)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return true
+	}
+
+	if pending {
+		ctx.JSON(http.StatusBadRequest, errors.ErrApprovalInProgress.Error())
+		return true
+	}
+
+	return false
+}
+
+func (c MemberController) getTags(ctx *gin.Context) {
+	tagNames, err := c.memberService.GetAllTagNames(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tagNames)
+}
+
+// getPasswordHashReport 는 아직 이전 알고리즘이나 비용으로 비밀번호가 저장된 멤버 수를 보여준다.
+func (c MemberController) getPasswordHashReport(ctx *gin.Context) {
+	report, err := c.memberService.GetPasswordHashReport(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (c MemberController) setTags(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var memberTags dtos.MemberTags
+	if err := ctx.BindJSON(&memberTags); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.memberService.SetTags(ctx.Request.Context(), uint(memberId), memberTags); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -353,3 +593,59 @@ func (c MemberController) rejectMember(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func (c MemberController) getMemberHistory(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var until *time.Time
+	if len(ctx.Query("until")) > 0 {
+		parsed, err := time.Parse(time.RFC3339, ctx.Query("until"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		until = &parsed
+	}
+
+	events, err := c.domainEventService.GetMemberHistory(ctx.Request.Context(), uint(memberId), until)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, events)
+}
+
+// getMemberHistoryState 는 at(RFC3339) 시각의 멤버 상태를 반환한다. at 이 없으면 현재 시각의 상태를 반환한다.
+func (c MemberController) getMemberHistoryState(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	at := time.Now()
+	if len(ctx.Query("at")) > 0 {
+		at, err = time.Parse(time.RFC3339, ctx.Query("at"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	state, err := c.domainEventService.GetMemberStateAt(ctx.Request.Context(), uint(memberId), at)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.JSON(http.StatusNotFound, err)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, state)
+}
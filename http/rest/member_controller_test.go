@@ -1,12 +1,17 @@
 package rest
 
 import (
+	"better-admin-backend-service/app/middlewares"
+	auditDomain "better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/testdata/testdb"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -180,6 +185,7 @@ func TestMemberController_getMembers_by_멤버_역할(t *testing.T) {
 		"Id": 1,
 		"Permissions": []string{
 			"MANAGE_MEMBERS",
+			"VIEW_MONITORING",
 		},
 	}, time.Minute*15)
 
@@ -285,6 +291,7 @@ func TestMemberController_getMembers_승인된_멤버(t *testing.T) {
 		"Id": 1,
 		"Permissions": []string{
 			"MANAGE_MEMBERS",
+			"VIEW_MONITORING",
 		},
 	}, time.Minute*15)
 
@@ -389,6 +396,7 @@ func TestMemberController_getMembers_신청한_멤버(t *testing.T) {
 		"Id": 1,
 		"Permissions": []string{
 			"MANAGE_MEMBERS",
+			"VIEW_MONITORING",
 		},
 	}, time.Minute*15)
 
@@ -438,6 +446,7 @@ func TestMemberController_getMembers_by_멤버_이름(t *testing.T) {
 		"Id": 1,
 		"Permissions": []string{
 			"MANAGE_MEMBERS",
+			"VIEW_MONITORING",
 		},
 	}, time.Minute*15)
 
@@ -534,6 +543,7 @@ func TestMemberController_getMembers_by_멤버_유형(t *testing.T) {
 		"Id": 1,
 		"Permissions": []string{
 			"MANAGE_MEMBERS",
+			"VIEW_MONITORING",
 		},
 	}, time.Minute*15)
 
@@ -740,6 +750,53 @@ func TestMemberController_getMember(t *testing.T) {
 	assert.Equal(t, "SYSTEM MANAGER", memberRoles[memberRoleIndex].(map[string]interface{})["name"])
 }
 
+func getMemberWithPermissions(memberId uint, permissions []string) map[string]interface{} {
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/members/%d", memberId), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual
+}
+
+func TestMemberController_getMember_개인_정보_권한이_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Exec("UPDATE members SET google_mail = ?, phone = ? WHERE id = ?", "ymyoo@bettercode.kr", "010-1234-5678", 2)
+
+	// when
+	actual := getMemberWithPermissions(2, []string{"MANAGE_MEMBERS"})
+
+	// then
+	assert.Equal(t, "유영모", actual["name"])
+	assert.NotContains(t, actual, "email")
+	assert.NotContains(t, actual, "phone")
+	assert.NotContains(t, actual, "lastAccessAt")
+}
+
+func TestMemberController_getMember_개인_정보_권한(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Exec("UPDATE members SET google_mail = ?, phone = ? WHERE id = ?", "ymyoo@bettercode.kr", "010-1234-5678", 2)
+
+	// when
+	personalInfo := getMemberWithPermissions(2, []string{"MANAGE_MEMBERS", "VIEW_MEMBER_PERSONAL_INFO"})
+	monitoring := getMemberWithPermissions(2, []string{"MANAGE_MEMBERS", "VIEW_MONITORING"})
+
+	// then
+	assert.Equal(t, "ymyoo@bettercode.kr", personalInfo["email"])
+	assert.Equal(t, "010-1234-5678", personalInfo["phone"])
+	assert.Equal(t, "1982-01-05T00:00:00Z", personalInfo["lastAccessAt"])
+
+	assert.NotContains(t, monitoring, "email")
+	assert.NotContains(t, monitoring, "phone")
+	assert.Equal(t, "1982-01-05T00:00:00Z", monitoring["lastAccessAt"])
+}
+
 func TestMemberController_assignRole_Bad_Request_필수_값_확인(t *testing.T) {
 	// given
 	requestBody := `{
@@ -875,6 +932,7 @@ func TestMemberController_assignRoles_역할이_없는_경우(t *testing.T) {
 		t.Failed()
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(1))
 	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
@@ -1114,6 +1172,10 @@ func TestMemberController_getSearchFilters(t *testing.T) {
 					"text":  "구글",
 					"value": "google",
 				},
+				map[string]interface{}{
+					"text":  "외부 인증",
+					"value": "custom",
+				},
 			},
 		},
 		map[string]interface{}{
@@ -1137,3 +1199,285 @@ func TestMemberController_getSearchFilters(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestMemberController_getMemberHistoryState(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer func(enabled bool) { config.Config.EventStore.Enabled = enabled }(config.Config.EventStore.Enabled)
+	config.Config.EventStore.Enabled = true
+
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+
+	assignRoles := func(requestBody string) {
+		req := httptest.NewRequest(http.MethodPut, "/api/members/1/assign-roles", strings.NewReader(requestBody))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	}
+
+	// given
+	before := time.Now().Add(-time.Second).Format(time.RFC3339Nano)
+	assignRoles(`{"roleIds": [1]}`)
+	middle := time.Now().Format(time.RFC3339Nano)
+	assignRoles(`{"roleIds": [1, 2]}`)
+
+	getState := func(at string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(http.MethodGet, "/api/members/1/history/state?at="+url.QueryEscape(at), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+
+		var state map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &state)
+		return rec.Code, state
+	}
+
+	// when
+	middleCode, middleState := getState(middle)
+	nowCode, nowState := getState(time.Now().Format(time.RFC3339Nano))
+	beforeCode, _ := getState(before)
+
+	// then
+	assert.Equal(t, http.StatusOK, middleCode)
+	assert.Equal(t, float64(1), middleState["version"])
+	assert.Equal(t, []interface{}{"SYSTEM MANAGER"}, middleState["roles"])
+
+	assert.Equal(t, http.StatusOK, nowCode)
+	assert.Equal(t, float64(2), nowState["version"])
+	assert.ElementsMatch(t, []interface{}{"SYSTEM MANAGER", "MEMBER MANAGER"}, nowState["roles"])
+	assert.Equal(t, false, nowState["deleted"])
+
+	assert.Equal(t, http.StatusNotFound, beforeCode)
+}
+
+func TestMemberController_getMemberHistory(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer func(enabled bool) { config.Config.EventStore.Enabled = enabled }(config.Config.EventStore.Enabled)
+	config.Config.EventStore.Enabled = true
+
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/1/assign-roles", strings.NewReader(`{"roleIds": [2]}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(1))
+	req.Header.Set("Content-Type", "application/json")
+	ginApp.ServeHTTP(httptest.NewRecorder(), req)
+
+	// given
+	req = httptest.NewRequest(http.MethodGet, "/api/members/1/history", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var events []map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &events)
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, float64(1), events[0]["sequence"])
+	assert.Equal(t, "member.roles-assigned", events[0]["type"])
+	assert.Equal(t, float64(1), events[0]["actorId"])
+	data := events[0]["data"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"MEMBER MANAGER"}, data["roles"])
+}
+
+func TestMemberController_approveMembers(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/member-approval", strings.NewReader(`{"defaultRoleIds": [3]}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// given
+	req = httptest.NewRequest(http.MethodPost, "/api/members/approve/bulk", strings.NewReader(`{"memberIds": [4, 3, 1000, 4]}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.MemberBulkApprovalResult
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 3, actual.Requested)
+	assert.Equal(t, 1, actual.Succeeded)
+	assert.Equal(t, []string{"테스트 관리자"}, actual.DefaultRoles)
+	assert.ElementsMatch(t, []dtos.MemberBulkApprovalMember{
+		{MemberId: 1000, Status: "failed", Reason: "not-found"},
+		{MemberId: 4, Status: "approved"},
+		{MemberId: 3, Status: "failed", Reason: "already-approved"},
+	}, actual.Results)
+
+	var status string
+	gormDB.Table("members").Where("id = ?", 4).Pluck("status", &status)
+	assert.Equal(t, "approved", status)
+
+	var roleIds []uint
+	gormDB.Table("member_roles").Where("member_entity_id = ?", 4).Pluck("role_entity_id", &roleIds)
+	assert.Equal(t, []uint{3}, roleIds)
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).Where("action = ?", "members-bulk-approved").Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+
+	var activityFeedCount int64
+	gormDB.Model(&auditDomain.ActivityFeedEntity{}).
+		Where("action = ? AND entity_type = ? AND entity_id = ?", "members-bulk-approved", "member", 4).
+		Count(&activityFeedCount)
+	assert.Equal(t, int64(1), activityFeedCount)
+}
+
+func TestMemberController_approveMembers_조건으로_승인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/members/approve/bulk", strings.NewReader(`{"filter": {"types": ["site"]}}`))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.MemberBulkApprovalResult
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, actual.Requested)
+	assert.Equal(t, 1, actual.Succeeded)
+	assert.Equal(t, []dtos.MemberBulkApprovalMember{{MemberId: 4, Status: "approved"}}, actual.Results)
+	assert.Equal(t, []string{}, actual.DefaultRoles)
+}
+
+func TestMemberController_approveMembers_대상이_없는_경우(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/members/approve/bulk", strings.NewReader(`{}`))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_getPasswordHashReport_로그인할_때_다시_저장(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	passwordHashing := config.Config.PasswordHashing
+	defer func() { config.Config.PasswordHashing = passwordHashing }()
+
+	getReport := func() dtos.PasswordHashReport {
+		req := httptest.NewRequest(http.MethodGet, "/api/members/password-hashes", nil)
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          1,
+			"Permissions": []string{"MANAGE_SYSTEM_SETTINGS"},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var report dtos.PasswordHashReport
+		json.Unmarshal(rec.Body.Bytes(), &report)
+		return report
+	}
+	signIn := func(signId string) {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(fmt.Sprintf(`{"id": "%s", "password": "123456"}`, signId)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// given
+	// 기본 데이터의 비밀번호는 설정(bcrypt, 10)보다 낮은 비용(4)으로 저장되어 있다.
+	report := getReport()
+	assert.Equal(t, "bcrypt", report.Algorithm)
+	assert.Equal(t, 3, report.TotalCount)
+	assert.Equal(t, 3, report.LegacyCount)
+
+	// when
+	config.Config.PasswordHashing.Algorithm = "argon2id"
+	config.Config.PasswordHashing.Argon2id.MemoryKiB = 1024
+	config.Config.PasswordHashing.Argon2id.Iterations = 1
+	config.Config.PasswordHashing.Argon2id.Parallelism = 1
+	signIn("siteadm")
+
+	// then
+	var password string
+	gormDB.Table("members").Select("password").Where("sign_id = ?", "siteadm").Scan(&password)
+	assert.True(t, strings.HasPrefix(password, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	report = getReport()
+	assert.Equal(t, 2, report.LegacyCount)
+	assert.Equal(t, []dtos.PasswordHashAlgorithmCount{
+		{Algorithm: "argon2id", Count: 1, LegacyCount: 0},
+		{Algorithm: "bcrypt", Count: 2, LegacyCount: 2},
+	}, report.Algorithms)
+
+	// 다시 저장한 비밀번호로 로그인할 수 있다.
+	signIn("siteadm")
+}
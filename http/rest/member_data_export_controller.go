@@ -0,0 +1,56 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+type MemberDataExportController struct {
+	routerGroup             *gin.RouterGroup
+	memberDataExportService *services.MemberDataExportService
+}
+
+func NewMemberDataExportController(
+	routerGroup *gin.RouterGroup,
+	memberDataExportService *services.MemberDataExportService) *MemberDataExportController {
+
+	return &MemberDataExportController{
+		routerGroup:             routerGroup,
+		memberDataExportService: memberDataExportService,
+	}
+}
+
+func (c MemberDataExportController) MapRoutes() {
+	c.routerGroup.GET("/members/:id/data-export", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.exportMemberData)
+}
+
+// exportMemberData 는 멤버 정보와 메모, 첨부 파일을 zip 파일로 내려받는다.
+func (c MemberDataExportController) exportMemberData(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fileName, content, err := c.memberDataExportService.ExportMemberData(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/zip", content)
+}
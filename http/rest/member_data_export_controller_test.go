@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"archive/zip"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/testdata/testdb"
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestMemberDataExportController_exportMemberData(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// given
+	noteFileId := uploadTestAttachment(t, 1, "consent.png", testPngContent)
+	rec := requestTestNote(http.MethodPost, "/api/members/3/notes",
+		strings.NewReader(fmt.Sprintf(`{"content": "본인 확인 동의서", "attachmentFileIds": [%v]}`, noteFileId)), 1)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// 승인 요청(Id: 1)은 멤버 3 의 역할 할당 요청이다.
+	approvalFileId := uploadTestAttachment(t, 2, "id-card.pdf", []byte("%PDF-1.4\n신분증 사본"))
+	rec = requestTestApprovalAttachment(http.MethodPost, "/api/approvals/1/attachments", fmt.Sprintf(`{"fileIds": [%v]}`, approvalFileId),
+		map[string]interface{}{"Id": 2, "Permissions": []string{}})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// when
+	rec = requestTestNote(http.MethodGet, "/api/members/3/data-export", nil, 1)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+
+	reader, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	assert.NoError(t, err)
+
+	names := make([]string, 0)
+	contents := map[string]string{}
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+		content, _ := file.Open()
+		data, _ := io.ReadAll(content)
+		contents[file.Name] = string(data)
+	}
+	sort.Strings(names)
+
+	assert.Equal(t, 4, len(names))
+	assert.True(t, strings.HasPrefix(names[0], "attachments/approval/1/"))
+	assert.True(t, strings.HasSuffix(names[0], "-id-card.pdf"))
+	assert.True(t, strings.HasPrefix(names[1], "attachments/note/"))
+	assert.Equal(t, []string{"member.json", "notes.json"}, names[2:])
+	assert.Contains(t, contents["member.json"], `"signId": "ymyoo"`)
+	assert.Contains(t, contents["notes.json"], "본인 확인 동의서")
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = 3", constants.AuditActionMemberDataExported).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestMemberDataExportController_없는_멤버(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestTestNote(http.MethodGet, "/api/members/999/data-export", nil, 1)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
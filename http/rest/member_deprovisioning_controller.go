@@ -0,0 +1,100 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+type MemberDeprovisioningController struct {
+	routerGroup                 *gin.RouterGroup
+	memberDeprovisioningService *services.MemberDeprovisioningService
+}
+
+func NewMemberDeprovisioningController(
+	routerGroup *gin.RouterGroup,
+	memberDeprovisioningService *services.MemberDeprovisioningService) *MemberDeprovisioningController {
+
+	return &MemberDeprovisioningController{
+		routerGroup:                 routerGroup,
+		memberDeprovisioningService: memberDeprovisioningService,
+	}
+}
+
+func (c MemberDeprovisioningController) MapRoutes() {
+	route := c.routerGroup.Group("/members")
+	route.POST("/:id/deprovisioning", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.deprovisionMember)
+	route.GET("/:id/deprovisionings", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.getDeprovisionings)
+}
+
+// deprovisionMember 는 해지 체크리스트를 실행하고 완료 보고서를 응답한다.
+func (c MemberDeprovisioningController) deprovisionMember(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var request dtos.MemberDeprovisioningRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.memberDeprovisioningService.DeprovisionMember(ctx.Request.Context(), uint(memberId), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToReport())
+}
+
+func (c MemberDeprovisioningController) getDeprovisionings(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entities, err := c.memberDeprovisioningService.GetDeprovisionings(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	reports := make([]dtos.MemberDeprovisioningReport, 0)
+	for _, entity := range entities {
+		reports = append(reports, entity.ToReport())
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+func (MemberDeprovisioningController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	var invalidDeprovisioning *errors.ErrInvalidDeprovisioning
+	if pkgerrors.As(err, &invalidDeprovisioning) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if handleSuperAdminProtectionError(ctx, err) {
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestTestDeprovisioning(method, url string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, body)
+	req.Header.Set("Content-Type", "application/json")
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(2))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMemberDeprovisioningController_deprovisionMember(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	webHookSender := &fakeWebHookSender{}
+	adapters.OutgoingWebHookAdapter().SetSender(webHookSender)
+	defer adapters.OutgoingWebHookAdapter().SetSender(nil)
+	config.Config.Deprovisioning.WebHookUrls = []string{"https://hr.bettercode.kr/deprovisioning"}
+	defer func() { config.Config.Deprovisioning.WebHookUrls = nil }()
+
+	// when
+	rec := requestTestDeprovisioning(http.MethodPost, "/api/members/1/deprovisioning",
+		strings.NewReader(`{"successorId": 3, "reason": "퇴사"}`))
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var report dtos.MemberDeprovisioningReport
+	json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.Equal(t, constants.DeprovisioningStatusCompleted, report.Status)
+	assert.Equal(t, uint(3), report.SuccessorId)
+	assert.Equal(t, uint(2), report.RequestedBy)
+	assert.NotNil(t, report.CompletedAt)
+
+	var stepNames []string
+	for _, step := range report.Steps {
+		stepNames = append(stepNames, step.Name)
+	}
+	assert.Equal(t, []string{
+		constants.DeprovisioningStepDeactivate,
+		constants.DeprovisioningStepRevokeSessions,
+		constants.DeprovisioningStepRevokeApiKeys,
+		constants.DeprovisioningStepRemoveRoles,
+		constants.DeprovisioningStepTransferResources,
+		constants.DeprovisioningStepNotifyIntegrations,
+	}, stepNames)
+	assert.Equal(t, constants.DeprovisioningStepStatusDone, report.Steps[2].Status)
+	assert.Equal(t, 6, len(report.TransferredResources))
+
+	var status string
+	gormDB.Table("members").Select("status").Where("id = ?", 1).Scan(&status)
+	assert.Equal(t, constants.StatusMemberDeprovisioned, status)
+
+	var ownedCount int64
+	gormDB.Table("web_hooks").Where("owner_id = ?", 3).Count(&ownedCount)
+	assert.Equal(t, int64(3), ownedCount)
+	gormDB.Table("reports").Where("owner_id = ?", 3).Count(&ownedCount)
+	assert.Equal(t, int64(1), ownedCount)
+
+	var apiKeyHash string
+	gormDB.Table("service_accounts").Select("api_key_hash").Where("id = ? AND owner_id = ?", 1, 3).Scan(&apiKeyHash)
+	assert.Equal(t, "", apiKeyHash)
+
+	var roleCount int64
+	gormDB.Table("member_roles").Where("member_entity_id = ?", 1).Count(&roleCount)
+	assert.Equal(t, int64(0), roleCount)
+
+	// 연동 시스템에 보고서를 보낸다.
+	assert.Equal(t, 1, len(webHookSender.requests))
+	assert.Equal(t, "https://hr.bettercode.kr/deprovisioning", webHookSender.requests[0].Url)
+	assert.Contains(t, string(webHookSender.requests[0].Body), `"memberId":1`)
+
+	// 해지한 멤버는 다시 해지할 수 없다.
+	rec = requestTestDeprovisioning(http.MethodPost, "/api/members/1/deprovisioning", strings.NewReader(`{"successorId": 3}`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestTestDeprovisioning(http.MethodGet, "/api/members/1/deprovisionings", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var reports []dtos.MemberDeprovisioningReport
+	json.Unmarshal(rec.Body.Bytes(), &reports)
+	assert.Equal(t, 1, len(reports))
+	assert.Equal(t, report.Id, reports[0].Id)
+}
+
+func TestMemberDeprovisioningController_deprovisionMember_후임자_확인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	// 승인되지 않은 멤버(4)는 후임자가 될 수 없다.
+	rec := requestTestDeprovisioning(http.MethodPost, "/api/members/1/deprovisioning", strings.NewReader(`{"successorId": 4}`))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_DEPROVISIONING")
+
+	var status string
+	gormDB.Table("members").Select("status").Where("id = ?", 1).Scan(&status)
+	assert.Equal(t, constants.StatusMemberApproved, status)
+}
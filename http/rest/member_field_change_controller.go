@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+type MemberFieldChangeController struct {
+	routerGroup              *gin.RouterGroup
+	memberFieldChangeService *services.MemberFieldChangeService
+}
+
+func NewMemberFieldChangeController(
+	routerGroup *gin.RouterGroup,
+	memberFieldChangeService *services.MemberFieldChangeService) *MemberFieldChangeController {
+
+	return &MemberFieldChangeController{
+		routerGroup:              routerGroup,
+		memberFieldChangeService: memberFieldChangeService,
+	}
+}
+
+func (c MemberFieldChangeController) MapRoutes() {
+	route := c.routerGroup.Group("/members")
+	route.POST("/:id/field-changes", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.requestFieldChange)
+	route.GET("/:id/field-changes", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		etag.HttpEtagCache(0),
+		c.getFieldChanges)
+	// 승인자는 승인 요청(subject: member-field-change)의 targetId 로 변경 전후 값을 확인한다.
+	route.GET("/field-changes/:id/diff", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		etag.HttpEtagCache(0),
+		c.getFieldChangeDiff)
+}
+
+// requestFieldChange 는 승인을 기다리면 202, 승인 절차가 없어 바로 반영되면 200 으로 변경 내용을 응답한다.
+func (c MemberFieldChangeController) requestFieldChange(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var request dtos.MemberFieldChangeRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.memberFieldChangeService.RequestFieldChange(ctx.Request.Context(), uint(memberId), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	if entity.IsPending() {
+		ctx.JSON(http.StatusAccepted, entity.ToInformation())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c MemberFieldChangeController) getFieldChanges(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entities, err := c.memberFieldChangeService.GetMemberFieldChanges(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	fieldChanges := make([]dtos.MemberFieldChangeInformation, 0)
+	for _, entity := range entities {
+		fieldChanges = append(fieldChanges, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, fieldChanges)
+}
+
+func (c MemberFieldChangeController) getFieldChangeDiff(ctx *gin.Context) {
+	fieldChangeId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.memberFieldChangeService.GetFieldChange(ctx.Request.Context(), uint(fieldChangeId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToDiff())
+}
+
+func (MemberFieldChangeController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrDuplicated) || errors.Is(err, errors.ErrApprovalInProgress) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	var invalidMemberFieldChange *errors.ErrInvalidMemberFieldChange
+	if pkgerrors.As(err, &invalidMemberFieldChange) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setUpMemberFieldChangeApprovalWorkflow(t *testing.T) {
+	requestBody := `{
+		"workflows": [{
+			"subject": "member-field-change",
+			"steps": [
+				{"name": "멤버 관리자 승인", "approverRoleName": "MEMBER MANAGER", "requiredApprovals": 1}
+			]
+		}]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/approval-workflow", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func requestMemberFieldChange(memberId uint, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/members/%v/field-changes", memberId), strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func getMemberFieldChangeDiff(id uint) dtos.MemberFieldChangeDiff {
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/members/field-changes/%v/diff", id), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	var diff dtos.MemberFieldChangeDiff
+	json.Unmarshal(rec.Body.Bytes(), &diff)
+	return diff
+}
+
+func TestMemberFieldChangeController_아이디_변경_승인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpMemberFieldChangeApprovalWorkflow(t)
+
+	// when
+	rec := requestMemberFieldChange(3, `{"field": "sign-id", "value": "ymyoo-new"}`)
+
+	// then
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var fieldChange dtos.MemberFieldChangeInformation
+	json.Unmarshal(rec.Body.Bytes(), &fieldChange)
+	assert.Equal(t, constants.MemberFieldChangeStatusPending, fieldChange.Status)
+
+	// 승인 전에는 아이디가 바뀌지 않는다.
+	var signId string
+	gormDB.Raw("SELECT sign_id FROM members WHERE id = 3").Scan(&signId)
+	assert.Equal(t, "ymyoo", signId)
+
+	diff := getMemberFieldChangeDiff(fieldChange.Id)
+	assert.Equal(t, constants.MemberFieldSignId, diff.Field)
+	assert.Equal(t, "ymyoo", diff.OldValue)
+	assert.Equal(t, "ymyoo-new", diff.NewValue)
+
+	// 같은 필드의 변경이 승인을 기다리는 동안에는 다시 요청할 수 없다.
+	rec = requestMemberFieldChange(3, `{"field": "sign-id", "value": "ymyoo-other"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'member-field-change' AND target_id = ?", fieldChange.Id).Scan(&approvalId)
+	assert.NotZero(t, approvalId)
+
+	// 요청한 관리자는 승인할 수 없다.
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          1,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SELF_REVIEW")
+
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	gormDB.Raw("SELECT sign_id FROM members WHERE id = 3").Scan(&signId)
+	assert.Equal(t, "ymyoo-new", signId)
+
+	var status string
+	gormDB.Raw("SELECT status FROM member_field_changes WHERE id = ?", fieldChange.Id).Scan(&status)
+	assert.Equal(t, constants.MemberFieldChangeStatusApplied, status)
+}
+
+func TestMemberFieldChangeController_승인_절차가_없으면_바로_반영(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestMemberFieldChange(2, `{"field": "email", "value": "dooray@example.com"}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var email string
+	gormDB.Raw("SELECT email FROM members WHERE id = 2").Scan(&email)
+	assert.Equal(t, "dooray@example.com", email)
+
+	// 두레이 멤버는 아이디를 변경할 수 없다.
+	rec = requestMemberFieldChange(2, `{"field": "sign-id", "value": "dooray"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_MEMBER_FIELD_CHANGE")
+
+	rec = requestMemberFieldChange(3, `{"field": "email", "value": "not-email"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberFieldChangeController_최고_관리자_역할_변경(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpMemberFieldChangeApprovalWorkflow(t)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/members/1/assign-roles", strings.NewReader(`{"roleIds": [3]}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(2))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var fieldChange dtos.MemberFieldChangeInformation
+	json.Unmarshal(rec.Body.Bytes(), &fieldChange)
+
+	diff := getMemberFieldChangeDiff(fieldChange.Id)
+	assert.Equal(t, constants.MemberFieldRoles, diff.Field)
+	assert.Equal(t, []string{"테스트 관리자"}, diff.AddedRoles)
+	assert.Equal(t, []string{"SYSTEM MANAGER"}, diff.RemovedRoles)
+
+	// 승인 전에는 역할이 바뀌지 않는다.
+	var roleId uint
+	gormDB.Raw("SELECT role_entity_id FROM member_roles WHERE member_entity_id = 1").Scan(&roleId)
+	assert.Equal(t, uint(1), roleId)
+
+	// 최고 관리자와 관련 없는 역할 할당은 중요 필드 변경이 아니다.
+	req = httptest.NewRequest(http.MethodPut, "/api/members/3/assign-roles", strings.NewReader(`{"roleIds": [3]}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestMemberFieldChangeController_승인되지_않은_변경_만료(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpMemberFieldChangeApprovalWorkflow(t)
+
+	rec := requestMemberFieldChange(3, `{"field": "email", "value": "ymyoo@example.com"}`)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var fieldChange dtos.MemberFieldChangeInformation
+	json.Unmarshal(rec.Body.Bytes(), &fieldChange)
+	gormDB.Exec("UPDATE member_field_changes SET expires_at = ? WHERE id = ?", time.Now().Add(-time.Minute), fieldChange.Id)
+
+	// when
+	err := NewContainer().MemberFieldChangeService.ExpireFieldChanges(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.NoError(t, err)
+
+	var status string
+	gormDB.Raw("SELECT status FROM member_field_changes WHERE id = ?", fieldChange.Id).Scan(&status)
+	assert.Equal(t, constants.MemberFieldChangeStatusExpired, status)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'member-field-change' AND target_id = ?", fieldChange.Id).Scan(&approvalId)
+	gormDB.Raw("SELECT status FROM approval_requests WHERE id = ?", approvalId).Scan(&status)
+	assert.Equal(t, constants.ApprovalStatusExpired, status)
+
+	// 만료된 요청은 승인할 수 없다.
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var email string
+	gormDB.Raw("SELECT email FROM members WHERE id = 3").Scan(&email)
+	assert.Equal(t, "", email)
+}
@@ -0,0 +1,214 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type MemberIdentityController struct {
+	routerGroup           *gin.RouterGroup
+	memberIdentityService *services.MemberIdentityService
+	authService           *services.AuthService
+}
+
+func NewMemberIdentityController(
+	routerGroup *gin.RouterGroup,
+	memberIdentityService *services.MemberIdentityService,
+	authService *services.AuthService) *MemberIdentityController {
+
+	return &MemberIdentityController{
+		routerGroup:           routerGroup,
+		memberIdentityService: memberIdentityService,
+		authService:           authService,
+	}
+}
+
+func (c MemberIdentityController) MapRoutes() {
+	route := c.routerGroup.Group("/members")
+	route.GET("/me/identities", middlewares.PermissionChecker([]string{"*"}),
+		c.getMyIdentities)
+	route.POST("/me/identities/dooray", middlewares.PermissionChecker([]string{"*"}),
+		c.linkDoorayIdentity)
+	route.POST("/me/identities/custom/:name", middlewares.PermissionChecker([]string{"*"}),
+		c.linkCustomIdentity)
+	route.GET("/me/identities/google-workspace/start", middlewares.PermissionChecker([]string{"*"}),
+		c.startGoogleWorkspaceLink)
+	route.DELETE("/me/identities/:identityId", middlewares.PermissionChecker([]string{"*"}),
+		c.unlinkMyIdentity)
+	route.GET("/:id/identities", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.getIdentities)
+	route.DELETE("/:id/identities/:identityId", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.unlinkIdentity)
+}
+
+func (c MemberIdentityController) getMyIdentities(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	// 서비스 계정의 Id 는 멤버 Id 와 겹칠 수 있으므로 멤버의 계정으로 다루지 않는다.
+	if userClaim.IsServiceAccount() {
+		c.handleError(ctx, errors.ErrAuthentication)
+		return
+	}
+
+	identities, err := c.memberIdentityService.GetIdentities(ctx.Request.Context(), userClaim.Id)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, identities)
+}
+
+// linkDoorayIdentity 는 두레이 아이디와 비밀번호로 확인한 두레이 계정을 연결한다.
+func (c MemberIdentityController) linkDoorayIdentity(ctx *gin.Context) {
+	var signIn dtos.MemberSignIn
+	if err := ctx.BindJSON(&signIn); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.authService.LinkDoorayIdentity(ctx.Request.Context(), signIn)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+// linkCustomIdentity 는 Authenticator(name)로 확인한 계정을 연결한다.
+func (c MemberIdentityController) linkCustomIdentity(ctx *gin.Context) {
+	var credentials map[string]string
+	if err := ctx.BindJSON(&credentials); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.authService.LinkCustomIdentity(ctx.Request.Context(), ctx.Param("name"), credentials)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+// startGoogleWorkspaceLink 는 구글 계정을 연결할 구글 로그인 화면 주소를 응답한다. 연결한 뒤 redirect 로 돌아간다.
+func (c MemberIdentityController) startGoogleWorkspaceLink(ctx *gin.Context) {
+	oauthUri, err := c.authService.StartGoogleWorkspaceLink(ctx.Request.Context(), ctx.DefaultQuery("redirect", "/"))
+	if err != nil {
+		if errors.Is(err, errors.ErrInvalidTarget) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: "redirect is not allowed"})
+			return
+		}
+
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.GoogleIdentityLinkStart{Url: oauthUri})
+}
+
+func (c MemberIdentityController) unlinkMyIdentity(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	// 서비스 계정의 Id 는 멤버 Id 와 겹칠 수 있으므로 멤버의 계정으로 다루지 않는다.
+	if userClaim.IsServiceAccount() {
+		c.handleError(ctx, errors.ErrAuthentication)
+		return
+	}
+
+	identityId, err := strconv.ParseInt(ctx.Param("identityId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.memberIdentityService.UnlinkIdentity(ctx.Request.Context(), userClaim.Id, uint(identityId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberIdentityController) getIdentities(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	identities, err := c.memberIdentityService.GetIdentities(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, identities)
+}
+
+func (c MemberIdentityController) unlinkIdentity(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	identityId, err := strconv.ParseInt(ctx.Param("identityId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.memberIdentityService.UnlinkIdentity(ctx.Request.Context(), uint(memberId), uint(identityId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (MemberIdentityController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrUnApproved) {
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrDuplicated) {
+		ctx.JSON(http.StatusConflict, dtos.ErrorMessage{Code: errors.Code(err), Message: "identity is already linked to a member"})
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidGoogleWorkspaceAccount); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
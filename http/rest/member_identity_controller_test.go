@@ -0,0 +1,204 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getTestMemberIdentities(t *testing.T, url string, memberId int, permissions []string) []dtos.MemberIdentityInformation {
+	rec := requestTestExport(http.MethodGet, url, "", memberId, permissions)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var identities []dtos.MemberIdentityInformation
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &identities))
+	return identities
+}
+
+func TestMemberIdentityController_계정_연결과_해제(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-sso", fakeAuthenticator{})
+
+	// given
+	rec := requestTestExport(http.MethodPost, "/api/members/me/identities/custom/test-sso",
+		`{"username": "kim", "otp": "123456"}`, 3, []string{})
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var linked dtos.MemberIdentityInformation
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &linked))
+	assert.Equal(t, "custom:test-sso", linked.Provider)
+	assert.Equal(t, "emp-1", linked.ExternalId)
+	assert.False(t, linked.Primary)
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = 3", constants.AuditActionMemberIdentityLinked).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+
+	// 연결한 계정으로 로그인하면 새로 가입하지 않고 연결한 멤버로 로그인한다.
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/custom/test-sso", strings.NewReader(`{"username": "kim", "otp": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	loginRec := httptest.NewRecorder()
+	ginApp.ServeHTTP(loginRec, req)
+	assert.Equal(t, http.StatusOK, loginRec.Code)
+
+	var memberCount int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("authenticator_name = ? AND external_id = ?", "test-sso", "emp-1").Count(&memberCount)
+	assert.Equal(t, int64(0), memberCount)
+
+	identities := getTestMemberIdentities(t, "/api/members/me/identities", 3, []string{})
+	assert.Len(t, identities, 2)
+	assert.True(t, identities[0].Primary)
+	assert.Equal(t, "site", identities[0].Provider)
+	assert.Equal(t, "ymyoo", identities[0].ExternalId)
+	assert.Equal(t, linked.Id, identities[1].Id)
+	assert.NotNil(t, identities[1].LastUsedAt)
+
+	// 관리자는 멤버가 연결한 계정을 조회한다.
+	identities = getTestMemberIdentities(t, "/api/members/3/identities", 1, []string{constants.PermissionManageMembers})
+	assert.Len(t, identities, 2)
+
+	// 다른 멤버가 연결한 계정은 연결할 수 없다.
+	rec = requestTestExport(http.MethodPost, "/api/members/me/identities/custom/test-sso",
+		`{"username": "kim", "otp": "123456"}`, 2, []string{})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), errors.Code(errors.ErrDuplicated))
+
+	// 다른 멤버의 계정은 연결을 해제할 수 없다.
+	rec = requestTestExport(http.MethodDelete, fmt.Sprintf("/api/members/me/identities/%v", linked.Id), "", 2, []string{})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// when
+	rec = requestTestExport(http.MethodDelete, fmt.Sprintf("/api/members/me/identities/%v", linked.Id), "", 3, []string{})
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	identities = getTestMemberIdentities(t, "/api/members/me/identities", 3, []string{})
+	assert.Len(t, identities, 1)
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = 3", constants.AuditActionMemberIdentityUnlinked).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestMemberIdentityController_계정_연결_실패(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-sso", fakeAuthenticator{})
+
+	testCases := []struct {
+		name         string
+		url          string
+		body         string
+		memberId     int
+		expectedCode int
+	}{
+		{name: "인증 실패", url: "/api/members/me/identities/custom/test-sso", body: `{"username": "kim", "otp": "000000"}`, memberId: 3, expectedCode: http.StatusUnauthorized},
+		{name: "없는 Authenticator", url: "/api/members/me/identities/custom/not-supported", body: `{"username": "kim", "otp": "123456"}`, memberId: 3, expectedCode: http.StatusNotFound},
+		{name: "승인하지 않은 멤버", url: "/api/members/me/identities/custom/test-sso", body: `{"username": "kim", "otp": "123456"}`, memberId: 4, expectedCode: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// when
+			rec := requestTestExport(http.MethodPost, testCase.url, testCase.body, testCase.memberId, []string{})
+
+			// then
+			assert.Equal(t, testCase.expectedCode, rec.Code)
+		})
+	}
+
+	var identityCount int64
+	gormDB.Model(&memberDomain.MemberIdentityEntity{}).Count(&identityCount)
+	assert.Equal(t, int64(0), identityCount)
+}
+
+// requestAsServiceAccount 는 서비스 계정(serviceAccountId)의 토큰으로 요청한다.
+func requestAsServiceAccount(method, url string, requestBody string, serviceAccountId int, permissions []string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":            serviceAccountId,
+		"Permissions":   permissions,
+		"PrincipalType": security.PrincipalTypeServiceAccount,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMemberIdentityController_서비스_계정은_연결할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-sso", fakeAuthenticator{})
+
+	// when
+	// 서비스 계정의 Id(1)는 멤버(1, 최고 관리자)의 Id 와 같다.
+	rec := requestAsServiceAccount(http.MethodPost, "/api/members/me/identities/custom/test-sso",
+		`{"username": "kim", "otp": "123456"}`, 1, []string{})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	var identityCount int64
+	gormDB.Model(&memberDomain.MemberIdentityEntity{}).Count(&identityCount)
+	assert.Equal(t, int64(0), identityCount)
+
+	rec = requestAsServiceAccount(http.MethodGet, "/api/members/me/identities/google-workspace/start", "", 1, []string{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = requestAsServiceAccount(http.MethodGet, "/api/members/me/identities", "", 1, []string{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMemberIdentityController_관리자_권한(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestTestExport(http.MethodGet, "/api/members/3/identities", "", 2, []string{})
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func Test_authWithGoogleWorkspaceAccount_계정_연결_상태(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	jwtAuthentication := security.JwtAuthentication{}
+
+	// 계정 연결 상태 토큰은 로그인 상태 토큰으로 사용할 수 없다.
+	linkState, err := jwtAuthentication.GenerateOAuthLinkStateToken(constants.LoginMethodGoogleWorkspace, 3, "/members/me", time.Minute)
+	assert.NoError(t, err)
+	_, err = jwtAuthentication.ConvertOAuthStateToken(linkState, constants.LoginMethodGoogleWorkspace)
+	assert.Equal(t, security.InvalidOAuthState, err)
+
+	loginState, err := jwtAuthentication.GenerateOAuthStateToken(constants.LoginMethodGoogleWorkspace, "/", time.Minute)
+	assert.NoError(t, err)
+	_, _, err = jwtAuthentication.ConvertOAuthLinkStateToken(loginState, constants.LoginMethodGoogleWorkspace)
+	assert.Equal(t, security.InvalidOAuthState, err)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace?code=test-code&state="+url.QueryEscape(linkState), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// 구글 로그인을 설정하지 않았으므로 연결에 실패하고 연결을 시작한 화면으로 돌아간다.
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "/members/me?error=server-internal-error", rec.Header().Get("Location"))
+}
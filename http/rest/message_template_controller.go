@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type MessageTemplateController struct {
+	routerGroup            *gin.RouterGroup
+	messageTemplateService *services.MessageTemplateService
+}
+
+func NewMessageTemplateController(
+	routerGroup *gin.RouterGroup,
+	messageTemplateService *services.MessageTemplateService) *MessageTemplateController {
+
+	return &MessageTemplateController{
+		routerGroup:            routerGroup,
+		messageTemplateService: messageTemplateService,
+	}
+}
+
+func (c MessageTemplateController) MapRoutes() {
+	route := c.routerGroup.Group("/message-templates")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getMessageTemplates)
+	route.GET("/:name", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getMessageTemplate)
+	route.PUT("/:name", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.updateMessageTemplate)
+	route.DELETE("/:name", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.resetMessageTemplate)
+	route.POST("/:name/preview", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.previewMessageTemplate)
+}
+
+func (c MessageTemplateController) getMessageTemplates(ctx *gin.Context) {
+	templates, err := c.messageTemplateService.GetMessageTemplates(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, templates)
+}
+
+func (c MessageTemplateController) getMessageTemplate(ctx *gin.Context) {
+	template, err := c.messageTemplateService.GetMessageTemplate(ctx.Request.Context(), ctx.Param("name"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+func (c MessageTemplateController) updateMessageTemplate(ctx *gin.Context) {
+	var information dtos.MessageTemplateInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	template, err := c.messageTemplateService.UpdateMessageTemplate(ctx.Request.Context(), ctx.Param("name"), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, template)
+}
+
+// resetMessageTemplate 은 바꾼 템플릿을 지워 내장 템플릿으로 되돌린다.
+func (c MessageTemplateController) resetMessageTemplate(ctx *gin.Context) {
+	if err := c.messageTemplateService.ResetMessageTemplate(ctx.Request.Context(), ctx.Param("name")); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MessageTemplateController) previewMessageTemplate(ctx *gin.Context) {
+	var request dtos.MessageTemplatePreviewRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	preview, err := c.messageTemplateService.PreviewMessageTemplate(ctx.Request.Context(), ctx.Param("name"), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preview)
+}
+
+func (MessageTemplateController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidMessageTemplate); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
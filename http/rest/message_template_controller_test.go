@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestMessageTemplate(method, url string, requestBody string, permissions []string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMessageTemplateController_템플릿_목록(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestMessageTemplate(http.MethodGet, "/api/message-templates", "", []string{constants.PermissionManageSystemSettings})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var templates []dtos.MessageTemplate
+	json.Unmarshal(rec.Body.Bytes(), &templates)
+	assert.Equal(t, 16, len(templates))
+	assert.Equal(t, constants.MessageTemplateApprovalRequested, templates[0].Name)
+	assert.Equal(t, false, templates[0].Customized)
+	assert.Equal(t, templates[0].DefaultSubject, templates[0].Subject)
+	assert.Equal(t, 4, len(templates[0].Variables))
+	assert.Equal(t, "title", templates[0].Variables[0].Name)
+
+	rec = requestMessageTemplate(http.MethodGet, "/api/message-templates", "", []string{constants.PermissionManageMembers})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = requestMessageTemplate(http.MethodGet, "/api/message-templates/unknown", "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMessageTemplateController_템플릿_변경_후_삭제하면_내장_템플릿(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+
+	// given
+	rec := requestMessageTemplate(http.MethodPut, "/api/message-templates/"+constants.MessageTemplateSignIdChangeConfirm, `{
+		"subject": "[Admin] {{.newSignId}} 확인",
+		"body": "{{if .confirmUrl}}링크: {{.confirmUrl}}{{else}}링크 없음{{end}}\n기한: {{.expiresAt}}"
+	}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var template dtos.MessageTemplate
+	json.Unmarshal(rec.Body.Bytes(), &template)
+	assert.Equal(t, true, template.Customized)
+	assert.Equal(t, "[Admin] {{.newSignId}} 확인", template.Subject)
+	assert.Equal(t, "[Better Admin] 아이디 변경 확인", template.DefaultSubject)
+
+	// when
+	rec = requestMessageTemplate(http.MethodPost, "/api/members/me/sign-id-change", `{
+		"newSignId": "siteadm@bettercode.kr",
+		"password": "123456"
+	}`, []string{})
+
+	// then
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 1, len(mailSender.messages))
+	assert.Equal(t, "[Admin] siteadm@bettercode.kr 확인", mailSender.messages[0].Subject)
+	assert.True(t, strings.HasPrefix(mailSender.messages[0].Body, "링크: "))
+
+	rec = requestMessageTemplate(http.MethodDelete, "/api/message-templates/"+constants.MessageTemplateSignIdChangeConfirm, "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestMessageTemplate(http.MethodGet, "/api/message-templates/"+constants.MessageTemplateSignIdChangeConfirm, "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &template)
+	assert.Equal(t, false, template.Customized)
+	assert.Equal(t, "[Better Admin] 아이디 변경 확인", template.Subject)
+
+	// 바꾼 템플릿이 없으면 지울 수 없다.
+	rec = requestMessageTemplate(http.MethodDelete, "/api/message-templates/"+constants.MessageTemplateSignIdChangeConfirm, "", []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var count int64
+	gormDB.Table("audit_logs").Where("action IN ?", []string{constants.AuditActionMessageTemplateChanged, constants.AuditActionMessageTemplateReset}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestMessageTemplateController_지원하지_않는_템플릿_문법(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	bodies := []string{
+		`{{.unknown}}`,
+		`{{printf \"%s\" .newSignId}}`,
+		`{{range .newSignId}}x{{end}}`,
+		`{{define \"other\"}}x{{end}}{{.newSignId}}`,
+		`{{$x := .newSignId}}{{$x}}`,
+		`{{.newSignId`,
+	}
+
+	for _, body := range bodies {
+		// when
+		rec := requestMessageTemplate(http.MethodPut, "/api/message-templates/"+constants.MessageTemplateSignIdChanged,
+			fmt.Sprintf(`{"subject": "아이디 변경", "body": "%s"}`, body), []string{constants.PermissionManageSystemSettings})
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		var errorMessage dtos.ErrorMessage
+		json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+		assert.Equal(t, "INVALID_MESSAGE_TEMPLATE", errorMessage.Code, body)
+	}
+
+	rec := requestMessageTemplate(http.MethodGet, "/api/message-templates/"+constants.MessageTemplateSignIdChanged, "", []string{constants.PermissionManageSystemSettings})
+	var template dtos.MessageTemplate
+	json.Unmarshal(rec.Body.Bytes(), &template)
+	assert.Equal(t, false, template.Customized)
+}
+
+func TestMessageTemplateController_미리보기(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestMessageTemplate(http.MethodPost, "/api/message-templates/"+constants.MessageTemplatePasswordReset+"/preview", `{}`,
+		[]string{constants.PermissionManageSystemSettings})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var preview dtos.MessageTemplatePreview
+	json.Unmarshal(rec.Body.Bytes(), &preview)
+	assert.Equal(t, "[Better Admin] 비밀번호 재설정", preview.Subject)
+	assert.True(t, strings.HasSuffix(preview.Body, "https://admin.example.com/password-reset?token=sample"))
+
+	// 저장하지 않은 템플릿을 요청한 값으로 렌더링한다.
+	rec = requestMessageTemplate(http.MethodPost, "/api/message-templates/"+constants.MessageTemplatePasswordReset+"/preview", `{
+		"body": "재설정: {{.resetUrl}}",
+		"data": {"resetUrl": "https://example.com/reset"}
+	}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &preview)
+	assert.Equal(t, "[Better Admin] 비밀번호 재설정", preview.Subject)
+	assert.Equal(t, "재설정: https://example.com/reset", preview.Body)
+
+	rec = requestMessageTemplate(http.MethodPost, "/api/message-templates/"+constants.MessageTemplatePasswordReset+"/preview", `{
+		"data": {"unknown": "value"}
+	}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestMessageTemplate(http.MethodGet, "/api/message-templates/"+constants.MessageTemplatePasswordReset, "", []string{constants.PermissionManageSystemSettings})
+	var template dtos.MessageTemplate
+	json.Unmarshal(rec.Body.Bytes(), &template)
+	assert.Equal(t, false, template.Customized)
+}
@@ -0,0 +1,41 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	"sync"
+)
+
+// Module 은 배포 환경에서 추가하는 컨트롤러(서비스)이다. 기본 컨트롤러를 등록한 뒤 등록한 순서대로 MapRoutes 를 호출한다.
+type Module struct {
+	Name      string
+	MapRoutes func(routerGroup *gin.RouterGroup, container *Container)
+}
+
+var (
+	moduleMutex sync.Mutex
+	modules     []Module
+)
+
+// RegisterModule 은 모듈을 등록한다. 같은 이름의 모듈이 있으면 교체하며 app.Run(SetUp) 전에 호출해야 한다.
+func RegisterModule(module Module) {
+	moduleMutex.Lock()
+	defer moduleMutex.Unlock()
+
+	for i := range modules {
+		if modules[i].Name == module.Name {
+			modules[i] = module
+			return
+		}
+	}
+	modules = append(modules, module)
+}
+
+func mapModuleRoutes(routerGroup *gin.RouterGroup, container *Container) {
+	moduleMutex.Lock()
+	registered := append([]Module{}, modules...)
+	moduleMutex.Unlock()
+
+	for _, module := range registered {
+		module.MapRoutes(routerGroup, container)
+	}
+}
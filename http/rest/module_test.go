@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testModule 은 init 에서 테스트 서버를 만들기 전에 등록한다.
+var testModule = Module{
+	Name: "test-module",
+	MapRoutes: func(routerGroup *gin.RouterGroup, container *Container) {
+		routerGroup.GET("/test-module/members/1", middlewares.PermissionChecker([]string{"*"}), func(ctx *gin.Context) {
+			member, err := container.MemberService.GetMemberById(ctx.Request.Context(), 1)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			ctx.JSON(http.StatusOK, map[string]string{"signId": member.SignId})
+		})
+		// 요청 제한 시간이 지날 때까지 기다린 뒤 DB 를 조회한다.
+		routerGroup.GET("/test-module/slow", middlewares.Public(), func(ctx *gin.Context) {
+			<-ctx.Request.Context().Done()
+
+			var count int64
+			if err := helpers.ContextHelper().GetDB(ctx.Request.Context()).Raw("SELECT count(*) FROM members").Scan(&count).Error; err != nil {
+				helpers.ErrorHelper().InternalServerError(ctx, err)
+				return
+			}
+
+			ctx.JSON(http.StatusOK, count)
+		})
+		routerGroup.POST("/test-module/echo", middlewares.Public(), func(ctx *gin.Context) {
+			body, err := io.ReadAll(ctx.Request.Body)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, err.Error())
+				return
+			}
+
+			ctx.JSON(http.StatusOK, len(body))
+		})
+	},
+}
+
+func TestRegisterModule(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/test-module/members/1", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]string
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "siteadm", actual["signId"])
+}
+
+func TestRequestTimeout_제한_시간_초과(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	routeGroups := config.Config.RequestTimeout.RouteGroups
+	defer func() { config.Config.RequestTimeout.RouteGroups = routeGroups }()
+	config.Config.RequestTimeout.RouteGroups = map[string]int{"/api/test-module": 1}
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/test-module/slow", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	startedAt := time.Now()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.Equal(t, "GATEWAY_TIMEOUT", rec.Header().Get(middlewares.ErrorCodeHeader))
+	assert.Less(t, time.Since(startedAt), 5*time.Second)
+}
+
+func TestRequestSizeLimit_최대_크기_초과(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	routeGroups := config.Config.RequestSize.RouteGroups
+	defer func() { config.Config.RequestSize.RouteGroups = routeGroups }()
+	config.Config.RequestSize.RouteGroups = map[string]int64{"/api/test-module": 10}
+
+	// when
+	req := httptest.NewRequest(http.MethodPost, "/api/test-module/echo", strings.NewReader("0123456789A"))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(t, "REQUEST_TOO_LARGE", rec.Header().Get(middlewares.ErrorCodeHeader))
+
+	// Content-Length 가 없는 요청도 읽은 크기로 확인한다.
+	req = httptest.NewRequest(http.MethodPost, "/api/test-module/echo", io.MultiReader(strings.NewReader("0123456789A")))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/test-module/echo", io.MultiReader(strings.NewReader("0123456789")))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "10", rec.Body.String())
+}
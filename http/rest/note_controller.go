@@ -0,0 +1,218 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+// noteEntityRoutes 는 메모를 남길 수 있는 대상(경로, 대상 유형, 권한)이다. 대상 유형은 NoteService 에 등록해야 한다.
+var noteEntityRoutes = []struct {
+	path       string
+	entityType string
+	permission string
+}{
+	{"/members", constants.AuditTargetTypeMember, constants.PermissionManageMembers},
+	{"/approvals", constants.AuditTargetTypeApproval, "*"},
+	{"/change-requests", constants.AuditTargetTypeChangeRequest, constants.PermissionManageSystemSettings},
+}
+
+type NoteController struct {
+	routerGroup *gin.RouterGroup
+	noteService *services.NoteService
+}
+
+func NewNoteController(
+	routerGroup *gin.RouterGroup,
+	noteService *services.NoteService) *NoteController {
+
+	return &NoteController{
+		routerGroup: routerGroup,
+		noteService: noteService,
+	}
+}
+
+func (c NoteController) MapRoutes() {
+	for _, route := range noteEntityRoutes {
+		c.routerGroup.GET(route.path+"/:id/notes", middlewares.PermissionChecker([]string{route.permission}),
+			etag.HttpEtagCache(0),
+			c.getNotes(route.entityType))
+		c.routerGroup.POST(route.path+"/:id/notes", middlewares.PermissionChecker([]string{route.permission}),
+			c.createNote(route.entityType))
+		c.routerGroup.PUT(route.path+"/:id/notes/:noteId", middlewares.PermissionChecker([]string{route.permission}),
+			c.updateNote(route.entityType))
+		c.routerGroup.DELETE(route.path+"/:id/notes/:noteId", middlewares.PermissionChecker([]string{route.permission}),
+			c.deleteNote(route.entityType))
+		c.routerGroup.GET(route.path+"/:id/notes/:noteId/attachments/:attachmentId/content", middlewares.PermissionChecker([]string{route.permission}),
+			c.getNoteAttachmentContent(route.entityType))
+	}
+}
+
+func (c NoteController) getNotes(entityType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		entityId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		notes, totalCount, err := c.noteService.GetNotes(ctx.Request.Context(), entityType, uint(entityId), dtos.NewPageableFromRequest(ctx))
+		if err != nil {
+			c.handleError(ctx, err)
+			return
+		}
+
+		pageResult := dtos.PageResult{
+			Result:     notes,
+			TotalCount: totalCount,
+		}
+
+		ctx.JSON(http.StatusOK, pageResult)
+	}
+}
+
+func (c NoteController) createNote(entityType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		entityId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var information dtos.NoteInformation
+		if err := ctx.BindJSON(&information); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		note, err := c.noteService.CreateNote(ctx.Request.Context(), entityType, uint(entityId), information)
+		if err != nil {
+			c.handleError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, note)
+	}
+}
+
+func (c NoteController) updateNote(entityType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		entityId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		noteId, err := strconv.ParseInt(ctx.Param("noteId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var information dtos.NoteInformation
+		if err := ctx.BindJSON(&information); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		note, err := c.noteService.UpdateNote(ctx.Request.Context(), entityType, uint(entityId), uint(noteId), information)
+		if err != nil {
+			c.handleError(ctx, err)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, note)
+	}
+}
+
+func (c NoteController) deleteNote(entityType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		entityId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		noteId, err := strconv.ParseInt(ctx.Param("noteId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err := c.noteService.DeleteNote(ctx.Request.Context(), entityType, uint(entityId), uint(noteId)); err != nil {
+			c.handleError(ctx, err)
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+func (c NoteController) getNoteAttachmentContent(entityType string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		entityId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		noteId, err := strconv.ParseInt(ctx.Param("noteId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		attachmentId, err := strconv.ParseInt(ctx.Param("attachmentId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		attachment, content, err := c.noteService.OpenNoteAttachment(ctx.Request.Context(), entityType, uint(entityId), uint(noteId), uint(attachmentId))
+		if err != nil {
+			c.handleError(ctx, err)
+			return
+		}
+		defer content.Close()
+
+		writeAttachmentContent(ctx, attachment, content)
+	}
+}
+
+func (NoteController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrForbidden) || errors.Is(err, errors.ErrQuarantined) {
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidFile); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: e.Error()})
+		return
+	}
+
+	var invalidNote *errors.ErrInvalidNote
+	if pkgerrors.As(err, &invalidNote) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
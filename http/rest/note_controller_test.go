@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestTestNote(method, url string, body io.Reader, memberId uint, permissions ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, body)
+	req.Header.Set("Content-Type", "application/json")
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": append([]string{constants.PermissionManageMembers}, permissions...),
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNoteController_멤버_메모_답글과_언급(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+	gormDB.Exec("UPDATE members SET external_mail = 'ymyoo@bettercode.kr' WHERE id = 3")
+	gormDB.Exec("UPDATE members SET external_mail = 'ymyoo3@bettercode.kr' WHERE id = 4")
+	// 멤버 4 는 언급 알림을 받지 않는다.
+	gormDB.Exec(`INSERT INTO member_preferences (member_id, namespace, value, created_at, updated_at)
+		VALUES (4, 'notification', '{"optOuts": ["note-mention"]}', datetime('now'), datetime('now'))`)
+
+	// when
+	rec := requestTestNote(http.MethodPost, "/api/members/2/notes",
+		strings.NewReader(`{"content": "전화 상담 완료. @ymyoo, @ymyoo3 @siteadm 확인 부탁드립니다."}`), 1)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var note dtos.NoteInformation
+	json.Unmarshal(rec.Body.Bytes(), &note)
+	assert.Equal(t, []uint{3, 4, 1}, note.Mentions)
+	assert.Equal(t, 1, len(mailSender.messages))
+	assert.Equal(t, []string{"ymyoo@bettercode.kr"}, mailSender.messages[0].To)
+
+	// when
+	rec = requestTestNote(http.MethodPost, "/api/members/2/notes",
+		strings.NewReader(fmt.Sprintf(`{"content": "확인했습니다.", "parentId": %v}`, note.Id)), 3)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var reply dtos.NoteInformation
+	json.Unmarshal(rec.Body.Bytes(), &reply)
+
+	// 답글에는 답글을 달 수 없다.
+	rec = requestTestNote(http.MethodPost, "/api/members/2/notes",
+		strings.NewReader(fmt.Sprintf(`{"content": "답글의 답글", "parentId": %v}`, reply.Id)), 1)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_NOTE")
+
+	rec = requestTestNote(http.MethodGet, "/api/members/2/notes", nil, 1)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual struct {
+		Result     []dtos.NoteInformation `json:"result"`
+		TotalCount int64                  `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, int64(1), actual.TotalCount)
+	assert.Equal(t, 1, len(actual.Result[0].Replies))
+	assert.Equal(t, "확인했습니다.", actual.Result[0].Replies[0].Content)
+	assert.Equal(t, uint(3), actual.Result[0].Replies[0].CreatedBy)
+
+	// 멤버의 활동 피드에 메모가 보인다.
+	activities := getTestActivities(t, "/api/members/2/activity?eventTypes=note", constants.PermissionManageMembers)
+	assert.Equal(t, float64(2), activities["totalCount"])
+	activity := activities["result"].([]interface{})[1].(map[string]interface{})
+	assert.Equal(t, constants.AuditActionNoteCreated, activity["action"])
+	assert.Equal(t, "메모를 남겼습니다.", activity["summary"])
+}
+
+func TestNoteController_메모_수정과_삭제_권한(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+	gormDB.Exec("UPDATE members SET external_mail = 'ymyoo@bettercode.kr' WHERE id = 3")
+
+	rec := requestTestNote(http.MethodPost, "/api/members/2/notes", strings.NewReader(`{"content": "@ymyoo 메모"}`), 1)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var note dtos.NoteInformation
+	json.Unmarshal(rec.Body.Bytes(), &note)
+	rec = requestTestNote(http.MethodPost, "/api/members/2/notes",
+		strings.NewReader(fmt.Sprintf(`{"content": "답글", "parentId": %v}`, note.Id)), 3)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// 작성자만 수정할 수 있다.
+	rec = requestTestNote(http.MethodPut, fmt.Sprintf("/api/members/2/notes/%v", note.Id), strings.NewReader(`{"content": "수정"}`), 3)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 이미 언급한 멤버에게는 다시 알리지 않는다.
+	rec = requestTestNote(http.MethodPut, fmt.Sprintf("/api/members/2/notes/%v", note.Id), strings.NewReader(`{"content": "@ymyoo 수정한 메모"}`), 1)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &note)
+	assert.NotNil(t, note.EditedAt)
+	assert.Equal(t, 1, len(mailSender.messages))
+
+	// 다른 대상의 경로로는 접근할 수 없다.
+	rec = requestTestNote(http.MethodDelete, fmt.Sprintf("/api/members/3/notes/%v", note.Id), nil, 1)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 작성자가 아니면 시스템 설정 관리 권한이 있어야 삭제할 수 있다.
+	rec = requestTestNote(http.MethodDelete, fmt.Sprintf("/api/members/2/notes/%v", note.Id), nil, 3)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = requestTestNote(http.MethodDelete, fmt.Sprintf("/api/members/2/notes/%v", note.Id), nil, 3, constants.PermissionManageSystemSettings)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 최상위 메모를 삭제하면 답글도 삭제된다.
+	var noteCount int64
+	gormDB.Raw("SELECT count(*) FROM notes WHERE deleted_at IS NULL").Scan(&noteCount)
+	assert.Equal(t, int64(0), noteCount)
+}
+
+func TestNoteController_없는_대상(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestTestNote(http.MethodPost, "/api/members/999/notes", strings.NewReader(`{"content": "메모"}`), 1)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestNoteController_메모_첨부_파일(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpTestFileStorage(t)
+
+	// given
+	fileId := uploadTestAttachment(t, 1, "consent.png", testPngContent)
+
+	// when
+	rec := requestTestNote(http.MethodPost, "/api/members/3/notes",
+		strings.NewReader(fmt.Sprintf(`{"content": "본인 확인 동의서", "attachmentFileIds": [%v]}`, fileId)), 1)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var note dtos.NoteInformation
+	json.Unmarshal(rec.Body.Bytes(), &note)
+	assert.Equal(t, 1, len(note.Attachments))
+	assert.Equal(t, "consent.png", note.Attachments[0].Name)
+
+	rec = requestTestNote(http.MethodGet, "/api/members/3/notes", nil, 2)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "consent.png")
+
+	// 메모를 남긴 대상의 경로로만 내려받을 수 있다.
+	rec = requestTestNote(http.MethodGet, fmt.Sprintf("/api/members/2/notes/%v/attachments/%v/content", note.Id, note.Attachments[0].Id), nil, 2)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = requestTestNote(http.MethodGet, fmt.Sprintf("/api/members/3/notes/%v/attachments/%v/content", note.Id, note.Attachments[0].Id), nil, 2)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, testPngContent, rec.Body.Bytes())
+
+	// 메모를 삭제하면 첨부 파일도 삭제된다.
+	rec = requestTestNote(http.MethodDelete, fmt.Sprintf("/api/members/3/notes/%v", note.Id), nil, 1)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var fileCount int64
+	gormDB.Raw("SELECT count(*) FROM files WHERE id = ? AND deleted_at IS NULL", fileId).Scan(&fileCount)
+	assert.Equal(t, int64(0), fileCount)
+}
@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type OAuthAuthorizationController struct {
+	routerGroup               *gin.RouterGroup
+	oauthAuthorizationService *services.OAuthAuthorizationService
+}
+
+func NewOAuthAuthorizationController(
+	routerGroup *gin.RouterGroup,
+	oauthAuthorizationService *services.OAuthAuthorizationService) *OAuthAuthorizationController {
+
+	return &OAuthAuthorizationController{
+		routerGroup:               routerGroup,
+		oauthAuthorizationService: oauthAuthorizationService,
+	}
+}
+
+func (c OAuthAuthorizationController) MapRoutes() {
+	route := c.routerGroup.Group("/oauth")
+	route.POST("/authorize", middlewares.PermissionChecker([]string{"*"}),
+		c.authorize)
+	route.GET("/device/:userCode", middlewares.PermissionChecker([]string{"*"}),
+		c.getDeviceAuthorization)
+	route.POST("/device/:userCode", middlewares.PermissionChecker([]string{"*"}),
+		c.decideDeviceAuthorization)
+}
+
+// authorize 는 동의 화면에서 로그인한 멤버가 Client 에 인가 코드를 발급한다. 화면은 응답의 redirectUri 로 이동한다.
+func (c OAuthAuthorizationController) authorize(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "no-store")
+
+	var request dtos.OAuthAuthorizationRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: err.Error()})
+		return
+	}
+
+	authorizationCode, err := c.oauthAuthorizationService.Authorize(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
+
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, errors.ErrInvalidScope) {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidScope})
+			return
+		}
+
+		if errors.Is(err, errors.ErrConsentRequired) {
+			ctx.JSON(http.StatusForbidden, dtos.OAuthError{Error: constants.OAuthErrorConsentRequired})
+			return
+		}
+
+		if e, ok := err.(*errors.ErrInvalidAuthorizationRequest); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: e.Reason})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, authorizationCode)
+}
+
+// getDeviceAuthorization 은 기기 승인 화면에서 멤버가 입력한 사용자 코드의 Client 와 scope 를 보여준다.
+func (c OAuthAuthorizationController) getDeviceAuthorization(ctx *gin.Context) {
+	information, err := c.oauthAuthorizationService.GetDeviceAuthorization(ctx.Request.Context(), ctx.Param("userCode"))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, information)
+}
+
+func (c OAuthAuthorizationController) decideDeviceAuthorization(ctx *gin.Context) {
+	var approval dtos.OAuthDeviceApproval
+	if err := ctx.BindJSON(&approval); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+		return
+	}
+
+	if err := c.oauthAuthorizationService.DecideDeviceAuthorization(ctx.Request.Context(), ctx.Param("userCode"), approval); err != nil {
+		if errors.Is(err, errors.ErrAuthentication) {
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
+
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,425 @@
+package rest
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func authorizeTestOAuthClient(t *testing.T, memberId uint, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/authorize", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func exchangeTestAuthorizationCode(code, codeVerifier string) *httptest.ResponseRecorder {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", "intranet")
+	form.Set("code", code)
+	form.Set("redirect_uri", "https://intranet.example.com/callback")
+	if len(codeVerifier) > 0 {
+		form.Set("code_verifier", codeVerifier)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOAuthAuthorizationController_authorize_PKCE(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	hashed := sha256.Sum256([]byte(testCodeVerifier))
+	codeChallenge := base64.RawURLEncoding.EncodeToString(hashed[:])
+
+	// when
+	rec := authorizeTestOAuthClient(t, 1, fmt.Sprintf(`{
+		"clientId": "intranet",
+		"redirectUri": "https://intranet.example.com/callback",
+		"scope": "profile",
+		"state": "xyz",
+		"codeChallenge": "%s",
+		"codeChallengeMethod": "S256"
+	}`, codeChallenge))
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var authorizationCode dtos.OAuthAuthorizationCode
+	json.Unmarshal(rec.Body.Bytes(), &authorizationCode)
+	assert.NotEmpty(t, authorizationCode.Code)
+	assert.Equal(t, 60, authorizationCode.ExpiresIn)
+	redirectUri, _ := url.Parse(authorizationCode.RedirectUri)
+	assert.Equal(t, "intranet.example.com", redirectUri.Host)
+	assert.Equal(t, authorizationCode.Code, redirectUri.Query().Get("code"))
+	assert.Equal(t, "xyz", redirectUri.Query().Get("state"))
+
+	// 코드는 해시로만 저장한다.
+	var storedCount int64
+	gormDB.Raw("SELECT count(*) FROM oauth_authorization_codes WHERE code_hash = ?", authorizationCode.Code).Scan(&storedCount)
+	assert.Equal(t, int64(0), storedCount)
+
+	rec = exchangeTestAuthorizationCode(authorizationCode.Code, testCodeVerifier)
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var actual dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "Bearer", actual.TokenType)
+	assert.Equal(t, 900, actual.ExpiresIn)
+	assert.Equal(t, "profile", actual.Scope)
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(actual.AccessToken, claims, func(token *jwt.Token) (interface{}, error) { return []byte(config.Config.JwtSecret), nil })
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), claims["id"])
+	assert.Equal(t, "intranet", claims["client_id"])
+	assert.Equal(t, "profile", claims["scope"])
+	// profile scope 만 인가했으므로 멤버의 권한을 넣지 않는다.
+	assert.Equal(t, []interface{}{}, claims["permissions"])
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual.AccessToken))
+	memberRec := httptest.NewRecorder()
+	ginApp.ServeHTTP(memberRec, req)
+	assert.Equal(t, http.StatusForbidden, memberRec.Code)
+
+	// 같은 코드는 다시 교환할 수 없다.
+	rec = exchangeTestAuthorizationCode(authorizationCode.Code, testCodeVerifier)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_grant")
+}
+
+func TestOAuthAuthorizationController_authorize_잘못된_code_verifier(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	hashed := sha256.Sum256([]byte(testCodeVerifier))
+	codeChallenge := base64.RawURLEncoding.EncodeToString(hashed[:])
+
+	for _, codeVerifier := range []string{"", "short", strings.Repeat("a", 43)} {
+		// given
+		rec := authorizeTestOAuthClient(t, 1, fmt.Sprintf(`{
+			"clientId": "intranet",
+			"redirectUri": "https://intranet.example.com/callback",
+			"codeChallenge": "%s",
+			"codeChallengeMethod": "S256"
+		}`, codeChallenge))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var authorizationCode dtos.OAuthAuthorizationCode
+		json.Unmarshal(rec.Body.Bytes(), &authorizationCode)
+
+		// when
+		rec = exchangeTestAuthorizationCode(authorizationCode.Code, codeVerifier)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, codeVerifier)
+		assert.Contains(t, rec.Body.String(), "invalid_grant", codeVerifier)
+	}
+}
+
+func TestOAuthAuthorizationController_authorize_PKCE_정책(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	plainChallenge := fmt.Sprintf(`"codeChallenge": "%s", "codeChallengeMethod": "plain",`, testCodeVerifier)
+	testCases := []struct {
+		pkcePolicy   string
+		pkceFields   string
+		expectedCode int
+	}{
+		// 정책을 지정하지 않은 Client 는 S256 을 요구한다.
+		{"", "", http.StatusBadRequest},
+		{"", plainChallenge, http.StatusBadRequest},
+		{"required", "", http.StatusBadRequest},
+		{"required", plainChallenge, http.StatusOK},
+		{"required", `"codeChallenge": "too-short", "codeChallengeMethod": "S256",`, http.StatusBadRequest},
+		{"optional", "", http.StatusOK},
+		{"optional", `"codeChallenge": "` + testCodeVerifier + `", "codeChallengeMethod": "S512",`, http.StatusBadRequest},
+	}
+
+	for _, testCase := range testCases {
+		// given
+		gormDB.Exec("UPDATE oauth_clients SET pkce_policy = ? WHERE client_id = 'intranet'", testCase.pkcePolicy)
+
+		// when
+		rec := authorizeTestOAuthClient(t, 1, `{
+			"clientId": "intranet",
+			`+testCase.pkceFields+`
+			"redirectUri": "https://intranet.example.com/callback"
+		}`)
+
+		// then
+		assert.Equal(t, testCase.expectedCode, rec.Code, testCase)
+		if testCase.expectedCode == http.StatusBadRequest {
+			assert.Contains(t, rec.Body.String(), "invalid_request", testCase)
+		}
+	}
+
+	// plain 으로 발급한 코드는 같은 code_verifier 로 교환한다.
+	gormDB.Exec("UPDATE oauth_clients SET pkce_policy = 'required' WHERE client_id = 'intranet'")
+	rec := authorizeTestOAuthClient(t, 1, `{
+		"clientId": "intranet",
+		`+plainChallenge+`
+		"redirectUri": "https://intranet.example.com/callback"
+	}`)
+	var authorizationCode dtos.OAuthAuthorizationCode
+	json.Unmarshal(rec.Body.Bytes(), &authorizationCode)
+	assert.Equal(t, http.StatusOK, exchangeTestAuthorizationCode(authorizationCode.Code, testCodeVerifier).Code)
+}
+
+func TestOAuthAuthorizationController_authorize_허용되지_않은_요청(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	hashed := sha256.Sum256([]byte(testCodeVerifier))
+	codeChallenge := base64.RawURLEncoding.EncodeToString(hashed[:])
+	testCases := map[string]struct {
+		requestBody  string
+		expectedCode int
+		expectedBody string
+	}{
+		"동의하지 않은 scope": {fmt.Sprintf(`{"clientId": "report-app", "redirectUri": "https://report.example.com/callback",
+			"scope": "profile organizations", "codeChallenge": "%s", "codeChallengeMethod": "S256"}`, codeChallenge),
+			http.StatusForbidden, "consent_required"},
+		"등록되지 않은 redirect_uri": {fmt.Sprintf(`{"clientId": "intranet", "redirectUri": "https://evil.example.com/callback",
+			"codeChallenge": "%s", "codeChallengeMethod": "S256"}`, codeChallenge),
+			http.StatusBadRequest, "redirect_uri is not registered"},
+		"등록되지 않은 scope": {fmt.Sprintf(`{"clientId": "intranet", "redirectUri": "https://intranet.example.com/callback",
+			"scope": "organizations", "codeChallenge": "%s", "codeChallengeMethod": "S256"}`, codeChallenge),
+			http.StatusBadRequest, "invalid_scope"},
+		"없는 Client": {`{"clientId": "unknown", "redirectUri": "https://intranet.example.com/callback"}`,
+			http.StatusNotFound, ""},
+	}
+
+	for name, testCase := range testCases {
+		// when
+		rec := authorizeTestOAuthClient(t, 1, testCase.requestBody)
+
+		// then
+		assert.Equal(t, testCase.expectedCode, rec.Code, name)
+		assert.Contains(t, rec.Body.String(), testCase.expectedBody, name)
+	}
+}
+
+func TestOAuthAuthorizationController_authorize_서비스_계정은_인가할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	hashed := sha256.Sum256([]byte(testCodeVerifier))
+	codeChallenge := base64.RawURLEncoding.EncodeToString(hashed[:])
+
+	// when
+	// 서비스 계정의 Id(1)는 멤버(1, 최고 관리자)의 Id 와 같다.
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/authorize", strings.NewReader(fmt.Sprintf(`{
+		"clientId": "intranet",
+		"redirectUri": "https://intranet.example.com/callback",
+		"scope": "profile",
+		"codeChallenge": "%s",
+		"codeChallengeMethod": "S256"
+	}`, codeChallenge)))
+	req.Header.Set("X-Api-Key", "sa_test-api-key")
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var storedCount int64
+	gormDB.Raw("SELECT count(*) FROM oauth_authorization_codes").Scan(&storedCount)
+	assert.Equal(t, int64(0), storedCount)
+}
+
+func startTestDeviceAuthorization(t *testing.T, clientId, scope string) (*httptest.ResponseRecorder, dtos.DeviceAuthorization) {
+	form := url.Values{}
+	form.Set("client_id", clientId)
+	form.Set("scope", scope)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/device/code", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	var deviceAuthorization dtos.DeviceAuthorization
+	json.Unmarshal(rec.Body.Bytes(), &deviceAuthorization)
+	return rec, deviceAuthorization
+}
+
+func pollTestDeviceCode(clientId, deviceCode string) *httptest.ResponseRecorder {
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+	form.Set("client_id", clientId)
+	form.Set("device_code", deviceCode)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func decideTestDeviceAuthorization(t *testing.T, userCode string, approved bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/device/"+userCode, strings.NewReader(fmt.Sprintf(`{"approved": %v}`, approved)))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOAuthAuthorizationController_device_flow(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec, deviceAuthorization := startTestDeviceAuthorization(t, "report-app", "profile organizations")
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Regexp(t, "^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$", deviceAuthorization.UserCode)
+	assert.Equal(t, "http://localhost:3000/device", deviceAuthorization.VerificationUri)
+	assert.Equal(t, "http://localhost:3000/device?user_code="+deviceAuthorization.UserCode, deviceAuthorization.VerificationUriComplete)
+	assert.Equal(t, 600, deviceAuthorization.ExpiresIn)
+	assert.Equal(t, 5, deviceAuthorization.Interval)
+
+	// 승인 전에는 기다리라고 응답하고 너무 자주 확인하면 간격을 늘리라고 응답한다.
+	rec = pollTestDeviceCode("report-app", deviceAuthorization.DeviceCode)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "authorization_pending")
+	rec = pollTestDeviceCode("report-app", deviceAuthorization.DeviceCode)
+	assert.Contains(t, rec.Body.String(), "slow_down")
+
+	// 관리 화면에서는 입력한 그대로(소문자, 구분자 없이)의 사용자 코드로 조회할 수 있다.
+	userCode := strings.ToLower(strings.Replace(deviceAuthorization.UserCode, "-", "", 1))
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/device/"+userCode, nil)
+	token, _ := generateTestJWT(map[string]interface{}{"Id": 1, "Permissions": []string{}}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var information dtos.OAuthDeviceAuthorizationInformation
+	json.Unmarshal(rec.Body.Bytes(), &information)
+	assert.Equal(t, "리포트 앱", information.Client.Name)
+	assert.Equal(t, []string{"profile", "organizations"}, information.Scopes)
+
+	// when
+	rec = decideTestDeviceAuthorization(t, userCode, true)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var consentScopes string
+	gormDB.Raw("SELECT scopes FROM member_consents WHERE member_id = 1 AND oauth_client_id = 1").Scan(&consentScopes)
+	assert.Equal(t, "profile organizations", consentScopes)
+
+	rec = pollTestDeviceCode("report-app", deviceAuthorization.DeviceCode)
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "profile organizations", actual.Scope)
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(actual.AccessToken, claims, func(token *jwt.Token) (interface{}, error) { return []byte(config.Config.JwtSecret), nil })
+	assert.NoError(t, err)
+	assert.Equal(t, float64(1), claims["id"])
+	assert.Equal(t, "report-app", claims["client_id"])
+
+	// 기기 코드는 한 번만 토큰으로 바꿀 수 있고 처리한 요청은 다시 조회할 수 없다.
+	assert.Contains(t, pollTestDeviceCode("report-app", deviceAuthorization.DeviceCode).Body.String(), "invalid_grant")
+	assert.Equal(t, http.StatusNotFound, decideTestDeviceAuthorization(t, userCode, true).Code)
+}
+
+func TestOAuthAuthorizationController_device_flow_거부_만료(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// 거부한 요청
+	_, denied := startTestDeviceAuthorization(t, "intranet", "")
+	assert.Equal(t, http.StatusNoContent, decideTestDeviceAuthorization(t, denied.UserCode, false).Code)
+	rec := pollTestDeviceCode("intranet", denied.DeviceCode)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "access_denied")
+
+	// 만료된 요청
+	_, expired := startTestDeviceAuthorization(t, "intranet", "profile")
+	gormDB.Exec("UPDATE oauth_device_codes SET expires_at = ? WHERE user_code = ?", time.Now().Add(-time.Minute), expired.UserCode)
+	assert.Equal(t, http.StatusNotFound, decideTestDeviceAuthorization(t, expired.UserCode, true).Code)
+	assert.Contains(t, pollTestDeviceCode("intranet", expired.DeviceCode).Body.String(), "expired_token")
+
+	// 다른 Client 의 기기 코드
+	_, other := startTestDeviceAuthorization(t, "intranet", "profile")
+	assert.Contains(t, pollTestDeviceCode("report-app", other.DeviceCode).Body.String(), "invalid_grant")
+
+	// 등록되지 않은 Client 와 scope
+	rec, _ = startTestDeviceAuthorization(t, "unknown", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_client")
+	rec, _ = startTestDeviceAuthorization(t, "intranet", "organizations")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_scope")
+}
+
+func TestOAuthAuthorizationController_device_flow_서비스_계정은_승인할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	_, deviceAuthorization := startTestDeviceAuthorization(t, "report-app", "profile")
+
+	// when
+	// 서비스 계정의 Id(1)는 멤버(1, 최고 관리자)의 Id 와 같다.
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/device/"+deviceAuthorization.UserCode, strings.NewReader(`{"approved": true}`))
+	req.Header.Set("X-Api-Key", "sa_test-api-key")
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = pollTestDeviceCode("report-app", deviceAuthorization.DeviceCode)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "authorization_pending")
+}
@@ -0,0 +1,167 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/oauth/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type OAuthClientController struct {
+	routerGroup        *gin.RouterGroup
+	oauthClientService *services.OAuthClientService
+}
+
+func NewOAuthClientController(
+	routerGroup *gin.RouterGroup,
+	oauthClientService *services.OAuthClientService) *OAuthClientController {
+
+	return &OAuthClientController{
+		routerGroup:        routerGroup,
+		oauthClientService: oauthClientService,
+	}
+}
+
+func (c OAuthClientController) MapRoutes() {
+	route := c.routerGroup.Group("/oauth-clients")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.createOAuthClient)
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getOAuthClients)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getOAuthClient)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.updateOAuthClient)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.deleteOAuthClient)
+}
+
+func (c OAuthClientController) createOAuthClient(ctx *gin.Context) {
+	var information dtos.OAuthClientInformation
+	if err := ctx.Bind(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.oauthClientService.CreateOAuthClient(ctx.Request.Context(), information)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (c OAuthClientController) getOAuthClients(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.oauthClientService.GetOAuthClients(ctx.Request.Context(), pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var oauthClients = make([]dtos.OAuthClientInformation, 0)
+	for _, entity := range entities {
+		oauthClients = append(oauthClients, c.toOAuthClientInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     oauthClients,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (c OAuthClientController) getOAuthClient(ctx *gin.Context) {
+	oauthClientId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.oauthClientService.GetOAuthClient(ctx.Request.Context(), uint(oauthClientId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toOAuthClientInformation(entity))
+}
+
+func (c OAuthClientController) updateOAuthClient(ctx *gin.Context) {
+	oauthClientId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.OAuthClientInformation
+	if err := ctx.Bind(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.oauthClientService.UpdateOAuthClient(ctx.Request.Context(), uint(oauthClientId), information)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c OAuthClientController) deleteOAuthClient(ctx *gin.Context) {
+	oauthClientId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.oauthClientService.DeleteOAuthClient(ctx.Request.Context(), uint(oauthClientId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (OAuthClientController) toOAuthClientInformation(entity domain.OAuthClientEntity) dtos.OAuthClientInformation {
+	return dtos.OAuthClientInformation{
+		Id:           entity.ID,
+		ClientId:     entity.ClientId,
+		Name:         entity.Name,
+		Description:  entity.Description,
+		RedirectUris: entity.GetRedirectUris(),
+		Scopes:       entity.GetScopes(),
+		Trusted:      entity.Trusted,
+		PkcePolicy:   entity.GetPkcePolicy(),
+		CreatedAt:    entity.CreatedAt,
+	}
+}
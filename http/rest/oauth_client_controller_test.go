@@ -0,0 +1,92 @@
+package rest
+
+import (
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOAuthClientController_createOAuthClient(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"name": "사내 위키",
+		"redirectUris": ["https://wiki.example.com/callback"],
+		"scopes": ["profile"],
+		"trusted": true
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth-clients", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/oauth-clients/3", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "사내 위키", actual["name"])
+	assert.NotEmpty(t, actual["clientId"])
+	assert.Equal(t, true, actual["trusted"])
+	// PKCE 정책을 지정하지 않으면 S256 을 요구한다.
+	assert.Equal(t, "s256", actual["pkcePolicy"])
+}
+
+func TestOAuthClientController_deleteOAuthClient(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodDelete, "/api/oauth-clients/1", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// Client 에 대한 멤버 동의도 함께 철회된다.
+	var consentCount int64
+	gormDB.Raw("SELECT count(*) FROM member_consents WHERE oauth_client_id = 1 AND deleted_at IS NULL").Scan(&consentCount)
+	assert.Equal(t, int64(0), consentCount)
+}
@@ -6,11 +6,14 @@ import (
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/organization/domain"
 	"better-admin-backend-service/organization/factory"
 	"better-admin-backend-service/services"
+	"fmt"
 	etag "github.com/bettercode-oss/gin-middleware-etag"
 	"github.com/gin-gonic/gin"
 	pkgerrors "github.com/pkg/errors"
+	"mime"
 	"net/http"
 	"strconv"
 )
@@ -38,6 +41,9 @@ func (c OrganizationController) MapRoutes() {
 	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageOrganization}),
 		etag.HttpEtagCache(0),
 		c.getOrganizations)
+	route.GET("/chart", middlewares.PermissionChecker([]string{constants.PermissionManageOrganization}),
+		etag.HttpEtagCache(0),
+		c.getOrganizationChart)
 	route.GET("/:organizationId", middlewares.PermissionChecker([]string{constants.PermissionManageOrganization}),
 		etag.HttpEtagCache(0),
 		c.getOrganization)
@@ -49,6 +55,8 @@ func (c OrganizationController) MapRoutes() {
 		c.assignRoles)
 	route.PUT("/:organizationId/assign-members", middlewares.PermissionChecker([]string{constants.PermissionManageOrganization}),
 		c.assignMembers)
+	route.PUT("/:organizationId/assign-leaders", middlewares.PermissionChecker([]string{constants.PermissionManageOrganization}),
+		c.assignLeaders)
 	route.DELETE("/:organizationId", middlewares.PermissionChecker([]string{constants.PermissionManageOrganization}),
 		c.deleteOrganization)
 }
@@ -98,7 +106,7 @@ func (c OrganizationController) getOrganizations(ctx *gin.Context) {
 		parentOrganizationInformation.SubOrganizations = append(parentOrganizationInformation.SubOrganizations, organizationInformation)
 	}
 
-	ctx.JSON(http.StatusOK, organizations)
+	helpers.ResponseHelper().JSON(ctx, http.StatusOK, organizations)
 }
 
 func findParentOrganizationInformation(organizations *[]dtos.OrganizationInformation, parentId uint) *dtos.OrganizationInformation {
@@ -116,6 +124,39 @@ func findParentOrganizationInformation(organizations *[]dtos.OrganizationInforma
 	return nil
 }
 
+// getOrganizationChart 는 format(json, csv, dot) 에 따라 조직도를 응답한다. csv 와 dot 은 파일로 내려받는다.
+func (c OrganizationController) getOrganizationChart(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", constants.OrganizationChartFormatJson)
+	if format != constants.OrganizationChartFormatJson &&
+		format != constants.OrganizationChartFormatCsv &&
+		format != constants.OrganizationChartFormatDot {
+		ctx.JSON(http.StatusBadRequest, fmt.Sprintf("unsupported format: %v", format))
+		return
+	}
+
+	chart, err := c.organizationService.GetOrganizationChart(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	// 내려받는 파일에도 응답과 같은 마스킹 정책을 적용한다.
+	chart = helpers.ResponseHelper().Serialize(ctx.Request.Context(), chart).([]dtos.OrganizationChartNode)
+	if format == constants.OrganizationChartFormatJson {
+		ctx.JSON(http.StatusOK, chart)
+		return
+	}
+
+	file, err := domain.NewOrganizationChartFile(format, chart)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
 func (c OrganizationController) getOrganization(ctx *gin.Context) {
 	organizationId, err := strconv.ParseInt(ctx.Param("organizationId"), 10, 64)
 	if err != nil {
@@ -125,7 +166,7 @@ func (c OrganizationController) getOrganization(ctx *gin.Context) {
 
 	organizationEntity, err := c.organizationService.GetOrganization(ctx.Request.Context(), uint(organizationId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -149,15 +190,24 @@ func (c OrganizationController) getOrganization(ctx *gin.Context) {
 		})
 	}
 
+	organizationLeaders := make([]dtos.OrganizationMember, 0)
+	for _, leader := range organizationEntity.Leaders {
+		organizationLeaders = append(organizationLeaders, dtos.OrganizationMember{
+			Id:   leader.ID,
+			Name: leader.Name,
+		})
+	}
+
 	organizationDetails := dtos.OrganizationDetails{
 		Id:        organizationEntity.ID,
 		Name:      organizationEntity.Name,
 		CreatedAt: organizationEntity.CreatedAt,
 		Roles:     organizationRoles,
 		Members:   organizationMembers,
+		Leaders:   organizationLeaders,
 	}
 
-	ctx.JSON(http.StatusOK, organizationDetails)
+	helpers.ResponseHelper().JSON(ctx, http.StatusOK, organizationDetails)
 }
 
 func (c OrganizationController) changeOrganizationName(ctx *gin.Context) {
@@ -175,7 +225,7 @@ func (c OrganizationController) changeOrganizationName(ctx *gin.Context) {
 
 	err = c.organizationService.ChangeOrganizationName(ctx.Request.Context(), uint(organizationId), organizationInformation.Name)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -202,7 +252,7 @@ func (c OrganizationController) changePosition(ctx *gin.Context) {
 	parentOrganizationId := requestBody["parentOrganizationId"]
 	err = c.organizationService.ChangePosition(ctx.Request.Context(), uint(organizationId), parentOrganizationId)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -228,7 +278,7 @@ func (c OrganizationController) assignRoles(ctx *gin.Context) {
 
 	err = c.organizationService.AssignRoles(ctx.Request.Context(), uint(organizationId), organizationAssignRole)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -254,7 +304,7 @@ func (c OrganizationController) assignMembers(ctx *gin.Context) {
 
 	err = c.organizationService.AssignMembers(ctx.Request.Context(), uint(organizationId), organizationAssignMember)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -265,6 +315,36 @@ func (c OrganizationController) assignMembers(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+func (c OrganizationController) assignLeaders(ctx *gin.Context) {
+	organizationId, err := strconv.ParseInt(ctx.Param("organizationId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var organizationAssignLeader dtos.OrganizationAssignLeader
+	if err := ctx.BindJSON(&organizationAssignLeader); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.organizationService.AssignLeaders(ctx.Request.Context(), uint(organizationId), organizationAssignLeader)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if e, ok := err.(*errors.ErrInvalidOrganization); ok {
+			ctx.JSON(http.StatusBadRequest, e.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func (c OrganizationController) deleteOrganization(ctx *gin.Context) {
 	organizationId, err := strconv.ParseInt(ctx.Param("organizationId"), 10, 64)
 	if err != nil {
@@ -274,7 +354,7 @@ func (c OrganizationController) deleteOrganization(ctx *gin.Context) {
 
 	err = c.organizationService.DeleteOrganization(ctx.Request.Context(), uint(organizationId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
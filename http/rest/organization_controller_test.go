@@ -740,3 +740,273 @@ func TestOrganizationController_DeleteOrganization_최상위(t *testing.T) {
 	// then
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 }
+
+func TestOrganizationController_getOrganizationChart(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/organizations/chart", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ORGANIZATION",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"id":               float64(1),
+			"name":             "베터코드 연구소",
+			"memberCount":      float64(2),
+			"totalMemberCount": float64(3),
+			"leaders":          []interface{}{},
+			"children": []interface{}{
+				map[string]interface{}{
+					"id":               float64(3),
+					"name":             "부서B",
+					"memberCount":      float64(0),
+					"totalMemberCount": float64(1),
+					"leaders":          []interface{}{},
+					"children": []interface{}{
+						map[string]interface{}{
+							"id":               float64(4),
+							"name":             "부서C",
+							"memberCount":      float64(1),
+							"totalMemberCount": float64(1),
+							"leaders": []interface{}{
+								map[string]interface{}{
+									"id":   float64(3),
+									"name": "유영모2",
+								},
+							},
+							"children": []interface{}{},
+						},
+					},
+				},
+			},
+		},
+		map[string]interface{}{
+			"id":               float64(5),
+			"name":             "베터코드 연구소2",
+			"memberCount":      float64(0),
+			"totalMemberCount": float64(0),
+			"leaders":          []interface{}{},
+			"children": []interface{}{
+				map[string]interface{}{
+					"id":               float64(2),
+					"name":             "부서A",
+					"memberCount":      float64(0),
+					"totalMemberCount": float64(0),
+					"leaders":          []interface{}{},
+					"children":         []interface{}{},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestOrganizationController_getOrganizationChart_CSV(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/organizations/chart?format=csv", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ORGANIZATION",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=organization_chart.csv", rec.Header().Get("Content-Disposition"))
+
+	expected := "\xEF\xBB\xBF" +
+		"id,parentId,path,name,memberCount,totalMemberCount,leaders\n" +
+		"1,,베터코드 연구소,베터코드 연구소,2,3,\n" +
+		"3,1,베터코드 연구소 > 부서B,부서B,0,1,\n" +
+		"4,3,베터코드 연구소 > 부서B > 부서C,부서C,1,1,유영모2\n" +
+		"5,,베터코드 연구소2,베터코드 연구소2,0,0,\n" +
+		"2,5,베터코드 연구소2 > 부서A,부서A,0,0,\n"
+	assert.Equal(t, expected, rec.Body.String())
+}
+
+func TestOrganizationController_getOrganizationChart_DOT(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/organizations/chart?format=dot", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ORGANIZATION",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "attachment; filename=organization_chart.dot", rec.Header().Get("Content-Disposition"))
+
+	body := rec.Body.String()
+	assert.True(t, strings.HasPrefix(body, "digraph organization_chart {\n"))
+	assert.Contains(t, body, `org4 [label="부서C\nmembers: 1 (total 1)\nleaders: 유영모2"];`)
+	assert.Contains(t, body, "org1 -> org3;\n")
+	assert.Contains(t, body, "org3 -> org4;\n")
+	assert.Contains(t, body, "org5 -> org2;\n")
+}
+
+func TestOrganizationController_getOrganizationChart_지원하지_않는_형식(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/organizations/chart?format=pdf", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ORGANIZATION",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestOrganizationController_assignLeaders(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"memberIds": [2]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/organizations/1/assign-leaders", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ORGANIZATION",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var leaderIds []uint
+	gormDB.Raw("SELECT member_entity_id FROM organization_leaders WHERE organization_entity_id = 1").Scan(&leaderIds)
+	assert.Equal(t, []uint{2}, leaderIds)
+}
+
+func TestOrganizationController_assignLeaders_조직의_멤버가_아닌_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"memberIds": [3]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/organizations/1/assign-leaders", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ORGANIZATION",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestOrganizationController_assignMembers_빠진_멤버는_리더에서도_제외(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"memberIds": [4]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/organizations/4/assign-members", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_ORGANIZATION",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var leaderCount int64
+	gormDB.Raw("SELECT COUNT(*) FROM organization_leaders WHERE organization_entity_id = 4").Scan(&leaderCount)
+	assert.Equal(t, int64(0), leaderCount)
+}
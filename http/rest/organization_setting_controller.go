@@ -0,0 +1,179 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type OrganizationSettingController struct {
+	routerGroup                *gin.RouterGroup
+	organizationSettingService *services.OrganizationSettingService
+}
+
+func NewOrganizationSettingController(
+	routerGroup *gin.RouterGroup,
+	organizationSettingService *services.OrganizationSettingService) *OrganizationSettingController {
+
+	return &OrganizationSettingController{
+		routerGroup:                routerGroup,
+		organizationSettingService: organizationSettingService,
+	}
+}
+
+func (c OrganizationSettingController) MapRoutes() {
+	siteRoute := c.routerGroup.Group("/site")
+	siteRoute.GET("/settings/password-policy",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getPasswordPolicySetting)
+	siteRoute.PUT("/settings/password-policy",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setPasswordPolicySetting)
+	siteRoute.GET("/settings/session-lifetime",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getSessionLifetimeSetting)
+	siteRoute.PUT("/settings/session-lifetime",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setSessionLifetimeSetting)
+
+	route := c.routerGroup.Group("/organizations")
+	route.GET("/:organizationId/settings",
+		middlewares.PermissionChecker([]string{constants.PermissionManageOrganization, constants.PermissionManageSystemSettings}),
+		c.getSettingOverrides)
+	route.PUT("/:organizationId/settings",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.changeSettingOverrides)
+	route.GET("/:organizationId/settings/effective",
+		middlewares.PermissionChecker([]string{constants.PermissionManageOrganization, constants.PermissionManageSystemSettings}),
+		c.getEffectiveSettings)
+}
+
+func (c OrganizationSettingController) getPasswordPolicySetting(ctx *gin.Context) {
+	setting, err := c.organizationSettingService.GetPasswordPolicySetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c OrganizationSettingController) setPasswordPolicySetting(ctx *gin.Context) {
+	var setting dtos.PasswordPolicySetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.organizationSettingService.SetPasswordPolicySetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c OrganizationSettingController) getSessionLifetimeSetting(ctx *gin.Context) {
+	setting, err := c.organizationSettingService.GetSessionLifetimeSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c OrganizationSettingController) setSessionLifetimeSetting(ctx *gin.Context) {
+	var setting dtos.SessionLifetimeSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.organizationSettingService.SetSessionLifetimeSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c OrganizationSettingController) getSettingOverrides(ctx *gin.Context) {
+	organizationId, err := strconv.ParseUint(ctx.Param("organizationId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	overrides, err := c.organizationSettingService.GetSettingOverrides(ctx.Request.Context(), uint(organizationId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, overrides)
+}
+
+// changeSettingOverrides 는 조직에서 바꾼 설정을 덮어쓴다. 요청에 없는(null) 설정은 상위 조직의 설정을 사용한다.
+func (c OrganizationSettingController) changeSettingOverrides(ctx *gin.Context) {
+	organizationId, err := strconv.ParseUint(ctx.Param("organizationId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var overrides dtos.OrganizationSettingOverrides
+	if err := ctx.BindJSON(&overrides); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changed, err := c.organizationSettingService.ChangeSettingOverrides(ctx.Request.Context(), uint(organizationId), overrides)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, changed)
+}
+
+func (c OrganizationSettingController) getEffectiveSettings(ctx *gin.Context) {
+	organizationId, err := strconv.ParseUint(ctx.Param("organizationId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	settings, err := c.organizationSettingService.GetEffectiveSettings(ctx.Request.Context(), uint(organizationId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+func (OrganizationSettingController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidOrganizationSetting); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,203 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestOrganizationSetting(method, url string, requestBody string, permissions []string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func signInWithPassword(signId string, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(fmt.Sprintf(`{"id": "%s", "password": "%s"}`, signId, password)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOrganizationSettingController_상위_조직의_설정을_상속(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestOrganizationSetting(http.MethodPut, "/api/site/settings/session-lifetime", `{"lifetimeMinutes": 720}`,
+		[]string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestOrganizationSetting(http.MethodPut, "/api/organizations/1/settings", `{
+		"passwordPolicy": {"minLength": 12, "requireDigit": true}
+	}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// when
+	rec = requestOrganizationSetting(http.MethodGet, "/api/organizations/4/settings/effective", "", []string{constants.PermissionManageOrganization})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var settings dtos.OrganizationEffectiveSettings
+	json.Unmarshal(rec.Body.Bytes(), &settings)
+	assert.Equal(t, uint(4), settings.OrganizationId)
+	assert.Equal(t, 12, settings.PasswordPolicy.Value.MinLength)
+	assert.Equal(t, true, settings.PasswordPolicy.Value.RequireDigit)
+	assert.Equal(t, constants.OrganizationSettingLevelOrganization, settings.PasswordPolicy.Source.Level)
+	assert.Equal(t, uint(1), settings.PasswordPolicy.Source.OrganizationId)
+	assert.Equal(t, "베터코드 연구소", settings.PasswordPolicy.Source.OrganizationName)
+	assert.Equal(t, 720, settings.SessionLifetime.Value.LifetimeMinutes)
+	assert.Equal(t, constants.OrganizationSettingLevelGlobal, settings.SessionLifetime.Source.Level)
+	assert.Equal(t, []string{constants.LoginMethodPassword, constants.LoginMethodDooray, constants.LoginMethodGoogleWorkspace}, settings.AuthMethods.Value)
+	assert.Equal(t, constants.OrganizationSettingLevelGlobal, settings.AuthMethods.Source.Level)
+
+	// 가까운 상위 조직에서 바꾼 설정을 사용한다.
+	rec = requestOrganizationSetting(http.MethodPut, "/api/organizations/3/settings", `{
+		"sessionLifetime": {"lifetimeMinutes": 30},
+		"authMethods": ["dooray"]
+	}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = requestOrganizationSetting(http.MethodGet, "/api/organizations/4/settings/effective", "", []string{constants.PermissionManageOrganization})
+	json.Unmarshal(rec.Body.Bytes(), &settings)
+	assert.Equal(t, 30, settings.SessionLifetime.Value.LifetimeMinutes)
+	assert.Equal(t, uint(3), settings.SessionLifetime.Source.OrganizationId)
+	assert.Equal(t, []string{constants.LoginMethodDooray}, settings.AuthMethods.Value)
+	assert.Equal(t, uint(1), settings.PasswordPolicy.Source.OrganizationId)
+
+	rec = requestOrganizationSetting(http.MethodGet, "/api/organizations/4/settings", "", []string{constants.PermissionManageOrganization})
+	var overrides dtos.OrganizationSettingOverrides
+	json.Unmarshal(rec.Body.Bytes(), &overrides)
+	assert.Nil(t, overrides.PasswordPolicy)
+	assert.Nil(t, overrides.SessionLifetime)
+	assert.Nil(t, overrides.AuthMethods)
+
+	rec = requestOrganizationSetting(http.MethodGet, "/api/organizations/100/settings/effective", "", []string{constants.PermissionManageOrganization})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var count int64
+	gormDB.Table("audit_logs").Where("action = ?", constants.AuditActionOrganizationSettingChanged).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestOrganizationSettingController_잘못된_조직_설정(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	bodies := []string{
+		`{"authMethods": []}`,
+		`{"authMethods": ["unknown"]}`,
+		`{"authMethods": ["password", "password"]}`,
+	}
+
+	for _, body := range bodies {
+		// when
+		rec := requestOrganizationSetting(http.MethodPut, "/api/organizations/1/settings", body, []string{constants.PermissionManageSystemSettings})
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		var errorMessage dtos.ErrorMessage
+		json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+		assert.Equal(t, "INVALID_ORGANIZATION_SETTING", errorMessage.Code, body)
+	}
+
+	for _, body := range []string{`{"sessionLifetime": {"lifetimeMinutes": 0}}`, `{"passwordPolicy": {"minLength": 200}}`} {
+		rec := requestOrganizationSetting(http.MethodPut, "/api/organizations/1/settings", body, []string{constants.PermissionManageSystemSettings})
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+
+	rec := requestOrganizationSetting(http.MethodPut, "/api/organizations/1/settings", `{"authMethods": ["password"]}`, []string{constants.PermissionManageOrganization})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = requestOrganizationSetting(http.MethodPut, "/api/organizations/100/settings", `{"authMethods": ["password"]}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestOrganizationSettingController_조직의_로그인_방법과_세션_유지_시간(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestOrganizationSetting(http.MethodPut, "/api/organizations/1/settings", `{"authMethods": ["dooray"]}`,
+		[]string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// when
+	rec = signInWithPassword("siteadm", "123456")
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "LOGIN_METHOD_NOT_ALLOWED", errorMessage.Code)
+
+	// 허용한 로그인 방법이면 조직의 세션 유지 시간으로 세션을 시작한다.
+	rec = requestOrganizationSetting(http.MethodPut, "/api/organizations/1/settings", `{
+		"authMethods": ["password", "dooray"],
+		"sessionLifetime": {"lifetimeMinutes": 60}
+	}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = signInWithPassword("siteadm", "123456")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var expiresAt time.Time
+	gormDB.Raw("SELECT expires_at FROM member_sessions WHERE member_id = 1 ORDER BY id DESC LIMIT 1").Scan(&expiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+}
+
+func TestOrganizationSettingController_비밀번호_정책(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestOrganizationSetting(http.MethodPut, "/api/site/settings/password-policy", `{"minLength": 8, "requireDigit": true}`,
+		[]string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestOrganizationSetting(http.MethodGet, "/api/site/settings/password-policy", "", []string{constants.PermissionManageSystemSettings})
+	var policy dtos.PasswordPolicySetting
+	json.Unmarshal(rec.Body.Bytes(), &policy)
+	assert.Equal(t, 8, policy.MinLength)
+
+	passwords := map[string]int{
+		"1234":         http.StatusBadRequest,
+		"password":     http.StatusBadRequest,
+		"password1234": http.StatusCreated,
+	}
+	for password, statusCode := range passwords {
+		// when
+		req := httptest.NewRequest(http.MethodPost, "/api/members", strings.NewReader(fmt.Sprintf(`{
+			"signId": "policy-%s",
+			"name": "정책",
+			"password": "%s"
+		}`, password, password)))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, statusCode, rec.Code, password)
+		if statusCode == http.StatusBadRequest {
+			var errorMessage dtos.ErrorMessage
+			json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+			assert.Equal(t, "PASSWORD_POLICY_VIOLATED", errorMessage.Code, password)
+		}
+	}
+}
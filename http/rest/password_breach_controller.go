@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PasswordBreachController struct {
+	routerGroup           *gin.RouterGroup
+	passwordBreachService *services.PasswordBreachService
+}
+
+func NewPasswordBreachController(
+	routerGroup *gin.RouterGroup,
+	passwordBreachService *services.PasswordBreachService) *PasswordBreachController {
+
+	return &PasswordBreachController{
+		routerGroup:           routerGroup,
+		passwordBreachService: passwordBreachService,
+	}
+}
+
+func (c PasswordBreachController) MapRoutes() {
+	route := c.routerGroup.Group("/site")
+	route.GET("/settings/password-breach-check",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getPasswordBreachCheckSetting)
+	route.PUT("/settings/password-breach-check",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setPasswordBreachCheckSetting)
+}
+
+func (c PasswordBreachController) getPasswordBreachCheckSetting(ctx *gin.Context) {
+	setting, err := c.passwordBreachService.GetPasswordBreachCheckSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c PasswordBreachController) setPasswordBreachCheckSetting(ctx *gin.Context) {
+	var setting dtos.PasswordBreachCheckSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.passwordBreachService.SetPasswordBreachCheckSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// checkBreachedPassword 는 새 비밀번호가 유출된 비밀번호인지 확인한다. 거절하면 응답하고 false 를 반환한다.
+// 경고만 하는 설정이면 경고 코드 헤더(BREACHED_PASSWORD)를 설정하고 계속 처리한다.
+func checkBreachedPassword(ctx *gin.Context, passwordBreachService *services.PasswordBreachService, password string) bool {
+	result, err := passwordBreachService.CheckPassword(ctx.Request.Context(), password)
+	if err != nil {
+		if errors.Is(err, errors.ErrBreachedPassword) {
+			ctx.Header(middlewares.ErrorCodeHeader, errors.ErrBreachedPassword.Code)
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return false
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return false
+	}
+
+	if result.Breached {
+		ctx.Header(middlewares.WarningCodeHeader, errors.ErrBreachedPassword.Code)
+	}
+
+	return true
+}
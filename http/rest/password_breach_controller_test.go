@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/testdata/testdb"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setUpTestPasswordBreachCheck(t *testing.T, mode, action string) {
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/site/settings/password-breach-check",
+		strings.NewReader(fmt.Sprintf(`{"mode": "%s", "action": "%s"}`, mode, action)), 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func requestTestSignUp(signId, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/members",
+		strings.NewReader(fmt.Sprintf(`{"signId": "%s", "name": "유영모", "password": "%s"}`, signId, password)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPasswordBreachController_range_API_로_확인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	sum := sha1.Sum([]byte("123456"))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	var requestedPaths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPaths = append(requestedPaths, r.URL.Path)
+		fmt.Fprintf(w, "0000000000000000000000000000000000A:0\r\n%s:37359195\r\n", hash[5:])
+	}))
+	defer server.Close()
+	rangeApiUrl := config.Config.PasswordBreach.RangeApiUrl
+	config.Config.PasswordBreach.RangeApiUrl = server.URL + "/range/"
+	defer func() { config.Config.PasswordBreach.RangeApiUrl = rangeApiUrl }()
+
+	// 설정하지 않았으면 확인하지 않는다.
+	rec := requestTestSignUp("breach1@bettercode.kr", "123456")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 0, len(requestedPaths))
+
+	// when
+	setUpTestPasswordBreachCheck(t, constants.PasswordBreachCheckModeOnline, constants.PasswordBreachCheckActionReject)
+	rec = requestTestSignUp("breach2@bettercode.kr", "123456")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "BREACHED_PASSWORD", rec.Header().Get(middlewares.ErrorCodeHeader))
+	// SHA-1 의 앞 5자리만 보낸다.
+	assert.Equal(t, []string{"/range/" + hash[:5]}, requestedPaths)
+
+	var count int64
+	gormDB.Table("members").Where("sign_id = ?", "breach2@bettercode.kr").Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	rec = requestTestSignUp("breach3@bettercode.kr", "correct horse battery staple")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "", rec.Header().Get(middlewares.WarningCodeHeader))
+
+	// 경고만 하면 가입하고 경고 코드를 응답한다.
+	setUpTestPasswordBreachCheck(t, constants.PasswordBreachCheckModeOnline, constants.PasswordBreachCheckActionWarn)
+	rec = requestTestSignUp("breach4@bettercode.kr", "123456")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "BREACHED_PASSWORD", rec.Header().Get(middlewares.WarningCodeHeader))
+
+	// range API 가 응답하지 않으면 막지 않는다.
+	setUpTestPasswordBreachCheck(t, constants.PasswordBreachCheckModeOnline, constants.PasswordBreachCheckActionReject)
+	server.Close()
+	rec = requestTestSignUp("breach5@bettercode.kr", "123456")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestPasswordBreachController_bloom_filter_로_확인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	bloomFilter := security.NewPasswordBloomFilter(10, 0.001)
+	bloomFilter.Add("breached-password-1234")
+	path := filepath.Join(t.TempDir(), "breached-passwords.bloom")
+	file, err := os.Create(path)
+	assert.Nil(t, err)
+	_, err = bloomFilter.WriteTo(file)
+	assert.Nil(t, err)
+	file.Close()
+
+	config.Config.PasswordBreach.BloomFilterPath = path
+	defer func() { config.Config.PasswordBreach.BloomFilterPath = "" }()
+	setUpTestPasswordBreachCheck(t, constants.PasswordBreachCheckModeOffline, constants.PasswordBreachCheckActionReject)
+
+	// when
+	rec := requestTestResourceOwnership(http.MethodPost, "/api/break-glass-accounts",
+		strings.NewReader(`{"signId": "emergency-admin", "password": "breached-password-1234", "memberId": 1}`), 1,
+		[]string{constants.PermissionManageSystemSettings})
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "BREACHED_PASSWORD")
+
+	rec = requestTestResourceOwnership(http.MethodPost, "/api/break-glass-accounts",
+		strings.NewReader(`{"signId": "emergency-admin", "password": "not-breached-password-5678", "memberId": 1}`), 1,
+		[]string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/site/settings/password-breach-check", nil, 1,
+		[]string{constants.PermissionManageSystemSettings})
+	assert.JSONEq(t, `{"mode": "offline", "action": "reject"}`, rec.Body.String())
+}
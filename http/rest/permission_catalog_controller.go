@@ -0,0 +1,134 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PermissionCatalogController struct {
+	routerGroup              *gin.RouterGroup
+	permissionCatalogService *services.PermissionCatalogService
+}
+
+func NewPermissionCatalogController(
+	routerGroup *gin.RouterGroup,
+	permissionCatalogService *services.PermissionCatalogService) *PermissionCatalogController {
+
+	return &PermissionCatalogController{
+		routerGroup:              routerGroup,
+		permissionCatalogService: permissionCatalogService,
+	}
+}
+
+func (c PermissionCatalogController) MapRoutes() {
+	route := c.routerGroup.Group("/permission-catalog")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl, constants.PermissionRegisterPermissionCatalog}),
+		etag.HttpEtagCache(0),
+		c.getCatalog)
+	route.GET("/:namespace", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl, constants.PermissionRegisterPermissionCatalog}),
+		etag.HttpEtagCache(0),
+		c.getNamespace)
+	route.PUT("/:namespace", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl, constants.PermissionRegisterPermissionCatalog}),
+		c.register)
+	route.DELETE("/:namespace", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl, constants.PermissionRegisterPermissionCatalog}),
+		c.deleteNamespace)
+}
+
+func (c PermissionCatalogController) register(ctx *gin.Context) {
+	var registration dtos.PermissionCatalogRegistration
+	if err := ctx.BindJSON(&registration); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.permissionCatalogService.Register(ctx.Request.Context(), ctx.Param("namespace"), registration)
+	if err != nil {
+		if _, ok := err.(*errors.ErrInvalidPermissionCatalog); ok || errors.Is(err, errors.ErrDuplicated) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c PermissionCatalogController) getCatalog(ctx *gin.Context) {
+	entities, err := c.permissionCatalogService.GetCatalog(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toCatalogNamespaces(entities))
+}
+
+func (c PermissionCatalogController) getNamespace(ctx *gin.Context) {
+	entities, err := c.permissionCatalogService.GetNamespace(ctx.Request.Context(), ctx.Param("namespace"))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toCatalogNamespaces(entities)[0])
+}
+
+func (c PermissionCatalogController) deleteNamespace(ctx *gin.Context) {
+	err := c.permissionCatalogService.DeleteNamespace(ctx.Request.Context(), ctx.Param("namespace"))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// toCatalogNamespaces 는 네임스페이스, 그룹 순으로 정렬된 권한을 네임스페이스와 그룹별로 묶는다.
+func (PermissionCatalogController) toCatalogNamespaces(entities []domain.PermissionEntity) []dtos.PermissionCatalogNamespace {
+	namespaces := make([]dtos.PermissionCatalogNamespace, 0)
+	for _, entity := range entities {
+		if len(namespaces) == 0 || namespaces[len(namespaces)-1].Namespace != entity.Namespace {
+			namespaces = append(namespaces, dtos.PermissionCatalogNamespace{
+				Namespace: entity.Namespace,
+				Groups:    make([]dtos.PermissionCatalogGroup, 0),
+			})
+		}
+
+		namespace := &namespaces[len(namespaces)-1]
+		if len(namespace.Groups) == 0 || namespace.Groups[len(namespace.Groups)-1].Name != entity.GroupName {
+			namespace.Groups = append(namespace.Groups, dtos.PermissionCatalogGroup{
+				Name:        entity.GroupName,
+				Permissions: make([]dtos.PermissionCatalogPermission, 0),
+			})
+		}
+
+		group := &namespace.Groups[len(namespace.Groups)-1]
+		group.Permissions = append(group.Permissions, dtos.PermissionCatalogPermission{
+			Id:          entity.ID,
+			Name:        entity.Name,
+			Description: entity.Description,
+		})
+	}
+
+	return namespaces
+}
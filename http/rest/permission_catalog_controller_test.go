@@ -0,0 +1,158 @@
+package rest
+
+import (
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func registerTestPermissionCatalog(requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/permission-catalog/inventory", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":            1,
+		"Permissions":   []string{"REGISTER_PERMISSION_CATALOG"},
+		"PrincipalType": "service-account",
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPermissionCatalogController_register(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := registerTestPermissionCatalog(`{
+		"permissions": [
+			{"name": "VIEW_STOCK", "description": "재고 조회", "group": "재고"},
+			{"name": "ADJUST_STOCK", "description": "재고 조정", "group": "재고"},
+			{"name": "VIEW_ORDER", "description": "주문 조회", "group": "주문"}
+		]
+	}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var result map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &result)
+	assert.Equal(t, float64(3), result["created"])
+
+	req := httptest.NewRequest(http.MethodGet, "/api/permission-catalog/inventory", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"MANAGE_ACCESS_CONTROL"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "inventory", actual["namespace"])
+	groups := actual["groups"].([]interface{})
+	assert.Len(t, groups, 2)
+	assert.Equal(t, "재고", groups[0].(map[string]interface{})["name"])
+	stockPermissions := groups[0].(map[string]interface{})["permissions"].([]interface{})
+	assert.Equal(t, "inventory:ADJUST_STOCK", stockPermissions[0].(map[string]interface{})["name"])
+	assert.Equal(t, "inventory:VIEW_STOCK", stockPermissions[1].(map[string]interface{})["name"])
+
+	var permission rbacDomain.PermissionEntity
+	gormDB.Where("name = ?", "inventory:VIEW_STOCK").First(&permission)
+	assert.Equal(t, "catalog", permission.Type)
+}
+
+func TestPermissionCatalogController_register_변경(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	registerTestPermissionCatalog(`{
+		"permissions": [
+			{"name": "VIEW_STOCK", "description": "재고 조회", "group": "재고"},
+			{"name": "ADJUST_STOCK", "description": "재고 조정", "group": "재고"}
+		]
+	}`)
+
+	var adjustStock rbacDomain.PermissionEntity
+	gormDB.Where("name = ?", "inventory:ADJUST_STOCK").First(&adjustStock)
+	gormDB.Exec("INSERT INTO role_permissions (role_entity_id, permission_entity_id) VALUES (?, ?)", 3, adjustStock.ID)
+
+	// when
+	rec := registerTestPermissionCatalog(`{
+		"permissions": [
+			{"name": "VIEW_STOCK", "description": "재고 목록 조회", "group": "재고"},
+			{"name": "VIEW_ORDER", "description": "주문 조회", "group": "주문"}
+		]
+	}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var result map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &result)
+	assert.Equal(t, float64(1), result["created"])
+	assert.Equal(t, float64(1), result["updated"])
+	assert.Equal(t, float64(1), result["removed"])
+
+	var rolePermissionCount int64
+	gormDB.Table("role_permissions").Where("permission_entity_id = ?", adjustStock.ID).Count(&rolePermissionCount)
+	assert.Equal(t, int64(0), rolePermissionCount)
+
+	var viewStock rbacDomain.PermissionEntity
+	gormDB.Where("name = ?", "inventory:VIEW_STOCK").First(&viewStock)
+	assert.Equal(t, "재고 목록 조회", viewStock.Description)
+}
+
+func TestPermissionCatalogController_register_잘못된_네임스페이스(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/permission-catalog/Inventory", strings.NewReader(`{"permissions": [{"name": "VIEW_STOCK"}]}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"REGISTER_PERMISSION_CATALOG"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPermissionCatalogController_카탈로그_권한_수정_불가(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	registerTestPermissionCatalog(`{"permissions": [{"name": "VIEW_STOCK", "description": "재고 조회"}]}`)
+
+	var permission rbacDomain.PermissionEntity
+	gormDB.Where("name = ?", "inventory:VIEW_STOCK").First(&permission)
+
+	// given
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/access-control/permissions/%v", permission.ID), nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"MANAGE_ACCESS_CONTROL"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,104 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// PluginSettingController 는 확장 기능의 설정 API 이다. Namespace 별 권한은 PluginSettingService 에서 확인한다.
+type PluginSettingController struct {
+	routerGroup          *gin.RouterGroup
+	pluginSettingService *services.PluginSettingService
+}
+
+func NewPluginSettingController(
+	routerGroup *gin.RouterGroup,
+	pluginSettingService *services.PluginSettingService) *PluginSettingController {
+
+	return &PluginSettingController{
+		routerGroup:          routerGroup,
+		pluginSettingService: pluginSettingService,
+	}
+}
+
+func (c PluginSettingController) MapRoutes() {
+	route := c.routerGroup.Group("/settings")
+	route.GET("", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getPluginSettings)
+	route.GET("/:namespace", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getPluginSetting)
+	route.PUT("/:namespace", middlewares.PermissionChecker([]string{"*"}),
+		c.setPluginSetting)
+	route.DELETE("/:namespace", middlewares.PermissionChecker([]string{"*"}),
+		c.deletePluginSetting)
+}
+
+func (c PluginSettingController) getPluginSettings(ctx *gin.Context) {
+	settings, err := c.pluginSettingService.GetPluginSettings(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, settings)
+}
+
+func (c PluginSettingController) getPluginSetting(ctx *gin.Context) {
+	setting, err := c.pluginSettingService.GetPluginSetting(ctx.Request.Context(), ctx.Param("namespace"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+// setPluginSetting 은 요청 본문(JSON)을 그대로 Namespace 의 값으로 저장한다.
+func (c PluginSettingController) setPluginSetting(ctx *gin.Context) {
+	value, err := ctx.GetRawData()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	setting, err := c.pluginSettingService.SetPluginSetting(ctx.Request.Context(), ctx.Param("namespace"), value)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c PluginSettingController) deletePluginSetting(ctx *gin.Context) {
+	if err := c.pluginSettingService.DeletePluginSetting(ctx.Request.Context(), ctx.Param("namespace")); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (PluginSettingController) handleError(ctx *gin.Context, err error) {
+	if _, ok := err.(*errors.ErrInvalidPluginSetting); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+		return
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		ctx.Status(http.StatusNotFound)
+	case errors.Is(err, errors.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: "Can't access this API"})
+	default:
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+	}
+}
@@ -0,0 +1,146 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/services"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func init() {
+	services.RegisterPluginSettingNamespace("test-banner", services.PluginSettingNamespace{
+		Schema: `{
+			"type": "object",
+			"required": ["message"],
+			"additionalProperties": false,
+			"properties": {
+				"message": {"type": "string", "maxLength": 20},
+				"level": {"enum": ["info", "warning"]},
+				"displaySeconds": {"type": "integer", "minimum": 1}
+			}
+		}`,
+		ReadPermissions: []string{"*"},
+	})
+	services.RegisterPluginSettingNamespace("test-sync", services.PluginSettingNamespace{})
+}
+
+func requestPluginSetting(method string, target string, requestBody string, permissions []string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPluginSettingController_setPluginSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	var mutex sync.Mutex
+	events := make([]dtos.Event, 0)
+	adapters.EventBusAdapter().Subscribe(func(event dtos.Event) {
+		if event.Type == constants.EventTypePluginSetting {
+			mutex.Lock()
+			events = append(events, event)
+			mutex.Unlock()
+		}
+	})
+
+	// when
+	rec := requestPluginSetting(http.MethodPut, "/api/settings/test-banner", `{"message": "점검 예정", "level": "info"}`,
+		[]string{constants.PermissionManageSystemSettings})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = requestPluginSetting(http.MethodGet, "/api/settings/test-banner", "", []string{constants.PermissionManageMembers})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var setting dtos.PluginSetting
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &setting))
+	assert.JSONEq(t, `{"message": "점검 예정", "level": "info"}`, string(setting.Value))
+	assert.False(t, setting.Writable)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, constants.PluginSettingActionChanged, events[0].Action)
+	assert.Equal(t, "test-banner", events[0].Detail)
+}
+
+func TestPluginSettingController_스키마에_맞지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	testCases := []string{
+		`{"level": "info"}`,
+		`{"message": "점검", "level": "error"}`,
+		`{"message": "점검", "displaySeconds": 1.5}`,
+		`{"message": "점검", "color": "red"}`,
+		`{"message": 1}`,
+		`not json`,
+	}
+
+	for _, requestBody := range testCases {
+		// when
+		rec := requestPluginSetting(http.MethodPut, "/api/settings/test-banner", requestBody, []string{constants.PermissionManageSystemSettings})
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, requestBody)
+	}
+}
+
+func TestPluginSettingController_권한(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestPluginSetting(http.MethodPut, "/api/settings/test-sync", `{"enabled": true}`, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// when
+	readRec := requestPluginSetting(http.MethodGet, "/api/settings/test-sync", "", []string{constants.PermissionManageMembers})
+	writeRec := requestPluginSetting(http.MethodPut, "/api/settings/test-sync", `{}`, []string{constants.PermissionManageMembers})
+	listRec := requestPluginSetting(http.MethodGet, "/api/settings", "", []string{constants.PermissionManageMembers})
+	unknownRec := requestPluginSetting(http.MethodGet, "/api/settings/unknown", "", []string{constants.PermissionManageSystemSettings})
+
+	// then
+	assert.Equal(t, http.StatusForbidden, readRec.Code)
+	assert.Equal(t, http.StatusForbidden, writeRec.Code)
+	assert.Equal(t, http.StatusNotFound, unknownRec.Code)
+
+	var settings []dtos.PluginSetting
+	assert.NoError(t, json.Unmarshal(listRec.Body.Bytes(), &settings))
+	assert.Equal(t, 1, len(settings))
+	assert.Equal(t, "test-banner", settings[0].Namespace)
+	assert.Equal(t, "null", string(settings[0].Value))
+}
+
+func TestPluginSettingController_deletePluginSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestPluginSetting(http.MethodPut, "/api/settings/test-sync", `{"enabled": true}`, []string{constants.PermissionManageSystemSettings})
+
+	// when
+	rec := requestPluginSetting(http.MethodDelete, "/api/settings/test-sync", "", []string{constants.PermissionManageSystemSettings})
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestPluginSetting(http.MethodGet, "/api/settings/test-sync", "", []string{constants.PermissionManageSystemSettings})
+	var setting dtos.PluginSetting
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &setting))
+	assert.Equal(t, "null", string(setting.Value))
+}
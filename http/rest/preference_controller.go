@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PreferenceController struct {
+	routerGroup       *gin.RouterGroup
+	preferenceService *services.PreferenceService
+}
+
+func NewPreferenceController(
+	routerGroup *gin.RouterGroup,
+	preferenceService *services.PreferenceService) *PreferenceController {
+
+	return &PreferenceController{
+		routerGroup:       routerGroup,
+		preferenceService: preferenceService,
+	}
+}
+
+func (c PreferenceController) MapRoutes() {
+	route := c.routerGroup.Group("/members/me/preferences")
+	route.GET("", middlewares.PermissionChecker([]string{"*"}),
+		c.getMyPreferences)
+	route.PUT("", middlewares.PermissionChecker([]string{"*"}),
+		c.updateMyPreferences)
+}
+
+func (c PreferenceController) getMyPreferences(ctx *gin.Context) {
+	preferences, err := c.preferenceService.GetMyPreferences(ctx.Request.Context())
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, preferences)
+}
+
+func (c PreferenceController) updateMyPreferences(ctx *gin.Context) {
+	var preferences dtos.MemberPreferences
+	if err := ctx.BindJSON(&preferences); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.preferenceService.UpdateMyPreferences(ctx.Request.Context(), preferences); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (PreferenceController) handleError(ctx *gin.Context, err error) {
+	if e, ok := err.(*errors.ErrInvalidPreference); ok {
+		ctx.JSON(http.StatusBadRequest, e.Error())
+		return
+	}
+
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"better-admin-backend-service/testdata/testdb"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestTestPreferences(t *testing.T, method string, requestBody string) *httptest.ResponseRecorder {
+	var req *http.Request
+	if len(requestBody) > 0 {
+		req = httptest.NewRequest(method, "/api/members/me/preferences", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req = httptest.NewRequest(method, "/api/members/me/preferences", nil)
+	}
+
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	assert.Nil(t, err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPreferenceController_getMyPreferences(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestTestPreferences(t, http.MethodGet, "")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"theme": {"mode": "dark"}}`, rec.Body.String())
+}
+
+func TestPreferenceController_updateMyPreferences(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"theme": {"mode": "light", "locale": "ko"},
+		"table-columns": {"tables": {"members": {"columns": ["name", "type"], "pageSize": 20}}},
+		"dashboard-widgets": ["calendar", "todo"]
+	}`
+
+	// when
+	rec := requestTestPreferences(t, http.MethodPut, requestBody)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestTestPreferences(t, http.MethodGet, "")
+	assert.JSONEq(t, `{
+		"theme": {"mode": "light", "locale": "ko"},
+		"table-columns": {"tables": {"members": {"columns": ["name", "type"], "pageSize": 20}}},
+		"dashboard-widgets": ["calendar", "todo"]
+	}`, rec.Body.String())
+
+	// 요청에 없는 Namespace 는 유지되고, null 인 Namespace 는 삭제된다.
+	rec = requestTestPreferences(t, http.MethodPut, `{"theme": null, "notification": {"optOuts": ["approval"]}}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestTestPreferences(t, http.MethodGet, "")
+	assert.JSONEq(t, `{
+		"table-columns": {"tables": {"members": {"columns": ["name", "type"], "pageSize": 20}}},
+		"dashboard-widgets": ["calendar", "todo"],
+		"notification": {"optOuts": ["approval"]}
+	}`, rec.Body.String())
+}
+
+func TestPreferenceController_updateMyPreferences_Bad_Request_스키마_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestTestPreferences(t, http.MethodPut, `{"theme": {"mode": "blue"}}`)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestTestPreferences(t, http.MethodPut, `{"theme": {"unknown": true}}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestTestPreferences(t, http.MethodPut, `{"Invalid Namespace": {}}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 실패한 요청은 저장하지 않는다.
+	rec = requestTestPreferences(t, http.MethodGet, "")
+	assert.JSONEq(t, `{"theme": {"mode": "dark"}}`, rec.Body.String())
+}
+
+func TestPreferenceController_updateMyPreferences_Bad_Request_크기_제한(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := fmt.Sprintf(`{"large": "%s"}`, strings.Repeat("a", 17*1024))
+
+	// when
+	rec := requestTestPreferences(t, http.MethodPut, requestBody)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPreferenceController_서비스_계정은_사용할_수_없다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	// 서비스 계정의 Id(1)는 멤버(1)의 Id 와 같다.
+	rec := requestAsServiceAccount(http.MethodGet, "/api/members/me/preferences", "", 1, []string{})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = requestAsServiceAccount(http.MethodPut, "/api/members/me/preferences", `{"theme": {"mode": "light"}}`, 1, []string{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 멤버의 환경 설정은 그대로이다.
+	rec = requestTestPreferences(t, http.MethodGet, "")
+	assert.JSONEq(t, `{"theme": {"mode": "dark"}}`, rec.Body.String())
+}
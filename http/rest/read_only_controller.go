@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ReadOnlyController struct {
+	routerGroup     *gin.RouterGroup
+	readOnlyService *services.ReadOnlyService
+}
+
+func NewReadOnlyController(
+	routerGroup *gin.RouterGroup,
+	readOnlyService *services.ReadOnlyService) *ReadOnlyController {
+
+	return &ReadOnlyController{
+		routerGroup:     routerGroup,
+		readOnlyService: readOnlyService,
+	}
+}
+
+func (c ReadOnlyController) MapRoutes() {
+	route := c.routerGroup.Group("/site")
+	route.GET("/settings/read-only",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getReadOnlySetting)
+	route.PUT("/settings/read-only",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setReadOnlySetting)
+}
+
+func (c ReadOnlyController) getReadOnlySetting(ctx *gin.Context) {
+	setting, err := c.readOnlyService.GetReadOnlySetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c ReadOnlyController) setReadOnlySetting(ctx *gin.Context) {
+	var setting dtos.ReadOnlySetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.readOnlyService.SetReadOnlySetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/testdata/testdb"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadOnlyController_전체_읽기_전용(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	manageSystemSettings := []string{constants.PermissionManageSystemSettings}
+
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/site/settings/read-only",
+		strings.NewReader(`{"enabled": true, "message": "장애 대응 중"}`), 1, manageSystemSettings)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	rec = requestTestResourceOwnership(http.MethodPut, "/api/site/settings/login-notification",
+		strings.NewReader(`{"enabled": true, "channels": ["mail"]}`), 1, manageSystemSettings)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, "READ_ONLY", rec.Header().Get(middlewares.ErrorCodeHeader))
+	assert.Contains(t, rec.Body.String(), "장애 대응 중")
+
+	var eventCount int64
+	gormDB.Raw("SELECT count(*) FROM security_events WHERE type = 'read-only-write' AND member_id = 1").Scan(&eventCount)
+	assert.Equal(t, int64(1), eventCount)
+
+	// 조회와 로그인은 할 수 있다.
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/site/settings/login-notification", nil, 1, manageSystemSettings)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, http.StatusOK, loginWithUserAgent("siteadm", "123456", "browser").Code)
+
+	// 읽기 전용 설정은 끌 수 있다.
+	rec = requestTestResourceOwnership(http.MethodPut, "/api/site/settings/read-only",
+		strings.NewReader(`{"enabled": false}`), 1, manageSystemSettings)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestTestResourceOwnership(http.MethodPut, "/api/site/settings/login-notification",
+		strings.NewReader(`{"enabled": true, "channels": ["mail"]}`), 1, manageSystemSettings)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestReadOnlyController_역할별_읽기_전용(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/site/settings/read-only",
+		strings.NewReader(`{"enabled": false, "roleNames": ["감사인"]}`), 1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// given
+	requestAs := func(roles []string, requestBody string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/api/site/settings/read-only", strings.NewReader(requestBody))
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          1,
+			"Roles":       roles,
+			"Permissions": []string{constants.PermissionManageSystemSettings},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// when
+	// 역할로 읽기 전용인 사용자는 읽기 전용 설정도 바꿀 수 없다.
+	rec = requestAs([]string{"감사인"}, `{"enabled": false, "roleNames": []}`)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, "READ_ONLY", rec.Header().Get(middlewares.ErrorCodeHeader))
+
+	rec = requestAs([]string{"시스템 관리자"}, `{"enabled": false, "roleNames": []}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
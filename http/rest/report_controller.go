@@ -0,0 +1,272 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/report/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+type ReportController struct {
+	routerGroup   *gin.RouterGroup
+	reportService *services.ReportService
+}
+
+func NewReportController(
+	routerGroup *gin.RouterGroup,
+	reportService *services.ReportService) *ReportController {
+
+	return &ReportController{
+		routerGroup:   routerGroup,
+		reportService: reportService,
+	}
+}
+
+func (c ReportController) MapRoutes() {
+	route := c.routerGroup.Group("/reports")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.createReport)
+	// MANAGE_OWN_RESOURCES 권한만 있으면 소유한 리포트를 조회하고 삭제할 수 있다.
+	// 수정과 실행은 리포트 대상 데이터에 접근하므로 시스템 설정 관리 권한이 필요하다.
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		etag.HttpEtagCache(0),
+		c.getReports)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.reportService.AuthorizeOwner),
+		etag.HttpEtagCache(0),
+		c.getReport)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.updateReport)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.reportService.AuthorizeOwner),
+		c.deleteReport)
+	route.POST("/:id/runs", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.runReport)
+	route.GET("/:id/runs", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getReportRuns)
+	route.GET("/:id/runs/:runId/download", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.downloadReportRun)
+}
+
+func (c ReportController) createReport(ctx *gin.Context) {
+	var information dtos.ReportInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.reportService.CreateReport(ctx.Request.Context(), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.toReportInformation(entity))
+}
+
+func (c ReportController) getReports(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.reportService.GetReports(ctx.Request.Context(), listFiltersOf(ctx), pageable)
+	if err != nil {
+		if errors.Is(err, errors.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var reports = make([]dtos.ReportInformation, 0)
+	for _, entity := range entities {
+		reports = append(reports, c.toReportInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     reports,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (c ReportController) getReport(ctx *gin.Context) {
+	reportId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.reportService.GetReport(ctx.Request.Context(), uint(reportId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toReportInformation(entity))
+}
+
+func (c ReportController) updateReport(ctx *gin.Context) {
+	reportId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.ReportInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.reportService.UpdateReport(ctx.Request.Context(), uint(reportId), information); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c ReportController) deleteReport(ctx *gin.Context) {
+	reportId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.reportService.DeleteReport(ctx.Request.Context(), uint(reportId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// runReport 는 리포트를 바로 실행한다. deliver=true 이면 설정된 메일/웹훅으로도 보낸다.
+func (c ReportController) runReport(ctx *gin.Context) {
+	reportId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	deliver := ctx.Query("deliver") == "true"
+	entity, err := c.reportService.RunReport(ctx.Request.Context(), uint(reportId), deliver)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.toReportRunInformation(entity))
+}
+
+func (c ReportController) getReportRuns(ctx *gin.Context) {
+	reportId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pageable := dtos.NewPageableFromRequest(ctx)
+	entities, totalCount, err := c.reportService.GetReportRuns(ctx.Request.Context(), uint(reportId), pageable)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	var runs = make([]dtos.ReportRunInformation, 0)
+	for _, entity := range entities {
+		runs = append(runs, c.toReportRunInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     runs,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (c ReportController) downloadReportRun(ctx *gin.Context) {
+	reportId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	runId, err := strconv.ParseInt(ctx.Param("runId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.reportService.GetReportRun(ctx.Request.Context(), uint(reportId), uint(runId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	// 실패한 실행에는 내려받을 결과 파일이 없다.
+	if !entity.IsSucceeded() {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	file := entity.GetFile()
+	ctx.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	ctx.Data(http.StatusOK, file.ContentType, file.Content)
+}
+
+func (ReportController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidReport); ok {
+		ctx.JSON(http.StatusBadRequest, e.Error())
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+func (ReportController) toReportInformation(entity domain.ReportEntity) dtos.ReportInformation {
+	return dtos.ReportInformation{
+		Id:          entity.ID,
+		Name:        entity.Name,
+		Description: entity.Description,
+		Definition:  entity.GetDefinition(),
+		Format:      entity.Format,
+		Schedule:    entity.Schedule,
+		Delivery:    entity.GetDelivery(),
+		NextRunAt:   entity.NextRunAt,
+		OwnerId:     entity.GetOwnerId(),
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
+
+func (ReportController) toReportRunInformation(entity domain.ReportRunEntity) dtos.ReportRunInformation {
+	return dtos.ReportRunInformation{
+		Id:             entity.ID,
+		ReportId:       entity.ReportId,
+		Trigger:        entity.TriggerType,
+		Status:         entity.Status,
+		DeliveryStatus: entity.DeliveryStatus,
+		FileName:       entity.FileName,
+		RowCount:       entity.RowCount,
+		ErrorMessage:   entity.ErrorMessage,
+		RequestedBy:    entity.RequestedBy,
+		StartedAt:      entity.StartedAt,
+		FinishedAt:     entity.FinishedAt,
+	}
+}
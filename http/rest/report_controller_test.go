@@ -0,0 +1,289 @@
+package rest
+
+import (
+	"archive/zip"
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/testdata/testdb"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeWebHookSender struct {
+	requests []dtos.OutgoingWebHookRequest
+}
+
+func (f *fakeWebHookSender) Send(request dtos.OutgoingWebHookRequest) error {
+	f.requests = append(f.requests, request)
+	return nil
+}
+
+func requestTestReport(method, url string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, body)
+	req.Header.Set("Content-Type", "application/json")
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func runTestReport(t *testing.T, reportId uint) dtos.ReportRunInformation {
+	rec := requestTestReport(http.MethodPost, fmt.Sprintf("/api/reports/%v/runs", reportId), nil)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var run dtos.ReportRunInformation
+	json.Unmarshal(rec.Body.Bytes(), &run)
+	return run
+}
+
+func TestReportController_runReport_결과_내려받기(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	run := runTestReport(t, 1)
+
+	// then
+	assert.Equal(t, constants.ReportRunStatusSucceeded, run.Status)
+	assert.Equal(t, constants.ReportDeliveryStatusNone, run.DeliveryStatus)
+	assert.Equal(t, 3, run.RowCount)
+
+	rec := requestTestReport(http.MethodGet, fmt.Sprintf("/api/reports/1/runs/%v/download", run.Id), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment")
+	assert.Equal(t, "\xEF\xBB\xBFid,signId,name\n1,siteadm,사이트 관리자\n2,,유영모\n3,ymyoo,유영모2\n", rec.Body.String())
+}
+
+func TestReportController_runReport_마스킹_정책(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Exec("UPDATE members SET phone = ? WHERE id = ?", "01012345678", 3)
+	setUpDataMaskingSetting(t, `{"policies": [{"field": "name", "method": "name"}, {"field": "phone", "method": "phone"}]}`)
+
+	// given
+	requestBody := `{
+		"name": "멤버 연락처",
+		"format": "csv",
+		"definition": {"entity": "members", "columns": ["signId", "name", "phone"], "filters": [{"column": "id", "operator": "eq", "value": "3"}]}
+	}`
+	rec := requestTestReport(http.MethodPost, "/api/reports", strings.NewReader(requestBody))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var report dtos.ReportInformation
+	json.Unmarshal(rec.Body.Bytes(), &report)
+
+	// when
+	run := runTestReport(t, report.Id)
+
+	// then
+	rec = requestTestReport(http.MethodGet, fmt.Sprintf("/api/reports/%v/runs/%v/download", report.Id, run.Id), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "\xEF\xBB\xBFsignId,name,phone\nymyoo,유**2,010****5678\n", rec.Body.String())
+}
+
+func TestReportController_createReport_집계_XLSX(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"name": "상태별 멤버 수",
+		"format": "xlsx",
+		"definition": {
+			"entity": "members",
+			"aggregation": {"groupBy": ["status"], "function": "count"}
+		}
+	}`
+	rec := requestTestReport(http.MethodPost, "/api/reports", strings.NewReader(requestBody))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var report dtos.ReportInformation
+	json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.Nil(t, report.NextRunAt)
+
+	// when
+	run := runTestReport(t, report.Id)
+
+	// then
+	assert.Equal(t, constants.ReportRunStatusSucceeded, run.Status)
+	assert.Equal(t, 2, run.RowCount)
+
+	rec = requestTestReport(http.MethodGet, fmt.Sprintf("/api/reports/%v/runs/%v/download", report.Id, run.Id), nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	zipReader, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	assert.Nil(t, err)
+
+	var sheet string
+	for _, file := range zipReader.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, _ := file.Open()
+			content, _ := io.ReadAll(reader)
+			sheet = string(content)
+		}
+	}
+	assert.Contains(t, sheet, "<t xml:space=\"preserve\">applied</t></is></c><c t=\"inlineStr\"><is><t xml:space=\"preserve\">1</t>")
+	assert.Contains(t, sheet, "<t xml:space=\"preserve\">approved</t></is></c><c t=\"inlineStr\"><is><t xml:space=\"preserve\">3</t>")
+}
+
+func TestReportController_createReport_잘못된_정의(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	tests := []struct {
+		requestBody string
+		expected    string
+	}{
+		{`{"name": "r", "format": "csv", "definition": {"entity": "passwords"}}`, "\"unsupported entity: passwords\""},
+		{`{"name": "r", "format": "csv", "definition": {"entity": "members", "columns": ["password"]}}`, "\"unknown column: password\""},
+		{`{"name": "r", "format": "csv", "definition": {"entity": "members"}, "schedule": "0 25 * * *"}`, "\"cron value out of range: \\\"25\\\"\""},
+		{`{"name": "r", "format": "csv", "definition": {"entity": "members"}, "delivery": {"channel": "email"}}`, "\"recipients are required for email delivery\""},
+	}
+
+	for _, test := range tests {
+		// when
+		rec := requestTestReport(http.MethodPost, "/api/reports", strings.NewReader(test.requestBody))
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, test.expected, rec.Body.String())
+	}
+}
+
+func TestReportController_getReportRuns(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	runTestReport(t, 1)
+	lastRun := runTestReport(t, 1)
+
+	// when
+	rec := requestTestReport(http.MethodGet, "/api/reports/1/runs", nil)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(2), actual["totalCount"])
+	latest := actual["result"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(lastRun.Id), latest["id"])
+	assert.Equal(t, constants.ReportRunTriggerManual, latest["trigger"])
+	assert.Equal(t, float64(1), latest["requestedBy"])
+}
+
+func TestReportService_ProcessScheduledReports_메일_전송(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+
+	// given
+	requestBody := `{
+		"name": "일일 멤버 목록",
+		"format": "csv",
+		"schedule": "0 9 * * *",
+		"definition": {"entity": "members", "columns": ["signId"], "filters": [{"column": "signId", "operator": "contains", "value": "ymyoo"}]},
+		"delivery": {"channel": "email", "recipients": ["admin@bettercode.kr"]}
+	}`
+	rec := requestTestReport(http.MethodPost, "/api/reports", strings.NewReader(requestBody))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var report dtos.ReportInformation
+	json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.NotNil(t, report.NextRunAt)
+	assert.Equal(t, 9, report.NextRunAt.Hour())
+
+	gormDB.Exec("UPDATE reports SET next_run_at = ? WHERE id = ?", time.Now().Add(-time.Minute), report.Id)
+
+	// when
+	err := NewContainer().ReportService.ProcessScheduledReports(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(mailSender.messages))
+	message := mailSender.messages[0]
+	assert.Equal(t, []string{"admin@bettercode.kr"}, message.To)
+	assert.Equal(t, "[리포트] 일일 멤버 목록", message.Subject)
+	assert.Equal(t, 1, len(message.Attachments))
+	assert.Equal(t, "\xEF\xBB\xBFsignId\nymyoo\nymyoo3\n", string(message.Attachments[0].Content))
+
+	var nextRunAt time.Time
+	gormDB.Raw("SELECT next_run_at FROM reports WHERE id = ?", report.Id).Scan(&nextRunAt)
+	assert.True(t, nextRunAt.After(time.Now()))
+
+	var trigger, deliveryStatus string
+	gormDB.Raw("SELECT trigger_type FROM report_runs WHERE report_id = ?", report.Id).Scan(&trigger)
+	gormDB.Raw("SELECT delivery_status FROM report_runs WHERE report_id = ?", report.Id).Scan(&deliveryStatus)
+	assert.Equal(t, constants.ReportRunTriggerScheduled, trigger)
+	assert.Equal(t, constants.ReportDeliveryStatusDelivered, deliveryStatus)
+
+	// 다음 실행 시간 전에는 다시 실행하지 않는다.
+	err = NewContainer().ReportService.ProcessScheduledReports(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(mailSender.messages))
+}
+
+func TestReportController_runReport_웹훅_전송(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	webHookSender := &fakeWebHookSender{}
+	adapters.OutgoingWebHookAdapter().SetSender(webHookSender)
+	defer adapters.OutgoingWebHookAdapter().SetSender(nil)
+
+	// given
+	requestBody := `{
+		"name": "감사 로그",
+		"format": "csv",
+		"definition": {"entity": "audit-logs", "columns": ["action"]},
+		"delivery": {"channel": "webhook", "webHookUrl": "https://hooks.bettercode.kr/reports"}
+	}`
+	rec := requestTestReport(http.MethodPost, "/api/reports", strings.NewReader(requestBody))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var report dtos.ReportInformation
+	json.Unmarshal(rec.Body.Bytes(), &report)
+
+	// when
+	rec = requestTestReport(http.MethodPost, fmt.Sprintf("/api/reports/%v/runs?deliver=true", report.Id), nil)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var run dtos.ReportRunInformation
+	json.Unmarshal(rec.Body.Bytes(), &run)
+	assert.Equal(t, constants.ReportDeliveryStatusDelivered, run.DeliveryStatus)
+	assert.Equal(t, 1, len(webHookSender.requests))
+	assert.Equal(t, "https://hooks.bettercode.kr/reports", webHookSender.requests[0].Url)
+	assert.Equal(t, fmt.Sprint(report.Id), webHookSender.requests[0].Headers["X-Report-Id"])
+}
+
+func TestReportController_권한_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/reports", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
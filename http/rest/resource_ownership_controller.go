@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"context"
+	"github.com/gin-gonic/gin"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"strconv"
+)
+
+type ResourceOwnershipController struct {
+	routerGroup              *gin.RouterGroup
+	resourceOwnershipService *services.ResourceOwnershipService
+}
+
+func NewResourceOwnershipController(
+	routerGroup *gin.RouterGroup,
+	resourceOwnershipService *services.ResourceOwnershipService) *ResourceOwnershipController {
+
+	return &ResourceOwnershipController{
+		routerGroup:              routerGroup,
+		resourceOwnershipService: resourceOwnershipService,
+	}
+}
+
+func (c ResourceOwnershipController) MapRoutes() {
+	route := c.routerGroup.Group("/resource-ownership")
+	route.GET("/members/:id", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.getOwnedResources)
+	route.POST("/transfer", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.transferMemberResources)
+	// 리소스마다 소유자이거나 리소스 관리 권한이 있는지 확인한다.
+	route.POST("/transfer/resources", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings,
+		constants.PermissionManageMembers, constants.PermissionManageOwnResources}),
+		c.transferResources)
+}
+
+func (c ResourceOwnershipController) getOwnedResources(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resources, err := c.resourceOwnershipService.GetOwnedResources(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resources)
+}
+
+func (c ResourceOwnershipController) transferMemberResources(ctx *gin.Context) {
+	var transfer dtos.ResourceOwnershipTransfer
+	if err := ctx.BindJSON(&transfer); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resources, err := c.resourceOwnershipService.TransferMemberResources(ctx.Request.Context(), transfer)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resources)
+}
+
+func (c ResourceOwnershipController) transferResources(ctx *gin.Context) {
+	var transfer dtos.ResourceOwnershipResourcesTransfer
+	if err := ctx.BindJSON(&transfer); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resources, err := c.resourceOwnershipService.TransferResources(ctx.Request.Context(), transfer)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resources)
+}
+
+func (ResourceOwnershipController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrForbidden) {
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	var invalidOwnershipTransfer *errors.ErrInvalidOwnershipTransfer
+	if pkgerrors.As(err, &invalidOwnershipTransfer) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+// resourceOwnerChecker 는 :id 리소스의 소유자이거나 리소스 관리 권한이 있는 경우에만 다음 handler 를 실행한다.
+// PermissionChecker 다음에 등록한다.
+func resourceOwnerChecker(authorizeOwner func(ctx context.Context, id uint) error) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		resourceId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			ctx.Abort()
+			return
+		}
+
+		if err := authorizeOwner(ctx.Request.Context(), uint(resourceId)); err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				ctx.Status(http.StatusNotFound)
+			} else if errors.Is(err, errors.ErrForbidden) {
+				ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			} else {
+				helpers.ErrorHelper().InternalServerError(ctx, err)
+			}
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// listFiltersOf 는 목록 조회 조건이다. ownedByMe=true 이면 요청한 멤버가 소유한 리소스만 조회한다.
+func listFiltersOf(ctx *gin.Context) map[string]interface{} {
+	filters := map[string]interface{}{}
+	if ctx.Query("ownedByMe") == "true" {
+		filters["ownedByMe"] = true
+	}
+
+	return filters
+}
@@ -0,0 +1,142 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestTestResourceOwnership(method, url string, body io.Reader, memberId uint, permissions []string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, body)
+	req.Header.Set("Content-Type", "application/json")
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestResourceOwnershipController_소유한_리소스만_관리(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	ownResources := []string{constants.PermissionManageOwnResources}
+
+	// given
+	// 멤버 3 은 아직 소유한 웹훅이 없다.
+	rec := requestTestResourceOwnership(http.MethodGet, "/api/web-hooks", nil, 3, ownResources)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"totalCount":0`)
+
+	// 소유하지 않은 웹훅은 넘길 수 없다.
+	rec = requestTestResourceOwnership(http.MethodPost, "/api/resource-ownership/transfer/resources",
+		strings.NewReader(`{"toOwnerId": 3, "resources": [{"type": "web-hook", "id": 1}]}`), 3, ownResources)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// when
+	rec = requestTestResourceOwnership(http.MethodPost, "/api/resource-ownership/transfer/resources",
+		strings.NewReader(`{"toOwnerId": 3, "resources": [{"type": "web-hook", "id": 1}]}`), 1, []string{constants.PermissionManageSystemSettings})
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var transferred []dtos.OwnedResource
+	json.Unmarshal(rec.Body.Bytes(), &transferred)
+	assert.Equal(t, []dtos.OwnedResource{{Type: constants.OwnedResourceTypeWebHook, Id: 1, Name: "테스트 웹훅"}}, transferred)
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/web-hooks", nil, 3, ownResources)
+	assert.Contains(t, rec.Body.String(), `"totalCount":1`)
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/web-hooks/1", nil, 3, ownResources)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"ownerId":3`)
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/web-hooks/2", nil, 3, ownResources)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// 관리자도 ownedByMe 로 소유한 리소스만 볼 수 있다.
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/web-hooks?ownedByMe=true", nil, 1, []string{constants.PermissionManageSystemSettings})
+	assert.Contains(t, rec.Body.String(), `"totalCount":2`)
+
+	// 리포트 수정에는 시스템 설정 관리 권한이 필요하다.
+	rec = requestTestResourceOwnership(http.MethodPut, "/api/reports/1", strings.NewReader(`{}`), 3, ownResources)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestResourceOwnershipController_transferMemberResources(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	manageMembers := []string{constants.PermissionManageMembers}
+
+	// when
+	rec := requestTestResourceOwnership(http.MethodPost, "/api/resource-ownership/transfer",
+		strings.NewReader(`{"fromOwnerId": 1, "toOwnerId": 3, "resourceTypes": ["web-hook", "report"]}`), 2, manageMembers)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var transferred []dtos.OwnedResource
+	json.Unmarshal(rec.Body.Bytes(), &transferred)
+	assert.Equal(t, 4, len(transferred))
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/resource-ownership/members/3", nil, 2, manageMembers)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var owned []dtos.OwnedResource
+	json.Unmarshal(rec.Body.Bytes(), &owned)
+	assert.Equal(t, transferred, owned)
+
+	// 서비스 계정과 세그먼트는 그대로 멤버 1 이 소유한다.
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/resource-ownership/members/1", nil, 2, manageMembers)
+	json.Unmarshal(rec.Body.Bytes(), &owned)
+	assert.Equal(t, 2, len(owned))
+
+	var auditCount int64
+	gormDB.Table("audit_logs").Where("action = ? AND target_id = ?", constants.AuditActionResourceOwnershipTransferred, 3).Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestResourceOwnershipController_transferMemberResources_잘못된_요청(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	manageMembers := []string{constants.PermissionManageMembers}
+
+	// 승인되지 않은 멤버(4)에게는 넘길 수 없다.
+	rec := requestTestResourceOwnership(http.MethodPost, "/api/resource-ownership/transfer",
+		strings.NewReader(`{"fromOwnerId": 1, "toOwnerId": 4}`), 2, manageMembers)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_OWNERSHIP_TRANSFER")
+
+	rec = requestTestResourceOwnership(http.MethodPost, "/api/resource-ownership/transfer",
+		strings.NewReader(`{"fromOwnerId": 1, "toOwnerId": 3, "resourceTypes": ["role"]}`), 2, manageMembers)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_OWNERSHIP_TRANSFER")
+}
+
+func TestResourceOwnershipController_서비스_계정은_소유자가_아니다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	ownResources := []string{constants.PermissionManageOwnResources}
+
+	// when
+	// 서비스 계정의 Id(1)는 웹훅을 소유한 멤버(1)의 Id 와 같다.
+	rec := requestAsServiceAccount(http.MethodGet, "/api/web-hooks", "", 1, ownResources)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = requestAsServiceAccount(http.MethodGet, "/api/web-hooks/1", "", 1, ownResources)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = requestAsServiceAccount(http.MethodPost, "/api/resource-ownership/transfer/resources",
+		`{"toOwnerId": 3, "resources": [{"type": "web-hook", "id": 1}]}`, 1, ownResources)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
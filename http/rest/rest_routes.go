@@ -1,56 +1,418 @@
 package rest
 
 import (
-	memberRepository "better-admin-backend-service/member/repository"
-	organizationRepository "better-admin-backend-service/organization/repository"
-	rbacRepository "better-admin-backend-service/rbac/repository"
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/consumer"
+	"better-admin-backend-service/scheduler"
+	"better-admin-backend-service/security"
 	"better-admin-backend-service/services"
-	siteRepository "better-admin-backend-service/site/repository"
-	webHookRepository "better-admin-backend-service/webhook/repository"
+	"context"
 	"github.com/gin-gonic/gin"
+	"time"
 )
 
 type Router struct {
 }
 
 func (Router) MapRoutes(routerGroup *gin.RouterGroup) {
-	rbacService := services.NewRoleBasedAccessControlService(&rbacRepository.PermissionRepository{}, &rbacRepository.RoleRepository{})
-	memberService := services.NewMemberService(rbacService, &memberRepository.MemberRepository{})
-	organizationService := services.NewOrganizationService(rbacService, &organizationRepository.OrganizationRepository{}, memberService)
-	siteService := services.NewSiteService(&siteRepository.SiteSettingRepository{})
-	webHookService := services.NewWebHookService(&webHookRepository.WebHookRepository{})
-	authService := services.NewAuthService(memberService, organizationService, siteService)
+	container := NewContainer()
+
+	scheduler.Register(scheduler.Job{
+		Name:     "approval-overdue",
+		Interval: 10 * time.Minute,
+		Run:      container.ApprovalService.ProcessOverdueApprovals,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "member-field-change-expiry",
+		Interval: 10 * time.Minute,
+		Run:      container.MemberFieldChangeService.ExpireFieldChanges,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "role-elevation-expiry",
+		Interval: time.Minute,
+		Run:      container.RoleElevationService.ExpireElevations,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "pending-signup",
+		Interval: time.Hour,
+		Run:      container.PendingSignUpService.ProcessPendingSignUps,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "report-schedule",
+		Interval: time.Minute,
+		Run:      container.ReportService.ProcessScheduledReports,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "change-request-schedule",
+		Interval: time.Minute,
+		Run:      container.ChangeRequestService.ProcessScheduledChangeRequests,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "attachment-retention",
+		Interval: time.Hour,
+		Run:      container.FileService.DeleteExpiredAttachments,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "role-member-bulk",
+		Interval: 10 * time.Second,
+		Run:      container.RoleMemberBulkService.ProcessPendingJobs,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "export-job",
+		Interval: 10 * time.Second,
+		Run:      container.ExportJobService.ProcessPendingJobs,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "export-retention",
+		Interval: time.Hour,
+		Run:      container.ExportJobService.DeleteExpiredExports,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "retention",
+		Interval: time.Duration(config.Config.Retention.IntervalHours) * time.Hour,
+		Run:      container.RetentionService.EnforceRetention,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "usage-statistics",
+		Interval: time.Hour,
+		Run:      container.UsageStatisticsService.AggregateUsageStatistics,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "revoked-token-cleanup",
+		Interval: time.Hour,
+		Run:      container.TokenService.DeleteExpiredRevokedTokens,
+	})
+	scheduler.Register(scheduler.Job{
+		Name:     "domain-event-publish",
+		Interval: time.Duration(config.Config.MessageBroker.PublishIntervalSeconds) * time.Second,
+		Run:      container.DomainEventService.PublishPendingEvents,
+	})
+	if commandsConfig := config.Config.MessageBroker.Commands; len(commandsConfig.Topic) > 0 {
+		consumer.Register(consumer.Consumer{
+			Name:       "inbound-command",
+			Topic:      commandsConfig.Topic,
+			Group:      commandsConfig.Group,
+			Handle:     container.InboundCommandService.HandleMessage,
+			LoadOffset: container.InboundCommandService.LoadOffset,
+		})
+	}
+	if len(config.Config.GeoIp.DatabaseUrl) > 0 {
+		scheduler.Register(scheduler.Job{
+			Name:     "geo-ip-database-update",
+			Interval: time.Duration(config.Config.GeoIp.UpdateIntervalHours) * time.Hour,
+			Run: func(ctx context.Context) error {
+				return adapters.GeoIpAdapter().UpdateDatabase()
+			},
+		})
+	}
+
+	security.RegisterClaimEnricher(constants.ClaimEnricherOrganizationPath, services.NewOrganizationPathClaimEnricher(container.OrganizationService))
+	security.RegisterClaimEnricher(constants.ClaimEnricherMemberFields, services.NewMemberFieldsClaimEnricher(container.MemberService))
+
+	// 이전 프론트엔드도 버전을 조회하여 새로고침을 안내할 수 있어야 한다.
+	routerGroup.Use(middlewares.FrontendVersion(routerGroup.BasePath() + "/system/version"))
+	routerGroup.Use(middlewares.Chaos(routerGroup.BasePath() + "/system/chaos"))
+	// 서비스 계정의 API Key 인증은 DB 조회가 필요하여 GORMDb 이후 라우터 그룹에 등록한다.
+	// 폐기한 토큰과 종료된 세션 확인도 DB 조회가 필요하다.
+	routerGroup.Use(middlewares.ApiKey(container.ServiceAccountService.AuthenticateApiKey))
+	routerGroup.Use(middlewares.RevokedToken(container.TokenService.IsTokenRevoked))
+	routerGroup.Use(middlewares.RevokedSession(container.SessionService.IsSessionRevoked))
+	routerGroup.Use(middlewares.RequestCapture())
+	// 읽기 전용 모드에서도 로그인, 로그아웃과 토큰 발급은 사용할 수 있어야 한다.
+	routerGroup.Use(middlewares.ReadOnly(container.ReadOnlyService.GetReadOnlyStatus, container.SecurityEventService.RecordReadOnlyWrite,
+		routerGroup.BasePath()+"/site/settings/read-only",
+		routerGroup.BasePath()+"/auth"))
+	routerGroup.Use(middlewares.PermissionDenied(container.SecurityEventService.RecordPermissionDenied))
+	// 점검 중에도 로그인과 점검 안내(화면 문구 포함), 점검 설정은 사용할 수 있어야 한다.
+	routerGroup.Use(middlewares.Maintenance(container.MaintenanceService.GetMaintenanceStatus,
+		routerGroup.BasePath()+"/auth",
+		routerGroup.BasePath()+"/i18n",
+		routerGroup.BasePath()+"/site/maintenance",
+		routerGroup.BasePath()+"/site/login",
+		routerGroup.BasePath()+"/site/settings/maintenance",
+		routerGroup.BasePath()+"/status"))
+	routerGroup.Use(middlewares.DataMasking(container.DataMaskingService.GetPolicies))
 
 	NewAccessControlController(
 		routerGroup,
-		rbacService,
+		container.RbacService,
+		container.RoleMemberBulkService,
+		container.AccessHistoryService,
+		container.PermissionSimulationService,
+	).MapRoutes()
+
+	NewPermissionCatalogController(
+		routerGroup,
+		container.PermissionCatalogService,
+	).MapRoutes()
+
+	NewPluginSettingController(
+		routerGroup,
+		container.PluginSettingService,
 	).MapRoutes()
 
 	NewMemberController(
 		routerGroup,
-		rbacService,
-		memberService,
-		organizationService,
+		container.RbacService,
+		container.MemberService,
+		container.OrganizationService,
+		container.ApprovalService,
+		container.DomainEventService,
+		container.MemberApprovalService,
+		container.PasswordBreachService,
+		container.MemberFieldChangeService,
+	).MapRoutes()
+
+	NewMemberFieldChangeController(
+		routerGroup,
+		container.MemberFieldChangeService,
 	).MapRoutes()
 
 	NewOrganizationController(
 		routerGroup,
-		organizationService,
+		container.OrganizationService,
 	).MapRoutes()
 
 	NewSiteController(
 		routerGroup,
-		siteService,
+		container.SiteService,
+		container.SessionService,
+		container.ApprovalService,
+		container.PendingSignUpService,
+		container.MaintenanceService,
+		container.DataMaskingService,
+		container.ConcurrencyLimitService,
+		container.LoginSettingService,
+		container.MemberApprovalService,
+		container.GoogleWorkspaceService,
+	).MapRoutes()
+
+	NewServiceStatusController(
+		routerGroup,
+		container.ServiceStatusService,
+	).MapRoutes()
+
+	NewPasswordBreachController(
+		routerGroup,
+		container.PasswordBreachService,
+	).MapRoutes()
+
+	NewSecurityEventController(
+		routerGroup,
+		container.SecurityEventService,
 	).MapRoutes()
 
 	NewWebHookController(
 		routerGroup,
-		webHookService,
+		container.WebHookService,
 	).MapRoutes()
 
 	NewAuthController(
 		routerGroup,
-		authService,
-		memberService,
+		container.AuthUseCase,
+		container.TokenService,
+		container.OauthAuthorizationService,
+	).MapRoutes()
+
+	NewBreakGlassController(
+		routerGroup,
+		container.BreakGlassService,
+		container.PasswordBreachService,
+	).MapRoutes()
+
+	NewSystemController(
+		routerGroup,
+		container.SystemService,
+	).MapRoutes()
+
+	NewServiceAccountController(
+		routerGroup,
+		container.ServiceAccountService,
+		container.ApprovalService,
+	).MapRoutes()
+
+	NewOAuthClientController(
+		routerGroup,
+		container.OauthClientService,
+	).MapRoutes()
+
+	NewConsentController(
+		routerGroup,
+		container.ConsentService,
+	).MapRoutes()
+
+	NewOAuthAuthorizationController(
+		routerGroup,
+		container.OauthAuthorizationService,
+	).MapRoutes()
+
+	NewSignIdChangeController(
+		routerGroup,
+		container.SignIdChangeService,
+	).MapRoutes()
+
+	NewReadOnlyController(
+		routerGroup,
+		container.ReadOnlyService,
+	).MapRoutes()
+
+	NewSuperAdminProtectionController(
+		routerGroup,
+		container.SuperAdminProtectionService,
+	).MapRoutes()
+
+	NewDiagnosticsController(
+		routerGroup,
+		container.DiagnosticsService,
+	).MapRoutes()
+
+	NewLoginNotificationController(
+		routerGroup,
+		container.LoginNotificationService,
+		container.PasswordBreachService,
+	).MapRoutes()
+
+	NewMemberDeprovisioningController(
+		routerGroup,
+		container.MemberDeprovisioningService,
+	).MapRoutes()
+
+	NewResourceOwnershipController(
+		routerGroup,
+		container.ResourceOwnershipService,
+	).MapRoutes()
+
+	NewApprovalController(
+		routerGroup,
+		container.ApprovalService,
+		container.FileService,
+	).MapRoutes()
+
+	NewApprovalDelegationController(
+		routerGroup,
+		container.ApprovalDelegationService,
+	).MapRoutes()
+
+	NewSegmentController(
+		routerGroup,
+		container.SegmentService,
+	).MapRoutes()
+
+	NewMemberAssignmentRuleController(
+		routerGroup,
+		container.MemberAssignmentRuleService,
+	).MapRoutes()
+
+	NewPreferenceController(
+		routerGroup,
+		container.PreferenceService,
+	).MapRoutes()
+
+	NewActivityController(
+		routerGroup,
+		container.AuditService,
+	).MapRoutes()
+
+	NewReportController(
+		routerGroup,
+		container.ReportService,
+	).MapRoutes()
+
+	NewUsageStatisticsController(
+		routerGroup,
+		container.UsageStatisticsService,
+	).MapRoutes()
+
+	NewAccessLogController(
+		routerGroup,
+		container.UsageStatisticsService,
+	).MapRoutes()
+
+	NewFileController(
+		routerGroup,
+		container.FileService,
+		container.TokenService,
+	).MapRoutes()
+
+	NewDomainEventController(
+		routerGroup,
+		container.DomainEventService,
+	).MapRoutes()
+
+	NewInboundCommandController(
+		routerGroup,
+		container.InboundCommandService,
+	).MapRoutes()
+
+	NewChangeRequestController(
+		routerGroup,
+		container.ChangeRequestService,
+	).MapRoutes()
+
+	NewNoteController(
+		routerGroup,
+		container.NoteService,
 	).MapRoutes()
+
+	NewMemberDataExportController(
+		routerGroup,
+		container.MemberDataExportService,
+	).MapRoutes()
+
+	NewExportController(
+		routerGroup,
+		container.ExportJobService,
+		container.TokenService,
+	).MapRoutes()
+
+	NewRetentionController(
+		routerGroup,
+		container.RetentionService,
+	).MapRoutes()
+
+	NewMemberIdentityController(
+		routerGroup,
+		container.MemberIdentityService,
+		container.AuthService,
+	).MapRoutes()
+
+	NewGroupRoleMappingController(
+		routerGroup,
+		container.GroupRoleMappingService,
+	).MapRoutes()
+
+	NewRoleElevationController(
+		routerGroup,
+		container.RoleElevationService,
+	).MapRoutes()
+
+	NewTableViewController(
+		routerGroup,
+		container.TableViewService,
+	).MapRoutes()
+
+	NewI18nController(
+		routerGroup,
+		container.I18nService,
+	).MapRoutes()
+
+	NewMessageTemplateController(
+		routerGroup,
+		container.MessageTemplateService,
+	).MapRoutes()
+
+	NewOrganizationSettingController(
+		routerGroup,
+		container.OrganizationSettingService,
+	).MapRoutes()
+
+	NewLicenseController(
+		routerGroup,
+		container.LicenseService,
+	).MapRoutes()
+
+	mapModuleRoutes(routerGroup, container)
 }
@@ -0,0 +1,208 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type RetentionController struct {
+	routerGroup      *gin.RouterGroup
+	retentionService *services.RetentionService
+}
+
+func NewRetentionController(
+	routerGroup *gin.RouterGroup,
+	retentionService *services.RetentionService) *RetentionController {
+
+	return &RetentionController{
+		routerGroup:      routerGroup,
+		retentionService: retentionService,
+	}
+}
+
+func (c RetentionController) MapRoutes() {
+	route := c.routerGroup.Group("/retention")
+	route.GET("/policies", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getRetentionPolicies)
+	route.GET("/legal-holds", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getLegalHolds)
+	route.POST("/legal-holds", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.createLegalHold)
+	route.DELETE("/legal-holds/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.releaseLegalHold)
+	route.GET("/runs", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getRetentionRuns)
+	route.POST("/runs", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.runRetention)
+	route.GET("/runs/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getRetentionRun)
+
+	settingRoute := c.routerGroup.Group("/site")
+	settingRoute.GET("/settings/retention",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getRetentionSetting)
+	settingRoute.PUT("/settings/retention",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setRetentionSetting)
+}
+
+func (c RetentionController) getRetentionPolicies(ctx *gin.Context) {
+	policies, err := c.retentionService.GetRetentionPolicies(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policies)
+}
+
+// getLegalHolds 는 memberId 로 멤버를 보존하는 법적 보존만 조회한다.
+func (c RetentionController) getLegalHolds(ctx *gin.Context) {
+	var memberId uint64
+	if len(ctx.Query("memberId")) > 0 {
+		var err error
+		if memberId, err = strconv.ParseUint(ctx.Query("memberId"), 10, 64); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	entities, err := c.retentionService.GetLegalHolds(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var legalHolds = make([]dtos.LegalHoldInformation, 0)
+	for _, entity := range entities {
+		legalHolds = append(legalHolds, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, legalHolds)
+}
+
+func (c RetentionController) createLegalHold(ctx *gin.Context) {
+	var request dtos.LegalHoldRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.retentionService.CreateLegalHold(ctx.Request.Context(), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+func (c RetentionController) releaseLegalHold(ctx *gin.Context) {
+	legalHoldId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.retentionService.ReleaseLegalHold(ctx.Request.Context(), uint(legalHoldId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c RetentionController) getRetentionRuns(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.retentionService.GetRetentionRuns(ctx.Request.Context(), pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var retentionRuns = make([]dtos.RetentionRunInformation, 0)
+	for _, entity := range entities {
+		retentionRuns = append(retentionRuns, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     retentionRuns,
+		TotalCount: totalCount,
+	})
+}
+
+// runRetention 은 주기 작업을 기다리지 않고 보관 기간을 적용하고 실행 보고서를 응답한다.
+func (c RetentionController) runRetention(ctx *gin.Context) {
+	entity, err := c.retentionService.RunRetention(ctx.Request.Context(), constants.RetentionRunTriggerManual)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+func (c RetentionController) getRetentionRun(ctx *gin.Context) {
+	retentionRunId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.retentionService.GetRetentionRun(ctx.Request.Context(), uint(retentionRunId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c RetentionController) getRetentionSetting(ctx *gin.Context) {
+	setting, err := c.retentionService.GetRetentionSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c RetentionController) setRetentionSetting(ctx *gin.Context) {
+	var setting dtos.RetentionSetting
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.retentionService.SetRetentionSetting(ctx.Request.Context(), setting); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (RetentionController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidRetention); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
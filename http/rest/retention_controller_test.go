@@ -0,0 +1,238 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func insertTestLoginAttempt(memberId uint, attemptedAt time.Time) {
+	gormDB.Exec("INSERT INTO login_attempts(created_at, updated_at, method, member_id, succeeded, attempted_at) VALUES (?, ?, 'id-password', ?, true, ?)",
+		attemptedAt, attemptedAt, memberId, attemptedAt)
+}
+
+func countTestLoginAttempts(memberId uint) int64 {
+	var count int64
+	gormDB.Raw("SELECT count(*) FROM login_attempts WHERE member_id = ?", memberId).Scan(&count)
+	return count
+}
+
+func findTestRetentionResult(run dtos.RetentionRunInformation, category string) dtos.RetentionResult {
+	for _, result := range run.Results {
+		if result.Category == category {
+			return result
+		}
+	}
+	return dtos.RetentionResult{}
+}
+
+func TestRetentionController_보관_기간_적용과_멤버_법적_보존(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	permissions := []string{constants.PermissionManageSystemSettings}
+
+	// given
+	now := time.Now()
+	insertTestLoginAttempt(2, now.AddDate(0, 0, -200))
+	insertTestLoginAttempt(3, now.AddDate(0, 0, -200))
+	insertTestLoginAttempt(3, now.AddDate(0, 0, -10))
+	gormDB.Exec("INSERT INTO audit_logs(created_at, updated_at, actor_type, actor_id, action) VALUES (?, ?, ?, 2, 'login')",
+		now.AddDate(-3, 0, 0), now.AddDate(-3, 0, 0), constants.AuditActorTypeMember)
+	gormDB.Exec("INSERT INTO audit_logs(created_at, updated_at, actor_type, actor_id, action, target_type, target_id) VALUES (?, ?, ?, 1, 'member-updated', ?, 3)",
+		now.AddDate(-3, 0, 0), now.AddDate(-3, 0, 0), constants.AuditActorTypeMember, constants.AuditTargetTypeMember)
+
+	rec := requestTestExport(http.MethodPost, "/api/retention/legal-holds", `{"memberId": 3, "reason": "소송 대응"}`, 1, permissions)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var legalHold dtos.LegalHoldInformation
+	json.Unmarshal(rec.Body.Bytes(), &legalHold)
+	assert.Equal(t, uint(3), legalHold.MemberId)
+	assert.Equal(t, uint(1), legalHold.CreatedBy)
+
+	// when
+	rec = requestTestExport(http.MethodPost, "/api/retention/runs", "", 1, permissions)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var run dtos.RetentionRunInformation
+	json.Unmarshal(rec.Body.Bytes(), &run)
+	assert.Equal(t, constants.RetentionRunTriggerManual, run.TriggerType)
+	assert.Equal(t, constants.RetentionRunStatusSucceeded, run.Status)
+	assert.Equal(t, uint(1), run.RequestedBy)
+	assert.Len(t, run.Results, 5)
+
+	accessLogs := findTestRetentionResult(run, constants.RetentionCategoryAccessLogs)
+	assert.Equal(t, 180, accessLogs.Days)
+	assert.Equal(t, int64(1), accessLogs.DeletedCount)
+	assert.Equal(t, int64(1), accessLogs.HeldCount)
+	assert.Equal(t, int64(0), countTestLoginAttempts(2))
+	assert.Equal(t, int64(2), countTestLoginAttempts(3))
+
+	// 보존 대상 멤버를 대상으로 한 감사 로그도 남긴다.
+	auditLogs := findTestRetentionResult(run, constants.RetentionCategoryAuditLogs)
+	assert.Equal(t, int64(1), auditLogs.DeletedCount)
+	assert.Equal(t, int64(1), auditLogs.HeldCount)
+	var auditLogCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE target_type = ? AND target_id = 3", constants.AuditTargetTypeMember).Scan(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+
+	rec = requestTestExport(http.MethodGet, "/api/retention/runs", "", 1, permissions)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var runs struct {
+		Result     []dtos.RetentionRunInformation `json:"result"`
+		TotalCount int64                          `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &runs)
+	assert.Equal(t, int64(1), runs.TotalCount)
+
+	rec = requestTestExport(http.MethodGet, fmt.Sprintf("/api/retention/runs/%d", run.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 보존을 해제하면 다음 실행에서 지운다.
+	rec = requestTestExport(http.MethodDelete, fmt.Sprintf("/api/retention/legal-holds/%d", legalHold.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = requestTestExport(http.MethodDelete, fmt.Sprintf("/api/retention/legal-holds/%d", legalHold.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = requestTestExport(http.MethodPost, "/api/retention/runs", "", 1, permissions)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, int64(1), countTestLoginAttempts(3))
+
+	var legalHoldAuditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE target_type = ? AND target_id = ?", constants.AuditTargetTypeLegalHold, legalHold.Id).Scan(&legalHoldAuditCount)
+	assert.Equal(t, int64(2), legalHoldAuditCount)
+}
+
+func TestRetentionController_기간_법적_보존과_사이트_설정(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	permissions := []string{constants.PermissionManageSystemSettings}
+
+	// given
+	now := time.Now()
+	insertTestLoginAttempt(2, now.AddDate(0, 0, -100))
+	insertTestLoginAttempt(3, now.AddDate(0, 0, -60))
+	insertTestLoginAttempt(3, now.AddDate(0, 0, -10))
+	gormDB.Exec("INSERT INTO login_notifications(created_at, updated_at, member_id, session_id) VALUES (?, ?, 2, 1)", now.AddDate(-1, 0, 0), now.AddDate(-1, 0, 0))
+
+	rec := requestTestExport(http.MethodPut, "/api/site/settings/retention", `{"days": {"access-logs": 30, "notifications": 0}}`, 1, permissions)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	heldFrom := now.AddDate(0, 0, -70).Format(time.RFC3339)
+	heldTo := now.AddDate(0, 0, -50).Format(time.RFC3339)
+	rec = requestTestExport(http.MethodPost, "/api/retention/legal-holds",
+		fmt.Sprintf(`{"heldFrom": %q, "heldTo": %q, "reason": "감사 기간"}`, heldFrom, heldTo), 1, permissions)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requestTestExport(http.MethodGet, "/api/retention/policies", "", 1, permissions)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var policies []dtos.RetentionPolicy
+	json.Unmarshal(rec.Body.Bytes(), &policies)
+	assert.Len(t, policies, 5)
+	assert.Equal(t, dtos.RetentionPolicy{Category: constants.RetentionCategoryAccessLogs, DefaultDays: 180, Days: 30, Overridden: true}, policies[0])
+	assert.Equal(t, dtos.RetentionPolicy{Category: constants.RetentionCategoryAuditLogs, DefaultDays: 730, Days: 730}, policies[1])
+
+	// when
+	rec = requestTestExport(http.MethodPost, "/api/retention/runs", "", 1, permissions)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var run dtos.RetentionRunInformation
+	json.Unmarshal(rec.Body.Bytes(), &run)
+	accessLogs := findTestRetentionResult(run, constants.RetentionCategoryAccessLogs)
+	assert.Equal(t, 30, accessLogs.Days)
+	assert.Equal(t, int64(1), accessLogs.DeletedCount)
+	assert.Equal(t, int64(1), accessLogs.HeldCount)
+	assert.Equal(t, int64(0), countTestLoginAttempts(2))
+	assert.Equal(t, int64(2), countTestLoginAttempts(3))
+
+	// 보관 기간이 0 이면 지우지 않는다.
+	notifications := findTestRetentionResult(run, constants.RetentionCategoryNotifications)
+	assert.Equal(t, 0, notifications.Days)
+	assert.Nil(t, notifications.Cutoff)
+	var notificationCount int64
+	gormDB.Raw("SELECT count(*) FROM login_notifications").Scan(&notificationCount)
+	assert.Equal(t, int64(1), notificationCount)
+}
+
+func TestRetentionController_잘못된_설정과_법적_보존(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	permissions := []string{constants.PermissionManageSystemSettings}
+
+	rec := requestTestExport(http.MethodPut, "/api/site/settings/retention", `{"days": {"sessions": 30}}`, 1, permissions)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "INVALID_RETENTION", errorMessage.Code)
+
+	rec = requestTestExport(http.MethodPut, "/api/site/settings/retention", `{"days": {"access-logs": -1}}`, 1, permissions)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 멤버나 기간을 지정하지 않은 법적 보존은 만들 수 없다.
+	rec = requestTestExport(http.MethodPost, "/api/retention/legal-holds", `{"reason": "전체 보존"}`, 1, permissions)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestTestExport(http.MethodPost, "/api/retention/legal-holds",
+		`{"heldFrom": "2026-02-01T00:00:00+09:00", "heldTo": "2026-01-01T00:00:00+09:00", "reason": "감사 기간"}`, 1, permissions)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestTestExport(http.MethodPost, "/api/retention/runs", "", 1, []string{constants.PermissionManageMembers})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestRetentionController_멤버_법적_보존(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	permissions := []string{constants.PermissionManageSystemSettings, constants.PermissionManageMembers}
+
+	// given
+	rec := requestTestExport(http.MethodPost, "/api/retention/legal-holds", `{"memberId": 4, "ownerId": 2, "reason": "소송 대응"}`, 1, permissions)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var legalHold dtos.LegalHoldInformation
+	json.Unmarshal(rec.Body.Bytes(), &legalHold)
+	assert.Equal(t, uint(2), legalHold.OwnerId)
+	assert.Equal(t, uint(1), legalHold.CreatedBy)
+
+	// when
+	rec = requestTestExport(http.MethodPut, "/api/members/4/rejected", "", 1, permissions)
+
+	// then
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "LEGAL_HOLD", errorMessage.Code)
+
+	rec = requestTestExport(http.MethodGet, "/api/retention/legal-holds?memberId=4", "", 1, permissions)
+	var legalHolds []dtos.LegalHoldInformation
+	json.Unmarshal(rec.Body.Bytes(), &legalHolds)
+	assert.Len(t, legalHolds, 1)
+	rec = requestTestExport(http.MethodGet, "/api/retention/legal-holds?memberId=3", "", 1, permissions)
+	json.Unmarshal(rec.Body.Bytes(), &legalHolds)
+	assert.Len(t, legalHolds, 0)
+
+	// 컴플라이언스 리포트에서 보존 중인 멤버를 조회한다.
+	rec = requestTestReport(http.MethodPost, "/api/reports",
+		strings.NewReader(`{"name": "법적 보존 멤버", "format": "csv", "definition": {"entity": "legal-holds", "columns": ["memberId", "ownerId", "reason"]}}`))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var report dtos.ReportInformation
+	json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.Equal(t, 1, runTestReport(t, report.Id).RowCount)
+
+	// 보존을 해제하면 거절할 수 있고 리포트에서 조회하지 않는다.
+	rec = requestTestExport(http.MethodDelete, fmt.Sprintf("/api/retention/legal-holds/%d", legalHold.Id), "", 1, permissions)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 0, runTestReport(t, report.Id).RowCount)
+	rec = requestTestExport(http.MethodPut, "/api/members/4/rejected", "", 1, permissions)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 없는 멤버는 보존할 수 없다.
+	rec = requestTestExport(http.MethodPost, "/api/retention/legal-holds", `{"memberId": 999, "reason": "소송 대응"}`, 1, permissions)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type RoleElevationController struct {
+	routerGroup          *gin.RouterGroup
+	roleElevationService *services.RoleElevationService
+}
+
+func NewRoleElevationController(
+	routerGroup *gin.RouterGroup,
+	roleElevationService *services.RoleElevationService) *RoleElevationController {
+
+	return &RoleElevationController{
+		routerGroup:          routerGroup,
+		roleElevationService: roleElevationService,
+	}
+}
+
+func (c RoleElevationController) MapRoutes() {
+	memberRoute := c.routerGroup.Group("/members")
+	memberRoute.POST("/me/role-elevations", middlewares.PermissionChecker([]string{"*"}),
+		c.requestElevation)
+	memberRoute.GET("/me/role-elevations", middlewares.PermissionChecker([]string{"*"}),
+		c.getMyElevations)
+
+	route := c.routerGroup.Group("/role-elevations")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		etag.HttpEtagCache(0),
+		c.getElevations)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		etag.HttpEtagCache(0),
+		c.getElevation)
+	route.POST("/:id/revoke", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.revokeElevation)
+	route.GET("/:id/audit-logs", middlewares.PermissionChecker([]string{constants.PermissionManageAccessControl}),
+		c.getElevationAuditLogs)
+}
+
+func (c RoleElevationController) requestElevation(ctx *gin.Context) {
+	var request dtos.RoleElevationRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.roleElevationService.RequestElevation(ctx.Request.Context(), request)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+func (c RoleElevationController) getMyElevations(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	// 서비스 계정의 Id 는 멤버 Id 와 겹칠 수 있으므로 멤버의 권한 상승으로 다루지 않는다.
+	if userClaim.IsServiceAccount() {
+		c.handleError(ctx, errors.ErrAuthentication)
+		return
+	}
+
+	c.writeElevations(ctx, map[string]interface{}{"memberId": userClaim.Id})
+}
+
+// getElevations 는 memberId, status 로 임시 권한 상승을 조회한다.
+func (c RoleElevationController) getElevations(ctx *gin.Context) {
+	filters := map[string]interface{}{}
+	if value := ctx.Query("memberId"); len(value) > 0 {
+		memberId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters["memberId"] = uint(memberId)
+	}
+
+	if status := ctx.Query("status"); len(status) > 0 {
+		filters["status"] = status
+	}
+
+	c.writeElevations(ctx, filters)
+}
+
+func (c RoleElevationController) writeElevations(ctx *gin.Context, filters map[string]interface{}) {
+	entities, totalCount, err := c.roleElevationService.GetElevations(ctx.Request.Context(), filters, dtos.NewPageableFromRequest(ctx))
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     c.toInformations(entities),
+		TotalCount: totalCount,
+	})
+}
+
+func (c RoleElevationController) getElevation(ctx *gin.Context) {
+	elevationId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.roleElevationService.GetElevation(ctx.Request.Context(), uint(elevationId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c RoleElevationController) revokeElevation(ctx *gin.Context) {
+	elevationId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.roleElevationService.RevokeElevation(ctx.Request.Context(), uint(elevationId)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getElevationAuditLogs 는 상승 중에 멤버가 남긴 감사 로그로 상승 후 활동을 검토한다.
+func (c RoleElevationController) getElevationAuditLogs(ctx *gin.Context) {
+	elevationId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := c.roleElevationService.GetElevationAuditLogs(ctx.Request.Context(), uint(elevationId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, events)
+}
+
+func (RoleElevationController) toInformations(entities []domain.RoleElevationEntity) []dtos.RoleElevationInformation {
+	informations := make([]dtos.RoleElevationInformation, 0)
+	for _, entity := range entities {
+		informations = append(informations, entity.ToInformation())
+	}
+
+	return informations
+}
+
+func (RoleElevationController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrApprovalInProgress) || errors.Is(err, errors.ErrNonChangeable) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidRoleElevation); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
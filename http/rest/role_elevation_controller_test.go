@@ -0,0 +1,321 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setUpRoleElevationApprovalWorkflow(t *testing.T) {
+	requestBody := `{
+		"workflows": [{
+			"subject": "role-elevation",
+			"steps": [
+				{"name": "멤버 관리자 승인", "approverRoleName": "MEMBER MANAGER", "requiredApprovals": 1}
+			]
+		}]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/approval-workflow", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func requestRoleElevation(memberId uint, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/members/me/role-elevations", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func requestRoleElevationAdmin(method, url string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageAccessControl},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func getRoleElevation(t *testing.T, id uint) dtos.RoleElevationInformation {
+	rec := requestRoleElevationAdmin(http.MethodGet, fmt.Sprintf("/api/role-elevations/%v", id))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var elevation dtos.RoleElevationInformation
+	json.Unmarshal(rec.Body.Bytes(), &elevation)
+	return elevation
+}
+
+func countMemberRole(memberId, roleId uint) int64 {
+	var count int64
+	gormDB.Raw("SELECT count(*) FROM member_roles WHERE member_entity_id = ? AND role_entity_id = ?", memberId, roleId).Scan(&count)
+	return count
+}
+
+func TestRoleElevationController_승인_후_기한_동안_역할_할당(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpRoleElevationApprovalWorkflow(t)
+
+	// when
+	rec := requestRoleElevation(3, `{"roleId": 3, "hours": 2, "justification": "장애 대응"}`)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var elevation dtos.RoleElevationInformation
+	json.Unmarshal(rec.Body.Bytes(), &elevation)
+	assert.Equal(t, constants.RoleElevationStatusPending, elevation.Status)
+	assert.Equal(t, "테스트 관리자", elevation.RoleName)
+	assert.Equal(t, int64(0), countMemberRole(3, 3))
+
+	// 같은 역할의 상승이 승인을 기다리는 동안에는 다시 요청할 수 없다.
+	rec = requestRoleElevation(3, `{"roleId": 3, "hours": 1, "justification": "다시 요청"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'role-elevation' AND target_id = ?", elevation.Id).Scan(&approvalId)
+	assert.NotZero(t, approvalId)
+
+	// 요청한 멤버는 승인할 수 없다.
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          3,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "SELF_REVIEW")
+
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, int64(1), countMemberRole(3, 3))
+
+	elevation = getRoleElevation(t, elevation.Id)
+	assert.Equal(t, constants.RoleElevationStatusActive, elevation.Status)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), *elevation.ExpiresAt, time.Minute)
+
+	// 상승 중에 멤버가 남긴 감사 로그에는 상승 ID 가 기록된다.
+	rec = requestRoleElevation(3, `{"roleId": 2, "hours": 1, "justification": "멤버 관리"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requestRoleElevationAdmin(http.MethodGet, fmt.Sprintf("/api/role-elevations/%v/audit-logs", elevation.Id))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var events []dtos.Event
+	json.Unmarshal(rec.Body.Bytes(), &events)
+	assert.NotEmpty(t, events)
+	assert.Equal(t, constants.AuditActionRoleElevationRequested, events[0].Action)
+	assert.Equal(t, uint(3), events[0].ActorId)
+	assert.Equal(t, elevation.Id, events[0].ElevationId)
+
+	// 승인한 멤버의 감사 로그는 상승 중 활동이 아니다.
+	var approverTaggedCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE actor_id = 2 AND elevation_id <> 0").Scan(&approverTaggedCount)
+	assert.Equal(t, int64(0), approverTaggedCount)
+}
+
+func TestRoleElevationController_기한이_지나면_역할_회수(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpRoleElevationApprovalWorkflow(t)
+
+	rec := requestRoleElevation(3, `{"roleId": 3, "hours": 1, "justification": "장애 대응"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var elevation dtos.RoleElevationInformation
+	json.Unmarshal(rec.Body.Bytes(), &elevation)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'role-elevation' AND target_id = ?", elevation.Id).Scan(&approvalId)
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, int64(1), countMemberRole(3, 3))
+
+	// given
+	gormDB.Exec("UPDATE role_elevations SET expires_at = ? WHERE id = ?", time.Now().Add(-time.Minute), elevation.Id)
+
+	// when
+	err := NewContainer().RoleElevationService.ExpireElevations(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), countMemberRole(3, 3))
+	assert.Equal(t, constants.RoleElevationStatusExpired, getRoleElevation(t, elevation.Id).Status)
+
+	var count int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = ?", constants.AuditActionRoleElevationExpired, elevation.Id).Scan(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestRoleElevationController_반려(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpRoleElevationApprovalWorkflow(t)
+
+	rec := requestRoleElevation(3, `{"roleId": 3, "hours": 1, "justification": "장애 대응"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var elevation dtos.RoleElevationInformation
+	json.Unmarshal(rec.Body.Bytes(), &elevation)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'role-elevation' AND target_id = ?", elevation.Id).Scan(&approvalId)
+
+	// when
+	rec = decideApproval(approvalId, "rejected", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, int64(0), countMemberRole(3, 3))
+	assert.Equal(t, constants.RoleElevationStatusRejected, getRoleElevation(t, elevation.Id).Status)
+}
+
+func TestRoleElevationController_기한_전에_회수(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpRoleElevationApprovalWorkflow(t)
+
+	rec := requestRoleElevation(3, `{"roleId": 3, "hours": 1, "justification": "장애 대응"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var pending dtos.RoleElevationInformation
+	json.Unmarshal(rec.Body.Bytes(), &pending)
+
+	// 승인을 기다리는 요청을 회수하면 승인 요청도 만료된다.
+	rec = requestRoleElevationAdmin(http.MethodPost, fmt.Sprintf("/api/role-elevations/%v/revoke", pending.Id))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, constants.RoleElevationStatusRevoked, getRoleElevation(t, pending.Id).Status)
+
+	var approvalStatus string
+	gormDB.Raw("SELECT status FROM approval_requests WHERE subject = 'role-elevation' AND target_id = ?", pending.Id).Scan(&approvalStatus)
+	assert.Equal(t, constants.ApprovalStatusExpired, approvalStatus)
+
+	// 상승 중인 요청을 회수하면 역할을 회수한다.
+	rec = requestRoleElevation(3, `{"roleId": 3, "hours": 1, "justification": "장애 대응"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var active dtos.RoleElevationInformation
+	json.Unmarshal(rec.Body.Bytes(), &active)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'role-elevation' AND target_id = ?", active.Id).Scan(&approvalId)
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, int64(1), countMemberRole(3, 3))
+
+	rec = requestRoleElevationAdmin(http.MethodPost, fmt.Sprintf("/api/role-elevations/%v/revoke", active.Id))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, int64(0), countMemberRole(3, 3))
+
+	revoked := getRoleElevation(t, active.Id)
+	assert.Equal(t, constants.RoleElevationStatusRevoked, revoked.Status)
+	assert.Equal(t, uint(1), revoked.EndedBy)
+
+	rec = requestRoleElevationAdmin(http.MethodPost, fmt.Sprintf("/api/role-elevations/%v/revoke", active.Id))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestRoleElevationAdmin(http.MethodGet, "/api/role-elevations?memberId=3&status=revoked")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var pageResult struct {
+		Result     []dtos.RoleElevationInformation `json:"result"`
+		TotalCount int64                           `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &pageResult)
+	assert.Equal(t, int64(2), pageResult.TotalCount)
+}
+
+func TestRoleElevationController_요청할_수_없는_상승(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// 승인 절차가 없으면 요청할 수 없다.
+	rec := requestRoleElevation(3, `{"roleId": 3, "hours": 1, "justification": "장애 대응"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "INVALID_ROLE_ELEVATION")
+
+	setUpRoleElevationApprovalWorkflow(t)
+
+	testCases := []struct {
+		name        string
+		memberId    uint
+		requestBody string
+	}{
+		{"최대 시간 초과", 3, `{"roleId": 3, "hours": 100, "justification": "장애 대응"}`},
+		{"최고 관리자 역할", 3, `{"roleId": 1, "hours": 1, "justification": "장애 대응"}`},
+		{"없는 역할", 3, `{"roleId": 100, "hours": 1, "justification": "장애 대응"}`},
+		{"이미 가진 역할", 2, `{"roleId": 2, "hours": 1, "justification": "장애 대응"}`},
+	}
+
+	for _, testCase := range testCases {
+		rec := requestRoleElevation(testCase.memberId, testCase.requestBody)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, testCase.name)
+		assert.Contains(t, rec.Body.String(), "INVALID_ROLE_ELEVATION", testCase.name)
+	}
+
+	// 사유는 반드시 남겨야 한다.
+	rec = requestRoleElevation(3, `{"roleId": 3, "hours": 1}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestRoleElevationController_서비스_계정은_요청할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpRoleElevationApprovalWorkflow(t)
+
+	// when
+	// 서비스 계정의 Id(3)는 멤버(3)의 Id 와 같다.
+	rec := requestAsServiceAccount(http.MethodPost, "/api/members/me/role-elevations",
+		`{"roleId": 3, "hours": 1, "justification": "장애 대응"}`, 3, []string{})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	var elevationCount int64
+	gormDB.Raw("SELECT COUNT(*) FROM role_elevations").Scan(&elevationCount)
+	assert.Equal(t, int64(0), elevationCount)
+
+	rec = requestAsServiceAccount(http.MethodGet, "/api/members/me/role-elevations", "", 3, []string{})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
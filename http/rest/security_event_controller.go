@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type SecurityEventController struct {
+	routerGroup          *gin.RouterGroup
+	securityEventService *services.SecurityEventService
+}
+
+func NewSecurityEventController(
+	routerGroup *gin.RouterGroup,
+	securityEventService *services.SecurityEventService) *SecurityEventController {
+
+	return &SecurityEventController{
+		routerGroup:          routerGroup,
+		securityEventService: securityEventService,
+	}
+}
+
+func (c SecurityEventController) MapRoutes() {
+	route := c.routerGroup.Group("/security/events")
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getSecurityEvents)
+	route.GET("/summary", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getSecurityEventSummary)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getSecurityEvent)
+	route.PUT("/:id/acknowledged", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.acknowledgeSecurityEvent)
+
+	settingRoute := c.routerGroup.Group("/site")
+	settingRoute.GET("/settings/security-event-rules",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getSecurityEventRuleSetting)
+	settingRoute.PUT("/settings/security-event-rules",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setSecurityEventRuleSetting)
+}
+
+// getSecurityEvents 는 types(쉼표로 구분), minSeverity, status, memberId 로 보안 이벤트를 조회한다.
+func (c SecurityEventController) getSecurityEvents(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	filters := map[string]interface{}{}
+
+	if len(ctx.Query("types")) > 0 {
+		filters["types"] = strings.Split(ctx.Query("types"), ",")
+	}
+
+	if len(ctx.Query("minSeverity")) > 0 {
+		filters["minSeverity"] = ctx.Query("minSeverity")
+	}
+
+	if len(ctx.Query("status")) > 0 {
+		filters["status"] = ctx.Query("status")
+	}
+
+	if len(ctx.Query("memberId")) > 0 {
+		memberId, err := strconv.ParseUint(ctx.Query("memberId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters["memberId"] = uint(memberId)
+	}
+
+	entities, totalCount, err := c.securityEventService.GetSecurityEvents(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var securityEvents = make([]dtos.SecurityEventInformation, 0)
+	for _, entity := range entities {
+		securityEvents = append(securityEvents, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     securityEvents,
+		TotalCount: totalCount,
+	})
+}
+
+func (c SecurityEventController) getSecurityEventSummary(ctx *gin.Context) {
+	summary, err := c.securityEventService.GetSecurityEventSummary(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (c SecurityEventController) getSecurityEvent(ctx *gin.Context) {
+	securityEventId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.securityEventService.GetSecurityEvent(ctx.Request.Context(), uint(securityEventId))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c SecurityEventController) acknowledgeSecurityEvent(ctx *gin.Context) {
+	securityEventId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var acknowledgement dtos.SecurityEventAcknowledgement
+	if err := ctx.BindJSON(&acknowledgement); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.securityEventService.AcknowledgeSecurityEvent(ctx.Request.Context(), uint(securityEventId), acknowledgement)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c SecurityEventController) getSecurityEventRuleSetting(ctx *gin.Context) {
+	setting, err := c.securityEventService.GetSecurityEventRuleSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SecurityEventController) setSecurityEventRuleSetting(ctx *gin.Context) {
+	var setting dtos.SecurityEventRuleSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.securityEventService.SetSecurityEventRuleSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (SecurityEventController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrAlreadyAcknowledged) {
+		ctx.Header(middlewares.ErrorCodeHeader, errors.ErrAlreadyAcknowledged.Code)
+		ctx.JSON(http.StatusConflict, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
@@ -0,0 +1,119 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSecurityEventController_보안_이벤트_확인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	manageSystemSettings := []string{constants.PermissionManageSystemSettings}
+
+	// given
+	// 비밀번호가 틀린 로그인과 권한이 없는 요청
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(`{"id": "ymyoo", "password": "wrong"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/security/events", nil, 3, []string{constants.PermissionManageMembers})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// when
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/security/events", nil, 1, manageSystemSettings)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var events struct {
+		Result     []dtos.SecurityEventInformation `json:"result"`
+		TotalCount int64                           `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &events)
+	assert.Equal(t, int64(2), events.TotalCount)
+	assert.Equal(t, constants.SecurityEventTypePermissionDenied, events.Result[0].Type)
+	assert.Equal(t, uint(3), events.Result[0].MemberId)
+	assert.Equal(t, "method=GET, path=/api/security/events", events.Result[0].Detail)
+	assert.Equal(t, constants.SecurityEventTypeLoginFailed, events.Result[1].Type)
+	assert.Equal(t, constants.SecurityEventSeverityLow, events.Result[1].Severity)
+	assert.Equal(t, constants.SecurityEventStatusOpen, events.Result[1].Status)
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/security/events?types=login-failed", nil, 1, manageSystemSettings)
+	assert.Contains(t, rec.Body.String(), `"totalCount":1`)
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/security/events?minSeverity=high", nil, 1, manageSystemSettings)
+	assert.Contains(t, rec.Body.String(), `"totalCount":0`)
+
+	// 확인하면 open 수에서 빠진다.
+	rec = requestTestResourceOwnership(http.MethodPut, fmt.Sprintf("/api/security/events/%d/acknowledged", events.Result[1].Id),
+		strings.NewReader(`{"note": "본인 오타 확인"}`), 1, manageSystemSettings)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var acknowledged dtos.SecurityEventInformation
+	json.Unmarshal(rec.Body.Bytes(), &acknowledged)
+	assert.Equal(t, constants.SecurityEventStatusAcknowledged, acknowledged.Status)
+	assert.Equal(t, uint(1), acknowledged.AcknowledgedBy)
+	assert.NotNil(t, acknowledged.AcknowledgedAt)
+
+	rec = requestTestResourceOwnership(http.MethodPut, fmt.Sprintf("/api/security/events/%d/acknowledged", events.Result[1].Id),
+		strings.NewReader(`{}`), 1, manageSystemSettings)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "ALREADY_ACKNOWLEDGED")
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/security/events/summary", nil, 1, manageSystemSettings)
+	var summary dtos.SecurityEventSummary
+	json.Unmarshal(rec.Body.Bytes(), &summary)
+	assert.Equal(t, int64(1), summary.OpenCount)
+	assert.Equal(t, []dtos.SecurityEventCount{{Name: constants.SecurityEventTypePermissionDenied, Count: 1}}, summary.Types)
+
+	var auditCount int64
+	gormDB.Table("audit_logs").Where("action = ? AND target_id = ?", constants.AuditActionSecurityEventAcknowledged, events.Result[1].Id).Count(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestSecurityEventController_알림_규칙으로_전달(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	createTestBreakGlassAccount(t)
+	webHookSender := &fakeWebHookSender{}
+	adapters.OutgoingWebHookAdapter().SetSender(webHookSender)
+	defer adapters.OutgoingWebHookAdapter().SetSender(nil)
+
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/site/settings/security-event-rules",
+		strings.NewReader(`{"rules": [{"name": "보안팀", "minSeverity": "high", "webHookUrls": ["https://siem.bettercode.kr/events"]}]}`),
+		1, []string{constants.PermissionManageSystemSettings})
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/break-glass",
+		strings.NewReader(`{"id": "emergency", "password": "wrong-password", "reason": "SSO(두레이) 장애로 긴급 점검"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/break-glass",
+		strings.NewReader(`{"id": "emergency", "password": "emergency-1234", "reason": "SSO(두레이) 장애로 긴급 점검"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// then
+	// 심각도가 낮은 로그인 실패는 보내지 않고 비상 접근 계정 사용(critical)만 보낸다.
+	assert.Equal(t, 1, len(webHookSender.requests))
+	assert.Equal(t, "https://siem.bettercode.kr/events", webHookSender.requests[0].Url)
+	var forwarded dtos.SecurityEventInformation
+	json.Unmarshal(webHookSender.requests[0].Body, &forwarded)
+	assert.Equal(t, constants.SecurityEventTypeBreakGlassUsed, forwarded.Type)
+	assert.Equal(t, constants.SecurityEventSeverityCritical, forwarded.Severity)
+	assert.Equal(t, uint(1), forwarded.MemberId)
+}
@@ -0,0 +1,227 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/segment/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type SegmentController struct {
+	routerGroup    *gin.RouterGroup
+	segmentService *services.SegmentService
+}
+
+func NewSegmentController(
+	routerGroup *gin.RouterGroup,
+	segmentService *services.SegmentService) *SegmentController {
+
+	return &SegmentController{
+		routerGroup:    routerGroup,
+		segmentService: segmentService,
+	}
+}
+
+func (c SegmentController) MapRoutes() {
+	route := c.routerGroup.Group("/segments")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.createSegment)
+	// MANAGE_OWN_RESOURCES 권한만 있으면 소유한 세그먼트만 조회하고 관리할 수 있다. 멤버 조회에는 멤버 관리 권한이 필요하다.
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageMembers, constants.PermissionManageOwnResources}),
+		etag.HttpEtagCache(0),
+		c.getSegments)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageMembers, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.segmentService.AuthorizeOwner),
+		etag.HttpEtagCache(0),
+		c.getSegment)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageMembers, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.segmentService.AuthorizeOwner),
+		c.updateSegment)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageMembers, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.segmentService.AuthorizeOwner),
+		c.deleteSegment)
+	route.GET("/:id/members", middlewares.PermissionChecker([]string{constants.PermissionManageMembers}),
+		c.getSegmentMembers)
+}
+
+func (c SegmentController) createSegment(ctx *gin.Context) {
+	var information dtos.SegmentInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.segmentService.CreateSegment(ctx.Request.Context(), information)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.toSegmentInformation(entity))
+}
+
+func (c SegmentController) getSegments(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.segmentService.GetSegments(ctx.Request.Context(), listFiltersOf(ctx), pageable)
+	if err != nil {
+		if errors.Is(err, errors.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var segments = make([]dtos.SegmentInformation, 0)
+	for _, entity := range entities {
+		segments = append(segments, c.toSegmentInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     segments,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (c SegmentController) getSegment(ctx *gin.Context) {
+	segmentId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.segmentService.GetSegment(ctx.Request.Context(), uint(segmentId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toSegmentInformation(entity))
+}
+
+func (c SegmentController) updateSegment(ctx *gin.Context) {
+	segmentId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.SegmentInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.segmentService.UpdateSegment(ctx.Request.Context(), uint(segmentId), information); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SegmentController) deleteSegment(ctx *gin.Context) {
+	segmentId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.segmentService.DeleteSegment(ctx.Request.Context(), uint(segmentId)); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getSegmentMembers 는 세그먼트 조건으로 지금 시점의 멤버 목록을 조회한다.
+func (c SegmentController) getSegmentMembers(ctx *gin.Context) {
+	segmentId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pageable := dtos.NewPageableFromRequest(ctx)
+	memberEntities, totalCount, err := c.segmentService.GetSegmentMembers(ctx.Request.Context(), uint(segmentId), pageable)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var members = make([]dtos.MemberInformation, 0)
+	for _, entity := range memberEntities {
+		var roles = make([]dtos.MemberRole, 0)
+		for _, memberRole := range entity.Roles {
+			roles = append(roles, dtos.MemberRole{
+				Id:   memberRole.ID,
+				Name: memberRole.Name,
+			})
+		}
+
+		members = append(members, dtos.MemberInformation{
+			Id:           entity.ID,
+			SignId:       entity.SignId,
+			CandidateId:  entity.GetCandidateId(),
+			Type:         entity.Type,
+			TypeName:     entity.GetTypeName(),
+			Name:         entity.Name,
+			MemberRoles:  roles,
+			Tags:         entity.GetTagNames(),
+			CreatedAt:    entity.CreatedAt,
+			Email:        entity.GetEmail(),
+			Phone:        entity.Phone,
+			LastAccessAt: entity.LastAccessAt,
+		})
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     members,
+		TotalCount: totalCount,
+	}
+
+	helpers.ResponseHelper().JSON(ctx, http.StatusOK, pageResult)
+}
+
+func (SegmentController) toSegmentInformation(entity domain.SegmentEntity) dtos.SegmentInformation {
+	return dtos.SegmentInformation{
+		Id:          entity.ID,
+		Name:        entity.Name,
+		Description: entity.Description,
+		Filter:      entity.GetFilter(),
+		OwnerId:     entity.GetOwnerId(),
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
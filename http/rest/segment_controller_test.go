@@ -0,0 +1,176 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setTestMemberTags(t *testing.T, memberId uint, tags string) {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/members/%v/tags", memberId), strings.NewReader(tags))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestMemberController_setTags(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setTestMemberTags(t, 3, `{"tags": [" vip ", "beta", "vip", ""]}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/3", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.ElementsMatch(t, []interface{}{"vip", "beta"}, actual["tags"])
+
+	// 태그를 바꾸면 기존 태그는 지워진다.
+	setTestMemberTags(t, 3, `{"tags": ["beta"]}`)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/tags", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `["beta"]`, rec.Body.String())
+}
+
+func TestMemberController_getMembers_by_태그(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setTestMemberTags(t, 1, `{"tags": ["vip"]}`)
+	setTestMemberTags(t, 3, `{"tags": ["beta"]}`)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/members?tags=vip,staff", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalCount"])
+}
+
+func TestSegmentController_getSegmentMembers(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setTestMemberTags(t, 1, `{"tags": ["vip"]}`)
+	setTestMemberTags(t, 3, `{"tags": ["vip", "beta"]}`)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/segments/1/members?page=1&pageSize=10", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(2), actual["totalCount"])
+
+	// 멤버의 태그가 바뀌면 세그먼트 멤버도 바뀐다.
+	setTestMemberTags(t, 1, `{"tags": []}`)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalCount"])
+}
+
+func TestSegmentController_createSegment(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"name": "신청한 사이트 멤버",
+		"filter": {"status": "applied", "types": ["site"]}
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/api/segments", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var created map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	assert.Equal(t, "신청한 사이트 멤버", created["name"])
+
+	req = httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/segments/%v/members", created["id"]), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalCount"])
+}
+
+func TestSegmentController_deleteSegment(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodDelete, "/api/segments/1", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/segments/1", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,362 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/serviceaccount/domain"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type ServiceAccountController struct {
+	routerGroup           *gin.RouterGroup
+	serviceAccountService *services.ServiceAccountService
+	approvalService       *services.ApprovalService
+}
+
+func NewServiceAccountController(
+	routerGroup *gin.RouterGroup,
+	serviceAccountService *services.ServiceAccountService,
+	approvalService *services.ApprovalService) *ServiceAccountController {
+
+	return &ServiceAccountController{
+		routerGroup:           routerGroup,
+		serviceAccountService: serviceAccountService,
+		approvalService:       approvalService,
+	}
+}
+
+func (c ServiceAccountController) MapRoutes() {
+	route := c.routerGroup.Group("/service-accounts")
+	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.createServiceAccount)
+	// MANAGE_OWN_RESOURCES 권한만 있으면 소유한 서비스 계정을 관리하고 키를 발급할 수 있다. 역할과 토큰 교환 정책은 바꿀 수 없다.
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		etag.HttpEtagCache(0),
+		c.getServiceAccounts)
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.serviceAccountService.AuthorizeOwner),
+		etag.HttpEtagCache(0),
+		c.getServiceAccount)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.serviceAccountService.AuthorizeOwner),
+		c.updateServiceAccount)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.serviceAccountService.AuthorizeOwner),
+		c.deleteServiceAccount)
+	route.PUT("/:id/assign-roles", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.assignRole)
+	route.POST("/:id/api-key", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.serviceAccountService.AuthorizeOwner),
+		c.issueApiKey)
+	route.POST("/:id/client-secret", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.serviceAccountService.AuthorizeOwner),
+		c.issueClientSecret)
+	route.GET("/:id/token-exchange-policies", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getTokenExchangePolicies)
+	route.PUT("/:id/token-exchange-policies", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setTokenExchangePolicies)
+}
+
+func (c ServiceAccountController) createServiceAccount(ctx *gin.Context) {
+	var information dtos.ServiceAccountInformation
+	if err := ctx.Bind(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.serviceAccountService.CreateServiceAccount(ctx.Request.Context(), information)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+func (c ServiceAccountController) getServiceAccounts(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.serviceAccountService.GetServiceAccounts(ctx.Request.Context(), listFiltersOf(ctx), pageable)
+	if err != nil {
+		if errors.Is(err, errors.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var serviceAccounts = make([]dtos.ServiceAccountInformation, 0)
+	for _, entity := range entities {
+		serviceAccounts = append(serviceAccounts, c.toServiceAccountInformation(entity))
+	}
+
+	pageResult := dtos.PageResult{
+		Result:     serviceAccounts,
+		TotalCount: totalCount,
+	}
+
+	ctx.JSON(http.StatusOK, pageResult)
+}
+
+func (c ServiceAccountController) getServiceAccount(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.serviceAccountService.GetServiceAccount(ctx.Request.Context(), uint(serviceAccountId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toServiceAccountInformation(entity))
+}
+
+func (c ServiceAccountController) updateServiceAccount(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.ServiceAccountInformation
+	if err := ctx.Bind(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.serviceAccountService.UpdateServiceAccount(ctx.Request.Context(), uint(serviceAccountId), information)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c ServiceAccountController) deleteServiceAccount(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.serviceAccountService.DeleteServiceAccount(ctx.Request.Context(), uint(serviceAccountId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c ServiceAccountController) assignRole(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var assignRole dtos.ServiceAccountAssignRole
+	if err := ctx.BindJSON(&assignRole); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.serviceAccountService.AssignRole(ctx.Request.Context(), uint(serviceAccountId), assignRole)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusOK)
+}
+
+func (c ServiceAccountController) issueApiKey(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := c.serviceAccountService.GetServiceAccount(ctx.Request.Context(), uint(serviceAccountId)); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	// API Key 는 한 번만 노출되므로 승인이 끝난 뒤 요청자가 다시 요청하면 발급한다.
+	approved, err := c.approvalService.RequireApproval(ctx.Request.Context(), constants.ApprovalSubjectApiKeyCreation, uint(serviceAccountId), nil)
+	if err != nil {
+		if errors.Is(err, errors.ErrApprovalInProgress) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if !approved {
+		ctx.Status(http.StatusAccepted)
+		return
+	}
+
+	apiKey, err := c.serviceAccountService.IssueApiKey(ctx.Request.Context(), uint(serviceAccountId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if respondLicenseError(ctx, err) {
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.ServiceAccountApiKey{ApiKey: apiKey})
+}
+
+func (c ServiceAccountController) issueClientSecret(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	clientSecret, err := c.serviceAccountService.IssueClientSecret(ctx.Request.Context(), uint(serviceAccountId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, clientSecret)
+}
+
+func (c ServiceAccountController) getTokenExchangePolicies(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entities, err := c.serviceAccountService.GetTokenExchangePolicies(ctx.Request.Context(), uint(serviceAccountId))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	policies := make([]dtos.TokenExchangePolicy, 0)
+	for _, entity := range entities {
+		policies = append(policies, dtos.TokenExchangePolicy{
+			Audience:        entity.Audience,
+			Scopes:          entity.GetScopes(),
+			LifetimeSeconds: entity.LifetimeSeconds,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, dtos.ServiceAccountTokenExchangePolicies{Policies: policies})
+}
+
+func (c ServiceAccountController) setTokenExchangePolicies(ctx *gin.Context) {
+	serviceAccountId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var tokenExchangePolicies dtos.ServiceAccountTokenExchangePolicies
+	if err := ctx.BindJSON(&tokenExchangePolicies); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.serviceAccountService.SetTokenExchangePolicies(ctx.Request.Context(), uint(serviceAccountId), tokenExchangePolicies.Policies)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, errors.ErrDuplicated) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (ServiceAccountController) toServiceAccountInformation(entity domain.ServiceAccountEntity) dtos.ServiceAccountInformation {
+	var roles = make([]dtos.ServiceAccountRole, 0)
+	for _, role := range entity.Roles {
+		roles = append(roles, dtos.ServiceAccountRole{
+			Id:   role.ID,
+			Name: role.Name,
+		})
+	}
+
+	return dtos.ServiceAccountInformation{
+		Id:                   entity.ID,
+		Name:                 entity.Name,
+		Description:          entity.Description,
+		TokenLifetimeSeconds: entity.TokenLifetimeSeconds,
+		ClientId:             entity.ClientId,
+		Roles:                roles,
+		ApiKeyPrefix:         entity.ApiKeyPrefix,
+		ApiKeyIssuedAt:       entity.ApiKeyIssuedAt,
+		OwnerId:              entity.GetOwnerId(),
+		CreatedAt:            entity.CreatedAt,
+	}
+}
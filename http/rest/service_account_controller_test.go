@@ -0,0 +1,299 @@
+package rest
+
+import (
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceAccountController_getServiceAccounts(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/service-accounts?page=1&pageSize=10", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalCount"])
+	serviceAccount := actual["result"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "배포 자동화", serviceAccount["name"])
+	assert.Equal(t, "sa_test-api", serviceAccount["apiKeyPrefix"])
+	assert.Equal(t, "SYSTEM MANAGER", serviceAccount["roles"].([]interface{})[0].(map[string]interface{})["name"])
+}
+
+func TestServiceAccountController_createServiceAccount_필수값_확인(t *testing.T) {
+	// given
+	requestBody := `{
+		"description": "설명...."
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/service-accounts", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServiceAccountController_API_Key_인증(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"name": "모니터링 수집기"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/service-accounts", strings.NewReader(requestBody))
+	req.Header.Set("X-Api-Key", "sa_test-api-key")
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// 서비스 계정이 수행한 작업은 감사 로그에 서비스 계정으로 기록된다.
+	var actorType string
+	gormDB.Raw("SELECT actor_type FROM audit_logs WHERE action = ? ORDER BY id DESC LIMIT 1", "service-account-created").Scan(&actorType)
+	assert.Equal(t, "service-account", actorType)
+}
+
+func TestServiceAccountController_API_Key_인증_실패(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/service-accounts", nil)
+	req.Header.Set("X-Api-Key", "sa_invalid")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServiceAccountController_issueApiKey(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/service-accounts/1/api-key", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	apiKey := actual["apiKey"].(string)
+	assert.True(t, strings.HasPrefix(apiKey, "sa_"))
+
+	// 새로 발급한 키만 사용할 수 있다.
+	req = httptest.NewRequest(http.MethodGet, "/api/service-accounts/1", nil)
+	req.Header.Set("X-Api-Key", apiKey)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/service-accounts/1", nil)
+	req.Header.Set("X-Api-Key", "sa_test-api-key")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServiceAccountController_assignRole(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"roleIds": [2]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/service-accounts/1/assign-roles", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// MANAGE_SYSTEM_SETTINGS 권한이 있는 역할이 해제되어 더 이상 서비스 계정 API 접근이 불가하다.
+	req = httptest.NewRequest(http.MethodGet, "/api/service-accounts", nil)
+	req.Header.Set("X-Api-Key", "sa_test-api-key")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestServiceAccountController_issueClientSecret(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/service-accounts/1/client-secret", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	// 이미 발급된 Client Id 는 유지하고 Secret 만 교체한다.
+	assert.Equal(t, "test-client", actual["clientId"])
+	assert.NotEmpty(t, actual["clientSecret"])
+}
+
+func setTestTokenExchangePolicies(t *testing.T, serviceAccountId uint, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/service-accounts/%d/token-exchange-policies", serviceAccountId), strings.NewReader(body))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id": 1,
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServiceAccountController_tokenExchangePolicies(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := setTestTokenExchangePolicies(t, 1, `{
+		"policies": [
+			{"audience": "billing-service", "scopes": ["MANAGE_MEMBERS"], "lifetimeSeconds": 120},
+			{"audience": "report-service", "scopes": []}
+		]
+	}`)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/service-accounts/1/token-exchange-policies", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"MANAGE_SYSTEM_SETTINGS"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	policies := actual["policies"].([]interface{})
+	assert.Equal(t, 2, len(policies))
+	assert.Equal(t, map[string]interface{}{"audience": "billing-service", "scopes": []interface{}{"MANAGE_MEMBERS"}, "lifetimeSeconds": float64(120)}, policies[0])
+	assert.Equal(t, "report-service", policies[1].(map[string]interface{})["audience"])
+
+	var auditDetail string
+	gormDB.Raw("SELECT detail FROM audit_logs WHERE action = 'token-exchange-policy-changed'").Scan(&auditDetail)
+	assert.Equal(t, "billing-service,report-service", auditDetail)
+}
+
+func TestServiceAccountController_tokenExchangePolicies_중복된_audience(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := setTestTokenExchangePolicies(t, 1, `{
+		"policies": [
+			{"audience": "billing-service", "scopes": ["MANAGE_MEMBERS"]},
+			{"audience": "billing-service", "scopes": ["MANAGE_SYSTEM_SETTINGS"]}
+		]
+	}`)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusNotFound, setTestTokenExchangePolicies(t, 100, `{"policies": []}`).Code)
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type ServiceStatusController struct {
+	routerGroup          *gin.RouterGroup
+	serviceStatusService *services.ServiceStatusService
+}
+
+func NewServiceStatusController(
+	routerGroup *gin.RouterGroup,
+	serviceStatusService *services.ServiceStatusService) *ServiceStatusController {
+
+	return &ServiceStatusController{
+		routerGroup:          routerGroup,
+		serviceStatusService: serviceStatusService,
+	}
+}
+
+func (c ServiceStatusController) MapRoutes() {
+	// 인증하지 않으므로 요청 수를 제한하고, 점검 항목을 요청마다 실행하지 않도록 응답을 저장한다.
+	c.routerGroup.GET("/status",
+		middlewares.Public(),
+		middlewares.RateLimit("status", time.Minute, func() int { return config.Config.Status.RateLimitPerMinute }),
+		middlewares.ResponseCache(constants.ResponseCacheServiceStatus, time.Duration(config.Config.Status.CacheSeconds)*time.Second),
+		c.getServiceStatus)
+
+	route := c.routerGroup.Group("/site")
+	route.GET("/settings/announcement-banners",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getAnnouncementBannerSetting)
+	route.PUT("/settings/announcement-banners",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		middlewares.PurgeResponseCache(constants.ResponseCacheServiceStatus),
+		c.setAnnouncementBannerSetting)
+}
+
+// getServiceStatus 는 로그인하지 않은 사용자와 모니터링도 서비스 상태를 볼 수 있도록 권한을 확인하지 않는다.
+func (c ServiceStatusController) getServiceStatus(ctx *gin.Context) {
+	status, err := c.serviceStatusService.GetServiceStatus(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+func (c ServiceStatusController) getAnnouncementBannerSetting(ctx *gin.Context) {
+	setting, err := c.serviceStatusService.GetAnnouncementBannerSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c ServiceStatusController) setAnnouncementBannerSetting(ctx *gin.Context) {
+	var setting dtos.AnnouncementBannerSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.serviceStatusService.SetAnnouncementBannerSetting(ctx.Request.Context(), setting); err != nil {
+		if errors.Is(err, errors.ErrInvalidPeriod) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setUpAnnouncementBannerSetting(requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/announcement-banners", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func requestTestServiceStatus(remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/status", nil)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServiceStatusController_getServiceStatus(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	now := time.Now()
+	rec := setUpAnnouncementBannerSetting(fmt.Sprintf(`{"banners": [
+		{"id": "always", "message": "새 기능이 추가되었습니다.", "level": "info"},
+		{"id": "current", "message": "오늘 밤 점검 예정입니다.", "level": "warning", "startAt": "%s", "endAt": "%s"},
+		{"id": "expired", "message": "지난 공지", "level": "info", "endAt": "%s"},
+		{"id": "upcoming", "message": "다음 공지", "level": "critical", "startAt": "%s"}
+	]}`,
+		now.Add(-time.Minute).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339),
+		now.Add(-time.Minute).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339)))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	rec = requestTestServiceStatus("192.0.2.10:1234")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Cache-Control"), "public")
+	var status dtos.ServiceStatus
+	json.Unmarshal(rec.Body.Bytes(), &status)
+	assert.Equal(t, config.Version, status.Build.Version)
+	assert.False(t, status.Maintenance.Active)
+	assert.NotEqual(t, constants.ServiceStatusMaintenance, status.Status)
+	var bannerIds []string
+	for _, banner := range status.Banners {
+		bannerIds = append(bannerIds, banner.Id)
+	}
+	assert.Equal(t, []string{"always", "current"}, bannerIds)
+	// 점검 항목의 상세 결과는 공개하지 않는다.
+	assert.NotContains(t, rec.Body.String(), "results")
+
+	// 점검 중에도 상태를 볼 수 있다.
+	setUpMaintenanceSetting(t, `{"enabled": true, "message": "DB 점검 중입니다."}`)
+	rec = requestTestServiceStatus("192.0.2.10:1234")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &status)
+	assert.Equal(t, constants.ServiceStatusMaintenance, status.Status)
+	assert.Equal(t, "DB 점검 중입니다.", status.Maintenance.Message)
+}
+
+func TestServiceStatusController_setAnnouncementBannerSetting_잘못된_요청(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	rec := setUpAnnouncementBannerSetting(`{"banners": [{"id": "notice", "message": "공지", "level": "urgent"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = setUpAnnouncementBannerSetting(`{"banners": [{"id": "notice", "message": "공지", "level": "info",
+		"startAt": "2026-10-02T00:00:00+09:00", "endAt": "2026-10-01T00:00:00+09:00"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServiceStatusController_getServiceStatus_요청_수_제한(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	rateLimit := config.Config.Status.RateLimitPerMinute
+	config.Config.Status.RateLimitPerMinute = 2
+	defer func() { config.Config.Status.RateLimitPerMinute = rateLimit }()
+
+	// given
+	for i := 0; i < 2; i++ {
+		rec := requestTestServiceStatus("198.51.100.7:1234")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// when
+	rec := requestTestServiceStatus("198.51.100.7:1234")
+
+	// then
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// 다른 클라이언트는 제한하지 않는다.
+	rec = requestTestServiceStatus("198.51.100.8:1234")
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
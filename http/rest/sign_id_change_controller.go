@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SignIdChangeController struct {
+	routerGroup         *gin.RouterGroup
+	signIdChangeService *services.SignIdChangeService
+}
+
+func NewSignIdChangeController(
+	routerGroup *gin.RouterGroup,
+	signIdChangeService *services.SignIdChangeService) *SignIdChangeController {
+
+	return &SignIdChangeController{
+		routerGroup:         routerGroup,
+		signIdChangeService: signIdChangeService,
+	}
+}
+
+func (c SignIdChangeController) MapRoutes() {
+	route := c.routerGroup.Group("/members")
+	route.POST("/me/sign-id-change", middlewares.PermissionChecker([]string{"*"}),
+		c.requestSignIdChange)
+	// 확인/취소는 메일로 받은 토큰으로 인증한다.
+	route.POST("/sign-id-change/confirm", middlewares.Public(), c.confirmSignIdChange)
+	route.POST("/sign-id-change/cancel", middlewares.Public(), c.cancelSignIdChange)
+}
+
+func (c SignIdChangeController) requestSignIdChange(ctx *gin.Context) {
+	var request dtos.SignIdChangeRequest
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.signIdChangeService.RequestSignIdChange(ctx.Request.Context(), request)
+	if err != nil {
+		if errors.Is(err, errors.ErrAuthentication) || errors.Is(err, errors.ErrDuplicated) || errors.Is(err, errors.ErrNonChangeable) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+func (c SignIdChangeController) confirmSignIdChange(ctx *gin.Context) {
+	var token dtos.SignIdChangeToken
+	if err := ctx.BindJSON(&token); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.signIdChangeService.ConfirmSignIdChange(ctx.Request.Context(), token.Token)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, errors.ErrExpired) || errors.Is(err, errors.ErrNonChangeable) || errors.Is(err, errors.ErrDuplicated) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SignIdChangeController) cancelSignIdChange(ctx *gin.Context) {
+	var token dtos.SignIdChangeToken
+	if err := ctx.BindJSON(&token); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := c.signIdChangeService.CancelSignIdChange(ctx.Request.Context(), token.Token)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, errors.ErrNonChangeable) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
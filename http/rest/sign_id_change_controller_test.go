@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeMailSender struct {
+	messages []dtos.MailMessage
+}
+
+func (f *fakeMailSender) Send(message dtos.MailMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func extractMailToken(message dtos.MailMessage) string {
+	return regexp.MustCompile(`token=([0-9a-f]+)`).FindStringSubmatch(message.Body)[1]
+}
+
+func TestSignIdChangeController_아이디_변경_확인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	mailSender := &fakeMailSender{}
+	adapters.MailAdapter().SetSender(mailSender)
+	defer adapters.MailAdapter().SetSender(nil)
+
+	// given
+	requestBody := `{
+		"newSignId": "siteadm@bettercode.kr",
+		"password": "123456"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/me/sign-id-change", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, 1, len(mailSender.messages))
+	assert.Equal(t, []string{"siteadm@bettercode.kr"}, mailSender.messages[0].To)
+
+	// 확인 전에는 아이디가 변경되지 않는다.
+	var signId string
+	gormDB.Raw("SELECT sign_id FROM members WHERE id = 1").Scan(&signId)
+	assert.Equal(t, "siteadm", signId)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/members/sign-id-change/confirm",
+		strings.NewReader(fmt.Sprintf(`{"token": "%s"}`, extractMailToken(mailSender.messages[0]))))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	gormDB.Raw("SELECT sign_id FROM members WHERE id = 1").Scan(&signId)
+	assert.Equal(t, "siteadm@bettercode.kr", signId)
+
+	// 변경이 완료되면 멤버의 모든 세션이 종료된다.
+	var activeSessionCount int64
+	gormDB.Raw("SELECT count(*) FROM member_sessions WHERE member_id = 1 AND revoked_at IS NULL").Scan(&activeSessionCount)
+	assert.Equal(t, int64(0), activeSessionCount)
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = 'sign-id-changed' AND target_id = 1").Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+}
+
+func TestSignIdChangeController_아이디_변경_비밀번호_불일치(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"newSignId": "siteadm@bettercode.kr",
+		"password": "wrong"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/me/sign-id-change", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSignIdChangeController_만료된_요청_확인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/members/sign-id-change/confirm", strings.NewReader(`{"token": "expired-token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var signId, status string
+	gormDB.Raw("SELECT sign_id FROM members WHERE id = 3").Scan(&signId)
+	gormDB.Raw("SELECT status FROM sign_id_changes WHERE id = 1").Scan(&status)
+	assert.Equal(t, "ymyoo", signId)
+	assert.Equal(t, "expired", status)
+}
+
+func TestSignIdChangeController_아이디_변경_취소(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/members/sign-id-change/cancel", strings.NewReader(`{"token": "expired-cancel-token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var status string
+	gormDB.Raw("SELECT status FROM sign_id_changes WHERE id = 1").Scan(&status)
+	assert.Equal(t, "canceled", status)
+}
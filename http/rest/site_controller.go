@@ -7,25 +7,56 @@ import (
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/services"
+	siteDomain "better-admin-backend-service/site/domain"
+	"encoding/json"
 	etag "github.com/bettercode-oss/gin-middleware-etag"
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 	pkgerrors "github.com/pkg/errors"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type SiteController struct {
-	routerGroup *gin.RouterGroup
-	siteService *services.SiteService
+	routerGroup             *gin.RouterGroup
+	siteService             *services.SiteService
+	sessionService          *services.SessionService
+	approvalService         *services.ApprovalService
+	pendingSignUpService    *services.PendingSignUpService
+	maintenanceService      *services.MaintenanceService
+	dataMaskingService      *services.DataMaskingService
+	concurrencyLimitService *services.ConcurrencyLimitService
+	loginSettingService     *services.LoginSettingService
+	memberApprovalService   *services.MemberApprovalService
+	googleWorkspaceService  *services.GoogleWorkspaceService
 }
 
 func NewSiteController(
 	routerGroup *gin.RouterGroup,
-	siteService *services.SiteService) *SiteController {
+	siteService *services.SiteService,
+	sessionService *services.SessionService,
+	approvalService *services.ApprovalService,
+	pendingSignUpService *services.PendingSignUpService,
+	maintenanceService *services.MaintenanceService,
+	dataMaskingService *services.DataMaskingService,
+	concurrencyLimitService *services.ConcurrencyLimitService,
+	loginSettingService *services.LoginSettingService,
+	memberApprovalService *services.MemberApprovalService,
+	googleWorkspaceService *services.GoogleWorkspaceService) *SiteController {
 
 	return &SiteController{
-		routerGroup: routerGroup,
-		siteService: siteService,
+		routerGroup:             routerGroup,
+		siteService:             siteService,
+		sessionService:          sessionService,
+		approvalService:         approvalService,
+		pendingSignUpService:    pendingSignUpService,
+		maintenanceService:      maintenanceService,
+		dataMaskingService:      dataMaskingService,
+		concurrencyLimitService: concurrencyLimitService,
+		loginSettingService:     loginSettingService,
+		memberApprovalService:   memberApprovalService,
+		googleWorkspaceService:  googleWorkspaceService,
 	}
 }
 
@@ -33,6 +64,7 @@ func (c SiteController) MapRoutes() {
 	route := c.routerGroup.Group("/site")
 
 	route.GET("/settings",
+		middlewares.Public(),
 		etag.HttpEtagCache(0),
 		c.getSettingsSummary)
 	route.GET("/settings/dooray-login",
@@ -41,19 +73,133 @@ func (c SiteController) MapRoutes() {
 		c.getDoorayLoginSetting)
 	route.PUT("/settings/dooray-login",
 		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		middlewares.PurgeResponseCache(constants.ResponseCacheLoginPage),
 		c.setDoorayLoginSetting)
+	route.POST("/settings/dooray-login/validate",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.validateDoorayLoginSetting)
 	route.GET("/settings/google-workspace-login",
 		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
 		etag.HttpEtagCache(0),
 		c.getGoogleWorkspaceLoginSetting)
 	route.PUT("/settings/google-workspace-login",
 		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		middlewares.PurgeResponseCache(constants.ResponseCacheLoginPage),
 		c.setGoogleWorkspaceLoginSetting)
+	route.POST("/settings/google-workspace-login/validate",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.validateGoogleWorkspaceLoginSetting)
+	route.GET("/settings/google-workspace",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getGoogleWorkspaceSetting)
+	route.PUT("/settings/google-workspace",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setGoogleWorkspaceSetting)
 	route.GET("/settings/app-version",
+		middlewares.Public(),
 		etag.HttpEtagCache(0),
+		middlewares.ResponseCache(constants.ResponseCacheAppVersion, time.Minute),
 		c.getAppVersion)
 	route.PUT("/settings/app-version",
+		middlewares.Public(),
+		middlewares.PurgeResponseCache(constants.ResponseCacheAppVersion),
 		c.increaseAppVersion)
+	route.GET("/settings/session-limit",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getSessionLimitSetting)
+	route.PUT("/settings/session-limit",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setSessionLimitSetting)
+	route.GET("/settings/refresh-token-binding",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getRefreshTokenBindingSetting)
+	route.PUT("/settings/refresh-token-binding",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setRefreshTokenBindingSetting)
+	route.GET("/settings/approval-workflow",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getApprovalWorkflowSetting)
+	route.PUT("/settings/approval-workflow",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setApprovalWorkflowSetting)
+	route.GET("/settings/pending-signup",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getPendingSignUpSetting)
+	route.PUT("/settings/pending-signup",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setPendingSignUpSetting)
+	route.GET("/settings/maintenance",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getMaintenanceSetting)
+	route.PUT("/settings/maintenance",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		middlewares.PurgeResponseCache(constants.ResponseCacheMaintenanceStatus, constants.ResponseCacheServiceStatus),
+		c.setMaintenanceSetting)
+	route.GET("/settings/data-masking",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getDataMaskingSetting)
+	route.PUT("/settings/data-masking",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setDataMaskingSetting)
+	route.GET("/settings/concurrency-limit",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getConcurrencyLimitSetting)
+	route.PUT("/settings/concurrency-limit",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setConcurrencyLimitSetting)
+	route.GET("/settings/login",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getLoginSetting)
+	route.PUT("/settings/login",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		middlewares.PurgeResponseCache(constants.ResponseCacheLoginPage),
+		c.setLoginSetting)
+	route.GET("/settings/member-approval",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getMemberApprovalSetting)
+	route.PUT("/settings/member-approval",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setMemberApprovalSetting)
+	route.GET("/settings/auto-approval",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getAutoApprovalSetting)
+	route.PUT("/settings/auto-approval",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setAutoApprovalSetting)
+	route.POST("/settings/auto-approval/dry-run",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.dryRunAutoApproval)
+	route.GET("/settings/versions",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getSettingVersions)
+	route.GET("/settings/versions/:version",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getSettingVersion)
+	route.POST("/settings/versions/:version/rollback",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		middlewares.PurgeResponseCache(constants.ResponseCacheLoginPage, constants.ResponseCacheMaintenanceStatus, constants.ResponseCacheAppVersion,
+			constants.ResponseCacheServiceStatus),
+		c.rollbackSettings)
+	// 점검 일정의 시작, 종료가 늦게 반영되지 않도록 짧게 저장한다.
+	route.GET("/maintenance",
+		middlewares.Public(),
+		middlewares.ResponseCache(constants.ResponseCacheMaintenanceStatus, 10*time.Second),
+		c.getMaintenanceStatus)
+	route.GET("/login",
+		middlewares.Public(),
+		middlewares.ResponseCache(constants.ResponseCacheLoginPage, time.Minute),
+		c.getLoginPage)
 }
 func (c SiteController) getSettingsSummary(ctx *gin.Context) {
 	settings, err := c.siteService.GetSettings(ctx.Request.Context())
@@ -89,7 +235,12 @@ func (c SiteController) getSettingsSummary(ctx *gin.Context) {
 
 			if *googleWorkspaceSetting.Used {
 				summary.GoogleWorkspaceLoginUsed = true
-				summary.GoogleWorkspaceOAuthUri = googleWorkspaceSetting.GetOAuthUri()
+				oauthUri, err := c.googleWorkspaceService.GetOAuthUri(ctx.Request.Context(), googleWorkspaceSetting)
+				if err != nil {
+					helpers.ErrorHelper().InternalServerError(ctx, err)
+					return
+				}
+				summary.GoogleWorkspaceOAuthUri = oauthUri
 			}
 		}
 	}
@@ -100,7 +251,7 @@ func (c SiteController) getSettingsSummary(ctx *gin.Context) {
 func (c SiteController) getDoorayLoginSetting(ctx *gin.Context) {
 	setting, err := c.siteService.GetSettingWithKey(ctx.Request.Context(), constants.SettingKeyDoorayLogin)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.JSON(http.StatusOK, dtos.DoorayLoginSetting{})
 			return
 		}
@@ -120,6 +271,14 @@ func (c SiteController) setDoorayLoginSetting(ctx *gin.Context) {
 		return
 	}
 
+	// validate=true 이면 LDAP 서버에 연결할 수 없는 설정은 적용하지 않는다.
+	if ctx.Query("validate") == "true" {
+		if validation := c.siteService.ValidateDoorayLoginSetting(setting); !validation.Valid {
+			ctx.JSON(http.StatusBadRequest, validation)
+			return
+		}
+	}
+
 	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyDoorayLogin, setting); err != nil {
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
@@ -128,10 +287,21 @@ func (c SiteController) setDoorayLoginSetting(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+func (c SiteController) validateDoorayLoginSetting(ctx *gin.Context) {
+	var setting dtos.DoorayLoginSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.siteService.ValidateDoorayLoginSetting(setting))
+}
+
 func (c SiteController) getGoogleWorkspaceLoginSetting(ctx *gin.Context) {
 	setting, err := c.siteService.GetSettingWithKey(ctx.Request.Context(), constants.SettingKeyGoogleWorkspaceLogin)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.JSON(http.StatusOK, dtos.GoogleWorkspaceLoginSetting{})
 			return
 		}
@@ -151,6 +321,14 @@ func (c SiteController) setGoogleWorkspaceLoginSetting(ctx *gin.Context) {
 		return
 	}
 
+	// validate=true 이면 OAuth 서버에 연결할 수 없는 설정은 적용하지 않는다.
+	if ctx.Query("validate") == "true" {
+		if validation := c.siteService.ValidateGoogleWorkspaceLoginSetting(setting); !validation.Valid {
+			ctx.JSON(http.StatusBadRequest, validation)
+			return
+		}
+	}
+
 	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyGoogleWorkspaceLogin, setting); err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -159,6 +337,49 @@ func (c SiteController) setGoogleWorkspaceLoginSetting(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+func (c SiteController) validateGoogleWorkspaceLoginSetting(ctx *gin.Context) {
+	var setting dtos.GoogleWorkspaceLoginSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.siteService.ValidateGoogleWorkspaceLoginSetting(setting))
+}
+
+func (c SiteController) getGoogleWorkspaceSetting(ctx *gin.Context) {
+	setting, err := c.googleWorkspaceService.GetGoogleWorkspaceSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+// setGoogleWorkspaceSetting 은 로그인을 허용할 도메인 목록을 바꾼다. 목록에 없는 도메인의 계정은 로그인할 수 없다.
+func (c SiteController) setGoogleWorkspaceSetting(ctx *gin.Context) {
+	var setting dtos.GoogleWorkspaceSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.googleWorkspaceService.SetGoogleWorkspaceSetting(ctx.Request.Context(), setting); err != nil {
+		if invalidSetting, ok := err.(*errors.ErrInvalidGoogleWorkspaceSetting); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: invalidSetting.Error()})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func (c SiteController) getAppVersion(ctx *gin.Context) {
 	appVersion, err := c.siteService.GetAppVersion(ctx.Request.Context())
 	if err != nil {
@@ -178,3 +399,418 @@ func (c SiteController) increaseAppVersion(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func (c SiteController) getSessionLimitSetting(ctx *gin.Context) {
+	setting, err := c.sessionService.GetSessionLimitSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setSessionLimitSetting(ctx *gin.Context) {
+	var setting dtos.SessionLimitSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeySessionLimit, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getRefreshTokenBindingSetting(ctx *gin.Context) {
+	setting, err := c.sessionService.GetRefreshTokenBindingSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setRefreshTokenBindingSetting(ctx *gin.Context) {
+	var setting dtos.RefreshTokenBindingSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyRefreshTokenBinding, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getApprovalWorkflowSetting(ctx *gin.Context) {
+	setting, err := c.approvalService.GetWorkflowSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setApprovalWorkflowSetting(ctx *gin.Context) {
+	var setting dtos.ApprovalWorkflowSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyApprovalWorkflow, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getPendingSignUpSetting(ctx *gin.Context) {
+	setting, err := c.pendingSignUpService.GetPendingSignUpSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setPendingSignUpSetting(ctx *gin.Context) {
+	var setting dtos.PendingSignUpSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyPendingSignUp, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getMaintenanceSetting(ctx *gin.Context) {
+	setting, err := c.maintenanceService.GetMaintenanceSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setMaintenanceSetting(ctx *gin.Context) {
+	var setting dtos.MaintenanceSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.maintenanceService.SetMaintenanceSetting(ctx.Request.Context(), setting); err != nil {
+		if errors.Is(err, errors.ErrInvalidPeriod) {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// getMaintenanceStatus 는 로그인하지 않은 사용자도 점검 안내를 볼 수 있도록 권한을 확인하지 않는다.
+func (c SiteController) getMaintenanceStatus(ctx *gin.Context) {
+	status, err := c.maintenanceService.GetMaintenanceStatus(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, status)
+}
+
+func (c SiteController) getDataMaskingSetting(ctx *gin.Context) {
+	setting, err := c.dataMaskingService.GetDataMaskingSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setDataMaskingSetting(ctx *gin.Context) {
+	var setting dtos.DataMaskingSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.dataMaskingService.SetDataMaskingSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getConcurrencyLimitSetting(ctx *gin.Context) {
+	setting, err := c.concurrencyLimitService.GetConcurrencyLimitSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setConcurrencyLimitSetting(ctx *gin.Context) {
+	var setting dtos.ConcurrencyLimitSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	names := make(map[string]bool)
+	for _, group := range setting.RouteGroups {
+		if names[group.Name] {
+			ctx.JSON(http.StatusBadRequest, "duplicate route group name: "+group.Name)
+			return
+		}
+		names[group.Name] = true
+	}
+
+	if err := c.concurrencyLimitService.SetConcurrencyLimitSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getLoginSetting(ctx *gin.Context) {
+	setting, err := c.loginSettingService.GetLoginSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setLoginSetting(ctx *gin.Context) {
+	var setting dtos.LoginSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.loginSettingService.SetLoginSetting(ctx.Request.Context(), setting); err != nil {
+		var invalidLoginSetting *errors.ErrInvalidLoginSetting
+		if pkgerrors.As(err, &invalidLoginSetting) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getMemberApprovalSetting(ctx *gin.Context) {
+	setting, err := c.memberApprovalService.GetMemberApprovalSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setMemberApprovalSetting(ctx *gin.Context) {
+	var setting dtos.MemberApprovalSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.memberApprovalService.SetMemberApprovalSetting(ctx.Request.Context(), setting); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Message: "role not found"})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getAutoApprovalSetting(ctx *gin.Context) {
+	setting, err := c.memberApprovalService.GetAutoApprovalSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setAutoApprovalSetting(ctx *gin.Context) {
+	var setting dtos.AutoApprovalSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.memberApprovalService.SetAutoApprovalSetting(ctx.Request.Context(), setting); err != nil {
+		c.handleAutoApprovalError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// dryRunAutoApproval 은 저장하지 않은 규칙(또는 저장된 규칙)으로 승인 대기 멤버 중 자동 승인될 멤버를 보여준다.
+func (c SiteController) dryRunAutoApproval(ctx *gin.Context) {
+	var dryRun dtos.AutoApprovalDryRun
+
+	if err := ctx.BindJSON(&dryRun); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberApprovalService.DryRunAutoApproval(ctx.Request.Context(), dryRun)
+	if err != nil {
+		c.handleAutoApprovalError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (SiteController) handleAutoApprovalError(ctx *gin.Context, err error) {
+	if _, ok := err.(*errors.ErrInvalidAutoApprovalRule); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
+
+// getLoginPage 는 로그인 화면에서 사용하므로 권한을 확인하지 않는다.
+func (c SiteController) getLoginPage(ctx *gin.Context) {
+	loginPage, err := c.loginSettingService.GetLoginPage(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, loginPage)
+}
+
+func (c SiteController) getSettingVersions(ctx *gin.Context) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+
+	entities, totalCount, err := c.siteService.GetSettingVersions(ctx.Request.Context(), pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var versions = make([]dtos.SiteSettingVersion, 0)
+	for _, entity := range entities {
+		versions = append(versions, c.toSiteSettingVersion(entity))
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     versions,
+		TotalCount: totalCount,
+	})
+}
+
+func (c SiteController) getSettingVersion(ctx *gin.Context) {
+	version, err := strconv.ParseUint(ctx.Param("version"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.siteService.GetSettingVersion(ctx.Request.Context(), uint(version))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	var settings map[string]interface{}
+	if err := json.Unmarshal([]byte(entity.Document), &settings); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.SiteSettingVersionDetails{
+		SiteSettingVersion: c.toSiteSettingVersion(entity),
+		Settings:           settings,
+	})
+}
+
+// rollbackSettings 는 설정을 지정한 버전으로 되돌린다. 서명이 맞지 않는 스냅샷(DB 에서 직접 바꾼 경우)은 409 로 응답한다.
+func (c SiteController) rollbackSettings(ctx *gin.Context) {
+	version, err := strconv.ParseUint(ctx.Param("version"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.siteService.RollbackSettings(ctx.Request.Context(), uint(version))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, errors.ErrInvalidSignature) {
+			ctx.JSON(http.StatusConflict, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.toSiteSettingVersion(entity))
+}
+
+func (SiteController) toSiteSettingVersion(entity siteDomain.SettingVersionEntity) dtos.SiteSettingVersion {
+	return dtos.SiteSettingVersion{
+		Version:        entity.Version,
+		ChangedKey:     entity.ChangedKey,
+		RolledBackFrom: entity.RolledBackFrom,
+		CreatedBy:      entity.CreatedBy,
+		CreatedAt:      entity.CreatedAt,
+	}
+}
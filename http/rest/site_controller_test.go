@@ -1,12 +1,23 @@
 package rest
 
 import (
+	"better-admin-backend-service/app/middlewares"
+	auditDomain "better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
 	"better-admin-backend-service/testdata/testdb"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -278,6 +289,107 @@ func TestSiteController_setGoogleWorkspaceLoginSetting(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 }
 
+func TestSiteController_getGoogleWorkspaceSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/google-workspace", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// 설정하지 않았으면 구글 워크스페이스 로그인 설정의 도메인만 허용한다.
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"domains": [{"domain": "bettercode.kr", "defaultRoleIds": []}],
+		"verifyHostedDomain": true,
+		"directoryAccess": {"enabled": false, "adminConsented": false}
+	}`, rec.Body.String())
+}
+
+func TestSiteController_setGoogleWorkspaceSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/google-workspace", strings.NewReader(`{
+		"domains": [{"domain": " @BetterCode.kr ", "defaultRoleIds": [2]}, {"domain": "example.com"}],
+		"verifyHostedDomain": true,
+		"directoryAccess": {"enabled": true, "adminConsented": true}
+	}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/google-workspace", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.GoogleWorkspaceSetting
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, []string{"bettercode.kr", "example.com"}, actual.GetDomainNames())
+	assert.Equal(t, []uint{2}, actual.Domains[0].DefaultRoleIds)
+
+	// 디렉터리 프로필을 가져오면 로그인할 때 디렉터리 읽기 범위도 요청한다.
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings", nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var summary dtos.SiteSettingsSummary
+	json.Unmarshal(rec.Body.Bytes(), &summary)
+	assert.True(t, strings.Contains(summary.GoogleWorkspaceOAuthUri, constants.GoogleOAuthScopeDirectoryUserReadonly))
+}
+
+func TestSiteController_setGoogleWorkspaceSetting_Bad_Request(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	testCases := map[string]string{
+		"도메인이 없는 경우":     `{"domains": [], "verifyHostedDomain": true}`,
+		"중복된 도메인":        `{"domains": [{"domain": "bettercode.kr"}, {"domain": "BETTERCODE.KR"}], "verifyHostedDomain": true}`,
+		"잘못된 도메인":        `{"domains": [{"domain": "kim@bettercode.kr"}], "verifyHostedDomain": true}`,
+		"없는 역할":          `{"domains": [{"domain": "bettercode.kr", "defaultRoleIds": [999]}], "verifyHostedDomain": true}`,
+		"관리자 승인 없는 디렉터리": `{"domains": [{"domain": "bettercode.kr"}], "verifyHostedDomain": true, "directoryAccess": {"enabled": true}}`,
+	}
+
+	for name, body := range testCases {
+		// given
+		req := httptest.NewRequest(http.MethodPut, "/api/site/settings/google-workspace", strings.NewReader(body))
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          1,
+			"Permissions": []string{constants.PermissionManageSystemSettings},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		fmt.Println(rec.Body.String())
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+	}
+}
+
 func TestSiteController_setGoogleWorkspaceLoginSetting_Bad_Request_필수_값_확인(t *testing.T) {
 	// given
 	requestBody := `{
@@ -378,4 +490,778 @@ func TestSiteController_increaseAppVersion(t *testing.T) {
 
 	// then
 	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 로그인하지 않고 바꾸는 앱 버전은 설정 스냅샷을 만들지 않는다.
+	var versionCount int64
+	gormDB.Table("site_setting_versions").Count(&versionCount)
+	assert.Equal(t, int64(0), versionCount)
+}
+
+func TestSiteController_getSessionLimitSetting_설정이_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/session-limit", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	fmt.Println(rec.Body.String())
+
+	var actual interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(0), actual.(map[string]interface{})["maxSessions"])
+	assert.Equal(t, "block", actual.(map[string]interface{})["exceedAction"])
+}
+
+func TestSiteController_setSessionLimitSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"maxSessions": 2,
+		"exceedAction": "revoke-oldest",
+		"roleOverrides": [{ "roleName": "SYSTEM MANAGER", "maxSessions": 5 }]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/session-limit", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestSiteController_setSessionLimitSetting_Bad_Request_초과_시_동작_확인(t *testing.T) {
+	// given
+	requestBody := `{
+		"maxSessions": 2,
+		"exceedAction": "ignore"
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/session-limit", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func setUpPendingSignUpSetting(t *testing.T, requestBody string) {
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/pending-signup", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestSiteController_getPendingSignUpSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpPendingSignUpSetting(t, `{"approverRoleName": "MEMBER MANAGER", "reminderDays": 3, "expiryDays": 30}`)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/pending-signup", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "MEMBER MANAGER", actual["approverRoleName"])
+	assert.Equal(t, float64(3), actual["reminderDays"])
+	assert.Equal(t, float64(30), actual["expiryDays"])
+}
+
+func TestPendingSignUpService_재알림과_이관(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpPendingSignUpSetting(t, `{
+		"approverRoleName": "MEMBER MANAGER",
+		"reminderDays": 1,
+		"escalationDays": 2,
+		"escalationRoleName": "SYSTEM MANAGER"
+	}`)
+
+	// when
+	err := NewContainer().PendingSignUpService.ProcessPendingSignUps(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.Nil(t, err)
+
+	var count int64
+	gormDB.Raw("SELECT count(*) FROM members WHERE id = 4 AND sign_up_reminded_at IS NOT NULL AND sign_up_escalated_at IS NOT NULL").Scan(&count)
+	assert.Equal(t, int64(1), count)
+
+	// 승인된 멤버는 처리하지 않는다.
+	gormDB.Raw("SELECT count(*) FROM members WHERE sign_up_reminded_at IS NOT NULL").Scan(&count)
+	assert.Equal(t, int64(1), count)
+
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = 4", constants.AuditActionSignUpEscalated).Scan(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestPendingSignUpService_기한이_지난_가입_신청_자동_거절(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpPendingSignUpSetting(t, `{"expiryDays": 30}`)
+
+	// when
+	err := NewContainer().PendingSignUpService.ProcessPendingSignUps(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.Nil(t, err)
+
+	var count int64
+	gormDB.Raw("SELECT count(*) FROM members WHERE id = 4 AND deleted_at IS NOT NULL").Scan(&count)
+	assert.Equal(t, int64(1), count)
+
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ? AND target_id = 4", constants.AuditActionSignUpExpired).Scan(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func setUpMaintenanceSetting(t *testing.T, requestBody string) {
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/maintenance", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func getMembersWithPermissions(permissions []string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/members", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSiteController_점검_모드(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpMaintenanceSetting(t, `{"enabled": true, "message": "정기 점검 중입니다.", "retryAfterSeconds": 600}`)
+
+	// when
+	rec := getMembersWithPermissions([]string{constants.PermissionManageMembers})
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "600", rec.Header().Get("Retry-After"))
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "정기 점검 중입니다.", actual["message"])
+
+	// 점검 우회 권한이 있으면 사용할 수 있다.
+	rec = getMembersWithPermissions([]string{constants.PermissionManageMembers, constants.PermissionBypassMaintenance})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 점검 중에도 로그인할 수 있다.
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(`{"id": "siteadm", "password": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 점검 모드를 해제하면 다시 사용할 수 있다.
+	setUpMaintenanceSetting(t, `{"enabled": false}`)
+	rec = getMembersWithPermissions([]string{constants.PermissionManageMembers})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSiteController_점검_일정(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	now := time.Now()
+	setUpMaintenanceSetting(t, fmt.Sprintf(`{"windows": [
+		{"startAt": "%s", "endAt": "%s", "message": "DB 점검 중입니다."},
+		{"startAt": "%s", "endAt": "%s", "message": "다음 점검"}
+	]}`,
+		now.Add(-time.Minute).Format(time.RFC3339), now.Add(time.Hour).Format(time.RFC3339),
+		now.Add(24*time.Hour).Format(time.RFC3339), now.Add(25*time.Hour).Format(time.RFC3339)))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/site/maintenance", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, true, actual["active"])
+	assert.Equal(t, "DB 점검 중입니다.", actual["message"])
+	upcomingWindows := actual["upcomingWindows"].([]interface{})
+	assert.Equal(t, 1, len(upcomingWindows))
+	assert.Equal(t, "다음 점검", upcomingWindows[0].(map[string]interface{})["message"])
+
+	rec = getMembersWithPermissions([]string{constants.PermissionManageMembers})
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	retryAfter, _ := strconv.Atoi(rec.Header().Get("Retry-After"))
+	assert.True(t, retryAfter > 3500 && retryAfter <= 3601)
+
+	setUpMaintenanceSetting(t, `{"enabled": false}`)
+}
+
+func TestSiteController_잘못된_점검_일정(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	for _, requestBody := range []string{
+		`{"windows": [{"startAt": "2022-01-02T00:00:00+09:00", "endAt": "2022-01-01T00:00:00+09:00"}]}`,
+		`{"windows": [{"startAt": "2022-01-01 00:00", "endAt": "2022-01-02T00:00:00+09:00"}]}`,
+		`{"retryAfterSeconds": -1}`,
+	} {
+		// given
+		req := httptest.NewRequest(http.MethodPut, "/api/site/settings/maintenance", strings.NewReader(requestBody))
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          1,
+			"Permissions": []string{constants.PermissionManageSystemSettings},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, requestBody)
+	}
+}
+
+func setUpDataMaskingSetting(t *testing.T, requestBody string) {
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/data-masking", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func setUpConcurrencyLimitSetting(t *testing.T, requestBody string) {
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/concurrency-limit", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestSiteController_setConcurrencyLimitSetting_그룹_이름_중복(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{"routeGroups": [{"name": "reports", "pathPrefixes": ["/api/reports"], "maxConcurrent": 1},
+		{"name": "reports", "pathPrefixes": ["/api/files"], "maxConcurrent": 1}]}`
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/concurrency-limit", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSiteController_getLoginPage_응답_캐시(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	middlewares.PurgeResponseCacheByName(constants.ResponseCacheLoginPage)
+
+	requestLoginPage := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/site/login", nil))
+		return rec
+	}
+
+	// when
+	first := requestLoginPage()
+	second := requestLoginPage()
+
+	// then
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get(middlewares.ResponseCacheHeader))
+	assert.Equal(t, "public, max-age=60", first.Header().Get("Cache-Control"))
+	assert.Equal(t, "HIT", second.Header().Get(middlewares.ResponseCacheHeader))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+
+	// 설정을 바꾸면 저장한 응답을 지운다.
+	rec := putLoginSetting(`{"methods": [{"type": "password", "enabled": true}], "defaultRedirect": "/members"}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	third := requestLoginPage()
+	assert.Equal(t, "MISS", third.Header().Get(middlewares.ResponseCacheHeader))
+	var loginPage dtos.LoginPage
+	json.Unmarshal(third.Body.Bytes(), &loginPage)
+	assert.Equal(t, "/members", loginPage.DefaultRedirect)
+	assert.Equal(t, 1, len(loginPage.Methods))
+}
+
+func TestSiteController_마스킹_정책(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	gormDB.Exec("UPDATE members SET google_mail = ?, phone = ? WHERE id = ?", "ymyoo@bettercode.kr", "010-1234-5678", 2)
+
+	// given
+	setUpDataMaskingSetting(t, `{"policies": [
+		{"field": "phone", "method": "phone"},
+		{"field": "email", "method": "email"},
+		{"field": "name", "method": "name"}
+	]}`)
+
+	// when
+	masked := getMemberWithPermissions(2, []string{constants.PermissionManageMembers, constants.PermissionViewMemberPersonalInfo})
+	unmasked := getMemberWithPermissions(2, []string{constants.PermissionManageMembers, constants.PermissionViewMemberPersonalInfo, constants.PermissionUnmask})
+
+	// then
+	assert.Equal(t, "010-****-5678", masked["phone"])
+	assert.Equal(t, "ym***@bettercode.kr", masked["email"])
+	assert.Equal(t, "유*모", masked["name"])
+
+	assert.Equal(t, "010-1234-5678", unmasked["phone"])
+	assert.Equal(t, "ymyoo@bettercode.kr", unmasked["email"])
+	assert.Equal(t, "유영모", unmasked["name"])
+
+	// 원본 데이터는 바뀌지 않는다.
+	var phone string
+	gormDB.Raw("SELECT phone FROM members WHERE id = 2").Scan(&phone)
+	assert.Equal(t, "010-1234-5678", phone)
+}
+
+func TestSiteController_잘못된_마스킹_정책(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/data-masking", strings.NewReader(`{"policies": [{"field": "phone", "method": "hash"}]}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func getLoginPage(t *testing.T) dtos.LoginPage {
+	// 다른 테스트가 DB 를 바꾸기 전에 저장한 응답을 사용하지 않도록 지운다.
+	middlewares.PurgeResponseCacheByName(constants.ResponseCacheLoginPage)
+	req := httptest.NewRequest(http.MethodGet, "/api/site/login", nil)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var loginPage dtos.LoginPage
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &loginPage))
+	return loginPage
+}
+
+func putLoginSetting(requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/login", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSiteController_로그인_화면_기본값(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	loginPage := getLoginPage(t)
+
+	// then
+	assert.Equal(t, 3, len(loginPage.Methods))
+	assert.Equal(t, constants.LoginMethodPassword, loginPage.Methods[0].Type)
+	assert.Equal(t, "/api/auth", loginPage.Methods[0].Endpoint)
+	assert.Equal(t, constants.LoginMethodDooray, loginPage.Methods[1].Type)
+	assert.Equal(t, constants.LoginMethodGoogleWorkspace, loginPage.Methods[2].Type)
+	assert.Contains(t, loginPage.Methods[2].OAuthUri, "test-client-id")
+}
+
+func TestSiteController_로그인_설정(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := putLoginSetting(`{
+		"methods": [
+			{"type": "google-workspace", "enabled": true, "label": "회사 계정"},
+			{"type": "password", "enabled": true},
+			{"type": "dooray", "enabled": false}
+		],
+		"defaultRedirect": "/members"
+	}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	loginPage := getLoginPage(t)
+
+	// then
+	assert.Equal(t, "/members", loginPage.DefaultRedirect)
+	assert.Equal(t, 2, len(loginPage.Methods))
+	assert.Equal(t, constants.LoginMethodGoogleWorkspace, loginPage.Methods[0].Type)
+	assert.Equal(t, "회사 계정", loginPage.Methods[0].Label)
+	assert.Equal(t, constants.LoginMethodPassword, loginPage.Methods[1].Type)
+	assert.Equal(t, "아이디/비밀번호", loginPage.Methods[1].Label)
+}
+
+func TestSiteController_잘못된_로그인_설정(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	testCases := []string{
+		// 사용하는 로그인 방법이 없음
+		`{"methods": [{"type": "password", "enabled": false}]}`,
+		// 중복된 로그인 방법
+		`{"methods": [{"type": "password", "enabled": true}, {"type": "password", "enabled": false}]}`,
+		// 등록되지 않은 외부 인증
+		`{"methods": [{"type": "saml", "enabled": true, "authenticator": "unknown"}]}`,
+		// 다른 사이트로 이동
+		`{"methods": [{"type": "password", "enabled": true}], "defaultRedirect": "//evil.example.com"}`,
+	}
+
+	for _, requestBody := range testCases {
+		// when
+		rec := putLoginSetting(requestBody)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, requestBody)
+	}
+}
+
+func requestSiteSetting(method string, target string, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSiteController_설정_버전(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestSiteSetting(http.MethodPut, "/api/site/settings/session-limit", `{"maxSessions": 2, "exceedAction": "block"}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = requestSiteSetting(http.MethodPut, "/api/site/settings/dooray-login", `{"used": false}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	rec = requestSiteSetting(http.MethodGet, "/api/site/settings/versions", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var pageResult struct {
+		Result     []dtos.SiteSettingVersion `json:"result"`
+		TotalCount int64                     `json:"totalCount"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &pageResult))
+	assert.Equal(t, int64(2), pageResult.TotalCount)
+	assert.Equal(t, uint(2), pageResult.Result[0].Version)
+	assert.Equal(t, constants.SettingKeyDoorayLogin, pageResult.Result[0].ChangedKey)
+
+	rec = requestSiteSetting(http.MethodGet, "/api/site/settings/versions/1", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var details dtos.SiteSettingVersionDetails
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &details))
+	assert.Equal(t, constants.SettingKeySessionLimit, details.ChangedKey)
+	assert.Equal(t, true, details.Settings[constants.SettingKeyDoorayLogin].(map[string]interface{})["used"])
+	assert.Contains(t, details.Settings, constants.SettingKeySessionLimit)
+}
+
+func TestSiteController_설정_되돌리기(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestSiteSetting(http.MethodPut, "/api/site/settings/session-limit", `{"maxSessions": 2, "exceedAction": "block"}`)
+	requestSiteSetting(http.MethodPut, "/api/site/settings/dooray-login", `{"used": false}`)
+	requestSiteSetting(http.MethodPut, "/api/site/settings/maintenance", `{"enabled": false, "message": "점검"}`)
+
+	// when
+	rec := requestSiteSetting(http.MethodPost, "/api/site/settings/versions/1/rollback", "")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var version dtos.SiteSettingVersion
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &version))
+	assert.Equal(t, uint(4), version.Version)
+	assert.Equal(t, uint(1), version.RolledBackFrom)
+
+	rec = requestSiteSetting(http.MethodGet, "/api/site/settings/dooray-login", "")
+	var doorayLoginSetting dtos.DoorayLoginSetting
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doorayLoginSetting))
+	assert.True(t, *doorayLoginSetting.Used)
+
+	// 1 버전 이후에 추가한 설정은 지운다.
+	var count int64
+	gormDB.Table("site_settings").Where("key = ? AND deleted_at IS NULL", constants.SettingKeyMaintenance).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestSiteController_설정_되돌리기_JWT_Secret_을_교체한_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer config.SetRotatedJwtSecret("")
+
+	// given
+	requestSiteSetting(http.MethodPut, "/api/site/settings/dooray-login", `{"used": false}`)
+	config.SetRotatedJwtSecret("rotated-jwt-secret")
+
+	token, _, err := security.JwtAuthentication{}.GenerateJwtAccessToken(security.UserClaim{
+		Id:          1,
+		Permissions: []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/site/settings/versions/1/rollback", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// 교체하기 전에 만든 스냅샷도 검증할 수 있다.
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSiteController_설정_되돌리기_서명이_맞지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestSiteSetting(http.MethodPut, "/api/site/settings/dooray-login", `{"used": false}`)
+	gormDB.Exec("UPDATE site_setting_versions SET document = ? WHERE version = 1", `{"dooray-login": {"used": true, "domain": "evil"}}`)
+
+	// when
+	rec := requestSiteSetting(http.MethodPost, "/api/site/settings/versions/1/rollback", "")
+
+	// then
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestSiteController_두레이_로그인_설정_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	ldapDialUrl := config.Config.Dooray.LdapDialUrl
+	defer func() { config.Config.Dooray.LdapDialUrl = ldapDialUrl }()
+	config.Config.Dooray.LdapDialUrl = "ldap://" + listener.Addr().String()
+	requestBody := `{"used": true, "domain": "bettercode", "authorizationToken": "token"}`
+
+	// when
+	rec := requestSiteSetting(http.MethodPost, "/api/site/settings/dooray-login/validate", requestBody)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var validation dtos.SettingValidation
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &validation))
+	assert.True(t, validation.Valid)
+
+	// LDAP 서버에 연결할 수 없으면 적용하지 않는다.
+	assert.NoError(t, listener.Close())
+	rec = requestSiteSetting(http.MethodPut, "/api/site/settings/dooray-login?validate=true", requestBody)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &validation))
+	assert.False(t, validation.Valid)
+	assert.Equal(t, "ldap-reachable", validation.Results[0].Name)
+}
+
+func signUpTestMember(t *testing.T, signId string) uint {
+	req := httptest.NewRequest(http.MethodPost, "/api/members", strings.NewReader(fmt.Sprintf(`{"signId": "%v", "name": "신입", "password": "1111"}`, signId)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var memberId uint
+	gormDB.Table("members").Where("sign_id = ?", signId).Pluck("id", &memberId)
+	return memberId
+}
+
+func TestSiteController_자동_승인_가입(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestSiteSetting(http.MethodPut, "/api/site/settings/auto-approval",
+		`{"enabled": true, "rules": [{"domain": "@BetterCode.kr", "defaultRoleIds": [3]}]}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requestSiteSetting(http.MethodGet, "/api/site/settings/auto-approval", "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var setting dtos.AutoApprovalSetting
+	json.Unmarshal(rec.Body.Bytes(), &setting)
+	assert.Equal(t, []dtos.AutoApprovalRule{{Domain: "bettercode.kr", DefaultRoleIds: []uint{3}}}, setting.Rules)
+
+	// when
+	trustedMemberId := signUpTestMember(t, "lee@bettercode.kr")
+	otherMemberId := signUpTestMember(t, "lee@example.com")
+
+	// then
+	var status string
+	gormDB.Table("members").Where("id = ?", trustedMemberId).Pluck("status", &status)
+	assert.Equal(t, "approved", status)
+
+	var roleIds []uint
+	gormDB.Table("member_roles").Where("member_entity_id = ?", trustedMemberId).Pluck("role_entity_id", &roleIds)
+	assert.Equal(t, []uint{3}, roleIds)
+
+	gormDB.Table("members").Where("id = ?", otherMemberId).Pluck("status", &status)
+	assert.Equal(t, "applied", status)
+
+	var auditLogCount int64
+	gormDB.Model(&auditDomain.AuditLogEntity{}).Where("action = ? AND target_id = ?", "member-auto-approved", trustedMemberId).Count(&auditLogCount)
+	assert.Equal(t, int64(1), auditLogCount)
+}
+
+func TestSiteController_자동_승인_SSO_승인_대기와_dry_run(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	services.RegisterAuthenticator("test-sso", fakeAuthenticator{})
+
+	// given
+	rec := requestSiteSetting(http.MethodPut, "/api/site/settings/auto-approval",
+		`{"enabled": true, "rules": [{"domain": "example.com"}]}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/custom/test-sso", strings.NewReader(`{"username": "kim", "otp": "123456"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec = httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+	}
+
+	var status string
+	gormDB.Table("members").Where("external_id = ?", "emp-1").Pluck("status", &status)
+	assert.Equal(t, "applied", status)
+
+	// when
+	rec = requestSiteSetting(http.MethodPost, "/api/site/settings/auto-approval/dry-run",
+		`{"rules": [{"domain": "bettercode.kr", "defaultRoleIds": [3]}]}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var result dtos.AutoApprovalDryRunResult
+	json.Unmarshal(rec.Body.Bytes(), &result)
+	assert.Equal(t, 2, result.Evaluated)
+	assert.Equal(t, 1, result.Matched)
+	assert.Equal(t, "kim@bettercode.kr", result.Results[0].Email)
+	assert.Equal(t, []uint{3}, result.Results[0].DefaultRoleIds)
+
+	// dry-run 은 승인하지 않는다.
+	gormDB.Table("members").Where("external_id = ?", "emp-1").Pluck("status", &status)
+	assert.Equal(t, "applied", status)
+}
+
+func TestSiteController_자동_승인_잘못된_규칙(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	testCases := []string{
+		`{"rules": [{"domain": "bettercode"}]}`,
+		`{"rules": [{"domain": "bettercode.kr"}, {"domain": "BETTERCODE.KR"}]}`,
+		`{"rules": [{"domain": "bettercode.kr", "defaultRoleIds": [1000]}]}`,
+	}
+
+	for _, requestBody := range testCases {
+		// when
+		rec := requestSiteSetting(http.MethodPut, "/api/site/settings/auto-approval", requestBody)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "INVALID_AUTO_APPROVAL_RULE")
+	}
 }
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SuperAdminProtectionController struct {
+	routerGroup                 *gin.RouterGroup
+	superAdminProtectionService *services.SuperAdminProtectionService
+}
+
+func NewSuperAdminProtectionController(
+	routerGroup *gin.RouterGroup,
+	superAdminProtectionService *services.SuperAdminProtectionService) *SuperAdminProtectionController {
+
+	return &SuperAdminProtectionController{
+		routerGroup:                 routerGroup,
+		superAdminProtectionService: superAdminProtectionService,
+	}
+}
+
+func (c SuperAdminProtectionController) MapRoutes() {
+	route := c.routerGroup.Group("/site")
+	route.GET("/settings/super-admin-protection",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		etag.HttpEtagCache(0),
+		c.getSuperAdminProtectionSetting)
+	route.PUT("/settings/super-admin-protection",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.setSuperAdminProtectionSetting)
+}
+
+func (c SuperAdminProtectionController) getSuperAdminProtectionSetting(ctx *gin.Context) {
+	setting, err := c.superAdminProtectionService.GetSuperAdminProtectionSetting(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SuperAdminProtectionController) setSuperAdminProtectionSetting(ctx *gin.Context) {
+	var setting dtos.SuperAdminProtectionSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.superAdminProtectionService.SetSuperAdminProtectionSetting(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// handleSuperAdminProtectionError 는 최고 관리자 보호로 거부한 요청에 응답하고 true 를 반환한다.
+// 단계 인증이 없으면 401(STEP_UP_REQUIRED), 최소 인원보다 적어지면 409(SUPER_ADMIN_MINIMUM)이다.
+func handleSuperAdminProtectionError(ctx *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, errors.ErrStepUpRequired):
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return true
+	case errors.Is(err, errors.ErrSuperAdminMinimum):
+		ctx.JSON(http.StatusConflict, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,214 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func assignTestRoles(memberId uint, requestBody string, requestedBy uint, stepUpToken string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/members/%v/assign-roles", memberId), strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          requestedBy,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, stepUpToken)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSuperAdminProtection_단계_인증(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := assignTestRoles(3, `{"roleIds": [1]}`, 1, "")
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "STEP_UP_REQUIRED", errorMessage.Code)
+
+	var roleCount int64
+	gormDB.Table("member_roles").Where("member_entity_id = ?", 3).Count(&roleCount)
+	assert.Equal(t, int64(0), roleCount)
+
+	var blockedCount int64
+	gormDB.Raw("SELECT count(*) FROM security_events WHERE type = 'super-admin-blocked' AND member_id = 1").Scan(&blockedCount)
+	assert.Equal(t, int64(1), blockedCount)
+
+	// 다른 멤버의 단계 인증 토큰은 사용할 수 없다.
+	rec = assignTestRoles(3, `{"roleIds": [1]}`, 1, generateTestStepUpToken(2))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 비밀번호를 다시 확인하고 받은 토큰으로 요청한다.
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/step-up", strings.NewReader(`{"password": "qwert"}`))
+	token, _ := generateTestJWT(map[string]interface{}{"Id": 1, "Permissions": []string{}}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/step-up", strings.NewReader(`{"password": "123456"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var stepUpToken dtos.StepUpToken
+	json.Unmarshal(rec.Body.Bytes(), &stepUpToken)
+	assert.NotEmpty(t, stepUpToken.Token)
+
+	// 단계 인증 토큰은 Access 토큰으로 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/3", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", stepUpToken.Token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = assignTestRoles(3, `{"roleIds": [1]}`, 1, stepUpToken.Token)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var changedCount int64
+	gormDB.Raw("SELECT count(*) FROM security_events WHERE type = 'super-admin-changed' AND severity = 'high' AND member_id = 1").Scan(&changedCount)
+	assert.Equal(t, int64(1), changedCount)
+}
+
+func TestSuperAdminProtection_마지막_최고_관리자(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := assignTestRoles(2, `{"roleIds": [2]}`, 1, generateTestStepUpToken(1))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	rec = assignTestRoles(1, `{"roleIds": [3]}`, 1, generateTestStepUpToken(1))
+
+	// then
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, "SUPER_ADMIN_MINIMUM", errorMessage.Code)
+
+	var roleIds []uint
+	gormDB.Table("member_roles").Where("member_entity_id = ?", 1).Pluck("role_entity_id", &roleIds)
+	assert.Equal(t, []uint{1}, roleIds)
+
+	// 마지막 최고 관리자는 해지할 수도 없다.
+	req := httptest.NewRequest(http.MethodPost, "/api/members/1/deprovisioning", strings.NewReader(`{"successorId": 3, "reason": "퇴사"}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(2))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var status string
+	gormDB.Raw("SELECT status FROM members WHERE id = 1").Scan(&status)
+	assert.Equal(t, constants.StatusMemberApproved, status)
+
+	var deprovisioningCount int64
+	gormDB.Raw("SELECT count(*) FROM member_deprovisionings").Scan(&deprovisioningCount)
+	assert.Equal(t, int64(0), deprovisioningCount)
+}
+
+func TestSuperAdminProtection_최소_인원_설정(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/super-admin-protection", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"minimumCount": 1}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodPut, "/api/site/settings/super-admin-protection", strings.NewReader(`{"minimumCount": 2}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// when
+	rec = assignTestRoles(2, `{"roleIds": [2]}`, 1, generateTestStepUpToken(1))
+
+	// then
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var superAdminCount int64
+	gormDB.Table("member_roles").Where("role_entity_id = ?", 1).Count(&superAdminCount)
+	assert.Equal(t, int64(2), superAdminCount)
+}
+
+func TestSuperAdminProtection_승인할_때_단계_인증(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpMemberFieldChangeApprovalWorkflow(t)
+
+	rec := assignTestRoles(1, `{"roleIds": [3]}`, 1, generateTestStepUpToken(1))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+
+	var fieldChange dtos.MemberFieldChangeInformation
+	json.Unmarshal(rec.Body.Bytes(), &fieldChange)
+
+	var approvalId uint
+	gormDB.Raw("SELECT id FROM approval_requests WHERE subject = 'member-field-change' AND target_id = ?", fieldChange.Id).Scan(&approvalId)
+
+	// when
+	rec = decideApproval(approvalId, "approved", map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 반영하지 못한 승인은 저장하지 않는다.
+	var status string
+	gormDB.Raw("SELECT status FROM approval_requests WHERE id = ?", approvalId).Scan(&status)
+	assert.Equal(t, constants.ApprovalStatusPending, status)
+
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/approvals/%v/approved", approvalId), strings.NewReader(`{"comment": "확인"}`))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Roles":       []string{"MEMBER MANAGER"},
+		"Permissions": []string{},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set(middlewares.StepUpTokenHeader, generateTestStepUpToken(2))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var roleIds []uint
+	gormDB.Table("member_roles").Where("member_entity_id = ?", 1).Pluck("role_entity_id", &roleIds)
+	assert.Equal(t, []uint{3}, roleIds)
+}
@@ -0,0 +1,283 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/backup"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/datamigration"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/scheduler"
+	"better-admin-backend-service/selfcheck"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SystemController struct {
+	routerGroup   *gin.RouterGroup
+	systemService *services.SystemService
+}
+
+func NewSystemController(
+	routerGroup *gin.RouterGroup,
+	systemService *services.SystemService) *SystemController {
+
+	return &SystemController{
+		routerGroup:   routerGroup,
+		systemService: systemService,
+	}
+}
+
+func (c SystemController) MapRoutes() {
+	route := c.routerGroup.Group("/system")
+
+	route.POST("/force-logout",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.forceLogout)
+	route.POST("/jwt-secret/rotate",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.rotateJwtSecret)
+	route.GET("/selfcheck",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}),
+		c.getSelfCheckReport)
+	route.GET("/logging",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getLogging)
+	route.PUT("/logging",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.changeLogging)
+	route.GET("/chaos",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getChaos)
+	route.PUT("/chaos",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.changeChaos)
+	route.DELETE("/chaos",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.stopChaos)
+	route.GET("/logging/request-captures",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getRequestCaptures)
+	route.GET("/log-shipping",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}),
+		c.getLogShippingStatus)
+	route.GET("/http-clients",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}),
+		c.getHttpClientMetrics)
+	route.GET("/concurrency-limits",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}),
+		c.getConcurrencyLimitMetrics)
+	route.GET("/db-pool",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}),
+		c.getDatabasePoolMetric)
+	route.GET("/backups",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getBackups)
+	route.GET("/data-migrations",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}),
+		c.getDataMigrations)
+	route.GET("/jobs/schedule",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}),
+		c.getJobSchedule)
+	// 로그인 전에도 오류를 구분할 수 있도록 인증 없이 조회한다.
+	route.GET("/error-codes", middlewares.Public(), c.getErrorCodes)
+	route.GET("/version", middlewares.Public(), c.getVersion)
+	route.GET("/routes",
+		middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+		c.getRoutes)
+	route.GET("/authorization-matrix",
+		middlewares.PermissionChecker([]string{"*"}),
+		c.getAuthorizationMatrix)
+}
+
+func (c SystemController) forceLogout(ctx *gin.Context) {
+	if err := c.systemService.ForceLogout(ctx.Request.Context()); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SystemController) rotateJwtSecret(ctx *gin.Context) {
+	jwtToken, err := c.systemService.RotateJwtSecret(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if err := setRefreshTokenCookie(ctx, jwtToken); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	result := map[string]string{}
+	result["accessToken"] = jwtToken.AccessToken
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c SystemController) getLogging(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, helpers.LoggingHelper().GetStatus())
+}
+
+func (c SystemController) changeLogging(ctx *gin.Context) {
+	var setting dtos.LoggingSetting
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.systemService.ChangeLogging(ctx.Request.Context(), setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.LoggingHelper().GetStatus())
+}
+
+func (c SystemController) getRequestCaptures(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, helpers.LoggingHelper().GetRequestCaptures())
+}
+
+func (c SystemController) getChaos(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, helpers.ChaosHelper().GetStatus(config.Config.Chaos.Enabled))
+}
+
+func (c SystemController) changeChaos(ctx *gin.Context) {
+	var setting dtos.ChaosSetting
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.systemService.ChangeChaos(ctx.Request.Context(), setting); err != nil {
+		if errors.Is(err, errors.ErrChaosDisabled) {
+			ctx.JSON(http.StatusConflict, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, helpers.ChaosHelper().GetStatus(config.Config.Chaos.Enabled))
+}
+
+// stopChaos 는 장애 주입을 바로 끈다. Chaos.Enabled 가 아니어도 끌 수 있다.
+func (c SystemController) stopChaos(ctx *gin.Context) {
+	if err := c.systemService.ChangeChaos(ctx.Request.Context(), dtos.ChaosSetting{}); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SystemController) getLogShippingStatus(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, adapters.LogShippingAdapter().GetStatus())
+}
+
+// getHttpClientMetrics 는 외부 연동별 HTTP 호출 현황과 회로 상태를 조회한다.
+func (c SystemController) getHttpClientMetrics(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, adapters.HttpClientAdapter().GetMetrics())
+}
+
+// getConcurrencyLimitMetrics 는 경로 그룹별 동시 처리 요청 수와 거절한 요청 수를 조회한다.
+func (c SystemController) getConcurrencyLimitMetrics(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, middlewares.GetConcurrencyLimitMetrics())
+}
+
+// getDatabasePoolMetric 은 DB 커넥션 풀 사용 현황을 조회한다.
+func (c SystemController) getDatabasePoolMetric(ctx *gin.Context) {
+	sqlDB, err := helpers.ContextHelper().GetDB(ctx.Request.Context()).DB()
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, db.PoolMonitor().GetMetric(sqlDB))
+}
+
+// getBackups 는 파일 저장소에 저장한 DB 백업을 최근 순서로 조회한다. 복원은 서버를 멈추고 restore-backup 명령으로 한다.
+func (c SystemController) getBackups(ctx *gin.Context) {
+	backups, err := backup.GetBackups()
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, backups)
+}
+
+// getDataMigrations 는 데이터 이전 작업의 진행 상태를 조회한다.
+func (c SystemController) getDataMigrations(ctx *gin.Context) {
+	statuses, err := datamigration.GetStatuses(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, statuses)
+}
+
+// getJobSchedule 은 주기 작업을 실행하는 리더 인스턴스와 작업별 실행 현황을 조회한다.
+func (c SystemController) getJobSchedule(ctx *gin.Context) {
+	schedule, err := scheduler.GetSchedule(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schedule)
+}
+
+func (SystemController) getErrorCodes(ctx *gin.Context) {
+	errorCodes := make([]dtos.ErrorCode, 0)
+	for _, info := range errors.GetErrorCodes() {
+		errorCodes = append(errorCodes, dtos.ErrorCode{Code: info.Code, Message: info.Message})
+	}
+
+	ctx.JSON(http.StatusOK, errorCodes)
+}
+
+// getVersion 은 로그인하지 않은 프론트엔드도 호환되는 버전인지 확인할 수 있도록 권한을 확인하지 않는다.
+func (c SystemController) getVersion(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.systemService.GetVersion())
+}
+
+// getSelfCheckReport 는 시작 점검을 다시 실행한다. error 수준의 점검이 실패하면 503 으로 응답한다.
+func (c SystemController) getSelfCheckReport(ctx *gin.Context) {
+	report := selfcheck.Run(ctx.Request.Context())
+	if !report.Healthy {
+		ctx.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// getRoutes 는 /api 아래의 모든 API 와 handler, 로그인 없이 호출할 수 있는지 여부, 필요한 권한을 반환한다.
+func (c SystemController) getRoutes(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, middlewares.DescribeRoutes(c.routerGroup.BasePath()))
+}
+
+// getAuthorizationMatrix 는 /api 아래의 모든 API 에 필요한 권한과 요청한 멤버의 호출 가능 여부를 반환한다.
+func (c SystemController) getAuthorizationMatrix(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	rules := middlewares.AuthorizationMatrix(c.routerGroup.BasePath())
+	for i, rule := range rules {
+		rules[i].Allowed = middlewares.IsAuthorized(rule, userClaim.Permissions)
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
@@ -0,0 +1,1212 @@
+package rest
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/backup"
+	clusterDomain "better-admin-backend-service/cluster/domain"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/datamigration"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/selfcheck"
+	"better-admin-backend-service/testdata/testdb"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSystemController_forceLogout(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/system/force-logout", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 강제 로그아웃 이전에 발급된 토큰은 더 이상 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/dooray-login", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+
+	ginApp.ServeHTTP(rec, req)
+
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 만료되지 않는 웹훅 토큰은 새 에포크로 다시 발급되어 계속 사용할 수 있다.
+	var webHookAccessToken string
+	gormDB.Raw("SELECT access_token FROM web_hooks WHERE id = ?", 1).Scan(&webHookAccessToken)
+	assert.NotEqual(t, "test-access-tokens1", webHookAccessToken)
+
+	webHookClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(webHookAccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{constants.PermissionNoteWebHooks}, webHookClaim.Permissions)
+}
+
+func TestSystemController_forceLogout_권한이_없는_경우(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/system/force-logout", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestSystemController_rotateJwtSecret(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	originalJwtSecret, _ := config.GetJwtSecret()
+	defer config.SetRotatedJwtSecret("")
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/system/jwt-secret/rotate", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_SYSTEM_SETTINGS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rotatedJwtSecret, _ := config.GetJwtSecret()
+	assert.NotEqual(t, originalJwtSecret, rotatedJwtSecret)
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Set-Cookie"), "refreshToken="))
+
+	var actual interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	reissuedAccessToken := actual.(map[string]interface{})["accessToken"].(string)
+
+	userClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(reissuedAccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), userClaim.Id)
+
+	_, err = security.JwtAuthentication{}.ConvertTokenUserClaim(token)
+	assert.NotNil(t, err)
+}
+
+func changeTestLogging(requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/system/logging", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSystemController_changeLogging(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer func() {
+		helpers.LoggingHelper().SetLevel("info")
+		helpers.LoggingHelper().DisableDebug(constants.LoggingModuleAuth)
+		helpers.LoggingHelper().DisableDebug(constants.LoggingModuleWebHooks)
+	}()
+
+	// when
+	rec := changeTestLogging(`{"level": "warn", "debugModules": ["auth", "webhooks"], "debugMinutes": 10}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "warning", actual["level"])
+	debugModules := actual["debugModules"].([]interface{})
+	assert.Equal(t, 2, len(debugModules))
+	assert.Equal(t, constants.LoggingModuleAuth, debugModules[0].(map[string]interface{})["module"])
+	assert.True(t, helpers.LoggingHelper().IsDebugEnabled(constants.LoggingModuleAuth))
+	assert.False(t, helpers.LoggingHelper().IsDebugEnabled(constants.LoggingModuleDb))
+
+	var auditCount int64
+	gormDB.Raw("SELECT count(*) FROM audit_logs WHERE action = ?", constants.AuditActionLoggingChanged).Scan(&auditCount)
+	assert.Equal(t, int64(1), auditCount)
+
+	// debugMinutes 가 0 이면 디버그 로그를 끈다.
+	rec = changeTestLogging(`{"debugModules": ["webhooks"], "debugMinutes": 0}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, helpers.LoggingHelper().IsDebugEnabled(constants.LoggingModuleWebHooks))
+}
+
+func TestSystemController_changeLogging_디버그_로그_자동_해제(t *testing.T) {
+	// given
+	helpers.LoggingHelper().EnableDebug(constants.LoggingModuleDb, time.Now().Add(-time.Second))
+
+	// then
+	assert.False(t, helpers.LoggingHelper().IsDebugEnabled(constants.LoggingModuleDb))
+	assert.Equal(t, 0, len(helpers.LoggingHelper().GetStatus().DebugModules))
+}
+
+func TestSystemController_changeLogging_잘못된_설정(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	for _, requestBody := range []string{
+		`{"level": "verbose"}`,
+		`{"debugModules": ["payments"], "debugMinutes": 10}`,
+		`{"debugModules": ["auth"], "debugMinutes": 2000}`,
+		`{"requestCapture": {"memberId": 2, "requests": 0}}`,
+	} {
+		// when
+		rec := changeTestLogging(requestBody)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, requestBody)
+	}
+}
+
+func TestSystemController_요청_상세_기록(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer helpers.LoggingHelper().StopRequestCapture()
+
+	// given
+	rec := changeTestLogging(`{"requestCapture": {"memberId": 2, "requests": 1}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	capturedCount := len(helpers.LoggingHelper().GetRequestCaptures())
+
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          2,
+		"Permissions": []string{constants.PermissionManageMembers},
+	}, time.Minute*15)
+
+	// when
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// then
+	req := httptest.NewRequest(http.MethodGet, "/api/system/logging/request-captures", nil)
+	adminToken, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", adminToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual []map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	// 지정한 횟수만 기록한다.
+	assert.Equal(t, capturedCount+1, len(actual))
+	capture := actual[len(actual)-1]
+	assert.Equal(t, float64(2), capture["memberId"])
+	assert.Equal(t, "/api/members/my", capture["path"])
+	assert.Equal(t, float64(http.StatusOK), capture["status"])
+	assert.Contains(t, capture["responseBody"], "유영모")
+	assert.Equal(t, "[REDACTED]", capture["headers"].(map[string]interface{})["Authorization"])
+}
+
+func getTestSelfCheckReport(t *testing.T, expectedStatus int) map[string]map[string]interface{} {
+	req := httptest.NewRequest(http.MethodGet, "/api/system/selfcheck", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, expectedStatus, rec.Code)
+
+	var report struct {
+		Healthy bool                     `json:"healthy"`
+		Results []map[string]interface{} `json:"results"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &report)
+	assert.Equal(t, expectedStatus == http.StatusOK, report.Healthy)
+
+	results := map[string]map[string]interface{}{}
+	for _, result := range report.Results {
+		results[result["name"].(string)] = result
+	}
+	return results
+}
+
+func TestSystemController_getSelfCheckReport(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	results := getTestSelfCheckReport(t, http.StatusOK)
+
+	// then
+	assert.Equal(t, true, results["database-connection"]["passed"])
+	assert.Equal(t, true, results["database-migration"]["passed"])
+	assert.Equal(t, true, results["sqlite-journal-mode"]["passed"])
+	assert.Equal(t, true, results["jwt-secret"]["passed"])
+	assert.Equal(t, true, results["smtp"]["passed"])
+	// 기본 JWT Secret 을 사용하면 경고한다.
+	assert.Equal(t, false, results["jwt-secret-strength"]["passed"])
+	assert.Equal(t, constants.SelfCheckSeverityWarning, results["jwt-secret-strength"]["severity"])
+	assert.Equal(t, false, results["cookie-security"]["passed"])
+}
+
+func TestSelfCheck_오류_수준_점검_실패(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	jwtSecret := config.Config.JwtSecret
+	config.Config.JwtSecret = ""
+	defer func() { config.Config.JwtSecret = jwtSecret }()
+
+	// when
+	report := selfcheck.Run(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.False(t, report.Healthy)
+	for _, result := range report.Results {
+		if result.Name == "jwt-secret" {
+			assert.False(t, result.Passed)
+			assert.Equal(t, "jwt secret is empty", result.Message)
+		}
+	}
+}
+
+func TestSystemController_getSelfCheckReport_시간_차이(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	timeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-5*time.Minute).UTC().Format(http.TimeFormat))
+	}))
+	defer timeServer.Close()
+	config.Config.SelfCheck.TimeServerUrl = timeServer.URL
+	defer func() { config.Config.SelfCheck.TimeServerUrl = "" }()
+
+	// when
+	results := getTestSelfCheckReport(t, http.StatusOK)
+
+	// then
+	assert.Equal(t, false, results["clock-skew"]["passed"])
+	assert.Contains(t, results["clock-skew"]["message"], "clock skew is 5m")
+}
+
+type fakeLogSink struct {
+	mutex    sync.Mutex
+	events   []dtos.Event
+	failures int
+	release  chan struct{}
+}
+
+func (*fakeLogSink) Name() string {
+	return "fake"
+}
+
+func (f *fakeLogSink) Ship(events []dtos.Event) error {
+	if f.release != nil {
+		<-f.release
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.failures > 0 {
+		f.failures--
+		return fmt.Errorf("fake sink error")
+	}
+	f.events = append(f.events, events...)
+	return nil
+}
+
+func startTestLogShipping(sinks ...adapters.LogSink) func() {
+	adapters.LogShippingAdapter().SetSinks(sinks)
+	adapters.LogShippingAdapter().Start()
+
+	return func() {
+		adapters.LogShippingAdapter().Stop()
+		adapters.LogShippingAdapter().SetSinks(nil)
+	}
+}
+
+func TestSystemController_getLogShippingStatus_인증_감사_이벤트_전송(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	defer helpers.LoggingHelper().SetLevel("info")
+
+	// given
+	sink := &fakeLogSink{}
+	reset := startTestLogShipping(sink)
+	defer reset()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(`{"id": "siteadm", "password": "wrong"}`))
+	req.Header.Set("Content-Type", "application/json")
+	ginApp.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, http.StatusOK, changeTestLogging(`{"level": "info"}`).Code)
+
+	// when
+	adapters.LogShippingAdapter().Stop()
+
+	req = httptest.NewRequest(http.MethodGet, "/api/system/log-shipping", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, 2, len(sink.events))
+	assert.Equal(t, constants.EventTypeLogin, sink.events[0].Type)
+	assert.False(t, sink.events[0].Succeeded)
+	assert.Equal(t, constants.LoginFailureReasonInvalidCredential, sink.events[0].Reason)
+	assert.Equal(t, uint(1), sink.events[0].ActorId)
+	assert.Equal(t, constants.EventTypeAudit, sink.events[1].Type)
+	assert.Equal(t, constants.AuditActionLoggingChanged, sink.events[1].Action)
+	assert.Equal(t, constants.AuditActorTypeMember, sink.events[1].ActorType)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.LogShippingStatus
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, len(actual.Sinks))
+	assert.Equal(t, "fake", actual.Sinks[0].Name)
+	assert.Equal(t, int64(2), actual.Sinks[0].Shipped)
+	assert.Equal(t, int64(0), actual.Sinks[0].Dropped)
+	assert.NotNil(t, actual.Sinks[0].LastShippedAt)
+}
+
+func TestSystemController_getLogShippingStatus_권한_확인(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/system/log-shipping", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{"TC"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestSystemController_getHttpClientMetrics_재시도와_회로_차단(t *testing.T) {
+	// given
+	config.Config.HttpClient.Integrations["test-http-client"] = config.HttpClientPolicy{
+		TimeoutSeconds: 1, MaxRetries: 1, RetryDelayMilliseconds: 1, RetryBudgetPercent: 100, FailureThreshold: 2, OpenSeconds: 60,
+	}
+	defer delete(config.Config.HttpClient.Integrations, "test-http-client")
+
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := adapters.HttpClientAdapter().Client("test-http-client")
+	var lastErr error
+	for i := 0; i < 3; i++ {
+		request, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		response, err := client.Do(request)
+		if err == nil {
+			response.Body.Close()
+		}
+		lastErr = err
+	}
+
+	// when
+	req := httptest.NewRequest(http.MethodGet, "/api/system/http-clients", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, 4, hits)
+	assert.ErrorIs(t, lastErr, errors.ErrCircuitOpen)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var metrics []dtos.HttpClientMetric
+	json.Unmarshal(rec.Body.Bytes(), &metrics)
+	var actual dtos.HttpClientMetric
+	for _, metric := range metrics {
+		if metric.Name == "test-http-client" {
+			actual = metric
+		}
+	}
+	assert.Equal(t, int64(2), actual.Requests)
+	assert.Equal(t, int64(2), actual.Retries)
+	assert.Equal(t, int64(2), actual.Failures)
+	assert.Equal(t, int64(1), actual.Rejected)
+	assert.Equal(t, 1, len(actual.CircuitBreakers))
+	assert.Equal(t, constants.CircuitBreakerStateOpen, actual.CircuitBreakers[0].State)
+	assert.NotNil(t, actual.CircuitBreakers[0].OpenUntil)
+}
+
+func TestSystemController_getConcurrencyLimitMetrics_동시_처리_제한(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpConcurrencyLimitSetting(t, `{"routeGroups": [{"name": "test-slow", "pathPrefixes": ["/api/test-module/slow"], "maxConcurrent": 1, "retryAfterSeconds": 3}]}`)
+	defer setUpConcurrencyLimitSetting(t, `{"routeGroups": []}`)
+
+	// given
+	// 요청을 취소할 때까지 처리 중인 요청
+	slowCtx, cancelSlow := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ginApp.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/test-module/slow", nil).WithContext(slowCtx))
+	}()
+	assert.Eventually(t, func() bool {
+		for _, metric := range middlewares.GetConcurrencyLimitMetrics() {
+			if metric.Name == "test-slow" {
+				return metric.InFlight == 1
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	// when
+	rejectedRec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rejectedRec, httptest.NewRequest(http.MethodGet, "/api/test-module/slow", nil))
+
+	// 다른 경로는 제한하지 않는다.
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodGet, "/api/system/concurrency-limits", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	cancelSlow()
+	<-done
+
+	// then
+	assert.Equal(t, http.StatusTooManyRequests, rejectedRec.Code)
+	assert.Equal(t, "3", rejectedRec.Header().Get("Retry-After"))
+	assert.Equal(t, errors.ErrTooManyRequests.Code, rejectedRec.Header().Get(middlewares.ErrorCodeHeader))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var metrics []dtos.ConcurrencyLimitMetric
+	json.Unmarshal(rec.Body.Bytes(), &metrics)
+	var actual dtos.ConcurrencyLimitMetric
+	for _, metric := range metrics {
+		if metric.Name == "test-slow" {
+			actual = metric
+		}
+	}
+	assert.Equal(t, 1, actual.MaxConcurrent)
+	assert.Equal(t, int64(1), actual.InFlight)
+	assert.Equal(t, int64(1), actual.Admitted)
+	assert.Equal(t, int64(1), actual.Rejected)
+}
+
+func TestHttpClient_POST_요청은_재시도하지_않음(t *testing.T) {
+	// given
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("{}"))
+
+	// when
+	response, err := adapters.HttpClientAdapter().Client(constants.HttpClientWebHook).Do(request)
+
+	// then
+	assert.Nil(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, 1, hits)
+}
+
+func TestLogShipping_버퍼가_가득_차면_버림(t *testing.T) {
+	// given
+	bufferSize, batchSize := config.Config.LogShipping.BufferSize, config.Config.LogShipping.BatchSize
+	config.Config.LogShipping.BufferSize, config.Config.LogShipping.BatchSize = 1, 1
+	defer func() {
+		config.Config.LogShipping.BufferSize, config.Config.LogShipping.BatchSize = bufferSize, batchSize
+	}()
+
+	sink := &fakeLogSink{release: make(chan struct{})}
+	reset := startTestLogShipping(sink)
+	defer reset()
+
+	// when
+	for i := 0; i < 5; i++ {
+		adapters.EventBusAdapter().Publish(dtos.Event{Id: fmt.Sprintf("test-%d", i), Type: constants.EventTypeAudit, Succeeded: true})
+	}
+	status := adapters.LogShippingAdapter().GetStatus().Sinks[0]
+	close(sink.release)
+	adapters.LogShippingAdapter().Stop()
+
+	// then
+	assert.GreaterOrEqual(t, status.Dropped, int64(3))
+	final := adapters.LogShippingAdapter().GetStatus().Sinks[0]
+	assert.Equal(t, int64(5), final.Shipped+final.Dropped)
+	assert.Equal(t, int(final.Shipped), len(sink.events))
+}
+
+func TestLogShipping_실패하면_다시_전송(t *testing.T) {
+	// given
+	batchSize := config.Config.LogShipping.BatchSize
+	config.Config.LogShipping.BatchSize = 1
+	defer func() { config.Config.LogShipping.BatchSize = batchSize }()
+
+	sink := &fakeLogSink{failures: 1}
+	reset := startTestLogShipping(sink)
+	defer reset()
+
+	// when
+	adapters.EventBusAdapter().Publish(dtos.Event{Id: "test-1", Type: constants.EventTypeAudit, Succeeded: true})
+
+	// then
+	assert.Eventually(t, func() bool {
+		return adapters.LogShippingAdapter().GetStatus().Sinks[0].Shipped == 1
+	}, 5*time.Second, 50*time.Millisecond)
+	status := adapters.LogShippingAdapter().GetStatus().Sinks[0]
+	assert.Equal(t, int64(1), status.Retries)
+	assert.Equal(t, int64(0), status.Failed)
+	assert.Equal(t, "fake sink error", status.LastError)
+}
+
+func TestLogShipping_Syslog_CEF(t *testing.T) {
+	// given
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	sink := adapters.SyslogLogSink{Network: "udp", Address: listener.LocalAddr().String(), AppName: "better-admin"}
+	event := dtos.Event{
+		Id:         "login-7",
+		Type:       constants.EventTypeLogin,
+		Action:     constants.ActivityActionLogin,
+		OccurredAt: time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC),
+		ActorType:  constants.AuditActorTypeMember,
+		ActorId:    3,
+		Reason:     constants.LoginFailureReasonInvalidCredential,
+		Detail:     "site=a|b",
+		IpAddress:  "10.0.0.1",
+	}
+
+	// when
+	err = sink.Ship([]dtos.Event{event})
+
+	// then
+	assert.NoError(t, err)
+	buffer := make([]byte, 2048)
+	listener.SetDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := listener.ReadFrom(buffer)
+	assert.NoError(t, err)
+
+	message := string(buffer[:n])
+	assert.True(t, strings.HasPrefix(message, "<84>1 2022-03-04T05:06:07.000Z "))
+	assert.Contains(t, message, " better-admin - - - CEF:0|Bettercode|better-ADMIN|1.0|login:login|login failure|5|")
+	assert.Contains(t, message, "externalId=login-7 rt=1646370367000 cat=login act=login outcome=failure reason=invalid-credential")
+	assert.Contains(t, message, "cs1Label=actorType cs1=member suid=3 src=10.0.0.1 msg=site\\=a|b")
+}
+
+// startFakeKafkaBroker 는 Metadata(파티션 0 의 리더가 자신)와 Produce 요청에 응답하고 받은 Produce 요청을 produced 로 보낸다.
+func startFakeKafkaBroker(t *testing.T, topic string, produced chan []byte) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	host, portValue, _ := net.SplitHostPort(listener.Addr().String())
+	var port int32
+	fmt.Sscan(portValue, &port)
+
+	writeString := func(buffer *bytes.Buffer, value string) {
+		binary.Write(buffer, binary.BigEndian, int16(len(value)))
+		buffer.WriteString(value)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func(conn net.Conn) {
+				defer conn.Close()
+				for {
+					var size int32
+					if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+						return
+					}
+					request := make([]byte, size)
+					if _, err := io.ReadFull(conn, request); err != nil {
+						return
+					}
+					apiKey := binary.BigEndian.Uint16(request[0:2])
+					correlationId := binary.BigEndian.Uint32(request[4:8])
+
+					response := &bytes.Buffer{}
+					binary.Write(response, binary.BigEndian, correlationId)
+					if apiKey == 3 {
+						binary.Write(response, binary.BigEndian, int32(1))
+						binary.Write(response, binary.BigEndian, int32(0))
+						writeString(response, host)
+						binary.Write(response, binary.BigEndian, port)
+						binary.Write(response, binary.BigEndian, int16(-1))
+						binary.Write(response, binary.BigEndian, int32(0))
+						binary.Write(response, binary.BigEndian, int32(1))
+						binary.Write(response, binary.BigEndian, int16(0))
+						writeString(response, topic)
+						response.WriteByte(0)
+						binary.Write(response, binary.BigEndian, []int32{1, 0, 0, 0})
+						binary.Write(response, binary.BigEndian, int16(0))
+						binary.Write(response, binary.BigEndian, []int32{0, 0, 1, 0, 1, 0})
+					} else {
+						produced <- request
+						binary.Write(response, binary.BigEndian, int32(1))
+						writeString(response, topic)
+						binary.Write(response, binary.BigEndian, []int32{1, 0})
+						binary.Write(response, binary.BigEndian, int16(0))
+						binary.Write(response, binary.BigEndian, []int64{0, -1})
+						binary.Write(response, binary.BigEndian, int32(0))
+					}
+
+					binary.Write(conn, binary.BigEndian, int32(response.Len()))
+					conn.Write(response.Bytes())
+				}
+			}(conn)
+		}
+	}()
+
+	return listener
+}
+
+func TestLogShipping_Kafka(t *testing.T) {
+	// given
+	produced := make(chan []byte, 1)
+	listener := startFakeKafkaBroker(t, "security-events", produced)
+	defer listener.Close()
+
+	sink := adapters.KafkaLogSink{
+		Producer: &adapters.KafkaProducer{Brokers: []string{listener.Addr().String()}, ClientId: "test"},
+		Topic:    "security-events",
+	}
+
+	// when
+	err := sink.Ship([]dtos.Event{{Id: "audit-1", Type: constants.EventTypeAudit, Action: constants.AuditActionLoggingChanged, Succeeded: true}})
+
+	// then
+	assert.NoError(t, err)
+	request := <-produced
+	assert.Equal(t, uint16(0), binary.BigEndian.Uint16(request[0:2]))
+	assert.Equal(t, uint16(3), binary.BigEndian.Uint16(request[2:4]))
+	assert.True(t, bytes.Contains(request, []byte("security-events")))
+	assert.True(t, bytes.Contains(request, []byte(`"id":"audit-1","type":"audit","action":"logging-changed"`)))
+	assert.True(t, bytes.Contains(request, []byte("eventType")))
+}
+
+func TestSystemController_getAuthorizationMatrix(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/system/authorization-matrix", nil)
+	token, err := generateTestJWT(map[string]interface{}{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"MANAGE_MEMBERS",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var rules []dtos.AuthorizationRule
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rules))
+
+	ruleMap := make(map[string]dtos.AuthorizationRule)
+	for _, rule := range rules {
+		ruleMap[rule.Method+" "+rule.Path] = rule
+		assert.NotEqual(t, constants.AuthorizationUnknown, rule.Authentication, rule.Path)
+	}
+
+	forceLogout := ruleMap["POST /api/system/force-logout"]
+	assert.Equal(t, constants.AuthorizationPermission, forceLogout.Authentication)
+	assert.Equal(t, []string{constants.PermissionManageSystemSettings}, forceLogout.Permissions)
+	assert.False(t, forceLogout.Allowed)
+
+	selfCheck := ruleMap["GET /api/system/selfcheck"]
+	assert.ElementsMatch(t, []string{constants.PermissionManageSystemSettings, constants.PermissionViewMonitoring}, selfCheck.Permissions)
+
+	matrix := ruleMap["GET /api/system/authorization-matrix"]
+	assert.Equal(t, constants.AuthorizationAuthenticated, matrix.Authentication)
+	assert.True(t, matrix.Allowed)
+
+	maintenance := ruleMap["GET /api/site/maintenance"]
+	assert.Equal(t, constants.AuthorizationNone, maintenance.Authentication)
+	assert.True(t, maintenance.Allowed)
+
+	// 내부 요청은 handler 를 실행하지 않으므로 force-logout 이후에도 토큰을 사용할 수 있다(401 이 아님).
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/dooray-login", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestSystemController_getRoutes(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/system/routes", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var routes []dtos.RouteDescription
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &routes))
+
+	routeMap := make(map[string]dtos.RouteDescription)
+	for _, route := range routes {
+		routeMap[route.Method+" "+route.Path] = route
+		// 시작할 때 점검하므로 분류되지 않은 API 는 없다.
+		assert.NotEqual(t, constants.AuthorizationUnclassified, route.Authentication, route.Path)
+	}
+
+	signIn := routeMap["POST /api/auth"]
+	assert.True(t, signIn.Public)
+	assert.Contains(t, signIn.Handler, "authWithSignIdPassword")
+
+	member := routeMap["GET /api/members/:id"]
+	assert.False(t, member.Public)
+	assert.Equal(t, constants.AuthorizationPermission, member.Authentication)
+	assert.Equal(t, []string{constants.PermissionManageMembers}, member.Permissions)
+}
+
+func TestSystemController_getErrorCodes(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/system/error-codes", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []dtos.ErrorCode
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+
+	codes := map[string]bool{}
+	for _, errorCode := range actual {
+		assert.NotEmpty(t, errorCode.Message)
+		codes[errorCode.Code] = true
+	}
+	assert.True(t, codes["MEMBER_NOT_FOUND"])
+	assert.True(t, codes["AUTH_FAILED"])
+	assert.True(t, codes["MEMBER_UNAPPROVED"])
+	assert.True(t, codes["INTERNAL_ERROR"])
+}
+
+func TestSystemController_changeChaos(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	systemSettings := []string{constants.PermissionManageSystemSettings}
+	manageMembers := []string{constants.PermissionManageMembers}
+	defer requestTestResourceOwnership(http.MethodDelete, "/api/system/chaos", nil, 1, systemSettings)
+
+	// 운영 환경(Chaos.Enabled 가 아닌 경우)에서는 장애를 주입할 수 없다.
+	rec := requestTestResourceOwnership(http.MethodPut, "/api/system/chaos",
+		strings.NewReader(`{"rules": [{"pathPrefix": "/api/members", "percent": 100, "errorStatus": 503}]}`), 1, systemSettings)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "CHAOS_DISABLED")
+
+	config.Config.Chaos.Enabled = true
+	defer func() { config.Config.Chaos.Enabled = false }()
+
+	// given
+	rec = requestTestResourceOwnership(http.MethodPut, "/api/system/chaos",
+		strings.NewReader(`{"rules": [{"pathPrefix": "/api/members", "methods": ["GET"], "percent": 100, "latencyMs": 50, "errorStatus": 503}], "durationMinutes": 5}`),
+		1, systemSettings)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var status dtos.ChaosStatus
+	json.Unmarshal(rec.Body.Bytes(), &status)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, 1, len(status.Rules))
+	assert.True(t, status.Until.After(time.Now().Add(4*time.Minute)))
+
+	// when
+	startedAt := time.Now()
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/members", nil, 1, manageMembers)
+
+	// then
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "CHAOS_INJECTED", rec.Header().Get(middlewares.ErrorCodeHeader))
+	assert.True(t, time.Since(startedAt) >= 50*time.Millisecond)
+
+	// 일치하지 않는 요청과 장애 주입 설정에는 주입하지 않는다.
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/site/maintenance", nil, 1, manageMembers)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/system/chaos", nil, 1, systemSettings)
+	json.Unmarshal(rec.Body.Bytes(), &status)
+	assert.Equal(t, int64(1), status.Injected)
+
+	// DB 조회를 실패시킨다.
+	rec = requestTestResourceOwnership(http.MethodPut, "/api/system/chaos",
+		strings.NewReader(`{"rules": [{"pathPrefix": "/api/members", "percent": 100, "faults": ["database"]}]}`), 1, systemSettings)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/members", nil, 1, manageMembers)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = requestTestResourceOwnership(http.MethodDelete, "/api/system/chaos", nil, 1, systemSettings)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/members", nil, 1, manageMembers)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var auditCount int64
+	gormDB.Table("audit_logs").Where("action = ?", constants.AuditActionChaosChanged).Count(&auditCount)
+	assert.Equal(t, int64(3), auditCount)
+}
+
+func TestSystemController_getVersion(t *testing.T) {
+	// given
+	config.Config.Compatibility.MinFrontendVersion = "1.4.0"
+	defer func() { config.Config.Compatibility.MinFrontendVersion = "" }()
+	req := httptest.NewRequest(http.MethodGet, "/api/system/version", nil)
+	// 지원하지 않는 프론트엔드도 버전을 조회할 수 있다.
+	req.Header.Set(middlewares.FrontendVersionHeader, "1.3.9")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.VersionInfo
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+	assert.Equal(t, config.Version, actual.Version)
+	assert.Equal(t, "1.4.0", actual.MinFrontendVersion)
+}
+
+func TestFrontendVersion_최소_버전_확인(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	config.Config.Compatibility.MinFrontendVersion = "1.4.0"
+	defer func() { config.Config.Compatibility.MinFrontendVersion = "" }()
+
+	requestWithFrontendVersion := func(frontendVersion string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/system/error-codes", nil)
+		if len(frontendVersion) > 0 {
+			req.Header.Set(middlewares.FrontendVersionHeader, frontendVersion)
+		}
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// when
+	rec := requestWithFrontendVersion("1.3.12")
+
+	// then
+	assert.Equal(t, http.StatusUpgradeRequired, rec.Code)
+	assert.Equal(t, "UPGRADE_REQUIRED", rec.Header().Get(middlewares.ErrorCodeHeader))
+	var upgradeRequired dtos.UpgradeRequired
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &upgradeRequired))
+	assert.Equal(t, dtos.UpgradeRequired{
+		Code:               "UPGRADE_REQUIRED",
+		Message:            "frontend version 1.3.12 is no longer supported. upgrade to 1.4.0 or later",
+		FrontendVersion:    "1.3.12",
+		MinFrontendVersion: "1.4.0",
+		BackendVersion:     config.Version,
+	}, upgradeRequired)
+
+	assert.Equal(t, http.StatusUpgradeRequired, requestWithFrontendVersion("unknown").Code)
+	assert.Equal(t, http.StatusOK, requestWithFrontendVersion("v1.4.0-beta.1").Code)
+	assert.Equal(t, http.StatusOK, requestWithFrontendVersion("1.10").Code)
+	// 버전을 보내지 않는 클라이언트는 확인하지 않는다.
+	assert.Equal(t, http.StatusOK, requestWithFrontendVersion("").Code)
+}
+
+func TestErrorCode_상태_코드별_기본_코드(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/system/http-clients", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "UNAUTHORIZED", rec.Header().Get(middlewares.ErrorCodeHeader))
+}
+
+func TestSystemController_getDatabasePoolMetric(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/system/db-pool", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.DatabasePoolMetric
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+	assert.GreaterOrEqual(t, actual.OpenConnections, 1)
+	assert.Equal(t, actual.InUse+actual.Idle, actual.OpenConnections)
+}
+
+func TestSystemController_getDataMigrations_실패_후_이어서_실행(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	var visited []uint
+	var batches int
+	datamigration.Register(datamigration.Job{
+		Name:        "test-member-backfill",
+		Description: "테스트 멤버 이전",
+		BatchSize:   2,
+		Count: func(ctx context.Context) (int64, error) {
+			var count int64
+			err := helpers.ContextHelper().GetDB(ctx).Model(&memberDomain.MemberEntity{}).Count(&count).Error
+			return count, err
+		},
+		RunBatch: func(ctx context.Context, checkpoint string, batchSize int) (datamigration.Batch, error) {
+			batches++
+			if batches == 2 {
+				return datamigration.Batch{}, pkgerrors.New("test failure")
+			}
+
+			lastId, _ := strconv.Atoi(checkpoint)
+			var ids []uint
+			if err := helpers.ContextHelper().GetDB(ctx).Model(&memberDomain.MemberEntity{}).
+				Where("id > ?", lastId).Order("id").Limit(batchSize).Pluck("id", &ids).Error; err != nil {
+				return datamigration.Batch{}, err
+			}
+			if len(ids) == 0 {
+				return datamigration.Batch{Checkpoint: checkpoint, Done: true}, nil
+			}
+
+			visited = append(visited, ids...)
+			return datamigration.Batch{Checkpoint: strconv.Itoa(int(ids[len(ids)-1])), Processed: len(ids)}, nil
+		},
+	})
+
+	getStatus := func() dtos.DataMigrationStatus {
+		req := httptest.NewRequest(http.MethodGet, "/api/system/data-migrations", nil)
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          1,
+			"Permissions": []string{constants.PermissionViewMonitoring},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var statuses []dtos.DataMigrationStatus
+		json.Unmarshal(rec.Body.Bytes(), &statuses)
+		for _, status := range statuses {
+			if status.Name == "test-member-backfill" {
+				return status
+			}
+		}
+		return dtos.DataMigrationStatus{}
+	}
+
+	// when
+	datamigration.RunPending(gormDB, nil)
+	failed := getStatus()
+	datamigration.RunPending(gormDB, nil)
+	completed := getStatus()
+
+	// then
+	var memberCount int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Count(&memberCount)
+
+	assert.Equal(t, constants.DataMigrationStatusFailed, failed.Status)
+	assert.Equal(t, int64(2), failed.Processed)
+	assert.Equal(t, memberCount, failed.Total)
+	assert.Contains(t, failed.LastError, "test failure")
+
+	assert.Equal(t, constants.DataMigrationStatusCompleted, completed.Status)
+	assert.Equal(t, memberCount, completed.Processed)
+	assert.Equal(t, 100, completed.ProgressPercent)
+	assert.Empty(t, completed.LastError)
+	assert.Len(t, visited, int(memberCount))
+
+	// 완료한 작업은 다시 실행하지 않는다.
+	executed := batches
+	datamigration.RunPending(gormDB, nil)
+	assert.Equal(t, executed, batches)
+}
+
+func TestSystemController_getBackups(t *testing.T) {
+	// given
+	adapters.FileStorageAdapter().SetStorage(adapters.LocalFileStorage{Directory: t.TempDir()})
+	defer adapters.FileStorageAdapter().SetStorage(nil)
+
+	created, err := backup.CreateBackup(gormDB, constants.BackupReasonScheduled)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/system/backups", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionManageSystemSettings},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []dtos.Backup
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+	assert.Len(t, actual, 1)
+	assert.Equal(t, created.Key, actual[0].Key)
+	assert.Equal(t, constants.BackupReasonScheduled, actual[0].Reason)
+	assert.Equal(t, created.Checksum, actual[0].Checksum)
+}
+
+func TestSystemController_getJobSchedule_다른_인스턴스가_리더(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	schedulerConfig := config.Config.Scheduler
+	defer func() { config.Config.Scheduler = schedulerConfig }()
+	config.Config.Scheduler.LeaderElection = constants.LeaderElectionDatabase
+
+	// given
+	expiresAt := time.Now().UTC().Add(time.Minute)
+	gormDB.Create(&clusterDomain.ClusterLockEntity{Name: "leader:scheduler", Owner: "other-instance", ExpiresAt: expiresAt})
+	gormDB.Create(&clusterDomain.ClusterLockEntity{Name: "lock:scheduler:report-schedule", Owner: "other-instance", ExpiresAt: expiresAt})
+	// 만료된 잠금은 실행 중이 아니다.
+	gormDB.Create(&clusterDomain.ClusterLockEntity{Name: "lock:scheduler:usage-statistics", Owner: "stopped-instance", ExpiresAt: time.Now().UTC().Add(-time.Minute)})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/system/jobs/schedule", nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var schedule dtos.JobSchedule
+	json.Unmarshal(rec.Body.Bytes(), &schedule)
+	assert.Equal(t, "other-instance", schedule.Leader)
+	assert.False(t, schedule.IsLeader)
+	assert.Equal(t, constants.LeaderElectionDatabase, schedule.LeaderElection)
+	assert.NotEmpty(t, schedule.InstanceId)
+
+	jobs := map[string]dtos.ScheduledJob{}
+	for _, job := range schedule.Jobs {
+		jobs[job.Name] = job
+	}
+	assert.Equal(t, "other-instance", jobs["report-schedule"].RunningOn)
+	assert.Equal(t, "", jobs["usage-statistics"].RunningOn)
+	assert.Equal(t, int64(3600), jobs["usage-statistics"].IntervalSeconds)
+}
+
+func TestSystemController_권한_확인_오류_코드(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	req := httptest.NewRequest(http.MethodGet, "/api/system/concurrency-limits", nil)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	var errorMessage dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, errors.ErrUnauthorized.Code, errorMessage.Code)
+
+	rec = requestTestResourceOwnership(http.MethodGet, "/api/system/concurrency-limits", nil, 1, []string{})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &errorMessage)
+	assert.Equal(t, errors.ErrForbidden.Code, errorMessage.Code)
+	assert.Equal(t, errors.ErrForbidden.Code, rec.Header().Get(middlewares.ErrorCodeHeader))
+}
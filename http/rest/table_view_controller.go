@@ -0,0 +1,158 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type TableViewController struct {
+	routerGroup      *gin.RouterGroup
+	tableViewService *services.TableViewService
+}
+
+func NewTableViewController(
+	routerGroup *gin.RouterGroup,
+	tableViewService *services.TableViewService) *TableViewController {
+
+	return &TableViewController{
+		routerGroup:      routerGroup,
+		tableViewService: tableViewService,
+	}
+}
+
+func (c TableViewController) MapRoutes() {
+	route := c.routerGroup.Group("/table-views")
+	route.GET("", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getTableViews)
+	route.POST("", middlewares.PermissionChecker([]string{"*"}),
+		c.createTableView)
+	route.GET("/:id", middlewares.PermissionChecker([]string{"*"}),
+		etag.HttpEtagCache(0),
+		c.getTableView)
+	route.PUT("/:id", middlewares.PermissionChecker([]string{"*"}),
+		c.updateTableView)
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{"*"}),
+		c.deleteTableView)
+}
+
+// getTableViews 는 table(members, audit-logs, roles) 목록 화면의 보기를 조회한다. table 이 없으면 모든 목록 화면의 보기이다.
+func (c TableViewController) getTableViews(ctx *gin.Context) {
+	entities, err := c.tableViewService.GetTableViews(ctx.Request.Context(), ctx.Query("table"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	informations := make([]dtos.TableViewInformation, 0)
+	for _, entity := range entities {
+		informations = append(informations, entity.ToInformation())
+	}
+
+	ctx.JSON(http.StatusOK, informations)
+}
+
+func (c TableViewController) createTableView(ctx *gin.Context) {
+	var information dtos.TableViewInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.tableViewService.CreateTableView(ctx.Request.Context(), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, entity.ToInformation())
+}
+
+func (c TableViewController) getTableView(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.tableViewService.GetTableView(ctx.Request.Context(), uint(id))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c TableViewController) updateTableView(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var information dtos.TableViewInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.tableViewService.UpdateTableView(ctx.Request.Context(), uint(id), information)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, entity.ToInformation())
+}
+
+func (c TableViewController) deleteTableView(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.tableViewService.DeleteTableView(ctx.Request.Context(), uint(id)); err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (TableViewController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, errors.ErrNotFound) {
+		ctx.Status(http.StatusNotFound)
+		return
+	}
+
+	if errors.Is(err, errors.ErrForbidden) {
+		ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrAuthentication) {
+		ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if errors.Is(err, errors.ErrDuplicated) {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+		return
+	}
+
+	if e, ok := err.(*errors.ErrInvalidTableView); ok {
+		ctx.JSON(http.StatusBadRequest, dtos.ErrorMessage{Code: errors.Code(e), Message: e.Error()})
+		return
+	}
+
+	helpers.ErrorHelper().InternalServerError(ctx, err)
+}
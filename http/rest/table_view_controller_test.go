@@ -0,0 +1,207 @@
+package rest
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func requestTableView(memberId uint, permissions []string, method, url string, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          memberId,
+		"Permissions": permissions,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func getTableViews(t *testing.T, memberId uint, permissions []string, table string) []dtos.TableViewInformation {
+	rec := requestTableView(memberId, permissions, http.MethodGet, "/api/table-views?table="+table, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var views []dtos.TableViewInformation
+	json.Unmarshal(rec.Body.Bytes(), &views)
+	return views
+}
+
+func TestTableViewController_개인_보기(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"table": "members",
+		"name": "승인 대기",
+		"columns": ["name", "signId", "createdAt"],
+		"filters": {"status": "applied"},
+		"sort": {"field": "createdAt", "order": "desc"},
+		"pageSize": 50
+	}`
+
+	// when
+	rec := requestTableView(3, []string{}, http.MethodPost, "/api/table-views", requestBody)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var created dtos.TableViewInformation
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	assert.Equal(t, uint(3), created.MemberId)
+	assert.Equal(t, uint(0), created.RoleId)
+
+	views := getTableViews(t, 3, []string{}, constants.TableViewTableMembers)
+	assert.Equal(t, 1, len(views))
+	assert.Equal(t, "승인 대기", views[0].Name)
+	assert.Equal(t, []string{"name", "signId", "createdAt"}, views[0].Columns)
+	assert.Equal(t, map[string]string{"status": "applied"}, views[0].Filters)
+	assert.Equal(t, dtos.TableViewSort{Field: "createdAt", Order: "desc"}, views[0].Sort)
+	assert.Equal(t, 50, views[0].PageSize)
+
+	assert.Equal(t, 0, len(getTableViews(t, 3, []string{}, constants.TableViewTableAuditLogs)))
+
+	// 다른 멤버의 개인 보기는 찾을 수 없다.
+	assert.Equal(t, 0, len(getTableViews(t, 2, []string{constants.PermissionManageAccessControl}, constants.TableViewTableMembers)))
+	rec = requestTableView(2, []string{}, http.MethodGet, fmt.Sprintf("/api/table-views/%v", created.Id), "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = requestTableView(2, []string{}, http.MethodDelete, fmt.Sprintf("/api/table-views/%v", created.Id), "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 같은 이름으로 만들 수 없다.
+	rec = requestTableView(3, []string{}, http.MethodPost, "/api/table-views", requestBody)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestTableViewController_개인_보기_변경과_삭제(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestTableView(3, []string{}, http.MethodPost, "/api/table-views", `{"table": "audit-logs", "name": "로그인", "columns": ["actor"]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var created dtos.TableViewInformation
+	json.Unmarshal(rec.Body.Bytes(), &created)
+
+	// when
+	rec = requestTableView(3, []string{}, http.MethodPut, fmt.Sprintf("/api/table-views/%v", created.Id),
+		`{"table": "roles", "name": "최근 로그인", "columns": ["actor", "createdAt"], "pageSize": 100}`)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var updated dtos.TableViewInformation
+	json.Unmarshal(rec.Body.Bytes(), &updated)
+	assert.Equal(t, constants.TableViewTableAuditLogs, updated.Table)
+	assert.Equal(t, "최근 로그인", updated.Name)
+	assert.Equal(t, []string{"actor", "createdAt"}, updated.Columns)
+	assert.Equal(t, 100, updated.PageSize)
+
+	rec = requestTableView(3, []string{}, http.MethodDelete, fmt.Sprintf("/api/table-views/%v", created.Id), "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 0, len(getTableViews(t, 3, []string{}, "")))
+}
+
+func TestTableViewController_서비스_계정은_사용할_수_없다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	rec := requestTableView(3, []string{}, http.MethodPost, "/api/table-views", `{"table": "audit-logs", "name": "로그인", "columns": ["actor"]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var created dtos.TableViewInformation
+	json.Unmarshal(rec.Body.Bytes(), &created)
+
+	// when
+	// 서비스 계정의 Id(3)가 보기를 만든 멤버(3)의 Id 와 같아도 멤버의 보기를 보거나 바꿀 수 없다.
+	getRec := requestAsServiceAccount(http.MethodGet, "/api/table-views", "", 3, []string{})
+	getOneRec := requestAsServiceAccount(http.MethodGet, fmt.Sprintf("/api/table-views/%v", created.Id), "", 3, []string{})
+	createRec := requestAsServiceAccount(http.MethodPost, "/api/table-views", `{"table": "audit-logs", "name": "수집", "columns": ["actor"]}`, 3, []string{})
+	deleteRec := requestAsServiceAccount(http.MethodDelete, fmt.Sprintf("/api/table-views/%v", created.Id), "", 3, []string{})
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, getRec.Code)
+	assert.Equal(t, http.StatusUnauthorized, getOneRec.Code)
+	assert.Equal(t, http.StatusUnauthorized, createRec.Code)
+	assert.Equal(t, http.StatusUnauthorized, deleteRec.Code)
+	assert.Equal(t, 1, len(getTableViews(t, 3, []string{}, "")))
+}
+
+func TestTableViewController_역할에_공유한_보기(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	manager := []string{constants.PermissionManageAccessControl}
+	rec := requestTableView(1, manager, http.MethodPost, "/api/table-views", `{"table": "members", "name": "멤버 관리자 기본", "roleId": 2, "columns": ["name"]}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var created dtos.TableViewInformation
+	json.Unmarshal(rec.Body.Bytes(), &created)
+	assert.Equal(t, uint(0), created.MemberId)
+	assert.Equal(t, uint(2), created.RoleId)
+
+	// when
+	// 멤버 2 는 MEMBER MANAGER 역할을 가지고, 멤버 3 은 가지지 않는다.
+	memberViews := getTableViews(t, 2, []string{}, constants.TableViewTableMembers)
+	otherViews := getTableViews(t, 3, []string{}, constants.TableViewTableMembers)
+
+	// then
+	assert.Equal(t, 1, len(memberViews))
+	assert.Equal(t, created.Id, memberViews[0].Id)
+	assert.Equal(t, 0, len(otherViews))
+
+	rec = requestTableView(3, []string{}, http.MethodGet, fmt.Sprintf("/api/table-views/%v", created.Id), "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 공유된 보기는 MANAGE_ACCESS_CONTROL 권한이 있어야 바꿀 수 있다.
+	rec = requestTableView(2, []string{}, http.MethodPut, fmt.Sprintf("/api/table-views/%v", created.Id), `{"table": "members", "name": "변경"}`)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = requestTableView(1, manager, http.MethodDelete, fmt.Sprintf("/api/table-views/%v", created.Id), "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestTableViewController_역할에_공유할_권한이_없는_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := requestTableView(2, []string{}, http.MethodPost, "/api/table-views", `{"table": "members", "name": "공유", "roleId": 2}`)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestTableViewController_잘못된_요청(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// 지원하지 않는 목록 화면
+	rec := requestTableView(3, []string{}, http.MethodPost, "/api/table-views", `{"table": "webhooks", "name": "웹훅"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 정렬 순서
+	rec = requestTableView(3, []string{}, http.MethodPost, "/api/table-views", `{"table": "roles", "name": "역할", "sort": {"field": "name", "order": "up"}}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 없는 역할
+	rec = requestTableView(1, []string{constants.PermissionManageAccessControl}, http.MethodPost, "/api/table-views", `{"table": "roles", "name": "역할", "roleId": 100}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual dtos.ErrorMessage
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "INVALID_TABLE_VIEW", actual.Code)
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	etag "github.com/bettercode-oss/gin-middleware-etag"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+type UsageStatisticsController struct {
+	routerGroup            *gin.RouterGroup
+	usageStatisticsService *services.UsageStatisticsService
+}
+
+func NewUsageStatisticsController(
+	routerGroup *gin.RouterGroup,
+	usageStatisticsService *services.UsageStatisticsService) *UsageStatisticsController {
+
+	return &UsageStatisticsController{
+		routerGroup:            routerGroup,
+		usageStatisticsService: usageStatisticsService,
+	}
+}
+
+func (c UsageStatisticsController) MapRoutes() {
+	route := c.routerGroup.Group("/stats")
+	for _, metric := range []string{
+		constants.UsageStatisticMetricLogins,
+		constants.UsageStatisticMetricActiveMembers,
+		constants.UsageStatisticMetricLoginFailures,
+	} {
+		route.GET("/"+metric, middlewares.PermissionChecker([]string{constants.PermissionViewMonitoring}),
+			etag.HttpEtagCache(0),
+			middlewares.ResponseCache(constants.ResponseCacheUsageStatistics, time.Minute),
+			c.getUsageStatistics(metric))
+	}
+}
+
+func (c UsageStatisticsController) getUsageStatistics(metric string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var query dtos.UsageStatisticsQuery
+		if err := ctx.BindQuery(&query); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		entities, err := c.usageStatisticsService.GetUsageStatistics(ctx.Request.Context(), metric, query)
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		var statistics = make([]dtos.UsageStatistic, 0)
+		for _, entity := range entities {
+			statistics = append(statistics, dtos.UsageStatistic{
+				Date:           entity.PeriodStart,
+				OrganizationId: entity.OrganizationId,
+				Dimension:      entity.Dimension,
+				Value:          entity.Value,
+			})
+		}
+
+		ctx.JSON(http.StatusOK, statistics)
+	}
+}
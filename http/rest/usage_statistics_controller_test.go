@@ -0,0 +1,171 @@
+package rest
+
+import (
+	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/helpers"
+	statisticsDomain "better-admin-backend-service/statistics/domain"
+	"better-admin-backend-service/testdata/testdb"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTestLoginAttempt(method string, memberId uint, succeeded bool, failureReason string, attemptedAt time.Time) {
+	gormDB.Create(&statisticsDomain.LoginAttemptEntity{
+		Method:        method,
+		MemberId:      memberId,
+		Succeeded:     succeeded,
+		FailureReason: failureReason,
+		AttemptedAt:   attemptedAt,
+	})
+}
+
+func getTestUsageStatistics(t *testing.T, url string) []map[string]interface{} {
+	// 다른 테스트가 DB 를 바꾸기 전에 저장한 응답을 사용하지 않도록 지운다.
+	middlewares.PurgeResponseCacheByName(constants.ResponseCacheUsageStatistics)
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	token, _ := generateTestJWT(map[string]interface{}{
+		"Id":          1,
+		"Permissions": []string{constants.PermissionViewMonitoring},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []map[string]interface{}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual
+}
+
+func TestAuthController_로그인_시도_기록(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	for _, requestBody := range []string{
+		`{"id": "siteadm", "password": "123456"}`,
+		`{"id": "siteadm", "password": "wrong"}`,
+		`{"id": "nobody", "password": "123456"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+	}
+
+	// then
+	var attempts []statisticsDomain.LoginAttemptEntity
+	gormDB.Order("id").Find(&attempts)
+	assert.Equal(t, 3, len(attempts))
+	assert.True(t, attempts[0].Succeeded)
+	assert.Equal(t, uint(1), attempts[0].MemberId)
+	assert.Equal(t, constants.TypeMemberSite, attempts[0].Method)
+	assert.Equal(t, constants.LoginFailureReasonInvalidCredential, attempts[1].FailureReason)
+	assert.Equal(t, uint(1), attempts[1].MemberId)
+	assert.Equal(t, constants.LoginFailureReasonUnknownMember, attempts[2].FailureReason)
+	assert.Equal(t, uint(0), attempts[2].MemberId)
+}
+
+func TestUsageStatisticsController_일간_통계(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	today := time.Now()
+	yesterday := time.Date(today.Year(), today.Month(), today.Day()-1, 10, 0, 0, 0, time.Local)
+	createTestLoginAttempt(constants.TypeMemberSite, 1, true, "", yesterday)
+	createTestLoginAttempt(constants.TypeMemberSite, 1, true, "", yesterday.Add(time.Hour))
+	createTestLoginAttempt(constants.TypeMemberDooray, 2, true, "", yesterday)
+	createTestLoginAttempt(constants.TypeMemberSite, 3, true, "", yesterday)
+	createTestLoginAttempt(constants.TypeMemberSite, 3, false, constants.LoginFailureReasonInvalidCredential, yesterday)
+	createTestLoginAttempt(constants.TypeMemberSite, 0, false, constants.LoginFailureReasonUnknownMember, yesterday)
+	// 오늘 기록은 아직 집계하지 않는다.
+	createTestLoginAttempt(constants.TypeMemberSite, 1, true, "", today)
+
+	// when
+	ctx := helpers.ContextHelper().SetDB(context.Background(), gormDB)
+	assert.Nil(t, NewContainer().UsageStatisticsService.AggregateUsageStatistics(ctx))
+	// 다시 실행해도 중복으로 집계하지 않는다.
+	assert.Nil(t, NewContainer().UsageStatisticsService.AggregateUsageStatistics(ctx))
+
+	// then
+	date := yesterday.Format("2006-01-02")
+	query := fmt.Sprintf("from=%s&to=%s", date, date)
+
+	logins := getTestUsageStatistics(t, "/api/stats/logins?"+query)
+	assert.Equal(t, []map[string]interface{}{
+		{"date": date, "organizationId": float64(0), "dimension": constants.TypeMemberDooray, "value": float64(1)},
+		{"date": date, "organizationId": float64(0), "dimension": constants.TypeMemberSite, "value": float64(3)},
+	}, logins)
+
+	activeMembers := getTestUsageStatistics(t, "/api/stats/active-members?"+query)
+	assert.Equal(t, []map[string]interface{}{
+		{"date": date, "organizationId": float64(0), "value": float64(3)},
+	}, activeMembers)
+
+	failures := getTestUsageStatistics(t, "/api/stats/login-failures?"+query+"&organizationId=4")
+	assert.Equal(t, []map[string]interface{}{
+		{"date": date, "organizationId": float64(4), "dimension": constants.LoginFailureReasonInvalidCredential, "value": float64(1)},
+	}, failures)
+
+	byOrganization := getTestUsageStatistics(t, "/api/stats/active-members?"+query+"&breakdown=organization")
+	assert.Equal(t, []map[string]interface{}{
+		{"date": date, "organizationId": float64(1), "value": float64(2)},
+		{"date": date, "organizationId": float64(4), "value": float64(1)},
+	}, byOrganization)
+}
+
+func TestUsageStatisticsController_주간_통계(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	today := time.Now()
+	lastSunday := time.Date(today.Year(), today.Month(), today.Day()-int(today.Weekday()), 0, 0, 0, 0, time.Local)
+	if lastSunday.Day() == today.Day() {
+		lastSunday = lastSunday.AddDate(0, 0, -7)
+	}
+	weekStart := lastSunday.AddDate(0, 0, -6)
+	createTestLoginAttempt(constants.TypeMemberSite, 1, true, "", weekStart.Add(9*time.Hour))
+	createTestLoginAttempt(constants.TypeMemberSite, 1, true, "", lastSunday.Add(9*time.Hour))
+	createTestLoginAttempt(constants.TypeMemberSite, 3, true, "", lastSunday.Add(9*time.Hour))
+
+	// when
+	err := NewContainer().UsageStatisticsService.AggregateUsageStatistics(helpers.ContextHelper().SetDB(context.Background(), gormDB))
+
+	// then
+	assert.Nil(t, err)
+	date := weekStart.Format("2006-01-02")
+	actual := getTestUsageStatistics(t, fmt.Sprintf("/api/stats/active-members?period=weekly&from=%s&to=%s", date, date))
+	assert.Equal(t, []map[string]interface{}{
+		{"date": date, "organizationId": float64(0), "value": float64(2)},
+	}, actual)
+}
+
+func TestUsageStatisticsController_잘못된_조회_조건(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	for _, query := range []string{"", "from=2022-01-01", "from=2022/01/01&to=2022-01-31", "from=2022-01-01&to=2022-01-31&period=monthly"} {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/logins?"+query, nil)
+		token, _ := generateTestJWT(map[string]interface{}{
+			"Id":          1,
+			"Permissions": []string{constants.PermissionViewMonitoring},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
@@ -32,15 +32,19 @@ func (c WebHookController) MapRoutes() {
 	route := c.routerGroup.Group("/web-hooks")
 	route.POST("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
 		c.createWebHook)
-	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+	// MANAGE_OWN_RESOURCES 권한만 있으면 소유한 웹훅만 조회하고 관리할 수 있다.
+	route.GET("", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
 		etag.HttpEtagCache(0),
 		c.getWebHooks)
-	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+	route.GET("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.webHookService.AuthorizeOwner),
 		etag.HttpEtagCache(0),
 		c.getWebHook)
-	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+	route.DELETE("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.webHookService.AuthorizeOwner),
 		c.deleteWebHook)
-	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings}),
+	route.PUT("/:id", middlewares.PermissionChecker([]string{constants.PermissionManageSystemSettings, constants.PermissionManageOwnResources}),
+		resourceOwnerChecker(c.webHookService.AuthorizeOwner),
 		c.updateWebHook)
 	route.POST("/:id/note", middlewares.PermissionChecker([]string{constants.PermissionNoteWebHooks}),
 		c.noteMessage)
@@ -65,8 +69,12 @@ func (c WebHookController) createWebHook(ctx *gin.Context) {
 func (c WebHookController) getWebHooks(ctx *gin.Context) {
 	pageable := dtos.NewPageableFromRequest(ctx)
 
-	entities, totalCount, err := c.webHookService.GetWebHooks(ctx.Request.Context(), pageable)
+	entities, totalCount, err := c.webHookService.GetWebHooks(ctx.Request.Context(), listFiltersOf(ctx), pageable)
 	if err != nil {
+		if errors.Is(err, errors.ErrForbidden) {
+			ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Code: errors.Code(err), Message: err.Error()})
+			return
+		}
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
@@ -77,6 +85,7 @@ func (c WebHookController) getWebHooks(ctx *gin.Context) {
 			Id:          entity.ID,
 			Name:        entity.Name,
 			Description: entity.Description,
+			OwnerId:     entity.GetOwnerId(),
 		})
 	}
 
@@ -97,7 +106,7 @@ func (c WebHookController) getWebHook(ctx *gin.Context) {
 
 	entity, err := c.webHookService.GetWebHook(ctx.Request.Context(), uint(webHookId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -110,6 +119,7 @@ func (c WebHookController) getWebHook(ctx *gin.Context) {
 		Id:          entity.ID,
 		Name:        entity.Name,
 		Description: entity.Description,
+		OwnerId:     entity.GetOwnerId(),
 	}
 
 	webHookDetails.FillInWebHookCallSpec(ctx.Request, entity.AccessToken)
@@ -126,7 +136,7 @@ func (c WebHookController) deleteWebHook(ctx *gin.Context) {
 
 	err = c.webHookService.DeleteWebHook(ctx.Request.Context(), uint(webHookId))
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -153,7 +163,7 @@ func (c WebHookController) updateWebHook(ctx *gin.Context) {
 
 	err = c.webHookService.UpdateWebHook(ctx.Request.Context(), uint(webHookId), webHookInformation)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
@@ -180,7 +190,7 @@ func (c WebHookController) noteMessage(ctx *gin.Context) {
 
 	err = c.webHookService.NoteMessage(ctx.Request.Context(), uint(webHookId), message)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
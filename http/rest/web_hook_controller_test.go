@@ -106,11 +106,13 @@ func TestWebHookController_getWebHooks(t *testing.T) {
 				"id":          float64(1),
 				"name":        "테스트 웹훅",
 				"description": "...",
+				"ownerId":     float64(1),
 			},
 			map[string]interface{}{
 				"id":          float64(2),
 				"name":        "테스트 웹훅2",
 				"description": "...",
+				"ownerId":     float64(1),
 			},
 		},
 	}
@@ -175,6 +177,7 @@ func TestWebHookController_getWebHook(t *testing.T) {
 		"id":          float64(3),
 		"name":        "테스트 웹훅3",
 		"description": "...",
+		"ownerId":     float64(1),
 		"webHookCallSpec": map[string]interface{}{
 			"httpRequestMethod": "POST",
 			"url":               "http://example.com/api/web-hooks/3/note",
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// LocaleBundleEntity 는 로케일(예. ko, en-US)별로 관리자가 바꾼 화면 문구(키별 값)이다.
+// 프론트엔드는 내장 문구 위에 번들의 문구를 덮어쓴다. 바꿀 때마다 Version 이 올라가고 LocaleBundleVersionEntity 를 남긴다.
+type LocaleBundleEntity struct {
+	gorm.Model
+	Locale    string `gorm:"type:varchar(20);not null;uniqueIndex"`
+	Version   uint   `gorm:"not null"`
+	Messages  string `gorm:"type:text"`
+	UpdatedBy uint
+}
+
+func (LocaleBundleEntity) TableName() string {
+	return "locale_bundles"
+}
+
+func (l LocaleBundleEntity) GetMessages() map[string]string {
+	return decodeMessages(l.Messages)
+}
+
+// Change 는 문구를 messages 로 바꾸고 다음 버전의 스냅샷을 만든다.
+func (l *LocaleBundleEntity) Change(messages map[string]string, rolledBackFrom uint, changedBy uint) (LocaleBundleVersionEntity, error) {
+	b, err := json.Marshal(messages)
+	if err != nil {
+		return LocaleBundleVersionEntity{}, pkgerrors.Wrap(err, "locale messages encode error")
+	}
+
+	l.Version++
+	l.Messages = string(b)
+	l.UpdatedBy = changedBy
+
+	return LocaleBundleVersionEntity{
+		Locale:         l.Locale,
+		Version:        l.Version,
+		Messages:       l.Messages,
+		RolledBackFrom: rolledBackFrom,
+		CreatedBy:      changedBy,
+	}, nil
+}
+
+// LocaleBundleVersionEntity 는 로케일 번들이 바뀔 때마다 저장하는 전체 문구의 스냅샷이다.
+type LocaleBundleVersionEntity struct {
+	gorm.Model
+	Locale         string `gorm:"type:varchar(20);not null;uniqueIndex:idx_locale_bundle_version"`
+	Version        uint   `gorm:"not null;uniqueIndex:idx_locale_bundle_version"`
+	Messages       string `gorm:"type:text"`
+	RolledBackFrom uint
+	CreatedBy      uint
+}
+
+func (LocaleBundleVersionEntity) TableName() string {
+	return "locale_bundle_versions"
+}
+
+func (l LocaleBundleVersionEntity) GetMessages() map[string]string {
+	return decodeMessages(l.Messages)
+}
+
+func decodeMessages(value string) map[string]string {
+	messages := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &messages); err != nil || messages == nil {
+		return map[string]string{}
+	}
+
+	return messages
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/i18n/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type LocaleBundleRepository struct {
+}
+
+func (LocaleBundleRepository) FindAll(ctx context.Context) ([]domain.LocaleBundleEntity, error) {
+	var entities = make([]domain.LocaleBundleEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).Order("locale").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (LocaleBundleRepository) FindByLocale(ctx context.Context, locale string) (domain.LocaleBundleEntity, error) {
+	var entity domain.LocaleBundleEntity
+
+	if err := helpers.ContextHelper().GetDB(ctx).Where("locale = ?", locale).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (LocaleBundleRepository) Save(ctx context.Context, entity *domain.LocaleBundleEntity) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LocaleBundleRepository) CreateVersion(ctx context.Context, entity *domain.LocaleBundleVersionEntity) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LocaleBundleRepository) FindVersions(ctx context.Context, locale string, pageable dtos.Pageable) ([]domain.LocaleBundleVersionEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.LocaleBundleVersionEntity{}).Where("locale = ?", locale)
+
+	var entities = make([]domain.LocaleBundleVersionEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("version DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (LocaleBundleRepository) FindVersion(ctx context.Context, locale string, version uint) (domain.LocaleBundleVersionEntity, error) {
+	var entity domain.LocaleBundleVersionEntity
+
+	if err := helpers.ContextHelper().GetDB(ctx).Where("locale = ? AND version = ?", locale, version).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// DeleteVersionsOlderThan 은 로케일의 version 보다 오래된 스냅샷을 지운다.
+func (LocaleBundleRepository) DeleteVersionsOlderThan(ctx context.Context, locale string, version uint) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Unscoped().Where("locale = ? AND version < ?", locale, version).
+		Delete(&domain.LocaleBundleVersionEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"better-admin-backend-service/anonymization"
 	"better-admin-backend-service/app"
 	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/backup"
 	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/http/rest"
+	"better-admin-backend-service/security"
+	"bufio"
+	"flag"
 	filename "github.com/keepeye/logrus-filename"
+	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"os"
+	"strings"
 )
 
 func main() {
@@ -17,9 +26,186 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "restore-backup" {
+		if err := restoreBackup(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		log.Info("database restored")
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "create-anonymized-snapshot" {
+		if err := createAnonymizedSnapshot(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		log.Info("anonymized snapshot created")
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "anonymize-database" {
+		if err := anonymizeDatabase(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		log.Info("database anonymized")
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "build-password-bloom-filter" {
+		if err := buildPasswordBloomFilter(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		log.Info("password bloom filter built")
+		return
+	}
+
 	log.Fatal(app.NewApp(rest.Router{}, db.ProductionDbConnector{}).Run())
 }
 
+// restoreBackup 은 서버를 멈춘 상태에서 백업(GET /api/system/backups 의 key)으로 DB 를 복원한다.
+// 실수로 복원하지 않도록 -confirm 에 같은 key 를 한 번 더 입력해야 한다.
+func restoreBackup(args []string) error {
+	flags := flag.NewFlagSet("restore-backup", flag.ContinueOnError)
+	key := flags.String("key", "", "restore backup key")
+	confirm := flags.String("confirm", "", "same backup key to confirm restore")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(*key) == 0 || *key != *confirm {
+		return errors.New("usage: restore-backup -key <backup key> -confirm <backup key>")
+	}
+
+	gormDB, err := db.ProductionDbConnector{}.Connect()
+	if err != nil {
+		return err
+	}
+
+	return backup.Restore(gormDB, *key)
+}
+
+// createAnonymizedSnapshot 은 staging 환경에서 사용할 수 있도록 개인 정보를 익명화한 SQLite DB 사본(-output)을 만든다.
+func createAnonymizedSnapshot(args []string) error {
+	flags := flag.NewFlagSet("create-anonymized-snapshot", flag.ContinueOnError)
+	output := flags.String("output", "", "anonymized snapshot file")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(*output) == 0 {
+		return errors.New("usage: create-anonymized-snapshot -output <snapshot file>")
+	}
+
+	gormDB, err := db.ProductionDbConnector{}.Connect()
+	if err != nil {
+		return err
+	}
+
+	tables, err := anonymization.CreateSnapshot(gormDB, *output)
+	if err != nil {
+		return err
+	}
+	logAnonymizedTables(tables)
+	return nil
+}
+
+// anonymizeDatabase 는 백업을 복원한 staging DB 의 개인 정보를 직접 익명화한다(MySQL).
+// 운영 DB 를 익명화하지 않도록 -confirm 에 DB 이름(MySQL 은 DB_NAME, SQLite 는 파일 경로)을 입력해야 한다.
+func anonymizeDatabase(args []string) error {
+	flags := flag.NewFlagSet("anonymize-database", flag.ContinueOnError)
+	confirm := flags.String("confirm", "", "database name to confirm anonymization")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	databaseName := db.SqlitePath()
+	if db.GetDriver() == db.DriverMysql {
+		databaseName = os.Getenv(db.EnvDbName)
+	}
+	if len(databaseName) == 0 || *confirm != databaseName {
+		return errors.New("usage: anonymize-database -confirm <database name>")
+	}
+
+	gormDB, err := db.ProductionDbConnector{}.Connect()
+	if err != nil {
+		return err
+	}
+
+	if err := anonymization.CheckLegalHolds(gormDB); err != nil {
+		return err
+	}
+
+	tables, err := anonymization.Anonymize(gormDB)
+	if err != nil {
+		return err
+	}
+	logAnonymizedTables(tables)
+	return nil
+}
+
+func logAnonymizedTables(tables []dtos.AnonymizedTable) {
+	for _, table := range tables {
+		log.Infof("%s: %d rows anonymized (%s)", table.Table, table.Rows, strings.Join(table.Columns, ", "))
+	}
+}
+
+// buildPasswordBloomFilter 는 유출된 비밀번호 SHA-1 목록(예. Pwned Passwords 의 "해시:횟수" 파일)으로
+// offline 유출 확인에서 사용할 bloom filter 파일(PasswordBreach.BloomFilterPath)을 만든다.
+func buildPasswordBloomFilter(args []string) error {
+	flags := flag.NewFlagSet("build-password-bloom-filter", flag.ContinueOnError)
+	input := flags.String("input", "", "breached password sha-1 hash list file")
+	output := flags.String("output", "", "bloom filter file")
+	falsePositiveRate := flags.Float64("false-positive-rate", 0.001, "false positive rate")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if len(*input) == 0 || len(*output) == 0 {
+		return errors.New("usage: build-password-bloom-filter -input <sha-1 hash list> -output <bloom filter file>")
+	}
+
+	// 크기를 정하기 위해 먼저 해시 수를 센다.
+	var count uint64
+	if err := scanLines(*input, func(string) error {
+		count++
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	bloomFilter := security.NewPasswordBloomFilter(count, *falsePositiveRate)
+	if err := scanLines(*input, bloomFilter.AddSha1Hex); err != nil {
+		return err
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = bloomFilter.WriteTo(file)
+	return err
+}
+
+func scanLines(path string, handle func(line string) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); len(line) > 0 {
+			if err := handle(line); err != nil {
+				return err
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
 func setUpLogFormatter() {
 	filenameHook := filename.NewHook()
 	filenameHook.Field = "line"
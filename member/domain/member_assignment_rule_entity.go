@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// MemberAssignmentRuleEntity 는 SSO 로 처음 로그인한 멤버에게 역할과 조직을 자동으로 할당하는 규칙이다.
+type MemberAssignmentRuleEntity struct {
+	gorm.Model
+	Name            string `gorm:"type:varchar(100);not null"`
+	Priority        int    `gorm:"not null;default:0"`
+	Condition       string `gorm:"type:text"`
+	RoleIds         string `gorm:"type:text"`
+	OrganizationIds string `gorm:"type:text"`
+	CreatedBy       uint
+	UpdatedBy       uint
+}
+
+func (MemberAssignmentRuleEntity) TableName() string {
+	return "member_assignment_rules"
+}
+
+func (r MemberAssignmentRuleEntity) GetCondition() dtos.MemberAssignmentCondition {
+	var condition dtos.MemberAssignmentCondition
+	if err := json.Unmarshal([]byte(r.Condition), &condition); err != nil {
+		return dtos.MemberAssignmentCondition{}
+	}
+
+	return condition
+}
+
+func (r MemberAssignmentRuleEntity) GetRoleIds() []uint {
+	return r.decodeIds(r.RoleIds)
+}
+
+func (r MemberAssignmentRuleEntity) GetOrganizationIds() []uint {
+	return r.decodeIds(r.OrganizationIds)
+}
+
+func (MemberAssignmentRuleEntity) decodeIds(value string) []uint {
+	ids := make([]uint, 0)
+	if err := json.Unmarshal([]byte(value), &ids); err != nil {
+		return make([]uint, 0)
+	}
+
+	return ids
+}
+
+// Matches 는 가입 정보가 규칙의 조건을 모두 만족하는지 확인한다.
+func (r MemberAssignmentRuleEntity) Matches(attributes dtos.MemberSignUpAttributes) bool {
+	condition := r.GetCondition()
+
+	if len(condition.MemberTypes) > 0 && !r.containsAny(condition.MemberTypes, func(value string) bool {
+		return value == attributes.Type
+	}) {
+		return false
+	}
+
+	if len(condition.EmailDomains) > 0 {
+		at := strings.LastIndex(attributes.Email, "@")
+		if at < 0 {
+			return false
+		}
+
+		domain := attributes.Email[at+1:]
+		if !r.containsAny(condition.EmailDomains, func(value string) bool {
+			return strings.EqualFold(strings.TrimPrefix(value, "@"), domain)
+		}) {
+			return false
+		}
+	}
+
+	if len(condition.GoogleOrgUnits) > 0 && !r.containsAny(condition.GoogleOrgUnits, func(value string) bool {
+		orgUnit := strings.TrimSuffix(value, "/")
+		return len(orgUnit) > 0 && (attributes.GoogleOrgUnit == orgUnit || strings.HasPrefix(attributes.GoogleOrgUnit, orgUnit+"/"))
+	}) {
+		return false
+	}
+
+	if len(condition.DoorayDepartments) > 0 && !r.containsAny(condition.DoorayDepartments, func(value string) bool {
+		return len(attributes.DoorayDepartment) > 0 && value == attributes.DoorayDepartment
+	}) {
+		return false
+	}
+
+	return true
+}
+
+func (MemberAssignmentRuleEntity) containsAny(values []string, match func(value string) bool) bool {
+	for _, value := range values {
+		if match(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *MemberAssignmentRuleEntity) Update(ctx context.Context, information dtos.MemberAssignmentRuleInformation) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := r.apply(information); err != nil {
+		return err
+	}
+
+	r.UpdatedBy = userClaim.Id
+	return nil
+}
+
+func (r *MemberAssignmentRuleEntity) apply(information dtos.MemberAssignmentRuleInformation) error {
+	condition, err := json.Marshal(information.Condition)
+	if err != nil {
+		return pkgerrors.Wrap(err, "member assignment rule condition encode error")
+	}
+
+	roleIds, err := json.Marshal(r.normalizeIds(information.RoleIds))
+	if err != nil {
+		return pkgerrors.Wrap(err, "member assignment rule role ids encode error")
+	}
+
+	organizationIds, err := json.Marshal(r.normalizeIds(information.OrganizationIds))
+	if err != nil {
+		return pkgerrors.Wrap(err, "member assignment rule organization ids encode error")
+	}
+
+	r.Name = information.Name
+	r.Priority = information.Priority
+	r.Condition = string(condition)
+	r.RoleIds = string(roleIds)
+	r.OrganizationIds = string(organizationIds)
+	return nil
+}
+
+func (MemberAssignmentRuleEntity) normalizeIds(ids []uint) []uint {
+	if ids == nil {
+		return make([]uint, 0)
+	}
+
+	return ids
+}
+
+func NewMemberAssignmentRuleEntity(ctx context.Context, information dtos.MemberAssignmentRuleInformation) (MemberAssignmentRuleEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return MemberAssignmentRuleEntity{}, err
+	}
+
+	entity := MemberAssignmentRuleEntity{CreatedBy: userClaim.Id}
+	if err := entity.Update(ctx, information); err != nil {
+		return MemberAssignmentRuleEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// NewMemberAssignmentRuleEntityForDryRun 은 저장하지 않고 미리 보기에만 사용할 규칙을 만든다.
+func NewMemberAssignmentRuleEntityForDryRun(information dtos.MemberAssignmentRuleInformation) (MemberAssignmentRuleEntity, error) {
+	entity := MemberAssignmentRuleEntity{}
+	entity.ID = information.Id
+	if err := entity.apply(information); err != nil {
+		return MemberAssignmentRuleEntity{}, err
+	}
+
+	return entity, nil
+}
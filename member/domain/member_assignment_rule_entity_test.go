@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMemberAssignmentRuleEntity_Matches(t *testing.T) {
+	// given
+	rule := MemberAssignmentRuleEntity{
+		Condition: `{"emailDomains":["@BetterCode.kr"],"googleOrgUnits":["/Engineering/"]}`,
+	}
+
+	testCases := map[string]struct {
+		attributes dtos.MemberSignUpAttributes
+		expected   bool
+	}{
+		"하위 조직 단위":      {dtos.MemberSignUpAttributes{Email: "kim@bettercode.kr", GoogleOrgUnit: "/Engineering/Backend"}, true},
+		"같은 조직 단위":      {dtos.MemberSignUpAttributes{Email: "kim@bettercode.kr", GoogleOrgUnit: "/Engineering"}, true},
+		"이름만 비슷한 조직 단위": {dtos.MemberSignUpAttributes{Email: "kim@bettercode.kr", GoogleOrgUnit: "/EngineeringOps"}, false},
+		"다른 메일 도메인":     {dtos.MemberSignUpAttributes{Email: "kim@example.com", GoogleOrgUnit: "/Engineering"}, false},
+		"메일이 없는 경우":     {dtos.MemberSignUpAttributes{GoogleOrgUnit: "/Engineering"}, false},
+	}
+
+	for name, testCase := range testCases {
+		// when
+		actual := rule.Matches(testCase.attributes)
+
+		// then
+		assert.Equal(t, testCase.expected, actual, name)
+	}
+}
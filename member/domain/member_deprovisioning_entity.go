@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"time"
+)
+
+// MemberDeprovisioningEntity 는 멤버 해지 체크리스트를 실행한 기록(완료 보고서)이다.
+type MemberDeprovisioningEntity struct {
+	gorm.Model
+	MemberId             uint   `gorm:"not null;index"`
+	SuccessorId          uint   `gorm:"not null"`
+	Reason               string `gorm:"type:varchar(1000)"`
+	Status               string `gorm:"type:varchar(30);not null"`
+	Steps                string `gorm:"type:text"`
+	TransferredResources string `gorm:"type:text"`
+	RequestedBy          uint
+	CompletedAt          *time.Time
+}
+
+func (MemberDeprovisioningEntity) TableName() string {
+	return "member_deprovisionings"
+}
+
+func (m MemberDeprovisioningEntity) GetSteps() []dtos.DeprovisioningStep {
+	steps := make([]dtos.DeprovisioningStep, 0)
+	if len(m.Steps) > 0 {
+		json.Unmarshal([]byte(m.Steps), &steps)
+	}
+
+	return steps
+}
+
+func (m MemberDeprovisioningEntity) GetTransferredResources() []dtos.OwnedResource {
+	resources := make([]dtos.OwnedResource, 0)
+	if len(m.TransferredResources) > 0 {
+		json.Unmarshal([]byte(m.TransferredResources), &resources)
+	}
+
+	return resources
+}
+
+func (m *MemberDeprovisioningEntity) AddStep(name, status, detail string) {
+	steps := append(m.GetSteps(), dtos.DeprovisioningStep{Name: name, Status: status, Detail: detail})
+	value, _ := json.Marshal(steps)
+	m.Steps = string(value)
+}
+
+func (m *MemberDeprovisioningEntity) AddTransferredResources(resources []dtos.OwnedResource) {
+	value, _ := json.Marshal(append(m.GetTransferredResources(), resources...))
+	m.TransferredResources = string(value)
+}
+
+// Complete 는 모든 단계를 실행한 뒤 호출한다. 실패한 단계가 있으면 completed-with-errors 이다.
+func (m *MemberDeprovisioningEntity) Complete(now time.Time) {
+	m.Status = constants.DeprovisioningStatusCompleted
+	for _, step := range m.GetSteps() {
+		if step.Status == constants.DeprovisioningStepStatusFailed {
+			m.Status = constants.DeprovisioningStatusCompletedWithErrors
+		}
+	}
+	m.CompletedAt = &now
+}
+
+func (m MemberDeprovisioningEntity) ToReport() dtos.MemberDeprovisioningReport {
+	return dtos.MemberDeprovisioningReport{
+		Id:                   m.ID,
+		MemberId:             m.MemberId,
+		SuccessorId:          m.SuccessorId,
+		Reason:               m.Reason,
+		Status:               m.Status,
+		Steps:                m.GetSteps(),
+		TransferredResources: m.GetTransferredResources(),
+		RequestedBy:          m.RequestedBy,
+		CreatedAt:            m.CreatedAt,
+		CompletedAt:          m.CompletedAt,
+	}
+}
+
+func NewMemberDeprovisioningEntity(ctx context.Context, memberId uint, request dtos.MemberDeprovisioningRequest) (MemberDeprovisioningEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return MemberDeprovisioningEntity{}, err
+	}
+
+	return MemberDeprovisioningEntity{
+		MemberId:    memberId,
+		SuccessorId: request.SuccessorId,
+		Reason:      request.Reason,
+		Status:      constants.DeprovisioningStatusCompleted,
+		RequestedBy: userClaim.Id,
+	}, nil
+}
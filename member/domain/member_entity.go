@@ -6,10 +6,11 @@ import (
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/security"
 	"context"
 	pkgerrors "github.com/pkg/errors"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"strings"
 	"time"
 )
 
@@ -18,16 +19,33 @@ type MemberEntity struct {
 	Type           string `gorm:"type:varchar(20);not null"`
 	SignId         string `gorm:"type:varchar(50)"`
 	Name           string `gorm:"type:varchar(50)"`
-	Password       string `gorm:"type:varchar(100)"`
+	Password       string `gorm:"type:varchar(255)"`
 	Status         string `gorm:"type:varchar(20);not null"`
 	DoorayId       string `gorm:"type:varchar(50)"`
 	DoorayUserCode string `gorm:"type:varchar(50)"`
 	GoogleId       string `gorm:"type:varchar(50)"`
 	GoogleMail     string `gorm:"type:varchar(50)"`
 	Picture        string `gorm:"type:varchar(1000)"`
-	UpdatedBy      uint
-	LastAccessAt   *time.Time
-	Roles          []domain.RoleEntity `gorm:"many2many:member_roles;"`
+	Phone          string `gorm:"type:varchar(20)"`
+	// 외부 인증(Authenticator)으로 가입한 멤버의 Authenticator 이름과 그 안의 사용자 Id, 메일
+	AuthenticatorName string `gorm:"type:varchar(50)"`
+	ExternalId        string `gorm:"type:varchar(100)"`
+	ExternalMail      string `gorm:"type:varchar(100)"`
+	// 두레이 멤버의 메일과 부서, 구글 워크스페이스 멤버의 조직 단위. 가입 시 할당 규칙을 평가할 때 사용한다.
+	DoorayMail       string `gorm:"type:varchar(100)"`
+	DoorayDepartment string `gorm:"type:varchar(100)"`
+	GoogleOrgUnit    string `gorm:"type:varchar(200)"`
+	UpdatedBy        uint
+	LastAccessAt     *time.Time
+	// 승인되지 않은 가입 신청을 승인자에게 다시 알리거나 상위 승인자에게 넘긴 시간
+	SignUpRemindedAt  *time.Time
+	SignUpEscalatedAt *time.Time
+	Roles             []domain.RoleEntity `gorm:"many2many:member_roles;"`
+	Tags              []MemberTagEntity   `gorm:"foreignKey:MemberId"`
+	// 본인이 하지 않은 로그인으로 신고하면 비밀번호를 다시 설정할 때까지 비밀번호로 로그인할 수 없다.
+	PasswordResetRequired bool `gorm:"not null;default:false"`
+	// 관리자가 지정한 알림 메일 주소. 있으면 인증 방식별 메일보다 먼저 사용한다.
+	Email string `gorm:"type:varchar(50)"`
 }
 
 func (MemberEntity) TableName() string {
@@ -35,38 +53,58 @@ func (MemberEntity) TableName() string {
 }
 
 func (m MemberEntity) ValidatePassword(password string) error {
-	if m.comparePasswords(m.Password, password) == false {
+	matched, err := security.VerifyPassword(m.Password, password)
+	if err != nil {
+		return pkgerrors.Wrap(err, "InvalidPassword")
+	}
+
+	if !matched {
 		return pkgerrors.New("InvalidPassword")
 	}
 
 	return nil
 }
 
-func (m MemberEntity) hashAndSalt(pwd string) (string, error) {
-	// Use GenerateFromPassword to hash & salt pwd.
-	// MinCost is just an integer constant provided by the bcrypt
-	// package along with DefaultCost & MaxCost.
-	// The cost can be any value you want provided it isn't lower
-	// than the MinCost (4)
-	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.MinCost)
+// PasswordNeedsRehash 는 비밀번호가 설정(PasswordHashing)과 다른 알고리즘이나 비용으로 저장되어 있는지 여부이다.
+func (m MemberEntity) PasswordNeedsRehash() bool {
+	return len(m.Password) > 0 && security.PasswordNeedsRehash(m.Password)
+}
+
+// RehashPassword 는 확인한 비밀번호를 설정된 알고리즘과 비용으로 다시 해시한다.
+func (m *MemberEntity) RehashPassword(password string) error {
+	hashedPassword, err := security.HashPassword(password)
 	if err != nil {
-		return "", err
+		return err
 	}
-	// GenerateFromPassword returns a byte slice so we need to
-	// convert the bytes to a string and return it
-	return string(hash), nil
+
+	m.Password = hashedPassword
+	return nil
 }
 
-func (m MemberEntity) comparePasswords(hashedPwd string, plainPwd string) bool {
-	// Since we'll be getting the hashed password from the DB it
-	// will be a string so we'll need to convert it to a byte slice
-	byteHash := []byte(hashedPwd)
-	err := bcrypt.CompareHashAndPassword(byteHash, []byte(plainPwd))
+// RequirePasswordReset 은 비밀번호를 다시 설정할 때까지 비밀번호 로그인을 막는다. 비밀번호가 없는 멤버(SSO)는 바꾸지 않는다.
+func (m *MemberEntity) RequirePasswordReset() {
+	if m.Type != constants.TypeMemberSite {
+		return
+	}
+
+	m.PasswordResetRequired = true
+}
+
+// ResetPassword 는 새 비밀번호를 설정하고 비밀번호 로그인을 다시 허용한다.
+func (m *MemberEntity) ResetPassword(password string) error {
+	if m.Type != constants.TypeMemberSite {
+		return errors.ErrNonChangeable
+	}
+
+	hashedPassword, err := security.HashPassword(password)
 	if err != nil {
-		return false
+		return err
 	}
 
-	return true
+	m.Password = hashedPassword
+	m.PasswordResetRequired = false
+	m.UpdatedBy = m.ID
+	return nil
 }
 
 func (m MemberEntity) GetTypeName() string {
@@ -82,6 +120,10 @@ func (m MemberEntity) GetTypeName() string {
 		return constants.TypeMemberGoogleName
 	}
 
+	if m.Type == constants.TypeMemberCustom {
+		return constants.TypeMemberCustomName
+	}
+
 	return ""
 }
 
@@ -98,6 +140,27 @@ func (m *MemberEntity) AssignRole(ctx context.Context, roleEntities []domain.Rol
 	return nil
 }
 
+func (m MemberEntity) HasRole(roleId uint) bool {
+	for _, role := range m.Roles {
+		if role.ID == roleId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsSuperAdmin 은 최고 관리자 역할(SYSTEM MANAGER)이 직접 할당되었는지 여부이다.
+func (m MemberEntity) IsSuperAdmin() bool {
+	for _, role := range m.Roles {
+		if role.Name == constants.RoleNameSuperAdmin {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (m MemberEntity) GetRoleNames() []string {
 	var rolesNames = make([]string, 0)
 	if m.Roles == nil {
@@ -111,6 +174,36 @@ func (m MemberEntity) GetRoleNames() []string {
 	return rolesNames
 }
 
+func (m MemberEntity) GetRoleIds() []uint {
+	var roleIds = make([]uint, 0, len(m.Roles))
+	for _, role := range m.Roles {
+		roleIds = append(roleIds, role.ID)
+	}
+
+	return roleIds
+}
+
+// ToEventData 는 도메인 이벤트(member.*)로 발행할 멤버 정보이다. 비밀번호 등 민감한 정보는 넣지 않는다.
+func (m MemberEntity) ToEventData() dtos.MemberEventData {
+	return dtos.MemberEventData{
+		MemberId: m.ID,
+		Type:     m.Type,
+		SignId:   m.SignId,
+		Name:     m.Name,
+		Status:   m.Status,
+		Roles:    m.GetRoleNames(),
+	}
+}
+
+func (m MemberEntity) GetTagNames() []string {
+	var tagNames = make([]string, 0)
+	for _, tag := range m.Tags {
+		tagNames = append(tagNames, tag.Name)
+	}
+
+	return tagNames
+}
+
 func (m MemberEntity) GetPermissionNames() []string {
 	// 역할에 할당된 권한을 반환한다.
 	// 권한이 중복이 일어날 수 있기 때문에 중복을 없애고 반환한다.
@@ -150,6 +243,38 @@ func (m *MemberEntity) Approve(ctx context.Context) error {
 	return nil
 }
 
+// AutoApprove 는 자동 승인 규칙에 맞는 멤버를 승인하고 기본 역할을 추가한다. 기존 역할은 그대로 둔다.
+// 가입 처리 중(로그인 전)에 호출하므로 변경한 사용자(UpdatedBy)는 기록하지 않는다.
+func (m *MemberEntity) AutoApprove(roleEntities []domain.RoleEntity) {
+	m.Status = constants.StatusMemberApproved
+	for _, roleEntity := range roleEntities {
+		if !m.HasRole(roleEntity.ID) {
+			m.Roles = append(m.Roles, roleEntity)
+		}
+	}
+}
+
+// Deprovision 은 해지한 멤버를 더 이상 로그인할 수 없게 한다.
+func (m *MemberEntity) Deprovision(ctx context.Context) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.Status = constants.StatusMemberDeprovisioned
+	m.UpdatedBy = userClaim.Id
+	return nil
+}
+
+func (m MemberEntity) IsDeprovisioned() bool {
+	return m.Status == constants.StatusMemberDeprovisioned
+}
+
+// WaitForApproval 은 자동 승인 규칙에 맞지 않는 SSO 멤버를 승인 대기(applied)로 둔다.
+func (m *MemberEntity) WaitForApproval() {
+	m.Status = constants.StatusMemberApplied
+}
+
 func (m MemberEntity) IsApproved() bool {
 	if m.Status == constants.StatusMemberApproved {
 		return true
@@ -164,18 +289,114 @@ func (m MemberEntity) GetCandidateId() string {
 		return m.DoorayUserCode
 	} else if m.Type == constants.TypeMemberGoogle {
 		return m.GoogleMail
+	} else if m.Type == constants.TypeMemberCustom {
+		return m.ExternalId
 	} else {
 		return ""
 	}
 }
 
+// ChangeSignId 는 아이디를 변경한다. 사이트 멤버만 아이디를 변경할 수 있다.
+func (m *MemberEntity) ChangeSignId(newSignId string) error {
+	if m.Type != constants.TypeMemberSite {
+		return errors.ErrNonChangeable
+	}
+
+	m.SignId = newSignId
+	m.UpdatedBy = m.ID
+	return nil
+}
+
+// ChangeEmail 은 알림 메일 주소를 변경한다.
+func (m *MemberEntity) ChangeEmail(ctx context.Context, email string) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.Email = email
+	m.UpdatedBy = userClaim.Id
+	return nil
+}
+
+// GetEmail 은 알림을 보낼 수 있는 이메일 주소를 반환한다. 없으면 빈 문자열이다.
+func (m MemberEntity) GetEmail() string {
+	if len(m.Email) > 0 {
+		return m.Email
+	}
+
+	if len(m.GoogleMail) > 0 {
+		return m.GoogleMail
+	}
+
+	if len(m.ExternalMail) > 0 {
+		return m.ExternalMail
+	}
+
+	if len(m.DoorayMail) > 0 {
+		return m.DoorayMail
+	}
+
+	if m.Type == constants.TypeMemberSite && strings.Contains(m.SignId, "@") {
+		return m.SignId
+	}
+
+	return ""
+}
+
+// GetSignUpAttributes 는 할당 규칙을 평가할 가입 정보(멤버 유형, 메일, 조직 단위, 부서)이다.
+func (m MemberEntity) GetSignUpAttributes() dtos.MemberSignUpAttributes {
+	return dtos.MemberSignUpAttributes{
+		Type:             m.Type,
+		Email:            m.GetEmail(),
+		GoogleOrgUnit:    m.GoogleOrgUnit,
+		DoorayDepartment: m.DoorayDepartment,
+	}
+}
+
+func (m MemberEntity) IsPendingSignUp() bool {
+	return m.Status == constants.StatusMemberApplied
+}
+
+func (m MemberEntity) NeedsSignUpReminder(setting dtos.PendingSignUpSetting, now time.Time) bool {
+	return m.IsPendingSignUp() && m.SignUpRemindedAt == nil && m.isPendingLongerThan(setting.ReminderDays, now)
+}
+
+func (m MemberEntity) NeedsSignUpEscalation(setting dtos.PendingSignUpSetting, now time.Time) bool {
+	return m.IsPendingSignUp() && m.SignUpEscalatedAt == nil && len(setting.EscalationRoleName) > 0 &&
+		m.isPendingLongerThan(setting.EscalationDays, now)
+}
+
+func (m MemberEntity) NeedsSignUpExpiry(setting dtos.PendingSignUpSetting, now time.Time) bool {
+	return m.IsPendingSignUp() && m.isPendingLongerThan(setting.ExpiryDays, now)
+}
+
+// isPendingLongerThan 은 가입 신청 후 days 일이 지났는지 확인한다. days 가 0 이면 사용하지 않는 것이다.
+func (m MemberEntity) isPendingLongerThan(days int, now time.Time) bool {
+	if days <= 0 {
+		return false
+	}
+
+	return m.CreatedAt.AddDate(0, 0, days).Before(now)
+}
+
+func (m *MemberEntity) MarkSignUpReminded() {
+	now := time.Now()
+	m.SignUpRemindedAt = &now
+}
+
+func (m *MemberEntity) MarkSignUpEscalated() {
+	now := time.Now()
+	m.SignUpEscalatedAt = &now
+}
+
 func (m *MemberEntity) UpdateLastAccessAt() {
 	now := time.Now()
 	m.LastAccessAt = &now
 }
 
 func NewMemberEntityFromSignUp(signUp dtos.MemberSignUp) (MemberEntity, error) {
-	hashedPassword, err := MemberEntity{}.hashAndSalt(signUp.Password)
+	hashedPassword, err := security.HashPassword(signUp.Password)
 	if err != nil {
 		return MemberEntity{}, err
 	}
@@ -185,6 +406,7 @@ func NewMemberEntityFromSignUp(signUp dtos.MemberSignUp) (MemberEntity, error) {
 		SignId:   signUp.SignId,
 		Name:     signUp.Name,
 		Password: hashedPassword,
+		Phone:    signUp.Phone,
 		Status:   constants.StatusMemberApplied,
 	}, nil
 }
@@ -192,22 +414,38 @@ func NewMemberEntityFromSignUp(signUp dtos.MemberSignUp) (MemberEntity, error) {
 func NewMemberEntityFromDoorayMember(doorayMember dtos.DoorayMember) MemberEntity {
 	// 두레이 사용자의 경우 이미 두레이를 통해 인증된 사용자 이기 때문에 상태를 '승인' 설정
 	return MemberEntity{
-		Type:           constants.TypeMemberDooray,
-		DoorayId:       doorayMember.Id,
-		DoorayUserCode: doorayMember.UserCode,
-		Name:           doorayMember.Name,
-		Status:         constants.StatusMemberApproved,
+		Type:             constants.TypeMemberDooray,
+		DoorayId:         doorayMember.Id,
+		DoorayUserCode:   doorayMember.UserCode,
+		DoorayMail:       doorayMember.ExternalEmailAddress,
+		DoorayDepartment: doorayMember.Department,
+		Name:             doorayMember.Name,
+		Status:           constants.StatusMemberApproved,
 	}
 }
 
 func NewMemberEntityFromGoogleMember(googleMember dtos.GoogleMember) MemberEntity {
 	// 구글 워크스페이스 사용자의 경우 이미 구글 워크스페이스를 통해 인증된 사용자 이기 때문에 상태를 '승인' 설정
 	return MemberEntity{
-		Type:       constants.TypeMemberGoogle,
-		GoogleId:   googleMember.Id,
-		GoogleMail: googleMember.Email,
-		Name:       googleMember.Name,
-		Picture:    googleMember.Picture,
-		Status:     constants.StatusMemberApproved,
+		Type:          constants.TypeMemberGoogle,
+		GoogleId:      googleMember.Id,
+		GoogleMail:    googleMember.Email,
+		GoogleOrgUnit: googleMember.OrgUnitPath,
+		Name:          googleMember.Name,
+		Picture:       googleMember.Picture,
+		Phone:         googleMember.Phone,
+		Status:        constants.StatusMemberApproved,
+	}
+}
+
+func NewMemberEntityFromCustomAuthIdentity(authenticatorName string, identity dtos.CustomAuthIdentity) MemberEntity {
+	// 외부 인증 사용자의 경우 이미 Authenticator 를 통해 인증된 사용자 이기 때문에 상태를 '승인' 설정
+	return MemberEntity{
+		Type:              constants.TypeMemberCustom,
+		AuthenticatorName: authenticatorName,
+		ExternalId:        identity.ExternalId,
+		ExternalMail:      identity.Email,
+		Name:              identity.Name,
+		Status:            constants.StatusMemberApproved,
 	}
 }
@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"net/mail"
+	"time"
+)
+
+// MemberFieldChangeEntity 는 다른 관리자의 승인을 기다리는 멤버 중요 필드(아이디, 메일, 최고 관리자의 역할) 변경이다.
+// 승인 전까지 멤버는 바뀌지 않으며, 기한(ExpiresAt)까지 승인되지 않으면 만료된다.
+// 역할 변경의 OldValue, NewValue 는 역할 이름 목록(JSON)이고 할당할 역할은 RoleIds(JSON)에 보관한다.
+type MemberFieldChangeEntity struct {
+	gorm.Model
+	MemberId    uint   `gorm:"not null;index"`
+	Field       string `gorm:"type:varchar(20);not null"`
+	OldValue    string `gorm:"type:text"`
+	NewValue    string `gorm:"type:text"`
+	RoleIds     string `gorm:"type:varchar(1000)"`
+	Status      string `gorm:"type:varchar(20);not null;index"`
+	RequestedBy uint
+	ExpiresAt   time.Time
+	CompletedAt *time.Time
+}
+
+func (MemberFieldChangeEntity) TableName() string {
+	return "member_field_changes"
+}
+
+func (m MemberFieldChangeEntity) IsPending() bool {
+	return m.Status == constants.MemberFieldChangeStatusPending
+}
+
+func (m MemberFieldChangeEntity) IsExpired(now time.Time) bool {
+	return m.ExpiresAt.Before(now)
+}
+
+// Apply 는 변경을 반영한 것으로 표시한다. 기한이 지난 변경은 만료 처리되고 ErrExpired 를 반환한다.
+func (m *MemberFieldChangeEntity) Apply() error {
+	if !m.IsPending() {
+		return errors.ErrNonChangeable
+	}
+
+	if m.IsExpired(time.Now()) {
+		m.complete(constants.MemberFieldChangeStatusExpired)
+		return errors.ErrExpired
+	}
+
+	m.complete(constants.MemberFieldChangeStatusApplied)
+	return nil
+}
+
+func (m *MemberFieldChangeEntity) Reject() error {
+	if !m.IsPending() {
+		return errors.ErrNonChangeable
+	}
+
+	m.complete(constants.MemberFieldChangeStatusRejected)
+	return nil
+}
+
+func (m *MemberFieldChangeEntity) Expire() error {
+	if !m.IsPending() {
+		return errors.ErrNonChangeable
+	}
+
+	m.complete(constants.MemberFieldChangeStatusExpired)
+	return nil
+}
+
+func (m *MemberFieldChangeEntity) complete(status string) {
+	now := time.Now()
+	m.Status = status
+	m.CompletedAt = &now
+}
+
+func (m MemberFieldChangeEntity) GetRoleIds() []uint {
+	var roleIds []uint
+	if err := json.Unmarshal([]byte(m.RoleIds), &roleIds); err != nil {
+		return []uint{}
+	}
+
+	return roleIds
+}
+
+// ToDiff 는 승인자가 확인할 변경 전후 값이다. 역할 변경은 추가/제거되는 역할도 함께 반환한다.
+func (m MemberFieldChangeEntity) ToDiff() dtos.MemberFieldChangeDiff {
+	diff := dtos.MemberFieldChangeDiff{
+		Id:        m.ID,
+		MemberId:  m.MemberId,
+		Field:     m.Field,
+		OldValue:  m.OldValue,
+		NewValue:  m.NewValue,
+		Status:    m.Status,
+		ExpiresAt: m.ExpiresAt,
+	}
+
+	if m.Field != constants.MemberFieldRoles {
+		return diff
+	}
+
+	oldRoleNames, newRoleNames := decodeRoleNames(m.OldValue), decodeRoleNames(m.NewValue)
+	diff.AddedRoles = subtractRoleNames(newRoleNames, oldRoleNames)
+	diff.RemovedRoles = subtractRoleNames(oldRoleNames, newRoleNames)
+	return diff
+}
+
+func (m MemberFieldChangeEntity) ToInformation() dtos.MemberFieldChangeInformation {
+	return dtos.MemberFieldChangeInformation{
+		Id:          m.ID,
+		MemberId:    m.MemberId,
+		Field:       m.Field,
+		OldValue:    m.OldValue,
+		NewValue:    m.NewValue,
+		Status:      m.Status,
+		RequestedBy: m.RequestedBy,
+		ExpiresAt:   m.ExpiresAt,
+		CompletedAt: m.CompletedAt,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func decodeRoleNames(value string) []string {
+	var roleNames []string
+	if err := json.Unmarshal([]byte(value), &roleNames); err != nil {
+		return []string{}
+	}
+
+	return roleNames
+}
+
+func subtractRoleNames(roleNames []string, excludes []string) []string {
+	excluded := map[string]bool{}
+	for _, roleName := range excludes {
+		excluded[roleName] = true
+	}
+
+	result := make([]string, 0)
+	for _, roleName := range roleNames {
+		if !excluded[roleName] {
+			result = append(result, roleName)
+		}
+	}
+
+	return result
+}
+
+func newMemberFieldChangeEntity(ctx context.Context, member MemberEntity, field, oldValue, newValue string, lifetime time.Duration) MemberFieldChangeEntity {
+	entity := MemberFieldChangeEntity{
+		MemberId:  member.ID,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		Status:    constants.MemberFieldChangeStatusPending,
+		ExpiresAt: time.Now().Add(lifetime),
+	}
+
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(ctx); err == nil {
+		entity.RequestedBy = userClaim.Id
+	}
+
+	return entity
+}
+
+// NewMemberFieldChangeEntity 는 아이디 또는 메일 변경이다. 아이디는 사이트 멤버만 변경할 수 있다.
+func NewMemberFieldChangeEntity(ctx context.Context, member MemberEntity, request dtos.MemberFieldChangeRequest, lifetime time.Duration) (MemberFieldChangeEntity, error) {
+	var oldValue string
+	switch request.Field {
+	case constants.MemberFieldSignId:
+		if member.Type != constants.TypeMemberSite {
+			return MemberFieldChangeEntity{}, &errors.ErrInvalidMemberFieldChange{Reason: "only site members can change sign id"}
+		}
+		oldValue = member.SignId
+	case constants.MemberFieldEmail:
+		if address, err := mail.ParseAddress(request.Value); err != nil || address.Address != request.Value {
+			return MemberFieldChangeEntity{}, &errors.ErrInvalidMemberFieldChange{Reason: "invalid email"}
+		}
+		oldValue = member.GetEmail()
+	default:
+		return MemberFieldChangeEntity{}, &errors.ErrInvalidMemberFieldChange{Reason: "unsupported field " + request.Field}
+	}
+
+	if oldValue == request.Value {
+		return MemberFieldChangeEntity{}, &errors.ErrInvalidMemberFieldChange{Reason: "value is not changed"}
+	}
+
+	return newMemberFieldChangeEntity(ctx, member, request.Field, oldValue, request.Value, lifetime), nil
+}
+
+// NewMemberRoleChangeEntity 는 최고 관리자의 역할 변경이다. 기존 역할을 덮어쓰는 역할 할당과 같다.
+func NewMemberRoleChangeEntity(ctx context.Context, member MemberEntity, roleEntities []rbacDomain.RoleEntity, lifetime time.Duration) (MemberFieldChangeEntity, error) {
+	roleIds := make([]uint, 0)
+	newRoleNames := make([]string, 0)
+	for _, roleEntity := range roleEntities {
+		roleIds = append(roleIds, roleEntity.ID)
+		newRoleNames = append(newRoleNames, roleEntity.Name)
+	}
+
+	roleIdsBytes, err := json.Marshal(roleIds)
+	if err != nil {
+		return MemberFieldChangeEntity{}, pkgerrors.Wrap(err, "member role change encode error")
+	}
+
+	oldValue, err := json.Marshal(member.GetRoleNames())
+	if err != nil {
+		return MemberFieldChangeEntity{}, pkgerrors.Wrap(err, "member role change encode error")
+	}
+
+	newValue, err := json.Marshal(newRoleNames)
+	if err != nil {
+		return MemberFieldChangeEntity{}, pkgerrors.Wrap(err, "member role change encode error")
+	}
+
+	entity := newMemberFieldChangeEntity(ctx, member, constants.MemberFieldRoles, string(oldValue), string(newValue), lifetime)
+	entity.RoleIds = string(roleIdsBytes)
+	return entity, nil
+}
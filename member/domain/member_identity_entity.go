@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// MemberIdentityEntity 는 멤버에 연결한 외부 인증 계정이다. 가입한 인증 방식이 아닌 계정으로 로그인해도 같은 멤버로 로그인한다.
+// Provider 는 두레이(dooray), 구글(google) 또는 외부 인증(custom:Authenticator 이름)이다.
+type MemberIdentityEntity struct {
+	gorm.Model
+	MemberId   uint   `gorm:"not null;index"`
+	Provider   string `gorm:"type:varchar(100);not null;uniqueIndex:idx_member_identity_external_id"`
+	ExternalId string `gorm:"type:varchar(100);not null;uniqueIndex:idx_member_identity_external_id"`
+	Email      string `gorm:"type:varchar(100)"`
+	LastUsedAt *time.Time
+}
+
+func (MemberIdentityEntity) TableName() string {
+	return "member_identities"
+}
+
+func NewMemberIdentityEntity(memberId uint, provider string, externalId string, email string) MemberIdentityEntity {
+	return MemberIdentityEntity{
+		MemberId:   memberId,
+		Provider:   provider,
+		ExternalId: externalId,
+		Email:      email,
+	}
+}
+
+// CustomIdentityProvider 는 Authenticator(name) 계정의 Provider 이다.
+func CustomIdentityProvider(name string) string {
+	return constants.TypeMemberCustom + ":" + name
+}
+
+// ParseCustomIdentityProvider 는 외부 인증 계정의 Provider 이면 Authenticator 이름을 반환한다.
+func ParseCustomIdentityProvider(provider string) (string, bool) {
+	if !strings.HasPrefix(provider, constants.TypeMemberCustom+":") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(provider, constants.TypeMemberCustom+":"), true
+}
+
+// MarkUsed 는 연결한 계정으로 로그인한 시간을 기록한다.
+func (e *MemberIdentityEntity) MarkUsed(now time.Time) {
+	e.LastUsedAt = &now
+}
+
+func (e MemberIdentityEntity) ToInformation() dtos.MemberIdentityInformation {
+	return dtos.MemberIdentityInformation{
+		Id:         e.ID,
+		Provider:   e.Provider,
+		ExternalId: e.ExternalId,
+		Email:      e.Email,
+		LinkedAt:   &e.CreatedAt,
+		LastUsedAt: e.LastUsedAt,
+	}
+}
+
+// GetPrimaryIdentity 는 멤버가 가입한 인증 방식의 계정이다. 가입한 계정은 연결을 해제할 수 없다.
+func (m MemberEntity) GetPrimaryIdentity() dtos.MemberIdentityInformation {
+	identity := dtos.MemberIdentityInformation{Provider: m.Type, Primary: true}
+
+	switch m.Type {
+	case constants.TypeMemberSite:
+		identity.ExternalId = m.SignId
+		identity.Email = m.Email
+	case constants.TypeMemberDooray:
+		identity.ExternalId = m.DoorayId
+		identity.Email = m.DoorayMail
+	case constants.TypeMemberGoogle:
+		identity.ExternalId = m.GoogleId
+		identity.Email = m.GoogleMail
+	case constants.TypeMemberCustom:
+		identity.Provider = CustomIdentityProvider(m.AuthenticatorName)
+		identity.ExternalId = m.ExternalId
+		identity.Email = m.ExternalMail
+	}
+
+	return identity
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+)
+
+// MemberPreferenceEntity 는 멤버의 Namespace 별 설정(JSON)이다.(예. 테이블 컬럼 배치, 테마, 알림 수신 거부)
+type MemberPreferenceEntity struct {
+	gorm.Model
+	MemberId  uint   `gorm:"not null;uniqueIndex:idx_member_preference"`
+	Namespace string `gorm:"type:varchar(50);not null;uniqueIndex:idx_member_preference"`
+	Value     string `gorm:"type:text"`
+}
+
+func (MemberPreferenceEntity) TableName() string {
+	return "member_preferences"
+}
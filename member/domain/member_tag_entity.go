@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"strings"
+)
+
+// MemberTagEntity 는 멤버에 자유롭게 붙이는 태그이다.
+type MemberTagEntity struct {
+	ID       uint   `gorm:"primarykey"`
+	MemberId uint   `gorm:"not null;uniqueIndex:idx_member_tag"`
+	Name     string `gorm:"type:varchar(50);not null;uniqueIndex:idx_member_tag;index"`
+}
+
+func (MemberTagEntity) TableName() string {
+	return "member_tags"
+}
+
+// NewMemberTagEntities 는 태그의 앞뒤 공백을 제거하고 중복과 빈 태그를 제외한다.
+func NewMemberTagEntities(memberId uint, names []string) []MemberTagEntity {
+	exists := make(map[string]bool)
+	tags := make([]MemberTagEntity, 0)
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if len(name) == 0 || exists[name] {
+			continue
+		}
+
+		exists[name] = true
+		tags = append(tags, MemberTagEntity{MemberId: memberId, Name: name})
+	}
+
+	return tags
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// SignIdChangeEntity 는 새 아이디(이메일) 확인을 기다리는 아이디 변경 요청이다.
+// 확인 전까지 멤버의 아이디는 바뀌지 않으며, 기간 내에 확인하지 않거나 기존 주소에서 취소하면 변경되지 않는다.
+type SignIdChangeEntity struct {
+	gorm.Model
+	MemberId         uint   `gorm:"not null;index"`
+	OldSignId        string `gorm:"type:varchar(50)"`
+	NewSignId        string `gorm:"type:varchar(50);not null"`
+	ConfirmTokenHash string `gorm:"type:varchar(64);index"`
+	CancelTokenHash  string `gorm:"type:varchar(64);index"`
+	Status           string `gorm:"type:varchar(20);not null"`
+	ExpiresAt        time.Time
+	CompletedAt      *time.Time
+}
+
+func (SignIdChangeEntity) TableName() string {
+	return "sign_id_changes"
+}
+
+func (s SignIdChangeEntity) IsPending() bool {
+	return s.Status == constants.SignIdChangeStatusPending
+}
+
+// Confirm 은 새 아이디 확인을 완료한다. 만료된 요청은 만료 처리되고 ErrExpired 를 반환한다.
+func (s *SignIdChangeEntity) Confirm() error {
+	if !s.IsPending() {
+		return errors.ErrNonChangeable
+	}
+
+	if s.ExpiresAt.Before(time.Now()) {
+		s.complete(constants.SignIdChangeStatusExpired)
+		return errors.ErrExpired
+	}
+
+	s.complete(constants.SignIdChangeStatusConfirmed)
+	return nil
+}
+
+func (s *SignIdChangeEntity) Cancel() error {
+	if !s.IsPending() {
+		return errors.ErrNonChangeable
+	}
+
+	s.complete(constants.SignIdChangeStatusCanceled)
+	return nil
+}
+
+func (s *SignIdChangeEntity) complete(status string) {
+	now := time.Now()
+	s.Status = status
+	s.CompletedAt = &now
+}
+
+func HashSignIdChangeToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func generateSignIdChangeToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", pkgerrors.Wrap(err, "generate sign id change token error")
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
+
+// NewSignIdChangeEntity 는 변경 요청과 함께 새 주소로 보낼 확인 토큰, 기존 주소로 보낼 취소 토큰을 반환한다.
+func NewSignIdChangeEntity(member MemberEntity, newSignId string, lifetime time.Duration) (entity SignIdChangeEntity, confirmToken string, cancelToken string, err error) {
+	if member.Type != constants.TypeMemberSite {
+		err = errors.ErrNonChangeable
+		return
+	}
+
+	if confirmToken, err = generateSignIdChangeToken(); err != nil {
+		return
+	}
+
+	if cancelToken, err = generateSignIdChangeToken(); err != nil {
+		return
+	}
+
+	entity = SignIdChangeEntity{
+		MemberId:         member.ID,
+		OldSignId:        member.SignId,
+		NewSignId:        newSignId,
+		ConfirmTokenHash: HashSignIdChangeToken(confirmToken),
+		CancelTokenHash:  HashSignIdChangeToken(cancelToken),
+		Status:           constants.SignIdChangeStatusPending,
+		ExpiresAt:        time.Now().Add(lifetime),
+	}
+
+	return
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MemberAssignmentRuleRepository struct {
+}
+
+func (MemberAssignmentRuleRepository) Create(ctx context.Context, entity *domain.MemberAssignmentRuleEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindAll 은 평가 순서(우선순위, 등록 순)대로 규칙을 조회한다.
+func (MemberAssignmentRuleRepository) FindAll(ctx context.Context) ([]domain.MemberAssignmentRuleEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberAssignmentRuleEntity, 0)
+	if err := db.Order("priority").Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (MemberAssignmentRuleRepository) FindById(ctx context.Context, id uint) (domain.MemberAssignmentRuleEntity, error) {
+	var entity domain.MemberAssignmentRuleEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberAssignmentRuleRepository) Save(ctx context.Context, entity *domain.MemberAssignmentRuleEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberAssignmentRuleRepository) Delete(ctx context.Context, entity domain.MemberAssignmentRuleEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
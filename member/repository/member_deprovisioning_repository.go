@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type MemberDeprovisioningRepository struct {
+}
+
+func (MemberDeprovisioningRepository) Create(ctx context.Context, entity *domain.MemberDeprovisioningEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberDeprovisioningRepository) Save(ctx context.Context, entity *domain.MemberDeprovisioningEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberDeprovisioningRepository) FindByMemberId(ctx context.Context, memberId uint) ([]domain.MemberDeprovisioningEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberDeprovisioningEntity, 0)
+	if err := db.Where("member_id = ?", memberId).Order("id DESC").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
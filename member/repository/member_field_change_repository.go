@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type MemberFieldChangeRepository struct {
+}
+
+func (MemberFieldChangeRepository) Create(ctx context.Context, entity *domain.MemberFieldChangeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberFieldChangeRepository) Save(ctx context.Context, entity *domain.MemberFieldChangeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberFieldChangeRepository) FindById(ctx context.Context, id uint) (domain.MemberFieldChangeEntity, error) {
+	var entity domain.MemberFieldChangeEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindByMemberId 는 멤버의 중요 필드 변경을 최근 순으로 조회한다.
+func (MemberFieldChangeRepository) FindByMemberId(ctx context.Context, memberId uint) ([]domain.MemberFieldChangeEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberFieldChangeEntity, 0)
+	if err := db.Where("member_id = ?", memberId).
+		Order("created_at desc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (MemberFieldChangeRepository) FindPendingByMemberIdAndField(ctx context.Context, memberId uint, field string) ([]domain.MemberFieldChangeEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberFieldChangeEntity, 0)
+	if err := db.Where("member_id = ? AND field = ? AND status = ?", memberId, field, constants.MemberFieldChangeStatusPending).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (MemberFieldChangeRepository) FindExpiredPending(ctx context.Context, now time.Time) ([]domain.MemberFieldChangeEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberFieldChangeEntity, 0)
+	if err := db.Where("status = ? AND expires_at < ?", constants.MemberFieldChangeStatusPending, now).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MemberIdentityRepository struct {
+}
+
+func (MemberIdentityRepository) Create(ctx context.Context, entity *domain.MemberIdentityEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberIdentityRepository) Save(ctx context.Context, entity *domain.MemberIdentityEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberIdentityRepository) FindById(ctx context.Context, id uint) (domain.MemberIdentityEntity, error) {
+	return findMemberIdentity(ctx, "id = ?", id)
+}
+
+func (MemberIdentityRepository) FindByProviderAndExternalId(ctx context.Context, provider string, externalId string) (domain.MemberIdentityEntity, error) {
+	return findMemberIdentity(ctx, "provider = ? AND external_id = ?", provider, externalId)
+}
+
+func (MemberIdentityRepository) FindByMemberId(ctx context.Context, memberId uint) ([]domain.MemberIdentityEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberIdentityEntity, 0)
+	if err := db.Where("member_id = ?", memberId).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// Delete 는 연결을 해제한 계정을 다시 연결할 수 있도록 행을 지운다.
+func (MemberIdentityRepository) Delete(ctx context.Context, entity domain.MemberIdentityEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Unscoped().Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func findMemberIdentity(ctx context.Context, query string, args ...interface{}) (domain.MemberIdentityEntity, error) {
+	var entity domain.MemberIdentityEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Where(query, args...).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm/clause"
+)
+
+type MemberPreferenceRepository struct {
+}
+
+func (MemberPreferenceRepository) FindByMemberId(ctx context.Context, memberId uint) ([]domain.MemberPreferenceEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberPreferenceEntity, 0)
+	if err := db.Where(&domain.MemberPreferenceEntity{MemberId: memberId}).
+		Order("namespace").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// Upsert 는 Namespace 의 설정이 있으면 값을 바꾸고, 없으면 추가한다.
+func (MemberPreferenceRepository) Upsert(ctx context.Context, entity *domain.MemberPreferenceEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "member_id"}, {Name: "namespace"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// DeleteByNamespace 는 설정을 지운다. 같은 Namespace 를 다시 저장할 수 있도록 완전히 삭제한다.
+func (MemberPreferenceRepository) DeleteByNamespace(ctx context.Context, memberId uint, namespace string) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Unscoped().Where(&domain.MemberPreferenceEntity{MemberId: memberId, Namespace: namespace}).
+		Delete(&domain.MemberPreferenceEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
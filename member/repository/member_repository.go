@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
@@ -96,6 +97,11 @@ func (MemberRepository) FindAll(ctx context.Context, filters map[string]interfac
 				db.Where("type IN ?", value)
 			}
 
+			if key == "tags" {
+				db.Where("members.id IN (?)", helpers.ContextHelper().GetDB(ctx).
+					Model(&domain.MemberTagEntity{}).Select("member_id").Where("name IN ?", value))
+			}
+
 			if key == "roleIds" {
 				// member_roles 테이블을 조인하여 members 테이블 조회 시 필터링 한다.
 				db.Joins("INNER JOIN member_roles ON member_roles.member_entity_id = members.id").
@@ -130,6 +136,70 @@ func (MemberRepository) Save(ctx context.Context, entity *domain.MemberEntity) e
 	return nil
 }
 
+// AddRole 은 멤버들의 기존 역할은 그대로 두고 역할을 추가한다. 이미 역할이 있는 멤버는 호출하는 쪽에서 제외한다.
+func (MemberRepository) AddRole(ctx context.Context, roleId uint, memberIds []uint, updatedBy uint) error {
+	if len(memberIds) == 0 {
+		return nil
+	}
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	memberRoles := make([]map[string]interface{}, 0, len(memberIds))
+	for _, memberId := range memberIds {
+		memberRoles = append(memberRoles, map[string]interface{}{
+			"member_entity_id": memberId,
+			"role_entity_id":   roleId,
+		})
+	}
+
+	if err := db.Table("member_roles").Create(&memberRoles).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Model(&domain.MemberEntity{}).Where("id IN ?", memberIds).Update("updated_by", updatedBy).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// RemoveRole 은 멤버들에게서 역할만 제거한다.
+func (MemberRepository) RemoveRole(ctx context.Context, roleId uint, memberIds []uint, updatedBy uint) error {
+	if len(memberIds) == 0 {
+		return nil
+	}
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Exec("DELETE FROM member_roles WHERE role_entity_id = ? AND member_entity_id IN ?", roleId, memberIds).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Model(&domain.MemberEntity{}).Where("id IN ?", memberIds).Update("updated_by", updatedBy).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberRepository) FindByExternalId(ctx context.Context, authenticatorName string, externalId string) (domain.MemberEntity, error) {
+	var memberEntity domain.MemberEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberEntity{AuthenticatorName: authenticatorName, ExternalId: externalId}).
+		Preload("Roles.Permissions").Preload(clause.Associations).
+		First(&memberEntity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return memberEntity, errors.ErrNotFound
+		}
+
+		return memberEntity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return memberEntity, nil
+}
+
 func (MemberRepository) FindByGoogleId(ctx context.Context, googleId string) (domain.MemberEntity, error) {
 	var memberEntity domain.MemberEntity
 
@@ -161,3 +231,118 @@ func (MemberRepository) Delete(ctx context.Context, entity domain.MemberEntity)
 
 	return nil
 }
+
+// FindByRoleName 은 역할이 직접 할당된 멤버를 조회한다.(조직을 통해 할당된 역할은 포함하지 않는다.)
+func (MemberRepository) FindByRoleName(ctx context.Context, roleName string) ([]domain.MemberEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberEntity, 0)
+	if err := db.Joins("INNER JOIN member_roles ON member_roles.member_entity_id = members.id").
+		Joins("INNER JOIN roles ON roles.id = member_roles.role_entity_id").
+		Where("roles.name = ? AND roles.deleted_at IS NULL", roleName).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindByIdsWithDeleted 는 거절(삭제)된 멤버도 함께 조회한다.
+func (MemberRepository) FindByIdsWithDeleted(ctx context.Context, ids []uint) ([]domain.MemberEntity, error) {
+	var entities = make([]domain.MemberEntity, 0)
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Unscoped().
+		Where("id IN ?", ids).
+		Order("id asc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (MemberRepository) FindByStatus(ctx context.Context, status string) ([]domain.MemberEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberEntity, 0)
+	if err := db.Where(&domain.MemberEntity{Status: status}).
+		Order("created_at asc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// UpdatePassword 는 멤버의 비밀번호(해시)만 바꾼다.
+func (MemberRepository) UpdatePassword(ctx context.Context, memberId uint, password string) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Model(&domain.MemberEntity{}).Where("id = ?", memberId).Update("password", password).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindPasswordHashes 는 비밀번호로 로그인하는 멤버들의 비밀번호 해시이다.
+func (MemberRepository) FindPasswordHashes(ctx context.Context) ([]string, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var passwords = make([]string, 0)
+	if err := db.Model(&domain.MemberEntity{}).
+		Where("type = ? AND password IS NOT NULL AND password <> ''", constants.TypeMemberSite).
+		Pluck("password", &passwords).Error; err != nil {
+		return passwords, pkgerrors.Wrap(err, "db error")
+	}
+
+	return passwords, nil
+}
+
+// ReplaceTags 는 멤버의 태그를 모두 지우고 새 태그로 바꾼다.
+func (MemberRepository) ReplaceTags(ctx context.Context, memberId uint, tags []domain.MemberTagEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberTagEntity{MemberId: memberId}).Delete(&domain.MemberTagEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if err := db.Create(&tags).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberRepository) FindAllTagNames(ctx context.Context) ([]string, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var tagNames = make([]string, 0)
+	if err := db.Model(&domain.MemberTagEntity{}).
+		Joins("INNER JOIN members ON members.id = member_tags.member_id AND members.deleted_at IS NULL").
+		Distinct("member_tags.name").Order("member_tags.name").
+		Pluck("member_tags.name", &tagNames).Error; err != nil {
+		return tagNames, pkgerrors.Wrap(err, "db error")
+	}
+
+	return tagNames, nil
+}
+
+// CountNotDeprovisioned 는 해지하지 않은 멤버(승인 대기 포함) 수를 조회한다.
+func (MemberRepository) CountNotDeprovisioned(ctx context.Context) (int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var count int64
+	if err := db.Model(&domain.MemberEntity{}).Where("status <> ?", constants.StatusMemberDeprovisioned).Count(&count).Error; err != nil {
+		return 0, pkgerrors.Wrap(err, "db error")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type SignIdChangeRepository struct {
+}
+
+func (SignIdChangeRepository) Create(ctx context.Context, entity *domain.SignIdChangeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SignIdChangeRepository) Save(ctx context.Context, entity *domain.SignIdChangeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SignIdChangeRepository) FindByConfirmTokenHash(ctx context.Context, tokenHash string) (domain.SignIdChangeEntity, error) {
+	return findSignIdChange(ctx, "confirm_token_hash = ?", tokenHash)
+}
+
+func (SignIdChangeRepository) FindByCancelTokenHash(ctx context.Context, tokenHash string) (domain.SignIdChangeEntity, error) {
+	return findSignIdChange(ctx, "cancel_token_hash = ?", tokenHash)
+}
+
+func (SignIdChangeRepository) FindPendingByMemberId(ctx context.Context, memberId uint) ([]domain.SignIdChangeEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.SignIdChangeEntity, 0)
+	if err := db.Where("member_id = ? AND status = ?", memberId, constants.SignIdChangeStatusPending).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func findSignIdChange(ctx context.Context, query string, tokenHash string) (domain.SignIdChangeEntity, error) {
+	var entity domain.SignIdChangeEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(query, tokenHash).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"better-admin-backend-service/errors"
+	"bytes"
+	"fmt"
+	"gorm.io/gorm"
+	"text/template"
+	"text/template/parse"
+)
+
+// MessageTemplateEntity 는 관리자가 바꾼 메일(알림) 템플릿이다. 없으면 내장 템플릿을 사용한다.
+type MessageTemplateEntity struct {
+	gorm.Model
+	Name      string `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Subject   string `gorm:"type:varchar(200);not null"`
+	Body      string `gorm:"type:text;not null"`
+	UpdatedBy uint
+}
+
+func (MessageTemplateEntity) TableName() string {
+	return "message_templates"
+}
+
+func (m *MessageTemplateEntity) Change(subject string, body string, changedBy uint) {
+	m.Subject = subject
+	m.Body = body
+	m.UpdatedBy = changedBy
+}
+
+// RenderTemplate 는 템플릿을 data 로 렌더링한다.
+// 템플릿은 변수 출력({{.name}})과 조건({{if .name}}...{{else}}...{{end}})만 사용할 수 있고, 변수는 data 에 있는 이름만 사용할 수 있다.
+func RenderTemplate(text string, data map[string]string) (string, error) {
+	tmpl, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", &errors.ErrInvalidMessageTemplate{Reason: err.Error()}
+	}
+
+	// define, block 으로 다른 템플릿을 만들거나 부를 수 없다.
+	if len(tmpl.Templates()) > 1 {
+		return "", &errors.ErrInvalidMessageTemplate{Reason: "define is not supported"}
+	}
+
+	if tmpl.Tree != nil {
+		if err := validateTemplateNode(tmpl.Tree.Root, data); err != nil {
+			return "", err
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, data); err != nil {
+		return "", &errors.ErrInvalidMessageTemplate{Reason: err.Error()}
+	}
+
+	return buffer.String(), nil
+}
+
+func validateTemplateNode(node parse.Node, data map[string]string) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := validateTemplateNode(child, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.TextNode:
+		return nil
+	case *parse.ActionNode:
+		return validateTemplatePipe(n.Pipe, data)
+	case *parse.IfNode:
+		if err := validateTemplatePipe(n.Pipe, data); err != nil {
+			return err
+		}
+		if err := validateTemplateNode(n.List, data); err != nil {
+			return err
+		}
+		return validateTemplateNode(n.ElseList, data)
+	default:
+		return &errors.ErrInvalidMessageTemplate{Reason: fmt.Sprintf("unsupported syntax %s", node.String())}
+	}
+}
+
+// validateTemplatePipe 는 파이프라인이 변수 하나({{.name}})인지 확인한다. 함수 호출, 변수 선언은 사용할 수 없다.
+func validateTemplatePipe(pipe *parse.PipeNode, data map[string]string) error {
+	if pipe == nil || len(pipe.Decl) > 0 || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return &errors.ErrInvalidMessageTemplate{Reason: fmt.Sprintf("unsupported syntax {{%v}}", pipe)}
+	}
+
+	field, ok := pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok || len(field.Ident) != 1 {
+		return &errors.ErrInvalidMessageTemplate{Reason: fmt.Sprintf("unsupported syntax {{%v}}", pipe)}
+	}
+
+	if _, exists := data[field.Ident[0]]; !exists {
+		return &errors.ErrInvalidMessageTemplate{Reason: fmt.Sprintf("unknown variable %s", field.Ident[0])}
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/messagetemplate/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MessageTemplateRepository struct {
+}
+
+func (MessageTemplateRepository) FindAll(ctx context.Context) ([]domain.MessageTemplateEntity, error) {
+	var entities = make([]domain.MessageTemplateEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).Order("name").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (MessageTemplateRepository) FindByName(ctx context.Context, name string) (domain.MessageTemplateEntity, error) {
+	var entity domain.MessageTemplateEntity
+
+	if err := helpers.ContextHelper().GetDB(ctx).Where("name = ?", name).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MessageTemplateRepository) Save(ctx context.Context, entity *domain.MessageTemplateEntity) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// Delete 는 템플릿을 완전히 지운다. 같은 이름(uniqueIndex)으로 다시 저장할 수 있어야 한다.
+func (MessageTemplateRepository) Delete(ctx context.Context, entity domain.MessageTemplateEntity) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Unscoped().Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"better-admin-backend-service/errors"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NoteEntity 는 멤버, 결재 요청 등 대상(EntityType, EntityId)에 남기는 관리자 메모이다.
+// 답글은 최상위 메모(ParentId)에만 단다.
+type NoteEntity struct {
+	gorm.Model
+	EntityType string `gorm:"type:varchar(50);not null;index:idx_note_entity"`
+	EntityId   uint   `gorm:"not null;index:idx_note_entity"`
+	ParentId   uint   `gorm:"index"`
+	Content    string `gorm:"type:text;not null"`
+	// Mentions 는 메모에서 언급(@signId)한 멤버 Id 목록(콤마 구분)이다.
+	Mentions  string `gorm:"type:varchar(1000)"`
+	CreatedBy uint
+	UpdatedBy uint
+	EditedAt  *time.Time
+}
+
+func (NoteEntity) TableName() string {
+	return "notes"
+}
+
+func NewNoteEntity(entityType string, entityId uint, parentId uint, content string, mentions []uint, createdBy uint) NoteEntity {
+	return NoteEntity{
+		EntityType: entityType,
+		EntityId:   entityId,
+		ParentId:   parentId,
+		Content:    content,
+		Mentions:   joinMentions(mentions),
+		CreatedBy:  createdBy,
+		UpdatedBy:  createdBy,
+	}
+}
+
+func (n NoteEntity) GetMentions() []uint {
+	mentions := make([]uint, 0)
+	if len(n.Mentions) == 0 {
+		return mentions
+	}
+
+	for _, value := range strings.Split(n.Mentions, ",") {
+		if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+			mentions = append(mentions, uint(id))
+		}
+	}
+
+	return mentions
+}
+
+// Edit 은 작성자만 할 수 있다.
+func (n *NoteEntity) Edit(content string, mentions []uint, editedBy uint) error {
+	if n.CreatedBy != editedBy {
+		return errors.ErrForbidden
+	}
+
+	now := time.Now()
+	n.Content = content
+	n.Mentions = joinMentions(mentions)
+	n.UpdatedBy = editedBy
+	n.EditedAt = &now
+	return nil
+}
+
+func (n NoteEntity) IsReply() bool {
+	return n.ParentId > 0
+}
+
+func joinMentions(mentions []uint) string {
+	values := make([]string, 0, len(mentions))
+	for _, mention := range mentions {
+		values = append(values, strconv.FormatUint(uint64(mention), 10))
+	}
+
+	return strings.Join(values, ",")
+}
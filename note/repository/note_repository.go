@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/note/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type NoteRepository struct {
+}
+
+func (NoteRepository) Create(ctx context.Context, entity *domain.NoteEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (NoteRepository) Save(ctx context.Context, entity *domain.NoteEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (NoteRepository) FindById(ctx context.Context, id uint) (domain.NoteEntity, error) {
+	var entity domain.NoteEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindRootNotes 는 대상의 최상위 메모를 최근 순으로 조회한다.
+func (NoteRepository) FindRootNotes(ctx context.Context, entityType string, entityId uint, pageable dtos.Pageable) ([]domain.NoteEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.NoteEntity{}).
+		Where("entity_type = ? AND entity_id = ? AND parent_id = 0", entityType, entityId)
+
+	var entities = make([]domain.NoteEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id desc").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+// FindByParentIds 는 최상위 메모들의 답글을 작성 순으로 조회한다.
+func (NoteRepository) FindByParentIds(ctx context.Context, parentIds []uint) ([]domain.NoteEntity, error) {
+	var entities = make([]domain.NoteEntity, 0)
+	if len(parentIds) == 0 {
+		return entities, nil
+	}
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Where("parent_id IN ?", parentIds).
+		Order("id").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// Delete 는 메모와 답글을 함께 삭제한다.
+func (NoteRepository) Delete(ctx context.Context, entity domain.NoteEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("id = ? OR parent_id = ?", entity.ID, entity.ID).Delete(&domain.NoteEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
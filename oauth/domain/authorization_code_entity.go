@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// OAuthAuthorizationCodeEntity 는 멤버가 Client 에 발급한 인가 코드이다.
+// 코드는 해시(CodeHash)로만 저장하며 한 번 교환하면 UsedAt 을 기록하여 다시 사용할 수 없다.
+type OAuthAuthorizationCodeEntity struct {
+	gorm.Model
+	CodeHash      string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	OAuthClientId uint   `gorm:"column:oauth_client_id;not null;index"`
+	MemberId      uint   `gorm:"not null"`
+	RedirectUri   string `gorm:"type:varchar(1000)"`
+	Scopes        string `gorm:"type:text"`
+	// CodeChallenge 와 CodeChallengeMethod 는 PKCE(RFC 7636) 로 토큰 교환 시 code_verifier 를 확인하는 값이다.
+	CodeChallenge       string `gorm:"type:varchar(128)"`
+	CodeChallengeMethod string `gorm:"type:varchar(10)"`
+	ExpiresAt           time.Time
+	UsedAt              *time.Time
+}
+
+func (OAuthAuthorizationCodeEntity) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+func (c OAuthAuthorizationCodeEntity) GetScopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+// IsUsable 은 만료되지 않았고 아직 교환하지 않은 코드인지 확인한다.
+func (c OAuthAuthorizationCodeEntity) IsUsable(now time.Time) bool {
+	return c.UsedAt == nil && now.Before(c.ExpiresAt)
+}
+
+// VerifyCodeVerifier 는 토큰 교환 요청의 code_verifier 가 인가 요청의 code_challenge 와 맞는지 확인한다.(RFC 7636 4.6)
+// PKCE 없이 발급한 코드에 code_verifier 를 보내는 경우도 거부한다.
+func (c OAuthAuthorizationCodeEntity) VerifyCodeVerifier(codeVerifier string) bool {
+	if len(c.CodeChallenge) == 0 {
+		return len(codeVerifier) == 0
+	}
+
+	if !codeChallengePattern.MatchString(codeVerifier) {
+		return false
+	}
+
+	expected := codeVerifier
+	if c.CodeChallengeMethod == constants.OAuthCodeChallengeMethodS256 {
+		hashed := sha256.Sum256([]byte(codeVerifier))
+		expected = base64.RawURLEncoding.EncodeToString(hashed[:])
+	}
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(c.CodeChallenge)) == 1
+}
+
+func (c *OAuthAuthorizationCodeEntity) MarkUsed(now time.Time) {
+	c.UsedAt = &now
+}
+
+// HashAuthorizationCode 는 저장하거나 조회할 때 사용할 인가 코드의 해시이다.
+func HashAuthorizationCode(code string) string {
+	hashed := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(hashed[:])
+}
+
+// NewOAuthAuthorizationCodeEntity 는 인가 코드를 만들고 저장할 엔티티와 Client 에 전달할 코드를 반환한다.
+func NewOAuthAuthorizationCodeEntity(client OAuthClientEntity, memberId uint, redirectUri string, scopes []string,
+	codeChallenge string, codeChallengeMethod string, lifetime time.Duration) (OAuthAuthorizationCodeEntity, string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return OAuthAuthorizationCodeEntity{}, "", pkgerrors.Wrap(err, "generate authorization code error")
+	}
+	code := base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	return OAuthAuthorizationCodeEntity{
+		CodeHash:            HashAuthorizationCode(code),
+		OAuthClientId:       client.ID,
+		MemberId:            memberId,
+		RedirectUri:         redirectUri,
+		Scopes:              strings.Join(scopes, " "),
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: codeChallengeMethod,
+		ExpiresAt:           time.Now().Add(lifetime),
+	}, code, nil
+}
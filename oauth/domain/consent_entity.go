@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"strings"
+)
+
+// MemberConsentEntity 는 멤버가 OAuth Client 에 동의한 scope 이다.
+// 동의를 철회하면 삭제(Soft Delete)한다.
+type MemberConsentEntity struct {
+	gorm.Model
+	MemberId      uint              `gorm:"not null;index"`
+	OAuthClientId uint              `gorm:"column:oauth_client_id;not null;index"`
+	OAuthClient   OAuthClientEntity `gorm:"foreignKey:OAuthClientId"`
+	Scopes        string            `gorm:"type:text"`
+}
+
+func (MemberConsentEntity) TableName() string {
+	return "member_consents"
+}
+
+func (c MemberConsentEntity) GetScopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+// Covers 는 요청한 scope 를 모두 이미 동의했는지 확인한다.
+func (c MemberConsentEntity) Covers(requestedScopes []string) bool {
+	granted := make(map[string]bool)
+	for _, scope := range c.GetScopes() {
+		granted[scope] = true
+	}
+
+	for _, scope := range requestedScopes {
+		if !granted[scope] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Grant 는 기존에 동의한 scope 에 새로 동의한 scope 를 더한다.
+func (c *MemberConsentEntity) Grant(scopes []string) {
+	merged := c.GetScopes()
+	for _, scope := range scopes {
+		if !c.Covers([]string{scope}) {
+			merged = append(merged, scope)
+			c.Scopes = strings.Join(merged, " ")
+		}
+	}
+}
+
+func NewMemberConsentEntity(memberId uint, oauthClientId uint, scopes []string) MemberConsentEntity {
+	return MemberConsentEntity{
+		MemberId:      memberId,
+		OAuthClientId: oauthClientId,
+		Scopes:        strings.Join(scopes, " "),
+	}
+}
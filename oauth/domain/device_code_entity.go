@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"crypto/rand"
+	"encoding/base64"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// userCodeCharacters 는 멤버가 직접 입력하는 사용자 코드에 쓰는 문자로 헷갈리기 쉬운 모음과 숫자는 뺐다.(RFC 8628 6.1)
+const userCodeCharacters = "BCDFGHJKLMNPQRSTVWXZ"
+
+// slowDownIntervalSeconds 는 너무 자주 확인한 Client 의 확인 간격에 더하는 시간이다.(RFC 8628 3.5)
+const slowDownIntervalSeconds = 5
+
+// OAuthDeviceCodeEntity 는 CLI 도구 같은 입력 제한 기기가 요청한 기기 인가(RFC 8628) 이다.
+// 기기 코드는 해시(DeviceCodeHash)로만 저장하며 멤버는 관리 화면에서 사용자 코드(UserCode)로 승인하거나 거부한다.
+type OAuthDeviceCodeEntity struct {
+	gorm.Model
+	DeviceCodeHash  string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	UserCode        string `gorm:"type:varchar(9);not null;uniqueIndex"`
+	OAuthClientId   uint   `gorm:"column:oauth_client_id;not null;index"`
+	Scopes          string `gorm:"type:text"`
+	Status          string `gorm:"type:varchar(20);not null"`
+	MemberId        uint
+	IntervalSeconds int
+	ExpiresAt       time.Time
+	LastPolledAt    *time.Time
+	UsedAt          *time.Time
+}
+
+func (OAuthDeviceCodeEntity) TableName() string {
+	return "oauth_device_codes"
+}
+
+func (c OAuthDeviceCodeEntity) GetScopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+func (c OAuthDeviceCodeEntity) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// IsPending 은 멤버가 아직 승인하거나 거부하지 않은 유효한 요청인지 확인한다.
+func (c OAuthDeviceCodeEntity) IsPending(now time.Time) bool {
+	return c.Status == constants.OAuthDeviceCodeStatusPending && !c.IsExpired(now)
+}
+
+func (c *OAuthDeviceCodeEntity) Approve(memberId uint) {
+	c.Status = constants.OAuthDeviceCodeStatusApproved
+	c.MemberId = memberId
+}
+
+func (c *OAuthDeviceCodeEntity) Deny(memberId uint) {
+	c.Status = constants.OAuthDeviceCodeStatusDenied
+	c.MemberId = memberId
+}
+
+// Poll 은 Client 의 토큰 요청(RFC 8628 3.5)을 기록하고 아직 토큰을 발급할 수 없으면 그 이유를 반환한다.
+// 승인을 기다리는 동안 간격(IntervalSeconds)보다 자주 확인하면 간격을 늘리고 ErrSlowDown 을 반환한다.
+func (c *OAuthDeviceCodeEntity) Poll(now time.Time) error {
+	if c.UsedAt != nil {
+		return errors.ErrInvalidGrant
+	}
+
+	if c.IsExpired(now) {
+		return errors.ErrExpired
+	}
+
+	lastPolledAt := c.LastPolledAt
+	c.LastPolledAt = &now
+	if c.Status == constants.OAuthDeviceCodeStatusDenied {
+		return errors.ErrAccessDenied
+	}
+
+	if c.Status == constants.OAuthDeviceCodeStatusApproved {
+		return nil
+	}
+
+	if lastPolledAt != nil && now.Sub(*lastPolledAt) < time.Duration(c.IntervalSeconds)*time.Second {
+		c.IntervalSeconds += slowDownIntervalSeconds
+		return errors.ErrSlowDown
+	}
+
+	return errors.ErrAuthorizationPending
+}
+
+func (c *OAuthDeviceCodeEntity) MarkUsed(now time.Time) {
+	c.UsedAt = &now
+}
+
+// NormalizeUserCode 는 멤버가 입력한 사용자 코드를 대문자로 바꾸고 구분자(-)와 공백을 정리하여 저장한 형식(XXXX-XXXX)으로 맞춘다.
+func NormalizeUserCode(userCode string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(userCode))
+	if len(normalized) != 8 {
+		return normalized
+	}
+
+	return normalized[:4] + "-" + normalized[4:]
+}
+
+// NewOAuthDeviceCodeEntity 는 기기 인가 요청을 만들고 저장할 엔티티와 Client 에 전달할 기기 코드를 반환한다.
+func NewOAuthDeviceCodeEntity(client OAuthClientEntity, scopes []string, lifetime time.Duration, intervalSeconds int) (OAuthDeviceCodeEntity, string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return OAuthDeviceCodeEntity{}, "", pkgerrors.Wrap(err, "generate device code error")
+	}
+	deviceCode := base64.RawURLEncoding.EncodeToString(randomBytes)
+
+	userCode := make([]byte, 8)
+	for i := range userCode {
+		index, err := rand.Int(rand.Reader, big.NewInt(int64(len(userCodeCharacters))))
+		if err != nil {
+			return OAuthDeviceCodeEntity{}, "", pkgerrors.Wrap(err, "generate user code error")
+		}
+		userCode[i] = userCodeCharacters[index.Int64()]
+	}
+
+	return OAuthDeviceCodeEntity{
+		DeviceCodeHash:  HashAuthorizationCode(deviceCode),
+		UserCode:        NormalizeUserCode(string(userCode)),
+		OAuthClientId:   client.ID,
+		Scopes:          strings.Join(scopes, " "),
+		Status:          constants.OAuthDeviceCodeStatusPending,
+		IntervalSeconds: intervalSeconds,
+		ExpiresAt:       time.Now().Add(lifetime),
+	}, deviceCode, nil
+}
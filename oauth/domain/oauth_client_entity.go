@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"regexp"
+	"strings"
+)
+
+// codeChallengePattern 은 RFC 7636 4.1, 4.2 의 code_verifier, code_challenge 형식(43~128 자의 unreserved 문자)이다.
+var codeChallengePattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
+// OAuthClientEntity 는 멤버를 대신하여 API 를 호출하는 외부 애플리케이션(OAuth/OIDC Client) 이다.
+type OAuthClientEntity struct {
+	gorm.Model
+	ClientId     string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Name         string `gorm:"type:varchar(100);not null"`
+	Description  string `gorm:"type:varchar(1000)"`
+	RedirectUris string `gorm:"type:text"`
+	// Scopes 는 Client 가 요청할 수 있는 scope 목록(공백 구분)이다.
+	Scopes string `gorm:"type:text"`
+	// Trusted 는 자사(First-party) Client 여부로 멤버 동의 없이 사용할 수 있다.
+	Trusted bool
+	// PkcePolicy 는 인가 요청에 PKCE 를 요구하는 정책이다. 비어 있으면 S256 을 요구한다.
+	PkcePolicy string `gorm:"type:varchar(20)"`
+	CreatedBy  uint
+	UpdatedBy  uint
+}
+
+func (OAuthClientEntity) TableName() string {
+	return "oauth_clients"
+}
+
+func (c OAuthClientEntity) GetScopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+func (c OAuthClientEntity) GetRedirectUris() []string {
+	return strings.Fields(c.RedirectUris)
+}
+
+// ValidateScopes 는 요청한 scope 가 Client 에 등록된 scope 인지 확인한다.
+func (c OAuthClientEntity) ValidateScopes(requestedScopes []string) error {
+	registered := make(map[string]bool)
+	for _, scope := range c.GetScopes() {
+		registered[scope] = true
+	}
+
+	for _, scope := range requestedScopes {
+		if !registered[scope] {
+			return errors.ErrInvalidScope
+		}
+	}
+
+	return nil
+}
+
+func (c OAuthClientEntity) GetPkcePolicy() string {
+	if len(c.PkcePolicy) == 0 {
+		return constants.OAuthPkcePolicyS256
+	}
+
+	return c.PkcePolicy
+}
+
+// ValidateRedirectUri 는 요청한 redirect_uri 가 Client 에 등록된 주소와 같은지 확인한다.
+func (c OAuthClientEntity) ValidateRedirectUri(redirectUri string) error {
+	for _, registered := range c.GetRedirectUris() {
+		if registered == redirectUri {
+			return nil
+		}
+	}
+
+	return &errors.ErrInvalidAuthorizationRequest{Reason: "redirect_uri is not registered"}
+}
+
+// ValidateCodeChallenge 는 인가 요청의 code_challenge 가 Client 의 PKCE 정책에 맞는지 확인하고 사용할 변환 방법을 반환한다.
+// 방법을 지정하지 않으면 RFC 7636 4.3 에 따라 plain 이다.
+func (c OAuthClientEntity) ValidateCodeChallenge(codeChallenge string, codeChallengeMethod string) (string, error) {
+	if len(codeChallenge) == 0 {
+		if len(codeChallengeMethod) > 0 {
+			return "", &errors.ErrInvalidAuthorizationRequest{Reason: "code_challenge is required"}
+		}
+
+		if c.GetPkcePolicy() != constants.OAuthPkcePolicyOptional {
+			return "", &errors.ErrInvalidAuthorizationRequest{Reason: "code_challenge is required"}
+		}
+
+		return "", nil
+	}
+
+	if len(codeChallengeMethod) == 0 {
+		codeChallengeMethod = constants.OAuthCodeChallengeMethodPlain
+	}
+
+	if codeChallengeMethod != constants.OAuthCodeChallengeMethodS256 && codeChallengeMethod != constants.OAuthCodeChallengeMethodPlain {
+		return "", &errors.ErrInvalidAuthorizationRequest{Reason: "code_challenge_method is not supported"}
+	}
+
+	if codeChallengeMethod == constants.OAuthCodeChallengeMethodPlain && c.GetPkcePolicy() == constants.OAuthPkcePolicyS256 {
+		return "", &errors.ErrInvalidAuthorizationRequest{Reason: "code_challenge_method must be S256"}
+	}
+
+	if !codeChallengePattern.MatchString(codeChallenge) {
+		return "", &errors.ErrInvalidAuthorizationRequest{Reason: "code_challenge is invalid"}
+	}
+
+	return codeChallengeMethod, nil
+}
+
+func (c *OAuthClientEntity) Update(ctx context.Context, information dtos.OAuthClientInformation) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.Name = information.Name
+	c.Description = information.Description
+	c.RedirectUris = strings.Join(information.RedirectUris, " ")
+	c.Scopes = strings.Join(information.Scopes, " ")
+	c.Trusted = information.Trusted
+	c.PkcePolicy = information.PkcePolicy
+	c.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+func NewOAuthClientEntity(ctx context.Context, information dtos.OAuthClientInformation) (OAuthClientEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return OAuthClientEntity{}, err
+	}
+
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return OAuthClientEntity{}, pkgerrors.Wrap(err, "generate client id error")
+	}
+
+	return OAuthClientEntity{
+		ClientId:     hex.EncodeToString(randomBytes),
+		Name:         information.Name,
+		Description:  information.Description,
+		RedirectUris: strings.Join(information.RedirectUris, " "),
+		Scopes:       strings.Join(information.Scopes, " "),
+		Trusted:      information.Trusted,
+		PkcePolicy:   information.PkcePolicy,
+		CreatedBy:    userClaim.Id,
+		UpdatedBy:    userClaim.Id,
+	}, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/oauth/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OAuthAuthorizationCodeRepository struct {
+}
+
+func (OAuthAuthorizationCodeRepository) Create(ctx context.Context, entity *domain.OAuthAuthorizationCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthAuthorizationCodeRepository) FindByCodeHash(ctx context.Context, codeHash string) (domain.OAuthAuthorizationCodeEntity, error) {
+	var entity domain.OAuthAuthorizationCodeEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("code_hash = ?", codeHash).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// MarkUsed 는 아직 교환하지 않은 코드에만 사용 시간을 기록한다. 동시에 교환하여 이미 기록된 경우 ErrNotFound 이다.
+func (OAuthAuthorizationCodeRepository) MarkUsed(ctx context.Context, entity domain.OAuthAuthorizationCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	result := db.Model(&domain.OAuthAuthorizationCodeEntity{}).
+		Where("id = ? AND used_at IS NULL", entity.ID).
+		Update("used_at", entity.UsedAt)
+	if result.Error != nil {
+		return pkgerrors.Wrap(result.Error, "db error")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
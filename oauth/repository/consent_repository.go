@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/oauth/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MemberConsentRepository struct {
+}
+
+func (MemberConsentRepository) FindByMemberIdAndClientId(ctx context.Context, memberId uint, oauthClientId uint) (domain.MemberConsentEntity, error) {
+	var entity domain.MemberConsentEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberConsentEntity{MemberId: memberId, OAuthClientId: oauthClientId}).
+		Preload("OAuthClient").
+		First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberConsentRepository) FindByMemberId(ctx context.Context, memberId uint) ([]domain.MemberConsentEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberConsentEntity, 0)
+	if err := db.Where(&domain.MemberConsentEntity{MemberId: memberId}).
+		Preload("OAuthClient").
+		Order("updated_at desc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (MemberConsentRepository) Save(ctx context.Context, entity *domain.MemberConsentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Omit("OAuthClient").Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberConsentRepository) Delete(ctx context.Context, entity domain.MemberConsentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/oauth/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OAuthDeviceCodeRepository struct {
+}
+
+func (OAuthDeviceCodeRepository) Create(ctx context.Context, entity *domain.OAuthDeviceCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthDeviceCodeRepository) FindByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (domain.OAuthDeviceCodeEntity, error) {
+	return findDeviceCode(ctx, "device_code_hash = ?", deviceCodeHash)
+}
+
+func (OAuthDeviceCodeRepository) FindByUserCode(ctx context.Context, userCode string) (domain.OAuthDeviceCodeEntity, error) {
+	return findDeviceCode(ctx, "user_code = ?", userCode)
+}
+
+func findDeviceCode(ctx context.Context, query string, value string) (domain.OAuthDeviceCodeEntity, error) {
+	var entity domain.OAuthDeviceCodeEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(query, value).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (OAuthDeviceCodeRepository) Save(ctx context.Context, entity *domain.OAuthDeviceCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// MarkUsed 는 아직 토큰을 발급하지 않은 기기 코드에만 사용 시간을 기록한다. 동시에 요청하여 이미 기록된 경우 ErrNotFound 이다.
+func (OAuthDeviceCodeRepository) MarkUsed(ctx context.Context, entity domain.OAuthDeviceCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	result := db.Model(&domain.OAuthDeviceCodeEntity{}).
+		Where("id = ? AND used_at IS NULL", entity.ID).
+		Updates(map[string]interface{}{"used_at": entity.UsedAt, "last_polled_at": entity.LastPolledAt})
+	if result.Error != nil {
+		return pkgerrors.Wrap(result.Error, "db error")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/oauth/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OAuthClientRepository struct {
+}
+
+func (OAuthClientRepository) Create(ctx context.Context, entity *domain.OAuthClientEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthClientRepository) FindAll(ctx context.Context, pageable dtos.Pageable) ([]domain.OAuthClientEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.OAuthClientEntity{})
+
+	var entities = make([]domain.OAuthClientEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (OAuthClientRepository) FindById(ctx context.Context, id uint) (domain.OAuthClientEntity, error) {
+	var entity domain.OAuthClientEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (OAuthClientRepository) FindByClientId(ctx context.Context, clientId string) (domain.OAuthClientEntity, error) {
+	var entity domain.OAuthClientEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("client_id = ?", clientId).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (OAuthClientRepository) Save(ctx context.Context, entity *domain.OAuthClientEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthClientRepository) Delete(ctx context.Context, entity domain.OAuthClientEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	// Client 가 삭제되면 멤버들의 동의도 함께 철회한다.
+	if err := db.Where("oauth_client_id = ?", entity.ID).Delete(&domain.MemberConsentEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
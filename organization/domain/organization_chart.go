@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"strconv"
+	"strings"
+)
+
+var organizationChartCsvHeaders = []string{"id", "parentId", "path", "name", "memberCount", "totalMemberCount", "leaders"}
+
+// OrganizationChartFile 은 조직도를 내보낸 파일이다.
+type OrganizationChartFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+func NewOrganizationChartFile(format string, nodes []dtos.OrganizationChartNode) (OrganizationChartFile, error) {
+	switch format {
+	case constants.OrganizationChartFormatCsv:
+		content, err := encodeOrganizationChartCsv(nodes)
+		if err != nil {
+			return OrganizationChartFile{}, err
+		}
+		return OrganizationChartFile{FileName: "organization_chart.csv", ContentType: "text/csv; charset=utf-8", Content: content}, nil
+	case constants.OrganizationChartFormatDot:
+		return OrganizationChartFile{FileName: "organization_chart.dot", ContentType: "text/vnd.graphviz; charset=utf-8", Content: encodeOrganizationChartDot(nodes)}, nil
+	}
+
+	return OrganizationChartFile{}, pkgerrors.Errorf("unsupported organization chart format: %v", format)
+}
+
+// encodeOrganizationChartCsv 는 상위 조직 다음에 하위 조직이 오도록 한 줄에 조직 하나씩 쓴다.
+func encodeOrganizationChartCsv(nodes []dtos.OrganizationChartNode) ([]byte, error) {
+	var buffer bytes.Buffer
+	// 엑셀에서 한글이 깨지지 않도록 BOM 을 붙인다.
+	buffer.WriteString("\xEF\xBB\xBF")
+
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(organizationChartCsvHeaders); err != nil {
+		return nil, pkgerrors.Wrap(err, "csv encode error")
+	}
+
+	rows := make([][]string, 0)
+	appendOrganizationChartCsvRows(&rows, nodes, nil, nil)
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, pkgerrors.Wrap(err, "csv encode error")
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func appendOrganizationChartCsvRows(rows *[][]string, nodes []dtos.OrganizationChartNode, parentId *uint, parentPath []string) {
+	for _, node := range nodes {
+		path := append(append([]string{}, parentPath...), node.Name)
+
+		parent := ""
+		if parentId != nil {
+			parent = strconv.FormatUint(uint64(*parentId), 10)
+		}
+
+		*rows = append(*rows, []string{
+			strconv.FormatUint(uint64(node.Id), 10),
+			parent,
+			strings.Join(path, " > "),
+			node.Name,
+			strconv.Itoa(node.MemberCount),
+			strconv.Itoa(node.TotalMemberCount),
+			strings.Join(organizationChartLeaderNames(node), ", "),
+		})
+
+		nodeId := node.Id
+		appendOrganizationChartCsvRows(rows, node.Children, &nodeId, path)
+	}
+}
+
+// encodeOrganizationChartDot 은 Graphviz 로 그릴 수 있는 DOT 문서를 만든다. (예: dot -Tpdf organization_chart.dot)
+func encodeOrganizationChartDot(nodes []dtos.OrganizationChartNode) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString("digraph organization_chart {\n")
+	buffer.WriteString("  rankdir=TB;\n")
+	buffer.WriteString("  node [shape=box];\n")
+	writeOrganizationChartDotNodes(&buffer, nodes)
+	buffer.WriteString("}\n")
+
+	return buffer.Bytes()
+}
+
+func writeOrganizationChartDotNodes(buffer *bytes.Buffer, nodes []dtos.OrganizationChartNode) {
+	for _, node := range nodes {
+		label := fmt.Sprintf("%s\\nmembers: %d (total %d)", escapeDotString(node.Name), node.MemberCount, node.TotalMemberCount)
+		if leaderNames := organizationChartLeaderNames(node); len(leaderNames) > 0 {
+			label += "\\nleaders: " + escapeDotString(strings.Join(leaderNames, ", "))
+		}
+		fmt.Fprintf(buffer, "  org%d [label=\"%s\"];\n", node.Id, label)
+
+		for _, child := range node.Children {
+			fmt.Fprintf(buffer, "  org%d -> org%d;\n", node.Id, child.Id)
+		}
+		writeOrganizationChartDotNodes(buffer, node.Children)
+	}
+}
+
+// escapeDotString 은 큰따옴표로 감싼 DOT 문자열 안에서 의미가 있는 문자를 이스케이프한다.
+func escapeDotString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", "").Replace(value)
+}
+
+func organizationChartLeaderNames(node dtos.OrganizationChartNode) []string {
+	names := make([]string, 0)
+	for _, leader := range node.Leaders {
+		names = append(names, leader.Name)
+	}
+	return names
+}
@@ -2,10 +2,12 @@ package domain
 
 import (
 	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	memberDomain "better-admin-backend-service/member/domain"
 	"better-admin-backend-service/rbac/domain"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/wesovilabs/koazee"
 	"gorm.io/gorm"
@@ -21,8 +23,11 @@ type OrganizationEntity struct {
 	Path                 string                      `gorm:"-"`
 	Roles                []domain.RoleEntity         `gorm:"many2many:organization_roles;"`
 	Members              []memberDomain.MemberEntity `gorm:"many2many:organization_members;"`
-	CreatedBy            uint
-	UpdatedBy            uint
+	Leaders              []memberDomain.MemberEntity `gorm:"many2many:organization_leaders;"`
+	// 조직에서 바꾼 사이트 설정(dtos.OrganizationSettingOverrides)의 JSON 이다.
+	SettingOverrides string `gorm:"type:text"`
+	CreatedBy        uint
+	UpdatedBy        uint
 }
 
 func (OrganizationEntity) TableName() string {
@@ -87,6 +92,37 @@ func (o *OrganizationEntity) AssignRole(ctx context.Context, roleEntities []doma
 func (o *OrganizationEntity) AssignMember(ctx context.Context, memberEntities []memberDomain.MemberEntity) error {
 	o.Members = memberEntities
 
+	// 조직에서 빠진 멤버는 리더에서도 뺀다.
+	leaders := make([]memberDomain.MemberEntity, 0)
+	for _, leader := range o.Leaders {
+		if o.ExistMember(leader.ID) {
+			leaders = append(leaders, leader)
+		}
+	}
+	o.Leaders = leaders
+
+	return nil
+}
+
+// AddMember 는 기존 멤버를 유지한 채 멤버를 추가한다.
+func (o *OrganizationEntity) AddMember(memberEntity memberDomain.MemberEntity) {
+	if o.ExistMember(memberEntity.ID) {
+		return
+	}
+
+	o.Members = append(o.Members, memberEntity)
+}
+
+// AssignLeader 는 기존 리더를 덮어쓴다. 리더는 조직에 속한 멤버 중에서만 지정할 수 있다.
+func (o *OrganizationEntity) AssignLeader(ctx context.Context, memberEntities []memberDomain.MemberEntity) error {
+	for _, member := range memberEntities {
+		if !o.ExistMember(member.ID) {
+			return &errors.ErrInvalidOrganization{Reason: fmt.Sprintf("member %v is not a member of the organization", member.ID)}
+		}
+	}
+
+	o.Leaders = memberEntities
+
 	return nil
 }
 
@@ -101,6 +137,36 @@ func (o *OrganizationEntity) ChangeName(ctx context.Context, name string) error
 	return nil
 }
 
+// GetSettingOverrides 는 조직에서 바꾼 사이트 설정이다. 바꾸지 않은 설정은 nil 이다.
+func (o OrganizationEntity) GetSettingOverrides() (dtos.OrganizationSettingOverrides, error) {
+	var overrides dtos.OrganizationSettingOverrides
+	if len(o.SettingOverrides) == 0 {
+		return overrides, nil
+	}
+
+	if err := json.Unmarshal([]byte(o.SettingOverrides), &overrides); err != nil {
+		return overrides, err
+	}
+
+	return overrides, nil
+}
+
+func (o *OrganizationEntity) ChangeSettingOverrides(ctx context.Context, overrides dtos.OrganizationSettingOverrides) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+
+	o.SettingOverrides = string(value)
+	o.UpdatedBy = userClaim.Id
+	return nil
+}
+
 func (o OrganizationEntity) ExistMember(memberId uint) bool {
 	for _, member := range o.Members {
 		if member.ID == memberId {
@@ -111,6 +177,16 @@ func (o OrganizationEntity) ExistMember(memberId uint) bool {
 	return false
 }
 
+func (o OrganizationEntity) HasRole(roleId uint) bool {
+	for _, role := range o.Roles {
+		if role.ID == roleId {
+			return true
+		}
+	}
+
+	return false
+}
+
 func NewOrganizationEntity(ctx context.Context, information dtos.OrganizationInformation) (OrganizationEntity, error) {
 	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
 	if err != nil {
@@ -35,3 +35,58 @@ func NewOrganizationInformationFromEntity(entity domain.OrganizationEntity) dtos
 
 	return organizationInformation
 }
+
+// NewOrganizationChartFromEntities 는 조직 목록을 최상위 조직부터 시작하는 트리로 만든다. 자식 조직은 entities 의 순서를 따른다.
+func NewOrganizationChartFromEntities(entities []domain.OrganizationEntity) []dtos.OrganizationChartNode {
+	organizationIds := map[uint]bool{}
+	childEntities := map[uint][]domain.OrganizationEntity{}
+	for _, entity := range entities {
+		organizationIds[entity.ID] = true
+		if entity.ParentOrganizationID != nil {
+			childEntities[*entity.ParentOrganizationID] = append(childEntities[*entity.ParentOrganizationID], entity)
+		}
+	}
+
+	nodes := make([]dtos.OrganizationChartNode, 0)
+	for _, entity := range entities {
+		if entity.ParentOrganizationID == nil || !organizationIds[*entity.ParentOrganizationID] {
+			node, _ := newOrganizationChartNode(entity, childEntities)
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+func newOrganizationChartNode(entity domain.OrganizationEntity, childEntities map[uint][]domain.OrganizationEntity) (dtos.OrganizationChartNode, map[uint]bool) {
+	memberIds := map[uint]bool{}
+	for _, member := range entity.Members {
+		memberIds[member.ID] = true
+	}
+
+	leaders := make([]dtos.OrganizationMember, 0)
+	for _, leader := range entity.Leaders {
+		leaders = append(leaders, dtos.OrganizationMember{
+			Id:   leader.ID,
+			Name: leader.Name,
+		})
+	}
+
+	children := make([]dtos.OrganizationChartNode, 0)
+	for _, childEntity := range childEntities[entity.ID] {
+		child, childMemberIds := newOrganizationChartNode(childEntity, childEntities)
+		children = append(children, child)
+		for memberId := range childMemberIds {
+			memberIds[memberId] = true
+		}
+	}
+
+	return dtos.OrganizationChartNode{
+		Id:               entity.ID,
+		Name:             entity.Name,
+		MemberCount:      len(entity.Members),
+		TotalMemberCount: len(memberIds),
+		Leaders:          leaders,
+		Children:         children,
+	}, memberIds
+}
@@ -31,6 +31,7 @@ func (OrganizationRepository) FindAll(ctx context.Context, filters map[string]in
 		Preload("Roles").
 		Preload("Roles.Permissions").
 		Preload("Members").
+		Preload("Leaders").
 		Find(&entities).Error; err != nil {
 		return entities, pkgerrors.Wrap(err, "db error")
 	}
@@ -75,7 +76,7 @@ func (OrganizationRepository) FindById(ctx context.Context, id uint) (domain.Org
 	var entity domain.OrganizationEntity
 
 	db := helpers.ContextHelper().GetDB(ctx)
-	if err := db.Preload("Roles").Preload("Members").First(&entity, id).Error; err != nil {
+	if err := db.Preload("Roles").Preload("Members").Preload("Leaders").First(&entity, id).Error; err != nil {
 		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
 			return entity, errors.ErrNotFound
 		}
@@ -97,6 +98,10 @@ func (OrganizationRepository) Save(ctx context.Context, entity *domain.Organizat
 		return pkgerrors.Wrap(err, "db error")
 	}
 
+	if err := db.Model(entity).Association("Leaders").Replace(entity.Leaders); err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
 	if err := db.Save(entity).Error; err != nil {
 		return pkgerrors.Wrap(err, "db error")
 	}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// PluginSettingEntity 는 확장 기능(플러그인, 추가한 컨트롤러)이 저장하는 Namespace 별 설정이다. Value 는 JSON 이다.
+type PluginSettingEntity struct {
+	gorm.Model
+	Namespace string `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Value     string `gorm:"type:text;not null"`
+	CreatedBy uint
+	UpdatedBy uint
+}
+
+func (PluginSettingEntity) TableName() string {
+	return "plugin_settings"
+}
+
+func (p PluginSettingEntity) ToPluginSetting() dtos.PluginSetting {
+	return dtos.PluginSetting{
+		Namespace: p.Namespace,
+		Value:     json.RawMessage(p.Value),
+		UpdatedBy: p.UpdatedBy,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// ToEvent 는 이벤트 버스로 보낼 설정 변경 이벤트를 만든다. 같은 설정이 여러 번 바뀌므로 Id 에 변경 시각을 포함한다.
+func (p PluginSettingEntity) ToEvent(action string) dtos.Event {
+	return dtos.Event{
+		Id:         fmt.Sprintf("%s-%d-%d", constants.EventTypePluginSetting, p.ID, p.UpdatedAt.UnixNano()),
+		Type:       constants.EventTypePluginSetting,
+		Action:     action,
+		OccurredAt: p.UpdatedAt,
+		ActorId:    p.UpdatedBy,
+		TargetType: constants.EventTypePluginSetting,
+		TargetId:   p.ID,
+		Succeeded:  true,
+		Detail:     p.Namespace,
+	}
+}
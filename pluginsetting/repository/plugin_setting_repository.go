@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/pluginsetting/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type PluginSettingRepository struct {
+}
+
+func (PluginSettingRepository) Save(ctx context.Context, entity *domain.PluginSettingEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (PluginSettingRepository) FindByNamespace(ctx context.Context, namespace string) (domain.PluginSettingEntity, error) {
+	var entity domain.PluginSettingEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Where(&domain.PluginSettingEntity{Namespace: namespace}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (PluginSettingRepository) FindByNamespaces(ctx context.Context, namespaces []string) ([]domain.PluginSettingEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.PluginSettingEntity, 0)
+	if err := db.Where("namespace IN ?", namespaces).Order("namespace").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// Delete 는 설정을 지운다. 같은 Namespace 를 다시 저장할 수 있도록 완전히 삭제한다.
+func (PluginSettingRepository) Delete(ctx context.Context, entity domain.PluginSettingEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Unscoped().Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
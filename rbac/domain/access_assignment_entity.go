@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"better-admin-backend-service/helpers"
+	"context"
+	"time"
+)
+
+// MemberRoleAssignmentEntity 는 멤버에게 역할이 할당되어 있던 기간이다. EffectiveTo 가 없으면 지금도 할당되어 있다.
+type MemberRoleAssignmentEntity struct {
+	ID            uint       `gorm:"primarykey"`
+	MemberId      uint       `gorm:"not null;index"`
+	RoleId        uint       `gorm:"not null;index"`
+	EffectiveFrom time.Time  `gorm:"not null"`
+	EffectiveTo   *time.Time `gorm:"index"`
+	AssignedBy    uint
+	RevokedBy     uint
+}
+
+func (MemberRoleAssignmentEntity) TableName() string {
+	return "member_role_assignments"
+}
+
+// RolePermissionAssignmentEntity 는 역할에 권한이 할당되어 있던 기간이다. EffectiveTo 가 없으면 지금도 할당되어 있다.
+type RolePermissionAssignmentEntity struct {
+	ID            uint       `gorm:"primarykey"`
+	RoleId        uint       `gorm:"not null;index"`
+	PermissionId  uint       `gorm:"not null;index"`
+	EffectiveFrom time.Time  `gorm:"not null"`
+	EffectiveTo   *time.Time `gorm:"index"`
+	AssignedBy    uint
+	RevokedBy     uint
+}
+
+func (RolePermissionAssignmentEntity) TableName() string {
+	return "role_permission_assignments"
+}
+
+func NewMemberRoleAssignmentEntity(ctx context.Context, memberId, roleId uint, effectiveFrom time.Time) MemberRoleAssignmentEntity {
+	return MemberRoleAssignmentEntity{
+		MemberId:      memberId,
+		RoleId:        roleId,
+		EffectiveFrom: effectiveFrom,
+		AssignedBy:    actorIdOf(ctx),
+	}
+}
+
+func NewRolePermissionAssignmentEntity(ctx context.Context, roleId, permissionId uint, effectiveFrom time.Time) RolePermissionAssignmentEntity {
+	return RolePermissionAssignmentEntity{
+		RoleId:        roleId,
+		PermissionId:  permissionId,
+		EffectiveFrom: effectiveFrom,
+		AssignedBy:    actorIdOf(ctx),
+	}
+}
+
+// actorIdOf 는 변경한 멤버의 ID 이다. 시스템 작업(스케줄러 등)이 변경하면 0 이다.
+func actorIdOf(ctx context.Context) uint {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return 0
+	}
+
+	return userClaim.Id
+}
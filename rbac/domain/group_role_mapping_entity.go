@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"context"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// GroupRoleMappingEntity 는 SSO 그룹(Provider 의 GroupName)에 속한 멤버에게 역할(RoleId)을 할당하는 매핑이다.
+// Authoritative 이면 그룹에 속하지 않은 멤버에게서 직접 할당한 역할도 회수한다.
+type GroupRoleMappingEntity struct {
+	gorm.Model
+	Provider      string `gorm:"type:varchar(100);not null;uniqueIndex:idx_group_role_mapping"`
+	GroupName     string `gorm:"type:varchar(255);not null;uniqueIndex:idx_group_role_mapping"`
+	RoleId        uint   `gorm:"not null;uniqueIndex:idx_group_role_mapping"`
+	Authoritative bool   `gorm:"not null;default:false"`
+	CreatedBy     uint
+	UpdatedBy     uint
+}
+
+func (GroupRoleMappingEntity) TableName() string {
+	return "group_role_mappings"
+}
+
+func NewGroupRoleMappingEntity(ctx context.Context, information dtos.GroupRoleMappingInformation) GroupRoleMappingEntity {
+	entity := GroupRoleMappingEntity{CreatedBy: actorIdOf(ctx)}
+	entity.Update(ctx, information)
+	return entity
+}
+
+func (m *GroupRoleMappingEntity) Update(ctx context.Context, information dtos.GroupRoleMappingInformation) {
+	m.Provider = information.Provider
+	m.GroupName = strings.TrimSpace(information.GroupName)
+	m.RoleId = information.RoleId
+	m.Authoritative = information.Authoritative
+	m.UpdatedBy = actorIdOf(ctx)
+}
+
+// Matches 는 멤버가 속한 그룹(groups)에 매핑의 그룹이 있는지 확인한다. 그룹 이름은 대소문자를 구분하지 않는다.
+func (m GroupRoleMappingEntity) Matches(groups []string) bool {
+	for _, group := range groups {
+		if strings.EqualFold(strings.TrimSpace(group), m.GroupName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m GroupRoleMappingEntity) ToInformation() dtos.GroupRoleMappingInformation {
+	return dtos.GroupRoleMappingInformation{
+		Id:            m.ID,
+		Provider:      m.Provider,
+		GroupName:     m.GroupName,
+		RoleId:        m.RoleId,
+		Authoritative: m.Authoritative,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+// GroupRoleGrantEntity 는 그룹-역할 매핑(MappingId)으로 멤버에게 할당한 역할이다.
+// 직접 할당한 역할과 구분하여, 권한 매핑(Authoritative)이 아니면 매핑으로 할당한 역할만 회수한다.
+type GroupRoleGrantEntity struct {
+	ID        uint      `gorm:"primarykey"`
+	MemberId  uint      `gorm:"not null;uniqueIndex:idx_group_role_grant"`
+	MappingId uint      `gorm:"not null;uniqueIndex:idx_group_role_grant"`
+	RoleId    uint      `gorm:"not null;index"`
+	GrantedAt time.Time `gorm:"not null"`
+}
+
+func (GroupRoleGrantEntity) TableName() string {
+	return "group_role_grants"
+}
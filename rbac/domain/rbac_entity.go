@@ -14,8 +14,11 @@ type PermissionEntity struct {
 	Type        string `gorm:"type:varchar(50);not null"`
 	Name        string `gorm:"type:varchar(100);not null"`
 	Description string `gorm:"type:varchar(1000)"`
-	CreatedBy   uint
-	UpdatedBy   uint
+	// Namespace, GroupName 은 권한 카탈로그(catalog)로 등록한 권한에만 있다.
+	Namespace string `gorm:"type:varchar(50);index"`
+	GroupName string `gorm:"type:varchar(100)"`
+	CreatedBy uint
+	UpdatedBy uint
 }
 
 func (PermissionEntity) TableName() string {
@@ -31,6 +34,10 @@ func (p PermissionEntity) GetTypeName() string {
 		return constants.UserDefineTypeName
 	}
 
+	if p.Type == constants.CatalogTypeKey {
+		return constants.CatalogTypeName
+	}
+
 	return ""
 }
 
@@ -40,7 +47,8 @@ func (p *PermissionEntity) Update(ctx context.Context, information dtos.Permissi
 		return err
 	}
 
-	if p.Type == constants.PreDefineTypeKey {
+	// 카탈로그 권한은 등록한 서비스만 바꿀 수 있다.
+	if p.Type == constants.PreDefineTypeKey || p.Type == constants.CatalogTypeKey {
 		return errors.ErrNonChangeable
 	}
 
@@ -52,7 +60,7 @@ func (p *PermissionEntity) Update(ctx context.Context, information dtos.Permissi
 }
 
 func (p PermissionEntity) Deletable() error {
-	if p.Type == constants.PreDefineTypeKey {
+	if p.Type == constants.PreDefineTypeKey || p.Type == constants.CatalogTypeKey {
 		return errors.ErrNonChangeable
 	}
 
@@ -74,6 +82,45 @@ func NewPermissionEntity(ctx context.Context, information dtos.PermissionInforma
 	}, nil
 }
 
+// NewCatalogPermissionEntity 는 서비스가 권한 카탈로그로 등록하는 권한이다. 이름은 "네임스페이스:이름" 이다.
+func NewCatalogPermissionEntity(ctx context.Context, namespace string, item dtos.PermissionCatalogItem) (PermissionEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return PermissionEntity{}, err
+	}
+
+	return PermissionEntity{
+		Type:        constants.CatalogTypeKey,
+		Name:        CatalogPermissionName(namespace, item.Name),
+		Description: item.Description,
+		Namespace:   namespace,
+		GroupName:   item.Group,
+		CreatedBy:   userClaim.Id,
+		UpdatedBy:   userClaim.Id,
+	}, nil
+}
+
+func CatalogPermissionName(namespace string, name string) string {
+	return namespace + ":" + name
+}
+
+// UpdateCatalog 는 카탈로그 권한의 설명과 그룹을 바꾼다. 바뀐 것이 없으면 false 를 반환한다.
+func (p *PermissionEntity) UpdateCatalog(ctx context.Context, item dtos.PermissionCatalogItem) (bool, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if p.Description == item.Description && p.GroupName == item.Group {
+		return false, nil
+	}
+
+	p.Description = item.Description
+	p.GroupName = item.Group
+	p.UpdatedBy = userClaim.Id
+	return true, nil
+}
+
 type RoleEntity struct {
 	gorm.Model
 	Type        string `gorm:"type:varchar(50);not null"`
@@ -100,6 +147,41 @@ func (r RoleEntity) GetTypeName() string {
 	return ""
 }
 
+func (r RoleEntity) GetPermissionIds() []uint {
+	var permissionIds = make([]uint, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		permissionIds = append(permissionIds, permission.ID)
+	}
+
+	return permissionIds
+}
+
+func (r RoleEntity) HasPermission(permissionName string) bool {
+	for _, permission := range r.Permissions {
+		if permission.Name == permissionName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ToEventData 는 도메인 이벤트(role.*)로 발행할 역할 정보이다.
+func (r RoleEntity) ToEventData() dtos.RoleEventData {
+	permissionNames := make([]string, 0)
+	for _, permission := range r.Permissions {
+		permissionNames = append(permissionNames, permission.Name)
+	}
+
+	return dtos.RoleEventData{
+		RoleId:      r.ID,
+		Type:        r.Type,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissionNames,
+	}
+}
+
 func (r RoleEntity) Deletable() error {
 	if r.Type == constants.PreDefineTypeKey {
 		return errors.ErrNonChangeable
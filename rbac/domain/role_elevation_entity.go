@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// RoleElevationEntity 는 멤버가 사유(Justification)를 남기고 요청한 임시 권한 상승이다.
+// 승인되면 Hours 시간 동안 역할을 할당하고(active), 기한(ExpiresAt)이 지나거나 관리자가 회수하면 역할을 회수한다.
+// 상승 중인 멤버의 감사 로그에는 상승 ID 가 함께 기록된다.
+type RoleElevationEntity struct {
+	gorm.Model
+	MemberId      uint   `gorm:"not null;index"`
+	RoleId        uint   `gorm:"not null"`
+	RoleName      string `gorm:"type:varchar(100);not null"`
+	Hours         int    `gorm:"not null"`
+	Justification string `gorm:"type:text;not null"`
+	Status        string `gorm:"type:varchar(20);not null;index"`
+	ActivatedAt   *time.Time
+	ExpiresAt     *time.Time `gorm:"index"`
+	EndedAt       *time.Time
+	EndedBy       uint
+}
+
+func (RoleElevationEntity) TableName() string {
+	return "role_elevations"
+}
+
+func NewRoleElevationEntity(memberId uint, role RoleEntity, request dtos.RoleElevationRequest) RoleElevationEntity {
+	return RoleElevationEntity{
+		MemberId:      memberId,
+		RoleId:        role.ID,
+		RoleName:      role.Name,
+		Hours:         request.Hours,
+		Justification: strings.TrimSpace(request.Justification),
+		Status:        constants.RoleElevationStatusPending,
+	}
+}
+
+func (r RoleElevationEntity) IsActive(now time.Time) bool {
+	return r.Status == constants.RoleElevationStatusActive && r.ExpiresAt != nil && r.ExpiresAt.After(now)
+}
+
+// Activate 는 승인된 때부터 Hours 시간 동안 상승을 시작한다.
+func (r *RoleElevationEntity) Activate(now time.Time) error {
+	if r.Status != constants.RoleElevationStatusPending {
+		return errors.ErrNonChangeable
+	}
+
+	expiresAt := now.Add(time.Duration(r.Hours) * time.Hour)
+	r.Status = constants.RoleElevationStatusActive
+	r.ActivatedAt = &now
+	r.ExpiresAt = &expiresAt
+	return nil
+}
+
+func (r *RoleElevationEntity) Reject(now time.Time) error {
+	if r.Status != constants.RoleElevationStatusPending {
+		return errors.ErrNonChangeable
+	}
+
+	r.Status = constants.RoleElevationStatusRejected
+	r.EndedAt = &now
+	return nil
+}
+
+func (r *RoleElevationEntity) Expire(now time.Time) error {
+	if r.Status != constants.RoleElevationStatusActive {
+		return errors.ErrNonChangeable
+	}
+
+	r.Status = constants.RoleElevationStatusExpired
+	r.EndedAt = &now
+	return nil
+}
+
+// Revoke 는 기한 전에 상승을 끝낸다. 승인을 기다리는 요청도 취소할 수 있다.
+func (r *RoleElevationEntity) Revoke(now time.Time, revokedBy uint) error {
+	if r.Status != constants.RoleElevationStatusPending && r.Status != constants.RoleElevationStatusActive {
+		return errors.ErrNonChangeable
+	}
+
+	r.Status = constants.RoleElevationStatusRevoked
+	r.EndedAt = &now
+	r.EndedBy = revokedBy
+	return nil
+}
+
+func (r RoleElevationEntity) ToInformation() dtos.RoleElevationInformation {
+	return dtos.RoleElevationInformation{
+		Id:            r.ID,
+		MemberId:      r.MemberId,
+		RoleId:        r.RoleId,
+		RoleName:      r.RoleName,
+		Hours:         r.Hours,
+		Justification: r.Justification,
+		Status:        r.Status,
+		ActivatedAt:   r.ActivatedAt,
+		ExpiresAt:     r.ExpiresAt,
+		EndedAt:       r.EndedAt,
+		EndedBy:       r.EndedBy,
+		CreatedAt:     r.CreatedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	"gorm.io/gorm"
+	"time"
+)
+
+// RoleMemberBulkJobEntity 는 비동기(Async)로 요청한 역할 멤버 일괄 할당/제거 작업이다.
+type RoleMemberBulkJobEntity struct {
+	gorm.Model
+	RoleId       uint   `gorm:"not null;index"`
+	Action       string `gorm:"type:varchar(20);not null"`
+	Request      string `gorm:"type:text;not null"`
+	Status       string `gorm:"type:varchar(20);not null;index"`
+	Result       string `gorm:"type:text"`
+	ErrorMessage string `gorm:"type:varchar(1000)"`
+	RequestedBy  uint
+	FinishedAt   *time.Time
+}
+
+func (RoleMemberBulkJobEntity) TableName() string {
+	return "role_member_bulk_jobs"
+}
+
+func NewRoleMemberBulkJobEntity(ctx context.Context, roleId uint, request dtos.RoleMemberBulkRequest) (RoleMemberBulkJobEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return RoleMemberBulkJobEntity{}, err
+	}
+
+	requestJson, err := json.Marshal(request)
+	if err != nil {
+		return RoleMemberBulkJobEntity{}, err
+	}
+
+	return RoleMemberBulkJobEntity{
+		RoleId:      roleId,
+		Action:      request.Action,
+		Request:     string(requestJson),
+		Status:      constants.RoleMemberBulkJobStatusPending,
+		RequestedBy: userClaim.Id,
+	}, nil
+}
+
+func (r RoleMemberBulkJobEntity) GetRequest() (dtos.RoleMemberBulkRequest, error) {
+	var request dtos.RoleMemberBulkRequest
+	if err := json.Unmarshal([]byte(r.Request), &request); err != nil {
+		return dtos.RoleMemberBulkRequest{}, err
+	}
+
+	return request, nil
+}
+
+func (r RoleMemberBulkJobEntity) GetResult() *dtos.RoleMemberBulkResult {
+	if len(r.Result) == 0 {
+		return nil
+	}
+
+	var result dtos.RoleMemberBulkResult
+	if err := json.Unmarshal([]byte(r.Result), &result); err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+func (r *RoleMemberBulkJobEntity) Succeed(result dtos.RoleMemberBulkResult) error {
+	resultJson, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	r.Status = constants.RoleMemberBulkJobStatusSucceeded
+	r.Result = string(resultJson)
+	r.FinishedAt = &now
+	return nil
+}
+
+func (r *RoleMemberBulkJobEntity) Fail(err error) {
+	message := []rune(err.Error())
+	if len(message) > 1000 {
+		message = message[:1000]
+	}
+
+	now := time.Now()
+	r.Status = constants.RoleMemberBulkJobStatusFailed
+	r.ErrorMessage = string(message)
+	r.FinishedAt = &now
+}
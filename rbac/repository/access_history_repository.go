@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/rbac/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AccessHistoryRepository struct {
+}
+
+// effectiveAt 은 at 시각에 유효했던 할당만 조회한다. 할당을 시작한 시각은 포함하고 끝난 시각은 포함하지 않는다.
+func effectiveAt(at time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("effective_from <= ? AND (effective_to IS NULL OR effective_to > ?)", at, at)
+	}
+}
+
+func (AccessHistoryRepository) FindCurrentMemberRoles(ctx context.Context, memberId uint) ([]domain.MemberRoleAssignmentEntity, error) {
+	var entities = make([]domain.MemberRoleAssignmentEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).
+		Where("member_id = ? AND effective_to IS NULL", memberId).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (AccessHistoryRepository) FindCurrentMemberRolesByRole(ctx context.Context, roleId uint) ([]domain.MemberRoleAssignmentEntity, error) {
+	var entities = make([]domain.MemberRoleAssignmentEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).
+		Where("role_id = ? AND effective_to IS NULL", roleId).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (AccessHistoryRepository) FindCurrentRolePermissions(ctx context.Context, roleId uint) ([]domain.RolePermissionAssignmentEntity, error) {
+	var entities = make([]domain.RolePermissionAssignmentEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).
+		Where("role_id = ? AND effective_to IS NULL", roleId).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (AccessHistoryRepository) CreateMemberRoles(ctx context.Context, entities []domain.MemberRoleAssignmentEntity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Create(&entities).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (AccessHistoryRepository) CreateRolePermissions(ctx context.Context, entities []domain.RolePermissionAssignmentEntity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Create(&entities).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// RevokeMemberRoles 는 할당을 지우지 않고 끝난 시각(effective_to)을 기록한다.
+func (AccessHistoryRepository) RevokeMemberRoles(ctx context.Context, ids []uint, at time.Time, revokedBy uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Model(&domain.MemberRoleAssignmentEntity{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"effective_to": at, "revoked_by": revokedBy}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// RevokeRolePermissions 는 할당을 지우지 않고 끝난 시각(effective_to)을 기록한다.
+func (AccessHistoryRepository) RevokeRolePermissions(ctx context.Context, ids []uint, at time.Time, revokedBy uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Model(&domain.RolePermissionAssignmentEntity{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{"effective_to": at, "revoked_by": revokedBy}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (AccessHistoryRepository) FindMemberRolesAt(ctx context.Context, memberId uint, at time.Time) ([]domain.MemberRoleAssignmentEntity, error) {
+	var entities = make([]domain.MemberRoleAssignmentEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).Scopes(effectiveAt(at)).
+		Where("member_id = ?", memberId).
+		Order("role_id ASC").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (AccessHistoryRepository) FindMemberRolesByRolesAt(ctx context.Context, roleIds []uint, at time.Time) ([]domain.MemberRoleAssignmentEntity, error) {
+	var entities = make([]domain.MemberRoleAssignmentEntity, 0)
+	if len(roleIds) == 0 {
+		return entities, nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Scopes(effectiveAt(at)).
+		Where("role_id IN ?", roleIds).
+		Order("member_id ASC, role_id ASC").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (AccessHistoryRepository) FindRolePermissionsAt(ctx context.Context, roleIds []uint, at time.Time) ([]domain.RolePermissionAssignmentEntity, error) {
+	var entities = make([]domain.RolePermissionAssignmentEntity, 0)
+	if len(roleIds) == 0 {
+		return entities, nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Scopes(effectiveAt(at)).
+		Where("role_id IN ?", roleIds).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (AccessHistoryRepository) FindRolePermissionsByPermissionsAt(ctx context.Context, permissionIds []uint, at time.Time) ([]domain.RolePermissionAssignmentEntity, error) {
+	var entities = make([]domain.RolePermissionAssignmentEntity, 0)
+	if len(permissionIds) == 0 {
+		return entities, nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Scopes(effectiveAt(at)).
+		Where("permission_id IN ?", permissionIds).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindRolesByIds 는 삭제된 역할도 이름을 보여줄 수 있도록 삭제 여부와 관계없이 찾는다.
+func (AccessHistoryRepository) FindRolesByIds(ctx context.Context, ids []uint) ([]domain.RoleEntity, error) {
+	var entities = make([]domain.RoleEntity, 0)
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Unscoped().Where("id IN ?", ids).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindPermissionsByIds 는 삭제된 권한도 이름을 보여줄 수 있도록 삭제 여부와 관계없이 찾는다.
+func (AccessHistoryRepository) FindPermissionsByIds(ctx context.Context, ids []uint) ([]domain.PermissionEntity, error) {
+	var entities = make([]domain.PermissionEntity, 0)
+	if len(ids) == 0 {
+		return entities, nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Unscoped().Where("id IN ?", ids).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindPermissionsByName 은 삭제된 권한도 찾는다. 같은 이름으로 다시 만든 권한이 있으면 모두 반환한다.
+func (AccessHistoryRepository) FindPermissionsByName(ctx context.Context, name string) ([]domain.PermissionEntity, error) {
+	var entities = make([]domain.PermissionEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).Unscoped().Where("name = ?", name).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
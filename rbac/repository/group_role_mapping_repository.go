@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/rbac/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type GroupRoleMappingRepository struct {
+}
+
+func (r GroupRoleMappingRepository) Create(ctx context.Context, entity *domain.GroupRoleMappingEntity) error {
+	if err := r.checkDuplicated(ctx, *entity); err != nil {
+		return err
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (r GroupRoleMappingRepository) Save(ctx context.Context, entity *domain.GroupRoleMappingEntity) error {
+	if err := r.checkDuplicated(ctx, *entity); err != nil {
+		return err
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// checkDuplicated 는 같은 그룹에 같은 역할을 할당하는 다른 매핑이 있는지 확인한다. 그룹 이름은 대소문자를 구분하지 않는다.
+func (GroupRoleMappingRepository) checkDuplicated(ctx context.Context, entity domain.GroupRoleMappingEntity) error {
+	var count int64
+	if err := helpers.ContextHelper().GetDB(ctx).Model(&domain.GroupRoleMappingEntity{}).
+		Where("provider = ? AND LOWER(group_name) = LOWER(?) AND role_id = ? AND id <> ?", entity.Provider, entity.GroupName, entity.RoleId, entity.ID).
+		Count(&count).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if count > 0 {
+		return errors.ErrDuplicated
+	}
+
+	return nil
+}
+
+func (GroupRoleMappingRepository) FindAll(ctx context.Context, filters map[string]interface{}) ([]domain.GroupRoleMappingEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.GroupRoleMappingEntity{})
+
+	for key, value := range filters {
+		if key == "provider" {
+			db.Where("provider = ?", value)
+		}
+
+		if key == "roleId" {
+			db.Where("role_id = ?", value)
+		}
+	}
+
+	var entities = make([]domain.GroupRoleMappingEntity, 0)
+	if err := db.Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (GroupRoleMappingRepository) FindById(ctx context.Context, id uint) (domain.GroupRoleMappingEntity, error) {
+	var entity domain.GroupRoleMappingEntity
+
+	if err := helpers.ContextHelper().GetDB(ctx).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// Delete 는 매핑과 매핑으로 할당한 기록을 지운다. 이미 할당한 역할은 그대로 두므로 직접 할당한 역할이 된다.
+func (GroupRoleMappingRepository) Delete(ctx context.Context, entity domain.GroupRoleMappingEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("mapping_id = ?", entity.ID).Delete(&domain.GroupRoleGrantEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (GroupRoleMappingRepository) FindGrantsByMemberId(ctx context.Context, memberId uint) ([]domain.GroupRoleGrantEntity, error) {
+	var entities = make([]domain.GroupRoleGrantEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).Where("member_id = ?", memberId).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (GroupRoleMappingRepository) CreateGrants(ctx context.Context, entities []domain.GroupRoleGrantEntity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Create(&entities).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (GroupRoleMappingRepository) DeleteGrants(ctx context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Where("id IN ?", ids).Delete(&domain.GroupRoleGrantEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
@@ -106,6 +107,55 @@ func (PermissionRepository) ExistsByName(ctx context.Context, name string) (bool
 	return false, nil
 }
 
+func (PermissionRepository) FindByNamespace(ctx context.Context, namespace string) ([]domain.PermissionEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.PermissionEntity, 0)
+	if err := db.Where("type = ? AND namespace = ?", constants.CatalogTypeKey, namespace).
+		Order("group_name").Order("name").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindAllCatalog 는 권한 카탈로그로 등록된 권한을 네임스페이스, 그룹, 이름 순으로 조회한다.
+func (PermissionRepository) FindAllCatalog(ctx context.Context) ([]domain.PermissionEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.PermissionEntity, 0)
+	if err := db.Where("type = ?", constants.CatalogTypeKey).
+		Order("namespace").Order("group_name").Order("name").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// DeleteWithRoles 는 권한을 삭제하고 역할에 할당된 권한도 함께 제거한다.
+func (PermissionRepository) DeleteWithRoles(ctx context.Context, entities []domain.PermissionEntity) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	permissionIds := make([]uint, 0, len(entities))
+	for _, entity := range entities {
+		permissionIds = append(permissionIds, entity.ID)
+	}
+
+	if err := db.Exec("DELETE FROM role_permissions WHERE permission_entity_id IN ?", permissionIds).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Delete(&domain.PermissionEntity{}, permissionIds).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
 type RoleRepository struct {
 }
 
@@ -187,3 +237,62 @@ func (RoleRepository) Save(ctx context.Context, entity *domain.RoleEntity) error
 
 	return nil
 }
+
+func (RoleRepository) Count(ctx context.Context) (int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var count int64
+	if err := db.Model(&domain.RoleEntity{}).Count(&count).Error; err != nil {
+		return 0, pkgerrors.Wrap(err, "db error")
+	}
+
+	return count, nil
+}
+
+type RoleMemberBulkJobRepository struct {
+}
+
+func (RoleMemberBulkJobRepository) Create(ctx context.Context, entity *domain.RoleMemberBulkJobEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RoleMemberBulkJobRepository) Save(ctx context.Context, entity *domain.RoleMemberBulkJobEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RoleMemberBulkJobRepository) FindByRoleIdAndId(ctx context.Context, roleId uint, id uint) (domain.RoleMemberBulkJobEntity, error) {
+	var entity domain.RoleMemberBulkJobEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.RoleMemberBulkJobEntity{RoleId: roleId}).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (RoleMemberBulkJobRepository) FindByStatus(ctx context.Context, status string) ([]domain.RoleMemberBulkJobEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.RoleMemberBulkJobEntity, 0)
+	if err := db.Where(&domain.RoleMemberBulkJobEntity{Status: status}).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
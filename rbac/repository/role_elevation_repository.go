@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/rbac/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type RoleElevationRepository struct {
+}
+
+func (RoleElevationRepository) Create(ctx context.Context, entity *domain.RoleElevationEntity) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RoleElevationRepository) Save(ctx context.Context, entity *domain.RoleElevationEntity) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RoleElevationRepository) FindById(ctx context.Context, id uint) (domain.RoleElevationEntity, error) {
+	var entity domain.RoleElevationEntity
+
+	if err := helpers.ContextHelper().GetDB(ctx).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindAll 은 임시 권한 상승을 최근 순으로 조회한다.
+func (RoleElevationRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.RoleElevationEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.RoleElevationEntity{})
+
+	for key, value := range filters {
+		if key == "memberId" {
+			db.Where("member_id = ?", value)
+		}
+
+		if key == "status" {
+			db.Where("status = ?", value)
+		}
+	}
+
+	var entities = make([]domain.RoleElevationEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Order("id DESC").Scopes(helpers.GormHelper().Pageable(pageable)).
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+// FindOpenByMemberIdAndRoleId 는 멤버가 같은 역할로 승인을 기다리거나 상승 중인 요청이다.
+func (RoleElevationRepository) FindOpenByMemberIdAndRoleId(ctx context.Context, memberId, roleId uint) ([]domain.RoleElevationEntity, error) {
+	var entities = make([]domain.RoleElevationEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).
+		Where("member_id = ? AND role_id = ? AND status IN ?", memberId, roleId,
+			[]string{constants.RoleElevationStatusPending, constants.RoleElevationStatusActive}).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindActiveByMemberId 는 멤버가 지금 상승 중인 요청을 최근 순으로 조회한다.
+func (RoleElevationRepository) FindActiveByMemberId(ctx context.Context, memberId uint, now time.Time) ([]domain.RoleElevationEntity, error) {
+	var entities = make([]domain.RoleElevationEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).
+		Where("member_id = ? AND status = ? AND expires_at > ?", memberId, constants.RoleElevationStatusActive, now).
+		Order("id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (RoleElevationRepository) FindExpiredActive(ctx context.Context, now time.Time) ([]domain.RoleElevationEntity, error) {
+	var entities = make([]domain.RoleElevationEntity, 0)
+	if err := helpers.ContextHelper().GetDB(ctx).
+		Where("status = ? AND expires_at <= ?", constants.RoleElevationStatusActive, now).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
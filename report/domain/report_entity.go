@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/scheduler"
+	"context"
+	"encoding/json"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// ReportEntity 는 관리자가 정의한 리포트이다. 요청할 때 또는 Schedule(cron) 에 따라 실행하고 결과 파일을 메일이나 웹훅으로 보낸다.
+type ReportEntity struct {
+	gorm.Model
+	Name            string `gorm:"type:varchar(100);not null"`
+	Description     string `gorm:"type:varchar(1000)"`
+	Definition      string `gorm:"type:text;not null"`
+	Format          string `gorm:"type:varchar(10);not null"`
+	Schedule        string `gorm:"type:varchar(100)"`
+	NextRunAt       *time.Time
+	DeliveryChannel string `gorm:"type:varchar(20)"`
+	Recipients      string `gorm:"type:varchar(1000)"`
+	WebHookUrl      string `gorm:"type:varchar(1000)"`
+	// OwnerId 는 리소스를 책임지는 멤버이다. 만든 멤버가 처음 소유자이며 멤버를 해지(deprovisioning)하면 후임자에게 넘긴다.
+	OwnerId   uint `gorm:"index"`
+	CreatedBy uint
+	UpdatedBy uint
+}
+
+func (ReportEntity) TableName() string {
+	return "reports"
+}
+
+// GetOwnerId 는 소유자이다. 소유자를 기록하기 전에 만든 리소스는 만든 멤버가 소유자이다.
+func (r ReportEntity) GetOwnerId() uint {
+	if r.OwnerId == 0 {
+		return r.CreatedBy
+	}
+
+	return r.OwnerId
+}
+
+func (r *ReportEntity) TransferOwnership(ctx context.Context, ownerId uint) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.OwnerId = ownerId
+	r.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+func (r ReportEntity) GetDefinition() dtos.ReportDefinition {
+	var definition dtos.ReportDefinition
+	if err := json.Unmarshal([]byte(r.Definition), &definition); err != nil {
+		return dtos.ReportDefinition{}
+	}
+
+	return definition
+}
+
+func (r ReportEntity) GetRecipients() []string {
+	if len(r.Recipients) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(r.Recipients, ",")
+}
+
+func (r ReportEntity) GetDelivery() dtos.ReportDelivery {
+	return dtos.ReportDelivery{
+		Channel:    r.DeliveryChannel,
+		Recipients: r.GetRecipients(),
+		WebHookUrl: r.WebHookUrl,
+	}
+}
+
+func (r ReportEntity) NewQuery() (ReportQuery, error) {
+	return NewReportQuery(r.GetDefinition())
+}
+
+func (r ReportEntity) IsDue(now time.Time) bool {
+	return r.NextRunAt != nil && !r.NextRunAt.After(now)
+}
+
+// ScheduleNextRun 은 now 이후 다음 실행 시간을 정한다. 스케줄이 없으면 자동으로 실행하지 않는다.
+func (r *ReportEntity) ScheduleNextRun(now time.Time) error {
+	r.NextRunAt = nil
+	if len(r.Schedule) == 0 {
+		return nil
+	}
+
+	schedule, err := scheduler.ParseCron(r.Schedule)
+	if err != nil {
+		return &errors.ErrInvalidReport{Reason: err.Error()}
+	}
+
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return &errors.ErrInvalidReport{Reason: fmt.Sprintf("schedule never runs: %v", r.Schedule)}
+	}
+
+	r.NextRunAt = &next
+	return nil
+}
+
+func (r *ReportEntity) Update(ctx context.Context, information dtos.ReportInformation) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := NewReportQuery(information.Definition); err != nil {
+		return err
+	}
+
+	if err := validateReportDelivery(information.Delivery); err != nil {
+		return err
+	}
+
+	definition, err := json.Marshal(information.Definition)
+	if err != nil {
+		return pkgerrors.Wrap(err, "report definition encode error")
+	}
+
+	r.Name = information.Name
+	r.Description = information.Description
+	r.Definition = string(definition)
+	r.Format = information.Format
+	r.Schedule = strings.TrimSpace(information.Schedule)
+	r.DeliveryChannel = information.Delivery.Channel
+	r.Recipients = strings.Join(information.Delivery.Recipients, ",")
+	r.WebHookUrl = information.Delivery.WebHookUrl
+	r.UpdatedBy = userClaim.Id
+
+	return r.ScheduleNextRun(time.Now())
+}
+
+func validateReportDelivery(delivery dtos.ReportDelivery) error {
+	switch delivery.Channel {
+	case constants.ReportDeliveryChannelEmail:
+		if len(delivery.Recipients) == 0 {
+			return &errors.ErrInvalidReport{Reason: "recipients are required for email delivery"}
+		}
+	case constants.ReportDeliveryChannelWebHook:
+		if len(delivery.WebHookUrl) == 0 {
+			return &errors.ErrInvalidReport{Reason: "webHookUrl is required for webhook delivery"}
+		}
+	}
+
+	return nil
+}
+
+func NewReportEntity(ctx context.Context, information dtos.ReportInformation) (ReportEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return ReportEntity{}, err
+	}
+
+	entity := ReportEntity{OwnerId: userClaim.Id, CreatedBy: userClaim.Id}
+	if err := entity.Update(ctx, information); err != nil {
+		return ReportEntity{}, err
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"archive/zip"
+	"better-admin-backend-service/constants"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"regexp"
+	"time"
+)
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[\\/:*?"<>|\s]+`)
+
+// ReportFile 은 리포트 실행 결과 파일이다.
+type ReportFile struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+func NewReportFile(format string, name string, headers []string, rows [][]string, createdAt time.Time) (ReportFile, error) {
+	fileName := fmt.Sprintf("%s_%s.%s", unsafeFileNameCharacters.ReplaceAllString(name, "_"), createdAt.Format("20060102150405"), format)
+
+	switch format {
+	case constants.ReportFormatCsv:
+		content, err := encodeCsv(headers, rows)
+		if err != nil {
+			return ReportFile{}, err
+		}
+		return ReportFile{FileName: fileName, ContentType: "text/csv; charset=utf-8", Content: content}, nil
+	case constants.ReportFormatXlsx:
+		content, err := encodeXlsx(headers, rows)
+		if err != nil {
+			return ReportFile{}, err
+		}
+		return ReportFile{FileName: fileName, ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", Content: content}, nil
+	}
+
+	return ReportFile{}, pkgerrors.Errorf("unsupported report format: %v", format)
+}
+
+func encodeCsv(headers []string, rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	// 엑셀에서 열었을 때 한글이 깨지지 않도록 UTF-8 BOM 을 붙인다.
+	buffer.WriteString("\xEF\xBB\xBF")
+
+	writer := csv.NewWriter(&buffer)
+	if err := writer.Write(headers); err != nil {
+		return nil, pkgerrors.Wrap(err, "csv encode error")
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, pkgerrors.Wrap(err, "csv encode error")
+	}
+
+	return buffer.Bytes(), nil
+}
+
+var xlsxStaticParts = map[string]string{
+	"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`,
+	"_rels/.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`,
+	"xl/workbook.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`,
+	"xl/_rels/workbook.xml.rels": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`,
+}
+
+// encodeXlsx 는 외부 라이브러리 없이 하나의 시트로 된 최소한의 XLSX(Office Open XML) 파일을 만든다. 모든 값은 문자열 셀이다.
+func encodeXlsx(headers []string, rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		part, err := writer.Create(name)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "xlsx encode error")
+		}
+		part.Write([]byte(xlsxStaticParts[name]))
+	}
+
+	sheet, err := writer.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "xlsx encode error")
+	}
+
+	sheet.Write([]byte(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`))
+	for _, row := range append([][]string{headers}, rows...) {
+		sheet.Write([]byte("<row>"))
+		for _, value := range row {
+			sheet.Write([]byte(`<c t="inlineStr"><is><t xml:space="preserve">`))
+			if err := xml.EscapeText(sheet, []byte(value)); err != nil {
+				return nil, pkgerrors.Wrap(err, "xlsx encode error")
+			}
+			sheet.Write([]byte("</t></is></c>"))
+		}
+		sheet.Write([]byte("</row>"))
+	}
+	sheet.Write([]byte("</sheetData></worksheet>"))
+
+	if err := writer.Close(); err != nil {
+		return nil, pkgerrors.Wrap(err, "xlsx encode error")
+	}
+
+	return buffer.Bytes(), nil
+}
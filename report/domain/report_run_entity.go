@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ReportRunEntity 는 리포트 실행 이력이다. 결과 파일을 함께 저장하여 나중에 내려받을 수 있다.
+type ReportRunEntity struct {
+	gorm.Model
+	ReportId       uint   `gorm:"not null;index"`
+	TriggerType    string `gorm:"type:varchar(20);not null"`
+	Status         string `gorm:"type:varchar(20);not null"`
+	DeliveryStatus string `gorm:"type:varchar(20);not null"`
+	FileName       string `gorm:"type:varchar(200)"`
+	ContentType    string `gorm:"type:varchar(100)"`
+	Content        []byte
+	RowCount       int
+	ErrorMessage   string `gorm:"type:varchar(1000)"`
+	RequestedBy    uint
+	StartedAt      time.Time `gorm:"not null"`
+	FinishedAt     *time.Time
+}
+
+func (ReportRunEntity) TableName() string {
+	return "report_runs"
+}
+
+func (r ReportRunEntity) IsSucceeded() bool {
+	return r.Status == constants.ReportRunStatusSucceeded
+}
+
+func (r *ReportRunEntity) Succeed(file ReportFile, rowCount int) {
+	now := time.Now()
+	r.Status = constants.ReportRunStatusSucceeded
+	r.FileName = file.FileName
+	r.ContentType = file.ContentType
+	r.Content = file.Content
+	r.RowCount = rowCount
+	r.FinishedAt = &now
+}
+
+func (r *ReportRunEntity) Fail(err error) {
+	now := time.Now()
+	r.Status = constants.ReportRunStatusFailed
+	r.ErrorMessage = truncateErrorMessage(err.Error())
+	r.FinishedAt = &now
+}
+
+func (r *ReportRunEntity) MarkDelivered() {
+	r.DeliveryStatus = constants.ReportDeliveryStatusDelivered
+}
+
+func (r *ReportRunEntity) MarkDeliveryFailed(err error) {
+	r.DeliveryStatus = constants.ReportDeliveryStatusFailed
+	r.ErrorMessage = truncateErrorMessage(err.Error())
+}
+
+func (r ReportRunEntity) GetFile() ReportFile {
+	return ReportFile{
+		FileName:    r.FileName,
+		ContentType: r.ContentType,
+		Content:     r.Content,
+	}
+}
+
+func truncateErrorMessage(message string) string {
+	runes := []rune(message)
+	if len(runes) > 1000 {
+		return string(runes[:1000])
+	}
+
+	return message
+}
+
+func NewReportRunEntity(report ReportEntity, trigger string, requestedBy uint) ReportRunEntity {
+	return ReportRunEntity{
+		ReportId:       report.ID,
+		TriggerType:    trigger,
+		Status:         constants.ReportRunStatusFailed,
+		DeliveryStatus: constants.ReportDeliveryStatusNone,
+		RequestedBy:    requestedBy,
+		StartedAt:      time.Now(),
+	}
+}
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"fmt"
+	"strings"
+)
+
+type reportColumn struct {
+	Name     string
+	DbColumn string
+}
+
+// reportSource 는 리포트로 조회할 수 있는 테이블과 컬럼이다. 화면에서 받은 컬럼 이름은 이 목록에 있는 것만 SQL 로 바꾼다.
+type reportSource struct {
+	Table      string
+	SoftDelete bool
+	Columns    []reportColumn
+}
+
+func (s reportSource) findColumn(name string) (reportColumn, bool) {
+	for _, column := range s.Columns {
+		if column.Name == name {
+			return column, true
+		}
+	}
+
+	return reportColumn{}, false
+}
+
+var reportSources = map[string]reportSource{
+	constants.ReportEntityMembers: {
+		Table:      "members",
+		SoftDelete: true,
+		Columns: []reportColumn{
+			{"id", "id"},
+			{"signId", "sign_id"},
+			{"name", "name"},
+			{"phone", "phone"},
+			{"type", "type"},
+			{"status", "status"},
+			{"createdAt", "created_at"},
+			{"lastAccessAt", "last_access_at"},
+		},
+	},
+	constants.ReportEntityAuditLogs: {
+		Table:      "audit_logs",
+		SoftDelete: true,
+		Columns: []reportColumn{
+			{"id", "id"},
+			{"actorType", "actor_type"},
+			{"actorId", "actor_id"},
+			{"action", "action"},
+			{"targetType", "target_type"},
+			{"targetId", "target_id"},
+			{"detail", "detail"},
+			{"ipAddress", "ip_address"},
+			{"country", "country"},
+			{"city", "city"},
+			{"asn", "asn"},
+			{"createdAt", "created_at"},
+		},
+	},
+	constants.ReportEntityActivityFeeds: {
+		Table:      "activity_feeds",
+		SoftDelete: true,
+		Columns: []reportColumn{
+			{"id", "id"},
+			{"eventType", "event_type"},
+			{"action", "action"},
+			{"actorType", "actor_type"},
+			{"actorId", "actor_id"},
+			{"entityType", "entity_type"},
+			{"entityId", "entity_id"},
+			{"summary", "summary"},
+			{"occurredAt", "occurred_at"},
+		},
+	},
+	// 컴플라이언스 리포트에서 보존 중인 멤버를 확인한다. 해제한 법적 보존은 조회하지 않는다.
+	constants.ReportEntityLegalHolds: {
+		Table:      "legal_holds",
+		SoftDelete: true,
+		Columns: []reportColumn{
+			{"id", "id"},
+			{"memberId", "member_id"},
+			{"heldFrom", "held_from"},
+			{"heldTo", "held_to"},
+			{"reason", "reason"},
+			{"ownerId", "owner_id"},
+			{"createdBy", "created_by"},
+			{"createdAt", "created_at"},
+		},
+	},
+}
+
+var reportFilterOperators = map[string]string{
+	"eq":       "%s = ?",
+	"ne":       "%s <> ?",
+	"contains": "%s LIKE ?",
+	"gte":      "%s >= ?",
+	"lte":      "%s <= ?",
+}
+
+// ReportQuery 는 리포트 정의로 만든 조회 조건이다. 컬럼과 표현식은 허용된 컬럼으로만 만들어지고 값은 파라미터로 전달한다.
+type ReportQuery struct {
+	Table      string
+	Headers    []string
+	Selects    []string
+	Conditions []ReportCondition
+	GroupBy    []string
+	OrderBy    string
+	Limit      int
+}
+
+type ReportCondition struct {
+	Expression string
+	Value      interface{}
+}
+
+func NewReportQuery(definition dtos.ReportDefinition) (ReportQuery, error) {
+	source, ok := reportSources[definition.Entity]
+	if !ok {
+		return ReportQuery{}, &errors.ErrInvalidReport{Reason: fmt.Sprintf("unsupported entity: %v", definition.Entity)}
+	}
+
+	query := ReportQuery{
+		Table: source.Table,
+		Limit: constants.ReportMaxRows,
+	}
+
+	if source.SoftDelete {
+		query.Conditions = append(query.Conditions, ReportCondition{Expression: "deleted_at IS NULL"})
+	}
+
+	for _, filter := range definition.Filters {
+		column, ok := source.findColumn(filter.Column)
+		if !ok {
+			return ReportQuery{}, &errors.ErrInvalidReport{Reason: fmt.Sprintf("unknown filter column: %v", filter.Column)}
+		}
+
+		expression, ok := reportFilterOperators[filter.Operator]
+		if !ok {
+			return ReportQuery{}, &errors.ErrInvalidReport{Reason: fmt.Sprintf("unsupported filter operator: %v", filter.Operator)}
+		}
+
+		value := filter.Value
+		if filter.Operator == "contains" {
+			value = "%" + value + "%"
+		}
+
+		query.Conditions = append(query.Conditions, ReportCondition{Expression: fmt.Sprintf(expression, column.DbColumn), Value: value})
+	}
+
+	if definition.Aggregation != nil {
+		return query.aggregate(source, *definition.Aggregation)
+	}
+
+	columnNames := definition.Columns
+	if len(columnNames) == 0 {
+		for _, column := range source.Columns {
+			columnNames = append(columnNames, column.Name)
+		}
+	}
+
+	for _, name := range columnNames {
+		column, ok := source.findColumn(name)
+		if !ok {
+			return ReportQuery{}, &errors.ErrInvalidReport{Reason: fmt.Sprintf("unknown column: %v", name)}
+		}
+
+		query.Headers = append(query.Headers, column.Name)
+		query.Selects = append(query.Selects, column.DbColumn)
+	}
+	query.OrderBy = "id"
+
+	return query, nil
+}
+
+func (q ReportQuery) aggregate(source reportSource, aggregation dtos.ReportAggregation) (ReportQuery, error) {
+	for _, name := range aggregation.GroupBy {
+		column, ok := source.findColumn(name)
+		if !ok {
+			return ReportQuery{}, &errors.ErrInvalidReport{Reason: fmt.Sprintf("unknown group by column: %v", name)}
+		}
+
+		q.Headers = append(q.Headers, column.Name)
+		q.Selects = append(q.Selects, column.DbColumn)
+		q.GroupBy = append(q.GroupBy, column.DbColumn)
+	}
+
+	switch aggregation.Function {
+	case "count":
+		q.Headers = append(q.Headers, "count")
+		q.Selects = append(q.Selects, "COUNT(*)")
+	case "sum", "avg", "min", "max":
+		column, ok := source.findColumn(aggregation.Column)
+		if !ok {
+			return ReportQuery{}, &errors.ErrInvalidReport{Reason: fmt.Sprintf("unknown aggregation column: %v", aggregation.Column)}
+		}
+
+		q.Headers = append(q.Headers, fmt.Sprintf("%s(%s)", aggregation.Function, column.Name))
+		q.Selects = append(q.Selects, fmt.Sprintf("%s(%s)", strings.ToUpper(aggregation.Function), column.DbColumn))
+	default:
+		return ReportQuery{}, &errors.ErrInvalidReport{Reason: fmt.Sprintf("unsupported aggregation function: %v", aggregation.Function)}
+	}
+
+	q.OrderBy = strings.Join(q.GroupBy, ", ")
+	return q, nil
+}
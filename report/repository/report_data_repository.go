@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/report/domain"
+	"context"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+// ReportDataRepository 는 리포트 정의로 만든 조회 조건(ReportQuery)을 실행한다.
+type ReportDataRepository struct {
+}
+
+func (ReportDataRepository) FindRows(ctx context.Context, query domain.ReportQuery) ([][]string, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Table(query.Table).Select(strings.Join(query.Selects, ", "))
+
+	for _, condition := range query.Conditions {
+		if condition.Value == nil {
+			db = db.Where(condition.Expression)
+		} else {
+			db = db.Where(condition.Expression, condition.Value)
+		}
+	}
+
+	if len(query.GroupBy) > 0 {
+		db = db.Group(strings.Join(query.GroupBy, ", "))
+	}
+
+	if len(query.OrderBy) > 0 {
+		db = db.Order(query.OrderBy)
+	}
+
+	rows, err := db.Limit(query.Limit).Rows()
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "db error")
+	}
+	defer rows.Close()
+
+	var result = make([][]string, 0)
+	for rows.Next() {
+		values := make([]interface{}, len(query.Selects))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, pkgerrors.Wrap(err, "db error")
+		}
+
+		row := make([]string, len(values))
+		for i, value := range values {
+			row[i] = formatReportValue(value)
+		}
+		result = append(result, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pkgerrors.Wrap(err, "db error")
+	}
+
+	return result, nil
+}
+
+func formatReportValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprint(v)
+	}
+}
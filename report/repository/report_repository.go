@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/report/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ReportRepository struct {
+}
+
+func (ReportRepository) Create(ctx context.Context, entity *domain.ReportEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ReportRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.ReportEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ReportEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "ownerId" {
+				db.Scopes(helpers.GormHelper().OwnedBy(value.(uint)))
+			}
+		}
+	}
+
+	var entities = make([]domain.ReportEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("name").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (ReportRepository) FindById(ctx context.Context, id uint) (domain.ReportEntity, error) {
+	var entity domain.ReportEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindDue 는 예약된 실행 시간이 지난 리포트를 조회한다.
+func (ReportRepository) FindDue(ctx context.Context, now time.Time) ([]domain.ReportEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ReportEntity, 0)
+	if err := db.Where("next_run_at IS NOT NULL AND next_run_at <= ?", now).
+		Order("next_run_at").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (ReportRepository) Save(ctx context.Context, entity *domain.ReportEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ReportRepository) Delete(ctx context.Context, entity domain.ReportEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ReportRepository) FindByOwnerId(ctx context.Context, ownerId uint) ([]domain.ReportEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ReportEntity, 0)
+	if err := db.Scopes(helpers.GormHelper().OwnedBy(ownerId)).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/report/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ReportRunRepository struct {
+}
+
+func (ReportRunRepository) Create(ctx context.Context, entity *domain.ReportRunEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ReportRunRepository) Save(ctx context.Context, entity *domain.ReportRunEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindByReportId 는 실행 이력을 최신순으로 조회한다. 결과 파일 내용은 조회하지 않는다.
+func (ReportRunRepository) FindByReportId(ctx context.Context, reportId uint, pageable dtos.Pageable) ([]domain.ReportRunEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ReportRunEntity{}).
+		Where("report_id = ?", reportId)
+
+	var entities = make([]domain.ReportRunEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Omit("content").
+		Order("started_at DESC, id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (ReportRunRepository) FindById(ctx context.Context, reportId, runId uint) (domain.ReportRunEntity, error) {
+	var entity domain.ReportRunEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("report_id = ?", reportId).First(&entity, runId).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
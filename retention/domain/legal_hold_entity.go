@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// LegalHoldEntity 는 보관 기간이 지나도 지우지 않을 데이터(법적 보존)이다.
+// MemberId 가 있으면 멤버의 데이터를, 기간(HeldFrom~HeldTo)이 있으면 기간에 만든 데이터를, 둘 다 있으면 멤버의 기간 데이터를 보존한다.
+// 멤버를 보존하면 해제할 때까지 멤버를 삭제(거절)하거나 익명화할 수 없다.
+type LegalHoldEntity struct {
+	gorm.Model
+	MemberId  uint `gorm:"index"`
+	HeldFrom  *time.Time
+	HeldTo    *time.Time
+	Reason    string `gorm:"type:varchar(1000);not null"`
+	OwnerId   uint
+	CreatedBy uint
+}
+
+func (LegalHoldEntity) TableName() string {
+	return "legal_holds"
+}
+
+func NewLegalHoldEntity(request dtos.LegalHoldRequest, createdBy uint) (LegalHoldEntity, error) {
+	if request.MemberId == 0 && request.HeldFrom == nil && request.HeldTo == nil {
+		return LegalHoldEntity{}, &errors.ErrInvalidRetention{Reason: "memberId or held period is required"}
+	}
+
+	if request.HeldFrom != nil && request.HeldTo != nil && !request.HeldFrom.Before(*request.HeldTo) {
+		return LegalHoldEntity{}, &errors.ErrInvalidRetention{Reason: "heldFrom must be before heldTo"}
+	}
+
+	ownerId := request.OwnerId
+	if ownerId == 0 {
+		ownerId = createdBy
+	}
+
+	return LegalHoldEntity{
+		MemberId:  request.MemberId,
+		HeldFrom:  request.HeldFrom,
+		HeldTo:    request.HeldTo,
+		Reason:    request.Reason,
+		OwnerId:   ownerId,
+		CreatedBy: createdBy,
+	}, nil
+}
+
+func (e LegalHoldEntity) ToInformation() dtos.LegalHoldInformation {
+	return dtos.LegalHoldInformation{
+		Id:        e.ID,
+		MemberId:  e.MemberId,
+		HeldFrom:  e.HeldFrom,
+		HeldTo:    e.HeldTo,
+		Reason:    e.Reason,
+		OwnerId:   e.OwnerId,
+		CreatedBy: e.CreatedBy,
+		CreatedAt: e.CreatedAt,
+	}
+}
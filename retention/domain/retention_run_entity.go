@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"encoding/json"
+	"gorm.io/gorm"
+	"time"
+)
+
+// RetentionRunEntity 는 보관 기간 적용 실행 보고서이다. 데이터 종류별 결과(dtos.RetentionResult)를 JSON 으로 저장한다.
+type RetentionRunEntity struct {
+	gorm.Model
+	TriggerType string `gorm:"type:varchar(20);not null"`
+	Status      string `gorm:"type:varchar(20);not null"`
+	Results     string `gorm:"type:text"`
+	RequestedBy uint
+	StartedAt   time.Time `gorm:"not null"`
+	FinishedAt  *time.Time
+}
+
+func (RetentionRunEntity) TableName() string {
+	return "retention_runs"
+}
+
+func NewRetentionRunEntity(trigger string, requestedBy uint) RetentionRunEntity {
+	return RetentionRunEntity{
+		TriggerType: trigger,
+		Status:      constants.RetentionRunStatusSucceeded,
+		RequestedBy: requestedBy,
+		StartedAt:   time.Now(),
+	}
+}
+
+// Finish 는 결과를 기록한다. 한 종류라도 실패하면 실행은 실패이다.
+func (e *RetentionRunEntity) Finish(results []dtos.RetentionResult) error {
+	for _, result := range results {
+		if len(result.ErrorMessage) > 0 {
+			e.Status = constants.RetentionRunStatusFailed
+		}
+	}
+
+	content, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	e.Results = string(content)
+	e.FinishedAt = &now
+	return nil
+}
+
+func (e RetentionRunEntity) GetResults() []dtos.RetentionResult {
+	results := make([]dtos.RetentionResult, 0)
+	if len(e.Results) > 0 {
+		json.Unmarshal([]byte(e.Results), &results)
+	}
+
+	return results
+}
+
+func (e RetentionRunEntity) ToInformation() dtos.RetentionRunInformation {
+	return dtos.RetentionRunInformation{
+		Id:          e.ID,
+		TriggerType: e.TriggerType,
+		Status:      e.Status,
+		Results:     e.GetResults(),
+		RequestedBy: e.RequestedBy,
+		StartedAt:   e.StartedAt,
+		FinishedAt:  e.FinishedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RetentionCondition 은 지울 행을 고르는 SQL 조건이다.
+type RetentionCondition struct {
+	Expression string
+	Values     []interface{}
+}
+
+// RetentionTarget 은 데이터 종류별로 지울 테이블과 보관 기간을 계산할 시각 컬럼이다.
+// MemberConditions 는 멤버의 데이터인지 확인하는 조건(? 는 멤버 Id)으로 법적 보존을 확인할 때 사용한다.
+type RetentionTarget struct {
+	Table            string
+	TimeColumn       string
+	MemberConditions []string
+	// Condition 은 보관 기간이 지나도 지울 수 있는 행의 조건이다(예. 결과 파일을 정리한 내보내기 작업).
+	Condition string
+}
+
+var retentionTargets = map[string]RetentionTarget{
+	constants.RetentionCategoryAccessLogs: {
+		Table:            "login_attempts",
+		TimeColumn:       "attempted_at",
+		MemberConditions: []string{"member_id = ?"},
+	},
+	constants.RetentionCategoryAuditLogs: {
+		Table:      "audit_logs",
+		TimeColumn: "created_at",
+		MemberConditions: []string{
+			"actor_type = '" + constants.AuditActorTypeMember + "' AND actor_id = ?",
+			"target_type = '" + constants.AuditTargetTypeMember + "' AND target_id = ?",
+		},
+	},
+	constants.RetentionCategoryNotifications: {
+		Table:            "login_notifications",
+		TimeColumn:       "created_at",
+		MemberConditions: []string{"member_id = ?"},
+	},
+	constants.RetentionCategoryExports: {
+		Table:            "export_jobs",
+		TimeColumn:       "created_at",
+		MemberConditions: []string{"requested_by = ?"},
+		Condition:        "file_id = 0",
+	},
+	constants.RetentionCategorySecurityEvents: {
+		Table:            "security_events",
+		TimeColumn:       "created_at",
+		MemberConditions: []string{"member_id = ?"},
+	},
+}
+
+func GetRetentionTarget(category string) (RetentionTarget, bool) {
+	target, ok := retentionTargets[category]
+	return target, ok
+}
+
+// RetentionCategories 는 보관 기간을 정할 수 있는 데이터 종류이다.
+func RetentionCategories() []string {
+	categories := make([]string, 0, len(retentionTargets))
+	for category := range retentionTargets {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	return categories
+}
+
+// Conditions 는 cutoff 전에 만들었고 법적 보존(holds) 대상이 아닌 행의 조건이다.
+func (t RetentionTarget) Conditions(cutoff time.Time, holds []LegalHoldEntity) []RetentionCondition {
+	conditions := t.ExpiredConditions(cutoff)
+	for _, hold := range holds {
+		conditions = append(conditions, t.holdCondition(hold))
+	}
+
+	return conditions
+}
+
+// ExpiredConditions 는 법적 보존과 관계없이 cutoff 전에 만든 행의 조건이다.
+func (t RetentionTarget) ExpiredConditions(cutoff time.Time) []RetentionCondition {
+	conditions := []RetentionCondition{{Expression: t.TimeColumn + " < ?", Values: []interface{}{cutoff}}}
+	if len(t.Condition) > 0 {
+		conditions = append(conditions, RetentionCondition{Expression: t.Condition})
+	}
+
+	return conditions
+}
+
+func (t RetentionTarget) holdCondition(hold LegalHoldEntity) RetentionCondition {
+	expressions := make([]string, 0)
+	values := make([]interface{}, 0)
+
+	if hold.MemberId > 0 {
+		memberExpressions := make([]string, 0, len(t.MemberConditions))
+		for _, memberCondition := range t.MemberConditions {
+			memberExpressions = append(memberExpressions, "("+memberCondition+")")
+			values = append(values, hold.MemberId)
+		}
+		expressions = append(expressions, "("+strings.Join(memberExpressions, " OR ")+")")
+	}
+	if hold.HeldFrom != nil {
+		expressions = append(expressions, t.TimeColumn+" >= ?")
+		values = append(values, *hold.HeldFrom)
+	}
+	if hold.HeldTo != nil {
+		expressions = append(expressions, t.TimeColumn+" <= ?")
+		values = append(values, *hold.HeldTo)
+	}
+
+	// NULL 인 컬럼(예. 대상이 없는 감사 로그)과 비교한 결과는 NULL 이므로 보존 대상이 아닌 것으로 본다.
+	return RetentionCondition{Expression: "NOT COALESCE((" + strings.Join(expressions, " AND ") + "), 0)", Values: values}
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/retention/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"strings"
+)
+
+type LegalHoldRepository struct {
+}
+
+func (LegalHoldRepository) Create(ctx context.Context, entity *domain.LegalHoldEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LegalHoldRepository) FindAll(ctx context.Context) ([]domain.LegalHoldEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.LegalHoldEntity, 0)
+	if err := db.Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// FindByMemberId 는 memberId 가 0 이면 멤버를 지정한 모든 법적 보존을 조회한다.
+func (LegalHoldRepository) FindByMemberId(ctx context.Context, memberId uint) ([]domain.LegalHoldEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if memberId > 0 {
+		db = db.Where("member_id = ?", memberId)
+	} else {
+		db = db.Where("member_id > 0")
+	}
+
+	var entities = make([]domain.LegalHoldEntity, 0)
+	if err := db.Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (LegalHoldRepository) FindById(ctx context.Context, id uint) (domain.LegalHoldEntity, error) {
+	var entity domain.LegalHoldEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (LegalHoldRepository) Delete(ctx context.Context, entity domain.LegalHoldEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+type RetentionRunRepository struct {
+}
+
+func (RetentionRunRepository) Create(ctx context.Context, entity *domain.RetentionRunEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RetentionRunRepository) FindAll(ctx context.Context, pageable dtos.Pageable) ([]domain.RetentionRunEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.RetentionRunEntity{})
+
+	var entities = make([]domain.RetentionRunEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (RetentionRunRepository) FindById(ctx context.Context, id uint) (domain.RetentionRunEntity, error) {
+	var entity domain.RetentionRunEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// RetentionDataRepository 는 데이터 종류(RetentionTarget)의 테이블에서 조건에 맞는 행을 세거나 지운다.
+// 보관 기간이 지난 데이터는 복구할 수 없도록 soft delete 한 행을 포함하여 완전히 지운다.
+type RetentionDataRepository struct {
+}
+
+func (RetentionDataRepository) Count(ctx context.Context, target domain.RetentionTarget, conditions []domain.RetentionCondition) (int64, error) {
+	where, values := joinConditions(conditions)
+
+	var count int64
+	if err := helpers.ContextHelper().GetDB(ctx).Raw("SELECT count(*) FROM "+target.Table+" WHERE "+where, values...).
+		Scan(&count).Error; err != nil {
+		return 0, pkgerrors.Wrap(err, "db error")
+	}
+
+	return count, nil
+}
+
+func (RetentionDataRepository) Delete(ctx context.Context, target domain.RetentionTarget, conditions []domain.RetentionCondition) (int64, error) {
+	where, values := joinConditions(conditions)
+
+	result := helpers.ContextHelper().GetDB(ctx).Exec("DELETE FROM "+target.Table+" WHERE "+where, values...)
+	if result.Error != nil {
+		return 0, pkgerrors.Wrap(result.Error, "db error")
+	}
+
+	return result.RowsAffected, nil
+}
+
+func joinConditions(conditions []domain.RetentionCondition) (string, []interface{}) {
+	expressions := make([]string, 0, len(conditions))
+	values := make([]interface{}, 0)
+	for _, condition := range conditions {
+		expressions = append(expressions, "("+condition.Expression+")")
+		values = append(values, condition.Values...)
+	}
+
+	return strings.Join(expressions, " AND "), values
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	pkgerrors "github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronSchedule 은 "분 시 일 월 요일" 5개 필드로 된 cron 표현식이다.
+// 각 필드는 *, 숫자, 범위(1-5), 목록(1,3,5), 간격(*/15, 1-30/5)을 지원한다.
+type CronSchedule struct {
+	minutes     map[int]bool
+	hours       map[int]bool
+	daysOfMonth map[int]bool
+	months      map[int]bool
+	daysOfWeek  map[int]bool
+	// 일과 요일이 모두 지정되면 둘 중 하나만 맞아도 실행한다.(표준 cron 동작)
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type cronField struct {
+	min int
+	max int
+}
+
+var cronFields = []cronField{
+	{0, 59}, // 분
+	{0, 23}, // 시
+	{1, 31}, // 일
+	{1, 12}, // 월
+	{0, 6},  // 요일 (0: 일요일)
+}
+
+func ParseCron(expression string) (CronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return CronSchedule{}, pkgerrors.Errorf("cron expression must have %d fields: %q", len(cronFields), expression)
+	}
+
+	values := make([]map[int]bool, len(fields))
+	for i, field := range fields {
+		value, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return CronSchedule{}, err
+		}
+		values[i] = value
+	}
+
+	return CronSchedule{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    values[4],
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, bounds cronField) (map[int]bool, error) {
+	values := map[int]bool{}
+
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			parsedStep, err := strconv.Atoi(part[i+1:])
+			if err != nil || parsedStep <= 0 {
+				return nil, pkgerrors.Errorf("invalid cron step: %q", part)
+			}
+			step = parsedStep
+			part = part[:i]
+		}
+
+		start, end := bounds.min, bounds.max
+		if part != "*" {
+			rangeValues := strings.SplitN(part, "-", 2)
+			parsedStart, err := strconv.Atoi(rangeValues[0])
+			if err != nil {
+				return nil, pkgerrors.Errorf("invalid cron value: %q", part)
+			}
+			start, end = parsedStart, parsedStart
+
+			if len(rangeValues) == 2 {
+				parsedEnd, err := strconv.Atoi(rangeValues[1])
+				if err != nil {
+					return nil, pkgerrors.Errorf("invalid cron value: %q", part)
+				}
+				end = parsedEnd
+			} else if step > 1 {
+				// 5/15 처럼 시작 값만 있는 간격은 최대 값까지 반복한다.
+				end = bounds.max
+			}
+		}
+
+		if start < bounds.min || end > bounds.max || start > end {
+			return nil, pkgerrors.Errorf("cron value out of range: %q", part)
+		}
+
+		for value := start; value <= end; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+// Next 는 after 이후(after 는 제외) 처음으로 실행할 시간을 분 단위로 반환한다.
+func (c CronSchedule) Next(after time.Time) time.Time {
+	next := after.Truncate(time.Minute).Add(time.Minute)
+	// 실행할 수 없는 표현식(예. 2월 30일)에서 무한히 찾지 않도록 5년 안에서만 찾는다.
+	limit := next.AddDate(5, 0, 0)
+
+	for next.Before(limit) {
+		if !c.months[int(next.Month())] {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+
+		if !c.matchDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+
+		if !c.hours[next.Hour()] {
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+			continue
+		}
+
+		if !c.minutes[next.Minute()] {
+			next = next.Add(time.Minute)
+			continue
+		}
+
+		return next
+	}
+
+	return time.Time{}
+}
+
+func (c CronSchedule) matchDay(t time.Time) bool {
+	dayOfMonth := c.daysOfMonth[t.Day()]
+	dayOfWeek := c.daysOfWeek[int(t.Weekday())]
+
+	if c.anyDayOfMonth || c.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
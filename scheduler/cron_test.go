@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCronSchedule_Next(t *testing.T) {
+	// 2022-03-01 은 화요일이다.
+	base := time.Date(2022, 3, 1, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"* * * * *", time.Date(2022, 3, 1, 10, 8, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2022, 3, 1, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2022, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{"30 8 * * 1-5", time.Date(2022, 3, 2, 8, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 6 * * 0", time.Date(2022, 3, 6, 6, 0, 0, 0, time.UTC)},
+		// 일과 요일이 모두 지정되면 둘 중 하나만 맞아도 된다.
+		{"0 12 15 * 5", time.Date(2022, 3, 4, 12, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		schedule, err := ParseCron(test.expression)
+		assert.Nil(t, err, test.expression)
+		assert.Equal(t, test.expected, schedule.Next(base), test.expression)
+	}
+}
+
+func TestParseCron_잘못된_표현식(t *testing.T) {
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *"} {
+		_, err := ParseCron(expression)
+		assert.NotNil(t, err, expression)
+	}
+}
+
+func TestCronSchedule_Next_실행할_수_없는_표현식(t *testing.T) {
+	schedule, err := ParseCron("0 0 30 2 *")
+	assert.Nil(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
@@ -0,0 +1,220 @@
+package scheduler
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/cluster"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"sync"
+	"time"
+)
+
+// 마지막 실행 결과를 공유 상태 저장소에 저장하는 key 의 접두사
+const lastRunKeyPrefix = "scheduler:last-run:"
+
+// Job 은 주기적으로 실행되는 작업이다. 실행할 때마다 DB 트랜잭션이 Context 에 설정된다.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+var (
+	mutex      sync.Mutex
+	jobs       []Job
+	stopped    chan struct{}
+	leadership *cluster.Leadership
+	locker     cluster.Locker
+)
+
+// Register 는 작업을 등록한다. 같은 이름의 작업이 있으면 교체한다.
+func Register(job Job) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for i := range jobs {
+		if jobs[i].Name == job.Name {
+			jobs[i] = job
+			return
+		}
+	}
+	jobs = append(jobs, job)
+}
+
+// Start 는 리더 선출에 참여하고 작업을 주기마다 실행한다. 여러 인스턴스 중 리더 인스턴스만 실행한다.
+func Start(db *gorm.DB) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if stopped != nil {
+		return
+	}
+	stopped = make(chan struct{})
+
+	locker = newLocker(db)
+	leadership = cluster.NewLeadership(constants.ClusterLeaderScheduler, leaderTtl(), locker)
+	leadership.Start()
+
+	for _, job := range jobs {
+		go runPeriodically(db, job, stopped, leadership, locker)
+	}
+}
+
+// Stop 은 리더에서 물러나 다른 인스턴스가 바로 리더가 되게 한다.
+func Stop() {
+	mutex.Lock()
+	if stopped == nil {
+		mutex.Unlock()
+		return
+	}
+	close(stopped)
+	stopped = nil
+	stoppedLeadership := leadership
+	mutex.Unlock()
+
+	stoppedLeadership.Stop()
+}
+
+func newLocker(db *gorm.DB) cluster.Locker {
+	if config.Config.Scheduler.LeaderElection == constants.LeaderElectionDatabase {
+		return cluster.NewDatabaseLocker(db)
+	}
+
+	return cluster.SharedStateLocker()
+}
+
+func leaderTtl() time.Duration {
+	if config.Config.Scheduler.LeaderTtlSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(config.Config.Scheduler.LeaderTtlSeconds) * time.Second
+}
+
+func runPeriodically(db *gorm.DB, job Job, stopped chan struct{}, leadership *cluster.Leadership, locker cluster.Locker) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopped:
+			return
+		case <-ticker.C:
+			runIfLeader(db, job, leadership, locker)
+		}
+	}
+}
+
+// runIfLeader 는 리더이면 작업을 실행한다.
+// 리더가 바뀌는 동안 이전 리더가 아직 실행 중일 수 있으므로 작업마다 잠그고, 실행 중인 작업은 다시 실행하지 않는다.
+func runIfLeader(db *gorm.DB, job Job, leadership *cluster.Leadership, locker cluster.Locker) bool {
+	if !leadership.IsLeader() {
+		return false
+	}
+
+	executed, err := cluster.RunExclusiveWithLocker(locker, jobLockName(job.Name), leaderTtl(), func() { RunJob(db, job) })
+	if err != nil {
+		log.Errorf("scheduled job(%v) lock error: %v", job.Name, err)
+	}
+	return executed
+}
+
+// RunJob 은 작업을 한 번 실행한다. 작업이 실패하면 트랜잭션을 롤백하고 로그를 남긴다.
+func RunJob(db *gorm.DB, job Job) {
+	run := dtos.ScheduledJobRun{InstanceId: cluster.InstanceId(), StartedAt: time.Now()}
+	var err error
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("scheduled job(%v) panic: %v", job.Name, r)
+			err = pkgerrors.Errorf("panic: %v", r)
+		}
+
+		run.DurationMilliseconds = time.Since(run.StartedAt).Milliseconds()
+		if err != nil {
+			run.Error = err.Error()
+		}
+		saveLastRun(job.Name, run)
+	}()
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		return job.Run(helpers.ContextHelper().SetDB(context.Background(), tx))
+	})
+
+	if err != nil {
+		log.Errorf("scheduled job(%v) error: %v", job.Name, err)
+	}
+}
+
+// GetSchedule 은 리더 인스턴스와 작업별 실행 현황을 조회한다. 마지막 실행 결과는 공유 상태 저장소(SharedState.Backend)에 저장한다.
+func GetSchedule(ctx context.Context) (dtos.JobSchedule, error) {
+	mutex.Lock()
+	registered := append([]Job{}, jobs...)
+	currentLeadership := leadership
+	currentLocker := locker
+	mutex.Unlock()
+
+	// 스케줄러를 시작하지 않았으면(예. 명령 실행) 요청의 DB 로 조회한다.
+	if currentLocker == nil {
+		currentLocker = newLocker(helpers.ContextHelper().GetDB(ctx))
+	}
+
+	leader, _, err := cluster.Leader(currentLocker, constants.ClusterLeaderScheduler)
+	if err != nil {
+		return dtos.JobSchedule{}, err
+	}
+
+	schedule := dtos.JobSchedule{
+		InstanceId:     cluster.InstanceId(),
+		LeaderElection: config.Config.Scheduler.LeaderElection,
+		Leader:         leader,
+		IsLeader:       currentLeadership != nil && currentLeadership.IsLeader(),
+		Jobs:           make([]dtos.ScheduledJob, 0, len(registered)),
+	}
+
+	for _, job := range registered {
+		scheduledJob := dtos.ScheduledJob{Name: job.Name, IntervalSeconds: int64(job.Interval / time.Second)}
+		if scheduledJob.RunningOn, _, err = cluster.LockOwner(currentLocker, jobLockName(job.Name)); err != nil {
+			return dtos.JobSchedule{}, err
+		}
+		if scheduledJob.LastRun, err = loadLastRun(job.Name); err != nil {
+			return dtos.JobSchedule{}, err
+		}
+		schedule.Jobs = append(schedule.Jobs, scheduledJob)
+	}
+
+	return schedule, nil
+}
+
+func jobLockName(name string) string {
+	return constants.ClusterLeaderScheduler + ":" + name
+}
+
+func saveLastRun(name string, run dtos.ScheduledJobRun) {
+	content, err := json.Marshal(run)
+	if err != nil {
+		return
+	}
+
+	if err := adapters.SharedStateAdapter().Store().Set(lastRunKeyPrefix+name, string(content), 0); err != nil {
+		log.Warnf("scheduled job(%v) last run save error: %v", name, err)
+	}
+}
+
+func loadLastRun(name string) (*dtos.ScheduledJobRun, error) {
+	content, ok, err := adapters.SharedStateAdapter().Store().Get(lastRunKeyPrefix + name)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var run dtos.ScheduledJobRun
+	if err := json.Unmarshal([]byte(content), &run); err != nil {
+		return nil, pkgerrors.Wrap(err, "scheduled job last run error")
+	}
+	return &run, nil
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/cluster"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestRunIfLeader(t *testing.T) {
+	adapters.SharedStateAdapter().SetStore(adapters.NewMemorySharedStore())
+	defer adapters.SharedStateAdapter().SetStore(nil)
+
+	gormDB, err := gorm.Open(sqlite.Open("file:scheduler_test?mode=memory&cache=shared"), &gorm.Config{})
+	assert.NoError(t, err)
+
+	// given
+	runs := 0
+	job := Job{Name: "test-job", Interval: time.Minute, Run: func(ctx context.Context) error {
+		runs++
+		return nil
+	}}
+	locker := cluster.SharedStateLocker()
+	leadership := cluster.NewLeadership("test-scheduler", time.Minute, locker)
+
+	// when
+	// 리더가 아니면 실행하지 않는다.
+	assert.False(t, runIfLeader(gormDB, job, leadership, locker))
+
+	leadership.Start()
+	defer leadership.Stop()
+	assert.Eventually(t, leadership.IsLeader, time.Second, 10*time.Millisecond)
+
+	// 다른 인스턴스(이전 리더)가 실행 중이면 실행하지 않는다.
+	locker.AcquireLock("lock:scheduler:test-job", "previous-leader", time.Minute)
+	assert.False(t, runIfLeader(gormDB, job, leadership, locker))
+	locker.ReleaseLock("lock:scheduler:test-job", "previous-leader")
+
+	// then
+	assert.True(t, runIfLeader(gormDB, job, leadership, locker))
+	assert.Equal(t, 1, runs)
+
+	lastRun, err := loadLastRun("test-job")
+	assert.NoError(t, err)
+	assert.Equal(t, cluster.InstanceId(), lastRun.InstanceId)
+	assert.Empty(t, lastRun.Error)
+}
@@ -0,0 +1,54 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"context"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"sync"
+)
+
+// ClaimEnricher 는 배포 환경에 따라 필요한 클레임(예. 사번, 테넌트, 로케일)을 Access 토큰에 추가한다.
+// 사용할 Enricher 는 설정(JwtClaimEnrichment.Enrichers)에 등록된 이름으로 결정된다.
+type ClaimEnricher interface {
+	Enrich(ctx context.Context, claim UserClaim) (map[string]interface{}, error)
+}
+
+var (
+	claimEnricherMutex sync.RWMutex
+	claimEnrichers     = map[string]ClaimEnricher{}
+)
+
+func RegisterClaimEnricher(name string, enricher ClaimEnricher) {
+	claimEnricherMutex.Lock()
+	defer claimEnricherMutex.Unlock()
+
+	claimEnrichers[name] = enricher
+}
+
+func enrichClaim(ctx context.Context, claim *UserClaim) error {
+	claimEnricherMutex.RLock()
+	defer claimEnricherMutex.RUnlock()
+
+	for _, name := range config.Config.JwtClaimEnrichment.Enrichers {
+		enricher, ok := claimEnrichers[name]
+		if !ok {
+			log.Warnf("Not registered claim enricher: %s", name)
+			continue
+		}
+
+		extraClaims, err := enricher.Enrich(ctx, *claim)
+		if err != nil {
+			return errors.Wrapf(err, "%s claim enricher error", name)
+		}
+
+		for key, value := range extraClaims {
+			if claim.Extra == nil {
+				claim.Extra = map[string]interface{}{}
+			}
+			claim.Extra[key] = value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testClaimEnricher struct {
+}
+
+func (testClaimEnricher) Enrich(ctx context.Context, claim UserClaim) (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"tenant": "bettercode",
+		"id":     999,
+	}, nil
+}
+
+func TestJwtAuthentication_GenerateJwtToken_ClaimEnricher(t *testing.T) {
+	// given
+	config.Config.JwtSecret = "test-secret"
+	config.Config.JwtClaimEnrichment.Enrichers = []string{"test", "not-registered"}
+	defer func() {
+		config.Config.JwtClaimEnrichment.Enrichers = nil
+	}()
+	RegisterClaimEnricher("test", testClaimEnricher{})
+
+	// when
+	token, err := JwtAuthentication{}.GenerateJwtToken(context.Background(), UserClaim{
+		Id:          1,
+		Roles:       []string{},
+		Permissions: []string{},
+	})
+
+	// then
+	assert.Nil(t, err)
+	userClaim, err := JwtAuthentication{}.ConvertTokenUserClaim(token.AccessToken)
+	assert.Nil(t, err)
+	// 예약된 클레임(id)은 Enricher 가 덮어쓸 수 없다.
+	assert.Equal(t, uint(1), userClaim.Id)
+	assert.Equal(t, map[string]interface{}{"tenant": "bettercode"}, userClaim.Extra)
+}
@@ -0,0 +1,114 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// 쿠키 코덱은 쿠키 값(예. Refresh 토큰, CSRF seed)을 AES-GCM 으로 암호화하고 서명한다.
+// 쿠키 이름을 추가 인증 데이터로 사용하므로 다른 이름의 쿠키로 옮긴 값은 복호화되지 않는다.
+const cookieKeyPrefix = "cookie:"
+
+var InvalidCookie = errors.New("invalid cookie")
+
+type CookieCodec struct {
+}
+
+// Encode 는 현재 키(config.CookieEncryption.Keys 의 첫 번째 키)로 값을 암호화한다.
+func (CookieCodec) Encode(name string, value string) (string, error) {
+	keys, err := getCookieKeys()
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newCookieCipher(keys[0])
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "create cookie nonce error")
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(value), []byte(name))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decode 는 현재 키와 이전 키로 차례로 복호화한다. 이전 키로 복호화했으면 rotated 가 true 이고, 현재 키로 다시 암호화한 쿠키를 내려줘야 한다.
+func (CookieCodec) Decode(name string, value string) (decoded string, rotated bool, err error) {
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return "", false, InvalidCookie
+	}
+
+	keys, err := getCookieKeys()
+	if err != nil {
+		return "", false, err
+	}
+
+	for i, key := range keys {
+		aead, err := newCookieCipher(key)
+		if err != nil {
+			return "", false, err
+		}
+
+		if len(sealed) < aead.NonceSize() {
+			return "", false, InvalidCookie
+		}
+
+		plain, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], []byte(name))
+		if err == nil {
+			return string(plain), i > 0, nil
+		}
+	}
+
+	return "", false, InvalidCookie
+}
+
+// getCookieKeys 는 SecretProvider 에서 조회한 쿠키 키(config.SecretCookieEncryptionKeys)이다. 비어 있으면 JWT Secret 을 사용한다.
+func getCookieKeys() ([]string, error) {
+	secret, err := config.GetSecret(config.SecretCookieEncryptionKeys)
+	if err != nil {
+		return nil, errors.Wrap(err, "get cookie encryption keys error")
+	}
+
+	var keys []string
+	for _, key := range strings.Split(secret, ",") {
+		if len(key) > 0 {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		jwtSecret, err := config.GetJwtSecret()
+		if err != nil {
+			return nil, errors.Wrap(err, "get jwt secret error")
+		}
+		return []string{jwtSecret}, nil
+	}
+
+	return keys, nil
+}
+
+func newCookieCipher(key string) (cipher.AEAD, error) {
+	// 설정한 키의 길이와 관계없이 AES-256 키를 사용한다.
+	derivedKey := sha256.Sum256([]byte(cookieKeyPrefix + key))
+	block, err := aes.NewCipher(derivedKey[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "create cookie cipher error")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "create cookie cipher error")
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,56 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCookieCodec_Encode_Decode(t *testing.T) {
+	keys := config.Config.CookieEncryption.Keys
+	defer func() { config.Config.CookieEncryption.Keys = keys }()
+	config.Config.CookieEncryption.Keys = []string{"current-cookie-key"}
+
+	// given
+	encoded, err := CookieCodec{}.Encode("refreshToken", "test-refresh-token")
+	assert.Nil(t, err)
+	assert.NotContains(t, encoded, "test-refresh-token")
+
+	// when
+	decoded, rotated, err := CookieCodec{}.Decode("refreshToken", encoded)
+	_, _, otherNameErr := CookieCodec{}.Decode("csrfSeed", encoded)
+	tampered := "A" + encoded[1:]
+	if encoded[0] == 'A' {
+		tampered = "B" + encoded[1:]
+	}
+	_, _, tamperedErr := CookieCodec{}.Decode("refreshToken", tampered)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, "test-refresh-token", decoded)
+	assert.False(t, rotated)
+	assert.Equal(t, InvalidCookie, otherNameErr)
+	assert.Equal(t, InvalidCookie, tamperedErr)
+}
+
+func TestCookieCodec_Decode_이전_키(t *testing.T) {
+	keys := config.Config.CookieEncryption.Keys
+	defer func() { config.Config.CookieEncryption.Keys = keys }()
+
+	// given
+	config.Config.CookieEncryption.Keys = []string{"previous-cookie-key"}
+	encoded, _ := CookieCodec{}.Encode("refreshToken", "test-refresh-token")
+
+	// when
+	config.Config.CookieEncryption.Keys = []string{"current-cookie-key", "previous-cookie-key"}
+	decoded, rotated, err := CookieCodec{}.Decode("refreshToken", encoded)
+
+	config.Config.CookieEncryption.Keys = []string{"current-cookie-key"}
+	_, _, removedKeyErr := CookieCodec{}.Decode("refreshToken", encoded)
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, "test-refresh-token", decoded)
+	assert.True(t, rotated)
+	assert.Equal(t, InvalidCookie, removedKeyErr)
+}
@@ -0,0 +1,40 @@
+package security
+
+import (
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// 토큰 교환(RFC 8693)으로 발급한 토큰은 aud 의 서비스에서만 사용하며(설정의 JwtClaims 참고), act 에 멤버 대신 요청한 서비스 계정을 기록한다.
+const (
+	claimKeyAudience = "aud"
+	claimKeyActor    = "act"
+)
+
+// GenerateExchangedToken 은 claim 의 멤버 대신 actorClientId 의 서비스 계정이 audience 서비스를 호출할 때 사용할 Access 토큰을 발급한다.
+func (JwtAuthentication) GenerateExchangedToken(claim UserClaim, audience string, actorClientId string, lifetime time.Duration) (string, time.Time, error) {
+	claimMap, err := claim.ConvertMap()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	exchangedTokenClaims := jwt.MapClaims{}
+	for key, value := range claimMap {
+		exchangedTokenClaims[key] = value
+	}
+
+	expiresAt := time.Now().Add(lifetime)
+	exchangedTokenClaims["exp"] = expiresAt.Unix()
+	exchangedTokenClaims[claimKeyAudience] = audience
+	exchangedTokenClaims[claimKeyActor] = map[string]interface{}{"sub": actorClientId}
+	exchangedTokenClaims[claimKeyTokenEpoch] = GetTokenEpoch()
+	setIssuerAndAudience(exchangedTokenClaims)
+
+	token, err := signToken(exchangedTokenClaims)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "create exchanged token error")
+	}
+
+	return token, expiresAt, nil
+}
@@ -2,22 +2,36 @@ package security
 
 import (
 	"better-admin-backend-service/config"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/golang-jwt/jwt"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"strings"
 	"time"
 )
 
 // https://docs.apigee.com/api-platform/reference/policies/oauth-http-status-code-reference
 var InvalidAccessToken = errors.New("invalid access token")
 var AccessTokenExpired = errors.New("access token expired")
+var TokenRevoked = errors.New("token revoked")
+
+const (
+	AccessTokenLifetime  = time.Minute * 15
+	RefreshTokenLifetime = time.Hour * 24 * 7
+
+	PrincipalTypeServiceAccount = "service-account"
+)
 
 type JwtAuthentication struct {
 }
 
-func (JwtAuthentication) GenerateJwtToken(claim UserClaim) (JwtToken, error) {
+func (JwtAuthentication) GenerateJwtToken(ctx context.Context, claim UserClaim) (JwtToken, error) {
+	if err := enrichClaim(ctx, &claim); err != nil {
+		return JwtToken{}, err
+	}
+
 	claimMap, err := claim.ConvertMap()
 	if err != nil {
 		return JwtToken{}, err
@@ -28,8 +42,10 @@ func (JwtAuthentication) GenerateJwtToken(claim UserClaim) (JwtToken, error) {
 		accessTokenClaims[key] = value
 	}
 
-	accessTokenClaims["exp"] = time.Now().Add(time.Minute * 15).Unix()
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims).SignedString([]byte(config.Config.JwtSecret))
+	accessTokenClaims["exp"] = time.Now().Add(AccessTokenLifetime).Unix()
+	accessTokenClaims[claimKeyTokenEpoch] = tokenEpochOf(ctx)
+	setIssuerAndAudience(accessTokenClaims)
+	accessToken, err := signTokenInContext(ctx, accessTokenClaims)
 
 	if err != nil {
 		return JwtToken{}, errors.Wrap(err, "create accessToken error")
@@ -40,9 +56,11 @@ func (JwtAuthentication) GenerateJwtToken(claim UserClaim) (JwtToken, error) {
 		refreshTokenClaims[key] = value
 	}
 
-	refreshTokenExpires := time.Now().Add(time.Hour * 24 * 7)
+	refreshTokenExpires := time.Now().Add(RefreshTokenLifetime)
 	refreshTokenClaims["exp"] = refreshTokenExpires.Unix()
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshTokenClaims).SignedString([]byte(config.Config.JwtSecret))
+	refreshTokenClaims[claimKeyTokenEpoch] = tokenEpochOf(ctx)
+	setIssuerAndAudience(refreshTokenClaims)
+	refreshToken, err := signTokenInContext(ctx, refreshTokenClaims)
 
 	if err != nil {
 		return JwtToken{}, errors.Wrap(err, "create refreshToken error")
@@ -55,7 +73,7 @@ func (JwtAuthentication) GenerateJwtToken(claim UserClaim) (JwtToken, error) {
 	}, nil
 }
 
-func (JwtAuthentication) GenerateJwtAccessTokenNeverExpired(claim UserClaim) (string, error) {
+func (JwtAuthentication) GenerateJwtAccessTokenNeverExpired(ctx context.Context, claim UserClaim) (string, error) {
 	claimMap, err := claim.ConvertMap()
 	if err != nil {
 		return "", err
@@ -65,8 +83,10 @@ func (JwtAuthentication) GenerateJwtAccessTokenNeverExpired(claim UserClaim) (st
 	for key, value := range claimMap {
 		accessTokenClaims[key] = value
 	}
+	accessTokenClaims[claimKeyTokenEpoch] = tokenEpochOf(ctx)
+	setIssuerAndAudience(accessTokenClaims)
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims).SignedString([]byte(config.Config.JwtSecret))
+	accessToken, err := signTokenInContext(ctx, accessTokenClaims)
 
 	if err != nil {
 		return "", errors.Wrap(err, "create accessToken error")
@@ -75,8 +95,76 @@ func (JwtAuthentication) GenerateJwtAccessTokenNeverExpired(claim UserClaim) (st
 	return accessToken, nil
 }
 
-func (JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error) {
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) { return []byte(config.Config.JwtSecret), nil })
+// GenerateJwtAccessToken 은 Refresh 토큰 없이 지정한 수명의 Access 토큰만 발급한다.(예. client_credentials 그랜트)
+func (JwtAuthentication) GenerateJwtAccessToken(claim UserClaim, lifetime time.Duration) (string, time.Time, error) {
+	claimMap, err := claim.ConvertMap()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	accessTokenClaims := jwt.MapClaims{}
+	for key, value := range claimMap {
+		accessTokenClaims[key] = value
+	}
+
+	expiresAt := time.Now().Add(lifetime)
+	accessTokenClaims["exp"] = expiresAt.Unix()
+	accessTokenClaims[claimKeyTokenEpoch] = GetTokenEpoch()
+	setIssuerAndAudience(accessTokenClaims)
+
+	accessToken, err := signToken(accessTokenClaims)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "create accessToken error")
+	}
+
+	return accessToken, expiresAt, nil
+}
+
+// jwtSigningKey 는 SecretProvider 에서 조회한 JWT 서명 키이다.
+func jwtSigningKey() ([]byte, error) {
+	secret, err := config.GetJwtSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "get jwt secret error")
+	}
+
+	return []byte(secret), nil
+}
+
+func signToken(claims jwt.MapClaims) (string, error) {
+	key, err := jwtSigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+}
+
+// signTokenInContext 는 커밋하지 않은 JWT Secret(WithPendingTokenSigning)이 있으면 그 Secret 으로 서명한다.
+func signTokenInContext(ctx context.Context, claims jwt.MapClaims) (string, error) {
+	if pending, ok := ctx.Value(pendingTokenSigningKey{}).(pendingTokenSigning); ok && len(pending.secret) > 0 {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(pending.secret))
+	}
+
+	return signToken(claims)
+}
+
+func (jwtAuthentication JwtAuthentication) parseToken(token string) (jwt.MapClaims, error) {
+	claimInfo, err := jwtAuthentication.verifyToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateIssuerAndAudience(claimInfo); err != nil {
+		log.Error(fmt.Sprintf("Error: jwt token issuer(%v) or audience(%v) is not accepted", claimInfo[claimKeyIssuer], claimInfo[claimKeyAudience]))
+		return nil, err
+	}
+
+	return claimInfo, nil
+}
+
+// verifyToken 은 서명, 만료 시간과 토큰 세대(epoch)를 확인한다. iss, aud 는 확인하지 않는다.
+func (JwtAuthentication) verifyToken(token string) (jwt.MapClaims, error) {
+	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) { return jwtSigningKey() })
 
 	if err != nil {
 		log.Error("JWT parsing error: " + err.Error())
@@ -104,6 +192,25 @@ func (JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error)
 		return nil, InvalidAccessToken
 	}
 
+	if epoch, _ := claimInfo[claimKeyTokenEpoch].(float64); uint(epoch) != GetTokenEpoch() {
+		return nil, TokenRevoked
+	}
+
+	return claimInfo, nil
+}
+
+func (jwtAuthentication JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error) {
+	claimInfo, err := jwtAuthentication.parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// 스코프 토큰은 지정된 리소스에만, OAuth 상태 토큰은 로그인 콜백에만, 단계 인증 토큰은 본인 확인에만 사용할 수 있으므로 일반 토큰으로 사용할 수 없다.
+	if claimInfo[claimKeyTokenType] == tokenTypeScoped || claimInfo[claimKeyTokenType] == tokenTypeOAuthState ||
+		claimInfo[claimKeyTokenType] == tokenTypeStepUp {
+		return nil, InvalidAccessToken
+	}
+
 	userClaim, err := NewUserClaim(claimInfo)
 	if err != nil {
 		return nil, err
@@ -112,13 +219,13 @@ func (JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error)
 	return &userClaim, nil
 }
 
-func (jwtAuthentication JwtAuthentication) RefreshAccessToken(refreshToken string) (string, error) {
+func (jwtAuthentication JwtAuthentication) RefreshAccessToken(ctx context.Context, refreshToken string) (string, error) {
 	userClaim, err := jwtAuthentication.ConvertTokenUserClaim(refreshToken)
 	if err != nil {
 		return "", err
 	}
 
-	jwtToken, err := jwtAuthentication.GenerateJwtToken(*userClaim)
+	jwtToken, err := jwtAuthentication.GenerateJwtToken(ctx, *userClaim)
 	if err != nil {
 		return "", err
 	}
@@ -131,6 +238,21 @@ func (jwtAuthentication JwtAuthentication) ValidateToken(token string) error {
 	return err
 }
 
+// GetTokenExpires 는 토큰의 만료 시간(exp)을 반환한다.
+func (jwtAuthentication JwtAuthentication) GetTokenExpires(token string) (time.Time, error) {
+	claimInfo, err := jwtAuthentication.parseToken(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	exp, ok := claimInfo["exp"].(float64)
+	if !ok {
+		return time.Time{}, InvalidAccessToken
+	}
+
+	return time.Unix(int64(exp), 0), nil
+}
+
 type JwtToken struct {
 	AccessToken         string
 	RefreshToken        string
@@ -148,6 +270,15 @@ type UserClaim struct {
 	Id          uint     `json:"id"`
 	Roles       []string `json:"roles"`
 	Permissions []string `json:"permissions"`
+	SessionId   string   `json:"sessionId,omitempty"`
+	// PrincipalType 이 비어 있으면 멤버, service-account 이면 서비스 계정이다.
+	PrincipalType string `json:"principalType,omitempty"`
+	// Extra 는 ClaimEnricher 가 추가한 클레임으로 토큰에는 최상위 클레임으로 기록된다.
+	Extra map[string]interface{} `json:"-"`
+}
+
+func (c UserClaim) IsServiceAccount() bool {
+	return c.PrincipalType == PrincipalTypeServiceAccount
 }
 
 func (c UserClaim) ConvertMap() (map[string]interface{}, error) {
@@ -162,6 +293,13 @@ func (c UserClaim) ConvertMap() (map[string]interface{}, error) {
 		return nil, errors.Wrap(err, "JSON Unmarshal error")
 	}
 
+	for key, value := range c.Extra {
+		if isReservedClaimKey(key) {
+			continue
+		}
+		resultMap[key] = value
+	}
+
 	return resultMap, nil
 }
 
@@ -176,5 +314,25 @@ func NewUserClaim(mapUserClaim map[string]interface{}) (UserClaim, error) {
 		return UserClaim{}, errors.Wrap(err, "JSON Unmarshal error")
 	}
 
+	for key, value := range mapUserClaim {
+		if isReservedClaimKey(key) {
+			continue
+		}
+
+		if claim.Extra == nil {
+			claim.Extra = map[string]interface{}{}
+		}
+		claim.Extra[key] = value
+	}
+
 	return claim, nil
 }
+
+func isReservedClaimKey(key string) bool {
+	switch strings.ToLower(key) {
+	case "id", "roles", "permissions", "sessionid", "principaltype", "exp", claimKeyTokenEpoch, claimKeyTokenType, claimKeyIssuer, claimKeyAudience, claimKeyActor:
+		return true
+	}
+
+	return false
+}
@@ -1,6 +1,8 @@
 package security
 
 import (
+	"better-admin-backend-service/config"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -23,3 +25,99 @@ func TestJwtToken_GetRefreshTokenExpiresForCookie(t *testing.T) {
 	expected := now.Add(9 * time.Hour)
 	assert.Equal(t, expected, actual)
 }
+
+func TestJwtAuthentication_ConvertTokenUserClaim_iss_aud(t *testing.T) {
+	jwtClaims := config.Config.JwtClaims
+	defer func() { config.Config.JwtClaims = jwtClaims }()
+
+	jwtAuthentication := JwtAuthentication{}
+	claim := UserClaim{Id: 1, Permissions: []string{"MANAGE_MEMBERS"}}
+
+	// given
+	config.Config.JwtClaims.Issuer = ""
+	config.Config.JwtClaims.Audience = ""
+	config.Config.JwtClaims.AcceptedAudiences = nil
+	tokenWithoutAudience, _, err := jwtAuthentication.GenerateJwtAccessToken(claim, time.Minute)
+	assert.NoError(t, err)
+
+	config.Config.JwtClaims.Issuer = "better-admin"
+	config.Config.JwtClaims.Audience = "admin-frontend"
+	frontendToken, _, err := jwtAuthentication.GenerateJwtAccessToken(claim, time.Minute)
+	assert.NoError(t, err)
+
+	config.Config.JwtClaims.Audience = "internal-api"
+	internalToken, _, err := jwtAuthentication.GenerateJwtAccessToken(claim, time.Minute)
+	assert.NoError(t, err)
+
+	config.Config.JwtClaims.Issuer = "other-issuer"
+	otherIssuerToken, _, err := jwtAuthentication.GenerateJwtAccessToken(claim, time.Minute)
+	assert.NoError(t, err)
+
+	// when
+	config.Config.JwtClaims.Issuer = "better-admin"
+	config.Config.JwtClaims.Audience = "admin-frontend"
+	config.Config.JwtClaims.AcceptedAudiences = []string{"admin-mobile"}
+
+	// then
+	userClaim, err := jwtAuthentication.ConvertTokenUserClaim(frontendToken)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), userClaim.Id)
+	assert.Empty(t, userClaim.Extra)
+
+	_, err = jwtAuthentication.ConvertTokenUserClaim(internalToken)
+	assert.Equal(t, InvalidAccessToken, err)
+	_, err = jwtAuthentication.ConvertTokenUserClaim(otherIssuerToken)
+	assert.Equal(t, InvalidAccessToken, err)
+	_, err = jwtAuthentication.ConvertTokenUserClaim(tokenWithoutAudience)
+	assert.Equal(t, InvalidAccessToken, err)
+
+	config.Config.JwtClaims.AcceptedAudiences = []string{"admin-mobile", "internal-api"}
+	_, err = jwtAuthentication.ConvertTokenUserClaim(internalToken)
+	assert.NoError(t, err)
+}
+
+type testSecretProvider map[string]string
+
+func (p testSecretProvider) GetSecret(name string) (string, error) {
+	secret, ok := p[name]
+	if !ok {
+		return "", errors.New("secret store unavailable")
+	}
+
+	return secret, nil
+}
+
+func TestJwtAuthentication_SecretProvider(t *testing.T) {
+	defer config.RegisterSecretProvider(nil)
+	jwtAuthentication := JwtAuthentication{}
+	claim := UserClaim{Id: 1, Permissions: []string{"MANAGE_MEMBERS"}}
+
+	// given
+	config.RegisterSecretProvider(testSecretProvider{
+		config.SecretJwt:                  "provider-secret",
+		config.SecretCookieEncryptionKeys: "current-cookie-key,previous-cookie-key",
+	})
+	token, _, err := jwtAuthentication.GenerateJwtAccessToken(claim, time.Minute)
+	assert.NoError(t, err)
+	encoded, err := CookieCodec{}.Encode("refreshToken", "test-refresh-token")
+	assert.NoError(t, err)
+
+	// when
+	config.RegisterSecretProvider(testSecretProvider{config.SecretJwt: "other-secret", config.SecretCookieEncryptionKeys: "current-cookie-key"})
+	_, otherSecretErr := jwtAuthentication.ConvertTokenUserClaim(token)
+	decoded, _, decodeErr := CookieCodec{}.Decode("refreshToken", encoded)
+
+	config.RegisterSecretProvider(testSecretProvider{})
+	_, _, unavailableErr := jwtAuthentication.GenerateJwtAccessToken(claim, time.Minute)
+
+	config.RegisterSecretProvider(testSecretProvider{config.SecretJwt: "provider-secret"})
+	actual, err := jwtAuthentication.ConvertTokenUserClaim(token)
+
+	// then
+	assert.Equal(t, InvalidAccessToken, otherSecretErr)
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, "test-refresh-token", decoded)
+	assert.EqualError(t, unavailableErr, "create accessToken error: get jwt secret error: secret store unavailable")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), actual.Id)
+}
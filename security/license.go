@@ -0,0 +1,78 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// LicensePublicKey 는 라이선스 서명을 확인할 공급자의 ed25519 공개 키(base64)이다. 라이선스를 받는 쪽에서 바꾸지 못하도록 설정이 아닌 바이너리에 넣으며,
+// 빌드할 때 -ldflags "-X better-admin-backend-service/security.LicensePublicKey=..." 로 설정한다.(Dockerfile 의 LICENSE_PUBLIC_KEY)
+// 비어 있으면 어떤 라이선스도 확인할 수 없다.
+var LicensePublicKey string
+
+// License 는 라이선스 파일에 서명한 내용이다. Max* 가 0 이면 수를 제한하지 않는다.
+type License struct {
+	Licensee   string    `json:"licensee"`
+	IssuedAt   time.Time `json:"issuedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	MaxMembers int64     `json:"maxMembers"`
+	MaxRoles   int64     `json:"maxRoles"`
+	MaxApiKeys int64     `json:"maxApiKeys"`
+}
+
+// signedLicense 는 라이선스 파일 형식이다. Payload 는 License 의 JSON 을, Signature 는 Payload(디코딩한 바이트)의 ed25519 서명을 base64 로 인코딩한다.
+type signedLicense struct {
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// IsExpired 는 now 가 만료 시간이 지났는지 확인한다. 만료 시간이 없으면 만료되지 않는다.
+func (l License) IsExpired(now time.Time) bool {
+	return !l.ExpiresAt.IsZero() && now.After(l.ExpiresAt)
+}
+
+// ParseLicense 는 라이선스 파일의 서명을 base64 로 인코딩한 ed25519 공개 키로 확인하고 내용을 읽는다.
+func ParseLicense(data []byte, publicKey string) (License, error) {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return License{}, errors.New("invalid license public key")
+	}
+
+	var signed signedLicense
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return License{}, errors.Wrap(err, "invalid license file")
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(signed.Payload)
+	if err != nil {
+		return License{}, errors.Wrap(err, "invalid license payload")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil || !ed25519.Verify(key, payload, signature) {
+		return License{}, errors.New("invalid license signature")
+	}
+
+	var license License
+	if err := json.Unmarshal(payload, &license); err != nil {
+		return License{}, errors.Wrap(err, "invalid license payload")
+	}
+
+	return license, nil
+}
+
+// SignLicense 는 라이선스를 개인 키로 서명해 라이선스 파일을 만든다.
+func SignLicense(license License, privateKey ed25519.PrivateKey) ([]byte, error) {
+	payload, err := json.Marshal(license)
+	if err != nil {
+		return nil, errors.Wrap(err, "license marshal error")
+	}
+
+	return json.Marshal(signedLicense{
+		Payload:   base64.StdEncoding.EncodeToString(payload),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, payload)),
+	})
+}
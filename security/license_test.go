@@ -0,0 +1,42 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLicense_서명한_파일로_확인(t *testing.T) {
+	// given
+	publicKey, privateKey, _ := ed25519.GenerateKey(rand.Reader)
+	otherPublicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	data, err := SignLicense(License{Licensee: "베터코드", ExpiresAt: expiresAt, MaxMembers: 10}, privateKey)
+	assert.Nil(t, err)
+
+	// when
+	license, err := ParseLicense(data, base64.StdEncoding.EncodeToString(publicKey))
+
+	// then
+	assert.Nil(t, err)
+	assert.Equal(t, "베터코드", license.Licensee)
+	assert.Equal(t, int64(10), license.MaxMembers)
+	assert.False(t, license.IsExpired(expiresAt.Add(-time.Second)))
+	assert.True(t, license.IsExpired(expiresAt.Add(time.Second)))
+
+	_, err = ParseLicense(data, base64.StdEncoding.EncodeToString(otherPublicKey))
+	assert.NotNil(t, err)
+
+	// 서명한 내용을 바꾸면 확인할 수 없다.
+	tampered := strings.Replace(string(data), `"payload":"`, `"payload":"e30`, 1)
+	_, err = ParseLicense([]byte(tampered), base64.StdEncoding.EncodeToString(publicKey))
+	assert.NotNil(t, err)
+
+	_, err = ParseLicense(data, "invalid")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,93 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// OAuth 상태(state) 토큰은 외부 로그인(예. 구글 워크스페이스)을 시작할 때 발급하여 콜백에서 돌아갈 주소를 꺼내는 짧은 수명의 서명된 토큰이다.
+// 콜백의 state 를 그대로 이동할 주소로 사용하지 않도록 서명과 만료를 확인한다.
+const (
+	tokenTypeOAuthState = "oauth-state"
+)
+
+var InvalidOAuthState = errors.New("invalid oauth state")
+
+func (JwtAuthentication) GenerateOAuthStateToken(provider string, redirect string, lifetime time.Duration) (string, error) {
+	return generateOAuthStateToken(jwt.MapClaims{"provider": provider, "redirect": redirect}, lifetime)
+}
+
+// GenerateOAuthLinkStateToken 은 로그인한 멤버(memberId)에 외부 계정을 연결하기 위한 상태 토큰이다. 로그인 상태 토큰으로는 사용할 수 없다.
+func (JwtAuthentication) GenerateOAuthLinkStateToken(provider string, memberId uint, redirect string, lifetime time.Duration) (string, error) {
+	return generateOAuthStateToken(jwt.MapClaims{"provider": provider, "redirect": redirect, "memberId": memberId}, lifetime)
+}
+
+func generateOAuthStateToken(stateClaims jwt.MapClaims, lifetime time.Duration) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "create oauth state nonce error")
+	}
+
+	stateClaims["nonce"] = hex.EncodeToString(nonce)
+	stateClaims["exp"] = time.Now().Add(lifetime).Unix()
+	stateClaims[claimKeyTokenType] = tokenTypeOAuthState
+	stateClaims[claimKeyTokenEpoch] = GetTokenEpoch()
+	setIssuerAndAudience(stateClaims)
+
+	token, err := signToken(stateClaims)
+	if err != nil {
+		return "", errors.Wrap(err, "create oauth state token error")
+	}
+
+	return token, nil
+}
+
+// ConvertOAuthStateToken 은 provider 로그인을 위해 발급한 상태 토큰인지 확인하고 돌아갈 주소를 반환한다.
+func (jwtAuthentication JwtAuthentication) ConvertOAuthStateToken(token string, provider string) (string, error) {
+	claimInfo, err := jwtAuthentication.parseToken(token)
+	if err != nil {
+		return "", InvalidOAuthState
+	}
+
+	if claimInfo[claimKeyTokenType] != tokenTypeOAuthState || claimInfo["provider"] != provider {
+		return "", InvalidOAuthState
+	}
+
+	if _, ok := claimInfo["memberId"]; ok {
+		return "", InvalidOAuthState
+	}
+
+	redirect, ok := claimInfo["redirect"].(string)
+	if !ok {
+		return "", InvalidOAuthState
+	}
+
+	return redirect, nil
+}
+
+// ConvertOAuthLinkStateToken 은 provider 계정 연결을 위해 발급한 상태 토큰인지 확인하고 연결할 멤버와 돌아갈 주소를 반환한다.
+func (jwtAuthentication JwtAuthentication) ConvertOAuthLinkStateToken(token string, provider string) (uint, string, error) {
+	claimInfo, err := jwtAuthentication.parseToken(token)
+	if err != nil {
+		return 0, "", InvalidOAuthState
+	}
+
+	if claimInfo[claimKeyTokenType] != tokenTypeOAuthState || claimInfo["provider"] != provider {
+		return 0, "", InvalidOAuthState
+	}
+
+	memberId, ok := claimInfo["memberId"].(float64)
+	if !ok || memberId <= 0 {
+		return 0, "", InvalidOAuthState
+	}
+
+	redirect, ok := claimInfo["redirect"].(string)
+	if !ok {
+		return 0, "", InvalidOAuthState
+	}
+
+	return uint(memberId), redirect, nil
+}
@@ -0,0 +1,151 @@
+package security
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"io"
+	"math"
+	"strings"
+)
+
+// passwordBloomFilterMagic 는 bloom filter 파일의 시작 표시이다.
+const passwordBloomFilterMagic = "BPBF"
+
+// passwordBloomFilterMaxBytes 는 읽을 수 있는 비트 배열의 최대 크기(4GiB)이다. 유출된 비밀번호 전체(약 10억 개)를 오탐률 0.1% 로 넣어도 2GiB 이하이다.
+const passwordBloomFilterMaxBytes = 4 << 30
+
+// PasswordBloomFilter 는 유출된 비밀번호 SHA-1 해시의 bloom filter 이다. 외부 API 를 호출할 수 없는 환경에서 유출 여부를 확인한다.
+// 파일은 "BPBF", 해시 수(uint32), 비트 수(uint64), 비트 배열 순서이고 숫자는 big endian 이다.
+type PasswordBloomFilter struct {
+	bits      []byte
+	bitCount  uint64
+	hashCount uint32
+}
+
+// NewPasswordBloomFilter 는 expectedCount 개를 넣었을 때 오탐률이 falsePositiveRate 가 되는 크기로 만든다.
+func NewPasswordBloomFilter(expectedCount uint64, falsePositiveRate float64) *PasswordBloomFilter {
+	if expectedCount == 0 {
+		expectedCount = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.001
+	}
+
+	bitCount := uint64(math.Ceil(-float64(expectedCount) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	if bitCount < 8 {
+		bitCount = 8
+	}
+	hashCount := uint32(math.Round(float64(bitCount) / float64(expectedCount) * math.Ln2))
+	if hashCount < 1 {
+		hashCount = 1
+	}
+
+	return &PasswordBloomFilter{
+		bits:      make([]byte, (bitCount+7)/8),
+		bitCount:  bitCount,
+		hashCount: hashCount,
+	}
+}
+
+// ReadPasswordBloomFilter 는 WriteTo 로 저장한 bloom filter 를 읽는다.
+func ReadPasswordBloomFilter(reader io.Reader) (*PasswordBloomFilter, error) {
+	header := make([]byte, len(passwordBloomFilterMagic)+4+8)
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return nil, errors.Wrap(err, "password bloom filter read error")
+	}
+
+	if string(header[:len(passwordBloomFilterMagic)]) != passwordBloomFilterMagic {
+		return nil, errors.New("invalid password bloom filter file")
+	}
+
+	hashCount := binary.BigEndian.Uint32(header[len(passwordBloomFilterMagic):])
+	bitCount := binary.BigEndian.Uint64(header[len(passwordBloomFilterMagic)+4:])
+	if hashCount == 0 || bitCount == 0 {
+		return nil, errors.New("invalid password bloom filter file")
+	}
+
+	// 헤더의 비트 수를 믿고 미리 할당하지 않는다. 최대 크기를 넘으면 거부하고, 실제로 읽은 만큼만 할당한다.
+	byteCount := (bitCount + 7) / 8
+	if bitCount > math.MaxUint64-7 || byteCount > passwordBloomFilterMaxBytes {
+		return nil, errors.Errorf("password bloom filter too large: %v bits", bitCount)
+	}
+
+	var bits bytes.Buffer
+	if _, err := io.CopyN(&bits, reader, int64(byteCount)); err != nil {
+		return nil, errors.Wrap(err, "password bloom filter read error")
+	}
+
+	return &PasswordBloomFilter{bits: bits.Bytes(), bitCount: bitCount, hashCount: hashCount}, nil
+}
+
+func (f *PasswordBloomFilter) Add(password string) {
+	f.addSum(sha1.Sum([]byte(password)))
+}
+
+// AddSha1Hex 는 SHA-1 해시(16진수, 예. Pwned Passwords 의 "해시:횟수" 목록)를 넣는다.
+func (f *PasswordBloomFilter) AddSha1Hex(sha1Hex string) error {
+	sha1Hex, _, _ = strings.Cut(strings.TrimSpace(sha1Hex), ":")
+	decoded, err := hex.DecodeString(sha1Hex)
+	if err != nil || len(decoded) != sha1.Size {
+		return errors.Errorf("invalid sha-1 hash %q", sha1Hex)
+	}
+
+	var sum [sha1.Size]byte
+	copy(sum[:], decoded)
+	f.addSum(sum)
+	return nil
+}
+
+// MayContain 은 비밀번호가 들어 있을 수 있는지 여부이다. false 이면 확실히 없고 true 는 오탐일 수 있다.
+func (f *PasswordBloomFilter) MayContain(password string) bool {
+	for _, position := range f.positions(sha1.Sum([]byte(password))) {
+		if f.bits[position/8]&(1<<(position%8)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *PasswordBloomFilter) WriteTo(writer io.Writer) (int64, error) {
+	header := make([]byte, len(passwordBloomFilterMagic)+4+8)
+	copy(header, passwordBloomFilterMagic)
+	binary.BigEndian.PutUint32(header[len(passwordBloomFilterMagic):], f.hashCount)
+	binary.BigEndian.PutUint64(header[len(passwordBloomFilterMagic)+4:], f.bitCount)
+
+	bufferedWriter := bufio.NewWriter(writer)
+	headerSize, err := bufferedWriter.Write(header)
+	if err != nil {
+		return int64(headerSize), err
+	}
+
+	bitsSize, err := bufferedWriter.Write(f.bits)
+	if err != nil {
+		return int64(headerSize + bitsSize), err
+	}
+
+	return int64(headerSize + bitsSize), bufferedWriter.Flush()
+}
+
+func (f *PasswordBloomFilter) addSum(sum [sha1.Size]byte) {
+	for _, position := range f.positions(sum) {
+		f.bits[position/8] |= 1 << (position % 8)
+	}
+}
+
+// positions 는 SHA-1 해시를 두 값으로 나누어(double hashing) 해시 수만큼의 비트 위치를 만든다.
+func (f *PasswordBloomFilter) positions(sum [sha1.Size]byte) []uint64 {
+	first := binary.BigEndian.Uint64(sum[0:8])
+	second := binary.BigEndian.Uint64(sum[8:16]) | 1
+
+	positions := make([]uint64, f.hashCount)
+	for i := range positions {
+		positions[i] = (first + uint64(i)*second) % f.bitCount
+	}
+
+	return positions
+}
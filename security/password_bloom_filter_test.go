@@ -0,0 +1,52 @@
+package security
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestPasswordBloomFilter_저장한_파일로_확인(t *testing.T) {
+	// given
+	bloomFilter := NewPasswordBloomFilter(100, 0.001)
+	bloomFilter.Add("password")
+	sum := sha1.Sum([]byte("123456"))
+	assert.Nil(t, bloomFilter.AddSha1Hex(hex.EncodeToString(sum[:])+":37359195"))
+	assert.NotNil(t, bloomFilter.AddSha1Hex("not-a-hash"))
+
+	var buffer bytes.Buffer
+	_, err := bloomFilter.WriteTo(&buffer)
+	assert.Nil(t, err)
+
+	// when
+	loaded, err := ReadPasswordBloomFilter(&buffer)
+
+	// then
+	assert.Nil(t, err)
+	assert.True(t, loaded.MayContain("password"))
+	assert.True(t, loaded.MayContain("123456"))
+	assert.False(t, loaded.MayContain("correct horse battery staple"))
+
+	_, err = ReadPasswordBloomFilter(bytes.NewReader([]byte("invalid bloom filter")))
+	assert.NotNil(t, err)
+}
+
+func TestPasswordBloomFilter_헤더의_비트_수를_믿지_않는다(t *testing.T) {
+	header := func(bitCount uint64) []byte {
+		data := append([]byte(passwordBloomFilterMagic), 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(data[len(passwordBloomFilterMagic)+4:], bitCount)
+		return data
+	}
+
+	// 최대 크기를 넘는 비트 수는 할당하지 않고 거부한다.
+	_, err := ReadPasswordBloomFilter(bytes.NewReader(header(math.MaxUint64)))
+	assert.EqualError(t, err, "password bloom filter too large: 18446744073709551615 bits")
+
+	// 비트 배열이 헤더의 비트 수보다 짧으면 거부한다.
+	_, err = ReadPasswordBloomFilter(bytes.NewReader(append(header(1<<30), 0xff, 0xff)))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,246 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+	"math/bits"
+	"strings"
+)
+
+const (
+	passwordSaltLength = 16
+	passwordKeyLength  = 32
+)
+
+// PasswordHasher 는 비밀번호 해시 알고리즘이다. 해시 문자열에 알고리즘과 비용이 포함되어 있어 비용을 바꿔도 이전 해시를 확인할 수 있다.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(hash, password string) (bool, error)
+	// IsHashOf 는 hash 가 이 알고리즘의 해시인지 여부이다.
+	IsHashOf(hash string) bool
+	// NeedsRehash 는 hash 가 설정된 비용과 다른 비용으로 만든 해시인지 여부이다.
+	NeedsRehash(hash string) bool
+}
+
+var passwordHashers = map[string]PasswordHasher{
+	constants.PasswordHashAlgorithmBcrypt:   bcryptPasswordHasher{},
+	constants.PasswordHashAlgorithmScrypt:   scryptPasswordHasher{},
+	constants.PasswordHashAlgorithmArgon2id: argon2idPasswordHasher{},
+}
+
+// HashPassword 는 설정된 알고리즘(PasswordHashing.Algorithm)으로 비밀번호를 해시한다.
+func HashPassword(password string) (string, error) {
+	hasher, ok := passwordHashers[config.Config.PasswordHashing.Algorithm]
+	if !ok {
+		return "", errors.Errorf("unsupported password hash algorithm %s", config.Config.PasswordHashing.Algorithm)
+	}
+
+	return hasher.Hash(password)
+}
+
+// VerifyPassword 는 해시의 알고리즘으로 비밀번호를 확인한다.
+func VerifyPassword(hash, password string) (bool, error) {
+	algorithm := PasswordHashAlgorithm(hash)
+	if algorithm == constants.PasswordHashAlgorithmUnknown {
+		return false, nil
+	}
+
+	return passwordHashers[algorithm].Verify(hash, password)
+}
+
+// PasswordHashAlgorithm 은 해시의 알고리즘(constants.PasswordHashAlgorithm*)이다.
+func PasswordHashAlgorithm(hash string) string {
+	for algorithm, hasher := range passwordHashers {
+		if hasher.IsHashOf(hash) {
+			return algorithm
+		}
+	}
+
+	return constants.PasswordHashAlgorithmUnknown
+}
+
+// PasswordNeedsRehash 는 해시가 설정된 알고리즘, 비용과 달라 다시 저장해야 하는지 여부이다.
+func PasswordNeedsRehash(hash string) bool {
+	algorithm := PasswordHashAlgorithm(hash)
+	if algorithm != config.Config.PasswordHashing.Algorithm {
+		return true
+	}
+
+	return passwordHashers[algorithm].NeedsRehash(hash)
+}
+
+type bcryptPasswordHasher struct {
+}
+
+func (bcryptPasswordHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), config.Config.PasswordHashing.BcryptCost)
+	if err != nil {
+		return "", errors.Wrap(err, "bcrypt hash error")
+	}
+
+	return string(hash), nil
+}
+
+func (bcryptPasswordHasher) Verify(hash, password string) (bool, error) {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "bcrypt verify error")
+	}
+
+	return true, nil
+}
+
+func (bcryptPasswordHasher) IsHashOf(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (bcryptPasswordHasher) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost != config.Config.PasswordHashing.BcryptCost
+}
+
+// scryptPasswordHasher 의 해시는 $scrypt$ln=15,r=8,p=1$salt$key 형식이다. ln 은 log2(N) 이다.
+type scryptPasswordHasher struct {
+}
+
+func (h scryptPasswordHasher) Hash(password string) (string, error) {
+	scryptConfig := config.Config.PasswordHashing.Scrypt
+	salt, err := newPasswordSalt()
+	if err != nil {
+		return "", err
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, scryptConfig.N, scryptConfig.R, scryptConfig.P, passwordKeyLength)
+	if err != nil {
+		return "", errors.Wrap(err, "scrypt hash error")
+	}
+
+	return fmt.Sprintf("$scrypt$%s$%s$%s", h.params(scryptConfig.N, scryptConfig.R, scryptConfig.P),
+		encodePasswordHashPart(salt), encodePasswordHashPart(key)), nil
+}
+
+func (h scryptPasswordHasher) Verify(hash, password string) (bool, error) {
+	var logN, r, p int
+	params, salt, key, err := splitPasswordHash(hash, 5)
+	if err != nil {
+		return false, err
+	}
+	if _, err := fmt.Sscanf(params[0], "ln=%d,r=%d,p=%d", &logN, &r, &p); err != nil {
+		return false, errors.Wrap(err, "invalid scrypt hash")
+	}
+
+	actual, err := scrypt.Key([]byte(password), salt, 1<<logN, r, p, len(key))
+	if err != nil {
+		return false, errors.Wrap(err, "scrypt verify error")
+	}
+
+	return subtle.ConstantTimeCompare(actual, key) == 1, nil
+}
+
+func (scryptPasswordHasher) IsHashOf(hash string) bool {
+	return strings.HasPrefix(hash, "$scrypt$")
+}
+
+func (h scryptPasswordHasher) NeedsRehash(hash string) bool {
+	scryptConfig := config.Config.PasswordHashing.Scrypt
+	params, _, _, err := splitPasswordHash(hash, 5)
+	return err != nil || params[0] != h.params(scryptConfig.N, scryptConfig.R, scryptConfig.P)
+}
+
+func (scryptPasswordHasher) params(n, r, p int) string {
+	return fmt.Sprintf("ln=%d,r=%d,p=%d", bits.Len(uint(n))-1, r, p)
+}
+
+// argon2idPasswordHasher 의 해시는 $argon2id$v=19$m=65536,t=3,p=2$salt$key 형식(PHC)이다.
+type argon2idPasswordHasher struct {
+}
+
+func (h argon2idPasswordHasher) Hash(password string) (string, error) {
+	argon2Config := config.Config.PasswordHashing.Argon2id
+	salt, err := newPasswordSalt()
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, uint32(argon2Config.Iterations), uint32(argon2Config.MemoryKiB),
+		uint8(argon2Config.Parallelism), passwordKeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$%s$%s$%s", argon2.Version,
+		h.params(argon2Config.MemoryKiB, argon2Config.Iterations, argon2Config.Parallelism),
+		encodePasswordHashPart(salt), encodePasswordHashPart(key)), nil
+}
+
+func (argon2idPasswordHasher) Verify(hash, password string) (bool, error) {
+	var memory, iterations, parallelism int
+	params, salt, key, err := splitPasswordHash(hash, 6)
+	if err != nil {
+		return false, err
+	}
+	if params[0] != fmt.Sprintf("v=%d", argon2.Version) {
+		return false, errors.Errorf("unsupported argon2 version %s", params[0])
+	}
+	if _, err := fmt.Sscanf(params[1], "m=%d,t=%d,p=%d", &memory, &iterations, &parallelism); err != nil {
+		return false, errors.Wrap(err, "invalid argon2id hash")
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, uint32(iterations), uint32(memory), uint8(parallelism), uint32(len(key)))
+	return subtle.ConstantTimeCompare(actual, key) == 1, nil
+}
+
+func (argon2idPasswordHasher) IsHashOf(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+func (h argon2idPasswordHasher) NeedsRehash(hash string) bool {
+	argon2Config := config.Config.PasswordHashing.Argon2id
+	params, _, _, err := splitPasswordHash(hash, 6)
+	return err != nil || params[0] != fmt.Sprintf("v=%d", argon2.Version) ||
+		params[1] != h.params(argon2Config.MemoryKiB, argon2Config.Iterations, argon2Config.Parallelism)
+}
+
+func (argon2idPasswordHasher) params(memory, iterations, parallelism int) string {
+	return fmt.Sprintf("m=%d,t=%d,p=%d", memory, iterations, parallelism)
+}
+
+func newPasswordSalt() ([]byte, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "create password salt error")
+	}
+
+	return salt, nil
+}
+
+func encodePasswordHashPart(value []byte) string {
+	return base64.RawStdEncoding.EncodeToString(value)
+}
+
+// splitPasswordHash 는 $알고리즘$파라미터...$salt$key 형식의 해시를 나눈다. parts 는 $ 로 나눈 개수(맨 앞의 빈 문자열 포함)이다.
+func splitPasswordHash(hash string, parts int) ([]string, []byte, []byte, error) {
+	values := strings.Split(hash, "$")
+	if len(values) != parts {
+		return nil, nil, nil, errors.New("invalid password hash")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(values[parts-2])
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid password hash salt")
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(values[parts-1])
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "invalid password hash key")
+	}
+
+	return values[2 : parts-2], salt, key, nil
+}
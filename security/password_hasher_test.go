@@ -0,0 +1,76 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func setUpTestPasswordHashing(algorithm string) func() {
+	passwordHashing := config.Config.PasswordHashing
+	config.Config.PasswordHashing.Algorithm = algorithm
+	config.Config.PasswordHashing.BcryptCost = 4
+	config.Config.PasswordHashing.Scrypt.N = 1024
+	config.Config.PasswordHashing.Scrypt.R = 8
+	config.Config.PasswordHashing.Scrypt.P = 1
+	config.Config.PasswordHashing.Argon2id.MemoryKiB = 1024
+	config.Config.PasswordHashing.Argon2id.Iterations = 1
+	config.Config.PasswordHashing.Argon2id.Parallelism = 1
+
+	return func() { config.Config.PasswordHashing = passwordHashing }
+}
+
+func TestHashPassword_알고리즘별_확인(t *testing.T) {
+	for _, algorithm := range []string{constants.PasswordHashAlgorithmBcrypt, constants.PasswordHashAlgorithmScrypt, constants.PasswordHashAlgorithmArgon2id} {
+		restore := setUpTestPasswordHashing(algorithm)
+
+		// when
+		hash, err := HashPassword("123456")
+
+		// then
+		assert.Nil(t, err)
+		assert.Equal(t, algorithm, PasswordHashAlgorithm(hash))
+		matched, err := VerifyPassword(hash, "123456")
+		assert.Nil(t, err)
+		assert.True(t, matched, algorithm)
+		matched, err = VerifyPassword(hash, "654321")
+		assert.Nil(t, err)
+		assert.False(t, matched, algorithm)
+		assert.False(t, PasswordNeedsRehash(hash), algorithm)
+
+		restore()
+	}
+}
+
+func TestPasswordNeedsRehash(t *testing.T) {
+	defer setUpTestPasswordHashing(constants.PasswordHashAlgorithmArgon2id)()
+
+	// given
+	argon2idHash, _ := HashPassword("123456")
+	config.Config.PasswordHashing.Algorithm = constants.PasswordHashAlgorithmBcrypt
+	bcryptHash, _ := HashPassword("123456")
+	config.Config.PasswordHashing.Algorithm = constants.PasswordHashAlgorithmArgon2id
+
+	// then
+	// 다른 알고리즘으로 저장된 해시도 확인할 수 있다.
+	matched, err := VerifyPassword(bcryptHash, "123456")
+	assert.Nil(t, err)
+	assert.True(t, matched)
+	assert.True(t, PasswordNeedsRehash(bcryptHash))
+	assert.False(t, PasswordNeedsRehash(argon2idHash))
+	assert.True(t, strings.HasPrefix(argon2idHash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	// 비용이 바뀌면 다시 저장한다.
+	config.Config.PasswordHashing.Argon2id.Iterations = 2
+	assert.True(t, PasswordNeedsRehash(argon2idHash))
+	matched, _ = VerifyPassword(argon2idHash, "123456")
+	assert.True(t, matched)
+
+	// 알 수 없는 형식은 일치하지 않는다.
+	matched, err = VerifyPassword("plain-text", "plain-text")
+	assert.Nil(t, err)
+	assert.False(t, matched)
+	assert.Equal(t, constants.PasswordHashAlgorithmUnknown, PasswordHashAlgorithm("plain-text"))
+}
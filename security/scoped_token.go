@@ -0,0 +1,81 @@
+package security
+
+import (
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// 스코프 토큰은 <img>, <a> 태그처럼 Authorization 헤더를 사용할 수 없는 요청을 위해
+// 특정 리소스(경로)와 동작(HTTP Method)에만 사용할 수 있도록 발급하는 짧은 수명의 토큰이다.
+const (
+	ScopedTokenQueryKey = "token"
+	claimKeyTokenType   = "typ"
+	tokenTypeScoped     = "scoped"
+)
+
+var ScopedTokenMismatched = errors.New("scoped token mismatched")
+
+type ScopedTokenClaim struct {
+	Id          uint     `json:"id"`
+	Permissions []string `json:"permissions"`
+	// SessionId 는 발급을 요청한 토큰의 세션이다. 세션을 종료하면 스코프 토큰도 사용할 수 없다.
+	SessionId string `json:"sessionId,omitempty"`
+	Resource  string `json:"resource"`
+	Action    string `json:"action"`
+}
+
+func (JwtAuthentication) GenerateScopedToken(claim ScopedTokenClaim, lifetime time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(lifetime)
+	scopedTokenClaims := jwt.MapClaims{
+		"id":               claim.Id,
+		"permissions":      claim.Permissions,
+		"resource":         claim.Resource,
+		"action":           claim.Action,
+		"exp":              expiresAt.Unix(),
+		claimKeyTokenType:  tokenTypeScoped,
+		claimKeyTokenEpoch: GetTokenEpoch(),
+	}
+	if len(claim.SessionId) > 0 {
+		scopedTokenClaims["sessionId"] = claim.SessionId
+	}
+	setIssuerAndAudience(scopedTokenClaims)
+
+	token, err := signToken(scopedTokenClaims)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "create scoped token error")
+	}
+
+	return token, expiresAt, nil
+}
+
+// ConvertScopedTokenUserClaim 은 스코프 토큰이 요청한 리소스와 동작에 대해 발급된 것인지 확인하고 UserClaim 으로 변환한다.
+// 세션은 UserClaim 의 SessionId 로 옮기므로 종료된 세션의 스코프 토큰은 RevokedSession 에서 거부한다.
+func (jwtAuthentication JwtAuthentication) ConvertScopedTokenUserClaim(token string, resource string, action string) (*UserClaim, error) {
+	claimInfo, err := jwtAuthentication.parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if claimInfo[claimKeyTokenType] != tokenTypeScoped {
+		return nil, InvalidAccessToken
+	}
+
+	if claimInfo["resource"] != resource || claimInfo["action"] != action {
+		return nil, ScopedTokenMismatched
+	}
+
+	if sessionId, ok := claimInfo["sessionId"]; ok {
+		if _, isString := sessionId.(string); !isString {
+			return nil, InvalidAccessToken
+		}
+	}
+
+	userClaim, err := NewUserClaim(claimInfo)
+	if err != nil {
+		return nil, err
+	}
+	userClaim.Extra = nil
+
+	return &userClaim, nil
+}
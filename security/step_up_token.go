@@ -0,0 +1,51 @@
+package security
+
+import (
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// 단계 인증(step-up) 토큰은 로그인한 멤버가 비밀번호를 다시 확인하면 발급하는 짧은 수명의 토큰이다.
+// 최고 관리자 역할 변경처럼 중요한 요청에 Access 토큰과 함께 보내 본인이 직접 요청했음을 확인한다.
+const (
+	tokenTypeStepUp = "step-up"
+)
+
+var InvalidStepUpToken = errors.New("invalid step-up token")
+
+func (JwtAuthentication) GenerateStepUpToken(memberId uint, lifetime time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(lifetime)
+	stepUpClaims := jwt.MapClaims{
+		"id":               memberId,
+		"exp":              expiresAt.Unix(),
+		claimKeyTokenType:  tokenTypeStepUp,
+		claimKeyTokenEpoch: GetTokenEpoch(),
+	}
+	setIssuerAndAudience(stepUpClaims)
+
+	token, err := signToken(stepUpClaims)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, "create step-up token error")
+	}
+
+	return token, expiresAt, nil
+}
+
+// VerifyStepUpToken 은 memberId 멤버에게 발급한 만료되지 않은 단계 인증 토큰인지 확인한다.
+func (jwtAuthentication JwtAuthentication) VerifyStepUpToken(token string, memberId uint) error {
+	claimInfo, err := jwtAuthentication.parseToken(token)
+	if err != nil {
+		return InvalidStepUpToken
+	}
+
+	if claimInfo[claimKeyTokenType] != tokenTypeStepUp {
+		return InvalidStepUpToken
+	}
+
+	if id, ok := claimInfo["id"].(float64); !ok || uint(id) != memberId {
+		return InvalidStepUpToken
+	}
+
+	return nil
+}
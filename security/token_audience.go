@@ -0,0 +1,82 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"github.com/golang-jwt/jwt"
+)
+
+// 같은 서명 키를 사용하는 서비스끼리 토큰을 재사용하지 못하도록 설정(JwtClaims)의 iss, aud 를 토큰에 기록하고 검증한다.
+const claimKeyIssuer = "iss"
+
+// setIssuerAndAudience 는 설정의 Issuer, Audience 를 토큰 클레임에 기록한다. 이미 aud 가 있으면(예. 토큰 교환) 바꾸지 않는다.
+func setIssuerAndAudience(claims jwt.MapClaims) {
+	jwtClaimsConfig := config.Config.JwtClaims
+	if len(jwtClaimsConfig.Issuer) > 0 {
+		claims[claimKeyIssuer] = jwtClaimsConfig.Issuer
+	}
+
+	if _, ok := claims[claimKeyAudience]; !ok && len(jwtClaimsConfig.Audience) > 0 {
+		claims[claimKeyAudience] = jwtClaimsConfig.Audience
+	}
+}
+
+// validateIssuerAndAudience 는 토큰의 iss 가 설정의 Issuer 와 같고 aud 중 하나가 받아들일 aud(Audience, AcceptedAudiences)인지 확인한다.
+// 받아들일 aud 가 없으면 aud 가 없는 토큰만 사용할 수 있다.
+func validateIssuerAndAudience(claims jwt.MapClaims) error {
+	jwtClaimsConfig := config.Config.JwtClaims
+	if len(jwtClaimsConfig.Issuer) > 0 && claims[claimKeyIssuer] != jwtClaimsConfig.Issuer {
+		return InvalidAccessToken
+	}
+
+	acceptedAudiences := getAcceptedAudiences()
+	audiences, ok := claims[claimKeyAudience]
+	if !ok {
+		if len(acceptedAudiences) > 0 {
+			return InvalidAccessToken
+		}
+		return nil
+	}
+
+	for _, audience := range getClaimAudiences(audiences) {
+		if acceptedAudiences[audience] {
+			return nil
+		}
+	}
+
+	return InvalidAccessToken
+}
+
+func getAcceptedAudiences() map[string]bool {
+	jwtClaimsConfig := config.Config.JwtClaims
+	acceptedAudiences := map[string]bool{}
+	if len(jwtClaimsConfig.Audience) > 0 {
+		acceptedAudiences[jwtClaimsConfig.Audience] = true
+	}
+	for _, audience := range jwtClaimsConfig.AcceptedAudiences {
+		if len(audience) > 0 {
+			acceptedAudiences[audience] = true
+		}
+	}
+
+	return acceptedAudiences
+}
+
+// getClaimAudiences 는 aud 클레임을 읽는다. aud 는 문자열이거나 문자열 배열이다.
+func getClaimAudiences(audiences interface{}) []string {
+	switch value := audiences.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, audience := range value {
+			if audience, ok := audience.(string); ok {
+				result = append(result, audience)
+			}
+		}
+		return result
+	case []string:
+		return value
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package security
+
+import (
+	"context"
+	"sync"
+)
+
+// 토큰 에포크(epoch)는 발급된 모든 토큰을 한 번에 무효화 하기 위해 사용한다.
+// 토큰 발급 시점의 에포크를 토큰에 기록하고, 검증 시 현재 에포크와 다르면 유효하지 않은 토큰으로 판단한다.
+const claimKeyTokenEpoch = "epoch"
+
+var (
+	tokenEpochMutex sync.RWMutex
+	tokenEpoch      uint
+)
+
+func GetTokenEpoch() uint {
+	tokenEpochMutex.RLock()
+	defer tokenEpochMutex.RUnlock()
+
+	return tokenEpoch
+}
+
+func SetTokenEpoch(epoch uint) {
+	tokenEpochMutex.Lock()
+	defer tokenEpochMutex.Unlock()
+
+	tokenEpoch = epoch
+}
+
+type pendingTokenSigningKey struct{}
+
+// pendingTokenSigning 은 트랜잭션에서 바꾸었지만 아직 커밋하지 않은 토큰 에포크와 JWT Secret 이다.
+type pendingTokenSigning struct {
+	epoch  uint
+	secret string
+}
+
+// WithPendingTokenSigning 은 ctx 로 발급하는 토큰에 새 에포크와 JWT Secret(바꾸지 않았으면 빈 문자열)을 사용한다.
+// 프로세스의 에포크와 Secret 은 커밋한 뒤에 바꾸므로 같은 트랜잭션에서 다시 발급하는 토큰(예. 웹훅 토큰)에 사용한다.
+func WithPendingTokenSigning(ctx context.Context, epoch uint, secret string) context.Context {
+	return context.WithValue(ctx, pendingTokenSigningKey{}, pendingTokenSigning{epoch: epoch, secret: secret})
+}
+
+func tokenEpochOf(ctx context.Context) uint {
+	if pending, ok := ctx.Value(pendingTokenSigningKey{}).(pendingTokenSigning); ok {
+		return pending.epoch
+	}
+
+	return GetTokenEpoch()
+}
@@ -0,0 +1,39 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"github.com/golang-jwt/jwt"
+)
+
+// ConvertIntrospectionTokenClaims 는 토큰 조회(RFC 7662)를 위해 다른 서비스가 받은 토큰의 클레임을 확인한다.
+// 토큰 교환으로 다른 서비스(aud)에 발급한 토큰도 조회할 수 있도록 aud 는 확인하지 않으며 호출한 서비스가 응답의 aud 를 확인한다.
+// 스코프 토큰과 OAuth 상태 토큰은 Access 토큰이 아니므로 받지 않는다.
+func (jwtAuthentication JwtAuthentication) ConvertIntrospectionTokenClaims(token string) (jwt.MapClaims, error) {
+	claimInfo, err := jwtAuthentication.verifyToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if issuer := config.Config.JwtClaims.Issuer; len(issuer) > 0 && claimInfo[claimKeyIssuer] != issuer {
+		return nil, InvalidAccessToken
+	}
+
+	if claimInfo[claimKeyTokenType] == tokenTypeScoped || claimInfo[claimKeyTokenType] == tokenTypeOAuthState ||
+		claimInfo[claimKeyTokenType] == tokenTypeStepUp {
+		return nil, InvalidAccessToken
+	}
+
+	return claimInfo, nil
+}
+
+// GetClaimAudiences 는 aud 클레임을 문자열 목록으로 읽는다.
+func GetClaimAudiences(claims jwt.MapClaims) []string {
+	return getClaimAudiences(claims[claimKeyAudience])
+}
+
+// GetActorClientId 는 토큰 교환으로 발급한 토큰에서 멤버 대신 요청한 서비스 계정의 Client Id(act.sub)를 읽는다.
+func GetActorClientId(claims jwt.MapClaims) string {
+	actor, _ := claims[claimKeyActor].(map[string]interface{})
+	clientId, _ := actor["sub"].(string)
+	return clientId
+}
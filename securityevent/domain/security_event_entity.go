@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// severityRanks 는 심각도의 순서이다. 알림 규칙의 최소 심각도와 비교할 때 사용한다.
+var severityRanks = map[string]int{
+	constants.SecurityEventSeverityLow:      1,
+	constants.SecurityEventSeverityMedium:   2,
+	constants.SecurityEventSeverityHigh:     3,
+	constants.SecurityEventSeverityCritical: 4,
+}
+
+// typeSeverities 는 보안 이벤트 유형별 심각도이다.
+var typeSeverities = map[string]string{
+	constants.SecurityEventTypeLoginFailed:      constants.SecurityEventSeverityLow,
+	constants.SecurityEventTypePermissionDenied: constants.SecurityEventSeverityLow,
+	constants.SecurityEventTypeReadOnlyWrite:    constants.SecurityEventSeverityLow,
+	constants.SecurityEventTypeLockout:          constants.SecurityEventSeverityMedium,
+	constants.SecurityEventTypeAnomaly:          constants.SecurityEventSeverityHigh,
+	constants.SecurityEventTypeImpersonation:    constants.SecurityEventSeverityHigh,
+	constants.SecurityEventTypeSuperAdminRole:   constants.SecurityEventSeverityHigh,
+	constants.SecurityEventTypeSuperAdminBlock:  constants.SecurityEventSeverityHigh,
+	constants.SecurityEventTypeBreakGlassUsed:   constants.SecurityEventSeverityCritical,
+}
+
+// SecurityEventEntity 는 보안 관련 이벤트(로그인 실패, 권한 거부, 이상 징후, 비상 접근 계정 사용 등)이다.
+// 보안 담당자가 확인(acknowledged)할 때까지 open 상태로 둔다.
+type SecurityEventEntity struct {
+	gorm.Model
+	Type     string `gorm:"type:varchar(50);not null;index"`
+	Severity string `gorm:"type:varchar(20);not null;index"`
+	Status   string `gorm:"type:varchar(20);not null;index"`
+	MemberId uint   `gorm:"index"`
+	Detail   string `gorm:"type:text"`
+	// 요청한 클라이언트의 IP 와 GeoIP 로 찾은 국가
+	IpAddress       string `gorm:"type:varchar(45)"`
+	Country         string `gorm:"type:varchar(2)"`
+	AcknowledgedBy  uint
+	AcknowledgedAt  *time.Time
+	AcknowledgeNote string `gorm:"type:varchar(1000)"`
+}
+
+func (SecurityEventEntity) TableName() string {
+	return "security_events"
+}
+
+// NewSecurityEventEntity 는 유형의 심각도로 보안 이벤트를 만든다. 멤버를 알 수 없으면(예. 없는 아이디로 로그인) memberId 는 0 이다.
+func NewSecurityEventEntity(ctx context.Context, eventType string, memberId uint, detail string) SecurityEventEntity {
+	severity, ok := typeSeverities[eventType]
+	if !ok {
+		severity = constants.SecurityEventSeverityMedium
+	}
+
+	ipAddress := helpers.ContextHelper().GetClientIp(ctx)
+	return SecurityEventEntity{
+		Type:      eventType,
+		Severity:  severity,
+		Status:    constants.SecurityEventStatusOpen,
+		MemberId:  memberId,
+		Detail:    detail,
+		IpAddress: ipAddress,
+		Country:   adapters.GeoIpAdapter().Lookup(ipAddress).Country,
+	}
+}
+
+// SeveritiesAtLeast 는 minSeverity 이상인 심각도 목록이다.
+func SeveritiesAtLeast(minSeverity string) []string {
+	severities := make([]string, 0)
+	for _, severity := range []string{constants.SecurityEventSeverityLow, constants.SecurityEventSeverityMedium,
+		constants.SecurityEventSeverityHigh, constants.SecurityEventSeverityCritical} {
+		if severityRanks[severity] >= severityRanks[minSeverity] {
+			severities = append(severities, severity)
+		}
+	}
+
+	return severities
+}
+
+// IsAtLeast 는 이벤트의 심각도가 minSeverity 이상인지 여부이다.
+func (s SecurityEventEntity) IsAtLeast(minSeverity string) bool {
+	return severityRanks[s.Severity] >= severityRanks[minSeverity]
+}
+
+func (s *SecurityEventEntity) Acknowledge(memberId uint, note string) error {
+	if s.Status == constants.SecurityEventStatusAcknowledged {
+		return errors.ErrAlreadyAcknowledged
+	}
+
+	now := time.Now()
+	s.Status = constants.SecurityEventStatusAcknowledged
+	s.AcknowledgedBy = memberId
+	s.AcknowledgedAt = &now
+	s.AcknowledgeNote = note
+	return nil
+}
+
+func (s SecurityEventEntity) ToInformation() dtos.SecurityEventInformation {
+	return dtos.SecurityEventInformation{
+		Id:              s.ID,
+		Type:            s.Type,
+		Severity:        s.Severity,
+		Status:          s.Status,
+		MemberId:        s.MemberId,
+		IpAddress:       s.IpAddress,
+		Country:         s.Country,
+		Detail:          s.Detail,
+		OccurredAt:      s.CreatedAt,
+		AcknowledgedBy:  s.AcknowledgedBy,
+		AcknowledgedAt:  s.AcknowledgedAt,
+		AcknowledgeNote: s.AcknowledgeNote,
+	}
+}
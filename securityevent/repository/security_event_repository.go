@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/securityevent/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type SecurityEventRepository struct {
+}
+
+func (SecurityEventRepository) Create(ctx context.Context, entity *domain.SecurityEventEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SecurityEventRepository) Save(ctx context.Context, entity *domain.SecurityEventEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SecurityEventRepository) FindById(ctx context.Context, id uint) (domain.SecurityEventEntity, error) {
+	var entity domain.SecurityEventEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindAll 은 보안 이벤트를 최근 순으로 조회한다.
+func (SecurityEventRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.SecurityEventEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.SecurityEventEntity{})
+
+	for key, value := range filters {
+		if key == "types" {
+			db.Where("type IN ?", value)
+		}
+
+		if key == "severities" {
+			db.Where("severity IN ?", value)
+		}
+
+		if key == "status" {
+			db.Where("status = ?", value)
+		}
+
+		if key == "memberId" {
+			db.Where("member_id = ?", value)
+		}
+	}
+
+	var entities = make([]domain.SecurityEventEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("created_at DESC, id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+// CountOpenBy 는 확인하지 않은 보안 이벤트 수를 column(type, severity) 값별로 센다.
+func (SecurityEventRepository) CountOpenBy(ctx context.Context, column string) ([]dtos.SecurityEventCount, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var counts = make([]dtos.SecurityEventCount, 0)
+	if err := db.Model(&domain.SecurityEventEntity{}).
+		Select(column+" AS name, count(*) AS count").
+		Where("status = ?", constants.SecurityEventStatusOpen).
+		Group(column).
+		Order("name").
+		Scan(&counts).Error; err != nil {
+		return counts, pkgerrors.Wrap(err, "db error")
+	}
+
+	return counts, nil
+}
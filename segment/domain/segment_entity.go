@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// SegmentEntity 는 저장된 멤버 검색 조건이다. 알림, 기능 플래그, 접근 검토 등의 대상으로 사용한다.
+// 조건만 저장하므로 멤버 목록은 조회할 때마다 새로 계산된다.
+type SegmentEntity struct {
+	gorm.Model
+	Name        string `gorm:"type:varchar(100);not null"`
+	Description string `gorm:"type:varchar(1000)"`
+	Filter      string `gorm:"type:text"`
+	// OwnerId 는 리소스를 책임지는 멤버이다. 만든 멤버가 처음 소유자이며 멤버를 해지(deprovisioning)하면 후임자에게 넘긴다.
+	OwnerId   uint `gorm:"index"`
+	CreatedBy uint
+	UpdatedBy uint
+}
+
+func (SegmentEntity) TableName() string {
+	return "segments"
+}
+
+func (s SegmentEntity) GetFilter() dtos.SegmentFilter {
+	var filter dtos.SegmentFilter
+	if err := json.Unmarshal([]byte(s.Filter), &filter); err != nil {
+		return dtos.SegmentFilter{}
+	}
+
+	return filter
+}
+
+func (s *SegmentEntity) Update(ctx context.Context, information dtos.SegmentInformation) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter, err := json.Marshal(information.Filter)
+	if err != nil {
+		return pkgerrors.Wrap(err, "segment filter encode error")
+	}
+
+	s.Name = information.Name
+	s.Description = information.Description
+	s.Filter = string(filter)
+	s.UpdatedBy = userClaim.Id
+	return nil
+}
+
+// GetOwnerId 는 소유자이다. 소유자를 기록하기 전에 만든 리소스는 만든 멤버가 소유자이다.
+func (s SegmentEntity) GetOwnerId() uint {
+	if s.OwnerId == 0 {
+		return s.CreatedBy
+	}
+
+	return s.OwnerId
+}
+
+func (s *SegmentEntity) TransferOwnership(ctx context.Context, ownerId uint) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.OwnerId = ownerId
+	s.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+func NewSegmentEntity(ctx context.Context, information dtos.SegmentInformation) (SegmentEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return SegmentEntity{}, err
+	}
+
+	entity := SegmentEntity{OwnerId: userClaim.Id, CreatedBy: userClaim.Id}
+	if err := entity.Update(ctx, information); err != nil {
+		return SegmentEntity{}, err
+	}
+
+	return entity, nil
+}
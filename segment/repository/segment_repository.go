@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/segment/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type SegmentRepository struct {
+}
+
+func (SegmentRepository) Create(ctx context.Context, entity *domain.SegmentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SegmentRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.SegmentEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.SegmentEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "ownerId" {
+				db.Scopes(helpers.GormHelper().OwnedBy(value.(uint)))
+			}
+		}
+	}
+
+	var entities = make([]domain.SegmentEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("name").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (SegmentRepository) FindByOwnerId(ctx context.Context, ownerId uint) ([]domain.SegmentEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.SegmentEntity, 0)
+	if err := db.Scopes(helpers.GormHelper().OwnedBy(ownerId)).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (SegmentRepository) FindById(ctx context.Context, id uint) (domain.SegmentEntity, error) {
+	var entity domain.SegmentEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (SegmentRepository) Save(ctx context.Context, entity *domain.SegmentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SegmentRepository) Delete(ctx context.Context, entity domain.SegmentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
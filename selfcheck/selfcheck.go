@@ -0,0 +1,70 @@
+package selfcheck
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"context"
+	"sync"
+	"time"
+)
+
+// Check 는 시작할 때와 GET /system/selfcheck 에서 실행하는 점검 항목이다. 문제가 있으면 Run 이 오류를 반환한다.
+// Severity 가 error 인 항목이 실패하면 애플리케이션을 시작하지 않는다.
+type Check struct {
+	Name     string
+	Severity string
+	Run      func(ctx context.Context) error
+}
+
+var (
+	mutex  sync.Mutex
+	checks []Check
+)
+
+// Register 는 점검 항목을 등록한다. 같은 이름의 항목이 있으면 교체한다.
+func Register(check Check) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	for i := range checks {
+		if checks[i].Name == check.Name {
+			checks[i] = check
+			return
+		}
+	}
+	checks = append(checks, check)
+}
+
+// Run 은 등록된 모든 항목을 점검하고 결과를 모아 반환한다.
+func Run(ctx context.Context) dtos.SelfCheckReport {
+	mutex.Lock()
+	registeredChecks := make([]Check, len(checks))
+	copy(registeredChecks, checks)
+	mutex.Unlock()
+
+	report := dtos.SelfCheckReport{
+		Healthy:   true,
+		CheckedAt: time.Now(),
+		Results:   make([]dtos.SelfCheckResult, 0),
+	}
+
+	for _, check := range registeredChecks {
+		result := dtos.SelfCheckResult{
+			Name:     check.Name,
+			Severity: check.Severity,
+			Passed:   true,
+		}
+
+		if err := check.Run(ctx); err != nil {
+			result.Passed = false
+			result.Message = err.Error()
+			if check.Severity == constants.SelfCheckSeverityError {
+				report.Healthy = false
+			}
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
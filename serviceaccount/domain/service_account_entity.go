@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/rbac/domain"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	pkgerrors "github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	apiKeyPrefix       = "sa_"
+	apiKeyDisplayChars = 8
+)
+
+type ServiceAccountEntity struct {
+	gorm.Model
+	Name           string `gorm:"type:varchar(100);not null"`
+	Description    string `gorm:"type:varchar(1000)"`
+	ApiKeyHash     string `gorm:"type:varchar(64);index"`
+	ApiKeyPrefix   string `gorm:"type:varchar(20)"`
+	ApiKeyIssuedAt *time.Time
+	ClientId       string `gorm:"type:varchar(64);index"`
+	// ClientSecret 은 bcrypt 로 해시하여 저장한다.
+	ClientSecret         string `gorm:"type:varchar(100)"`
+	TokenLifetimeSeconds int
+	Roles                []domain.RoleEntity `gorm:"many2many:service_account_roles;"`
+	// OwnerId 는 리소스를 책임지는 멤버이다. 만든 멤버가 처음 소유자이며 멤버를 해지(deprovisioning)하면 후임자에게 넘긴다.
+	OwnerId   uint `gorm:"index"`
+	CreatedBy uint
+	UpdatedBy uint
+}
+
+func (ServiceAccountEntity) TableName() string {
+	return "service_accounts"
+}
+
+func (s *ServiceAccountEntity) Update(ctx context.Context, information dtos.ServiceAccountInformation) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.Name = information.Name
+	s.Description = information.Description
+	s.TokenLifetimeSeconds = information.TokenLifetimeSeconds
+	s.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+func (s *ServiceAccountEntity) AssignRole(ctx context.Context, roleEntities []domain.RoleEntity) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 기존 역할을 덮어쓰기
+	s.Roles = roleEntities
+	s.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+// GetOwnerId 는 소유자이다. 소유자를 기록하기 전에 만든 리소스는 만든 멤버가 소유자이다.
+func (s ServiceAccountEntity) GetOwnerId() uint {
+	if s.OwnerId == 0 {
+		return s.CreatedBy
+	}
+
+	return s.OwnerId
+}
+
+func (s *ServiceAccountEntity) TransferOwnership(ctx context.Context, ownerId uint) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.OwnerId = ownerId
+	s.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+// RevokeCredentials 는 API Key 와 Client Secret 을 더 이상 사용할 수 없게 한다. 다시 사용하려면 새로 발급해야 한다.
+func (s *ServiceAccountEntity) RevokeCredentials(ctx context.Context) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.ApiKeyHash = ""
+	s.ApiKeyPrefix = ""
+	s.ApiKeyIssuedAt = nil
+	s.ClientSecret = ""
+	s.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+func (s ServiceAccountEntity) HasCredentials() bool {
+	return len(s.ApiKeyHash) > 0 || len(s.ClientSecret) > 0
+}
+
+// IssueApiKey 는 새 API Key 를 발급하고 원문을 반환한다.
+// 원문은 저장하지 않고 해시만 보관하므로 발급 시에만 확인할 수 있으며, 기존 키는 더 이상 사용할 수 없다.
+func (s *ServiceAccountEntity) IssueApiKey(ctx context.Context) (string, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	randomHex, err := generateRandomHex(24)
+	if err != nil {
+		return "", err
+	}
+
+	apiKey := apiKeyPrefix + randomHex
+	now := time.Now()
+
+	s.ApiKeyHash = HashApiKey(apiKey)
+	s.ApiKeyPrefix = apiKey[:len(apiKeyPrefix)+apiKeyDisplayChars]
+	s.ApiKeyIssuedAt = &now
+	s.UpdatedBy = userClaim.Id
+
+	return apiKey, nil
+}
+
+// IssueClientSecret 은 client_credentials 그랜트에 사용할 Client Id/Secret 을 발급한다.
+// Client Id 는 처음 발급 시에만 생성하고, Secret 은 발급할 때마다 교체된다.
+func (s *ServiceAccountEntity) IssueClientSecret(ctx context.Context) (string, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(s.ClientId) == 0 {
+		clientId, err := generateRandomHex(16)
+		if err != nil {
+			return "", err
+		}
+		s.ClientId = clientId
+	}
+
+	clientSecret, err := generateRandomHex(32)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(clientSecret), bcrypt.DefaultCost)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "hash client secret error")
+	}
+
+	s.ClientSecret = string(hash)
+	s.UpdatedBy = userClaim.Id
+
+	return clientSecret, nil
+}
+
+func (s ServiceAccountEntity) ValidateClientSecret(clientSecret string) error {
+	if len(s.ClientSecret) == 0 {
+		return errors.ErrAuthentication
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(s.ClientSecret), []byte(clientSecret)); err != nil {
+		return errors.ErrAuthentication
+	}
+
+	return nil
+}
+
+// ResolveScopes 는 요청한 scope 중 서비스 계정이 가진 권한만 허용한다.
+// scope 를 요청하지 않으면 서비스 계정의 모든 권한을 부여한다.
+func (s ServiceAccountEntity) ResolveScopes(requestedScopes []string) ([]string, error) {
+	permissionNames := s.GetPermissionNames()
+	if len(requestedScopes) == 0 {
+		return permissionNames, nil
+	}
+
+	allowed := make(map[string]bool)
+	for _, permissionName := range permissionNames {
+		allowed[permissionName] = true
+	}
+
+	for _, scope := range requestedScopes {
+		if !allowed[scope] {
+			return nil, errors.ErrInvalidScope
+		}
+	}
+
+	return requestedScopes, nil
+}
+
+func (s ServiceAccountEntity) GetRoleNames() []string {
+	var rolesNames = make([]string, 0)
+	if s.Roles == nil {
+		return rolesNames
+	}
+
+	for _, role := range s.Roles {
+		rolesNames = append(rolesNames, role.Name)
+	}
+
+	return rolesNames
+}
+
+func (s ServiceAccountEntity) GetPermissionNames() []string {
+	// 역할에 할당된 권한을 중복 없이 반환한다.
+	keys := make(map[string]bool)
+	permissionNames := make([]string, 0)
+	if s.Roles == nil {
+		return permissionNames
+	}
+
+	for _, role := range s.Roles {
+		for _, permission := range role.Permissions {
+			if _, exists := keys[permission.Name]; !exists {
+				keys[permission.Name] = true
+				permissionNames = append(permissionNames, permission.Name)
+			}
+		}
+	}
+
+	return permissionNames
+}
+
+func generateRandomHex(length int) (string, error) {
+	randomBytes := make([]byte, length)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", pkgerrors.Wrap(err, "generate random error")
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
+
+func HashApiKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(hash[:])
+}
+
+func NewServiceAccountEntity(ctx context.Context, information dtos.ServiceAccountInformation) (ServiceAccountEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return ServiceAccountEntity{}, err
+	}
+
+	return ServiceAccountEntity{
+		Name:                 information.Name,
+		Description:          information.Description,
+		TokenLifetimeSeconds: information.TokenLifetimeSeconds,
+		OwnerId:              userClaim.Id,
+		CreatedBy:            userClaim.Id,
+		UpdatedBy:            userClaim.Id,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// TokenExchangePolicyEntity 는 서비스 계정이 토큰 교환(RFC 8693)으로 멤버의 토큰을 바꿀 수 있는 대상 서비스(audience)이다.
+type TokenExchangePolicyEntity struct {
+	gorm.Model
+	ServiceAccountId uint   `gorm:"not null;index"`
+	Audience         string `gorm:"type:varchar(255);not null"`
+	// Scopes 는 교환한 토큰에 넘겨줄 수 있는 권한 이름을 공백으로 구분한다.
+	Scopes          string `gorm:"type:varchar(1000)"`
+	LifetimeSeconds int
+	CreatedBy       uint
+}
+
+func (TokenExchangePolicyEntity) TableName() string {
+	return "token_exchange_policies"
+}
+
+// NewTokenExchangePolicyEntities 는 서비스 계정의 교환 정책을 만든다. 같은 audience 를 두 번 지정할 수 없다.
+func NewTokenExchangePolicyEntities(ctx context.Context, serviceAccountId uint, policies []dtos.TokenExchangePolicy) ([]TokenExchangePolicyEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]TokenExchangePolicyEntity, 0)
+	audiences := map[string]bool{}
+	for _, policy := range policies {
+		if audiences[policy.Audience] {
+			return nil, errors.ErrDuplicated
+		}
+		audiences[policy.Audience] = true
+
+		entities = append(entities, TokenExchangePolicyEntity{
+			ServiceAccountId: serviceAccountId,
+			Audience:         policy.Audience,
+			Scopes:           strings.Join(policy.Scopes, " "),
+			LifetimeSeconds:  policy.LifetimeSeconds,
+			CreatedBy:        userClaim.Id,
+		})
+	}
+
+	return entities, nil
+}
+
+func (e TokenExchangePolicyEntity) GetScopes() []string {
+	return strings.Fields(e.Scopes)
+}
+
+// ResolveScopes 는 요청한 scope 중 정책이 허용하고 멤버가 가진 권한만 허용한다.
+// scope 를 요청하지 않으면 정책이 허용하는 멤버의 모든 권한을 넘겨준다.
+func (e TokenExchangePolicyEntity) ResolveScopes(subjectPermissions []string, requestedScopes []string) ([]string, error) {
+	allowed := make(map[string]bool)
+	for _, scope := range e.GetScopes() {
+		allowed[scope] = true
+	}
+
+	if len(requestedScopes) == 0 {
+		permissions := make([]string, 0)
+		for _, permission := range subjectPermissions {
+			if allowed[permission] {
+				permissions = append(permissions, permission)
+			}
+		}
+		return permissions, nil
+	}
+
+	granted := make(map[string]bool)
+	for _, permission := range subjectPermissions {
+		granted[permission] = true
+	}
+
+	for _, scope := range requestedScopes {
+		if !allowed[scope] || !granted[scope] {
+			return nil, errors.ErrInvalidScope
+		}
+	}
+
+	return requestedScopes, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/serviceaccount/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ServiceAccountRepository struct {
+}
+
+func (ServiceAccountRepository) Create(ctx context.Context, entity *domain.ServiceAccountEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ServiceAccountRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.ServiceAccountEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ServiceAccountEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "ownerId" {
+				db.Scopes(helpers.GormHelper().OwnedBy(value.(uint)))
+			}
+		}
+	}
+
+	var entities = make([]domain.ServiceAccountEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Preload("Roles.Permissions").Preload(clause.Associations).
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (ServiceAccountRepository) FindById(ctx context.Context, id uint) (domain.ServiceAccountEntity, error) {
+	var entity domain.ServiceAccountEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Preload("Roles.Permissions").Preload(clause.Associations).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (ServiceAccountRepository) FindByApiKeyHash(ctx context.Context, apiKeyHash string) (domain.ServiceAccountEntity, error) {
+	var entity domain.ServiceAccountEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.ServiceAccountEntity{ApiKeyHash: apiKeyHash}).
+		Preload("Roles.Permissions").Preload(clause.Associations).
+		First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (ServiceAccountRepository) FindByClientId(ctx context.Context, clientId string) (domain.ServiceAccountEntity, error) {
+	var entity domain.ServiceAccountEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("client_id = ?", clientId).
+		Preload("Roles.Permissions").Preload(clause.Associations).
+		First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (ServiceAccountRepository) Save(ctx context.Context, entity *domain.ServiceAccountEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Model(entity).Association("Roles").Replace(entity.Roles); err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ServiceAccountRepository) Delete(ctx context.Context, entity domain.ServiceAccountEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Model(&entity).Association("Roles").Clear(); err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Save(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ServiceAccountRepository) FindByOwnerId(ctx context.Context, ownerId uint) ([]domain.ServiceAccountEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.ServiceAccountEntity, 0)
+	if err := db.Scopes(helpers.GormHelper().OwnedBy(ownerId)).Preload("Roles.Permissions").Preload(clause.Associations).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// CountWithApiKey 는 API Key 를 발급한 서비스 계정 수를 조회한다.
+func (ServiceAccountRepository) CountWithApiKey(ctx context.Context) (int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var count int64
+	if err := db.Model(&domain.ServiceAccountEntity{}).Where("api_key_hash <> ''").Count(&count).Error; err != nil {
+		return 0, pkgerrors.Wrap(err, "db error")
+	}
+
+	return count, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/serviceaccount/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type TokenExchangePolicyRepository struct {
+}
+
+func (TokenExchangePolicyRepository) FindByServiceAccountId(ctx context.Context, serviceAccountId uint) ([]domain.TokenExchangePolicyEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.TokenExchangePolicyEntity, 0)
+	if err := db.Where("service_account_id = ?", serviceAccountId).
+		Order("id ASC").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (TokenExchangePolicyRepository) FindByServiceAccountIdAndAudience(ctx context.Context, serviceAccountId uint, audience string) (domain.TokenExchangePolicyEntity, error) {
+	var entity domain.TokenExchangePolicyEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("service_account_id = ? AND audience = ?", serviceAccountId, audience).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// ReplaceAll 은 서비스 계정의 교환 정책을 모두 지우고 entities 로 바꾼다.
+func (TokenExchangePolicyRepository) ReplaceAll(ctx context.Context, serviceAccountId uint, entities []domain.TokenExchangePolicyEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Unscoped().Where("service_account_id = ?", serviceAccountId).
+		Delete(&domain.TokenExchangePolicyEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if len(entities) == 0 {
+		return nil
+	}
+
+	if err := db.Create(&entities).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberRepository "better-admin-backend-service/member/repository"
+	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/rbac/repository"
+	"context"
+	"sort"
+	"time"
+)
+
+// AccessHistoryService 는 멤버-역할, 역할-권한 할당의 유효 기간을 기록하고 과거 시각의 권한을 계산한다.
+type AccessHistoryService struct {
+	accessHistoryRepository *repository.AccessHistoryRepository
+	memberRepository        *memberRepository.MemberRepository
+}
+
+func NewAccessHistoryService(accessHistoryRepository *repository.AccessHistoryRepository,
+	memberRepository *memberRepository.MemberRepository) *AccessHistoryService {
+	return &AccessHistoryService{
+		accessHistoryRepository: accessHistoryRepository,
+		memberRepository:        memberRepository,
+	}
+}
+
+// SyncMemberRoles 는 멤버의 현재 역할(roleIds)에 맞게 없어진 할당은 끝내고 새 할당은 시작한다.
+func (s AccessHistoryService) SyncMemberRoles(ctx context.Context, memberId uint, roleIds []uint) error {
+	current, err := s.accessHistoryRepository.FindCurrentMemberRoles(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	wanted := make(map[uint]bool)
+	for _, id := range roleIds {
+		wanted[id] = true
+	}
+
+	assigned := make(map[uint]bool)
+	revokeIds := make([]uint, 0)
+	for _, assignment := range current {
+		if wanted[assignment.RoleId] && !assigned[assignment.RoleId] {
+			assigned[assignment.RoleId] = true
+			continue
+		}
+		revokeIds = append(revokeIds, assignment.ID)
+	}
+
+	newAssignments := make([]domain.MemberRoleAssignmentEntity, 0)
+	for _, roleId := range roleIds {
+		if assigned[roleId] {
+			continue
+		}
+		assigned[roleId] = true
+		newAssignments = append(newAssignments, domain.NewMemberRoleAssignmentEntity(ctx, memberId, roleId, now))
+	}
+
+	if err := s.accessHistoryRepository.RevokeMemberRoles(ctx, revokeIds, now, actorIdOf(ctx)); err != nil {
+		return err
+	}
+
+	return s.accessHistoryRepository.CreateMemberRoles(ctx, newAssignments)
+}
+
+// SyncRolePermissions 는 역할의 현재 권한(permissionIds)에 맞게 없어진 할당은 끝내고 새 할당은 시작한다.
+func (s AccessHistoryService) SyncRolePermissions(ctx context.Context, roleId uint, permissionIds []uint) error {
+	current, err := s.accessHistoryRepository.FindCurrentRolePermissions(ctx, roleId)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	wanted := make(map[uint]bool)
+	for _, id := range permissionIds {
+		wanted[id] = true
+	}
+
+	assigned := make(map[uint]bool)
+	revokeIds := make([]uint, 0)
+	for _, assignment := range current {
+		if wanted[assignment.PermissionId] && !assigned[assignment.PermissionId] {
+			assigned[assignment.PermissionId] = true
+			continue
+		}
+		revokeIds = append(revokeIds, assignment.ID)
+	}
+
+	newAssignments := make([]domain.RolePermissionAssignmentEntity, 0)
+	for _, permissionId := range permissionIds {
+		if assigned[permissionId] {
+			continue
+		}
+		assigned[permissionId] = true
+		newAssignments = append(newAssignments, domain.NewRolePermissionAssignmentEntity(ctx, roleId, permissionId, now))
+	}
+
+	if err := s.accessHistoryRepository.RevokeRolePermissions(ctx, revokeIds, now, actorIdOf(ctx)); err != nil {
+		return err
+	}
+
+	return s.accessHistoryRepository.CreateRolePermissions(ctx, newAssignments)
+}
+
+// RevokeRole 은 삭제한 역할의 권한 할당과 멤버 할당을 모두 끝낸다.
+func (s AccessHistoryService) RevokeRole(ctx context.Context, roleId uint) error {
+	if err := s.SyncRolePermissions(ctx, roleId, nil); err != nil {
+		return err
+	}
+
+	current, err := s.accessHistoryRepository.FindCurrentMemberRolesByRole(ctx, roleId)
+	if err != nil {
+		return err
+	}
+
+	revokeIds := make([]uint, 0, len(current))
+	for _, assignment := range current {
+		revokeIds = append(revokeIds, assignment.ID)
+	}
+
+	return s.accessHistoryRepository.RevokeMemberRoles(ctx, revokeIds, time.Now(), actorIdOf(ctx))
+}
+
+// GetMemberAccessAt 은 at 시각에 멤버에게 할당되어 있던 역할과 권한을 계산한다.
+func (s AccessHistoryService) GetMemberAccessAt(ctx context.Context, memberId uint, at time.Time) (dtos.MemberAccessHistory, error) {
+	members, err := s.memberRepository.FindByIdsWithDeleted(ctx, []uint{memberId})
+	if err != nil {
+		return dtos.MemberAccessHistory{}, err
+	}
+
+	if len(members) == 0 {
+		return dtos.MemberAccessHistory{}, errors.ErrNotFound
+	}
+
+	memberRoles, err := s.accessHistoryRepository.FindMemberRolesAt(ctx, memberId, at)
+	if err != nil {
+		return dtos.MemberAccessHistory{}, err
+	}
+
+	roles, err := s.toAccessHistoryRoles(ctx, memberRoles)
+	if err != nil {
+		return dtos.MemberAccessHistory{}, err
+	}
+
+	roleIds := make([]uint, 0, len(memberRoles))
+	for _, memberRole := range memberRoles {
+		roleIds = append(roleIds, memberRole.RoleId)
+	}
+
+	rolePermissions, err := s.accessHistoryRepository.FindRolePermissionsAt(ctx, roleIds, at)
+	if err != nil {
+		return dtos.MemberAccessHistory{}, err
+	}
+
+	permissionIds := make([]uint, 0, len(rolePermissions))
+	for _, rolePermission := range rolePermissions {
+		permissionIds = append(permissionIds, rolePermission.PermissionId)
+	}
+
+	permissionEntities, err := s.accessHistoryRepository.FindPermissionsByIds(ctx, permissionIds)
+	if err != nil {
+		return dtos.MemberAccessHistory{}, err
+	}
+
+	permissionNames := make(map[string]bool)
+	permissions := make([]string, 0, len(permissionEntities))
+	for _, permission := range permissionEntities {
+		if !permissionNames[permission.Name] {
+			permissionNames[permission.Name] = true
+			permissions = append(permissions, permission.Name)
+		}
+	}
+	sort.Strings(permissions)
+
+	return dtos.MemberAccessHistory{
+		MemberId:    memberId,
+		At:          at,
+		Roles:       roles,
+		Permissions: permissions,
+	}, nil
+}
+
+// GetPermissionHoldersAt 은 at 시각에 역할을 통해 권한(permissionName)을 가지고 있던 멤버를 찾는다.
+func (s AccessHistoryService) GetPermissionHoldersAt(ctx context.Context, permissionName string, at time.Time) (dtos.PermissionAccessHistory, error) {
+	history := dtos.PermissionAccessHistory{
+		Permission: permissionName,
+		At:         at,
+		Holders:    make([]dtos.PermissionAccessHolder, 0),
+	}
+
+	permissionEntities, err := s.accessHistoryRepository.FindPermissionsByName(ctx, permissionName)
+	if err != nil {
+		return history, err
+	}
+
+	permissionIds := make([]uint, 0, len(permissionEntities))
+	for _, permission := range permissionEntities {
+		permissionIds = append(permissionIds, permission.ID)
+	}
+
+	rolePermissions, err := s.accessHistoryRepository.FindRolePermissionsByPermissionsAt(ctx, permissionIds, at)
+	if err != nil {
+		return history, err
+	}
+
+	roleIds := make([]uint, 0, len(rolePermissions))
+	for _, rolePermission := range rolePermissions {
+		roleIds = append(roleIds, rolePermission.RoleId)
+	}
+
+	memberRoles, err := s.accessHistoryRepository.FindMemberRolesByRolesAt(ctx, roleIds, at)
+	if err != nil {
+		return history, err
+	}
+
+	memberIds := make([]uint, 0)
+	memberRolesByMember := make(map[uint][]domain.MemberRoleAssignmentEntity)
+	for _, memberRole := range memberRoles {
+		if _, ok := memberRolesByMember[memberRole.MemberId]; !ok {
+			memberIds = append(memberIds, memberRole.MemberId)
+		}
+		memberRolesByMember[memberRole.MemberId] = append(memberRolesByMember[memberRole.MemberId], memberRole)
+	}
+
+	members, err := s.memberRepository.FindByIdsWithDeleted(ctx, memberIds)
+	if err != nil {
+		return history, err
+	}
+
+	for _, member := range members {
+		roles, err := s.toAccessHistoryRoles(ctx, memberRolesByMember[member.ID])
+		if err != nil {
+			return history, err
+		}
+
+		history.Holders = append(history.Holders, dtos.PermissionAccessHolder{
+			MemberId: member.ID,
+			SignId:   member.SignId,
+			Name:     member.Name,
+			Roles:    roles,
+		})
+	}
+
+	return history, nil
+}
+
+func (s AccessHistoryService) toAccessHistoryRoles(ctx context.Context, memberRoles []domain.MemberRoleAssignmentEntity) ([]dtos.AccessHistoryRole, error) {
+	roleIds := make([]uint, 0, len(memberRoles))
+	for _, memberRole := range memberRoles {
+		roleIds = append(roleIds, memberRole.RoleId)
+	}
+
+	roleEntities, err := s.accessHistoryRepository.FindRolesByIds(ctx, roleIds)
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := make(map[uint]string)
+	for _, role := range roleEntities {
+		roleNames[role.ID] = role.Name
+	}
+
+	roles := make([]dtos.AccessHistoryRole, 0, len(memberRoles))
+	for _, memberRole := range memberRoles {
+		roles = append(roles, dtos.AccessHistoryRole{
+			Id:            memberRole.RoleId,
+			Name:          roleNames[memberRole.RoleId],
+			EffectiveFrom: memberRole.EffectiveFrom,
+			EffectiveTo:   memberRole.EffectiveTo,
+		})
+	}
+
+	return roles, nil
+}
+
+// actorIdOf 는 변경한 멤버의 ID 이다. 시스템 작업(스케줄러 등)이 변경하면 0 이다.
+func actorIdOf(ctx context.Context) uint {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return 0
+	}
+
+	return userClaim.Id
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"better-admin-backend-service/approval/domain"
+	"better-admin-backend-service/approval/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"context"
+	"fmt"
+	"time"
+)
+
+type ApprovalDelegationService struct {
+	memberService                *MemberService
+	approvalDelegationRepository *repository.ApprovalDelegationRepository
+	auditService                 *AuditService
+}
+
+func NewApprovalDelegationService(memberService *MemberService,
+	approvalDelegationRepository *repository.ApprovalDelegationRepository,
+	auditService *AuditService) *ApprovalDelegationService {
+	return &ApprovalDelegationService{
+		memberService:                memberService,
+		approvalDelegationRepository: approvalDelegationRepository,
+		auditService:                 auditService,
+	}
+}
+
+func (s ApprovalDelegationService) CreateDelegation(ctx context.Context, information dtos.ApprovalDelegationInformation) (domain.ApprovalDelegationEntity, error) {
+	if _, err := memberClaimOf(ctx); err != nil {
+		return domain.ApprovalDelegationEntity{}, err
+	}
+
+	if _, err := s.memberService.GetMemberById(ctx, information.DelegateId); err != nil {
+		return domain.ApprovalDelegationEntity{}, err
+	}
+
+	entity, err := domain.NewApprovalDelegationEntity(ctx, information)
+	if err != nil {
+		return domain.ApprovalDelegationEntity{}, err
+	}
+
+	if err := s.approvalDelegationRepository.Create(ctx, &entity); err != nil {
+		return domain.ApprovalDelegationEntity{}, err
+	}
+
+	return entity, s.recordDelegated(ctx, entity)
+}
+
+func (s ApprovalDelegationService) GetMyDelegations(ctx context.Context) ([]domain.ApprovalDelegationEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.approvalDelegationRepository.FindByDelegatorId(ctx, userClaim.Id)
+}
+
+func (s ApprovalDelegationService) UpdateDelegation(ctx context.Context, delegationId uint, information dtos.ApprovalDelegationInformation) error {
+	if _, err := memberClaimOf(ctx); err != nil {
+		return err
+	}
+
+	entity, err := s.approvalDelegationRepository.FindById(ctx, delegationId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.memberService.GetMemberById(ctx, information.DelegateId); err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, information); err != nil {
+		return err
+	}
+
+	if err := s.approvalDelegationRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.recordDelegated(ctx, entity)
+}
+
+func (s ApprovalDelegationService) DeleteDelegation(ctx context.Context, delegationId uint) error {
+	if _, err := memberClaimOf(ctx); err != nil {
+		return err
+	}
+
+	entity, err := s.approvalDelegationRepository.FindById(ctx, delegationId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.CheckDeletable(ctx); err != nil {
+		return err
+	}
+
+	if err := s.approvalDelegationRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalDelegationDeleted, constants.AuditTargetTypeApprovalDelegation,
+		entity.ID, fmt.Sprintf("delegateId=%v", entity.DelegateId))
+}
+
+// GetApprovers 는 로그인한 멤버 자신과, 지금 위임 받은 멤버를 대신하는 승인자를 반환한다.
+// 서비스 계정은 승인자가 아니므로 ErrAuthentication 이다.
+func (s ApprovalDelegationService) GetApprovers(ctx context.Context) ([]domain.Approver, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers := []domain.Approver{{Id: userClaim.Id, RoleNames: userClaim.Roles}}
+
+	delegations, err := s.approvalDelegationRepository.FindActiveByDelegateId(ctx, userClaim.Id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, delegation := range delegations {
+		delegator, err := s.memberService.GetMemberById(ctx, delegation.DelegatorId)
+		if err != nil {
+			return nil, err
+		}
+
+		approvers = append(approvers, domain.Approver{
+			Id:         userClaim.Id,
+			RoleNames:  delegator.GetRoleNames(),
+			OnBehalfOf: delegation.DelegatorId,
+		})
+	}
+
+	return approvers, nil
+}
+
+// GetActiveDelegateIds 는 부재 중인 멤버 대신 승인 요청을 받을 멤버를 반환한다.
+func (s ApprovalDelegationService) GetActiveDelegateIds(ctx context.Context, delegatorId uint) ([]uint, error) {
+	delegations, err := s.approvalDelegationRepository.FindActiveByDelegatorId(ctx, delegatorId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	delegateIds := make([]uint, 0)
+	for _, delegation := range delegations {
+		delegateIds = append(delegateIds, delegation.DelegateId)
+	}
+
+	return delegateIds, nil
+}
+
+func (s ApprovalDelegationService) recordDelegated(ctx context.Context, entity domain.ApprovalDelegationEntity) error {
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalDelegated, constants.AuditTargetTypeApprovalDelegation,
+		entity.ID, fmt.Sprintf("delegateId=%v, startsAt=%v, endsAt=%v",
+			entity.DelegateId, entity.StartsAt.Format(time.RFC3339), entity.EndsAt.Format(time.RFC3339)))
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"better-admin-backend-service/approval/domain"
+	"better-admin-backend-service/dtos"
+	"context"
+)
+
+// MemberSignUpApprovalHandler 는 회원 가입 승인 절차의 결과에 따라 멤버를 승인하거나 거절한다.
+type MemberSignUpApprovalHandler struct {
+	memberService *MemberService
+}
+
+func NewMemberSignUpApprovalHandler(memberService *MemberService) *MemberSignUpApprovalHandler {
+	return &MemberSignUpApprovalHandler{
+		memberService: memberService,
+	}
+}
+
+func (h MemberSignUpApprovalHandler) OnApproved(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	return h.memberService.ApproveMember(ctx, request.TargetId)
+}
+
+func (h MemberSignUpApprovalHandler) OnRejected(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	return h.memberService.RejectMember(ctx, request.TargetId)
+}
+
+// RoleGrantApprovalHandler 는 역할 할당이 승인되면 요청한 역할을 할당한다.
+type RoleGrantApprovalHandler struct {
+	memberService *MemberService
+}
+
+func NewRoleGrantApprovalHandler(memberService *MemberService) *RoleGrantApprovalHandler {
+	return &RoleGrantApprovalHandler{
+		memberService: memberService,
+	}
+}
+
+func (h RoleGrantApprovalHandler) OnApproved(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	var assignRole dtos.MemberAssignRole
+	if err := request.DecodePayload(&assignRole); err != nil {
+		return err
+	}
+
+	return h.memberService.AssignRole(ctx, request.TargetId, assignRole)
+}
+
+func (h RoleGrantApprovalHandler) OnRejected(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	return nil
+}
+
+// MemberFieldChangeApprovalHandler 는 멤버 중요 필드 변경이 승인되면 반영하고, 반려되면 변경을 반려 처리한다.
+type MemberFieldChangeApprovalHandler struct {
+	memberFieldChangeService *MemberFieldChangeService
+}
+
+func NewMemberFieldChangeApprovalHandler(memberFieldChangeService *MemberFieldChangeService) *MemberFieldChangeApprovalHandler {
+	return &MemberFieldChangeApprovalHandler{
+		memberFieldChangeService: memberFieldChangeService,
+	}
+}
+
+func (h MemberFieldChangeApprovalHandler) OnApproved(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	return h.memberFieldChangeService.ApplyFieldChange(ctx, request.TargetId)
+}
+
+func (h MemberFieldChangeApprovalHandler) OnRejected(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	return h.memberFieldChangeService.RejectFieldChange(ctx, request.TargetId)
+}
+
+// RoleElevationApprovalHandler 는 임시 권한 상승이 승인되면 기한 동안 역할을 할당하고, 반려되면 요청을 반려 처리한다.
+type RoleElevationApprovalHandler struct {
+	roleElevationService *RoleElevationService
+}
+
+func NewRoleElevationApprovalHandler(roleElevationService *RoleElevationService) *RoleElevationApprovalHandler {
+	return &RoleElevationApprovalHandler{
+		roleElevationService: roleElevationService,
+	}
+}
+
+func (h RoleElevationApprovalHandler) OnApproved(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	return h.roleElevationService.ActivateElevation(ctx, request.TargetId)
+}
+
+func (h RoleElevationApprovalHandler) OnRejected(ctx context.Context, request domain.ApprovalRequestEntity) error {
+	return h.roleElevationService.RejectElevation(ctx, request.TargetId)
+}
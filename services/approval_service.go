@@ -0,0 +1,414 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/approval/domain"
+	"better-admin-backend-service/approval/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// ApprovalHandler 는 승인 절차가 끝났을 때 대상에 결과를 반영한다.
+type ApprovalHandler interface {
+	OnApproved(ctx context.Context, request domain.ApprovalRequestEntity) error
+	OnRejected(ctx context.Context, request domain.ApprovalRequestEntity) error
+}
+
+type ApprovalService struct {
+	siteService               *SiteService
+	memberService             *MemberService
+	delegationService         *ApprovalDelegationService
+	approvalRequestRepository *repository.ApprovalRequestRepository
+	auditService              *AuditService
+	messageTemplateService    *MessageTemplateService
+	handlers                  map[string]ApprovalHandler
+}
+
+func NewApprovalService(siteService *SiteService,
+	memberService *MemberService,
+	delegationService *ApprovalDelegationService,
+	approvalRequestRepository *repository.ApprovalRequestRepository,
+	auditService *AuditService,
+	messageTemplateService *MessageTemplateService) *ApprovalService {
+	return &ApprovalService{
+		siteService:               siteService,
+		memberService:             memberService,
+		delegationService:         delegationService,
+		approvalRequestRepository: approvalRequestRepository,
+		auditService:              auditService,
+		messageTemplateService:    messageTemplateService,
+		handlers:                  map[string]ApprovalHandler{},
+	}
+}
+
+func (s ApprovalService) RegisterHandler(subject string, handler ApprovalHandler) {
+	s.handlers[subject] = handler
+}
+
+func (s ApprovalService) GetWorkflowSetting(ctx context.Context) (dtos.ApprovalWorkflowSetting, error) {
+	workflowSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyApprovalWorkflow)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.ApprovalWorkflowSetting{Workflows: []dtos.ApprovalWorkflow{}}, nil
+		}
+		return dtos.ApprovalWorkflowSetting{}, err
+	}
+
+	var setting dtos.ApprovalWorkflowSetting
+	if err = mapstructure.Decode(workflowSetting, &setting); err != nil {
+		return dtos.ApprovalWorkflowSetting{}, err
+	}
+
+	return setting, nil
+}
+
+// RequireApproval 은 대상에 승인 절차가 정의되어 있으면 승인 요청을 만들고 false 를 반환한다.
+// 승인 절차가 없거나, 요청자가 이미 승인 받은(사용하지 않은) 요청이 있으면 true 를 반환하여 바로 처리하게 한다.
+func (s ApprovalService) RequireApproval(ctx context.Context, subject string, targetId uint, payload interface{}) (bool, error) {
+	setting, err := s.GetWorkflowSetting(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	workflow, ok := setting.FindWorkflow(subject)
+	if !ok {
+		return true, nil
+	}
+
+	consumed, err := s.consumeApproved(ctx, subject, targetId)
+	if err != nil || consumed {
+		return consumed, err
+	}
+
+	pending, err := s.HasPendingRequest(ctx, subject, targetId)
+	if err != nil {
+		return false, err
+	}
+
+	if pending {
+		return false, errors.ErrApprovalInProgress
+	}
+
+	entity, err := domain.NewApprovalRequestEntity(ctx, workflow, targetId, payload)
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.approvalRequestRepository.Create(ctx, &entity); err != nil {
+		return false, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalRequested, constants.AuditTargetTypeApproval,
+		entity.ID, fmt.Sprintf("subject=%v, targetId=%v", subject, targetId)); err != nil {
+		return false, err
+	}
+
+	s.notifyApprovers(ctx, entity, entity.GetApproverRoleNames(), "승인 요청")
+	return false, nil
+}
+
+func (s ApprovalService) HasPendingRequest(ctx context.Context, subject string, targetId uint) (bool, error) {
+	entities, err := s.approvalRequestRepository.FindBySubjectAndTargetId(ctx, subject, targetId, constants.ApprovalStatusPending)
+	if err != nil {
+		return false, err
+	}
+
+	return len(entities) > 0, nil
+}
+
+func (s ApprovalService) consumeApproved(ctx context.Context, subject string, targetId uint) (bool, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	entities, err := s.approvalRequestRepository.FindBySubjectAndTargetId(ctx, subject, targetId, constants.ApprovalStatusApproved)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range entities {
+		if entities[i].ConsumedAt == nil && entities[i].IsRequestedBy(*userClaim) {
+			entities[i].Consume()
+			return true, s.approvalRequestRepository.Save(ctx, &entities[i])
+		}
+	}
+
+	return false, nil
+}
+
+// GetDecidableRequests 는 로그인한 멤버가 직접 또는 위임 받아 처리할 수 있는 승인 요청을 반환한다.
+func (s ApprovalService) GetDecidableRequests(ctx context.Context) ([]domain.ApprovalRequestEntity, error) {
+	approvers, err := s.delegationService.GetApprovers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := s.approvalRequestRepository.FindAllPending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	decidableEntities := make([]domain.ApprovalRequestEntity, 0)
+	for _, entity := range entities {
+		if _, ok := findApprover(entity, approvers); ok {
+			decidableEntities = append(decidableEntities, entity)
+		}
+	}
+
+	return decidableEntities, nil
+}
+
+// GetApprovalRequest 는 요청자 또는 현재 단계의 승인자만 조회할 수 있다.
+func (s ApprovalService) GetApprovalRequest(ctx context.Context, id uint) (domain.ApprovalRequestEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return domain.ApprovalRequestEntity{}, err
+	}
+
+	entity, err := s.approvalRequestRepository.FindById(ctx, id)
+	if err != nil {
+		return domain.ApprovalRequestEntity{}, err
+	}
+
+	approvers, err := s.delegationService.GetApprovers(ctx)
+	if err != nil {
+		return domain.ApprovalRequestEntity{}, err
+	}
+
+	if _, ok := findApprover(entity, approvers); !entity.IsRequestedBy(*userClaim) && !ok {
+		return domain.ApprovalRequestEntity{}, errors.ErrForbidden
+	}
+
+	return entity, nil
+}
+
+// GetMemberApprovalRequests 는 멤버가 대상인 가입, 역할 할당 승인 요청이다. 멤버 관리(데이터 내보내기)에서 사용한다.
+func (s ApprovalService) GetMemberApprovalRequests(ctx context.Context, memberId uint) ([]domain.ApprovalRequestEntity, error) {
+	return s.approvalRequestRepository.FindByTargetId(ctx,
+		[]string{constants.ApprovalSubjectMemberSignUp, constants.ApprovalSubjectRoleGrant}, memberId)
+}
+
+func (s ApprovalService) ApproveRequest(ctx context.Context, id uint, comment string) error {
+	entity, err := s.approvalRequestRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	approver, err := s.resolveApprover(ctx, entity)
+	if err != nil {
+		return err
+	}
+
+	step := entity.CurrentStep
+	completed, err := entity.Approve(approver, comment)
+	if err != nil {
+		return err
+	}
+
+	// 요청한 변경을 반영하지 못하면(예. 최고 관리자 보호) 승인도 저장하지 않도록 처리기를 먼저 실행한다.
+	if handler, ok := s.handlers[entity.Subject]; ok && completed {
+		if err := handler.OnApproved(ctx, entity); err != nil {
+			return err
+		}
+	}
+
+	if err := s.approvalRequestRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalStepApproved, constants.AuditTargetTypeApproval,
+		entity.ID, fmt.Sprintf("step=%v%v", step, onBehalfOfDetail(approver))); err != nil {
+		return err
+	}
+
+	if !completed {
+		if entity.CurrentStep != step {
+			s.notifyApprovers(ctx, entity, entity.GetApproverRoleNames(), "승인 요청")
+		}
+		return nil
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalApproved, constants.AuditTargetTypeApproval,
+		entity.ID, fmt.Sprintf("subject=%v, targetId=%v", entity.Subject, entity.TargetId))
+}
+
+func (s ApprovalService) RejectRequest(ctx context.Context, id uint, comment string) error {
+	entity, err := s.approvalRequestRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	approver, err := s.resolveApprover(ctx, entity)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Reject(approver, comment); err != nil {
+		return err
+	}
+
+	if handler, ok := s.handlers[entity.Subject]; ok {
+		if err := handler.OnRejected(ctx, entity); err != nil {
+			return err
+		}
+	}
+
+	if err := s.approvalRequestRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalRejected, constants.AuditTargetTypeApproval,
+		entity.ID, fmt.Sprintf("subject=%v, targetId=%v%v", entity.Subject, entity.TargetId, onBehalfOfDetail(approver)))
+}
+
+// ExpirePendingRequests 는 대상의 처리되지 않은 승인 요청을 만료한다. 대상이 먼저 만료된 경우(예. 멤버 중요 필드 변경)에 사용한다.
+func (s ApprovalService) ExpirePendingRequests(ctx context.Context, subject string, targetId uint) error {
+	entities, err := s.approvalRequestRepository.FindBySubjectAndTargetId(ctx, subject, targetId, constants.ApprovalStatusPending)
+	if err != nil {
+		return err
+	}
+
+	for i := range entities {
+		if err := entities[i].Expire(); err != nil {
+			return err
+		}
+
+		if err := s.approvalRequestRepository.Save(ctx, &entities[i]); err != nil {
+			return err
+		}
+
+		if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalExpired, constants.AuditTargetTypeApproval,
+			entities[i].ID, fmt.Sprintf("subject=%v, targetId=%v", subject, targetId)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveApprover 는 멤버 자신의 역할로 처리할 수 없으면 위임 받은 멤버를 대신하여 처리한다.
+func (s ApprovalService) resolveApprover(ctx context.Context, entity domain.ApprovalRequestEntity) (domain.Approver, error) {
+	approvers, err := s.delegationService.GetApprovers(ctx)
+	if err != nil {
+		return domain.Approver{}, err
+	}
+
+	if approver, ok := findApprover(entity, approvers); ok {
+		return approver, nil
+	}
+
+	return approvers[0], nil
+}
+
+func findApprover(entity domain.ApprovalRequestEntity, approvers []domain.Approver) (domain.Approver, bool) {
+	for _, approver := range approvers {
+		if entity.CanDecide(approver.RoleNames) {
+			return approver, true
+		}
+	}
+
+	return domain.Approver{}, false
+}
+
+func onBehalfOfDetail(approver domain.Approver) string {
+	if approver.OnBehalfOf == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf(", onBehalfOf=%v", approver.OnBehalfOf)
+}
+
+// ProcessOverdueApprovals 는 기한(SLA)이 지난 승인 요청의 승인자에게 다시 알리고, 상위 승인자에게 넘긴다.
+func (s ApprovalService) ProcessOverdueApprovals(ctx context.Context) error {
+	entities, err := s.approvalRequestRepository.FindAllPending(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range entities {
+		entity := &entities[i]
+		changed := false
+
+		if entity.NeedsReminder(now) {
+			entity.MarkReminded()
+			s.notifyApprovers(ctx, *entity, entity.GetApproverRoleNames(), "승인 요청 재알림")
+			changed = true
+		}
+
+		if entity.NeedsEscalation(now) {
+			entity.Escalate()
+			step, _ := entity.GetCurrentStep()
+			s.notifyApprovers(ctx, *entity, []string{step.EscalationRoleName}, "승인 요청 이관")
+			if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionApprovalEscalated, constants.AuditTargetTypeApproval,
+				entity.ID, fmt.Sprintf("step=%v, escalationRoleName=%v", entity.CurrentStep, step.EscalationRoleName)); err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		if changed {
+			if err := s.approvalRequestRepository.Save(ctx, entity); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// notifyApprovers 는 역할이 할당된 멤버 중 이메일이 있는 멤버에게 알린다. 알림 실패가 승인 처리를 막지는 않는다.
+func (s ApprovalService) notifyApprovers(ctx context.Context, entity domain.ApprovalRequestEntity, roleNames []string, title string) {
+	step, _ := entity.GetCurrentStep()
+
+	for _, roleName := range roleNames {
+		members, err := s.memberService.GetMembersByRoleName(ctx, roleName)
+		if err != nil {
+			log.Error("approval notification error: ", err)
+			return
+		}
+
+		for _, member := range members {
+			s.sendApprovalMail(ctx, member.GetEmail(), entity, step.Name, title)
+
+			// 부재 중인 승인자의 요청은 위임 받은 멤버에게도 알린다.
+			delegateIds, err := s.delegationService.GetActiveDelegateIds(ctx, member.ID)
+			if err != nil {
+				log.Error("approval notification error: ", err)
+				continue
+			}
+
+			for _, delegateId := range delegateIds {
+				delegate, err := s.memberService.GetMemberById(ctx, delegateId)
+				if err != nil {
+					log.Error("approval notification error: ", err)
+					continue
+				}
+				s.sendApprovalMail(ctx, delegate.GetEmail(), entity, step.Name, title)
+			}
+		}
+	}
+}
+
+func (s ApprovalService) sendApprovalMail(ctx context.Context, email string, entity domain.ApprovalRequestEntity, stepName string, title string) {
+	if len(email) == 0 {
+		return
+	}
+
+	if err := adapters.MailAdapter().Send(s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateApprovalRequested, []string{email}, map[string]string{
+		"title":      title,
+		"subject":    entity.Subject,
+		"approvalId": fmt.Sprint(entity.ID),
+		"stepName":   stepName,
+	})); err != nil {
+		log.Error("approval notification error: ", err)
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/audit/domain"
+	"better-admin-backend-service/audit/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+)
+
+// ElevationFinder 는 감사 로그를 남기는 멤버가 임시 권한 상승 중이면 상승 ID 를, 아니면 0 을 반환한다.
+type ElevationFinder interface {
+	FindActiveElevationId(ctx context.Context, memberId uint) (uint, error)
+}
+
+type AuditService struct {
+	auditLogRepository     *repository.AuditLogRepository
+	activityFeedRepository *repository.ActivityFeedRepository
+	elevationFinder        ElevationFinder
+}
+
+func NewAuditService(auditLogRepository *repository.AuditLogRepository,
+	activityFeedRepository *repository.ActivityFeedRepository) *AuditService {
+	return &AuditService{
+		auditLogRepository:     auditLogRepository,
+		activityFeedRepository: activityFeedRepository,
+	}
+}
+
+// RegisterElevationFinder 는 감사 로그에 임시 권한 상승 ID 를 기록할 ElevationFinder 를 등록한다. 등록하지 않으면 기록하지 않는다.
+func (s *AuditService) RegisterElevationFinder(finder ElevationFinder) {
+	s.elevationFinder = finder
+}
+
+// RecordAuditLog 는 감사 로그를 기록하고 활동 피드에도 추가한다. 기록한 감사 로그는 트랜잭션이 커밋되면 이벤트 버스로 보낸다.
+func (s AuditService) RecordAuditLog(ctx context.Context, action, targetType string, targetId uint, detail string) error {
+	entity := domain.NewAuditLogEntity(ctx, action, targetType, targetId, detail)
+	if err := s.tagElevation(ctx, &entity); err != nil {
+		return err
+	}
+	if err := s.auditLogRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		adapters.EventBusAdapter().Publish(entity.ToEvent())
+	})
+
+	activityFeed := domain.NewActivityFeedEntityFromAuditLog(entity)
+	return s.activityFeedRepository.Create(ctx, &activityFeed)
+}
+
+// RecordBatchAuditLog 는 여러 대상에 한 번에 한 변경을 감사 로그 하나로 기록한다.
+// 대상마다 활동 피드를 추가(같은 감사 로그 ID)하므로 대상의 활동 피드에서도 변경을 볼 수 있다.
+func (s AuditService) RecordBatchAuditLog(ctx context.Context, action, targetType string, targetIds []uint, detail string) error {
+	entity := domain.NewAuditLogEntity(ctx, action, targetType, 0, detail)
+	if err := s.tagElevation(ctx, &entity); err != nil {
+		return err
+	}
+	if err := s.auditLogRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		adapters.EventBusAdapter().Publish(entity.ToEvent())
+	})
+
+	for _, targetId := range targetIds {
+		activityFeed := domain.NewActivityFeedEntityFromAuditLog(entity)
+		activityFeed.EntityId = targetId
+		if err := s.activityFeedRepository.Create(ctx, &activityFeed); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagElevation 은 멤버가 임시 권한 상승 중에 남긴 감사 로그에 상승 ID 를 기록하여 나중에 상승 중 활동을 검토할 수 있게 한다.
+func (s AuditService) tagElevation(ctx context.Context, entity *domain.AuditLogEntity) error {
+	if s.elevationFinder == nil || entity.ActorType != constants.AuditActorTypeMember {
+		return nil
+	}
+
+	elevationId, err := s.elevationFinder.FindActiveElevationId(ctx, entity.ActorId)
+	if err != nil {
+		return err
+	}
+
+	entity.ElevationId = elevationId
+	return nil
+}
+
+// GetElevationAuditLogs 는 임시 권한 상승 중에 기록한 감사 로그이다.
+func (s AuditService) GetElevationAuditLogs(ctx context.Context, elevationId uint) ([]domain.AuditLogEntity, error) {
+	return s.auditLogRepository.FindByElevationId(ctx, elevationId)
+}
+
+// RecordLogin 은 로그인을 활동 피드에 추가한다. 로그인은 감사 대상이 아니므로 감사 로그는 남기지 않는다.
+func (s AuditService) RecordLogin(ctx context.Context, memberId uint, sessionId uint) error {
+	activityFeed := domain.NewLoginActivityFeedEntity(memberId, sessionId)
+	return s.activityFeedRepository.Create(ctx, &activityFeed)
+}
+
+func (s AuditService) GetMemberActivities(ctx context.Context, memberId uint, eventTypes []string, pageable dtos.Pageable) ([]domain.ActivityFeedEntity, int64, error) {
+	return s.activityFeedRepository.FindByMemberId(ctx, memberId, eventTypes, pageable)
+}
+
+func (s AuditService) GetEntityActivities(ctx context.Context, entityType string, entityId uint, eventTypes []string, pageable dtos.Pageable) ([]domain.ActivityFeedEntity, int64, error) {
+	return s.activityFeedRepository.FindByEntity(ctx, entityType, entityId, eventTypes, pageable)
+}
@@ -2,69 +2,275 @@ package services
 
 import (
 	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
 	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
 	memberDomain "better-admin-backend-service/member/domain"
 	"better-admin-backend-service/security"
 	"context"
+	"fmt"
 	"github.com/mitchellh/mapstructure"
 	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"net/url"
+	"strings"
+	"time"
 )
 
 type AuthService struct {
 	memberService       *MemberService
 	organizationService *OrganizationService
 	siteService         *SiteService
+	sessionService      *SessionService
+	// 로그인 시도를 사용 통계로 기록한다.
+	usageStatisticsService *UsageStatisticsService
+	auditService           *AuditService
+	breakGlassService      *BreakGlassService
+	// SSO 로 처음 로그인한 멤버를 가입시키면서 할당 규칙에 맞는 역할과 조직을 할당한다.
+	memberAssignmentRuleService *MemberAssignmentRuleService
+	// 구글 워크스페이스 로그인을 허용할 도메인과 도메인별 기본 역할
+	googleWorkspaceService *GoogleWorkspaceService
+	// 로그인 실패와 이상 징후(다른 클라이언트의 Refresh 토큰 사용)를 보안 이벤트로 기록한다.
+	securityEventService *SecurityEventService
+	// 처음 보는 기기나 위치에서 로그인하면 멤버에게 알린다.
+	loginNotificationService *LoginNotificationService
+	// 멤버에 연결한 외부 인증 계정으로 로그인한 멤버를 찾는다.
+	memberIdentityService *MemberIdentityService
+	// SSO 로 로그인할 때 멤버가 속한 그룹을 그룹-역할 매핑에 맞춰 역할로 동기화한다.
+	groupRoleMappingService *GroupRoleMappingService
+	messageTemplateService  *MessageTemplateService
+	// 멤버가 속한 조직의 설정으로 로그인 방법을 제한하고 세션 유지 시간을 정한다.
+	organizationSettingService *OrganizationSettingService
 }
 
 func NewAuthService(
 	memberService *MemberService,
 	organizationService *OrganizationService,
-	siteService *SiteService) *AuthService {
+	siteService *SiteService,
+	sessionService *SessionService,
+	usageStatisticsService *UsageStatisticsService,
+	auditService *AuditService,
+	breakGlassService *BreakGlassService,
+	memberAssignmentRuleService *MemberAssignmentRuleService,
+	googleWorkspaceService *GoogleWorkspaceService,
+	securityEventService *SecurityEventService,
+	loginNotificationService *LoginNotificationService,
+	memberIdentityService *MemberIdentityService,
+	groupRoleMappingService *GroupRoleMappingService,
+	messageTemplateService *MessageTemplateService,
+	organizationSettingService *OrganizationSettingService) *AuthService {
 
 	return &AuthService{
-		memberService:       memberService,
-		organizationService: organizationService,
-		siteService:         siteService,
+		memberService:               memberService,
+		organizationService:         organizationService,
+		siteService:                 siteService,
+		sessionService:              sessionService,
+		usageStatisticsService:      usageStatisticsService,
+		auditService:                auditService,
+		breakGlassService:           breakGlassService,
+		memberAssignmentRuleService: memberAssignmentRuleService,
+		googleWorkspaceService:      googleWorkspaceService,
+		securityEventService:        securityEventService,
+		loginNotificationService:    loginNotificationService,
+		memberIdentityService:       memberIdentityService,
+		groupRoleMappingService:     groupRoleMappingService,
+		messageTemplateService:      messageTemplateService,
+		organizationSettingService:  organizationSettingService,
 	}
 }
 
 func (s AuthService) AuthWithSignIdPassword(ctx context.Context, signIn dtos.MemberSignIn) (security.JwtToken, error) {
+	memberEntity, token, err := s.authWithSignIdPassword(ctx, signIn)
+	s.recordLoginAttempt(ctx, constants.TypeMemberSite, memberEntity.ID, err)
+	return token, err
+}
+
+// recordLoginAttempt 는 로그인 시도를 사용 통계로 기록하고, 실패한 로그인은 보안 이벤트로도 기록한다.
+// 기록에 실패해도 로그인 결과는 바꾸지 않는다.
+func (s AuthService) recordLoginAttempt(ctx context.Context, method string, memberId uint, loginErr error) {
+	s.usageStatisticsService.RecordLoginAttempt(ctx, method, memberId, loginErr)
+	if loginErr == nil {
+		return
+	}
+
+	if err := s.securityEventService.RecordSecurityEvent(ctx, constants.SecurityEventTypeLoginFailed, memberId,
+		fmt.Sprintf("method=%v, error=%v", method, loginErr)); err != nil {
+		log.Errorf("record login failed security event error. %v", err)
+	}
+}
+
+func (s AuthService) authWithSignIdPassword(ctx context.Context, signIn dtos.MemberSignIn) (memberDomain.MemberEntity, security.JwtToken, error) {
 	memberEntity, err := s.memberService.GetMemberBySignId(ctx, signIn.Id)
 	if err != nil {
-		return security.JwtToken{}, err
+		return memberEntity, security.JwtToken{}, err
 	}
 
 	err = memberEntity.ValidatePassword(signIn.Password)
 	if err != nil {
-		return security.JwtToken{}, errors.ErrAuthentication
+		return memberEntity, security.JwtToken{}, errors.ErrAuthentication
+	}
+
+	// 다시 저장하지 못해도 이전 해시로 로그인할 수 있으므로 로그인은 계속한다.
+	if err := s.memberService.RehashPassword(ctx, &memberEntity, signIn.Password); err != nil {
+		log.Warnf("rehash password of member %v error: %v", memberEntity.ID, err)
+	}
+
+	// 본인이 하지 않은 로그인으로 신고한 멤버는 메일로 받은 링크로 비밀번호를 다시 설정해야 한다.
+	if memberEntity.PasswordResetRequired {
+		return memberEntity, security.JwtToken{}, errors.ErrPasswordResetNeeded
 	}
 
 	approved := memberEntity.IsApproved()
 	if approved == false {
-		return security.JwtToken{}, errors.ErrUnApproved
+		return memberEntity, security.JwtToken{}, errors.ErrUnApproved
 	}
 
-	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity)
+	token, err := s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.LoginMethodPassword)
+	return memberEntity, token, err
 }
 
-func (s AuthService) generateJwtTokenAndLogMemberAccess(ctx context.Context, memberEntity memberDomain.MemberEntity) (token security.JwtToken, err error) {
+// AuthWithBreakGlassAccount 는 IdP(SSO) 장애 시 비상 접근 계정으로 연결된 멤버로 로그인한다.
+// 외부 확인(PreAuthHook)과 세션 수 제한을 적용하지 않는 대신 세션은 BreakGlass.SessionLifetimeMinutes 가 지나면 만료되고, 사용 기록과 알림을 남긴다.
+func (s AuthService) AuthWithBreakGlassAccount(ctx context.Context, signIn dtos.BreakGlassSignIn) (security.JwtToken, error) {
+	memberEntity, token, err := s.authWithBreakGlassAccount(ctx, signIn)
+	s.recordLoginAttempt(ctx, constants.TypeMemberBreakGlass, memberEntity.ID, err)
+	return token, err
+}
+
+func (s AuthService) authWithBreakGlassAccount(ctx context.Context, signIn dtos.BreakGlassSignIn) (memberDomain.MemberEntity, security.JwtToken, error) {
+	account, memberEntity, err := s.breakGlassService.Authenticate(ctx, signIn)
+	if err != nil {
+		return memberEntity, security.JwtToken{}, err
+	}
+
 	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
 	if err != nil {
-		return
+		return memberEntity, security.JwtToken{}, err
 	}
 
-	token, err = security.JwtAuthentication{}.GenerateJwtToken(security.UserClaim{
+	session, err := s.sessionService.StartLimitedSession(ctx, memberEntity.ID, s.breakGlassService.GetSessionLifetime())
+	if err != nil {
+		return memberEntity, security.JwtToken{}, err
+	}
+
+	if err := s.loginNotificationService.CheckLoginDevice(ctx, memberEntity, session); err != nil {
+		return memberEntity, security.JwtToken{}, err
+	}
+
+	token, err := security.JwtAuthentication{}.GenerateJwtToken(ctx, security.UserClaim{
 		Id:          memberEntity.ID,
 		Roles:       memberAssignedAllRoleAndPermission.Roles,
 		Permissions: memberAssignedAllRoleAndPermission.Permissions,
+		SessionId:   session.SessionKey,
 	})
+	if err != nil {
+		return memberEntity, security.JwtToken{}, err
+	}
+	// 세션이 만료되면 Refresh 토큰도 사용할 수 없으므로 쿠키도 세션과 함께 만료시킨다.
+	token.RefreshTokenExpires = session.ExpiresAt
+
+	if err := s.breakGlassService.RecordUsage(ctx, account, session, signIn.Reason); err != nil {
+		return memberEntity, security.JwtToken{}, err
+	}
+
+	return memberEntity, token, s.logMemberAccessAt(ctx, memberEntity.ID)
+}
+
+func (s AuthService) generateJwtTokenAndLogMemberAccess(ctx context.Context, memberEntity memberDomain.MemberEntity, loginMethod string) (token security.JwtToken, err error) {
+	token, err = s.generateJwtToken(ctx, memberEntity, loginMethod)
+	if err != nil {
+		return
+	}
 
 	err = s.logMemberAccessAt(ctx, memberEntity.ID)
 	return
 }
 
+// generateJwtToken 은 멤버가 속한 조직에서 허용하는 로그인 방법(loginMethod)이면 조직의 세션 유지 시간으로 세션을 시작하고 토큰을 발급한다.
+func (s AuthService) generateJwtToken(ctx context.Context, memberEntity memberDomain.MemberEntity, loginMethod string) (security.JwtToken, error) {
+	if err := s.organizationSettingService.CheckLoginMethod(ctx, memberEntity.ID, loginMethod); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	sessionLifetime, err := s.organizationSettingService.GetSessionLifetime(ctx, memberEntity.ID)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	extraClaims, err := s.checkPreAuth(ctx, memberEntity, memberAssignedAllRoleAndPermission.Roles)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	session, err := s.sessionService.StartSession(ctx, memberEntity.ID, memberAssignedAllRoleAndPermission.Roles, sessionLifetime)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if err := s.loginNotificationService.CheckLoginDevice(ctx, memberEntity, session); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	token, err := security.JwtAuthentication{}.GenerateJwtToken(ctx, security.UserClaim{
+		Id:          memberEntity.ID,
+		Roles:       memberAssignedAllRoleAndPermission.Roles,
+		Permissions: memberAssignedAllRoleAndPermission.Permissions,
+		SessionId:   session.SessionKey,
+		Extra:       extraClaims,
+	})
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	// 세션이 Refresh 토큰보다 먼저 만료되면 쿠키도 세션과 함께 만료시킨다.
+	if session.ExpiresAt.Before(token.RefreshTokenExpires) {
+		token.RefreshTokenExpires = session.ExpiresAt
+	}
+
+	return token, nil
+}
+
+// checkPreAuth 는 외부 시스템(PreAuthHook)에 로그인 허용 여부를 묻고 토큰에 추가할 클레임을 받는다.
+// 외부 시스템이 응답하지 않으면 설정(PreAuthHook.FailureAction)에 따라 로그인을 거부하거나 클레임 없이 허용한다.
+func (s AuthService) checkPreAuth(ctx context.Context, memberEntity memberDomain.MemberEntity, roleNames []string) (map[string]interface{}, error) {
+	if !adapters.PreAuthAdapter().IsEnabled() {
+		return nil, nil
+	}
+
+	result, err := adapters.PreAuthAdapter().Check(ctx, dtos.PreAuthRequest{
+		MemberId: memberEntity.ID,
+		Type:     memberEntity.Type,
+		SignId:   memberEntity.SignId,
+		DoorayId: memberEntity.DoorayId,
+		GoogleId: memberEntity.GoogleId,
+		Name:     memberEntity.Name,
+		Email:    memberEntity.GetEmail(),
+		Roles:    roleNames,
+		ClientIp: helpers.ContextHelper().GetClientIp(ctx),
+	})
+	if err != nil {
+		if config.Config.PreAuthHook.FailureAction == constants.PreAuthFailureActionAllow {
+			log.Warn("pre auth hook error, login allowed: ", err)
+			return nil, nil
+		}
+		log.Error("pre auth hook error, login denied: ", err)
+		return nil, &errors.ErrPreAuthDenied{Reason: "pre auth hook is not available"}
+	}
+
+	if !result.Allow {
+		return nil, &errors.ErrPreAuthDenied{Reason: result.Reason}
+	}
+
+	return result.Claims, nil
+}
+
 func (s AuthService) logMemberAccessAt(ctx context.Context, memberId uint) error {
 	err := s.memberService.UpdateMemberLastAccessAt(ctx, memberId)
 	if err != nil {
@@ -75,102 +281,528 @@ func (s AuthService) logMemberAccessAt(ctx context.Context, memberId uint) error
 }
 
 func (s AuthService) AuthWithDoorayIdAndPassword(ctx context.Context, signIn dtos.MemberSignIn) (security.JwtToken, error) {
-	doorayLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyDoorayLogin)
+	memberEntity, token, err := s.authWithDoorayIdAndPassword(ctx, signIn)
+	s.recordLoginAttempt(ctx, constants.TypeMemberDooray, memberEntity.ID, err)
+	return token, err
+}
+
+func (s AuthService) authWithDoorayIdAndPassword(ctx context.Context, signIn dtos.MemberSignIn) (memberDomain.MemberEntity, security.JwtToken, error) {
+	doorayMember, err := s.authenticateDooray(ctx, signIn)
 	if err != nil {
-		return security.JwtToken{}, err
+		return memberDomain.MemberEntity{}, security.JwtToken{}, err
 	}
 
-	var settings dtos.DoorayLoginSetting
-	if err = mapstructure.Decode(doorayLoginSetting, &settings); err != nil {
-		return security.JwtToken{}, err
+	memberEntity, err := s.memberIdentityService.GetMemberByIdentity(ctx, constants.TypeMemberDooray, doorayMember.Id)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			newMemberEntity := memberDomain.NewMemberEntityFromDoorayMember(doorayMember)
+
+			if err = s.memberAssignmentRuleService.ProvisionMember(ctx, &newMemberEntity); err != nil {
+				return memberEntity, security.JwtToken{}, err
+			}
+
+			// 자동 승인 규칙에 맞지 않으면 승인 대기로 가입하므로 승인될 때까지 로그인할 수 없다.
+			if !newMemberEntity.IsApproved() {
+				return newMemberEntity, security.JwtToken{}, errors.ErrUnApproved
+			}
+
+			token, err := s.generateJwtToken(ctx, newMemberEntity, constants.LoginMethodDooray)
+			return newMemberEntity, token, err
+		}
+		return memberEntity, security.JwtToken{}, err
 	}
 
-	if *settings.Used == false {
-		err = pkgerrors.New("not supported dooray login")
-		return security.JwtToken{}, err
+	// 승인 대기 중이거나 해지한 멤버는 로그인할 수 없다.
+	if !memberEntity.IsApproved() {
+		return memberEntity, security.JwtToken{}, errors.ErrUnApproved
+	}
+
+	token, err := s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.LoginMethodDooray)
+	return memberEntity, token, err
+}
+
+// AuthWithCustomAuthenticator 는 name 으로 등록된 Authenticator 로 인증한다. 처음 인증한 사용자는 승인된 멤버로 가입시킨다.
+func (s AuthService) AuthWithCustomAuthenticator(ctx context.Context, name string, credentials map[string]string) (security.JwtToken, error) {
+	authenticator, ok := getAuthenticator(name)
+	if !ok {
+		return security.JwtToken{}, errors.ErrNotFound
 	}
 
-	doorayMember, err := adapters.DoorayAdapter{}.Authenticate(settings.Domain, settings.AuthorizationToken, signIn.Id, signIn.Password)
+	memberEntity, token, err := s.authWithCustomAuthenticator(ctx, name, authenticator, credentials)
+	s.recordLoginAttempt(ctx, constants.TypeMemberCustom, memberEntity.ID, err)
+	return token, err
+}
+
+func (s AuthService) authWithCustomAuthenticator(ctx context.Context, name string, authenticator Authenticator, credentials map[string]string) (memberDomain.MemberEntity, security.JwtToken, error) {
+	identity, err := authenticator.Authenticate(ctx, credentials)
 	if err != nil {
-		return security.JwtToken{}, err
+		return memberDomain.MemberEntity{}, security.JwtToken{}, err
+	}
+
+	if len(identity.ExternalId) == 0 {
+		return memberDomain.MemberEntity{}, security.JwtToken{}, pkgerrors.Errorf("%s authenticator returned empty external id", name)
 	}
 
-	memberEntity, err := s.memberService.GetMemberByDoorayId(ctx, doorayMember.Id)
+	loginMethod, err := s.organizationSettingService.GetAuthenticatorLoginMethod(ctx, name)
 	if err != nil {
-		if err == errors.ErrNotFound {
-			newMemberEntity := memberDomain.NewMemberEntityFromDoorayMember(doorayMember)
+		return memberDomain.MemberEntity{}, security.JwtToken{}, err
+	}
+
+	memberEntity, err := s.memberIdentityService.GetMemberByIdentity(ctx, memberDomain.CustomIdentityProvider(name), identity.ExternalId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			newMemberEntity := memberDomain.NewMemberEntityFromCustomAuthIdentity(name, identity)
+
+			if err = s.memberAssignmentRuleService.ProvisionMember(ctx, &newMemberEntity); err != nil {
+				return memberEntity, security.JwtToken{}, err
+			}
 
-			if err = s.memberService.CreateMember(ctx, &newMemberEntity); err != nil {
-				return security.JwtToken{}, err
+			if _, err = s.groupRoleMappingService.SyncMemberRoles(ctx, &newMemberEntity, memberDomain.CustomIdentityProvider(name), customAuthGroupsOf(identity)); err != nil {
+				return newMemberEntity, security.JwtToken{}, err
 			}
 
-			memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, newMemberEntity)
-			if err != nil {
-				return security.JwtToken{}, err
+			// 자동 승인 규칙에 맞지 않으면 승인 대기로 가입하므로 승인될 때까지 로그인할 수 없다.
+			if !newMemberEntity.IsApproved() {
+				return newMemberEntity, security.JwtToken{}, errors.ErrUnApproved
 			}
 
-			return security.JwtAuthentication{}.GenerateJwtToken(security.UserClaim{
-				Id:          newMemberEntity.ID,
-				Roles:       memberAssignedAllRoleAndPermission.Roles,
-				Permissions: memberAssignedAllRoleAndPermission.Permissions,
-			})
+			token, err := s.generateJwtToken(ctx, newMemberEntity, loginMethod)
+			return newMemberEntity, token, err
 		}
-		return security.JwtToken{}, err
+		return memberEntity, security.JwtToken{}, err
+	}
+
+	// 승인 대기 중이거나 해지한 멤버는 로그인할 수 없다.
+	if !memberEntity.IsApproved() {
+		return memberEntity, security.JwtToken{}, errors.ErrUnApproved
+	}
+
+	if _, err = s.groupRoleMappingService.SyncMemberRoles(ctx, &memberEntity, memberDomain.CustomIdentityProvider(name), customAuthGroupsOf(identity)); err != nil {
+		return memberEntity, security.JwtToken{}, err
 	}
 
-	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity)
+	token, err := s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, loginMethod)
+	return memberEntity, token, err
 }
 
-func (s AuthService) AuthWithGoogleWorkspaceAccount(ctx context.Context, code string) (security.JwtToken, error) {
+// customAuthGroupsOf 는 Authenticator 가 알려준 그룹이다. Authenticator 는 그룹을 빠짐없이 알려주므로 그룹이 없으면 속한 그룹이 없는 것으로 본다.
+func customAuthGroupsOf(identity dtos.CustomAuthIdentity) []string {
+	if identity.Groups == nil {
+		return make([]string, 0)
+	}
+
+	return identity.Groups
+}
+
+// StartGoogleWorkspaceAuth 는 로그인 후 돌아갈 주소(redirect)를 서명된 상태 토큰에 담아 구글 로그인 화면 주소를 만든다.
+func (s AuthService) StartGoogleWorkspaceAuth(ctx context.Context, redirect string) (string, error) {
+	if err := validateOAuthRedirect(redirect); err != nil {
+		return "", err
+	}
+
 	googleWorkspaceLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
 	if err != nil {
-		return security.JwtToken{}, err
+		return "", err
 	}
 
 	var settings dtos.GoogleWorkspaceLoginSetting
 	if err = mapstructure.Decode(googleWorkspaceLoginSetting, &settings); err != nil {
-		return security.JwtToken{}, err
+		return "", err
 	}
 
-	if *settings.Used == false {
-		err = pkgerrors.New("not supported google workspace login")
-		return security.JwtToken{}, err
+	if settings.Used == nil || !*settings.Used {
+		return "", errors.ErrNotFound
 	}
 
-	googleMember, err := adapters.GoogleOAuthAdapter{}.Authenticate(code, settings)
+	oauthUri, err := s.googleWorkspaceService.GetOAuthUri(ctx, settings)
+	if err != nil {
+		return "", err
+	}
 
+	lifetime := time.Duration(config.Config.OAuthState.LifetimeSeconds) * time.Second
+	state, err := security.JwtAuthentication{}.GenerateOAuthStateToken(constants.LoginMethodGoogleWorkspace, redirect, lifetime)
 	if err != nil {
-		return security.JwtToken{}, err
+		return "", err
+	}
+
+	return oauthUri + "&state=" + url.QueryEscape(state), nil
+}
+
+// VerifyGoogleWorkspaceState 는 콜백의 state 가 StartGoogleWorkspaceAuth 에서 발급한 토큰인지 확인하고 돌아갈 주소를 반환한다.
+// 발급한 뒤 허용 주소 설정이 바뀌었을 수 있으므로 주소도 다시 확인한다.
+func (s AuthService) VerifyGoogleWorkspaceState(state string) (string, error) {
+	redirect, err := security.JwtAuthentication{}.ConvertOAuthStateToken(state, constants.LoginMethodGoogleWorkspace)
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateOAuthRedirect(redirect); err != nil {
+		return "", err
+	}
+
+	return redirect, nil
+}
+
+// validateOAuthRedirect 는 로그인 후 돌아갈 주소가 상대 경로이거나 허용된 origin(OAuthState.AllowedRedirectOrigins)인지 확인한다.
+func validateOAuthRedirect(redirect string) error {
+	if strings.HasPrefix(redirect, "/") && !strings.HasPrefix(redirect, "//") && !strings.HasPrefix(redirect, "/\\") {
+		return nil
+	}
+
+	redirectUrl, err := url.Parse(redirect)
+	if err != nil || (redirectUrl.Scheme != "http" && redirectUrl.Scheme != "https") || len(redirectUrl.Host) == 0 || redirectUrl.User != nil {
+		return errors.ErrInvalidTarget
 	}
 
-	if googleMember.Hd != settings.Domain {
-		return security.JwtToken{}, &errors.ErrInvalidGoogleWorkspaceAccount{
-			Domain: settings.Domain,
+	origin := strings.ToLower(redirectUrl.Scheme + "://" + redirectUrl.Host)
+	for _, allowedOrigin := range config.Config.OAuthState.AllowedRedirectOrigins {
+		if origin == strings.ToLower(strings.TrimSuffix(allowedOrigin, "/")) {
+			return nil
 		}
 	}
 
-	memberEntity, err := s.memberService.GetMemberByGoogleId(ctx, googleMember.Id)
+	return errors.ErrInvalidTarget
+}
+
+func (s AuthService) AuthWithGoogleWorkspaceAccount(ctx context.Context, code string) (security.JwtToken, error) {
+	memberEntity, token, err := s.authWithGoogleWorkspaceAccount(ctx, code)
+	s.recordLoginAttempt(ctx, constants.TypeMemberGoogle, memberEntity.ID, err)
+	return token, err
+}
+
+func (s AuthService) authWithGoogleWorkspaceAccount(ctx context.Context, code string) (memberDomain.MemberEntity, security.JwtToken, error) {
+	googleMember, allowedDomain, err := s.authenticateGoogleWorkspace(ctx, code)
+	if err != nil {
+		return memberDomain.MemberEntity{}, security.JwtToken{}, err
+	}
+
+	memberEntity, err := s.memberIdentityService.GetMemberByIdentity(ctx, constants.TypeMemberGoogle, googleMember.Id)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			newMemberEntity := memberDomain.NewMemberEntityFromGoogleMember(googleMember)
+			if newMemberEntity.Roles, err = s.googleWorkspaceService.GetDefaultRoles(ctx, allowedDomain); err != nil {
+				return memberEntity, security.JwtToken{}, err
+			}
 
-			if err = s.memberService.CreateMember(ctx, &newMemberEntity); err != nil {
-				return security.JwtToken{}, err
+			if err = s.memberAssignmentRuleService.ProvisionMember(ctx, &newMemberEntity); err != nil {
+				return memberEntity, security.JwtToken{}, err
 			}
 
-			memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, newMemberEntity)
-			if err != nil {
-				return security.JwtToken{}, err
+			if _, err = s.groupRoleMappingService.SyncMemberRoles(ctx, &newMemberEntity, constants.TypeMemberGoogle, googleMember.Groups); err != nil {
+				return newMemberEntity, security.JwtToken{}, err
 			}
 
-			return security.JwtAuthentication{}.GenerateJwtToken(security.UserClaim{
-				Id:          newMemberEntity.ID,
-				Roles:       memberAssignedAllRoleAndPermission.Roles,
-				Permissions: memberAssignedAllRoleAndPermission.Permissions,
-			})
+			// 자동 승인 규칙에 맞지 않으면 승인 대기로 가입하므로 승인될 때까지 로그인할 수 없다.
+			if !newMemberEntity.IsApproved() {
+				return newMemberEntity, security.JwtToken{}, errors.ErrUnApproved
+			}
+
+			token, err := s.generateJwtToken(ctx, newMemberEntity, constants.LoginMethodGoogleWorkspace)
+			return newMemberEntity, token, err
 		}
-		return security.JwtToken{}, err
+		return memberEntity, security.JwtToken{}, err
+	}
+
+	// 승인 대기 중이거나 해지한 멤버는 로그인할 수 없다.
+	if !memberEntity.IsApproved() {
+		return memberEntity, security.JwtToken{}, errors.ErrUnApproved
+	}
+
+	if _, err = s.groupRoleMappingService.SyncMemberRoles(ctx, &memberEntity, constants.TypeMemberGoogle, googleMember.Groups); err != nil {
+		return memberEntity, security.JwtToken{}, err
+	}
+
+	token, err := s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.LoginMethodGoogleWorkspace)
+	return memberEntity, token, err
+}
+
+func (s AuthService) authenticateDooray(ctx context.Context, signIn dtos.MemberSignIn) (dtos.DoorayMember, error) {
+	doorayLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyDoorayLogin)
+	if err != nil {
+		return dtos.DoorayMember{}, err
+	}
+
+	var settings dtos.DoorayLoginSetting
+	if err = mapstructure.Decode(doorayLoginSetting, &settings); err != nil {
+		return dtos.DoorayMember{}, err
+	}
+
+	if *settings.Used == false {
+		return dtos.DoorayMember{}, pkgerrors.New("not supported dooray login")
+	}
+
+	return adapters.DoorayAdapter{}.Authenticate(ctx, settings.Domain, settings.AuthorizationToken, signIn.Id, signIn.Password)
+}
+
+// authenticateGoogleWorkspace 는 인가 코드(code)로 구글 계정을 인증하고 계정이 속한 허용 도메인을 찾는다.
+func (s AuthService) authenticateGoogleWorkspace(ctx context.Context, code string) (dtos.GoogleMember, dtos.GoogleWorkspaceDomain, error) {
+	googleWorkspaceLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
+	if err != nil {
+		return dtos.GoogleMember{}, dtos.GoogleWorkspaceDomain{}, err
+	}
+
+	var settings dtos.GoogleWorkspaceLoginSetting
+	if err = mapstructure.Decode(googleWorkspaceLoginSetting, &settings); err != nil {
+		return dtos.GoogleMember{}, dtos.GoogleWorkspaceDomain{}, err
+	}
+
+	if *settings.Used == false {
+		return dtos.GoogleMember{}, dtos.GoogleWorkspaceDomain{}, pkgerrors.New("not supported google workspace login")
+	}
+
+	workspaceSetting, err := s.googleWorkspaceService.GetGoogleWorkspaceSetting(ctx)
+	if err != nil {
+		return dtos.GoogleMember{}, dtos.GoogleWorkspaceDomain{}, err
+	}
+
+	googleMember, err := adapters.GoogleOAuthAdapter{}.Authenticate(ctx, code, settings, workspaceSetting.DirectoryAccess)
+	if err != nil {
+		return dtos.GoogleMember{}, dtos.GoogleWorkspaceDomain{}, err
+	}
+
+	allowedDomain, ok := workspaceSetting.FindDomain(googleMember)
+	if !ok {
+		return dtos.GoogleMember{}, dtos.GoogleWorkspaceDomain{}, &errors.ErrInvalidGoogleWorkspaceAccount{
+			Domains: workspaceSetting.GetDomainNames(),
+		}
+	}
+
+	return googleMember, allowedDomain, nil
+}
+
+// LinkDoorayIdentity 는 두레이 아이디와 비밀번호로 두레이 계정을 확인하고 로그인한 멤버에 연결한다. 서비스 계정은 연결할 수 없다.
+func (s AuthService) LinkDoorayIdentity(ctx context.Context, signIn dtos.MemberSignIn) (memberDomain.MemberIdentityEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return memberDomain.MemberIdentityEntity{}, err
+	}
+
+	doorayMember, err := s.authenticateDooray(ctx, signIn)
+	if err != nil {
+		return memberDomain.MemberIdentityEntity{}, err
+	}
+
+	return s.memberIdentityService.LinkIdentity(ctx, userClaim.Id, constants.TypeMemberDooray, doorayMember.Id, doorayMember.ExternalEmailAddress)
+}
+
+// LinkCustomIdentity 는 Authenticator(name)로 계정을 확인하고 로그인한 멤버에 연결한다.
+func (s AuthService) LinkCustomIdentity(ctx context.Context, name string, credentials map[string]string) (memberDomain.MemberIdentityEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return memberDomain.MemberIdentityEntity{}, err
+	}
+
+	authenticator, ok := getAuthenticator(name)
+	if !ok {
+		return memberDomain.MemberIdentityEntity{}, errors.ErrNotFound
+	}
+
+	identity, err := authenticator.Authenticate(ctx, credentials)
+	if err != nil {
+		return memberDomain.MemberIdentityEntity{}, err
+	}
+
+	return s.memberIdentityService.LinkIdentity(ctx, userClaim.Id, memberDomain.CustomIdentityProvider(name), identity.ExternalId, identity.Email)
+}
+
+// StartGoogleWorkspaceLink 는 로그인한 멤버에 구글 계정을 연결할 구글 로그인 화면 주소를 만든다.
+// 구글 로그인 후 콜백(/auth/google-workspace)에서 상태 토큰의 멤버에 계정을 연결하고 redirect 로 돌아간다.
+func (s AuthService) StartGoogleWorkspaceLink(ctx context.Context, redirect string) (string, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := validateOAuthRedirect(redirect); err != nil {
+		return "", err
+	}
+
+	googleWorkspaceLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
+	if err != nil {
+		return "", err
+	}
+
+	var settings dtos.GoogleWorkspaceLoginSetting
+	if err = mapstructure.Decode(googleWorkspaceLoginSetting, &settings); err != nil {
+		return "", err
+	}
+
+	if settings.Used == nil || !*settings.Used {
+		return "", errors.ErrNotFound
+	}
+
+	oauthUri, err := s.googleWorkspaceService.GetOAuthUri(ctx, settings)
+	if err != nil {
+		return "", err
+	}
+
+	lifetime := time.Duration(config.Config.OAuthState.LifetimeSeconds) * time.Second
+	state, err := security.JwtAuthentication{}.GenerateOAuthLinkStateToken(constants.LoginMethodGoogleWorkspace, userClaim.Id, redirect, lifetime)
+	if err != nil {
+		return "", err
+	}
+
+	return oauthUri + "&state=" + url.QueryEscape(state), nil
+}
+
+// VerifyGoogleWorkspaceLinkState 는 콜백의 state 가 StartGoogleWorkspaceLink 에서 발급한 토큰인지 확인하고 연결할 멤버와 돌아갈 주소를 반환한다.
+func (s AuthService) VerifyGoogleWorkspaceLinkState(state string) (uint, string, error) {
+	memberId, redirect, err := security.JwtAuthentication{}.ConvertOAuthLinkStateToken(state, constants.LoginMethodGoogleWorkspace)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if err := validateOAuthRedirect(redirect); err != nil {
+		return 0, "", err
+	}
+
+	return memberId, redirect, nil
+}
+
+// LinkGoogleWorkspaceIdentity 는 인가 코드(code)로 구글 계정을 확인하고 멤버(memberId)에 연결한다.
+func (s AuthService) LinkGoogleWorkspaceIdentity(ctx context.Context, memberId uint, code string) (memberDomain.MemberIdentityEntity, error) {
+	googleMember, _, err := s.authenticateGoogleWorkspace(ctx, code)
+	if err != nil {
+		return memberDomain.MemberIdentityEntity{}, err
+	}
+
+	return s.memberIdentityService.LinkIdentity(ctx, memberId, constants.TypeMemberGoogle, googleMember.Id, googleMember.Email)
+}
+
+func (s AuthService) RefreshAccessToken(ctx context.Context, refreshToken string) (string, error) {
+	jwtAuthentication := security.JwtAuthentication{}
+	userClaim, err := jwtAuthentication.ConvertTokenUserClaim(refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	// 세션 정보가 없는 토큰(예. 웹훅 토큰)은 세션 검증을 하지 않는다.
+	if len(userClaim.SessionId) > 0 {
+		if err := s.validateRefreshSession(ctx, userClaim.SessionId); err != nil {
+			return "", err
+		}
+	}
+
+	accessToken, err := jwtAuthentication.RefreshAccessToken(ctx, refreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.logMemberAccessAt(ctx, userClaim.Id); err != nil {
+		return "", err
+	}
+
+	return accessToken, nil
+}
+
+// validateRefreshSession 은 세션이 유효한지, Refresh 토큰을 사용한 클라이언트가 세션을 시작한 클라이언트와 같은지 확인한다.
+// 클라이언트가 다르면 감사 로그를 남기고 멤버에게 알리며, 설정(refresh-token-binding)이 enforce 이면 ErrClientMismatched 를 반환한다.
+func (s AuthService) validateRefreshSession(ctx context.Context, sessionKey string) error {
+	session, err := s.sessionService.GetActiveSession(ctx, sessionKey)
+	if err != nil {
+		return err
+	}
+
+	if session.IsSameClient(helpers.ContextHelper().GetClientFingerprint(ctx)) {
+		return nil
+	}
+
+	setting, err := s.sessionService.GetRefreshTokenBindingSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	detail := fmt.Sprintf("memberId=%v, clientIp=%v, action=%v", session.MemberId, helpers.ContextHelper().GetClientIp(ctx), setting.Action)
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionSessionClientMismatched, constants.AuditTargetTypeSession, session.ID, detail); err != nil {
+		return err
+	}
+
+	if err := s.securityEventService.RecordSecurityEvent(ctx, constants.SecurityEventTypeAnomaly, session.MemberId,
+		fmt.Sprintf("refresh token used by another client. sessionId=%v, action=%v", session.ID, setting.Action)); err != nil {
+		return err
+	}
+
+	s.notifyClientMismatched(ctx, session.MemberId, setting.Action)
+
+	if setting.Action == constants.RefreshTokenBindingActionEnforce {
+		return errors.ErrClientMismatched
+	}
+
+	return nil
+}
+
+func (s AuthService) notifyClientMismatched(ctx context.Context, memberId uint, action string) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		log.Error("session client mismatched notification error: ", err)
+		return
+	}
+
+	email := memberEntity.GetEmail()
+	if len(email) == 0 {
+		return
+	}
+
+	result := "로그인을 유지했습니다."
+	if action == constants.RefreshTokenBindingActionEnforce {
+		result = "로그인 유지를 거부했습니다."
+	}
+
+	message := s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateSessionClientMismatched, []string{email}, map[string]string{
+		"result":   result,
+		"clientIp": helpers.ContextHelper().GetClientIp(ctx),
+	})
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		if err := adapters.MailAdapter().Send(message); err != nil {
+			log.Error("session client mismatched notification error: ", err)
+		}
+	})
+}
+
+func (s AuthService) Logout(ctx context.Context, refreshToken string) error {
+	userClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(refreshToken)
+	if err != nil || len(userClaim.SessionId) == 0 {
+		// 이미 만료되었거나 세션 정보가 없는 토큰은 쿠키만 삭제한다.
+		return nil
+	}
+
+	err = s.sessionService.RevokeSession(ctx, userClaim.SessionId, constants.SessionRevokedReasonLogout)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// StepUp 은 로그인한 멤버의 비밀번호를 다시 확인하고 단계 인증 토큰을 발급한다. 비밀번호가 틀리면 로그인 실패로 기록한다.
+func (s AuthService) StepUp(ctx context.Context, request dtos.StepUpRequest) (dtos.StepUpToken, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return dtos.StepUpToken{}, err
+	}
+
+	if userClaim.IsServiceAccount() {
+		return dtos.StepUpToken{}, errors.ErrAuthentication
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, userClaim.Id)
+	if err != nil {
+		return dtos.StepUpToken{}, err
+	}
+
+	if err := memberEntity.ValidatePassword(request.Password); err != nil {
+		if err := s.securityEventService.RecordSecurityEvent(ctx, constants.SecurityEventTypeLoginFailed, memberEntity.ID,
+			fmt.Sprintf("method=step-up, error=%v", errors.ErrAuthentication)); err != nil {
+			log.Errorf("record step-up failed security event error. %v", err)
+		}
+		return dtos.StepUpToken{}, errors.ErrAuthentication
+	}
+
+	token, expiresAt, err := security.JwtAuthentication{}.GenerateStepUpToken(memberEntity.ID,
+		time.Duration(config.Config.StepUp.LifetimeSeconds)*time.Second)
+	if err != nil {
+		return dtos.StepUpToken{}, err
 	}
 
-	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity)
+	return dtos.StepUpToken{Token: token, ExpiresAt: expiresAt}, nil
 }
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"context"
+	"sync"
+	"time"
+)
+
+// Authenticator 는 배포 환경에 따라 필요한 인증 수단(예. 사내 인증 서버)으로 멤버를 인증한다.
+// RegisterAuthenticator 로 등록한 이름으로 POST /api/auth/custom/:name 에서 로그인할 수 있으며, 인증 정보가 맞지 않으면 errors.ErrAuthentication 을 반환한다.
+type Authenticator interface {
+	Authenticate(ctx context.Context, credentials map[string]string) (dtos.CustomAuthIdentity, error)
+}
+
+var (
+	authenticatorMutex sync.RWMutex
+	authenticators     = map[string]Authenticator{}
+)
+
+func RegisterAuthenticator(name string, authenticator Authenticator) {
+	authenticatorMutex.Lock()
+	defer authenticatorMutex.Unlock()
+
+	authenticators[name] = authenticator
+}
+
+// getAuthenticator 는 등록된 Authenticator 를 찾는다. 없으면 설정(CustomAuthenticators)의 사이드카를 사용한다.
+func getAuthenticator(name string) (Authenticator, bool) {
+	authenticatorMutex.RLock()
+	authenticator, ok := authenticators[name]
+	authenticatorMutex.RUnlock()
+
+	if ok {
+		return authenticator, true
+	}
+
+	for _, sidecarConfig := range config.Config.CustomAuthenticators {
+		if sidecarConfig.Name != name || len(sidecarConfig.SidecarAddress) == 0 {
+			continue
+		}
+
+		timeout := time.Duration(sidecarConfig.TimeoutSeconds) * time.Second
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+
+		return sidecarAuthenticator{
+			name:    name,
+			sidecar: adapters.AuthenticatorSidecar{Address: sidecarConfig.SidecarAddress, Timeout: timeout},
+		}, true
+	}
+
+	return nil, false
+}
+
+type sidecarAuthenticator struct {
+	name    string
+	sidecar adapters.AuthenticatorSidecar
+}
+
+func (a sidecarAuthenticator) Authenticate(ctx context.Context, credentials map[string]string) (dtos.CustomAuthIdentity, error) {
+	return a.sidecar.Authenticate(ctx, dtos.CustomAuthRequest{
+		Authenticator: a.name,
+		Credentials:   credentials,
+		ClientIp:      helpers.ContextHelper().GetClientIp(ctx),
+	})
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/breakglass/domain"
+	"better-admin-backend-service/breakglass/repository"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	sessionDomain "better-admin-backend-service/session/domain"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type BreakGlassService struct {
+	memberService               *MemberService
+	breakGlassAccountRepository *repository.BreakGlassAccountRepository
+	breakGlassUsageRepository   *repository.BreakGlassUsageRepository
+	auditService                *AuditService
+	securityEventService        *SecurityEventService
+	messageTemplateService      *MessageTemplateService
+}
+
+func NewBreakGlassService(
+	memberService *MemberService,
+	breakGlassAccountRepository *repository.BreakGlassAccountRepository,
+	breakGlassUsageRepository *repository.BreakGlassUsageRepository,
+	auditService *AuditService,
+	securityEventService *SecurityEventService,
+	messageTemplateService *MessageTemplateService) *BreakGlassService {
+
+	return &BreakGlassService{
+		memberService:               memberService,
+		breakGlassAccountRepository: breakGlassAccountRepository,
+		breakGlassUsageRepository:   breakGlassUsageRepository,
+		auditService:                auditService,
+		securityEventService:        securityEventService,
+		messageTemplateService:      messageTemplateService,
+	}
+}
+
+func (s BreakGlassService) CreateAccount(ctx context.Context, creation dtos.BreakGlassAccountCreation) error {
+	if _, err := s.breakGlassAccountRepository.FindBySignId(ctx, creation.SignId); err == nil {
+		return errors.ErrDuplicated
+	} else if !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+
+	if _, err := s.memberService.GetMemberById(ctx, creation.MemberId); err != nil {
+		return err
+	}
+
+	entity, err := domain.NewBreakGlassAccountEntity(ctx, creation)
+	if err != nil {
+		return err
+	}
+
+	if err := s.breakGlassAccountRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionBreakGlassAccountCreated, constants.AuditTargetTypeBreakGlassAccount, entity.ID,
+		fmt.Sprintf("signId=%v, memberId=%v", entity.SignId, entity.MemberId))
+}
+
+func (s BreakGlassService) GetAccounts(ctx context.Context, pageable dtos.Pageable) ([]domain.BreakGlassAccountEntity, int64, error) {
+	return s.breakGlassAccountRepository.FindAll(ctx, pageable)
+}
+
+func (s BreakGlassService) DeleteAccount(ctx context.Context, accountId uint) error {
+	entity, err := s.breakGlassAccountRepository.FindById(ctx, accountId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.breakGlassAccountRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionBreakGlassAccountDeleted, constants.AuditTargetTypeBreakGlassAccount, entity.ID,
+		fmt.Sprintf("signId=%v, memberId=%v", entity.SignId, entity.MemberId))
+}
+
+func (s BreakGlassService) GetUsages(ctx context.Context, pageable dtos.Pageable) ([]domain.BreakGlassUsageEntity, int64, error) {
+	return s.breakGlassUsageRepository.FindAll(ctx, pageable)
+}
+
+// Authenticate 는 비상 접근 계정을 인증하고 연결된 멤버를 찾는다. 계정이 없거나 멤버가 삭제된 경우도 인증 실패로 처리한다.
+func (s BreakGlassService) Authenticate(ctx context.Context, signIn dtos.BreakGlassSignIn) (domain.BreakGlassAccountEntity, memberDomain.MemberEntity, error) {
+	account, err := s.breakGlassAccountRepository.FindBySignId(ctx, signIn.Id)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return account, memberDomain.MemberEntity{}, errors.ErrAuthentication
+		}
+		return account, memberDomain.MemberEntity{}, err
+	}
+
+	if err := account.ValidatePassword(signIn.Password); err != nil {
+		return account, memberDomain.MemberEntity{}, err
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, account.MemberId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return account, memberEntity, errors.ErrAuthentication
+		}
+		return account, memberEntity, err
+	}
+
+	return account, memberEntity, nil
+}
+
+// GetSessionLifetime 은 비상 접근 계정으로 시작한 세션의 수명이다.
+func (BreakGlassService) GetSessionLifetime() time.Duration {
+	return time.Duration(config.Config.BreakGlass.SessionLifetimeMinutes) * time.Minute
+}
+
+// RecordUsage 는 비상 접근 계정의 사용을 기록하고 감사 로그를 남긴 뒤 보안 담당자(BreakGlass.NotifyRoleName)에게 알린다.
+func (s BreakGlassService) RecordUsage(ctx context.Context, account domain.BreakGlassAccountEntity, session sessionDomain.MemberSessionEntity, reason string) error {
+	account.MarkUsed()
+	if err := s.breakGlassAccountRepository.Save(ctx, &account); err != nil {
+		return err
+	}
+
+	usage := domain.NewBreakGlassUsageEntity(account, reason, helpers.ContextHelper().GetClientIp(ctx), session.ID, session.ExpiresAt)
+	if err := s.breakGlassUsageRepository.Create(ctx, &usage); err != nil {
+		return err
+	}
+
+	detail := fmt.Sprintf("signId=%v, memberId=%v, clientIp=%v, reason=%v", account.SignId, account.MemberId, usage.ClientIp, reason)
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionBreakGlassAccountUsed, constants.AuditTargetTypeBreakGlassAccount, account.ID, detail); err != nil {
+		return err
+	}
+
+	if err := s.securityEventService.RecordSecurityEvent(ctx, constants.SecurityEventTypeBreakGlassUsed, account.MemberId, detail); err != nil {
+		return err
+	}
+
+	log.Warnf("break glass account used. %s", detail)
+	s.notifyUsed(ctx, usage)
+
+	return nil
+}
+
+func (s BreakGlassService) notifyUsed(ctx context.Context, usage domain.BreakGlassUsageEntity) {
+	roleName := config.Config.BreakGlass.NotifyRoleName
+	if len(roleName) == 0 {
+		return
+	}
+
+	members, err := s.memberService.GetMembersByRoleName(ctx, roleName)
+	if err != nil {
+		log.Error("break glass notification error: ", err)
+		return
+	}
+
+	to := make([]string, 0)
+	for _, member := range members {
+		if email := member.GetEmail(); len(email) > 0 {
+			to = append(to, email)
+		}
+	}
+	if len(to) == 0 {
+		return
+	}
+
+	message := s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateBreakGlassUsed, to, map[string]string{
+		"signId":    usage.SignId,
+		"memberId":  fmt.Sprint(usage.MemberId),
+		"clientIp":  usage.ClientIp,
+		"reason":    usage.Reason,
+		"expiresAt": usage.ExpiresAt.Format(time.RFC3339),
+	})
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		if err := adapters.MailAdapter().Send(message); err != nil {
+			log.Error("break glass notification error: ", err)
+		}
+	})
+}
@@ -0,0 +1,524 @@
+package services
+
+import (
+	"better-admin-backend-service/changerequest/domain"
+	"better-admin-backend-service/changerequest/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/selfcheck"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// changeRequestSetting 은 변경 요청으로 바꿀 수 있는 사이트 설정이다.
+// newValue 는 값을 읽을 설정 DTO 를 만들고, apply 는 설정 화면(PUT /site/settings/...)과 같은 방법으로 저장한다.
+type changeRequestSetting struct {
+	newValue func() interface{}
+	apply    func(ctx context.Context, setting interface{}) error
+}
+
+type ChangeRequestService struct {
+	changeRequestRepository *repository.ChangeRequestRepository
+	siteService             *SiteService
+	rbacService             *RoleBasedAccessControlService
+	auditService            *AuditService
+	settings                map[string]changeRequestSetting
+}
+
+func NewChangeRequestService(
+	changeRequestRepository *repository.ChangeRequestRepository,
+	siteService *SiteService,
+	rbacService *RoleBasedAccessControlService,
+	maintenanceService *MaintenanceService,
+	dataMaskingService *DataMaskingService,
+	concurrencyLimitService *ConcurrencyLimitService,
+	loginSettingService *LoginSettingService,
+	auditService *AuditService) *ChangeRequestService {
+
+	setSetting := func(key string) func(ctx context.Context, setting interface{}) error {
+		return func(ctx context.Context, setting interface{}) error {
+			return siteService.SetSettingWithKey(ctx, key, reflect.ValueOf(setting).Elem().Interface())
+		}
+	}
+
+	return &ChangeRequestService{
+		changeRequestRepository: changeRequestRepository,
+		siteService:             siteService,
+		rbacService:             rbacService,
+		auditService:            auditService,
+		settings: map[string]changeRequestSetting{
+			constants.SettingKeySessionLimit: {
+				newValue: func() interface{} { return &dtos.SessionLimitSetting{} },
+				apply:    setSetting(constants.SettingKeySessionLimit),
+			},
+			constants.SettingKeyRefreshTokenBinding: {
+				newValue: func() interface{} { return &dtos.RefreshTokenBindingSetting{} },
+				apply:    setSetting(constants.SettingKeyRefreshTokenBinding),
+			},
+			constants.SettingKeyApprovalWorkflow: {
+				newValue: func() interface{} { return &dtos.ApprovalWorkflowSetting{} },
+				apply:    setSetting(constants.SettingKeyApprovalWorkflow),
+			},
+			constants.SettingKeyPendingSignUp: {
+				newValue: func() interface{} { return &dtos.PendingSignUpSetting{} },
+				apply:    setSetting(constants.SettingKeyPendingSignUp),
+			},
+			constants.SettingKeyMaintenance: {
+				newValue: func() interface{} { return &dtos.MaintenanceSetting{} },
+				apply: func(ctx context.Context, setting interface{}) error {
+					return maintenanceService.SetMaintenanceSetting(ctx, *setting.(*dtos.MaintenanceSetting))
+				},
+			},
+			constants.SettingKeyDataMasking: {
+				newValue: func() interface{} { return &dtos.DataMaskingSetting{} },
+				apply: func(ctx context.Context, setting interface{}) error {
+					return dataMaskingService.SetDataMaskingSetting(ctx, *setting.(*dtos.DataMaskingSetting))
+				},
+			},
+			constants.SettingKeyConcurrencyLimit: {
+				newValue: func() interface{} { return &dtos.ConcurrencyLimitSetting{} },
+				apply: func(ctx context.Context, setting interface{}) error {
+					return concurrencyLimitService.SetConcurrencyLimitSetting(ctx, *setting.(*dtos.ConcurrencyLimitSetting))
+				},
+			},
+			constants.SettingKeyLogin: {
+				newValue: func() interface{} { return &dtos.LoginSetting{} },
+				apply: func(ctx context.Context, setting interface{}) error {
+					return loginSettingService.SetLoginSetting(ctx, *setting.(*dtos.LoginSetting))
+				},
+			},
+		},
+	}
+}
+
+func (s ChangeRequestService) CreateChangeRequest(ctx context.Context, information dtos.ChangeRequestInformation) (domain.ChangeRequestEntity, error) {
+	if err := s.validateChanges(ctx, information.Changes); err != nil {
+		return domain.ChangeRequestEntity{}, err
+	}
+
+	entity, err := domain.NewChangeRequestEntity(ctx, information)
+	if err != nil {
+		return domain.ChangeRequestEntity{}, err
+	}
+
+	if err := s.changeRequestRepository.Create(ctx, &entity); err != nil {
+		return domain.ChangeRequestEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s ChangeRequestService) GetChangeRequests(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.ChangeRequestEntity, int64, error) {
+	return s.changeRequestRepository.FindAll(ctx, filters, pageable)
+}
+
+func (s ChangeRequestService) GetChangeRequest(ctx context.Context, changeRequestId uint) (domain.ChangeRequestEntity, error) {
+	return s.changeRequestRepository.FindById(ctx, changeRequestId)
+}
+
+func (s ChangeRequestService) UpdateChangeRequest(ctx context.Context, changeRequestId uint, information dtos.ChangeRequestInformation) error {
+	entity, err := s.changeRequestRepository.FindById(ctx, changeRequestId)
+	if err != nil {
+		return err
+	}
+
+	if entity.CreatedBy != s.currentUserId(ctx) {
+		return errors.ErrForbidden
+	}
+
+	if err := s.validateChanges(ctx, information.Changes); err != nil {
+		return err
+	}
+
+	if err := entity.Update(information); err != nil {
+		return err
+	}
+
+	return s.changeRequestRepository.Save(ctx, &entity)
+}
+
+func (s ChangeRequestService) SubmitChangeRequest(ctx context.Context, changeRequestId uint) error {
+	entity, err := s.changeRequestRepository.FindById(ctx, changeRequestId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Submit(ctx); err != nil {
+		return err
+	}
+
+	return s.changeRequestRepository.Save(ctx, &entity)
+}
+
+func (s ChangeRequestService) CancelChangeRequest(ctx context.Context, changeRequestId uint) error {
+	entity, err := s.changeRequestRepository.FindById(ctx, changeRequestId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Cancel(ctx); err != nil {
+		return err
+	}
+
+	return s.changeRequestRepository.Save(ctx, &entity)
+}
+
+// ReviewChangeRequest 는 작성하지 않은 다른 관리자가 승인하거나 반려한다. 역할을 바꾸는 요청은 역할을 관리할 수 있어야 검토할 수 있다.
+func (s ChangeRequestService) ReviewChangeRequest(ctx context.Context, changeRequestId uint, approved bool, comment string) error {
+	entity, err := s.changeRequestRepository.FindById(ctx, changeRequestId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkItemPermissions(ctx, entity.GetChanges()); err != nil {
+		return err
+	}
+
+	if err := entity.Review(ctx, approved, comment); err != nil {
+		return err
+	}
+
+	if err := s.changeRequestRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	action := constants.AuditActionChangeRequestRejected
+	if approved {
+		action = constants.AuditActionChangeRequestApproved
+	}
+	return s.auditService.RecordAuditLog(ctx, action, constants.AuditTargetTypeChangeRequest, entity.ID,
+		fmt.Sprintf("title=%v, comment=%v", entity.Title, comment))
+}
+
+// ApplyChangeRequest 는 승인된 요청을 바로 적용한다. 적용 시간을 예약한 요청은 그 시간이 지나야 적용할 수 있다.
+// 적용한 뒤 점검에 실패하여 되돌린 경우에도 오류가 아니며 결과는 요청의 상태(rolled-back)로 알 수 있다.
+func (s ChangeRequestService) ApplyChangeRequest(ctx context.Context, changeRequestId uint) (domain.ChangeRequestEntity, error) {
+	entity, err := s.changeRequestRepository.FindById(ctx, changeRequestId)
+	if err != nil {
+		return domain.ChangeRequestEntity{}, err
+	}
+
+	if !entity.CanApply(time.Now()) {
+		return domain.ChangeRequestEntity{}, errors.ErrNonChangeable
+	}
+
+	if err := s.apply(ctx, &entity, s.currentUserId(ctx)); err != nil {
+		return domain.ChangeRequestEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// ProcessScheduledChangeRequests 는 적용 시간이 된 승인된 요청을 승인한 관리자로 적용한다.
+func (s ChangeRequestService) ProcessScheduledChangeRequests(ctx context.Context) error {
+	entities, err := s.changeRequestRepository.FindScheduled(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range entities {
+		reviewerCtx := helpers.ContextHelper().SetUserClaim(ctx, &security.UserClaim{Id: entities[i].ReviewedBy})
+		if err := s.apply(reviewerCtx, &entities[i], entities[i].ReviewedBy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetChangeRequestDiff 는 변경 항목마다 현재 값과 바꿀 값을 비교한다.
+func (s ChangeRequestService) GetChangeRequestDiff(ctx context.Context, changeRequestId uint) ([]dtos.ChangeRequestItemDiff, error) {
+	entity, err := s.changeRequestRepository.FindById(ctx, changeRequestId)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]dtos.ChangeRequestItemDiff, 0)
+	for _, item := range entity.GetChanges() {
+		diff, err := s.diffItem(ctx, item)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+
+	return diffs, nil
+}
+
+// apply 는 모든 변경 항목을 한 트랜잭션(savepoint)으로 적용하고 점검(selfcheck)한다.
+// 적용하지 못하거나 점검에 실패하면 모든 변경을 되돌리고 요청에 이유와 점검 결과를 남긴다.
+func (s ChangeRequestService) apply(ctx context.Context, entity *domain.ChangeRequestEntity, appliedBy uint) error {
+	var healthCheck *dtos.SelfCheckReport
+	var failureReason string
+
+	err := helpers.ContextHelper().GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := helpers.ContextHelper().SetDB(ctx, tx)
+		for _, item := range entity.GetChanges() {
+			if err := s.applyItem(txCtx, item); err != nil {
+				failureReason = err.Error()
+				return err
+			}
+		}
+
+		report := selfcheck.Run(txCtx)
+		healthCheck = &report
+		if !report.Healthy {
+			failureReason = "health check failed after apply"
+			return pkgerrors.New(failureReason)
+		}
+
+		return nil
+	})
+	if err != nil && len(failureReason) == 0 {
+		return err
+	}
+
+	if err := entity.Applied(appliedBy, healthCheck, failureReason); err != nil {
+		return err
+	}
+
+	if err := s.changeRequestRepository.Save(ctx, entity); err != nil {
+		return err
+	}
+
+	action := constants.AuditActionChangeRequestApplied
+	if len(failureReason) > 0 {
+		action = constants.AuditActionChangeRequestRolledBack
+	}
+	return s.auditService.RecordAuditLog(ctx, action, constants.AuditTargetTypeChangeRequest, entity.ID,
+		fmt.Sprintf("title=%v, failureReason=%v", entity.Title, failureReason))
+}
+
+func (s ChangeRequestService) applyItem(ctx context.Context, item dtos.ChangeRequestItem) error {
+	if item.Type == constants.ChangeRequestItemTypeRole {
+		role, err := s.decodeRole(item)
+		if err != nil {
+			return err
+		}
+
+		return s.rbacService.UpdateRole(ctx, item.RoleId, role)
+	}
+
+	value, err := s.decodeSetting(item)
+	if err != nil {
+		return err
+	}
+
+	return s.settings[item.Key].apply(ctx, value)
+}
+
+// validateChanges 는 작성할 때 항목의 값을 미리 검증하여 적용할 때 실패하지 않게 한다.
+func (s ChangeRequestService) validateChanges(ctx context.Context, changes []dtos.ChangeRequestItem) error {
+	if err := s.checkItemPermissions(ctx, changes); err != nil {
+		return err
+	}
+
+	for _, item := range changes {
+		if item.Type == constants.ChangeRequestItemTypeRole {
+			if _, err := s.decodeRole(item); err != nil {
+				return err
+			}
+
+			role, err := s.rbacService.GetRole(ctx, item.RoleId)
+			if err != nil {
+				if errors.Is(err, errors.ErrNotFound) {
+					return &errors.ErrInvalidChangeRequest{Reason: fmt.Sprintf("role %d not found", item.RoleId)}
+				}
+				return err
+			}
+			if role.Type == constants.PreDefineTypeKey {
+				return &errors.ErrInvalidChangeRequest{Reason: fmt.Sprintf("role %d is pre-defined", item.RoleId)}
+			}
+			continue
+		}
+
+		if _, err := s.decodeSetting(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkItemPermissions 는 역할을 바꾸는 항목이 있으면 역할을 관리할 수 있는지 확인한다.
+func (s ChangeRequestService) checkItemPermissions(ctx context.Context, changes []dtos.ChangeRequestItem) error {
+	for _, item := range changes {
+		if item.Type != constants.ChangeRequestItemTypeRole {
+			continue
+		}
+
+		userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+		if err != nil {
+			return err
+		}
+		for _, permission := range userClaim.Permissions {
+			if permission == constants.PermissionManageAccessControl {
+				return nil
+			}
+		}
+		return errors.ErrForbidden
+	}
+
+	return nil
+}
+
+func (s ChangeRequestService) decodeSetting(item dtos.ChangeRequestItem) (interface{}, error) {
+	setting, ok := s.settings[item.Key]
+	if !ok {
+		return nil, &errors.ErrInvalidChangeRequest{Reason: fmt.Sprintf("%q setting is not supported", item.Key)}
+	}
+
+	value := setting.newValue()
+	if err := decodeChangeRequestValue(item.Value, value); err != nil {
+		return nil, &errors.ErrInvalidChangeRequest{Reason: item.Key + ": " + err.Error()}
+	}
+
+	return value, nil
+}
+
+func (s ChangeRequestService) decodeRole(item dtos.ChangeRequestItem) (dtos.RoleInformation, error) {
+	var role dtos.RoleInformation
+	if err := decodeChangeRequestValue(item.Value, &role); err != nil {
+		return dtos.RoleInformation{}, &errors.ErrInvalidChangeRequest{Reason: fmt.Sprintf("role %d: %v", item.RoleId, err)}
+	}
+
+	return role, nil
+}
+
+func decodeChangeRequestValue(content json.RawMessage, value interface{}) error {
+	if err := json.Unmarshal(content, value); err != nil {
+		return err
+	}
+
+	return binding.Validator.ValidateStruct(value)
+}
+
+func (s ChangeRequestService) diffItem(ctx context.Context, item dtos.ChangeRequestItem) (dtos.ChangeRequestItemDiff, error) {
+	diff := dtos.ChangeRequestItemDiff{Type: item.Type, Key: item.Key, RoleId: item.RoleId}
+
+	if item.Type == constants.ChangeRequestItemTypeRole {
+		proposed, err := s.decodeRole(item)
+		if err != nil {
+			return dtos.ChangeRequestItemDiff{}, err
+		}
+
+		role, err := s.rbacService.GetRole(ctx, item.RoleId)
+		if err != nil && !errors.Is(err, errors.ErrNotFound) {
+			return dtos.ChangeRequestItemDiff{}, err
+		}
+
+		var current *dtos.RoleInformation
+		currentPermissionIds := map[uint]bool{}
+		if err == nil {
+			current = &dtos.RoleInformation{Name: role.Name, Description: role.Description, AllowedPermissionIds: role.GetPermissionIds()}
+			for _, permission := range role.Permissions {
+				currentPermissionIds[permission.ID] = true
+				if !containsUint(proposed.AllowedPermissionIds, permission.ID) {
+					diff.RemovedPermissions = append(diff.RemovedPermissions, permission.Name)
+				}
+			}
+		}
+
+		permissions, _, err := s.rbacService.GetPermissions(ctx, map[string]interface{}{"permissionIds": proposed.AllowedPermissionIds}, dtos.Pageable{Page: 0})
+		if err != nil {
+			return dtos.ChangeRequestItemDiff{}, err
+		}
+		for _, permission := range permissions {
+			if !currentPermissionIds[permission.ID] {
+				diff.AddedPermissions = append(diff.AddedPermissions, permission.Name)
+			}
+		}
+
+		return fillChangeRequestItemDiff(diff, current, proposed)
+	}
+
+	proposed, err := s.decodeSetting(item)
+	if err != nil {
+		return dtos.ChangeRequestItemDiff{}, err
+	}
+
+	current, err := s.siteService.GetSettingWithKey(ctx, item.Key)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return dtos.ChangeRequestItemDiff{}, err
+	}
+
+	return fillChangeRequestItemDiff(diff, current, proposed)
+}
+
+// fillChangeRequestItemDiff 는 두 값을 JSON 객체로 바꾸어 바뀌는 최상위 필드를 찾는다.
+func fillChangeRequestItemDiff(diff dtos.ChangeRequestItemDiff, current, proposed interface{}) (dtos.ChangeRequestItemDiff, error) {
+	currentFields, err := toJsonObject(current)
+	if err != nil {
+		return dtos.ChangeRequestItemDiff{}, err
+	}
+	proposedFields, err := toJsonObject(proposed)
+	if err != nil {
+		return dtos.ChangeRequestItemDiff{}, err
+	}
+
+	diff.ChangedFields = make([]string, 0)
+	for name, value := range proposedFields {
+		if !reflect.DeepEqual(currentFields[name], value) {
+			diff.ChangedFields = append(diff.ChangedFields, name)
+		}
+	}
+	for name := range currentFields {
+		if _, ok := proposedFields[name]; !ok {
+			diff.ChangedFields = append(diff.ChangedFields, name)
+		}
+	}
+	sort.Strings(diff.ChangedFields)
+
+	if currentFields != nil {
+		diff.Current = currentFields
+	}
+	diff.Proposed = proposedFields
+	return diff, nil
+}
+
+func toJsonObject(value interface{}) (map[string]interface{}, error) {
+	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
+		return nil, nil
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "change request diff encode error")
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(content, &fields); err != nil {
+		return nil, pkgerrors.Wrap(err, "change request diff decode error")
+	}
+
+	return fields, nil
+}
+
+func containsUint(values []uint, value uint) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s ChangeRequestService) currentUserId(ctx context.Context) uint {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return 0
+	}
+
+	return userClaim.Id
+}
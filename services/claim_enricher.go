@@ -0,0 +1,96 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/organization/domain"
+	"better-admin-backend-service/security"
+	"context"
+	"strings"
+)
+
+// OrganizationPathClaimEnricher 는 멤버가 속한 조직의 전체 경로(예. 베터코드 연구소/부서B)를 organizations 클레임으로 추가한다.
+type OrganizationPathClaimEnricher struct {
+	organizationService *OrganizationService
+}
+
+func NewOrganizationPathClaimEnricher(organizationService *OrganizationService) *OrganizationPathClaimEnricher {
+	return &OrganizationPathClaimEnricher{
+		organizationService: organizationService,
+	}
+}
+
+func (e OrganizationPathClaimEnricher) Enrich(ctx context.Context, claim security.UserClaim) (map[string]interface{}, error) {
+	allOrganizations, err := e.organizationService.GetAllOrganizations(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationPaths := make([]string, 0)
+	for _, organization := range allOrganizations {
+		if organization.ExistMember(claim.Id) {
+			organizationPaths = append(organizationPaths, e.getOrganizationNamePath(organization, allOrganizations))
+		}
+	}
+
+	return map[string]interface{}{"organizations": organizationPaths}, nil
+}
+
+func (e OrganizationPathClaimEnricher) getOrganizationNamePath(organization domain.OrganizationEntity, allOrganizations []domain.OrganizationEntity) string {
+	names := []string{organization.Name}
+
+	parentId := organization.ParentOrganizationID
+	for parentId != nil {
+		var parent *domain.OrganizationEntity
+		for i := 0; i < len(allOrganizations); i++ {
+			if allOrganizations[i].ID == *parentId {
+				parent = &allOrganizations[i]
+				break
+			}
+		}
+
+		if parent == nil {
+			break
+		}
+
+		names = append([]string{parent.Name}, names...)
+		parentId = parent.ParentOrganizationID
+	}
+
+	return strings.Join(names, "/")
+}
+
+// MemberFieldsClaimEnricher 는 설정(JwtClaimEnrichment.MemberFields)에 지정된 멤버 정보를 클레임으로 추가한다.
+type MemberFieldsClaimEnricher struct {
+	memberService *MemberService
+}
+
+func NewMemberFieldsClaimEnricher(memberService *MemberService) *MemberFieldsClaimEnricher {
+	return &MemberFieldsClaimEnricher{
+		memberService: memberService,
+	}
+}
+
+func (e MemberFieldsClaimEnricher) Enrich(ctx context.Context, claim security.UserClaim) (map[string]interface{}, error) {
+	memberEntity, err := e.memberService.GetMemberById(ctx, claim.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	memberFields := map[string]interface{}{}
+	for _, field := range config.Config.JwtClaimEnrichment.MemberFields {
+		switch field {
+		case "name":
+			memberFields[field] = memberEntity.Name
+		case "type":
+			memberFields[field] = memberEntity.Type
+		case "signId":
+			memberFields[field] = memberEntity.SignId
+		case "candidateId":
+			memberFields[field] = memberEntity.GetCandidateId()
+		case "picture":
+			memberFields[field] = memberEntity.Picture
+		}
+	}
+
+	return memberFields, nil
+}
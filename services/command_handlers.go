@@ -0,0 +1,95 @@
+package services
+
+import (
+	"better-admin-backend-service/dtos"
+	"context"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// MemberApproveCommandHandler 는 member.approve 명령으로 가입 신청한 멤버를 승인한다.
+type MemberApproveCommandHandler struct {
+	memberService *MemberService
+}
+
+func NewMemberApproveCommandHandler(memberService *MemberService) *MemberApproveCommandHandler {
+	return &MemberApproveCommandHandler{
+		memberService: memberService,
+	}
+}
+
+func (h MemberApproveCommandHandler) Handle(ctx context.Context, command dtos.InboundCommand) error {
+	var data dtos.MemberCommandData
+	if err := decodeCommandData(command, &data); err != nil {
+		return err
+	}
+
+	return h.memberService.ApproveMember(ctx, data.MemberId)
+}
+
+// MemberRejectCommandHandler 는 member.reject 명령으로 가입 신청한 멤버를 거절한다.
+type MemberRejectCommandHandler struct {
+	memberService *MemberService
+}
+
+func NewMemberRejectCommandHandler(memberService *MemberService) *MemberRejectCommandHandler {
+	return &MemberRejectCommandHandler{
+		memberService: memberService,
+	}
+}
+
+func (h MemberRejectCommandHandler) Handle(ctx context.Context, command dtos.InboundCommand) error {
+	var data dtos.MemberCommandData
+	if err := decodeCommandData(command, &data); err != nil {
+		return err
+	}
+
+	return h.memberService.RejectMember(ctx, data.MemberId)
+}
+
+// MemberGrantRolesCommandHandler 는 member.grant-roles 명령으로 멤버가 가진 역할에 역할을 더한다.
+// 이미 가진 역할은 다시 더하지 않으므로 같은 명령을 여러 번 실행해도 결과가 같다.
+type MemberGrantRolesCommandHandler struct {
+	memberService *MemberService
+}
+
+func NewMemberGrantRolesCommandHandler(memberService *MemberService) *MemberGrantRolesCommandHandler {
+	return &MemberGrantRolesCommandHandler{
+		memberService: memberService,
+	}
+}
+
+func (h MemberGrantRolesCommandHandler) Handle(ctx context.Context, command dtos.InboundCommand) error {
+	var data dtos.MemberGrantRolesCommandData
+	if err := decodeCommandData(command, &data); err != nil {
+		return err
+	}
+
+	memberEntity, err := h.memberService.GetMemberById(ctx, data.MemberId)
+	if err != nil {
+		return err
+	}
+
+	roleIds := make([]uint, 0)
+	granted := map[uint]bool{}
+	for _, role := range memberEntity.Roles {
+		roleIds = append(roleIds, role.ID)
+		granted[role.ID] = true
+	}
+	for _, roleId := range data.RoleIds {
+		if !granted[roleId] {
+			roleIds = append(roleIds, roleId)
+			granted[roleId] = true
+		}
+	}
+
+	return h.memberService.AssignRole(ctx, data.MemberId, dtos.MemberAssignRole{RoleIds: roleIds})
+}
+
+func decodeCommandData(command dtos.InboundCommand, data interface{}) error {
+	if err := json.Unmarshal(command.Data, data); err != nil {
+		return pkgerrors.Wrapf(err, "%s command data decode error", command.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"github.com/mitchellh/mapstructure"
+	"sync"
+	"time"
+)
+
+// 요청마다 DB 를 조회하지 않도록 동시 처리 제한 설정을 메모리에 두는 시간.
+// 다른 인스턴스에서 바꾸거나 설정 버전을 되돌린 경우에도 이 시간이 지나면 반영된다.
+const concurrencyLimitCacheTtl = 10 * time.Second
+
+var concurrencyLimitCache struct {
+	mutex       sync.Mutex
+	routeGroups []dtos.ConcurrencyLimitRouteGroup
+	loadedAt    time.Time
+}
+
+type ConcurrencyLimitService struct {
+	siteService *SiteService
+}
+
+func NewConcurrencyLimitService(siteService *SiteService) *ConcurrencyLimitService {
+	return &ConcurrencyLimitService{
+		siteService: siteService,
+	}
+}
+
+func (s ConcurrencyLimitService) GetConcurrencyLimitSetting(ctx context.Context) (dtos.ConcurrencyLimitSetting, error) {
+	concurrencyLimitSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyConcurrencyLimit)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.ConcurrencyLimitSetting{RouteGroups: make([]dtos.ConcurrencyLimitRouteGroup, 0)}, nil
+		}
+		return dtos.ConcurrencyLimitSetting{}, err
+	}
+
+	var setting dtos.ConcurrencyLimitSetting
+	if err = mapstructure.Decode(concurrencyLimitSetting, &setting); err != nil {
+		return dtos.ConcurrencyLimitSetting{}, err
+	}
+
+	if setting.RouteGroups == nil {
+		setting.RouteGroups = make([]dtos.ConcurrencyLimitRouteGroup, 0)
+	}
+
+	return setting, nil
+}
+
+// SetConcurrencyLimitSetting 은 설정을 저장하고 커밋한 뒤 이 인스턴스에 바로 반영한다.
+func (s ConcurrencyLimitService) SetConcurrencyLimitSetting(ctx context.Context, setting dtos.ConcurrencyLimitSetting) error {
+	if err := s.siteService.SetSettingWithKey(ctx, constants.SettingKeyConcurrencyLimit, setting); err != nil {
+		return err
+	}
+
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		concurrencyLimitCache.mutex.Lock()
+		defer concurrencyLimitCache.mutex.Unlock()
+		concurrencyLimitCache.loadedAt = time.Time{}
+	})
+	return nil
+}
+
+// GetRouteGroups 는 middlewares.ConcurrencyLimit 이 사용할 경로 그룹을 반환한다. concurrencyLimitCacheTtl 마다 DB 에서 다시 읽는다.
+func (s ConcurrencyLimitService) GetRouteGroups(ctx context.Context) ([]dtos.ConcurrencyLimitRouteGroup, error) {
+	concurrencyLimitCache.mutex.Lock()
+	defer concurrencyLimitCache.mutex.Unlock()
+
+	if !concurrencyLimitCache.loadedAt.IsZero() && time.Since(concurrencyLimitCache.loadedAt) < concurrencyLimitCacheTtl {
+		return concurrencyLimitCache.routeGroups, nil
+	}
+
+	setting, err := s.GetConcurrencyLimitSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrencyLimitCache.routeGroups = setting.RouteGroups
+	concurrencyLimitCache.loadedAt = time.Now()
+	return setting.RouteGroups, nil
+}
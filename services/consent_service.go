@@ -0,0 +1,159 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/oauth/domain"
+	"better-admin-backend-service/oauth/repository"
+	"context"
+	"strings"
+)
+
+type ConsentService struct {
+	oauthClientService      *OAuthClientService
+	memberConsentRepository *repository.MemberConsentRepository
+	auditService            *AuditService
+}
+
+func NewConsentService(oauthClientService *OAuthClientService,
+	memberConsentRepository *repository.MemberConsentRepository,
+	auditService *AuditService) *ConsentService {
+	return &ConsentService{
+		oauthClientService:      oauthClientService,
+		memberConsentRepository: memberConsentRepository,
+		auditService:            auditService,
+	}
+}
+
+// GetConsent 는 Client 가 요청한 scope 에 대해 로그인한 멤버의 동의가 필요한지 확인한다.
+// 자사(Trusted) Client 는 동의 없이 사용할 수 있다. 동의는 멤버만 하므로 서비스 계정은 ErrAuthentication 이다.
+func (s ConsentService) GetConsent(ctx context.Context, clientId string, requestedScopes []string) (dtos.OAuthConsent, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return dtos.OAuthConsent{}, err
+	}
+
+	client, err := s.oauthClientService.GetOAuthClientByClientId(ctx, clientId)
+	if err != nil {
+		return dtos.OAuthConsent{}, err
+	}
+
+	if len(requestedScopes) == 0 {
+		requestedScopes = client.GetScopes()
+	}
+
+	if err := client.ValidateScopes(requestedScopes); err != nil {
+		return dtos.OAuthConsent{}, err
+	}
+
+	consent := dtos.OAuthConsent{
+		Client: dtos.OAuthClientSummary{
+			ClientId:    client.ClientId,
+			Name:        client.Name,
+			Description: client.Description,
+		},
+		RequestedScopes: requestedScopes,
+		GrantedScopes:   make([]string, 0),
+		ConsentRequired: !client.Trusted,
+	}
+
+	consentEntity, err := s.memberConsentRepository.FindByMemberIdAndClientId(ctx, userClaim.Id, client.ID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return consent, nil
+		}
+
+		return dtos.OAuthConsent{}, err
+	}
+
+	consent.GrantedScopes = consentEntity.GetScopes()
+	if consentEntity.Covers(requestedScopes) {
+		consent.ConsentRequired = false
+	}
+
+	return consent, nil
+}
+
+func (s ConsentService) GrantConsent(ctx context.Context, grant dtos.MemberConsentGrant) error {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.oauthClientService.GetOAuthClientByClientId(ctx, grant.ClientId)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ValidateScopes(grant.Scopes); err != nil {
+		return err
+	}
+
+	consentEntity, err := s.memberConsentRepository.FindByMemberIdAndClientId(ctx, userClaim.Id, client.ID)
+	if err != nil {
+		if !errors.Is(err, errors.ErrNotFound) {
+			return err
+		}
+
+		consentEntity = domain.NewMemberConsentEntity(userClaim.Id, client.ID, grant.Scopes)
+	} else {
+		consentEntity.Grant(grant.Scopes)
+	}
+
+	if err := s.memberConsentRepository.Save(ctx, &consentEntity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionConsentGranted,
+		constants.AuditTargetTypeOAuthClient, client.ID, strings.Join(grant.Scopes, " "))
+}
+
+func (s ConsentService) GetMyConsents(ctx context.Context) ([]domain.MemberConsentEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.memberConsentRepository.FindByMemberId(ctx, userClaim.Id)
+}
+
+func (s ConsentService) RevokeConsent(ctx context.Context, clientId string) error {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.oauthClientService.GetOAuthClientByClientId(ctx, clientId)
+	if err != nil {
+		return err
+	}
+
+	consentEntity, err := s.memberConsentRepository.FindByMemberIdAndClientId(ctx, userClaim.Id, client.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.memberConsentRepository.Delete(ctx, consentEntity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionConsentRevoked,
+		constants.AuditTargetTypeOAuthClient, client.ID, consentEntity.Scopes)
+}
+
+// RevokeMemberConsents 는 멤버가 Client 에 한 동의를 모두 철회하고 철회한 수를 반환한다.(예. 멤버 해지)
+func (s ConsentService) RevokeMemberConsents(ctx context.Context, memberId uint) (int, error) {
+	consentEntities, err := s.memberConsentRepository.FindByMemberId(ctx, memberId)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, consentEntity := range consentEntities {
+		if err := s.memberConsentRepository.Delete(ctx, consentEntity); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(consentEntities), nil
+}
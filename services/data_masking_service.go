@@ -0,0 +1,69 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"github.com/mitchellh/mapstructure"
+)
+
+type DataMaskingService struct {
+	siteService *SiteService
+}
+
+func NewDataMaskingService(siteService *SiteService) *DataMaskingService {
+	return &DataMaskingService{
+		siteService: siteService,
+	}
+}
+
+func (s DataMaskingService) GetDataMaskingSetting(ctx context.Context) (dtos.DataMaskingSetting, error) {
+	dataMaskingSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyDataMasking)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.DataMaskingSetting{Policies: make([]dtos.DataMaskingPolicy, 0)}, nil
+		}
+		return dtos.DataMaskingSetting{}, err
+	}
+
+	var setting dtos.DataMaskingSetting
+	if err = mapstructure.Decode(dataMaskingSetting, &setting); err != nil {
+		return dtos.DataMaskingSetting{}, err
+	}
+
+	if setting.Policies == nil {
+		setting.Policies = make([]dtos.DataMaskingPolicy, 0)
+	}
+
+	return setting, nil
+}
+
+func (s DataMaskingService) SetDataMaskingSetting(ctx context.Context, setting dtos.DataMaskingSetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyDataMasking, setting)
+}
+
+// GetPolicies 는 요청한 사용자에게 적용할 마스킹 정책(필드 이름별 마스킹 방법)을 반환한다.
+// UNMASK 권한이 있으면 가리지 않고, 사용자가 없는 경우(예. 예약 실행한 리포트)는 가린다.
+func (s DataMaskingService) GetPolicies(ctx context.Context) (map[string]string, error) {
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(ctx); err == nil {
+		for _, permission := range userClaim.Permissions {
+			if permission == constants.PermissionUnmask {
+				return map[string]string{}, nil
+			}
+		}
+	}
+
+	setting, err := s.GetDataMaskingSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := map[string]string{}
+	for _, policy := range setting.Policies {
+		policies[policy.Field] = policy.Method
+	}
+
+	return policies, nil
+}
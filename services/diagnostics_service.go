@@ -0,0 +1,109 @@
+package services
+
+import (
+	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/file/domain"
+	"better-admin-backend-service/helpers"
+	"bytes"
+	"context"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"time"
+)
+
+// 최근 GC 의 멈춘 시간은 최대 10 개까지 응답한다.
+const recentGCPauseCount = 10
+
+// DiagnosticsService 는 서버 프로세스의 런타임 현황을 조회하고, 분석할 수 있도록 goroutine, heap 덤프를 파일 저장소에 저장한다.
+type DiagnosticsService struct {
+	fileService  *FileService
+	auditService *AuditService
+}
+
+func NewDiagnosticsService(fileService *FileService, auditService *AuditService) *DiagnosticsService {
+	return &DiagnosticsService{
+		fileService:  fileService,
+		auditService: auditService,
+	}
+}
+
+// GetRuntimeMetric 은 goroutine 수, heap 과 GC 현황, DB 커넥션 풀 사용 현황을 조회한다.
+func (DiagnosticsService) GetRuntimeMetric(ctx context.Context) (dtos.RuntimeMetric, error) {
+	sqlDB, err := helpers.ContextHelper().GetDB(ctx).DB()
+	if err != nil {
+		return dtos.RuntimeMetric{}, err
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	gcMetric := dtos.GCMetric{
+		NumGC:                   memStats.NumGC,
+		NextGCBytes:             memStats.NextGC,
+		PauseTotalMilliseconds:  float64(memStats.PauseTotalNs) / float64(time.Millisecond),
+		RecentPauseMicroseconds: make([]uint64, 0),
+		CPUFraction:             memStats.GCCPUFraction,
+	}
+	if memStats.NumGC > 0 {
+		lastGCAt := time.Unix(0, int64(memStats.LastGC))
+		gcMetric.LastGCAt = &lastGCAt
+	}
+	// PauseNs 는 순환 버퍼이며 가장 최근 GC 는 (NumGC+255)%256 에 있다.
+	for i := uint32(0); i < memStats.NumGC && i < recentGCPauseCount; i++ {
+		pause := memStats.PauseNs[(memStats.NumGC-1-i)%uint32(len(memStats.PauseNs))]
+		gcMetric.RecentPauseMicroseconds = append(gcMetric.RecentPauseMicroseconds, pause/uint64(time.Microsecond))
+	}
+
+	return dtos.RuntimeMetric{
+		GoVersion:  runtime.Version(),
+		NumCPU:     runtime.NumCPU(),
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		Goroutines: runtime.NumGoroutine(),
+		Heap: dtos.HeapMetric{
+			AllocBytes:      memStats.HeapAlloc,
+			SysBytes:        memStats.HeapSys,
+			InuseBytes:      memStats.HeapInuse,
+			IdleBytes:       memStats.HeapIdle,
+			ReleasedBytes:   memStats.HeapReleased,
+			Objects:         memStats.HeapObjects,
+			TotalAllocBytes: memStats.TotalAlloc,
+			Mallocs:         memStats.Mallocs,
+			Frees:           memStats.Frees,
+		},
+		GC:       gcMetric,
+		Database: db.PoolMonitor().GetMetric(sqlDB),
+	}, nil
+}
+
+// CaptureDump 는 goroutine 덤프(모든 goroutine 의 stack, text)나 heap 프로파일(pprof)을 진단 파일로 저장하고 감사 로그를 남긴다.
+// 저장한 파일은 SYSTEM_DIAGNOSTICS 권한이 있어야 /api/files/:id/content 로 내려받을 수 있다.
+func (s DiagnosticsService) CaptureDump(ctx context.Context, request dtos.DiagnosticsDumpRequest) (domain.FileEntity, error) {
+	var content bytes.Buffer
+	name := fmt.Sprintf("%s-%s", request.Type, time.Now().Format("20060102-150405"))
+
+	switch request.Type {
+	case constants.DiagnosticsDumpGoroutine:
+		if err := pprof.Lookup("goroutine").WriteTo(&content, 2); err != nil {
+			return domain.FileEntity{}, err
+		}
+		name += ".txt"
+	case constants.DiagnosticsDumpHeap:
+		// heap 프로파일은 마지막 GC 시점의 현황이므로 GC 를 실행한 뒤 저장한다.
+		runtime.GC()
+		if err := pprof.Lookup("heap").WriteTo(&content, 0); err != nil {
+			return domain.FileEntity{}, err
+		}
+		name += ".pb.gz"
+	}
+
+	entity, err := s.fileService.UploadFile(ctx, constants.FilePurposeDiagnostics, name, content.Bytes())
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	return entity, s.auditService.RecordAuditLog(ctx, constants.AuditActionDiagnosticsDumpCaptured, constants.AuditTargetTypeFile, entity.ID,
+		fmt.Sprintf("type=%v, name=%v, size=%v", request.Type, entity.Name, entity.Size))
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/event/domain"
+	"better-admin-backend-service/event/repository"
+	memberDomain "better-admin-backend-service/member/domain"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"context"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type DomainEventService struct {
+	domainEventRepository *repository.DomainEventRepository
+	eventStoreRepository  *repository.EventStoreRepository
+}
+
+func NewDomainEventService(domainEventRepository *repository.DomainEventRepository,
+	eventStoreRepository *repository.EventStoreRepository) *DomainEventService {
+	return &DomainEventService{
+		domainEventRepository: domainEventRepository,
+		eventStoreRepository:  eventStoreRepository,
+	}
+}
+
+// RecordMemberEvent 는 멤버의 변경을 아웃박스에 기록하고, 이벤트 저장소를 사용하면 이벤트 저장소에도 추가한다.
+func (s DomainEventService) RecordMemberEvent(ctx context.Context, eventType string, member memberDomain.MemberEntity) error {
+	data := member.ToEventData()
+	if config.Config.EventStore.Enabled {
+		entity, err := domain.NewEventStoreEntity(ctx, eventType, constants.DomainEventAggregateMember, member.ID, data)
+		if err != nil {
+			return err
+		}
+
+		if err := s.eventStoreRepository.Append(ctx, &entity); err != nil {
+			return err
+		}
+	}
+
+	return s.record(ctx, eventType, constants.DomainEventAggregateMember, member.ID, data)
+}
+
+func (s DomainEventService) RecordRoleEvent(ctx context.Context, eventType string, role rbacDomain.RoleEntity) error {
+	return s.record(ctx, eventType, constants.DomainEventAggregateRole, role.ID, role.ToEventData())
+}
+
+// record 는 도메인 변경과 같은 트랜잭션에서 아웃박스에 이벤트를 기록한다. 발행할 브로커가 없으면 기록하지 않는다.
+func (s DomainEventService) record(ctx context.Context, eventType, aggregateType string, aggregateId uint, data interface{}) error {
+	if !adapters.MessageBrokerAdapter().IsEnabled() {
+		return nil
+	}
+
+	entity, err := domain.NewDomainEventEntity(ctx, eventType, aggregateType, aggregateId, data)
+	if err != nil {
+		return err
+	}
+
+	return s.domainEventRepository.Create(ctx, &entity)
+}
+
+// PublishPendingEvents 는 스케줄러 작업으로, 발행할 차례가 된 이벤트를 기록한 순서대로 발행한다.
+// 브로커가 받은 뒤 발행 완료를 저장하지 못하면 다시 발행하므로 소비자는 eventId 로 중복을 걸러야 한다(at-least-once).
+func (s DomainEventService) PublishPendingEvents(ctx context.Context) error {
+	if !adapters.MessageBrokerAdapter().IsEnabled() {
+		return nil
+	}
+
+	brokerConfig := config.Config.MessageBroker
+	now := time.Now()
+	entities, err := s.domainEventRepository.FindPublishable(ctx, now, brokerConfig.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range entities {
+		entity := &entities[i]
+		message := entity.ToBrokerMessage(brokerConfig.TopicStrategy, brokerConfig.TopicPrefix, brokerConfig.Topic)
+		if err := adapters.MessageBrokerAdapter().Publish([]adapters.BrokerMessage{message}); err != nil {
+			log.Warnf("domain event %s publish error: %v", entity.EventId, err)
+			entity.MarkPublishFailed(err, now, brokerConfig.MaxAttempts, time.Duration(brokerConfig.RetryBaseSeconds)*time.Second)
+			if err := s.domainEventRepository.Save(ctx, entity); err != nil {
+				return err
+			}
+			continue
+		}
+
+		entity.MarkPublished(now)
+		if err := s.domainEventRepository.Save(ctx, entity); err != nil {
+			return err
+		}
+	}
+
+	if brokerConfig.RetentionDays > 0 {
+		return s.domainEventRepository.DeletePublishedBefore(ctx, now.AddDate(0, 0, -brokerConfig.RetentionDays))
+	}
+
+	return nil
+}
+
+func (s DomainEventService) GetDomainEvents(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.DomainEventEntity, int64, error) {
+	return s.domainEventRepository.FindAll(ctx, filters, pageable)
+}
+
+// RetryDomainEvent 는 발행에 실패(failed)한 이벤트를 다음 스케줄러 작업에서 다시 발행하도록 한다.
+func (s DomainEventService) RetryDomainEvent(ctx context.Context, id uint) (domain.DomainEventEntity, error) {
+	entity, err := s.domainEventRepository.FindById(ctx, id)
+	if err != nil {
+		return entity, err
+	}
+
+	if err := entity.Retry(time.Now()); err != nil {
+		return entity, err
+	}
+
+	return entity, s.domainEventRepository.Save(ctx, &entity)
+}
+
+// GetMemberHistory 는 이벤트 저장소에 기록된 멤버의 이벤트를 순서대로 반환한다. until 이 있으면 그 시각까지의 이벤트만 반환한다.
+func (s DomainEventService) GetMemberHistory(ctx context.Context, memberId uint, until *time.Time) ([]dtos.MemberHistoryEvent, error) {
+	entities, err := s.eventStoreRepository.FindByAggregate(ctx, constants.DomainEventAggregateMember, memberId, until)
+	if err != nil {
+		return nil, err
+	}
+
+	var events = make([]dtos.MemberHistoryEvent, 0)
+	for _, entity := range entities {
+		event, err := entity.ToMemberHistoryEvent()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// GetMemberStateAt 은 at 시각까지의 이벤트로 멤버의 상태를 만든다. 그 시각까지 기록된 이벤트가 없으면 ErrNotFound 를 반환한다.
+func (s DomainEventService) GetMemberStateAt(ctx context.Context, memberId uint, at time.Time) (dtos.MemberHistoryState, error) {
+	events, err := s.GetMemberHistory(ctx, memberId, &at)
+	if err != nil {
+		return dtos.MemberHistoryState{}, err
+	}
+
+	state, ok := domain.ProjectMemberState(events, at)
+	if !ok {
+		return dtos.MemberHistoryState{}, errors.ErrNotFound
+	}
+
+	return state, nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/export/domain"
+	"better-admin-backend-service/export/repository"
+	fileDomain "better-admin-backend-service/file/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"io"
+	"time"
+)
+
+// 내보내기 유형별로 요청할 수 있는 권한
+var exportTypePermissions = map[string]string{
+	constants.ExportTypeMemberData: constants.PermissionManageMembers,
+	constants.ExportTypeReport:     constants.PermissionManageSystemSettings,
+}
+
+// ExportJobService 는 오래 걸리는 내보내기를 작업으로 등록하고 주기 작업(ProcessPendingJobs)에서 처리한다.
+// 결과는 파일 저장소에 저장하며 요청한 멤버만 보관 시간(Export.RetentionHours) 동안 내려받을 수 있다.
+type ExportJobService struct {
+	exportJobRepository     *repository.ExportJobRepository
+	fileService             *FileService
+	memberService           *MemberService
+	memberDataExportService *MemberDataExportService
+	reportService           *ReportService
+}
+
+func NewExportJobService(
+	exportJobRepository *repository.ExportJobRepository,
+	fileService *FileService,
+	memberService *MemberService,
+	memberDataExportService *MemberDataExportService,
+	reportService *ReportService) *ExportJobService {
+
+	return &ExportJobService{
+		exportJobRepository:     exportJobRepository,
+		fileService:             fileService,
+		memberService:           memberService,
+		memberDataExportService: memberDataExportService,
+		reportService:           reportService,
+	}
+}
+
+// CreateExportJob 은 내보내기 유형의 권한을 확인하고 작업을 등록한다.
+// 작업은 요청한 멤버의 권한으로 처리하고 결과도 그 멤버만 내려받으므로 서비스 계정은 요청할 수 없다(ErrAuthentication).
+func (s ExportJobService) CreateExportJob(ctx context.Context, request dtos.ExportJobRequest) (domain.ExportJobEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return domain.ExportJobEntity{}, err
+	}
+
+	permitted := false
+	for _, permission := range userClaim.Permissions {
+		if permission == exportTypePermissions[request.Type] {
+			permitted = true
+		}
+	}
+	if !permitted {
+		return domain.ExportJobEntity{}, errors.ErrForbidden
+	}
+
+	if err := s.checkTarget(ctx, request); err != nil {
+		return domain.ExportJobEntity{}, err
+	}
+
+	entity, err := domain.NewExportJobEntity(ctx, request)
+	if err != nil {
+		return domain.ExportJobEntity{}, err
+	}
+
+	if err := s.exportJobRepository.Create(ctx, &entity); err != nil {
+		return domain.ExportJobEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// GetExportJobs 는 요청한 멤버의 내보내기 작업을 최근 순으로 조회한다.
+func (s ExportJobService) GetExportJobs(ctx context.Context, pageable dtos.Pageable) ([]domain.ExportJobEntity, int64, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.exportJobRepository.FindByRequestedBy(ctx, userClaim.Id, pageable)
+}
+
+// GetExportJob 은 요청한 멤버의 작업만 조회한다. 다른 멤버의 작업은 ErrNotFound 이다.
+func (s ExportJobService) GetExportJob(ctx context.Context, jobId uint) (domain.ExportJobEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return domain.ExportJobEntity{}, err
+	}
+
+	return s.exportJobRepository.FindByIdAndRequestedBy(ctx, jobId, userClaim.Id)
+}
+
+// GetExportFile 은 완료한 작업의 결과 파일 정보이다. 아직 완료하지 않았거나 보관 시간이 지났으면 ErrNotFound 이다.
+func (s ExportJobService) GetExportFile(ctx context.Context, job domain.ExportJobEntity) (fileDomain.FileEntity, error) {
+	if !job.IsDownloadable(time.Now()) {
+		return fileDomain.FileEntity{}, errors.ErrNotFound
+	}
+
+	return s.fileService.GetFile(ctx, job.FileId)
+}
+
+// OpenExportFile 은 요청한 멤버가 결과 파일을 내려받도록 연다. 다 읽은 뒤에는 닫아야 한다.
+func (s ExportJobService) OpenExportFile(ctx context.Context, jobId uint) (fileDomain.FileEntity, io.ReadCloser, error) {
+	job, err := s.GetExportJob(ctx, jobId)
+	if err != nil {
+		return fileDomain.FileEntity{}, nil, err
+	}
+
+	if !job.IsDownloadable(time.Now()) {
+		return fileDomain.FileEntity{}, nil, errors.ErrNotFound
+	}
+
+	return s.fileService.OpenFileContent(ctx, job.FileId)
+}
+
+// ProcessPendingJobs 는 대기 중인 작업을 요청한 멤버의 권한으로 처리한다. 진행 상태는 단계마다 저장하여 처리하는 동안에도 조회할 수 있다.
+func (s ExportJobService) ProcessPendingJobs(ctx context.Context) error {
+	jobs, err := s.exportJobRepository.FindByStatus(ctx, constants.ExportJobStatusPending)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		job.Start()
+		if err := s.exportJobRepository.Save(ctx, &job); err != nil {
+			return err
+		}
+
+		if err := s.process(ctx, &job); err != nil {
+			log.Errorf("export job error. jobId=%v, %v", job.ID, err)
+			job.Fail(err)
+			if err := s.exportJobRepository.Save(ctx, &job); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpiredExports 는 보관 시간이 지난 결과 파일을 지우고 작업을 expired 로 바꾼다.
+func (s ExportJobService) DeleteExpiredExports(ctx context.Context) error {
+	jobs, err := s.exportJobRepository.FindExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		file, err := s.fileService.GetFile(ctx, job.FileId)
+		if err != nil && !errors.Is(err, errors.ErrNotFound) {
+			return err
+		}
+
+		if err == nil {
+			if err := s.fileService.deleteFile(ctx, file); err != nil {
+				log.Errorf("export file delete error. jobId=%v, fileId=%v, %v", job.ID, job.FileId, err)
+				continue
+			}
+		}
+
+		job.Expire()
+		if err := s.exportJobRepository.Save(ctx, &job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s ExportJobService) process(ctx context.Context, job *domain.ExportJobEntity) error {
+	jobCtx := helpers.ContextHelper().SetUserClaim(ctx, job.GetUserClaim())
+
+	fileName, content, err := s.generate(jobCtx, *job)
+	if err != nil {
+		return err
+	}
+
+	job.Progress = domain.ExportProgressGenerated
+	if err := s.exportJobRepository.Save(ctx, job); err != nil {
+		return err
+	}
+
+	return helpers.ContextHelper().GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := helpers.ContextHelper().SetDB(jobCtx, tx)
+
+		file, err := s.fileService.StoreGeneratedFile(txCtx, constants.FilePurposeExport, fileName, content)
+		if err != nil {
+			return err
+		}
+
+		job.Succeed(file.ID, time.Duration(config.Config.Export.RetentionHours)*time.Hour)
+		return s.exportJobRepository.Save(txCtx, job)
+	})
+}
+
+func (s ExportJobService) checkTarget(ctx context.Context, request dtos.ExportJobRequest) error {
+	switch request.Type {
+	case constants.ExportTypeMemberData:
+		_, err := s.memberService.GetMember(ctx, request.TargetId)
+		return err
+	case constants.ExportTypeReport:
+		_, err := s.reportService.GetReport(ctx, request.TargetId)
+		return err
+	}
+
+	return errors.ErrBadRequest
+}
+
+func (s ExportJobService) generate(ctx context.Context, job domain.ExportJobEntity) (string, []byte, error) {
+	switch job.Type {
+	case constants.ExportTypeMemberData:
+		return s.memberDataExportService.ExportMemberData(ctx, job.TargetId)
+	case constants.ExportTypeReport:
+		return s.reportService.ExportReport(ctx, job.TargetId)
+	}
+
+	return "", nil, errors.ErrBadRequest
+}
@@ -0,0 +1,655 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/file/domain"
+	"better-admin-backend-service/file/repository"
+	"better-admin-backend-service/helpers"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"time"
+)
+
+type FileService struct {
+	fileRepository         *repository.FileRepository
+	attachmentRepository   *repository.AttachmentRepository
+	siteService            *SiteService
+	memberService          *MemberService
+	auditService           *AuditService
+	messageTemplateService *MessageTemplateService
+}
+
+func NewFileService(fileRepository *repository.FileRepository,
+	attachmentRepository *repository.AttachmentRepository,
+	siteService *SiteService,
+	memberService *MemberService,
+	auditService *AuditService,
+	messageTemplateService *MessageTemplateService) *FileService {
+	return &FileService{
+		fileRepository:         fileRepository,
+		attachmentRepository:   attachmentRepository,
+		siteService:            siteService,
+		memberService:          memberService,
+		auditService:           auditService,
+		messageTemplateService: messageTemplateService,
+	}
+}
+
+// UploadFile 은 파일의 크기와 형식, 저장 한도를 확인하고 바이러스 검사를 통과하면 저장소에 저장한 뒤 파일 정보를 기록한다.
+// 바이러스가 있는 파일은 격리하여 보관하고 업로드는 실패한다. 저장 한도를 넘으면 ErrInsufficientQuota 이다.
+func (s FileService) UploadFile(ctx context.Context, purpose string, name string, content []byte) (domain.FileEntity, error) {
+	if int64(len(content)) > config.Config.FileStorage.MaxSizeBytes {
+		return domain.FileEntity{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("file is larger than %d bytes", config.Config.FileStorage.MaxSizeBytes)}
+	}
+
+	entity, err := domain.NewFileEntity(ctx, purpose, name, content, adapters.FileStorageAdapter().GetBackend())
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	accessible, err := entity.IsAccessible(ctx)
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+	if !accessible {
+		return domain.FileEntity{}, errors.ErrForbidden
+	}
+
+	if err := s.checkQuota(ctx, entity.UploaderMemberId(), entity.Size); err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	scanResult, err := adapters.FileScanAdapter().Scan(entity.Name, content)
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+	entity.ApplyScanResult(adapters.FileScanAdapter().IsEnabled(), scanResult)
+
+	if entity.IsQuarantined() {
+		return domain.FileEntity{}, s.quarantine(ctx, entity, content)
+	}
+
+	if err := s.store(ctx, &entity, content); err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// StoreGeneratedFile 은 서버가 만든 파일(예. 내보내기 결과)을 저장한다. 올린 파일이 아니므로 크기 제한(FileStorage.MaxSizeBytes)과
+// 바이러스 검사는 하지 않고 저장 한도만 확인한다.
+func (s FileService) StoreGeneratedFile(ctx context.Context, purpose string, name string, content []byte) (domain.FileEntity, error) {
+	entity, err := domain.NewFileEntity(ctx, purpose, name, content, adapters.FileStorageAdapter().GetBackend())
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	if err := s.checkQuota(ctx, entity.UploaderMemberId(), entity.Size); err != nil {
+		return domain.FileEntity{}, err
+	}
+	entity.ApplyScanResult(false, dtos.FileScanResult{})
+
+	if err := s.store(ctx, &entity, content); err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s FileService) GetFiles(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.FileEntity, int64, error) {
+	return s.fileRepository.FindAll(ctx, filters, pageable)
+}
+
+func (s FileService) GetFile(ctx context.Context, fileId uint) (domain.FileEntity, error) {
+	return s.fileRepository.FindById(ctx, fileId)
+}
+
+// OpenFileContent 는 파일 내용을 읽을 수 있도록 연다. 다 읽은 뒤에는 닫아야 한다.
+// 첨부 파일은 첨부한 대상을 볼 수 있는 경우에만 내려받을 수 있으므로 OpenAttachmentContent 로 연다.
+func (s FileService) OpenFileContent(ctx context.Context, fileId uint) (domain.FileEntity, io.ReadCloser, error) {
+	entity, err := s.fileRepository.FindById(ctx, fileId)
+	if err != nil {
+		return domain.FileEntity{}, nil, err
+	}
+
+	if entity.IsAttachment() {
+		return domain.FileEntity{}, nil, errors.ErrForbidden
+	}
+
+	accessible, err := entity.IsAccessible(ctx)
+	if err != nil {
+		return domain.FileEntity{}, nil, err
+	}
+	if !accessible {
+		return domain.FileEntity{}, nil, errors.ErrForbidden
+	}
+
+	return s.openContent(entity)
+}
+
+func (s FileService) openContent(entity domain.FileEntity) (domain.FileEntity, io.ReadCloser, error) {
+	if entity.IsQuarantined() {
+		return domain.FileEntity{}, nil, errors.ErrQuarantined
+	}
+
+	content, err := adapters.FileStorageAdapter().Get(entity.StorageKey)
+	if err != nil {
+		if err == adapters.ErrFileNotFound {
+			return domain.FileEntity{}, nil, errors.ErrNotFound
+		}
+		return domain.FileEntity{}, nil, err
+	}
+
+	return entity, content, nil
+}
+
+// DeleteFile 은 파일을 삭제한다. 첨부한 파일은 첨부한 대상과 함께 삭제한다.
+func (s FileService) DeleteFile(ctx context.Context, fileId uint) error {
+	entity, err := s.fileRepository.FindById(ctx, fileId)
+	if err != nil {
+		return err
+	}
+
+	modifiable, err := entity.IsModifiable(ctx)
+	if err != nil {
+		return err
+	}
+	if !modifiable {
+		return errors.ErrForbidden
+	}
+
+	if entity.IsAttachment() {
+		attached, err := s.attachmentRepository.ExistsByFileId(ctx, entity.ID)
+		if err != nil {
+			return err
+		}
+		if attached {
+			return errors.ErrForbidden
+		}
+	}
+
+	return s.deleteFile(ctx, entity)
+}
+
+func (s FileService) deleteFile(ctx context.Context, entity domain.FileEntity) error {
+	if err := s.fileRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	s.deleteThumbnails(entity)
+	return adapters.FileStorageAdapter().Delete(entity.StorageKey)
+}
+
+// ReplaceFile 은 파일 내용을 교체한다. 파일 Id 는 그대로이므로 파일을 참조하는 곳(아바타, 로고 등)은 바꾸지 않아도 된다.
+// 이전 내용과 이전 내용으로 만든 썸네일은 지운다.
+func (s FileService) ReplaceFile(ctx context.Context, fileId uint, name string, content []byte) (domain.FileEntity, error) {
+	if int64(len(content)) > config.Config.FileStorage.MaxSizeBytes {
+		return domain.FileEntity{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("file is larger than %d bytes", config.Config.FileStorage.MaxSizeBytes)}
+	}
+
+	entity, err := s.fileRepository.FindById(ctx, fileId)
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	modifiable, err := entity.IsModifiable(ctx)
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+	// 첨부 파일은 검토한 내용이 바뀌지 않도록 교체할 수 없다.
+	if !modifiable || entity.IsAttachment() {
+		return domain.FileEntity{}, errors.ErrForbidden
+	}
+
+	if entity.IsQuarantined() {
+		return domain.FileEntity{}, errors.ErrQuarantined
+	}
+
+	previous := entity
+	if err := entity.Replace(ctx, name, content); err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	// 교체한 파일은 처음 올린 멤버의 저장 한도에 포함한다.
+	if err := s.checkQuota(ctx, entity.UploaderMemberId(), entity.Size-previous.Size); err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	scanResult, err := adapters.FileScanAdapter().Scan(entity.Name, content)
+	if err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	// 바이러스가 있으면 기존 파일은 그대로 두고 새 내용은 별도의 파일로 격리한다.
+	if scanResult.Infected {
+		quarantined, err := domain.NewFileEntity(ctx, previous.Purpose, name, content, adapters.FileStorageAdapter().GetBackend())
+		if err != nil {
+			return domain.FileEntity{}, err
+		}
+		quarantined.ApplyScanResult(true, scanResult)
+
+		return domain.FileEntity{}, s.quarantine(ctx, quarantined, content)
+	}
+	entity.ApplyScanResult(adapters.FileScanAdapter().IsEnabled(), scanResult)
+
+	if err := adapters.FileStorageAdapter().Put(entity.StorageKey, content, entity.ContentType); err != nil {
+		return domain.FileEntity{}, err
+	}
+
+	if err := s.fileRepository.Save(ctx, &entity); err != nil {
+		if deleteErr := adapters.FileStorageAdapter().Delete(entity.StorageKey); deleteErr != nil {
+			log.Errorf("file storage delete error(%s): %v", entity.StorageKey, deleteErr)
+		}
+		return domain.FileEntity{}, err
+	}
+
+	s.deleteThumbnails(previous)
+	if err := adapters.FileStorageAdapter().Delete(previous.StorageKey); err != nil {
+		log.Errorf("file storage delete error(%s): %v", previous.StorageKey, err)
+	}
+
+	return entity, nil
+}
+
+// GetThumbnail 은 이미지 파일의 썸네일을 반환한다. 처음 요청한 크기는 만들어서 저장소에 캐시한다.
+func (s FileService) GetThumbnail(ctx context.Context, fileId uint, width, height int) (domain.FileEntity, domain.Thumbnail, error) {
+	if !isAllowedThumbnailSize(width, height) {
+		return domain.FileEntity{}, domain.Thumbnail{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("thumbnail size %dx%d is not allowed", width, height)}
+	}
+
+	entity, err := s.fileRepository.FindById(ctx, fileId)
+	if err != nil {
+		return domain.FileEntity{}, domain.Thumbnail{}, err
+	}
+
+	if entity.IsQuarantined() {
+		return domain.FileEntity{}, domain.Thumbnail{}, errors.ErrQuarantined
+	}
+
+	if !entity.IsThumbnailSupported() {
+		return domain.FileEntity{}, domain.Thumbnail{}, &errors.ErrInvalidFile{Reason: fmt.Sprintf("thumbnail is not supported for %v(%v)", entity.Purpose, entity.ContentType)}
+	}
+
+	thumbnailKey := entity.ThumbnailStorageKey(width, height)
+	cached, err := s.readStorage(thumbnailKey)
+	if err == nil {
+		return entity, domain.Thumbnail{ContentType: domain.DetectContentType("", cached), Content: cached}, nil
+	}
+	if err != adapters.ErrFileNotFound {
+		return domain.FileEntity{}, domain.Thumbnail{}, err
+	}
+
+	content, err := s.readStorage(entity.StorageKey)
+	if err != nil {
+		if err == adapters.ErrFileNotFound {
+			return domain.FileEntity{}, domain.Thumbnail{}, errors.ErrNotFound
+		}
+		return domain.FileEntity{}, domain.Thumbnail{}, err
+	}
+
+	thumbnail, err := domain.NewThumbnail(entity, content, width, height)
+	if err != nil {
+		return domain.FileEntity{}, domain.Thumbnail{}, err
+	}
+
+	// 캐시하지 못해도 썸네일은 응답한다. 다음 요청에서 다시 만든다.
+	if err := adapters.FileStorageAdapter().Put(thumbnailKey, thumbnail.Content, thumbnail.ContentType); err != nil {
+		log.Errorf("thumbnail cache error(%s): %v", thumbnailKey, err)
+	}
+
+	return entity, thumbnail, nil
+}
+
+// AttachFiles 는 업로드한 첨부 파일(purpose: attachment)을 대상에 첨부한다. 대상을 볼 수 있는지는 호출하는 서비스가 확인한다.
+// 자신이 업로드한 파일만 첨부할 수 있고, 대상별 보관 기간(Attachment)이 지나면 삭제한다.
+func (s FileService) AttachFiles(ctx context.Context, entityType string, entityId uint, fileIds []uint) ([]domain.AttachmentEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]domain.AttachmentEntity, 0, len(fileIds))
+	if len(fileIds) == 0 {
+		return attachments, nil
+	}
+
+	existAttachments, err := s.attachmentRepository.FindByEntityIds(ctx, entityType, []uint{entityId})
+	if err != nil {
+		return nil, err
+	}
+	if len(existAttachments)+len(fileIds) > config.Config.Attachment.MaxFilesPerEntity {
+		return nil, &errors.ErrInvalidFile{Reason: fmt.Sprintf("attachments must be at most %d", config.Config.Attachment.MaxFilesPerEntity)}
+	}
+
+	now := time.Now()
+	for _, fileId := range fileIds {
+		file, err := s.fileRepository.FindById(ctx, fileId)
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				return nil, &errors.ErrInvalidFile{Reason: fmt.Sprintf("file %d is not found", fileId)}
+			}
+			return nil, err
+		}
+
+		if !file.IsAttachment() {
+			return nil, &errors.ErrInvalidFile{Reason: fmt.Sprintf("file %d is not an attachment", fileId)}
+		}
+		if file.IsQuarantined() {
+			return nil, errors.ErrQuarantined
+		}
+		if !file.IsUploadedBy(*userClaim) {
+			return nil, errors.ErrForbidden
+		}
+
+		attached, err := s.attachmentRepository.ExistsByFileId(ctx, fileId)
+		if err != nil {
+			return nil, err
+		}
+		if attached {
+			return nil, &errors.ErrInvalidFile{Reason: fmt.Sprintf("file %d is already attached", fileId)}
+		}
+
+		attachment := domain.NewAttachmentEntity(entityType, entityId, file, userClaim.Id, attachmentRetentionDays(entityType), now)
+		if err := s.attachmentRepository.Create(ctx, &attachment); err != nil {
+			return nil, err
+		}
+
+		detail := fmt.Sprintf("attachmentId=%v, fileId=%v, name=%v", attachment.ID, file.ID, file.Name)
+		if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionAttachmentAdded, entityType, entityId, detail); err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+func (s FileService) GetAttachments(ctx context.Context, entityType string, entityIds []uint) ([]domain.AttachmentEntity, error) {
+	return s.attachmentRepository.FindByEntityIds(ctx, entityType, entityIds)
+}
+
+// OpenAttachmentContent 는 대상에 첨부한 파일 내용을 연다. 누가 내려받았는지 감사 로그에 남긴다.
+func (s FileService) OpenAttachmentContent(ctx context.Context, entityType string, entityId uint, attachmentId uint) (domain.AttachmentEntity, io.ReadCloser, error) {
+	attachment, err := s.attachmentRepository.FindById(ctx, attachmentId)
+	if err != nil {
+		return domain.AttachmentEntity{}, nil, err
+	}
+
+	if attachment.EntityType != entityType || attachment.EntityId != entityId {
+		return domain.AttachmentEntity{}, nil, errors.ErrNotFound
+	}
+
+	content, err := s.openAttachment(ctx, attachment)
+	if err != nil {
+		return domain.AttachmentEntity{}, nil, err
+	}
+
+	detail := fmt.Sprintf("attachmentId=%v, fileId=%v, name=%v", attachment.ID, attachment.FileId, attachment.Name)
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionAttachmentDownloaded, entityType, entityId, detail); err != nil {
+		content.Close()
+		return domain.AttachmentEntity{}, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (s FileService) openAttachment(ctx context.Context, attachment domain.AttachmentEntity) (io.ReadCloser, error) {
+	file, err := s.fileRepository.FindById(ctx, attachment.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, content, err := s.openContent(file)
+	return content, err
+}
+
+// DeleteAttachments 는 대상들의 첨부 파일을 파일과 함께 삭제한다. 대상을 삭제할 때 사용한다.
+func (s FileService) DeleteAttachments(ctx context.Context, entityType string, entityIds []uint) error {
+	attachments, err := s.attachmentRepository.FindByEntityIds(ctx, entityType, entityIds)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := s.deleteAttachment(ctx, attachment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteExpiredAttachments 는 스케줄러 작업으로, 보관 기간이 지난 첨부 파일을 삭제한다.
+func (s FileService) DeleteExpiredAttachments(ctx context.Context) error {
+	attachments, err := s.attachmentRepository.FindExpired(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := s.deleteAttachment(ctx, attachment); err != nil {
+			return err
+		}
+
+		detail := fmt.Sprintf("attachmentId=%v, fileId=%v, name=%v", attachment.ID, attachment.FileId, attachment.Name)
+		if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionAttachmentExpired, attachment.EntityType, attachment.EntityId, detail); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s FileService) deleteAttachment(ctx context.Context, attachment domain.AttachmentEntity) error {
+	if err := s.attachmentRepository.Delete(ctx, attachment); err != nil {
+		return err
+	}
+
+	file, err := s.fileRepository.FindById(ctx, attachment.FileId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	return s.deleteFile(ctx, file)
+}
+
+func attachmentRetentionDays(entityType string) int {
+	switch entityType {
+	case constants.AuditTargetTypeApproval:
+		return config.Config.Attachment.ApprovalRetentionDays
+	case constants.AuditTargetTypeNote:
+		return config.Config.Attachment.NoteRetentionDays
+	}
+
+	return 0
+}
+
+// GetFileQuotaSetting 은 파일 저장 한도 설정을 반환한다. 설정하지 않았으면 제한하지 않는다.
+func (s FileService) GetFileQuotaSetting(ctx context.Context) (dtos.FileQuotaSetting, error) {
+	fileQuotaSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyFileQuota)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.FileQuotaSetting{}, nil
+		}
+		return dtos.FileQuotaSetting{}, err
+	}
+
+	var setting dtos.FileQuotaSetting
+	if err = mapstructure.Decode(fileQuotaSetting, &setting); err != nil {
+		return dtos.FileQuotaSetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s FileService) SetFileQuotaSetting(ctx context.Context, setting dtos.FileQuotaSetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyFileQuota, setting)
+}
+
+// GetFileStorageUsage 는 요청한 멤버가 올린 파일의 크기 합과 멤버 저장 한도를 조회한다. 서비스 계정은 멤버 저장 한도가 없으므로 ErrAuthentication 이다.
+func (s FileService) GetFileStorageUsage(ctx context.Context) (dtos.FileStorageUsage, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return dtos.FileStorageUsage{}, err
+	}
+
+	setting, err := s.GetFileQuotaSetting(ctx)
+	if err != nil {
+		return dtos.FileStorageUsage{}, err
+	}
+
+	usedBytes, err := s.fileRepository.SumSize(ctx, userClaim.Id)
+	if err != nil {
+		return dtos.FileStorageUsage{}, err
+	}
+
+	return dtos.FileStorageUsage{UsedBytes: usedBytes, QuotaBytes: setting.MemberQuotaBytes}, nil
+}
+
+// checkQuota 는 멤버(memberId)가 올린 파일과 사이트 전체 파일에 additionalBytes 를 더해도 저장 한도를 넘지 않는지 확인한다.
+// 격리한 파일도 저장소를 차지하므로 포함한다. 서비스 계정이 올린 파일(memberId 0)은 사이트 전체 한도만 확인한다.
+func (s FileService) checkQuota(ctx context.Context, memberId uint, additionalBytes int64) error {
+	if additionalBytes <= 0 {
+		return nil
+	}
+
+	setting, err := s.GetFileQuotaSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	if setting.MemberQuotaBytes > 0 && memberId > 0 {
+		usedBytes, err := s.fileRepository.SumSize(ctx, memberId)
+		if err != nil {
+			return err
+		}
+		if usedBytes+additionalBytes > setting.MemberQuotaBytes {
+			return errors.ErrInsufficientQuota
+		}
+	}
+
+	if setting.TotalQuotaBytes > 0 {
+		usedBytes, err := s.fileRepository.SumSize(ctx, 0)
+		if err != nil {
+			return err
+		}
+		if usedBytes+additionalBytes > setting.TotalQuotaBytes {
+			return errors.ErrInsufficientQuota
+		}
+	}
+
+	return nil
+}
+
+// store 는 파일 내용을 저장소에 저장하고 파일 정보를 기록한다.
+func (s FileService) store(ctx context.Context, entity *domain.FileEntity, content []byte) error {
+	if err := adapters.FileStorageAdapter().Put(entity.StorageKey, content, entity.ContentType); err != nil {
+		return err
+	}
+
+	if err := s.fileRepository.Create(ctx, entity); err != nil {
+		// 파일 정보가 없는 파일은 찾을 수 없으므로 저장소에서도 지운다.
+		if deleteErr := adapters.FileStorageAdapter().Delete(entity.StorageKey); deleteErr != nil {
+			log.Errorf("file storage delete error(%s): %v", entity.StorageKey, deleteErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// quarantine 은 바이러스가 있는 파일을 격리 영역에 보관하고 감사 로그를 남긴 뒤 보안 담당자(FileScan.NotifyRoleName)에게 알린다.
+func (s FileService) quarantine(ctx context.Context, entity domain.FileEntity, content []byte) error {
+	if err := s.store(ctx, &entity, content); err != nil {
+		return err
+	}
+
+	detail := fmt.Sprintf("name=%v, signature=%v", entity.Name, entity.ScanSignature)
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionFileQuarantined, constants.AuditTargetTypeFile, entity.ID, detail); err != nil {
+		return err
+	}
+
+	s.notifyQuarantined(ctx, entity)
+
+	return &errors.ErrInvalidFile{Reason: fmt.Sprintf("file is infected: %v", entity.ScanSignature)}
+}
+
+func (s FileService) notifyQuarantined(ctx context.Context, entity domain.FileEntity) {
+	roleName := config.Config.FileScan.NotifyRoleName
+	if len(roleName) == 0 {
+		return
+	}
+
+	members, err := s.memberService.GetMembersByRoleName(ctx, roleName)
+	if err != nil {
+		log.Error("file quarantine notification error: ", err)
+		return
+	}
+
+	for _, member := range members {
+		email := member.GetEmail()
+		if len(email) == 0 {
+			continue
+		}
+
+		if err := adapters.MailAdapter().Send(s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateFileQuarantined, []string{email}, map[string]string{
+			"fileName":  entity.Name,
+			"fileId":    fmt.Sprint(entity.ID),
+			"purpose":   entity.Purpose,
+			"signature": entity.ScanSignature,
+			"createdBy": fmt.Sprint(entity.CreatedBy),
+		})); err != nil {
+			log.Error("file quarantine notification error: ", err)
+		}
+	}
+}
+
+func (FileService) readStorage(key string) ([]byte, error) {
+	reader, err := adapters.FileStorageAdapter().Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// deleteThumbnails 는 허용된 크기로 만든 썸네일을 지운다. 만들지 않은 크기는 저장소에서 무시한다.
+func (FileService) deleteThumbnails(entity domain.FileEntity) {
+	if !entity.IsThumbnailSupported() {
+		return
+	}
+
+	for _, size := range config.Config.Thumbnail.Sizes {
+		var width, height int
+		if _, err := fmt.Sscanf(size, "%dx%d", &width, &height); err != nil {
+			continue
+		}
+
+		thumbnailKey := entity.ThumbnailStorageKey(width, height)
+		if err := adapters.FileStorageAdapter().Delete(thumbnailKey); err != nil {
+			log.Errorf("thumbnail delete error(%s): %v", thumbnailKey, err)
+		}
+	}
+}
+
+func isAllowedThumbnailSize(width, height int) bool {
+	for _, size := range config.Config.Thumbnail.Sizes {
+		if size == fmt.Sprintf("%dx%d", width, height) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	"strings"
+)
+
+// GoogleWorkspaceService 는 구글 워크스페이스 로그인을 허용할 도메인, 도메인별 기본 역할, 디렉터리 프로필 가져오기를 관리한다.
+type GoogleWorkspaceService struct {
+	siteService *SiteService
+	rbacService *RoleBasedAccessControlService
+}
+
+func NewGoogleWorkspaceService(siteService *SiteService, rbacService *RoleBasedAccessControlService) *GoogleWorkspaceService {
+	return &GoogleWorkspaceService{
+		siteService: siteService,
+		rbacService: rbacService,
+	}
+}
+
+// GetGoogleWorkspaceSetting 은 허용 도메인 설정을 반환한다. 설정하지 않았으면 구글 워크스페이스 로그인 설정의 도메인 하나만 허용한다.
+func (s GoogleWorkspaceService) GetGoogleWorkspaceSetting(ctx context.Context) (dtos.GoogleWorkspaceSetting, error) {
+	googleWorkspaceSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspace)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return s.newLegacyGoogleWorkspaceSetting(ctx)
+		}
+		return dtos.GoogleWorkspaceSetting{}, err
+	}
+
+	var setting dtos.GoogleWorkspaceSetting
+	if err = mapstructure.Decode(googleWorkspaceSetting, &setting); err != nil {
+		return dtos.GoogleWorkspaceSetting{}, err
+	}
+
+	if setting.Domains == nil {
+		setting.Domains = make([]dtos.GoogleWorkspaceDomain, 0)
+	}
+
+	return setting, nil
+}
+
+func (s GoogleWorkspaceService) newLegacyGoogleWorkspaceSetting(ctx context.Context) (dtos.GoogleWorkspaceSetting, error) {
+	verifyHostedDomain := true
+	setting := dtos.GoogleWorkspaceSetting{
+		Domains:            make([]dtos.GoogleWorkspaceDomain, 0),
+		VerifyHostedDomain: &verifyHostedDomain,
+	}
+
+	googleWorkspaceLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return setting, nil
+		}
+		return dtos.GoogleWorkspaceSetting{}, err
+	}
+
+	var loginSetting dtos.GoogleWorkspaceLoginSetting
+	if err = mapstructure.Decode(googleWorkspaceLoginSetting, &loginSetting); err != nil {
+		return dtos.GoogleWorkspaceSetting{}, err
+	}
+
+	if len(loginSetting.Domain) > 0 {
+		setting.Domains = append(setting.Domains, dtos.GoogleWorkspaceDomain{Domain: loginSetting.Domain, DefaultRoleIds: make([]uint, 0)})
+	}
+
+	return setting, nil
+}
+
+func (s GoogleWorkspaceService) SetGoogleWorkspaceSetting(ctx context.Context, setting dtos.GoogleWorkspaceSetting) error {
+	domains := map[string]bool{}
+	for i, domain := range setting.Domains {
+		name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain.Domain), "@"))
+		if len(name) == 0 || strings.ContainsAny(name, "@/ ") {
+			return &errors.ErrInvalidGoogleWorkspaceSetting{Reason: fmt.Sprintf("invalid domain %q", domain.Domain)}
+		}
+
+		if domains[name] {
+			return &errors.ErrInvalidGoogleWorkspaceSetting{Reason: fmt.Sprintf("duplicated domain %q", name)}
+		}
+		domains[name] = true
+
+		if err := s.validateRoles(ctx, domain.DefaultRoleIds); err != nil {
+			return err
+		}
+
+		setting.Domains[i].Domain = name
+		if domain.DefaultRoleIds == nil {
+			setting.Domains[i].DefaultRoleIds = make([]uint, 0)
+		}
+	}
+
+	if setting.DirectoryAccess.Enabled && !setting.DirectoryAccess.AdminConsented {
+		return &errors.ErrInvalidGoogleWorkspaceSetting{Reason: "directory access requires admin consent"}
+	}
+
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspace, setting)
+}
+
+func (s GoogleWorkspaceService) validateRoles(ctx context.Context, roleIds []uint) error {
+	if len(roleIds) == 0 {
+		return nil
+	}
+
+	roleEntities, err := s.findRoles(ctx, roleIds)
+	if err != nil {
+		return err
+	}
+
+	for _, roleId := range roleIds {
+		found := false
+		for _, roleEntity := range roleEntities {
+			if roleEntity.ID == roleId {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return &errors.ErrInvalidGoogleWorkspaceSetting{Reason: fmt.Sprintf("role %v not found", roleId)}
+		}
+	}
+
+	return nil
+}
+
+// GetDefaultRoles 는 도메인 계정으로 처음 로그인한 멤버에게 할당할 역할이다. 설정한 뒤 지워진 역할은 빠진다.
+func (s GoogleWorkspaceService) GetDefaultRoles(ctx context.Context, domain dtos.GoogleWorkspaceDomain) ([]rbacDomain.RoleEntity, error) {
+	if len(domain.DefaultRoleIds) == 0 {
+		return make([]rbacDomain.RoleEntity, 0), nil
+	}
+
+	return s.findRoles(ctx, domain.DefaultRoleIds)
+}
+
+func (s GoogleWorkspaceService) findRoles(ctx context.Context, roleIds []uint) ([]rbacDomain.RoleEntity, error) {
+	filters := map[string]interface{}{}
+	filters["roleIds"] = roleIds
+
+	roleEntities, _, err := s.rbacService.GetRoles(ctx, filters, dtos.Pageable{Page: 0})
+	return roleEntities, err
+}
+
+// GetOAuthUri 는 구글 로그인 화면 주소이다. 디렉터리 프로필을 가져오면 디렉터리 읽기 범위도 요청하고, 그룹을 동기화하면 그룹 읽기 범위도 요청한다.
+func (s GoogleWorkspaceService) GetOAuthUri(ctx context.Context, loginSetting dtos.GoogleWorkspaceLoginSetting) (string, error) {
+	setting, err := s.GetGoogleWorkspaceSetting(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if setting.DirectoryAccess.IsGroupSyncUsable() {
+		return loginSetting.GetOAuthUri(constants.GoogleOAuthScopeDirectoryUserReadonly, constants.GoogleOAuthScopeDirectoryGroupReadonly), nil
+	}
+
+	if setting.DirectoryAccess.IsUsable() {
+		return loginSetting.GetOAuthUri(constants.GoogleOAuthScopeDirectoryUserReadonly), nil
+	}
+
+	return loginSetting.GetOAuthUri(), nil
+}
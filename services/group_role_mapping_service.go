@@ -0,0 +1,232 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/rbac/repository"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+)
+
+// GroupRoleMappingService 는 SSO 로 로그인할 때 멤버가 속한 그룹(구글 워크스페이스 그룹, SAML/LDAP 그룹)을
+// 관리자가 정한 그룹-역할 매핑에 맞춰 역할로 동기화한다.
+type GroupRoleMappingService struct {
+	groupRoleMappingRepository *repository.GroupRoleMappingRepository
+	rbacService                *RoleBasedAccessControlService
+	memberService              *MemberService
+	auditService               *AuditService
+}
+
+func NewGroupRoleMappingService(
+	groupRoleMappingRepository *repository.GroupRoleMappingRepository,
+	rbacService *RoleBasedAccessControlService,
+	memberService *MemberService,
+	auditService *AuditService) *GroupRoleMappingService {
+
+	return &GroupRoleMappingService{
+		groupRoleMappingRepository: groupRoleMappingRepository,
+		rbacService:                rbacService,
+		memberService:              memberService,
+		auditService:               auditService,
+	}
+}
+
+func (s GroupRoleMappingService) CreateMapping(ctx context.Context, information dtos.GroupRoleMappingInformation) (domain.GroupRoleMappingEntity, error) {
+	if err := s.validateMapping(ctx, information); err != nil {
+		return domain.GroupRoleMappingEntity{}, err
+	}
+
+	entity := domain.NewGroupRoleMappingEntity(ctx, information)
+	if err := s.groupRoleMappingRepository.Create(ctx, &entity); err != nil {
+		return domain.GroupRoleMappingEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// GetMappings 는 provider 가 있으면 그 인증 방식의 매핑만 조회한다.
+func (s GroupRoleMappingService) GetMappings(ctx context.Context, provider string) ([]domain.GroupRoleMappingEntity, error) {
+	filters := map[string]interface{}{}
+	if len(provider) > 0 {
+		filters["provider"] = provider
+	}
+
+	return s.groupRoleMappingRepository.FindAll(ctx, filters)
+}
+
+func (s GroupRoleMappingService) GetMapping(ctx context.Context, mappingId uint) (domain.GroupRoleMappingEntity, error) {
+	return s.groupRoleMappingRepository.FindById(ctx, mappingId)
+}
+
+func (s GroupRoleMappingService) UpdateMapping(ctx context.Context, mappingId uint, information dtos.GroupRoleMappingInformation) error {
+	entity, err := s.groupRoleMappingRepository.FindById(ctx, mappingId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateMapping(ctx, information); err != nil {
+		return err
+	}
+
+	entity.Update(ctx, information)
+	return s.groupRoleMappingRepository.Save(ctx, &entity)
+}
+
+// DeleteMapping 은 매핑을 지운다. 매핑으로 이미 할당한 역할은 회수하지 않는다.
+func (s GroupRoleMappingService) DeleteMapping(ctx context.Context, mappingId uint) error {
+	entity, err := s.groupRoleMappingRepository.FindById(ctx, mappingId)
+	if err != nil {
+		return err
+	}
+
+	return s.groupRoleMappingRepository.Delete(ctx, entity)
+}
+
+// validateMapping 은 그룹을 알려주는 인증 방식인지, 할당할 역할이 있는지 확인한다. 최고 관리자 역할은 매핑할 수 없다.
+func (s GroupRoleMappingService) validateMapping(ctx context.Context, information dtos.GroupRoleMappingInformation) error {
+	if information.Provider != constants.TypeMemberGoogle {
+		name, ok := memberDomain.ParseCustomIdentityProvider(information.Provider)
+		if !ok {
+			return &errors.ErrInvalidGroupRoleMapping{Reason: fmt.Sprintf("%q is not a supported provider", information.Provider)}
+		}
+		if _, ok := getAuthenticator(name); !ok {
+			return &errors.ErrInvalidGroupRoleMapping{Reason: fmt.Sprintf("authenticator %q not found", name)}
+		}
+	}
+
+	roleEntity, err := s.rbacService.GetRole(ctx, information.RoleId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return &errors.ErrInvalidGroupRoleMapping{Reason: fmt.Sprintf("role %v not found", information.RoleId)}
+		}
+		return err
+	}
+
+	if roleEntity.Name == constants.RoleNameSuperAdmin {
+		return &errors.ErrInvalidGroupRoleMapping{Reason: "super admin role can not be mapped"}
+	}
+
+	return nil
+}
+
+// SyncMemberRoles 는 멤버가 속한 그룹(groups)에 맞춰 provider 매핑의 역할을 추가하고 회수한다. groups 가 nil 이면 그룹을 알 수 없으므로 바꾸지 않는다.
+// 그룹에서 빠진 멤버에게서는 매핑으로 할당한 역할만 회수하고, 권한 매핑(Authoritative)이면 직접 할당한 역할도 회수한다.
+// 그룹에 속한 다른 매핑이 같은 역할을 할당하면 회수하지 않는다.
+func (s GroupRoleMappingService) SyncMemberRoles(ctx context.Context, memberEntity *memberDomain.MemberEntity, provider string, groups []string) (dtos.GroupRoleSyncResult, error) {
+	result := dtos.GroupRoleSyncResult{AddedRoleIds: make([]uint, 0), RemovedRoleIds: make([]uint, 0)}
+	if groups == nil {
+		return result, nil
+	}
+
+	mappings, err := s.groupRoleMappingRepository.FindAll(ctx, map[string]interface{}{"provider": provider})
+	if err != nil || len(mappings) == 0 {
+		return result, err
+	}
+
+	err = helpers.ContextHelper().GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := helpers.ContextHelper().SetDB(ctx, tx)
+
+		grants, err := s.groupRoleMappingRepository.FindGrantsByMemberId(txCtx, memberEntity.ID)
+		if err != nil {
+			return err
+		}
+
+		grantOf := make(map[uint]domain.GroupRoleGrantEntity)
+		grantedRoles := make(map[uint]bool)
+		for _, grant := range grants {
+			grantOf[grant.MappingId] = grant
+			grantedRoles[grant.RoleId] = true
+		}
+
+		wanted := make(map[uint]bool)
+		for _, mapping := range mappings {
+			if mapping.Matches(groups) {
+				wanted[mapping.RoleId] = true
+			}
+		}
+
+		now := time.Now()
+		newGrants := make([]domain.GroupRoleGrantEntity, 0)
+		deleteGrantIds := make([]uint, 0)
+		addRoleIds := make([]uint, 0)
+		removeRoles := make(map[uint]bool)
+		for _, mapping := range mappings {
+			grant, granted := grantOf[mapping.ID]
+			if mapping.Matches(groups) {
+				// 직접 할당한 역할은 매핑으로 할당한 역할로 바꾸지 않는다.
+				if !granted && (!memberEntity.HasRole(mapping.RoleId) || grantedRoles[mapping.RoleId]) {
+					newGrants = append(newGrants, domain.GroupRoleGrantEntity{MemberId: memberEntity.ID, MappingId: mapping.ID, RoleId: mapping.RoleId, GrantedAt: now})
+					grantedRoles[mapping.RoleId] = true
+				}
+				if !memberEntity.HasRole(mapping.RoleId) && !containsId(addRoleIds, mapping.RoleId) {
+					addRoleIds = append(addRoleIds, mapping.RoleId)
+				}
+				continue
+			}
+
+			if granted {
+				deleteGrantIds = append(deleteGrantIds, grant.ID)
+			}
+			if !wanted[mapping.RoleId] && memberEntity.HasRole(mapping.RoleId) && (granted || mapping.Authoritative) {
+				removeRoles[mapping.RoleId] = true
+			}
+		}
+
+		var addRoles []domain.RoleEntity
+		if len(addRoleIds) > 0 {
+			// 매핑을 만든 뒤 지운 역할은 건너뛴다.
+			if addRoles, _, err = s.rbacService.GetRoles(txCtx, map[string]interface{}{"roleIds": addRoleIds}, dtos.Pageable{Page: 0}); err != nil {
+				return err
+			}
+		}
+
+		for _, roleEntity := range addRoles {
+			result.AddedRoleIds = append(result.AddedRoleIds, roleEntity.ID)
+		}
+		for _, role := range memberEntity.Roles {
+			if removeRoles[role.ID] {
+				result.RemovedRoleIds = append(result.RemovedRoleIds, role.ID)
+			}
+		}
+
+		if err := s.groupRoleMappingRepository.DeleteGrants(txCtx, deleteGrantIds); err != nil {
+			return err
+		}
+
+		if err := s.groupRoleMappingRepository.CreateGrants(txCtx, newGrants); err != nil {
+			return err
+		}
+
+		if len(result.AddedRoleIds) == 0 && len(result.RemovedRoleIds) == 0 {
+			return nil
+		}
+
+		if err := s.memberService.UpdateRoles(txCtx, memberEntity, addRoles, result.RemovedRoleIds); err != nil {
+			return err
+		}
+
+		return s.auditService.RecordAuditLog(txCtx, constants.AuditActionMemberGroupRolesSynced, constants.AuditTargetTypeMember, memberEntity.ID,
+			fmt.Sprintf("provider=%v, addedRoleIds=%v, removedRoleIds=%v", provider, result.AddedRoleIds, result.RemovedRoleIds))
+	})
+	if err != nil {
+		return dtos.GroupRoleSyncResult{}, err
+	}
+
+	return result, nil
+}
+
+func containsId(ids []uint, id uint) bool {
+	for _, value := range ids {
+		if value == id {
+			return true
+		}
+	}
+
+	return false
+}
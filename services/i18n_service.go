@@ -0,0 +1,196 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/i18n/domain"
+	"better-admin-backend-service/i18n/repository"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"regexp"
+	"unicode/utf8"
+)
+
+// localePattern 은 BCP 47 형식의 로케일이다.(예. ko, en-US, zh-Hant-TW)
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8}){0,2}$`)
+
+// I18nService 는 관리자가 로케일별로 바꾼 화면 문구를 관리한다. 바꿀 때마다 버전을 남기고 이전 버전으로 되돌릴 수 있다.
+type I18nService struct {
+	localeBundleRepository *repository.LocaleBundleRepository
+	auditService           *AuditService
+}
+
+func NewI18nService(localeBundleRepository *repository.LocaleBundleRepository, auditService *AuditService) *I18nService {
+	return &I18nService{
+		localeBundleRepository: localeBundleRepository,
+		auditService:           auditService,
+	}
+}
+
+func (s I18nService) GetLocaleBundles(ctx context.Context) ([]domain.LocaleBundleEntity, error) {
+	return s.localeBundleRepository.FindAll(ctx)
+}
+
+func (s I18nService) GetLocaleBundle(ctx context.Context, locale string) (domain.LocaleBundleEntity, error) {
+	return s.localeBundleRepository.FindByLocale(ctx, locale)
+}
+
+// UpdateMessages 는 요청에 포함된 키만 바꾼다. 값이 null 이면 키를 지운다. 로케일 번들이 없으면 만든다.
+func (s I18nService) UpdateMessages(ctx context.Context, locale string, changes dtos.LocaleMessageChanges) (domain.LocaleBundleEntity, error) {
+	entity, err := s.findOrNewBundle(ctx, locale)
+	if err != nil {
+		return domain.LocaleBundleEntity{}, err
+	}
+
+	messages := entity.GetMessages()
+	for key, value := range changes {
+		if value == nil {
+			delete(messages, key)
+		} else {
+			messages[key] = *value
+		}
+	}
+
+	return s.changeBundle(ctx, entity, messages, 0, constants.AuditActionLocaleBundleChanged)
+}
+
+// ImportBundle 은 내보낸 번들의 문구를 가져온다. replace 이면 번들에 없는 키를 지우고, 아니면 기존 문구에 덮어쓴다.
+func (s I18nService) ImportBundle(ctx context.Context, locale string, bundle dtos.LocaleBundle, replace bool) (domain.LocaleBundleEntity, error) {
+	if len(bundle.Locale) > 0 && bundle.Locale != locale {
+		return domain.LocaleBundleEntity{}, &errors.ErrInvalidLocaleBundle{Reason: fmt.Sprintf("bundle locale %s does not match %s", bundle.Locale, locale)}
+	}
+
+	entity, err := s.findOrNewBundle(ctx, locale)
+	if err != nil {
+		return domain.LocaleBundleEntity{}, err
+	}
+
+	messages := map[string]string{}
+	if !replace {
+		messages = entity.GetMessages()
+	}
+	for key, value := range bundle.Messages {
+		messages[key] = value
+	}
+
+	return s.changeBundle(ctx, entity, messages, 0, constants.AuditActionLocaleBundleImported)
+}
+
+func (s I18nService) GetVersions(ctx context.Context, locale string, pageable dtos.Pageable) ([]domain.LocaleBundleVersionEntity, int64, error) {
+	return s.localeBundleRepository.FindVersions(ctx, locale, pageable)
+}
+
+func (s I18nService) GetVersion(ctx context.Context, locale string, version uint) (domain.LocaleBundleVersionEntity, error) {
+	return s.localeBundleRepository.FindVersion(ctx, locale, version)
+}
+
+// RollbackBundle 은 번들을 이전 버전의 문구로 되돌린다. 되돌린 결과도 새 버전으로 남는다.
+func (s I18nService) RollbackBundle(ctx context.Context, locale string, version uint) (domain.LocaleBundleEntity, error) {
+	versionEntity, err := s.localeBundleRepository.FindVersion(ctx, locale, version)
+	if err != nil {
+		return domain.LocaleBundleEntity{}, err
+	}
+
+	entity, err := s.localeBundleRepository.FindByLocale(ctx, locale)
+	if err != nil {
+		return domain.LocaleBundleEntity{}, err
+	}
+
+	return s.changeBundle(ctx, entity, versionEntity.GetMessages(), version, constants.AuditActionLocaleBundleRolledBack)
+}
+
+func (s I18nService) findOrNewBundle(ctx context.Context, locale string) (domain.LocaleBundleEntity, error) {
+	if !localePattern.MatchString(locale) {
+		return domain.LocaleBundleEntity{}, &errors.ErrInvalidLocaleBundle{Reason: fmt.Sprintf("invalid locale %s", locale)}
+	}
+
+	entity, err := s.localeBundleRepository.FindByLocale(ctx, locale)
+	if errors.Is(err, errors.ErrNotFound) {
+		return domain.LocaleBundleEntity{Locale: locale}, nil
+	}
+
+	return entity, err
+}
+
+// changeBundle 은 문구를 확인하고 바뀐 경우에만 번들과 새 버전을 저장한다. 오래된 버전은 LocaleBundleMaxVersions 개만 남긴다.
+func (s I18nService) changeBundle(ctx context.Context, entity domain.LocaleBundleEntity, messages map[string]string,
+	rolledBackFrom uint, action string) (domain.LocaleBundleEntity, error) {
+	if err := validateLocaleMessages(messages); err != nil {
+		return domain.LocaleBundleEntity{}, err
+	}
+
+	if entity.ID != 0 && equalLocaleMessages(entity.GetMessages(), messages) {
+		return entity, nil
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return domain.LocaleBundleEntity{}, err
+	}
+
+	err = helpers.ContextHelper().GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := helpers.ContextHelper().SetDB(ctx, tx)
+
+		versionEntity, err := entity.Change(messages, rolledBackFrom, userClaim.Id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.localeBundleRepository.Save(txCtx, &entity); err != nil {
+			return err
+		}
+
+		if err := s.localeBundleRepository.CreateVersion(txCtx, &versionEntity); err != nil {
+			return err
+		}
+
+		if versionEntity.Version > constants.LocaleBundleMaxVersions {
+			if err := s.localeBundleRepository.DeleteVersionsOlderThan(txCtx, entity.Locale, versionEntity.Version-constants.LocaleBundleMaxVersions+1); err != nil {
+				return err
+			}
+		}
+
+		return s.auditService.RecordAuditLog(txCtx, action, constants.AuditTargetTypeLocaleBundle, entity.ID,
+			fmt.Sprintf("locale=%s, version=%v, messages=%v", entity.Locale, entity.Version, len(messages)))
+	})
+	if err != nil {
+		return domain.LocaleBundleEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func validateLocaleMessages(messages map[string]string) error {
+	if len(messages) > constants.LocaleBundleMaxMessages {
+		return &errors.ErrInvalidLocaleBundle{Reason: fmt.Sprintf("messages must be less than or equal to %v", constants.LocaleBundleMaxMessages)}
+	}
+
+	for key, value := range messages {
+		if len(key) == 0 || utf8.RuneCountInString(key) > constants.LocaleBundleMaxKeyLength {
+			return &errors.ErrInvalidLocaleBundle{Reason: fmt.Sprintf("message key length must be between 1 and %v", constants.LocaleBundleMaxKeyLength)}
+		}
+
+		if utf8.RuneCountInString(value) > constants.LocaleBundleMaxValueLength {
+			return &errors.ErrInvalidLocaleBundle{Reason: fmt.Sprintf("message %s must be less than or equal to %v characters", key, constants.LocaleBundleMaxValueLength)}
+		}
+	}
+
+	return nil
+}
+
+func equalLocaleMessages(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, value := range a {
+		if other, exists := b[key]; !exists || other != value {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/command/domain"
+	"better-admin-backend-service/command/repository"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// CommandHandler 는 메시지 브로커로 받은 명령을 실행한다. Context 에는 명령을 보낸 서비스 계정의 UserClaim 이 설정된다.
+type CommandHandler interface {
+	Handle(ctx context.Context, command dtos.InboundCommand) error
+}
+
+type commandRegistration struct {
+	permission string
+	handler    CommandHandler
+}
+
+type InboundCommandService struct {
+	serviceAccountService    *ServiceAccountService
+	inboundCommandRepository *repository.InboundCommandRepository
+	consumerOffsetRepository *repository.ConsumerOffsetRepository
+	handlers                 map[string]commandRegistration
+}
+
+func NewInboundCommandService(serviceAccountService *ServiceAccountService,
+	inboundCommandRepository *repository.InboundCommandRepository,
+	consumerOffsetRepository *repository.ConsumerOffsetRepository) *InboundCommandService {
+	return &InboundCommandService{
+		serviceAccountService:    serviceAccountService,
+		inboundCommandRepository: inboundCommandRepository,
+		consumerOffsetRepository: consumerOffsetRepository,
+		handlers:                 map[string]commandRegistration{},
+	}
+}
+
+// RegisterHandler 는 명령 타입의 처리기를 등록한다. 명령을 보낸 서비스 계정에 permission 이 있어야 실행한다.
+func (s InboundCommandService) RegisterHandler(commandType, permission string, handler CommandHandler) {
+	s.handlers[commandType] = commandRegistration{permission: permission, handler: handler}
+}
+
+// HandleMessage 는 컨슈머 작업으로, 받은 명령을 실행하고 결과와 다음 offset 을 같은 트랜잭션에서 저장한다.
+// 처리할 수 없는 명령은 데드 레터 토픽으로 보내고 다음 메시지로 넘어간다.
+func (s InboundCommandService) HandleMessage(ctx context.Context, message adapters.BrokerMessage) error {
+	if err := s.handle(ctx, message); err != nil {
+		return err
+	}
+
+	if message.Offset < 0 {
+		return nil
+	}
+
+	return s.consumerOffsetRepository.Save(ctx, message.Topic, message.Partition, message.Offset+1)
+}
+
+func (s InboundCommandService) handle(ctx context.Context, message adapters.BrokerMessage) error {
+	var command dtos.InboundCommand
+	if err := json.Unmarshal(message.Value, &command); err != nil || len(command.Id) == 0 || len(command.Type) == 0 {
+		reason := "command id and type are required"
+		if err != nil {
+			reason = err.Error()
+		}
+		log.Warnf("invalid inbound command. topic=%s, offset=%d, error=%s", message.Topic, message.Offset, reason)
+		return s.deadLetter(message, constants.DeadLetterReasonInvalidCommand, reason)
+	}
+
+	if _, err := s.inboundCommandRepository.FindByCommandId(ctx, command.Id); err == nil {
+		log.Debugf("inbound command(%s) is already handled", command.Id)
+		return nil
+	} else if !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+
+	entity := domain.NewInboundCommandEntity(message, command)
+	userClaim, reason, err := s.authorize(ctx, command)
+	if err != nil {
+		return err
+	}
+	if len(reason) > 0 {
+		entity.MarkRejected(reason)
+		if err := s.deadLetter(message, reason, reason); err != nil {
+			return err
+		}
+		return s.inboundCommandRepository.Create(ctx, &entity)
+	}
+
+	commandCtx := helpers.ContextHelper().SetUserClaim(ctx, userClaim)
+	handler := s.handlers[command.Type].handler
+	maxAttempts := config.Config.MessageBroker.Commands.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var handleErr error
+	attempts := 0
+	for attempts < maxAttempts {
+		attempts++
+		if handleErr = s.runHandler(commandCtx, handler, command); handleErr == nil {
+			break
+		}
+		log.Warnf("inbound command(%s) attempt %d error: %v", command.Id, attempts, handleErr)
+	}
+
+	if handleErr != nil {
+		entity.MarkDeadLettered(attempts, handleErr)
+		if err := s.deadLetter(message, constants.DeadLetterReasonMaxAttempts, handleErr.Error()); err != nil {
+			return err
+		}
+	} else {
+		entity.MarkProcessed(attempts)
+	}
+
+	return s.inboundCommandRepository.Create(ctx, &entity)
+}
+
+// authorize 는 명령을 보낸 서비스 계정을 찾아 명령에 필요한 권한이 있는지 확인한다. 실행할 수 없으면 거부 사유를 반환한다.
+func (s InboundCommandService) authorize(ctx context.Context, command dtos.InboundCommand) (userClaim *security.UserClaim, reason string, err error) {
+	registration, ok := s.handlers[command.Type]
+	if !ok {
+		return nil, constants.DeadLetterReasonUnknownCommand, nil
+	}
+
+	userClaim, err = s.serviceAccountService.GetServiceAccountUserClaim(ctx, command.Principal)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, constants.DeadLetterReasonUnknownPrincipal, nil
+		}
+		return nil, "", err
+	}
+
+	for _, permission := range userClaim.Permissions {
+		if permission == registration.permission {
+			return userClaim, "", nil
+		}
+	}
+
+	return nil, constants.DeadLetterReasonPermissionDenied, nil
+}
+
+// runHandler 는 명령을 savepoint 안에서 실행한다. 실패한 시도의 변경과 AfterCommit 함수는 버리고 성공한 시도의 것만 남긴다.
+func (s InboundCommandService) runHandler(ctx context.Context, handler CommandHandler, command dtos.InboundCommand) error {
+	attemptCtx := helpers.ContextHelper().SetAfterCommit(ctx)
+	err := helpers.ContextHelper().GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		return handler.Handle(helpers.ContextHelper().SetDB(attemptCtx, tx), command)
+	})
+	if err != nil {
+		return err
+	}
+
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		helpers.ContextHelper().RunAfterCommit(attemptCtx)
+	})
+	return nil
+}
+
+// deadLetter 는 받은 메시지를 그대로 데드 레터 토픽으로 보낸다. 보내지 못하면 에러를 반환하여 메시지를 다시 받는다.
+func (s InboundCommandService) deadLetter(message adapters.BrokerMessage, reason, errorMessage string) error {
+	headers := map[string]string{}
+	for key, value := range message.Headers {
+		headers[key] = value
+	}
+	headers["dlqReason"] = reason
+	headers["dlqError"] = errorMessage
+	headers["dlqSourceTopic"] = message.Topic
+
+	return adapters.MessageBrokerAdapter().Publish([]adapters.BrokerMessage{{
+		Topic:   config.Config.MessageBroker.Commands.DeadLetterTopic,
+		Key:     message.Key,
+		Value:   message.Value,
+		Headers: headers,
+	}})
+}
+
+// LoadOffset 은 파티션에서 다음에 읽을 offset 을 찾는다.
+func (s InboundCommandService) LoadOffset(ctx context.Context, topic string, partition int32) (int64, bool, error) {
+	entity, err := s.consumerOffsetRepository.FindByTopicAndPartition(ctx, topic, partition)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	return entity.NextOffset, true, nil
+}
+
+func (s InboundCommandService) GetInboundCommands(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.InboundCommandEntity, int64, error) {
+	return s.inboundCommandRepository.FindAll(ctx, filters, pageable)
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"context"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// licenseResources 는 라이선스로 수를 제한하는 대상이다. 라이선스 조회 결과의 순서이다.
+var licenseResources = []string{constants.LicenseResourceMembers, constants.LicenseResourceRoles, constants.LicenseResourceApiKeys}
+
+// LicenseService 는 라이선스 파일(config.Config.License)의 최대 수로 멤버, 역할, API Key 를 만들 수 있는지 확인한다.
+type LicenseService struct {
+	memberService          *MemberService
+	rbacService            *RoleBasedAccessControlService
+	serviceAccountService  *ServiceAccountService
+	messageTemplateService *MessageTemplateService
+}
+
+func NewLicenseService(memberService *MemberService,
+	rbacService *RoleBasedAccessControlService,
+	serviceAccountService *ServiceAccountService,
+	messageTemplateService *MessageTemplateService) *LicenseService {
+	return &LicenseService{
+		memberService:          memberService,
+		rbacService:            rbacService,
+		serviceAccountService:  serviceAccountService,
+		messageTemplateService: messageTemplateService,
+	}
+}
+
+// GetLicenseStatus 는 라이선스와 대상별 사용량을 조회한다. 라이선스를 읽을 수 없으면 오류 대신 Valid 를 false 로 응답한다.
+func (s LicenseService) GetLicenseStatus(ctx context.Context) (dtos.LicenseStatus, error) {
+	status := dtos.LicenseStatus{Configured: len(config.Config.License.FilePath) > 0, Quotas: make([]dtos.LicenseQuota, 0, len(licenseResources))}
+
+	license, err := s.getLicense()
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Valid = true
+	}
+
+	if license != nil {
+		status.Licensee = license.Licensee
+		if !license.IssuedAt.IsZero() {
+			status.IssuedAt = &license.IssuedAt
+		}
+		if !license.ExpiresAt.IsZero() {
+			status.ExpiresAt = &license.ExpiresAt
+		}
+	}
+
+	for _, resource := range licenseResources {
+		usage, err := s.countUsage(ctx, resource)
+		if err != nil {
+			return status, err
+		}
+
+		limit := licenseLimitOf(license, resource)
+		status.Quotas = append(status.Quotas, dtos.LicenseQuota{
+			Resource: resource,
+			Usage:    usage,
+			Limit:    limit,
+			Warning:  limit > 0 && usage >= licenseWarningThreshold(limit),
+		})
+	}
+
+	return status, nil
+}
+
+// CheckQuota 는 resource 를 하나 더 만들면 라이선스의 최대 수를 넘는지 확인한다.
+// 라이선스 파일을 설정했는데 읽을 수 없거나 만료되었으면 ErrInvalidLicense, 최대 수에 이르렀으면 ErrLicenseQuotaExceeded 이다.
+func (s LicenseService) CheckQuota(ctx context.Context, resource string) error {
+	license, err := s.getLicense()
+	if err != nil {
+		return err
+	}
+
+	limit := licenseLimitOf(license, resource)
+	if limit == 0 {
+		return nil
+	}
+
+	usage, err := s.countUsage(ctx, resource)
+	if err != nil {
+		return err
+	}
+
+	if usage >= limit {
+		return &errors.ErrLicenseQuotaExceeded{Resource: resource, Limit: limit}
+	}
+
+	return nil
+}
+
+// RecordQuotaUsage 는 resource 를 만든 뒤 사용량이 알림 기준이나 최대 수에 이르면 알림 역할(License.NotifyRoleName)의 멤버에게 알린다.
+// 이미 만들었으므로 오류는 기록만 한다.
+func (s LicenseService) RecordQuotaUsage(ctx context.Context, resource string) {
+	license, err := s.getLicense()
+	if err != nil || license == nil {
+		return
+	}
+
+	limit := licenseLimitOf(license, resource)
+	if limit == 0 {
+		return
+	}
+
+	usage, err := s.countUsage(ctx, resource)
+	if err != nil {
+		log.Error("license quota usage error: ", err)
+		return
+	}
+
+	// 기준을 넘을 때와 최대 수에 이를 때 한 번씩 알린다.
+	if usage != licenseWarningThreshold(limit) && usage != limit {
+		return
+	}
+
+	s.notifyQuotaWarning(ctx, *license, resource, usage, limit)
+}
+
+// getLicense 는 설정한 라이선스를 읽는다. 라이선스 파일을 설정하지 않았으면 nil 이다.
+// 만료된 라이선스는 내용과 함께 ErrInvalidLicense 를 반환한다.
+func (LicenseService) getLicense() (*security.License, error) {
+	license, err := adapters.LicenseAdapter().GetLicense()
+	if err != nil {
+		return nil, &errors.ErrInvalidLicense{Reason: err.Error()}
+	}
+
+	if license != nil && license.IsExpired(time.Now()) {
+		return license, &errors.ErrInvalidLicense{Reason: fmt.Sprintf("license expired at %s", license.ExpiresAt.Format(time.RFC3339))}
+	}
+
+	return license, nil
+}
+
+func (s LicenseService) countUsage(ctx context.Context, resource string) (int64, error) {
+	switch resource {
+	case constants.LicenseResourceMembers:
+		return s.memberService.CountMembers(ctx)
+	case constants.LicenseResourceRoles:
+		return s.rbacService.CountRoles(ctx)
+	case constants.LicenseResourceApiKeys:
+		return s.serviceAccountService.CountApiKeys(ctx)
+	}
+
+	return 0, pkgerrors.Errorf("unknown license resource: %s", resource)
+}
+
+func (s LicenseService) notifyQuotaWarning(ctx context.Context, license security.License, resource string, usage, limit int64) {
+	roleName := config.Config.License.NotifyRoleName
+	if len(roleName) == 0 {
+		return
+	}
+
+	members, err := s.memberService.GetMembersByRoleName(ctx, roleName)
+	if err != nil {
+		log.Error("license quota notification error: ", err)
+		return
+	}
+
+	for _, member := range members {
+		email := member.GetEmail()
+		if len(email) == 0 {
+			continue
+		}
+
+		if err := adapters.MailAdapter().Send(s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateLicenseQuotaWarning, []string{email}, map[string]string{
+			"resource": resource,
+			"usage":    fmt.Sprint(usage),
+			"limit":    fmt.Sprint(limit),
+			"licensee": license.Licensee,
+		})); err != nil {
+			log.Error("license quota notification error: ", err)
+		}
+	}
+}
+
+// licenseLimitOf 는 라이선스의 resource 최대 수이다. 라이선스가 없으면 0(제한하지 않음)이다.
+func licenseLimitOf(license *security.License, resource string) int64 {
+	if license == nil {
+		return 0
+	}
+
+	switch resource {
+	case constants.LicenseResourceMembers:
+		return license.MaxMembers
+	case constants.LicenseResourceRoles:
+		return license.MaxRoles
+	case constants.LicenseResourceApiKeys:
+		return license.MaxApiKeys
+	}
+
+	return 0
+}
+
+// licenseWarningThreshold 는 알림을 보낼 사용량이다. 최대 수의 WarningPercent % 를 올림하고 1 ~ 최대 수 사이로 맞춘다.
+func licenseWarningThreshold(limit int64) int64 {
+	percent := int64(config.Config.License.WarningPercent)
+	if percent <= 0 || percent > 100 {
+		percent = 100
+	}
+
+	threshold := (limit*percent + 99) / 100
+	if threshold < 1 {
+		threshold = 1
+	}
+
+	return threshold
+}
+
+func checkQuota(ctx context.Context, guard QuotaGuard, resource string) error {
+	if guard == nil {
+		return nil
+	}
+
+	return guard.CheckQuota(ctx, resource)
+}
+
+func recordQuotaUsage(ctx context.Context, guard QuotaGuard, resource string) {
+	if guard == nil {
+		return
+	}
+
+	guard.RecordQuotaUsage(ctx, resource)
+}
@@ -0,0 +1,273 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/session/domain"
+	"better-admin-backend-service/session/repository"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// 앱 내 알림으로 보여줄 최근 알림 수
+const recentLoginNotificationCount = 20
+
+type LoginNotificationService struct {
+	loginDeviceRepository       *repository.LoginDeviceRepository
+	loginNotificationRepository *repository.LoginNotificationRepository
+	siteService                 *SiteService
+	memberService               *MemberService
+	sessionService              *SessionService
+	auditService                *AuditService
+	// 멤버가 본인이 아니라고 신고한 로그인을 보안 이벤트(anomaly)로 기록한다.
+	securityEventService   *SecurityEventService
+	messageTemplateService *MessageTemplateService
+}
+
+func NewLoginNotificationService(
+	loginDeviceRepository *repository.LoginDeviceRepository,
+	loginNotificationRepository *repository.LoginNotificationRepository,
+	siteService *SiteService,
+	memberService *MemberService,
+	sessionService *SessionService,
+	auditService *AuditService,
+	securityEventService *SecurityEventService,
+	messageTemplateService *MessageTemplateService) *LoginNotificationService {
+
+	return &LoginNotificationService{
+		loginDeviceRepository:       loginDeviceRepository,
+		loginNotificationRepository: loginNotificationRepository,
+		siteService:                 siteService,
+		memberService:               memberService,
+		sessionService:              sessionService,
+		auditService:                auditService,
+		securityEventService:        securityEventService,
+		messageTemplateService:      messageTemplateService,
+	}
+}
+
+// CheckLoginDevice 는 로그인한 기기(클라이언트 지문)와 위치(국가)를 기록하고, 처음 보는 기기나 위치이면 멤버에게 알린다.
+// 알림을 켠 뒤 처음 로그인한 기기는 비교할 기록이 없으므로 기록만 한다.
+func (s LoginNotificationService) CheckLoginDevice(ctx context.Context, memberEntity memberDomain.MemberEntity, session domain.MemberSessionEntity) error {
+	setting, err := s.GetLoginNotificationSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	clientFingerprint := helpers.ContextHelper().GetClientFingerprint(ctx)
+	if !setting.Enabled || len(clientFingerprint) == 0 {
+		return nil
+	}
+
+	ipAddress := helpers.ContextHelper().GetClientIp(ctx)
+	location := adapters.GeoIpAdapter().Lookup(ipAddress)
+
+	devices, err := s.loginDeviceRepository.FindByMemberId(ctx, memberEntity.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range devices {
+		if devices[i].ClientFingerprint == clientFingerprint && devices[i].Country == location.Country {
+			devices[i].UpdateLastLogin(ipAddress)
+			return s.loginDeviceRepository.Save(ctx, &devices[i])
+		}
+	}
+
+	device := domain.NewLoginDeviceEntity(memberEntity.ID, clientFingerprint, location.Country, ipAddress)
+	if err := s.loginDeviceRepository.Create(ctx, &device); err != nil {
+		return err
+	}
+
+	if len(devices) == 0 {
+		return nil
+	}
+
+	entity, reportToken, err := domain.NewLoginNotificationEntity(session, location, ipAddress, s.getTokenLifetime())
+	if err != nil {
+		return err
+	}
+
+	if err := s.loginNotificationRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+
+	email := memberEntity.GetEmail()
+	if !setting.HasChannel(constants.LoginNotificationChannelMail) || len(email) == 0 {
+		return nil
+	}
+
+	message := s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateLoginNewDevice, []string{email}, map[string]string{
+		"ipAddress":  entity.IpAddress,
+		"country":    entity.Country,
+		"city":       entity.City,
+		"loggedInAt": entity.CreatedAt.Format(time.RFC3339),
+		"reportUrl":  fmt.Sprintf(config.Config.LoginNotification.ReportUrl, reportToken),
+	})
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		if err := adapters.MailAdapter().Send(message); err != nil {
+			log.Error("login notification error: ", err)
+		}
+	})
+
+	return nil
+}
+
+// GetMyLoginNotifications 는 로그인한 멤버의 최근 알림(앱 내 알림)이다. 앱 내 알림(in-app)을 사용하지 않으면 비어 있다.
+func (s LoginNotificationService) GetMyLoginNotifications(ctx context.Context) ([]domain.LoginNotificationEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	setting, err := s.GetLoginNotificationSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !setting.Enabled || !setting.HasChannel(constants.LoginNotificationChannelInApp) {
+		return make([]domain.LoginNotificationEntity, 0), nil
+	}
+
+	return s.loginNotificationRepository.FindRecentByMemberId(ctx, userClaim.Id, recentLoginNotificationCount)
+}
+
+// ReportLogin 은 알림 메일의 "본인이 아닙니다" 링크로 받은 토큰으로 로그인을 신고한다.
+func (s LoginNotificationService) ReportLogin(ctx context.Context, token string) error {
+	entity, err := s.loginNotificationRepository.FindByReportTokenHash(ctx, domain.HashLoginNotificationToken(token))
+	if err != nil {
+		return err
+	}
+
+	return s.report(ctx, entity)
+}
+
+// ReportMyLogin 은 로그인한 멤버가 앱 내 알림에서 로그인을 신고한다. 서비스 계정은 신고할 수 없다(ErrAuthentication).
+func (s LoginNotificationService) ReportMyLogin(ctx context.Context, id uint) error {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	entity, err := s.loginNotificationRepository.FindByIdAndMemberId(ctx, id, userClaim.Id)
+	if err != nil {
+		return err
+	}
+
+	return s.report(ctx, entity)
+}
+
+// report 는 멤버의 모든 세션을 종료하고 비밀번호를 다시 설정할 때까지 비밀번호 로그인을 막는다.
+// 비밀번호 재설정 링크는 앱 내 알림이 아닌 메일로만 보내므로 신고한 사람이 비밀번호를 바꿀 수는 없다.
+func (s LoginNotificationService) report(ctx context.Context, entity domain.LoginNotificationEntity) error {
+	resetToken, err := entity.Report(s.getTokenLifetime())
+	if err != nil {
+		return err
+	}
+
+	if err := s.loginNotificationRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, entity.MemberId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionService.RevokeMemberSessions(ctx, memberEntity.ID, constants.SessionRevokedReasonLoginReported); err != nil {
+		return err
+	}
+
+	if err := s.memberService.RequirePasswordReset(ctx, memberEntity.ID); err != nil {
+		return err
+	}
+
+	detail := fmt.Sprintf("loginNotificationId=%v, sessionId=%v, ip=%v, country=%v", entity.ID, entity.SessionId, entity.IpAddress, entity.Country)
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionLoginReported, constants.AuditTargetTypeMember, memberEntity.ID, detail); err != nil {
+		return err
+	}
+
+	if err := s.securityEventService.RecordSecurityEvent(ctx, constants.SecurityEventTypeAnomaly, memberEntity.ID,
+		"login reported by member. "+detail); err != nil {
+		return err
+	}
+
+	email := memberEntity.GetEmail()
+	if memberEntity.Type != constants.TypeMemberSite || len(email) == 0 {
+		return nil
+	}
+
+	message := s.messageTemplateService.RenderMail(ctx, constants.MessageTemplatePasswordReset, []string{email}, map[string]string{
+		"resetUrl": fmt.Sprintf(config.Config.LoginNotification.PasswordResetUrl, resetToken),
+	})
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		if err := adapters.MailAdapter().Send(message); err != nil {
+			log.Error("login notification error: ", err)
+		}
+	})
+
+	return nil
+}
+
+// ResetPassword 는 신고한 뒤 메일로 받은 토큰으로 비밀번호를 다시 설정하고 비밀번호 로그인을 다시 허용한다.
+func (s LoginNotificationService) ResetPassword(ctx context.Context, passwordReset dtos.PasswordReset) error {
+	entity, err := s.loginNotificationRepository.FindByResetTokenHash(ctx, domain.HashLoginNotificationToken(passwordReset.Token))
+	if err != nil {
+		return err
+	}
+
+	if err := entity.ResetPassword(); err != nil {
+		return err
+	}
+
+	if err := s.memberService.ResetPassword(ctx, entity.MemberId, passwordReset.Password); err != nil {
+		return err
+	}
+
+	if err := s.loginNotificationRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionPasswordReset, constants.AuditTargetTypeMember, entity.MemberId,
+		fmt.Sprintf("loginNotificationId=%v", entity.ID))
+}
+
+// GetLoginNotificationSetting 은 새 기기 로그인 알림 설정을 반환한다. 설정하지 않았으면 알리지 않는다.
+func (s LoginNotificationService) GetLoginNotificationSetting(ctx context.Context) (dtos.LoginNotificationSetting, error) {
+	loginNotificationSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyLoginNotification)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.LoginNotificationSetting{
+				Channels: []string{constants.LoginNotificationChannelMail, constants.LoginNotificationChannelInApp},
+			}, nil
+		}
+		return dtos.LoginNotificationSetting{}, err
+	}
+
+	var setting dtos.LoginNotificationSetting
+	if err = mapstructure.Decode(loginNotificationSetting, &setting); err != nil {
+		return dtos.LoginNotificationSetting{}, err
+	}
+
+	if setting.Channels == nil {
+		setting.Channels = make([]string, 0)
+	}
+
+	return setting, nil
+}
+
+func (s LoginNotificationService) SetLoginNotificationSetting(ctx context.Context, setting dtos.LoginNotificationSetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyLoginNotification, setting)
+}
+
+func (LoginNotificationService) getTokenLifetime() time.Duration {
+	return time.Duration(config.Config.LoginNotification.TokenLifetimeMinutes) * time.Minute
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"context"
+	"github.com/mitchellh/mapstructure"
+	"strings"
+)
+
+var loginMethodLabels = map[string]string{
+	constants.LoginMethodPassword:        "아이디/비밀번호",
+	constants.LoginMethodDooray:          "두레이",
+	constants.LoginMethodGoogleWorkspace: "Google Workspace",
+	constants.LoginMethodSaml:            "SAML",
+	constants.LoginMethodLdap:            "LDAP",
+	constants.LoginMethodPasskey:         "패스키",
+}
+
+type LoginSettingService struct {
+	siteService            *SiteService
+	googleWorkspaceService *GoogleWorkspaceService
+}
+
+func NewLoginSettingService(siteService *SiteService, googleWorkspaceService *GoogleWorkspaceService) *LoginSettingService {
+	return &LoginSettingService{
+		siteService:            siteService,
+		googleWorkspaceService: googleWorkspaceService,
+	}
+}
+
+// GetLoginSetting 은 로그인 방법 설정을 반환한다. 설정하지 않았으면 아이디/비밀번호, 두레이, Google Workspace 순서로 사용한다.
+func (s LoginSettingService) GetLoginSetting(ctx context.Context) (dtos.LoginSetting, error) {
+	loginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyLogin)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return s.newDefaultLoginSetting(), nil
+		}
+		return dtos.LoginSetting{}, err
+	}
+
+	var setting dtos.LoginSetting
+	if err = mapstructure.Decode(loginSetting, &setting); err != nil {
+		return dtos.LoginSetting{}, err
+	}
+
+	if setting.Methods == nil {
+		setting.Methods = make([]dtos.LoginMethodSetting, 0)
+	}
+
+	return setting, nil
+}
+
+func (s LoginSettingService) SetLoginSetting(ctx context.Context, setting dtos.LoginSetting) error {
+	if err := s.validate(setting); err != nil {
+		return err
+	}
+
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyLogin, setting)
+}
+
+// GetLoginPage 는 로그인 화면에 보여줄 로그인 방법을 순서대로 반환한다.
+// 두레이, Google Workspace 는 각 로그인 설정을 사용하는 경우에만, saml, ldap, passkey 는 외부 인증이 등록된 경우에만 포함한다.
+func (s LoginSettingService) GetLoginPage(ctx context.Context) (dtos.LoginPage, error) {
+	setting, err := s.GetLoginSetting(ctx)
+	if err != nil {
+		return dtos.LoginPage{}, err
+	}
+
+	loginPage := dtos.LoginPage{
+		Methods:         make([]dtos.LoginPageMethod, 0),
+		DefaultRedirect: setting.DefaultRedirect,
+	}
+	for _, method := range setting.Methods {
+		if method.Enabled == nil || !*method.Enabled {
+			continue
+		}
+
+		pageMethod := dtos.LoginPageMethod{
+			Type:  method.Type,
+			Label: method.Label,
+		}
+		if len(pageMethod.Label) == 0 {
+			pageMethod.Label = loginMethodLabels[method.Type]
+		}
+
+		switch method.Type {
+		case constants.LoginMethodPassword:
+			pageMethod.Endpoint = "/api/auth"
+		case constants.LoginMethodDooray:
+			used, err := s.isDoorayLoginUsed(ctx)
+			if err != nil {
+				return dtos.LoginPage{}, err
+			}
+			if !used {
+				continue
+			}
+			pageMethod.Endpoint = "/api/auth/dooray"
+		case constants.LoginMethodGoogleWorkspace:
+			googleWorkspaceSetting, err := s.getGoogleWorkspaceLoginSetting(ctx)
+			if err != nil {
+				return dtos.LoginPage{}, err
+			}
+			if googleWorkspaceSetting.Used == nil || !*googleWorkspaceSetting.Used {
+				continue
+			}
+			pageMethod.Endpoint = "/api/auth/google-workspace"
+			if pageMethod.OAuthUri, err = s.googleWorkspaceService.GetOAuthUri(ctx, googleWorkspaceSetting); err != nil {
+				return dtos.LoginPage{}, err
+			}
+		default:
+			if _, ok := getAuthenticator(method.Authenticator); !ok {
+				continue
+			}
+			pageMethod.Endpoint = "/api/auth/custom/" + method.Authenticator
+		}
+		loginPage.Methods = append(loginPage.Methods, pageMethod)
+	}
+
+	return loginPage, nil
+}
+
+func (s LoginSettingService) validate(setting dtos.LoginSetting) error {
+	enabledCount := 0
+	methodTypes := make(map[string]bool)
+	for _, method := range setting.Methods {
+		if methodTypes[method.Type] {
+			return &errors.ErrInvalidLoginSetting{Reason: "duplicated login method: " + method.Type}
+		}
+		methodTypes[method.Type] = true
+
+		if !*method.Enabled {
+			continue
+		}
+		enabledCount++
+
+		switch method.Type {
+		case constants.LoginMethodSaml, constants.LoginMethodLdap, constants.LoginMethodPasskey:
+			if _, ok := getAuthenticator(method.Authenticator); !ok {
+				return &errors.ErrInvalidLoginSetting{Reason: "authenticator not registered: " + method.Type}
+			}
+		}
+	}
+
+	if enabledCount == 0 {
+		return &errors.ErrInvalidLoginSetting{Reason: "at least one login method must be enabled"}
+	}
+
+	// 다른 사이트로 이동시키지 않도록 경로만 허용한다.
+	redirect := setting.DefaultRedirect
+	if len(redirect) > 0 && (!strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.Contains(redirect, "\\")) {
+		return &errors.ErrInvalidLoginSetting{Reason: "default redirect must be a path"}
+	}
+
+	return nil
+}
+
+func (s LoginSettingService) isDoorayLoginUsed(ctx context.Context) (bool, error) {
+	doorayLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyDoorayLogin)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var setting dtos.DoorayLoginSetting
+	if err = mapstructure.Decode(doorayLoginSetting, &setting); err != nil {
+		return false, err
+	}
+
+	return setting.Used != nil && *setting.Used, nil
+}
+
+func (s LoginSettingService) getGoogleWorkspaceLoginSetting(ctx context.Context) (dtos.GoogleWorkspaceLoginSetting, error) {
+	googleWorkspaceLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.GoogleWorkspaceLoginSetting{}, nil
+		}
+		return dtos.GoogleWorkspaceLoginSetting{}, err
+	}
+
+	var setting dtos.GoogleWorkspaceLoginSetting
+	if err = mapstructure.Decode(googleWorkspaceLoginSetting, &setting); err != nil {
+		return dtos.GoogleWorkspaceLoginSetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s LoginSettingService) newDefaultLoginSetting() dtos.LoginSetting {
+	enabled := true
+	methods := make([]dtos.LoginMethodSetting, 0)
+	for _, methodType := range []string{constants.LoginMethodPassword, constants.LoginMethodDooray, constants.LoginMethodGoogleWorkspace} {
+		methods = append(methods, dtos.LoginMethodSetting{
+			Type:    methodType,
+			Enabled: &enabled,
+			Label:   loginMethodLabels[methodType],
+		})
+	}
+
+	return dtos.LoginSetting{Methods: methods}
+}
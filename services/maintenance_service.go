@@ -0,0 +1,106 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"context"
+	"github.com/mitchellh/mapstructure"
+	"time"
+)
+
+const defaultMaintenanceMessage = "서비스 점검 중입니다."
+
+type MaintenanceService struct {
+	siteService *SiteService
+}
+
+func NewMaintenanceService(siteService *SiteService) *MaintenanceService {
+	return &MaintenanceService{
+		siteService: siteService,
+	}
+}
+
+func (s MaintenanceService) GetMaintenanceSetting(ctx context.Context) (dtos.MaintenanceSetting, error) {
+	maintenanceSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyMaintenance)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.MaintenanceSetting{Windows: make([]dtos.MaintenanceWindow, 0)}, nil
+		}
+		return dtos.MaintenanceSetting{}, err
+	}
+
+	var setting dtos.MaintenanceSetting
+	if err = mapstructure.Decode(maintenanceSetting, &setting); err != nil {
+		return dtos.MaintenanceSetting{}, err
+	}
+
+	if setting.Windows == nil {
+		setting.Windows = make([]dtos.MaintenanceWindow, 0)
+	}
+
+	return setting, nil
+}
+
+func (s MaintenanceService) SetMaintenanceSetting(ctx context.Context, setting dtos.MaintenanceSetting) error {
+	for _, window := range setting.Windows {
+		startAt, endAt := parseMaintenanceWindow(window)
+		if !endAt.After(startAt) {
+			return errors.ErrInvalidPeriod
+		}
+	}
+
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyMaintenance, setting)
+}
+
+// GetMaintenanceStatus 는 현재 점검 모드인지와 앞으로의 점검 일정을 반환한다.
+// Retry-After 가 설정되지 않은 경우 점검 일정의 종료 시각까지 남은 시간을 사용한다.
+func (s MaintenanceService) GetMaintenanceStatus(ctx context.Context) (dtos.MaintenanceStatus, error) {
+	setting, err := s.GetMaintenanceSetting(ctx)
+	if err != nil {
+		return dtos.MaintenanceStatus{}, err
+	}
+
+	now := time.Now()
+	status := dtos.MaintenanceStatus{
+		Active:            setting.Enabled,
+		Message:           setting.Message,
+		RetryAfterSeconds: setting.RetryAfterSeconds,
+		UpcomingWindows:   make([]dtos.MaintenanceWindow, 0),
+	}
+
+	for _, window := range setting.Windows {
+		startAt, endAt := parseMaintenanceWindow(window)
+		if now.After(endAt) {
+			continue
+		}
+
+		if now.Before(startAt) {
+			status.UpcomingWindows = append(status.UpcomingWindows, window)
+			continue
+		}
+
+		if !status.Active {
+			status.Active = true
+			if len(window.Message) > 0 {
+				status.Message = window.Message
+			}
+			if status.RetryAfterSeconds == 0 {
+				status.RetryAfterSeconds = int(endAt.Sub(now).Seconds()) + 1
+			}
+		}
+	}
+
+	if status.Active && len(status.Message) == 0 {
+		status.Message = defaultMaintenanceMessage
+	}
+
+	return status, nil
+}
+
+// parseMaintenanceWindow 는 입력 시 형식을 검증하므로 파싱 오류는 무시한다.
+func parseMaintenanceWindow(window dtos.MaintenanceWindow) (time.Time, time.Time) {
+	startAt, _ := time.Parse(time.RFC3339, window.StartAt)
+	endAt, _ := time.Parse(time.RFC3339, window.EndAt)
+	return startAt, endAt
+}
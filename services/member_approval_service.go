@@ -0,0 +1,455 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/member/domain"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	"strings"
+)
+
+// MemberApprovalService 는 가입 신청(applied)을 일괄 승인하고 기본 역할을 할당한다.
+// 멤버마다 결과를 모아 반환하고, 감사 로그는 요청마다 하나만 남긴다.
+// 메일 도메인 규칙(auto-approval)에 맞는 가입 신청과 SSO 멤버는 가입할 때 자동으로 승인한다.
+type MemberApprovalService struct {
+	siteService     *SiteService
+	rbacService     *RoleBasedAccessControlService
+	memberService   *MemberService
+	approvalService *ApprovalService
+	auditService    *AuditService
+}
+
+func NewMemberApprovalService(siteService *SiteService,
+	rbacService *RoleBasedAccessControlService,
+	memberService *MemberService,
+	approvalService *ApprovalService,
+	auditService *AuditService) *MemberApprovalService {
+	return &MemberApprovalService{
+		siteService:     siteService,
+		rbacService:     rbacService,
+		memberService:   memberService,
+		approvalService: approvalService,
+		auditService:    auditService,
+	}
+}
+
+func (s MemberApprovalService) GetMemberApprovalSetting(ctx context.Context) (dtos.MemberApprovalSetting, error) {
+	memberApprovalSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyMemberApproval)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.MemberApprovalSetting{DefaultRoleIds: make([]uint, 0)}, nil
+		}
+		return dtos.MemberApprovalSetting{}, err
+	}
+
+	var setting dtos.MemberApprovalSetting
+	if err = mapstructure.Decode(memberApprovalSetting, &setting); err != nil {
+		return dtos.MemberApprovalSetting{}, err
+	}
+
+	if setting.DefaultRoleIds == nil {
+		setting.DefaultRoleIds = make([]uint, 0)
+	}
+
+	return setting, nil
+}
+
+// SetMemberApprovalSetting 은 기본 역할을 저장한다. 없는 역할이 있으면 ErrNotFound 를 반환한다.
+func (s MemberApprovalService) SetMemberApprovalSetting(ctx context.Context, setting dtos.MemberApprovalSetting) error {
+	roleEntities, err := s.findDefaultRoles(ctx, setting)
+	if err != nil {
+		return err
+	}
+
+	if len(roleEntities) != len(uniqueIds(setting.DefaultRoleIds)) {
+		return errors.ErrNotFound
+	}
+
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyMemberApproval, setting)
+}
+
+// ApproveMembers 는 대상 멤버를 승인하고 기본 역할을 추가한다. 기존 역할은 그대로 둔다.
+// 역할 할당(role-grant)에 승인 절차가 있으면 기본 역할을 일괄로 할당할 수 없으므로 ErrApprovalRequired 를 반환한다.
+func (s MemberApprovalService) ApproveMembers(ctx context.Context, request dtos.MemberBulkApproval) (dtos.MemberBulkApprovalResult, error) {
+	setting, err := s.GetMemberApprovalSetting(ctx)
+	if err != nil {
+		return dtos.MemberBulkApprovalResult{}, err
+	}
+
+	defaultRoles, err := s.findDefaultRoles(ctx, setting)
+	if err != nil {
+		return dtos.MemberBulkApprovalResult{}, err
+	}
+
+	if len(defaultRoles) > 0 {
+		workflowSetting, err := s.approvalService.GetWorkflowSetting(ctx)
+		if err != nil {
+			return dtos.MemberBulkApprovalResult{}, err
+		}
+
+		if _, ok := workflowSetting.FindWorkflow(constants.ApprovalSubjectRoleGrant); ok {
+			return dtos.MemberBulkApprovalResult{}, errors.ErrApprovalRequired
+		}
+	}
+
+	result := dtos.MemberBulkApprovalResult{
+		DefaultRoles: make([]string, 0, len(defaultRoles)),
+		Results:      make([]dtos.MemberBulkApprovalMember, 0),
+	}
+	for _, role := range defaultRoles {
+		result.DefaultRoles = append(result.DefaultRoles, role.Name)
+	}
+
+	memberEntities, err := s.findMembers(ctx, request, &result)
+	if err != nil {
+		return dtos.MemberBulkApprovalResult{}, err
+	}
+
+	approvedMemberIds := make([]uint, 0, len(memberEntities))
+	for _, memberEntity := range memberEntities {
+		reason, err := s.approve(ctx, memberEntity, defaultRoles)
+		if err != nil {
+			return dtos.MemberBulkApprovalResult{}, err
+		}
+
+		if len(reason) > 0 {
+			result.Results = append(result.Results, dtos.MemberBulkApprovalMember{
+				MemberId: memberEntity.ID, Status: constants.MemberBulkApprovalFailed, Reason: reason,
+			})
+			continue
+		}
+
+		approvedMemberIds = append(approvedMemberIds, memberEntity.ID)
+		result.Results = append(result.Results, dtos.MemberBulkApprovalMember{
+			MemberId: memberEntity.ID, Status: constants.MemberBulkApprovalApproved,
+		})
+	}
+	result.Succeeded = len(approvedMemberIds)
+
+	detail, err := json.Marshal(result)
+	if err != nil {
+		return dtos.MemberBulkApprovalResult{}, err
+	}
+
+	if err := s.auditService.RecordBatchAuditLog(ctx, constants.AuditActionMembersBulkApproved, constants.AuditTargetTypeMember,
+		approvedMemberIds, string(detail)); err != nil {
+		return dtos.MemberBulkApprovalResult{}, err
+	}
+
+	return result, nil
+}
+
+func (s MemberApprovalService) GetAutoApprovalSetting(ctx context.Context) (dtos.AutoApprovalSetting, error) {
+	autoApprovalSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyAutoApproval)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.AutoApprovalSetting{Rules: make([]dtos.AutoApprovalRule, 0)}, nil
+		}
+		return dtos.AutoApprovalSetting{}, err
+	}
+
+	var setting dtos.AutoApprovalSetting
+	if err = mapstructure.Decode(autoApprovalSetting, &setting); err != nil {
+		return dtos.AutoApprovalSetting{}, err
+	}
+
+	if setting.Rules == nil {
+		setting.Rules = make([]dtos.AutoApprovalRule, 0)
+	}
+
+	return setting, nil
+}
+
+// SetAutoApprovalSetting 은 도메인을 소문자로 바꾸어 저장한다. 도메인이 중복되거나 없는 역할이 있으면 ErrInvalidAutoApprovalRule 을 반환한다.
+func (s MemberApprovalService) SetAutoApprovalSetting(ctx context.Context, setting dtos.AutoApprovalSetting) error {
+	rules, err := s.validateAutoApprovalRules(ctx, setting.Rules)
+	if err != nil {
+		return err
+	}
+	setting.Rules = rules
+
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyAutoApproval, setting)
+}
+
+// AutoApproveSignUp 은 가입 신청한 멤버가 규칙에 맞으면 승인하고 기본 역할을 할당한다. 승인하면 true 이다.
+func (s MemberApprovalService) AutoApproveSignUp(ctx context.Context, memberEntity domain.MemberEntity) (bool, error) {
+	setting, err := s.GetAutoApprovalSetting(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !setting.Enabled {
+		return false, nil
+	}
+
+	rule, roleEntities, matched, err := s.findAutoApprovalRule(ctx, setting, memberEntity)
+	if err != nil || !matched {
+		return false, err
+	}
+
+	if err := s.memberService.AutoApproveMember(ctx, memberEntity.ID, roleEntities); err != nil {
+		return false, err
+	}
+
+	return true, s.RecordAutoApproval(ctx, memberEntity.ID, rule)
+}
+
+// ApplyAutoApproval 은 SSO 로 처음 로그인하여 아직 저장하지 않은 멤버에게 규칙을 적용한다.
+// 규칙에 맞으면 기본 역할을 추가하고, 맞지 않으면 승인 대기로 둔다. 규칙을 사용하지 않으면 멤버를 바꾸지 않는다.
+func (s MemberApprovalService) ApplyAutoApproval(ctx context.Context, memberEntity *domain.MemberEntity) (dtos.AutoApprovalRule, bool, error) {
+	setting, err := s.GetAutoApprovalSetting(ctx)
+	if err != nil {
+		return dtos.AutoApprovalRule{}, false, err
+	}
+
+	if !setting.Enabled {
+		return dtos.AutoApprovalRule{}, false, nil
+	}
+
+	rule, roleEntities, matched, err := s.findAutoApprovalRule(ctx, setting, *memberEntity)
+	if err != nil {
+		return dtos.AutoApprovalRule{}, false, err
+	}
+
+	if !matched {
+		memberEntity.WaitForApproval()
+		return dtos.AutoApprovalRule{}, false, nil
+	}
+
+	memberEntity.AutoApprove(roleEntities)
+	return rule, true, nil
+}
+
+func (s MemberApprovalService) RecordAutoApproval(ctx context.Context, memberId uint, rule dtos.AutoApprovalRule) error {
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberAutoApproved, constants.AuditTargetTypeMember, memberId,
+		fmt.Sprintf("domain=%v, defaultRoleIds=%v", rule.Domain, rule.DefaultRoleIds))
+}
+
+// DryRunAutoApproval 은 규칙을 승인 대기 멤버에게 적용하면 누가 승인되는지 미리 보여준다. 실제로 승인하지는 않는다.
+// 규칙 사용 여부(Enabled)와 상관없이 평가한다.
+func (s MemberApprovalService) DryRunAutoApproval(ctx context.Context, dryRun dtos.AutoApprovalDryRun) (dtos.AutoApprovalDryRunResult, error) {
+	setting, err := s.GetAutoApprovalSetting(ctx)
+	if err != nil {
+		return dtos.AutoApprovalDryRunResult{}, err
+	}
+
+	if len(dryRun.Rules) > 0 {
+		if setting.Rules, err = s.validateAutoApprovalRules(ctx, dryRun.Rules); err != nil {
+			return dtos.AutoApprovalDryRunResult{}, err
+		}
+	}
+
+	filters := map[string]interface{}{}
+	filters["status"] = constants.StatusMemberApplied
+	memberEntities, _, err := s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+	if err != nil {
+		return dtos.AutoApprovalDryRunResult{}, err
+	}
+
+	result := dtos.AutoApprovalDryRunResult{
+		Evaluated: len(memberEntities),
+		Results:   make([]dtos.AutoApprovalDryRunMember, 0),
+	}
+
+	for _, memberEntity := range memberEntities {
+		rule, _, matched, err := s.findAutoApprovalRule(ctx, setting, memberEntity)
+		if err != nil {
+			return dtos.AutoApprovalDryRunResult{}, err
+		}
+
+		if !matched {
+			continue
+		}
+
+		result.Results = append(result.Results, dtos.AutoApprovalDryRunMember{
+			MemberId:       memberEntity.ID,
+			Name:           memberEntity.Name,
+			Email:          memberEntity.GetEmail(),
+			Domain:         rule.Domain,
+			DefaultRoleIds: rule.DefaultRoleIds,
+		})
+	}
+	result.Matched = len(result.Results)
+
+	return result, nil
+}
+
+// findAutoApprovalRule 은 멤버의 메일 도메인에 맞는 규칙과 할당할 역할을 찾는다.
+// 역할 할당(role-grant)에 승인 절차가 있으면 기본 역할을 바로 할당할 수 없으므로 역할이 있는 규칙은 맞지 않는 것으로 본다.
+func (s MemberApprovalService) findAutoApprovalRule(ctx context.Context, setting dtos.AutoApprovalSetting, memberEntity domain.MemberEntity) (dtos.AutoApprovalRule, []rbacDomain.RoleEntity, bool, error) {
+	rule, matched := setting.FindRule(memberEntity.GetEmail())
+	if !matched {
+		return dtos.AutoApprovalRule{}, nil, false, nil
+	}
+
+	roleEntities, err := s.findDefaultRoles(ctx, dtos.MemberApprovalSetting{DefaultRoleIds: rule.DefaultRoleIds})
+	if err != nil {
+		return dtos.AutoApprovalRule{}, nil, false, err
+	}
+
+	if len(roleEntities) > 0 {
+		workflowSetting, err := s.approvalService.GetWorkflowSetting(ctx)
+		if err != nil {
+			return dtos.AutoApprovalRule{}, nil, false, err
+		}
+
+		if _, ok := workflowSetting.FindWorkflow(constants.ApprovalSubjectRoleGrant); ok {
+			return dtos.AutoApprovalRule{}, nil, false, nil
+		}
+	}
+
+	return rule, roleEntities, true, nil
+}
+
+func (s MemberApprovalService) validateAutoApprovalRules(ctx context.Context, rules []dtos.AutoApprovalRule) ([]dtos.AutoApprovalRule, error) {
+	validated := make([]dtos.AutoApprovalRule, 0, len(rules))
+	domains := map[string]bool{}
+	for _, rule := range rules {
+		domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(rule.Domain), "@"))
+		if len(domain) == 0 || strings.ContainsAny(domain, "@ ") || !strings.Contains(domain, ".") {
+			return nil, &errors.ErrInvalidAutoApprovalRule{Reason: fmt.Sprintf("invalid domain %v", rule.Domain)}
+		}
+
+		if domains[domain] {
+			return nil, &errors.ErrInvalidAutoApprovalRule{Reason: fmt.Sprintf("duplicated domain %v", domain)}
+		}
+		domains[domain] = true
+
+		roleIds := uniqueIds(rule.DefaultRoleIds)
+		roleEntities, err := s.findDefaultRoles(ctx, dtos.MemberApprovalSetting{DefaultRoleIds: roleIds})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(roleEntities) != len(roleIds) {
+			return nil, &errors.ErrInvalidAutoApprovalRule{Reason: fmt.Sprintf("role not found in domain %v", domain)}
+		}
+
+		validated = append(validated, dtos.AutoApprovalRule{Domain: domain, DefaultRoleIds: roleIds})
+	}
+
+	return validated, nil
+}
+
+// approve 는 멤버 한 명을 승인한다. 승인하지 못하면 사유를 반환한다.
+func (s MemberApprovalService) approve(ctx context.Context, memberEntity domain.MemberEntity, defaultRoles []rbacDomain.RoleEntity) (string, error) {
+	if memberEntity.IsApproved() {
+		return constants.MemberBulkApprovalFailureAlreadyApproved, nil
+	}
+
+	pending, err := s.approvalService.HasPendingRequest(ctx, constants.ApprovalSubjectMemberSignUp, memberEntity.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if pending {
+		return constants.MemberBulkApprovalFailureApprovalProgress, nil
+	}
+
+	roleIds := memberEntity.GetRoleIds()
+	added := false
+	for _, role := range defaultRoles {
+		if !memberEntity.HasRole(role.ID) {
+			roleIds = append(roleIds, role.ID)
+			added = true
+		}
+	}
+
+	if added {
+		if err := s.memberService.AssignRole(ctx, memberEntity.ID, dtos.MemberAssignRole{RoleIds: roleIds}); err != nil {
+			return "", err
+		}
+	}
+
+	return "", s.memberService.ApproveMember(ctx, memberEntity.ID)
+}
+
+// findMembers 는 요청 대상 멤버를 찾는다. 멤버 ID 로 요청한 경우 없는 멤버는 실패(not-found)로 기록한다.
+func (s MemberApprovalService) findMembers(ctx context.Context, request dtos.MemberBulkApproval, result *dtos.MemberBulkApprovalResult) ([]domain.MemberEntity, error) {
+	if request.Filter != nil {
+		filters := map[string]interface{}{}
+		filters["status"] = constants.StatusMemberApplied
+		if len(request.Filter.Name) > 0 {
+			filters["name"] = request.Filter.Name
+		}
+		if len(request.Filter.Types) > 0 {
+			filters["types"] = request.Filter.Types
+		}
+		if len(request.Filter.Tags) > 0 {
+			filters["tags"] = request.Filter.Tags
+		}
+
+		memberEntities, _, err := s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+		if err != nil {
+			return nil, err
+		}
+		result.Requested = len(memberEntities)
+
+		return memberEntities, nil
+	}
+
+	memberIds := uniqueIds(request.MemberIds)
+	result.Requested = len(memberIds)
+
+	found := map[uint]domain.MemberEntity{}
+	for start := 0; start < len(memberIds); start += constants.RoleMemberBulkChunkSize {
+		end := start + constants.RoleMemberBulkChunkSize
+		if end > len(memberIds) {
+			end = len(memberIds)
+		}
+
+		filters := map[string]interface{}{}
+		filters["memberIds"] = memberIds[start:end]
+		memberEntities, _, err := s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, memberEntity := range memberEntities {
+			found[memberEntity.ID] = memberEntity
+		}
+	}
+
+	memberEntities := make([]domain.MemberEntity, 0, len(found))
+	for _, memberId := range memberIds {
+		memberEntity, ok := found[memberId]
+		if !ok {
+			result.Results = append(result.Results, dtos.MemberBulkApprovalMember{
+				MemberId: memberId, Status: constants.MemberBulkApprovalFailed, Reason: constants.MemberBulkApprovalFailureNotFound,
+			})
+			continue
+		}
+		memberEntities = append(memberEntities, memberEntity)
+	}
+
+	return memberEntities, nil
+}
+
+func (s MemberApprovalService) findDefaultRoles(ctx context.Context, setting dtos.MemberApprovalSetting) ([]rbacDomain.RoleEntity, error) {
+	if len(setting.DefaultRoleIds) == 0 {
+		return make([]rbacDomain.RoleEntity, 0), nil
+	}
+
+	filters := map[string]interface{}{}
+	filters["roleIds"] = setting.DefaultRoleIds
+	roleEntities, _, err := s.rbacService.GetRoles(ctx, filters, dtos.Pageable{Page: 0})
+	return roleEntities, err
+}
+
+func uniqueIds(ids []uint) []uint {
+	uniques := make([]uint, 0, len(ids))
+	seen := map[uint]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniques = append(uniques, id)
+		}
+	}
+
+	return uniques
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/member/repository"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"sort"
+)
+
+// MemberAssignmentRuleService 는 SSO 로 처음 로그인한 멤버에게 가입 정보(메일 도메인, 조직 단위, 부서)에 맞는 역할과 조직을 할당한다.
+type MemberAssignmentRuleService struct {
+	memberAssignmentRuleRepository *repository.MemberAssignmentRuleRepository
+	rbacService                    *RoleBasedAccessControlService
+	organizationService            *OrganizationService
+	memberService                  *MemberService
+	memberApprovalService          *MemberApprovalService
+}
+
+func NewMemberAssignmentRuleService(
+	memberAssignmentRuleRepository *repository.MemberAssignmentRuleRepository,
+	rbacService *RoleBasedAccessControlService,
+	organizationService *OrganizationService,
+	memberService *MemberService,
+	memberApprovalService *MemberApprovalService) *MemberAssignmentRuleService {
+
+	return &MemberAssignmentRuleService{
+		memberAssignmentRuleRepository: memberAssignmentRuleRepository,
+		rbacService:                    rbacService,
+		organizationService:            organizationService,
+		memberService:                  memberService,
+		memberApprovalService:          memberApprovalService,
+	}
+}
+
+func (s MemberAssignmentRuleService) CreateRule(ctx context.Context, information dtos.MemberAssignmentRuleInformation) (domain.MemberAssignmentRuleEntity, error) {
+	if err := s.validateRule(ctx, information); err != nil {
+		return domain.MemberAssignmentRuleEntity{}, err
+	}
+
+	entity, err := domain.NewMemberAssignmentRuleEntity(ctx, information)
+	if err != nil {
+		return domain.MemberAssignmentRuleEntity{}, err
+	}
+
+	if err := s.memberAssignmentRuleRepository.Create(ctx, &entity); err != nil {
+		return domain.MemberAssignmentRuleEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s MemberAssignmentRuleService) GetRules(ctx context.Context) ([]domain.MemberAssignmentRuleEntity, error) {
+	return s.memberAssignmentRuleRepository.FindAll(ctx)
+}
+
+func (s MemberAssignmentRuleService) GetRule(ctx context.Context, ruleId uint) (domain.MemberAssignmentRuleEntity, error) {
+	return s.memberAssignmentRuleRepository.FindById(ctx, ruleId)
+}
+
+func (s MemberAssignmentRuleService) UpdateRule(ctx context.Context, ruleId uint, information dtos.MemberAssignmentRuleInformation) error {
+	entity, err := s.memberAssignmentRuleRepository.FindById(ctx, ruleId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateRule(ctx, information); err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, information); err != nil {
+		return err
+	}
+
+	return s.memberAssignmentRuleRepository.Save(ctx, &entity)
+}
+
+func (s MemberAssignmentRuleService) DeleteRule(ctx context.Context, ruleId uint) error {
+	entity, err := s.memberAssignmentRuleRepository.FindById(ctx, ruleId)
+	if err != nil {
+		return err
+	}
+
+	return s.memberAssignmentRuleRepository.Delete(ctx, entity)
+}
+
+// validateRule 은 조건이 비어 있지 않은지, 할당할 역할과 조직이 있는지 확인한다.
+func (s MemberAssignmentRuleService) validateRule(ctx context.Context, information dtos.MemberAssignmentRuleInformation) error {
+	condition := information.Condition
+	if len(condition.MemberTypes) == 0 && len(condition.EmailDomains) == 0 &&
+		len(condition.GoogleOrgUnits) == 0 && len(condition.DoorayDepartments) == 0 {
+		return &errors.ErrInvalidMemberAssignmentRule{Reason: "condition is required"}
+	}
+
+	if len(information.RoleIds) == 0 && len(information.OrganizationIds) == 0 {
+		return &errors.ErrInvalidMemberAssignmentRule{Reason: "roleIds or organizationIds is required"}
+	}
+
+	if len(information.RoleIds) > 0 {
+		roleEntities, _, err := s.rbacService.GetRoles(ctx, map[string]interface{}{"roleIds": information.RoleIds}, dtos.Pageable{Page: 0})
+		if err != nil {
+			return err
+		}
+
+		for _, roleId := range information.RoleIds {
+			if !s.containsRole(roleEntities, roleId) {
+				return &errors.ErrInvalidMemberAssignmentRule{Reason: fmt.Sprintf("role %v not found", roleId)}
+			}
+		}
+	}
+
+	for _, organizationId := range information.OrganizationIds {
+		if _, err := s.organizationService.GetOrganization(ctx, organizationId); err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				return &errors.ErrInvalidMemberAssignmentRule{Reason: fmt.Sprintf("organization %v not found", organizationId)}
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (MemberAssignmentRuleService) containsRole(roleEntities []rbacDomain.RoleEntity, roleId uint) bool {
+	for _, roleEntity := range roleEntities {
+		if roleEntity.ID == roleId {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findMatchingRule 은 우선순위 순으로 규칙을 평가하여 처음 맞는 규칙을 찾는다.
+func (MemberAssignmentRuleService) findMatchingRule(rules []domain.MemberAssignmentRuleEntity, attributes dtos.MemberSignUpAttributes) (domain.MemberAssignmentRuleEntity, bool) {
+	for _, rule := range rules {
+		if rule.Matches(attributes) {
+			return rule, true
+		}
+	}
+
+	return domain.MemberAssignmentRuleEntity{}, false
+}
+
+// ProvisionMember 는 SSO 로 처음 로그인한 멤버를 가입시키고 맞는 규칙의 역할과 조직을 할당한다.
+// 멤버에 이미 넣어 둔 역할(도메인 기본 역할 등)은 유지하고, 규칙을 만든 뒤 지워진 역할이나 조직은 건너뛴다.
+// 자동 승인 규칙을 사용하면 메일 도메인 규칙에 맞지 않는 멤버는 승인 대기로 가입한다.
+func (s MemberAssignmentRuleService) ProvisionMember(ctx context.Context, memberEntity *domain.MemberEntity) error {
+	autoApprovalRule, autoApproved, err := s.memberApprovalService.ApplyAutoApproval(ctx, memberEntity)
+	if err != nil {
+		return err
+	}
+
+	rules, err := s.memberAssignmentRuleRepository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	rule, matched := s.findMatchingRule(rules, memberEntity.GetSignUpAttributes())
+	if matched && len(rule.GetRoleIds()) > 0 {
+		roleEntities, _, err := s.rbacService.GetRoles(ctx, map[string]interface{}{"roleIds": rule.GetRoleIds()}, dtos.Pageable{Page: 0})
+		if err != nil {
+			return err
+		}
+
+		for _, roleEntity := range roleEntities {
+			if !memberEntity.HasRole(roleEntity.ID) {
+				memberEntity.Roles = append(memberEntity.Roles, roleEntity)
+			}
+		}
+	}
+
+	if err := s.memberService.CreateMember(ctx, memberEntity); err != nil {
+		return err
+	}
+
+	if autoApproved {
+		if err := s.memberApprovalService.RecordAutoApproval(ctx, memberEntity.ID, autoApprovalRule); err != nil {
+			return err
+		}
+	}
+
+	if !matched {
+		return nil
+	}
+
+	for _, organizationId := range rule.GetOrganizationIds() {
+		if err := s.organizationService.AddMember(ctx, organizationId, *memberEntity); err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				log.Warnf("member assignment rule %v: organization %v not found", rule.ID, organizationId)
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DryRun 은 규칙을 기존 멤버에게 적용하면 어떤 역할과 조직이 할당되는지 미리 보여준다. 실제로 할당하지는 않는다.
+func (s MemberAssignmentRuleService) DryRun(ctx context.Context, dryRun dtos.MemberAssignmentDryRun) (dtos.MemberAssignmentDryRunResult, error) {
+	rules, err := s.dryRunRules(ctx, dryRun.Rules)
+	if err != nil {
+		return dtos.MemberAssignmentDryRunResult{}, err
+	}
+
+	filters := map[string]interface{}{}
+	if len(dryRun.MemberIds) > 0 {
+		filters["memberIds"] = dryRun.MemberIds
+	} else {
+		filters["types"] = []string{constants.TypeMemberDooray, constants.TypeMemberGoogle, constants.TypeMemberCustom}
+	}
+
+	memberEntities, _, err := s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+	if err != nil {
+		return dtos.MemberAssignmentDryRunResult{}, err
+	}
+
+	result := dtos.MemberAssignmentDryRunResult{
+		Evaluated: len(memberEntities),
+		Results:   make([]dtos.MemberAssignmentDryRunMember, 0),
+	}
+
+	for _, memberEntity := range memberEntities {
+		attributes := memberEntity.GetSignUpAttributes()
+		rule, matched := s.findMatchingRule(rules, attributes)
+		if !matched {
+			continue
+		}
+
+		result.Results = append(result.Results, dtos.MemberAssignmentDryRunMember{
+			MemberId:        memberEntity.ID,
+			Name:            memberEntity.Name,
+			Attributes:      attributes,
+			RuleId:          rule.ID,
+			RuleName:        rule.Name,
+			RoleIds:         rule.GetRoleIds(),
+			OrganizationIds: rule.GetOrganizationIds(),
+		})
+	}
+	result.Matched = len(result.Results)
+
+	return result, nil
+}
+
+// dryRunRules 는 요청에 규칙이 있으면 저장하지 않은 그 규칙을, 없으면 저장된 규칙을 평가 순서대로 돌려준다.
+func (s MemberAssignmentRuleService) dryRunRules(ctx context.Context, informations []dtos.MemberAssignmentRuleInformation) ([]domain.MemberAssignmentRuleEntity, error) {
+	if len(informations) == 0 {
+		return s.memberAssignmentRuleRepository.FindAll(ctx)
+	}
+
+	rules := make([]domain.MemberAssignmentRuleEntity, 0, len(informations))
+	for _, information := range informations {
+		rule, err := domain.NewMemberAssignmentRuleEntityForDryRun(information)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Priority < rules[j].Priority
+	})
+
+	return rules, nil
+}
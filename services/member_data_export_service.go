@@ -0,0 +1,155 @@
+package services
+
+import (
+	"archive/zip"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	fileDomain "better-admin-backend-service/file/domain"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"time"
+)
+
+type MemberDataExportService struct {
+	memberService   *MemberService
+	noteService     *NoteService
+	approvalService *ApprovalService
+	fileService     *FileService
+	auditService    *AuditService
+}
+
+func NewMemberDataExportService(
+	memberService *MemberService,
+	noteService *NoteService,
+	approvalService *ApprovalService,
+	fileService *FileService,
+	auditService *AuditService) *MemberDataExportService {
+
+	return &MemberDataExportService{
+		memberService:   memberService,
+		noteService:     noteService,
+		approvalService: approvalService,
+		fileService:     fileService,
+		auditService:    auditService,
+	}
+}
+
+// ExportMemberData 는 멤버 정보(member.json), 멤버에 남긴 메모(notes.json)와 첨부 파일,
+// 멤버가 대상인 승인 요청의 첨부 파일을 하나의 zip 파일로 만든다. 파일 이름과 내용을 반환한다.
+func (s MemberDataExportService) ExportMemberData(ctx context.Context, memberId uint) (string, []byte, error) {
+	member, err := s.memberService.GetMember(ctx, memberId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	notes, _, err := s.noteService.GetNotes(ctx, constants.AuditTargetTypeMember, memberId, dtos.Pageable{})
+	if err != nil {
+		return "", nil, err
+	}
+
+	noteIds := make([]uint, 0)
+	for _, note := range notes {
+		noteIds = append(noteIds, note.Id)
+		for _, reply := range note.Replies {
+			noteIds = append(noteIds, reply.Id)
+		}
+	}
+
+	noteAttachments, err := s.fileService.GetAttachments(ctx, constants.AuditTargetTypeNote, noteIds)
+	if err != nil {
+		return "", nil, err
+	}
+
+	approvals, err := s.approvalService.GetMemberApprovalRequests(ctx, memberId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	approvalIds := make([]uint, 0, len(approvals))
+	for _, approval := range approvals {
+		approvalIds = append(approvalIds, approval.ID)
+	}
+
+	approvalAttachments, err := s.fileService.GetAttachments(ctx, constants.AuditTargetTypeApproval, approvalIds)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var buffer bytes.Buffer
+	writer := zip.NewWriter(&buffer)
+
+	if err := writeZipJson(writer, "member.json", member.ToEventData()); err != nil {
+		return "", nil, err
+	}
+
+	if err := writeZipJson(writer, "notes.json", notes); err != nil {
+		return "", nil, err
+	}
+
+	for _, attachment := range append(noteAttachments, approvalAttachments...) {
+		if err := s.writeZipAttachment(ctx, writer, attachment); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", nil, pkgerrors.Wrap(err, "zip encode error")
+	}
+
+	detail := fmt.Sprintf("notes=%v, attachments=%v", len(noteIds), len(noteAttachments)+len(approvalAttachments))
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberDataExported, constants.AuditTargetTypeMember, memberId, detail); err != nil {
+		return "", nil, err
+	}
+
+	fileName := fmt.Sprintf("member_%d_%s.zip", memberId, time.Now().Format("20060102150405"))
+	return fileName, buffer.Bytes(), nil
+}
+
+// writeZipAttachment 는 첨부 파일을 attachments/{대상 유형}/{대상 Id}/{첨부 Id}-{이름} 으로 저장한다.
+// 저장소에 없는 파일은 건너뛴다.
+func (s MemberDataExportService) writeZipAttachment(ctx context.Context, writer *zip.Writer, attachment fileDomain.AttachmentEntity) error {
+	content, err := s.fileService.openAttachment(ctx, attachment)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			log.Warnf("member data export: attachment %d is not found", attachment.ID)
+			return nil
+		}
+		return err
+	}
+	defer content.Close()
+
+	part, err := writer.Create(fmt.Sprintf("attachments/%s/%d/%d-%s", attachment.EntityType, attachment.EntityId, attachment.ID, attachment.Name))
+	if err != nil {
+		return pkgerrors.Wrap(err, "zip encode error")
+	}
+
+	if _, err := io.Copy(part, content); err != nil {
+		return pkgerrors.Wrap(err, "zip encode error")
+	}
+
+	return nil
+}
+
+func writeZipJson(writer *zip.Writer, name string, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return pkgerrors.Wrap(err, "json encode error")
+	}
+
+	part, err := writer.Create(name)
+	if err != nil {
+		return pkgerrors.Wrap(err, "zip encode error")
+	}
+
+	if _, err := part.Write(content); err != nil {
+		return pkgerrors.Wrap(err, "zip encode error")
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/member/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type MemberDeprovisioningService struct {
+	memberService                  *MemberService
+	sessionService                 *SessionService
+	consentService                 *ConsentService
+	serviceAccountService          *ServiceAccountService
+	resourceOwnershipService       *ResourceOwnershipService
+	memberDeprovisioningRepository *repository.MemberDeprovisioningRepository
+	auditService                   *AuditService
+}
+
+func NewMemberDeprovisioningService(memberService *MemberService,
+	sessionService *SessionService,
+	consentService *ConsentService,
+	serviceAccountService *ServiceAccountService,
+	resourceOwnershipService *ResourceOwnershipService,
+	memberDeprovisioningRepository *repository.MemberDeprovisioningRepository,
+	auditService *AuditService) *MemberDeprovisioningService {
+	return &MemberDeprovisioningService{
+		memberService:                  memberService,
+		sessionService:                 sessionService,
+		consentService:                 consentService,
+		serviceAccountService:          serviceAccountService,
+		resourceOwnershipService:       resourceOwnershipService,
+		memberDeprovisioningRepository: memberDeprovisioningRepository,
+		auditService:                   auditService,
+	}
+}
+
+// DeprovisionMember 는 퇴사 등으로 멤버를 해지하는 체크리스트를 순서대로 실행하고 완료 보고서를 남긴다.
+// 연동 시스템 알림이 실패해도 해지는 완료하며 보고서의 상태가 completed-with-errors 가 된다.
+func (s MemberDeprovisioningService) DeprovisionMember(ctx context.Context, memberId uint,
+	request dtos.MemberDeprovisioningRequest) (domain.MemberDeprovisioningEntity, error) {
+
+	memberEntity, err := s.memberService.GetMember(ctx, memberId)
+	if err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+
+	if err := s.validate(ctx, memberEntity, request); err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+
+	entity, err := domain.NewMemberDeprovisioningEntity(ctx, memberId, request)
+	if err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+
+	// 1. 로그인 차단
+	if err := s.memberService.DeprovisionMember(ctx, memberId); err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+	entity.AddStep(constants.DeprovisioningStepDeactivate, constants.DeprovisioningStepStatusDone, "")
+
+	// 2. 세션과 OAuth 동의(발급한 토큰) 폐기
+	if err := s.sessionService.RevokeMemberSessions(ctx, memberId, constants.SessionRevokedReasonDeprovisioned); err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+	revokedConsents, err := s.consentService.RevokeMemberConsents(ctx, memberId)
+	if err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+	entity.AddStep(constants.DeprovisioningStepRevokeSessions, constants.DeprovisioningStepStatusDone,
+		fmt.Sprintf("consents=%v", revokedConsents))
+
+	// 3. 소유한 서비스 계정의 API 키 폐기
+	revokedAccounts, err := s.serviceAccountService.RevokeOwnedCredentials(ctx, memberId)
+	if err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+	entity.AddStep(constants.DeprovisioningStepRevokeApiKeys, stepStatusOf(len(revokedAccounts)), resourceNamesOf(revokedAccounts))
+
+	// 4. 역할 회수
+	roleNames := memberEntity.GetRoleNames()
+	if len(roleNames) > 0 {
+		if err := s.memberService.AssignRole(ctx, memberId, dtos.MemberAssignRole{RoleIds: []uint{}}); err != nil {
+			return domain.MemberDeprovisioningEntity{}, err
+		}
+	}
+	entity.AddStep(constants.DeprovisioningStepRemoveRoles, stepStatusOf(len(roleNames)), strings.Join(roleNames, ", "))
+
+	// 5. 소유한 리소스를 후임자에게 이전
+	transferredResources, err := s.resourceOwnershipService.TransferOwnership(ctx, memberId, request.SuccessorId, nil)
+	if err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+	entity.AddTransferredResources(transferredResources)
+	entity.AddStep(constants.DeprovisioningStepTransferResources, stepStatusOf(len(transferredResources)),
+		fmt.Sprintf("successorId=%v, resources=%v", request.SuccessorId, len(transferredResources)))
+
+	if err := s.memberDeprovisioningRepository.Create(ctx, &entity); err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+
+	// 6. 연동 시스템 알림
+	status, detail := s.notifyIntegrations(entity)
+	entity.AddStep(constants.DeprovisioningStepNotifyIntegrations, status, detail)
+
+	entity.Complete(time.Now())
+	if err := s.memberDeprovisioningRepository.Save(ctx, &entity); err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberDeprovisioned, constants.AuditTargetTypeMember, memberId,
+		fmt.Sprintf("successorId=%v, deprovisioningId=%v", request.SuccessorId, entity.ID)); err != nil {
+		return domain.MemberDeprovisioningEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s MemberDeprovisioningService) GetDeprovisionings(ctx context.Context, memberId uint) ([]domain.MemberDeprovisioningEntity, error) {
+	if _, err := s.memberService.GetMember(ctx, memberId); err != nil {
+		return nil, err
+	}
+
+	return s.memberDeprovisioningRepository.FindByMemberId(ctx, memberId)
+}
+
+func (s MemberDeprovisioningService) validate(ctx context.Context, memberEntity domain.MemberEntity, request dtos.MemberDeprovisioningRequest) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if memberEntity.IsDeprovisioned() {
+		return &errors.ErrInvalidDeprovisioning{Reason: "member is already deprovisioned"}
+	}
+
+	if memberEntity.ID == userClaim.Id {
+		return &errors.ErrInvalidDeprovisioning{Reason: "cannot deprovision yourself"}
+	}
+
+	if memberEntity.ID == request.SuccessorId {
+		return &errors.ErrInvalidDeprovisioning{Reason: "successor must be another member"}
+	}
+
+	successor, err := s.memberService.GetMember(ctx, request.SuccessorId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return &errors.ErrInvalidDeprovisioning{Reason: fmt.Sprintf("successor %v not found", request.SuccessorId)}
+		}
+		return err
+	}
+
+	if !successor.IsApproved() {
+		return &errors.ErrInvalidDeprovisioning{Reason: fmt.Sprintf("successor %v is not an approved member", request.SuccessorId)}
+	}
+
+	return nil
+}
+
+// notifyIntegrations 는 설정한 연동 시스템(deprovisioning.webHookUrls)에 해지 보고서(알림 전까지의 단계)를 보낸다.
+func (MemberDeprovisioningService) notifyIntegrations(entity domain.MemberDeprovisioningEntity) (string, string) {
+	webHookUrls := config.Config.Deprovisioning.WebHookUrls
+	if len(webHookUrls) == 0 {
+		return constants.DeprovisioningStepStatusSkipped, ""
+	}
+
+	body, err := json.Marshal(entity.ToReport())
+	if err != nil {
+		return constants.DeprovisioningStepStatusFailed, err.Error()
+	}
+
+	headers := map[string]string{}
+	if len(config.Config.Deprovisioning.Authorization) > 0 {
+		headers["Authorization"] = config.Config.Deprovisioning.Authorization
+	}
+
+	failures := make([]string, 0)
+	for _, url := range webHookUrls {
+		if err := adapters.OutgoingWebHookAdapter().Send(dtos.OutgoingWebHookRequest{
+			Url:         url,
+			ContentType: "application/json",
+			Headers:     headers,
+			Body:        body,
+		}); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", url, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return constants.DeprovisioningStepStatusFailed, strings.Join(failures, "; ")
+	}
+
+	return constants.DeprovisioningStepStatusDone, fmt.Sprintf("notified=%v", len(webHookUrls))
+}
+
+func stepStatusOf(count int) string {
+	if count == 0 {
+		return constants.DeprovisioningStepStatusSkipped
+	}
+
+	return constants.DeprovisioningStepStatusDone
+}
+
+func resourceNamesOf(resources []dtos.OwnedResource) string {
+	names := make([]string, 0)
+	for _, resource := range resources {
+		names = append(names, resource.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
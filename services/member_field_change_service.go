@@ -0,0 +1,254 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/member/repository"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"context"
+	"fmt"
+	"time"
+)
+
+// MemberFieldChangeService 는 멤버 중요 필드(아이디, 메일, 최고 관리자의 역할) 변경을 승인 절차(member-field-change)를 거쳐 반영한다.
+// 승인 절차가 정의되어 있지 않으면 바로 반영한다.
+type MemberFieldChangeService struct {
+	memberService               *MemberService
+	rbacService                 *RoleBasedAccessControlService
+	approvalService             *ApprovalService
+	memberFieldChangeRepository *repository.MemberFieldChangeRepository
+	auditService                *AuditService
+}
+
+func NewMemberFieldChangeService(memberService *MemberService,
+	rbacService *RoleBasedAccessControlService,
+	approvalService *ApprovalService,
+	memberFieldChangeRepository *repository.MemberFieldChangeRepository,
+	auditService *AuditService) *MemberFieldChangeService {
+	return &MemberFieldChangeService{
+		memberService:               memberService,
+		rbacService:                 rbacService,
+		approvalService:             approvalService,
+		memberFieldChangeRepository: memberFieldChangeRepository,
+		auditService:                auditService,
+	}
+}
+
+// RequestFieldChange 는 멤버의 아이디 또는 메일 변경을 요청한다.
+func (s MemberFieldChangeService) RequestFieldChange(ctx context.Context, memberId uint, request dtos.MemberFieldChangeRequest) (domain.MemberFieldChangeEntity, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return domain.MemberFieldChangeEntity{}, err
+	}
+
+	if request.Field == constants.MemberFieldSignId {
+		if _, err := s.memberService.GetMemberBySignId(ctx, request.Value); err == nil {
+			return domain.MemberFieldChangeEntity{}, errors.ErrDuplicated
+		} else if !errors.Is(err, errors.ErrNotFound) {
+			return domain.MemberFieldChangeEntity{}, err
+		}
+	}
+
+	entity, err := domain.NewMemberFieldChangeEntity(ctx, memberEntity, request, s.getLifetime())
+	if err != nil {
+		return domain.MemberFieldChangeEntity{}, err
+	}
+
+	return entity, s.request(ctx, &entity)
+}
+
+// IsCriticalRoleChange 는 최고 관리자 역할이 있는 멤버의 역할을 바꾸거나 최고 관리자 역할을 할당하는지 여부이다.
+func (s MemberFieldChangeService) IsCriticalRoleChange(ctx context.Context, memberId uint, assignRole dtos.MemberAssignRole) (bool, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return false, err
+	}
+
+	if memberEntity.IsSuperAdmin() {
+		return true, nil
+	}
+
+	roleEntities, err := s.getRoles(ctx, assignRole)
+	if err != nil {
+		return false, err
+	}
+
+	for _, roleEntity := range roleEntities {
+		if roleEntity.Name == constants.RoleNameSuperAdmin {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// RequestRoleChange 는 최고 관리자의 역할 변경을 요청한다.
+func (s MemberFieldChangeService) RequestRoleChange(ctx context.Context, memberId uint, assignRole dtos.MemberAssignRole) (domain.MemberFieldChangeEntity, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return domain.MemberFieldChangeEntity{}, err
+	}
+
+	roleEntities, err := s.getRoles(ctx, assignRole)
+	if err != nil {
+		return domain.MemberFieldChangeEntity{}, err
+	}
+
+	// 요청할 때 요청자의 단계 인증과 최고 관리자 최소 인원을 확인하고, 승인되어 반영할 때 승인자를 다시 확인한다.
+	granted, revoked := superAdminChangeOf(memberEntity.ID, memberEntity.IsSuperAdmin(), domain.MemberEntity{Roles: roleEntities}.IsSuperAdmin())
+	if err := s.memberService.CheckSuperAdminChange(ctx, granted, revoked); err != nil {
+		return domain.MemberFieldChangeEntity{}, err
+	}
+
+	entity, err := domain.NewMemberRoleChangeEntity(ctx, memberEntity, roleEntities, s.getLifetime())
+	if err != nil {
+		return domain.MemberFieldChangeEntity{}, err
+	}
+
+	return entity, s.request(ctx, &entity)
+}
+
+// request 는 변경을 저장하고 승인을 요청한다. 같은 필드의 변경이 승인을 기다리고 있으면 ErrApprovalInProgress 를 반환한다.
+func (s MemberFieldChangeService) request(ctx context.Context, entity *domain.MemberFieldChangeEntity) error {
+	pendingEntities, err := s.memberFieldChangeRepository.FindPendingByMemberIdAndField(ctx, entity.MemberId, entity.Field)
+	if err != nil {
+		return err
+	}
+
+	if len(pendingEntities) > 0 {
+		return errors.ErrApprovalInProgress
+	}
+
+	if err := s.memberFieldChangeRepository.Create(ctx, entity); err != nil {
+		return err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberFieldChangeRequested, constants.AuditTargetTypeMember,
+		entity.MemberId, fmt.Sprintf("field=%v, %v -> %v", entity.Field, entity.OldValue, entity.NewValue)); err != nil {
+		return err
+	}
+
+	approved, err := s.approvalService.RequireApproval(ctx, constants.ApprovalSubjectMemberFieldChange, entity.ID, entity.ToDiff())
+	if err != nil || !approved {
+		return err
+	}
+
+	return s.apply(ctx, entity)
+}
+
+// ApplyFieldChange 는 승인된 변경을 반영한다. 승인되었지만 기한이 지난 변경은 반영하지 않고 만료 처리한다.
+func (s MemberFieldChangeService) ApplyFieldChange(ctx context.Context, id uint) error {
+	entity, err := s.memberFieldChangeRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.apply(ctx, &entity)
+}
+
+func (s MemberFieldChangeService) apply(ctx context.Context, entity *domain.MemberFieldChangeEntity) error {
+	if err := entity.Apply(); err != nil {
+		if errors.Is(err, errors.ErrExpired) {
+			return s.saveExpired(ctx, entity)
+		}
+		return err
+	}
+
+	var err error
+	switch entity.Field {
+	case constants.MemberFieldSignId:
+		err = s.memberService.ChangeSignId(ctx, entity.MemberId, entity.NewValue)
+	case constants.MemberFieldEmail:
+		err = s.memberService.ChangeEmail(ctx, entity.MemberId, entity.NewValue)
+	case constants.MemberFieldRoles:
+		err = s.memberService.AssignRole(ctx, entity.MemberId, dtos.MemberAssignRole{RoleIds: entity.GetRoleIds()})
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.memberFieldChangeRepository.Save(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberFieldChangeApplied, constants.AuditTargetTypeMember,
+		entity.MemberId, fmt.Sprintf("field=%v, %v -> %v", entity.Field, entity.OldValue, entity.NewValue))
+}
+
+func (s MemberFieldChangeService) RejectFieldChange(ctx context.Context, id uint) error {
+	entity, err := s.memberFieldChangeRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Reject(); err != nil {
+		return err
+	}
+
+	if err := s.memberFieldChangeRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberFieldChangeRejected, constants.AuditTargetTypeMember,
+		entity.MemberId, fmt.Sprintf("field=%v, %v -> %v", entity.Field, entity.OldValue, entity.NewValue))
+}
+
+func (s MemberFieldChangeService) GetMemberFieldChanges(ctx context.Context, memberId uint) ([]domain.MemberFieldChangeEntity, error) {
+	if _, err := s.memberService.GetMemberById(ctx, memberId); err != nil {
+		return nil, err
+	}
+
+	return s.memberFieldChangeRepository.FindByMemberId(ctx, memberId)
+}
+
+func (s MemberFieldChangeService) GetFieldChange(ctx context.Context, id uint) (domain.MemberFieldChangeEntity, error) {
+	return s.memberFieldChangeRepository.FindById(ctx, id)
+}
+
+// ExpireFieldChanges 는 기한까지 승인되지 않은 변경과 그 승인 요청을 만료한다.
+func (s MemberFieldChangeService) ExpireFieldChanges(ctx context.Context) error {
+	entities, err := s.memberFieldChangeRepository.FindExpiredPending(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range entities {
+		if err := entities[i].Expire(); err != nil {
+			return err
+		}
+
+		if err := s.saveExpired(ctx, &entities[i]); err != nil {
+			return err
+		}
+
+		if err := s.approvalService.ExpirePendingRequests(ctx, constants.ApprovalSubjectMemberFieldChange, entities[i].ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s MemberFieldChangeService) saveExpired(ctx context.Context, entity *domain.MemberFieldChangeEntity) error {
+	if err := s.memberFieldChangeRepository.Save(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberFieldChangeExpired, constants.AuditTargetTypeMember,
+		entity.MemberId, fmt.Sprintf("field=%v, %v -> %v", entity.Field, entity.OldValue, entity.NewValue))
+}
+
+func (s MemberFieldChangeService) getRoles(ctx context.Context, assignRole dtos.MemberAssignRole) ([]rbacDomain.RoleEntity, error) {
+	filters := map[string]interface{}{}
+	filters["roleIds"] = assignRole.RoleIds
+
+	roleEntities, _, err := s.rbacService.GetRoles(ctx, filters, dtos.Pageable{Page: 0})
+	return roleEntities, err
+}
+
+func (MemberFieldChangeService) getLifetime() time.Duration {
+	return time.Duration(config.Config.MemberFieldChange.ExpiryHours) * time.Hour
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/member/repository"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// MemberIdentityService 는 한 멤버에 여러 외부 인증 계정(두레이, 구글, Authenticator)을 연결한다.
+// 연결한 계정으로 로그인하면 새 멤버로 가입하지 않고 연결한 멤버로 로그인한다.
+type MemberIdentityService struct {
+	memberService            *MemberService
+	memberIdentityRepository *repository.MemberIdentityRepository
+	auditService             *AuditService
+}
+
+func NewMemberIdentityService(memberService *MemberService,
+	memberIdentityRepository *repository.MemberIdentityRepository,
+	auditService *AuditService) *MemberIdentityService {
+	return &MemberIdentityService{
+		memberService:            memberService,
+		memberIdentityRepository: memberIdentityRepository,
+		auditService:             auditService,
+	}
+}
+
+// GetMemberByIdentity 는 provider 계정으로 가입했거나 계정을 연결한 멤버를 찾는다.
+func (s MemberIdentityService) GetMemberByIdentity(ctx context.Context, provider string, externalId string) (domain.MemberEntity, error) {
+	memberEntity, err := s.findPrimaryMember(ctx, provider, externalId)
+	if !errors.Is(err, errors.ErrNotFound) {
+		return memberEntity, err
+	}
+
+	identity, err := s.memberIdentityRepository.FindByProviderAndExternalId(ctx, provider, externalId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity, err = s.memberService.GetMember(ctx, identity.MemberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	identity.MarkUsed(time.Now())
+	if err := s.memberIdentityRepository.Save(ctx, &identity); err != nil {
+		log.Error("member identity last used update error: ", err)
+	}
+
+	return memberEntity, nil
+}
+
+// GetIdentities 는 가입한 계정과 연결한 계정이다.
+func (s MemberIdentityService) GetIdentities(ctx context.Context, memberId uint) ([]dtos.MemberIdentityInformation, error) {
+	memberEntity, err := s.memberService.GetMember(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := s.memberIdentityRepository.FindByMemberId(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := []dtos.MemberIdentityInformation{memberEntity.GetPrimaryIdentity()}
+	for _, entity := range entities {
+		identities = append(identities, entity.ToInformation())
+	}
+
+	return identities, nil
+}
+
+// LinkIdentity 는 인증을 마친 provider 계정을 멤버에 연결한다. 이미 다른 멤버가 사용하는 계정은 연결할 수 없다.
+func (s MemberIdentityService) LinkIdentity(ctx context.Context, memberId uint, provider string, externalId string, email string) (domain.MemberIdentityEntity, error) {
+	memberEntity, err := s.memberService.GetMember(ctx, memberId)
+	if err != nil {
+		return domain.MemberIdentityEntity{}, err
+	}
+
+	if !memberEntity.IsApproved() {
+		return domain.MemberIdentityEntity{}, errors.ErrUnApproved
+	}
+
+	if _, err := s.findPrimaryMember(ctx, provider, externalId); !errors.Is(err, errors.ErrNotFound) {
+		if err == nil {
+			return domain.MemberIdentityEntity{}, errors.ErrDuplicated
+		}
+		return domain.MemberIdentityEntity{}, err
+	}
+
+	if _, err := s.memberIdentityRepository.FindByProviderAndExternalId(ctx, provider, externalId); !errors.Is(err, errors.ErrNotFound) {
+		if err == nil {
+			return domain.MemberIdentityEntity{}, errors.ErrDuplicated
+		}
+		return domain.MemberIdentityEntity{}, err
+	}
+
+	entity := domain.NewMemberIdentityEntity(memberId, provider, externalId, email)
+	if err := s.memberIdentityRepository.Create(ctx, &entity); err != nil {
+		return domain.MemberIdentityEntity{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberIdentityLinked, constants.AuditTargetTypeMember, memberId,
+		fmt.Sprintf("provider=%v, externalId=%v", provider, externalId)); err != nil {
+		return domain.MemberIdentityEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// UnlinkIdentity 는 멤버에 연결한 계정(identityId)의 연결을 해제한다. 가입한 계정은 해제할 수 없다.
+func (s MemberIdentityService) UnlinkIdentity(ctx context.Context, memberId uint, identityId uint) error {
+	entity, err := s.memberIdentityRepository.FindById(ctx, identityId)
+	if err != nil {
+		return err
+	}
+
+	if entity.MemberId != memberId {
+		return errors.ErrNotFound
+	}
+
+	if err := s.memberIdentityRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionMemberIdentityUnlinked, constants.AuditTargetTypeMember, memberId,
+		fmt.Sprintf("provider=%v, externalId=%v", entity.Provider, entity.ExternalId))
+}
+
+// findPrimaryMember 는 provider 계정으로 가입한 멤버를 찾는다.
+func (s MemberIdentityService) findPrimaryMember(ctx context.Context, provider string, externalId string) (domain.MemberEntity, error) {
+	switch provider {
+	case constants.TypeMemberDooray:
+		return s.memberService.GetMemberByDoorayId(ctx, externalId)
+	case constants.TypeMemberGoogle:
+		return s.memberService.GetMemberByGoogleId(ctx, externalId)
+	}
+
+	if name, ok := domain.ParseCustomIdentityProvider(provider); ok {
+		return s.memberService.GetMemberByExternalId(ctx, name, externalId)
+	}
+
+	return domain.MemberEntity{}, errors.ErrNotFound
+}
@@ -1,25 +1,99 @@
 package services
 
 import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/member/domain"
 	"better-admin-backend-service/member/repository"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/security"
 	"context"
+	"sort"
 )
 
+// SuperAdminGuard 는 최고 관리자 역할(SYSTEM MANAGER)을 얻거나(granted) 잃는(revoked) 멤버가 있을 때 바꾸기 전에 확인하고 바꾼 뒤 기록한다.
+type SuperAdminGuard interface {
+	CheckSuperAdminChange(ctx context.Context, granted []uint, revoked []uint) error
+	RecordSuperAdminChange(ctx context.Context, granted []uint, revoked []uint) error
+}
+
+// LegalHoldGuard 는 멤버를 삭제하기 전에 법적 보존 대상인지 확인한다.
+type LegalHoldGuard interface {
+	CheckMemberLegalHold(ctx context.Context, memberId uint) error
+}
+
+// PasswordPolicyGuard 는 멤버가 정하는 비밀번호가 비밀번호 정책에 맞는지 확인한다. 가입하는 멤버는 memberId 가 0 이다.
+type PasswordPolicyGuard interface {
+	CheckPasswordPolicy(ctx context.Context, memberId uint, password string) error
+}
+
+// QuotaGuard 는 멤버, 역할, API Key(constants.LicenseResource*)를 만들기 전에 라이선스의 최대 수를 넘는지 확인하고 만든 뒤 사용량을 기록한다.
+type QuotaGuard interface {
+	CheckQuota(ctx context.Context, resource string) error
+	RecordQuotaUsage(ctx context.Context, resource string)
+}
+
 type MemberService struct {
-	rbacService      *RoleBasedAccessControlService
-	memberRepository *repository.MemberRepository
+	rbacService          *RoleBasedAccessControlService
+	memberRepository     *repository.MemberRepository
+	domainEventService   *DomainEventService
+	accessHistoryService *AccessHistoryService
+	superAdminGuard      SuperAdminGuard
+	legalHoldGuard       LegalHoldGuard
+	passwordPolicyGuard  PasswordPolicyGuard
+	quotaGuard           QuotaGuard
 }
 
 func NewMemberService(rbacService *RoleBasedAccessControlService,
-	memberRepository *repository.MemberRepository) *MemberService {
+	memberRepository *repository.MemberRepository,
+	domainEventService *DomainEventService,
+	accessHistoryService *AccessHistoryService) *MemberService {
 	return &MemberService{
-		rbacService:      rbacService,
-		memberRepository: memberRepository,
+		rbacService:          rbacService,
+		memberRepository:     memberRepository,
+		domainEventService:   domainEventService,
+		accessHistoryService: accessHistoryService,
+	}
+}
+
+// RegisterSuperAdminGuard 는 최고 관리자 역할 변경을 확인할 SuperAdminGuard 를 등록한다. 등록하지 않으면 확인하지 않는다.
+func (s *MemberService) RegisterSuperAdminGuard(guard SuperAdminGuard) {
+	s.superAdminGuard = guard
+}
+
+// RegisterLegalHoldGuard 는 멤버 삭제를 확인할 LegalHoldGuard 를 등록한다. 등록하지 않으면 확인하지 않는다.
+func (s *MemberService) RegisterLegalHoldGuard(guard LegalHoldGuard) {
+	s.legalHoldGuard = guard
+}
+
+// RegisterPasswordPolicyGuard 는 비밀번호 정책을 확인할 PasswordPolicyGuard 를 등록한다. 등록하지 않으면 확인하지 않는다.
+func (s *MemberService) RegisterPasswordPolicyGuard(guard PasswordPolicyGuard) {
+	s.passwordPolicyGuard = guard
+}
+
+// RegisterQuotaGuard 는 멤버 수를 제한할 QuotaGuard 를 등록한다. 등록하지 않으면 확인하지 않는다.
+func (s *MemberService) RegisterQuotaGuard(guard QuotaGuard) {
+	s.quotaGuard = guard
+}
+
+func (s MemberService) checkPasswordPolicy(ctx context.Context, memberId uint, password string) error {
+	if s.passwordPolicyGuard == nil {
+		return nil
 	}
+
+	return s.passwordPolicyGuard.CheckPasswordPolicy(ctx, memberId, password)
+}
+
+// CheckLegalHold 는 멤버가 법적 보존 대상이면 errors.ErrLegalHold 이다.
+func (s MemberService) CheckLegalHold(ctx context.Context, memberId uint) error {
+	if s.legalHoldGuard == nil {
+		return nil
+	}
+
+	return s.legalHoldGuard.CheckMemberLegalHold(ctx, memberId)
 }
 
 func (s MemberService) GetMemberBySignId(ctx context.Context, signId string) (domain.MemberEntity, error) {
@@ -31,7 +105,29 @@ func (s MemberService) GetMemberByDoorayId(ctx context.Context, doorayId string)
 }
 
 func (s MemberService) CreateMember(ctx context.Context, entity *domain.MemberEntity) error {
-	return s.memberRepository.Create(ctx, entity)
+	if err := checkQuota(ctx, s.quotaGuard, constants.LicenseResourceMembers); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.Create(ctx, entity); err != nil {
+		return err
+	}
+
+	if err := s.accessHistoryService.SyncMemberRoles(ctx, entity.ID, entity.GetRoleIds()); err != nil {
+		return err
+	}
+
+	if err := s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberCreated, *entity); err != nil {
+		return err
+	}
+
+	recordQuotaUsage(ctx, s.quotaGuard, constants.LicenseResourceMembers)
+	return nil
+}
+
+// CountMembers 는 라이선스 사용량으로 세는 해지하지 않은 멤버 수이다.
+func (s MemberService) CountMembers(ctx context.Context) (int64, error) {
+	return s.memberRepository.CountNotDeprovisioned(ctx)
 }
 
 func (s MemberService) GetMemberById(ctx context.Context, id uint) (domain.MemberEntity, error) {
@@ -56,35 +152,235 @@ func (s MemberService) AssignRole(ctx context.Context, memberId uint, assignRole
 		return err
 	}
 
+	wasSuperAdmin := memberEntity.IsSuperAdmin()
 	err = memberEntity.AssignRole(ctx, findRoleEntities)
 	if err != nil {
 		return err
 	}
 
-	return s.memberRepository.Save(ctx, &memberEntity)
+	granted, revoked := superAdminChangeOf(memberEntity.ID, wasSuperAdmin, memberEntity.IsSuperAdmin())
+	if err := s.checkSuperAdminChange(ctx, granted, revoked); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return err
+	}
+
+	if err := s.accessHistoryService.SyncMemberRoles(ctx, memberEntity.ID, memberEntity.GetRoleIds()); err != nil {
+		return err
+	}
+
+	if err := s.recordSuperAdminChange(ctx, granted, revoked); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberRolesAssigned, memberEntity)
+}
+
+// AddRoleToMembers 는 멤버들의 기존 역할은 유지하고 역할을 추가한다. 멤버들은 아직 역할이 없어야 한다.
+func (s MemberService) AddRoleToMembers(ctx context.Context, roleEntity rbacDomain.RoleEntity, memberEntities []domain.MemberEntity) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	granted := superAdminMemberIdsOf(roleEntity, memberEntities)
+	if err := s.checkSuperAdminChange(ctx, granted, nil); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.AddRole(ctx, roleEntity.ID, memberIdsOf(memberEntities), userClaim.Id); err != nil {
+		return err
+	}
+
+	for _, memberEntity := range memberEntities {
+		memberEntity.Roles = append(memberEntity.Roles, roleEntity)
+		if err := s.accessHistoryService.SyncMemberRoles(ctx, memberEntity.ID, memberEntity.GetRoleIds()); err != nil {
+			return err
+		}
+
+		if err := s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberRolesAssigned, memberEntity); err != nil {
+			return err
+		}
+	}
+
+	return s.recordSuperAdminChange(ctx, granted, nil)
+}
+
+// RemoveRoleFromMembers 는 멤버들에게서 역할만 제거한다.
+func (s MemberService) RemoveRoleFromMembers(ctx context.Context, roleEntity rbacDomain.RoleEntity, memberEntities []domain.MemberEntity) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	revoked := superAdminMemberIdsOf(roleEntity, memberEntities)
+	if err := s.checkSuperAdminChange(ctx, nil, revoked); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.RemoveRole(ctx, roleEntity.ID, memberIdsOf(memberEntities), userClaim.Id); err != nil {
+		return err
+	}
+
+	for _, memberEntity := range memberEntities {
+		roles := make([]rbacDomain.RoleEntity, 0, len(memberEntity.Roles))
+		for _, role := range memberEntity.Roles {
+			if role.ID != roleEntity.ID {
+				roles = append(roles, role)
+			}
+		}
+		memberEntity.Roles = roles
+
+		if err := s.accessHistoryService.SyncMemberRoles(ctx, memberEntity.ID, memberEntity.GetRoleIds()); err != nil {
+			return err
+		}
+
+		if err := s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberRolesAssigned, memberEntity); err != nil {
+			return err
+		}
+	}
+
+	return s.recordSuperAdminChange(ctx, nil, revoked)
+}
+
+// UpdateRoles 는 멤버의 다른 역할은 그대로 두고 역할을 추가(addRoles)하고 회수(removeRoleIds)한다. 그룹-역할 매핑과 임시 권한 상승에서 사용한다.
+// 로그인 중이나 스케줄러에서 실행하면 변경한 멤버가 없으므로 시스템(0)이 변경한 것으로 남긴다.
+func (s MemberService) UpdateRoles(ctx context.Context, memberEntity *domain.MemberEntity, addRoles []rbacDomain.RoleEntity, removeRoleIds []uint) error {
+	for _, roleEntity := range addRoles {
+		if err := s.memberRepository.AddRole(ctx, roleEntity.ID, []uint{memberEntity.ID}, actorIdOf(ctx)); err != nil {
+			return err
+		}
+	}
+
+	for _, roleId := range removeRoleIds {
+		if err := s.memberRepository.RemoveRole(ctx, roleId, []uint{memberEntity.ID}, actorIdOf(ctx)); err != nil {
+			return err
+		}
+	}
+
+	removed := make(map[uint]bool)
+	for _, roleId := range removeRoleIds {
+		removed[roleId] = true
+	}
+
+	roles := make([]rbacDomain.RoleEntity, 0, len(memberEntity.Roles)+len(addRoles))
+	for _, role := range memberEntity.Roles {
+		if !removed[role.ID] {
+			roles = append(roles, role)
+		}
+	}
+	memberEntity.Roles = append(roles, addRoles...)
+
+	if err := s.accessHistoryService.SyncMemberRoles(ctx, memberEntity.ID, memberEntity.GetRoleIds()); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberRolesAssigned, *memberEntity)
+}
+
+// MoveRole 은 멤버들의 역할(source)을 다른 역할(target)로 옮긴다. 이미 target 역할이 있는 멤버는 source 역할만 제거한다.
+func (s MemberService) MoveRole(ctx context.Context, source rbacDomain.RoleEntity, target rbacDomain.RoleEntity, memberEntities []domain.MemberEntity) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	targetMembers := make([]domain.MemberEntity, 0, len(memberEntities))
+	for _, memberEntity := range memberEntities {
+		if !memberEntity.HasRole(target.ID) {
+			targetMembers = append(targetMembers, memberEntity)
+		}
+	}
+
+	granted, revoked := superAdminMemberIdsOf(target, targetMembers), superAdminMemberIdsOf(source, memberEntities)
+	if err := s.checkSuperAdminChange(ctx, granted, revoked); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.RemoveRole(ctx, source.ID, memberIdsOf(memberEntities), userClaim.Id); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.AddRole(ctx, target.ID, memberIdsOf(targetMembers), userClaim.Id); err != nil {
+		return err
+	}
+
+	for _, memberEntity := range memberEntities {
+		roles := make([]rbacDomain.RoleEntity, 0, len(memberEntity.Roles)+1)
+		for _, role := range memberEntity.Roles {
+			if role.ID != source.ID {
+				roles = append(roles, role)
+			}
+		}
+		if !memberEntity.HasRole(target.ID) {
+			roles = append(roles, target)
+		}
+		memberEntity.Roles = roles
+
+		if err := s.accessHistoryService.SyncMemberRoles(ctx, memberEntity.ID, memberEntity.GetRoleIds()); err != nil {
+			return err
+		}
+
+		if err := s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberRolesAssigned, memberEntity); err != nil {
+			return err
+		}
+	}
+
+	return s.recordSuperAdminChange(ctx, granted, revoked)
+}
+
+func (s MemberService) SetTags(ctx context.Context, memberId uint, memberTags dtos.MemberTags) error {
+	if _, err := s.memberRepository.FindById(ctx, memberId); err != nil {
+		return err
+	}
+
+	return s.memberRepository.ReplaceTags(ctx, memberId, domain.NewMemberTagEntities(memberId, memberTags.Tags))
+}
+
+func (s MemberService) GetAllTagNames(ctx context.Context) ([]string, error) {
+	return s.memberRepository.FindAllTagNames(ctx)
 }
 
 func (s MemberService) GetMember(ctx context.Context, memberId uint) (domain.MemberEntity, error) {
 	return s.memberRepository.FindById(ctx, memberId)
 }
 
-func (s MemberService) SignUpMember(ctx context.Context, signUp dtos.MemberSignUp) error {
+func (s MemberService) SignUpMember(ctx context.Context, signUp dtos.MemberSignUp) (domain.MemberEntity, error) {
+	if err := s.checkPasswordPolicy(ctx, 0, signUp.Password); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
 	_, err := s.memberRepository.FindBySignId(ctx, signUp.SignId)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			// signId 가 중복이 없을 때만 가입
+			if err := checkQuota(ctx, s.quotaGuard, constants.LicenseResourceMembers); err != nil {
+				return domain.MemberEntity{}, err
+			}
+
 			newMember, err := domain.NewMemberEntityFromSignUp(signUp)
 			if err != nil {
-				return err
+				return domain.MemberEntity{}, err
 			}
 
-			return s.memberRepository.Create(ctx, &newMember)
+			if err := s.memberRepository.Create(ctx, &newMember); err != nil {
+				return domain.MemberEntity{}, err
+			}
+
+			if err := s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberSignedUp, newMember); err != nil {
+				return domain.MemberEntity{}, err
+			}
+
+			recordQuotaUsage(ctx, s.quotaGuard, constants.LicenseResourceMembers)
+			return newMember, nil
 		}
 
-		return err
+		return domain.MemberEntity{}, err
 	}
 
-	return errors.ErrDuplicated
+	return domain.MemberEntity{}, errors.ErrDuplicated
 }
 
 func (s MemberService) ApproveMember(ctx context.Context, memberId uint) error {
@@ -97,7 +393,61 @@ func (s MemberService) ApproveMember(ctx context.Context, memberId uint) error {
 		return err
 	}
 
-	return s.memberRepository.Save(ctx, &memberEntity)
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberApproved, memberEntity)
+}
+
+// AutoApproveMember 는 자동 승인 규칙에 맞는 가입 신청을 승인하고 기본 역할을 추가한다.
+func (s MemberService) AutoApproveMember(ctx context.Context, memberId uint, roleEntities []rbacDomain.RoleEntity) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	memberEntity.AutoApprove(roleEntities)
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return err
+	}
+
+	if err := s.accessHistoryService.SyncMemberRoles(ctx, memberEntity.ID, memberEntity.GetRoleIds()); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberApproved, memberEntity)
+}
+
+// DeprovisionMember 는 멤버를 해지 상태로 바꾸어 더 이상 로그인할 수 없게 한다.
+func (s MemberService) DeprovisionMember(ctx context.Context, memberId uint) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	revoked := revokedSuperAdminOf(memberEntity)
+	if err := s.checkSuperAdminChange(ctx, nil, revoked); err != nil {
+		return err
+	}
+
+	if err := memberEntity.Deprovision(ctx); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return err
+	}
+
+	if err := s.recordSuperAdminChange(ctx, nil, revoked); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberDeprovisioned, memberEntity)
+}
+
+func (s MemberService) GetMemberByExternalId(ctx context.Context, authenticatorName string, externalId string) (domain.MemberEntity, error) {
+	return s.memberRepository.FindByExternalId(ctx, authenticatorName, externalId)
 }
 
 func (s MemberService) GetMemberByGoogleId(ctx context.Context, googleId string) (domain.MemberEntity, error) {
@@ -115,8 +465,30 @@ func (s MemberService) RejectMember(ctx context.Context, memberId uint) error {
 		return err
 	}
 
+	if err := s.CheckLegalHold(ctx, memberId); err != nil {
+		return err
+	}
+
+	revoked := revokedSuperAdminOf(memberEntity)
+	if err := s.checkSuperAdminChange(ctx, nil, revoked); err != nil {
+		return err
+	}
+
 	memberEntity.UpdatedBy = userClaim.Id
-	return s.memberRepository.Delete(ctx, memberEntity)
+	if err := s.memberRepository.Delete(ctx, memberEntity); err != nil {
+		return err
+	}
+
+	// 거절(삭제)된 멤버는 더 이상 역할을 가지지 않는다.
+	if err := s.accessHistoryService.SyncMemberRoles(ctx, memberEntity.ID, nil); err != nil {
+		return err
+	}
+
+	if err := s.recordSuperAdminChange(ctx, nil, revoked); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberRejected, memberEntity)
 }
 
 func (s MemberService) UpdateMemberLastAccessAt(ctx context.Context, memberId uint) error {
@@ -129,3 +501,192 @@ func (s MemberService) UpdateMemberLastAccessAt(ctx context.Context, memberId ui
 
 	return s.memberRepository.Save(ctx, &memberEntity)
 }
+
+func (s MemberService) ChangeSignId(ctx context.Context, memberId uint, newSignId string) error {
+	if _, err := s.memberRepository.FindBySignId(ctx, newSignId); err == nil {
+		return errors.ErrDuplicated
+	} else if !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if err := memberEntity.ChangeSignId(newSignId); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordMemberEvent(ctx, constants.DomainEventMemberSignIdChanged, memberEntity)
+}
+
+func (s MemberService) ChangeEmail(ctx context.Context, memberId uint, email string) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if err := memberEntity.ChangeEmail(ctx, email); err != nil {
+		return err
+	}
+
+	return s.memberRepository.Save(ctx, &memberEntity)
+}
+
+// RehashPassword 는 로그인할 때 확인한 비밀번호가 이전 알고리즘이나 비용으로 저장되어 있으면 설정(PasswordHashing)에 맞게 다시 저장한다.
+func (s MemberService) RehashPassword(ctx context.Context, memberEntity *domain.MemberEntity, password string) error {
+	if !memberEntity.PasswordNeedsRehash() {
+		return nil
+	}
+
+	if err := memberEntity.RehashPassword(password); err != nil {
+		return err
+	}
+
+	return s.memberRepository.UpdatePassword(ctx, memberEntity.ID, memberEntity.Password)
+}
+
+// RequirePasswordReset 은 멤버가 비밀번호를 다시 설정할 때까지 비밀번호로 로그인하지 못하게 한다.
+func (s MemberService) RequirePasswordReset(ctx context.Context, memberId uint) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	memberEntity.RequirePasswordReset()
+	return s.memberRepository.Save(ctx, &memberEntity)
+}
+
+func (s MemberService) ResetPassword(ctx context.Context, memberId uint, password string) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkPasswordPolicy(ctx, memberId, password); err != nil {
+		return err
+	}
+
+	if err := memberEntity.ResetPassword(password); err != nil {
+		return err
+	}
+
+	return s.memberRepository.Save(ctx, &memberEntity)
+}
+
+// GetPasswordHashReport 는 비밀번호를 알고리즘별로 세고, 설정과 다른 알고리즘이나 비용(legacy)으로 저장된 멤버 수를 반환한다.
+func (s MemberService) GetPasswordHashReport(ctx context.Context) (dtos.PasswordHashReport, error) {
+	passwords, err := s.memberRepository.FindPasswordHashes(ctx)
+	if err != nil {
+		return dtos.PasswordHashReport{}, err
+	}
+
+	report := dtos.PasswordHashReport{
+		Algorithm:  config.Config.PasswordHashing.Algorithm,
+		TotalCount: len(passwords),
+		Algorithms: make([]dtos.PasswordHashAlgorithmCount, 0),
+	}
+
+	indexes := map[string]int{}
+	for _, password := range passwords {
+		algorithm := security.PasswordHashAlgorithm(password)
+		index, ok := indexes[algorithm]
+		if !ok {
+			index = len(report.Algorithms)
+			indexes[algorithm] = index
+			report.Algorithms = append(report.Algorithms, dtos.PasswordHashAlgorithmCount{Algorithm: algorithm})
+		}
+
+		report.Algorithms[index].Count++
+		if security.PasswordNeedsRehash(password) {
+			report.Algorithms[index].LegacyCount++
+			report.LegacyCount++
+		}
+	}
+
+	sort.Slice(report.Algorithms, func(i, j int) bool { return report.Algorithms[i].Algorithm < report.Algorithms[j].Algorithm })
+	return report, nil
+}
+
+func (s MemberService) GetMembersByRoleName(ctx context.Context, roleName string) ([]domain.MemberEntity, error) {
+	return s.memberRepository.FindByRoleName(ctx, roleName)
+}
+
+// CheckSuperAdminChange 는 멤버들이 최고 관리자 역할을 얻거나 잃어도 되는지 미리 확인한다. 여러 번 나누어 바꾸기 전에 한 번에 확인할 때 사용한다.
+func (s MemberService) CheckSuperAdminChange(ctx context.Context, granted []uint, revoked []uint) error {
+	return s.checkSuperAdminChange(ctx, granted, revoked)
+}
+
+func (s MemberService) checkSuperAdminChange(ctx context.Context, granted []uint, revoked []uint) error {
+	if s.superAdminGuard == nil || (len(granted) == 0 && len(revoked) == 0) {
+		return nil
+	}
+
+	return s.superAdminGuard.CheckSuperAdminChange(ctx, granted, revoked)
+}
+
+func (s MemberService) recordSuperAdminChange(ctx context.Context, granted []uint, revoked []uint) error {
+	if s.superAdminGuard == nil || (len(granted) == 0 && len(revoked) == 0) {
+		return nil
+	}
+
+	return s.superAdminGuard.RecordSuperAdminChange(ctx, granted, revoked)
+}
+
+// superAdminChangeOf 는 멤버의 역할을 바꾸기 전후 최고 관리자 역할 여부로 얻거나 잃는 멤버를 구한다.
+func superAdminChangeOf(memberId uint, before bool, after bool) ([]uint, []uint) {
+	if !before && after {
+		return []uint{memberId}, nil
+	}
+	if before && !after {
+		return nil, []uint{memberId}
+	}
+
+	return nil, nil
+}
+
+// superAdminMemberIdsOf 는 역할이 최고 관리자 역할이면 멤버들의 Id 를, 아니면 nil 을 반환한다.
+func superAdminMemberIdsOf(roleEntity rbacDomain.RoleEntity, memberEntities []domain.MemberEntity) []uint {
+	if roleEntity.Name != constants.RoleNameSuperAdmin {
+		return nil
+	}
+
+	return memberIdsOf(memberEntities)
+}
+
+// revokedSuperAdminOf 는 해지하거나 삭제하는 멤버가 최고 관리자이면 잃는 멤버로 반환한다.
+func revokedSuperAdminOf(memberEntity domain.MemberEntity) []uint {
+	if !memberEntity.IsSuperAdmin() {
+		return nil
+	}
+
+	return []uint{memberEntity.ID}
+}
+
+func memberIdsOf(memberEntities []domain.MemberEntity) []uint {
+	memberIds := make([]uint, 0, len(memberEntities))
+	for _, memberEntity := range memberEntities {
+		memberIds = append(memberIds, memberEntity.ID)
+	}
+
+	return memberIds
+}
+
+// memberClaimOf 는 로그인한 멤버의 claim 이다. 서비스 계정의 Id 는 멤버 Id 와 겹칠 수 있으므로 멤버로 다루지 않고 ErrAuthentication 이다.
+func memberClaimOf(ctx context.Context) (*security.UserClaim, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userClaim.IsServiceAccount() {
+		return nil, errors.ErrAuthentication
+	}
+
+	return userClaim, nil
+}
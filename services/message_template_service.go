@@ -0,0 +1,451 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/messagetemplate/domain"
+	"better-admin-backend-service/messagetemplate/repository"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"unicode/utf8"
+)
+
+// messageTemplateDefinition 은 내장 메일 템플릿이다. 관리자가 바꾼 템플릿을 지우면 내장 템플릿으로 돌아간다.
+type messageTemplateDefinition struct {
+	name        string
+	description string
+	variables   []dtos.MessageTemplateVariable
+	subject     string
+	body        string
+}
+
+func (d messageTemplateDefinition) sampleData() map[string]string {
+	data := map[string]string{}
+	for _, variable := range d.variables {
+		data[variable.Name] = variable.Sample
+	}
+
+	return data
+}
+
+var builtInMessageTemplates = []messageTemplateDefinition{
+	{
+		name:        constants.MessageTemplateApprovalRequested,
+		description: "승인 단계의 승인자(위임 받은 멤버 포함)에게 보내는 승인 요청, 재알림, 이관 알림",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "title", Description: "알림 종류(승인 요청, 승인 요청 재알림, 승인 요청 이관)", Sample: "승인 요청"},
+			{Name: "subject", Description: "승인 대상", Sample: "member-sign-up"},
+			{Name: "approvalId", Description: "승인 요청 Id", Sample: "12"},
+			{Name: "stepName", Description: "현재 승인 단계", Sample: "팀장 승인"},
+		},
+		subject: "[Better Admin] {{.title}} - {{.subject}}",
+		body:    "'{{.stepName}}' 단계의 승인 요청(#{{.approvalId}})이 있습니다.",
+	},
+	{
+		name:        constants.MessageTemplateSignIdChangeConfirm,
+		description: "아이디 변경을 확인하도록 새 주소로 보내는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "newSignId", Description: "새 아이디", Sample: "new@example.com"},
+			{Name: "confirmUrl", Description: "변경 확인 링크", Sample: "https://admin.example.com/sign-id-change/confirm?token=sample"},
+			{Name: "expiresAt", Description: "확인 기한(RFC3339)", Sample: "2026-01-02T15:04:05+09:00"},
+		},
+		subject: "[Better Admin] 아이디 변경 확인",
+		body:    "아이디를 {{.newSignId}}(으)로 변경하려면 아래 링크를 눌러 주세요.\n{{.confirmUrl}}\n\n{{.expiresAt}} 까지 확인하지 않으면 변경되지 않습니다.",
+	},
+	{
+		name:        constants.MessageTemplateSignIdChangeRequested,
+		description: "아이디 변경 요청을 기존 주소(이메일인 경우)로 알리는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "newSignId", Description: "새 아이디", Sample: "new@example.com"},
+			{Name: "cancelUrl", Description: "변경 취소 링크", Sample: "https://admin.example.com/sign-id-change/cancel?token=sample"},
+		},
+		subject: "[Better Admin] 아이디 변경 요청 알림",
+		body:    "아이디를 {{.newSignId}}(으)로 변경하는 요청이 있었습니다. 본인이 요청하지 않았다면 아래 링크를 눌러 취소해 주세요.\n{{.cancelUrl}}",
+	},
+	{
+		name:        constants.MessageTemplateSignIdChanged,
+		description: "아이디 변경 완료를 기존 주소(이메일인 경우)로 알리는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "newSignId", Description: "새 아이디", Sample: "new@example.com"},
+		},
+		subject: "[Better Admin] 아이디 변경 완료",
+		body:    "아이디가 {{.newSignId}}(으)로 변경되었습니다. 본인이 변경하지 않았다면 관리자에게 문의해 주세요.",
+	},
+	{
+		name:        constants.MessageTemplateLoginNewDevice,
+		description: "처음 보는 기기나 위치에서 로그인했을 때 멤버에게 보내는 알림",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "ipAddress", Description: "로그인한 IP", Sample: "203.0.113.10"},
+			{Name: "country", Description: "국가", Sample: "KR"},
+			{Name: "city", Description: "도시", Sample: "Seoul"},
+			{Name: "loggedInAt", Description: "로그인 시각(RFC3339)", Sample: "2026-01-02T15:04:05+09:00"},
+			{Name: "reportUrl", Description: "본인이 아닌 로그인 신고 링크", Sample: "https://admin.example.com/login-report?token=sample"},
+		},
+		subject: "[Better Admin] 새 기기에서 로그인",
+		body: "처음 보는 기기(브라우저)나 위치에서 로그인했습니다.\nIP: {{.ipAddress}}\n위치: {{.country}} {{.city}}\n시각: {{.loggedInAt}}\n\n" +
+			"본인이 아니면 아래 링크를 눌러 주세요. 모든 세션을 종료하고 비밀번호를 다시 설정하도록 합니다.\n{{.reportUrl}}",
+	},
+	{
+		name:        constants.MessageTemplatePasswordReset,
+		description: "본인이 하지 않은 로그인으로 신고한 멤버에게 보내는 비밀번호 재설정 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "resetUrl", Description: "비밀번호 재설정 링크", Sample: "https://admin.example.com/password-reset?token=sample"},
+		},
+		subject: "[Better Admin] 비밀번호 재설정",
+		body:    "본인이 하지 않은 로그인으로 신고하여 모든 세션을 종료했습니다.\n아래 링크에서 비밀번호를 다시 설정해야 로그인할 수 있습니다.\n{{.resetUrl}}",
+	},
+	{
+		name:        constants.MessageTemplateSessionClientMismatched,
+		description: "로그인한 기기와 다른 기기에서 로그인 유지(토큰 갱신)를 요청했을 때 멤버에게 보내는 알림",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "result", Description: "처리 결과", Sample: "로그인 유지를 거부했습니다."},
+			{Name: "clientIp", Description: "요청한 IP", Sample: "203.0.113.10"},
+		},
+		subject: "[Better Admin] 다른 기기에서 로그인 유지 시도",
+		body:    "로그인한 기기와 다른 기기(브라우저)에서 로그인 유지를 요청하여 {{.result}}\nIP: {{.clientIp}}\n본인이 아니면 비밀번호를 변경하고 다른 세션을 종료하세요.",
+	},
+	{
+		name:        constants.MessageTemplateBreakGlassUsed,
+		description: "비상 접근 계정으로 로그인했을 때 알림 역할의 멤버에게 보내는 알림",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "signId", Description: "비상 접근 계정", Sample: "break-glass"},
+			{Name: "memberId", Description: "멤버 Id", Sample: "1"},
+			{Name: "clientIp", Description: "로그인한 IP", Sample: "203.0.113.10"},
+			{Name: "reason", Description: "사용 사유", Sample: "SSO 장애"},
+			{Name: "expiresAt", Description: "세션 만료 시각(RFC3339)", Sample: "2026-01-02T15:04:05+09:00"},
+		},
+		subject: "[Better Admin][긴급] 비상 접근 계정 사용",
+		body:    "비상 접근 계정으로 로그인했습니다.\n계정: {{.signId}}\n멤버 Id: {{.memberId}}\nIP: {{.clientIp}}\n사유: {{.reason}}\n세션 만료: {{.expiresAt}}",
+	},
+	{
+		name:        constants.MessageTemplateSignUpReminder,
+		description: "승인 대기 중인 가입 신청을 승인 역할의 멤버에게 다시 알리는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "name", Description: "신청자 이름", Sample: "홍길동"},
+			{Name: "candidateId", Description: "신청자 아이디", Sample: "hong@example.com"},
+			{Name: "days", Description: "승인 대기 일수", Sample: "3"},
+		},
+		subject: "[Better Admin] 가입 승인 요청 재알림",
+		body:    "'{{.name}}'({{.candidateId}}) 님의 가입 신청이 {{.days}} 일째 승인 대기 중입니다.",
+	},
+	{
+		name:        constants.MessageTemplateSignUpEscalated,
+		description: "승인 대기 중인 가입 신청을 상위 승인 역할의 멤버에게 넘기는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "name", Description: "신청자 이름", Sample: "홍길동"},
+			{Name: "candidateId", Description: "신청자 아이디", Sample: "hong@example.com"},
+			{Name: "days", Description: "승인 대기 일수", Sample: "7"},
+		},
+		subject: "[Better Admin] 가입 승인 요청 이관",
+		body:    "'{{.name}}'({{.candidateId}}) 님의 가입 신청이 {{.days}} 일째 승인 대기 중입니다.",
+	},
+	{
+		name:        constants.MessageTemplateSignUpExpired,
+		description: "기한 안에 승인되지 않아 자동으로 거절된 가입 신청자에게 보내는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "expiryDays", Description: "자동 거절 기한(일)", Sample: "14"},
+		},
+		subject: "[Better Admin] 가입 신청이 거절되었습니다",
+		body:    "가입 신청 후 {{.expiryDays}} 일 동안 승인되지 않아 자동으로 거절되었습니다. 필요하면 다시 가입 신청해 주세요.",
+	},
+	{
+		name:        constants.MessageTemplateNoteMentioned,
+		description: "메모에서 언급한 멤버에게 보내는 알림",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "entityType", Description: "메모 대상 종류", Sample: "member"},
+			{Name: "entityId", Description: "메모 대상 Id", Sample: "3"},
+			{Name: "authorId", Description: "작성자 Id", Sample: "1"},
+			{Name: "content", Description: "메모 내용(앞부분)", Sample: "@ymyoo 확인 부탁드립니다."},
+		},
+		subject: "[Better Admin] 메모에서 언급되었습니다",
+		body:    "메모에서 회원님을 언급했습니다.\n대상: {{.entityType}} {{.entityId}}\n작성자 Id: {{.authorId}}\n내용: {{.content}}",
+	},
+	{
+		name:        constants.MessageTemplateReportDelivered,
+		description: "리포트 실행 결과를 첨부해 수신자에게 보내는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "name", Description: "리포트 이름", Sample: "주간 가입자"},
+			{Name: "startedAt", Description: "실행 시간", Sample: "2026-01-02 15:04:05"},
+			{Name: "rowCount", Description: "행 수", Sample: "42"},
+		},
+		subject: "[리포트] {{.name}}",
+		body:    "리포트 '{{.name}}' 실행 결과를 첨부합니다.\n\n실행 시간: {{.startedAt}}\n행 수: {{.rowCount}}",
+	},
+	{
+		name:        constants.MessageTemplateFileQuarantined,
+		description: "업로드한 파일에서 바이러스가 발견되어 격리했을 때 알림 역할의 멤버에게 보내는 알림",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "fileName", Description: "파일 이름", Sample: "report.xlsx"},
+			{Name: "fileId", Description: "파일 Id", Sample: "7"},
+			{Name: "purpose", Description: "파일 용도", Sample: "attachment"},
+			{Name: "signature", Description: "진단명", Sample: "Eicar-Test-Signature"},
+			{Name: "createdBy", Description: "업로드한 멤버 Id", Sample: "2"},
+		},
+		subject: "[Better Admin] 바이러스 파일 격리",
+		body:    "업로드한 파일에서 바이러스가 발견되어 격리했습니다.\n파일: {{.fileName}}(Id: {{.fileId}})\n용도: {{.purpose}}\n진단명: {{.signature}}\n업로드한 멤버 Id: {{.createdBy}}",
+	},
+	{
+		name:        constants.MessageTemplateSecurityEvent,
+		description: "알림 규칙에 맞는 보안 이벤트를 수신자에게 보내는 메일",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "type", Description: "이벤트 유형", Sample: "brute-force"},
+			{Name: "severity", Description: "심각도", Sample: "high"},
+			{Name: "memberId", Description: "멤버 Id", Sample: "3"},
+			{Name: "ipAddress", Description: "IP", Sample: "203.0.113.10"},
+			{Name: "detail", Description: "내용", Sample: "login failed 10 times in 5 minutes"},
+			{Name: "createdAt", Description: "발생 시각(RFC3339)", Sample: "2026-01-02T15:04:05+09:00"},
+		},
+		subject: "[Better Admin][{{.severity}}] 보안 이벤트 {{.type}}",
+		body:    "보안 이벤트가 발생했습니다.\n유형: {{.type}}\n심각도: {{.severity}}\n멤버 Id: {{.memberId}}\nIP: {{.ipAddress}}\n내용: {{.detail}}\n발생 시각: {{.createdAt}}",
+	},
+	{
+		name:        constants.MessageTemplateLicenseQuotaWarning,
+		description: "라이선스 사용량이 최대 수에 가까워졌을 때 알림 역할의 멤버에게 보내는 알림",
+		variables: []dtos.MessageTemplateVariable{
+			{Name: "resource", Description: "대상(members, roles, api-keys)", Sample: "members"},
+			{Name: "usage", Description: "사용량", Sample: "90"},
+			{Name: "limit", Description: "최대 수", Sample: "100"},
+			{Name: "licensee", Description: "라이선스 사용자", Sample: "베터코드"},
+		},
+		subject: "[Better Admin] 라이선스 사용량 {{.resource}} {{.usage}}/{{.limit}}",
+		body:    "라이선스({{.licensee}})의 {{.resource}} 사용량이 최대 수에 가까워졌습니다.\n사용량: {{.usage}}\n최대 수: {{.limit}}\n최대 수를 넘으면 더 만들 수 없으므로 라이선스를 갱신해 주세요.",
+	},
+}
+
+func findMessageTemplateDefinition(name string) (messageTemplateDefinition, bool) {
+	for _, definition := range builtInMessageTemplates {
+		if definition.name == name {
+			return definition, true
+		}
+	}
+
+	return messageTemplateDefinition{}, false
+}
+
+// MessageTemplateService 는 시스템이 보내는 메일(알림)의 템플릿을 관리하고 렌더링한다.
+// 템플릿은 내장되어 있고, 관리자가 바꾼 템플릿이 있으면 바꾼 템플릿을 사용한다.
+type MessageTemplateService struct {
+	messageTemplateRepository *repository.MessageTemplateRepository
+	auditService              *AuditService
+}
+
+func NewMessageTemplateService(messageTemplateRepository *repository.MessageTemplateRepository, auditService *AuditService) *MessageTemplateService {
+	return &MessageTemplateService{
+		messageTemplateRepository: messageTemplateRepository,
+		auditService:              auditService,
+	}
+}
+
+func (s MessageTemplateService) GetMessageTemplates(ctx context.Context) ([]dtos.MessageTemplate, error) {
+	entities, err := s.messageTemplateRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	customized := map[string]domain.MessageTemplateEntity{}
+	for _, entity := range entities {
+		customized[entity.Name] = entity
+	}
+
+	templates := make([]dtos.MessageTemplate, 0, len(builtInMessageTemplates))
+	for _, definition := range builtInMessageTemplates {
+		entity, exists := customized[definition.name]
+		templates = append(templates, s.toMessageTemplate(definition, entity, exists))
+	}
+
+	return templates, nil
+}
+
+func (s MessageTemplateService) GetMessageTemplate(ctx context.Context, name string) (dtos.MessageTemplate, error) {
+	definition, entity, customized, err := s.find(ctx, name)
+	if err != nil {
+		return dtos.MessageTemplate{}, err
+	}
+
+	return s.toMessageTemplate(definition, entity, customized), nil
+}
+
+// UpdateMessageTemplate 은 템플릿을 바꾼다. 예시 값으로 렌더링할 수 없는 템플릿은 저장하지 않는다.
+func (s MessageTemplateService) UpdateMessageTemplate(ctx context.Context, name string, information dtos.MessageTemplateInformation) (dtos.MessageTemplate, error) {
+	definition, entity, _, err := s.find(ctx, name)
+	if err != nil {
+		return dtos.MessageTemplate{}, err
+	}
+
+	if err := validateMessageTemplate(definition, information.Subject, information.Body); err != nil {
+		return dtos.MessageTemplate{}, err
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return dtos.MessageTemplate{}, err
+	}
+
+	entity.Name = definition.name
+	entity.Change(information.Subject, information.Body, userClaim.Id)
+	if err := s.messageTemplateRepository.Save(ctx, &entity); err != nil {
+		return dtos.MessageTemplate{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionMessageTemplateChanged, constants.AuditTargetTypeMessageTemplate,
+		entity.ID, fmt.Sprintf("name=%s", entity.Name)); err != nil {
+		return dtos.MessageTemplate{}, err
+	}
+
+	return s.toMessageTemplate(definition, entity, true), nil
+}
+
+// ResetMessageTemplate 은 바꾼 템플릿을 지워 내장 템플릿으로 되돌린다. 바꾼 템플릿이 없으면 ErrNotFound 이다.
+func (s MessageTemplateService) ResetMessageTemplate(ctx context.Context, name string) error {
+	_, entity, customized, err := s.find(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if !customized {
+		return errors.ErrNotFound
+	}
+
+	if err := s.messageTemplateRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionMessageTemplateReset, constants.AuditTargetTypeMessageTemplate,
+		entity.ID, fmt.Sprintf("name=%s", entity.Name))
+}
+
+// PreviewMessageTemplate 은 템플릿을 저장하지 않고 예시 값(요청의 Data 가 있으면 Data)으로 렌더링한다.
+func (s MessageTemplateService) PreviewMessageTemplate(ctx context.Context, name string, request dtos.MessageTemplatePreviewRequest) (dtos.MessageTemplatePreview, error) {
+	definition, entity, customized, err := s.find(ctx, name)
+	if err != nil {
+		return dtos.MessageTemplatePreview{}, err
+	}
+
+	subject, body := definition.subject, definition.body
+	if customized {
+		subject, body = entity.Subject, entity.Body
+	}
+	if len(request.Subject) > 0 {
+		subject = request.Subject
+	}
+	if len(request.Body) > 0 {
+		body = request.Body
+	}
+
+	if err := validateMessageTemplate(definition, subject, body); err != nil {
+		return dtos.MessageTemplatePreview{}, err
+	}
+
+	data := definition.sampleData()
+	for key, value := range request.Data {
+		if _, exists := data[key]; !exists {
+			return dtos.MessageTemplatePreview{}, &errors.ErrInvalidMessageTemplate{Reason: fmt.Sprintf("unknown variable %s", key)}
+		}
+		data[key] = value
+	}
+
+	message, err := renderMail(subject, body, nil, data)
+	if err != nil {
+		return dtos.MessageTemplatePreview{}, err
+	}
+
+	return dtos.MessageTemplatePreview{Subject: message.Subject, Body: message.Body}, nil
+}
+
+// RenderMail 은 템플릿으로 메일을 만든다. 바꾼 템플릿을 조회하거나 렌더링할 수 없으면 내장 템플릿을 사용하므로 메일 발송을 막지 않는다.
+func (s MessageTemplateService) RenderMail(ctx context.Context, name string, to []string, data map[string]string) dtos.MailMessage {
+	definition, exists := findMessageTemplateDefinition(name)
+	if !exists {
+		log.Errorf("message template %s not found", name)
+		return dtos.MailMessage{To: to}
+	}
+
+	entity, err := s.messageTemplateRepository.FindByName(ctx, name)
+	if err == nil {
+		message, renderErr := renderMail(entity.Subject, entity.Body, to, data)
+		if renderErr == nil {
+			return message
+		}
+		log.Errorf("message template render error. name=%s, %v", name, renderErr)
+	} else if !errors.Is(err, errors.ErrNotFound) {
+		log.Errorf("message template find error. name=%s, %v", name, err)
+	}
+
+	message, err := renderMail(definition.subject, definition.body, to, data)
+	if err != nil {
+		log.Errorf("built-in message template render error. name=%s, %v", name, err)
+	}
+
+	return message
+}
+
+// find 는 내장 템플릿과 관리자가 바꾼 템플릿(있으면 customized)이다. 내장 템플릿에 없는 이름은 ErrNotFound 이다.
+func (s MessageTemplateService) find(ctx context.Context, name string) (messageTemplateDefinition, domain.MessageTemplateEntity, bool, error) {
+	definition, exists := findMessageTemplateDefinition(name)
+	if !exists {
+		return messageTemplateDefinition{}, domain.MessageTemplateEntity{}, false, errors.ErrNotFound
+	}
+
+	entity, err := s.messageTemplateRepository.FindByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return definition, domain.MessageTemplateEntity{}, false, nil
+		}
+		return messageTemplateDefinition{}, domain.MessageTemplateEntity{}, false, err
+	}
+
+	return definition, entity, true, nil
+}
+
+func (MessageTemplateService) toMessageTemplate(definition messageTemplateDefinition, entity domain.MessageTemplateEntity, customized bool) dtos.MessageTemplate {
+	template := dtos.MessageTemplate{
+		Name:           definition.name,
+		Description:    definition.description,
+		Variables:      definition.variables,
+		Subject:        definition.subject,
+		Body:           definition.body,
+		DefaultSubject: definition.subject,
+		DefaultBody:    definition.body,
+	}
+
+	if customized {
+		template.Subject = entity.Subject
+		template.Body = entity.Body
+		template.Customized = true
+		template.UpdatedBy = entity.UpdatedBy
+		template.UpdatedAt = &entity.UpdatedAt
+	}
+
+	return template
+}
+
+// validateMessageTemplate 은 템플릿의 길이를 확인하고 예시 값으로 렌더링해 본다.
+func validateMessageTemplate(definition messageTemplateDefinition, subject string, body string) error {
+	if utf8.RuneCountInString(subject) > constants.MessageTemplateMaxSubjectLength {
+		return &errors.ErrInvalidMessageTemplate{Reason: fmt.Sprintf("subject must be less than or equal to %v characters", constants.MessageTemplateMaxSubjectLength)}
+	}
+
+	if utf8.RuneCountInString(body) > constants.MessageTemplateMaxBodyLength {
+		return &errors.ErrInvalidMessageTemplate{Reason: fmt.Sprintf("body must be less than or equal to %v characters", constants.MessageTemplateMaxBodyLength)}
+	}
+
+	_, err := renderMail(subject, body, nil, definition.sampleData())
+	return err
+}
+
+func renderMail(subject string, body string, to []string, data map[string]string) (dtos.MailMessage, error) {
+	renderedSubject, err := domain.RenderTemplate(subject, data)
+	if err != nil {
+		return dtos.MailMessage{To: to}, err
+	}
+
+	renderedBody, err := domain.RenderTemplate(body, data)
+	if err != nil {
+		return dtos.MailMessage{To: to}, err
+	}
+
+	return dtos.MailMessage{To: to, Subject: renderedSubject, Body: renderedBody}, nil
+}
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	fileDomain "better-admin-backend-service/file/domain"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/note/domain"
+	"better-admin-backend-service/note/repository"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var noteMentionPattern = regexp.MustCompile(`(?:^|\s)@([0-9A-Za-z._+\-@]+)`)
+
+// NoteEntityChecker 는 메모를 남길 대상이 있는지, 요청한 사용자가 대상을 볼 수 있는지 확인한다.
+type NoteEntityChecker func(ctx context.Context, entityId uint) error
+
+type NoteService struct {
+	noteRepository         *repository.NoteRepository
+	memberService          *MemberService
+	preferenceService      *PreferenceService
+	fileService            *FileService
+	auditService           *AuditService
+	messageTemplateService *MessageTemplateService
+	entityCheckers         map[string]NoteEntityChecker
+}
+
+func NewNoteService(
+	noteRepository *repository.NoteRepository,
+	memberService *MemberService,
+	preferenceService *PreferenceService,
+	fileService *FileService,
+	auditService *AuditService,
+	messageTemplateService *MessageTemplateService) *NoteService {
+
+	return &NoteService{
+		noteRepository:         noteRepository,
+		memberService:          memberService,
+		preferenceService:      preferenceService,
+		fileService:            fileService,
+		auditService:           auditService,
+		messageTemplateService: messageTemplateService,
+		entityCheckers:         map[string]NoteEntityChecker{},
+	}
+}
+
+// RegisterEntityType 은 메모를 남길 수 있는 대상 유형을 등록한다.
+func (s NoteService) RegisterEntityType(entityType string, checker NoteEntityChecker) {
+	s.entityCheckers[entityType] = checker
+}
+
+// GetNotes 는 대상의 최상위 메모를 최근 순으로 조회하고, 답글은 작성 순으로 최상위 메모에 담는다. 페이지를 지정하지 않으면 모두 조회한다.
+func (s NoteService) GetNotes(ctx context.Context, entityType string, entityId uint, pageable dtos.Pageable) ([]dtos.NoteInformation, int64, error) {
+	if err := s.checkEntity(ctx, entityType, entityId); err != nil {
+		return nil, 0, err
+	}
+
+	entities, totalCount, err := s.noteRepository.FindRootNotes(ctx, entityType, entityId, pageable)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	parentIds := make([]uint, 0, len(entities))
+	for _, entity := range entities {
+		parentIds = append(parentIds, entity.ID)
+	}
+
+	replies, err := s.noteRepository.FindByParentIds(ctx, parentIds)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	noteIds := parentIds
+	for _, reply := range replies {
+		noteIds = append(noteIds, reply.ID)
+	}
+
+	attachments, err := s.fileService.GetAttachments(ctx, constants.AuditTargetTypeNote, noteIds)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	attachmentsByNoteId := map[uint][]fileDomain.AttachmentEntity{}
+	for _, attachment := range attachments {
+		attachmentsByNoteId[attachment.EntityId] = append(attachmentsByNoteId[attachment.EntityId], attachment)
+	}
+
+	repliesByParentId := map[uint][]dtos.NoteInformation{}
+	for _, reply := range replies {
+		repliesByParentId[reply.ParentId] = append(repliesByParentId[reply.ParentId], toNoteInformation(reply, attachmentsByNoteId[reply.ID]))
+	}
+
+	notes := make([]dtos.NoteInformation, 0, len(entities))
+	for _, entity := range entities {
+		note := toNoteInformation(entity, attachmentsByNoteId[entity.ID])
+		note.Replies = repliesByParentId[entity.ID]
+		notes = append(notes, note)
+	}
+
+	return notes, totalCount, nil
+}
+
+func (s NoteService) CreateNote(ctx context.Context, entityType string, entityId uint, information dtos.NoteInformation) (dtos.NoteInformation, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	if err := s.checkEntity(ctx, entityType, entityId); err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	if information.ParentId > 0 {
+		parent, err := s.noteRepository.FindById(ctx, information.ParentId)
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				return dtos.NoteInformation{}, &errors.ErrInvalidNote{Reason: "parent note not found"}
+			}
+			return dtos.NoteInformation{}, err
+		}
+
+		if parent.EntityType != entityType || parent.EntityId != entityId || parent.IsReply() {
+			return dtos.NoteInformation{}, &errors.ErrInvalidNote{Reason: "replies can only be added to a root note of the same entity"}
+		}
+	}
+
+	mentionedMembers, err := s.findMentionedMembers(ctx, information.Content)
+	if err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	entity := domain.NewNoteEntity(entityType, entityId, information.ParentId, information.Content, mentionedMemberIds(mentionedMembers), userClaim.Id)
+	if err := s.noteRepository.Create(ctx, &entity); err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	if err := s.recordAuditLog(ctx, constants.AuditActionNoteCreated, entity); err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	attachments, err := s.fileService.AttachFiles(ctx, constants.AuditTargetTypeNote, entity.ID, information.AttachmentFileIds)
+	if err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	s.notifyMentioned(ctx, entity, mentionedMembers)
+
+	return toNoteInformation(entity, attachments), nil
+}
+
+// UpdateNote 는 작성자만 할 수 있다. 새로 언급한 멤버에게만 알리고, 첨부 파일은 추가만 할 수 있다.
+func (s NoteService) UpdateNote(ctx context.Context, entityType string, entityId uint, noteId uint, information dtos.NoteInformation) (dtos.NoteInformation, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	entity, err := s.getNote(ctx, entityType, entityId, noteId)
+	if err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	mentionedMembers, err := s.findMentionedMembers(ctx, information.Content)
+	if err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	previousMentions := map[uint]bool{}
+	for _, mention := range entity.GetMentions() {
+		previousMentions[mention] = true
+	}
+
+	if err := entity.Edit(information.Content, mentionedMemberIds(mentionedMembers), userClaim.Id); err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	if err := s.noteRepository.Save(ctx, &entity); err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	if err := s.recordAuditLog(ctx, constants.AuditActionNoteUpdated, entity); err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	if _, err := s.fileService.AttachFiles(ctx, constants.AuditTargetTypeNote, entity.ID, information.AttachmentFileIds); err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	attachments, err := s.fileService.GetAttachments(ctx, constants.AuditTargetTypeNote, []uint{entity.ID})
+	if err != nil {
+		return dtos.NoteInformation{}, err
+	}
+
+	newlyMentioned := make([]memberDomain.MemberEntity, 0)
+	for _, member := range mentionedMembers {
+		if !previousMentions[member.ID] {
+			newlyMentioned = append(newlyMentioned, member)
+		}
+	}
+	s.notifyMentioned(ctx, entity, newlyMentioned)
+
+	return toNoteInformation(entity, attachments), nil
+}
+
+// DeleteNote 는 작성자 또는 시스템 설정 관리 권한이 있는 관리자만 할 수 있다. 최상위 메모를 삭제하면 답글과 첨부 파일도 함께 삭제한다.
+func (s NoteService) DeleteNote(ctx context.Context, entityType string, entityId uint, noteId uint) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	entity, err := s.getNote(ctx, entityType, entityId, noteId)
+	if err != nil {
+		return err
+	}
+
+	if (userClaim.IsServiceAccount() || entity.CreatedBy != userClaim.Id) && !hasAnyClaimPermission(ctx, []string{constants.PermissionManageSystemSettings}) {
+		return errors.ErrForbidden
+	}
+
+	replies, err := s.noteRepository.FindByParentIds(ctx, []uint{entity.ID})
+	if err != nil {
+		return err
+	}
+
+	noteIds := []uint{entity.ID}
+	for _, reply := range replies {
+		noteIds = append(noteIds, reply.ID)
+	}
+
+	if err := s.fileService.DeleteAttachments(ctx, constants.AuditTargetTypeNote, noteIds); err != nil {
+		return err
+	}
+
+	if err := s.noteRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.recordAuditLog(ctx, constants.AuditActionNoteDeleted, entity)
+}
+
+// OpenNoteAttachment 는 메모의 첨부 파일 내용을 연다. 메모를 남긴 대상을 볼 수 있어야 한다.
+func (s NoteService) OpenNoteAttachment(ctx context.Context, entityType string, entityId uint, noteId uint, attachmentId uint) (fileDomain.AttachmentEntity, io.ReadCloser, error) {
+	if _, err := s.getNote(ctx, entityType, entityId, noteId); err != nil {
+		return fileDomain.AttachmentEntity{}, nil, err
+	}
+
+	return s.fileService.OpenAttachmentContent(ctx, constants.AuditTargetTypeNote, noteId, attachmentId)
+}
+
+func (s NoteService) getNote(ctx context.Context, entityType string, entityId uint, noteId uint) (domain.NoteEntity, error) {
+	if err := s.checkEntity(ctx, entityType, entityId); err != nil {
+		return domain.NoteEntity{}, err
+	}
+
+	entity, err := s.noteRepository.FindById(ctx, noteId)
+	if err != nil {
+		return domain.NoteEntity{}, err
+	}
+
+	if entity.EntityType != entityType || entity.EntityId != entityId {
+		return domain.NoteEntity{}, errors.ErrNotFound
+	}
+
+	return entity, nil
+}
+
+func (s NoteService) checkEntity(ctx context.Context, entityType string, entityId uint) error {
+	checker, ok := s.entityCheckers[entityType]
+	if !ok {
+		return errors.ErrNotFound
+	}
+
+	return checker(ctx, entityId)
+}
+
+// recordAuditLog 는 메모의 대상을 감사 대상으로 기록하여 대상(예. 멤버)의 활동 피드에 보이게 한다.
+func (s NoteService) recordAuditLog(ctx context.Context, action string, entity domain.NoteEntity) error {
+	detail := fmt.Sprintf("noteId=%v, parentId=%v, content=%v", entity.ID, entity.ParentId, excerptNoteContent(entity.Content))
+	return s.auditService.RecordAuditLog(ctx, action, entity.EntityType, entity.EntityId, detail)
+}
+
+// findMentionedMembers 는 내용에서 @signId 로 언급한 멤버를 찾는다. 없는 아이디는 무시한다.
+func (s NoteService) findMentionedMembers(ctx context.Context, content string) ([]memberDomain.MemberEntity, error) {
+	members := make([]memberDomain.MemberEntity, 0)
+	found := map[uint]bool{}
+	for _, match := range noteMentionPattern.FindAllStringSubmatch(content, -1) {
+		signId := strings.TrimRight(match[1], ".,")
+		if len(signId) == 0 {
+			continue
+		}
+
+		member, err := s.memberService.GetMemberBySignId(ctx, signId)
+		if err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		if found[member.ID] {
+			continue
+		}
+		found[member.ID] = true
+		members = append(members, member)
+
+		if len(members) > constants.NoteMaxMentions {
+			return nil, &errors.ErrInvalidNote{Reason: fmt.Sprintf("mentions must be at most %v", constants.NoteMaxMentions)}
+		}
+	}
+
+	return members, nil
+}
+
+// notifyMentioned 는 언급한 멤버에게 메일을 보낸다. 자신을 언급했거나 알림을 받지 않기로 한 멤버는 제외한다.
+func (s NoteService) notifyMentioned(ctx context.Context, entity domain.NoteEntity, members []memberDomain.MemberEntity) {
+	for _, member := range members {
+		if member.ID == entity.UpdatedBy {
+			continue
+		}
+
+		email := member.GetEmail()
+		if len(email) == 0 {
+			continue
+		}
+
+		optedOut, err := s.preferenceService.IsNotificationOptedOut(ctx, member.ID, constants.NotificationTypeNoteMention)
+		if err != nil {
+			log.Error("note mention notification error: ", err)
+			continue
+		}
+		if optedOut {
+			continue
+		}
+
+		message := s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateNoteMentioned, []string{email}, map[string]string{
+			"entityType": entity.EntityType,
+			"entityId":   fmt.Sprint(entity.EntityId),
+			"authorId":   fmt.Sprint(entity.UpdatedBy),
+			"content":    excerptNoteContent(entity.Content),
+		})
+		helpers.ContextHelper().AfterCommit(ctx, func() {
+			if err := adapters.MailAdapter().Send(message); err != nil {
+				log.Error("note mention notification error: ", err)
+			}
+		})
+	}
+}
+
+func mentionedMemberIds(members []memberDomain.MemberEntity) []uint {
+	ids := make([]uint, 0, len(members))
+	for _, member := range members {
+		ids = append(ids, member.ID)
+	}
+
+	return ids
+}
+
+// excerptNoteContent 는 감사 로그와 알림에 남길 내용 앞부분이다.
+func excerptNoteContent(content string) string {
+	const maxLength = 100
+	if utf8.RuneCountInString(content) <= maxLength {
+		return content
+	}
+
+	return string([]rune(content)[:maxLength]) + "..."
+}
+
+func toNoteInformation(entity domain.NoteEntity, attachments []fileDomain.AttachmentEntity) dtos.NoteInformation {
+	return dtos.NoteInformation{
+		Id:          entity.ID,
+		EntityType:  entity.EntityType,
+		EntityId:    entity.EntityId,
+		ParentId:    entity.ParentId,
+		Content:     entity.Content,
+		Mentions:    entity.GetMentions(),
+		Attachments: fileDomain.ToAttachmentInformations(attachments),
+		CreatedBy:   entity.CreatedBy,
+		UpdatedBy:   entity.UpdatedBy,
+		EditedAt:    entity.EditedAt,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
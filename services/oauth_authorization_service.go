@@ -0,0 +1,354 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/oauth/domain"
+	"better-admin-backend-service/oauth/repository"
+	"better-admin-backend-service/security"
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type OAuthAuthorizationService struct {
+	oauthClientService          *OAuthClientService
+	consentService              *ConsentService
+	memberService               *MemberService
+	organizationService         *OrganizationService
+	authorizationCodeRepository *repository.OAuthAuthorizationCodeRepository
+	deviceCodeRepository        *repository.OAuthDeviceCodeRepository
+}
+
+func NewOAuthAuthorizationService(oauthClientService *OAuthClientService, consentService *ConsentService,
+	memberService *MemberService, organizationService *OrganizationService,
+	authorizationCodeRepository *repository.OAuthAuthorizationCodeRepository,
+	deviceCodeRepository *repository.OAuthDeviceCodeRepository) *OAuthAuthorizationService {
+	return &OAuthAuthorizationService{
+		oauthClientService:          oauthClientService,
+		consentService:              consentService,
+		memberService:               memberService,
+		organizationService:         organizationService,
+		authorizationCodeRepository: authorizationCodeRepository,
+		deviceCodeRepository:        deviceCodeRepository,
+	}
+}
+
+// Authorize 는 로그인한 멤버가 동의한 Client 에 인가 코드를 발급한다.
+// redirect_uri 는 등록된 주소여야 하며 code_challenge 는 Client 의 PKCE 정책을 따른다. 서비스 계정은 인가할 수 없다(ErrAuthentication).
+func (s OAuthAuthorizationService) Authorize(ctx context.Context, request dtos.OAuthAuthorizationRequest) (dtos.OAuthAuthorizationCode, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return dtos.OAuthAuthorizationCode{}, err
+	}
+
+	client, err := s.oauthClientService.GetOAuthClientByClientId(ctx, request.ClientId)
+	if err != nil {
+		return dtos.OAuthAuthorizationCode{}, err
+	}
+
+	if err := client.ValidateRedirectUri(request.RedirectUri); err != nil {
+		return dtos.OAuthAuthorizationCode{}, err
+	}
+
+	consent, err := s.consentService.GetConsent(ctx, request.ClientId, strings.Fields(request.Scope))
+	if err != nil {
+		return dtos.OAuthAuthorizationCode{}, err
+	}
+
+	if consent.ConsentRequired {
+		return dtos.OAuthAuthorizationCode{}, errors.ErrConsentRequired
+	}
+
+	codeChallengeMethod, err := client.ValidateCodeChallenge(request.CodeChallenge, request.CodeChallengeMethod)
+	if err != nil {
+		return dtos.OAuthAuthorizationCode{}, err
+	}
+
+	lifetimeSeconds := config.Config.AuthorizationCode.LifetimeSeconds
+	entity, code, err := domain.NewOAuthAuthorizationCodeEntity(client, userClaim.Id, request.RedirectUri, consent.RequestedScopes,
+		request.CodeChallenge, codeChallengeMethod, time.Duration(lifetimeSeconds)*time.Second)
+	if err != nil {
+		return dtos.OAuthAuthorizationCode{}, err
+	}
+
+	if err := s.authorizationCodeRepository.Create(ctx, &entity); err != nil {
+		return dtos.OAuthAuthorizationCode{}, err
+	}
+
+	redirectUri, err := url.Parse(request.RedirectUri)
+	if err != nil {
+		return dtos.OAuthAuthorizationCode{}, &errors.ErrInvalidAuthorizationRequest{Reason: "redirect_uri is invalid"}
+	}
+	query := redirectUri.Query()
+	query.Set("code", code)
+	if len(request.State) > 0 {
+		query.Set("state", request.State)
+	}
+	redirectUri.RawQuery = query.Encode()
+
+	return dtos.OAuthAuthorizationCode{
+		Code:        code,
+		State:       request.State,
+		RedirectUri: redirectUri.String(),
+		ExpiresIn:   lifetimeSeconds,
+	}, nil
+}
+
+// ExchangeAuthorizationCode 는 인가 코드(RFC 6749 4.1.3) 그랜트로 코드를 발급받은 멤버의 Access 토큰을 발급한다.
+// 코드는 한 번만 교환할 수 있으며 PKCE 로 발급한 코드는 code_verifier 가 맞아야 한다.
+func (s OAuthAuthorizationService) ExchangeAuthorizationCode(ctx context.Context, request dtos.ClientCredentialsTokenRequest) (dtos.OAuthToken, error) {
+	client, err := s.oauthClientService.GetOAuthClientByClientId(ctx, request.ClientId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.OAuthToken{}, errors.ErrAuthentication
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	entity, err := s.authorizationCodeRepository.FindByCodeHash(ctx, domain.HashAuthorizationCode(request.Code))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.OAuthToken{}, errors.ErrInvalidGrant
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	now := time.Now()
+	if entity.OAuthClientId != client.ID || entity.RedirectUri != request.RedirectUri ||
+		!entity.IsUsable(now) || !entity.VerifyCodeVerifier(request.CodeVerifier) {
+		return dtos.OAuthToken{}, errors.ErrInvalidGrant
+	}
+
+	entity.MarkUsed(now)
+	if err := s.authorizationCodeRepository.MarkUsed(ctx, entity); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.OAuthToken{}, errors.ErrInvalidGrant
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	return s.issueMemberAccessToken(ctx, client, entity.MemberId, entity.GetScopes())
+}
+
+// issueMemberAccessToken 은 멤버가 인가한 Client 에 멤버의 역할로 Access 토큰을 발급한다.
+// 권한은 인가한 scope 중 멤버가 가진 권한 이름(예. MANAGE_MEMBERS)만 넣으므로 profile 같은 scope 만 인가한 토큰으로는 관리 API 를 호출할 수 없다.
+func (s OAuthAuthorizationService) issueMemberAccessToken(ctx context.Context, client domain.OAuthClientEntity, memberId uint,
+	scopes []string) (dtos.OAuthToken, error) {
+	memberEntity, err := s.memberService.GetMember(ctx, memberId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.OAuthToken{}, errors.ErrInvalidGrant
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	if !memberEntity.IsApproved() {
+		return dtos.OAuthToken{}, errors.ErrInvalidGrant
+	}
+
+	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	lifetimeSeconds := config.Config.AuthorizationCode.AccessTokenLifetimeSeconds
+	scope := strings.Join(scopes, " ")
+	accessToken, _, err := security.JwtAuthentication{}.GenerateJwtAccessToken(security.UserClaim{
+		Id:          memberEntity.ID,
+		Roles:       memberAssignedAllRoleAndPermission.Roles,
+		Permissions: permissionsOfScopes(memberAssignedAllRoleAndPermission.Permissions, scopes),
+		Extra: map[string]interface{}{
+			constants.OAuthClaimKeyClientId: client.ClientId,
+			constants.OAuthClaimKeyScope:    scope,
+		},
+	}, time.Duration(lifetimeSeconds)*time.Second)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	return dtos.OAuthToken{
+		AccessToken: accessToken,
+		TokenType:   constants.OAuthTokenTypeBearer,
+		ExpiresIn:   lifetimeSeconds,
+		Scope:       scope,
+	}, nil
+}
+
+// permissionsOfScopes 는 멤버의 권한 중 scope 로 인가한 권한이다.
+func permissionsOfScopes(memberPermissions []string, scopes []string) []string {
+	granted := make(map[string]bool)
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	permissions := make([]string, 0)
+	for _, permission := range memberPermissions {
+		if granted[permission] {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	return permissions
+}
+
+// StartDeviceAuthorization 은 기기 인가(RFC 8628 3.1) 요청으로 기기 코드와 멤버가 관리 화면에 입력할 사용자 코드를 발급한다.
+func (s OAuthAuthorizationService) StartDeviceAuthorization(ctx context.Context, request dtos.DeviceAuthorizationRequest) (dtos.DeviceAuthorization, error) {
+	client, err := s.oauthClientService.GetOAuthClientByClientId(ctx, request.ClientId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.DeviceAuthorization{}, errors.ErrAuthentication
+		}
+
+		return dtos.DeviceAuthorization{}, err
+	}
+
+	scopes := strings.Fields(request.Scope)
+	if len(scopes) == 0 {
+		scopes = client.GetScopes()
+	}
+
+	if err := client.ValidateScopes(scopes); err != nil {
+		return dtos.DeviceAuthorization{}, err
+	}
+
+	deviceAuthorizationConfig := config.Config.DeviceAuthorization
+	entity, deviceCode, err := domain.NewOAuthDeviceCodeEntity(client, scopes,
+		time.Duration(deviceAuthorizationConfig.LifetimeSeconds)*time.Second, deviceAuthorizationConfig.IntervalSeconds)
+	if err != nil {
+		return dtos.DeviceAuthorization{}, err
+	}
+
+	if err := s.deviceCodeRepository.Create(ctx, &entity); err != nil {
+		return dtos.DeviceAuthorization{}, err
+	}
+
+	return dtos.DeviceAuthorization{
+		DeviceCode:              deviceCode,
+		UserCode:                entity.UserCode,
+		VerificationUri:         deviceAuthorizationConfig.VerificationUri,
+		VerificationUriComplete: deviceAuthorizationConfig.VerificationUri + "?user_code=" + url.QueryEscape(entity.UserCode),
+		ExpiresIn:               deviceAuthorizationConfig.LifetimeSeconds,
+		Interval:                deviceAuthorizationConfig.IntervalSeconds,
+	}, nil
+}
+
+// GetDeviceAuthorization 은 관리 화면에서 멤버가 입력한 사용자 코드의 기기 인가 요청을 찾는다. 이미 처리했거나 만료된 요청은 ErrNotFound 이다.
+func (s OAuthAuthorizationService) GetDeviceAuthorization(ctx context.Context, userCode string) (dtos.OAuthDeviceAuthorizationInformation, error) {
+	entity, client, err := s.findPendingDeviceCode(ctx, userCode)
+	if err != nil {
+		return dtos.OAuthDeviceAuthorizationInformation{}, err
+	}
+
+	return dtos.OAuthDeviceAuthorizationInformation{
+		Client: dtos.OAuthClientSummary{
+			ClientId:    client.ClientId,
+			Name:        client.Name,
+			Description: client.Description,
+		},
+		UserCode:  entity.UserCode,
+		Scopes:    entity.GetScopes(),
+		ExpiresAt: entity.ExpiresAt,
+	}, nil
+}
+
+// DecideDeviceAuthorization 은 로그인한 멤버가 기기 인가 요청을 승인하거나 거부한다.
+// 자사(Trusted)가 아닌 Client 를 승인하면 요청한 scope 에 동의한 것으로 기록한다. 서비스 계정은 승인할 수 없다(ErrAuthentication).
+func (s OAuthAuthorizationService) DecideDeviceAuthorization(ctx context.Context, userCode string, approval dtos.OAuthDeviceApproval) error {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	entity, client, err := s.findPendingDeviceCode(ctx, userCode)
+	if err != nil {
+		return err
+	}
+
+	if !approval.Approved {
+		entity.Deny(userClaim.Id)
+		return s.deviceCodeRepository.Save(ctx, &entity)
+	}
+
+	if !client.Trusted && len(entity.GetScopes()) > 0 {
+		if err := s.consentService.GrantConsent(ctx, dtos.MemberConsentGrant{ClientId: client.ClientId, Scopes: entity.GetScopes()}); err != nil {
+			return err
+		}
+	}
+
+	entity.Approve(userClaim.Id)
+	return s.deviceCodeRepository.Save(ctx, &entity)
+}
+
+func (s OAuthAuthorizationService) findPendingDeviceCode(ctx context.Context, userCode string) (domain.OAuthDeviceCodeEntity, domain.OAuthClientEntity, error) {
+	entity, err := s.deviceCodeRepository.FindByUserCode(ctx, domain.NormalizeUserCode(userCode))
+	if err != nil {
+		return entity, domain.OAuthClientEntity{}, err
+	}
+
+	if !entity.IsPending(time.Now()) {
+		return entity, domain.OAuthClientEntity{}, errors.ErrNotFound
+	}
+
+	client, err := s.oauthClientService.GetOAuthClient(ctx, entity.OAuthClientId)
+	if err != nil {
+		return entity, client, err
+	}
+
+	return entity, client, nil
+}
+
+// ExchangeDeviceCode 는 기기 인가(RFC 8628 3.4) 그랜트로 멤버가 승인한 기기 코드를 멤버의 Access 토큰으로 바꾼다.
+// 아직 승인하지 않았거나 거부, 만료된 경우 그 이유(ErrAuthorizationPending, ErrSlowDown, ErrAccessDenied, ErrExpired)를 반환한다.
+func (s OAuthAuthorizationService) ExchangeDeviceCode(ctx context.Context, request dtos.ClientCredentialsTokenRequest) (dtos.OAuthToken, error) {
+	client, err := s.oauthClientService.GetOAuthClientByClientId(ctx, request.ClientId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.OAuthToken{}, errors.ErrAuthentication
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	entity, err := s.deviceCodeRepository.FindByDeviceCodeHash(ctx, domain.HashAuthorizationCode(request.DeviceCode))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.OAuthToken{}, errors.ErrInvalidGrant
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	if entity.OAuthClientId != client.ID {
+		return dtos.OAuthToken{}, errors.ErrInvalidGrant
+	}
+
+	now := time.Now()
+	if err := entity.Poll(now); err != nil {
+		if errors.Is(err, errors.ErrAuthorizationPending) || errors.Is(err, errors.ErrSlowDown) {
+			if err := s.deviceCodeRepository.Save(ctx, &entity); err != nil {
+				return dtos.OAuthToken{}, err
+			}
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	entity.MarkUsed(now)
+	if err := s.deviceCodeRepository.MarkUsed(ctx, entity); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.OAuthToken{}, errors.ErrInvalidGrant
+		}
+
+		return dtos.OAuthToken{}, err
+	}
+
+	return s.issueMemberAccessToken(ctx, client, entity.MemberId, entity.GetScopes())
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/oauth/domain"
+	"better-admin-backend-service/oauth/repository"
+	"context"
+)
+
+type OAuthClientService struct {
+	oauthClientRepository *repository.OAuthClientRepository
+}
+
+func NewOAuthClientService(oauthClientRepository *repository.OAuthClientRepository) *OAuthClientService {
+	return &OAuthClientService{
+		oauthClientRepository: oauthClientRepository,
+	}
+}
+
+func (s OAuthClientService) CreateOAuthClient(ctx context.Context, information dtos.OAuthClientInformation) error {
+	entity, err := domain.NewOAuthClientEntity(ctx, information)
+	if err != nil {
+		return err
+	}
+
+	return s.oauthClientRepository.Create(ctx, &entity)
+}
+
+func (s OAuthClientService) GetOAuthClients(ctx context.Context, pageable dtos.Pageable) ([]domain.OAuthClientEntity, int64, error) {
+	return s.oauthClientRepository.FindAll(ctx, pageable)
+}
+
+func (s OAuthClientService) GetOAuthClient(ctx context.Context, id uint) (domain.OAuthClientEntity, error) {
+	return s.oauthClientRepository.FindById(ctx, id)
+}
+
+func (s OAuthClientService) GetOAuthClientByClientId(ctx context.Context, clientId string) (domain.OAuthClientEntity, error) {
+	return s.oauthClientRepository.FindByClientId(ctx, clientId)
+}
+
+func (s OAuthClientService) UpdateOAuthClient(ctx context.Context, id uint, information dtos.OAuthClientInformation) error {
+	entity, err := s.oauthClientRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, information); err != nil {
+		return err
+	}
+
+	return s.oauthClientRepository.Save(ctx, &entity)
+}
+
+func (s OAuthClientService) DeleteOAuthClient(ctx context.Context, id uint) error {
+	entity, err := s.oauthClientRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, dtos.OAuthClientInformation{
+		Name:         entity.Name,
+		Description:  entity.Description,
+		RedirectUris: entity.GetRedirectUris(),
+		Scopes:       entity.GetScopes(),
+		Trusted:      entity.Trusted,
+		PkcePolicy:   entity.PkcePolicy,
+	}); err != nil {
+		return err
+	}
+
+	return s.oauthClientRepository.Delete(ctx, entity)
+}
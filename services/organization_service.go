@@ -5,6 +5,7 @@ import (
 	"better-admin-backend-service/helpers"
 	memberDomain "better-admin-backend-service/member/domain"
 	"better-admin-backend-service/organization/domain"
+	"better-admin-backend-service/organization/factory"
 	"better-admin-backend-service/organization/repository"
 	"context"
 	"github.com/wesovilabs/koazee"
@@ -143,6 +144,51 @@ func (s OrganizationService) AssignMembers(ctx context.Context, organizationId u
 	return s.organizationRepository.Save(ctx, &organizationEntity)
 }
 
+// AddMember 는 조직의 기존 멤버를 유지한 채 멤버 한 명을 추가한다.
+func (s OrganizationService) AddMember(ctx context.Context, organizationId uint, memberEntity memberDomain.MemberEntity) error {
+	organizationEntity, err := s.organizationRepository.FindById(ctx, organizationId)
+	if err != nil {
+		return err
+	}
+
+	organizationEntity.AddMember(memberEntity)
+	return s.organizationRepository.Save(ctx, &organizationEntity)
+}
+
+func (s OrganizationService) AssignLeaders(ctx context.Context, organizationId uint, assignLeader dtos.OrganizationAssignLeader) error {
+	organizationEntity, err := s.organizationRepository.FindById(ctx, organizationId)
+	if err != nil {
+		return err
+	}
+
+	findMemberEntities := make([]memberDomain.MemberEntity, 0)
+	if len(assignLeader.MemberIds) > 0 {
+		filters := map[string]interface{}{}
+		filters["memberIds"] = assignLeader.MemberIds
+
+		findMemberEntities, _, err = s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = organizationEntity.AssignLeader(ctx, findMemberEntities)
+	if err != nil {
+		return err
+	}
+
+	return s.organizationRepository.Save(ctx, &organizationEntity)
+}
+
+func (s OrganizationService) GetOrganizationChart(ctx context.Context) ([]dtos.OrganizationChartNode, error) {
+	entities, err := s.GetAllOrganizations(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return factory.NewOrganizationChartFromEntities(entities), nil
+}
+
 func (s OrganizationService) ChangeOrganizationName(ctx context.Context, organizationId uint, organizationName string) error {
 	organizationEntity, err := s.organizationRepository.FindById(ctx, organizationId)
 	if err != nil {
@@ -157,6 +203,19 @@ func (s OrganizationService) ChangeOrganizationName(ctx context.Context, organiz
 	return s.organizationRepository.Save(ctx, &organizationEntity)
 }
 
+func (s OrganizationService) ChangeSettingOverrides(ctx context.Context, organizationId uint, overrides dtos.OrganizationSettingOverrides) error {
+	organizationEntity, err := s.organizationRepository.FindById(ctx, organizationId)
+	if err != nil {
+		return err
+	}
+
+	if err = organizationEntity.ChangeSettingOverrides(ctx, overrides); err != nil {
+		return err
+	}
+
+	return s.organizationRepository.Save(ctx, &organizationEntity)
+}
+
 func (s OrganizationService) GetMemberAssignedAllRoleAndPermission(ctx context.Context, member memberDomain.MemberEntity) (dtos.MemberAssignedAllRoleAndPermission, error) {
 	memberAssignedAllRoleAndPermission := dtos.MemberAssignedAllRoleAndPermission{}
 
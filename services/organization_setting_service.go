@@ -0,0 +1,421 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/organization/domain"
+	"better-admin-backend-service/security"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var organizationLoginMethods = []string{
+	constants.LoginMethodPassword,
+	constants.LoginMethodDooray,
+	constants.LoginMethodGoogleWorkspace,
+	constants.LoginMethodSaml,
+	constants.LoginMethodLdap,
+	constants.LoginMethodPasskey,
+}
+
+// OrganizationSettingService 는 조직에서 바꿀 수 있는 사이트 설정(비밀번호 정책, 세션 유지 시간, 로그인 방법)을 관리한다.
+// 조직에서 바꾸지 않은 설정은 상위 조직의 설정을, 상위 조직도 바꾸지 않았으면 사이트 설정을 사용한다.
+type OrganizationSettingService struct {
+	siteService         *SiteService
+	organizationService *OrganizationService
+	loginSettingService *LoginSettingService
+	auditService        *AuditService
+}
+
+func NewOrganizationSettingService(
+	siteService *SiteService,
+	organizationService *OrganizationService,
+	loginSettingService *LoginSettingService,
+	auditService *AuditService) *OrganizationSettingService {
+
+	return &OrganizationSettingService{
+		siteService:         siteService,
+		organizationService: organizationService,
+		loginSettingService: loginSettingService,
+		auditService:        auditService,
+	}
+}
+
+// GetPasswordPolicySetting 은 사이트의 비밀번호 정책을 반환한다. 설정하지 않았으면 제한하지 않는다.
+func (s OrganizationSettingService) GetPasswordPolicySetting(ctx context.Context) (dtos.PasswordPolicySetting, error) {
+	passwordPolicySetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyPasswordPolicy)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.PasswordPolicySetting{}, nil
+		}
+		return dtos.PasswordPolicySetting{}, err
+	}
+
+	var setting dtos.PasswordPolicySetting
+	if err = mapstructure.Decode(passwordPolicySetting, &setting); err != nil {
+		return dtos.PasswordPolicySetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s OrganizationSettingService) SetPasswordPolicySetting(ctx context.Context, setting dtos.PasswordPolicySetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyPasswordPolicy, setting)
+}
+
+// GetSessionLifetimeSetting 은 사이트의 세션 유지 시간을 반환한다. 설정하지 않았으면 Refresh 토큰의 유효 기간(7일)이다.
+func (s OrganizationSettingService) GetSessionLifetimeSetting(ctx context.Context) (dtos.SessionLifetimeSetting, error) {
+	sessionLifetimeSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeySessionLifetime)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.SessionLifetimeSetting{LifetimeMinutes: int(security.RefreshTokenLifetime / time.Minute)}, nil
+		}
+		return dtos.SessionLifetimeSetting{}, err
+	}
+
+	var setting dtos.SessionLifetimeSetting
+	if err = mapstructure.Decode(sessionLifetimeSetting, &setting); err != nil {
+		return dtos.SessionLifetimeSetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s OrganizationSettingService) SetSessionLifetimeSetting(ctx context.Context, setting dtos.SessionLifetimeSetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeySessionLifetime, setting)
+}
+
+// GetSettingOverrides 는 조직에서 바꾼 설정이다.
+func (s OrganizationSettingService) GetSettingOverrides(ctx context.Context, organizationId uint) (dtos.OrganizationSettingOverrides, error) {
+	entity, err := s.organizationService.GetOrganization(ctx, organizationId)
+	if err != nil {
+		return dtos.OrganizationSettingOverrides{}, err
+	}
+
+	return entity.GetSettingOverrides()
+}
+
+// ChangeSettingOverrides 는 조직에서 바꾼 설정을 덮어쓴다. nil 인 설정은 상위 조직의 설정을 사용한다.
+func (s OrganizationSettingService) ChangeSettingOverrides(ctx context.Context, organizationId uint, overrides dtos.OrganizationSettingOverrides) (dtos.OrganizationSettingOverrides, error) {
+	if err := s.validateSettingOverrides(overrides); err != nil {
+		return dtos.OrganizationSettingOverrides{}, err
+	}
+
+	if err := s.organizationService.ChangeSettingOverrides(ctx, organizationId, overrides); err != nil {
+		return dtos.OrganizationSettingOverrides{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionOrganizationSettingChanged, constants.AuditTargetTypeOrganization, organizationId,
+		fmt.Sprintf("passwordPolicy=%v, sessionLifetime=%v, authMethods=%v",
+			overrides.PasswordPolicy != nil, overrides.SessionLifetime != nil, overrides.AuthMethods)); err != nil {
+		return dtos.OrganizationSettingOverrides{}, err
+	}
+
+	return overrides, nil
+}
+
+func (s OrganizationSettingService) validateSettingOverrides(overrides dtos.OrganizationSettingOverrides) error {
+	if policy := overrides.PasswordPolicy; policy != nil && (policy.MinLength < 0 || policy.MinLength > constants.PasswordPolicyMaxMinLength) {
+		return &errors.ErrInvalidOrganizationSetting{Reason: fmt.Sprintf("password min length must be between 0 and %v", constants.PasswordPolicyMaxMinLength)}
+	}
+
+	if lifetime := overrides.SessionLifetime; lifetime != nil && (lifetime.LifetimeMinutes < 1 || lifetime.LifetimeMinutes > constants.SessionLifetimeMaxMinutes) {
+		return &errors.ErrInvalidOrganizationSetting{Reason: fmt.Sprintf("session lifetime must be between 1 and %v minutes", constants.SessionLifetimeMaxMinutes)}
+	}
+
+	if overrides.AuthMethods == nil {
+		return nil
+	}
+
+	if len(overrides.AuthMethods) == 0 {
+		return &errors.ErrInvalidOrganizationSetting{Reason: "at least one login method must be allowed"}
+	}
+
+	methods := make(map[string]bool)
+	for _, method := range overrides.AuthMethods {
+		if !containsString(organizationLoginMethods, method) {
+			return &errors.ErrInvalidOrganizationSetting{Reason: "unknown login method: " + method}
+		}
+		if methods[method] {
+			return &errors.ErrInvalidOrganizationSetting{Reason: "duplicated login method: " + method}
+		}
+		methods[method] = true
+	}
+
+	return nil
+}
+
+// GetEffectiveSettings 는 조직에 적용되는 설정과 설정 값을 정한 단계(조직, 사이트)이다.
+func (s OrganizationSettingService) GetEffectiveSettings(ctx context.Context, organizationId uint) (dtos.OrganizationEffectiveSettings, error) {
+	organizations, err := s.organizationService.GetAllOrganizations(ctx, nil)
+	if err != nil {
+		return dtos.OrganizationEffectiveSettings{}, err
+	}
+
+	global, err := s.getGlobalSettings(ctx)
+	if err != nil {
+		return dtos.OrganizationEffectiveSettings{}, err
+	}
+
+	return s.resolveSettings(organizationId, organizations, global)
+}
+
+// getGlobalSettings 는 조직에서 바꾸지 않았을 때 사용하는 사이트 설정이다. 로그인 방법은 로그인 설정에서 사용하는 방법이다.
+func (s OrganizationSettingService) getGlobalSettings(ctx context.Context) (dtos.OrganizationEffectiveSettings, error) {
+	globalSource := dtos.OrganizationSettingSource{Level: constants.OrganizationSettingLevelGlobal}
+
+	passwordPolicy, err := s.GetPasswordPolicySetting(ctx)
+	if err != nil {
+		return dtos.OrganizationEffectiveSettings{}, err
+	}
+
+	sessionLifetime, err := s.GetSessionLifetimeSetting(ctx)
+	if err != nil {
+		return dtos.OrganizationEffectiveSettings{}, err
+	}
+
+	loginSetting, err := s.loginSettingService.GetLoginSetting(ctx)
+	if err != nil {
+		return dtos.OrganizationEffectiveSettings{}, err
+	}
+
+	authMethods := make([]string, 0)
+	for _, method := range loginSetting.Methods {
+		if method.Enabled != nil && *method.Enabled {
+			authMethods = append(authMethods, method.Type)
+		}
+	}
+
+	return dtos.OrganizationEffectiveSettings{
+		PasswordPolicy:  dtos.EffectivePasswordPolicySetting{Value: passwordPolicy, Source: globalSource},
+		SessionLifetime: dtos.EffectiveSessionLifetimeSetting{Value: sessionLifetime, Source: globalSource},
+		AuthMethods:     dtos.EffectiveAuthMethodsSetting{Value: authMethods, Source: globalSource},
+	}, nil
+}
+
+// resolveSettings 는 조직에서 상위 조직 순서로 올라가며 설정마다 처음으로 바꾼 조직의 값을 사용한다.
+func (s OrganizationSettingService) resolveSettings(organizationId uint, organizations []domain.OrganizationEntity,
+	global dtos.OrganizationEffectiveSettings) (dtos.OrganizationEffectiveSettings, error) {
+
+	settings := global
+	settings.OrganizationId = organizationId
+
+	var passwordPolicyResolved, sessionLifetimeResolved, authMethodsResolved bool
+	visited := make(map[uint]bool)
+	currentId := &organizationId
+	for currentId != nil && !visited[*currentId] {
+		visited[*currentId] = true
+
+		organization, ok := findOrganization(organizations, *currentId)
+		if !ok {
+			if *currentId == organizationId {
+				return dtos.OrganizationEffectiveSettings{}, errors.ErrNotFound
+			}
+			break
+		}
+
+		overrides, err := organization.GetSettingOverrides()
+		if err != nil {
+			return dtos.OrganizationEffectiveSettings{}, err
+		}
+
+		source := dtos.OrganizationSettingSource{
+			Level:            constants.OrganizationSettingLevelOrganization,
+			OrganizationId:   organization.ID,
+			OrganizationName: organization.Name,
+		}
+		if !passwordPolicyResolved && overrides.PasswordPolicy != nil {
+			settings.PasswordPolicy = dtos.EffectivePasswordPolicySetting{Value: *overrides.PasswordPolicy, Source: source}
+			passwordPolicyResolved = true
+		}
+		if !sessionLifetimeResolved && overrides.SessionLifetime != nil {
+			settings.SessionLifetime = dtos.EffectiveSessionLifetimeSetting{Value: *overrides.SessionLifetime, Source: source}
+			sessionLifetimeResolved = true
+		}
+		if !authMethodsResolved && overrides.AuthMethods != nil {
+			settings.AuthMethods = dtos.EffectiveAuthMethodsSetting{Value: overrides.AuthMethods, Source: source}
+			authMethodsResolved = true
+		}
+
+		currentId = organization.ParentOrganizationID
+	}
+
+	return settings, nil
+}
+
+func findOrganization(organizations []domain.OrganizationEntity, organizationId uint) (domain.OrganizationEntity, bool) {
+	for _, organization := range organizations {
+		if organization.ID == organizationId {
+			return organization, true
+		}
+	}
+
+	return domain.OrganizationEntity{}, false
+}
+
+// memberSettings 는 멤버에게 적용되는 설정이다. 여러 조직에 속한 멤버는 가장 엄격한 설정을 사용한다.
+// authMethods 가 nil 이면 로그인 방법을 제한하지 않는다.
+type memberSettings struct {
+	passwordPolicy  dtos.PasswordPolicySetting
+	sessionLifetime time.Duration
+	authMethods     []string
+}
+
+// getMemberSettings 는 멤버가 속한 조직마다 적용되는 설정을 합친다.
+// 비밀번호 정책은 가장 긴 길이와 모든 문자 종류를, 세션 유지 시간은 가장 짧은 시간을 사용하고,
+// 로그인 방법은 조직에서 바꾼 로그인 방법에 모두 포함된 방법만 허용한다. 조직에 속하지 않은 멤버(memberId 0 포함)는 사이트 설정을 사용한다.
+func (s OrganizationSettingService) getMemberSettings(ctx context.Context, memberId uint) (memberSettings, error) {
+	global, err := s.getGlobalSettings(ctx)
+	if err != nil {
+		return memberSettings{}, err
+	}
+
+	settings := memberSettings{
+		passwordPolicy:  global.PasswordPolicy.Value,
+		sessionLifetime: time.Duration(global.SessionLifetime.Value.LifetimeMinutes) * time.Minute,
+	}
+	if memberId == 0 {
+		return settings, nil
+	}
+
+	organizations, err := s.organizationService.GetAllOrganizations(ctx, nil)
+	if err != nil {
+		return memberSettings{}, err
+	}
+
+	first := true
+	for _, organization := range organizations {
+		if !organization.ExistMember(memberId) {
+			continue
+		}
+
+		effective, err := s.resolveSettings(organization.ID, organizations, global)
+		if err != nil {
+			return memberSettings{}, err
+		}
+
+		policy := effective.PasswordPolicy.Value
+		lifetime := time.Duration(effective.SessionLifetime.Value.LifetimeMinutes) * time.Minute
+		if first {
+			settings.passwordPolicy = policy
+			settings.sessionLifetime = lifetime
+			first = false
+		} else {
+			if policy.MinLength > settings.passwordPolicy.MinLength {
+				settings.passwordPolicy.MinLength = policy.MinLength
+			}
+			settings.passwordPolicy.RequireUppercase = settings.passwordPolicy.RequireUppercase || policy.RequireUppercase
+			settings.passwordPolicy.RequireLowercase = settings.passwordPolicy.RequireLowercase || policy.RequireLowercase
+			settings.passwordPolicy.RequireDigit = settings.passwordPolicy.RequireDigit || policy.RequireDigit
+			settings.passwordPolicy.RequireSymbol = settings.passwordPolicy.RequireSymbol || policy.RequireSymbol
+			if lifetime < settings.sessionLifetime {
+				settings.sessionLifetime = lifetime
+			}
+		}
+
+		// 사이트 설정의 로그인 방법은 로그인 화면만 정하므로 조직에서 바꾼 로그인 방법만 제한한다.
+		if effective.AuthMethods.Source.Level != constants.OrganizationSettingLevelOrganization {
+			continue
+		}
+		if settings.authMethods == nil {
+			settings.authMethods = effective.AuthMethods.Value
+			continue
+		}
+		authMethods := make([]string, 0)
+		for _, method := range settings.authMethods {
+			if containsString(effective.AuthMethods.Value, method) {
+				authMethods = append(authMethods, method)
+			}
+		}
+		settings.authMethods = authMethods
+	}
+
+	return settings, nil
+}
+
+// CheckPasswordPolicy 는 멤버에게 적용되는 비밀번호 정책에 맞는지 확인한다. 가입하는 멤버는 memberId 가 0 이고 사이트 설정을 사용한다.
+func (s OrganizationSettingService) CheckPasswordPolicy(ctx context.Context, memberId uint, password string) error {
+	settings, err := s.getMemberSettings(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	policy := settings.passwordPolicy
+	if len([]rune(password)) < policy.MinLength {
+		return &errors.ErrPasswordPolicyViolated{Reason: fmt.Sprintf("password must be at least %v characters", policy.MinLength)}
+	}
+
+	if policy.RequireUppercase && strings.IndexFunc(password, unicode.IsUpper) < 0 {
+		return &errors.ErrPasswordPolicyViolated{Reason: "password must contain an uppercase letter"}
+	}
+
+	if policy.RequireLowercase && strings.IndexFunc(password, unicode.IsLower) < 0 {
+		return &errors.ErrPasswordPolicyViolated{Reason: "password must contain a lowercase letter"}
+	}
+
+	if policy.RequireDigit && strings.IndexFunc(password, unicode.IsDigit) < 0 {
+		return &errors.ErrPasswordPolicyViolated{Reason: "password must contain a digit"}
+	}
+
+	if policy.RequireSymbol && strings.IndexFunc(password, func(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) }) < 0 {
+		return &errors.ErrPasswordPolicyViolated{Reason: "password must contain a symbol"}
+	}
+
+	return nil
+}
+
+// GetSessionLifetime 은 멤버에게 적용되는 세션 유지 시간이다.
+func (s OrganizationSettingService) GetSessionLifetime(ctx context.Context, memberId uint) (time.Duration, error) {
+	settings, err := s.getMemberSettings(ctx, memberId)
+	if err != nil {
+		return 0, err
+	}
+
+	return settings.sessionLifetime, nil
+}
+
+// CheckLoginMethod 는 멤버가 속한 조직에서 허용하는 로그인 방법인지 확인한다. 허용하지 않으면 errors.ErrLoginMethodNotAllowed 이다.
+func (s OrganizationSettingService) CheckLoginMethod(ctx context.Context, memberId uint, method string) error {
+	settings, err := s.getMemberSettings(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if settings.authMethods != nil && !containsString(settings.authMethods, method) {
+		return errors.ErrLoginMethodNotAllowed
+	}
+
+	return nil
+}
+
+// GetAuthenticatorLoginMethod 는 외부 인증(Authenticator) 이름으로 로그인 설정에 등록된 로그인 방법(saml, ldap, passkey)을 찾는다.
+// 로그인 설정에 없으면 이름을 그대로 사용한다.
+func (s OrganizationSettingService) GetAuthenticatorLoginMethod(ctx context.Context, name string) (string, error) {
+	loginSetting, err := s.loginSettingService.GetLoginSetting(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, method := range loginSetting.Methods {
+		if method.Authenticator == name {
+			return method.Type, nil
+		}
+	}
+
+	return name, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"context"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+)
+
+type PasswordBreachService struct {
+	siteService *SiteService
+}
+
+func NewPasswordBreachService(siteService *SiteService) *PasswordBreachService {
+	return &PasswordBreachService{
+		siteService: siteService,
+	}
+}
+
+// GetPasswordBreachCheckSetting 은 유출된 비밀번호 확인 설정을 반환한다. 설정하지 않았으면 확인하지 않는다.
+func (s PasswordBreachService) GetPasswordBreachCheckSetting(ctx context.Context) (dtos.PasswordBreachCheckSetting, error) {
+	passwordBreachCheckSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyPasswordBreachCheck)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.PasswordBreachCheckSetting{
+				Mode:   constants.PasswordBreachCheckModeOff,
+				Action: constants.PasswordBreachCheckActionWarn,
+			}, nil
+		}
+		return dtos.PasswordBreachCheckSetting{}, err
+	}
+
+	var setting dtos.PasswordBreachCheckSetting
+	if err = mapstructure.Decode(passwordBreachCheckSetting, &setting); err != nil {
+		return dtos.PasswordBreachCheckSetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s PasswordBreachService) SetPasswordBreachCheckSetting(ctx context.Context, setting dtos.PasswordBreachCheckSetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyPasswordBreachCheck, setting)
+}
+
+// CheckPassword 는 새 비밀번호가 유출된 비밀번호인지 확인한다. 설정이 reject 이면 유출된 비밀번호에 ErrBreachedPassword 를 반환한다.
+// 확인할 수 없으면(range API 장애, bloom filter 파일 없음) 비밀번호 설정을 막지 않도록 로그만 남기고 통과시킨다.
+func (s PasswordBreachService) CheckPassword(ctx context.Context, password string) (dtos.PasswordBreachCheckResult, error) {
+	setting, err := s.GetPasswordBreachCheckSetting(ctx)
+	if err != nil {
+		return dtos.PasswordBreachCheckResult{}, err
+	}
+
+	if setting.Mode == constants.PasswordBreachCheckModeOff {
+		return dtos.PasswordBreachCheckResult{}, nil
+	}
+
+	count, err := adapters.PasswordBreachAdapter().Check(ctx, setting.Mode, password)
+	if err != nil {
+		log.Warnf("password breach check error. mode=%s, %v", setting.Mode, err)
+		return dtos.PasswordBreachCheckResult{}, nil
+	}
+
+	if count == 0 {
+		return dtos.PasswordBreachCheckResult{}, nil
+	}
+
+	result := dtos.PasswordBreachCheckResult{Breached: true}
+	if setting.Mode == constants.PasswordBreachCheckModeOnline {
+		result.Count = count
+	}
+
+	if setting.Action == constants.PasswordBreachCheckActionReject {
+		return result, errors.ErrBreachedPassword
+	}
+
+	return result, nil
+}
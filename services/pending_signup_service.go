@@ -0,0 +1,159 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/member/repository"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// PendingSignUpService 는 승인되지 않은 가입 신청을 기한에 따라 다시 알리고, 상위 승인자에게 넘기고, 자동으로 거절한다.
+type PendingSignUpService struct {
+	siteService            *SiteService
+	memberService          *MemberService
+	memberRepository       *repository.MemberRepository
+	auditService           *AuditService
+	messageTemplateService *MessageTemplateService
+}
+
+func NewPendingSignUpService(siteService *SiteService,
+	memberService *MemberService,
+	memberRepository *repository.MemberRepository,
+	auditService *AuditService,
+	messageTemplateService *MessageTemplateService) *PendingSignUpService {
+	return &PendingSignUpService{
+		siteService:            siteService,
+		memberService:          memberService,
+		memberRepository:       memberRepository,
+		auditService:           auditService,
+		messageTemplateService: messageTemplateService,
+	}
+}
+
+func (s PendingSignUpService) GetPendingSignUpSetting(ctx context.Context) (dtos.PendingSignUpSetting, error) {
+	pendingSignUpSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyPendingSignUp)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.PendingSignUpSetting{}, nil
+		}
+		return dtos.PendingSignUpSetting{}, err
+	}
+
+	var setting dtos.PendingSignUpSetting
+	if err = mapstructure.Decode(pendingSignUpSetting, &setting); err != nil {
+		return dtos.PendingSignUpSetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s PendingSignUpService) ProcessPendingSignUps(ctx context.Context) error {
+	setting, err := s.GetPendingSignUpSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.memberRepository.FindByStatus(ctx, constants.StatusMemberApplied)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range members {
+		member := &members[i]
+
+		if member.NeedsSignUpExpiry(setting, now) {
+			// 법적 보존 대상인 신청은 보존을 해제할 때까지 거절(삭제)하지 않는다.
+			if err := s.memberService.CheckLegalHold(ctx, member.ID); err != nil {
+				if errors.Is(err, errors.ErrLegalHold) {
+					continue
+				}
+				return err
+			}
+			if err := s.expire(ctx, *member, setting); err != nil {
+				return err
+			}
+			continue
+		}
+
+		changed := false
+		if member.NeedsSignUpReminder(setting, now) {
+			member.MarkSignUpReminded()
+			s.notifyRoleMembers(ctx, setting.ApproverRoleName, constants.MessageTemplateSignUpReminder, map[string]string{
+				"name":        member.Name,
+				"candidateId": member.GetCandidateId(),
+				"days":        fmt.Sprint(setting.ReminderDays),
+			})
+			changed = true
+		}
+
+		if member.NeedsSignUpEscalation(setting, now) {
+			member.MarkSignUpEscalated()
+			s.notifyRoleMembers(ctx, setting.EscalationRoleName, constants.MessageTemplateSignUpEscalated, map[string]string{
+				"name":        member.Name,
+				"candidateId": member.GetCandidateId(),
+				"days":        fmt.Sprint(setting.EscalationDays),
+			})
+			if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionSignUpEscalated, constants.AuditTargetTypeMember,
+				member.ID, fmt.Sprintf("escalationRoleName=%v", setting.EscalationRoleName)); err != nil {
+				return err
+			}
+			changed = true
+		}
+
+		if changed {
+			if err := s.memberRepository.Save(ctx, member); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// expire 는 기한이 지난 가입 신청을 거절하고 신청자에게 알린다.
+func (s PendingSignUpService) expire(ctx context.Context, member domain.MemberEntity, setting dtos.PendingSignUpSetting) error {
+	if err := s.memberRepository.Delete(ctx, member); err != nil {
+		return err
+	}
+
+	if email := member.GetEmail(); len(email) > 0 {
+		if err := adapters.MailAdapter().Send(s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateSignUpExpired, []string{email},
+			map[string]string{"expiryDays": fmt.Sprint(setting.ExpiryDays)})); err != nil {
+			log.Error("sign up expiry notification error: ", err)
+		}
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionSignUpExpired, constants.AuditTargetTypeMember,
+		member.ID, fmt.Sprintf("expiryDays=%v", setting.ExpiryDays))
+}
+
+func (s PendingSignUpService) notifyRoleMembers(ctx context.Context, roleName string, templateName string, data map[string]string) {
+	if len(roleName) == 0 {
+		return
+	}
+
+	members, err := s.memberService.GetMembersByRoleName(ctx, roleName)
+	if err != nil {
+		log.Error("sign up notification error: ", err)
+		return
+	}
+
+	for _, member := range members {
+		email := member.GetEmail()
+		if len(email) == 0 {
+			continue
+		}
+
+		if err := adapters.MailAdapter().Send(s.messageTemplateService.RenderMail(ctx, templateName, []string{email}, data)); err != nil {
+			log.Error("sign up notification error: ", err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/rbac/repository"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var permissionCatalogNamespacePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,49}$`)
+
+// PermissionCatalogService 는 다른 서비스가 자신이 사용할 권한을 네임스페이스 단위로 등록하고 관리한다.
+// 등록한 권한은 일반 권한처럼 역할에 할당할 수 있고, 할당된 멤버의 토큰(UserClaim.Permissions)에 포함된다.
+type PermissionCatalogService struct {
+	permissionRepository *repository.PermissionRepository
+	auditService         *AuditService
+}
+
+func NewPermissionCatalogService(permissionRepository *repository.PermissionRepository, auditService *AuditService) *PermissionCatalogService {
+	return &PermissionCatalogService{
+		permissionRepository: permissionRepository,
+		auditService:         auditService,
+	}
+}
+
+// Register 는 네임스페이스의 권한을 요청한 목록으로 맞춘다. 새 권한은 만들고, 설명과 그룹이 바뀐 권한은 고치고, 목록에 없는 권한은 역할에서도 제거한다.
+func (s PermissionCatalogService) Register(ctx context.Context, namespace string, registration dtos.PermissionCatalogRegistration) (dtos.PermissionCatalogRegistrationResult, error) {
+	if !permissionCatalogNamespacePattern.MatchString(namespace) {
+		return dtos.PermissionCatalogRegistrationResult{}, &errors.ErrInvalidPermissionCatalog{Reason: "invalid namespace: " + namespace}
+	}
+
+	items := map[string]dtos.PermissionCatalogItem{}
+	for _, item := range registration.Permissions {
+		if strings.ContainsAny(item.Name, ": \t\n") {
+			return dtos.PermissionCatalogRegistrationResult{}, &errors.ErrInvalidPermissionCatalog{Reason: "invalid permission name: " + item.Name}
+		}
+
+		name := domain.CatalogPermissionName(namespace, item.Name)
+		if _, ok := items[name]; ok {
+			return dtos.PermissionCatalogRegistrationResult{}, &errors.ErrInvalidPermissionCatalog{Reason: "duplicated permission name: " + item.Name}
+		}
+		items[name] = item
+	}
+
+	existingEntities, err := s.permissionRepository.FindByNamespace(ctx, namespace)
+	if err != nil {
+		return dtos.PermissionCatalogRegistrationResult{}, err
+	}
+
+	result := dtos.PermissionCatalogRegistrationResult{Namespace: namespace}
+	existing := map[string]bool{}
+	removedEntities := make([]domain.PermissionEntity, 0)
+	for _, entity := range existingEntities {
+		item, ok := items[entity.Name]
+		if !ok {
+			removedEntities = append(removedEntities, entity)
+			continue
+		}
+		existing[entity.Name] = true
+
+		updated, err := entity.UpdateCatalog(ctx, item)
+		if err != nil {
+			return dtos.PermissionCatalogRegistrationResult{}, err
+		}
+
+		if updated {
+			if err := s.permissionRepository.Save(ctx, entity); err != nil {
+				return dtos.PermissionCatalogRegistrationResult{}, err
+			}
+			result.Updated++
+		}
+	}
+
+	for _, item := range registration.Permissions {
+		if existing[domain.CatalogPermissionName(namespace, item.Name)] {
+			continue
+		}
+
+		entity, err := domain.NewCatalogPermissionEntity(ctx, namespace, item)
+		if err != nil {
+			return dtos.PermissionCatalogRegistrationResult{}, err
+		}
+
+		if err := s.permissionRepository.Create(ctx, &entity); err != nil {
+			return dtos.PermissionCatalogRegistrationResult{}, err
+		}
+		result.Created++
+	}
+
+	if err := s.permissionRepository.DeleteWithRoles(ctx, removedEntities); err != nil {
+		return dtos.PermissionCatalogRegistrationResult{}, err
+	}
+	result.Removed = len(removedEntities)
+
+	if result.Created+result.Updated+result.Removed == 0 {
+		return result, nil
+	}
+
+	if err := s.recordAuditLog(ctx, result); err != nil {
+		return dtos.PermissionCatalogRegistrationResult{}, err
+	}
+
+	return result, nil
+}
+
+func (s PermissionCatalogService) GetCatalog(ctx context.Context) ([]domain.PermissionEntity, error) {
+	return s.permissionRepository.FindAllCatalog(ctx)
+}
+
+func (s PermissionCatalogService) GetNamespace(ctx context.Context, namespace string) ([]domain.PermissionEntity, error) {
+	entities, err := s.permissionRepository.FindByNamespace(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entities) == 0 {
+		return nil, errors.ErrNotFound
+	}
+
+	return entities, nil
+}
+
+// DeleteNamespace 는 네임스페이스의 권한을 모두 제거한다. 서비스를 더 이상 운영하지 않을 때 사용한다.
+func (s PermissionCatalogService) DeleteNamespace(ctx context.Context, namespace string) error {
+	entities, err := s.GetNamespace(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := s.permissionRepository.DeleteWithRoles(ctx, entities); err != nil {
+		return err
+	}
+
+	return s.recordAuditLog(ctx, dtos.PermissionCatalogRegistrationResult{Namespace: namespace, Removed: len(entities)})
+}
+
+func (s PermissionCatalogService) recordAuditLog(ctx context.Context, result dtos.PermissionCatalogRegistrationResult) error {
+	detail, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionPermissionCatalogChanged, constants.AuditTargetTypePermissionCatalog, 0, string(detail))
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/organization/domain"
+	"context"
+	"fmt"
+	"sort"
+)
+
+// PermissionSimulationService 는 역할에서 권한을 제거하기 전에 접근을 잃는 멤버를 미리 확인한다. 역할은 바꾸지 않는다.
+type PermissionSimulationService struct {
+	rbacService              *RoleBasedAccessControlService
+	memberService            *MemberService
+	organizationService      *OrganizationService
+	resourceOwnershipService *ResourceOwnershipService
+}
+
+func NewPermissionSimulationService(rbacService *RoleBasedAccessControlService,
+	memberService *MemberService,
+	organizationService *OrganizationService,
+	resourceOwnershipService *ResourceOwnershipService) *PermissionSimulationService {
+	return &PermissionSimulationService{
+		rbacService:              rbacService,
+		memberService:            memberService,
+		organizationService:      organizationService,
+		resourceOwnershipService: resourceOwnershipService,
+	}
+}
+
+// SimulateRemovePermissions 는 역할이 직접 또는 조직을 통해 할당된 멤버마다 권한을 제거하기 전후의 권한과 잃는 권한, 소유 리소스를 계산한다.
+// 호출할 수 없게 되는 API 는 권한 매트릭스로 호출하는 쪽(controller)에서 계산한다.
+func (s PermissionSimulationService) SimulateRemovePermissions(ctx context.Context, request dtos.PermissionSimulationRequest) (dtos.PermissionSimulationResult, error) {
+	roleEntity, err := s.rbacService.GetRole(ctx, request.RoleId)
+	if err != nil {
+		return dtos.PermissionSimulationResult{}, err
+	}
+
+	removed := map[string]bool{}
+	for _, permission := range request.RemovePermissions {
+		if !roleEntity.HasPermission(permission) {
+			return dtos.PermissionSimulationResult{}, &errors.ErrInvalidPermissionSimulation{
+				Reason: fmt.Sprintf("role %s does not have permission %s", roleEntity.Name, permission)}
+		}
+		removed[permission] = true
+	}
+
+	memberEntities, err := s.findRoleMembers(ctx, roleEntity.ID)
+	if err != nil {
+		return dtos.PermissionSimulationResult{}, err
+	}
+
+	result := dtos.PermissionSimulationResult{
+		RoleId:            roleEntity.ID,
+		RoleName:          roleEntity.Name,
+		RemovePermissions: request.RemovePermissions,
+		MemberCount:       len(memberEntities),
+		AffectedMembers:   make([]dtos.PermissionSimulationMember, 0),
+		LostEndpoints:     make([]dtos.AuthorizationRule, 0),
+	}
+
+	for _, memberEntity := range memberEntities {
+		organizations, err := s.organizationService.GetAllOrganizations(ctx, map[string]interface{}{"memberId": memberEntity.ID})
+		if err != nil {
+			return dtos.PermissionSimulationResult{}, err
+		}
+
+		before, after := s.permissionsOf(memberEntity, organizations, roleEntity.ID, removed)
+		lostPermissions := make([]string, 0)
+		for _, permission := range before {
+			if !containsAnyString(after, []string{permission}) {
+				lostPermissions = append(lostPermissions, permission)
+			}
+		}
+
+		if len(lostPermissions) == 0 {
+			continue
+		}
+
+		lostResources, err := s.resourceOwnershipService.GetUnmanageableResources(ctx, memberEntity.ID, before, after)
+		if err != nil {
+			return dtos.PermissionSimulationResult{}, err
+		}
+
+		result.AffectedMembers = append(result.AffectedMembers, dtos.PermissionSimulationMember{
+			MemberId:          memberEntity.ID,
+			SignId:            memberEntity.SignId,
+			Name:              memberEntity.Name,
+			LostPermissions:   lostPermissions,
+			LostEndpoints:     make([]dtos.AuthorizationRule, 0),
+			LostResources:     lostResources,
+			PermissionsBefore: before,
+			PermissionsAfter:  after,
+		})
+	}
+
+	return result, nil
+}
+
+// findRoleMembers 는 역할이 직접 할당된 멤버와 역할이 할당된 조직의 멤버를 ID 순으로 반환한다.
+func (s PermissionSimulationService) findRoleMembers(ctx context.Context, roleId uint) ([]memberDomain.MemberEntity, error) {
+	memberEntities, _, err := s.memberService.GetMembers(ctx, map[string]interface{}{"roleIds": []uint{roleId}}, dtos.Pageable{Page: 0})
+	if err != nil {
+		return nil, err
+	}
+
+	memberIds := map[uint]bool{}
+	for _, memberEntity := range memberEntities {
+		memberIds[memberEntity.ID] = true
+	}
+
+	organizations, err := s.organizationService.GetAllOrganizations(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, organization := range organizations {
+		if !organization.HasRole(roleId) {
+			continue
+		}
+
+		for _, member := range organization.Members {
+			if memberIds[member.ID] {
+				continue
+			}
+
+			memberEntity, err := s.memberService.GetMemberById(ctx, member.ID)
+			if err != nil {
+				return nil, err
+			}
+			memberIds[member.ID] = true
+			memberEntities = append(memberEntities, memberEntity)
+		}
+	}
+
+	sort.Slice(memberEntities, func(i, j int) bool {
+		return memberEntities[i].ID < memberEntities[j].ID
+	})
+
+	return memberEntities, nil
+}
+
+// permissionsOf 는 멤버와 멤버가 속한 조직의 역할로 가진 권한(before)과 역할(roleId)에서 removed 권한을 제거했을 때의 권한(after)이다.
+func (PermissionSimulationService) permissionsOf(memberEntity memberDomain.MemberEntity, organizations []domain.OrganizationEntity,
+	roleId uint, removed map[string]bool) ([]string, []string) {
+	before := make([]string, 0)
+	after := make([]string, 0)
+	beforeKeys := map[string]bool{}
+	afterKeys := map[string]bool{}
+
+	roles := memberEntity.Roles
+	for _, organization := range organizations {
+		roles = append(roles, organization.Roles...)
+	}
+
+	for _, role := range roles {
+		for _, permission := range role.Permissions {
+			if !beforeKeys[permission.Name] {
+				beforeKeys[permission.Name] = true
+				before = append(before, permission.Name)
+			}
+
+			if role.ID == roleId && removed[permission.Name] {
+				continue
+			}
+
+			if !afterKeys[permission.Name] {
+				afterKeys[permission.Name] = true
+				after = append(after, permission.Name)
+			}
+		}
+	}
+
+	return before, after
+}
+
+func containsAnyString(values []string, targets []string) bool {
+	for _, value := range values {
+		for _, target := range targets {
+			if value == target {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/pluginsetting/domain"
+	"better-admin-backend-service/pluginsetting/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+var pluginSettingNamespacePattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,49}$`)
+
+// PluginSettingNamespace 는 확장 기능이 사용할 설정의 Namespace 이다.
+// Schema(JSON Schema)가 비어 있으면 JSON 형식과 크기만 확인한다.
+// WritePermissions 가 비어 있으면 MANAGE_SYSTEM_SETTINGS 권한으로, ReadPermissions 가 비어 있으면 WritePermissions 로 확인한다.
+// PermissionChecker 처럼 "*" 는 로그인한 사용자 누구나이다.
+type PluginSettingNamespace struct {
+	Schema           string
+	ReadPermissions  []string
+	WritePermissions []string
+}
+
+type pluginSettingNamespace struct {
+	schema           *helpers.JsonSchema
+	readPermissions  []string
+	writePermissions []string
+}
+
+var (
+	pluginSettingNamespaceMutex sync.RWMutex
+	pluginSettingNamespaces     = map[string]pluginSettingNamespace{}
+)
+
+// RegisterPluginSettingNamespace 는 설정 Namespace 를 등록한다. 이름이나 스키마가 잘못되면 시작할 때 알 수 있도록 panic 한다.
+func RegisterPluginSettingNamespace(namespace string, setting PluginSettingNamespace) {
+	if !pluginSettingNamespacePattern.MatchString(namespace) {
+		panic(fmt.Sprintf("invalid plugin setting namespace %q", namespace))
+	}
+
+	registered := pluginSettingNamespace{
+		readPermissions:  setting.ReadPermissions,
+		writePermissions: setting.WritePermissions,
+	}
+	if len(registered.writePermissions) == 0 {
+		registered.writePermissions = []string{constants.PermissionManageSystemSettings}
+	}
+	if len(registered.readPermissions) == 0 {
+		registered.readPermissions = registered.writePermissions
+	}
+	if len(setting.Schema) > 0 {
+		schema, err := helpers.JsonSchemaHelper().Compile(setting.Schema)
+		if err != nil {
+			panic(fmt.Sprintf("plugin setting namespace %q: %v", namespace, err))
+		}
+		registered.schema = schema
+	}
+
+	pluginSettingNamespaceMutex.Lock()
+	defer pluginSettingNamespaceMutex.Unlock()
+	pluginSettingNamespaces[namespace] = registered
+}
+
+func getPluginSettingNamespace(namespace string) (pluginSettingNamespace, bool) {
+	pluginSettingNamespaceMutex.RLock()
+	defer pluginSettingNamespaceMutex.RUnlock()
+
+	registered, ok := pluginSettingNamespaces[namespace]
+	return registered, ok
+}
+
+type PluginSettingService struct {
+	pluginSettingRepository *repository.PluginSettingRepository
+}
+
+func NewPluginSettingService(pluginSettingRepository *repository.PluginSettingRepository) *PluginSettingService {
+	return &PluginSettingService{
+		pluginSettingRepository: pluginSettingRepository,
+	}
+}
+
+// GetPluginSettings 는 요청한 사용자가 읽을 수 있는 모든 Namespace 의 설정을 반환한다.
+func (s PluginSettingService) GetPluginSettings(ctx context.Context) ([]dtos.PluginSetting, error) {
+	pluginSettingNamespaceMutex.RLock()
+	namespaces := make([]string, 0)
+	for namespace, registered := range pluginSettingNamespaces {
+		if hasAnyClaimPermission(ctx, registered.readPermissions) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	pluginSettingNamespaceMutex.RUnlock()
+	sort.Strings(namespaces)
+
+	entities, err := s.pluginSettingRepository.FindByNamespaces(ctx, namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	foundEntities := make(map[string]domain.PluginSettingEntity)
+	for _, entity := range entities {
+		foundEntities[entity.Namespace] = entity
+	}
+
+	settings := make([]dtos.PluginSetting, 0)
+	for _, namespace := range namespaces {
+		settings = append(settings, s.toPluginSetting(ctx, namespace, foundEntities[namespace]))
+	}
+
+	return settings, nil
+}
+
+func (s PluginSettingService) GetPluginSetting(ctx context.Context, namespace string) (dtos.PluginSetting, error) {
+	registered, ok := getPluginSettingNamespace(namespace)
+	if !ok {
+		return dtos.PluginSetting{}, errors.ErrNotFound
+	}
+	if !hasAnyClaimPermission(ctx, registered.readPermissions) {
+		return dtos.PluginSetting{}, errors.ErrForbidden
+	}
+
+	entity, err := s.pluginSettingRepository.FindByNamespace(ctx, namespace)
+	if err != nil && !errors.Is(err, errors.ErrNotFound) {
+		return dtos.PluginSetting{}, err
+	}
+
+	return s.toPluginSetting(ctx, namespace, entity), nil
+}
+
+// SetPluginSetting 은 값을 스키마로 확인한 뒤 저장하고, 트랜잭션이 커밋되면 변경 이벤트를 이벤트 버스로 보낸다.
+func (s PluginSettingService) SetPluginSetting(ctx context.Context, namespace string, value json.RawMessage) (dtos.PluginSetting, error) {
+	registered, ok := getPluginSettingNamespace(namespace)
+	if !ok {
+		return dtos.PluginSetting{}, errors.ErrNotFound
+	}
+	if !hasAnyClaimPermission(ctx, registered.writePermissions) {
+		return dtos.PluginSetting{}, errors.ErrForbidden
+	}
+
+	if len(value) > constants.PluginSettingMaxValueBytes {
+		return dtos.PluginSetting{}, &errors.ErrInvalidPluginSetting{Namespace: namespace,
+			Reason: fmt.Sprintf("value must be at most %v bytes", constants.PluginSettingMaxValueBytes)}
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, value); err != nil {
+		return dtos.PluginSetting{}, &errors.ErrInvalidPluginSetting{Namespace: namespace, Reason: "invalid json"}
+	}
+
+	if registered.schema != nil {
+		if err := helpers.JsonSchemaHelper().Validate(registered.schema, value); err != nil {
+			return dtos.PluginSetting{}, &errors.ErrInvalidPluginSetting{Namespace: namespace, Reason: err.Error()}
+		}
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return dtos.PluginSetting{}, err
+	}
+
+	entity, err := s.pluginSettingRepository.FindByNamespace(ctx, namespace)
+	if err != nil {
+		if !errors.Is(err, errors.ErrNotFound) {
+			return dtos.PluginSetting{}, err
+		}
+		entity = domain.PluginSettingEntity{Namespace: namespace, CreatedBy: userClaim.Id}
+	}
+
+	entity.Value = compacted.String()
+	entity.UpdatedBy = userClaim.Id
+	if err := s.pluginSettingRepository.Save(ctx, &entity); err != nil {
+		return dtos.PluginSetting{}, err
+	}
+
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		adapters.EventBusAdapter().Publish(entity.ToEvent(constants.PluginSettingActionChanged))
+	})
+
+	return s.toPluginSetting(ctx, namespace, entity), nil
+}
+
+func (s PluginSettingService) DeletePluginSetting(ctx context.Context, namespace string) error {
+	registered, ok := getPluginSettingNamespace(namespace)
+	if !ok {
+		return errors.ErrNotFound
+	}
+	if !hasAnyClaimPermission(ctx, registered.writePermissions) {
+		return errors.ErrForbidden
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	entity, err := s.pluginSettingRepository.FindByNamespace(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	if err := s.pluginSettingRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	entity.UpdatedBy = userClaim.Id
+	entity.UpdatedAt = time.Now()
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		adapters.EventBusAdapter().Publish(entity.ToEvent(constants.PluginSettingActionDeleted))
+	})
+
+	return nil
+}
+
+func (s PluginSettingService) toPluginSetting(ctx context.Context, namespace string, entity domain.PluginSettingEntity) dtos.PluginSetting {
+	setting := dtos.PluginSetting{Namespace: namespace, Value: json.RawMessage("null")}
+	if entity.ID > 0 {
+		setting = entity.ToPluginSetting()
+	}
+
+	if registered, ok := getPluginSettingNamespace(namespace); ok {
+		setting.Writable = hasAnyClaimPermission(ctx, registered.writePermissions)
+	}
+
+	return setting
+}
+
+func hasAnyClaimPermission(ctx context.Context, permissions []string) bool {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return false
+	}
+
+	for _, permission := range permissions {
+		if permission == "*" {
+			return true
+		}
+		for _, claimPermission := range userClaim.Permissions {
+			if permission == claimPermission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
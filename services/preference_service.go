@@ -0,0 +1,170 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/member/repository"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"regexp"
+)
+
+var preferenceNamespacePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// preferenceSchemas 는 값의 형식이 정해진 Namespace 이다. 정의되지 않은 Namespace 는 JSON 형식과 크기만 확인한다.
+var preferenceSchemas = map[string]func() interface{}{
+	constants.PreferenceNamespaceTheme:        func() interface{} { return &dtos.ThemePreference{} },
+	constants.PreferenceNamespaceNotification: func() interface{} { return &dtos.NotificationPreference{} },
+	constants.PreferenceNamespaceTableColumns: func() interface{} { return &dtos.TableColumnPreference{} },
+}
+
+type PreferenceService struct {
+	memberPreferenceRepository *repository.MemberPreferenceRepository
+}
+
+func NewPreferenceService(memberPreferenceRepository *repository.MemberPreferenceRepository) *PreferenceService {
+	return &PreferenceService{
+		memberPreferenceRepository: memberPreferenceRepository,
+	}
+}
+
+// GetMyPreferences 는 로그인한 멤버의 환경 설정이다. 서비스 계정은 멤버가 아니므로 ErrAuthentication 이다.
+func (s PreferenceService) GetMyPreferences(ctx context.Context) (dtos.MemberPreferences, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entities, err := s.memberPreferenceRepository.FindByMemberId(ctx, userClaim.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := dtos.MemberPreferences{}
+	for _, entity := range entities {
+		preferences[entity.Namespace] = json.RawMessage(entity.Value)
+	}
+
+	return preferences, nil
+}
+
+// UpdateMyPreferences 는 요청에 포함된 Namespace 만 바꾼다. 값이 null 이면 Namespace 를 삭제한다.
+func (s PreferenceService) UpdateMyPreferences(ctx context.Context, preferences dtos.MemberPreferences) error {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	existPreferences, err := s.GetMyPreferences(ctx)
+	if err != nil {
+		return err
+	}
+
+	for namespace, value := range preferences {
+		if err := validatePreference(namespace, value); err != nil {
+			return err
+		}
+
+		if isNullPreference(value) {
+			delete(existPreferences, namespace)
+		} else {
+			existPreferences[namespace] = value
+		}
+	}
+
+	if len(existPreferences) > constants.PreferenceMaxNamespaces {
+		return &errors.ErrInvalidPreference{Reason: fmt.Sprintf("namespaces must be at most %v", constants.PreferenceMaxNamespaces)}
+	}
+
+	for namespace, value := range preferences {
+		if isNullPreference(value) {
+			if err := s.memberPreferenceRepository.DeleteByNamespace(ctx, userClaim.Id, namespace); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, value); err != nil {
+			return &errors.ErrInvalidPreference{Namespace: namespace, Reason: "invalid json"}
+		}
+
+		entity := domain.MemberPreferenceEntity{MemberId: userClaim.Id, Namespace: namespace, Value: compacted.String()}
+		if err := s.memberPreferenceRepository.Upsert(ctx, &entity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsNotificationOptedOut 은 멤버가 알림 설정(notification)에서 해당 종류의 알림을 받지 않기로 했는지 확인한다.
+func (s PreferenceService) IsNotificationOptedOut(ctx context.Context, memberId uint, notificationType string) (bool, error) {
+	entities, err := s.memberPreferenceRepository.FindByMemberId(ctx, memberId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entity := range entities {
+		if entity.Namespace != constants.PreferenceNamespaceNotification {
+			continue
+		}
+
+		var preference dtos.NotificationPreference
+		if err := json.Unmarshal([]byte(entity.Value), &preference); err != nil {
+			return false, nil
+		}
+		for _, optOut := range preference.OptOuts {
+			if optOut == notificationType {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func isNullPreference(value json.RawMessage) bool {
+	return len(value) == 0 || string(bytes.TrimSpace(value)) == "null"
+}
+
+func validatePreference(namespace string, value json.RawMessage) error {
+	if !preferenceNamespacePattern.MatchString(namespace) {
+		return &errors.ErrInvalidPreference{Namespace: namespace, Reason: "invalid namespace"}
+	}
+
+	if len(value) > constants.PreferenceMaxValueBytes {
+		return &errors.ErrInvalidPreference{Namespace: namespace,
+			Reason: fmt.Sprintf("value must be at most %v bytes", constants.PreferenceMaxValueBytes)}
+	}
+
+	if isNullPreference(value) {
+		return nil
+	}
+
+	newSchema, ok := preferenceSchemas[namespace]
+	if !ok {
+		if !json.Valid(value) {
+			return &errors.ErrInvalidPreference{Namespace: namespace, Reason: "invalid json"}
+		}
+		return nil
+	}
+
+	schema := newSchema()
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(schema); err != nil {
+		return &errors.ErrInvalidPreference{Namespace: namespace, Reason: err.Error()}
+	}
+
+	if err := binding.Validator.ValidateStruct(schema); err != nil {
+		return &errors.ErrInvalidPreference{Namespace: namespace, Reason: err.Error()}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
@@ -13,18 +14,30 @@ import (
 type RoleBasedAccessControlService struct {
 	permissionRepository *repository.PermissionRepository
 	roleRepository       *repository.RoleRepository
+	domainEventService   *DomainEventService
+	accessHistoryService *AccessHistoryService
+	quotaGuard           QuotaGuard
 }
 
 func NewRoleBasedAccessControlService(
 	permissionRepository *repository.PermissionRepository,
-	roleRepository *repository.RoleRepository) *RoleBasedAccessControlService {
+	roleRepository *repository.RoleRepository,
+	domainEventService *DomainEventService,
+	accessHistoryService *AccessHistoryService) *RoleBasedAccessControlService {
 
 	return &RoleBasedAccessControlService{
 		permissionRepository: permissionRepository,
 		roleRepository:       roleRepository,
+		domainEventService:   domainEventService,
+		accessHistoryService: accessHistoryService,
 	}
 }
 
+// RegisterQuotaGuard 는 역할 수를 제한할 QuotaGuard 를 등록한다. 등록하지 않으면 확인하지 않는다.
+func (s *RoleBasedAccessControlService) RegisterQuotaGuard(guard QuotaGuard) {
+	s.quotaGuard = guard
+}
+
 func (s RoleBasedAccessControlService) CreatePermission(ctx context.Context, permissionInformation dtos.PermissionInformation) error {
 	permissionEntity, err := domain.NewPermissionEntity(ctx, permissionInformation)
 	if err != nil {
@@ -42,12 +55,33 @@ func (s RoleBasedAccessControlService) GetPermission(ctx context.Context, permis
 }
 
 func (s RoleBasedAccessControlService) CreateRole(ctx context.Context, roleInformation dtos.RoleInformation) error {
+	if err := checkQuota(ctx, s.quotaGuard, constants.LicenseResourceRoles); err != nil {
+		return err
+	}
+
 	roleEntity, err := factory.NewRoleEntity(ctx, roleInformation, s.permissionRepository)
 	if err != nil {
 		return err
 	}
 
-	return s.roleRepository.Create(ctx, &roleEntity)
+	if err := s.roleRepository.Create(ctx, &roleEntity); err != nil {
+		return err
+	}
+
+	if err := s.accessHistoryService.SyncRolePermissions(ctx, roleEntity.ID, roleEntity.GetPermissionIds()); err != nil {
+		return err
+	}
+
+	if err := s.domainEventService.RecordRoleEvent(ctx, constants.DomainEventRoleCreated, roleEntity); err != nil {
+		return err
+	}
+
+	recordQuotaUsage(ctx, s.quotaGuard, constants.LicenseResourceRoles)
+	return nil
+}
+
+func (s RoleBasedAccessControlService) CountRoles(ctx context.Context) (int64, error) {
+	return s.roleRepository.Count(ctx)
 }
 
 func (s RoleBasedAccessControlService) GetRoles(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.RoleEntity, int64, error) {
@@ -116,7 +150,15 @@ func (s RoleBasedAccessControlService) DeleteRole(ctx context.Context, roleId ui
 
 	roleEntity.UpdatedBy = userClaim.Id
 
-	return s.roleRepository.Delete(ctx, roleEntity)
+	if err := s.roleRepository.Delete(ctx, roleEntity); err != nil {
+		return err
+	}
+
+	if err := s.accessHistoryService.RevokeRole(ctx, roleEntity.ID); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordRoleEvent(ctx, constants.DomainEventRoleDeleted, roleEntity)
 }
 
 func (s RoleBasedAccessControlService) UpdateRole(ctx context.Context, roleId uint, roleInformation dtos.RoleInformation) error {
@@ -136,7 +178,15 @@ func (s RoleBasedAccessControlService) UpdateRole(ctx context.Context, roleId ui
 		return err
 	}
 
-	return s.roleRepository.Save(ctx, &roleEntity)
+	if err := s.roleRepository.Save(ctx, &roleEntity); err != nil {
+		return err
+	}
+
+	if err := s.accessHistoryService.SyncRolePermissions(ctx, roleEntity.ID, roleEntity.GetPermissionIds()); err != nil {
+		return err
+	}
+
+	return s.domainEventService.RecordRoleEvent(ctx, constants.DomainEventRoleUpdated, roleEntity)
 }
 
 func (s RoleBasedAccessControlService) GetRole(ctx context.Context, roleId uint) (domain.RoleEntity, error) {
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"github.com/mitchellh/mapstructure"
+)
+
+const defaultReadOnlyMessage = "읽기 전용 모드입니다. 변경할 수 없습니다."
+
+type ReadOnlyService struct {
+	siteService *SiteService
+}
+
+func NewReadOnlyService(siteService *SiteService) *ReadOnlyService {
+	return &ReadOnlyService{
+		siteService: siteService,
+	}
+}
+
+func (s ReadOnlyService) GetReadOnlySetting(ctx context.Context) (dtos.ReadOnlySetting, error) {
+	readOnlySetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyReadOnly)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.ReadOnlySetting{RoleNames: make([]string, 0)}, nil
+		}
+		return dtos.ReadOnlySetting{}, err
+	}
+
+	var setting dtos.ReadOnlySetting
+	if err = mapstructure.Decode(readOnlySetting, &setting); err != nil {
+		return dtos.ReadOnlySetting{}, err
+	}
+
+	if setting.RoleNames == nil {
+		setting.RoleNames = make([]string, 0)
+	}
+
+	return setting, nil
+}
+
+func (s ReadOnlyService) SetReadOnlySetting(ctx context.Context, setting dtos.ReadOnlySetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyReadOnly, setting)
+}
+
+// GetReadOnlyStatus 는 요청한 사용자에게 읽기 전용 모드가 적용되는지 반환한다.
+// 역할로 적용하는 읽기 전용 모드는 전체 읽기 전용 모드보다 우선하여 ByRole 로 구분한다.
+func (s ReadOnlyService) GetReadOnlyStatus(ctx context.Context) (dtos.ReadOnlyStatus, error) {
+	setting, err := s.GetReadOnlySetting(ctx)
+	if err != nil {
+		return dtos.ReadOnlyStatus{}, err
+	}
+
+	message := setting.Message
+	if len(message) == 0 {
+		message = defaultReadOnlyMessage
+	}
+
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(ctx); err == nil {
+		for _, roleName := range userClaim.Roles {
+			for _, readOnlyRoleName := range setting.RoleNames {
+				if roleName == readOnlyRoleName {
+					return dtos.ReadOnlyStatus{Active: true, ByRole: true, Message: message}, nil
+				}
+			}
+		}
+	}
+
+	return dtos.ReadOnlyStatus{Active: setting.Enabled, Message: message}, nil
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/report/domain"
+	"better-admin-backend-service/report/repository"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"mime"
+	"time"
+)
+
+type ReportService struct {
+	reportRepository       *repository.ReportRepository
+	reportRunRepository    *repository.ReportRunRepository
+	reportDataRepository   *repository.ReportDataRepository
+	dataMaskingService     *DataMaskingService
+	messageTemplateService *MessageTemplateService
+}
+
+func NewReportService(
+	reportRepository *repository.ReportRepository,
+	reportRunRepository *repository.ReportRunRepository,
+	reportDataRepository *repository.ReportDataRepository,
+	dataMaskingService *DataMaskingService,
+	messageTemplateService *MessageTemplateService) *ReportService {
+
+	return &ReportService{
+		reportRepository:       reportRepository,
+		reportRunRepository:    reportRunRepository,
+		reportDataRepository:   reportDataRepository,
+		dataMaskingService:     dataMaskingService,
+		messageTemplateService: messageTemplateService,
+	}
+}
+
+func (s ReportService) CreateReport(ctx context.Context, information dtos.ReportInformation) (domain.ReportEntity, error) {
+	entity, err := domain.NewReportEntity(ctx, information)
+	if err != nil {
+		return domain.ReportEntity{}, err
+	}
+
+	if err := s.reportRepository.Create(ctx, &entity); err != nil {
+		return domain.ReportEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s ReportService) GetReports(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.ReportEntity, int64, error) {
+	if err := applyOwnerFilter(ctx, filters, constants.PermissionManageSystemSettings); err != nil {
+		return nil, 0, err
+	}
+
+	return s.reportRepository.FindAll(ctx, filters, pageable)
+}
+
+func (s ReportService) GetReport(ctx context.Context, reportId uint) (domain.ReportEntity, error) {
+	return s.reportRepository.FindById(ctx, reportId)
+}
+
+func (s ReportService) UpdateReport(ctx context.Context, reportId uint, information dtos.ReportInformation) error {
+	entity, err := s.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, information); err != nil {
+		return err
+	}
+
+	return s.reportRepository.Save(ctx, &entity)
+}
+
+func (s ReportService) DeleteReport(ctx context.Context, reportId uint) error {
+	entity, err := s.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	return s.reportRepository.Delete(ctx, entity)
+}
+
+// TransferOwnership 은 멤버가 소유한 리포트를 다른 멤버에게 넘기고 넘긴 리포트를 반환한다.
+func (s ReportService) TransferOwnership(ctx context.Context, fromOwnerId, toOwnerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.reportRepository.FindByOwnerId(ctx, fromOwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for i := range entities {
+		if err := entities[i].TransferOwnership(ctx, toOwnerId); err != nil {
+			return nil, err
+		}
+
+		if err := s.reportRepository.Save(ctx, &entities[i]); err != nil {
+			return nil, err
+		}
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeReport, Id: entities[i].ID, Name: entities[i].Name})
+	}
+
+	return resources, nil
+}
+
+// AuthorizeOwner 는 리포트의 소유자이거나 시스템 설정 관리 권한이 있는지 확인한다. 권한이 있으면 리소스를 조회하지 않는다.
+func (s ReportService) AuthorizeOwner(ctx context.Context, reportId uint) error {
+	if hasAnyClaimPermission(ctx, []string{constants.PermissionManageSystemSettings}) {
+		return nil
+	}
+
+	entity, err := s.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return err
+	}
+
+	return authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageSystemSettings)
+}
+
+func (s ReportService) GetOwnedResources(ctx context.Context, ownerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.reportRepository.FindByOwnerId(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for _, entity := range entities {
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeReport, Id: entity.ID, Name: entity.Name})
+	}
+
+	return resources, nil
+}
+
+func (s ReportService) TransferResourceOwnership(ctx context.Context, reportId, toOwnerId uint) (dtos.OwnedResource, error) {
+	entity, err := s.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageSystemSettings); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := entity.TransferOwnership(ctx, toOwnerId); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := s.reportRepository.Save(ctx, &entity); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	return dtos.OwnedResource{Type: constants.OwnedResourceTypeReport, Id: entity.ID, Name: entity.Name}, nil
+}
+
+// RunReport 는 리포트를 바로 실행한다. deliver 가 true 이면 설정된 채널로 결과를 보낸다.
+// 실행이나 전송이 실패해도 실행 이력에 남기고, 이력을 저장하지 못한 경우에만 오류를 반환한다.
+func (s ReportService) RunReport(ctx context.Context, reportId uint, deliver bool) (domain.ReportRunEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return domain.ReportRunEntity{}, err
+	}
+
+	report, err := s.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return domain.ReportRunEntity{}, err
+	}
+
+	return s.run(ctx, report, constants.ReportRunTriggerManual, userClaim.Id, deliver)
+}
+
+// ExportReport 는 실행 이력을 남기지 않고 리포트 파일을 만든다. 파일 이름과 내용을 반환한다.
+func (s ReportService) ExportReport(ctx context.Context, reportId uint) (string, []byte, error) {
+	report, err := s.reportRepository.FindById(ctx, reportId)
+	if err != nil {
+		return "", nil, err
+	}
+
+	file, _, err := s.generate(ctx, report, time.Now())
+	if err != nil {
+		return "", nil, err
+	}
+
+	return file.FileName, file.Content, nil
+}
+
+func (s ReportService) GetReportRuns(ctx context.Context, reportId uint, pageable dtos.Pageable) ([]domain.ReportRunEntity, int64, error) {
+	if _, err := s.reportRepository.FindById(ctx, reportId); err != nil {
+		return nil, 0, err
+	}
+
+	return s.reportRunRepository.FindByReportId(ctx, reportId, pageable)
+}
+
+func (s ReportService) GetReportRun(ctx context.Context, reportId, runId uint) (domain.ReportRunEntity, error) {
+	return s.reportRunRepository.FindById(ctx, reportId, runId)
+}
+
+// ProcessScheduledReports 는 실행 시간이 된 리포트를 실행하고 결과를 보낸 뒤 다음 실행 시간을 정한다.
+func (s ReportService) ProcessScheduledReports(ctx context.Context) error {
+	now := time.Now()
+
+	reports, err := s.reportRepository.FindDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		if _, err := s.run(ctx, report, constants.ReportRunTriggerScheduled, 0, true); err != nil {
+			return err
+		}
+
+		if err := report.ScheduleNextRun(now); err != nil {
+			// 저장된 뒤 스케줄이 바뀔 수는 없지만, 잘못된 스케줄이면 더 이상 자동으로 실행하지 않는다.
+			log.Errorf("report schedule error. reportId=%v, %v", report.ID, err)
+		}
+
+		if err := s.reportRepository.Save(ctx, &report); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s ReportService) run(ctx context.Context, report domain.ReportEntity, trigger string, requestedBy uint, deliver bool) (domain.ReportRunEntity, error) {
+	run := domain.NewReportRunEntity(report, trigger, requestedBy)
+
+	file, rowCount, err := s.generate(ctx, report, run.StartedAt)
+	if err != nil {
+		log.Errorf("report run error. reportId=%v, %v", report.ID, err)
+		run.Fail(err)
+	} else {
+		run.Succeed(file, rowCount)
+
+		if deliver && len(report.DeliveryChannel) > 0 {
+			if err := s.deliver(ctx, report, run); err != nil {
+				log.Errorf("report delivery error. reportId=%v, %v", report.ID, err)
+				run.MarkDeliveryFailed(err)
+			} else {
+				run.MarkDelivered()
+			}
+		}
+	}
+
+	if err := s.reportRunRepository.Create(ctx, &run); err != nil {
+		return domain.ReportRunEntity{}, err
+	}
+
+	return run, nil
+}
+
+func (s ReportService) generate(ctx context.Context, report domain.ReportEntity, startedAt time.Time) (domain.ReportFile, int, error) {
+	query, err := report.NewQuery()
+	if err != nil {
+		return domain.ReportFile{}, 0, err
+	}
+
+	rows, err := s.reportDataRepository.FindRows(ctx, query)
+	if err != nil {
+		return domain.ReportFile{}, 0, err
+	}
+
+	// 리포트 파일은 메일, 웹훅으로 전달되므로 화면과 같은 마스킹 정책을 적용한다.
+	policies, err := s.dataMaskingService.GetPolicies(ctx)
+	if err != nil {
+		return domain.ReportFile{}, 0, err
+	}
+	helpers.DataMaskingHelper().MaskRows(query.Headers, rows, policies)
+
+	file, err := domain.NewReportFile(report.Format, report.Name, query.Headers, rows, startedAt)
+	if err != nil {
+		return domain.ReportFile{}, 0, err
+	}
+
+	return file, len(rows), nil
+}
+
+func (s ReportService) deliver(ctx context.Context, report domain.ReportEntity, run domain.ReportRunEntity) error {
+	file := run.GetFile()
+
+	switch report.DeliveryChannel {
+	case constants.ReportDeliveryChannelEmail:
+		message := s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateReportDelivered, report.GetRecipients(), map[string]string{
+			"name":      report.Name,
+			"startedAt": run.StartedAt.Format("2006-01-02 15:04:05"),
+			"rowCount":  fmt.Sprint(run.RowCount),
+		})
+		message.Attachments = []dtos.MailAttachment{{
+			FileName:    file.FileName,
+			ContentType: file.ContentType,
+			Content:     file.Content,
+		}}
+		return adapters.MailAdapter().Send(message)
+	case constants.ReportDeliveryChannelWebHook:
+		return adapters.OutgoingWebHookAdapter().Send(dtos.OutgoingWebHookRequest{
+			Url:         report.WebHookUrl,
+			ContentType: file.ContentType,
+			Headers: map[string]string{
+				"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}),
+				"X-Report-Id":         fmt.Sprint(report.ID),
+				"X-Report-Row-Count":  fmt.Sprint(run.RowCount),
+			},
+			Body: file.Content,
+		})
+	}
+
+	return nil
+}
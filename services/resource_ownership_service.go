@@ -0,0 +1,240 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OwnedResourceProvider 는 소유자가 있는 리소스 유형(웹훅, 리포트, 서비스 계정, 세그먼트)이다.
+type OwnedResourceProvider interface {
+	GetOwnedResources(ctx context.Context, ownerId uint) ([]dtos.OwnedResource, error)
+	// TransferOwnership 은 멤버가 소유한 리소스를 모두 다른 멤버에게 넘긴다. 호출하는 쪽에서 권한을 확인한다.
+	TransferOwnership(ctx context.Context, fromOwnerId, toOwnerId uint) ([]dtos.OwnedResource, error)
+	// TransferResourceOwnership 은 리소스 하나를 넘긴다. 소유자이거나 리소스 관리 권한이 있어야 한다.
+	TransferResourceOwnership(ctx context.Context, resourceId, toOwnerId uint) (dtos.OwnedResource, error)
+}
+
+type ResourceOwnershipService struct {
+	memberService *MemberService
+	auditService  *AuditService
+	resourceTypes []string
+	providers     map[string]OwnedResourceProvider
+	// managePermissions 는 유형별로 다른 멤버의 리소스까지 관리하는 권한이다.
+	managePermissions map[string]string
+}
+
+func NewResourceOwnershipService(memberService *MemberService, auditService *AuditService) *ResourceOwnershipService {
+	return &ResourceOwnershipService{
+		memberService:     memberService,
+		auditService:      auditService,
+		providers:         map[string]OwnedResourceProvider{},
+		managePermissions: map[string]string{},
+	}
+}
+
+// RegisterResourceType 은 소유자가 있는 리소스 유형과 유형의 리소스 관리 권한(managePermission)을 등록한다. 등록한 순서대로 조회하고 넘긴다.
+func (s *ResourceOwnershipService) RegisterResourceType(resourceType string, managePermission string, provider OwnedResourceProvider) {
+	if _, exists := s.providers[resourceType]; !exists {
+		s.resourceTypes = append(s.resourceTypes, resourceType)
+	}
+	s.providers[resourceType] = provider
+	s.managePermissions[resourceType] = managePermission
+}
+
+func (s ResourceOwnershipService) GetOwnedResources(ctx context.Context, ownerId uint) ([]dtos.OwnedResource, error) {
+	if _, err := s.memberService.GetMember(ctx, ownerId); err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0)
+	for _, resourceType := range s.resourceTypes {
+		owned, err := s.providers[resourceType].GetOwnedResources(ctx, ownerId)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, owned...)
+	}
+
+	return resources, nil
+}
+
+// GetUnmanageableResources 는 멤버가 소유한 리소스 중 권한이 before 에서 after 로 바뀌면 관리할 수 없게 되는 리소스이다.
+// 리소스 관리 권한이나 MANAGE_OWN_RESOURCES 권한이 있어야 소유한 리소스를 관리할 수 있다.
+func (s ResourceOwnershipService) GetUnmanageableResources(ctx context.Context, ownerId uint, before []string, after []string) ([]dtos.OwnedResource, error) {
+	resources := make([]dtos.OwnedResource, 0)
+	for _, resourceType := range s.resourceTypes {
+		manageable := []string{s.managePermissions[resourceType], constants.PermissionManageOwnResources}
+		if !containsAnyString(before, manageable) || containsAnyString(after, manageable) {
+			continue
+		}
+
+		owned, err := s.providers[resourceType].GetOwnedResources(ctx, ownerId)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, owned...)
+	}
+
+	return resources, nil
+}
+
+// TransferMemberResources 는 멤버가 소유한 리소스를 다른 멤버에게 한 번에 넘긴다.(예. 퇴사, 조직 이동)
+func (s ResourceOwnershipService) TransferMemberResources(ctx context.Context, transfer dtos.ResourceOwnershipTransfer) ([]dtos.OwnedResource, error) {
+	if transfer.FromOwnerId == transfer.ToOwnerId {
+		return nil, &errors.ErrInvalidOwnershipTransfer{Reason: "new owner must be another member"}
+	}
+
+	if _, err := s.memberService.GetMember(ctx, transfer.FromOwnerId); err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, &errors.ErrInvalidOwnershipTransfer{Reason: fmt.Sprintf("member %v not found", transfer.FromOwnerId)}
+		}
+		return nil, err
+	}
+
+	if err := s.validateNewOwner(ctx, transfer.ToOwnerId); err != nil {
+		return nil, err
+	}
+
+	for _, resourceType := range transfer.ResourceTypes {
+		if _, exists := s.providers[resourceType]; !exists {
+			return nil, &errors.ErrInvalidOwnershipTransfer{Reason: fmt.Sprintf("unsupported resource type %s", resourceType)}
+		}
+	}
+
+	resources, err := s.TransferOwnership(ctx, transfer.FromOwnerId, transfer.ToOwnerId, transfer.ResourceTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.recordTransfer(ctx, transfer.ToOwnerId, fmt.Sprintf("fromOwnerId=%v, ", transfer.FromOwnerId), resources); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+// TransferOwnership 은 resourceTypes(비어 있으면 모든 유형)의 리소스 중 멤버가 소유한 것을 넘긴다. 멤버 해지에서도 사용한다.
+func (s ResourceOwnershipService) TransferOwnership(ctx context.Context, fromOwnerId, toOwnerId uint, resourceTypes []string) ([]dtos.OwnedResource, error) {
+	if len(resourceTypes) == 0 {
+		resourceTypes = s.resourceTypes
+	}
+
+	resources := make([]dtos.OwnedResource, 0)
+	for _, resourceType := range resourceTypes {
+		transferred, err := s.providers[resourceType].TransferOwnership(ctx, fromOwnerId, toOwnerId)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, transferred...)
+	}
+
+	return resources, nil
+}
+
+// TransferResources 는 지정한 리소스들을 새 소유자에게 넘긴다. 하나라도 넘길 수 없으면 모두 넘기지 않는다.
+func (s ResourceOwnershipService) TransferResources(ctx context.Context, transfer dtos.ResourceOwnershipResourcesTransfer) ([]dtos.OwnedResource, error) {
+	if err := s.validateNewOwner(ctx, transfer.ToOwnerId); err != nil {
+		return nil, err
+	}
+
+	for _, resource := range transfer.Resources {
+		if _, exists := s.providers[resource.Type]; !exists {
+			return nil, &errors.ErrInvalidOwnershipTransfer{Reason: fmt.Sprintf("unsupported resource type %s", resource.Type)}
+		}
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(transfer.Resources))
+	for _, resource := range transfer.Resources {
+		transferred, err := s.providers[resource.Type].TransferResourceOwnership(ctx, resource.Id, transfer.ToOwnerId)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, transferred)
+	}
+
+	if err := s.recordTransfer(ctx, transfer.ToOwnerId, "", resources); err != nil {
+		return nil, err
+	}
+
+	return resources, nil
+}
+
+func (s ResourceOwnershipService) validateNewOwner(ctx context.Context, ownerId uint) error {
+	owner, err := s.memberService.GetMember(ctx, ownerId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return &errors.ErrInvalidOwnershipTransfer{Reason: fmt.Sprintf("member %v not found", ownerId)}
+		}
+		return err
+	}
+
+	if !owner.IsApproved() {
+		return &errors.ErrInvalidOwnershipTransfer{Reason: fmt.Sprintf("member %v is not an approved member", ownerId)}
+	}
+
+	return nil
+}
+
+// recordTransfer 는 새 소유자를 대상으로 감사 로그를 남긴다. 넘긴 리소스가 없으면 남기지 않는다.
+func (s ResourceOwnershipService) recordTransfer(ctx context.Context, toOwnerId uint, detailPrefix string, resources []dtos.OwnedResource) error {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	references := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		references = append(references, fmt.Sprintf("%s:%v", resource.Type, resource.Id))
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionResourceOwnershipTransferred, constants.AuditTargetTypeMember, toOwnerId,
+		fmt.Sprintf("%sresources=%s", detailPrefix, strings.Join(references, ", ")))
+}
+
+// authorizeResourceOwner 는 리소스의 소유자이거나 리소스 관리 권한(permission)이 있는지 확인한다.
+// 리소스 관리 권한 없이 MANAGE_OWN_RESOURCES 권한만 있는 멤버는 소유한 리소스만 관리할 수 있다.
+func authorizeResourceOwner(ctx context.Context, ownerId uint, permission string) error {
+	if hasAnyClaimPermission(ctx, []string{permission}) {
+		return nil
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 소유자는 멤버이므로 서비스 계정은 Id 가 같아도 소유자가 아니다.
+	if userClaim.IsServiceAccount() || userClaim.Id != ownerId {
+		return errors.ErrForbidden
+	}
+
+	return nil
+}
+
+// applyOwnerFilter 는 목록 조회 조건(filters)에 소유자 조건을 추가한다.
+// ownedByMe 가 true 이거나 리소스 관리 권한(permission)이 없으면 요청한 멤버가 소유한 리소스만 조회한다.
+// 서비스 계정은 소유한 리소스가 없으므로 리소스 관리 권한이 없으면 ErrForbidden 이다.
+func applyOwnerFilter(ctx context.Context, filters map[string]interface{}, permission string) error {
+	ownedByMe := filters["ownedByMe"] == true
+	delete(filters, "ownedByMe")
+
+	if !ownedByMe && hasAnyClaimPermission(ctx, []string{permission}) {
+		return nil
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userClaim.IsServiceAccount() {
+		return errors.ErrForbidden
+	}
+
+	filters["ownerId"] = userClaim.Id
+	return nil
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/retention/domain"
+	"better-admin-backend-service/retention/repository"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"time"
+)
+
+// RetentionService 는 데이터 종류별 보관 기간이 지난 데이터를 지운다. 법적 보존(legal hold)으로 지정한 멤버나 기간의 데이터는 지우지 않는다.
+// 실행할 때마다 종류별로 지운 수를 보고서(retention_runs)로 남긴다.
+type RetentionService struct {
+	siteService             *SiteService
+	memberService           *MemberService
+	auditService            *AuditService
+	legalHoldRepository     *repository.LegalHoldRepository
+	retentionRunRepository  *repository.RetentionRunRepository
+	retentionDataRepository *repository.RetentionDataRepository
+}
+
+func NewRetentionService(
+	siteService *SiteService,
+	memberService *MemberService,
+	auditService *AuditService,
+	legalHoldRepository *repository.LegalHoldRepository,
+	retentionRunRepository *repository.RetentionRunRepository,
+	retentionDataRepository *repository.RetentionDataRepository) *RetentionService {
+
+	return &RetentionService{
+		siteService:             siteService,
+		memberService:           memberService,
+		auditService:            auditService,
+		legalHoldRepository:     legalHoldRepository,
+		retentionRunRepository:  retentionRunRepository,
+		retentionDataRepository: retentionDataRepository,
+	}
+}
+
+// GetRetentionSetting 은 데이터 종류별로 바꾼 보관 기간이다. 설정하지 않았으면 기본 설정(config.Retention)을 사용한다.
+func (s RetentionService) GetRetentionSetting(ctx context.Context) (dtos.RetentionSetting, error) {
+	retentionSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyRetention)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.RetentionSetting{Days: map[string]int{}}, nil
+		}
+		return dtos.RetentionSetting{}, err
+	}
+
+	var setting dtos.RetentionSetting
+	if err = mapstructure.Decode(retentionSetting, &setting); err != nil {
+		return dtos.RetentionSetting{}, err
+	}
+	if setting.Days == nil {
+		setting.Days = map[string]int{}
+	}
+
+	return setting, nil
+}
+
+func (s RetentionService) SetRetentionSetting(ctx context.Context, setting dtos.RetentionSetting) error {
+	for category, days := range setting.Days {
+		if _, ok := domain.GetRetentionTarget(category); !ok {
+			return &errors.ErrInvalidRetention{Reason: fmt.Sprintf("%q is not a retention category", category)}
+		}
+		if days < 0 {
+			return &errors.ErrInvalidRetention{Reason: fmt.Sprintf("%q retention days must be 0 or more", category)}
+		}
+	}
+
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyRetention, setting)
+}
+
+// GetRetentionPolicies 는 데이터 종류별 기본 보관 기간과 사이트 설정을 반영한 보관 기간이다.
+func (s RetentionService) GetRetentionPolicies(ctx context.Context) ([]dtos.RetentionPolicy, error) {
+	setting, err := s.GetRetentionSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]dtos.RetentionPolicy, 0)
+	for _, category := range domain.RetentionCategories() {
+		policy := dtos.RetentionPolicy{Category: category, DefaultDays: defaultRetentionDays(category)}
+		policy.Days, policy.Overridden = setting.Days[category]
+		if !policy.Overridden {
+			policy.Days = policy.DefaultDays
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// CreateLegalHold 는 법적 보존을 지정한다. 지정한 데이터는 보존을 해제할 때까지 보관 기간이 지나도 지우지 않는다.
+func (s RetentionService) CreateLegalHold(ctx context.Context, request dtos.LegalHoldRequest) (domain.LegalHoldEntity, error) {
+	entity, err := domain.NewLegalHoldEntity(request, actorIdOf(ctx))
+	if err != nil {
+		return domain.LegalHoldEntity{}, err
+	}
+
+	for _, memberId := range []uint{entity.MemberId, entity.OwnerId} {
+		if memberId == 0 {
+			continue
+		}
+		if _, err := s.memberService.GetMember(ctx, memberId); err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				return domain.LegalHoldEntity{}, &errors.ErrInvalidRetention{Reason: fmt.Sprintf("member %v not found", memberId)}
+			}
+			return domain.LegalHoldEntity{}, err
+		}
+	}
+
+	if err := s.legalHoldRepository.Create(ctx, &entity); err != nil {
+		return domain.LegalHoldEntity{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionLegalHoldCreated, constants.AuditTargetTypeLegalHold, entity.ID,
+		fmt.Sprintf("memberId=%v, ownerId=%v, reason=%v", entity.MemberId, entity.OwnerId, entity.Reason)); err != nil {
+		return domain.LegalHoldEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// GetLegalHolds 는 memberId 가 있으면 멤버를 보존하는 법적 보존만 조회한다.
+func (s RetentionService) GetLegalHolds(ctx context.Context, memberId uint) ([]domain.LegalHoldEntity, error) {
+	if memberId > 0 {
+		return s.legalHoldRepository.FindByMemberId(ctx, memberId)
+	}
+
+	return s.legalHoldRepository.FindAll(ctx)
+}
+
+// CheckMemberLegalHold 는 멤버를 보존하는 법적 보존이 있으면 errors.ErrLegalHold 이다.
+func (s RetentionService) CheckMemberLegalHold(ctx context.Context, memberId uint) error {
+	holds, err := s.legalHoldRepository.FindByMemberId(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if len(holds) > 0 {
+		return errors.ErrLegalHold
+	}
+
+	return nil
+}
+
+func (s RetentionService) ReleaseLegalHold(ctx context.Context, legalHoldId uint) error {
+	entity, err := s.legalHoldRepository.FindById(ctx, legalHoldId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.legalHoldRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionLegalHoldReleased, constants.AuditTargetTypeLegalHold, entity.ID, entity.Reason)
+}
+
+// EnforceRetention 은 주기 작업에서 보관 기간을 적용한다.
+func (s RetentionService) EnforceRetention(ctx context.Context) error {
+	run, err := s.RunRetention(ctx, constants.RetentionRunTriggerScheduled)
+	if err != nil {
+		return err
+	}
+
+	if run.Status == constants.RetentionRunStatusFailed {
+		log.Errorf("retention run %v failed", run.ID)
+	}
+
+	return nil
+}
+
+// RunRetention 은 데이터 종류마다 보관 기간이 지난 데이터를 지우고 실행 보고서를 남긴다.
+// 종류마다 따로 지우므로 한 종류가 실패해도 나머지 종류는 지운다.
+func (s RetentionService) RunRetention(ctx context.Context, trigger string) (domain.RetentionRunEntity, error) {
+	policies, err := s.GetRetentionPolicies(ctx)
+	if err != nil {
+		return domain.RetentionRunEntity{}, err
+	}
+
+	holds, err := s.legalHoldRepository.FindAll(ctx)
+	if err != nil {
+		return domain.RetentionRunEntity{}, err
+	}
+
+	run := domain.NewRetentionRunEntity(trigger, actorIdOf(ctx))
+	results := make([]dtos.RetentionResult, 0, len(policies))
+	for _, policy := range policies {
+		result := dtos.RetentionResult{Category: policy.Category, Days: policy.Days}
+		if policy.Days > 0 {
+			cutoff := run.StartedAt.AddDate(0, 0, -policy.Days)
+			result.Cutoff = &cutoff
+			if err := s.deleteExpired(ctx, policy.Category, cutoff, holds, &result); err != nil {
+				log.Errorf("retention %v error: %v", policy.Category, err)
+				result.ErrorMessage = err.Error()
+			}
+		}
+		results = append(results, result)
+	}
+
+	if err := run.Finish(results); err != nil {
+		return domain.RetentionRunEntity{}, err
+	}
+
+	if err := s.retentionRunRepository.Create(ctx, &run); err != nil {
+		return domain.RetentionRunEntity{}, err
+	}
+
+	return run, nil
+}
+
+func (s RetentionService) GetRetentionRuns(ctx context.Context, pageable dtos.Pageable) ([]domain.RetentionRunEntity, int64, error) {
+	return s.retentionRunRepository.FindAll(ctx, pageable)
+}
+
+func (s RetentionService) GetRetentionRun(ctx context.Context, runId uint) (domain.RetentionRunEntity, error) {
+	return s.retentionRunRepository.FindById(ctx, runId)
+}
+
+func (s RetentionService) deleteExpired(ctx context.Context, category string, cutoff time.Time, holds []domain.LegalHoldEntity, result *dtos.RetentionResult) error {
+	target, _ := domain.GetRetentionTarget(category)
+
+	return helpers.ContextHelper().GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		txCtx := helpers.ContextHelper().SetDB(ctx, tx)
+
+		expiredCount, err := s.retentionDataRepository.Count(txCtx, target, target.ExpiredConditions(cutoff))
+		if err != nil {
+			return err
+		}
+
+		deletedCount, err := s.retentionDataRepository.Delete(txCtx, target, target.Conditions(cutoff, holds))
+		if err != nil {
+			return err
+		}
+
+		result.DeletedCount = deletedCount
+		result.HeldCount = expiredCount - deletedCount
+		return nil
+	})
+}
+
+func defaultRetentionDays(category string) int {
+	retentionConfig := config.Config.Retention
+
+	switch category {
+	case constants.RetentionCategoryAccessLogs:
+		return retentionConfig.AccessLogDays
+	case constants.RetentionCategoryAuditLogs:
+		return retentionConfig.AuditLogDays
+	case constants.RetentionCategoryNotifications:
+		return retentionConfig.NotificationDays
+	case constants.RetentionCategoryExports:
+		return retentionConfig.ExportDays
+	case constants.RetentionCategorySecurityEvents:
+		return retentionConfig.SecurityEventDays
+	}
+
+	return 0
+}
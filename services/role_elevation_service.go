@@ -0,0 +1,266 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/rbac/repository"
+	"context"
+	"fmt"
+	"time"
+)
+
+// RoleElevationService 는 멤버가 사유를 남기고 요청한 임시 권한 상승을 승인 절차(role-elevation)를 거쳐 기한 동안만 할당한다.
+// 승인 절차가 정의되어 있지 않으면 요청할 수 없다.
+type RoleElevationService struct {
+	memberService           *MemberService
+	rbacService             *RoleBasedAccessControlService
+	approvalService         *ApprovalService
+	roleElevationRepository *repository.RoleElevationRepository
+	auditService            *AuditService
+}
+
+func NewRoleElevationService(memberService *MemberService,
+	rbacService *RoleBasedAccessControlService,
+	approvalService *ApprovalService,
+	roleElevationRepository *repository.RoleElevationRepository,
+	auditService *AuditService) *RoleElevationService {
+	return &RoleElevationService{
+		memberService:           memberService,
+		rbacService:             rbacService,
+		approvalService:         approvalService,
+		roleElevationRepository: roleElevationRepository,
+		auditService:            auditService,
+	}
+}
+
+// RequestElevation 은 로그인한 멤버의 임시 권한 상승을 요청하고 승인자에게 알린다.
+// 이미 가진 역할과 최고 관리자 역할(비상 접근 계정을 사용한다)은 요청할 수 없다. 서비스 계정은 요청할 수 없다(ErrAuthentication).
+func (s RoleElevationService) RequestElevation(ctx context.Context, request dtos.RoleElevationRequest) (domain.RoleElevationEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return domain.RoleElevationEntity{}, err
+	}
+
+	if maxHours := config.Config.RoleElevation.MaxHours; request.Hours > maxHours {
+		return domain.RoleElevationEntity{}, &errors.ErrInvalidRoleElevation{Reason: fmt.Sprintf("hours must be less than or equal to %v", maxHours)}
+	}
+
+	setting, err := s.approvalService.GetWorkflowSetting(ctx)
+	if err != nil {
+		return domain.RoleElevationEntity{}, err
+	}
+
+	if _, ok := setting.FindWorkflow(constants.ApprovalSubjectRoleElevation); !ok {
+		return domain.RoleElevationEntity{}, &errors.ErrInvalidRoleElevation{Reason: "approval workflow for role elevation is not defined"}
+	}
+
+	roleEntity, err := s.rbacService.GetRole(ctx, request.RoleId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return domain.RoleElevationEntity{}, &errors.ErrInvalidRoleElevation{Reason: fmt.Sprintf("role %v not found", request.RoleId)}
+		}
+		return domain.RoleElevationEntity{}, err
+	}
+
+	if roleEntity.Name == constants.RoleNameSuperAdmin {
+		return domain.RoleElevationEntity{}, &errors.ErrInvalidRoleElevation{Reason: "super admin role can not be elevated"}
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, userClaim.Id)
+	if err != nil {
+		return domain.RoleElevationEntity{}, err
+	}
+
+	if memberEntity.HasRole(roleEntity.ID) {
+		return domain.RoleElevationEntity{}, &errors.ErrInvalidRoleElevation{Reason: "role is already assigned"}
+	}
+
+	openEntities, err := s.roleElevationRepository.FindOpenByMemberIdAndRoleId(ctx, memberEntity.ID, roleEntity.ID)
+	if err != nil {
+		return domain.RoleElevationEntity{}, err
+	}
+
+	if len(openEntities) > 0 {
+		return domain.RoleElevationEntity{}, errors.ErrApprovalInProgress
+	}
+
+	entity := domain.NewRoleElevationEntity(memberEntity.ID, roleEntity, request)
+	if err := s.roleElevationRepository.Create(ctx, &entity); err != nil {
+		return domain.RoleElevationEntity{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionRoleElevationRequested, constants.AuditTargetTypeRoleElevation, entity.ID,
+		fmt.Sprintf("memberId=%v, role=%v, hours=%v, justification=%v", entity.MemberId, entity.RoleName, entity.Hours, entity.Justification)); err != nil {
+		return domain.RoleElevationEntity{}, err
+	}
+
+	if _, err := s.approvalService.RequireApproval(ctx, constants.ApprovalSubjectRoleElevation, entity.ID, entity.ToInformation()); err != nil {
+		return domain.RoleElevationEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// ActivateElevation 은 승인된 상승의 역할을 할당하고 기한을 시작한다. 승인을 기다리는 동안 역할을 받았거나 역할이 지워졌으면 승인할 수 없다.
+func (s RoleElevationService) ActivateElevation(ctx context.Context, id uint) error {
+	entity, err := s.roleElevationRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, entity.MemberId)
+	if err != nil {
+		return err
+	}
+
+	if memberEntity.HasRole(entity.RoleId) {
+		return &errors.ErrInvalidRoleElevation{Reason: "role is already assigned"}
+	}
+
+	roleEntity, err := s.rbacService.GetRole(ctx, entity.RoleId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return &errors.ErrInvalidRoleElevation{Reason: fmt.Sprintf("role %v not found", entity.RoleId)}
+		}
+		return err
+	}
+
+	if err := entity.Activate(time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.roleElevationRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	if err := s.memberService.UpdateRoles(ctx, &memberEntity, []domain.RoleEntity{roleEntity}, nil); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionRoleElevationActivated, constants.AuditTargetTypeRoleElevation, entity.ID,
+		fmt.Sprintf("memberId=%v, role=%v, expiresAt=%v", entity.MemberId, entity.RoleName, entity.ExpiresAt.Format(time.RFC3339)))
+}
+
+func (s RoleElevationService) RejectElevation(ctx context.Context, id uint) error {
+	entity, err := s.roleElevationRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Reject(time.Now()); err != nil {
+		return err
+	}
+
+	if err := s.roleElevationRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionRoleElevationRejected, constants.AuditTargetTypeRoleElevation, entity.ID,
+		fmt.Sprintf("memberId=%v, role=%v", entity.MemberId, entity.RoleName))
+}
+
+// RevokeElevation 은 기한 전에 상승을 끝내고 역할을 회수한다. 승인을 기다리는 요청은 승인 요청도 만료한다.
+func (s RoleElevationService) RevokeElevation(ctx context.Context, id uint) error {
+	entity, err := s.roleElevationRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	pending := entity.Status == constants.RoleElevationStatusPending
+	if err := entity.Revoke(time.Now(), actorIdOf(ctx)); err != nil {
+		return err
+	}
+
+	if pending {
+		if err := s.approvalService.ExpirePendingRequests(ctx, constants.ApprovalSubjectRoleElevation, entity.ID); err != nil {
+			return err
+		}
+	}
+
+	return s.end(ctx, &entity, !pending, constants.AuditActionRoleElevationRevoked)
+}
+
+// ExpireElevations 는 기한이 지난 상승의 역할을 회수한다.
+func (s RoleElevationService) ExpireElevations(ctx context.Context) error {
+	now := time.Now()
+	entities, err := s.roleElevationRepository.FindExpiredActive(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range entities {
+		if err := entities[i].Expire(now); err != nil {
+			return err
+		}
+
+		if err := s.end(ctx, &entities[i], true, constants.AuditActionRoleElevationExpired); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// end 는 끝난 상승을 저장하고 revokeRole 이면 역할을 회수한다. 그 사이 역할이 회수되었으면 다시 회수하지 않는다.
+func (s RoleElevationService) end(ctx context.Context, entity *domain.RoleElevationEntity, revokeRole bool, action string) error {
+	if err := s.roleElevationRepository.Save(ctx, entity); err != nil {
+		return err
+	}
+
+	if revokeRole {
+		memberEntity, err := s.memberService.GetMemberById(ctx, entity.MemberId)
+		if err != nil && !errors.Is(err, errors.ErrNotFound) {
+			return err
+		}
+
+		if err == nil && memberEntity.HasRole(entity.RoleId) {
+			if err := s.memberService.UpdateRoles(ctx, &memberEntity, nil, []uint{entity.RoleId}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.auditService.RecordAuditLog(ctx, action, constants.AuditTargetTypeRoleElevation, entity.ID,
+		fmt.Sprintf("memberId=%v, role=%v", entity.MemberId, entity.RoleName))
+}
+
+func (s RoleElevationService) GetElevation(ctx context.Context, id uint) (domain.RoleElevationEntity, error) {
+	return s.roleElevationRepository.FindById(ctx, id)
+}
+
+// GetElevations 는 memberId, status 로 임시 권한 상승을 조회한다.
+func (s RoleElevationService) GetElevations(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.RoleElevationEntity, int64, error) {
+	return s.roleElevationRepository.FindAll(ctx, filters, pageable)
+}
+
+// FindActiveElevationId 는 멤버가 지금 상승 중이면 가장 최근 상승의 ID 를 반환한다. 감사 로그에 상승 ID 를 기록할 때 사용한다.
+func (s RoleElevationService) FindActiveElevationId(ctx context.Context, memberId uint) (uint, error) {
+	entities, err := s.roleElevationRepository.FindActiveByMemberId(ctx, memberId, time.Now())
+	if err != nil || len(entities) == 0 {
+		return 0, err
+	}
+
+	return entities[0].ID, nil
+}
+
+// GetElevationAuditLogs 는 상승 중에 멤버가 남긴 감사 로그이다.
+func (s RoleElevationService) GetElevationAuditLogs(ctx context.Context, id uint) ([]dtos.Event, error) {
+	if _, err := s.roleElevationRepository.FindById(ctx, id); err != nil {
+		return nil, err
+	}
+
+	entities, err := s.auditService.GetElevationAuditLogs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]dtos.Event, 0)
+	for _, entity := range entities {
+		events = append(events, entity.ToEvent())
+	}
+
+	return events, nil
+}
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/rbac/repository"
+	"better-admin-backend-service/security"
+	"context"
+	"encoding/json"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RoleMemberBulkService 는 역할에 여러 멤버를 한 번에 할당하거나 제거한다. 중복된 역할을 정리할 수 있도록 역할 비교와 병합도 제공한다.
+// 멤버마다 실패 사유를 모아 반환하고, 감사 로그는 요청마다 하나만 남긴다.
+type RoleMemberBulkService struct {
+	rbacService                 *RoleBasedAccessControlService
+	memberService               *MemberService
+	segmentService              *SegmentService
+	approvalService             *ApprovalService
+	auditService                *AuditService
+	roleMemberBulkJobRepository *repository.RoleMemberBulkJobRepository
+}
+
+func NewRoleMemberBulkService(
+	rbacService *RoleBasedAccessControlService,
+	memberService *MemberService,
+	segmentService *SegmentService,
+	approvalService *ApprovalService,
+	auditService *AuditService,
+	roleMemberBulkJobRepository *repository.RoleMemberBulkJobRepository) *RoleMemberBulkService {
+
+	return &RoleMemberBulkService{
+		rbacService:                 rbacService,
+		memberService:               memberService,
+		segmentService:              segmentService,
+		approvalService:             approvalService,
+		auditService:                auditService,
+		roleMemberBulkJobRepository: roleMemberBulkJobRepository,
+	}
+}
+
+// Apply 는 요청을 바로 처리한다.
+func (s RoleMemberBulkService) Apply(ctx context.Context, roleId uint, request dtos.RoleMemberBulkRequest) (dtos.RoleMemberBulkResult, error) {
+	roleEntity, err := s.rbacService.GetRole(ctx, roleId)
+	if err != nil {
+		return dtos.RoleMemberBulkResult{}, err
+	}
+
+	if err := s.checkApproval(ctx, request); err != nil {
+		return dtos.RoleMemberBulkResult{}, err
+	}
+
+	return s.apply(ctx, roleEntity, request)
+}
+
+// Enqueue 는 요청을 작업으로 등록한다. 작업은 ProcessPendingJobs 에서 요청자의 권한으로 처리한다.
+func (s RoleMemberBulkService) Enqueue(ctx context.Context, roleId uint, request dtos.RoleMemberBulkRequest) (domain.RoleMemberBulkJobEntity, error) {
+	roleEntity, err := s.rbacService.GetRole(ctx, roleId)
+	if err != nil {
+		return domain.RoleMemberBulkJobEntity{}, err
+	}
+
+	// 작업은 요청자의 단계 인증을 확인할 수 없으므로 최고 관리자 역할은 바로 처리(Apply)해야 한다.
+	if roleEntity.Name == constants.RoleNameSuperAdmin {
+		return domain.RoleMemberBulkJobEntity{}, errors.ErrStepUpRequired
+	}
+
+	if err := s.checkApproval(ctx, request); err != nil {
+		return domain.RoleMemberBulkJobEntity{}, err
+	}
+
+	entity, err := domain.NewRoleMemberBulkJobEntity(ctx, roleId, request)
+	if err != nil {
+		return domain.RoleMemberBulkJobEntity{}, err
+	}
+
+	if err := s.roleMemberBulkJobRepository.Create(ctx, &entity); err != nil {
+		return domain.RoleMemberBulkJobEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s RoleMemberBulkService) GetJob(ctx context.Context, roleId uint, jobId uint) (domain.RoleMemberBulkJobEntity, error) {
+	return s.roleMemberBulkJobRepository.FindByRoleIdAndId(ctx, roleId, jobId)
+}
+
+// ProcessPendingJobs 는 대기 중인 작업을 처리한다. 작업마다 트랜잭션(savepoint)을 나누어 실패한 작업만 되돌린다.
+func (s RoleMemberBulkService) ProcessPendingJobs(ctx context.Context) error {
+	jobs, err := s.roleMemberBulkJobRepository.FindByStatus(ctx, constants.RoleMemberBulkJobStatusPending)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		var result dtos.RoleMemberBulkResult
+		jobCtx := helpers.ContextHelper().SetUserClaim(ctx, &security.UserClaim{Id: job.RequestedBy})
+		err := helpers.ContextHelper().GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+			txCtx := helpers.ContextHelper().SetDB(jobCtx, tx)
+
+			request, err := job.GetRequest()
+			if err != nil {
+				return err
+			}
+
+			roleEntity, err := s.rbacService.GetRole(txCtx, job.RoleId)
+			if err != nil {
+				return err
+			}
+
+			result, err = s.apply(txCtx, roleEntity, request)
+			return err
+		})
+
+		if err != nil {
+			log.Errorf("role member bulk job error. jobId=%v, %v", job.ID, err)
+			job.Fail(err)
+		} else if err := job.Succeed(result); err != nil {
+			return err
+		}
+
+		if err := s.roleMemberBulkJobRepository.Save(ctx, &job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CompareRoles 는 두 역할의 권한과 직접 할당된 멤버를 비교한다.
+func (s RoleMemberBulkService) CompareRoles(ctx context.Context, roleIdA uint, roleIdB uint) (dtos.RoleComparison, error) {
+	roleA, err := s.rbacService.GetRole(ctx, roleIdA)
+	if err != nil {
+		return dtos.RoleComparison{}, err
+	}
+
+	roleB, err := s.rbacService.GetRole(ctx, roleIdB)
+	if err != nil {
+		return dtos.RoleComparison{}, err
+	}
+
+	membersA, err := s.findRoleMembers(ctx, roleA.ID)
+	if err != nil {
+		return dtos.RoleComparison{}, err
+	}
+
+	membersB, err := s.findRoleMembers(ctx, roleB.ID)
+	if err != nil {
+		return dtos.RoleComparison{}, err
+	}
+
+	comparison := dtos.RoleComparison{
+		RoleA:             dtos.RoleComparisonRole{Id: roleA.ID, Name: roleA.Name, MemberCount: len(membersA)},
+		RoleB:             dtos.RoleComparisonRole{Id: roleB.ID, Name: roleB.Name, MemberCount: len(membersB)},
+		OnlyInA:           make([]dtos.AllowedPermission, 0),
+		OnlyInB:           make([]dtos.AllowedPermission, 0),
+		SharedPermissions: make([]dtos.AllowedPermission, 0),
+		Members: dtos.RoleMemberOverlap{
+			OnlyInA: make([]uint, 0),
+			OnlyInB: make([]uint, 0),
+			Shared:  make([]uint, 0),
+		},
+	}
+
+	permissionsB := map[uint]bool{}
+	for _, permission := range roleB.Permissions {
+		permissionsB[permission.ID] = true
+	}
+	permissionsA := map[uint]bool{}
+	for _, permission := range roleA.Permissions {
+		permissionsA[permission.ID] = true
+		allowedPermission := dtos.AllowedPermission{Id: permission.ID, Name: permission.Name}
+		if permissionsB[permission.ID] {
+			comparison.SharedPermissions = append(comparison.SharedPermissions, allowedPermission)
+		} else {
+			comparison.OnlyInA = append(comparison.OnlyInA, allowedPermission)
+		}
+	}
+	for _, permission := range roleB.Permissions {
+		if !permissionsA[permission.ID] {
+			comparison.OnlyInB = append(comparison.OnlyInB, dtos.AllowedPermission{Id: permission.ID, Name: permission.Name})
+		}
+	}
+
+	memberIdsB := map[uint]bool{}
+	for _, memberEntity := range membersB {
+		memberIdsB[memberEntity.ID] = true
+	}
+	memberIdsA := map[uint]bool{}
+	for _, memberEntity := range membersA {
+		memberIdsA[memberEntity.ID] = true
+		if memberIdsB[memberEntity.ID] {
+			comparison.Members.Shared = append(comparison.Members.Shared, memberEntity.ID)
+		} else {
+			comparison.Members.OnlyInA = append(comparison.Members.OnlyInA, memberEntity.ID)
+		}
+	}
+	for _, memberEntity := range membersB {
+		if !memberIdsA[memberEntity.ID] {
+			comparison.Members.OnlyInB = append(comparison.Members.OnlyInB, memberEntity.ID)
+		}
+	}
+
+	return comparison, nil
+}
+
+// MergeRole 은 역할(sourceRoleId)의 멤버를 모두 다른 역할(merge.TargetRoleId)로 옮긴다. 옮긴 뒤 역할은 삭제하지 않는다.
+func (s RoleMemberBulkService) MergeRole(ctx context.Context, sourceRoleId uint, merge dtos.RoleMerge) (dtos.RoleMergeResult, error) {
+	if sourceRoleId == merge.TargetRoleId {
+		return dtos.RoleMergeResult{}, errors.ErrInvalidTarget
+	}
+
+	source, err := s.rbacService.GetRole(ctx, sourceRoleId)
+	if err != nil {
+		return dtos.RoleMergeResult{}, err
+	}
+
+	target, err := s.rbacService.GetRole(ctx, merge.TargetRoleId)
+	if err != nil {
+		return dtos.RoleMergeResult{}, err
+	}
+
+	if err := s.checkApproval(ctx, dtos.RoleMemberBulkRequest{Action: constants.RoleMemberBulkActionAssign}); err != nil {
+		return dtos.RoleMergeResult{}, err
+	}
+
+	memberEntities, err := s.findRoleMembers(ctx, source.ID)
+	if err != nil {
+		return dtos.RoleMergeResult{}, err
+	}
+
+	result := dtos.RoleMergeResult{
+		SourceRoleId: source.ID,
+		TargetRoleId: target.ID,
+	}
+	movedMembers := make([]memberDomain.MemberEntity, 0, len(memberEntities))
+	for _, memberEntity := range memberEntities {
+		if memberEntity.HasRole(target.ID) {
+			result.AlreadyAssigned++
+		} else {
+			result.Moved++
+			movedMembers = append(movedMembers, memberEntity)
+		}
+	}
+
+	// 나누어 옮기다 중간에 거부되지 않도록 최고 관리자 역할 변경은 전체를 먼저 확인한다.
+	if err := s.memberService.CheckSuperAdminChange(ctx, superAdminMemberIdsOf(target, movedMembers),
+		superAdminMemberIdsOf(source, memberEntities)); err != nil {
+		return dtos.RoleMergeResult{}, err
+	}
+
+	for start := 0; start < len(memberEntities); start += constants.RoleMemberBulkChunkSize {
+		end := start + constants.RoleMemberBulkChunkSize
+		if end > len(memberEntities) {
+			end = len(memberEntities)
+		}
+
+		if err := s.memberService.MoveRole(ctx, source, target, memberEntities[start:end]); err != nil {
+			return dtos.RoleMergeResult{}, err
+		}
+	}
+
+	detail, err := json.Marshal(result)
+	if err != nil {
+		return dtos.RoleMergeResult{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionRoleMembersMerged, constants.AuditTargetTypeRole, source.ID, string(detail)); err != nil {
+		return dtos.RoleMergeResult{}, err
+	}
+
+	return result, nil
+}
+
+func (s RoleMemberBulkService) findRoleMembers(ctx context.Context, roleId uint) ([]memberDomain.MemberEntity, error) {
+	filters := map[string]interface{}{}
+	filters["roleIds"] = []uint{roleId}
+	memberEntities, _, err := s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+	return memberEntities, err
+}
+
+// checkApproval 은 역할 부여에 승인 절차가 있으면 일괄 할당을 거부한다. 승인은 멤버마다 받아야 하므로 일괄로 우회할 수 없다.
+func (s RoleMemberBulkService) checkApproval(ctx context.Context, request dtos.RoleMemberBulkRequest) error {
+	if request.Action != constants.RoleMemberBulkActionAssign {
+		return nil
+	}
+
+	setting, err := s.approvalService.GetWorkflowSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := setting.FindWorkflow(constants.ApprovalSubjectRoleGrant); ok {
+		return errors.ErrApprovalRequired
+	}
+
+	return nil
+}
+
+func (s RoleMemberBulkService) apply(ctx context.Context, roleEntity domain.RoleEntity, request dtos.RoleMemberBulkRequest) (dtos.RoleMemberBulkResult, error) {
+	result := dtos.RoleMemberBulkResult{
+		Action: request.Action,
+		Failed: make([]dtos.RoleMemberBulkFailure, 0),
+	}
+
+	memberEntities, err := s.findMembers(ctx, request, &result)
+	if err != nil {
+		return dtos.RoleMemberBulkResult{}, err
+	}
+
+	targets := make([]memberDomain.MemberEntity, 0, len(memberEntities))
+	for _, memberEntity := range memberEntities {
+		hasRole := memberEntity.HasRole(roleEntity.ID)
+		if request.Action == constants.RoleMemberBulkActionAssign && hasRole {
+			result.Failed = append(result.Failed, dtos.RoleMemberBulkFailure{MemberId: memberEntity.ID, Reason: constants.RoleMemberBulkFailureAlreadyAssigned})
+			continue
+		}
+		if request.Action == constants.RoleMemberBulkActionRemove && !hasRole {
+			result.Failed = append(result.Failed, dtos.RoleMemberBulkFailure{MemberId: memberEntity.ID, Reason: constants.RoleMemberBulkFailureNotAssigned})
+			continue
+		}
+		targets = append(targets, memberEntity)
+	}
+
+	// 나누어 처리하다 중간에 거부되지 않도록 최고 관리자 역할 변경은 전체를 먼저 확인한다.
+	superAdmins := superAdminMemberIdsOf(roleEntity, targets)
+	if request.Action == constants.RoleMemberBulkActionAssign {
+		err = s.memberService.CheckSuperAdminChange(ctx, superAdmins, nil)
+	} else {
+		err = s.memberService.CheckSuperAdminChange(ctx, nil, superAdmins)
+	}
+	if err != nil {
+		return dtos.RoleMemberBulkResult{}, err
+	}
+
+	for start := 0; start < len(targets); start += constants.RoleMemberBulkChunkSize {
+		end := start + constants.RoleMemberBulkChunkSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+
+		if request.Action == constants.RoleMemberBulkActionAssign {
+			err = s.memberService.AddRoleToMembers(ctx, roleEntity, targets[start:end])
+		} else {
+			err = s.memberService.RemoveRoleFromMembers(ctx, roleEntity, targets[start:end])
+		}
+		if err != nil {
+			return dtos.RoleMemberBulkResult{}, err
+		}
+	}
+	result.Succeeded = len(targets)
+
+	detail, err := json.Marshal(result)
+	if err != nil {
+		return dtos.RoleMemberBulkResult{}, err
+	}
+
+	action := constants.AuditActionRoleMembersAssigned
+	if request.Action == constants.RoleMemberBulkActionRemove {
+		action = constants.AuditActionRoleMembersRemoved
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, action, constants.AuditTargetTypeRole, roleEntity.ID, string(detail)); err != nil {
+		return dtos.RoleMemberBulkResult{}, err
+	}
+
+	return result, nil
+}
+
+// findMembers 는 요청 대상 멤버를 찾는다. 멤버 ID 로 요청한 경우 없는 멤버는 실패(not-found)로 기록한다.
+func (s RoleMemberBulkService) findMembers(ctx context.Context, request dtos.RoleMemberBulkRequest, result *dtos.RoleMemberBulkResult) ([]memberDomain.MemberEntity, error) {
+	if request.SegmentId > 0 {
+		memberEntities, _, err := s.segmentService.GetSegmentMembers(ctx, request.SegmentId, dtos.Pageable{Page: 0})
+		if err != nil {
+			return nil, err
+		}
+		result.Requested = len(memberEntities)
+
+		return memberEntities, nil
+	}
+
+	memberIds := make([]uint, 0, len(request.MemberIds))
+	requested := map[uint]bool{}
+	for _, memberId := range request.MemberIds {
+		if !requested[memberId] {
+			requested[memberId] = true
+			memberIds = append(memberIds, memberId)
+		}
+	}
+	result.Requested = len(memberIds)
+
+	found := map[uint]memberDomain.MemberEntity{}
+	for start := 0; start < len(memberIds); start += constants.RoleMemberBulkChunkSize {
+		end := start + constants.RoleMemberBulkChunkSize
+		if end > len(memberIds) {
+			end = len(memberIds)
+		}
+
+		filters := map[string]interface{}{}
+		filters["memberIds"] = memberIds[start:end]
+		memberEntities, _, err := s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, memberEntity := range memberEntities {
+			found[memberEntity.ID] = memberEntity
+		}
+	}
+
+	memberEntities := make([]memberDomain.MemberEntity, 0, len(found))
+	for _, memberId := range memberIds {
+		memberEntity, ok := found[memberId]
+		if !ok {
+			result.Failed = append(result.Failed, dtos.RoleMemberBulkFailure{MemberId: memberId, Reason: constants.RoleMemberBulkFailureNotFound})
+			continue
+		}
+		memberEntities = append(memberEntities, memberEntity)
+	}
+
+	return memberEntities, nil
+}
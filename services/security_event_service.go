@@ -0,0 +1,267 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/securityevent/domain"
+	"better-admin-backend-service/securityevent/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type SecurityEventService struct {
+	securityEventRepository *repository.SecurityEventRepository
+	siteService             *SiteService
+	memberService           *MemberService
+	auditService            *AuditService
+	messageTemplateService  *MessageTemplateService
+}
+
+func NewSecurityEventService(
+	securityEventRepository *repository.SecurityEventRepository,
+	siteService *SiteService,
+	memberService *MemberService,
+	auditService *AuditService,
+	messageTemplateService *MessageTemplateService) *SecurityEventService {
+
+	return &SecurityEventService{
+		securityEventRepository: securityEventRepository,
+		siteService:             siteService,
+		memberService:           memberService,
+		auditService:            auditService,
+		messageTemplateService:  messageTemplateService,
+	}
+}
+
+// RecordSecurityEvent 는 보안 이벤트를 기록하고, 트랜잭션이 커밋되면 알림 규칙에 맞는 대상에게 보낸다.
+func (s SecurityEventService) RecordSecurityEvent(ctx context.Context, eventType string, memberId uint, detail string) error {
+	entity := domain.NewSecurityEventEntity(ctx, eventType, memberId, detail)
+	if err := s.securityEventRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+
+	webHookUrls, mailTo, err := s.getRecipients(ctx, entity)
+	if err != nil {
+		return err
+	}
+
+	if len(webHookUrls) > 0 || len(mailTo) > 0 {
+		var message dtos.MailMessage
+		if len(mailTo) > 0 {
+			message = s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateSecurityEvent, mailTo, map[string]string{
+				"type":      entity.Type,
+				"severity":  entity.Severity,
+				"memberId":  fmt.Sprint(entity.MemberId),
+				"ipAddress": entity.IpAddress,
+				"detail":    entity.Detail,
+				"createdAt": entity.CreatedAt.Format(time.RFC3339),
+			})
+		}
+
+		helpers.ContextHelper().AfterCommit(ctx, func() {
+			s.forward(entity, webHookUrls, message)
+		})
+	}
+
+	return nil
+}
+
+// RecordPermissionDenied 는 권한이 없어 거부한 요청을 기록한다. 기록에 실패해도 응답은 바꾸지 않는다.
+func (s SecurityEventService) RecordPermissionDenied(ctx context.Context, method, path string) {
+	s.recordRequest(ctx, constants.SecurityEventTypePermissionDenied, method, path)
+}
+
+// RecordReadOnlyWrite 는 읽기 전용 모드에서 거부한 변경 요청을 기록한다.
+func (s SecurityEventService) RecordReadOnlyWrite(ctx context.Context, method, path string) {
+	s.recordRequest(ctx, constants.SecurityEventTypeReadOnlyWrite, method, path)
+}
+
+func (s SecurityEventService) recordRequest(ctx context.Context, eventType, method, path string) {
+	var memberId uint
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(ctx); err == nil {
+		memberId = userClaim.Id
+	}
+
+	if err := s.RecordSecurityEvent(ctx, eventType, memberId, fmt.Sprintf("method=%s, path=%s", method, path)); err != nil {
+		log.Errorf("record %s security event error. %v", eventType, err)
+	}
+}
+
+// GetSecurityEvents 는 보안 이벤트를 최근 순으로 조회한다. minSeverity 조건은 그 이상인 심각도 조건으로 바꾼다.
+func (s SecurityEventService) GetSecurityEvents(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.SecurityEventEntity, int64, error) {
+	if minSeverity, ok := filters["minSeverity"].(string); ok {
+		delete(filters, "minSeverity")
+		filters["severities"] = domain.SeveritiesAtLeast(minSeverity)
+	}
+
+	return s.securityEventRepository.FindAll(ctx, filters, pageable)
+}
+
+func (s SecurityEventService) GetSecurityEvent(ctx context.Context, id uint) (domain.SecurityEventEntity, error) {
+	return s.securityEventRepository.FindById(ctx, id)
+}
+
+func (s SecurityEventService) GetSecurityEventSummary(ctx context.Context) (dtos.SecurityEventSummary, error) {
+	severities, err := s.securityEventRepository.CountOpenBy(ctx, "severity")
+	if err != nil {
+		return dtos.SecurityEventSummary{}, err
+	}
+
+	types, err := s.securityEventRepository.CountOpenBy(ctx, "type")
+	if err != nil {
+		return dtos.SecurityEventSummary{}, err
+	}
+
+	summary := dtos.SecurityEventSummary{Severities: severities, Types: types}
+	for _, severity := range severities {
+		summary.OpenCount += severity.Count
+	}
+
+	return summary, nil
+}
+
+// AcknowledgeSecurityEvent 는 보안 담당자가 이벤트를 확인했음을 기록한다. 이미 확인한 이벤트이면 ErrAlreadyAcknowledged 를 반환한다.
+func (s SecurityEventService) AcknowledgeSecurityEvent(ctx context.Context, id uint, acknowledgement dtos.SecurityEventAcknowledgement) (domain.SecurityEventEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return domain.SecurityEventEntity{}, err
+	}
+
+	entity, err := s.securityEventRepository.FindById(ctx, id)
+	if err != nil {
+		return domain.SecurityEventEntity{}, err
+	}
+
+	if err := entity.Acknowledge(userClaim.Id, acknowledgement.Note); err != nil {
+		return domain.SecurityEventEntity{}, err
+	}
+
+	if err := s.securityEventRepository.Save(ctx, &entity); err != nil {
+		return domain.SecurityEventEntity{}, err
+	}
+
+	return entity, s.auditService.RecordAuditLog(ctx, constants.AuditActionSecurityEventAcknowledged, constants.AuditTargetTypeSecurityEvent, entity.ID,
+		fmt.Sprintf("type=%v, severity=%v, note=%v", entity.Type, entity.Severity, acknowledgement.Note))
+}
+
+// GetSecurityEventRuleSetting 은 보안 이벤트 알림 규칙을 반환한다. 설정하지 않았으면 알리지 않는다.
+func (s SecurityEventService) GetSecurityEventRuleSetting(ctx context.Context) (dtos.SecurityEventRuleSetting, error) {
+	securityEventRuleSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeySecurityEventRule)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.SecurityEventRuleSetting{Rules: make([]dtos.SecurityEventRule, 0)}, nil
+		}
+		return dtos.SecurityEventRuleSetting{}, err
+	}
+
+	var setting dtos.SecurityEventRuleSetting
+	if err = mapstructure.Decode(securityEventRuleSetting, &setting); err != nil {
+		return dtos.SecurityEventRuleSetting{}, err
+	}
+
+	if setting.Rules == nil {
+		setting.Rules = make([]dtos.SecurityEventRule, 0)
+	}
+
+	return setting, nil
+}
+
+func (s SecurityEventService) SetSecurityEventRuleSetting(ctx context.Context, setting dtos.SecurityEventRuleSetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeySecurityEventRule, setting)
+}
+
+// getRecipients 는 이벤트가 맞는 규칙의 웹훅 URL 과 알림 역할 멤버의 메일 주소를 중복 없이 모은다.
+func (s SecurityEventService) getRecipients(ctx context.Context, entity domain.SecurityEventEntity) ([]string, []string, error) {
+	setting, err := s.GetSecurityEventRuleSetting(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webHookUrls, mailTo := make([]string, 0), make([]string, 0)
+	added := map[string]bool{}
+	for _, rule := range setting.Rules {
+		if !s.matches(entity, rule) {
+			continue
+		}
+
+		for _, url := range rule.WebHookUrls {
+			if !added[url] {
+				added[url] = true
+				webHookUrls = append(webHookUrls, url)
+			}
+		}
+
+		if len(rule.NotifyRoleName) == 0 {
+			continue
+		}
+
+		members, err := s.memberService.GetMembersByRoleName(ctx, rule.NotifyRoleName)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, member := range members {
+			if email := member.GetEmail(); len(email) > 0 && !added[email] {
+				added[email] = true
+				mailTo = append(mailTo, email)
+			}
+		}
+	}
+
+	return webHookUrls, mailTo, nil
+}
+
+func (SecurityEventService) matches(entity domain.SecurityEventEntity, rule dtos.SecurityEventRule) bool {
+	if !entity.IsAtLeast(rule.MinSeverity) {
+		return false
+	}
+
+	if len(rule.EventTypes) == 0 {
+		return true
+	}
+
+	for _, eventType := range rule.EventTypes {
+		if eventType == entity.Type {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forward 는 이벤트를 웹훅과 메일로 보낸다. 보내지 못해도 이벤트 기록은 유지하고 로그만 남긴다.
+// forward 는 보안 이벤트를 웹훅으로 보내고, 수신자가 있으면 message 를 메일로 보낸다.
+func (SecurityEventService) forward(entity domain.SecurityEventEntity, webHookUrls []string, message dtos.MailMessage) {
+	if len(webHookUrls) > 0 {
+		body, err := json.Marshal(entity.ToInformation())
+		if err != nil {
+			log.Errorf("security event forward error. %v", err)
+			return
+		}
+
+		for _, url := range webHookUrls {
+			if err := adapters.OutgoingWebHookAdapter().Send(dtos.OutgoingWebHookRequest{
+				Url:         url,
+				ContentType: "application/json",
+				Body:        body,
+			}); err != nil {
+				log.Errorf("security event forward error. url=%s, %v", url, err)
+			}
+		}
+	}
+
+	if len(message.To) == 0 {
+		return
+	}
+
+	if err := adapters.MailAdapter().Send(message); err != nil {
+		log.Errorf("security event notification error. %v", err)
+	}
+}
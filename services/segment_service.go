@@ -0,0 +1,168 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/segment/domain"
+	"better-admin-backend-service/segment/repository"
+	"context"
+)
+
+type SegmentService struct {
+	memberService     *MemberService
+	segmentRepository *repository.SegmentRepository
+}
+
+func NewSegmentService(memberService *MemberService, segmentRepository *repository.SegmentRepository) *SegmentService {
+	return &SegmentService{
+		memberService:     memberService,
+		segmentRepository: segmentRepository,
+	}
+}
+
+func (s SegmentService) CreateSegment(ctx context.Context, information dtos.SegmentInformation) (domain.SegmentEntity, error) {
+	entity, err := domain.NewSegmentEntity(ctx, information)
+	if err != nil {
+		return domain.SegmentEntity{}, err
+	}
+
+	if err := s.segmentRepository.Create(ctx, &entity); err != nil {
+		return domain.SegmentEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s SegmentService) GetSegments(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.SegmentEntity, int64, error) {
+	if err := applyOwnerFilter(ctx, filters, constants.PermissionManageMembers); err != nil {
+		return nil, 0, err
+	}
+
+	return s.segmentRepository.FindAll(ctx, filters, pageable)
+}
+
+// AuthorizeOwner 는 세그먼트의 소유자이거나 멤버 관리 권한이 있는지 확인한다. 권한이 있으면 리소스를 조회하지 않는다.
+func (s SegmentService) AuthorizeOwner(ctx context.Context, segmentId uint) error {
+	if hasAnyClaimPermission(ctx, []string{constants.PermissionManageMembers}) {
+		return nil
+	}
+
+	entity, err := s.segmentRepository.FindById(ctx, segmentId)
+	if err != nil {
+		return err
+	}
+
+	return authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageMembers)
+}
+
+func (s SegmentService) GetOwnedResources(ctx context.Context, ownerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.segmentRepository.FindByOwnerId(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for _, entity := range entities {
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeSegment, Id: entity.ID, Name: entity.Name})
+	}
+
+	return resources, nil
+}
+
+func (s SegmentService) TransferResourceOwnership(ctx context.Context, segmentId, toOwnerId uint) (dtos.OwnedResource, error) {
+	entity, err := s.segmentRepository.FindById(ctx, segmentId)
+	if err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageMembers); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := entity.TransferOwnership(ctx, toOwnerId); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := s.segmentRepository.Save(ctx, &entity); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	return dtos.OwnedResource{Type: constants.OwnedResourceTypeSegment, Id: entity.ID, Name: entity.Name}, nil
+}
+
+func (s SegmentService) GetSegment(ctx context.Context, segmentId uint) (domain.SegmentEntity, error) {
+	return s.segmentRepository.FindById(ctx, segmentId)
+}
+
+func (s SegmentService) UpdateSegment(ctx context.Context, segmentId uint, information dtos.SegmentInformation) error {
+	entity, err := s.segmentRepository.FindById(ctx, segmentId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, information); err != nil {
+		return err
+	}
+
+	return s.segmentRepository.Save(ctx, &entity)
+}
+
+func (s SegmentService) DeleteSegment(ctx context.Context, segmentId uint) error {
+	entity, err := s.segmentRepository.FindById(ctx, segmentId)
+	if err != nil {
+		return err
+	}
+
+	return s.segmentRepository.Delete(ctx, entity)
+}
+
+// GetSegmentMembers 는 세그먼트 조건에 맞는 현재 멤버를 조회한다.
+func (s SegmentService) GetSegmentMembers(ctx context.Context, segmentId uint, pageable dtos.Pageable) ([]memberDomain.MemberEntity, int64, error) {
+	entity, err := s.segmentRepository.FindById(ctx, segmentId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.memberService.GetMembers(ctx, entity.GetFilter().ToMemberFilters(), pageable)
+}
+
+// ContainsMember 는 멤버가 세그먼트에 속하는지 확인한다. 세그먼트를 대상으로 하는 기능(알림, 기능 플래그 등)에서 사용한다.
+func (s SegmentService) ContainsMember(ctx context.Context, segmentId uint, memberId uint) (bool, error) {
+	entity, err := s.segmentRepository.FindById(ctx, segmentId)
+	if err != nil {
+		return false, err
+	}
+
+	filters := entity.GetFilter().ToMemberFilters()
+	filters["memberIds"] = []uint{memberId}
+
+	_, totalCount, err := s.memberService.GetMembers(ctx, filters, dtos.Pageable{Page: 0})
+	if err != nil {
+		return false, err
+	}
+
+	return totalCount > 0, nil
+}
+
+// TransferOwnership 은 멤버가 소유한 세그먼트를 다른 멤버에게 넘기고 넘긴 세그먼트를 반환한다.
+func (s SegmentService) TransferOwnership(ctx context.Context, fromOwnerId, toOwnerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.segmentRepository.FindByOwnerId(ctx, fromOwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for i := range entities {
+		if err := entities[i].TransferOwnership(ctx, toOwnerId); err != nil {
+			return nil, err
+		}
+
+		if err := s.segmentRepository.Save(ctx, &entities[i]); err != nil {
+			return nil, err
+		}
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeSegment, Id: entities[i].ID, Name: entities[i].Name})
+	}
+
+	return resources, nil
+}
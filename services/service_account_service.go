@@ -0,0 +1,387 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/serviceaccount/domain"
+	"better-admin-backend-service/serviceaccount/repository"
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ServiceAccountService struct {
+	rbacService                   *RoleBasedAccessControlService
+	serviceAccountRepository      *repository.ServiceAccountRepository
+	tokenExchangePolicyRepository *repository.TokenExchangePolicyRepository
+	auditService                  *AuditService
+	quotaGuard                    QuotaGuard
+}
+
+func NewServiceAccountService(rbacService *RoleBasedAccessControlService,
+	serviceAccountRepository *repository.ServiceAccountRepository,
+	tokenExchangePolicyRepository *repository.TokenExchangePolicyRepository,
+	auditService *AuditService) *ServiceAccountService {
+	return &ServiceAccountService{
+		rbacService:                   rbacService,
+		serviceAccountRepository:      serviceAccountRepository,
+		tokenExchangePolicyRepository: tokenExchangePolicyRepository,
+		auditService:                  auditService,
+	}
+}
+
+// RegisterQuotaGuard 는 API Key 수를 제한할 QuotaGuard 를 등록한다. 등록하지 않으면 확인하지 않는다.
+func (s *ServiceAccountService) RegisterQuotaGuard(guard QuotaGuard) {
+	s.quotaGuard = guard
+}
+
+func (s ServiceAccountService) CreateServiceAccount(ctx context.Context, information dtos.ServiceAccountInformation) error {
+	entity, err := domain.NewServiceAccountEntity(ctx, information)
+	if err != nil {
+		return err
+	}
+
+	if err := s.serviceAccountRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionServiceAccountCreated,
+		constants.AuditTargetTypeServiceAccount, entity.ID, entity.Name)
+}
+
+func (s ServiceAccountService) GetServiceAccounts(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.ServiceAccountEntity, int64, error) {
+	if err := applyOwnerFilter(ctx, filters, constants.PermissionManageSystemSettings); err != nil {
+		return nil, 0, err
+	}
+
+	return s.serviceAccountRepository.FindAll(ctx, filters, pageable)
+}
+
+// AuthorizeOwner 는 서비스 계정의 소유자이거나 시스템 설정 관리 권한이 있는지 확인한다. 권한이 있으면 리소스를 조회하지 않는다.
+func (s ServiceAccountService) AuthorizeOwner(ctx context.Context, serviceAccountId uint) error {
+	if hasAnyClaimPermission(ctx, []string{constants.PermissionManageSystemSettings}) {
+		return nil
+	}
+
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return err
+	}
+
+	return authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageSystemSettings)
+}
+
+func (s ServiceAccountService) GetOwnedResources(ctx context.Context, ownerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.serviceAccountRepository.FindByOwnerId(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for _, entity := range entities {
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeServiceAccount, Id: entity.ID, Name: entity.Name})
+	}
+
+	return resources, nil
+}
+
+func (s ServiceAccountService) TransferResourceOwnership(ctx context.Context, serviceAccountId, toOwnerId uint) (dtos.OwnedResource, error) {
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageSystemSettings); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := entity.TransferOwnership(ctx, toOwnerId); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := s.serviceAccountRepository.Save(ctx, &entity); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	return dtos.OwnedResource{Type: constants.OwnedResourceTypeServiceAccount, Id: entity.ID, Name: entity.Name}, nil
+}
+
+func (s ServiceAccountService) GetServiceAccount(ctx context.Context, serviceAccountId uint) (domain.ServiceAccountEntity, error) {
+	return s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+}
+
+func (s ServiceAccountService) UpdateServiceAccount(ctx context.Context, serviceAccountId uint, information dtos.ServiceAccountInformation) error {
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, information); err != nil {
+		return err
+	}
+
+	return s.serviceAccountRepository.Save(ctx, &entity)
+}
+
+func (s ServiceAccountService) DeleteServiceAccount(ctx context.Context, serviceAccountId uint) error {
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, dtos.ServiceAccountInformation{Name: entity.Name, Description: entity.Description}); err != nil {
+		return err
+	}
+
+	if err := s.serviceAccountRepository.Delete(ctx, entity); err != nil {
+		return err
+	}
+
+	if err := s.tokenExchangePolicyRepository.ReplaceAll(ctx, entity.ID, nil); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionServiceAccountDeleted,
+		constants.AuditTargetTypeServiceAccount, entity.ID, entity.Name)
+}
+
+func (s ServiceAccountService) AssignRole(ctx context.Context, serviceAccountId uint, assignRole dtos.ServiceAccountAssignRole) error {
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return err
+	}
+
+	filters := map[string]interface{}{}
+	filters["roleIds"] = assignRole.RoleIds
+
+	findRoleEntities, _, err := s.rbacService.GetRoles(ctx, filters, dtos.Pageable{Page: 0})
+	if err != nil {
+		return err
+	}
+
+	if err := entity.AssignRole(ctx, findRoleEntities); err != nil {
+		return err
+	}
+
+	if err := s.serviceAccountRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionServiceAccountRoleAssigned,
+		constants.AuditTargetTypeServiceAccount, entity.ID, strings.Join(entity.GetRoleNames(), ","))
+}
+
+func (s ServiceAccountService) GetTokenExchangePolicies(ctx context.Context, serviceAccountId uint) ([]domain.TokenExchangePolicyEntity, error) {
+	if _, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId); err != nil {
+		return nil, err
+	}
+
+	return s.tokenExchangePolicyRepository.FindByServiceAccountId(ctx, serviceAccountId)
+}
+
+// SetTokenExchangePolicies 는 서비스 계정의 토큰 교환 정책을 policies 로 바꾼다.
+func (s ServiceAccountService) SetTokenExchangePolicies(ctx context.Context, serviceAccountId uint, policies []dtos.TokenExchangePolicy) error {
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return err
+	}
+
+	policyEntities, err := domain.NewTokenExchangePolicyEntities(ctx, entity.ID, policies)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenExchangePolicyRepository.ReplaceAll(ctx, entity.ID, policyEntities); err != nil {
+		return err
+	}
+
+	audiences := make([]string, 0)
+	for _, policy := range policyEntities {
+		audiences = append(audiences, policy.Audience)
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionTokenExchangePolicyChanged,
+		constants.AuditTargetTypeServiceAccount, entity.ID, strings.Join(audiences, ","))
+}
+
+// GetTokenExchangePolicy 는 서비스 계정이 audience 로 토큰을 교환할 수 있는 정책을 찾는다. 없으면 ErrInvalidTarget 이다.
+func (s ServiceAccountService) GetTokenExchangePolicy(ctx context.Context, serviceAccountId uint, audience string) (domain.TokenExchangePolicyEntity, error) {
+	policy, err := s.tokenExchangePolicyRepository.FindByServiceAccountIdAndAudience(ctx, serviceAccountId, audience)
+	if errors.Is(err, errors.ErrNotFound) {
+		return policy, errors.ErrInvalidTarget
+	}
+
+	return policy, err
+}
+
+func (s ServiceAccountService) IssueApiKey(ctx context.Context, serviceAccountId uint) (string, error) {
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return "", err
+	}
+
+	// 이미 발급한 API Key 를 다시 발급하면 API Key 수가 늘지 않는다.
+	newApiKey := len(entity.ApiKeyHash) == 0
+	if newApiKey {
+		if err := checkQuota(ctx, s.quotaGuard, constants.LicenseResourceApiKeys); err != nil {
+			return "", err
+		}
+	}
+
+	apiKey, err := entity.IssueApiKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.serviceAccountRepository.Save(ctx, &entity); err != nil {
+		return "", err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionApiKeyIssued,
+		constants.AuditTargetTypeServiceAccount, entity.ID, fmt.Sprintf("prefix=%s", entity.ApiKeyPrefix)); err != nil {
+		return "", err
+	}
+
+	if newApiKey {
+		recordQuotaUsage(ctx, s.quotaGuard, constants.LicenseResourceApiKeys)
+	}
+	return apiKey, nil
+}
+
+// CountApiKeys 는 API Key 를 발급한 서비스 계정 수이다.
+func (s ServiceAccountService) CountApiKeys(ctx context.Context) (int64, error) {
+	return s.serviceAccountRepository.CountWithApiKey(ctx)
+}
+
+// RevokeOwnedCredentials 는 멤버가 소유한 서비스 계정의 API Key 와 Client Secret 을 폐기하고 폐기한 서비스 계정을 반환한다.
+// 멤버가 키를 알고 있으므로 멤버를 해지할 때 호출한다. 새 소유자가 다시 발급해야 한다.
+func (s ServiceAccountService) RevokeOwnedCredentials(ctx context.Context, ownerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.serviceAccountRepository.FindByOwnerId(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for i := range entities {
+		if !entities[i].HasCredentials() {
+			continue
+		}
+
+		if err := entities[i].RevokeCredentials(ctx); err != nil {
+			return nil, err
+		}
+
+		if err := s.serviceAccountRepository.Save(ctx, &entities[i]); err != nil {
+			return nil, err
+		}
+
+		if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionCredentialsRevoked,
+			constants.AuditTargetTypeServiceAccount, entities[i].ID, entities[i].Name); err != nil {
+			return nil, err
+		}
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeServiceAccount, Id: entities[i].ID, Name: entities[i].Name})
+	}
+
+	return resources, nil
+}
+
+// TransferOwnership 은 멤버가 소유한 서비스 계정을 다른 멤버에게 넘기고 넘긴 서비스 계정을 반환한다.
+func (s ServiceAccountService) TransferOwnership(ctx context.Context, fromOwnerId, toOwnerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.serviceAccountRepository.FindByOwnerId(ctx, fromOwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for i := range entities {
+		if err := entities[i].TransferOwnership(ctx, toOwnerId); err != nil {
+			return nil, err
+		}
+
+		if err := s.serviceAccountRepository.Save(ctx, &entities[i]); err != nil {
+			return nil, err
+		}
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeServiceAccount, Id: entities[i].ID, Name: entities[i].Name})
+	}
+
+	return resources, nil
+}
+
+func (s ServiceAccountService) IssueClientSecret(ctx context.Context, serviceAccountId uint) (dtos.ServiceAccountClientSecret, error) {
+	entity, err := s.serviceAccountRepository.FindById(ctx, serviceAccountId)
+	if err != nil {
+		return dtos.ServiceAccountClientSecret{}, err
+	}
+
+	clientSecret, err := entity.IssueClientSecret(ctx)
+	if err != nil {
+		return dtos.ServiceAccountClientSecret{}, err
+	}
+
+	if err := s.serviceAccountRepository.Save(ctx, &entity); err != nil {
+		return dtos.ServiceAccountClientSecret{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionClientSecretIssued,
+		constants.AuditTargetTypeServiceAccount, entity.ID, fmt.Sprintf("clientId=%s", entity.ClientId)); err != nil {
+		return dtos.ServiceAccountClientSecret{}, err
+	}
+
+	return dtos.ServiceAccountClientSecret{
+		ClientId:     entity.ClientId,
+		ClientSecret: clientSecret,
+	}, nil
+}
+
+// AuthenticateClient 는 Client Id/Secret 으로 서비스 계정을 인증한다.
+func (s ServiceAccountService) AuthenticateClient(ctx context.Context, clientId, clientSecret string) (domain.ServiceAccountEntity, error) {
+	entity, err := s.serviceAccountRepository.FindByClientId(ctx, clientId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return entity, errors.ErrAuthentication
+		}
+
+		return entity, err
+	}
+
+	if err := entity.ValidateClientSecret(clientSecret); err != nil {
+		return entity, err
+	}
+
+	return entity, nil
+}
+
+// AuthenticateApiKey 는 API Key 로 서비스 계정을 찾아 권한 검사에 사용할 UserClaim 을 반환한다.
+func (s ServiceAccountService) AuthenticateApiKey(ctx context.Context, apiKey string) (*security.UserClaim, error) {
+	entity, err := s.serviceAccountRepository.FindByApiKeyHash(ctx, domain.HashApiKey(apiKey))
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return nil, errors.ErrAuthentication
+		}
+
+		return nil, err
+	}
+
+	return newServiceAccountUserClaim(entity), nil
+}
+
+// GetServiceAccountUserClaim 은 Client Id 로 서비스 계정을 찾아 권한 검사에 사용할 UserClaim 을 반환한다.
+func (s ServiceAccountService) GetServiceAccountUserClaim(ctx context.Context, clientId string) (*security.UserClaim, error) {
+	entity, err := s.serviceAccountRepository.FindByClientId(ctx, clientId)
+	if err != nil {
+		return nil, err
+	}
+
+	return newServiceAccountUserClaim(entity), nil
+}
+
+func newServiceAccountUserClaim(entity domain.ServiceAccountEntity) *security.UserClaim {
+	return &security.UserClaim{
+		Id:            entity.ID,
+		Roles:         entity.GetRoleNames(),
+		Permissions:   entity.GetPermissionNames(),
+		PrincipalType: security.PrincipalTypeServiceAccount,
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/selfcheck"
+	"context"
+	"github.com/mitchellh/mapstructure"
+	"time"
+)
+
+type ServiceStatusService struct {
+	siteService        *SiteService
+	maintenanceService *MaintenanceService
+}
+
+func NewServiceStatusService(siteService *SiteService, maintenanceService *MaintenanceService) *ServiceStatusService {
+	return &ServiceStatusService{
+		siteService:        siteService,
+		maintenanceService: maintenanceService,
+	}
+}
+
+func (s ServiceStatusService) GetAnnouncementBannerSetting(ctx context.Context) (dtos.AnnouncementBannerSetting, error) {
+	bannerSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyAnnouncementBanners)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.AnnouncementBannerSetting{Banners: make([]dtos.AnnouncementBanner, 0)}, nil
+		}
+		return dtos.AnnouncementBannerSetting{}, err
+	}
+
+	var setting dtos.AnnouncementBannerSetting
+	if err = mapstructure.Decode(bannerSetting, &setting); err != nil {
+		return dtos.AnnouncementBannerSetting{}, err
+	}
+
+	if setting.Banners == nil {
+		setting.Banners = make([]dtos.AnnouncementBanner, 0)
+	}
+
+	return setting, nil
+}
+
+func (s ServiceStatusService) SetAnnouncementBannerSetting(ctx context.Context, setting dtos.AnnouncementBannerSetting) error {
+	for _, banner := range setting.Banners {
+		if len(banner.StartAt) == 0 || len(banner.EndAt) == 0 {
+			continue
+		}
+
+		startAt, endAt := parseAnnouncementBannerPeriod(banner)
+		if !endAt.After(startAt) {
+			return errors.ErrInvalidPeriod
+		}
+	}
+
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeyAnnouncementBanners, setting)
+}
+
+// GetServiceStatus 는 전체 상태, 빌드 정보, 지금 보여줄 공지와 점검 일정을 반환한다.
+// 점검 중이면 maintenance, 심각도가 error 인 점검 항목이 실패하면 degraded 이다.
+func (s ServiceStatusService) GetServiceStatus(ctx context.Context) (dtos.ServiceStatus, error) {
+	maintenanceStatus, err := s.maintenanceService.GetMaintenanceStatus(ctx)
+	if err != nil {
+		return dtos.ServiceStatus{}, err
+	}
+
+	banners, err := s.getActiveBanners(ctx)
+	if err != nil {
+		return dtos.ServiceStatus{}, err
+	}
+
+	status := dtos.ServiceStatus{
+		Status:      constants.ServiceStatusOperational,
+		Build:       buildInfo(),
+		Banners:     banners,
+		Maintenance: maintenanceStatus,
+		CheckedAt:   time.Now(),
+	}
+
+	if maintenanceStatus.Active {
+		status.Status = constants.ServiceStatusMaintenance
+	} else if !selfcheck.Run(ctx).Healthy {
+		status.Status = constants.ServiceStatusDegraded
+	}
+
+	return status, nil
+}
+
+func (s ServiceStatusService) getActiveBanners(ctx context.Context) ([]dtos.AnnouncementBanner, error) {
+	setting, err := s.GetAnnouncementBannerSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	banners := make([]dtos.AnnouncementBanner, 0)
+	for _, banner := range setting.Banners {
+		startAt, endAt := parseAnnouncementBannerPeriod(banner)
+		if len(banner.StartAt) > 0 && now.Before(startAt) {
+			continue
+		}
+		if len(banner.EndAt) > 0 && !now.Before(endAt) {
+			continue
+		}
+		banners = append(banners, banner)
+	}
+
+	return banners, nil
+}
+
+// parseAnnouncementBannerPeriod 는 입력 시 형식을 검증하므로 파싱 오류는 무시한다.
+func parseAnnouncementBannerPeriod(banner dtos.AnnouncementBanner) (time.Time, time.Time) {
+	startAt, _ := time.Parse(time.RFC3339, banner.StartAt)
+	endAt, _ := time.Parse(time.RFC3339, banner.EndAt)
+	return startAt, endAt
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/session/domain"
+	"better-admin-backend-service/session/repository"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	"time"
+)
+
+type SessionService struct {
+	memberSessionRepository *repository.MemberSessionRepository
+	siteService             *SiteService
+	auditService            *AuditService
+}
+
+func NewSessionService(
+	memberSessionRepository *repository.MemberSessionRepository,
+	siteService *SiteService,
+	auditService *AuditService) *SessionService {
+
+	return &SessionService{
+		memberSessionRepository: memberSessionRepository,
+		siteService:             siteService,
+		auditService:            auditService,
+	}
+}
+
+// StartSession 은 세션 수 제한 설정을 적용하여 lifetime 이 지나면 만료되는 세션을 시작한다.
+func (s SessionService) StartSession(ctx context.Context, memberId uint, roleNames []string, lifetime time.Duration) (domain.MemberSessionEntity, error) {
+	setting, err := s.GetSessionLimitSetting(ctx)
+	if err != nil {
+		return domain.MemberSessionEntity{}, err
+	}
+
+	maxSessions := setting.GetMaxSessions(roleNames)
+	if maxSessions > 0 {
+		activeSessions, err := s.memberSessionRepository.FindActiveByMemberId(ctx, memberId)
+		if err != nil {
+			return domain.MemberSessionEntity{}, err
+		}
+
+		if len(activeSessions) >= maxSessions {
+			if setting.ExceedAction != constants.SessionLimitExceedActionRevokeOldest {
+				return domain.MemberSessionEntity{}, errors.ErrSessionLimitExceeded
+			}
+
+			// 새로운 세션이 들어갈 자리를 만들기 위해 가장 오래된 세션부터 강제로 만료 시킨다.
+			for i := 0; i <= len(activeSessions)-maxSessions; i++ {
+				if err := s.forceRevokeSession(ctx, &activeSessions[i]); err != nil {
+					return domain.MemberSessionEntity{}, err
+				}
+			}
+		}
+	}
+
+	return s.createSession(ctx, memberId, time.Now().Add(lifetime))
+}
+
+// StartLimitedSession 은 세션 수를 제한하지 않고 lifetime 이 지나면 만료되는 세션을 시작한다.(예. 비상 접근 계정)
+func (s SessionService) StartLimitedSession(ctx context.Context, memberId uint, lifetime time.Duration) (domain.MemberSessionEntity, error) {
+	return s.createSession(ctx, memberId, time.Now().Add(lifetime))
+}
+
+func (s SessionService) createSession(ctx context.Context, memberId uint, expiresAt time.Time) (domain.MemberSessionEntity, error) {
+	entity, err := domain.NewMemberSessionEntity(memberId, expiresAt)
+	if err != nil {
+		return domain.MemberSessionEntity{}, err
+	}
+	entity.ClientFingerprint = helpers.ContextHelper().GetClientFingerprint(ctx)
+
+	if err := s.memberSessionRepository.Create(ctx, &entity); err != nil {
+		return domain.MemberSessionEntity{}, err
+	}
+
+	if err := s.auditService.RecordLogin(ctx, memberId, entity.ID); err != nil {
+		return domain.MemberSessionEntity{}, err
+	}
+
+	return entity, nil
+}
+
+// forceRevokeSession 은 세션 수 제한으로 세션을 종료한다. 종료한 세션에서 발급한 Access 토큰은 IsSessionRevoked 로 거부한다.
+func (s SessionService) forceRevokeSession(ctx context.Context, entity *domain.MemberSessionEntity) error {
+	entity.Revoke(constants.SessionRevokedReasonLimitExceeded)
+	if err := s.memberSessionRepository.Save(ctx, entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionSessionRevoked, constants.AuditTargetTypeSession, entity.ID,
+		fmt.Sprintf("memberId=%v, reason=%v", entity.MemberId, constants.SessionRevokedReasonLimitExceeded))
+}
+
+func (s SessionService) ValidateSession(ctx context.Context, sessionKey string) error {
+	_, err := s.GetActiveSession(ctx, sessionKey)
+	return err
+}
+
+// GetActiveSession 은 활성 세션을 찾는다. 없거나 종료된 세션이면 ErrSessionRevoked 를 반환한다.
+func (s SessionService) GetActiveSession(ctx context.Context, sessionKey string) (domain.MemberSessionEntity, error) {
+	entity, err := s.memberSessionRepository.FindBySessionKey(ctx, sessionKey)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return domain.MemberSessionEntity{}, errors.ErrSessionRevoked
+		}
+		return domain.MemberSessionEntity{}, err
+	}
+
+	if entity.IsActive() == false {
+		return domain.MemberSessionEntity{}, errors.ErrSessionRevoked
+	}
+
+	return entity, nil
+}
+
+// IsSessionRevoked 는 세션이 없거나 종료되었는지 확인한다. 종료된 세션에서 발급한 Access 토큰은 만료 전이라도 사용할 수 없다.
+func (s SessionService) IsSessionRevoked(ctx context.Context, sessionKey string) (bool, error) {
+	if _, err := s.GetActiveSession(ctx, sessionKey); err != nil {
+		if errors.Is(err, errors.ErrSessionRevoked) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return false, nil
+}
+
+func (s SessionService) RevokeSession(ctx context.Context, sessionKey string, reason string) error {
+	entity, err := s.memberSessionRepository.FindBySessionKey(ctx, sessionKey)
+	if err != nil {
+		return err
+	}
+
+	if entity.IsActive() == false {
+		return nil
+	}
+
+	entity.Revoke(reason)
+	return s.memberSessionRepository.Save(ctx, &entity)
+}
+
+// RevokeMemberSessions 는 멤버의 모든 활성 세션을 종료한다.(예. 아이디 변경)
+func (s SessionService) RevokeMemberSessions(ctx context.Context, memberId uint, reason string) error {
+	entities, err := s.memberSessionRepository.FindActiveByMemberId(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	for i := range entities {
+		entities[i].Revoke(reason)
+		if err := s.memberSessionRepository.Save(ctx, &entities[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s SessionService) GetSessionLimitSetting(ctx context.Context) (dtos.SessionLimitSetting, error) {
+	sessionLimitSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeySessionLimit)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			// 설정이 없으면 세션 수를 제한하지 않는다.
+			return dtos.SessionLimitSetting{ExceedAction: constants.SessionLimitExceedActionBlock}, nil
+		}
+		return dtos.SessionLimitSetting{}, err
+	}
+
+	var setting dtos.SessionLimitSetting
+	if err = mapstructure.Decode(sessionLimitSetting, &setting); err != nil {
+		return dtos.SessionLimitSetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s SessionService) GetRefreshTokenBindingSetting(ctx context.Context) (dtos.RefreshTokenBindingSetting, error) {
+	refreshTokenBindingSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyRefreshTokenBinding)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			// 설정이 없으면 알림만 보낸다.
+			return dtos.RefreshTokenBindingSetting{Action: constants.RefreshTokenBindingActionWarn}, nil
+		}
+		return dtos.RefreshTokenBindingSetting{}, err
+	}
+
+	var setting dtos.RefreshTokenBindingSetting
+	if err = mapstructure.Decode(refreshTokenBindingSetting, &setting); err != nil {
+		return dtos.RefreshTokenBindingSetting{}, err
+	}
+
+	return setting, nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/member/domain"
+	"better-admin-backend-service/member/repository"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+type SignIdChangeService struct {
+	memberService          *MemberService
+	signIdChangeRepository *repository.SignIdChangeRepository
+	sessionService         *SessionService
+	auditService           *AuditService
+	messageTemplateService *MessageTemplateService
+}
+
+func NewSignIdChangeService(memberService *MemberService,
+	signIdChangeRepository *repository.SignIdChangeRepository,
+	sessionService *SessionService,
+	auditService *AuditService,
+	messageTemplateService *MessageTemplateService) *SignIdChangeService {
+	return &SignIdChangeService{
+		memberService:          memberService,
+		signIdChangeRepository: signIdChangeRepository,
+		sessionService:         sessionService,
+		auditService:           auditService,
+		messageTemplateService: messageTemplateService,
+	}
+}
+
+// RequestSignIdChange 는 로그인한 멤버의 아이디 변경을 요청한다.
+// 새 주소로 확인 메일을, 기존 주소(이메일인 경우)로 취소 링크가 포함된 알림 메일을 보낸다.
+func (s SignIdChangeService) RequestSignIdChange(ctx context.Context, request dtos.SignIdChangeRequest) error {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return err
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, userClaim.Id)
+	if err != nil {
+		return err
+	}
+
+	if err := memberEntity.ValidatePassword(request.Password); err != nil {
+		return errors.ErrAuthentication
+	}
+
+	if _, err := s.memberService.GetMemberBySignId(ctx, request.NewSignId); err == nil {
+		return errors.ErrDuplicated
+	} else if !errors.Is(err, errors.ErrNotFound) {
+		return err
+	}
+
+	// 진행 중인 요청은 새 요청으로 대체한다.
+	pendingEntities, err := s.signIdChangeRepository.FindPendingByMemberId(ctx, memberEntity.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range pendingEntities {
+		if err := pendingEntities[i].Cancel(); err != nil {
+			return err
+		}
+
+		if err := s.signIdChangeRepository.Save(ctx, &pendingEntities[i]); err != nil {
+			return err
+		}
+	}
+
+	lifetime := time.Duration(config.Config.SignIdChange.TokenLifetimeMinutes) * time.Minute
+	entity, confirmToken, cancelToken, err := domain.NewSignIdChangeEntity(memberEntity, request.NewSignId, lifetime)
+	if err != nil {
+		return err
+	}
+
+	if err := s.signIdChangeRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionSignIdChangeRequested, constants.AuditTargetTypeMember,
+		memberEntity.ID, fmt.Sprintf("%v -> %v", entity.OldSignId, entity.NewSignId)); err != nil {
+		return err
+	}
+
+	if err := adapters.MailAdapter().Send(s.messageTemplateService.RenderMail(ctx, constants.MessageTemplateSignIdChangeConfirm, []string{entity.NewSignId}, map[string]string{
+		"newSignId":  entity.NewSignId,
+		"confirmUrl": fmt.Sprintf(config.Config.SignIdChange.ConfirmUrl, confirmToken),
+		"expiresAt":  entity.ExpiresAt.Format(time.RFC3339),
+	})); err != nil {
+		return err
+	}
+
+	s.notifyOldSignId(ctx, entity.OldSignId, constants.MessageTemplateSignIdChangeRequested, map[string]string{
+		"newSignId": entity.NewSignId,
+		"cancelUrl": fmt.Sprintf(config.Config.SignIdChange.CancelUrl, cancelToken),
+	})
+
+	return nil
+}
+
+// ConfirmSignIdChange 는 새 주소로 받은 토큰으로 아이디 변경을 완료하고 멤버의 모든 세션을 종료한다.
+func (s SignIdChangeService) ConfirmSignIdChange(ctx context.Context, token string) error {
+	entity, err := s.signIdChangeRepository.FindByConfirmTokenHash(ctx, domain.HashSignIdChangeToken(token))
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Confirm(); err != nil {
+		if errors.Is(err, errors.ErrExpired) {
+			// 만료 상태는 저장하고 요청은 실패 처리한다.
+			if saveErr := s.signIdChangeRepository.Save(ctx, &entity); saveErr != nil {
+				return saveErr
+			}
+		}
+		return err
+	}
+
+	if err := s.memberService.ChangeSignId(ctx, entity.MemberId, entity.NewSignId); err != nil {
+		return err
+	}
+
+	if err := s.signIdChangeRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	if err := s.sessionService.RevokeMemberSessions(ctx, entity.MemberId, constants.SessionRevokedReasonSignIdChanged); err != nil {
+		return err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionSignIdChangeConfirmed, constants.AuditTargetTypeMember,
+		entity.MemberId, fmt.Sprintf("%v -> %v", entity.OldSignId, entity.NewSignId)); err != nil {
+		return err
+	}
+
+	s.notifyOldSignId(ctx, entity.OldSignId, constants.MessageTemplateSignIdChanged, map[string]string{"newSignId": entity.NewSignId})
+
+	return nil
+}
+
+// CancelSignIdChange 는 기존 주소로 받은 토큰으로 진행 중인 아이디 변경을 취소한다.
+func (s SignIdChangeService) CancelSignIdChange(ctx context.Context, token string) error {
+	entity, err := s.signIdChangeRepository.FindByCancelTokenHash(ctx, domain.HashSignIdChangeToken(token))
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Cancel(); err != nil {
+		return err
+	}
+
+	if err := s.signIdChangeRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionSignIdChangeCanceled, constants.AuditTargetTypeMember,
+		entity.MemberId, fmt.Sprintf("%v -> %v", entity.OldSignId, entity.NewSignId))
+}
+
+// notifyOldSignId 는 기존 아이디가 이메일인 경우에만 알림을 보낸다. 알림 실패가 변경을 막지는 않는다.
+func (s SignIdChangeService) notifyOldSignId(ctx context.Context, oldSignId string, templateName string, data map[string]string) {
+	if !strings.Contains(oldSignId, "@") {
+		return
+	}
+
+	if err := adapters.MailAdapter().Send(s.messageTemplateService.RenderMail(ctx, templateName, []string{oldSignId}, data)); err != nil {
+		log.Error("sign id change notification error: ", err)
+	}
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/cluster"
+	"better-admin-backend-service/config"
 	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
@@ -9,17 +12,33 @@ import (
 	"better-admin-backend-service/site/domain"
 	"better-admin-backend-service/site/repository"
 	"context"
+	"encoding/json"
 	"github.com/mitchellh/mapstructure"
 	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"net/url"
+	"time"
 )
 
+// versionExcludedSettingKeys 는 스냅샷에 저장하지 않고 되돌리지도 않는 설정이다.
+// 교체한 Secret 이나 무효화한 토큰이 되돌아가지 않도록 보안 설정은 제외한다.
+// 앱 버전은 로그인하지 않고도 바꿀 수 있으므로 스냅샷을 만들지 않는다.
+var versionExcludedSettingKeys = map[string]bool{
+	constants.SettingKeyJwtSecret:  true,
+	constants.SettingKeyTokenEpoch: true,
+	constants.SettingKeyAppVersion: true,
+}
+
 type SiteService struct {
-	siteSettingRepository *repository.SiteSettingRepository
+	siteSettingRepository        *repository.SiteSettingRepository
+	siteSettingVersionRepository *repository.SiteSettingVersionRepository
 }
 
-func NewSiteService(siteSettingRepository *repository.SiteSettingRepository) *SiteService {
+func NewSiteService(siteSettingRepository *repository.SiteSettingRepository,
+	siteSettingVersionRepository *repository.SiteSettingVersionRepository) *SiteService {
 	return &SiteService{
-		siteSettingRepository: siteSettingRepository,
+		siteSettingRepository:        siteSettingRepository,
+		siteSettingVersionRepository: siteSettingVersionRepository,
 	}
 }
 
@@ -33,7 +52,7 @@ func (s SiteService) SetSettingWithKey(ctx context.Context, key string, setting
 
 	foundSettingEntity, err := s.siteSettingRepository.FindByKey(ctx, key)
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			// 설정 값이 없으므로 새로 추가
 			newSetting := domain.SettingEntity{
 				Key:         key,
@@ -42,13 +61,21 @@ func (s SiteService) SetSettingWithKey(ctx context.Context, key string, setting
 				UpdatedBy:   userClaim.Id,
 			}
 
-			return s.siteSettingRepository.Save(ctx, newSetting)
+			if err := s.siteSettingRepository.Save(ctx, newSetting); err != nil {
+				return err
+			}
+			_, err = s.saveVersion(ctx, key, 0, userClaim.Id)
+			return err
 		}
 	}
 
 	foundSettingEntity.ValueObject = setting
 	foundSettingEntity.UpdatedBy = userClaim.Id
-	return s.siteSettingRepository.Save(ctx, foundSettingEntity)
+	if err := s.siteSettingRepository.Save(ctx, foundSettingEntity); err != nil {
+		return err
+	}
+	_, err = s.saveVersion(ctx, key, 0, userClaim.Id)
+	return err
 }
 
 func (s SiteService) GetSettingWithKey(ctx context.Context, key string) (interface{}, error) {
@@ -91,3 +118,265 @@ func (s SiteService) IncreaseAppVersion(ctx context.Context) error {
 
 	return s.SetSettingWithKey(ctx, constants.SettingKeyAppVersion, appVersion)
 }
+
+// LoadSecuritySettings 는 DB 에 저장된 토큰 에포크와 JWT Secret 을 애플리케이션에 반영한다.
+func (s SiteService) LoadSecuritySettings(ctx context.Context) error {
+	tokenEpochSetting, err := s.getTokenEpochSetting(ctx)
+	if err != nil {
+		return err
+	}
+	security.SetTokenEpoch(tokenEpochSetting.Epoch)
+
+	jwtSecretSetting, err := s.GetSettingWithKey(ctx, constants.SettingKeyJwtSecret)
+	if err != nil {
+		if pkgerrors.Is(err, errors.ErrNotFound) {
+			// 교체된 적이 없으면 SecretProvider 의 Secret 을 사용한다.
+			return nil
+		}
+		return err
+	}
+
+	var secret dtos.JwtSecretSetting
+	if err = mapstructure.Decode(jwtSecretSetting, &secret); err != nil {
+		return err
+	}
+
+	if len(secret.Secret) > 0 {
+		config.SetRotatedJwtSecret(secret.Secret)
+	}
+
+	return nil
+}
+
+// IncreaseTokenEpoch 는 토큰 에포크를 증가시키고 새 에포크를 반환한다. 프로세스의 에포크는 커밋한 뒤에 바꾼다.
+func (s SiteService) IncreaseTokenEpoch(ctx context.Context) (uint, error) {
+	tokenEpochSetting, err := s.getTokenEpochSetting(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tokenEpochSetting.Epoch = tokenEpochSetting.Epoch + 1
+
+	if err := s.SetSettingWithKey(ctx, constants.SettingKeyTokenEpoch, tokenEpochSetting); err != nil {
+		return 0, err
+	}
+
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		security.SetTokenEpoch(tokenEpochSetting.Epoch)
+	})
+	notifySecuritySettingsChanged(ctx)
+	return tokenEpochSetting.Epoch, nil
+}
+
+// ChangeJwtSecret 은 JWT Secret 을 교체한다. 프로세스의 Secret 은 커밋한 뒤에 바꾼다.
+func (s SiteService) ChangeJwtSecret(ctx context.Context, secret string) error {
+	if err := s.SetSettingWithKey(ctx, constants.SettingKeyJwtSecret, dtos.JwtSecretSetting{Secret: secret}); err != nil {
+		return err
+	}
+
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		config.SetRotatedJwtSecret(secret)
+	})
+	notifySecuritySettingsChanged(ctx)
+	return nil
+}
+
+// notifySecuritySettingsChanged 는 커밋한 뒤 다른 인스턴스가 DB 에서 보안 설정을 다시 읽도록 알린다.
+func notifySecuritySettingsChanged(ctx context.Context) {
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		if err := cluster.Notify(constants.ClusterTopicSecuritySettings, nil); err != nil {
+			log.Errorf("security settings notification error: %v", err)
+		}
+	})
+}
+
+func (s SiteService) getTokenEpochSetting(ctx context.Context) (dtos.TokenEpochSetting, error) {
+	tokenEpochSetting, err := s.GetSettingWithKey(ctx, constants.SettingKeyTokenEpoch)
+	if err != nil {
+		if pkgerrors.Is(err, errors.ErrNotFound) {
+			return dtos.TokenEpochSetting{}, nil
+		}
+		return dtos.TokenEpochSetting{}, err
+	}
+
+	var setting dtos.TokenEpochSetting
+	if err = mapstructure.Decode(tokenEpochSetting, &setting); err != nil {
+		return dtos.TokenEpochSetting{}, err
+	}
+
+	return setting, nil
+}
+
+func (s SiteService) GetSettingVersions(ctx context.Context, pageable dtos.Pageable) ([]domain.SettingVersionEntity, int64, error) {
+	return s.siteSettingVersionRepository.FindAll(ctx, pageable)
+}
+
+func (s SiteService) GetSettingVersion(ctx context.Context, version uint) (domain.SettingVersionEntity, error) {
+	return s.siteSettingVersionRepository.FindByVersion(ctx, version)
+}
+
+// RollbackSettings 는 설정을 스냅샷(version)의 내용으로 되돌리고 되돌린 설정을 새 버전으로 저장한다.
+// 스냅샷에 없는 설정은 지우며, 서명이 맞지 않는 스냅샷은 되돌리지 않는다.
+func (s SiteService) RollbackSettings(ctx context.Context, version uint) (domain.SettingVersionEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	versionEntity, err := s.siteSettingVersionRepository.FindByVersion(ctx, version)
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	signingKey, err := s.getVersionSigningKey()
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	if !versionEntity.VerifySignature(signingKey) {
+		return domain.SettingVersionEntity{}, errors.ErrInvalidSignature
+	}
+
+	document, err := versionEntity.GetDocument()
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	settings, err := s.siteSettingRepository.FindAll(ctx)
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	foundSettings := make(map[string]domain.SettingEntity)
+	for _, setting := range settings {
+		if versionExcludedSettingKeys[setting.Key] {
+			continue
+		}
+		foundSettings[setting.Key] = setting
+
+		if _, ok := document[setting.Key]; !ok {
+			if err := s.siteSettingRepository.Delete(ctx, setting); err != nil {
+				return domain.SettingVersionEntity{}, err
+			}
+		}
+	}
+
+	for key, value := range document {
+		setting, ok := foundSettings[key]
+		if !ok {
+			setting = domain.SettingEntity{Key: key, CreatedBy: userClaim.Id}
+		}
+
+		if err := json.Unmarshal(value, &setting.ValueObject); err != nil {
+			return domain.SettingVersionEntity{}, err
+		}
+		setting.UpdatedBy = userClaim.Id
+		if err := s.siteSettingRepository.Save(ctx, setting); err != nil {
+			return domain.SettingVersionEntity{}, err
+		}
+	}
+
+	return s.saveVersion(ctx, "", version, userClaim.Id)
+}
+
+// saveVersion 은 현재 전체 설정을 새 버전의 스냅샷으로 저장한다.
+func (s SiteService) saveVersion(ctx context.Context, changedKey string, rolledBackFrom uint, createdBy uint) (domain.SettingVersionEntity, error) {
+	if versionExcludedSettingKeys[changedKey] {
+		return domain.SettingVersionEntity{}, nil
+	}
+
+	settings, err := s.siteSettingRepository.FindAll(ctx)
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	document := make(map[string]json.RawMessage)
+	for _, setting := range settings {
+		if !versionExcludedSettingKeys[setting.Key] {
+			document[setting.Key] = json.RawMessage(setting.Value)
+		}
+	}
+
+	latestVersion, err := s.siteSettingVersionRepository.FindLatestVersion(ctx)
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	signingKey, err := s.getVersionSigningKey()
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	versionEntity, err := domain.NewSettingVersionEntity(latestVersion+1, changedKey, document, rolledBackFrom, createdBy, signingKey)
+	if err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	if err := s.siteSettingVersionRepository.Create(ctx, &versionEntity); err != nil {
+		return domain.SettingVersionEntity{}, err
+	}
+
+	maxVersions := uint(config.Config.SiteSettingVersion.MaxVersions)
+	if maxVersions > 0 && versionEntity.Version > maxVersions {
+		if err := s.siteSettingVersionRepository.DeleteOlderThan(ctx, versionEntity.Version-maxVersions+1); err != nil {
+			return domain.SettingVersionEntity{}, err
+		}
+	}
+
+	return versionEntity, nil
+}
+
+// getVersionSigningKey 는 SecretProvider 에서 조회한 스냅샷 서명 키이다. 비어 있으면 SecretProvider 의 JWT Secret 을 사용한다.
+// 교체한 JWT Secret(config.SetRotatedJwtSecret)을 사용하면 교체하기 전의 스냅샷을 검증할 수 없으므로 사용하지 않는다.
+func (s SiteService) getVersionSigningKey() (string, error) {
+	signingKey, err := config.GetSecret(config.SecretSiteSettingVersionSigningKey)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "get setting version signing key error")
+	}
+
+	if len(signingKey) > 0 {
+		return signingKey, nil
+	}
+
+	return config.GetSecret(config.SecretJwt)
+}
+
+// ValidateDoorayLoginSetting 은 두레이 로그인 설정을 적용하기 전에 LDAP 서버(Dooray.LdapDialUrl)에 연결할 수 있는지 확인한다.
+func (s SiteService) ValidateDoorayLoginSetting(setting dtos.DoorayLoginSetting) dtos.SettingValidation {
+	validation := dtos.SettingValidation{Valid: true, Results: make([]dtos.SettingValidationResult, 0)}
+	if setting.Used == nil || !*setting.Used {
+		return validation
+	}
+
+	validation.AddResult("ldap-reachable", s.checkReachable(config.Config.Dooray.LdapDialUrl))
+	return validation
+}
+
+// ValidateGoogleWorkspaceLoginSetting 은 Google Workspace 로그인 설정을 적용하기 전에 OAuth 서버에 연결할 수 있는지,
+// Redirect URI 가 절대 주소인지 확인한다.
+func (s SiteService) ValidateGoogleWorkspaceLoginSetting(setting dtos.GoogleWorkspaceLoginSetting) dtos.SettingValidation {
+	validation := dtos.SettingValidation{Valid: true, Results: make([]dtos.SettingValidationResult, 0)}
+	if setting.Used == nil || !*setting.Used {
+		return validation
+	}
+
+	validation.AddResult("oauth-token-uri-reachable", s.checkReachable(config.Config.GoogleOAuth.TokenUri))
+	validation.AddResult("oauth-auth-uri-reachable", s.checkReachable(config.Config.GoogleOAuth.AuthUri))
+
+	var redirectUriErr error
+	if redirectUri, err := url.Parse(setting.RedirectUri); err != nil || len(redirectUri.Host) == 0 ||
+		(redirectUri.Scheme != "http" && redirectUri.Scheme != "https") {
+		redirectUriErr = pkgerrors.Errorf("invalid redirect uri %q", setting.RedirectUri)
+	}
+	validation.AddResult("redirect-uri", redirectUriErr)
+
+	return validation
+}
+
+func (s SiteService) checkReachable(rawUrl string) error {
+	timeoutSeconds := config.Config.SiteSettingVersion.ValidationTimeoutSeconds
+	if timeoutSeconds <= 0 {
+		timeoutSeconds = 5
+	}
+
+	return adapters.ReachabilityAdapter{}.Check(rawUrl, time.Duration(timeoutSeconds)*time.Second)
+}
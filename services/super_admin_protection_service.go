@@ -0,0 +1,170 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"fmt"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+)
+
+// SuperAdminProtectionService 는 최고 관리자 역할(SYSTEM MANAGER)을 가진 멤버가 바뀔 때 단계 인증과 최소 인원을 확인하고 보안 이벤트로 알린다.
+type SuperAdminProtectionService struct {
+	siteService          *SiteService
+	memberService        *MemberService
+	securityEventService *SecurityEventService
+}
+
+func NewSuperAdminProtectionService(siteService *SiteService, memberService *MemberService,
+	securityEventService *SecurityEventService) *SuperAdminProtectionService {
+	return &SuperAdminProtectionService{
+		siteService:          siteService,
+		memberService:        memberService,
+		securityEventService: securityEventService,
+	}
+}
+
+// GetSuperAdminProtectionSetting 은 최고 관리자 최소 인원 설정을 반환한다. 설정하지 않았으면 1 명이다.
+func (s SuperAdminProtectionService) GetSuperAdminProtectionSetting(ctx context.Context) (dtos.SuperAdminProtectionSetting, error) {
+	superAdminProtectionSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeySuperAdminProtection)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return dtos.SuperAdminProtectionSetting{MinimumCount: 1}, nil
+		}
+		return dtos.SuperAdminProtectionSetting{}, err
+	}
+
+	var setting dtos.SuperAdminProtectionSetting
+	if err = mapstructure.Decode(superAdminProtectionSetting, &setting); err != nil {
+		return dtos.SuperAdminProtectionSetting{}, err
+	}
+
+	if setting.MinimumCount < 1 {
+		setting.MinimumCount = 1
+	}
+
+	return setting, nil
+}
+
+func (s SuperAdminProtectionService) SetSuperAdminProtectionSetting(ctx context.Context, setting dtos.SuperAdminProtectionSetting) error {
+	return s.siteService.SetSettingWithKey(ctx, constants.SettingKeySuperAdminProtection, setting)
+}
+
+// CheckSuperAdminChange 는 멤버들(granted)이 최고 관리자 역할을 얻거나 멤버들(revoked)이 잃기 전에 확인한다.
+// HTTP 요청이면 요청한 멤버의 단계 인증 토큰이 있어야 하고(ErrStepUpRequired), 남는 승인된 최고 관리자가 최소 인원보다 적어지면 ErrSuperAdminMinimum 이다.
+// 거부한 요청은 보안 이벤트(super-admin-blocked)로 기록한다.
+func (s SuperAdminProtectionService) CheckSuperAdminChange(ctx context.Context, granted []uint, revoked []uint) error {
+	if err := s.checkStepUp(ctx); err != nil {
+		s.recordBlocked(ctx, granted, revoked, err)
+		return err
+	}
+
+	if len(revoked) == 0 {
+		return nil
+	}
+
+	setting, err := s.GetSuperAdminProtectionSetting(ctx)
+	if err != nil {
+		return err
+	}
+
+	remaining, err := s.countRemaining(ctx, granted, revoked)
+	if err != nil {
+		return err
+	}
+
+	if remaining < setting.MinimumCount {
+		s.recordBlocked(ctx, granted, revoked, errors.ErrSuperAdminMinimum)
+		return errors.ErrSuperAdminMinimum
+	}
+
+	return nil
+}
+
+// RecordSuperAdminChange 는 최고 관리자 역할이 바뀐 것을 보안 이벤트(super-admin-changed)로 기록한다.
+func (s SuperAdminProtectionService) RecordSuperAdminChange(ctx context.Context, granted []uint, revoked []uint) error {
+	return s.securityEventService.RecordSecurityEvent(ctx, constants.SecurityEventTypeSuperAdminRole, s.requestedBy(ctx),
+		fmt.Sprintf("granted=%v, revoked=%v", granted, revoked))
+}
+
+// checkStepUp 은 HTTP 요청에 요청한 멤버의 단계 인증 토큰이 있는지 확인한다.
+// HTTP 요청이 아닌 처리(예. 예약 작업)는 요청을 받을 때 확인한 것으로 본다.
+func (SuperAdminProtectionService) checkStepUp(ctx context.Context) error {
+	stepUpToken, ok := helpers.ContextHelper().GetStepUpToken(ctx)
+	if !ok {
+		return nil
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 서비스 계정은 비밀번호로 단계 인증을 할 수 없으므로 최고 관리자 역할을 바꿀 수 없다.
+	if len(stepUpToken) == 0 || userClaim.IsServiceAccount() {
+		return errors.ErrStepUpRequired
+	}
+
+	if err := (security.JwtAuthentication{}).VerifyStepUpToken(stepUpToken, userClaim.Id); err != nil {
+		return errors.ErrStepUpRequired
+	}
+
+	return nil
+}
+
+// countRemaining 은 변경 후 최고 관리자 역할을 가진 승인된 멤버 수이다.
+func (s SuperAdminProtectionService) countRemaining(ctx context.Context, granted []uint, revoked []uint) (int, error) {
+	memberEntities, err := s.memberService.GetMembersByRoleName(ctx, constants.RoleNameSuperAdmin)
+	if err != nil {
+		return 0, err
+	}
+
+	revokedIds := map[uint]bool{}
+	for _, memberId := range revoked {
+		revokedIds[memberId] = true
+	}
+
+	superAdmins := map[uint]bool{}
+	for _, memberEntity := range memberEntities {
+		if memberEntity.IsApproved() && !revokedIds[memberEntity.ID] {
+			superAdmins[memberEntity.ID] = true
+		}
+	}
+
+	for _, memberId := range granted {
+		if superAdmins[memberId] || revokedIds[memberId] {
+			continue
+		}
+
+		memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+		if err != nil {
+			return 0, err
+		}
+
+		if memberEntity.IsApproved() {
+			superAdmins[memberId] = true
+		}
+	}
+
+	return len(superAdmins), nil
+}
+
+// recordBlocked 는 거부한 변경을 기록한다. 기록에 실패해도 거부 응답은 바꾸지 않는다.
+func (s SuperAdminProtectionService) recordBlocked(ctx context.Context, granted []uint, revoked []uint, reason error) {
+	if err := s.securityEventService.RecordSecurityEvent(ctx, constants.SecurityEventTypeSuperAdminBlock, s.requestedBy(ctx),
+		fmt.Sprintf("granted=%v, revoked=%v, reason=%v", granted, revoked, errors.Code(reason))); err != nil {
+		log.Errorf("record super admin blocked security event error. %v", err)
+	}
+}
+
+func (SuperAdminProtectionService) requestedBy(ctx context.Context) uint {
+	if userClaim, err := helpers.ContextHelper().GetUserClaim(ctx); err == nil {
+		return userClaim.Id
+	}
+
+	return 0
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"time"
+)
+
+const defaultChaosDurationMinutes = 10
+
+type SystemService struct {
+	siteService    *SiteService
+	webHookService *WebHookService
+	auditService   *AuditService
+}
+
+func NewSystemService(
+	siteService *SiteService,
+	webHookService *WebHookService,
+	auditService *AuditService) *SystemService {
+
+	return &SystemService{
+		siteService:    siteService,
+		webHookService: webHookService,
+		auditService:   auditService,
+	}
+}
+
+// GetVersion 은 빌드 정보와 지원하는 최소 프론트엔드 버전을 반환한다.
+func (s SystemService) GetVersion() dtos.VersionInfo {
+	return dtos.VersionInfo{
+		BuildInfo:          buildInfo(),
+		MinFrontendVersion: config.Config.Compatibility.MinFrontendVersion,
+	}
+}
+
+func buildInfo() dtos.BuildInfo {
+	return dtos.BuildInfo{
+		Version:   config.Version,
+		GitCommit: config.GitCommit,
+		BuildTime: config.BuildTime,
+	}
+}
+
+// ForceLogout 은 토큰 에포크를 증가시켜 발급된 모든 Access/Refresh 토큰을 즉시 무효화 한다.
+// 만료되지 않는 웹훅 토큰은 같은 트랜잭션에서 새 에포크로 다시 발급한다.
+func (s SystemService) ForceLogout(ctx context.Context) error {
+	epoch, err := s.siteService.IncreaseTokenEpoch(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.webHookService.ReissueAccessTokens(security.WithPendingTokenSigning(ctx, epoch, "")); err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionForceLogout, constants.AuditTargetTypeSystem, 0, "")
+}
+
+// ChangeLogging 은 로그 레벨과 모듈별 디버그 로그, 요청 상세 기록을 바꾼다. 설정은 재시작하면 초기화된다.
+func (s SystemService) ChangeLogging(ctx context.Context, setting dtos.LoggingSetting) error {
+	if len(setting.Level) > 0 {
+		if err := helpers.LoggingHelper().SetLevel(setting.Level); err != nil {
+			return err
+		}
+	}
+
+	for _, module := range setting.DebugModules {
+		if setting.DebugMinutes == 0 {
+			helpers.LoggingHelper().DisableDebug(module)
+			continue
+		}
+		helpers.LoggingHelper().EnableDebug(module, time.Now().Add(time.Duration(setting.DebugMinutes)*time.Minute))
+	}
+
+	if setting.RequestCapture != nil {
+		helpers.LoggingHelper().StartRequestCapture(setting.RequestCapture.MemberId, setting.RequestCapture.Requests)
+	}
+
+	detail, err := json.Marshal(setting)
+	if err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionLoggingChanged, constants.AuditTargetTypeSystem, 0, string(detail))
+}
+
+// ChangeChaos 는 이 인스턴스의 장애 주입 규칙을 바꾼다. 규칙을 비우면 끈다. DurationMinutes 가 0 이면 10분 동안 적용한다.
+func (s SystemService) ChangeChaos(ctx context.Context, setting dtos.ChaosSetting) error {
+	if len(setting.Rules) > 0 && !config.Config.Chaos.Enabled {
+		return errors.ErrChaosDisabled
+	}
+
+	if setting.DurationMinutes == 0 {
+		setting.DurationMinutes = defaultChaosDurationMinutes
+	}
+	helpers.ChaosHelper().SetRules(setting.Rules, time.Now().Add(time.Duration(setting.DurationMinutes)*time.Minute))
+
+	detail, err := json.Marshal(setting)
+	if err != nil {
+		return err
+	}
+
+	return s.auditService.RecordAuditLog(ctx, constants.AuditActionChaosChanged, constants.AuditTargetTypeSystem, 0, string(detail))
+}
+
+// RotateJwtSecret 은 JWT 서명 Secret 을 교체하고 모든 토큰을 무효화 한 뒤
+// 웹훅 토큰과 요청한 멤버의 토큰을 새로운 Secret 으로 다시 발급한다.
+func (s SystemService) RotateJwtSecret(ctx context.Context) (security.JwtToken, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return security.JwtToken{}, pkgerrors.Wrap(err, "generate jwt secret error")
+	}
+
+	jwtSecret := hex.EncodeToString(secret)
+	if err := s.siteService.ChangeJwtSecret(ctx, jwtSecret); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	epoch, err := s.siteService.IncreaseTokenEpoch(ctx)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	// 새 Secret 과 에포크는 커밋한 뒤에 반영되므로 다시 발급하는 토큰에는 직접 지정한다.
+	signingCtx := security.WithPendingTokenSigning(ctx, epoch, jwtSecret)
+	if err := s.webHookService.ReissueAccessTokens(signingCtx); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if err := s.auditService.RecordAuditLog(ctx, constants.AuditActionRotateSecret, constants.AuditTargetTypeSystem, 0, ""); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	return security.JwtAuthentication{}.GenerateJwtToken(signingCtx, *userClaim)
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/tableview/domain"
+	"better-admin-backend-service/tableview/repository"
+	"context"
+	"fmt"
+)
+
+// TableViewService 는 목록 화면(멤버, 감사 로그, 역할)의 보기 설정을 멤버별로 저장하고 역할의 멤버에게 공유한다.
+// 역할에 공유한 보기는 MANAGE_ACCESS_CONTROL 권한이 있어야 만들고 바꿀 수 있다.
+type TableViewService struct {
+	tableViewRepository *repository.TableViewRepository
+	memberService       *MemberService
+	organizationService *OrganizationService
+	rbacService         *RoleBasedAccessControlService
+}
+
+func NewTableViewService(tableViewRepository *repository.TableViewRepository,
+	memberService *MemberService,
+	organizationService *OrganizationService,
+	rbacService *RoleBasedAccessControlService) *TableViewService {
+	return &TableViewService{
+		tableViewRepository: tableViewRepository,
+		memberService:       memberService,
+		organizationService: organizationService,
+		rbacService:         rbacService,
+	}
+}
+
+// GetTableViews 는 로그인한 멤버의 보기와 멤버의 역할(조직을 통해 할당된 역할 포함)에 공유된 보기이다.
+// MANAGE_ACCESS_CONTROL 권한이 있으면 모든 역할에 공유된 보기를 반환한다. 보기는 멤버만 사용하므로 서비스 계정은 ErrAuthentication 이다.
+func (s TableViewService) GetTableViews(ctx context.Context, table string) ([]domain.TableViewEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasAnyClaimPermission(ctx, []string{constants.PermissionManageAccessControl}) {
+		return s.tableViewRepository.FindVisible(ctx, table, userClaim.Id, nil, true)
+	}
+
+	roleIds, err := s.findRoleIds(ctx, userClaim.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.tableViewRepository.FindVisible(ctx, table, userClaim.Id, roleIds, false)
+}
+
+// GetTableView 는 GetTableViews 로 조회할 수 있는 보기이다. 다른 멤버의 개인 보기는 찾을 수 없다.
+func (s TableViewService) GetTableView(ctx context.Context, id uint) (domain.TableViewEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	entity, err := s.tableViewRepository.FindById(ctx, id)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	if !entity.IsShared() {
+		if entity.MemberId != userClaim.Id {
+			return domain.TableViewEntity{}, errors.ErrNotFound
+		}
+		return entity, nil
+	}
+
+	if hasAnyClaimPermission(ctx, []string{constants.PermissionManageAccessControl}) {
+		return entity, nil
+	}
+
+	roleIds, err := s.findRoleIds(ctx, userClaim.Id)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	if !containsUint(roleIds, entity.RoleId) {
+		return domain.TableViewEntity{}, errors.ErrNotFound
+	}
+
+	return entity, nil
+}
+
+func (s TableViewService) CreateTableView(ctx context.Context, information dtos.TableViewInformation) (domain.TableViewEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	entity, err := domain.NewTableViewEntity(information, userClaim.Id)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	if entity.IsShared() {
+		if !hasAnyClaimPermission(ctx, []string{constants.PermissionManageAccessControl}) {
+			return domain.TableViewEntity{}, errors.ErrForbidden
+		}
+
+		if _, err := s.rbacService.GetRole(ctx, entity.RoleId); err != nil {
+			if errors.Is(err, errors.ErrNotFound) {
+				return domain.TableViewEntity{}, &errors.ErrInvalidTableView{Reason: fmt.Sprintf("role %v not found", entity.RoleId)}
+			}
+			return domain.TableViewEntity{}, err
+		}
+	}
+
+	count, err := s.tableViewRepository.CountByOwner(ctx, entity.Table, entity.MemberId, entity.RoleId)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	if count >= constants.TableViewMaxPerTable {
+		return domain.TableViewEntity{}, &errors.ErrInvalidTableView{Reason: fmt.Sprintf("table views must be less than or equal to %v", constants.TableViewMaxPerTable)}
+	}
+
+	if err := s.tableViewRepository.Create(ctx, &entity); err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s TableViewService) UpdateTableView(ctx context.Context, id uint, information dtos.TableViewInformation) (domain.TableViewEntity, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	entity, err := s.getEditableTableView(ctx, id)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	if err := entity.Update(information, userClaim.Id); err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	if err := s.tableViewRepository.Save(ctx, &entity); err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s TableViewService) DeleteTableView(ctx context.Context, id uint) error {
+	entity, err := s.getEditableTableView(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.tableViewRepository.Delete(ctx, entity)
+}
+
+// getEditableTableView 는 로그인한 멤버가 바꿀 수 있는 보기이다. 역할에 공유된 보기는 멤버도 조회할 수 있으므로 권한이 없으면 ErrForbidden 이다.
+func (s TableViewService) getEditableTableView(ctx context.Context, id uint) (domain.TableViewEntity, error) {
+	entity, err := s.GetTableView(ctx, id)
+	if err != nil {
+		return domain.TableViewEntity{}, err
+	}
+
+	if entity.IsShared() && !hasAnyClaimPermission(ctx, []string{constants.PermissionManageAccessControl}) {
+		return domain.TableViewEntity{}, errors.ErrForbidden
+	}
+
+	return entity, nil
+}
+
+// findRoleIds 는 멤버에게 직접 또는 조직을 통해 할당된 역할이다.
+func (s TableViewService) findRoleIds(ctx context.Context, memberId uint) ([]uint, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return make([]uint, 0), nil
+		}
+		return nil, err
+	}
+
+	organizations, err := s.organizationService.GetAllOrganizations(ctx, map[string]interface{}{"memberId": memberEntity.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	roleIds := make([]uint, 0)
+	for _, role := range memberEntity.Roles {
+		roleIds = append(roleIds, role.ID)
+	}
+
+	for _, organization := range organizations {
+		for _, role := range organization.Roles {
+			roleIds = append(roleIds, role.ID)
+		}
+	}
+
+	return roleIds, nil
+}
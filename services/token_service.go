@@ -0,0 +1,287 @@
+package services
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/token/domain"
+	"better-admin-backend-service/token/repository"
+	"context"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TokenService struct {
+	serviceAccountService  *ServiceAccountService
+	sessionService         *SessionService
+	auditService           *AuditService
+	revokedTokenRepository *repository.RevokedTokenRepository
+}
+
+func NewTokenService(serviceAccountService *ServiceAccountService, sessionService *SessionService, auditService *AuditService,
+	revokedTokenRepository *repository.RevokedTokenRepository) *TokenService {
+	return &TokenService{
+		serviceAccountService:  serviceAccountService,
+		sessionService:         sessionService,
+		auditService:           auditService,
+		revokedTokenRepository: revokedTokenRepository,
+	}
+}
+
+// IssueScopedToken 은 요청한 멤버의 권한으로 특정 리소스에만 사용할 수 있는 짧은 수명의 토큰을 발급한다.
+// 스코프 토큰은 멤버로 인증하므로 서비스 계정은 발급할 수 없다.
+func (s TokenService) IssueScopedToken(ctx context.Context, request dtos.ScopedTokenRequest) (dtos.ScopedToken, error) {
+	userClaim, err := memberClaimOf(ctx)
+	if err != nil {
+		return dtos.ScopedToken{}, err
+	}
+
+	lifetimeSeconds := config.Config.ScopedToken.LifetimeSeconds
+	if request.ExpiresIn > 0 {
+		lifetimeSeconds = request.ExpiresIn
+	}
+	if lifetimeSeconds > config.Config.ScopedToken.MaxLifetimeSeconds {
+		lifetimeSeconds = config.Config.ScopedToken.MaxLifetimeSeconds
+	}
+
+	token, expiresAt, err := security.JwtAuthentication{}.GenerateScopedToken(security.ScopedTokenClaim{
+		Id:          userClaim.Id,
+		Permissions: userClaim.Permissions,
+		SessionId:   userClaim.SessionId,
+		Resource:    request.Resource,
+		Action:      request.Action,
+	}, time.Duration(lifetimeSeconds)*time.Second)
+	if err != nil {
+		return dtos.ScopedToken{}, err
+	}
+
+	return dtos.ScopedToken{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// IssueClientCredentialsToken 은 client_credentials 그랜트로 서비스 계정의 Access 토큰을 발급한다.
+// 토큰의 권한은 요청한 scope(공백 구분 권한 이름)로 제한할 수 있다.
+func (s TokenService) IssueClientCredentialsToken(ctx context.Context, clientId, clientSecret, scope string) (dtos.OAuthToken, error) {
+	serviceAccount, err := s.serviceAccountService.AuthenticateClient(ctx, clientId, clientSecret)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	permissions, err := serviceAccount.ResolveScopes(strings.Fields(scope))
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	lifetimeSeconds := config.Config.ClientCredentials.DefaultLifetimeSeconds
+	if serviceAccount.TokenLifetimeSeconds > 0 {
+		lifetimeSeconds = serviceAccount.TokenLifetimeSeconds
+	}
+	if lifetimeSeconds > config.Config.ClientCredentials.MaxLifetimeSeconds {
+		lifetimeSeconds = config.Config.ClientCredentials.MaxLifetimeSeconds
+	}
+
+	accessToken, _, err := security.JwtAuthentication{}.GenerateJwtAccessToken(security.UserClaim{
+		Id:            serviceAccount.ID,
+		Roles:         serviceAccount.GetRoleNames(),
+		Permissions:   permissions,
+		PrincipalType: security.PrincipalTypeServiceAccount,
+	}, time.Duration(lifetimeSeconds)*time.Second)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	return dtos.OAuthToken{
+		AccessToken: accessToken,
+		TokenType:   constants.OAuthTokenTypeBearer,
+		ExpiresIn:   lifetimeSeconds,
+		Scope:       strings.Join(permissions, " "),
+	}, nil
+}
+
+// IssueExchangedToken 은 토큰 교환(RFC 8693) 그랜트로 서비스 계정이 받은 멤버의 Access 토큰을 audience 서비스에서만 사용할 토큰으로 바꾼다.
+// 서비스 계정의 교환 정책에 audience 가 있어야 하며 토큰의 권한은 정책의 scope 와 멤버의 권한 안으로 줄어든다.
+func (s TokenService) IssueExchangedToken(ctx context.Context, request dtos.ClientCredentialsTokenRequest) (dtos.OAuthToken, error) {
+	serviceAccount, err := s.serviceAccountService.AuthenticateClient(ctx, request.ClientId, request.ClientSecret)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	if request.SubjectTokenType != constants.OAuthTokenTypeAccessToken ||
+		(len(request.RequestedTokenType) > 0 && request.RequestedTokenType != constants.OAuthTokenTypeAccessToken) {
+		return dtos.OAuthToken{}, errors.ErrInvalidSubjectToken
+	}
+
+	// 멤버 대신 호출하기 위한 교환이므로 서비스 계정의 토큰이나 이미 교환한 토큰은 바꿀 수 없다.
+	subjectClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(request.SubjectToken)
+	if err != nil || subjectClaim.IsServiceAccount() {
+		return dtos.OAuthToken{}, errors.ErrInvalidSubjectToken
+	}
+
+	policy, err := s.serviceAccountService.GetTokenExchangePolicy(ctx, serviceAccount.ID, request.Audience)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	permissions, err := policy.ResolveScopes(subjectClaim.Permissions, strings.Fields(request.Scope))
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	lifetimeSeconds := config.Config.TokenExchange.DefaultLifetimeSeconds
+	if policy.LifetimeSeconds > 0 {
+		lifetimeSeconds = policy.LifetimeSeconds
+	}
+	if lifetimeSeconds > config.Config.TokenExchange.MaxLifetimeSeconds {
+		lifetimeSeconds = config.Config.TokenExchange.MaxLifetimeSeconds
+	}
+
+	exchangedClaim := *subjectClaim
+	exchangedClaim.Permissions = permissions
+	accessToken, _, err := security.JwtAuthentication{}.GenerateExchangedToken(exchangedClaim, policy.Audience, serviceAccount.ClientId,
+		time.Duration(lifetimeSeconds)*time.Second)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	// 토큰 엔드포인트는 로그인 없이 호출하므로 요청한 서비스 계정을 감사 로그의 행위자로 기록한다.
+	actorCtx := helpers.ContextHelper().SetUserClaim(ctx, &security.UserClaim{Id: serviceAccount.ID, PrincipalType: security.PrincipalTypeServiceAccount})
+	if err := s.auditService.RecordAuditLog(actorCtx, constants.AuditActionTokenExchanged, constants.AuditTargetTypeMember, subjectClaim.Id,
+		fmt.Sprintf("audience=%s, scope=%s", policy.Audience, strings.Join(permissions, " "))); err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	return dtos.OAuthToken{
+		AccessToken:     accessToken,
+		IssuedTokenType: constants.OAuthTokenTypeAccessToken,
+		TokenType:       constants.OAuthTokenTypeBearer,
+		ExpiresIn:       lifetimeSeconds,
+		Scope:           strings.Join(permissions, " "),
+	}, nil
+}
+
+// IntrospectToken 은 토큰 조회(RFC 7662) 요청으로 토큰을 사용할 수 있는지와 클레임을 알려준다.
+// 서명이나 만료 시간이 맞지 않거나, 폐기했거나, 세션이 종료된 토큰은 active 를 false 로 응답한다.
+func (s TokenService) IntrospectToken(ctx context.Context, request dtos.OAuthTokenRequest) (dtos.TokenIntrospection, error) {
+	if _, err := s.serviceAccountService.AuthenticateClient(ctx, request.ClientId, request.ClientSecret); err != nil {
+		return dtos.TokenIntrospection{}, err
+	}
+
+	claims, err := security.JwtAuthentication{}.ConvertIntrospectionTokenClaims(request.Token)
+	if err != nil {
+		return dtos.TokenIntrospection{Active: false}, nil
+	}
+
+	revoked, err := s.IsTokenRevoked(ctx, request.Token)
+	if err != nil {
+		return dtos.TokenIntrospection{}, err
+	}
+	if revoked {
+		return dtos.TokenIntrospection{Active: false}, nil
+	}
+
+	userClaim, err := security.NewUserClaim(claims)
+	if err != nil {
+		return dtos.TokenIntrospection{Active: false}, nil
+	}
+
+	if len(userClaim.SessionId) > 0 {
+		if _, err := s.sessionService.GetActiveSession(ctx, userClaim.SessionId); err != nil {
+			if errors.Is(err, errors.ErrSessionRevoked) {
+				return dtos.TokenIntrospection{Active: false}, nil
+			}
+
+			return dtos.TokenIntrospection{}, err
+		}
+	}
+
+	introspection := dtos.TokenIntrospection{
+		Active:        true,
+		Scope:         strings.Join(userClaim.Permissions, " "),
+		TokenType:     constants.OAuthTokenTypeBearer,
+		Sub:           strconv.FormatUint(uint64(userClaim.Id), 10),
+		Aud:           security.GetClaimAudiences(claims),
+		PrincipalType: userClaim.PrincipalType,
+		Roles:         userClaim.Roles,
+		Permissions:   userClaim.Permissions,
+	}
+
+	if scope, ok := claims[constants.OAuthClaimKeyScope].(string); ok {
+		introspection.Scope = scope
+	}
+	if clientId, ok := claims[constants.OAuthClaimKeyClientId].(string); ok {
+		introspection.ClientId = clientId
+	} else {
+		introspection.ClientId = security.GetActorClientId(claims)
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		introspection.Exp = int64(exp)
+	}
+	if iss, ok := claims["iss"].(string); ok {
+		introspection.Iss = iss
+	}
+
+	return introspection, nil
+}
+
+// RevokeToken 은 토큰 폐기(RFC 7009) 요청으로 서비스 계정이 발급받은 토큰(client_credentials, 토큰 교환)을 더 이상 사용할 수 없게 한다.
+// 이미 사용할 수 없는 토큰은 폐기한 것으로 보고 다른 서비스 계정에 발급한 토큰은 ErrForbidden 을 반환한다.
+func (s TokenService) RevokeToken(ctx context.Context, request dtos.OAuthTokenRequest) error {
+	serviceAccount, err := s.serviceAccountService.AuthenticateClient(ctx, request.ClientId, request.ClientSecret)
+	if err != nil {
+		return err
+	}
+
+	claims, err := security.JwtAuthentication{}.ConvertIntrospectionTokenClaims(request.Token)
+	if err != nil {
+		return nil
+	}
+
+	userClaim, err := security.NewUserClaim(claims)
+	if err != nil {
+		return nil
+	}
+
+	targetType := constants.AuditTargetTypeMember
+	if userClaim.IsServiceAccount() && userClaim.Id == serviceAccount.ID {
+		targetType = constants.AuditTargetTypeServiceAccount
+	} else if security.GetActorClientId(claims) != serviceAccount.ClientId {
+		return errors.ErrForbidden
+	}
+
+	entity := domain.NewRevokedTokenEntity(request.Token, s.getExpiresAt(claims), serviceAccount.ClientId)
+	if err := s.revokedTokenRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+
+	actorCtx := helpers.ContextHelper().SetUserClaim(ctx, &security.UserClaim{Id: serviceAccount.ID, PrincipalType: security.PrincipalTypeServiceAccount})
+	return s.auditService.RecordAuditLog(actorCtx, constants.AuditActionTokenRevoked, targetType, userClaim.Id,
+		fmt.Sprintf("tokenHash=%s", entity.TokenHash))
+}
+
+func (TokenService) getExpiresAt(claims jwt.MapClaims) *time.Time {
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil
+	}
+
+	expiresAt := time.Unix(int64(exp), 0)
+	return &expiresAt
+}
+
+// IsTokenRevoked 는 폐기한 토큰인지 확인한다.
+func (s TokenService) IsTokenRevoked(ctx context.Context, token string) (bool, error) {
+	return s.revokedTokenRepository.ExistsByTokenHash(ctx, domain.HashToken(token))
+}
+
+// DeleteExpiredRevokedTokens 는 만료되어 확인할 필요가 없는 폐기 기록을 정리한다.
+func (s TokenService) DeleteExpiredRevokedTokens(ctx context.Context) error {
+	return s.revokedTokenRepository.DeleteExpired(ctx, time.Now())
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/statistics/domain"
+	"better-admin-backend-service/statistics/repository"
+	"context"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type UsageStatisticsService struct {
+	organizationService      *OrganizationService
+	loginAttemptRepository   *repository.LoginAttemptRepository
+	usageStatisticRepository *repository.UsageStatisticRepository
+}
+
+func NewUsageStatisticsService(
+	organizationService *OrganizationService,
+	loginAttemptRepository *repository.LoginAttemptRepository,
+	usageStatisticRepository *repository.UsageStatisticRepository) *UsageStatisticsService {
+
+	return &UsageStatisticsService{
+		organizationService:      organizationService,
+		loginAttemptRepository:   loginAttemptRepository,
+		usageStatisticRepository: usageStatisticRepository,
+	}
+}
+
+// RecordLoginAttempt 는 로그인 결과를 기록한다. 기록에 실패해도 로그인 결과는 바꾸지 않는다.
+func (s UsageStatisticsService) RecordLoginAttempt(ctx context.Context, method string, memberId uint, loginErr error) {
+	helpers.LoggingHelper().Debugf(constants.LoggingModuleAuth, "login attempt. method=%s, memberId=%d, error=%v", method, memberId, loginErr)
+
+	entity := domain.NewLoginAttemptEntity(ctx, method, memberId, loginErr)
+	if err := s.loginAttemptRepository.Create(ctx, &entity); err != nil {
+		log.Errorf("record login attempt error. %v", err)
+		return
+	}
+
+	helpers.ContextHelper().AfterCommit(ctx, func() {
+		adapters.EventBusAdapter().Publish(entity.ToEvent())
+	})
+}
+
+// GetAccessLogs 는 로그인 시도 기록을 최근 순으로 조회한다.
+func (s UsageStatisticsService) GetAccessLogs(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.LoginAttemptEntity, int64, error) {
+	return s.loginAttemptRepository.FindAll(ctx, filters, pageable)
+}
+
+// AggregateUsageStatistics 는 아직 집계하지 않은 지난 날짜의 일간 통계를 만들고, 일요일까지 집계되면 그 주의 주간 통계도 만든다.
+func (s UsageStatisticsService) AggregateUsageStatistics(ctx context.Context) error {
+	today := truncateToDate(time.Now())
+
+	from, err := s.nextAggregationDate(ctx, today)
+	if err != nil {
+		return err
+	}
+
+	memberOrganizationIds, err := s.getMemberOrganizationIds(ctx)
+	if err != nil {
+		return err
+	}
+
+	for day := from; day.Before(today); day = day.AddDate(0, 0, 1) {
+		if err := s.aggregatePeriod(ctx, constants.UsageStatisticPeriodDaily, day, day.AddDate(0, 0, 1), memberOrganizationIds); err != nil {
+			return err
+		}
+
+		if day.Weekday() == time.Sunday {
+			weekStart := day.AddDate(0, 0, -6)
+			if err := s.aggregatePeriod(ctx, constants.UsageStatisticPeriodWeekly, weekStart, day.AddDate(0, 0, 1), memberOrganizationIds); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s UsageStatisticsService) nextAggregationDate(ctx context.Context, today time.Time) (time.Time, error) {
+	oldest := today.AddDate(0, 0, -constants.UsageStatisticMaxBackfillDays)
+
+	lastPeriodStart, err := s.usageStatisticRepository.FindLastPeriodStart(ctx, constants.UsageStatisticPeriodDaily)
+	if err == nil {
+		last, err := time.ParseInLocation(domain.UsageStatisticDateLayout, lastPeriodStart, time.Local)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return latestDate(last.AddDate(0, 0, 1), oldest), nil
+	}
+
+	if !errors.Is(err, errors.ErrNotFound) {
+		return time.Time{}, err
+	}
+
+	// 처음 집계하는 경우 가장 오래된 로그인 기록부터 집계한다.
+	firstAttempt, err := s.loginAttemptRepository.FindFirst(ctx)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			return today, nil
+		}
+		return time.Time{}, err
+	}
+
+	return latestDate(truncateToDate(firstAttempt.AttemptedAt), oldest), nil
+}
+
+func (s UsageStatisticsService) aggregatePeriod(ctx context.Context, period string, from, to time.Time, memberOrganizationIds map[uint][]uint) error {
+	attempts, err := s.loginAttemptRepository.FindBetween(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	entities := domain.NewUsageStatisticEntities(period, from, attempts, memberOrganizationIds)
+	return s.usageStatisticRepository.ReplacePeriod(ctx, period, from.Format(domain.UsageStatisticDateLayout), entities)
+}
+
+func (s UsageStatisticsService) getMemberOrganizationIds(ctx context.Context) (map[uint][]uint, error) {
+	organizations, err := s.organizationService.GetAllOrganizations(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	memberOrganizationIds := map[uint][]uint{}
+	for _, organization := range organizations {
+		for _, member := range organization.Members {
+			memberOrganizationIds[member.ID] = append(memberOrganizationIds[member.ID], organization.ID)
+		}
+	}
+
+	return memberOrganizationIds, nil
+}
+
+// GetUsageStatistics 는 집계된 통계를 조회한다. 조직별로 나누어 보는 경우(breakdown=organization) 전체 집계는 제외한다.
+func (s UsageStatisticsService) GetUsageStatistics(ctx context.Context, metric string, query dtos.UsageStatisticsQuery) ([]domain.UsageStatisticEntity, error) {
+	filters := map[string]interface{}{
+		"metric": metric,
+		"period": query.Period,
+		"from":   query.From,
+		"to":     query.To,
+	}
+
+	if len(query.Period) == 0 {
+		filters["period"] = constants.UsageStatisticPeriodDaily
+	}
+
+	if query.Breakdown != constants.UsageStatisticBreakdownOrganization {
+		filters["organizationId"] = query.OrganizationId
+	}
+
+	return s.usageStatisticRepository.Find(ctx, filters)
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func latestDate(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
@@ -23,7 +24,7 @@ func (s WebHookService) CreateWebHook(ctx context.Context, webHookInformation dt
 	lastEntity, err := s.webHookRepository.FindLast(ctx)
 	var nextId uint
 	if err != nil {
-		if err == errors.ErrNotFound {
+		if errors.Is(err, errors.ErrNotFound) {
 			nextId = 1
 		} else {
 			return err
@@ -40,8 +41,26 @@ func (s WebHookService) CreateWebHook(ctx context.Context, webHookInformation dt
 	return s.webHookRepository.Create(ctx, &entity)
 }
 
-func (s WebHookService) GetWebHooks(ctx context.Context, pageable dtos.Pageable) ([]domain.WebHookEntity, int64, error) {
-	return s.webHookRepository.FindAll(ctx, pageable)
+func (s WebHookService) GetWebHooks(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.WebHookEntity, int64, error) {
+	if err := applyOwnerFilter(ctx, filters, constants.PermissionManageSystemSettings); err != nil {
+		return nil, 0, err
+	}
+
+	return s.webHookRepository.FindAll(ctx, filters, pageable)
+}
+
+// AuthorizeOwner 는 웹훅의 소유자이거나 시스템 설정 관리 권한이 있는지 확인한다. 권한이 있으면 리소스를 조회하지 않는다.
+func (s WebHookService) AuthorizeOwner(ctx context.Context, webHookId uint) error {
+	if hasAnyClaimPermission(ctx, []string{constants.PermissionManageSystemSettings}) {
+		return nil
+	}
+
+	entity, err := s.webHookRepository.FindById(ctx, webHookId)
+	if err != nil {
+		return err
+	}
+
+	return authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageSystemSettings)
 }
 
 func (s WebHookService) DeleteWebHook(ctx context.Context, webHookId uint) error {
@@ -83,6 +102,7 @@ func (s WebHookService) NoteMessage(ctx context.Context, webHookId uint, message
 		return err
 	}
 
+	helpers.LoggingHelper().Debugf(constants.LoggingModuleWebHooks, "note web hook message. webHookId=%d, title=%s", webHookId, message.Title)
 	entity.AddMessage(message)
 
 	err = s.webHookRepository.Save(ctx, entity)
@@ -94,3 +114,80 @@ func (s WebHookService) NoteMessage(ctx context.Context, webHookId uint, message
 
 	return entity.NoteMessage(message)
 }
+
+// ReissueAccessTokens 는 JWT Secret 교체 등으로 기존 토큰이 무효화 되었을 때 모든 웹훅의 토큰을 다시 발급한다.
+func (s WebHookService) ReissueAccessTokens(ctx context.Context) error {
+	entities, err := s.webHookRepository.FindAllWithoutPaging(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, entity := range entities {
+		if err := entity.ReissueAccessToken(ctx); err != nil {
+			return err
+		}
+
+		if err := s.webHookRepository.Save(ctx, entity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TransferOwnership 은 멤버가 소유한 웹훅을 다른 멤버에게 넘기고 넘긴 웹훅을 반환한다.
+func (s WebHookService) TransferOwnership(ctx context.Context, fromOwnerId, toOwnerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.webHookRepository.FindByOwnerId(ctx, fromOwnerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for _, entity := range entities {
+		if err := entity.TransferOwnership(ctx, toOwnerId); err != nil {
+			return nil, err
+		}
+
+		if err := s.webHookRepository.Save(ctx, entity); err != nil {
+			return nil, err
+		}
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeWebHook, Id: entity.ID, Name: entity.Name})
+	}
+
+	return resources, nil
+}
+
+func (s WebHookService) GetOwnedResources(ctx context.Context, ownerId uint) ([]dtos.OwnedResource, error) {
+	entities, err := s.webHookRepository.FindByOwnerId(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]dtos.OwnedResource, 0, len(entities))
+	for _, entity := range entities {
+		resources = append(resources, dtos.OwnedResource{Type: constants.OwnedResourceTypeWebHook, Id: entity.ID, Name: entity.Name})
+	}
+
+	return resources, nil
+}
+
+func (s WebHookService) TransferResourceOwnership(ctx context.Context, webHookId, toOwnerId uint) (dtos.OwnedResource, error) {
+	entity, err := s.webHookRepository.FindById(ctx, webHookId)
+	if err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := authorizeResourceOwner(ctx, entity.GetOwnerId(), constants.PermissionManageSystemSettings); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := entity.TransferOwnership(ctx, toOwnerId); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	if err := s.webHookRepository.Save(ctx, entity); err != nil {
+		return dtos.OwnedResource{}, err
+	}
+
+	return dtos.OwnedResource{Type: constants.OwnedResourceTypeWebHook, Id: entity.ID, Name: entity.Name}, nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// LoginDeviceEntity 는 멤버가 로그인한 적이 있는 기기(클라이언트 지문)와 위치(국가)이다.
+// 처음 보는 기기나 위치에서 로그인하면 멤버에게 알린다.
+type LoginDeviceEntity struct {
+	gorm.Model
+	MemberId          uint   `gorm:"not null;index:idx_login_device_member"`
+	ClientFingerprint string `gorm:"type:varchar(64);not null;index:idx_login_device_member"`
+	Country           string `gorm:"type:varchar(2);index:idx_login_device_member"`
+	LastIpAddress     string `gorm:"type:varchar(45)"`
+	LastLoginAt       time.Time
+}
+
+func (LoginDeviceEntity) TableName() string {
+	return "member_login_devices"
+}
+
+func (d *LoginDeviceEntity) UpdateLastLogin(ipAddress string) {
+	d.LastIpAddress = ipAddress
+	d.LastLoginAt = time.Now()
+}
+
+func NewLoginDeviceEntity(memberId uint, clientFingerprint, country, ipAddress string) LoginDeviceEntity {
+	return LoginDeviceEntity{
+		MemberId:          memberId,
+		ClientFingerprint: clientFingerprint,
+		Country:           country,
+		LastIpAddress:     ipAddress,
+		LastLoginAt:       time.Now(),
+	}
+}
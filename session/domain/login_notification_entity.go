@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+// LoginNotificationEntity 는 처음 보는 기기나 위치에서의 로그인을 멤버에게 알린 기록이다.
+// 멤버가 본인이 아니라고 신고(Report)하면 세션을 종료하고, 메일로 보낸 재설정 토큰으로 비밀번호를 다시 설정할 때까지 비밀번호 로그인을 막는다.
+type LoginNotificationEntity struct {
+	gorm.Model
+	MemberId        uint   `gorm:"not null;index"`
+	SessionId       uint   `gorm:"not null"`
+	IpAddress       string `gorm:"type:varchar(45)"`
+	Country         string `gorm:"type:varchar(2)"`
+	City            string `gorm:"type:varchar(100)"`
+	ReportTokenHash string `gorm:"type:varchar(64);index"`
+	ResetTokenHash  string `gorm:"type:varchar(64);index"`
+	ExpiresAt       time.Time
+	ReportedAt      *time.Time
+	ResetExpiresAt  *time.Time
+	PasswordResetAt *time.Time
+}
+
+func (LoginNotificationEntity) TableName() string {
+	return "login_notifications"
+}
+
+func (n LoginNotificationEntity) IsReported() bool {
+	return n.ReportedAt != nil
+}
+
+// Report 는 본인이 하지 않은 로그인으로 신고하고 비밀번호 재설정 토큰을 반환한다. 이미 신고했으면 ErrNonChangeable 이다.
+func (n *LoginNotificationEntity) Report(lifetime time.Duration) (string, error) {
+	if n.IsReported() {
+		return "", errors.ErrNonChangeable
+	}
+
+	if n.ExpiresAt.Before(time.Now()) {
+		return "", errors.ErrExpired
+	}
+
+	resetToken, err := generateLoginNotificationToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	resetExpiresAt := now.Add(lifetime)
+	n.ReportedAt = &now
+	n.ResetTokenHash = HashLoginNotificationToken(resetToken)
+	n.ResetExpiresAt = &resetExpiresAt
+	return resetToken, nil
+}
+
+// ResetPassword 는 재설정 토큰을 사용한다. 토큰은 한 번만 사용할 수 있다.
+func (n *LoginNotificationEntity) ResetPassword() error {
+	if n.PasswordResetAt != nil {
+		return errors.ErrNonChangeable
+	}
+
+	if n.ResetExpiresAt == nil || n.ResetExpiresAt.Before(time.Now()) {
+		return errors.ErrExpired
+	}
+
+	now := time.Now()
+	n.PasswordResetAt = &now
+	return nil
+}
+
+func (n LoginNotificationEntity) ToInformation() dtos.LoginNotificationInformation {
+	return dtos.LoginNotificationInformation{
+		Id:         n.ID,
+		IpAddress:  n.IpAddress,
+		Country:    n.Country,
+		City:       n.City,
+		LoggedInAt: n.CreatedAt,
+		ReportedAt: n.ReportedAt,
+	}
+}
+
+func HashLoginNotificationToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func generateLoginNotificationToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", pkgerrors.Wrap(err, "generate login notification token error")
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
+
+// NewLoginNotificationEntity 는 알림과 함께 메일의 "본인이 아닙니다" 링크에 넣을 신고 토큰을 반환한다.
+func NewLoginNotificationEntity(session MemberSessionEntity, location dtos.GeoLocation, ipAddress string, lifetime time.Duration) (LoginNotificationEntity, string, error) {
+	reportToken, err := generateLoginNotificationToken()
+	if err != nil {
+		return LoginNotificationEntity{}, "", err
+	}
+
+	return LoginNotificationEntity{
+		MemberId:        session.MemberId,
+		SessionId:       session.ID,
+		IpAddress:       ipAddress,
+		Country:         location.Country,
+		City:            location.City,
+		ReportTokenHash: HashLoginNotificationToken(reportToken),
+		ExpiresAt:       time.Now().Add(lifetime),
+	}, reportToken, nil
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type MemberSessionEntity struct {
+	gorm.Model
+	MemberId      uint      `gorm:"not null;index"`
+	SessionKey    string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	RevokedAt     *time.Time
+	RevokedReason string `gorm:"type:varchar(50)"`
+	// ClientFingerprint 는 세션을 시작한 클라이언트의 지문으로 Refresh 토큰을 사용한 클라이언트와 비교한다.
+	ClientFingerprint string `gorm:"type:varchar(64)"`
+}
+
+func (MemberSessionEntity) TableName() string {
+	return "member_sessions"
+}
+
+func (s MemberSessionEntity) IsActive() bool {
+	if s.RevokedAt != nil {
+		return false
+	}
+
+	return s.ExpiresAt.After(time.Now())
+}
+
+// IsSameClient 는 clientFingerprint 가 세션을 시작한 클라이언트의 지문과 같은지 확인한다.
+// 지문을 기록하기 전에 시작한 세션은 비교하지 않는다.
+func (s MemberSessionEntity) IsSameClient(clientFingerprint string) bool {
+	if len(s.ClientFingerprint) == 0 {
+		return true
+	}
+
+	return s.ClientFingerprint == clientFingerprint
+}
+
+func (s *MemberSessionEntity) Revoke(reason string) {
+	now := time.Now()
+	s.RevokedAt = &now
+	s.RevokedReason = reason
+}
+
+func NewMemberSessionEntity(memberId uint, expiresAt time.Time) (MemberSessionEntity, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return MemberSessionEntity{}, pkgerrors.Wrap(err, "generate session key error")
+	}
+
+	return MemberSessionEntity{
+		MemberId:   memberId,
+		SessionKey: hex.EncodeToString(key),
+		ExpiresAt:  expiresAt,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/session/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type LoginDeviceRepository struct {
+}
+
+func (LoginDeviceRepository) Create(ctx context.Context, entity *domain.LoginDeviceEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LoginDeviceRepository) Save(ctx context.Context, entity *domain.LoginDeviceEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LoginDeviceRepository) FindByMemberId(ctx context.Context, memberId uint) ([]domain.LoginDeviceEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.LoginDeviceEntity, 0)
+	if err := db.Where("member_id = ?", memberId).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/session/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type LoginNotificationRepository struct {
+}
+
+func (LoginNotificationRepository) Create(ctx context.Context, entity *domain.LoginNotificationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LoginNotificationRepository) Save(ctx context.Context, entity *domain.LoginNotificationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (r LoginNotificationRepository) FindByReportTokenHash(ctx context.Context, tokenHash string) (domain.LoginNotificationEntity, error) {
+	return r.findBy(ctx, &domain.LoginNotificationEntity{ReportTokenHash: tokenHash})
+}
+
+func (r LoginNotificationRepository) FindByResetTokenHash(ctx context.Context, tokenHash string) (domain.LoginNotificationEntity, error) {
+	return r.findBy(ctx, &domain.LoginNotificationEntity{ResetTokenHash: tokenHash})
+}
+
+func (r LoginNotificationRepository) FindByIdAndMemberId(ctx context.Context, id uint, memberId uint) (domain.LoginNotificationEntity, error) {
+	condition := &domain.LoginNotificationEntity{MemberId: memberId}
+	condition.ID = id
+	return r.findBy(ctx, condition)
+}
+
+// FindRecentByMemberId 는 멤버의 최근 알림을 limit 개까지 최근 순으로 조회한다.
+func (LoginNotificationRepository) FindRecentByMemberId(ctx context.Context, memberId uint, limit int) ([]domain.LoginNotificationEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.LoginNotificationEntity, 0)
+	if err := db.Where("member_id = ?", memberId).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (LoginNotificationRepository) findBy(ctx context.Context, condition *domain.LoginNotificationEntity) (domain.LoginNotificationEntity, error) {
+	var entity domain.LoginNotificationEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(condition).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
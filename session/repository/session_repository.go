@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/session/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type MemberSessionRepository struct {
+}
+
+func (MemberSessionRepository) Create(ctx context.Context, entity *domain.MemberSessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberSessionRepository) Save(ctx context.Context, entity *domain.MemberSessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (MemberSessionRepository) FindBySessionKey(ctx context.Context, sessionKey string) (domain.MemberSessionEntity, error) {
+	var entity domain.MemberSessionEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberSessionEntity{SessionKey: sessionKey}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberSessionRepository) FindActiveByMemberId(ctx context.Context, memberId uint) ([]domain.MemberSessionEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.MemberSessionEntity, 0)
+	if err := db.Where("member_id = ? AND revoked_at IS NULL AND expires_at > ?", memberId, time.Now()).
+		Order("created_at asc").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+// SettingVersionEntity 는 사이트 설정이 바뀔 때마다 저장하는 전체 설정(Document)의 스냅샷이다.
+// Document 는 설정 키별 값(JSON)이며 Signature 로 저장한 뒤 바뀌지 않았는지 확인한다.
+type SettingVersionEntity struct {
+	gorm.Model
+	Version        uint   `gorm:"not null;uniqueIndex"`
+	ChangedKey     string `gorm:"type:varchar(20)"`
+	Document       string `gorm:"type:text;not null"`
+	Signature      string `gorm:"type:varchar(64);not null"`
+	RolledBackFrom uint
+	CreatedBy      uint
+}
+
+func (SettingVersionEntity) TableName() string {
+	return "site_setting_versions"
+}
+
+func NewSettingVersionEntity(version uint, changedKey string, document map[string]json.RawMessage,
+	rolledBackFrom uint, createdBy uint, signingKey string) (SettingVersionEntity, error) {
+
+	b, err := json.Marshal(document)
+	if err != nil {
+		return SettingVersionEntity{}, err
+	}
+
+	entity := SettingVersionEntity{
+		Version:        version,
+		ChangedKey:     changedKey,
+		Document:       string(b),
+		RolledBackFrom: rolledBackFrom,
+		CreatedBy:      createdBy,
+	}
+	entity.Signature = entity.sign(signingKey)
+
+	return entity, nil
+}
+
+func (s SettingVersionEntity) GetDocument() (map[string]json.RawMessage, error) {
+	document := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(s.Document), &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
+
+func (s SettingVersionEntity) VerifySignature(signingKey string) bool {
+	return hmac.Equal([]byte(s.Signature), []byte(s.sign(signingKey)))
+}
+
+func (s SettingVersionEntity) sign(signingKey string) string {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(fmt.Sprintf("%d\n%s\n%d\n%s", s.Version, s.ChangedKey, s.RolledBackFrom, s.Document)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
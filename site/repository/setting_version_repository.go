@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/site/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type SiteSettingVersionRepository struct {
+}
+
+func (SiteSettingVersionRepository) Create(ctx context.Context, entity *domain.SettingVersionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SiteSettingVersionRepository) FindLatestVersion(ctx context.Context) (uint, error) {
+	var version uint
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Model(&domain.SettingVersionEntity{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, pkgerrors.Wrap(err, "db error")
+	}
+
+	return version, nil
+}
+
+func (SiteSettingVersionRepository) FindByVersion(ctx context.Context, version uint) (domain.SettingVersionEntity, error) {
+	var entity domain.SettingVersionEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Where(&domain.SettingVersionEntity{Version: version}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (SiteSettingVersionRepository) FindAll(ctx context.Context, pageable dtos.Pageable) ([]domain.SettingVersionEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.SettingVersionEntity{})
+
+	var entities = make([]domain.SettingVersionEntity, 0)
+	var totalCount int64
+	if err := db.Count(&totalCount).Order("version DESC").Scopes(helpers.GormHelper().Pageable(pageable)).
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+// DeleteOlderThan 은 version 보다 이전 버전을 지운다.
+func (SiteSettingVersionRepository) DeleteOlderThan(ctx context.Context, version uint) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Unscoped().Where("version < ?", version).Delete(&domain.SettingVersionEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -48,3 +48,12 @@ func (SiteSettingRepository) FindAll(ctx context.Context) ([]domain.SettingEntit
 
 	return settings, nil
 }
+
+func (SiteSettingRepository) Delete(ctx context.Context, entity domain.SettingEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
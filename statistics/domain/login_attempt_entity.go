@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+)
+
+// LoginAttemptEntity 는 로그인 시도 기록이다. 통계 집계의 원본 데이터로 사용한다.
+type LoginAttemptEntity struct {
+	gorm.Model
+	Method        string `gorm:"type:varchar(20);not null"`
+	MemberId      uint
+	Succeeded     bool
+	FailureReason string    `gorm:"type:varchar(50)"`
+	AttemptedAt   time.Time `gorm:"not null;index"`
+	// 로그인을 요청한 클라이언트의 IP 와 GeoIP 로 찾은 위치
+	IpAddress       string `gorm:"type:varchar(45)"`
+	Country         string `gorm:"type:varchar(2);index"`
+	City            string `gorm:"type:varchar(100)"`
+	Asn             uint
+	AsnOrganization string `gorm:"type:varchar(200)"`
+}
+
+func (LoginAttemptEntity) TableName() string {
+	return "login_attempts"
+}
+
+// NewLoginAttemptEntity 는 로그인 결과(err)로 시도 기록을 만든다. 멤버를 찾지 못한 경우 MemberId 는 0 이다.
+func NewLoginAttemptEntity(ctx context.Context, method string, memberId uint, err error) LoginAttemptEntity {
+	ipAddress := helpers.ContextHelper().GetClientIp(ctx)
+	location := adapters.GeoIpAdapter().Lookup(ipAddress)
+
+	return LoginAttemptEntity{
+		Method:          method,
+		MemberId:        memberId,
+		Succeeded:       err == nil,
+		FailureReason:   toLoginFailureReason(err),
+		AttemptedAt:     time.Now(),
+		IpAddress:       ipAddress,
+		Country:         location.Country,
+		City:            location.City,
+		Asn:             location.Asn,
+		AsnOrganization: location.AsnOrganization,
+	}
+}
+
+// ToEvent 는 이벤트 버스로 보낼 인증 이벤트를 만든다. 로그인 방법(site, dooray, google)은 Detail 에 담는다.
+func (l LoginAttemptEntity) ToEvent() dtos.Event {
+	event := dtos.Event{
+		Id:         fmt.Sprintf("%s-%d", constants.EventTypeLogin, l.ID),
+		Type:       constants.EventTypeLogin,
+		Action:     constants.ActivityActionLogin,
+		OccurredAt: l.AttemptedAt,
+		Succeeded:  l.Succeeded,
+		Reason:     l.FailureReason,
+		Detail:     l.Method,
+		IpAddress:  l.IpAddress,
+		Country:    l.Country,
+		City:       l.City,
+	}
+
+	if l.MemberId > 0 {
+		event.ActorType = constants.AuditActorTypeMember
+		event.ActorId = l.MemberId
+	}
+
+	return event
+}
+
+func toLoginFailureReason(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if _, ok := err.(*errors.ErrInvalidGoogleWorkspaceAccount); ok {
+		return constants.LoginFailureReasonInvalidAccount
+	}
+
+	if _, ok := err.(*errors.ErrPreAuthDenied); ok {
+		return constants.LoginFailureReasonPreAuthDenied
+	}
+
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		return constants.LoginFailureReasonUnknownMember
+	case errors.Is(err, errors.ErrAuthentication):
+		return constants.LoginFailureReasonInvalidCredential
+	case errors.Is(err, errors.ErrUnApproved):
+		return constants.LoginFailureReasonUnApproved
+	case errors.Is(err, errors.ErrSessionLimitExceeded):
+		return constants.LoginFailureReasonSessionLimit
+	case errors.Is(err, errors.ErrPasswordResetNeeded):
+		return constants.LoginFailureReasonPasswordReset
+	}
+
+	return constants.LoginFailureReasonError
+}
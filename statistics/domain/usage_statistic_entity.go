@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"sort"
+	"time"
+)
+
+const UsageStatisticDateLayout = "2006-01-02"
+
+// UsageStatisticEntity 는 기간(일/주)별로 집계한 사용 통계이다.
+// OrganizationId 가 0 이면 전체 집계이고, Dimension 은 지표에 따라 로그인 방식 또는 실패 사유이다.
+type UsageStatisticEntity struct {
+	ID             uint   `gorm:"primarykey"`
+	Period         string `gorm:"type:varchar(10);not null;index:idx_usage_statistic"`
+	PeriodStart    string `gorm:"type:varchar(10);not null;index:idx_usage_statistic"`
+	Metric         string `gorm:"type:varchar(30);not null;index:idx_usage_statistic"`
+	OrganizationId uint   `gorm:"not null"`
+	Dimension      string `gorm:"type:varchar(50)"`
+	Value          int64
+	CreatedAt      time.Time
+}
+
+func (UsageStatisticEntity) TableName() string {
+	return "usage_statistics"
+}
+
+type usageStatisticKey struct {
+	metric         string
+	organizationId uint
+	dimension      string
+}
+
+// NewUsageStatisticEntities 는 기간 동안의 로그인 시도를 전체와 조직별로 집계한다.
+// 전체 활성 멤버 수는 0 이라도 만들어서 해당 기간이 집계되었음을 알 수 있게 한다.
+func NewUsageStatisticEntities(period string, periodStart time.Time, attempts []LoginAttemptEntity, memberOrganizationIds map[uint][]uint) []UsageStatisticEntity {
+	values := map[usageStatisticKey]int64{
+		{metric: constants.UsageStatisticMetricActiveMembers}: 0,
+	}
+	activeMembers := map[uint]map[uint]bool{}
+
+	for _, attempt := range attempts {
+		organizationIds := append([]uint{0}, memberOrganizationIds[attempt.MemberId]...)
+		if attempt.MemberId == 0 {
+			organizationIds = []uint{0}
+		}
+
+		for _, organizationId := range organizationIds {
+			if !attempt.Succeeded {
+				values[usageStatisticKey{constants.UsageStatisticMetricLoginFailures, organizationId, attempt.FailureReason}]++
+				continue
+			}
+
+			values[usageStatisticKey{constants.UsageStatisticMetricLogins, organizationId, attempt.Method}]++
+
+			if activeMembers[organizationId] == nil {
+				activeMembers[organizationId] = map[uint]bool{}
+			}
+			activeMembers[organizationId][attempt.MemberId] = true
+		}
+	}
+
+	for organizationId, members := range activeMembers {
+		values[usageStatisticKey{metric: constants.UsageStatisticMetricActiveMembers, organizationId: organizationId}] = int64(len(members))
+	}
+
+	entities := make([]UsageStatisticEntity, 0, len(values))
+	for key, value := range values {
+		entities = append(entities, UsageStatisticEntity{
+			Period:         period,
+			PeriodStart:    periodStart.Format(UsageStatisticDateLayout),
+			Metric:         key.metric,
+			OrganizationId: key.organizationId,
+			Dimension:      key.dimension,
+			Value:          value,
+		})
+	}
+
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Metric != entities[j].Metric {
+			return entities[i].Metric < entities[j].Metric
+		}
+		if entities[i].OrganizationId != entities[j].OrganizationId {
+			return entities[i].OrganizationId < entities[j].OrganizationId
+		}
+		return entities[i].Dimension < entities[j].Dimension
+	})
+
+	return entities
+}
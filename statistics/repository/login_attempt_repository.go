@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/statistics/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type LoginAttemptRepository struct {
+}
+
+func (LoginAttemptRepository) Create(ctx context.Context, entity *domain.LoginAttemptEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindBetween 는 from 이상 to 미만에 시도한 로그인 기록을 조회한다.
+func (LoginAttemptRepository) FindBetween(ctx context.Context, from, to time.Time) ([]domain.LoginAttemptEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.LoginAttemptEntity, 0)
+	if err := db.Where("attempted_at >= ? AND attempted_at < ?", from, to).
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (LoginAttemptRepository) FindFirst(ctx context.Context) (domain.LoginAttemptEntity, error) {
+	var entity domain.LoginAttemptEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Order("attempted_at").First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (LoginAttemptRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.LoginAttemptEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.LoginAttemptEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "memberId" {
+				db.Where("member_id = ?", value)
+			}
+
+			if key == "succeeded" {
+				db.Where("succeeded = ?", value)
+			}
+
+			if key == "countries" {
+				db.Where("country IN ?", value)
+			}
+
+			if key == "ipAddress" {
+				db.Where("ip_address = ?", value)
+			}
+		}
+	}
+
+	var entities = make([]domain.LoginAttemptEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("attempted_at DESC, id DESC").
+		Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
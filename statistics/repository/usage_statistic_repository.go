@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/statistics/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type UsageStatisticRepository struct {
+}
+
+// ReplacePeriod 는 기간의 통계를 지우고 다시 저장한다. 같은 기간을 여러 번 집계해도 결과가 같다.
+func (UsageStatisticRepository) ReplacePeriod(ctx context.Context, period, periodStart string, entities []domain.UsageStatisticEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("period = ? AND period_start = ?", period, periodStart).
+		Delete(&domain.UsageStatisticEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if len(entities) == 0 {
+		return nil
+	}
+
+	if err := db.Create(&entities).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// FindLastPeriodStart 는 마지막으로 집계한 기간의 시작 날짜를 조회한다.
+func (UsageStatisticRepository) FindLastPeriodStart(ctx context.Context, period string) (string, error) {
+	var entity domain.UsageStatisticEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("period = ? AND metric = ? AND organization_id = 0", period, constants.UsageStatisticMetricActiveMembers).
+		Order("period_start DESC").
+		First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errors.ErrNotFound
+		}
+
+		return "", pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity.PeriodStart, nil
+}
+
+func (UsageStatisticRepository) Find(ctx context.Context, filters map[string]interface{}) ([]domain.UsageStatisticEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx).
+		Where("period = ? AND metric = ? AND period_start >= ? AND period_start <= ?",
+			filters["period"], filters["metric"], filters["from"], filters["to"])
+
+	if filters["organizationId"] != nil {
+		db = db.Where("organization_id = ?", filters["organizationId"])
+	} else {
+		db = db.Where("organization_id <> 0")
+	}
+
+	var entities = make([]domain.UsageStatisticEntity, 0)
+	if err := db.Order("period_start, organization_id, dimension").
+		Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
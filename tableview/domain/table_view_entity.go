@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"encoding/json"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// TableViewEntity 는 목록 화면의 이름 붙인 보기 설정이다. 브라우저가 아닌 서버에 저장하므로 여러 기기에서 같은 화면을 사용한다.
+// MemberId 가 있으면 멤버 개인의 보기이고, RoleId 가 있으면 역할의 멤버에게 공유한 보기이다.
+type TableViewEntity struct {
+	gorm.Model
+	Table     string `gorm:"column:table_name;type:varchar(20);not null;index"`
+	Name      string `gorm:"type:varchar(100);not null"`
+	MemberId  uint   `gorm:"index"`
+	RoleId    uint   `gorm:"index"`
+	Columns   string `gorm:"type:text"`
+	Filters   string `gorm:"type:text"`
+	SortField string `gorm:"type:varchar(50)"`
+	SortOrder string `gorm:"type:varchar(4)"`
+	PageSize  int
+	CreatedBy uint
+	UpdatedBy uint
+}
+
+func (TableViewEntity) TableName() string {
+	return "table_views"
+}
+
+func (t TableViewEntity) IsShared() bool {
+	return t.RoleId != 0
+}
+
+// Update 는 이름과 보기 설정을 바꾼다. 목록 화면(Table)과 공유 대상은 바꾸지 않는다.
+func (t *TableViewEntity) Update(information dtos.TableViewInformation, updatedBy uint) error {
+	columns, err := json.Marshal(information.Columns)
+	if err != nil {
+		return pkgerrors.Wrap(err, "table view columns encode error")
+	}
+
+	filters, err := json.Marshal(information.Filters)
+	if err != nil {
+		return pkgerrors.Wrap(err, "table view filters encode error")
+	}
+
+	t.Name = information.Name
+	t.Columns = string(columns)
+	t.Filters = string(filters)
+	t.SortField = information.Sort.Field
+	t.SortOrder = information.Sort.Order
+	t.PageSize = information.PageSize
+	t.UpdatedBy = updatedBy
+	return nil
+}
+
+func (t TableViewEntity) ToInformation() dtos.TableViewInformation {
+	columns := make([]string, 0)
+	if err := json.Unmarshal([]byte(t.Columns), &columns); err != nil || columns == nil {
+		columns = make([]string, 0)
+	}
+
+	filters := map[string]string{}
+	if err := json.Unmarshal([]byte(t.Filters), &filters); err != nil || filters == nil {
+		filters = map[string]string{}
+	}
+
+	return dtos.TableViewInformation{
+		Id:        t.ID,
+		Table:     t.Table,
+		Name:      t.Name,
+		Columns:   columns,
+		Filters:   filters,
+		Sort:      dtos.TableViewSort{Field: t.SortField, Order: t.SortOrder},
+		PageSize:  t.PageSize,
+		RoleId:    t.RoleId,
+		MemberId:  t.MemberId,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// NewTableViewEntity 는 RoleId 가 없으면 만든 멤버(createdBy)의 개인 보기를 만든다.
+func NewTableViewEntity(information dtos.TableViewInformation, createdBy uint) (TableViewEntity, error) {
+	entity := TableViewEntity{
+		Table:     information.Table,
+		RoleId:    information.RoleId,
+		CreatedBy: createdBy,
+	}
+	if !entity.IsShared() {
+		entity.MemberId = createdBy
+	}
+
+	if err := entity.Update(information, createdBy); err != nil {
+		return TableViewEntity{}, err
+	}
+
+	return entity, nil
+}
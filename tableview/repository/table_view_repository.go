@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/tableview/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type TableViewRepository struct {
+}
+
+func (r TableViewRepository) Create(ctx context.Context, entity *domain.TableViewEntity) error {
+	if err := r.checkDuplicated(ctx, *entity); err != nil {
+		return err
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (r TableViewRepository) Save(ctx context.Context, entity *domain.TableViewEntity) error {
+	if err := r.checkDuplicated(ctx, *entity); err != nil {
+		return err
+	}
+
+	if err := helpers.ContextHelper().GetDB(ctx).Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// checkDuplicated 는 같은 목록 화면에 같은 멤버(혹은 역할)의 같은 이름을 가진 다른 보기가 있는지 확인한다.
+func (TableViewRepository) checkDuplicated(ctx context.Context, entity domain.TableViewEntity) error {
+	var count int64
+	if err := helpers.ContextHelper().GetDB(ctx).Model(&domain.TableViewEntity{}).
+		Where("table_name = ? AND member_id = ? AND role_id = ? AND name = ? AND id <> ?", entity.Table, entity.MemberId, entity.RoleId, entity.Name, entity.ID).
+		Count(&count).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	if count > 0 {
+		return errors.ErrDuplicated
+	}
+
+	return nil
+}
+
+func (TableViewRepository) FindById(ctx context.Context, id uint) (domain.TableViewEntity, error) {
+	var entity domain.TableViewEntity
+
+	if err := helpers.ContextHelper().GetDB(ctx).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindVisible 은 멤버의 개인 보기와 역할(roleIds)에 공유된 보기를 조회한다. allShared 이면 모든 역할에 공유된 보기를 조회한다.
+// table 이 비어 있으면 모든 목록 화면의 보기를 조회한다.
+func (TableViewRepository) FindVisible(ctx context.Context, table string, memberId uint, roleIds []uint, allShared bool) ([]domain.TableViewEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.TableViewEntity{})
+	if len(table) > 0 {
+		db.Where("table_name = ?", table)
+	}
+
+	if allShared {
+		db.Where("(member_id = ? OR role_id <> 0)", memberId)
+	} else {
+		db.Where("(member_id = ? OR (role_id <> 0 AND role_id IN ?))", memberId, append(roleIds, 0))
+	}
+
+	var entities = make([]domain.TableViewEntity, 0)
+	if err := db.Order("table_name, role_id, name").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+// CountByOwner 는 목록 화면에 멤버(혹은 역할)가 가진 보기의 수이다.
+func (TableViewRepository) CountByOwner(ctx context.Context, table string, memberId uint, roleId uint) (int64, error) {
+	var count int64
+	if err := helpers.ContextHelper().GetDB(ctx).Model(&domain.TableViewEntity{}).
+		Where("table_name = ? AND member_id = ? AND role_id = ?", table, memberId, roleId).
+		Count(&count).Error; err != nil {
+		return 0, pkgerrors.Wrap(err, "db error")
+	}
+
+	return count, nil
+}
+
+func (TableViewRepository) Delete(ctx context.Context, entity domain.TableViewEntity) error {
+	if err := helpers.ContextHelper().GetDB(ctx).Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
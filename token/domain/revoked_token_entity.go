@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"gorm.io/gorm"
+	"time"
+)
+
+// RevokedTokenEntity 는 폐기(RFC 7009)한 토큰이다. 토큰 원문 대신 해시(TokenHash)를 저장하며
+// 토큰이 만료(ExpiresAt)되면 더 이상 확인할 필요가 없으므로 정리한다. 만료 시간이 없는 토큰은 ExpiresAt 이 비어 있다.
+type RevokedTokenEntity struct {
+	gorm.Model
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt *time.Time `gorm:"index"`
+	// RevokedBy 는 폐기를 요청한 서비스 계정의 Client Id 이다.
+	RevokedBy string `gorm:"type:varchar(64)"`
+}
+
+func (RevokedTokenEntity) TableName() string {
+	return "revoked_tokens"
+}
+
+func HashToken(token string) string {
+	hashed := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hashed[:])
+}
+
+func NewRevokedTokenEntity(token string, expiresAt *time.Time, revokedBy string) RevokedTokenEntity {
+	return RevokedTokenEntity{
+		TokenHash: HashToken(token),
+		ExpiresAt: expiresAt,
+		RevokedBy: revokedBy,
+	}
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/token/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"time"
+)
+
+type RevokedTokenRepository struct {
+}
+
+// Create 는 폐기한 토큰을 기록한다. 이미 폐기한 토큰이면 기록하지 않는다.
+func (RevokedTokenRepository) Create(ctx context.Context, entity *domain.RevokedTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Where(domain.RevokedTokenEntity{TokenHash: entity.TokenHash}).FirstOrCreate(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RevokedTokenRepository) ExistsByTokenHash(ctx context.Context, tokenHash string) (bool, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var count int64
+	if err := db.Model(&domain.RevokedTokenEntity{}).Where("token_hash = ?", tokenHash).Count(&count).Error; err != nil {
+		return false, pkgerrors.Wrap(err, "db error")
+	}
+
+	return count > 0, nil
+}
+
+// DeleteExpired 는 만료 시간이 지난 토큰의 폐기 기록을 삭제한다.
+func (RevokedTokenRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Unscoped().Where("expires_at < ?", now).Delete(&domain.RevokedTokenEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -2,11 +2,13 @@ package domain
 
 import (
 	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/cluster"
 	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/security"
 	"context"
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
@@ -16,8 +18,10 @@ type WebHookEntity struct {
 	Description string                 `gorm:"type:varchar(1000)"`
 	AccessToken string                 `gorm:"type:varchar(1000)"`
 	Messages    []WebHookMessageEntity `gorm:"foreignKey:WebHookId"`
-	CreatedBy   uint
-	UpdatedBy   uint
+	// OwnerId 는 리소스를 책임지는 멤버이다. 만든 멤버가 처음 소유자이며 멤버를 해지(deprovisioning)하면 후임자에게 넘긴다.
+	OwnerId   uint `gorm:"index"`
+	CreatedBy uint
+	UpdatedBy uint
 }
 
 func (WebHookEntity) TableName() string {
@@ -47,6 +51,27 @@ func (w *WebHookEntity) Update(ctx context.Context, information dtos.WebHookInfo
 	return nil
 }
 
+// GetOwnerId 는 소유자이다. 소유자를 기록하기 전에 만든 리소스는 만든 멤버가 소유자이다.
+func (w WebHookEntity) GetOwnerId() uint {
+	if w.OwnerId == 0 {
+		return w.CreatedBy
+	}
+
+	return w.OwnerId
+}
+
+func (w *WebHookEntity) TransferOwnership(ctx context.Context, ownerId uint) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	w.OwnerId = ownerId
+	w.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
 func (w WebHookEntity) NextId() uint {
 	return w.ID + 1
 }
@@ -60,20 +85,38 @@ func (w WebHookEntity) NoteMessage(message dtos.WebHookMessage) error {
 		return err
 	}
 
+	// 다른 인스턴스에 연결한 웹 소켓에도 보낸다.
+	if err := cluster.Notify(constants.ClusterTopicWebSocketBroadcast, message); err != nil {
+		log.Warnf("web socket broadcast notification error: %v", err)
+	}
+
 	return nil
 }
 
-func NewWebHookEntity(ctx context.Context, id uint, information dtos.WebHookInformation) (WebHookEntity, error) {
-	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+func (w *WebHookEntity) ReissueAccessToken(ctx context.Context) error {
+	accessToken, err := generateWebHookAccessToken(ctx, w.ID)
 	if err != nil {
-		return WebHookEntity{}, err
+		return err
 	}
 
-	accessToken, err := security.JwtAuthentication{}.GenerateJwtAccessTokenNeverExpired(security.UserClaim{
+	w.AccessToken = accessToken
+	return nil
+}
+
+func generateWebHookAccessToken(ctx context.Context, id uint) (string, error) {
+	return security.JwtAuthentication{}.GenerateJwtAccessTokenNeverExpired(ctx, security.UserClaim{
 		Id:          id,
 		Permissions: []string{constants.PermissionNoteWebHooks},
 	})
+}
+
+func NewWebHookEntity(ctx context.Context, id uint, information dtos.WebHookInformation) (WebHookEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return WebHookEntity{}, err
+	}
 
+	accessToken, err := generateWebHookAccessToken(ctx, id)
 	if err != nil {
 		return WebHookEntity{}, nil
 	}
@@ -82,6 +125,7 @@ func NewWebHookEntity(ctx context.Context, id uint, information dtos.WebHookInfo
 		Name:        information.Name,
 		Description: information.Description,
 		AccessToken: accessToken,
+		OwnerId:     userClaim.Id,
 		CreatedBy:   userClaim.Id,
 		UpdatedBy:   userClaim.Id,
 	}, nil
@@ -22,9 +22,17 @@ func (WebHookRepository) Create(ctx context.Context, entity *domain.WebHookEntit
 	return nil
 }
 
-func (WebHookRepository) FindAll(ctx context.Context, pageable dtos.Pageable) ([]domain.WebHookEntity, int64, error) {
+func (WebHookRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.WebHookEntity, int64, error) {
 	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.WebHookEntity{})
 
+	if filters != nil {
+		for key, value := range filters {
+			if key == "ownerId" {
+				db.Scopes(helpers.GormHelper().OwnedBy(value.(uint)))
+			}
+		}
+	}
+
 	var entities = make([]domain.WebHookEntity, 0)
 	var totalCount int64
 	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).Find(&entities).Error; err != nil {
@@ -88,3 +96,25 @@ func (WebHookRepository) FindLast(ctx context.Context) (domain.WebHookEntity, er
 
 	return entity, nil
 }
+
+func (WebHookRepository) FindAllWithoutPaging(ctx context.Context) ([]domain.WebHookEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.WebHookEntity, 0)
+	if err := db.Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (WebHookRepository) FindByOwnerId(ctx context.Context, ownerId uint) ([]domain.WebHookEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.WebHookEntity, 0)
+	if err := db.Scopes(helpers.GormHelper().OwnedBy(ownerId)).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}